@@ -30,6 +30,8 @@ const (
 	ClientsService_RemoveConnections_FullMethodName          = "/clients.v1.ClientsService/RemoveConnections"
 	ClientsService_RemoveChannelConnections_FullMethodName   = "/clients.v1.ClientsService/RemoveChannelConnections"
 	ClientsService_UnsetParentGroupFromClient_FullMethodName = "/clients.v1.ClientsService/UnsetParentGroupFromClient"
+	ClientsService_CreateClients_FullMethodName              = "/clients.v1.ClientsService/CreateClients"
+	ClientsService_RemoveClients_FullMethodName              = "/clients.v1.ClientsService/RemoveClients"
 )
 
 // ClientsServiceClient is the client API for ClientsService service.
@@ -47,6 +49,12 @@ type ClientsServiceClient interface {
 	RemoveConnections(ctx context.Context, in *v1.RemoveConnectionsReq, opts ...grpc.CallOption) (*v1.RemoveConnectionsRes, error)
 	RemoveChannelConnections(ctx context.Context, in *RemoveChannelConnectionsReq, opts ...grpc.CallOption) (*RemoveChannelConnectionsRes, error)
 	UnsetParentGroupFromClient(ctx context.Context, in *UnsetParentGroupFromClientReq, opts ...grpc.CallOption) (*UnsetParentGroupFromClientRes, error)
+	// CreateClients creates clients with their built-in roles on behalf
+	// of the domain user. It is used for bulk provisioning.
+	CreateClients(ctx context.Context, in *CreateClientsReq, opts ...grpc.CallOption) (*CreateClientsRes, error)
+	// RemoveClients removes clients created by CreateClients together
+	// with their roles and policies.
+	RemoveClients(ctx context.Context, in *RemoveClientsReq, opts ...grpc.CallOption) (*RemoveClientsRes, error)
 }
 
 type clientsServiceClient struct {
@@ -127,6 +135,26 @@ func (c *clientsServiceClient) UnsetParentGroupFromClient(ctx context.Context, i
 	return out, nil
 }
 
+func (c *clientsServiceClient) CreateClients(ctx context.Context, in *CreateClientsReq, opts ...grpc.CallOption) (*CreateClientsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateClientsRes)
+	err := c.cc.Invoke(ctx, ClientsService_CreateClients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clientsServiceClient) RemoveClients(ctx context.Context, in *RemoveClientsReq, opts ...grpc.CallOption) (*RemoveClientsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveClientsRes)
+	err := c.cc.Invoke(ctx, ClientsService_RemoveClients_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ClientsServiceServer is the server API for ClientsService service.
 // All implementations must embed UnimplementedClientsServiceServer
 // for forward compatibility.
@@ -142,6 +170,12 @@ type ClientsServiceServer interface {
 	RemoveConnections(context.Context, *v1.RemoveConnectionsReq) (*v1.RemoveConnectionsRes, error)
 	RemoveChannelConnections(context.Context, *RemoveChannelConnectionsReq) (*RemoveChannelConnectionsRes, error)
 	UnsetParentGroupFromClient(context.Context, *UnsetParentGroupFromClientReq) (*UnsetParentGroupFromClientRes, error)
+	// CreateClients creates clients with their built-in roles on behalf
+	// of the domain user. It is used for bulk provisioning.
+	CreateClients(context.Context, *CreateClientsReq) (*CreateClientsRes, error)
+	// RemoveClients removes clients created by CreateClients together
+	// with their roles and policies.
+	RemoveClients(context.Context, *RemoveClientsReq) (*RemoveClientsRes, error)
 	mustEmbedUnimplementedClientsServiceServer()
 }
 
@@ -173,6 +207,12 @@ func (UnimplementedClientsServiceServer) RemoveChannelConnections(context.Contex
 func (UnimplementedClientsServiceServer) UnsetParentGroupFromClient(context.Context, *UnsetParentGroupFromClientReq) (*UnsetParentGroupFromClientRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UnsetParentGroupFromClient not implemented")
 }
+func (UnimplementedClientsServiceServer) CreateClients(context.Context, *CreateClientsReq) (*CreateClientsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateClients not implemented")
+}
+func (UnimplementedClientsServiceServer) RemoveClients(context.Context, *RemoveClientsReq) (*RemoveClientsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveClients not implemented")
+}
 func (UnimplementedClientsServiceServer) mustEmbedUnimplementedClientsServiceServer() {}
 func (UnimplementedClientsServiceServer) testEmbeddedByValue()                        {}
 
@@ -320,6 +360,42 @@ func _ClientsService_UnsetParentGroupFromClient_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ClientsService_CreateClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateClientsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).CreateClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientsService_CreateClients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).CreateClients(ctx, req.(*CreateClientsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClientsService_RemoveClients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveClientsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClientsServiceServer).RemoveClients(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ClientsService_RemoveClients_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClientsServiceServer).RemoveClients(ctx, req.(*RemoveClientsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ClientsService_ServiceDesc is the grpc.ServiceDesc for ClientsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -355,6 +431,14 @@ var ClientsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UnsetParentGroupFromClient",
 			Handler:    _ClientsService_UnsetParentGroupFromClient_Handler,
 		},
+		{
+			MethodName: "CreateClients",
+			Handler:    _ClientsService_CreateClients_Handler,
+		},
+		{
+			MethodName: "RemoveClients",
+			Handler:    _ClientsService_RemoveClients_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "clients/v1/clients.proto",
@@ -25,6 +25,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	DomainsService_DeleteUserFromDomains_FullMethodName = "/domains.v1.DomainsService/DeleteUserFromDomains"
 	DomainsService_RetrieveEntity_FullMethodName        = "/domains.v1.DomainsService/RetrieveEntity"
+	DomainsService_RequiresMFA_FullMethodName           = "/domains.v1.DomainsService/RequiresMFA"
 )
 
 // DomainsServiceClient is the client API for DomainsService service.
@@ -36,6 +37,7 @@ const (
 type DomainsServiceClient interface {
 	DeleteUserFromDomains(ctx context.Context, in *DeleteUserReq, opts ...grpc.CallOption) (*DeleteUserRes, error)
 	RetrieveEntity(ctx context.Context, in *v1.RetrieveEntityReq, opts ...grpc.CallOption) (*v1.RetrieveEntityRes, error)
+	RequiresMFA(ctx context.Context, in *RequiresMFAReq, opts ...grpc.CallOption) (*RequiresMFARes, error)
 }
 
 type domainsServiceClient struct {
@@ -66,6 +68,16 @@ func (c *domainsServiceClient) RetrieveEntity(ctx context.Context, in *v1.Retrie
 	return out, nil
 }
 
+func (c *domainsServiceClient) RequiresMFA(ctx context.Context, in *RequiresMFAReq, opts ...grpc.CallOption) (*RequiresMFARes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequiresMFARes)
+	err := c.cc.Invoke(ctx, DomainsService_RequiresMFA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DomainsServiceServer is the server API for DomainsService service.
 // All implementations must embed UnimplementedDomainsServiceServer
 // for forward compatibility.
@@ -75,6 +87,7 @@ func (c *domainsServiceClient) RetrieveEntity(ctx context.Context, in *v1.Retrie
 type DomainsServiceServer interface {
 	DeleteUserFromDomains(context.Context, *DeleteUserReq) (*DeleteUserRes, error)
 	RetrieveEntity(context.Context, *v1.RetrieveEntityReq) (*v1.RetrieveEntityRes, error)
+	RequiresMFA(context.Context, *RequiresMFAReq) (*RequiresMFARes, error)
 	mustEmbedUnimplementedDomainsServiceServer()
 }
 
@@ -91,6 +104,9 @@ func (UnimplementedDomainsServiceServer) DeleteUserFromDomains(context.Context,
 func (UnimplementedDomainsServiceServer) RetrieveEntity(context.Context, *v1.RetrieveEntityReq) (*v1.RetrieveEntityRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveEntity not implemented")
 }
+func (UnimplementedDomainsServiceServer) RequiresMFA(context.Context, *RequiresMFAReq) (*RequiresMFARes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequiresMFA not implemented")
+}
 func (UnimplementedDomainsServiceServer) mustEmbedUnimplementedDomainsServiceServer() {}
 func (UnimplementedDomainsServiceServer) testEmbeddedByValue()                        {}
 
@@ -148,6 +164,24 @@ func _DomainsService_RetrieveEntity_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DomainsService_RequiresMFA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequiresMFAReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DomainsServiceServer).RequiresMFA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DomainsService_RequiresMFA_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DomainsServiceServer).RequiresMFA(ctx, req.(*RequiresMFAReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DomainsService_ServiceDesc is the grpc.ServiceDesc for DomainsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -163,6 +197,10 @@ var DomainsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetrieveEntity",
 			Handler:    _DomainsService_RetrieveEntity_Handler,
 		},
+		{
+			MethodName: "RequiresMFA",
+			Handler:    _DomainsService_RequiresMFA_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "domains/v1/domains.proto",
@@ -15,6 +15,7 @@ import (
 	"github.com/absmach/supermq/groups"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/users"
 	"github.com/gofrs/uuid/v5"
 )
@@ -59,6 +60,7 @@ const (
 	DomainKey        = "domain"
 	ChannelKey       = "channel"
 	ConnTypeKey      = "connection_type"
+	PresenceKey      = "presence"
 	DefPermission    = "read_permission"
 	DefTotal         = uint64(100)
 	DefOffset        = 0
@@ -125,6 +127,19 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 
 	w.Header().Set("Content-Type", ContentType)
 	switch {
+	case errors.Contains(err, lockout.ErrLocked):
+		err = lockout.ErrLocked
+		w.WriteHeader(http.StatusTooManyRequests)
+	case errors.Contains(err, lockout.ErrLockout):
+		err = lockout.ErrLockout
+		w.WriteHeader(http.StatusTooManyRequests)
+	case errors.Contains(err, svcerr.ErrTooManyRequests):
+		err = svcerr.ErrTooManyRequests
+		w.WriteHeader(http.StatusTooManyRequests)
+	case errors.Contains(err, apiutil.ErrPasswordExpired):
+		err = apiutil.ErrPasswordExpired
+		w.WriteHeader(http.StatusUnauthorized)
+
 	case errors.Contains(err, svcerr.ErrAuthorization),
 		errors.Contains(err, svcerr.ErrDomainAuthorization),
 		errors.Contains(err, svcerr.ErrUnauthorizedPAT):
@@ -154,14 +169,25 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrLimitSize),
 		errors.Contains(err, apiutil.ErrBearerKey),
 		errors.Contains(err, svcerr.ErrInvalidStatus),
+		errors.Contains(err, svcerr.ErrInvalidPresence),
 		errors.Contains(err, apiutil.ErrNameSize),
 		errors.Contains(err, apiutil.ErrInvalidIDFormat),
 		errors.Contains(err, apiutil.ErrInvalidQueryParams),
+		errors.Contains(err, apiutil.ErrInvalidSchema),
 		errors.Contains(err, apiutil.ErrMissingRelation),
 		errors.Contains(err, apiutil.ErrValidation),
 		errors.Contains(err, apiutil.ErrMissingPass),
+		errors.Contains(err, apiutil.ErrMissingMFACode),
 		errors.Contains(err, apiutil.ErrMissingConfPass),
 		errors.Contains(err, apiutil.ErrPasswordFormat),
+		errors.Contains(err, apiutil.ErrPasswordTooShort),
+		errors.Contains(err, apiutil.ErrPasswordTooLong),
+		errors.Contains(err, apiutil.ErrPasswordUpper),
+		errors.Contains(err, apiutil.ErrPasswordLower),
+		errors.Contains(err, apiutil.ErrPasswordDigit),
+		errors.Contains(err, apiutil.ErrPasswordSpecial),
+		errors.Contains(err, apiutil.ErrPasswordReused),
+		errors.Contains(err, apiutil.ErrPasswordBreached),
 		errors.Contains(err, svcerr.ErrInvalidRole),
 		errors.Contains(err, svcerr.ErrInvalidPolicy),
 		errors.Contains(err, apiutil.ErrInvitationState),
@@ -177,6 +203,11 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrInvalidEntityType),
 		errors.Contains(err, apiutil.ErrMissingEntityType),
 		errors.Contains(err, apiutil.ErrInvalidTimeFormat),
+		errors.Contains(err, apiutil.ErrInvalidComparator),
+		errors.Contains(err, apiutil.ErrInvalidAggregation),
+		errors.Contains(err, apiutil.ErrInvalidInterval),
+		errors.Contains(err, apiutil.ErrMissingFrom),
+		errors.Contains(err, apiutil.ErrMissingTo),
 		errors.Contains(err, svcerr.ErrSearch),
 		errors.Contains(err, apiutil.ErrEmptySearchQuery),
 		errors.Contains(err, apiutil.ErrLenSearchQuery),
@@ -194,6 +225,10 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 		errors.Contains(err, apiutil.ErrMissingChildrenGroupIDs),
 		errors.Contains(err, apiutil.ErrMissingParentGroupID),
 		errors.Contains(err, apiutil.ErrMissingConnectionType),
+		errors.Contains(err, apiutil.ErrMissingManifestKey),
+		errors.Contains(err, apiutil.ErrDuplicateManifestKey),
+		errors.Contains(err, apiutil.ErrUnknownManifestKey),
+		errors.Contains(err, apiutil.ErrInvalidDuration),
 		errors.Contains(err, apiutil.ErrMissingRoleName),
 		errors.Contains(err, apiutil.ErrMissingRoleID),
 		errors.Contains(err, apiutil.ErrMissingPolicyEntityType),
@@ -217,7 +252,8 @@ func EncodeError(_ context.Context, err error, w http.ResponseWriter) {
 	case errors.Contains(err, errors.ErrStatusAlreadyAssigned),
 		errors.Contains(err, svcerr.ErrInvitationAlreadyRejected),
 		errors.Contains(err, svcerr.ErrInvitationAlreadyAccepted),
-		errors.Contains(err, svcerr.ErrConflict):
+		errors.Contains(err, svcerr.ErrConflict),
+		errors.Contains(err, svcerr.ErrRevisionConflict):
 		err = unwrap(err)
 		w.WriteHeader(http.StatusConflict)
 
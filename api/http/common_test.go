@@ -250,6 +250,12 @@ func TestEncodeError(t *testing.T) {
 				errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingMemberKind),
 				errors.Wrap(apiutil.ErrValidation, apiutil.ErrLimitSize),
 				errors.Wrap(apiutil.ErrValidation, apiutil.ErrNameSize),
+				errors.Wrap(apiutil.ErrValidation, apiutil.ErrPasswordTooShort),
+				errors.Wrap(apiutil.ErrValidation, apiutil.ErrPasswordReused),
+				errors.Wrap(apiutil.ErrValidation, apiutil.ErrPasswordBreached),
+				errors.Wrap(apiutil.ErrValidation, apiutil.ErrDuplicateManifestKey),
+				errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnknownManifestKey),
+				errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidDuration),
 			},
 			code: http.StatusBadRequest,
 		},
@@ -259,6 +265,7 @@ func TestEncodeError(t *testing.T) {
 				svcerr.ErrAuthentication,
 				svcerr.ErrAuthentication,
 				apiutil.ErrBearerToken,
+				apiutil.ErrPasswordExpired,
 			},
 			code: http.StatusUnauthorized,
 		},
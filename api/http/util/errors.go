@@ -168,6 +168,33 @@ var (
 	// ErrPasswordFormat indicates weak password.
 	ErrPasswordFormat = errors.New("password does not meet the requirements")
 
+	// ErrPasswordTooShort indicates that the password is shorter than the policy allows.
+	ErrPasswordTooShort = errors.New("password is too short")
+
+	// ErrPasswordTooLong indicates that the password is longer than the policy allows.
+	ErrPasswordTooLong = errors.New("password is too long")
+
+	// ErrPasswordUpper indicates that the password has no uppercase letter.
+	ErrPasswordUpper = errors.New("password must contain an uppercase letter")
+
+	// ErrPasswordLower indicates that the password has no lowercase letter.
+	ErrPasswordLower = errors.New("password must contain a lowercase letter")
+
+	// ErrPasswordDigit indicates that the password has no digit.
+	ErrPasswordDigit = errors.New("password must contain a digit")
+
+	// ErrPasswordSpecial indicates that the password has no special character.
+	ErrPasswordSpecial = errors.New("password must contain a special character")
+
+	// ErrPasswordReused indicates that the password was used recently.
+	ErrPasswordReused = errors.New("password was used recently")
+
+	// ErrPasswordBreached indicates that the password appeared in a data breach.
+	ErrPasswordBreached = errors.New("password appeared in a data breach")
+
+	// ErrPasswordExpired indicates that the password has expired and has to be reset.
+	ErrPasswordExpired = errors.New("password has expired and has to be reset")
+
 	// ErrMissingName indicates missing identity name.
 	ErrMissingName = errors.New("missing identity name")
 
@@ -253,4 +280,22 @@ var (
 
 	// ErrMissingPATID indicates missing pat ID.
 	ErrMissingPATID = errors.New("missing pat id")
+
+	// ErrInvalidSchema indicates invalid channel message schema.
+	ErrInvalidSchema = errors.New("invalid channel message schema")
+
+	// ErrMissingMFACode indicates missing multi-factor authentication code.
+	ErrMissingMFACode = errors.New("missing multi-factor authentication code")
+
+	// ErrMissingManifestKey indicates missing key of the manifest entity.
+	ErrMissingManifestKey = errors.New("missing manifest key")
+
+	// ErrDuplicateManifestKey indicates that the manifest key is used by more than one entity.
+	ErrDuplicateManifestKey = errors.New("duplicate manifest key")
+
+	// ErrUnknownManifestKey indicates that the manifest connection references an unknown key.
+	ErrUnknownManifestKey = errors.New("unknown manifest key")
+
+	// ErrInvalidDuration indicates missing or non-positive duration.
+	ErrInvalidDuration = errors.New("invalid duration")
 )
@@ -71,11 +71,12 @@ func TestIdentify(t *testing.T) {
 	grpcClient := grpcapi.NewAuthClient(conn, time.Second)
 
 	cases := []struct {
-		desc   string
-		token  string
-		idt    *grpcAuthV1.AuthNRes
-		svcErr error
-		err    error
+		desc    string
+		token   string
+		keyType auth.KeyType
+		idt     *grpcAuthV1.AuthNRes
+		svcErr  error
+		err     error
 	}{
 		{
 			desc:  "authenticate user with valid user token",
@@ -83,6 +84,14 @@ func TestIdentify(t *testing.T) {
 			idt:   &grpcAuthV1.AuthNRes{Id: id, UserId: email, DomainId: domainID},
 			err:   nil,
 		},
+		{
+			desc:    "authenticate user with verification token",
+			token:   validToken,
+			keyType: auth.VerificationKey,
+			idt:     &grpcAuthV1.AuthNRes{},
+			svcErr:  svcerr.ErrAuthentication,
+			err:     svcerr.ErrAuthentication,
+		},
 		{
 			desc:   "authenticate user with invalid user token",
 			token:  "invalid",
@@ -100,7 +109,7 @@ func TestIdentify(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			svcCall := svc.On("Identify", mock.Anything, mock.Anything).Return(auth.Key{Subject: id, User: email, Domain: domainID}, tc.svcErr)
+			svcCall := svc.On("Identify", mock.Anything, mock.Anything).Return(auth.Key{Type: tc.keyType, Subject: id, User: email, Domain: domainID}, tc.svcErr)
 			idt, err := grpcClient.Authenticate(context.Background(), &grpcAuthV1.AuthNReq{Token: tc.token})
 			if idt != nil {
 				assert.Equal(t, tc.idt, idt, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.idt, idt))
@@ -18,9 +18,12 @@ import (
 const tokenSvcName = "token.v1.TokenService"
 
 type tokenGrpcClient struct {
-	issue   endpoint.Endpoint
-	refresh endpoint.Endpoint
-	timeout time.Duration
+	issue         endpoint.Endpoint
+	refresh       endpoint.Endpoint
+	listSessions  endpoint.Endpoint
+	revokeSession endpoint.Endpoint
+	verify        endpoint.Endpoint
+	timeout       time.Duration
 }
 
 var _ grpcTokenV1.TokenServiceClient = (*tokenGrpcClient)(nil)
@@ -44,6 +47,30 @@ func NewTokenClient(conn *grpc.ClientConn, timeout time.Duration) grpcTokenV1.To
 			decodeRefreshResponse,
 			grpcTokenV1.Token{},
 		).Endpoint(),
+		listSessions: kitgrpc.NewClient(
+			conn,
+			tokenSvcName,
+			"ListSessions",
+			encodeListSessionsRequest,
+			decodeListSessionsResponse,
+			grpcTokenV1.ListSessionsRes{},
+		).Endpoint(),
+		revokeSession: kitgrpc.NewClient(
+			conn,
+			tokenSvcName,
+			"RevokeSession",
+			encodeRevokeSessionRequest,
+			decodeRevokeSessionResponse,
+			grpcTokenV1.RevokeSessionRes{},
+		).Endpoint(),
+		verify: kitgrpc.NewClient(
+			conn,
+			tokenSvcName,
+			"Verify",
+			encodeVerifyRequest,
+			decodeVerifyResponse,
+			grpcTokenV1.VerifyRes{},
+		).Endpoint(),
 		timeout: timeout,
 	}
 }
@@ -53,8 +80,12 @@ func (client tokenGrpcClient) Issue(ctx context.Context, req *grpcTokenV1.IssueR
 	defer cancel()
 
 	res, err := client.issue(ctx, issueReq{
-		userID:  req.GetUserId(),
-		keyType: auth.KeyType(req.GetType()),
+		userID:    req.GetUserId(),
+		keyType:   auth.KeyType(req.GetType()),
+		userAgent: req.GetUserAgent(),
+		ipAddress: req.GetIpAddress(),
+		email:     req.GetEmail(),
+		nonce:     req.GetNonce(),
 	})
 	if err != nil {
 		return &grpcTokenV1.Token{}, grpcapi.DecodeError(err)
@@ -65,8 +96,12 @@ func (client tokenGrpcClient) Issue(ctx context.Context, req *grpcTokenV1.IssueR
 func encodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(issueReq)
 	return &grpcTokenV1.IssueReq{
-		UserId: req.userID,
-		Type:   uint32(req.keyType),
+		UserId:    req.userID,
+		Type:      uint32(req.keyType),
+		UserAgent: req.userAgent,
+		IpAddress: req.ipAddress,
+		Email:     req.email,
+		Nonce:     req.nonce,
 	}, nil
 }
 
@@ -78,7 +113,11 @@ func (client tokenGrpcClient) Refresh(ctx context.Context, req *grpcTokenV1.Refr
 	ctx, cancel := context.WithTimeout(ctx, client.timeout)
 	defer cancel()
 
-	res, err := client.refresh(ctx, refreshReq{refreshToken: req.GetRefreshToken()})
+	res, err := client.refresh(ctx, refreshReq{
+		refreshToken: req.GetRefreshToken(),
+		userAgent:    req.GetUserAgent(),
+		ipAddress:    req.GetIpAddress(),
+	})
 	if err != nil {
 		return &grpcTokenV1.Token{}, grpcapi.DecodeError(err)
 	}
@@ -87,9 +126,85 @@ func (client tokenGrpcClient) Refresh(ctx context.Context, req *grpcTokenV1.Refr
 
 func encodeRefreshRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(refreshReq)
-	return &grpcTokenV1.RefreshReq{RefreshToken: req.refreshToken}, nil
+	return &grpcTokenV1.RefreshReq{
+		RefreshToken: req.refreshToken,
+		UserAgent:    req.userAgent,
+		IpAddress:    req.ipAddress,
+	}, nil
 }
 
 func decodeRefreshResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
 	return grpcRes, nil
 }
+
+func (client tokenGrpcClient) ListSessions(ctx context.Context, req *grpcTokenV1.ListSessionsReq, _ ...grpc.CallOption) (*grpcTokenV1.ListSessionsRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.listSessions(ctx, listSessionsReq{userID: req.GetUserId()})
+	if err != nil {
+		return &grpcTokenV1.ListSessionsRes{}, grpcapi.DecodeError(err)
+	}
+	return res.(*grpcTokenV1.ListSessionsRes), nil
+}
+
+func encodeListSessionsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(listSessionsReq)
+	return &grpcTokenV1.ListSessionsReq{UserId: req.userID}, nil
+}
+
+func decodeListSessionsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return grpcRes, nil
+}
+
+func (client tokenGrpcClient) RevokeSession(ctx context.Context, req *grpcTokenV1.RevokeSessionReq, _ ...grpc.CallOption) (*grpcTokenV1.RevokeSessionRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.revokeSession(ctx, revokeSessionReq{
+		userID:    req.GetUserId(),
+		sessionID: req.GetSessionId(),
+	})
+	if err != nil {
+		return &grpcTokenV1.RevokeSessionRes{}, grpcapi.DecodeError(err)
+	}
+	return res.(*grpcTokenV1.RevokeSessionRes), nil
+}
+
+func encodeRevokeSessionRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(revokeSessionReq)
+	return &grpcTokenV1.RevokeSessionReq{
+		UserId:    req.userID,
+		SessionId: req.sessionID,
+	}, nil
+}
+
+func decodeRevokeSessionResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return grpcRes, nil
+}
+
+func (client tokenGrpcClient) Verify(ctx context.Context, req *grpcTokenV1.VerifyReq, _ ...grpc.CallOption) (*grpcTokenV1.VerifyRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.verify(ctx, verifyReq{
+		token:   req.GetToken(),
+		keyType: auth.KeyType(req.GetType()),
+	})
+	if err != nil {
+		return &grpcTokenV1.VerifyRes{}, grpcapi.DecodeError(err)
+	}
+	return res.(*grpcTokenV1.VerifyRes), nil
+}
+
+func encodeVerifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(verifyReq)
+	return &grpcTokenV1.VerifyReq{
+		Token: req.token,
+		Type:  uint32(req.keyType),
+	}, nil
+}
+
+func decodeVerifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return grpcRes, nil
+}
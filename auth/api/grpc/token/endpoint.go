@@ -21,6 +21,11 @@ func issueEndpoint(svc auth.Service) endpoint.Endpoint {
 			Type: req.keyType,
 			User: req.userID,
 		}
+		if req.keyType == auth.VerificationKey {
+			key.ID = req.nonce
+			key.Email = req.email
+		}
+		ctx = auth.WithSessionInfo(ctx, auth.SessionInfo{UserAgent: req.userAgent, IPAddress: req.ipAddress})
 		tkn, err := svc.Issue(ctx, "", key)
 		if err != nil {
 			return issueRes{}, err
@@ -42,6 +47,7 @@ func refreshEndpoint(svc auth.Service) endpoint.Endpoint {
 		}
 
 		key := auth.Key{Type: auth.RefreshKey}
+		ctx = auth.WithSessionInfo(ctx, auth.SessionInfo{UserAgent: req.userAgent, IPAddress: req.ipAddress})
 		tkn, err := svc.Issue(ctx, req.refreshToken, key)
 		if err != nil {
 			return issueRes{}, err
@@ -54,3 +60,56 @@ func refreshEndpoint(svc auth.Service) endpoint.Endpoint {
 		return ret, nil
 	}
 }
+
+func listSessionsEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listSessionsReq)
+		if err := req.validate(); err != nil {
+			return listSessionsRes{}, err
+		}
+
+		sessions, err := svc.ListSessions(ctx, req.userID)
+		if err != nil {
+			return listSessionsRes{}, err
+		}
+
+		return listSessionsRes{sessions: sessions}, nil
+	}
+}
+
+func revokeSessionEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(revokeSessionReq)
+		if err := req.validate(); err != nil {
+			return revokeSessionRes{}, err
+		}
+
+		if req.sessionID == "" {
+			if err := svc.RevokeSessions(ctx, req.userID); err != nil {
+				return revokeSessionRes{}, err
+			}
+			return revokeSessionRes{}, nil
+		}
+		if err := svc.RevokeSession(ctx, req.userID, req.sessionID); err != nil {
+			return revokeSessionRes{}, err
+		}
+
+		return revokeSessionRes{}, nil
+	}
+}
+
+func verifyEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(verifyReq)
+		if err := req.validate(); err != nil {
+			return verifyRes{}, err
+		}
+
+		key, err := svc.Verify(ctx, req.token, req.keyType)
+		if err != nil {
+			return verifyRes{}, err
+		}
+
+		return verifyRes{userID: key.User, email: key.Email, nonce: key.ID}, nil
+	}
+}
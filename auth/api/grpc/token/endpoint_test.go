@@ -71,6 +71,8 @@ func TestIssue(t *testing.T) {
 		desc          string
 		userId        string
 		kind          auth.KeyType
+		email         string
+		nonce         string
 		issueResponse auth.Token
 		err           error
 	}{
@@ -94,6 +96,33 @@ func TestIssue(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc:   "issue verification key",
+			userId: validID,
+			kind:   auth.VerificationKey,
+			email:  "user@example.com",
+			nonce:  validID,
+			issueResponse: auth.Token{
+				AccessToken: validToken,
+			},
+			err: nil,
+		},
+		{
+			desc:          "issue verification key without email",
+			userId:        validID,
+			kind:          auth.VerificationKey,
+			nonce:         validID,
+			issueResponse: auth.Token{},
+			err:           svcerr.ErrAuthentication,
+		},
+		{
+			desc:          "issue verification key without nonce",
+			userId:        validID,
+			kind:          auth.VerificationKey,
+			email:         "user@example.com",
+			issueResponse: auth.Token{},
+			err:           errors.ErrMalformedEntity,
+		},
 		{
 			desc:          "issue API key unauthenticated",
 			userId:        validID,
@@ -119,7 +148,7 @@ func TestIssue(t *testing.T) {
 
 	for _, tc := range cases {
 		svcCall := svc.On("Issue", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.issueResponse, tc.err)
-		_, err := grpcClient.Issue(context.Background(), &grpcTokenV1.IssueReq{UserId: tc.userId, Type: uint32(tc.kind)})
+		_, err := grpcClient.Issue(context.Background(), &grpcTokenV1.IssueReq{UserId: tc.userId, Type: uint32(tc.kind), Email: tc.email, Nonce: tc.nonce})
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		svcCall.Unset()
 	}
@@ -167,3 +196,193 @@ func TestRefresh(t *testing.T) {
 		svcCall.Unset()
 	}
 }
+
+func TestListSessions(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer conn.Close()
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	grpcClient := grpcapi.NewTokenClient(conn, time.Second)
+
+	now := time.Now().UTC()
+	session := auth.Session{
+		ID:         validID,
+		UserID:     validID,
+		UserAgent:  "Mozilla/5.0",
+		IPAddress:  "192.168.0.1",
+		IssuedAt:   now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(refreshDuration),
+	}
+
+	cases := []struct {
+		desc     string
+		userID   string
+		sessions []auth.Session
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "list sessions with valid user ID",
+			userID:   validID,
+			sessions: []auth.Session{session},
+			err:      nil,
+		},
+		{
+			desc:   "list sessions with empty user ID",
+			userID: "",
+			err:    errors.ErrMalformedEntity,
+		},
+		{
+			desc:   "list sessions with failed to retrieve",
+			userID: validID,
+			svcErr: svcerr.ErrNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ListSessions", mock.Anything, tc.userID).Return(tc.sessions, tc.svcErr)
+			res, err := grpcClient.ListSessions(context.Background(), &grpcTokenV1.ListSessionsReq{UserId: tc.userID})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Len(t, res.GetSessions(), len(tc.sessions), fmt.Sprintf("%s: expected %d sessions got %d\n", tc.desc, len(tc.sessions), len(res.GetSessions())))
+				for i, s := range res.GetSessions() {
+					assert.Equal(t, tc.sessions[i].ID, s.GetId())
+					assert.Equal(t, tc.sessions[i].UserAgent, s.GetUserAgent())
+					assert.Equal(t, tc.sessions[i].IPAddress, s.GetIpAddress())
+					assert.True(t, tc.sessions[i].LastUsedAt.Equal(s.GetLastUsedAt().AsTime()))
+				}
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer conn.Close()
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	grpcClient := grpcapi.NewTokenClient(conn, time.Second)
+
+	cases := []struct {
+		desc      string
+		userID    string
+		sessionID string
+		svcErr    error
+		err       error
+	}{
+		{
+			desc:      "revoke session with valid ID",
+			userID:    validID,
+			sessionID: validID,
+			err:       nil,
+		},
+		{
+			desc:      "revoke all sessions",
+			userID:    validID,
+			sessionID: "",
+			err:       nil,
+		},
+		{
+			desc:      "revoke non-existing session",
+			userID:    validID,
+			sessionID: validID,
+			svcErr:    svcerr.ErrNotFound,
+			err:       svcerr.ErrNotFound,
+		},
+		{
+			desc:      "revoke session with empty user ID",
+			userID:    "",
+			sessionID: validID,
+			err:       errors.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("RevokeSession", mock.Anything, tc.userID, tc.sessionID).Return(tc.svcErr)
+			svcCall1 := svc.On("RevokeSessions", mock.Anything, tc.userID).Return(tc.svcErr)
+			_, err := grpcClient.RevokeSession(context.Background(), &grpcTokenV1.RevokeSessionReq{UserId: tc.userID, SessionId: tc.sessionID})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			switch {
+			case tc.userID == "":
+				svcCall.Parent.AssertNotCalled(t, "RevokeSession", mock.Anything, tc.userID, tc.sessionID)
+			case tc.sessionID == "":
+				svcCall1.Parent.AssertCalled(t, "RevokeSessions", mock.Anything, tc.userID)
+			default:
+				svcCall.Parent.AssertCalled(t, "RevokeSession", mock.Anything, tc.userID, tc.sessionID)
+			}
+			svcCall.Unset()
+			svcCall1.Unset()
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	defer conn.Close()
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	grpcClient := grpcapi.NewTokenClient(conn, time.Second)
+
+	cases := []struct {
+		desc     string
+		token    string
+		keyType  auth.KeyType
+		key      auth.Key
+		svcErr   error
+		response *grpcTokenV1.VerifyRes
+		err      error
+	}{
+		{
+			desc:     "verify valid verification token",
+			token:    validToken,
+			keyType:  auth.VerificationKey,
+			key:      auth.Key{ID: validID, Type: auth.VerificationKey, User: validID, Email: "user@example.com"},
+			response: &grpcTokenV1.VerifyRes{UserId: validID, Email: "user@example.com", Nonce: validID},
+			err:      nil,
+		},
+		{
+			desc:     "verify token of other type",
+			token:    validToken,
+			keyType:  auth.VerificationKey,
+			svcErr:   svcerr.ErrAuthentication,
+			response: &grpcTokenV1.VerifyRes{},
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "verify invalid token",
+			token:    inValidToken,
+			keyType:  auth.VerificationKey,
+			svcErr:   svcerr.ErrAuthentication,
+			response: &grpcTokenV1.VerifyRes{},
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "verify token with invalid type",
+			token:    validToken,
+			keyType:  auth.AccessKey,
+			response: &grpcTokenV1.VerifyRes{},
+			err:      errors.ErrMalformedEntity,
+		},
+		{
+			desc:     "verify empty token",
+			token:    "",
+			keyType:  auth.VerificationKey,
+			response: &grpcTokenV1.VerifyRes{},
+			err:      svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("Verify", mock.Anything, tc.token, tc.keyType).Return(tc.key, tc.svcErr)
+			res, err := grpcClient.Verify(context.Background(), &grpcTokenV1.VerifyReq{Token: tc.token, Type: uint32(tc.keyType)})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response.GetUserId(), res.GetUserId(), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.response.GetUserId(), res.GetUserId()))
+			assert.Equal(t, tc.response.GetEmail(), res.GetEmail(), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.response.GetEmail(), res.GetEmail()))
+			assert.Equal(t, tc.response.GetNonce(), res.GetNonce(), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.response.GetNonce(), res.GetNonce()))
+			svcCall.Unset()
+		})
+	}
+}
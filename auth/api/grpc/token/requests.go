@@ -9,23 +9,38 @@ import (
 )
 
 type issueReq struct {
-	userID  string
-	keyType auth.KeyType
+	userID    string
+	keyType   auth.KeyType
+	userAgent string
+	ipAddress string
+	email     string
+	nonce     string
 }
 
 func (req issueReq) validate() error {
 	if req.keyType != auth.AccessKey &&
 		req.keyType != auth.APIKey &&
 		req.keyType != auth.RecoveryKey &&
-		req.keyType != auth.InvitationKey {
+		req.keyType != auth.InvitationKey &&
+		req.keyType != auth.VerificationKey {
 		return apiutil.ErrInvalidAuthKey
 	}
+	if req.keyType == auth.VerificationKey {
+		if req.email == "" {
+			return apiutil.ErrMissingEmail
+		}
+		if req.nonce == "" {
+			return apiutil.ErrMissingID
+		}
+	}
 
 	return nil
 }
 
 type refreshReq struct {
 	refreshToken string
+	userAgent    string
+	ipAddress    string
 }
 
 func (req refreshReq) validate() error {
@@ -35,3 +50,45 @@ func (req refreshReq) validate() error {
 
 	return nil
 }
+
+type listSessionsReq struct {
+	userID string
+}
+
+func (req listSessionsReq) validate() error {
+	if req.userID == "" {
+		return apiutil.ErrMissingUserID
+	}
+
+	return nil
+}
+
+type revokeSessionReq struct {
+	userID    string
+	sessionID string
+}
+
+func (req revokeSessionReq) validate() error {
+	if req.userID == "" {
+		return apiutil.ErrMissingUserID
+	}
+
+	return nil
+}
+
+type verifyReq struct {
+	token   string
+	keyType auth.KeyType
+}
+
+func (req verifyReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.keyType != auth.RecoveryKey &&
+		req.keyType != auth.VerificationKey {
+		return apiutil.ErrInvalidAuthKey
+	}
+
+	return nil
+}
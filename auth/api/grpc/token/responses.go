@@ -3,8 +3,22 @@
 
 package token
 
+import "github.com/absmach/supermq/auth"
+
 type issueRes struct {
 	accessToken  string
 	refreshToken string
 	accessType   string
 }
+
+type listSessionsRes struct {
+	sessions []auth.Session
+}
+
+type revokeSessionRes struct{}
+
+type verifyRes struct {
+	userID string
+	email  string
+	nonce  string
+}
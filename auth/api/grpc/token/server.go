@@ -10,14 +10,18 @@ import (
 	"github.com/absmach/supermq/auth"
 	grpcapi "github.com/absmach/supermq/auth/api/grpc"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var _ grpcTokenV1.TokenServiceServer = (*tokenGrpcServer)(nil)
 
 type tokenGrpcServer struct {
 	grpcTokenV1.UnimplementedTokenServiceServer
-	issue   kitgrpc.Handler
-	refresh kitgrpc.Handler
+	issue         kitgrpc.Handler
+	refresh       kitgrpc.Handler
+	listSessions  kitgrpc.Handler
+	revokeSession kitgrpc.Handler
+	verify        kitgrpc.Handler
 }
 
 // NewAuthServer returns new AuthnServiceServer instance.
@@ -33,6 +37,21 @@ func NewTokenServer(svc auth.Service) grpcTokenV1.TokenServiceServer {
 			decodeRefreshRequest,
 			encodeIssueResponse,
 		),
+		listSessions: kitgrpc.NewServer(
+			listSessionsEndpoint(svc),
+			decodeListSessionsRequest,
+			encodeListSessionsResponse,
+		),
+		revokeSession: kitgrpc.NewServer(
+			revokeSessionEndpoint(svc),
+			decodeRevokeSessionRequest,
+			encodeRevokeSessionResponse,
+		),
+		verify: kitgrpc.NewServer(
+			verifyEndpoint(svc),
+			decodeVerifyRequest,
+			encodeVerifyResponse,
+		),
 	}
 }
 
@@ -52,17 +71,49 @@ func (s *tokenGrpcServer) Refresh(ctx context.Context, req *grpcTokenV1.RefreshR
 	return res.(*grpcTokenV1.Token), nil
 }
 
+func (s *tokenGrpcServer) ListSessions(ctx context.Context, req *grpcTokenV1.ListSessionsReq) (*grpcTokenV1.ListSessionsRes, error) {
+	_, res, err := s.listSessions.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcapi.EncodeError(err)
+	}
+	return res.(*grpcTokenV1.ListSessionsRes), nil
+}
+
+func (s *tokenGrpcServer) RevokeSession(ctx context.Context, req *grpcTokenV1.RevokeSessionReq) (*grpcTokenV1.RevokeSessionRes, error) {
+	_, res, err := s.revokeSession.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcapi.EncodeError(err)
+	}
+	return res.(*grpcTokenV1.RevokeSessionRes), nil
+}
+
+func (s *tokenGrpcServer) Verify(ctx context.Context, req *grpcTokenV1.VerifyReq) (*grpcTokenV1.VerifyRes, error) {
+	_, res, err := s.verify.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcapi.EncodeError(err)
+	}
+	return res.(*grpcTokenV1.VerifyRes), nil
+}
+
 func decodeIssueRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*grpcTokenV1.IssueReq)
 	return issueReq{
-		userID:  req.GetUserId(),
-		keyType: auth.KeyType(req.GetType()),
+		userID:    req.GetUserId(),
+		keyType:   auth.KeyType(req.GetType()),
+		userAgent: req.GetUserAgent(),
+		ipAddress: req.GetIpAddress(),
+		email:     req.GetEmail(),
+		nonce:     req.GetNonce(),
 	}, nil
 }
 
 func decodeRefreshRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
 	req := grpcReq.(*grpcTokenV1.RefreshReq)
-	return refreshReq{refreshToken: req.GetRefreshToken()}, nil
+	return refreshReq{
+		refreshToken: req.GetRefreshToken(),
+		userAgent:    req.GetUserAgent(),
+		ipAddress:    req.GetIpAddress(),
+	}, nil
 }
 
 func encodeIssueResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
@@ -74,3 +125,52 @@ func encodeIssueResponse(_ context.Context, grpcRes interface{}) (interface{}, e
 		AccessType:   res.accessType,
 	}, nil
 }
+
+func decodeListSessionsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcTokenV1.ListSessionsReq)
+	return listSessionsReq{userID: req.GetUserId()}, nil
+}
+
+func encodeListSessionsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(listSessionsRes)
+
+	sessions := make([]*grpcTokenV1.Session, len(res.sessions))
+	for i, s := range res.sessions {
+		sessions[i] = &grpcTokenV1.Session{
+			Id:         s.ID,
+			UserId:     s.UserID,
+			UserAgent:  s.UserAgent,
+			IpAddress:  s.IPAddress,
+			IssuedAt:   timestamppb.New(s.IssuedAt),
+			LastUsedAt: timestamppb.New(s.LastUsedAt),
+			ExpiresAt:  timestamppb.New(s.ExpiresAt),
+		}
+	}
+
+	return &grpcTokenV1.ListSessionsRes{Sessions: sessions}, nil
+}
+
+func decodeRevokeSessionRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcTokenV1.RevokeSessionReq)
+	return revokeSessionReq{
+		userID:    req.GetUserId(),
+		sessionID: req.GetSessionId(),
+	}, nil
+}
+
+func encodeRevokeSessionResponse(_ context.Context, _ interface{}) (interface{}, error) {
+	return &grpcTokenV1.RevokeSessionRes{}, nil
+}
+
+func decodeVerifyRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcTokenV1.VerifyReq)
+	return verifyReq{
+		token:   req.GetToken(),
+		keyType: auth.KeyType(req.GetType()),
+	}, nil
+}
+
+func encodeVerifyResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(verifyRes)
+	return &grpcTokenV1.VerifyRes{UserId: res.userID, Email: res.email, Nonce: res.nonce}, nil
+}
@@ -85,3 +85,14 @@ func revokeEndpoint(svc auth.Service) endpoint.Endpoint {
 		return revokeKeyRes{}, nil
 	}
 }
+
+func retrieveJWKSEndpoint(svc auth.Service) endpoint.Endpoint {
+	return func(ctx context.Context, _ interface{}) (interface{}, error) {
+		keys, err := svc.RetrieveJWKS(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return jwksRes{Keys: keys}, nil
+	}
+}
@@ -70,13 +70,15 @@ func (tr testRequest) make() (*http.Response, error) {
 func newService() (auth.Service, *mocks.KeyRepository) {
 	krepo := new(mocks.KeyRepository)
 	pRepo := new(mocks.PATSRepository)
+	sessions := new(mocks.SessionRepository)
+	sessions.On("Save", mock.Anything, mock.Anything).Return(nil)
 	hash := new(mocks.Hasher)
 	idProvider := uuid.NewMock()
 	pService := new(policymocks.Service)
 	pEvaluator := new(policymocks.Evaluator)
 	t := jwt.New([]byte(secret))
 
-	return auth.New(krepo, pRepo, hash, idProvider, t, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration), krepo
+	return auth.New(krepo, pRepo, sessions, hash, idProvider, t, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration), krepo
 }
 
 func newServer(svc auth.Service) *httptest.Server {
@@ -336,3 +338,52 @@ func TestRevoke(t *testing.T) {
 		repocall.Unset()
 	}
 }
+
+func TestRetrieveJWKS(t *testing.T) {
+	km, err := jwt.NewKeyManager(jwt.KeyManagerConfig{Algorithm: jwt.EdDSA})
+	assert.Nil(t, err, fmt.Sprintf("creating key manager expected to succeed: %s", err))
+	asymmetricTokenizer := jwt.NewAsymmetric(km)
+	jwks, err := asymmetricTokenizer.RetrieveJWKS()
+	assert.Nil(t, err, fmt.Sprintf("retrieving JWKS expected to succeed: %s", err))
+
+	cases := []struct {
+		desc      string
+		tokenizer auth.Tokenizer
+		status    int
+		keys      []auth.PublicKeyInfo
+	}{
+		{
+			desc:      "retrieve JWKS with asymmetric keys",
+			tokenizer: asymmetricTokenizer,
+			status:    http.StatusOK,
+			keys:      jwks,
+		},
+		{
+			desc:      "retrieve JWKS with symmetric key",
+			tokenizer: jwt.New([]byte(secret)),
+			status:    http.StatusOK,
+			keys:      []auth.PublicKeyInfo{},
+		},
+	}
+
+	for _, tc := range cases {
+		svc := auth.New(new(mocks.KeyRepository), new(mocks.PATSRepository), new(mocks.SessionRepository), new(mocks.Hasher), uuid.NewMock(), tc.tokenizer, new(policymocks.Evaluator), new(policymocks.Service), loginDuration, refreshDuration, invalidDuration)
+		ts := newServer(svc)
+		req := testRequest{
+			client: ts.Client(),
+			method: http.MethodGet,
+			url:    fmt.Sprintf("%s/.well-known/jwks.json", ts.URL),
+		}
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		assert.NotEmpty(t, res.Header.Get("Cache-Control"), fmt.Sprintf("%s: expected Cache-Control header", tc.desc))
+		var body struct {
+			Keys []auth.PublicKeyInfo `json:"keys"`
+		}
+		err = json.NewDecoder(res.Body).Decode(&body)
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding body %s", tc.desc, err))
+		assert.Equal(t, tc.keys, body.Keys, fmt.Sprintf("%s: expected keys %v got %v", tc.desc, tc.keys, body.Keys))
+		ts.Close()
+	}
+}
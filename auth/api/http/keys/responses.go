@@ -11,9 +11,14 @@ import (
 	"github.com/absmach/supermq/auth"
 )
 
+// jwksCacheControl lets verifiers cache the public keys for a short time,
+// so keys added on rotation are picked up well before they are used.
+const jwksCacheControl = "public, max-age=300"
+
 var (
 	_ supermq.Response = (*issueKeyRes)(nil)
 	_ supermq.Response = (*revokeKeyRes)(nil)
+	_ supermq.Response = (*jwksRes)(nil)
 )
 
 type issueKeyRes struct {
@@ -69,3 +74,21 @@ func (res revokeKeyRes) Headers() map[string]string {
 func (res revokeKeyRes) Empty() bool {
 	return true
 }
+
+type jwksRes struct {
+	Keys []auth.PublicKeyInfo `json:"keys"`
+}
+
+func (res jwksRes) Code() int {
+	return http.StatusOK
+}
+
+func (res jwksRes) Headers() map[string]string {
+	return map[string]string{
+		"Cache-Control": jwksCacheControl,
+	}
+}
+
+func (res jwksRes) Empty() bool {
+	return false
+}
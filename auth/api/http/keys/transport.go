@@ -47,6 +47,14 @@ func MakeHandler(svc auth.Service, mux *chi.Mux, logger *slog.Logger) *chi.Mux {
 			opts...,
 		).ServeHTTP)
 	})
+
+	mux.Get("/.well-known/jwks.json", kithttp.NewServer(
+		retrieveJWKSEndpoint(svc),
+		decodeJWKSReq,
+		api.EncodeResponse,
+		opts...,
+	).ServeHTTP)
+
 	return mux
 }
 
@@ -63,6 +71,10 @@ func decodeIssue(_ context.Context, r *http.Request) (interface{}, error) {
 	return req, nil
 }
 
+func decodeJWKSReq(_ context.Context, _ *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
 func decodeKeyReq(_ context.Context, r *http.Request) (interface{}, error) {
 	req := keyReq{
 		token: apiutil.ExtractBearerToken(r),
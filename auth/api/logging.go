@@ -100,6 +100,96 @@ func (lm *loggingMiddleware) Identify(ctx context.Context, token string) (id aut
 	return lm.svc.Identify(ctx, token)
 }
 
+func (lm *loggingMiddleware) Verify(ctx context.Context, token string, keyType auth.KeyType) (key auth.Key, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("key",
+				slog.String("user", key.User),
+				slog.Any("type", keyType),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Verify key failed", args...)
+			return
+		}
+		lm.logger.Info("Verify key completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Verify(ctx, token, keyType)
+}
+
+func (lm *loggingMiddleware) RetrieveJWKS(ctx context.Context) (keys []auth.PublicKeyInfo, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Int("keys", len(keys)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve JWKS failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve JWKS completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RetrieveJWKS(ctx)
+}
+
+func (lm *loggingMiddleware) ListSessions(ctx context.Context, userID string) (sessions []auth.Session, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", userID),
+			slog.Int("sessions", len(sessions)),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List sessions failed", args...)
+			return
+		}
+		lm.logger.Info("List sessions completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListSessions(ctx, userID)
+}
+
+func (lm *loggingMiddleware) RevokeSession(ctx context.Context, userID, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", userID),
+			slog.String("session_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke session failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke session completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RevokeSession(ctx, userID, id)
+}
+
+func (lm *loggingMiddleware) RevokeSessions(ctx context.Context, userID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("user_id", userID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke sessions failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke sessions completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RevokeSessions(ctx, userID)
+}
+
 func (lm *loggingMiddleware) Authorize(ctx context.Context, pr policies.Policy) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
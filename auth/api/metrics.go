@@ -67,6 +67,51 @@ func (ms *metricsMiddleware) Identify(ctx context.Context, token string) (auth.K
 	return ms.svc.Identify(ctx, token)
 }
 
+func (ms *metricsMiddleware) Verify(ctx context.Context, token string, keyType auth.KeyType) (auth.Key, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify").Add(1)
+		ms.latency.With("method", "verify").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.Verify(ctx, token, keyType)
+}
+
+func (ms *metricsMiddleware) RetrieveJWKS(ctx context.Context) ([]auth.PublicKeyInfo, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "retrieve_jwks").Add(1)
+		ms.latency.With("method", "retrieve_jwks").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RetrieveJWKS(ctx)
+}
+
+func (ms *metricsMiddleware) ListSessions(ctx context.Context, userID string) ([]auth.Session, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_sessions").Add(1)
+		ms.latency.With("method", "list_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.ListSessions(ctx, userID)
+}
+
+func (ms *metricsMiddleware) RevokeSession(ctx context.Context, userID, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_session").Add(1)
+		ms.latency.With("method", "revoke_session").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeSession(ctx, userID, id)
+}
+
+func (ms *metricsMiddleware) RevokeSessions(ctx context.Context, userID string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_sessions").Add(1)
+		ms.latency.With("method", "revoke_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return ms.svc.RevokeSessions(ctx, userID)
+}
+
 func (ms *metricsMiddleware) Authorize(ctx context.Context, pr policies.Policy) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "authorize").Add(1)
@@ -0,0 +1,59 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bolt
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	bolt "go.etcd.io/bbolt"
+)
+
+// MigratePATs copies all the PATs with their scopes and secret hashes from
+// the bolt bucket to the target repository. The PATs which already exist
+// in the target repository are skipped, so the migration can be repeated.
+// It returns the number of the copied PATs.
+func MigratePATs(ctx context.Context, db *bolt.DB, bucketName string, target auth.PATSRepository) (int, error) {
+	pr := &patRepo{
+		db:         db,
+		bucketName: bucketName,
+	}
+
+	var ids []patIndex
+	if err := db.View(func(tx *bolt.Tx) error {
+		return pr.forEachPAT(tx, func(idx patIndex, _ *bolt.Bucket) {
+			ids = append(ids, idx)
+		})
+	}); err != nil {
+		return 0, err
+	}
+
+	migrated := 0
+	for _, i := range ids {
+		_, err := target.Retrieve(ctx, i.userID, i.patID)
+		if err == nil {
+			continue
+		}
+		if !errors.Contains(err, repoerr.ErrNotFound) {
+			return migrated, err
+		}
+		pat, err := pr.Retrieve(ctx, i.userID, i.patID)
+		if err != nil {
+			return migrated, err
+		}
+		secret, _, _, err := pr.RetrieveSecretAndRevokeStatus(ctx, i.userID, i.patID)
+		if err != nil {
+			return migrated, err
+		}
+		pat.Secret = secret
+		if err := target.Save(ctx, pat); err != nil {
+			return migrated, err
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bolt_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/bolt"
+	"github.com/absmach/supermq/auth/mocks"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bbolt "go.etcd.io/bbolt"
+)
+
+const bucket = "test"
+
+func TestMigratePATs(t *testing.T) {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "pat.db"), 0o600, nil)
+	require.Nil(t, err, fmt.Sprintf("opening bolt db expected to succeed: %s", err))
+	defer db.Close()
+	err = db.Update(func(tx *bbolt.Tx) error {
+		return bolt.Init(tx, bucket)
+	})
+	require.Nil(t, err, fmt.Sprintf("initializing bolt db expected to succeed: %s", err))
+
+	repo := bolt.NewPATSRepository(db, bucket)
+	now := time.Now().Truncate(time.Second)
+	migrated := auth.PAT{
+		ID:        "a7a2f4e0-5d1b-4d5e-8c64-1f3e4f2c0a01",
+		User:      "b2f4a6c8-1d3e-4f5a-9b7c-2e4d6f8a0b02",
+		Name:      "migrated",
+		Secret:    "hash",
+		IssuedAt:  now,
+		ExpiresAt: now.Add(time.Hour),
+		Scope: auth.Scope{
+			Messaging: auth.OperationScope{
+				auth.PublishOp: &auth.AnyIDs{},
+			},
+		},
+	}
+	existing := migrated
+	existing.ID = "c3e5a7b9-2f4d-4a6b-8c0e-3f5a7b9c1d03"
+	existing.Name = "existing"
+	for _, pat := range []auth.PAT{migrated, existing} {
+		err := repo.Save(context.Background(), pat)
+		require.Nil(t, err, fmt.Sprintf("saving PAT expected to succeed: %s", err))
+	}
+	// Reactivated PAT has empty revoked at value.
+	err = repo.Revoke(context.Background(), migrated.User, migrated.ID)
+	require.Nil(t, err, fmt.Sprintf("revoking PAT expected to succeed: %s", err))
+	err = repo.Reactivate(context.Background(), migrated.User, migrated.ID)
+	require.Nil(t, err, fmt.Sprintf("reactivating PAT expected to succeed: %s", err))
+
+	target := new(mocks.PATSRepository)
+	target.On("Retrieve", mock.Anything, migrated.User, migrated.ID).Return(auth.PAT{}, repoerr.ErrNotFound)
+	target.On("Retrieve", mock.Anything, existing.User, existing.ID).Return(existing, nil)
+	var saved auth.PAT
+	target.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(auth.PAT)
+	}).Return(nil)
+
+	n, err := bolt.MigratePATs(context.Background(), db, bucket, target)
+	assert.Nil(t, err, fmt.Sprintf("migrating PATs expected to succeed: %s", err))
+	assert.Equal(t, 1, n, fmt.Sprintf("expected 1 migrated PAT got %d", n))
+	target.AssertNumberOfCalls(t, "Save", 1)
+	assert.Equal(t, migrated.ID, saved.ID, fmt.Sprintf("expected migrated PAT %s got %s", migrated.ID, saved.ID))
+	assert.Equal(t, migrated.Secret, saved.Secret, fmt.Sprintf("expected secret %s got %s", migrated.Secret, saved.Secret))
+	assert.False(t, saved.Revoked, "expected migrated PAT to be active")
+	assert.True(t, saved.Scope.Check(auth.PlatformMesagingScope, "", auth.DomainNullScope, auth.PublishOp, "channel"), "expected migrated PAT messaging scope")
+}
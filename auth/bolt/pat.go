@@ -30,6 +30,7 @@ const (
 	lastUsedAtKey       = "last_used_at"
 	revokedKey          = "revoked"
 	revokedAtKey        = "revoked_at"
+	notifiedKey         = "notified"
 	platformEntitiesKey = "platform_entities"
 	patKey              = "pat"
 
@@ -155,6 +156,10 @@ func (pr *patRepo) UpdateTokenHash(ctx context.Context, userID, patID, tokenHash
 		if err := b.Put([]byte(patID+keySeparator+updatedAtKey), timeToBytes(time.Now())); err != nil {
 			return errors.Wrap(repoerr.ErrUpdateEntity, err)
 		}
+		// The PAT with the new expiry is notified again before it expires.
+		if err := b.Delete([]byte(patID + keySeparator + notifiedKey)); err != nil {
+			return errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
 		c := b.Cursor()
 		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
 			kv[string(k)] = v
@@ -378,6 +383,107 @@ func (pr *patRepo) RemoveAllScopeEntry(ctx context.Context, userID, patID string
 	return nil
 }
 
+func (pr *patRepo) RetrieveExpiring(ctx context.Context, from, to time.Time) ([]auth.PAT, error) {
+	var ids []patIndex
+	if err := pr.db.Update(func(tx *bolt.Tx) error {
+		buckets := []*bolt.Bucket{}
+		if err := pr.forEachPAT(tx, func(idx patIndex, b *bolt.Bucket) {
+			if bytesToBoolean(b.Get([]byte(idx.patID + keySeparator + revokedKey))) {
+				return
+			}
+			if b.Get([]byte(idx.patID+keySeparator+notifiedKey)) != nil {
+				return
+			}
+			expiresAt := bytesToTime(b.Get([]byte(idx.patID + keySeparator + expiresAtKey)))
+			if expiresAt.After(from) && !expiresAt.After(to) {
+				ids = append(ids, idx)
+				buckets = append(buckets, b)
+			}
+		}); err != nil {
+			return err
+		}
+		// The PATs are marked after the iteration, since the buckets must
+		// not be modified while they are iterated over.
+		for i, idx := range ids {
+			if err := buckets[i].Put([]byte(idx.patID+keySeparator+notifiedKey), booleanToBytes(true)); err != nil {
+				return errors.Wrap(repoerr.ErrUpdateEntity, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	pats := []auth.PAT{}
+	for _, idx := range ids {
+		pat, err := pr.Retrieve(ctx, idx.userID, idx.patID)
+		if err != nil {
+			return nil, err
+		}
+		pats = append(pats, pat)
+	}
+
+	return pats, nil
+}
+
+func (pr *patRepo) RemoveExpired(ctx context.Context, before time.Time) (uint64, error) {
+	var ids []patIndex
+	if err := pr.db.View(func(tx *bolt.Tx) error {
+		return pr.forEachPAT(tx, func(idx patIndex, b *bolt.Bucket) {
+			expiresAt := bytesToTime(b.Get([]byte(idx.patID + keySeparator + expiresAtKey)))
+			revoked := bytesToBoolean(b.Get([]byte(idx.patID + keySeparator + revokedKey)))
+			revokedAt := bytesToTime(b.Get([]byte(idx.patID + keySeparator + revokedAtKey)))
+			if expiresAt.Before(before) || (revoked && revokedAt.Before(before)) {
+				ids = append(ids, idx)
+			}
+		})
+	}); err != nil {
+		return 0, err
+	}
+
+	var removed uint64
+	for _, idx := range ids {
+		if err := pr.Remove(ctx, idx.userID, idx.patID); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// patIndex identifies the PAT in the root bucket index.
+type patIndex struct {
+	userID string
+	patID  string
+}
+
+// forEachPAT calls fn for each PAT in the root bucket index with the bucket
+// of the PAT owner.
+func (pr *patRepo) forEachPAT(tx *bolt.Tx, fn func(idx patIndex, b *bolt.Bucket)) error {
+	rb, err := pr.retrieveRootBucket(tx)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	sep := []byte(keySeparator + patKey + keySeparator)
+	return rb.ForEach(func(k, v []byte) error {
+		// Nested user buckets have nil values.
+		if v == nil {
+			return nil
+		}
+		userID, _, ok := bytes.Cut(k, sep)
+		if !ok {
+			return nil
+		}
+		b := rb.Bucket(userID)
+		if b == nil {
+			return nil
+		}
+		fn(patIndex{userID: string(userID), patID: string(v)}, b)
+		return nil
+	})
+}
+
 func (pr *patRepo) updatePATField(_ context.Context, userID, patID, key string, value []byte) (auth.PAT, error) {
 	prefix := []byte(patID + keySeparator)
 	kv := map[string][]byte{}
@@ -793,6 +899,10 @@ func timeToBytes(t time.Time) []byte {
 }
 
 func bytesToTime(b []byte) time.Time {
+	// Reactivated PATs have empty revoked at value.
+	if len(b) < 8 {
+		return time.Time{}
+	}
 	timeAtSeconds := binary.BigEndian.Uint64(b)
 	return time.Unix(int64(timeAtSeconds), 0)
 }
@@ -0,0 +1,63 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package bolt_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/bolt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bbolt "go.etcd.io/bbolt"
+)
+
+func TestPATRetrieveExpiring(t *testing.T) {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "pat.db"), 0o600, nil)
+	require.Nil(t, err, fmt.Sprintf("opening bolt db expected to succeed: %s", err))
+	defer db.Close()
+	err = db.Update(func(tx *bbolt.Tx) error {
+		return bolt.Init(tx, bucket)
+	})
+	require.Nil(t, err, fmt.Sprintf("initializing bolt db expected to succeed: %s", err))
+
+	repo := bolt.NewPATSRepository(db, bucket)
+	now := time.Now().Truncate(time.Second)
+	expiring := auth.PAT{
+		ID:        "a7a2f4e0-5d1b-4d5e-8c64-1f3e4f2c0a01",
+		User:      "b2f4a6c8-1d3e-4f5a-9b7c-2e4d6f8a0b02",
+		Name:      "expiring",
+		Secret:    "hash",
+		IssuedAt:  now,
+		ExpiresAt: now.Add(time.Hour),
+	}
+	valid := expiring
+	valid.ID = "c3e5a7b9-2f4d-4a6b-8c0e-3f5a7b9c1d03"
+	valid.Name = "valid"
+	valid.ExpiresAt = now.Add(24 * time.Hour)
+	for _, pat := range []auth.PAT{expiring, valid} {
+		err := repo.Save(context.Background(), pat)
+		require.Nil(t, err, fmt.Sprintf("saving PAT expected to succeed: %s", err))
+	}
+	from, to := now, now.Add(2*time.Hour)
+
+	pats, err := repo.RetrieveExpiring(context.Background(), from, to)
+	assert.Nil(t, err, fmt.Sprintf("retrieving expiring PATs expected to succeed: %s", err))
+	require.Len(t, pats, 1, "expected expiring PAT to be retrieved")
+	assert.Equal(t, expiring.ID, pats[0].ID, fmt.Sprintf("expected PAT %s got %s", expiring.ID, pats[0].ID))
+
+	pats, err = repo.RetrieveExpiring(context.Background(), from, to)
+	assert.Nil(t, err, fmt.Sprintf("retrieving expiring PATs expected to succeed: %s", err))
+	assert.Empty(t, pats, "expected notified PAT not to be retrieved again")
+
+	_, err = repo.UpdateTokenHash(context.Background(), expiring.User, expiring.ID, "new-hash", now.Add(90*time.Minute))
+	require.Nil(t, err, fmt.Sprintf("updating PAT token hash expected to succeed: %s", err))
+	pats, err = repo.RetrieveExpiring(context.Background(), from, to)
+	assert.Nil(t, err, fmt.Sprintf("retrieving expiring PATs expected to succeed: %s", err))
+	assert.Len(t, pats, 1, "expected PAT with updated expiry to be retrieved again")
+}
@@ -0,0 +1,14 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import "context"
+
+// Emailer sends the PAT notifications to the PAT owners.
+//
+//go:generate mockery --name Emailer --output=./mocks --filename emailer.go --quiet --note "Copyright (c) Abstract Machines"
+type Emailer interface {
+	// SendPATExpiryNotification notifies the PAT owner that the PAT expires soon.
+	SendPATExpiryNotification(ctx context.Context, pat PAT) error
+}
@@ -0,0 +1,47 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+)
+
+const streamID = "supermq.auth"
+
+var _ auth.Emailer = (*emailer)(nil)
+
+type emailer struct {
+	events.Publisher
+	emailer auth.Emailer
+}
+
+// NewEmailer returns wrapper around PAT emailer that sends PAT expiring
+// events to event store. The PAT notifications are sent by the PAT handler
+// and not by the service, so the events are not sent by the service
+// middleware. If the wrapped emailer is nil, only the events are sent.
+func NewEmailer(ctx context.Context, e auth.Emailer, url string) (auth.Emailer, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailer{
+		emailer:   e,
+		Publisher: publisher,
+	}, nil
+}
+
+func (e *emailer) SendPATExpiryNotification(ctx context.Context, pat auth.PAT) error {
+	if e.emailer != nil {
+		if err := e.emailer.SendPATExpiryNotification(ctx, pat); err != nil {
+			return err
+		}
+	}
+
+	return e.Publish(ctx, patExpiringEvent{pat})
+}
@@ -0,0 +1,32 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/events"
+)
+
+const (
+	patPrefix   = "pat."
+	patExpiring = patPrefix + "expiring"
+)
+
+var _ events.Event = (*patExpiringEvent)(nil)
+
+type patExpiringEvent struct {
+	auth.PAT
+}
+
+func (pee patExpiringEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  patExpiring,
+		"id":         pee.ID,
+		"user_id":    pee.User,
+		"name":       pee.Name,
+		"expires_at": pee.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"encoding/json"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+type asymmetricTokenizer struct {
+	keys *KeyManager
+}
+
+var _ auth.Tokenizer = (*asymmetricTokenizer)(nil)
+
+// NewAsymmetric instantiates a Tokenizer that signs tokens with the active
+// key of the key manager. The key ID is set in the token header, so the
+// token can be verified with any of the keys published in the JWKS.
+func NewAsymmetric(keys *KeyManager) auth.Tokenizer {
+	return &asymmetricTokenizer{
+		keys: keys,
+	}
+}
+
+func (tok *asymmetricTokenizer) Issue(key auth.Key) (string, error) {
+	tkn, err := buildToken(key)
+	if err != nil {
+		return "", err
+	}
+	signingKey := tok.keys.active()
+	signedTkn, err := jwt.Sign(tkn, jwt.WithKey(signingKey.alg, signingKey.private))
+	if err != nil {
+		return "", errors.Wrap(ErrSignJWT, err)
+	}
+	return string(signedTkn), nil
+}
+
+func (tok *asymmetricTokenizer) Parse(token string) (auth.Key, error) {
+	return parse(token, jwt.WithKeySet(tok.keys.publicSet()))
+}
+
+func (tok *asymmetricTokenizer) RetrieveJWKS() ([]auth.PublicKeyInfo, error) {
+	keys := tok.keys.publicKeys()
+	infos := make([]auth.PublicKeyInfo, len(keys))
+	for i, key := range keys {
+		data, err := json.Marshal(key)
+		if err != nil {
+			return nil, errors.Wrap(ErrJSONHandle, err)
+		}
+		if err := json.Unmarshal(data, &infos[i]); err != nil {
+			return nil, errors.Wrap(ErrJSONHandle, err)
+		}
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,277 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	authjwt "github.com/absmach/supermq/auth/jwt"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyManager(t *testing.T) {
+	cases := []struct {
+		desc string
+		cfg  authjwt.KeyManagerConfig
+		err  error
+	}{
+		{
+			desc: "create key manager with RS256",
+			cfg:  authjwt.KeyManagerConfig{Algorithm: authjwt.RS256},
+			err:  nil,
+		},
+		{
+			desc: "create key manager with EdDSA",
+			cfg:  authjwt.KeyManagerConfig{Algorithm: authjwt.EdDSA},
+			err:  nil,
+		},
+		{
+			desc: "create key manager with keys directory",
+			cfg:  authjwt.KeyManagerConfig{Algorithm: authjwt.EdDSA, KeysDir: t.TempDir()},
+			err:  nil,
+		},
+		{
+			desc: "create key manager with symmetric algorithm",
+			cfg:  authjwt.KeyManagerConfig{Algorithm: authjwt.HS512},
+			err:  authjwt.ErrUnsupportedAlgorithm,
+		},
+		{
+			desc: "create key manager with invalid key file",
+			cfg:  authjwt.KeyManagerConfig{Algorithm: authjwt.EdDSA, KeysDir: invalidKeysDir(t)},
+			err:  authjwt.ErrLoadKey,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := authjwt.NewKeyManager(tc.cfg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestAsymmetricIssueParse(t *testing.T) {
+	for _, alg := range []string{authjwt.RS256, authjwt.EdDSA} {
+		t.Run(alg, func(t *testing.T) {
+			km, err := authjwt.NewKeyManager(authjwt.KeyManagerConfig{Algorithm: alg})
+			require.Nil(t, err, fmt.Sprintf("creating key manager expected to succeed: %s", err))
+			tokenizer := authjwt.NewAsymmetric(km)
+
+			token, err := tokenizer.Issue(key())
+			require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+			jwks, err := tokenizer.RetrieveJWKS()
+			require.Nil(t, err, fmt.Sprintf("retrieving JWKS expected to succeed: %s", err))
+			require.Len(t, jwks, 1)
+			assert.Equal(t, alg, jwks[0].Algorithm)
+			assert.Equal(t, "sig", jwks[0].Use)
+
+			msg, err := jws.Parse([]byte(token))
+			require.Nil(t, err, fmt.Sprintf("parsing JWS expected to succeed: %s", err))
+			headers := msg.Signatures()[0].ProtectedHeaders()
+			assert.Equal(t, jwks[0].KeyID, headers.KeyID())
+			assert.Equal(t, alg, headers.Algorithm().String())
+
+			expKey := key()
+			expKey.ExpiresAt = time.Now().UTC().Add(-1 * time.Minute).Round(time.Second)
+			expToken, err := tokenizer.Issue(expKey)
+			require.Nil(t, err, fmt.Sprintf("issuing expired key expected to succeed: %s", err))
+
+			cases := []struct {
+				desc  string
+				key   auth.Key
+				token string
+				err   error
+			}{
+				{
+					desc:  "parse valid key",
+					key:   key(),
+					token: token,
+					err:   nil,
+				},
+				{
+					desc:  "parse expired key",
+					token: expToken,
+					err:   auth.ErrExpiry,
+				},
+				{
+					desc:  "parse key signed with shared secret",
+					token: newToken(issuerName, key()),
+					err:   errors.ErrAuthentication,
+				},
+				{
+					desc:  "parse invalid key",
+					token: "invalid",
+					err:   errors.ErrAuthentication,
+				},
+			}
+
+			for _, tc := range cases {
+				key, err := tokenizer.Parse(tc.token)
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+				if err == nil {
+					assert.Equal(t, tc.key, key, fmt.Sprintf("%s expected %v, got %v", tc.desc, tc.key, key))
+				}
+			}
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	cases := []struct {
+		desc        string
+		gracePeriod time.Duration
+		keys        int
+		err         error
+	}{
+		{
+			desc:        "parse token signed with rotated key within grace period",
+			gracePeriod: time.Hour,
+			keys:        2,
+			err:         nil,
+		},
+		{
+			desc:        "parse token signed with rotated key after grace period",
+			gracePeriod: 0,
+			keys:        1,
+			err:         errors.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			km, err := authjwt.NewKeyManager(authjwt.KeyManagerConfig{Algorithm: authjwt.EdDSA, GracePeriod: tc.gracePeriod})
+			require.Nil(t, err, fmt.Sprintf("creating key manager expected to succeed: %s", err))
+			tokenizer := authjwt.NewAsymmetric(km)
+
+			oldToken, err := tokenizer.Issue(key())
+			require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+			err = km.Rotate()
+			require.Nil(t, err, fmt.Sprintf("rotating keys expected to succeed: %s", err))
+
+			newToken, err := tokenizer.Issue(key())
+			require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+			_, err = tokenizer.Parse(newToken)
+			assert.Nil(t, err, fmt.Sprintf("parsing token signed with active key expected to succeed: %s", err))
+
+			_, err = tokenizer.Parse(oldToken)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s, got %s", tc.desc, tc.err, err))
+
+			jwks, err := tokenizer.RetrieveJWKS()
+			require.Nil(t, err, fmt.Sprintf("retrieving JWKS expected to succeed: %s", err))
+			assert.Len(t, jwks, tc.keys)
+		})
+	}
+}
+
+func TestKeysDir(t *testing.T) {
+	dir := t.TempDir()
+	cfg := authjwt.KeyManagerConfig{Algorithm: authjwt.RS256, KeysDir: dir}
+
+	km, err := authjwt.NewKeyManager(cfg)
+	require.Nil(t, err, fmt.Sprintf("creating key manager expected to succeed: %s", err))
+	token, err := authjwt.NewAsymmetric(km).Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+
+	files, err := os.ReadDir(dir)
+	require.Nil(t, err, fmt.Sprintf("reading keys directory expected to succeed: %s", err))
+	assert.Len(t, files, 1)
+
+	reloaded, err := authjwt.NewKeyManager(cfg)
+	require.Nil(t, err, fmt.Sprintf("creating key manager expected to succeed: %s", err))
+	tokenizer := authjwt.NewAsymmetric(reloaded)
+	parsed, err := tokenizer.Parse(token)
+	assert.Nil(t, err, fmt.Sprintf("parsing token with reloaded key expected to succeed: %s", err))
+	assert.Equal(t, key(), parsed)
+
+	err = reloaded.Rotate()
+	require.Nil(t, err, fmt.Sprintf("rotating keys expected to succeed: %s", err))
+	files, err = os.ReadDir(dir)
+	require.Nil(t, err, fmt.Sprintf("reading keys directory expected to succeed: %s", err))
+	assert.Len(t, files, 1, "expected rotated key file to be removed after zero grace period")
+}
+
+func TestKeysDirCreationTime(t *testing.T) {
+	dir := t.TempDir()
+	cfg := authjwt.KeyManagerConfig{Algorithm: authjwt.EdDSA, KeysDir: dir, GracePeriod: time.Hour}
+
+	km, err := authjwt.NewKeyManager(cfg)
+	require.Nil(t, err, fmt.Sprintf("creating key manager expected to succeed: %s", err))
+	err = km.Rotate()
+	require.Nil(t, err, fmt.Sprintf("rotating keys expected to succeed: %s", err))
+	active := keyID(t, km)
+
+	// Copying or restoring the key files changes their modification times,
+	// which must not change the active key.
+	files, err := os.ReadDir(dir)
+	require.Nil(t, err, fmt.Sprintf("reading keys directory expected to succeed: %s", err))
+	require.Len(t, files, 2)
+	modTime := time.Now()
+	for _, f := range files {
+		modTime = modTime.Add(-time.Hour)
+		err := os.Chtimes(filepath.Join(dir, f.Name()), modTime, modTime)
+		require.Nil(t, err, fmt.Sprintf("changing key file time expected to succeed: %s", err))
+	}
+
+	reloaded, err := authjwt.NewKeyManager(cfg)
+	require.Nil(t, err, fmt.Sprintf("creating key manager expected to succeed: %s", err))
+	assert.Equal(t, active, keyID(t, reloaded), "expected reloaded key manager to keep the active key")
+}
+
+func TestKeysDirLock(t *testing.T) {
+	dir := t.TempDir()
+	cfg := authjwt.KeyManagerConfig{Algorithm: authjwt.EdDSA, KeysDir: dir}
+
+	km, err := authjwt.NewKeyManager(cfg)
+	require.Nil(t, err, fmt.Sprintf("creating key manager expected to succeed: %s", err))
+
+	lock := filepath.Join(dir, ".rotation.lock")
+	err = os.WriteFile(lock, nil, 0o600)
+	require.Nil(t, err, fmt.Sprintf("writing lock file expected to succeed: %s", err))
+
+	err = km.Rotate()
+	assert.True(t, errors.Contains(err, authjwt.ErrGenerateKey), fmt.Sprintf("rotating keys of locked directory expected %s, got %s", authjwt.ErrGenerateKey, err))
+
+	stale := time.Now().Add(-2 * time.Minute)
+	err = os.Chtimes(lock, stale, stale)
+	require.Nil(t, err, fmt.Sprintf("changing lock file time expected to succeed: %s", err))
+
+	err = km.Rotate()
+	assert.Nil(t, err, fmt.Sprintf("rotating keys of directory with stale lock expected to succeed: %s", err))
+	_, err = os.Stat(lock)
+	assert.True(t, os.IsNotExist(err), "expected lock file to be removed after rotation")
+
+	shared, err := authjwt.NewKeyManager(cfg)
+	require.Nil(t, err, fmt.Sprintf("creating key manager expected to succeed: %s", err))
+	token, err := authjwt.NewAsymmetric(km).Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+	_, err = authjwt.NewAsymmetric(shared).Parse(token)
+	assert.Nil(t, err, fmt.Sprintf("parsing token with shared keys expected to succeed: %s", err))
+}
+
+func invalidKeysDir(t *testing.T) string {
+	dir := t.TempDir()
+	err := os.WriteFile(dir+"/invalid.pem", []byte("invalid"), 0o600)
+	require.Nil(t, err, fmt.Sprintf("writing key file expected to succeed: %s", err))
+
+	return dir
+}
+
+func keyID(t *testing.T, km *authjwt.KeyManager) string {
+	token, err := authjwt.NewAsymmetric(km).Issue(key())
+	require.Nil(t, err, fmt.Sprintf("issuing key expected to succeed: %s", err))
+	msg, err := jws.Parse([]byte(token))
+	require.Nil(t, err, fmt.Sprintf("parsing JWS expected to succeed: %s", err))
+
+	return msg.Signatures()[0].ProtectedHeaders().KeyID()
+}
@@ -0,0 +1,470 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// Supported token signing algorithms.
+const (
+	HS512 = "HS512"
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+const (
+	rsaKeySize       = 2048
+	keyFileExt       = ".pem"
+	keyFileSep       = "-"
+	keyFileMode      = 0o600
+	keysDirMode      = 0o700
+	pemBlockType     = "PRIVATE KEY"
+	keyUsage         = "sig"
+	maxCheckInterval = time.Minute
+	lockFile         = ".rotation.lock"
+	lockTimeout      = time.Minute
+	lockRetryDelay   = 100 * time.Millisecond
+)
+
+var (
+	// ErrUnsupportedAlgorithm indicates that the signing algorithm is not supported.
+	ErrUnsupportedAlgorithm = errors.New("unsupported token signing algorithm")
+	// ErrLoadKey indicates a failure to load a signing key.
+	ErrLoadKey = errors.New("failed to load signing key")
+	// ErrGenerateKey indicates a failure to generate a signing key.
+	ErrGenerateKey = errors.New("failed to generate signing key")
+
+	errInvalidKeyFile = errors.New("invalid PEM encoded private key")
+	errLocked         = errors.New("keys directory is locked by another instance")
+)
+
+// KeyManagerConfig contains the asymmetric signing keys configuration.
+type KeyManagerConfig struct {
+	// Algorithm is used to generate new keys. Keys loaded from KeysDir
+	// keep the algorithm of their key type.
+	Algorithm string
+	// KeysDir is a directory with PEM encoded PKCS #8 private keys. Generated
+	// keys are stored in it, named by their creation time and key ID. Keys
+	// without the creation time in the file name are the oldest ones. If
+	// empty, keys are kept in memory only, so they are lost on restart and
+	// each instance uses its own keys. Instances sharing the directory rotate
+	// the keys one at a time using a lock file.
+	KeysDir string
+	// RotationInterval is the lifetime of the active signing key. Zero
+	// disables automatic rotation.
+	RotationInterval time.Duration
+	// GracePeriod is the time a key is still used for verification after
+	// it has been replaced by a new key. It should not be shorter than
+	// the longest token lifetime.
+	GracePeriod time.Duration
+}
+
+type signingKey struct {
+	private   jwk.Key
+	public    jwk.Key
+	alg       jwa.SignatureAlgorithm
+	file      string
+	createdAt time.Time
+}
+
+// KeyManager keeps the asymmetric token signing keys. The newest key is
+// used to sign tokens and all the keys within the grace period are used
+// to verify them.
+type KeyManager struct {
+	mu   sync.RWMutex
+	cfg  KeyManagerConfig
+	keys []signingKey
+}
+
+// NewKeyManager loads the keys from the configured directory and generates
+// a new key if there are none.
+func NewKeyManager(cfg KeyManagerConfig) (*KeyManager, error) {
+	if _, err := signatureAlgorithm(cfg.Algorithm); err != nil {
+		return nil, err
+	}
+	km := &KeyManager{cfg: cfg}
+	if err := km.load(); err != nil {
+		return nil, err
+	}
+	// If another instance sharing the keys directory is generating the
+	// first key, its key is loaded once the directory is unlocked.
+	for len(km.keys) == 0 {
+		if err := km.check(time.Now()); err != nil {
+			return nil, err
+		}
+		if len(km.keys) == 0 {
+			time.Sleep(lockRetryDelay)
+		}
+	}
+
+	return km, nil
+}
+
+// Rotate generates a new active signing key and removes the keys whose
+// grace period has passed.
+func (km *KeyManager) Rotate() error {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	if km.cfg.KeysDir == "" {
+		return km.rotate(time.Now())
+	}
+	unlock, err := lockDir(km.cfg.KeysDir)
+	if err != nil {
+		return errors.Wrap(ErrGenerateKey, err)
+	}
+	defer unlock()
+
+	return km.rotate(time.Now())
+}
+
+// StartRotation rotates the keys every RotationInterval until the context
+// is canceled. If KeysDir is set, keys added to or removed from it by other
+// instances are picked up as well.
+func (km *KeyManager) StartRotation(ctx context.Context, logger *slog.Logger) {
+	if km.cfg.RotationInterval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(min(km.cfg.RotationInterval, maxCheckInterval))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := km.check(time.Now()); err != nil {
+					logger.Error("failed to rotate token signing keys", slog.Any("error", err))
+				}
+			}
+		}
+	}()
+}
+
+func (km *KeyManager) check(now time.Time) error {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	if km.cfg.KeysDir != "" {
+		unlock, err := lockDir(km.cfg.KeysDir)
+		switch {
+		case errors.Contains(err, errLocked):
+			// Another instance is rotating the keys, so its key is loaded
+			// instead of generating one more.
+			return km.loadDir()
+		case err != nil:
+			return errors.Wrap(ErrGenerateKey, err)
+		}
+		defer unlock()
+		// The keys are reloaded under the lock, so the key generated by
+		// another instance since the last check is not replaced.
+		if err := km.loadDir(); err != nil {
+			return err
+		}
+	}
+	if km.due(now) {
+		return km.rotate(now)
+	}
+	km.prune(now)
+
+	return nil
+}
+
+// due reports whether there is no active key or the active key is older
+// than the rotation interval.
+func (km *KeyManager) due(now time.Time) bool {
+	if len(km.keys) == 0 {
+		return true
+	}
+
+	return km.cfg.RotationInterval > 0 && now.Sub(km.keys[len(km.keys)-1].createdAt) >= km.cfg.RotationInterval
+}
+
+func (km *KeyManager) rotate(now time.Time) error {
+	raw, err := generateKey(km.cfg.Algorithm)
+	if err != nil {
+		return errors.Wrap(ErrGenerateKey, err)
+	}
+	key, err := newSigningKey(raw, now)
+	if err != nil {
+		return errors.Wrap(ErrGenerateKey, err)
+	}
+	if km.cfg.KeysDir != "" {
+		if key.file, err = saveKey(km.cfg.KeysDir, key, raw); err != nil {
+			return errors.Wrap(ErrGenerateKey, err)
+		}
+	}
+	km.keys = append(km.keys, key)
+	km.prune(now)
+
+	return nil
+}
+
+// prune removes the keys that were replaced more than GracePeriod ago.
+func (km *KeyManager) prune(now time.Time) {
+	keys := km.keys[:0]
+	for i, key := range km.keys {
+		if i == len(km.keys)-1 || now.Before(km.keys[i+1].createdAt.Add(km.cfg.GracePeriod)) {
+			keys = append(keys, key)
+			continue
+		}
+		if key.file != "" {
+			_ = os.Remove(key.file)
+		}
+	}
+	km.keys = keys
+}
+
+func (km *KeyManager) load() error {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	if km.cfg.KeysDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(km.cfg.KeysDir, keysDirMode); err != nil {
+		return errors.Wrap(ErrLoadKey, err)
+	}
+	if err := km.loadDir(); err != nil {
+		return err
+	}
+	km.prune(time.Now())
+
+	return nil
+}
+
+func (km *KeyManager) loadDir() error {
+	entries, err := os.ReadDir(km.cfg.KeysDir)
+	if err != nil {
+		return errors.Wrap(ErrLoadKey, err)
+	}
+
+	var keys []signingKey
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), keyFileExt) {
+			continue
+		}
+		file := filepath.Join(km.cfg.KeysDir, entry.Name())
+		key, err := loadKey(file)
+		if err != nil {
+			return errors.Wrap(ErrLoadKey, fmt.Errorf("%s: %w", entry.Name(), err))
+		}
+		keys = append(keys, key)
+	}
+	// Keys created at the same time are ordered by ID, so all the instances
+	// use the same active key.
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].createdAt.Equal(keys[j].createdAt) {
+			return keys[i].public.KeyID() < keys[j].public.KeyID()
+		}
+		return keys[i].createdAt.Before(keys[j].createdAt)
+	})
+	km.keys = keys
+
+	return nil
+}
+
+func (km *KeyManager) active() signingKey {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+
+	return km.keys[len(km.keys)-1]
+}
+
+func (km *KeyManager) publicKeys() []jwk.Key {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+
+	keys := make([]jwk.Key, len(km.keys))
+	for i, key := range km.keys {
+		keys[i] = key.public
+	}
+
+	return keys
+}
+
+func (km *KeyManager) publicSet() jwk.Set {
+	set := jwk.NewSet()
+	for _, key := range km.publicKeys() {
+		_ = set.AddKey(key)
+	}
+
+	return set
+}
+
+func signatureAlgorithm(alg string) (jwa.SignatureAlgorithm, error) {
+	switch alg {
+	case RS256:
+		return jwa.RS256, nil
+	case EdDSA:
+		return jwa.EdDSA, nil
+	default:
+		return "", errors.Wrap(ErrUnsupportedAlgorithm, fmt.Errorf("%q", alg))
+	}
+}
+
+func generateKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case RS256:
+		return rsa.GenerateKey(rand.Reader, rsaKeySize)
+	case EdDSA:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		return priv, err
+	default:
+		return nil, ErrUnsupportedAlgorithm
+	}
+}
+
+func newSigningKey(raw crypto.Signer, createdAt time.Time) (signingKey, error) {
+	var alg jwa.SignatureAlgorithm
+	switch raw.(type) {
+	case *rsa.PrivateKey:
+		alg = jwa.RS256
+	case ed25519.PrivateKey:
+		alg = jwa.EdDSA
+	default:
+		return signingKey{}, ErrUnsupportedAlgorithm
+	}
+
+	private, err := jwk.FromRaw(raw)
+	if err != nil {
+		return signingKey{}, err
+	}
+	public, err := jwk.PublicKeyOf(private)
+	if err != nil {
+		return signingKey{}, err
+	}
+	// The key ID is the RFC 7638 thumbprint, so instances sharing the
+	// keys directory use the same ID for the same key.
+	thumbprint, err := public.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return signingKey{}, err
+	}
+	kid := base64.RawURLEncoding.EncodeToString(thumbprint)
+	for _, k := range []jwk.Key{private, public} {
+		if err := k.Set(jwk.KeyIDKey, kid); err != nil {
+			return signingKey{}, err
+		}
+		if err := k.Set(jwk.AlgorithmKey, alg); err != nil {
+			return signingKey{}, err
+		}
+		if err := k.Set(jwk.KeyUsageKey, keyUsage); err != nil {
+			return signingKey{}, err
+		}
+	}
+
+	return signingKey{
+		private:   private,
+		public:    public,
+		alg:       alg,
+		createdAt: createdAt,
+	}, nil
+}
+
+func loadKey(file string) (signingKey, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return signingKey{}, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemBlockType {
+		return signingKey{}, errInvalidKeyFile
+	}
+	raw, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return signingKey{}, err
+	}
+	signer, ok := raw.(crypto.Signer)
+	if !ok {
+		return signingKey{}, ErrUnsupportedAlgorithm
+	}
+	// The file modification time changes when the file is copied or
+	// restored, so the creation time is kept in the file name.
+	key, err := newSigningKey(signer, keyCreatedAt(filepath.Base(file)))
+	if err != nil {
+		return signingKey{}, err
+	}
+	key.file = file
+
+	return key, nil
+}
+
+func saveKey(dir string, key signingKey, raw crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(raw)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%d%s%s%s", key.createdAt.UnixNano(), keyFileSep, key.public.KeyID(), keyFileExt)
+	file := filepath.Join(dir, name)
+	data := pem.EncodeToMemory(&pem.Block{Type: pemBlockType, Bytes: der})
+	if err := os.WriteFile(file, data, keyFileMode); err != nil {
+		return "", err
+	}
+
+	return file, nil
+}
+
+// keyCreatedAt returns the creation time from the key file name, which is
+// the Unix time in nanoseconds followed by the key ID. Keys named otherwise,
+// such as the keys provided by the operator, are the oldest ones.
+func keyCreatedAt(name string) time.Time {
+	prefix, _, ok := strings.Cut(name, keyFileSep)
+	if !ok {
+		return time.Time{}
+	}
+	nanos, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.Unix(0, nanos)
+}
+
+// lockDir creates the lock file in the keys directory and returns the function
+// which removes it. The lock left by an instance which stopped while rotating
+// the keys is removed after the lock timeout.
+func lockDir(dir string) (func(), error) {
+	file := filepath.Join(dir, lockFile)
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, keyFileMode)
+	if os.IsExist(err) {
+		if info, serr := os.Stat(file); serr == nil && time.Since(info.ModTime()) > lockTimeout {
+			_ = os.Remove(file)
+			f, err = os.OpenFile(file, os.O_CREATE|os.O_EXCL|os.O_WRONLY, keyFileMode)
+		}
+	}
+	switch {
+	case os.IsExist(err):
+		return nil, errLocked
+	case err != nil:
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	return func() { _ = os.Remove(file) }, nil
+}
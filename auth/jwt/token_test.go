@@ -108,7 +108,7 @@ func TestIssue(t *testing.T) {
 			desc: "issue token without type",
 			key: auth.Key{
 				ID:       testsutil.GenerateUUID(t),
-				Type:     auth.KeyType(auth.InvitationKey + 1),
+				Type:     auth.KeyType(auth.VerificationKey + 1),
 				Subject:  testsutil.GenerateUUID(t),
 				User:     testsutil.GenerateUUID(t),
 				Domain:   testsutil.GenerateUUID(t),
@@ -169,7 +169,7 @@ func TestParse(t *testing.T) {
 	require.Nil(t, err, fmt.Sprintf("issuing user key expected to succeed: %s", err))
 
 	emptyTypeKey := key()
-	emptyTypeKey.Type = auth.KeyType(auth.InvitationKey + 1)
+	emptyTypeKey.Type = auth.KeyType(auth.VerificationKey + 1)
 	emptyTypeToken, err := tokenizer.Issue(emptyTypeKey)
 	require.Nil(t, err, fmt.Sprintf("issuing user key expected to succeed: %s", err))
 
@@ -179,6 +179,20 @@ func TestParse(t *testing.T) {
 	emptyToken, err := tokenizer.Issue(emptyKey)
 	require.Nil(t, err, fmt.Sprintf("issuing user key expected to succeed: %s", err))
 
+	refreshKey := key()
+	refreshKey.Type = auth.RefreshKey
+	refreshKey.User = "66af4a67-3823-438a-abd7-efdb613eaef6"
+	refreshKey.Session = "8a8f8ad1-c3b4-4a5e-8e5e-6c2d3f4c5b6a"
+	refreshToken, err := tokenizer.Issue(refreshKey)
+	require.Nil(t, err, fmt.Sprintf("issuing refresh key expected to succeed: %s", err))
+
+	verificationKey := key()
+	verificationKey.Type = auth.VerificationKey
+	verificationKey.User = "66af4a67-3823-438a-abd7-efdb613eaef6"
+	verificationKey.Email = "user@example.com"
+	verificationToken, err := tokenizer.Issue(verificationKey)
+	require.Nil(t, err, fmt.Sprintf("issuing verification key expected to succeed: %s", err))
+
 	inValidToken := newToken("invalid", key())
 
 	cases := []struct {
@@ -193,6 +207,18 @@ func TestParse(t *testing.T) {
 			token: token,
 			err:   nil,
 		},
+		{
+			desc:  "parse refresh key with session",
+			key:   refreshKey,
+			token: refreshToken,
+			err:   nil,
+		},
+		{
+			desc:  "parse verification key with email",
+			key:   verificationKey,
+			token: verificationToken,
+			err:   nil,
+		},
 		{
 			desc:  "parse invalid key",
 			key:   auth.Key{},
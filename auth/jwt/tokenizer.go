@@ -35,6 +35,8 @@ const (
 	issuerName             = "supermq.auth"
 	tokenType              = "type"
 	userField              = "user"
+	sessionField           = "session"
+	emailField             = "email"
 	oauthProviderField     = "oauth_provider"
 	oauthAccessTokenField  = "access_token"
 	oauthRefreshTokenField = "refresh_token"
@@ -46,7 +48,8 @@ type tokenizer struct {
 
 var _ auth.Tokenizer = (*tokenizer)(nil)
 
-// NewRepository instantiates an implementation of Token repository.
+// New instantiates a Tokenizer that signs tokens with the HS512 algorithm
+// using the shared secret.
 func New(secret []byte) auth.Tokenizer {
 	return &tokenizer{
 		secret: secret,
@@ -54,6 +57,26 @@ func New(secret []byte) auth.Tokenizer {
 }
 
 func (tok *tokenizer) Issue(key auth.Key) (string, error) {
+	tkn, err := buildToken(key)
+	if err != nil {
+		return "", err
+	}
+	signedTkn, err := jwt.Sign(tkn, jwt.WithKey(jwa.HS512, tok.secret))
+	if err != nil {
+		return "", errors.Wrap(ErrSignJWT, err)
+	}
+	return string(signedTkn), nil
+}
+
+func (tok *tokenizer) Parse(token string) (auth.Key, error) {
+	return parse(token, jwt.WithKey(jwa.HS512, tok.secret))
+}
+
+func (tok *tokenizer) RetrieveJWKS() ([]auth.PublicKeyInfo, error) {
+	return []auth.PublicKeyInfo{}, nil
+}
+
+func buildToken(key auth.Key) (jwt.Token, error) {
 	builder := jwt.NewBuilder()
 	builder.
 		Issuer(issuerName).
@@ -67,19 +90,22 @@ func (tok *tokenizer) Issue(key auth.Key) (string, error) {
 	if key.ID != "" {
 		builder.JwtID(key.ID)
 	}
-	tkn, err := builder.Build()
-	if err != nil {
-		return "", errors.Wrap(svcerr.ErrAuthentication, err)
+	if key.Session != "" {
+		builder.Claim(sessionField, key.Session)
 	}
-	signedTkn, err := jwt.Sign(tkn, jwt.WithKey(jwa.HS512, tok.secret))
+	if key.Email != "" {
+		builder.Claim(emailField, key.Email)
+	}
+	tkn, err := builder.Build()
 	if err != nil {
-		return "", errors.Wrap(ErrSignJWT, err)
+		return nil, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
-	return string(signedTkn), nil
+
+	return tkn, nil
 }
 
-func (tok *tokenizer) Parse(token string) (auth.Key, error) {
-	tkn, err := tok.validateToken(token)
+func parse(token string, opts ...jwt.ParseOption) (auth.Key, error) {
+	tkn, err := validateToken(token, opts...)
 	if err != nil {
 		return auth.Key{}, errors.Wrap(svcerr.ErrAuthentication, err)
 	}
@@ -92,12 +118,9 @@ func (tok *tokenizer) Parse(token string) (auth.Key, error) {
 	return key, nil
 }
 
-func (tok *tokenizer) validateToken(token string) (jwt.Token, error) {
-	tkn, err := jwt.Parse(
-		[]byte(token),
-		jwt.WithValidate(true),
-		jwt.WithKey(jwa.HS512, tok.secret),
-	)
+func validateToken(token string, opts ...jwt.ParseOption) (jwt.Token, error) {
+	opts = append(opts, jwt.WithValidate(true))
+	tkn, err := jwt.Parse([]byte(token), opts...)
 	if err != nil {
 		if errors.Contains(err, errJWTExpiryKey) {
 			return nil, auth.ErrExpiry
@@ -34,10 +34,17 @@ const (
 	PersonalAccessToken
 	// InvitationKey is a key for inviting new users.
 	InvitationKey
+	// MFAKey is a short-lived key issued after successful password login of
+	// the user with multi-factor authentication, exchanged for an access key
+	// once the second factor is verified.
+	MFAKey
+	// VerificationKey is a key sent to the user's e-mail address to confirm
+	// the ownership of the address.
+	VerificationKey
 )
 
 func (kt KeyType) Validate() bool {
-	return AccessKey <= kt && kt <= InvitationKey
+	return AccessKey <= kt && kt <= VerificationKey
 }
 
 func (kt KeyType) String() string {
@@ -52,6 +59,10 @@ func (kt KeyType) String() string {
 		return "API"
 	case PersonalAccessToken:
 		return "pat"
+	case MFAKey:
+		return "mfa"
+	case VerificationKey:
+		return "verification"
 	default:
 		return "unknown"
 	}
@@ -65,6 +76,8 @@ type Key struct {
 	Subject   string    `json:"subject,omitempty"` // user ID
 	User      string    `json:"user,omitempty"`
 	Domain    string    `json:"domain,omitempty"` // domain user ID
+	Session   string    `json:"session,omitempty"`
+	Email     string    `json:"email,omitempty"` // e-mail address of the verification key
 	IssuedAt  time.Time `json:"issued_at,omitempty"`
 	ExpiresAt time.Time `json:"expires_at,omitempty"`
 }
@@ -77,9 +90,10 @@ func (key Key) String() string {
 	subject: %s,
 	user: %s,
 	domain: %s,
+	session: %s,
 	iat: %v,
 	eat: %v
-}`, key.ID, key.Type, key.Issuer, key.Subject, key.User, key.Domain, key.IssuedAt, key.ExpiresAt)
+}`, key.ID, key.Type, key.Issuer, key.Subject, key.User, key.Domain, key.Session, key.IssuedAt, key.ExpiresAt)
 }
 
 // Expired verifies if the key is expired.
@@ -133,6 +133,34 @@ func (_m *PATSRepository) RemoveAllScopeEntry(ctx context.Context, userID string
 	return r0
 }
 
+// RemoveExpired provides a mock function with given fields: ctx, before
+func (_m *PATSRepository) RemoveExpired(ctx context.Context, before time.Time) (uint64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpired")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (uint64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) uint64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveScopeEntry provides a mock function with given fields: ctx, userID, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs
 func (_m *PATSRepository) RemoveScopeEntry(ctx context.Context, userID string, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (auth.Scope, error) {
 	_va := make([]interface{}, len(entityIDs))
@@ -224,6 +252,36 @@ func (_m *PATSRepository) RetrieveAll(ctx context.Context, userID string, pm aut
 	return r0, r1
 }
 
+// RetrieveExpiring provides a mock function with given fields: ctx, from, to
+func (_m *PATSRepository) RetrieveExpiring(ctx context.Context, from time.Time, to time.Time) ([]auth.PAT, error) {
+	ret := _m.Called(ctx, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveExpiring")
+	}
+
+	var r0 []auth.PAT
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]auth.PAT, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []auth.PAT); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.PAT)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveSecretAndRevokeStatus provides a mock function with given fields: ctx, userID, patID
 func (_m *PATSRepository) RetrieveSecretAndRevokeStatus(ctx context.Context, userID string, patID string) (string, bool, bool, error) {
 	ret := _m.Called(ctx, userID, patID)
@@ -300,6 +300,36 @@ func (_m *Service) ListPATS(ctx context.Context, token string, pm auth.PATSPageM
 	return r0, r1
 }
 
+// ListSessions provides a mock function with given fields: ctx, userID
+func (_m *Service) ListSessions(ctx context.Context, userID string) ([]auth.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []auth.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]auth.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []auth.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemovePATScopeEntry provides a mock function with given fields: ctx, token, patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs
 func (_m *Service) RemovePATScopeEntry(ctx context.Context, token string, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (auth.Scope, error) {
 	_va := make([]interface{}, len(entityIDs))
@@ -363,6 +393,36 @@ func (_m *Service) ResetPATSecret(ctx context.Context, token string, patID strin
 	return r0, r1
 }
 
+// RetrieveJWKS provides a mock function with given fields: ctx
+func (_m *Service) RetrieveJWKS(ctx context.Context) ([]auth.PublicKeyInfo, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveJWKS")
+	}
+
+	var r0 []auth.PublicKeyInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]auth.PublicKeyInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []auth.PublicKeyInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.PublicKeyInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveKey provides a mock function with given fields: ctx, token, id
 func (_m *Service) RetrieveKey(ctx context.Context, token string, id string) (auth.Key, error) {
 	ret := _m.Called(ctx, token, id)
@@ -455,6 +515,42 @@ func (_m *Service) RevokePATSecret(ctx context.Context, token string, patID stri
 	return r0
 }
 
+// RevokeSession provides a mock function with given fields: ctx, userID, id
+func (_m *Service) RevokeSession(ctx context.Context, userID string, id string) error {
+	ret := _m.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSessions provides a mock function with given fields: ctx, userID
+func (_m *Service) RevokeSessions(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePATDescription provides a mock function with given fields: ctx, token, patID, description
 func (_m *Service) UpdatePATDescription(ctx context.Context, token string, patID string, description string) (auth.PAT, error) {
 	ret := _m.Called(ctx, token, patID, description)
@@ -511,6 +607,34 @@ func (_m *Service) UpdatePATName(ctx context.Context, token string, patID string
 	return r0, r1
 }
 
+// Verify provides a mock function with given fields: ctx, token, keyType
+func (_m *Service) Verify(ctx context.Context, token string, keyType auth.KeyType) (auth.Key, error) {
+	ret := _m.Called(ctx, token, keyType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 auth.Key
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.KeyType) (auth.Key, error)); ok {
+		return rf(ctx, token, keyType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, auth.KeyType) auth.Key); ok {
+		r0 = rf(ctx, token, keyType)
+	} else {
+		r0 = ret.Get(0).(auth.Key)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, auth.KeyType) error); ok {
+		r1 = rf(ctx, token, keyType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
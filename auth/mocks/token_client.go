@@ -103,6 +103,80 @@ func (_c *TokenServiceClient_Issue_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// ListSessions provides a mock function with given fields: ctx, in, opts
+func (_m *TokenServiceClient) ListSessions(ctx context.Context, in *v1.ListSessionsReq, opts ...grpc.CallOption) (*v1.ListSessionsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 *v1.ListSessionsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.ListSessionsReq, ...grpc.CallOption) (*v1.ListSessionsRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.ListSessionsReq, ...grpc.CallOption) *v1.ListSessionsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.ListSessionsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.ListSessionsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenServiceClient_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type TokenServiceClient_ListSessions_Call struct {
+	*mock.Call
+}
+
+// ListSessions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v1.ListSessionsReq
+//   - opts ...grpc.CallOption
+func (_e *TokenServiceClient_Expecter) ListSessions(ctx interface{}, in interface{}, opts ...interface{}) *TokenServiceClient_ListSessions_Call {
+	return &TokenServiceClient_ListSessions_Call{Call: _e.mock.On("ListSessions",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *TokenServiceClient_ListSessions_Call) Run(run func(ctx context.Context, in *v1.ListSessionsReq, opts ...grpc.CallOption)) *TokenServiceClient_ListSessions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v1.ListSessionsReq), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *TokenServiceClient_ListSessions_Call) Return(_a0 *v1.ListSessionsRes, _a1 error) *TokenServiceClient_ListSessions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenServiceClient_ListSessions_Call) RunAndReturn(run func(context.Context, *v1.ListSessionsReq, ...grpc.CallOption) (*v1.ListSessionsRes, error)) *TokenServiceClient_ListSessions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Refresh provides a mock function with given fields: ctx, in, opts
 func (_m *TokenServiceClient) Refresh(ctx context.Context, in *v1.RefreshReq, opts ...grpc.CallOption) (*v1.Token, error) {
 	_va := make([]interface{}, len(opts))
@@ -177,6 +251,154 @@ func (_c *TokenServiceClient_Refresh_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// RevokeSession provides a mock function with given fields: ctx, in, opts
+func (_m *TokenServiceClient) RevokeSession(ctx context.Context, in *v1.RevokeSessionReq, opts ...grpc.CallOption) (*v1.RevokeSessionRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 *v1.RevokeSessionRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.RevokeSessionReq, ...grpc.CallOption) (*v1.RevokeSessionRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.RevokeSessionReq, ...grpc.CallOption) *v1.RevokeSessionRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.RevokeSessionRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.RevokeSessionReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenServiceClient_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type TokenServiceClient_RevokeSession_Call struct {
+	*mock.Call
+}
+
+// RevokeSession is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v1.RevokeSessionReq
+//   - opts ...grpc.CallOption
+func (_e *TokenServiceClient_Expecter) RevokeSession(ctx interface{}, in interface{}, opts ...interface{}) *TokenServiceClient_RevokeSession_Call {
+	return &TokenServiceClient_RevokeSession_Call{Call: _e.mock.On("RevokeSession",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *TokenServiceClient_RevokeSession_Call) Run(run func(ctx context.Context, in *v1.RevokeSessionReq, opts ...grpc.CallOption)) *TokenServiceClient_RevokeSession_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v1.RevokeSessionReq), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *TokenServiceClient_RevokeSession_Call) Return(_a0 *v1.RevokeSessionRes, _a1 error) *TokenServiceClient_RevokeSession_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenServiceClient_RevokeSession_Call) RunAndReturn(run func(context.Context, *v1.RevokeSessionReq, ...grpc.CallOption) (*v1.RevokeSessionRes, error)) *TokenServiceClient_RevokeSession_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: ctx, in, opts
+func (_m *TokenServiceClient) Verify(ctx context.Context, in *v1.VerifyReq, opts ...grpc.CallOption) (*v1.VerifyRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 *v1.VerifyRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.VerifyReq, ...grpc.CallOption) (*v1.VerifyRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.VerifyReq, ...grpc.CallOption) *v1.VerifyRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.VerifyRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.VerifyReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TokenServiceClient_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type TokenServiceClient_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v1.VerifyReq
+//   - opts ...grpc.CallOption
+func (_e *TokenServiceClient_Expecter) Verify(ctx interface{}, in interface{}, opts ...interface{}) *TokenServiceClient_Verify_Call {
+	return &TokenServiceClient_Verify_Call{Call: _e.mock.On("Verify",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *TokenServiceClient_Verify_Call) Run(run func(ctx context.Context, in *v1.VerifyReq, opts ...grpc.CallOption)) *TokenServiceClient_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v1.VerifyReq), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *TokenServiceClient_Verify_Call) Return(_a0 *v1.VerifyRes, _a1 error) *TokenServiceClient_Verify_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *TokenServiceClient_Verify_Call) RunAndReturn(run func(context.Context, *v1.VerifyReq, ...grpc.CallOption) (*v1.VerifyRes, error)) *TokenServiceClient_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewTokenServiceClient creates a new instance of TokenServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenServiceClient(t interface {
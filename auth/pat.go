@@ -227,6 +227,10 @@ func ParsePlatformEntityType(pet string) (PlatformEntityType, error) {
 		return PlatformUsersScope, nil
 	case platformDomainsScopeStr:
 		return PlatformDomainsScope, nil
+	case PlatformDashBoardScopeStr:
+		return PlatformDashBoardScope, nil
+	case PlatformMesagingScopeStr:
+		return PlatformMesagingScope, nil
 	default:
 		return 0, fmt.Errorf("unknown platform entity type %s", pet)
 	}
@@ -801,4 +805,15 @@ type PATSRepository interface {
 	CheckScopeEntry(ctx context.Context, userID, patID string, platformEntityType PlatformEntityType, optionalDomainID string, optionalDomainEntityType DomainEntityType, operation OperationType, entityIDs ...string) error
 
 	RemoveAllScopeEntry(ctx context.Context, userID, patID string) error
+
+	// RetrieveExpiring retrieves the active PATs of all the users which
+	// expire after from and not later than to, and marks them as notified.
+	// The PATs which are already marked are skipped, so each PAT is retrieved
+	// once, even by the concurrent service instances, until its expiry is
+	// updated.
+	RetrieveExpiring(ctx context.Context, from, to time.Time) ([]PAT, error)
+
+	// RemoveExpired removes the PATs of all the users which expired or were
+	// revoked before the given time and returns the number of removed PATs.
+	RemoveExpired(ctx context.Context, before time.Time) (uint64, error)
 }
@@ -0,0 +1,97 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// The PAT handler is a cron job that runs periodically to notify the owners
+// of the PATs which expire soon and to remove the PATs which expired or were
+// revoked a certain period of time ago.
+// A PAT is notified once, by the first run after the PAT enters the
+// notification period. The repository marks the retrieved PATs as notified,
+// so the notifications are not repeated on every run or by the other
+// service instances.
+
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type patHandler struct {
+	pats          PATSRepository
+	emailer       Emailer
+	checkInterval time.Duration
+	notifyBefore  time.Duration
+	retention     time.Duration
+	logger        *slog.Logger
+}
+
+// NewPATHandler starts the PAT handler which notifies the owners of the PATs
+// expiring within notifyBefore and removes the PATs which expired or were
+// revoked more than retention ago. Zero notifyBefore disables the
+// notifications.
+func NewPATHandler(ctx context.Context, pats PATSRepository, emailer Emailer, checkInterval, notifyBefore, retention time.Duration, logger *slog.Logger) {
+	handler := &patHandler{
+		pats:          pats,
+		emailer:       emailer,
+		checkInterval: checkInterval,
+		notifyBefore:  notifyBefore,
+		retention:     retention,
+		logger:        logger,
+	}
+
+	go func() {
+		ticker := time.NewTicker(handler.checkInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				handler.handle(ctx, now)
+			}
+		}
+	}()
+}
+
+func (h *patHandler) handle(ctx context.Context, now time.Time) {
+	h.notify(ctx, now)
+	h.cleanup(ctx, now)
+}
+
+func (h *patHandler) notify(ctx context.Context, now time.Time) {
+	if h.notifyBefore <= 0 {
+		return
+	}
+
+	pats, err := h.pats.RetrieveExpiring(ctx, now, now.Add(h.notifyBefore))
+	if err != nil {
+		h.logger.Error("failed to retrieve expiring PATs", slog.Any("error", err))
+		return
+	}
+
+	for _, pat := range pats {
+		if err := h.emailer.SendPATExpiryNotification(ctx, pat); err != nil {
+			h.logger.Error("failed to send PAT expiry notification", slog.String("id", pat.ID), slog.Any("error", err))
+			continue
+		}
+
+		h.logger.Info("PAT expiry notification sent", slog.Group("pat",
+			slog.String("id", pat.ID),
+			slog.String("user_id", pat.User),
+			slog.Time("expires_at", pat.ExpiresAt),
+		))
+	}
+}
+
+func (h *patHandler) cleanup(ctx context.Context, now time.Time) {
+	removed, err := h.pats.RemoveExpired(ctx, now.Add(-h.retention))
+	if err != nil {
+		h.logger.Error("failed to remove expired PATs", slog.Any("error", err))
+		return
+	}
+	if removed > 0 {
+		h.logger.Info("expired and revoked PATs removed", slog.Uint64("count", removed))
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/mocks"
+	smqlog "github.com/absmach/supermq/logger"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPATHandler(t *testing.T) {
+	const (
+		checkInterval = 10 * time.Millisecond
+		notifyBefore  = time.Hour
+		retention     = 24 * time.Hour
+	)
+
+	expiring := auth.PAT{ID: "pat1", User: "user1", ExpiresAt: time.Now().Add(notifyBefore)}
+	failing := auth.PAT{ID: "pat2", User: "user2", ExpiresAt: time.Now().Add(notifyBefore)}
+
+	pats := new(mocks.PATSRepository)
+	emailer := new(mocks.Emailer)
+
+	notified := make(chan auth.PAT, 10)
+	removed := make(chan time.Time, 10)
+	var from, to time.Time
+	pats.On("RetrieveExpiring", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		from, to = args.Get(1).(time.Time), args.Get(2).(time.Time)
+	}).Return([]auth.PAT{failing, expiring}, nil)
+	emailer.On("SendPATExpiryNotification", mock.Anything, failing).Return(repoerr.ErrNotFound)
+	emailer.On("SendPATExpiryNotification", mock.Anything, expiring).Run(func(args mock.Arguments) {
+		notified <- args.Get(1).(auth.PAT)
+	}).Return(nil)
+	pats.On("RemoveExpired", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		removed <- args.Get(1).(time.Time)
+	}).Return(uint64(1), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	start := time.Now()
+	auth.NewPATHandler(ctx, pats, emailer, checkInterval, notifyBefore, retention, smqlog.NewMock())
+
+	select {
+	case pat := <-notified:
+		assert.Equal(t, expiring.ID, pat.ID, "expected expiring PAT to be notified")
+	case <-time.After(time.Second):
+		t.Fatal("expected PAT expiry notification")
+	}
+
+	select {
+	case before := <-removed:
+		assert.True(t, before.Before(start.Add(-retention).Add(time.Second)), "expected PATs to be removed after retention period")
+	case <-time.After(time.Second):
+		t.Fatal("expected expired PATs removal")
+	}
+	cancel()
+
+	assert.Equal(t, notifyBefore, to.Sub(from), "expected notification window to match notify before period")
+	assert.True(t, from.After(start), "expected notification window to start now")
+}
@@ -0,0 +1,641 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+)
+
+// anyID is the entity ID stored for the scope entries which allow
+// the operation on any entity.
+const anyID = "*"
+
+var _ auth.PATSRepository = (*patRepo)(nil)
+
+type patRepo struct {
+	db postgres.Database
+}
+
+// NewPATSRepository instantiates a PostgreSQL implementation of PAT
+// repository.
+func NewPATSRepository(db postgres.Database) auth.PATSRepository {
+	return &patRepo{
+		db: db,
+	}
+}
+
+func (pr *patRepo) Save(ctx context.Context, pat auth.PAT) (retErr error) {
+	scopes, err := toDBScopes(pat.ID, pat.Scope)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				retErr = errors.Wrap(retErr, errRollback)
+			}
+		}
+	}()
+
+	q := `INSERT INTO pats (id, user_id, name, description, secret, issued_at, expires_at, updated_at, last_used_at, revoked, revoked_at)
+	VALUES (:id, :user_id, :name, :description, :secret, :issued_at, :expires_at, :updated_at, :last_used_at, :revoked, :revoked_at)`
+	if _, err := tx.NamedExecContext(ctx, q, toDBPAT(pat)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	if len(scopes) > 0 {
+		q := `INSERT INTO pat_scopes (pat_id, platform_entity_type, domain_id, domain_entity_type, operation, entity_id)
+		VALUES (:pat_id, :platform_entity_type, :domain_id, :domain_entity_type, :operation, :entity_id)`
+		if _, err := tx.NamedExecContext(ctx, q, scopes); err != nil {
+			return postgres.HandleError(repoerr.ErrCreateEntity, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (pr *patRepo) Retrieve(ctx context.Context, userID, patID string) (auth.PAT, error) {
+	q := `SELECT id, user_id, name, description, issued_at, expires_at, updated_at, last_used_at, revoked, revoked_at
+	FROM pats WHERE user_id = $1 AND id = $2`
+
+	var dbp dbPAT
+	if err := pr.db.QueryRowxContext(ctx, q, userID, patID).StructScan(&dbp); err != nil {
+		if err == sql.ErrNoRows {
+			return auth.PAT{}, repoerr.ErrNotFound
+		}
+		return auth.PAT{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	scope, err := pr.retrieveScope(ctx, patID)
+	if err != nil {
+		return auth.PAT{}, err
+	}
+	pat := toPAT(dbp)
+	pat.Scope = scope
+
+	return pat, nil
+}
+
+func (pr *patRepo) RetrieveSecretAndRevokeStatus(ctx context.Context, userID, patID string) (string, bool, bool, error) {
+	q := `SELECT secret, revoked, expires_at FROM pats WHERE user_id = $1 AND id = $2`
+
+	var dbp dbPAT
+	if err := pr.db.QueryRowxContext(ctx, q, userID, patID).StructScan(&dbp); err != nil {
+		if err == sql.ErrNoRows {
+			return "", true, true, repoerr.ErrNotFound
+		}
+		return "", true, true, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return dbp.Secret, dbp.Revoked, time.Now().UTC().After(dbp.ExpiresAt), nil
+}
+
+func (pr *patRepo) UpdateName(ctx context.Context, userID, patID, name string) (auth.PAT, error) {
+	q := `UPDATE pats SET name = $3, updated_at = $4 WHERE user_id = $1 AND id = $2`
+
+	return pr.update(ctx, userID, patID, q, name, time.Now().UTC())
+}
+
+func (pr *patRepo) UpdateDescription(ctx context.Context, userID, patID, description string) (auth.PAT, error) {
+	q := `UPDATE pats SET description = $3, updated_at = $4 WHERE user_id = $1 AND id = $2`
+
+	return pr.update(ctx, userID, patID, q, description, time.Now().UTC())
+}
+
+func (pr *patRepo) UpdateTokenHash(ctx context.Context, userID, patID, tokenHash string, expiryAt time.Time) (auth.PAT, error) {
+	q := `UPDATE pats SET secret = $3, expires_at = $4, updated_at = $5, notified = FALSE WHERE user_id = $1 AND id = $2`
+
+	return pr.update(ctx, userID, patID, q, tokenHash, expiryAt.UTC(), time.Now().UTC())
+}
+
+func (pr *patRepo) RetrieveAll(ctx context.Context, userID string, pm auth.PATSPageMeta) (auth.PATSPage, error) {
+	q := `SELECT id, user_id, name, description, issued_at, expires_at, updated_at, last_used_at, revoked, revoked_at
+	FROM pats WHERE user_id = $1 ORDER BY issued_at, id LIMIT $2 OFFSET $3`
+
+	rows, err := pr.db.QueryxContext(ctx, q, userID, pm.Limit, pm.Offset)
+	if err != nil {
+		return auth.PATSPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var pats []auth.PAT
+	for rows.Next() {
+		var dbp dbPAT
+		if err := rows.StructScan(&dbp); err != nil {
+			return auth.PATSPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		pats = append(pats, toPAT(dbp))
+	}
+	if err := rows.Err(); err != nil {
+		return auth.PATSPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	for i := range pats {
+		if pats[i].Scope, err = pr.retrieveScope(ctx, pats[i].ID); err != nil {
+			return auth.PATSPage{}, err
+		}
+	}
+
+	cq := `SELECT COUNT(*) FROM pats WHERE user_id = $1`
+	var total uint64
+	if err := pr.db.QueryRowxContext(ctx, cq, userID).Scan(&total); err != nil {
+		return auth.PATSPage{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return auth.PATSPage{
+		Total:  total,
+		Offset: pm.Offset,
+		Limit:  pm.Limit,
+		PATS:   pats,
+	}, nil
+}
+
+func (pr *patRepo) Revoke(ctx context.Context, userID, patID string) error {
+	q := `UPDATE pats SET revoked = TRUE, revoked_at = $3 WHERE user_id = $1 AND id = $2`
+
+	return pr.exec(ctx, repoerr.ErrUpdateEntity, q, userID, patID, time.Now().UTC())
+}
+
+func (pr *patRepo) Reactivate(ctx context.Context, userID, patID string) error {
+	q := `UPDATE pats SET revoked = FALSE, revoked_at = NULL WHERE user_id = $1 AND id = $2`
+
+	return pr.exec(ctx, repoerr.ErrUpdateEntity, q, userID, patID)
+}
+
+func (pr *patRepo) Remove(ctx context.Context, userID, patID string) error {
+	// Scope entries are removed by the foreign key cascade.
+	q := `DELETE FROM pats WHERE user_id = $1 AND id = $2`
+
+	return pr.exec(ctx, repoerr.ErrRemoveEntity, q, userID, patID)
+}
+
+func (pr *patRepo) AddScopeEntry(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (scope auth.Scope, retErr error) {
+	scopes, err := toDBScopeEntry(patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
+	if err != nil {
+		return auth.Scope{}, err
+	}
+	if err := pr.checkPAT(ctx, userID, patID, repoerr.ErrCreateEntity); err != nil {
+		return auth.Scope{}, err
+	}
+
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return auth.Scope{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				retErr = errors.Wrap(retErr, errRollback)
+			}
+		}
+	}()
+
+	// The wildcard and the selected IDs are mutually exclusive, so the
+	// wildcard replaces the selected IDs and the other way around.
+	dq := `DELETE FROM pat_scopes WHERE pat_id = :pat_id AND platform_entity_type = :platform_entity_type
+	AND domain_id = :domain_id AND domain_entity_type = :domain_entity_type AND operation = :operation`
+	root := scopes[0]
+	if root.EntityID != anyID {
+		dq += ` AND entity_id = :entity_id`
+		root.EntityID = anyID
+	}
+	if _, err := tx.NamedExecContext(ctx, dq, root); err != nil {
+		return auth.Scope{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	q := `INSERT INTO pat_scopes (pat_id, platform_entity_type, domain_id, domain_entity_type, operation, entity_id)
+	VALUES (:pat_id, :platform_entity_type, :domain_id, :domain_entity_type, :operation, :entity_id)
+	ON CONFLICT DO NOTHING`
+	if _, err := tx.NamedExecContext(ctx, q, scopes); err != nil {
+		return auth.Scope{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return auth.Scope{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return pr.retrieveScope(ctx, patID)
+}
+
+func (pr *patRepo) RemoveScopeEntry(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) (scope auth.Scope, retErr error) {
+	if len(entityIDs) == 0 {
+		return auth.Scope{}, repoerr.ErrMalformedEntity
+	}
+	scopes, err := toDBScopeEntry(patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, entityIDs...)
+	if err != nil {
+		return auth.Scope{}, err
+	}
+	if err := pr.checkPAT(ctx, userID, patID, repoerr.ErrRemoveEntity); err != nil {
+		return auth.Scope{}, err
+	}
+
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return auth.Scope{}, errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollback := tx.Rollback(); errRollback != nil {
+				retErr = errors.Wrap(retErr, errRollback)
+			}
+		}
+	}()
+
+	q := `DELETE FROM pat_scopes WHERE pat_id = :pat_id AND platform_entity_type = :platform_entity_type
+	AND domain_id = :domain_id AND domain_entity_type = :domain_entity_type AND operation = :operation AND entity_id = :entity_id`
+	for _, s := range scopes {
+		if _, err := tx.NamedExecContext(ctx, q, s); err != nil {
+			return auth.Scope{}, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return auth.Scope{}, errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+
+	return pr.retrieveScope(ctx, patID)
+}
+
+func (pr *patRepo) CheckScopeEntry(ctx context.Context, userID, patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) error {
+	root, err := toDBScopeEntry(patID, platformEntityType, optionalDomainID, optionalDomainEntityType, operation, anyID)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	if err := pr.checkPAT(ctx, userID, patID, repoerr.ErrViewEntity); err != nil {
+		return errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	q := `SELECT entity_id FROM pat_scopes WHERE pat_id = $1 AND platform_entity_type = $2
+	AND domain_id = $3 AND domain_entity_type = $4 AND operation = $5`
+	rows, err := pr.db.QueryxContext(ctx, q, root[0].PatID, root[0].PlatformEntityType, root[0].DomainID, root[0].DomainEntityType, root[0].Operation)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	allowed := map[string]struct{}{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		allowed[id] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		return postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	if _, ok := allowed[anyID]; ok {
+		return nil
+	}
+	if len(entityIDs) == 0 {
+		return repoerr.ErrNotFound
+	}
+	for _, id := range entityIDs {
+		if _, ok := allowed[id]; !ok {
+			return repoerr.ErrNotFound
+		}
+	}
+
+	return nil
+}
+
+func (pr *patRepo) RemoveAllScopeEntry(ctx context.Context, userID, patID string) error {
+	q := `DELETE FROM pat_scopes WHERE pat_id IN (SELECT id FROM pats WHERE user_id = $1 AND id = $2)`
+	if _, err := pr.db.ExecContext(ctx, q, userID, patID); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (pr *patRepo) RetrieveExpiring(ctx context.Context, from, to time.Time) ([]auth.PAT, error) {
+	// The PATs are marked as notified by the same statement, so the
+	// concurrent service instances don't retrieve the same PATs.
+	q := `UPDATE pats SET notified = TRUE
+	WHERE revoked = FALSE AND notified = FALSE AND expires_at > $1 AND expires_at <= $2
+	RETURNING id, user_id, name, description, issued_at, expires_at, updated_at, last_used_at, revoked, revoked_at`
+
+	rows, err := pr.db.QueryxContext(ctx, q, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+
+	pats := []auth.PAT{}
+	for rows.Next() {
+		var dbp dbPAT
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		pats = append(pats, toPAT(dbp))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	for i := range pats {
+		if pats[i].Scope, err = pr.retrieveScope(ctx, pats[i].ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return pats, nil
+}
+
+func (pr *patRepo) RemoveExpired(ctx context.Context, before time.Time) (uint64, error) {
+	q := `DELETE FROM pats WHERE expires_at < $1 OR (revoked = TRUE AND revoked_at < $1)`
+
+	res, err := pr.db.ExecContext(ctx, q, before.UTC())
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return 0, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return uint64(cnt), nil
+}
+
+func (pr *patRepo) update(ctx context.Context, userID, patID, q string, args ...any) (auth.PAT, error) {
+	if err := pr.exec(ctx, repoerr.ErrUpdateEntity, q, append([]any{userID, patID}, args...)...); err != nil {
+		return auth.PAT{}, err
+	}
+
+	return pr.Retrieve(ctx, userID, patID)
+}
+
+func (pr *patRepo) exec(ctx context.Context, wrap error, q string, args ...any) error {
+	res, err := pr.db.ExecContext(ctx, q, args...)
+	if err != nil {
+		return postgres.HandleError(wrap, err)
+	}
+	cnt, err := res.RowsAffected()
+	if err != nil {
+		return postgres.HandleError(wrap, err)
+	}
+	if cnt != 1 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (pr *patRepo) checkPAT(ctx context.Context, userID, patID string, wrap error) error {
+	q := `SELECT 1 FROM pats WHERE user_id = $1 AND id = $2`
+
+	var exists int
+	if err := pr.db.QueryRowxContext(ctx, q, userID, patID).Scan(&exists); err != nil {
+		if err == sql.ErrNoRows {
+			return repoerr.ErrNotFound
+		}
+		return postgres.HandleError(wrap, err)
+	}
+
+	return nil
+}
+
+func (pr *patRepo) retrieveScope(ctx context.Context, patID string) (auth.Scope, error) {
+	q := `SELECT pat_id, platform_entity_type, domain_id, domain_entity_type, operation, entity_id
+	FROM pat_scopes WHERE pat_id = $1`
+
+	rows, err := pr.db.QueryxContext(ctx, q, patID)
+	if err != nil {
+		return auth.Scope{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var scopes []dbScope
+	for rows.Next() {
+		var s dbScope
+		if err := rows.StructScan(&s); err != nil {
+			return auth.Scope{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		scopes = append(scopes, s)
+	}
+	if err := rows.Err(); err != nil {
+		return auth.Scope{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toScope(scopes)
+}
+
+type dbPAT struct {
+	ID          string       `db:"id"`
+	User        string       `db:"user_id"`
+	Name        string       `db:"name"`
+	Description string       `db:"description"`
+	Secret      string       `db:"secret"`
+	IssuedAt    time.Time    `db:"issued_at"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+	UpdatedAt   sql.NullTime `db:"updated_at"`
+	LastUsedAt  sql.NullTime `db:"last_used_at"`
+	Revoked     bool         `db:"revoked"`
+	RevokedAt   sql.NullTime `db:"revoked_at"`
+}
+
+type dbScope struct {
+	PatID              string `db:"pat_id"`
+	PlatformEntityType uint32 `db:"platform_entity_type"`
+	DomainID           string `db:"domain_id"`
+	DomainEntityType   uint32 `db:"domain_entity_type"`
+	Operation          uint32 `db:"operation"`
+	EntityID           string `db:"entity_id"`
+}
+
+func toDBPAT(pat auth.PAT) dbPAT {
+	return dbPAT{
+		ID:          pat.ID,
+		User:        pat.User,
+		Name:        pat.Name,
+		Description: pat.Description,
+		Secret:      pat.Secret,
+		IssuedAt:    pat.IssuedAt.UTC(),
+		ExpiresAt:   pat.ExpiresAt.UTC(),
+		UpdatedAt:   toNullTime(pat.UpdatedAt),
+		LastUsedAt:  toNullTime(pat.LastUsedAt),
+		Revoked:     pat.Revoked,
+		RevokedAt:   toNullTime(pat.RevokedAt),
+	}
+}
+
+func toPAT(dbp dbPAT) auth.PAT {
+	pat := auth.PAT{
+		ID:          dbp.ID,
+		User:        dbp.User,
+		Name:        dbp.Name,
+		Description: dbp.Description,
+		IssuedAt:    dbp.IssuedAt,
+		ExpiresAt:   dbp.ExpiresAt,
+		Revoked:     dbp.Revoked,
+	}
+	if dbp.UpdatedAt.Valid {
+		pat.UpdatedAt = dbp.UpdatedAt.Time
+	}
+	if dbp.LastUsedAt.Valid {
+		pat.LastUsedAt = dbp.LastUsedAt.Time
+	}
+	if dbp.RevokedAt.Valid {
+		pat.RevokedAt = dbp.RevokedAt.Time
+	}
+
+	return pat
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
+
+func toDBScopes(patID string, scope auth.Scope) ([]dbScope, error) {
+	var scopes []dbScope
+	add := func(platformEntityType auth.PlatformEntityType, domainID string, domainEntityType auth.DomainEntityType, ops auth.OperationScope) error {
+		for op, value := range ops {
+			entries, err := toDBScopeEntry(patID, platformEntityType, domainID, domainEntityType, op, value.Values()...)
+			if err != nil {
+				return err
+			}
+			scopes = append(scopes, entries...)
+		}
+		return nil
+	}
+
+	if err := add(auth.PlatformUsersScope, "", auth.DomainNullScope, scope.Users); err != nil {
+		return nil, err
+	}
+	if err := add(auth.PlatformDashBoardScope, "", auth.DomainNullScope, scope.Dashboard); err != nil {
+		return nil, err
+	}
+	if err := add(auth.PlatformMesagingScope, "", auth.DomainNullScope, scope.Messaging); err != nil {
+		return nil, err
+	}
+	for domainID, ds := range scope.Domains {
+		if err := add(auth.PlatformDomainsScope, domainID, auth.DomainManagementScope, ds.DomainManagement); err != nil {
+			return nil, err
+		}
+		for entityType, ops := range ds.Entities {
+			if err := add(auth.PlatformDomainsScope, domainID, entityType, ops); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return scopes, nil
+}
+
+// toDBScopeEntry validates the scope entry and converts it to the rows. The
+// domain fields are kept only for the domains platform entity type, the same
+// way the scope entry key is built by the bolt repository.
+func toDBScopeEntry(patID string, platformEntityType auth.PlatformEntityType, optionalDomainID string, optionalDomainEntityType auth.DomainEntityType, operation auth.OperationType, entityIDs ...string) ([]dbScope, error) {
+	if len(entityIDs) == 0 {
+		return nil, repoerr.ErrMalformedEntity
+	}
+	if _, err := operation.ValidString(); err != nil {
+		return nil, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	root := dbScope{
+		PatID:              patID,
+		PlatformEntityType: uint32(platformEntityType),
+		DomainEntityType:   uint32(auth.DomainNullScope),
+		Operation:          uint32(operation),
+	}
+	switch platformEntityType {
+	case auth.PlatformUsersScope, auth.PlatformDashBoardScope, auth.PlatformMesagingScope:
+	case auth.PlatformDomainsScope:
+		if optionalDomainID == "" {
+			return nil, errors.Wrap(repoerr.ErrMalformedEntity, fmt.Errorf("failed to add platform %s scope: invalid domain id", platformEntityType.String()))
+		}
+		if _, err := optionalDomainEntityType.ValidString(); err != nil {
+			return nil, errors.Wrap(repoerr.ErrMalformedEntity, err)
+		}
+		root.DomainID = optionalDomainID
+		root.DomainEntityType = uint32(optionalDomainEntityType)
+	default:
+		return nil, errors.Wrap(repoerr.ErrMalformedEntity, fmt.Errorf("invalid platform entity type %s", platformEntityType.String()))
+	}
+
+	scopes := make([]dbScope, 0, len(entityIDs))
+	for _, id := range entityIDs {
+		if id == anyID && len(entityIDs) > 1 {
+			return nil, repoerr.ErrMalformedEntity
+		}
+		s := root
+		s.EntityID = id
+		scopes = append(scopes, s)
+	}
+
+	return scopes, nil
+}
+
+func toScope(scopes []dbScope) (auth.Scope, error) {
+	scope := auth.Scope{
+		Domains: make(map[string]auth.DomainScope),
+	}
+	for _, s := range scopes {
+		op := auth.OperationType(s.Operation)
+		switch platformEntityType := auth.PlatformEntityType(s.PlatformEntityType); platformEntityType {
+		case auth.PlatformUsersScope:
+			scope.Users = addScopeValue(scope.Users, op, s.EntityID)
+		case auth.PlatformDashBoardScope:
+			scope.Dashboard = addScopeValue(scope.Dashboard, op, s.EntityID)
+		case auth.PlatformMesagingScope:
+			scope.Messaging = addScopeValue(scope.Messaging, op, s.EntityID)
+		case auth.PlatformDomainsScope:
+			ds := scope.Domains[s.DomainID]
+			switch entityType := auth.DomainEntityType(s.DomainEntityType); entityType {
+			case auth.DomainManagementScope:
+				ds.DomainManagement = addScopeValue(ds.DomainManagement, op, s.EntityID)
+			default:
+				if ds.Entities == nil {
+					ds.Entities = make(map[auth.DomainEntityType]auth.OperationScope)
+				}
+				ds.Entities[entityType] = addScopeValue(ds.Entities[entityType], op, s.EntityID)
+			}
+			scope.Domains[s.DomainID] = ds
+		default:
+			return auth.Scope{}, errors.Wrap(repoerr.ErrViewEntity, fmt.Errorf("invalid platform entity type : %s", platformEntityType.String()))
+		}
+	}
+
+	return scope, nil
+}
+
+func addScopeValue(ops auth.OperationScope, op auth.OperationType, entityID string) auth.OperationScope {
+	if ops == nil {
+		ops = make(auth.OperationScope)
+	}
+	if entityID == anyID {
+		ops[op] = &auth.AnyIDs{}
+		return ops
+	}
+	sids, ok := ops[op].(*auth.SelectedIDs)
+	if !ok {
+		sids = &auth.SelectedIDs{}
+		ops[op] = sids
+	}
+	(*sids)[entityID] = struct{}{}
+
+	return ops
+}
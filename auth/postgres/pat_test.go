@@ -0,0 +1,494 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/postgres"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPAT(t *testing.T, userID string) auth.PAT {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return auth.PAT{
+		ID:          generateID(t),
+		User:        userID,
+		Name:        "pat",
+		Description: "personal access token",
+		Secret:      "hash",
+		IssuedAt:    now,
+		ExpiresAt:   now.Add(time.Hour),
+		Scope: auth.Scope{
+			Users: auth.OperationScope{
+				auth.ReadOp: &auth.AnyIDs{},
+			},
+			Domains: map[string]auth.DomainScope{
+				"domain": {
+					Entities: map[auth.DomainEntityType]auth.OperationScope{
+						auth.DomainClientsScope: {
+							auth.ReadOp: &auth.SelectedIDs{"client1": {}, "client2": {}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPATSave(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+
+	pat := newPAT(t, generateID(t))
+
+	cases := []struct {
+		desc string
+		pat  auth.PAT
+		err  error
+	}{
+		{
+			desc: "save a new PAT",
+			pat:  pat,
+			err:  nil,
+		},
+		{
+			desc: "save PAT with duplicate id",
+			pat:  pat,
+			err:  repoerr.ErrConflict,
+		},
+		{
+			desc: "save PAT without scope",
+			pat: auth.PAT{
+				ID:        generateID(t),
+				User:      pat.User,
+				Name:      "pat",
+				Secret:    "hash",
+				IssuedAt:  pat.IssuedAt,
+				ExpiresAt: pat.ExpiresAt,
+			},
+			err: nil,
+		},
+		{
+			desc: "save PAT with invalid scope",
+			pat: auth.PAT{
+				ID:        generateID(t),
+				User:      pat.User,
+				Name:      "pat",
+				Secret:    "hash",
+				IssuedAt:  pat.IssuedAt,
+				ExpiresAt: pat.ExpiresAt,
+				Scope: auth.Scope{
+					Domains: map[string]auth.DomainScope{
+						"": {DomainManagement: auth.OperationScope{auth.ReadOp: &auth.AnyIDs{}}},
+					},
+				},
+			},
+			err: repoerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Save(context.Background(), tc.pat)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestPATRetrieve(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+
+	pat := newPAT(t, generateID(t))
+	err := repo.Save(context.Background(), pat)
+	require.Nil(t, err, fmt.Sprintf("saving PAT expected to succeed: %s", err))
+
+	cases := []struct {
+		desc   string
+		userID string
+		id     string
+		pat    auth.PAT
+		err    error
+	}{
+		{
+			desc:   "retrieve an existing PAT",
+			userID: pat.User,
+			id:     pat.ID,
+			pat:    pat,
+			err:    nil,
+		},
+		{
+			desc:   "retrieve PAT of another user",
+			userID: generateID(t),
+			id:     pat.ID,
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "retrieve non-existing PAT",
+			userID: pat.User,
+			id:     generateID(t),
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := repo.Retrieve(context.Background(), tc.userID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.pat.Name, p.Name, fmt.Sprintf("%s: expected name %s got %s\n", tc.desc, tc.pat.Name, p.Name))
+				assert.Empty(t, p.Secret, fmt.Sprintf("%s: expected empty secret got %s\n", tc.desc, p.Secret))
+				assert.True(t, p.Scope.Check(auth.PlatformUsersScope, "", auth.DomainNullScope, auth.ReadOp, "user"), fmt.Sprintf("%s: expected users read scope\n", tc.desc))
+				assert.True(t, p.Scope.Check(auth.PlatformDomainsScope, "domain", auth.DomainClientsScope, auth.ReadOp, "client1", "client2"), fmt.Sprintf("%s: expected clients read scope\n", tc.desc))
+			}
+		})
+	}
+}
+
+func TestPATRetrieveSecretAndRevokeStatus(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+
+	pat := newPAT(t, generateID(t))
+	expired := newPAT(t, pat.User)
+	expired.ExpiresAt = expired.IssuedAt.Add(-time.Minute)
+	for _, p := range []auth.PAT{pat, expired} {
+		err := repo.Save(context.Background(), p)
+		require.Nil(t, err, fmt.Sprintf("saving PAT expected to succeed: %s", err))
+	}
+
+	cases := []struct {
+		desc    string
+		id      string
+		revoke  bool
+		revoked bool
+		expired bool
+		err     error
+	}{
+		{
+			desc: "retrieve status of an active PAT",
+			id:   pat.ID,
+		},
+		{
+			desc:    "retrieve status of an expired PAT",
+			id:      expired.ID,
+			expired: true,
+		},
+		{
+			desc:    "retrieve status of a revoked PAT",
+			id:      pat.ID,
+			revoke:  true,
+			revoked: true,
+		},
+		{
+			desc:    "retrieve status of non-existing PAT",
+			id:      generateID(t),
+			revoked: true,
+			expired: true,
+			err:     repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.revoke {
+				err := repo.Revoke(context.Background(), pat.User, tc.id)
+				require.Nil(t, err, fmt.Sprintf("revoking PAT expected to succeed: %s", err))
+			}
+			secret, revoked, expired, err := repo.RetrieveSecretAndRevokeStatus(context.Background(), pat.User, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, pat.Secret, secret, fmt.Sprintf("%s: expected secret %s got %s\n", tc.desc, pat.Secret, secret))
+			}
+			assert.Equal(t, tc.revoked, revoked, fmt.Sprintf("%s: expected revoked %t got %t\n", tc.desc, tc.revoked, revoked))
+			assert.Equal(t, tc.expired, expired, fmt.Sprintf("%s: expected expired %t got %t\n", tc.desc, tc.expired, expired))
+		})
+	}
+}
+
+func TestPATRetrieveAll(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+
+	userID := generateID(t)
+	var ids []string
+	for i := 0; i < 5; i++ {
+		pat := newPAT(t, userID)
+		pat.IssuedAt = pat.IssuedAt.Add(time.Duration(i) * time.Second)
+		err := repo.Save(context.Background(), pat)
+		require.Nil(t, err, fmt.Sprintf("saving PAT expected to succeed: %s", err))
+		ids = append(ids, pat.ID)
+	}
+
+	cases := []struct {
+		desc   string
+		userID string
+		pm     auth.PATSPageMeta
+		total  uint64
+		ids    []string
+	}{
+		{
+			desc:   "retrieve all PATs of the user",
+			userID: userID,
+			pm:     auth.PATSPageMeta{Offset: 0, Limit: 10},
+			total:  5,
+			ids:    ids,
+		},
+		{
+			desc:   "retrieve PATs of the user with offset and limit",
+			userID: userID,
+			pm:     auth.PATSPageMeta{Offset: 1, Limit: 2},
+			total:  5,
+			ids:    ids[1:3],
+		},
+		{
+			desc:   "retrieve PATs of the user without PATs",
+			userID: generateID(t),
+			pm:     auth.PATSPageMeta{Offset: 0, Limit: 10},
+			total:  0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.userID, tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d\n", tc.desc, tc.total, page.Total))
+			var got []string
+			for _, p := range page.PATS {
+				got = append(got, p.ID)
+			}
+			assert.Equal(t, tc.ids, got, fmt.Sprintf("%s: expected PATs %v got %v\n", tc.desc, tc.ids, got))
+		})
+	}
+}
+
+func TestPATUpdate(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+
+	pat := newPAT(t, generateID(t))
+	err := repo.Save(context.Background(), pat)
+	require.Nil(t, err, fmt.Sprintf("saving PAT expected to succeed: %s", err))
+
+	p, err := repo.UpdateName(context.Background(), pat.User, pat.ID, "updated")
+	assert.Nil(t, err, fmt.Sprintf("updating PAT name expected to succeed: %s", err))
+	assert.Equal(t, "updated", p.Name, fmt.Sprintf("expected name updated got %s\n", p.Name))
+	assert.False(t, p.UpdatedAt.IsZero(), "expected updated at to be set")
+
+	p, err = repo.UpdateDescription(context.Background(), pat.User, pat.ID, "updated")
+	assert.Nil(t, err, fmt.Sprintf("updating PAT description expected to succeed: %s", err))
+	assert.Equal(t, "updated", p.Description, fmt.Sprintf("expected description updated got %s\n", p.Description))
+
+	expiry := pat.ExpiresAt.Add(time.Hour)
+	p, err = repo.UpdateTokenHash(context.Background(), pat.User, pat.ID, "new-hash", expiry)
+	assert.Nil(t, err, fmt.Sprintf("updating PAT token hash expected to succeed: %s", err))
+	assert.True(t, expiry.Equal(p.ExpiresAt), fmt.Sprintf("expected expiry %s got %s\n", expiry, p.ExpiresAt))
+	secret, _, _, err := repo.RetrieveSecretAndRevokeStatus(context.Background(), pat.User, pat.ID)
+	assert.Nil(t, err, fmt.Sprintf("retrieving PAT secret expected to succeed: %s", err))
+	assert.Equal(t, "new-hash", secret, fmt.Sprintf("expected secret new-hash got %s\n", secret))
+
+	_, err = repo.UpdateName(context.Background(), generateID(t), pat.ID, "updated")
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s\n", repoerr.ErrNotFound, err))
+}
+
+func TestPATRetrieveExpiring(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+
+	// The expiry is far enough from the PATs of the other tests.
+	pat := newPAT(t, generateID(t))
+	pat.ExpiresAt = pat.IssuedAt.Add(100 * time.Hour)
+	err := repo.Save(context.Background(), pat)
+	require.Nil(t, err, fmt.Sprintf("saving PAT expected to succeed: %s", err))
+	from, to := pat.IssuedAt.Add(99*time.Hour), pat.IssuedAt.Add(101*time.Hour)
+
+	pats, err := repo.RetrieveExpiring(context.Background(), from, to)
+	assert.Nil(t, err, fmt.Sprintf("retrieving expiring PATs expected to succeed: %s", err))
+	require.Len(t, pats, 1, "expected expiring PAT to be retrieved")
+	assert.Equal(t, pat.ID, pats[0].ID, fmt.Sprintf("expected PAT %s got %s\n", pat.ID, pats[0].ID))
+
+	pats, err = repo.RetrieveExpiring(context.Background(), from, to)
+	assert.Nil(t, err, fmt.Sprintf("retrieving expiring PATs expected to succeed: %s", err))
+	assert.Empty(t, pats, "expected notified PAT not to be retrieved again")
+
+	_, err = repo.UpdateTokenHash(context.Background(), pat.User, pat.ID, "new-hash", pat.ExpiresAt.Add(time.Minute))
+	require.Nil(t, err, fmt.Sprintf("updating PAT token hash expected to succeed: %s", err))
+	pats, err = repo.RetrieveExpiring(context.Background(), from, to)
+	assert.Nil(t, err, fmt.Sprintf("retrieving expiring PATs expected to succeed: %s", err))
+	assert.Len(t, pats, 1, "expected PAT with updated expiry to be retrieved again")
+}
+
+func TestPATRevokeAndReactivate(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+
+	pat := newPAT(t, generateID(t))
+	err := repo.Save(context.Background(), pat)
+	require.Nil(t, err, fmt.Sprintf("saving PAT expected to succeed: %s", err))
+
+	err = repo.Revoke(context.Background(), pat.User, pat.ID)
+	assert.Nil(t, err, fmt.Sprintf("revoking PAT expected to succeed: %s", err))
+	p, err := repo.Retrieve(context.Background(), pat.User, pat.ID)
+	require.Nil(t, err, fmt.Sprintf("retrieving PAT expected to succeed: %s", err))
+	assert.True(t, p.Revoked, "expected PAT to be revoked")
+	assert.False(t, p.RevokedAt.IsZero(), "expected revoked at to be set")
+
+	err = repo.Reactivate(context.Background(), pat.User, pat.ID)
+	assert.Nil(t, err, fmt.Sprintf("reactivating PAT expected to succeed: %s", err))
+	p, err = repo.Retrieve(context.Background(), pat.User, pat.ID)
+	require.Nil(t, err, fmt.Sprintf("retrieving PAT expected to succeed: %s", err))
+	assert.False(t, p.Revoked, "expected PAT to be active")
+	assert.True(t, p.RevokedAt.IsZero(), "expected revoked at to be cleared")
+
+	err = repo.Revoke(context.Background(), pat.User, generateID(t))
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s\n", repoerr.ErrNotFound, err))
+}
+
+func TestPATRemove(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+
+	pat := newPAT(t, generateID(t))
+	err := repo.Save(context.Background(), pat)
+	require.Nil(t, err, fmt.Sprintf("saving PAT expected to succeed: %s", err))
+
+	cases := []struct {
+		desc   string
+		userID string
+		id     string
+		err    error
+	}{
+		{
+			desc:   "remove PAT of another user",
+			userID: generateID(t),
+			id:     pat.ID,
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "remove an existing PAT",
+			userID: pat.User,
+			id:     pat.ID,
+			err:    nil,
+		},
+		{
+			desc:   "remove removed PAT",
+			userID: pat.User,
+			id:     pat.ID,
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.userID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestPATScopeEntry(t *testing.T) {
+	repo := postgres.NewPATSRepository(database)
+
+	pat := newPAT(t, generateID(t))
+	pat.Scope = auth.Scope{}
+	err := repo.Save(context.Background(), pat)
+	require.Nil(t, err, fmt.Sprintf("saving PAT expected to succeed: %s", err))
+
+	cases := []struct {
+		desc      string
+		userID    string
+		add       []string
+		remove    []string
+		check     []string
+		addErr    error
+		removeErr error
+		checkErr  error
+	}{
+		{
+			desc:     "check scope without entries",
+			userID:   pat.User,
+			check:    []string{"channel1"},
+			checkErr: repoerr.ErrNotFound,
+		},
+		{
+			desc:   "add selected IDs and check one of them",
+			userID: pat.User,
+			add:    []string{"channel1", "channel2"},
+			check:  []string{"channel1"},
+		},
+		{
+			desc:     "check ID which is not selected",
+			userID:   pat.User,
+			check:    []string{"channel1", "channel3"},
+			checkErr: repoerr.ErrNotFound,
+		},
+		{
+			desc:     "remove selected ID and check it",
+			userID:   pat.User,
+			remove:   []string{"channel1"},
+			check:    []string{"channel1"},
+			checkErr: repoerr.ErrNotFound,
+		},
+		{
+			desc:   "add wildcard and check any ID",
+			userID: pat.User,
+			add:    []string{"*"},
+			check:  []string{"channel3"},
+		},
+		{
+			desc:   "add wildcard with selected IDs",
+			userID: pat.User,
+			add:    []string{"*", "channel1"},
+			addErr: repoerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "add selected ID which replaces wildcard",
+			userID:   pat.User,
+			add:      []string{"channel1"},
+			check:    []string{"channel3"},
+			checkErr: repoerr.ErrNotFound,
+		},
+		{
+			desc:      "remove without IDs",
+			userID:    pat.User,
+			remove:    []string{},
+			removeErr: repoerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "add scope to PAT of another user",
+			userID:   generateID(t),
+			add:      []string{"channel1"},
+			addErr:   repoerr.ErrNotFound,
+			check:    []string{"channel1"},
+			checkErr: repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.add != nil {
+				scope, err := repo.AddScopeEntry(context.Background(), tc.userID, pat.ID, auth.PlatformMesagingScope, "", auth.DomainNullScope, auth.PublishOp, tc.add...)
+				assert.True(t, errors.Contains(err, tc.addErr), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.addErr, err))
+				if err == nil {
+					assert.True(t, scope.Check(auth.PlatformMesagingScope, "", auth.DomainNullScope, auth.PublishOp, tc.add...), fmt.Sprintf("%s: expected added scope entry\n", tc.desc))
+				}
+			}
+			if tc.remove != nil {
+				_, err := repo.RemoveScopeEntry(context.Background(), tc.userID, pat.ID, auth.PlatformMesagingScope, "", auth.DomainNullScope, auth.PublishOp, tc.remove...)
+				assert.True(t, errors.Contains(err, tc.removeErr), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.removeErr, err))
+			}
+			if tc.check != nil {
+				err := repo.CheckScopeEntry(context.Background(), tc.userID, pat.ID, auth.PlatformMesagingScope, "", auth.DomainNullScope, auth.PublishOp, tc.check...)
+				assert.True(t, errors.Contains(err, tc.checkErr), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.checkErr, err))
+			}
+		})
+	}
+
+	err = repo.RemoveAllScopeEntry(context.Background(), pat.User, pat.ID)
+	assert.Nil(t, err, fmt.Sprintf("removing all scope entries expected to succeed: %s", err))
+	p, err := repo.Retrieve(context.Background(), pat.User, pat.ID)
+	require.Nil(t, err, fmt.Sprintf("retrieving PAT expected to succeed: %s", err))
+	assert.Empty(t, p.Scope.Messaging, fmt.Sprintf("expected empty messaging scope got %v\n", p.Scope.Messaging))
+}
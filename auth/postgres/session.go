@@ -0,0 +1,168 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+)
+
+var _ auth.SessionRepository = (*sessionRepo)(nil)
+
+type sessionRepo struct {
+	db postgres.Database
+}
+
+// NewSessionRepository instantiates a PostgreSQL implementation of session
+// repository.
+func NewSessionRepository(db postgres.Database) auth.SessionRepository {
+	return &sessionRepo{
+		db: db,
+	}
+}
+
+func (repo *sessionRepo) Save(ctx context.Context, session auth.Session) error {
+	// The expired sessions of the user are removed along the way, so they do
+	// not pile up for the users who never log out.
+	q := `WITH expired AS (
+		DELETE FROM sessions WHERE user_id = :user_id AND expires_at < :issued_at
+	)
+	INSERT INTO sessions (id, user_id, token_id, user_agent, ip_address, issued_at, last_used_at, expires_at)
+	VALUES (:id, :user_id, :token_id, :user_agent, :ip_address, :issued_at, :last_used_at, :expires_at)`
+
+	if _, err := repo.db.NamedExecContext(ctx, q, toDBSession(session)); err != nil {
+		return postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *sessionRepo) Retrieve(ctx context.Context, userID, id string) (auth.Session, error) {
+	q := `SELECT id, user_id, token_id, user_agent, ip_address, issued_at, last_used_at, expires_at
+	FROM sessions WHERE user_id = $1 AND id = $2`
+
+	var dbs dbSession
+	if err := repo.db.QueryRowxContext(ctx, q, userID, id).StructScan(&dbs); err != nil {
+		if err == sql.ErrNoRows {
+			return auth.Session{}, repoerr.ErrNotFound
+		}
+		return auth.Session{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return toSession(dbs), nil
+}
+
+func (repo *sessionRepo) RetrieveAll(ctx context.Context, userID string) ([]auth.Session, error) {
+	q := `SELECT id, user_id, token_id, user_agent, ip_address, issued_at, last_used_at, expires_at
+	FROM sessions WHERE user_id = $1 AND expires_at > $2 ORDER BY last_used_at DESC`
+
+	rows, err := repo.db.QueryxContext(ctx, q, userID, time.Now().UTC())
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	sessions := []auth.Session{}
+	for rows.Next() {
+		var dbs dbSession
+		if err := rows.StructScan(&dbs); err != nil {
+			return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+		}
+		sessions = append(sessions, toSession(dbs))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return sessions, nil
+}
+
+func (repo *sessionRepo) Rotate(ctx context.Context, session auth.Session, tokenID string) error {
+	q := `UPDATE sessions SET token_id = :token_id, last_used_at = :last_used_at, expires_at = :expires_at,
+		user_agent = COALESCE(:user_agent, user_agent), ip_address = COALESCE(:ip_address, ip_address)
+	WHERE user_id = :user_id AND id = :id AND token_id = :current_token_id`
+
+	dbs := toDBSession(session)
+	dbs.CurrentTokenID = tokenID
+	result, err := repo.db.NamedExecContext(ctx, q, dbs)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *sessionRepo) Remove(ctx context.Context, userID, id string) error {
+	q := `DELETE FROM sessions WHERE user_id = $1 AND id = $2`
+
+	result, err := repo.db.ExecContext(ctx, q, userID, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *sessionRepo) RemoveAll(ctx context.Context, userID string) error {
+	q := `DELETE FROM sessions WHERE user_id = $1`
+
+	if _, err := repo.db.ExecContext(ctx, q, userID); err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+type dbSession struct {
+	ID             string         `db:"id"`
+	UserID         string         `db:"user_id"`
+	TokenID        string         `db:"token_id"`
+	CurrentTokenID string         `db:"current_token_id"`
+	UserAgent      sql.NullString `db:"user_agent"`
+	IPAddress      sql.NullString `db:"ip_address"`
+	IssuedAt       time.Time      `db:"issued_at"`
+	LastUsedAt     time.Time      `db:"last_used_at"`
+	ExpiresAt      time.Time      `db:"expires_at"`
+}
+
+func toDBSession(session auth.Session) dbSession {
+	return dbSession{
+		ID:         session.ID,
+		UserID:     session.UserID,
+		TokenID:    session.TokenID,
+		UserAgent:  toNullString(session.UserAgent),
+		IPAddress:  toNullString(session.IPAddress),
+		IssuedAt:   session.IssuedAt,
+		LastUsedAt: session.LastUsedAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+}
+
+func toSession(dbs dbSession) auth.Session {
+	return auth.Session{
+		ID:         dbs.ID,
+		UserID:     dbs.UserID,
+		TokenID:    dbs.TokenID,
+		UserAgent:  dbs.UserAgent.String,
+		IPAddress:  dbs.IPAddress.String,
+		IssuedAt:   dbs.IssuedAt,
+		LastUsedAt: dbs.LastUsedAt,
+		ExpiresAt:  dbs.ExpiresAt,
+	}
+}
+
+func toNullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
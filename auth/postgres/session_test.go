@@ -0,0 +1,290 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/auth/postgres"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSession(t *testing.T, userID string) auth.Session {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return auth.Session{
+		ID:         generateID(t),
+		UserID:     userID,
+		TokenID:    generateID(t),
+		UserAgent:  "Mozilla/5.0",
+		IPAddress:  "192.168.0.1",
+		IssuedAt:   now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+}
+
+func TestSessionSave(t *testing.T) {
+	repo := postgres.NewSessionRepository(database)
+
+	session := newSession(t, generateID(t))
+
+	cases := []struct {
+		desc    string
+		session auth.Session
+		err     error
+	}{
+		{
+			desc:    "save a new session",
+			session: session,
+			err:     nil,
+		},
+		{
+			desc:    "save session with duplicate id",
+			session: session,
+			err:     repoerr.ErrConflict,
+		},
+		{
+			desc: "save session without user agent and IP address",
+			session: auth.Session{
+				ID:         generateID(t),
+				UserID:     session.UserID,
+				TokenID:    generateID(t),
+				IssuedAt:   session.IssuedAt,
+				LastUsedAt: session.LastUsedAt,
+				ExpiresAt:  session.ExpiresAt,
+			},
+			err: nil,
+		},
+		{
+			desc: "save session with invalid id",
+			session: auth.Session{
+				ID:         invalidID,
+				UserID:     session.UserID,
+				TokenID:    generateID(t),
+				IssuedAt:   session.IssuedAt,
+				LastUsedAt: session.LastUsedAt,
+				ExpiresAt:  session.ExpiresAt,
+			},
+			err: repoerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Save(context.Background(), tc.session)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestSessionRetrieve(t *testing.T) {
+	repo := postgres.NewSessionRepository(database)
+
+	session := newSession(t, generateID(t))
+	err := repo.Save(context.Background(), session)
+	require.Nil(t, err, fmt.Sprintf("saving session expected to succeed: %s", err))
+
+	cases := []struct {
+		desc    string
+		userID  string
+		id      string
+		session auth.Session
+		err     error
+	}{
+		{
+			desc:    "retrieve an existing session",
+			userID:  session.UserID,
+			id:      session.ID,
+			session: session,
+			err:     nil,
+		},
+		{
+			desc:   "retrieve session of another user",
+			userID: generateID(t),
+			id:     session.ID,
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "retrieve non-existing session",
+			userID: session.UserID,
+			id:     generateID(t),
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			s, err := repo.Retrieve(context.Background(), tc.userID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.session.TokenID, s.TokenID, fmt.Sprintf("%s: expected token ID %s got %s\n", tc.desc, tc.session.TokenID, s.TokenID))
+				assert.Equal(t, tc.session.UserAgent, s.UserAgent, fmt.Sprintf("%s: expected user agent %s got %s\n", tc.desc, tc.session.UserAgent, s.UserAgent))
+				assert.Equal(t, tc.session.IPAddress, s.IPAddress, fmt.Sprintf("%s: expected IP address %s got %s\n", tc.desc, tc.session.IPAddress, s.IPAddress))
+			}
+		})
+	}
+}
+
+func TestSessionRetrieveAll(t *testing.T) {
+	repo := postgres.NewSessionRepository(database)
+
+	userID := generateID(t)
+	active := newSession(t, userID)
+	expired := newSession(t, userID)
+	expired.IssuedAt = expired.IssuedAt.Add(-2 * time.Hour)
+	expired.ExpiresAt = expired.IssuedAt.Add(time.Hour)
+	for _, s := range []auth.Session{expired, active} {
+		err := repo.Save(context.Background(), s)
+		require.Nil(t, err, fmt.Sprintf("saving session expected to succeed: %s", err))
+	}
+
+	cases := []struct {
+		desc   string
+		userID string
+		ids    []string
+	}{
+		{
+			desc:   "retrieve sessions of the user",
+			userID: userID,
+			ids:    []string{active.ID},
+		},
+		{
+			desc:   "retrieve sessions of the user without sessions",
+			userID: generateID(t),
+			ids:    []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sessions, err := repo.RetrieveAll(context.Background(), tc.userID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+			ids := []string{}
+			for _, s := range sessions {
+				ids = append(ids, s.ID)
+			}
+			assert.Equal(t, tc.ids, ids, fmt.Sprintf("%s: expected sessions %v got %v\n", tc.desc, tc.ids, ids))
+		})
+	}
+}
+
+func TestSessionRotate(t *testing.T) {
+	repo := postgres.NewSessionRepository(database)
+
+	session := newSession(t, generateID(t))
+	err := repo.Save(context.Background(), session)
+	require.Nil(t, err, fmt.Sprintf("saving session expected to succeed: %s", err))
+
+	rotated := session
+	rotated.TokenID = generateID(t)
+	rotated.UserAgent = ""
+	rotated.IPAddress = "192.168.0.2"
+	rotated.LastUsedAt = session.LastUsedAt.Add(time.Minute)
+
+	cases := []struct {
+		desc    string
+		session auth.Session
+		tokenID string
+		err     error
+	}{
+		{
+			desc:    "rotate with current token",
+			session: rotated,
+			tokenID: session.TokenID,
+			err:     nil,
+		},
+		{
+			desc: "rotate with reused token",
+			session: auth.Session{
+				ID:      session.ID,
+				UserID:  session.UserID,
+				TokenID: generateID(t),
+			},
+			tokenID: session.TokenID,
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc: "rotate non-existing session",
+			session: auth.Session{
+				ID:      generateID(t),
+				UserID:  session.UserID,
+				TokenID: generateID(t),
+			},
+			tokenID: rotated.TokenID,
+			err:     repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Rotate(context.Background(), tc.session, tc.tokenID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+
+	s, err := repo.Retrieve(context.Background(), session.UserID, session.ID)
+	require.Nil(t, err, fmt.Sprintf("retrieving session expected to succeed: %s", err))
+	assert.Equal(t, rotated.TokenID, s.TokenID, fmt.Sprintf("expected token ID %s got %s\n", rotated.TokenID, s.TokenID))
+	assert.Equal(t, session.UserAgent, s.UserAgent, fmt.Sprintf("expected user agent %s got %s\n", session.UserAgent, s.UserAgent))
+	assert.Equal(t, rotated.IPAddress, s.IPAddress, fmt.Sprintf("expected IP address %s got %s\n", rotated.IPAddress, s.IPAddress))
+}
+
+func TestSessionRemove(t *testing.T) {
+	repo := postgres.NewSessionRepository(database)
+
+	session := newSession(t, generateID(t))
+	err := repo.Save(context.Background(), session)
+	require.Nil(t, err, fmt.Sprintf("saving session expected to succeed: %s", err))
+
+	cases := []struct {
+		desc   string
+		userID string
+		id     string
+		err    error
+	}{
+		{
+			desc:   "remove an existing session",
+			userID: session.UserID,
+			id:     session.ID,
+			err:    nil,
+		},
+		{
+			desc:   "remove a removed session",
+			userID: session.UserID,
+			id:     session.ID,
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.userID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestSessionRemoveAll(t *testing.T) {
+	repo := postgres.NewSessionRepository(database)
+
+	userID := generateID(t)
+	for i := 0; i < 3; i++ {
+		err := repo.Save(context.Background(), newSession(t, userID))
+		require.Nil(t, err, fmt.Sprintf("saving session expected to succeed: %s", err))
+	}
+
+	err := repo.RemoveAll(context.Background(), userID)
+	assert.Nil(t, err, fmt.Sprintf("removing sessions expected to succeed: %s", err))
+
+	sessions, err := repo.RetrieveAll(context.Background(), userID)
+	assert.Nil(t, err, fmt.Sprintf("retrieving sessions expected to succeed: %s", err))
+	assert.Empty(t, sessions, "expected no sessions after removing all")
+}
@@ -12,17 +12,19 @@ import (
 
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/google/uuid"
 )
 
 const (
-	recoveryDuration   = 5 * time.Minute
-	defLimit           = 100
-	randStr            = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890!@#$%^&&*|+-="
-	patPrefix          = "pat"
-	patSecretSeparator = "_"
+	recoveryDuration     = 5 * time.Minute
+	verificationDuration = 24 * time.Hour
+	defLimit             = 100
+	randStr              = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890!@#$%^&&*|+-="
+	patPrefix            = "pat"
+	patSecretSeparator   = "_"
 )
 
 var (
@@ -35,6 +37,10 @@ var (
 	errRetrieve  = errors.New("failed to retrieve key data")
 	errIdentify  = errors.New("failed to validate token")
 	errPlatform  = errors.New("invalid platform id")
+	errKeyType   = errors.New("key type can't be issued")
+
+	errSessionRevoked    = errors.New("session is revoked")
+	errRefreshTokenReuse = errors.New("reuse of rotated refresh token, session revoked")
 
 	errMalformedPAT        = errors.New("malformed personal access token")
 	errFailedToParseUUID   = errors.New("failed to parse string to UUID")
@@ -81,6 +87,24 @@ type Authn interface {
 	// is returned. If token is invalid, or invocation failed for some
 	// other reason, non-nil error value is returned in response.
 	Identify(ctx context.Context, token string) (Key, error)
+
+	// Verify validates the single-purpose token of the given type, such as
+	// the e-mail verification token. These tokens are not accepted by
+	// Identify, so they can't be used to access the account.
+	Verify(ctx context.Context, token string, keyType KeyType) (Key, error)
+
+	// RetrieveJWKS returns the public keys used to verify issued tokens.
+	RetrieveJWKS(ctx context.Context) ([]PublicKeyInfo, error)
+
+	// ListSessions retrieves the active login sessions of the user.
+	ListSessions(ctx context.Context, userID string) ([]Session, error)
+
+	// RevokeSession revokes the login session of the user, so its refresh
+	// token can no longer be used.
+	RevokeSession(ctx context.Context, userID, id string) error
+
+	// RevokeSessions revokes all the login sessions of the user.
+	RevokeSessions(ctx context.Context, userID string) error
 }
 
 // Service specifies an API that must be fulfilled by the domain service
@@ -100,6 +124,7 @@ var _ Service = (*service)(nil)
 type service struct {
 	keys               KeyRepository
 	pats               PATSRepository
+	sessions           SessionRepository
 	hasher             Hasher
 	idProvider         supermq.IDProvider
 	evaluator          policies.Evaluator
@@ -111,11 +136,12 @@ type service struct {
 }
 
 // New instantiates the auth service implementation.
-func New(keys KeyRepository, pats PATSRepository, hasher Hasher, idp supermq.IDProvider, tokenizer Tokenizer, policyEvaluator policies.Evaluator, policyService policies.Service, loginDuration, refreshDuration, invitationDuration time.Duration) Service {
+func New(keys KeyRepository, pats PATSRepository, sessions SessionRepository, hasher Hasher, idp supermq.IDProvider, tokenizer Tokenizer, policyEvaluator policies.Evaluator, policyService policies.Service, loginDuration, refreshDuration, invitationDuration time.Duration) Service {
 	return &service{
 		tokenizer:          tokenizer,
 		keys:               keys,
 		pats:               pats,
+		sessions:           sessions,
 		hasher:             hasher,
 		idProvider:         idp,
 		evaluator:          policyEvaluator,
@@ -135,10 +161,16 @@ func (svc service) Issue(ctx context.Context, token string, key Key) (Token, err
 		return svc.refreshKey(ctx, token, key)
 	case RecoveryKey:
 		return svc.tmpKey(recoveryDuration, key)
+	case VerificationKey:
+		return svc.tmpKey(verificationDuration, key)
 	case InvitationKey:
 		return svc.invitationKey(ctx, key)
-	default:
+	case AccessKey:
 		return svc.accessKey(ctx, key)
+	default:
+		// MFA keys are issued by the users service using its own secret and
+		// PATs are created using CreatePAT, so they're never issued here.
+		return Token{}, errors.Wrap(svcerr.ErrMalformedEntity, errKeyType)
 	}
 }
 
@@ -166,6 +198,38 @@ func (svc service) RetrieveKey(ctx context.Context, token, id string) (Key, erro
 	return key, nil
 }
 
+func (svc service) RetrieveJWKS(_ context.Context) ([]PublicKeyInfo, error) {
+	return svc.tokenizer.RetrieveJWKS()
+}
+
+func (svc service) ListSessions(ctx context.Context, userID string) ([]Session, error) {
+	sessions, err := svc.sessions.RetrieveAll(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return sessions, nil
+}
+
+func (svc service) RevokeSession(ctx context.Context, userID, id string) error {
+	if err := svc.sessions.Remove(ctx, userID, id); err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return errors.Wrap(svcerr.ErrNotFound, err)
+		}
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) RevokeSessions(ctx context.Context, userID string) error {
+	if err := svc.sessions.RemoveAll(ctx, userID); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
 func (svc service) Identify(ctx context.Context, token string) (Key, error) {
 	key, err := svc.tokenizer.Parse(token)
 	if errors.Contains(err, ErrExpiry) {
@@ -177,7 +241,12 @@ func (svc service) Identify(ctx context.Context, token string) (Key, error) {
 	}
 
 	switch key.Type {
-	case RecoveryKey, AccessKey, InvitationKey, RefreshKey:
+	case RecoveryKey, AccessKey, InvitationKey:
+		return key, nil
+	case RefreshKey:
+		if err := svc.checkSession(ctx, key); err != nil {
+			return Key{}, errors.Wrap(svcerr.ErrAuthentication, err)
+		}
 		return key, nil
 	case APIKey:
 		_, err := svc.keys.Retrieve(ctx, key.Issuer, key.ID)
@@ -190,6 +259,21 @@ func (svc service) Identify(ctx context.Context, token string) (Key, error) {
 	}
 }
 
+func (svc service) Verify(_ context.Context, token string, keyType KeyType) (Key, error) {
+	key, err := svc.tokenizer.Parse(token)
+	if errors.Contains(err, ErrExpiry) {
+		return Key{}, errors.Wrap(svcerr.ErrAuthentication, ErrKeyExpired)
+	}
+	if err != nil {
+		return Key{}, errors.Wrap(svcerr.ErrAuthentication, errors.Wrap(errIdentify, err))
+	}
+	if key.Type != keyType {
+		return Key{}, svcerr.ErrAuthentication
+	}
+
+	return key, nil
+}
+
 func (svc service) Authorize(ctx context.Context, pr policies.Policy) error {
 	if err := svc.PolicyValidation(pr); err != nil {
 		return errors.Wrap(svcerr.ErrMalformedEntity, err)
@@ -275,11 +359,17 @@ func (svc service) accessKey(ctx context.Context, key Key) (Token, error) {
 		return Token{}, errors.Wrap(svcerr.ErrAuthorization, err)
 	}
 
+	if key.Session, err = svc.idProvider.ID(); err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
 	access, err := svc.tokenizer.Issue(key)
 	if err != nil {
 		return Token{}, errors.Wrap(errIssueTmp, err)
 	}
 
+	if key.ID, err = svc.idProvider.ID(); err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
 	key.ExpiresAt = time.Now().Add(svc.refreshDuration)
 	key.Type = RefreshKey
 	refresh, err := svc.tokenizer.Issue(key)
@@ -287,6 +377,21 @@ func (svc service) accessKey(ctx context.Context, key Key) (Token, error) {
 		return Token{}, errors.Wrap(errIssueTmp, err)
 	}
 
+	info := SessionInfoFromContext(ctx)
+	session := Session{
+		ID:         key.Session,
+		UserID:     key.User,
+		TokenID:    key.ID,
+		UserAgent:  info.UserAgent,
+		IPAddress:  info.IPAddress,
+		IssuedAt:   key.IssuedAt,
+		LastUsedAt: key.IssuedAt,
+		ExpiresAt:  key.ExpiresAt,
+	}
+	if err := svc.sessions.Save(ctx, session); err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
+
 	return Token{AccessToken: access, RefreshToken: refresh}, nil
 }
 
@@ -316,11 +421,14 @@ func (svc service) refreshKey(ctx context.Context, token string, key Key) (Token
 	if k.Type != RefreshKey {
 		return Token{}, errIssueUser
 	}
-	key.ID = k.ID
+	if k.Session == "" {
+		return Token{}, errors.Wrap(svcerr.ErrAuthentication, errSessionRevoked)
+	}
 	if key.Domain == "" {
 		key.Domain = k.Domain
 	}
 	key.User = k.User
+	key.Session = k.Session
 	key.Type = AccessKey
 
 	key.Subject, err = svc.checkUserDomain(ctx, key)
@@ -328,13 +436,36 @@ func (svc service) refreshKey(ctx context.Context, token string, key Key) (Token
 		return Token{}, errors.Wrap(svcerr.ErrAuthorization, err)
 	}
 
-	key.ExpiresAt = time.Now().Add(svc.loginDuration)
+	// Rotate the refresh token. The refresh token that is not the current one
+	// of the session has already been used, so the session is revoked.
+	if key.ID, err = svc.idProvider.ID(); err != nil {
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
+	info := SessionInfoFromContext(ctx)
+	now := time.Now().UTC()
+	session := Session{
+		ID:         k.Session,
+		UserID:     k.User,
+		TokenID:    key.ID,
+		UserAgent:  info.UserAgent,
+		IPAddress:  info.IPAddress,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(svc.refreshDuration),
+	}
+	if err := svc.sessions.Rotate(ctx, session, k.ID); err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return Token{}, errors.Wrap(svcerr.ErrAuthentication, svc.revokeReused(ctx, k))
+		}
+		return Token{}, errors.Wrap(errIssueTmp, err)
+	}
+
+	key.ExpiresAt = now.Add(svc.loginDuration)
 	access, err := svc.tokenizer.Issue(key)
 	if err != nil {
 		return Token{}, errors.Wrap(errIssueTmp, err)
 	}
 
-	key.ExpiresAt = time.Now().Add(svc.refreshDuration)
+	key.ExpiresAt = session.ExpiresAt
 	key.Type = RefreshKey
 	refresh, err := svc.tokenizer.Issue(key)
 	if err != nil {
@@ -344,6 +475,41 @@ func (svc service) refreshKey(ctx context.Context, token string, key Key) (Token
 	return Token{AccessToken: access, RefreshToken: refresh}, nil
 }
 
+// checkSession checks that the refresh token is the current refresh token of
+// its session.
+func (svc service) checkSession(ctx context.Context, key Key) error {
+	if key.Session == "" {
+		return errSessionRevoked
+	}
+	session, err := svc.sessions.Retrieve(ctx, key.User, key.Session)
+	if err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return errSessionRevoked
+		}
+		return errors.Wrap(errRetrieve, err)
+	}
+	if session.TokenID != key.ID {
+		return svc.revokeReused(ctx, key)
+	}
+
+	return nil
+}
+
+// revokeReused revokes the session of the refresh token that is no longer
+// the current one. Such token is either stolen or the session is already
+// revoked, so the whole session is revoked to cut off the holders of the
+// newer refresh tokens as well.
+func (svc service) revokeReused(ctx context.Context, key Key) error {
+	if err := svc.sessions.Remove(ctx, key.User, key.Session); err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return errSessionRevoked
+		}
+		return errors.Wrap(errRefreshTokenReuse, err)
+	}
+
+	return errRefreshTokenReuse
+}
+
 func (svc service) checkUserDomain(ctx context.Context, key Key) (subject string, err error) {
 	if key.Domain != "" {
 		// Check user is platform admin.
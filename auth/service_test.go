@@ -50,6 +50,7 @@ var (
 	pService   *policymocks.Service
 	pEvaluator *policymocks.Evaluator
 	patsrepo   *mocks.PATSRepository
+	sessions   *mocks.SessionRepository
 	hasher     *mocks.Hasher
 )
 
@@ -58,6 +59,7 @@ func newService() (auth.Service, string) {
 	pService = new(policymocks.Service)
 	pEvaluator = new(policymocks.Evaluator)
 	patsrepo = new(mocks.PATSRepository)
+	sessions = new(mocks.SessionRepository)
 	hasher = new(mocks.Hasher)
 	idProvider := uuid.NewMock()
 
@@ -72,7 +74,7 @@ func newService() (auth.Service, string) {
 	}
 	token, _ := t.Issue(key)
 
-	return auth.New(krepo, patsrepo, hasher, idProvider, t, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration), token
+	return auth.New(krepo, patsrepo, sessions, hasher, idProvider, t, pEvaluator, pService, loginDuration, refreshDuration, invalidDuration), token
 }
 
 func TestIssue(t *testing.T) {
@@ -92,16 +94,22 @@ func TestIssue(t *testing.T) {
 	assert.Nil(t, err, fmt.Sprintf("Issuing API key expected to succeed: %s", err))
 
 	refreshkey := auth.Key{
+		ID:        testsutil.GenerateUUID(t),
 		IssuedAt:  time.Now(),
 		ExpiresAt: time.Now().Add(refreshDuration),
 		Subject:   validID,
 		Type:      auth.RefreshKey,
 		User:      userID,
 		Domain:    domainID,
+		Session:   testsutil.GenerateUUID(t),
 	}
 	refreshToken, err := n.Issue(refreshkey)
 	assert.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
 
+	refreshkey.Session = ""
+	sessionlessToken, err := n.Issue(refreshkey)
+	assert.Nil(t, err, fmt.Sprintf("Issuing refresh key expected to succeed: %s", err))
+
 	cases := []struct {
 		desc  string
 		key   auth.Key
@@ -117,6 +125,36 @@ func TestIssue(t *testing.T) {
 			token: "",
 			err:   nil,
 		},
+		{
+			desc: "issue verification key",
+			key: auth.Key{
+				Type:     auth.VerificationKey,
+				User:     id,
+				IssuedAt: time.Now(),
+			},
+			token: "",
+			err:   nil,
+		},
+		{
+			desc: "issue MFA key",
+			key: auth.Key{
+				Type:     auth.MFAKey,
+				User:     id,
+				IssuedAt: time.Now(),
+			},
+			token: "",
+			err:   svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "issue personal access token key",
+			key: auth.Key{
+				Type:     auth.PersonalAccessToken,
+				User:     id,
+				IssuedAt: time.Now(),
+			},
+			token: "",
+			err:   svcerr.ErrMalformedEntity,
+		},
 	}
 
 	for _, tc := range cases {
@@ -136,6 +174,7 @@ func TestIssue(t *testing.T) {
 		checkPolicyErr         error
 		checkPolicyErr1        error
 		retreiveByIDErr        error
+		saveSessionErr         error
 		err                    error
 	}{
 		{
@@ -289,18 +328,41 @@ func TestIssue(t *testing.T) {
 			checkPolicyErr1: svcerr.ErrAuthorization,
 			err:             svcerr.ErrAuthorization,
 		},
+		{
+			desc: "issue access key with failed to save session",
+			key: auth.Key{
+				Type:     auth.AccessKey,
+				IssuedAt: time.Now(),
+			},
+			checkPolicyRequest: policies.Policy{
+				SubjectType: policies.UserType,
+				Object:      policies.SuperMQObject,
+				ObjectType:  policies.PlatformType,
+				Permission:  policies.AdminPermission,
+			},
+			checkDomainPolicyReq: policies.Policy{
+				SubjectType: policies.UserType,
+				ObjectType:  policies.DomainType,
+				Permission:  policies.MembershipPermission,
+			},
+			token:          accessToken,
+			saveSessionErr: repoerr.ErrCreateEntity,
+			err:            repoerr.ErrCreateEntity,
+		},
 	}
 	for _, tc := range cases2 {
 		repoCall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, tc.saveErr)
 		repoCall1 := pEvaluator.On("CheckPolicy", mock.Anything, tc.checkPolicyRequest).Return(tc.checkPolicyErr)
 		repoCall2 := pEvaluator.On("CheckPolicy", mock.Anything, tc.checkPlatformPolicyReq).Return(tc.checkPolicyErr1)
 		repoCall4 := pEvaluator.On("CheckPolicy", mock.Anything, tc.checkDomainPolicyReq).Return(tc.checkPolicyErr)
+		repoCall5 := sessions.On("Save", mock.Anything, mock.Anything).Return(tc.saveSessionErr)
 		_, err := svc.Issue(context.Background(), tc.token, tc.key)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
 		repoCall.Unset()
 		repoCall1.Unset()
 		repoCall2.Unset()
 		repoCall4.Unset()
+		repoCall5.Unset()
 	}
 
 	cases3 := []struct {
@@ -365,6 +427,7 @@ func TestIssue(t *testing.T) {
 		checkPlatformAdminErr error
 		checkDomainMemberErr  error
 		retrieveByIDErr       error
+		rotateErr             error
 		err                   error
 	}{
 		{
@@ -465,6 +528,62 @@ func TestIssue(t *testing.T) {
 			checkDomainMemberErr:  svcerr.ErrAuthorization,
 			err:                   svcerr.ErrAuthorization,
 		},
+		{
+			desc: "issue refresh key with reused token",
+			key: auth.Key{
+				Type:     auth.RefreshKey,
+				IssuedAt: time.Now(),
+				User:     validID,
+			},
+			token: refreshToken,
+			checkPlatformAdminReq: policies.Policy{
+				Subject:     userID,
+				SubjectType: policies.UserType,
+				Permission:  policies.AdminPermission,
+				Object:      policies.SuperMQObject,
+				ObjectType:  policies.PlatformType,
+			},
+			checkDomainMemberReq: policies.Policy{},
+			rotateErr:            repoerr.ErrNotFound,
+			err:                  svcerr.ErrAuthentication,
+		},
+		{
+			desc: "issue refresh key with failed to rotate token",
+			key: auth.Key{
+				Type:     auth.RefreshKey,
+				IssuedAt: time.Now(),
+				User:     validID,
+			},
+			token: refreshToken,
+			checkPlatformAdminReq: policies.Policy{
+				Subject:     userID,
+				SubjectType: policies.UserType,
+				Permission:  policies.AdminPermission,
+				Object:      policies.SuperMQObject,
+				ObjectType:  policies.PlatformType,
+			},
+			checkDomainMemberReq: policies.Policy{},
+			rotateErr:            repoerr.ErrUpdateEntity,
+			err:                  repoerr.ErrUpdateEntity,
+		},
+		{
+			desc: "issue refresh key with token without session",
+			key: auth.Key{
+				Type:     auth.RefreshKey,
+				IssuedAt: time.Now(),
+				User:     validID,
+			},
+			token: sessionlessToken,
+			checkPlatformAdminReq: policies.Policy{
+				Subject:     userID,
+				SubjectType: policies.UserType,
+				Permission:  policies.AdminPermission,
+				Object:      policies.SuperMQObject,
+				ObjectType:  policies.PlatformType,
+			},
+			checkDomainMemberReq: policies.Policy{},
+			err:                  svcerr.ErrAuthentication,
+		},
 		{
 			desc: "issue refresh key with invalid token",
 			key: auth.Key{
@@ -607,17 +726,23 @@ func TestIssue(t *testing.T) {
 	for _, tc := range cases4 {
 		repoCall := pEvaluator.On("CheckPolicy", mock.Anything, tc.checkPlatformAdminReq).Return(tc.checkPlatformAdminErr)
 		repoCall1 := pEvaluator.On("CheckPolicy", mock.Anything, tc.checkDomainMemberReq).Return(tc.checkDomainMemberErr)
+		repoCall2 := sessions.On("Rotate", mock.Anything, mock.Anything, refreshkey.ID).Return(tc.rotateErr)
+		repoCall3 := sessions.On("Remove", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 		_, err := svc.Issue(context.Background(), tc.token, tc.key)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
 		repoCall.Unset()
 		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
 	}
 }
 
 func TestRevoke(t *testing.T) {
 	svc, _ := newService()
 	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, errIssueUser)
+	sessionCall := sessions.On("Save", mock.Anything, mock.Anything).Return(nil)
 	secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, IssuedAt: time.Now(), Subject: id})
+	sessionCall.Unset()
 	repocall.Unset()
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	repocall1 := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
@@ -670,7 +795,9 @@ func TestRevoke(t *testing.T) {
 func TestRetrieve(t *testing.T) {
 	svc, _ := newService()
 	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
+	sessionCall := sessions.On("Save", mock.Anything, mock.Anything).Return(nil)
 	secret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, IssuedAt: time.Now(), Subject: id})
+	sessionCall.Unset()
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	repocall.Unset()
 	key := auth.Key{
@@ -681,7 +808,9 @@ func TestRetrieve(t *testing.T) {
 	}
 
 	repocall1 := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
+	sessionCall1 := sessions.On("Save", mock.Anything, mock.Anything).Return(nil)
 	userToken, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, IssuedAt: time.Now(), Subject: id})
+	sessionCall1.Unset()
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	repocall1.Unset()
 
@@ -742,10 +871,12 @@ func TestIdentify(t *testing.T) {
 
 	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	repocall1 := pEvaluator.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
+	repocall5 := sessions.On("Save", mock.Anything, mock.Anything).Return(nil)
 	loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, User: id, IssuedAt: time.Now(), Domain: groupName})
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	repocall.Unset()
 	repocall1.Unset()
+	repocall5.Unset()
 
 	repocall2 := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	recoverySecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.RecoveryKey, IssuedAt: time.Now(), Subject: id})
@@ -757,6 +888,9 @@ func TestIdentify(t *testing.T) {
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	repocall3.Unset()
 
+	verificationSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.VerificationKey, IssuedAt: time.Now(), Subject: id, User: id, Email: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing verification key expected to succeed: %s", err))
+
 	repocall4 := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	exp0 := time.Now().UTC().Add(-10 * time.Second).Round(time.Second)
 	exp1 := time.Now().UTC().Add(-1 * time.Minute).Round(time.Second)
@@ -769,17 +903,29 @@ func TestIdentify(t *testing.T) {
 		IssuedAt:  time.Now(),
 		ExpiresAt: time.Now().Add(refreshDuration),
 		Subject:   id,
-		Type:      7,
+		Type:      auth.VerificationKey + 1,
 		User:      email,
 		Domain:    groupName,
 	}
 	invalidTokenType, _ := te.Issue(key)
 
+	refreshKey, err := te.Parse(loginSecret.RefreshToken)
+	assert.Nil(t, err, fmt.Sprintf("Parsing refresh key expected to succeed: %s", err))
+	session := auth.Session{
+		ID:      refreshKey.Session,
+		UserID:  refreshKey.User,
+		TokenID: refreshKey.ID,
+	}
+	rotatedSession := session
+	rotatedSession.TokenID = testsutil.GenerateUUID(t)
+
 	cases := []struct {
-		desc string
-		key  string
-		idt  string
-		err  error
+		desc       string
+		key        string
+		idt        string
+		session    auth.Session
+		sessionErr error
+		err        error
 	}{
 		{
 			desc: "identify login key",
@@ -788,10 +934,25 @@ func TestIdentify(t *testing.T) {
 			err:  nil,
 		},
 		{
-			desc: "identify refresh key",
-			key:  loginSecret.RefreshToken,
-			idt:  id,
-			err:  nil,
+			desc:    "identify refresh key",
+			key:     loginSecret.RefreshToken,
+			idt:     id,
+			session: session,
+			err:     nil,
+		},
+		{
+			desc:       "identify refresh key of revoked session",
+			key:        loginSecret.RefreshToken,
+			idt:        "",
+			sessionErr: repoerr.ErrNotFound,
+			err:        svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "identify reused refresh key",
+			key:     loginSecret.RefreshToken,
+			idt:     "",
+			session: rotatedSession,
+			err:     svcerr.ErrAuthentication,
 		},
 		{
 			desc: "identify recovery key",
@@ -799,6 +960,12 @@ func TestIdentify(t *testing.T) {
 			idt:  id,
 			err:  nil,
 		},
+		{
+			desc: "identify verification key",
+			key:  verificationSecret.AccessToken,
+			idt:  "",
+			err:  svcerr.ErrAuthentication,
+		},
 		{
 			desc: "identify API key",
 			key:  apiSecret.AccessToken,
@@ -834,11 +1001,237 @@ func TestIdentify(t *testing.T) {
 	for _, tc := range cases {
 		repocall := krepo.On("Retrieve", mock.Anything, mock.Anything, mock.Anything).Return(auth.Key{}, tc.err)
 		repocall1 := krepo.On("Remove", mock.Anything, mock.Anything, mock.Anything).Return(tc.err)
+		repocall2 := sessions.On("Retrieve", mock.Anything, refreshKey.User, refreshKey.Session).Return(tc.session, tc.sessionErr)
+		repocall3 := sessions.On("Remove", mock.Anything, refreshKey.User, refreshKey.Session).Return(nil)
 		idt, err := svc.Identify(context.Background(), tc.key)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.idt, idt.Subject, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.idt, idt))
 		repocall.Unset()
 		repocall1.Unset()
+		repocall2.Unset()
+		repocall3.Unset()
+	}
+}
+
+func TestVerify(t *testing.T) {
+	svc, accessToken := newService()
+
+	verificationSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.VerificationKey, IssuedAt: time.Now(), ID: validID, User: id, Email: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing verification key expected to succeed: %s", err))
+
+	te := jwt.New([]byte(secret))
+	expSecret, err := te.Issue(auth.Key{Type: auth.VerificationKey, IssuedAt: time.Now().Add(-time.Hour), ExpiresAt: time.Now().Add(-time.Minute), User: id, Email: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing expired verification key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc    string
+		token   string
+		keyType auth.KeyType
+		key     auth.Key
+		err     error
+	}{
+		{
+			desc:    "verify verification key",
+			token:   verificationSecret.AccessToken,
+			keyType: auth.VerificationKey,
+			key:     auth.Key{ID: validID, Type: auth.VerificationKey, User: id, Email: email},
+			err:     nil,
+		},
+		{
+			desc:    "verify verification key as recovery key",
+			token:   verificationSecret.AccessToken,
+			keyType: auth.RecoveryKey,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "verify access key",
+			token:   accessToken,
+			keyType: auth.VerificationKey,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "verify expired verification key",
+			token:   expSecret,
+			keyType: auth.VerificationKey,
+			err:     auth.ErrKeyExpired,
+		},
+		{
+			desc:    "verify invalid key",
+			token:   inValidToken,
+			keyType: auth.VerificationKey,
+			err:     svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			key, err := svc.Verify(context.Background(), tc.token, tc.keyType)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.key.ID, key.ID, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.key.ID, key.ID))
+			assert.Equal(t, tc.key.User, key.User, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.key.User, key.User))
+			assert.Equal(t, tc.key.Email, key.Email, fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.key.Email, key.Email))
+		})
+	}
+}
+
+func TestCreatePAT(t *testing.T) {
+	svc, accessToken := newService()
+
+	verificationSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.VerificationKey, IssuedAt: time.Now(), User: userID, Email: email})
+	assert.Nil(t, err, fmt.Sprintf("Issuing verification key expected to succeed: %s", err))
+
+	cases := []struct {
+		desc  string
+		token string
+		err   error
+	}{
+		{
+			desc:  "create PAT with access token",
+			token: accessToken,
+			err:   nil,
+		},
+		{
+			desc:  "create PAT with verification token",
+			token: verificationSecret.AccessToken,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "create PAT with invalid token",
+			token: inValidToken,
+			err:   svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			hashCall := hasher.On("Hash", mock.Anything).Return("hash", nil)
+			saveCall := patsrepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+			pat, err := svc.CreatePAT(context.Background(), tc.token, "pat", "description", time.Hour, auth.Scope{})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, userID, pat.User, fmt.Sprintf("%s expected %s got %s\n", tc.desc, userID, pat.User))
+			}
+			hashCall.Unset()
+			saveCall.Unset()
+		})
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	svc, _ := newService()
+
+	userSessions := []auth.Session{
+		{
+			ID:        testsutil.GenerateUUID(t),
+			UserID:    userID,
+			UserAgent: "Mozilla/5.0",
+			IPAddress: "192.168.0.1",
+		},
+	}
+
+	cases := []struct {
+		desc        string
+		userID      string
+		retrieveRes []auth.Session
+		retrieveErr error
+		err         error
+	}{
+		{
+			desc:        "list sessions successfully",
+			userID:      userID,
+			retrieveRes: userSessions,
+			err:         nil,
+		},
+		{
+			desc:        "list sessions with failed to retrieve",
+			userID:      userID,
+			retrieveErr: repoerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := sessions.On("RetrieveAll", mock.Anything, tc.userID).Return(tc.retrieveRes, tc.retrieveErr)
+			res, err := svc.ListSessions(context.Background(), tc.userID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.retrieveRes, res, fmt.Sprintf("%s expected %v got %v\n", tc.desc, tc.retrieveRes, res))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	svc, _ := newService()
+
+	cases := []struct {
+		desc      string
+		userID    string
+		id        string
+		removeErr error
+		err       error
+	}{
+		{
+			desc:   "revoke session successfully",
+			userID: userID,
+			id:     validID,
+			err:    nil,
+		},
+		{
+			desc:      "revoke non-existing session",
+			userID:    userID,
+			id:        validID,
+			removeErr: repoerr.ErrNotFound,
+			err:       svcerr.ErrNotFound,
+		},
+		{
+			desc:      "revoke session with failed to remove",
+			userID:    userID,
+			id:        validID,
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := sessions.On("Remove", mock.Anything, tc.userID, tc.id).Return(tc.removeErr)
+			err := svc.RevokeSession(context.Background(), tc.userID, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	svc, _ := newService()
+
+	cases := []struct {
+		desc      string
+		userID    string
+		removeErr error
+		err       error
+	}{
+		{
+			desc:   "revoke sessions successfully",
+			userID: userID,
+			err:    nil,
+		},
+		{
+			desc:      "revoke sessions with failed to remove",
+			userID:    userID,
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := sessions.On("RemoveAll", mock.Anything, tc.userID).Return(tc.removeErr)
+			err := svc.RevokeSessions(context.Background(), tc.userID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+		})
 	}
 }
 
@@ -847,7 +1240,9 @@ func TestAuthorize(t *testing.T) {
 
 	repocall := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	repocall1 := pEvaluator.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
+	sessionCall := sessions.On("Save", mock.Anything, mock.Anything).Return(nil)
 	loginSecret, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, User: id, IssuedAt: time.Now(), Domain: groupName})
+	sessionCall.Unset()
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	repocall.Unset()
 	repocall1.Unset()
@@ -859,7 +1254,9 @@ func TestAuthorize(t *testing.T) {
 
 	repocall2 := krepo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, nil)
 	repocall3 := pEvaluator.On("CheckPolicy", mock.Anything, mock.Anything).Return(nil)
+	sessionCall1 := sessions.On("Save", mock.Anything, mock.Anything).Return(nil)
 	emptySubject, err := svc.Issue(context.Background(), "", auth.Key{Type: auth.AccessKey, User: "", IssuedAt: time.Now(), Domain: groupName})
+	sessionCall1.Unset()
 	assert.Nil(t, err, fmt.Sprintf("Issuing login key expected to succeed: %s", err))
 	repocall2.Unset()
 	repocall3.Unset()
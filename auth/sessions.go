@@ -0,0 +1,68 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Session represents a login session of the user. The session is started
+// on login and continued by the refresh tokens issued for it. Each refresh
+// rotates the refresh token, so only the last issued refresh token, which ID
+// is kept in the session, is valid.
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	TokenID    string    `json:"-"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	IssuedAt   time.Time `json:"issued_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// SessionInfo describes the user agent the session is started or used from.
+type SessionInfo struct {
+	UserAgent string
+	IPAddress string
+}
+
+type sessionInfoKey struct{}
+
+// WithSessionInfo returns a context that carries the session info.
+func WithSessionInfo(ctx context.Context, info SessionInfo) context.Context {
+	return context.WithValue(ctx, sessionInfoKey{}, info)
+}
+
+// SessionInfoFromContext returns the session info carried by the context.
+func SessionInfoFromContext(ctx context.Context) SessionInfo {
+	info, _ := ctx.Value(sessionInfoKey{}).(SessionInfo)
+	return info
+}
+
+// SessionRepository specifies session persistence API.
+//
+//go:generate mockery --name SessionRepository --output=./mocks --filename sessions.go --quiet --note "Copyright (c) Abstract Machines"
+type SessionRepository interface {
+	// Save persists the session.
+	Save(ctx context.Context, session Session) error
+
+	// Retrieve retrieves the session of the user by its unique identifier.
+	Retrieve(ctx context.Context, userID, id string) (Session, error)
+
+	// RetrieveAll retrieves the sessions of the user that are not expired.
+	RetrieveAll(ctx context.Context, userID string) ([]Session, error)
+
+	// Rotate replaces the refresh token of the session, provided that the
+	// refresh token with the given ID is still the current one, and updates
+	// the session usage. A not found error is returned otherwise.
+	Rotate(ctx context.Context, session Session, tokenID string) error
+
+	// Remove removes the session of the user.
+	Remove(ctx context.Context, userID, id string) error
+
+	// RemoveAll removes all the sessions of the user.
+	RemoveAll(ctx context.Context, userID string) error
+}
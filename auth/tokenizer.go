@@ -3,6 +3,23 @@
 
 package auth
 
+// PublicKeyInfo represents a public token verification key in the
+// JSON Web Key (RFC 7517) format.
+type PublicKeyInfo struct {
+	KeyID     string `json:"kid"`
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use,omitempty"`
+
+	// RSA keys.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// OKP keys.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
 // Tokenizer specifies API for encoding and decoding between string and Key.
 type Tokenizer interface {
 	// Issue converts API Key to its string representation.
@@ -10,4 +27,8 @@ type Tokenizer interface {
 
 	// Parse extracts API Key data from string token.
 	Parse(token string) (key Key, err error)
+
+	// RetrieveJWKS returns the public keys that can be used to verify
+	// issued tokens. Tokenizers using symmetric keys return an empty list.
+	RetrieveJWKS() ([]PublicKeyInfo, error)
 }
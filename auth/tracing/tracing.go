@@ -61,6 +61,50 @@ func (tm *tracingMiddleware) Identify(ctx context.Context, token string) (auth.K
 	return tm.svc.Identify(ctx, token)
 }
 
+func (tm *tracingMiddleware) Verify(ctx context.Context, token string, keyType auth.KeyType) (auth.Key, error) {
+	ctx, span := tm.tracer.Start(ctx, "verify", trace.WithAttributes(
+		attribute.String("type", fmt.Sprintf("%d", keyType)),
+	))
+	defer span.End()
+
+	return tm.svc.Verify(ctx, token, keyType)
+}
+
+func (tm *tracingMiddleware) RetrieveJWKS(ctx context.Context) ([]auth.PublicKeyInfo, error) {
+	ctx, span := tm.tracer.Start(ctx, "retrieve_jwks")
+	defer span.End()
+
+	return tm.svc.RetrieveJWKS(ctx)
+}
+
+func (tm *tracingMiddleware) ListSessions(ctx context.Context, userID string) ([]auth.Session, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_sessions", trace.WithAttributes(
+		attribute.String("user_id", userID),
+	))
+	defer span.End()
+
+	return tm.svc.ListSessions(ctx, userID)
+}
+
+func (tm *tracingMiddleware) RevokeSession(ctx context.Context, userID, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "revoke_session", trace.WithAttributes(
+		attribute.String("user_id", userID),
+		attribute.String("session_id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RevokeSession(ctx, userID, id)
+}
+
+func (tm *tracingMiddleware) RevokeSessions(ctx context.Context, userID string) error {
+	ctx, span := tm.tracer.Start(ctx, "revoke_sessions", trace.WithAttributes(
+		attribute.String("user_id", userID),
+	))
+	defer span.End()
+
+	return tm.svc.RevokeSessions(ctx, userID)
+}
+
 func (tm *tracingMiddleware) Authorize(ctx context.Context, pr policies.Policy) error {
 	ctx, span := tm.tracer.Start(ctx, "authorize", trace.WithAttributes(
 		attribute.String("subject", pr.Subject),
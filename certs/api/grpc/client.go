@@ -0,0 +1,98 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	grpcCertsV1 "github.com/absmach/supermq/api/grpc/certs/v1"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/go-kit/kit/endpoint"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const svcName = "certs.v1.CertsService"
+
+var _ grpcCertsV1.CertsServiceClient = (*grpcClient)(nil)
+
+type grpcClient struct {
+	timeout      time.Duration
+	retrieveCert endpoint.Endpoint
+}
+
+// NewClient returns new gRPC client instance.
+func NewClient(conn *grpc.ClientConn, timeout time.Duration) grpcCertsV1.CertsServiceClient {
+	return &grpcClient{
+		retrieveCert: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RetrieveCert",
+			encodeRetrieveCertRequest,
+			decodeRetrieveCertResponse,
+			grpcCertsV1.RetrieveCertRes{},
+		).Endpoint(),
+		timeout: timeout,
+	}
+}
+
+func (client grpcClient) RetrieveCert(ctx context.Context, req *grpcCertsV1.RetrieveCertReq, _ ...grpc.CallOption) (*grpcCertsV1.RetrieveCertRes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.retrieveCert(ctx, retrieveCertReq{serialNumber: req.GetSerialNumber()})
+	if err != nil {
+		return &grpcCertsV1.RetrieveCertRes{}, decodeError(err)
+	}
+
+	cr := res.(retrieveCertRes)
+	return &grpcCertsV1.RetrieveCertRes{
+		SerialNumber: cr.serialNumber,
+		ClientId:     cr.clientID,
+		Revoked:      cr.revoked,
+		ExpiryTime:   timestamppb.New(cr.expiryTime),
+		Certificate:  cr.certificate,
+	}, nil
+}
+
+func encodeRetrieveCertRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(retrieveCertReq)
+	return &grpcCertsV1.RetrieveCertReq{SerialNumber: req.serialNumber}, nil
+}
+
+func decodeRetrieveCertResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*grpcCertsV1.RetrieveCertRes)
+	return retrieveCertRes{
+		serialNumber: res.GetSerialNumber(),
+		clientID:     res.GetClientId(),
+		revoked:      res.GetRevoked(),
+		expiryTime:   res.GetExpiryTime().AsTime(),
+		certificate:  res.GetCertificate(),
+	}, nil
+}
+
+func decodeError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.InvalidArgument:
+			return errors.Wrap(errors.ErrMalformedEntity, errors.New(st.Message()))
+		case codes.NotFound:
+			return errors.Wrap(svcerr.ErrNotFound, errors.New(st.Message()))
+		case codes.OK:
+			if msg := st.Message(); msg != "" {
+				return errors.Wrap(errors.ErrUnidentified, errors.New(msg))
+			}
+			return nil
+		default:
+			return errors.Wrap(fmt.Errorf("unexpected gRPC status: %s (status code:%v)", st.Code().String(), st.Code()), errors.New(st.Message()))
+		}
+	}
+	return err
+}
@@ -0,0 +1,38 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"encoding/pem"
+
+	"github.com/absmach/supermq/certs"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func retrieveCertEndpoint(svc certs.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(retrieveCertReq)
+		if err := req.validate(); err != nil {
+			return retrieveCertRes{}, err
+		}
+
+		cert, err := svc.ViewCert(ctx, req.serialNumber)
+		if err != nil {
+			return retrieveCertRes{}, err
+		}
+
+		res := retrieveCertRes{
+			serialNumber: cert.SerialNumber,
+			clientID:     cert.ClientID,
+			revoked:      cert.Revoked,
+			expiryTime:   cert.ExpiryTime,
+		}
+		if block, _ := pem.Decode([]byte(cert.Certificate)); block != nil {
+			res.certificate = block.Bytes
+		}
+
+		return res, nil
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc_test
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	grpcCertsV1 "github.com/absmach/supermq/api/grpc/certs/v1"
+	"github.com/absmach/supermq/certs"
+	grpcapi "github.com/absmach/supermq/certs/api/grpc"
+	"github.com/absmach/supermq/certs/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	port   = 7012
+	serial = "123456789"
+)
+
+func startGRPCServer(svc *mocks.Service, port int) *grpc.Server {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		panic(fmt.Sprintf("failed to obtain port: %s", err))
+	}
+	server := grpc.NewServer()
+	grpcCertsV1.RegisterCertsServiceServer(server, grpcapi.NewServer(svc))
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			panic(fmt.Sprintf("failed to serve: %s", err))
+		}
+	}()
+	return server
+}
+
+func TestRetrieveCert(t *testing.T) {
+	svc := new(mocks.Service)
+	server := startGRPCServer(svc, port)
+	defer server.GracefulStop()
+	certsAddr := fmt.Sprintf("localhost:%d", port)
+	conn, _ := grpc.NewClient(certsAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	client := grpcapi.NewClient(conn, time.Second)
+
+	der := []byte("certificate")
+	cert := certs.Cert{
+		SerialNumber: serial,
+		Certificate:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		ClientID:     testsutil.GenerateUUID(t),
+		Revoked:      true,
+		ExpiryTime:   time.Now().Add(time.Hour).UTC().Round(time.Second),
+	}
+
+	cases := []struct {
+		desc   string
+		serial string
+		cert   certs.Cert
+		svcErr error
+		err    error
+	}{
+		{
+			desc:   "retrieve cert successfully",
+			serial: serial,
+			cert:   cert,
+		},
+		{
+			desc:   "retrieve cert with empty serial number",
+			serial: "",
+			err:    errors.ErrMalformedEntity,
+		},
+		{
+			desc:   "retrieve non-existing cert",
+			serial: serial,
+			svcErr: errors.Wrap(certs.ErrFailedReadFromPKI, svcerr.ErrNotFound),
+			err:    svcerr.ErrNotFound,
+		},
+		{
+			desc:   "retrieve cert with failed PKI read",
+			serial: serial,
+			svcErr: certs.ErrFailedReadFromPKI,
+			err:    certs.ErrFailedReadFromPKI,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ViewCert", mock.Anything, tc.serial).Return(tc.cert, tc.svcErr)
+			res, err := client.RetrieveCert(context.Background(), &grpcCertsV1.RetrieveCertReq{SerialNumber: tc.serial})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.cert.ClientID, res.GetClientId(), fmt.Sprintf("%s: expected client ID %s got %s", tc.desc, tc.cert.ClientID, res.GetClientId()))
+				assert.Equal(t, tc.cert.Revoked, res.GetRevoked(), fmt.Sprintf("%s: expected revoked %t got %t", tc.desc, tc.cert.Revoked, res.GetRevoked()))
+				assert.Equal(t, tc.cert.ExpiryTime, res.GetExpiryTime().AsTime(), fmt.Sprintf("%s: expected expiry time %s got %s", tc.desc, tc.cert.ExpiryTime, res.GetExpiryTime().AsTime()))
+				assert.Equal(t, der, res.GetCertificate(), fmt.Sprintf("%s: expected certificate %x got %x", tc.desc, der, res.GetCertificate()))
+			}
+			svcCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import apiutil "github.com/absmach/supermq/api/http/util"
+
+type retrieveCertReq struct {
+	serialNumber string
+}
+
+func (req retrieveCertReq) validate() error {
+	if req.serialNumber == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import "time"
+
+type retrieveCertRes struct {
+	serialNumber string
+	clientID     string
+	revoked      bool
+	expiryTime   time.Time
+	certificate  []byte
+}
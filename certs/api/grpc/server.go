@@ -0,0 +1,73 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+
+	grpcCertsV1 "github.com/absmach/supermq/api/grpc/certs/v1"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/certs"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	kitgrpc "github.com/go-kit/kit/transport/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ grpcCertsV1.CertsServiceServer = (*grpcServer)(nil)
+
+type grpcServer struct {
+	grpcCertsV1.UnimplementedCertsServiceServer
+	retrieveCert kitgrpc.Handler
+}
+
+// NewServer returns new CertsServiceServer instance.
+func NewServer(svc certs.Service) grpcCertsV1.CertsServiceServer {
+	return &grpcServer{
+		retrieveCert: kitgrpc.NewServer(
+			retrieveCertEndpoint(svc),
+			decodeRetrieveCertRequest,
+			encodeRetrieveCertResponse,
+		),
+	}
+}
+
+func (s *grpcServer) RetrieveCert(ctx context.Context, req *grpcCertsV1.RetrieveCertReq) (*grpcCertsV1.RetrieveCertRes, error) {
+	_, res, err := s.retrieveCert.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*grpcCertsV1.RetrieveCertRes), nil
+}
+
+func decodeRetrieveCertRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcCertsV1.RetrieveCertReq)
+	return retrieveCertReq{serialNumber: req.GetSerialNumber()}, nil
+}
+
+func encodeRetrieveCertResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(retrieveCertRes)
+	return &grpcCertsV1.RetrieveCertRes{
+		SerialNumber: res.serialNumber,
+		ClientId:     res.clientID,
+		Revoked:      res.revoked,
+		ExpiryTime:   timestamppb.New(res.expiryTime),
+		Certificate:  res.certificate,
+	}, nil
+}
+
+func encodeError(err error) error {
+	switch {
+	case errors.Contains(err, nil):
+		return nil
+	case err == apiutil.ErrMissingID:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Contains(err, svcerr.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
@@ -3,9 +3,12 @@
 package amcerts
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/absmach/certs/sdk"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
 )
 
 type Cert struct {
@@ -71,6 +74,9 @@ func (c sdkAgent) Issue(entityId, ttl string, ipAddrs []string) (Cert, error) {
 func (c sdkAgent) View(serial string) (Cert, error) {
 	cert, err := c.sdk.ViewCert(serial)
 	if err != nil {
+		if err.StatusCode() == http.StatusNotFound {
+			return Cert{}, errors.Wrap(svcerr.ErrNotFound, err)
+		}
 		return Cert{}, err
 	}
 	return Cert{
@@ -15,17 +15,18 @@ func authorizeEndpoint(svc channels.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(authorizeReq)
 
-		if err := svc.Authorize(ctx, ch.AuthzReq{
+		data, err := svc.Authorize(ctx, ch.AuthzReq{
 			DomainID:   req.domainID,
 			ClientID:   req.clientID,
 			ClientType: req.clientType,
 			ChannelID:  req.channelID,
 			Type:       req.connType,
-		}); err != nil {
+		})
+		if err != nil {
 			return authorizeRes{}, err
 		}
 
-		return authorizeRes{authorized: true}, nil
+		return authorizeRes{authorized: true, schema: data}, nil
 	}
 }
 
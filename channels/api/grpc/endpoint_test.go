@@ -70,6 +70,7 @@ func TestAuthorize(t *testing.T) {
 		channelID  string
 		connType   connections.ConnType
 		err        error
+		authzRes   []byte
 		authzErr   error
 		res        *grpcChannelsV1.AuthzRes
 		code       codes.Code
@@ -84,6 +85,17 @@ func TestAuthorize(t *testing.T) {
 			res:        &grpcChannelsV1.AuthzRes{Authorized: true},
 			err:        nil,
 		},
+		{
+			desc:       "authorize publish to channel with schema successfully",
+			domainID:   validID,
+			clientID:   validID,
+			clientType: policies.ClientType,
+			channelID:  validID,
+			connType:   connections.Publish,
+			authzRes:   []byte(`{"json":{"type":"object"}}`),
+			res:        &grpcChannelsV1.AuthzRes{Authorized: true, Schema: []byte(`{"json":{"type":"object"}}`)},
+			err:        nil,
+		},
 		{
 			desc:       "authorize with authorization  error",
 			domainID:   validID,
@@ -117,7 +129,7 @@ func TestAuthorize(t *testing.T) {
 				ChannelID:  tc.channelID,
 				Type:       tc.connType,
 			}
-			svcCall := svc.On("Authorize", mock.Anything, authReq).Return(tc.authzErr)
+			svcCall := svc.On("Authorize", mock.Anything, authReq).Return(tc.authzRes, tc.authzErr)
 			res, err := client.Authorize(context.Background(), &grpcChannelsV1.AuthzReq{
 				DomainId:   tc.domainID,
 				ClientId:   tc.clientID,
@@ -5,6 +5,7 @@ package grpc
 
 type authorizeRes struct {
 	authorized bool
+	schema     []byte
 }
 
 type removeClientConnectionsRes struct{}
@@ -275,3 +275,16 @@ func decodeDisconnectRequest(_ context.Context, r *http.Request) (interface{}, e
 
 	return req, nil
 }
+
+func decodeProvisionRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := provisionReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req.Manifest); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
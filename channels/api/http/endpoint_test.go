@@ -1993,6 +1993,212 @@ func TestDeleteChannelEndpoint(t *testing.T) {
 	}
 }
 
+func TestProvisionEndpoint(t *testing.T) {
+	gs, svc, authn := newChannelsServer()
+	defer gs.Close()
+
+	manifest := channels.Manifest{
+		Channels: []channels.ManifestChannel{{Key: "telemetry", Name: valid}},
+		Clients:  []channels.ManifestClient{{Key: "sensor", Name: valid}},
+		Connections: []channels.ManifestConnection{
+			{ClientKey: "sensor", ChannelKey: "telemetry", Types: []connections.ConnType{connections.Publish}},
+		},
+		AllOrNothing: true,
+	}
+	report := channels.ProvisionReport{
+		Rows: []channels.ProvisionRow{
+			{Kind: channels.ProvisionKindChannel, Key: "telemetry", ID: validID},
+			{Kind: channels.ProvisionKindClient, Key: "sensor", ID: validID, Secret: valid},
+			{Kind: channels.ProvisionKindConnection, Key: channels.ConnectionKey("sensor", "telemetry")},
+		},
+	}
+	failedReport := channels.ProvisionReport{
+		Rows: []channels.ProvisionRow{
+			{Kind: channels.ProvisionKindChannel, Key: "telemetry", Err: channels.ErrProvisionRolledBack},
+			{Kind: channels.ProvisionKindClient, Key: "sensor", Err: svcerr.ErrCreateEntity},
+			{Kind: channels.ProvisionKindConnection, Key: channels.ConnectionKey("sensor", "telemetry"), Err: channels.ErrProvisionSkipped},
+		},
+		RolledBack: true,
+	}
+
+	duplicateKey := manifest
+	duplicateKey.Clients = []channels.ManifestClient{{Key: "sensor"}, {Key: "sensor"}}
+	missingKey := manifest
+	missingKey.Channels = []channels.ManifestChannel{{Name: valid}}
+	unknownKey := manifest
+	unknownKey.Connections = []channels.ManifestConnection{
+		{ClientKey: "sensor", ChannelKey: "unknown", Types: []connections.ConnType{connections.Publish}},
+	}
+	missingTypes := manifest
+	missingTypes.Connections = []channels.ManifestConnection{{ClientKey: "sensor", ChannelKey: "telemetry"}}
+
+	cases := []struct {
+		desc        string
+		token       string
+		domainID    string
+		contentType string
+		data        string
+		manifest    channels.Manifest
+		session     smqauthn.Session
+		report      channels.ProvisionReport
+		svcErr      error
+		authnErr    error
+		status      int
+		failed      uint64
+		err         error
+	}{
+		{
+			desc:        "provision successfully",
+			token:       validToken,
+			domainID:    validID,
+			contentType: contentType,
+			data:        toJSON(manifest),
+			manifest:    manifest,
+			report:      report,
+			status:      http.StatusCreated,
+		},
+		{
+			desc:        "provision with failed rows",
+			token:       validToken,
+			domainID:    validID,
+			contentType: contentType,
+			data:        toJSON(manifest),
+			manifest:    manifest,
+			report:      failedReport,
+			status:      http.StatusMultiStatus,
+			failed:      3,
+		},
+		{
+			desc:        "provision with invalid token",
+			token:       invalidToken,
+			domainID:    validID,
+			contentType: contentType,
+			data:        toJSON(manifest),
+			manifest:    manifest,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "provision with invalid content type",
+			token:       validToken,
+			domainID:    validID,
+			contentType: "application/xml",
+			data:        toJSON(manifest),
+			manifest:    manifest,
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "provision with malformed manifest",
+			token:       validToken,
+			domainID:    validID,
+			contentType: contentType,
+			data:        "{",
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "provision with empty manifest",
+			token:       validToken,
+			domainID:    validID,
+			contentType: contentType,
+			data:        toJSON(channels.Manifest{}),
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrEmptyList,
+		},
+		{
+			desc:        "provision with missing key",
+			token:       validToken,
+			domainID:    validID,
+			contentType: contentType,
+			data:        toJSON(missingKey),
+			manifest:    missingKey,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingManifestKey,
+		},
+		{
+			desc:        "provision with duplicate key",
+			token:       validToken,
+			domainID:    validID,
+			contentType: contentType,
+			data:        toJSON(duplicateKey),
+			manifest:    duplicateKey,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrDuplicateManifestKey,
+		},
+		{
+			desc:        "provision with unknown connection key",
+			token:       validToken,
+			domainID:    validID,
+			contentType: contentType,
+			data:        toJSON(unknownKey),
+			manifest:    unknownKey,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrUnknownManifestKey,
+		},
+		{
+			desc:        "provision with missing connection types",
+			token:       validToken,
+			domainID:    validID,
+			contentType: contentType,
+			data:        toJSON(missingTypes),
+			manifest:    missingTypes,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrMissingConnectionType,
+		},
+		{
+			desc:        "provision with service error",
+			token:       validToken,
+			domainID:    validID,
+			contentType: contentType,
+			data:        toJSON(manifest),
+			manifest:    manifest,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      gs.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/channels/provision", gs.URL, tc.domainID),
+				token:       tc.token,
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID + "_" + validID, UserID: validID, DomainID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("Provision", mock.Anything, tc.session, tc.manifest).Return(tc.report, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var body struct {
+				respBody
+				Rows   []map[string]string `json:"rows"`
+				Failed uint64              `json:"failed"`
+			}
+			err = json.NewDecoder(res.Body).Decode(&body)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if body.Err != "" || body.Message != "" {
+				err = errors.Wrap(errors.New(body.Err), errors.New(body.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, len(tc.report.Rows), len(body.Rows), fmt.Sprintf("%s: unexpected number of report rows", tc.desc))
+				assert.Equal(t, tc.failed, body.Failed, fmt.Sprintf("%s: expected %d failed rows got %d", tc.desc, tc.failed, body.Failed))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 type testRequest struct {
 	client      *http.Client
 	method      string
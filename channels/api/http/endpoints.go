@@ -381,3 +381,42 @@ func deleteChannelEndpoint(svc channels.Service) endpoint.Endpoint {
 		return deleteChannelRes{}, nil
 	}
 }
+
+func provisionEndpoint(svc channels.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(provisionReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		report, err := svc.Provision(ctx, session, req.Manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		res := provisionRes{
+			Rows:       []provisionRowRes{},
+			RolledBack: report.RolledBack,
+		}
+		for _, row := range report.Rows {
+			r := provisionRowRes{
+				Kind:   row.Kind,
+				Key:    row.Key,
+				ID:     row.ID,
+				Secret: row.Secret,
+			}
+			if row.Err != nil {
+				r.Error = row.Err.Error()
+				res.Failed++
+			}
+			res.Rows = append(res.Rows, r)
+		}
+
+		return res, nil
+	}
+}
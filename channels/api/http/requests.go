@@ -4,6 +4,7 @@
 package http
 
 import (
+	"fmt"
 	"strings"
 
 	api "github.com/absmach/supermq/api/http"
@@ -11,6 +12,8 @@ import (
 	"github.com/absmach/supermq/channels"
 	"github.com/absmach/supermq/clients"
 	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/schema"
 )
 
 type createChannelReq struct {
@@ -26,6 +29,9 @@ func (req createChannelReq) validate() error {
 			return apiutil.ErrMissingChannelID
 		}
 	}
+	if err := schema.ValidateMetadata(req.Channel.Metadata); err != nil {
+		return errors.Wrap(apiutil.ErrInvalidSchema, err)
+	}
 
 	return nil
 }
@@ -47,6 +53,9 @@ func (req createChannelsReq) validate() error {
 		if len(channel.Name) > api.MaxNameSize {
 			return apiutil.ErrNameSize
 		}
+		if err := schema.ValidateMetadata(channel.Metadata); err != nil {
+			return errors.Wrap(apiutil.ErrInvalidSchema, err)
+		}
 	}
 
 	return nil
@@ -108,6 +117,9 @@ func (req updateChannelReq) validate() error {
 	if len(req.Name) > api.MaxNameSize {
 		return apiutil.ErrNameSize
 	}
+	if err := schema.ValidateMetadata(req.Metadata); err != nil {
+		return errors.Wrap(apiutil.ErrInvalidSchema, err)
+	}
 
 	return nil
 }
@@ -301,3 +313,67 @@ func (req deleteChannelReq) validate() error {
 	}
 	return nil
 }
+
+type provisionReq struct {
+	channels.Manifest
+}
+
+func (req provisionReq) validate() error {
+	if len(req.Clients) == 0 && len(req.Channels) == 0 {
+		return apiutil.ErrEmptyList
+	}
+
+	clientKeys := map[string]bool{}
+	for _, c := range req.Clients {
+		if strings.TrimSpace(c.Key) == "" {
+			return apiutil.ErrMissingManifestKey
+		}
+		if clientKeys[c.Key] {
+			return errors.Wrap(apiutil.ErrDuplicateManifestKey, fmt.Errorf("client key %s", c.Key))
+		}
+		clientKeys[c.Key] = true
+		if len(c.Name) > api.MaxNameSize {
+			return apiutil.ErrNameSize
+		}
+		if err := schema.ValidateMetadata(c.Metadata); err != nil {
+			return errors.Wrap(apiutil.ErrInvalidSchema, err)
+		}
+	}
+
+	channelKeys := map[string]bool{}
+	for _, c := range req.Channels {
+		if strings.TrimSpace(c.Key) == "" {
+			return apiutil.ErrMissingManifestKey
+		}
+		if channelKeys[c.Key] {
+			return errors.Wrap(apiutil.ErrDuplicateManifestKey, fmt.Errorf("channel key %s", c.Key))
+		}
+		channelKeys[c.Key] = true
+		if len(c.Name) > api.MaxNameSize {
+			return apiutil.ErrNameSize
+		}
+		if err := schema.ValidateMetadata(c.Metadata); err != nil {
+			return errors.Wrap(apiutil.ErrInvalidSchema, err)
+		}
+	}
+
+	connKeys := map[string]bool{}
+	for _, c := range req.Connections {
+		if !clientKeys[c.ClientKey] {
+			return errors.Wrap(apiutil.ErrUnknownManifestKey, fmt.Errorf("client key %s", c.ClientKey))
+		}
+		if !channelKeys[c.ChannelKey] {
+			return errors.Wrap(apiutil.ErrUnknownManifestKey, fmt.Errorf("channel key %s", c.ChannelKey))
+		}
+		key := channels.ConnectionKey(c.ClientKey, c.ChannelKey)
+		if connKeys[key] {
+			return errors.Wrap(apiutil.ErrDuplicateManifestKey, fmt.Errorf("connection %s", key))
+		}
+		connKeys[key] = true
+		if len(c.Types) == 0 {
+			return apiutil.ErrMissingConnectionType
+		}
+	}
+
+	return nil
+}
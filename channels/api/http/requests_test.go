@@ -13,9 +13,24 @@ import (
 	"github.com/absmach/supermq/channels"
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/schema"
 	"github.com/stretchr/testify/assert"
 )
 
+var (
+	validSchemaMetadata = map[string]interface{}{
+		schema.MetadataKey: map[string]interface{}{
+			"senml": map[string]interface{}{"temp": map[string]interface{}{"unit": "Cel", "min": -40, "max": 85}},
+		},
+	}
+	invalidSchemaMetadata = map[string]interface{}{
+		schema.MetadataKey: map[string]interface{}{
+			"json": map[string]interface{}{"type": "unknown"},
+		},
+	}
+)
+
 func TestCreateChannelReqValidation(t *testing.T) {
 	cases := []struct {
 		desc string
@@ -49,11 +64,31 @@ func TestCreateChannelReqValidation(t *testing.T) {
 			},
 			err: apiutil.ErrMissingChannelID,
 		},
+		{
+			desc: "valid request with schema",
+			req: createChannelReq{
+				Channel: channels.Channel{
+					Name:     valid,
+					Metadata: validSchemaMetadata,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "invalid schema",
+			req: createChannelReq{
+				Channel: channels.Channel{
+					Name:     valid,
+					Metadata: invalidSchemaMetadata,
+				},
+			},
+			err: apiutil.ErrInvalidSchema,
+		},
 	}
 
 	for _, tc := range cases {
 		err := tc.req.validate()
-		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
@@ -103,11 +138,23 @@ func TestCreateChannelsReqValidation(t *testing.T) {
 			},
 			err: apiutil.ErrEmptyList,
 		},
+		{
+			desc: "invalid schema",
+			req: createChannelsReq{
+				Channels: []channels.Channel{
+					{
+						Name:     valid,
+						Metadata: invalidSchemaMetadata,
+					},
+				},
+			},
+			err: apiutil.ErrInvalidSchema,
+		},
 	}
 
 	for _, tc := range cases {
 		err := tc.req.validate()
-		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
@@ -209,10 +256,26 @@ func TestUpdateChannelReqValidate(t *testing.T) {
 			},
 			err: apiutil.ErrNameSize,
 		},
+		{
+			desc: "valid request with schema",
+			req: updateChannelReq{
+				id:       valid,
+				Metadata: validSchemaMetadata,
+			},
+			err: nil,
+		},
+		{
+			desc: "invalid schema",
+			req: updateChannelReq{
+				id:       valid,
+				Metadata: invalidSchemaMetadata,
+			},
+			err: apiutil.ErrInvalidSchema,
+		},
 	}
 	for _, tc := range cases {
 		err := tc.req.validate()
-		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
 
@@ -603,3 +666,85 @@ func TestDeleteChannelReqValidate(t *testing.T) {
 		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestProvisionReqValidate(t *testing.T) {
+	conn := channels.ManifestConnection{ClientKey: "sensor", ChannelKey: "telemetry", Types: []connections.ConnType{connections.Publish}}
+	cases := []struct {
+		desc string
+		req  provisionReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: provisionReq{channels.Manifest{
+				Clients:     []channels.ManifestClient{{Key: "sensor", Name: valid}},
+				Channels:    []channels.ManifestChannel{{Key: "telemetry", Name: valid, Metadata: validSchemaMetadata}},
+				Connections: []channels.ManifestConnection{conn},
+			}},
+			err: nil,
+		},
+		{
+			desc: "empty manifest",
+			req:  provisionReq{},
+			err:  apiutil.ErrEmptyList,
+		},
+		{
+			desc: "missing client key",
+			req: provisionReq{channels.Manifest{
+				Clients: []channels.ManifestClient{{Key: " ", Name: valid}},
+			}},
+			err: apiutil.ErrMissingManifestKey,
+		},
+		{
+			desc: "duplicate channel key",
+			req: provisionReq{channels.Manifest{
+				Channels: []channels.ManifestChannel{{Key: "telemetry"}, {Key: "telemetry"}},
+			}},
+			err: apiutil.ErrDuplicateManifestKey,
+		},
+		{
+			desc: "long client name",
+			req: provisionReq{channels.Manifest{
+				Clients: []channels.ManifestClient{{Key: "sensor", Name: strings.Repeat("a", api.MaxNameSize+1)}},
+			}},
+			err: apiutil.ErrNameSize,
+		},
+		{
+			desc: "invalid channel schema",
+			req: provisionReq{channels.Manifest{
+				Channels: []channels.ManifestChannel{{Key: "telemetry", Metadata: invalidSchemaMetadata}},
+			}},
+			err: apiutil.ErrInvalidSchema,
+		},
+		{
+			desc: "connection with unknown client key",
+			req: provisionReq{channels.Manifest{
+				Channels:    []channels.ManifestChannel{{Key: "telemetry"}},
+				Connections: []channels.ManifestConnection{conn},
+			}},
+			err: apiutil.ErrUnknownManifestKey,
+		},
+		{
+			desc: "duplicate connection",
+			req: provisionReq{channels.Manifest{
+				Clients:     []channels.ManifestClient{{Key: "sensor"}},
+				Channels:    []channels.ManifestChannel{{Key: "telemetry"}},
+				Connections: []channels.ManifestConnection{conn, conn},
+			}},
+			err: apiutil.ErrDuplicateManifestKey,
+		},
+		{
+			desc: "connection without types",
+			req: provisionReq{channels.Manifest{
+				Clients:     []channels.ManifestClient{{Key: "sensor"}},
+				Channels:    []channels.ManifestChannel{{Key: "telemetry"}},
+				Connections: []channels.ManifestConnection{{ClientKey: "sensor", ChannelKey: "telemetry"}},
+			}},
+			err: apiutil.ErrMissingConnectionType,
+		},
+	}
+	for _, tc := range cases {
+		err := tc.req.validate()
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+	}
+}
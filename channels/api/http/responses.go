@@ -22,6 +22,7 @@ var (
 	_ supermq.Response = (*connectRes)(nil)
 	_ supermq.Response = (*disconnectRes)(nil)
 	_ supermq.Response = (*changeChannelStatusRes)(nil)
+	_ supermq.Response = (*provisionRes)(nil)
 )
 
 type pageRes struct {
@@ -219,3 +220,33 @@ func (res disconnectRes) Headers() map[string]string {
 func (res disconnectRes) Empty() bool {
 	return true
 }
+
+type provisionRowRes struct {
+	Kind   string `json:"kind"`
+	Key    string `json:"key"`
+	ID     string `json:"id,omitempty"`
+	Secret string `json:"secret,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type provisionRes struct {
+	Rows       []provisionRowRes `json:"rows"`
+	Failed     uint64            `json:"failed"`
+	RolledBack bool              `json:"rolled_back"`
+}
+
+func (res provisionRes) Code() int {
+	if res.Failed > 0 {
+		return http.StatusMultiStatus
+	}
+
+	return http.StatusCreated
+}
+
+func (res provisionRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res provisionRes) Empty() bool {
+	return false
+}
@@ -60,6 +60,13 @@ func MakeHandler(svc channels.Service, authn smqauthn.Authentication, mux *chi.M
 			opts...,
 		), "disconnect").ServeHTTP)
 
+		r.Post("/provision", otelhttp.NewHandler(kithttp.NewServer(
+			provisionEndpoint(svc),
+			decodeProvisionRequest,
+			api.EncodeResponse,
+			opts...,
+		), "provision").ServeHTTP)
+
 		r.Route("/{channelID}", func(r chi.Router) {
 			r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
 				viewChannelEndpoint(svc),
@@ -124,6 +124,12 @@ type Service interface {
 
 	RemoveParentGroup(ctx context.Context, session authn.Session, id string) error
 
+	// Provision creates the clients and channels of the manifest with their
+	// roles and connects them. The outcome of every manifest row is returned
+	// in the report. If the manifest requires all-or-nothing provisioning,
+	// the created entities and policies are rolled back on the first failure.
+	Provision(ctx context.Context, session authn.Session, manifest Manifest) (ProvisionReport, error)
+
 	roles.RoleManager
 }
 
@@ -167,7 +173,9 @@ type Repository interface {
 
 	CheckConnection(ctx context.Context, conn Connection) error
 
-	ClientAuthorize(ctx context.Context, conn Connection) error
+	// ClientAuthorize checks that the client is connected to the channel with
+	// the connection type and returns the channel metadata.
+	ClientAuthorize(ctx context.Context, conn Connection) (clients.Metadata, error)
 
 	ChannelConnectionsCount(ctx context.Context, id string) (uint64, error)
 
@@ -25,6 +25,7 @@ const (
 	channelDisconnect   = channelPrefix + "disconnect"
 	channelSetParent    = channelPrefix + "set_parent"
 	channelRemoveParent = channelPrefix + "remove_parent"
+	channelProvision    = channelPrefix + "provision"
 )
 
 var (
@@ -36,6 +37,7 @@ var (
 	_ events.Event = (*removeChannelEvent)(nil)
 	_ events.Event = (*connectEvent)(nil)
 	_ events.Event = (*disconnectEvent)(nil)
+	_ events.Event = (*provisionEvent)(nil)
 )
 
 type createChannelEvent struct {
@@ -352,3 +354,38 @@ func (rpge removeParentGroupEvent) Encode() (map[string]interface{}, error) {
 		"super_admin": rpge.SuperAdmin,
 	}, nil
 }
+
+type provisionEvent struct {
+	channels.ProvisionReport
+	authn.Session
+}
+
+func (pe provisionEvent) Encode() (map[string]interface{}, error) {
+	chIDs, clIDs := []string{}, []string{}
+	var conns, failed int
+	for _, row := range pe.Rows {
+		switch {
+		case row.Err != nil:
+			failed++
+		case row.Kind == channels.ProvisionKindChannel:
+			chIDs = append(chIDs, row.ID)
+		case row.Kind == channels.ProvisionKindClient:
+			clIDs = append(clIDs, row.ID)
+		case row.Kind == channels.ProvisionKindConnection:
+			conns++
+		}
+	}
+
+	return map[string]interface{}{
+		"operation":   channelProvision,
+		"channel_ids": chIDs,
+		"client_ids":  clIDs,
+		"connections": conns,
+		"failed":      failed,
+		"rolled_back": pe.RolledBack,
+		"domain":      pe.DomainID,
+		"user_id":     pe.UserID,
+		"token_type":  pe.Type.String(),
+		"super_admin": pe.SuperAdmin,
+	}, nil
+}
@@ -265,3 +265,21 @@ func (es *eventStore) RemoveParentGroup(ctx context.Context, session authn.Sessi
 
 	return nil
 }
+
+func (es *eventStore) Provision(ctx context.Context, session authn.Session, manifest channels.Manifest) (channels.ProvisionReport, error) {
+	report, err := es.svc.Provision(ctx, session, manifest)
+	if err != nil {
+		return report, err
+	}
+
+	event := provisionEvent{
+		ProvisionReport: report,
+		Session:         session,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
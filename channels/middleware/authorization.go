@@ -37,6 +37,8 @@ var (
 	errGroupRemoveChildChannels = errors.New("not authorized to remove child channel for group")
 	errClientDisConnectChannels = errors.New("not authorized to disconnect channel for client")
 	errClientConnectChannels    = errors.New("not authorized to connect channel for client")
+	errDomainCreateClients      = errors.New("not authorized to create client in domain")
+	errGroupSetChildClients     = errors.New("not authorized to set child client for group")
 )
 
 var _ channels.Service = (*authorizationMiddleware)(nil)
@@ -512,6 +514,91 @@ func (am *authorizationMiddleware) RemoveParentGroup(ctx context.Context, sessio
 	return nil
 }
 
+func (am *authorizationMiddleware) Provision(ctx context.Context, session authn.Session, manifest channels.Manifest) (channels.ProvisionReport, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if len(manifest.Channels) > 0 {
+			if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+				UserID:                   session.UserID,
+				PatID:                    session.PatID,
+				PlatformEntityType:       auth.PlatformDomainsScope,
+				OptionalDomainID:         session.DomainID,
+				OptionalDomainEntityType: auth.DomainChannelsScope,
+				Operation:                auth.CreateOp,
+				EntityIDs:                auth.AnyIDs{}.Values(),
+			}); err != nil {
+				return channels.ProvisionReport{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+			}
+		}
+		if len(manifest.Clients) > 0 {
+			if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+				UserID:                   session.UserID,
+				PatID:                    session.PatID,
+				PlatformEntityType:       auth.PlatformDomainsScope,
+				OptionalDomainID:         session.DomainID,
+				OptionalDomainEntityType: auth.DomainClientsScope,
+				Operation:                auth.CreateOp,
+				EntityIDs:                auth.AnyIDs{}.Values(),
+			}); err != nil {
+				return channels.ProvisionReport{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+			}
+		}
+	}
+
+	domainReq := smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	}
+	if len(manifest.Channels) > 0 {
+		if err := am.extAuthorize(ctx, channels.DomainOpCreateChannel, domainReq); err != nil {
+			return channels.ProvisionReport{}, errors.Wrap(err, errDomainCreateChannels)
+		}
+	}
+	if len(manifest.Clients) > 0 {
+		if err := am.extAuthorize(ctx, channels.DomainOpCreateClient, domainReq); err != nil {
+			return channels.ProvisionReport{}, errors.Wrap(err, errDomainCreateClients)
+		}
+	}
+
+	checked := map[string]bool{}
+	for _, ch := range manifest.Channels {
+		if ch.ParentGroup == "" || checked[ch.ParentGroup] {
+			continue
+		}
+		if err := am.extAuthorize(ctx, channels.GroupOpSetChildChannel, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			ObjectType:  policies.GroupType,
+			Object:      ch.ParentGroup,
+		}); err != nil {
+			return channels.ProvisionReport{}, errors.Wrap(err, errors.Wrap(errGroupSetChildChannels, fmt.Errorf("channel key %s parent group id %s", ch.Key, ch.ParentGroup)))
+		}
+		checked[ch.ParentGroup] = true
+	}
+
+	checked = map[string]bool{}
+	for _, cli := range manifest.Clients {
+		if cli.ParentGroup == "" || checked[cli.ParentGroup] {
+			continue
+		}
+		if err := am.extAuthorize(ctx, channels.GroupOpSetChildClient, smqauthz.PolicyReq{
+			Domain:      session.DomainID,
+			SubjectType: policies.UserType,
+			Subject:     session.DomainUserID,
+			ObjectType:  policies.GroupType,
+			Object:      cli.ParentGroup,
+		}); err != nil {
+			return channels.ProvisionReport{}, errors.Wrap(err, errors.Wrap(errGroupSetChildClients, fmt.Errorf("client key %s parent group id %s", cli.Key, cli.ParentGroup)))
+		}
+		checked[cli.ParentGroup] = true
+	}
+
+	return am.svc.Provision(ctx, session, manifest)
+}
+
 func (am *authorizationMiddleware) authorize(ctx context.Context, op svcutil.Operation, req smqauthz.PolicyReq) error {
 	perm, err := am.opp.GetPermission(op)
 	if err != nil {
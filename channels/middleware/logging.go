@@ -263,3 +263,29 @@ func (lm *loggingMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	}(time.Now())
 	return lm.svc.RemoveParentGroup(ctx, session, id)
 }
+
+func (lm *loggingMiddleware) Provision(ctx context.Context, session authn.Session, manifest channels.Manifest) (report channels.ProvisionReport, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("manifest",
+				slog.Int("clients", len(manifest.Clients)),
+				slog.Int("channels", len(manifest.Channels)),
+				slog.Int("connections", len(manifest.Connections)),
+				slog.Bool("all_or_nothing", manifest.AllOrNothing),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Provision failed", args...)
+			return
+		}
+		if report.Failed() {
+			args = append(args, slog.Bool("rolled_back", report.RolledBack))
+			lm.logger.Warn("Provision completed with failed rows", args...)
+			return
+		}
+		lm.logger.Info("Provision completed successfully", args...)
+	}(time.Now())
+	return lm.svc.Provision(ctx, session, manifest)
+}
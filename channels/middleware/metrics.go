@@ -137,3 +137,11 @@ func (ms *metricsMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	}(time.Now())
 	return ms.svc.RemoveParentGroup(ctx, session, id)
 }
+
+func (ms *metricsMiddleware) Provision(ctx context.Context, session authn.Session, manifest channels.Manifest) (channels.ProvisionReport, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "provision").Add(1)
+		ms.latency.With("method", "provision").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Provision(ctx, session, manifest)
+}
@@ -5,9 +5,10 @@
 package mocks
 
 import (
-	context "context"
-
 	channels "github.com/absmach/supermq/channels"
+	clients "github.com/absmach/supermq/clients"
+
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 
@@ -142,21 +143,33 @@ func (_m *Repository) CheckConnection(ctx context.Context, conn channels.Connect
 }
 
 // ClientAuthorize provides a mock function with given fields: ctx, conn
-func (_m *Repository) ClientAuthorize(ctx context.Context, conn channels.Connection) error {
+func (_m *Repository) ClientAuthorize(ctx context.Context, conn channels.Connection) (clients.Metadata, error) {
 	ret := _m.Called(ctx, conn)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ClientAuthorize")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, channels.Connection) error); ok {
+	var r0 clients.Metadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, channels.Connection) (clients.Metadata, error)); ok {
+		return rf(ctx, conn)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, channels.Connection) clients.Metadata); ok {
 		r0 = rf(ctx, conn)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(clients.Metadata)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, channels.Connection) error); ok {
+		r1 = rf(ctx, conn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // DoesChannelHaveConnections provides a mock function with given fields: ctx, id
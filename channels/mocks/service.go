@@ -274,6 +274,34 @@ func (_m *Service) ListUserChannels(ctx context.Context, session authn.Session,
 	return r0, r1
 }
 
+// Provision provides a mock function with given fields: ctx, session, manifest
+func (_m *Service) Provision(ctx context.Context, session authn.Session, manifest channels.Manifest) (channels.ProvisionReport, error) {
+	ret := _m.Called(ctx, session, manifest)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Provision")
+	}
+
+	var r0 channels.ProvisionReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, channels.Manifest) (channels.ProvisionReport, error)); ok {
+		return rf(ctx, session, manifest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, channels.Manifest) channels.ProvisionReport); ok {
+		r0 = rf(ctx, session, manifest)
+	} else {
+		r0 = ret.Get(0).(channels.ProvisionReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, channels.Manifest) error); ok {
+		r1 = rf(ctx, session, manifest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveChannel provides a mock function with given fields: ctx, session, id
 func (_m *Service) RemoveChannel(ctx context.Context, session authn.Session, id string) error {
 	ret := _m.Called(ctx, session, id)
@@ -749,19 +749,32 @@ func (cr *channelRepository) CheckConnection(ctx context.Context, conn channels.
 	return nil
 }
 
-func (cr *channelRepository) ClientAuthorize(ctx context.Context, conn channels.Connection) error {
-	query := `SELECT 1 FROM connections WHERE channel_id = :channel_id AND client_id = :client_id AND type = :type LIMIT 1`
+func (cr *channelRepository) ClientAuthorize(ctx context.Context, conn channels.Connection) (clients.Metadata, error) {
+	query := `SELECT ch.metadata FROM connections conn JOIN channels ch ON ch.id = conn.channel_id
+		WHERE conn.channel_id = :channel_id AND conn.client_id = :client_id AND conn.type = :type LIMIT 1`
 	dbConn := toDBConnection(conn)
 	rows, err := cr.db.NamedQueryContext(ctx, query, dbConn)
 	if err != nil {
-		return postgres.HandleError(repoerr.ErrViewEntity, err)
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
 	}
 	defer rows.Close()
 
 	if !rows.Next() {
-		return repoerr.ErrNotFound
+		return nil, repoerr.ErrNotFound
 	}
-	return nil
+
+	var data []byte
+	if err := rows.Scan(&data); err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	var metadata clients.Metadata
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &metadata); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+	}
+
+	return metadata, nil
 }
 
 func (cr *channelRepository) ChannelConnectionsCount(ctx context.Context, id string) (uint64, error) {
@@ -1075,11 +1075,13 @@ func TestClientAuthorize(t *testing.T) {
 	cases := []struct {
 		desc       string
 		connection channels.Connection
+		metadata   clients.Metadata
 		err        error
 	}{
 		{
 			desc:       "authorize successfully",
 			connection: validConnection,
+			metadata:   validChannel.Metadata,
 			err:        nil,
 		},
 		{
@@ -1106,8 +1108,9 @@ func TestClientAuthorize(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			err := repo.ClientAuthorize(context.Background(), tc.connection)
+			metadata, err := repo.ClientAuthorize(context.Background(), tc.connection)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.metadata, metadata, fmt.Sprintf("%s: expected metadata %v got %v\n", tc.desc, tc.metadata, metadata))
 		})
 	}
 }
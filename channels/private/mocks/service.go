@@ -18,21 +18,33 @@ type Service struct {
 }
 
 // Authorize provides a mock function with given fields: ctx, req
-func (_m *Service) Authorize(ctx context.Context, req channels.AuthzReq) error {
+func (_m *Service) Authorize(ctx context.Context, req channels.AuthzReq) ([]byte, error) {
 	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Authorize")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, channels.AuthzReq) error); ok {
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, channels.AuthzReq) ([]byte, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, channels.AuthzReq) []byte); ok {
 		r0 = rf(ctx, req)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, channels.AuthzReq) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // RemoveClientConnections provides a mock function with given fields: ctx, clientID
@@ -7,14 +7,18 @@ import (
 	"context"
 
 	"github.com/absmach/supermq/channels"
+	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/schema"
 )
 
 //go:generate mockery --name Service  --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
 type Service interface {
-	Authorize(ctx context.Context, req channels.AuthzReq) error
+	// Authorize checks the access to the channel. The channel message schema,
+	// if any, is returned for the publish requests.
+	Authorize(ctx context.Context, req channels.AuthzReq) ([]byte, error)
 	UnsetParentGroupFromChannels(ctx context.Context, parentGroupID string) error
 	RemoveClientConnections(ctx context.Context, clientID string) error
 	RetrieveByID(ctx context.Context, id string) (channels.Channel, error)
@@ -32,7 +36,7 @@ func New(repo channels.Repository, evaluator policies.Evaluator, policy policies
 	return service{repo, evaluator, policy}
 }
 
-func (svc service) Authorize(ctx context.Context, req channels.AuthzReq) error {
+func (svc service) Authorize(ctx context.Context, req channels.AuthzReq) ([]byte, error) {
 	switch req.ClientType {
 	case policies.UserType:
 		pr := policies.Policy{
@@ -42,21 +46,33 @@ func (svc service) Authorize(ctx context.Context, req channels.AuthzReq) error {
 			ObjectType:  policies.ChannelType,
 		}
 		if err := svc.evaluator.CheckPolicy(ctx, pr); err != nil {
-			return errors.Wrap(svcerr.ErrAuthorization, err)
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
 		}
-		return nil
+		if req.Type != connections.Publish {
+			return nil, nil
+		}
+		ch, err := svc.repo.RetrieveByID(ctx, req.ChannelID)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		return schema.FromMetadata(ch.Metadata)
 	case policies.ClientType:
-		// Optimization: Add cache
-		if err := svc.repo.ClientAuthorize(ctx, channels.Connection{
+		// The channel metadata is retrieved along with the connection, so
+		// the schema doesn't cost an additional query.
+		metadata, err := svc.repo.ClientAuthorize(ctx, channels.Connection{
 			ChannelID: req.ChannelID,
 			ClientID:  req.ClientID,
 			Type:      req.Type,
-		}); err != nil {
-			return errors.Wrap(svcerr.ErrAuthorization, err)
+		})
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		if req.Type != connections.Publish {
+			return nil, nil
 		}
-		return nil
+		return schema.FromMetadata(metadata)
 	default:
-		return svcerr.ErrAuthentication
+		return nil, svcerr.ErrAuthentication
 	}
 }
 
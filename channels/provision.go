@@ -0,0 +1,377 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	smqclients "github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/roles"
+)
+
+// Kinds of the provisioned entities reported in the ProvisionReport.
+const (
+	ProvisionKindClient     = "client"
+	ProvisionKindChannel    = "channel"
+	ProvisionKindConnection = "connection"
+)
+
+var (
+	// ErrProvisionSkipped indicates that the manifest row was not provisioned
+	// because an earlier row it depends on failed.
+	ErrProvisionSkipped = errors.New("skipped due to a failed dependency")
+
+	// ErrProvisionRolledBack indicates that the manifest row was provisioned
+	// and then rolled back because another row of the manifest failed.
+	ErrProvisionRolledBack = errors.New("rolled back due to a failed row")
+)
+
+// Manifest describes the clients and channels to be created in the domain
+// and the connections between them. Clients and channels are identified
+// within the manifest by their keys.
+type Manifest struct {
+	Clients     []ManifestClient     `json:"clients,omitempty"`
+	Channels    []ManifestChannel    `json:"channels,omitempty"`
+	Connections []ManifestConnection `json:"connections,omitempty"`
+
+	// AllOrNothing rolls back all the created entities and policies if
+	// any of the manifest rows fails.
+	AllOrNothing bool `json:"all_or_nothing,omitempty"`
+}
+
+// ManifestClient is a client to be provisioned.
+type ManifestClient struct {
+	Key         string              `json:"key"`
+	Name        string              `json:"name,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Metadata    smqclients.Metadata `json:"metadata,omitempty"`
+	ParentGroup string              `json:"parent_group_id,omitempty"`
+	Secret      string              `json:"secret,omitempty"`
+}
+
+// ManifestChannel is a channel to be provisioned.
+type ManifestChannel struct {
+	Key         string              `json:"key"`
+	Name        string              `json:"name,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Metadata    smqclients.Metadata `json:"metadata,omitempty"`
+	ParentGroup string              `json:"parent_group_id,omitempty"`
+}
+
+// ManifestConnection connects the manifest client to the manifest channel
+// for the given connection types.
+type ManifestConnection struct {
+	ClientKey  string                 `json:"client_key"`
+	ChannelKey string                 `json:"channel_key"`
+	Types      []connections.ConnType `json:"types"`
+}
+
+// ProvisionRow is the outcome of a single manifest row.
+type ProvisionRow struct {
+	Kind   string
+	Key    string
+	ID     string
+	Secret string
+	Err    error
+}
+
+// ProvisionReport contains the outcome of every manifest row in the order
+// of channels, clients and connections.
+type ProvisionReport struct {
+	Rows       []ProvisionRow
+	RolledBack bool
+}
+
+// Failed returns true if any of the manifest rows failed.
+func (r ProvisionReport) Failed() bool {
+	for _, row := range r.Rows {
+		if row.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ConnectionKey returns the key of the manifest connection used in the report.
+func ConnectionKey(clientKey, channelKey string) string {
+	return clientKey + ":" + channelKey
+}
+
+func (svc service) Provision(ctx context.Context, session authn.Session, m Manifest) (ProvisionReport, error) {
+	if err := svc.validateParentGroups(ctx, session, m); err != nil {
+		return ProvisionReport{}, err
+	}
+
+	var (
+		report   ProvisionReport
+		failed   bool
+		channels []Channel
+		clients  []string
+		conns    []Connection
+	)
+	chIDs := map[string]string{}
+	clIDs := map[string]string{}
+	add := func(row ProvisionRow, err error) {
+		row.Err = err
+		report.Rows = append(report.Rows, row)
+		if err != nil && err != ErrProvisionSkipped {
+			failed = true
+		}
+	}
+
+	for _, mc := range m.Channels {
+		row := ProvisionRow{Kind: ProvisionKindChannel, Key: mc.Key}
+		if failed && m.AllOrNothing {
+			add(row, ErrProvisionSkipped)
+			continue
+		}
+		ch, err := svc.provisionChannel(ctx, session, mc)
+		if err != nil {
+			add(row, err)
+			continue
+		}
+		channels = append(channels, ch)
+		chIDs[mc.Key] = ch.ID
+		row.ID = ch.ID
+		add(row, nil)
+	}
+
+	for _, mc := range m.Clients {
+		row := ProvisionRow{Kind: ProvisionKindClient, Key: mc.Key}
+		if failed && m.AllOrNothing {
+			add(row, ErrProvisionSkipped)
+			continue
+		}
+		cli, err := svc.provisionClient(ctx, session, mc)
+		if err != nil {
+			add(row, err)
+			continue
+		}
+		clients = append(clients, cli.GetId())
+		clIDs[mc.Key] = cli.GetId()
+		row.ID = cli.GetId()
+		row.Secret = cli.GetSecret()
+		add(row, nil)
+	}
+
+	for _, mc := range m.Connections {
+		row := ProvisionRow{Kind: ProvisionKindConnection, Key: ConnectionKey(mc.ClientKey, mc.ChannelKey)}
+		clientID, clOK := clIDs[mc.ClientKey]
+		channelID, chOK := chIDs[mc.ChannelKey]
+		if (failed && m.AllOrNothing) || !clOK || !chOK {
+			add(row, ErrProvisionSkipped)
+			continue
+		}
+		cs := []Connection{}
+		for _, connType := range mc.Types {
+			cs = append(cs, Connection{
+				ClientID:  clientID,
+				ChannelID: channelID,
+				DomainID:  session.DomainID,
+				Type:      connType,
+			})
+		}
+		if err := svc.addConnections(ctx, cs); err != nil {
+			add(row, err)
+			continue
+		}
+		conns = append(conns, cs...)
+		add(row, nil)
+	}
+
+	if !failed || !m.AllOrNothing {
+		return report, nil
+	}
+
+	if err := svc.rollbackProvision(ctx, session, conns, clients, channels); err != nil {
+		return report, err
+	}
+	for i, row := range report.Rows {
+		if row.Err == nil {
+			report.Rows[i] = ProvisionRow{Kind: row.Kind, Key: row.Key, Err: ErrProvisionRolledBack}
+		}
+	}
+	report.RolledBack = true
+
+	return report, nil
+}
+
+func (svc service) validateParentGroups(ctx context.Context, session authn.Session, m Manifest) error {
+	groups := map[string]bool{}
+	for _, c := range m.Channels {
+		groups[c.ParentGroup] = true
+	}
+	for _, c := range m.Clients {
+		groups[c.ParentGroup] = true
+	}
+	delete(groups, "")
+
+	for id := range groups {
+		resp, err := svc.groups.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: id})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrMalformedEntity, errors.Wrap(fmt.Errorf("parent group id %s", id), err))
+		}
+		if resp.GetEntity().GetDomainId() != session.DomainID {
+			return errors.Wrap(svcerr.ErrMalformedEntity, fmt.Errorf("parent group id %s has invalid domain id", id))
+		}
+		if resp.GetEntity().GetStatus() != uint32(smqclients.EnabledStatus) {
+			return errors.Wrap(svcerr.ErrMalformedEntity, fmt.Errorf("parent group id %s is not in enabled state", id))
+		}
+	}
+
+	return nil
+}
+
+func (svc service) provisionChannel(ctx context.Context, session authn.Session, mc ManifestChannel) (retCh Channel, retErr error) {
+	id, err := svc.idProvider.ID()
+	if err != nil {
+		return Channel{}, err
+	}
+	chs, err := svc.repo.Save(ctx, Channel{
+		ID:          id,
+		Name:        mc.Name,
+		Tags:        mc.Tags,
+		Metadata:    mc.Metadata,
+		ParentGroup: mc.ParentGroup,
+		Domain:      session.DomainID,
+		Status:      smqclients.EnabledStatus,
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		return Channel{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollBack := svc.repo.Remove(ctx, id); errRollBack != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(svcerr.ErrRollbackRepo, errRollBack))
+			}
+		}
+	}()
+
+	newBuiltInRoleMembers := map[roles.BuiltInRoleName][]roles.Member{
+		BuiltInRoleAdmin: {roles.Member(session.UserID)},
+	}
+	optionalPolicies := []policies.Policy{
+		{
+			SubjectType: policies.DomainType,
+			Subject:     session.DomainID,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ChannelType,
+			Object:      id,
+		},
+	}
+	if mc.ParentGroup != "" {
+		optionalPolicies = append(optionalPolicies, policies.Policy{
+			Domain:      session.DomainID,
+			SubjectType: policies.GroupType,
+			Subject:     mc.ParentGroup,
+			Relation:    policies.ParentGroupRelation,
+			ObjectType:  policies.ChannelType,
+			Object:      id,
+		})
+	}
+	if _, err := svc.AddNewEntitiesRoles(ctx, session.DomainID, session.UserID, []string{id}, optionalPolicies, newBuiltInRoleMembers); err != nil {
+		return Channel{}, errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+
+	return chs[0], nil
+}
+
+func (svc service) provisionClient(ctx context.Context, session authn.Session, mc ManifestClient) (*grpcClientsV1.Client, error) {
+	var metadata []byte
+	if mc.Metadata != nil {
+		var err error
+		if metadata, err = json.Marshal(mc.Metadata); err != nil {
+			return nil, errors.Wrap(svcerr.ErrMalformedEntity, err)
+		}
+	}
+
+	resp, err := svc.clients.CreateClients(ctx, &grpcClientsV1.CreateClientsReq{
+		DomainId: session.DomainID,
+		UserId:   session.UserID,
+		Clients: []*grpcClientsV1.Client{
+			{
+				Name:          mc.Name,
+				Tags:          mc.Tags,
+				Metadata:      metadata,
+				ParentGroupId: mc.ParentGroup,
+				Status:        uint32(smqclients.EnabledStatus),
+				Secret:        mc.Secret,
+			},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrCreateEntity, errors.Wrap(errCreateClients, err))
+	}
+	if len(resp.GetClients()) != 1 {
+		return nil, errors.Wrap(svcerr.ErrCreateEntity, errCreateClients)
+	}
+
+	return resp.GetClients()[0], nil
+}
+
+// rollbackProvision removes the provisioned connections, clients and channels
+// in the reverse order of their creation.
+func (svc service) rollbackProvision(ctx context.Context, session authn.Session, conns []Connection, clients []string, chs []Channel) error {
+	if len(conns) > 0 {
+		if err := svc.removeConnections(ctx, conns); err != nil {
+			return errors.Wrap(svcerr.ErrRollbackRepo, err)
+		}
+	}
+
+	if len(clients) > 0 {
+		if _, err := svc.clients.RemoveClients(ctx, &grpcClientsV1.RemoveClientsReq{
+			DomainId: session.DomainID,
+			UserId:   session.UserID,
+			Ids:      clients,
+		}); err != nil {
+			return errors.Wrap(svcerr.ErrRollbackRepo, errors.Wrap(errRemoveClients, err))
+		}
+	}
+
+	if len(chs) == 0 {
+		return nil
+	}
+	ids := []string{}
+	filterDeletePolicies := []policies.Policy{}
+	deletePolicies := []policies.Policy{}
+	for _, ch := range chs {
+		ids = append(ids, ch.ID)
+		filterDeletePolicies = append(filterDeletePolicies,
+			policies.Policy{
+				SubjectType: policies.ChannelType,
+				Subject:     ch.ID,
+			},
+			policies.Policy{
+				ObjectType: policies.ChannelType,
+				Object:     ch.ID,
+			},
+		)
+		deletePolicies = append(deletePolicies, policies.Policy{
+			SubjectType: policies.DomainType,
+			Subject:     session.DomainID,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ChannelType,
+			Object:      ch.ID,
+		})
+	}
+	if err := svc.RemoveEntitiesRoles(ctx, session.DomainID, session.DomainUserID, ids, filterDeletePolicies, deletePolicies); err != nil {
+		return errors.Wrap(svcerr.ErrRollbackRepo, err)
+	}
+	if err := svc.repo.Remove(ctx, ids...); err != nil {
+		return errors.Wrap(svcerr.ErrRollbackRepo, err)
+	}
+
+	return nil
+}
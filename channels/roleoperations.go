@@ -61,6 +61,8 @@ const (
 	GroupsOpRemoveChildChannel
 	ClientsOpConnectChannel
 	ClientsOpDisconnectChannel
+	DomainOpCreateClient
+	GroupOpSetChildClient
 )
 
 var expectedExternalOperations = []svcutil.ExternalOperation{
@@ -70,6 +72,8 @@ var expectedExternalOperations = []svcutil.ExternalOperation{
 	GroupsOpRemoveChildChannel,
 	ClientsOpConnectChannel,
 	ClientsOpDisconnectChannel,
+	DomainOpCreateClient,
+	GroupOpSetChildClient,
 }
 
 var externalOperationNames = []string{
@@ -79,6 +83,8 @@ var externalOperationNames = []string{
 	"GroupsOpRemoveChildChannel",
 	"ClientsOpConnectChannel",
 	"ClientsOpDisconnectChannel",
+	"DomainOpCreateClient",
+	"GroupOpSetChildClient",
 }
 
 func NewExternalOperationPerm() svcutil.ExternalOperationPerm {
@@ -142,9 +148,11 @@ const (
 	// Domains.
 	domainCreateChannelPermission = "channel_create_permission"
 	domainListChanelPermission    = "channel_read_permission"
+	domainCreateClientPermission  = "client_create_permission"
 	// Groups.
 	groupSetChildChannelPermission    = "channel_create_permission"
 	groupRemoveChildChannelPermission = "channel_create_permission"
+	groupSetChildClientPermission     = "client_create_permission"
 	// Client.
 	clientsConnectChannelPermission    = "connect_to_channel_permission"
 	clientsDisconnectChannelPermission = "connect_to_channel_permission"
@@ -158,6 +166,8 @@ func NewExternalOperationPermissionMap() map[svcutil.ExternalOperation]svcutil.P
 		GroupsOpRemoveChildChannel: groupRemoveChildChannelPermission,
 		ClientsOpConnectChannel:    clientsConnectChannelPermission,
 		ClientsOpDisconnectChannel: clientsDisconnectChannelPermission,
+		DomainOpCreateClient:       domainCreateClientPermission,
+		GroupOpSetChildClient:      groupSetChildClientPermission,
 	}
 	return extOpPerm
 }
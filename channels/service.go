@@ -26,6 +26,8 @@ import (
 var (
 	errAddConnectionsClients    = errors.New("failed to add connections in clients service")
 	errRemoveConnectionsClients = errors.New("failed to remove connections from clients service")
+	errCreateClients            = errors.New("failed to create clients in clients service")
+	errRemoveClients            = errors.New("failed to remove clients from clients service")
 	errSetParentGroup           = errors.New("channel already have parent")
 )
 
@@ -292,7 +294,6 @@ func (svc service) Connect(ctx context.Context, session authn.Session, chIDs, th
 	}
 
 	conns := []Connection{}
-	cliConns := []*grpcCommonV1.Connection{}
 	for _, chID := range chIDs {
 		for _, thID := range thIDs {
 			for _, connType := range connTypes {
@@ -302,12 +303,6 @@ func (svc service) Connect(ctx context.Context, session authn.Session, chIDs, th
 					DomainID:  session.DomainID,
 					Type:      connType,
 				})
-				cliConns = append(cliConns, &grpcCommonV1.Connection{
-					ClientId:  thID,
-					ChannelId: chID,
-					DomainId:  session.DomainID,
-					Type:      uint32(connType),
-				})
 			}
 		}
 	}
@@ -321,6 +316,20 @@ func (svc service) Connect(ctx context.Context, session authn.Session, chIDs, th
 			return errors.Wrap(svcerr.ErrCreateEntity, err)
 		}
 	}
+
+	return svc.addConnections(ctx, conns)
+}
+
+func (svc service) addConnections(ctx context.Context, conns []Connection) error {
+	cliConns := []*grpcCommonV1.Connection{}
+	for _, conn := range conns {
+		cliConns = append(cliConns, &grpcCommonV1.Connection{
+			ClientId:  conn.ClientID,
+			ChannelId: conn.ChannelID,
+			DomainId:  conn.DomainID,
+			Type:      uint32(conn.Type),
+		})
+	}
 	if _, err := svc.clients.AddConnections(ctx, &grpcCommonV1.AddConnectionsReq{Connections: cliConns}); err != nil {
 		return errors.Wrap(svcerr.ErrCreateEntity, errors.Wrap(errAddConnectionsClients, err))
 	}
@@ -355,7 +364,6 @@ func (svc service) Disconnect(ctx context.Context, session authn.Session, chIDs,
 	}
 
 	conns := []Connection{}
-	thConns := []*grpcCommonV1.Connection{}
 	for _, chID := range chIDs {
 		for _, thID := range thIDs {
 			for _, connType := range connTypes {
@@ -365,17 +373,24 @@ func (svc service) Disconnect(ctx context.Context, session authn.Session, chIDs,
 					DomainID:  session.DomainID,
 					Type:      connType,
 				})
-				thConns = append(thConns, &grpcCommonV1.Connection{
-					ClientId:  thID,
-					ChannelId: chID,
-					DomainId:  session.DomainID,
-					Type:      uint32(connType),
-				})
 			}
 		}
 	}
 
-	if _, err := svc.clients.RemoveConnections(ctx, &grpcCommonV1.RemoveConnectionsReq{Connections: thConns}); err != nil {
+	return svc.removeConnections(ctx, conns)
+}
+
+func (svc service) removeConnections(ctx context.Context, conns []Connection) error {
+	cliConns := []*grpcCommonV1.Connection{}
+	for _, conn := range conns {
+		cliConns = append(cliConns, &grpcCommonV1.Connection{
+			ClientId:  conn.ClientID,
+			ChannelId: conn.ChannelID,
+			DomainId:  conn.DomainID,
+			Type:      uint32(conn.Type),
+		})
+	}
+	if _, err := svc.clients.RemoveConnections(ctx, &grpcCommonV1.RemoveConnectionsReq{Connections: cliConns}); err != nil {
 		return errors.Wrap(svcerr.ErrRemoveEntity, errors.Wrap(errRemoveConnectionsClients, err))
 	}
 
@@ -1406,3 +1406,157 @@ func TestRemoveParentGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestProvision(t *testing.T) {
+	svc := newService(t)
+
+	channelID := testsutil.GenerateUUID(t)
+	clientID := testsutil.GenerateUUID(t)
+	manifest := channels.Manifest{
+		Channels: []channels.ManifestChannel{
+			{Key: "telemetry", Name: namegen.Generate(), ParentGroup: parentGroupID},
+		},
+		Clients: []channels.ManifestClient{
+			{Key: "sensor", Name: namegen.Generate(), Metadata: clients.Metadata{"serial": "A-0001"}},
+		},
+		Connections: []channels.ManifestConnection{
+			{ClientKey: "sensor", ChannelKey: "telemetry", Types: []connections.ConnType{connections.Publish, connections.Subscribe}},
+		},
+	}
+	allOrNothing := manifest
+	allOrNothing.AllOrNothing = true
+
+	validGroupRes := &grpcCommonV1.RetrieveEntityRes{
+		Entity: &grpcCommonV1.EntityBasic{
+			Id:       parentGroupID,
+			DomainId: validID,
+			Status:   uint32(clients.EnabledStatus),
+		},
+	}
+	createClientsRes := &grpcClientsV1.CreateClientsRes{
+		Clients: []*grpcClientsV1.Client{{Id: clientID, Secret: "secret"}},
+	}
+
+	cases := []struct {
+		desc             string
+		manifest         channels.Manifest
+		groupRes         *grpcCommonV1.RetrieveEntityRes
+		groupErr         error
+		saveErr          error
+		createClientsRes *grpcClientsV1.CreateClientsRes
+		createClientsErr error
+		addConnErr       error
+		removeClientsErr error
+		rowErrs          []error
+		rolledBack       bool
+		err              error
+	}{
+		{
+			desc:             "provision successfully",
+			manifest:         manifest,
+			groupRes:         validGroupRes,
+			createClientsRes: createClientsRes,
+			rowErrs:          []error{nil, nil, nil},
+		},
+		{
+			desc:     "provision with parent group from another domain",
+			manifest: manifest,
+			groupRes: &grpcCommonV1.RetrieveEntityRes{
+				Entity: &grpcCommonV1.EntityBasic{
+					Id:       parentGroupID,
+					DomainId: testsutil.GenerateUUID(t),
+					Status:   uint32(clients.EnabledStatus),
+				},
+			},
+			err: svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "provision with non-existing parent group",
+			manifest: manifest,
+			groupRes: &grpcCommonV1.RetrieveEntityRes{},
+			groupErr: svcerr.ErrNotFound,
+			err:      svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:             "provision with failed to save channel",
+			manifest:         manifest,
+			groupRes:         validGroupRes,
+			saveErr:          repoerr.ErrCreateEntity,
+			createClientsRes: createClientsRes,
+			rowErrs:          []error{svcerr.ErrCreateEntity, nil, channels.ErrProvisionSkipped},
+		},
+		{
+			desc:             "provision with failed to create client",
+			manifest:         manifest,
+			groupRes:         validGroupRes,
+			createClientsRes: &grpcClientsV1.CreateClientsRes{},
+			createClientsErr: svcerr.ErrConflict,
+			rowErrs:          []error{nil, svcerr.ErrCreateEntity, channels.ErrProvisionSkipped},
+		},
+		{
+			desc:             "provision all or nothing with failed to create client",
+			manifest:         allOrNothing,
+			groupRes:         validGroupRes,
+			createClientsRes: &grpcClientsV1.CreateClientsRes{},
+			createClientsErr: svcerr.ErrConflict,
+			rowErrs:          []error{channels.ErrProvisionRolledBack, svcerr.ErrCreateEntity, channels.ErrProvisionSkipped},
+			rolledBack:       true,
+		},
+		{
+			desc:             "provision all or nothing with failed to connect",
+			manifest:         allOrNothing,
+			groupRes:         validGroupRes,
+			createClientsRes: createClientsRes,
+			addConnErr:       svcerr.ErrAuthorization,
+			rowErrs:          []error{channels.ErrProvisionRolledBack, channels.ErrProvisionRolledBack, svcerr.ErrCreateEntity},
+			rolledBack:       true,
+		},
+		{
+			desc:             "provision all or nothing with failed to roll back clients",
+			manifest:         allOrNothing,
+			groupRes:         validGroupRes,
+			createClientsRes: createClientsRes,
+			addConnErr:       svcerr.ErrAuthorization,
+			removeClientsErr: svcerr.ErrRemoveEntity,
+			err:              svcerr.ErrRollbackRepo,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			groupsCall := groupsSvc.On("RetrieveEntity", context.Background(), &grpcCommonV1.RetrieveEntityReq{Id: parentGroupID}).Return(tc.groupRes, tc.groupErr)
+			repoCall := repo.On("Save", context.Background(), mock.Anything).Return([]channels.Channel{{ID: channelID}}, tc.saveErr)
+			repoCall1 := repo.On("AddRoles", context.Background(), mock.Anything).Return([]roles.RoleProvision{}, nil)
+			policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(nil)
+			clientsCall := clientsSvc.On("CreateClients", context.Background(), mock.Anything).Return(tc.createClientsRes, tc.createClientsErr)
+			clientsCall1 := clientsSvc.On("AddConnections", context.Background(), mock.Anything).Return(&grpcCommonV1.AddConnectionsRes{}, tc.addConnErr)
+			repoCall2 := repo.On("AddConnections", context.Background(), mock.Anything).Return(nil)
+			clientsCall2 := clientsSvc.On("RemoveClients", context.Background(), &grpcClientsV1.RemoveClientsReq{DomainId: validID, UserId: validID, Ids: []string{clientID}}).Return(&grpcClientsV1.RemoveClientsRes{}, tc.removeClientsErr)
+			repoCall3 := repo.On("RetrieveEntitiesRolesActionsMembers", context.Background(), []string{channelID}).Return([]roles.EntityActionRole{}, []roles.EntityMemberRole{}, nil)
+			policyCall1 := policies.On("DeletePolicies", context.Background(), mock.Anything).Return(nil)
+			policyCall2 := policies.On("DeletePolicyFilter", context.Background(), mock.Anything).Return(nil)
+			repoCall4 := repo.On("Remove", context.Background(), channelID).Return(nil)
+			report, err := svc.Provision(context.Background(), validSession, tc.manifest)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %v to contain %v", tc.desc, err, tc.err))
+			if tc.err == nil {
+				assert.Equal(t, len(tc.rowErrs), len(report.Rows), fmt.Sprintf("%s: unexpected number of report rows", tc.desc))
+				for i, row := range report.Rows {
+					assert.True(t, errors.Contains(row.Err, tc.rowErrs[i]), fmt.Sprintf("%s: expected row %s error %v to contain %v", tc.desc, row.Key, row.Err, tc.rowErrs[i]))
+				}
+				assert.Equal(t, tc.rolledBack, report.RolledBack, fmt.Sprintf("%s: unexpected rolled back status", tc.desc))
+			}
+			groupsCall.Unset()
+			repoCall.Unset()
+			repoCall1.Unset()
+			policyCall.Unset()
+			clientsCall.Unset()
+			clientsCall1.Unset()
+			repoCall2.Unset()
+			clientsCall2.Unset()
+			repoCall3.Unset()
+			policyCall1.Unset()
+			policyCall2.Unset()
+			repoCall4.Unset()
+		})
+	}
+}
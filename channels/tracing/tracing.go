@@ -132,3 +132,14 @@ func (tm *tracingMiddleware) RemoveParentGroup(ctx context.Context, session auth
 	defer span.End()
 	return tm.svc.RemoveParentGroup(ctx, session, id)
 }
+
+func (tm *tracingMiddleware) Provision(ctx context.Context, session authn.Session, manifest channels.Manifest) (channels.ProvisionReport, error) {
+	ctx, span := tm.tracer.Start(ctx, "provision", trace.WithAttributes(
+		attribute.Int("clients", len(manifest.Clients)),
+		attribute.Int("channels", len(manifest.Channels)),
+		attribute.Int("connections", len(manifest.Connections)),
+		attribute.Bool("all_or_nothing", manifest.AllOrNothing),
+	))
+	defer span.End()
+	return tm.svc.Provision(ctx, session, manifest)
+}
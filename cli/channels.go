@@ -186,12 +186,42 @@ var cmdChannels = []cobra.Command{
 			logJSONCmd(*cmd, ul)
 		},
 	},
+	{
+		Use:   "provision <manifest_file> <domain_id> <user_auth_token>",
+		Short: "Provision clients and channels",
+		Long: "Creates clients and channels from a JSON or CSV manifest and connects them\n" +
+			"CSV manifests have a header row and one client or channel per row with the columns\n" +
+			"kind, key, name, tags, metadata, parent_group_id, secret, channels and types.\n" +
+			"Usage:\n" +
+			"\tsupermq-cli channels provision manifest.csv $DOMAINID $USERTOKEN --all-or-nothing\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			manifest, err := readManifest(args[0])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+			manifest.AllOrNothing = manifest.AllOrNothing || AllOrNothing
+
+			report, err := sdk.Provision(manifest, args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, report)
+		},
+	},
 }
 
 // NewChannelsCmd returns channels command.
 func NewChannelsCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "channels [create | get | update | delete | connections | not-connected | assign | unassign | users | groups]",
+		Use:   "channels [create | get | update | delete | connections | not-connected | assign | unassign | users | groups | provision]",
 		Short: "Channels management",
 		Long:  `Channels management: create, get, update or delete Channel and get list of Clients connected or not connected to a Channel`,
 	}
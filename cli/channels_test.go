@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -530,3 +532,104 @@ func TestDisableChannelCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestProvisionChannelsCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	channelsCmd := cli.NewChannelsCmd()
+	rootCmd := setFlags(channelsCmd)
+
+	dir := t.TempDir()
+	jsonManifest := filepath.Join(dir, "manifest.json")
+	err := os.WriteFile(jsonManifest, []byte(`{"clients":[{"key":"sensor","name":"sensor"}],"channels":[{"key":"telemetry"}],"connections":[{"client_key":"sensor","channel_key":"telemetry","types":["publish"]}]}`), 0o600)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error writing manifest: %s", err))
+	csvManifest := filepath.Join(dir, "manifest.csv")
+	err = os.WriteFile(csvManifest, []byte("kind,key,name,tags,channels\nchannel,telemetry,telemetry,,\nclient,sensor,sensor,a;b,telemetry\n"), 0o600)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error writing manifest: %s", err))
+	invalidManifest := filepath.Join(dir, "invalid.csv")
+	err = os.WriteFile(invalidManifest, []byte("kind,key\ndevice,sensor\n"), 0o600)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error writing manifest: %s", err))
+
+	jsonReq := mgsdk.Manifest{
+		Clients:     []mgsdk.ManifestClient{{Key: "sensor", Name: "sensor"}},
+		Channels:    []mgsdk.ManifestChannel{{Key: "telemetry"}},
+		Connections: []mgsdk.ManifestConnection{{ClientKey: "sensor", ChannelKey: "telemetry", Types: []string{"publish"}}},
+	}
+	csvReq := mgsdk.Manifest{
+		Clients:     []mgsdk.ManifestClient{{Key: "sensor", Name: "sensor", Tags: []string{"a", "b"}}},
+		Channels:    []mgsdk.ManifestChannel{{Key: "telemetry", Name: "telemetry"}},
+		Connections: []mgsdk.ManifestConnection{{ClientKey: "sensor", ChannelKey: "telemetry", Types: []string{"publish", "subscribe"}}},
+	}
+	report := mgsdk.ProvisionReport{
+		Rows: []mgsdk.ProvisionRow{
+			{Kind: "channel", Key: "telemetry", ID: channel.ID},
+			{Kind: "client", Key: "sensor", ID: client.ID, Secret: client.Credentials.Secret},
+			{Kind: "connection", Key: "sensor:telemetry"},
+		},
+	}
+
+	var rp mgsdk.ProvisionReport
+	cases := []struct {
+		desc          string
+		args          []string
+		manifest      mgsdk.Manifest
+		report        mgsdk.ProvisionReport
+		sdkErr        errors.SDKError
+		errLogMessage string
+		logType       outputLog
+	}{
+		{
+			desc:     "provision from JSON manifest successfully",
+			args:     []string{jsonManifest, domainID, validToken},
+			manifest: jsonReq,
+			report:   report,
+			logType:  entityLog,
+		},
+		{
+			desc:     "provision from CSV manifest successfully",
+			args:     []string{csvManifest, domainID, validToken},
+			manifest: csvReq,
+			report:   report,
+			logType:  entityLog,
+		},
+		{
+			desc:          "provision from invalid CSV manifest",
+			args:          []string{invalidManifest, domainID, validToken},
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", "line 2: manifest CSV kind must be client or channel"),
+			logType:       errLog,
+		},
+		{
+			desc:          "provision with invalid token",
+			args:          []string{jsonManifest, domainID, invalidToken},
+			manifest:      jsonReq,
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+		{
+			desc:    "provision with invalid args",
+			args:    []string{jsonManifest, domainID, validToken, extraArg},
+			logType: usageLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("Provision", tc.manifest, tc.args[1], tc.args[2]).Return(tc.report, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{provCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &rp)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.report, rp, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.report, rp))
+			}
+
+			sdkCall.Unset()
+		})
+	}
+}
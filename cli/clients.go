@@ -5,6 +5,7 @@ package cli
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/absmach/supermq/clients"
 	smqsdk "github.com/absmach/supermq/pkg/sdk"
@@ -155,6 +156,60 @@ var cmdClients = []cobra.Command{
 			logJSONCmd(*cmd, client)
 		},
 	},
+	{
+		Use:   "rotate-secret <client_id> <duration> [<secret>] <domain_id> <user_auth_token>",
+		Short: "Rotate client secret",
+		Long: "Schedules the rotation of the client secret. The current and the new secret are both accepted\n" +
+			"for the given duration or until the new secret is confirmed. The secret is generated if it's omitted.\n" +
+			"Usage:\n" +
+			"\tsupermq-cli clients rotate-secret <client_id> 24h $DOMAINID $USERTOKEN\n" +
+			"\tsupermq-cli clients rotate-secret <client_id> 24h <newsecret> $DOMAINID $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 && len(args) != 5 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			duration, err := time.ParseDuration(args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			var secret string
+			if len(args) == 5 {
+				secret = args[2]
+			}
+			client, err := sdk.RotateClientSecret(args[0], secret, duration, args[len(args)-2], args[len(args)-1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, client)
+		},
+	},
+	{
+		Use:   "confirm-secret <client_id> <domain_id> <user_auth_token>",
+		Short: "Confirm client secret",
+		Long: "Confirms the adoption of the pending client secret, which replaces the current one\n" +
+			"Usage:\n" +
+			"\tsupermq-cli clients confirm-secret <client_id> $DOMAINID $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			client, err := sdk.ConfirmClientSecret(args[0], args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, client)
+		},
+	},
 	{
 		Use:   "enable <client_id> <domain_id> <user_auth_token>",
 		Short: "Change client status to enabled",
@@ -643,7 +698,7 @@ func NewClientsCmd() *cobra.Command {
 	}
 
 	cmd := cobra.Command{
-		Use:   "clients [create | get | update | delete | share | connect | disconnect | connections | not-connected | users ]",
+		Use:   "clients [create | get | update | rotate-secret | confirm-secret | delete | share | connect | disconnect | connections | not-connected | users ]",
 		Short: "Clients management",
 		Long:  `Clients management: create, get, update, delete or share Client, connect or disconnect Client from Channel and get the list of Channels connected or disconnected from a Client`,
 	}
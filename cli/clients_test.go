@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/cli"
@@ -547,6 +548,176 @@ func TestDeleteClientCmd(t *testing.T) {
 	}
 }
 
+func TestRotateClientSecretCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	clientsCmd := cli.NewClientsCmd()
+	rootCmd := setFlags(clientsCmd)
+	var tg smqsdk.Client
+
+	rotatedClient := client
+	rotatedClient.Credentials.PendingSecret = "newsecret"
+
+	cases := []struct {
+		desc          string
+		args          []string
+		secret        string
+		sdkErr        errors.SDKError
+		errLogMessage string
+		client        smqsdk.Client
+		logType       outputLog
+	}{
+		{
+			desc: "rotate client secret successfully",
+			args: []string{
+				client.ID,
+				"24h",
+				"newsecret",
+				domainID,
+				validToken,
+			},
+			secret:  "newsecret",
+			client:  rotatedClient,
+			logType: entityLog,
+		},
+		{
+			desc: "rotate client secret with generated secret",
+			args: []string{
+				client.ID,
+				"24h",
+				domainID,
+				validToken,
+			},
+			client:  rotatedClient,
+			logType: entityLog,
+		},
+		{
+			desc: "rotate client secret with invalid duration",
+			args: []string{
+				client.ID,
+				"invalid",
+				domainID,
+				validToken,
+			},
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", "time: invalid duration \"invalid\""),
+			logType:       errLog,
+		},
+		{
+			desc: "rotate client secret with invalid token",
+			args: []string{
+				client.ID,
+				"24h",
+				domainID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+		{
+			desc: "rotate client secret with invalid args",
+			args: []string{
+				client.ID,
+				"24h",
+				"newsecret",
+				domainID,
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("RotateClientSecret", tc.args[0], tc.secret, 24*time.Hour, domainID, tc.args[len(tc.args)-1]).Return(tc.client, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{rotSecCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &tg)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.client, tg, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.client, tg))
+			}
+
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestConfirmClientSecretCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	clientsCmd := cli.NewClientsCmd()
+	rootCmd := setFlags(clientsCmd)
+	var tg smqsdk.Client
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkErr        errors.SDKError
+		errLogMessage string
+		client        smqsdk.Client
+		logType       outputLog
+	}{
+		{
+			desc: "confirm client secret successfully",
+			args: []string{
+				client.ID,
+				domainID,
+				validToken,
+			},
+			client:  client,
+			logType: entityLog,
+		},
+		{
+			desc: "confirm client secret with invalid token",
+			args: []string{
+				client.ID,
+				domainID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+		{
+			desc: "confirm client secret with invalid args",
+			args: []string{
+				client.ID,
+				domainID,
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("ConfirmClientSecret", tc.args[0], tc.args[1], tc.args[2]).Return(tc.client, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{confSecCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &tg)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.client, tg, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.client, tg))
+			}
+
+			sdkCall.Unset()
+		})
+	}
+}
+
 func TestEnableClientCmd(t *testing.T) {
 	sdkMock := new(sdkmocks.SDK)
 	cli.SetSDK(sdkMock)
@@ -20,9 +20,15 @@ const (
 const (
 	tokCmd        = "token"
 	refTokCmd     = "refreshtoken"
+	mfaTokCmd     = "mfatoken"
+	mfaCmd        = "mfa"
+	sessionsCmd   = "sessions"
+	enrollCmd     = "enroll"
 	profCmd       = "profile"
 	resPassReqCmd = "resetpasswordrequest"
 	resPassCmd    = "resetpassword"
+	verEmailCmd   = "verifyemail"
+	resVerCmd     = "resendverification"
 	passCmd       = "password"
 	domsCmd       = "domains"
 )
@@ -35,6 +41,8 @@ const (
 	disconnCmd = "disconnect"
 	shrCmd     = "share"
 	unshrCmd   = "unshare"
+	rotSecCmd  = "rotate-secret"
+	confSecCmd = "confirm-secret"
 )
 
 // Groups and channels commands
@@ -46,6 +54,7 @@ const (
 	usrCmd      = "users"
 	assignCmd   = "assign"
 	unassignCmd = "unassign"
+	provCmd     = "provision"
 )
 
 // Certs commands
@@ -65,3 +74,8 @@ const (
 	acceptCmd = "accept"
 	rejectCmd = "reject"
 )
+
+// Device commands commands
+const (
+	callCmd = "call"
+)
@@ -28,6 +28,10 @@ const (
 	defInvitationsURL  string = defURL + ":9020"
 	defHTTPURL         string = defURL + ":8008"
 	defJournalURL      string = defURL + ":9021"
+	defReadersURL      string = defURL + ":9009"
+	defNotifiersURL    string = defURL + ":9015"
+	defCommandsURL     string = defURL + ":9011"
+	defTwinsURL        string = defURL + ":9018"
 	defTLSVerification bool   = false
 	defOffset          string = "0"
 	defLimit           string = "10"
@@ -45,6 +49,10 @@ type remotes struct {
 	CertsURL        string `toml:"certs_url"`
 	InvitationsURL  string `toml:"invitations_url"`
 	JournalURL      string `toml:"journal_url"`
+	ReadersURL      string `toml:"readers_url"`
+	NotifiersURL    string `toml:"notifiers_url"`
+	CommandsURL     string `toml:"commands_url"`
+	TwinsURL        string `toml:"twins_url"`
 	HostURL         string `toml:"host_url"`
 	TLSVerification bool   `toml:"tls_verification"`
 }
@@ -116,6 +124,10 @@ func ParseConfig(sdkConf smqsdk.Config) (smqsdk.Config, error) {
 				CertsURL:        defCertsURL,
 				InvitationsURL:  defInvitationsURL,
 				JournalURL:      defJournalURL,
+				ReadersURL:      defReadersURL,
+				NotifiersURL:    defNotifiersURL,
+				CommandsURL:     defCommandsURL,
+				TwinsURL:        defTwinsURL,
 				HostURL:         defURL,
 				TLSVerification: defTLSVerification,
 			},
@@ -207,6 +219,22 @@ func ParseConfig(sdkConf smqsdk.Config) (smqsdk.Config, error) {
 		sdkConf.JournalURL = config.Remotes.JournalURL
 	}
 
+	if sdkConf.ReadersURL == "" && config.Remotes.ReadersURL != "" {
+		sdkConf.ReadersURL = config.Remotes.ReadersURL
+	}
+
+	if sdkConf.NotifiersURL == "" && config.Remotes.NotifiersURL != "" {
+		sdkConf.NotifiersURL = config.Remotes.NotifiersURL
+	}
+
+	if sdkConf.CommandsURL == "" && config.Remotes.CommandsURL != "" {
+		sdkConf.CommandsURL = config.Remotes.CommandsURL
+	}
+
+	if sdkConf.TwinsURL == "" && config.Remotes.TwinsURL != "" {
+		sdkConf.TwinsURL = config.Remotes.TwinsURL
+	}
+
 	if sdkConf.HostURL == "" && config.Remotes.HostURL != "" {
 		sdkConf.HostURL = config.Remotes.HostURL
 	}
@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+
+	smqsdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+var cmdCommands = []cobra.Command{
+	{
+		Use:   "send <JSON_command> <domain_id> <user_auth_token>",
+		Short: "Send command",
+		Long: "Sends a command to the channel without waiting for the reply\n" +
+			"Usage:\n" +
+			"\tsupermq-cli commands send '{\"channel_id\":\"<channel_id>\", \"payload\":\"reboot\", \"timeout\":60}' $DOMAINID $USERTOKEN - sends command and returns its pending record\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			sendCommand(cmd, args, false)
+		},
+	},
+	{
+		Use:   "call <JSON_command> <domain_id> <user_auth_token>",
+		Short: "Call command",
+		Long: "Sends a command to the channel and waits for the reply or the timeout\n" +
+			"Usage:\n" +
+			"\tsupermq-cli commands call '{\"channel_id\":\"<channel_id>\", \"client_id\":\"<client_id>\", \"payload\":\"status\", \"timeout\":10}' $DOMAINID $USERTOKEN - sends command and prints the reply\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			sendCommand(cmd, args, true)
+		},
+	},
+	{
+		Use:   "get [all | <command_id>] <domain_id> <user_auth_token>",
+		Short: "Get command",
+		Long: "Get command\n" +
+			"Usage:\n" +
+			"\tsupermq-cli commands get all $DOMAINID $USERTOKEN - lists all commands\n" +
+			"\tsupermq-cli commands get all $DOMAINID $USERTOKEN --offset <offset> --limit <limit> --status <status> - lists commands with provided filters\n" +
+			"\tsupermq-cli commands get <command_id> $DOMAINID $USERTOKEN - shows command with provided id\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			if args[0] == all {
+				pageMetadata := smqsdk.PageMetadata{
+					Offset: Offset,
+					Limit:  Limit,
+					Status: Status,
+				}
+				cp, err := sdk.ListCommands(pageMetadata, args[1], args[2])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+				logJSONCmd(*cmd, cp)
+				return
+			}
+
+			c, err := sdk.ViewCommand(args[0], args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, c)
+		},
+	},
+}
+
+func sendCommand(cmd *cobra.Command, args []string, wait bool) {
+	if len(args) != 3 {
+		logUsageCmd(*cmd, cmd.Use)
+		return
+	}
+
+	var command smqsdk.Command
+	if err := json.Unmarshal([]byte(args[0]), &command); err != nil {
+		logErrorCmd(*cmd, err)
+		return
+	}
+
+	command, err := sdk.SendCommand(command, wait, args[1], args[2])
+	if err != nil {
+		logErrorCmd(*cmd, err)
+		return
+	}
+
+	logJSONCmd(*cmd, command)
+}
+
+// NewCommandsCmd returns device commands command.
+func NewCommandsCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "commands [send | call | get]",
+		Short: "Device commands management",
+		Long:  `Device commands management: send commands to clients over channels, wait for replies and get command records`,
+	}
+
+	for i := range cmdCommands {
+		cmd.AddCommand(&cmdCommands[i])
+	}
+
+	return &cmd
+}
@@ -0,0 +1,230 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/supermq/cli"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+	sdkmocks "github.com/absmach/supermq/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var deviceCommand = mgsdk.Command{
+	ID:        testsutil.GenerateUUID(&testing.T{}),
+	DomainID:  domainID,
+	ChannelID: channel.ID,
+	Payload:   "reboot",
+	Status:    "pending",
+}
+
+func TestSendCommandCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	cmdsCmd := cli.NewCommandsCmd()
+	rootCmd := setFlags(cmdsCmd)
+
+	cmdJSON := fmt.Sprintf("{\"channel_id\":\"%s\", \"payload\":\"%s\", \"timeout\":10}", deviceCommand.ChannelID, deviceCommand.Payload)
+	replied := deviceCommand
+	replied.Status = "replied"
+	replied.Response = "ok"
+
+	cases := []struct {
+		desc          string
+		cmd           string
+		args          []string
+		wait          bool
+		logType       outputLog
+		errLogMessage string
+		command       mgsdk.Command
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "send command successfully",
+			cmd:  sendCmd,
+			args: []string{
+				cmdJSON,
+				domainID,
+				validToken,
+			},
+			command: deviceCommand,
+			logType: entityLog,
+		},
+		{
+			desc: "call command successfully",
+			cmd:  callCmd,
+			args: []string{
+				cmdJSON,
+				domainID,
+				validToken,
+			},
+			wait:    true,
+			command: replied,
+			logType: entityLog,
+		},
+		{
+			desc: "send command with invalid args",
+			cmd:  sendCmd,
+			args: []string{
+				cmdJSON,
+				domainID,
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "send command with invalid JSON",
+			cmd:  sendCmd,
+			args: []string{
+				"{\"channel_id\":",
+				domainID,
+				validToken,
+			},
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", "unexpected end of JSON input"),
+			logType:       errLog,
+		},
+		{
+			desc: "send command with invalid token",
+			cmd:  sendCmd,
+			args: []string{
+				cmdJSON,
+				domainID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("SendCommand", mock.Anything, tc.wait, mock.Anything, mock.Anything).Return(tc.command, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{tc.cmd}, tc.args...)...)
+			switch tc.logType {
+			case entityLog:
+				var c mgsdk.Command
+				err := json.Unmarshal([]byte(out), &c)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.command, c, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.command, c))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestGetCommandCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	cmdsCmd := cli.NewCommandsCmd()
+	rootCmd := setFlags(cmdsCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		page          mgsdk.CommandsPage
+		command       mgsdk.Command
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "get all commands successfully",
+			args: []string{
+				all,
+				domainID,
+				validToken,
+			},
+			page: mgsdk.CommandsPage{
+				PageRes:  mgsdk.PageRes{Total: 1, Offset: 0, Limit: 10},
+				Commands: []mgsdk.Command{deviceCommand},
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "get command by id successfully",
+			args: []string{
+				deviceCommand.ID,
+				domainID,
+				validToken,
+			},
+			command: deviceCommand,
+			logType: entityLog,
+		},
+		{
+			desc: "get commands with invalid args",
+			args: []string{
+				all,
+				domainID,
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "get all commands with invalid token",
+			args: []string{
+				all,
+				domainID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+		{
+			desc: "get non-existing command",
+			args: []string{
+				invalidID,
+				domainID,
+				validToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			listCall := sdkMock.On("ListCommands", mock.Anything, mock.Anything, mock.Anything).Return(tc.page, tc.sdkErr)
+			viewCall := sdkMock.On("ViewCommand", mock.Anything, mock.Anything, mock.Anything).Return(tc.command, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{getCmd}, tc.args...)...)
+			switch tc.logType {
+			case entityLog:
+				if tc.args[0] == all {
+					var page mgsdk.CommandsPage
+					err := json.Unmarshal([]byte(out), &page)
+					assert.Nil(t, err)
+					assert.Equal(t, tc.page, page, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.page, page))
+					break
+				}
+				var c mgsdk.Command
+				err := json.Unmarshal([]byte(out), &c)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.command, c, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.command, c))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			listCall.Unset()
+			viewCall.Unset()
+		})
+	}
+}
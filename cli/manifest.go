@@ -0,0 +1,149 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	smqsdk "github.com/absmach/supermq/pkg/sdk"
+)
+
+const (
+	kindClient  = "client"
+	kindChannel = "channel"
+	listSep     = ";"
+)
+
+var (
+	errManifestHeader = errors.New("manifest CSV must have a header with kind and key columns")
+	errManifestKind   = errors.New("manifest CSV kind must be client or channel")
+	errConnectChannel = errors.New("only client rows can be connected to channels")
+)
+
+// defaultConnTypes are used for the CSV connections without the types column.
+var defaultConnTypes = []string{"publish", "subscribe"}
+
+// readManifest reads the provisioning manifest from the JSON or CSV file.
+// CSV files contain a header row and one client or channel per row with the
+// columns kind, key, name, tags, metadata, parent_group_id, secret, channels
+// and types. Lists are separated by semicolons and metadata is a JSON object.
+// The channels column lists the keys of the channels the client connects to.
+func readManifest(path string) (smqsdk.Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return smqsdk.Manifest{}, err
+	}
+	defer f.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return parseManifestCSV(f)
+	}
+
+	var m smqsdk.Manifest
+	if err := json.NewDecoder(f).Decode(&m); err != nil {
+		return smqsdk.Manifest{}, err
+	}
+
+	return m, nil
+}
+
+func parseManifestCSV(r io.Reader) (smqsdk.Manifest, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return smqsdk.Manifest{}, errManifestHeader
+	}
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := cols["kind"]; !ok {
+		return smqsdk.Manifest{}, errManifestHeader
+	}
+	if _, ok := cols["key"]; !ok {
+		return smqsdk.Manifest{}, errManifestHeader
+	}
+
+	var m smqsdk.Manifest
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return m, nil
+		}
+		if err != nil {
+			return smqsdk.Manifest{}, err
+		}
+		field := func(name string) string {
+			if i, ok := cols[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		var metadata smqsdk.Metadata
+		if md := field("metadata"); md != "" {
+			if err := json.Unmarshal([]byte(md), &metadata); err != nil {
+				return smqsdk.Manifest{}, fmt.Errorf("line %d: invalid metadata: %w", line, err)
+			}
+		}
+		key := field("key")
+		channels := splitList(field("channels"))
+
+		switch strings.ToLower(field("kind")) {
+		case kindClient:
+			m.Clients = append(m.Clients, smqsdk.ManifestClient{
+				Key:         key,
+				Name:        field("name"),
+				Tags:        splitList(field("tags")),
+				Metadata:    metadata,
+				ParentGroup: field("parent_group_id"),
+				Secret:      field("secret"),
+			})
+			types := splitList(field("types"))
+			if len(types) == 0 {
+				types = defaultConnTypes
+			}
+			for _, ch := range channels {
+				m.Connections = append(m.Connections, smqsdk.ManifestConnection{
+					ClientKey:  key,
+					ChannelKey: ch,
+					Types:      types,
+				})
+			}
+		case kindChannel:
+			if len(channels) > 0 {
+				return smqsdk.Manifest{}, fmt.Errorf("line %d: %w", line, errConnectChannel)
+			}
+			m.Channels = append(m.Channels, smqsdk.ManifestChannel{
+				Key:         key,
+				Name:        field("name"),
+				Tags:        splitList(field("tags")),
+				Metadata:    metadata,
+				ParentGroup: field("parent_group_id"),
+			})
+		default:
+			return smqsdk.Manifest{}, fmt.Errorf("line %d: %w", line, errManifestKind)
+		}
+	}
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, listSep) {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
@@ -3,7 +3,10 @@
 
 package cli
 
-import "github.com/spf13/cobra"
+import (
+	smqsdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/spf13/cobra"
+)
 
 var cmdMessages = []cobra.Command{
 	{
@@ -24,14 +27,42 @@ var cmdMessages = []cobra.Command{
 			logOKCmd(*cmd)
 		},
 	},
+	{
+		Use:   "read <channel_id.subtopic> <user_token>",
+		Short: "Read messages",
+		Long: "Reads all channel messages\n" +
+			"Usage:\n" +
+			"\tsupermq-cli messages read <channel_id.subtopic> <user_token> --offset <offset> --limit <limit> - lists all messages with provided offset and limit\n" +
+			"\tsupermq-cli messages read <channel_id.subtopic> 'Client <client_secret>' - lists all messages using client secret\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+			pageMetadata := smqsdk.MessagePageMetadata{
+				PageMetadata: smqsdk.PageMetadata{
+					Offset: Offset,
+					Limit:  Limit,
+				},
+			}
+
+			m, err := sdk.ReadMessages(pageMetadata, args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, m)
+		},
+	},
 }
 
 // NewMessagesCmd returns messages command.
 func NewMessagesCmd() *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "messages [send | read]",
-		Short: "Send messages",
-		Long:  `Send messages using the http-adapter`,
+		Short: "Send or read messages",
+		Long:  `Send messages using the http-adapter, or read messages using the readers`,
 	}
 
 	for i := range cmdMessages {
@@ -0,0 +1,108 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	smqsdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+var cmdSubscription = []cobra.Command{
+	{
+		Use:   "create <topic> <contact> <user_auth_token>",
+		Short: "Create subscription",
+		Long: "Create new subscription\n" +
+			"Usage:\n" +
+			"\tsupermq-cli subscriptions create channels.<channel_id>.temperature user@example.com $USERTOKEN - creates subscription for the channel subtopic\n" +
+			"\tsupermq-cli subscriptions create 'channels.<channel_id>.>' user@example.com $USERTOKEN - creates subscription for all channel subtopics\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			id, err := sdk.CreateSubscription(args[0], args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logCreatedCmd(*cmd, id)
+		},
+	},
+	{
+		Use:   "get [all | <subscription_id>] <user_auth_token>",
+		Short: "Get subscription",
+		Long: "Get subscription\n" +
+			"Usage:\n" +
+			"\tsupermq-cli subscriptions get all $USERTOKEN - lists all subscriptions\n" +
+			"\tsupermq-cli subscriptions get all $USERTOKEN --offset <offset> --limit <limit> --topic <topic> --contact <contact> - lists all subscriptions with provided filters\n" +
+			"\tsupermq-cli subscriptions get <subscription_id> $USERTOKEN - shows subscription with provided id\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			pageMetadata := smqsdk.PageMetadata{
+				Offset:  Offset,
+				Limit:   Limit,
+				Topic:   Topic,
+				Contact: Contact,
+			}
+			if args[0] == all {
+				sp, err := sdk.ListSubscriptions(pageMetadata, args[1])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+				logJSONCmd(*cmd, sp)
+				return
+			}
+
+			s, err := sdk.ViewSubscription(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, s)
+		},
+	},
+	{
+		Use:   "remove <subscription_id> <user_auth_token>",
+		Short: "Remove subscription",
+		Long: "Removes a subscription with the provided id\n" +
+			"Usage:\n" +
+			"\tsupermq-cli subscriptions remove <subscription_id> $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteSubscription(args[0], args[1]); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logOKCmd(*cmd)
+		},
+	},
+}
+
+// NewSubscriptionCmd returns subscription command.
+func NewSubscriptionCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "subscriptions [create | get | remove]",
+		Short: "Subscriptions management",
+		Long:  `Subscriptions management: create, get or remove subscriptions to channel topics for notifications`,
+	}
+
+	for i := range cmdSubscription {
+		cmd.AddCommand(&cmdSubscription[i])
+	}
+
+	return &cmd
+}
@@ -0,0 +1,247 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/supermq/cli"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+	sdkmocks "github.com/absmach/supermq/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var subscription = mgsdk.Subscription{
+	ID:      testsutil.GenerateUUID(&testing.T{}),
+	OwnerID: user.ID,
+	Topic:   fmt.Sprintf("channels.%s.temperature", channel.ID),
+	Contact: "user@example.com",
+}
+
+func TestCreateSubscriptionCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	subCmd := cli.NewSubscriptionCmd()
+	rootCmd := setFlags(subCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		id            string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "create subscription successfully",
+			args: []string{
+				subscription.Topic,
+				subscription.Contact,
+				validToken,
+			},
+			id:      subscription.ID,
+			logType: createLog,
+		},
+		{
+			desc: "create subscription with invalid args",
+			args: []string{
+				subscription.Topic,
+				subscription.Contact,
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "create subscription with invalid token",
+			args: []string{
+				subscription.Topic,
+				subscription.Contact,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("CreateSubscription", mock.Anything, mock.Anything, mock.Anything).Return(tc.id, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{createCmd}, tc.args...)...)
+			switch tc.logType {
+			case createLog:
+				assert.True(t, strings.Contains(out, tc.id), fmt.Sprintf("%s unexpected response: expected %s, got: %v", tc.desc, tc.id, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestGetSubscriptionCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	subCmd := cli.NewSubscriptionCmd()
+	rootCmd := setFlags(subCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		page          mgsdk.SubscriptionPage
+		subscription  mgsdk.Subscription
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "get all subscriptions successfully",
+			args: []string{
+				all,
+				validToken,
+			},
+			page: mgsdk.SubscriptionPage{
+				PageRes:       mgsdk.PageRes{Total: 1, Offset: 0, Limit: 10},
+				Subscriptions: []mgsdk.Subscription{subscription},
+			},
+			logType: entityLog,
+		},
+		{
+			desc: "get subscription by id successfully",
+			args: []string{
+				subscription.ID,
+				validToken,
+			},
+			subscription: subscription,
+			logType:      entityLog,
+		},
+		{
+			desc: "get subscriptions with invalid args",
+			args: []string{
+				all,
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "get all subscriptions with invalid token",
+			args: []string{
+				all,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+		{
+			desc: "get non-existing subscription",
+			args: []string{
+				invalidID,
+				validToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			listCall := sdkMock.On("ListSubscriptions", mock.Anything, mock.Anything).Return(tc.page, tc.sdkErr)
+			viewCall := sdkMock.On("ViewSubscription", mock.Anything, mock.Anything).Return(tc.subscription, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{getCmd}, tc.args...)...)
+			switch tc.logType {
+			case entityLog:
+				if tc.args[0] == all {
+					var page mgsdk.SubscriptionPage
+					err := json.Unmarshal([]byte(out), &page)
+					assert.Nil(t, err)
+					assert.Equal(t, tc.page, page, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.page, page))
+					break
+				}
+				var sub mgsdk.Subscription
+				err := json.Unmarshal([]byte(out), &sub)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.subscription, sub, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.subscription, sub))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			listCall.Unset()
+			viewCall.Unset()
+		})
+	}
+}
+
+func TestRemoveSubscriptionCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	subCmd := cli.NewSubscriptionCmd()
+	rootCmd := setFlags(subCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "remove subscription successfully",
+			args: []string{
+				subscription.ID,
+				validToken,
+			},
+			logType: okLog,
+		},
+		{
+			desc: "remove subscription with invalid args",
+			args: []string{
+				subscription.ID,
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "remove subscription with invalid token",
+			args: []string{
+				subscription.ID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DeleteSubscription", mock.Anything, mock.Anything).Return(tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{rmCmd}, tc.args...)...)
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
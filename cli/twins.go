@@ -0,0 +1,136 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var cmdTwins = []cobra.Command{
+	{
+		Use:   "create <client_id> <channel_id> <JSON_desired> <domain_id> <user_auth_token>",
+		Short: "Create twin",
+		Long: "Creates the device twin of the client. The desired state deltas are published to the client over the channel\n" +
+			"Usage:\n" +
+			"\tsupermq-cli twins create <client_id> <channel_id> '{\"mode\":\"eco\", \"interval\":10}' $DOMAINID $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 5 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var desired map[string]any
+			if err := json.Unmarshal([]byte(args[2]), &desired); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			twin, err := sdk.CreateTwin(args[0], args[1], desired, args[3], args[4])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, twin)
+		},
+	},
+	{
+		Use:   "get <client_id> <domain_id> <user_auth_token>",
+		Short: "Get twin",
+		Long: "Gets the device twin of the client with its desired state, reported state and delta\n" +
+			"Usage:\n" +
+			"\tsupermq-cli twins get <client_id> $DOMAINID $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			twin, err := sdk.ViewTwin(args[0], args[1], args[2])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, twin)
+		},
+	},
+	{
+		Use:   "update <client_id> <JSON_desired> [<revision>] <domain_id> <user_auth_token>",
+		Short: "Update twin desired state",
+		Long: "Merges the fields into the desired state of the client twin. Fields set to null are removed.\n" +
+			"If the revision is provided, the update fails unless it matches the current desired state revision.\n" +
+			"Usage:\n" +
+			"\tsupermq-cli twins update <client_id> '{\"mode\":\"boost\", \"interval\":null}' $DOMAINID $USERTOKEN\n" +
+			"\tsupermq-cli twins update <client_id> '{\"mode\":\"boost\"}' 3 $DOMAINID $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 4 && len(args) != 5 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			var desired map[string]any
+			if err := json.Unmarshal([]byte(args[1]), &desired); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			var revision uint64
+			if len(args) == 5 {
+				rev, err := strconv.ParseUint(args[2], 10, 64)
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+				revision = rev
+			}
+
+			twin, err := sdk.UpdateTwinDesired(args[0], desired, revision, args[len(args)-2], args[len(args)-1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, twin)
+		},
+	},
+	{
+		Use:   "delete <client_id> <domain_id> <user_auth_token>",
+		Short: "Delete twin",
+		Long: "Deletes the device twin of the client\n" +
+			"Usage:\n" +
+			"\tsupermq-cli twins delete <client_id> $DOMAINID $USERTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 3 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			if err := sdk.DeleteTwin(args[0], args[1], args[2]); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logOKCmd(*cmd)
+		},
+	},
+}
+
+// NewTwinsCmd returns device twins command.
+func NewTwinsCmd() *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "twins [create | get | update | delete]",
+		Short: "Device twins management",
+		Long:  `Device twins management: create, get and delete client twins and update their desired state`,
+	}
+
+	for i := range cmdTwins {
+		cmd.AddCommand(&cmdTwins[i])
+	}
+
+	return &cmd
+}
@@ -0,0 +1,352 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package cli_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/absmach/supermq/cli"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgsdk "github.com/absmach/supermq/pkg/sdk"
+	sdkmocks "github.com/absmach/supermq/pkg/sdk/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var twin = mgsdk.Twin{
+	ClientID:  testsutil.GenerateUUID(&testing.T{}),
+	DomainID:  domainID,
+	ChannelID: channel.ID,
+	Desired: mgsdk.TwinState{
+		Document:  map[string]any{"mode": "eco"},
+		Revisions: map[string]uint64{"mode": 1},
+		Revision:  1,
+	},
+	Delta: map[string]any{"mode": "eco"},
+}
+
+func TestCreateTwinCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	twinsCmd := cli.NewTwinsCmd()
+	rootCmd := setFlags(twinsCmd)
+
+	desiredJSON := "{\"mode\":\"eco\"}"
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		twin          mgsdk.Twin
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "create twin successfully",
+			args: []string{
+				twin.ClientID,
+				twin.ChannelID,
+				desiredJSON,
+				domainID,
+				validToken,
+			},
+			twin:    twin,
+			logType: entityLog,
+		},
+		{
+			desc: "create twin with invalid args",
+			args: []string{
+				twin.ClientID,
+				twin.ChannelID,
+				desiredJSON,
+				domainID,
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "create twin with invalid JSON",
+			args: []string{
+				twin.ClientID,
+				twin.ChannelID,
+				"{\"mode\":",
+				domainID,
+				validToken,
+			},
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", "unexpected end of JSON input"),
+			logType:       errLog,
+		},
+		{
+			desc: "create twin with invalid token",
+			args: []string{
+				twin.ClientID,
+				twin.ChannelID,
+				desiredJSON,
+				domainID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("CreateTwin", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tc.twin, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{createCmd}, tc.args...)...)
+			switch tc.logType {
+			case entityLog:
+				var tw mgsdk.Twin
+				err := json.Unmarshal([]byte(out), &tw)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.twin, tw, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.twin, tw))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestGetTwinCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	twinsCmd := cli.NewTwinsCmd()
+	rootCmd := setFlags(twinsCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		twin          mgsdk.Twin
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "get twin successfully",
+			args: []string{
+				twin.ClientID,
+				domainID,
+				validToken,
+			},
+			twin:    twin,
+			logType: entityLog,
+		},
+		{
+			desc: "get twin with invalid args",
+			args: []string{
+				twin.ClientID,
+				domainID,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "get non-existing twin",
+			args: []string{
+				twin.ClientID,
+				domainID,
+				validToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("ViewTwin", mock.Anything, mock.Anything, mock.Anything).Return(tc.twin, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{getCmd}, tc.args...)...)
+			switch tc.logType {
+			case entityLog:
+				var tw mgsdk.Twin
+				err := json.Unmarshal([]byte(out), &tw)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.twin, tw, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.twin, tw))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestUpdateTwinCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	twinsCmd := cli.NewTwinsCmd()
+	rootCmd := setFlags(twinsCmd)
+
+	patchJSON := "{\"mode\":\"boost\"}"
+	updated := twin
+	updated.Desired = mgsdk.TwinState{
+		Document:  map[string]any{"mode": "boost"},
+		Revisions: map[string]uint64{"mode": 2},
+		Revision:  2,
+	}
+	updated.Delta = map[string]any{"mode": "boost"}
+
+	cases := []struct {
+		desc          string
+		args          []string
+		revision      uint64
+		logType       outputLog
+		errLogMessage string
+		twin          mgsdk.Twin
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "update twin successfully",
+			args: []string{
+				twin.ClientID,
+				patchJSON,
+				domainID,
+				validToken,
+			},
+			twin:    updated,
+			logType: entityLog,
+		},
+		{
+			desc: "update twin with revision successfully",
+			args: []string{
+				twin.ClientID,
+				patchJSON,
+				"1",
+				domainID,
+				validToken,
+			},
+			revision: 1,
+			twin:     updated,
+			logType:  entityLog,
+		},
+		{
+			desc: "update twin with invalid args",
+			args: []string{
+				twin.ClientID,
+				patchJSON,
+				domainID,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "update twin with invalid revision",
+			args: []string{
+				twin.ClientID,
+				patchJSON,
+				"invalid",
+				domainID,
+				validToken,
+			},
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", "strconv.ParseUint: parsing \"invalid\": invalid syntax"),
+			logType:       errLog,
+		},
+		{
+			desc: "update twin with stale revision",
+			args: []string{
+				twin.ClientID,
+				patchJSON,
+				"1",
+				domainID,
+				validToken,
+			},
+			revision:      1,
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrRevisionConflict, http.StatusConflict),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrRevisionConflict, http.StatusConflict)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("UpdateTwinDesired", mock.Anything, mock.Anything, tc.revision, mock.Anything, mock.Anything).Return(tc.twin, tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{updateCmd}, tc.args...)...)
+			switch tc.logType {
+			case entityLog:
+				var tw mgsdk.Twin
+				err := json.Unmarshal([]byte(out), &tw)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.twin, tw, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.twin, tw))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestDeleteTwinCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	twinsCmd := cli.NewTwinsCmd()
+	rootCmd := setFlags(twinsCmd)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		logType       outputLog
+		errLogMessage string
+		sdkErr        errors.SDKError
+	}{
+		{
+			desc: "delete twin successfully",
+			args: []string{
+				twin.ClientID,
+				domainID,
+				validToken,
+			},
+			logType: okLog,
+		},
+		{
+			desc: "delete twin with invalid args",
+			args: []string{
+				twin.ClientID,
+				domainID,
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "delete twin with invalid token",
+			args: []string{
+				twin.ClientID,
+				domainID,
+				invalidToken,
+			},
+			sdkErr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("DeleteTwin", mock.Anything, mock.Anything, mock.Anything).Return(tc.sdkErr)
+			out := executeCommand(t, rootCmd, append([]string{delCmd}, tc.args...)...)
+			switch tc.logType {
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
@@ -141,6 +141,112 @@ var cmdUsers = []cobra.Command{
 			logJSONCmd(*cmd, token)
 		},
 	},
+	{
+		Use:   "mfatoken <mfa_token> <code>",
+		Short: "Get token with MFA code",
+		Long: "Generate a new token from the MFA challenge token returned by the token command\n" +
+			"and a TOTP or recovery code\n" +
+			"For example:\n" +
+			"\tsupermq-cli users mfatoken <mfa_token> 123456\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			token, err := sdk.VerifyMFA(args[0], args[1])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, token)
+		},
+	},
+	{
+		Use:   "mfa [enroll <user_auth_token> | enable <code> <user_auth_token> | disable <code> <user_auth_token>]",
+		Short: "Manage multi-factor authentication",
+		Long: "Enroll, enable or disable TOTP multi-factor authentication\n" +
+			"Usage:\n" +
+			"\tsupermq-cli users mfa enroll $USERTOKEN - returns the TOTP secret, URI and recovery codes\n" +
+			"\tsupermq-cli users mfa enable 123456 $USERTOKEN - enables MFA using a TOTP code\n" +
+			"\tsupermq-cli users mfa disable 123456 $USERTOKEN - disables MFA using a TOTP or recovery code\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			switch {
+			case args[0] == "enroll" && len(args) == 2:
+				enrolment, err := sdk.EnrollMFA(args[1])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logJSONCmd(*cmd, enrolment)
+			case args[0] == "enable" && len(args) == 3:
+				if err := sdk.EnableMFA(args[1], args[2]); err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logOKCmd(*cmd)
+			case args[0] == "disable" && len(args) == 3:
+				if err := sdk.DisableMFA(args[1], args[2]); err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logOKCmd(*cmd)
+			default:
+				logUsageCmd(*cmd, cmd.Use)
+			}
+		},
+	},
+	{
+		Use:   "sessions [get <user_auth_token> | revoke <session_id> <user_auth_token> | revoke all <user_auth_token>]",
+		Short: "Manage login sessions",
+		Long: "List or revoke the login sessions, i.e. the devices and browsers holding the user's refresh tokens\n" +
+			"Usage:\n" +
+			"\tsupermq-cli users sessions get $USERTOKEN - lists the active sessions\n" +
+			"\tsupermq-cli users sessions revoke <session_id> $USERTOKEN - revokes the session\n" +
+			"\tsupermq-cli users sessions revoke all $USERTOKEN - revokes all the sessions\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) < 2 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			switch {
+			case args[0] == "get" && len(args) == 2:
+				sessions, err := sdk.ListSessions(args[1])
+				if err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logJSONCmd(*cmd, sessions)
+			case args[0] == "revoke" && len(args) == 3 && args[1] == all:
+				if err := sdk.RevokeSessions(args[2]); err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logOKCmd(*cmd)
+			case args[0] == "revoke" && len(args) == 3:
+				if err := sdk.RevokeSession(args[1], args[2]); err != nil {
+					logErrorCmd(*cmd, err)
+					return
+				}
+
+				logOKCmd(*cmd)
+			default:
+				logUsageCmd(*cmd, cmd.Use)
+			}
+		},
+	},
 	{
 		Use:   "update [<user_id> <JSON_string> | tags <user_id> <tags> | username <user_id> <username> | email <user_id> <email>] <user_auth_token>",
 		Short: "Update user",
@@ -249,6 +355,47 @@ var cmdUsers = []cobra.Command{
 			logJSONCmd(*cmd, user)
 		},
 	},
+	{
+		Use:   "verifyemail <verification_token>",
+		Short: "Verify e-mail address",
+		Long: "Verify e-mail address using the token sent in the verification e-mail\n" +
+			"Usage:\n" +
+			"\tsupermq-cli users verifyemail $VERIFICATIONTOKEN\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			user, err := sdk.VerifyEmail(args[0])
+			if err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logJSONCmd(*cmd, user)
+		},
+	},
+	{
+		Use:   "resendverification <email>",
+		Short: "Resend verification e-mail",
+		Long: "Resend verification e-mail\n" +
+			"Usage:\n" +
+			"\tsupermq-cli users resendverification example@mail.com\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				logUsageCmd(*cmd, cmd.Use)
+				return
+			}
+
+			if err := sdk.ResendVerification(args[0]); err != nil {
+				logErrorCmd(*cmd, err)
+				return
+			}
+
+			logOKCmd(*cmd)
+		},
+	},
 	{
 		Use:   "resetpasswordrequest <email>",
 		Short: "Send reset password request",
@@ -416,7 +563,7 @@ var cmdUsers = []cobra.Command{
 // NewUsersCmd returns users command.
 func NewUsersCmd() *cobra.Command {
 	cmd := cobra.Command{
-		Use:   "users [create | get | update | token | password | enable | disable | delete | channels | clients | groups | search]",
+		Use:   "users [create | get | update | token | mfatoken | mfa | sessions | password | enable | disable | delete | channels | clients | groups | search]",
 		Short: "Users management",
 		Long:  `Users management: create accounts and tokens"`,
 	}
@@ -701,6 +701,127 @@ func TestGetUserProfileCmd(t *testing.T) {
 	}
 }
 
+func TestVerifyEmailCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	usersCmd := cli.NewUsersCmd()
+	rootCmd := setFlags(usersCmd)
+
+	var usr mgsdk.User
+
+	cases := []struct {
+		desc          string
+		args          []string
+		user          mgsdk.User
+		sdkerr        errors.SDKError
+		errLogMessage string
+		logType       outputLog
+	}{
+		{
+			desc: "verify email successfully",
+			args: []string{
+				validToken,
+			},
+			user:    user,
+			logType: entityLog,
+		},
+		{
+			desc: "verify email with invalid args",
+			args: []string{
+				validToken,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "failed to verify email",
+			args: []string{
+				invalidToken,
+			},
+			sdkerr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized).Error()),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("VerifyEmail", tc.args[0]).Return(tc.user, tc.sdkerr)
+			out := executeCommand(t, rootCmd, append([]string{verEmailCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &usr)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.user, usr, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.user, usr))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestResendVerificationCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	usersCmd := cli.NewUsersCmd()
+	rootCmd := setFlags(usersCmd)
+	exampleEmail := "example@mail.com"
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkerr        errors.SDKError
+		errLogMessage string
+		logType       outputLog
+	}{
+		{
+			desc: "resend verification successfully",
+			args: []string{
+				exampleEmail,
+			},
+			logType: okLog,
+		},
+		{
+			desc: "resend verification with invalid args",
+			args: []string{
+				exampleEmail,
+				extraArg,
+			},
+			logType: usageLog,
+		},
+		{
+			desc: "failed to resend verification",
+			args: []string{
+				exampleEmail,
+			},
+			sdkerr:        errors.NewSDKErrorWithStatus(svcerr.ErrViewEntity, http.StatusBadRequest),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrViewEntity, http.StatusBadRequest).Error()),
+			logType:       errLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("ResendVerification", tc.args[0]).Return(tc.sdkerr)
+			out := executeCommand(t, rootCmd, append([]string{resVerCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			}
+			sdkCall.Unset()
+		})
+	}
+}
+
 func TestResetPasswordRequestCmd(t *testing.T) {
 	sdkMock := new(sdkmocks.SDK)
 	cli.SetSDK(sdkMock)
@@ -900,6 +1021,260 @@ func TestUpdatePasswordCmd(t *testing.T) {
 	}
 }
 
+func TestVerifyMFACmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	usersCmd := cli.NewUsersCmd()
+	rootCmd := setFlags(usersCmd)
+
+	var tkn mgsdk.Token
+	token := mgsdk.Token{
+		AccessToken:  testsutil.GenerateUUID(t),
+		RefreshToken: testsutil.GenerateUUID(t),
+	}
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkerr        errors.SDKError
+		errLogMessage string
+		token         mgsdk.Token
+		logType       outputLog
+	}{
+		{
+			desc:    "verify MFA successfully",
+			args:    []string{validToken, "123456"},
+			token:   token,
+			logType: entityLog,
+		},
+		{
+			desc:          "verify MFA with invalid code",
+			args:          []string{validToken, "000000"},
+			sdkerr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+		{
+			desc:    "verify MFA with invalid args",
+			args:    []string{validToken, "123456", extraArg},
+			logType: usageLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("VerifyMFA", tc.args[0], tc.args[1]).Return(tc.token, tc.sdkerr)
+			out := executeCommand(t, rootCmd, append([]string{mfaTokCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &tkn)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.token, tkn, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.token, tkn))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestMFACmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	usersCmd := cli.NewUsersCmd()
+	rootCmd := setFlags(usersCmd)
+
+	var enr mgsdk.MFAEnrolment
+	enrolment := mgsdk.MFAEnrolment{
+		Secret:        "JBSWY3DPEHPK3PXP",
+		URI:           "otpauth://totp/SuperMQ:user?secret=JBSWY3DPEHPK3PXP",
+		RecoveryCodes: []string{"abcde-fghij"},
+	}
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkerr        errors.SDKError
+		errLogMessage string
+		enrolment     mgsdk.MFAEnrolment
+		logType       outputLog
+	}{
+		{
+			desc:      "enroll MFA successfully",
+			args:      []string{enrollCmd, validToken},
+			enrolment: enrolment,
+			logType:   entityLog,
+		},
+		{
+			desc:          "enroll MFA with invalid token",
+			args:          []string{enrollCmd, invalidToken},
+			sdkerr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+		{
+			desc:    "enable MFA successfully",
+			args:    []string{enableCmd, "123456", validToken},
+			logType: okLog,
+		},
+		{
+			desc:          "enable MFA with invalid code",
+			args:          []string{enableCmd, "000000", validToken},
+			sdkerr:        errors.NewSDKErrorWithStatus(svcerr.ErrMalformedEntity, http.StatusBadRequest),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrMalformedEntity, http.StatusBadRequest)),
+			logType:       errLog,
+		},
+		{
+			desc:    "disable MFA successfully",
+			args:    []string{disableCmd, "123456", validToken},
+			logType: okLog,
+		},
+		{
+			desc:          "disable MFA when required by domain",
+			args:          []string{disableCmd, "123456", validToken},
+			sdkerr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden)),
+			logType:       errLog,
+		},
+		{
+			desc:    "enable MFA with invalid args",
+			args:    []string{enableCmd, validToken},
+			logType: usageLog,
+		},
+		{
+			desc:    "MFA with invalid action",
+			args:    []string{"invalid", validToken},
+			logType: usageLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("EnrollMFA", mock.Anything).Return(tc.enrolment, tc.sdkerr)
+			sdkCall1 := sdkMock.On("EnableMFA", mock.Anything, mock.Anything).Return(tc.sdkerr)
+			sdkCall2 := sdkMock.On("DisableMFA", mock.Anything, mock.Anything).Return(tc.sdkerr)
+			out := executeCommand(t, rootCmd, append([]string{mfaCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &enr)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.enrolment, enr, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.enrolment, enr))
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+
+			sdkCall2.Unset()
+			sdkCall1.Unset()
+			sdkCall.Unset()
+		})
+	}
+}
+
+func TestSessionsCmd(t *testing.T) {
+	sdkMock := new(sdkmocks.SDK)
+	cli.SetSDK(sdkMock)
+	usersCmd := cli.NewUsersCmd()
+	rootCmd := setFlags(usersCmd)
+
+	var sp mgsdk.SessionsPage
+	page := mgsdk.SessionsPage{
+		Total: 1,
+		Sessions: []mgsdk.Session{
+			{
+				ID:        testsutil.GenerateUUID(t),
+				UserAgent: "Mozilla/5.0",
+				IPAddress: "192.168.0.1",
+			},
+		},
+	}
+	sessionID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc          string
+		args          []string
+		sdkerr        errors.SDKError
+		errLogMessage string
+		page          mgsdk.SessionsPage
+		logType       outputLog
+	}{
+		{
+			desc:    "list sessions successfully",
+			args:    []string{getCmd, validToken},
+			page:    page,
+			logType: entityLog,
+		},
+		{
+			desc:          "list sessions with invalid token",
+			args:          []string{getCmd, invalidToken},
+			sdkerr:        errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized)),
+			logType:       errLog,
+		},
+		{
+			desc:    "revoke session successfully",
+			args:    []string{revokeCmd, sessionID, validToken},
+			logType: okLog,
+		},
+		{
+			desc:          "revoke non-existing session",
+			args:          []string{revokeCmd, sessionID, validToken},
+			sdkerr:        errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+			errLogMessage: fmt.Sprintf("\nerror: %s\n\n", errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound)),
+			logType:       errLog,
+		},
+		{
+			desc:    "revoke all sessions successfully",
+			args:    []string{revokeCmd, all, validToken},
+			logType: okLog,
+		},
+		{
+			desc:    "revoke session with invalid args",
+			args:    []string{revokeCmd, validToken},
+			logType: usageLog,
+		},
+		{
+			desc:    "sessions with invalid action",
+			args:    []string{"invalid", validToken},
+			logType: usageLog,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sdkCall := sdkMock.On("ListSessions", mock.Anything).Return(tc.page, tc.sdkerr)
+			sdkCall1 := sdkMock.On("RevokeSession", sessionID, mock.Anything).Return(tc.sdkerr)
+			sdkCall2 := sdkMock.On("RevokeSessions", mock.Anything).Return(tc.sdkerr)
+			out := executeCommand(t, rootCmd, append([]string{sessionsCmd}, tc.args...)...)
+
+			switch tc.logType {
+			case entityLog:
+				err := json.Unmarshal([]byte(out), &sp)
+				assert.Nil(t, err)
+				assert.Equal(t, tc.page, sp, fmt.Sprintf("%s unexpected response: expected: %v, got: %v", tc.desc, tc.page, sp))
+			case okLog:
+				assert.True(t, strings.Contains(out, "ok"), fmt.Sprintf("%s unexpected response: expected success message, got: %v", tc.desc, out))
+			case errLog:
+				assert.Equal(t, tc.errLogMessage, out, fmt.Sprintf("%s unexpected error response: expected %s got errLogMessage:%s", tc.desc, tc.errLogMessage, out))
+			case usageLog:
+				assert.False(t, strings.Contains(out, rootCmd.Use), fmt.Sprintf("%s invalid usage: %s", tc.desc, out))
+			}
+
+			sdkCall2.Unset()
+			sdkCall1.Unset()
+			sdkCall.Unset()
+		})
+	}
+}
+
 func TestEnableUserCmd(t *testing.T) {
 	sdkMock := new(sdkmocks.SDK)
 	cli.SetSDK(sdkMock)
@@ -14,6 +14,7 @@ import (
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/go-kit/kit/endpoint"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 	"google.golang.org/grpc"
@@ -34,6 +35,8 @@ type grpcClient struct {
 	removeConnections          endpoint.Endpoint
 	removeChannelConnections   endpoint.Endpoint
 	unsetParentGroupFromClient endpoint.Endpoint
+	createClients              endpoint.Endpoint
+	removeClients              endpoint.Endpoint
 }
 
 // NewClient returns new gRPC client instance.
@@ -102,6 +105,24 @@ func NewClient(conn *grpc.ClientConn, timeout time.Duration) grpcClientsV1.Clien
 			grpcClientsV1.UnsetParentGroupFromClientRes{},
 		).Endpoint(),
 
+		createClients: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"CreateClients",
+			encodeCreateClientsRequest,
+			decodeCreateClientsResponse,
+			grpcClientsV1.CreateClientsRes{},
+		).Endpoint(),
+
+		removeClients: kitgrpc.NewClient(
+			conn,
+			svcName,
+			"RemoveClients",
+			encodeRemoveClientsRequest,
+			decodeRemoveClientsResponse,
+			grpcClientsV1.RemoveClientsRes{},
+		).Endpoint(),
+
 		timeout: timeout,
 	}
 }
@@ -110,9 +131,16 @@ func (client grpcClient) Authenticate(ctx context.Context, req *grpcClientsV1.Au
 	ctx, cancel := context.WithTimeout(ctx, client.timeout)
 	defer cancel()
 
+	// The adapters put the address of the client they serve to the
+	// context, so the failed attempts are limited per source.
+	source := req.GetSource()
+	if source == "" {
+		source = lockout.Source(ctx)
+	}
 	res, err := client.authenticate(ctx, authenticateReq{
 		ClientID:     req.GetClientId(),
 		ClientSecret: req.GetClientSecret(),
+		Source:       source,
 	})
 	if err != nil {
 		return &grpcClientsV1.AuthnRes{}, decodeError(err)
@@ -127,6 +155,7 @@ func encodeAuthenticateRequest(_ context.Context, grpcReq interface{}) (interfac
 	return &grpcClientsV1.AuthnReq{
 		ClientId:     req.ClientID,
 		ClientSecret: req.ClientSecret,
+		Source:       req.Source,
 	}, nil
 }
 
@@ -346,6 +375,45 @@ func decodeUnsetParentGroupFromClientResponse(_ context.Context, grpcRes interfa
 	return grpcRes.(*grpcClientsV1.UnsetParentGroupFromClientRes), nil
 }
 
+func (client grpcClient) CreateClients(ctx context.Context, req *grpcClientsV1.CreateClientsReq, _ ...grpc.CallOption) (r *grpcClientsV1.CreateClientsRes, err error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.createClients(ctx, req)
+	if err != nil {
+		return &grpcClientsV1.CreateClientsRes{}, decodeError(err)
+	}
+
+	return res.(*grpcClientsV1.CreateClientsRes), nil
+}
+
+func encodeCreateClientsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return grpcReq.(*grpcClientsV1.CreateClientsReq), nil
+}
+
+func decodeCreateClientsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return grpcRes.(*grpcClientsV1.CreateClientsRes), nil
+}
+
+func (client grpcClient) RemoveClients(ctx context.Context, req *grpcClientsV1.RemoveClientsReq, _ ...grpc.CallOption) (r *grpcClientsV1.RemoveClientsRes, err error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	if _, err := client.removeClients(ctx, req); err != nil {
+		return &grpcClientsV1.RemoveClientsRes{}, decodeError(err)
+	}
+
+	return &grpcClientsV1.RemoveClientsRes{}, nil
+}
+
+func encodeRemoveClientsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return grpcReq.(*grpcClientsV1.RemoveClientsReq), nil
+}
+
+func decodeRemoveClientsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	return grpcRes.(*grpcClientsV1.RemoveClientsRes), nil
+}
+
 func decodeError(err error) error {
 	if st, ok := status.FromError(err); ok {
 		switch st.Code() {
@@ -361,6 +429,8 @@ func decodeError(err error) error {
 			return errors.Wrap(svcerr.ErrNotFound, errors.New(st.Message()))
 		case codes.AlreadyExists:
 			return errors.Wrap(svcerr.ErrConflict, errors.New(st.Message()))
+		case codes.ResourceExhausted:
+			return errors.Wrap(lockout.ErrLocked, errors.New(st.Message()))
 		case codes.OK:
 			if msg := st.Message(); msg != "" {
 				return errors.Wrap(errors.ErrUnidentified, errors.New(msg))
@@ -8,13 +8,17 @@ import (
 
 	"github.com/absmach/supermq/clients"
 	pClients "github.com/absmach/supermq/clients/private"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/go-kit/kit/endpoint"
 )
 
 func authenticateEndpoint(svc pClients.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(authenticateReq)
-		id, err := svc.Authenticate(ctx, req.ClientSecret)
+		if req.Source != "" {
+			ctx = lockout.WithSource(ctx, req.Source)
+		}
+		id, err := svc.Authenticate(ctx, req.ClientID, req.ClientSecret)
 		if err != nil {
 			return authenticateRes{}, err
 		}
@@ -125,3 +129,28 @@ func UnsetParentGroupFromClientEndpoint(svc pClients.Service) endpoint.Endpoint
 		return UnsetParentGroupFromClientRes{}, nil
 	}
 }
+
+func createClientsEndpoint(svc pClients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createClientsReq)
+
+		cls, err := svc.CreateClients(ctx, req.session, req.clients...)
+		if err != nil {
+			return createClientsRes{}, err
+		}
+
+		return createClientsRes{clients: cls}, nil
+	}
+}
+
+func removeClientsEndpoint(svc pClients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(removeClientsReq)
+
+		if err := svc.RemoveClients(ctx, req.session, req.ids...); err != nil {
+			return removeClientsRes{}, err
+		}
+
+		return removeClientsRes{}, nil
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
@@ -64,7 +65,11 @@ func TestAuthenticate(t *testing.T) {
 
 	cases := []struct {
 		desc         string
+		reqClientID  string
 		clientSecret string
+		reqSource    string
+		ctxSource    string
+		source       string
 		clientID     string
 		resp         *grpcClientsV1.AuthnRes
 		svcErr       error
@@ -92,14 +97,56 @@ func TestAuthenticate(t *testing.T) {
 			svcErr:   svcerr.ErrAuthentication,
 			err:      svcerr.ErrAuthentication,
 		},
+		{
+			desc:         "authenticate with request source",
+			clientSecret: validSecret,
+			reqSource:    "192.0.2.1",
+			source:       "192.0.2.1",
+			resp: &grpcClientsV1.AuthnRes{
+				Authenticated: true,
+				Id:            validID,
+			},
+			clientID: validID,
+		},
+		{
+			desc:         "authenticate with context source",
+			clientSecret: validSecret,
+			ctxSource:    "192.0.2.2",
+			source:       "192.0.2.2",
+			resp: &grpcClientsV1.AuthnRes{
+				Authenticated: true,
+				Id:            validID,
+			},
+			clientID: validID,
+		},
+		{
+			desc:         "authenticate locked out client",
+			reqClientID:  validID,
+			clientSecret: validSecret,
+			resp: &grpcClientsV1.AuthnRes{
+				Authenticated: false,
+				Id:            "",
+			},
+			clientID: "",
+			svcErr:   errors.Wrap(svcerr.ErrAuthentication, lockout.ErrLocked),
+			err:      lockout.ErrLocked,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
-			svcCall := svc.On("Authenticate", mock.Anything, tc.clientSecret).Return(tc.clientID, tc.svcErr)
-			res, err := client.Authenticate(context.Background(), &grpcClientsV1.AuthnReq{ClientSecret: tc.clientSecret})
+			var source string
+			svcCall := svc.On("Authenticate", mock.Anything, tc.reqClientID, tc.clientSecret).Return(tc.clientID, tc.svcErr).Run(func(args mock.Arguments) {
+				source = lockout.Source(args.Get(0).(context.Context))
+			})
+			ctx := context.Background()
+			if tc.ctxSource != "" {
+				ctx = lockout.WithSource(ctx, tc.ctxSource)
+			}
+			res, err := client.Authenticate(ctx, &grpcClientsV1.AuthnReq{ClientId: tc.reqClientID, ClientSecret: tc.clientSecret, Source: tc.reqSource})
 			assert.True(t, errors.Contains(err, tc.err))
 			assert.Equal(t, tc.resp, res)
+			assert.Equal(t, tc.source, source)
 			svcCall.Unset()
 		})
 	}
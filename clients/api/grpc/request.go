@@ -3,9 +3,15 @@
 
 package grpc
 
+import (
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/pkg/authn"
+)
+
 type authenticateReq struct {
 	ClientID     string
 	ClientSecret string
+	Source       string
 }
 
 type retrieveEntitiesReq struct {
@@ -23,3 +29,13 @@ type removeChannelConnectionsReq struct {
 type UnsetParentGroupFromClientReq struct {
 	parentGroupID string
 }
+
+type createClientsReq struct {
+	session authn.Session
+	clients []clients.Client
+}
+
+type removeClientsReq struct {
+	session authn.Session
+	ids     []string
+}
@@ -3,7 +3,10 @@
 
 package grpc
 
-import "github.com/absmach/supermq/pkg/connections"
+import (
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/pkg/connections"
+)
 
 type entity struct {
 	id          string
@@ -43,3 +46,9 @@ type connectionsRes struct {
 type removeChannelConnectionsRes struct{}
 
 type UnsetParentGroupFromClientRes struct{}
+
+type createClientsRes struct {
+	clients []clients.Client
+}
+
+type removeClientsRes struct{}
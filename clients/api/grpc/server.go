@@ -5,15 +5,19 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
 
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	smqauth "github.com/absmach/supermq/auth"
+	smqclients "github.com/absmach/supermq/clients"
 	clients "github.com/absmach/supermq/clients/private"
+	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
 	kitgrpc "github.com/go-kit/kit/transport/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -30,6 +34,8 @@ type grpcServer struct {
 	removeConnections          kitgrpc.Handler
 	removeChannelConnections   kitgrpc.Handler
 	unsetParentGroupFromClient kitgrpc.Handler
+	createClients              kitgrpc.Handler
+	removeClients              kitgrpc.Handler
 }
 
 // NewServer returns new AuthServiceServer instance.
@@ -70,6 +76,16 @@ func NewServer(svc clients.Service) grpcClientsV1.ClientsServiceServer {
 			decodeUnsetParentGroupFromClientRequest,
 			encodeUnsetParentGroupFromClientResponse,
 		),
+		createClients: kitgrpc.NewServer(
+			createClientsEndpoint(svc),
+			decodeCreateClientsRequest,
+			encodeCreateClientsResponse,
+		),
+		removeClients: kitgrpc.NewServer(
+			removeClientsEndpoint(svc),
+			decodeRemoveClientsRequest,
+			encodeRemoveClientsResponse,
+		),
 	}
 }
 
@@ -86,6 +102,7 @@ func decodeAuthorizeRequest(_ context.Context, grpcReq interface{}) (interface{}
 	return authenticateReq{
 		ClientID:     req.GetClientId(),
 		ClientSecret: req.GetClientSecret(),
+		Source:       req.GetSource(),
 	}, nil
 }
 
@@ -264,6 +281,120 @@ func encodeUnsetParentGroupFromClientResponse(_ context.Context, grpcRes interfa
 	return &grpcClientsV1.UnsetParentGroupFromClientRes{}, nil
 }
 
+func (s *grpcServer) CreateClients(ctx context.Context, req *grpcClientsV1.CreateClientsReq) (*grpcClientsV1.CreateClientsRes, error) {
+	_, res, err := s.createClients.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*grpcClientsV1.CreateClientsRes), nil
+}
+
+func decodeCreateClientsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcClientsV1.CreateClientsReq)
+
+	cls := []smqclients.Client{}
+	for _, c := range req.GetClients() {
+		cli, err := fromProtoClient(c)
+		if err != nil {
+			return nil, err
+		}
+		cls = append(cls, cli)
+	}
+
+	return createClientsReq{
+		session: authn.Session{
+			DomainID:     req.GetDomainId(),
+			UserID:       req.GetUserId(),
+			DomainUserID: smqauth.EncodeDomainUserID(req.GetDomainId(), req.GetUserId()),
+		},
+		clients: cls,
+	}, nil
+}
+
+func encodeCreateClientsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(createClientsRes)
+
+	cls := []*grpcClientsV1.Client{}
+	for _, c := range res.clients {
+		cli, err := toProtoClient(c)
+		if err != nil {
+			return nil, err
+		}
+		cls = append(cls, cli)
+	}
+
+	return &grpcClientsV1.CreateClientsRes{Clients: cls}, nil
+}
+
+func (s *grpcServer) RemoveClients(ctx context.Context, req *grpcClientsV1.RemoveClientsReq) (*grpcClientsV1.RemoveClientsRes, error) {
+	_, res, err := s.removeClients.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, encodeError(err)
+	}
+	return res.(*grpcClientsV1.RemoveClientsRes), nil
+}
+
+func decodeRemoveClientsRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcClientsV1.RemoveClientsReq)
+
+	return removeClientsReq{
+		session: authn.Session{
+			DomainID:     req.GetDomainId(),
+			UserID:       req.GetUserId(),
+			DomainUserID: smqauth.EncodeDomainUserID(req.GetDomainId(), req.GetUserId()),
+		},
+		ids: req.GetIds(),
+	}, nil
+}
+
+func encodeRemoveClientsResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	_ = grpcRes.(removeClientsRes)
+	return &grpcClientsV1.RemoveClientsRes{}, nil
+}
+
+func toProtoClient(c smqclients.Client) (*grpcClientsV1.Client, error) {
+	var metadata []byte
+	if c.Metadata != nil {
+		var err error
+		if metadata, err = json.Marshal(c.Metadata); err != nil {
+			return nil, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return &grpcClientsV1.Client{
+		Id:            c.ID,
+		Name:          c.Name,
+		Tags:          c.Tags,
+		Metadata:      metadata,
+		ParentGroupId: c.ParentGroup,
+		Status:        uint32(c.Status),
+		Identity:      c.Credentials.Identity,
+		Secret:        c.Credentials.Secret,
+	}, nil
+}
+
+func fromProtoClient(c *grpcClientsV1.Client) (smqclients.Client, error) {
+	var metadata smqclients.Metadata
+	if len(c.GetMetadata()) > 0 {
+		if err := json.Unmarshal(c.GetMetadata(), &metadata); err != nil {
+			return smqclients.Client{}, errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return smqclients.Client{
+		ID:          c.GetId(),
+		Name:        c.GetName(),
+		Tags:        c.GetTags(),
+		Metadata:    metadata,
+		ParentGroup: c.GetParentGroupId(),
+		Status:      smqclients.Status(c.GetStatus()),
+		Credentials: smqclients.Credentials{
+			Identity: c.GetIdentity(),
+			Secret:   c.GetSecret(),
+		},
+	}, nil
+}
+
 func encodeError(err error) error {
 	switch {
 	case errors.Contains(err, nil):
@@ -274,8 +405,14 @@ func encodeError(err error) error {
 		err == apiutil.ErrMissingMemberType,
 		err == apiutil.ErrMissingPolicySub,
 		err == apiutil.ErrMissingPolicyObj,
-		err == apiutil.ErrMalformedPolicyAct:
+		err == apiutil.ErrMalformedPolicyAct,
+		errors.Contains(err, svcerr.ErrInvalidStatus):
 		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Contains(err, svcerr.ErrConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Contains(err, lockout.ErrLocked),
+		errors.Contains(err, lockout.ErrLockout):
+		return status.Error(codes.ResourceExhausted, err.Error())
 	case errors.Contains(err, svcerr.ErrAuthentication),
 		errors.Contains(err, smqauth.ErrKeyExpired),
 		err == apiutil.ErrMissingEmail,
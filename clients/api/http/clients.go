@@ -77,6 +77,20 @@ func clientsHandler(svc clients.Service, authn smqauthn.Authentication, r *chi.M
 					opts...,
 				), "update_client_credentials").ServeHTTP)
 
+				r.Post("/secret/rotate", otelhttp.NewHandler(kithttp.NewServer(
+					rotateClientSecretEndpoint(svc),
+					decodeRotateClientSecret,
+					api.EncodeResponse,
+					opts...,
+				), "rotate_client_secret").ServeHTTP)
+
+				r.Post("/secret/confirm", otelhttp.NewHandler(kithttp.NewServer(
+					confirmClientSecretEndpoint(svc),
+					decodeChangeClientStatus,
+					api.EncodeResponse,
+					opts...,
+				), "confirm_client_secret").ServeHTTP)
+
 				r.Post("/enable", otelhttp.NewHandler(kithttp.NewServer(
 					enableClientEndpoint(svc),
 					decodeChangeClientStatus,
@@ -91,6 +105,13 @@ func clientsHandler(svc clients.Service, authn smqauthn.Authentication, r *chi.M
 					opts...,
 				), "disable_client").ServeHTTP)
 
+				r.Post("/unlock", otelhttp.NewHandler(kithttp.NewServer(
+					unlockClientEndpoint(svc),
+					decodeChangeClientStatus,
+					api.EncodeResponse,
+					opts...,
+				), "unlock_client").ServeHTTP)
+
 				r.Post("/parent", otelhttp.NewHandler(kithttp.NewServer(
 					setClientParentGroupEndpoint(svc),
 					decodeSetClientParentGroupStatus,
@@ -46,6 +46,15 @@ func decodeListClients(_ context.Context, r *http.Request) (interface{}, error)
 		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
 	}
 
+	p, err := apiutil.ReadStringQuery(r, api.PresenceKey, "")
+	if err != nil {
+		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	presence, err := clients.ToPresenceStatus(p)
+	if err != nil {
+		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
 	meta, err := apiutil.ReadMetadataQuery(r, api.MetadataKey, nil)
 	if err != nil {
 		return listClientsReq{}, errors.Wrap(apiutil.ErrValidation, err)
@@ -120,6 +129,7 @@ func decodeListClients(_ context.Context, r *http.Request) (interface{}, error)
 		name:       name,
 		tag:        tag,
 		status:     status,
+		presence:   presence,
 		metadata:   meta,
 		roleName:   roleName,
 		roleID:     roleID,
@@ -182,6 +192,21 @@ func decodeUpdateClientCredentials(_ context.Context, r *http.Request) (interfac
 	return req, nil
 }
 
+func decodeRotateClientSecret(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := rotateClientSecretReq{
+		id: chi.URLParam(r, clientID),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
 func decodeCreateClientReq(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -107,6 +107,7 @@ func listClientsEndpoint(svc clients.Service) endpoint.Endpoint {
 			Name:           req.name,
 			Tag:            req.tag,
 			Status:         req.status,
+			Presence:       req.presence,
 			Metadata:       req.metadata,
 			RoleName:       req.roleName,
 			RoleID:         req.roleID,
@@ -221,6 +222,48 @@ func updateClientSecretEndpoint(svc clients.Service) endpoint.Endpoint {
 	}
 }
 
+func rotateClientSecretEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(rotateClientSecretReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		client, err := svc.RotateSecret(ctx, session, req.id, req.Secret, req.Duration)
+		if err != nil {
+			return nil, err
+		}
+
+		return updateClientRes{Client: client}, nil
+	}
+}
+
+func confirmClientSecretEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(changeClientStatusReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		client, err := svc.ConfirmSecret(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return updateClientRes{Client: client}, nil
+	}
+}
+
 func enableClientEndpoint(svc clients.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(changeClientStatusReq)
@@ -263,6 +306,26 @@ func disableClientEndpoint(svc clients.Service) endpoint.Endpoint {
 	}
 }
 
+func unlockClientEndpoint(svc clients.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(changeClientStatusReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(authn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		if err := svc.Unlock(ctx, session, req.id); err != nil {
+			return nil, err
+		}
+
+		return unlockClientRes{}, nil
+	}
+}
+
 func setClientParentGroupEndpoint(svc clients.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (interface{}, error) {
 		req := request.(setClientParentGroupReq)
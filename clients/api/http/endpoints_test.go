@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/0x6flab/namegenerator"
 	api "github.com/absmach/supermq/api/http"
@@ -598,6 +599,39 @@ func TestListClients(t *testing.T) {
 			status:   http.StatusBadRequest,
 			err:      apiutil.ErrInvalidQueryParams,
 		},
+		{
+			desc:     "list clients with presence",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, SuperAdmin: false},
+			listClientsResponse: clients.ClientsPage{
+				Page: clients.Page{
+					Total: 1,
+				},
+				Clients: []clients.Client{client},
+			},
+			query:  "presence=online",
+			status: http.StatusOK,
+			err:    nil,
+		},
+		{
+			desc:     "list clients with invalid presence",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, SuperAdmin: false},
+			query:    "presence=invalid",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrValidation,
+		},
+		{
+			desc:     "list clients with duplicate presence",
+			domainID: domainID,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID, DomainUserID: domainID + "_" + validID, SuperAdmin: false},
+			query:    "presence=online&presence=offline",
+			status:   http.StatusBadRequest,
+			err:      apiutil.ErrInvalidQueryParams,
+		},
 		{
 			desc:     "list clients with tags",
 			domainID: domainID,
@@ -975,7 +1009,7 @@ func TestUpdateClient(t *testing.T) {
 			}
 
 			if err == nil {
-				assert.Equal(t, tc.clientResponse.ID, resBody.ID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.clientResponse, resBody.ID))
+				assert.Equal(t, tc.clientResponse.ID, resBody.ID, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.clientResponse.ID, resBody.ID))
 			}
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
@@ -1287,6 +1321,219 @@ func TestUpdateClientSecret(t *testing.T) {
 	}
 }
 
+func TestRotateClientSecret(t *testing.T) {
+	ts, svc, authn := newClientsServer()
+	defer ts.Close()
+
+	expiresAt := time.Now().Add(time.Hour)
+	rotatedClient := clients.Client{
+		ID: client.ID,
+		Credentials: clients.Credentials{
+			PendingSecret:   "strongersecret",
+			SecretExpiresAt: &expiresAt,
+		},
+	}
+
+	cases := []struct {
+		desc        string
+		data        string
+		id          string
+		contentType string
+		domainID    string
+		token       string
+		status      int
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcRes      clients.Client
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "rotate client secret with valid token",
+			data:        `{"secret": "strongersecret", "duration": "1h"}`,
+			id:          client.ID,
+			contentType: contentType,
+			domainID:    domainID,
+			token:       validToken,
+			authnRes:    smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			svcRes:      rotatedClient,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:        "rotate client secret without secret",
+			data:        `{"duration": "1h"}`,
+			id:          client.ID,
+			contentType: contentType,
+			domainID:    domainID,
+			token:       validToken,
+			authnRes:    smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			svcRes:      rotatedClient,
+			status:      http.StatusOK,
+			err:         nil,
+		},
+		{
+			desc:        "rotate client secret with invalid token",
+			data:        `{"secret": "strongersecret", "duration": "1h"}`,
+			id:          client.ID,
+			contentType: contentType,
+			domainID:    domainID,
+			token:       inValid,
+			status:      http.StatusUnauthorized,
+			authnErr:    svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "rotate client secret without duration",
+			data:        `{"secret": "strongersecret"}`,
+			id:          client.ID,
+			contentType: contentType,
+			domainID:    domainID,
+			token:       validToken,
+			authnRes:    smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrInvalidDuration,
+		},
+		{
+			desc:        "rotate client secret with invalid duration",
+			data:        `{"secret": "strongersecret", "duration": "invalid"}`,
+			id:          client.ID,
+			contentType: contentType,
+			domainID:    domainID,
+			token:       validToken,
+			authnRes:    smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "rotate client secret with invalid content type",
+			data:        `{"secret": "strongersecret", "duration": "1h"}`,
+			id:          client.ID,
+			contentType: "application/xml",
+			domainID:    domainID,
+			token:       validToken,
+			authnRes:    smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "rotate client secret with conflicting secret",
+			data:        `{"secret": "strongersecret", "duration": "1h"}`,
+			id:          client.ID,
+			contentType: contentType,
+			domainID:    domainID,
+			token:       validToken,
+			authnRes:    smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			svcErr:      svcerr.ErrConflict,
+			status:      http.StatusConflict,
+			err:         svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/clients/%s/secret/rotate", ts.URL, tc.domainID, tc.id),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.data),
+			}
+
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("RotateSecret", mock.Anything, tc.authnRes, tc.id, mock.Anything, time.Hour).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestConfirmClientSecret(t *testing.T) {
+	ts, svc, authn := newClientsServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		id       string
+		domainID string
+		token    string
+		status   int
+		authnRes smqauthn.Session
+		authnErr error
+		svcRes   clients.Client
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "confirm client secret with valid token",
+			id:       client.ID,
+			domainID: domainID,
+			token:    validToken,
+			authnRes: smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			svcRes:   clients.Client{ID: client.ID},
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "confirm client secret with invalid token",
+			id:       client.ID,
+			domainID: domainID,
+			token:    inValidToken,
+			status:   http.StatusUnauthorized,
+			authnErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "confirm client secret without pending secret",
+			id:       client.ID,
+			domainID: domainID,
+			token:    validToken,
+			authnRes: smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID},
+			svcErr:   errors.Wrap(svcerr.ErrConflict, clients.ErrNoPendingSecret),
+			status:   http.StatusConflict,
+			err:      svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/clients/%s/secret/confirm", ts.URL, tc.domainID, tc.id),
+				contentType: contentType,
+				token:       tc.token,
+			}
+
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ConfirmSecret", mock.Anything, tc.authnRes, tc.id).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func TestEnableClient(t *testing.T) {
 	ts, svc, authn := newClientsServer()
 	defer ts.Close()
@@ -4,6 +4,9 @@
 package http
 
 import (
+	"encoding/json"
+	"time"
+
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/clients"
@@ -74,6 +77,7 @@ type listClientsReq struct {
 	name       string
 	tag        string
 	status     clients.Status
+	presence   clients.PresenceStatus
 	metadata   clients.Metadata
 	roleName   string
 	roleID     string
@@ -163,6 +167,45 @@ func (req updateClientCredentialsReq) validate() error {
 	return nil
 }
 
+type rotateClientSecretReq struct {
+	id       string
+	Secret   string        `json:"secret,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+func (req *rotateClientSecretReq) UnmarshalJSON(data []byte) error {
+	var temp struct {
+		Secret   string `json:"secret,omitempty"`
+		Duration string `json:"duration,omitempty"`
+	}
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	req.Secret = temp.Secret
+	if temp.Duration == "" {
+		return nil
+	}
+	duration, err := time.ParseDuration(temp.Duration)
+	if err != nil {
+		return err
+	}
+	req.Duration = duration
+
+	return nil
+}
+
+func (req rotateClientSecretReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	if req.Duration <= 0 {
+		return apiutil.ErrInvalidDuration
+	}
+
+	return nil
+}
+
 type changeClientStatusReq struct {
 	id string
 }
@@ -6,6 +6,7 @@ package http
 import (
 	"strings"
 	"testing"
+	"time"
 
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
@@ -365,6 +366,59 @@ func TestUpdateClientCredentialsReqValidate(t *testing.T) {
 	}
 }
 
+func TestRotateClientSecretReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  rotateClientSecretReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req: rotateClientSecretReq{
+				id:       validID,
+				Secret:   "secret",
+				Duration: time.Hour,
+			},
+			err: nil,
+		},
+		{
+			desc: "valid request without secret",
+			req: rotateClientSecretReq{
+				id:       validID,
+				Duration: time.Hour,
+			},
+			err: nil,
+		},
+		{
+			desc: "empty id",
+			req: rotateClientSecretReq{
+				id:       "",
+				Duration: time.Hour,
+			},
+			err: apiutil.ErrMissingID,
+		},
+		{
+			desc: "missing duration",
+			req: rotateClientSecretReq{
+				id: validID,
+			},
+			err: apiutil.ErrInvalidDuration,
+		},
+		{
+			desc: "negative duration",
+			req: rotateClientSecretReq{
+				id:       validID,
+				Duration: -time.Hour,
+			},
+			err: apiutil.ErrInvalidDuration,
+		},
+	}
+	for _, tc := range cases {
+		err := tc.req.validate()
+		assert.Equal(t, tc.err, err)
+	}
+}
+
 func TestChangeClientStatusReqValidate(t *testing.T) {
 	cases := []struct {
 		desc string
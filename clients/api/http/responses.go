@@ -18,6 +18,7 @@ var (
 	_ supermq.Response = (*clientsPageRes)(nil)
 	_ supermq.Response = (*changeClientStatusRes)(nil)
 	_ supermq.Response = (*deleteClientRes)(nil)
+	_ supermq.Response = (*unlockClientRes)(nil)
 )
 
 type clientsPageMetaRes struct {
@@ -162,6 +163,20 @@ func (res removeParentGroupRes) Empty() bool {
 	return true
 }
 
+type unlockClientRes struct{}
+
+func (res unlockClientRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unlockClientRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unlockClientRes) Empty() bool {
+	return true
+}
+
 type deleteClientRes struct{}
 
 func (res deleteClientRes) Code() int {
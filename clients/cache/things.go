@@ -15,8 +15,11 @@ import (
 )
 
 const (
-	keyPrefix = "client_key"
-	idPrefix  = "client_id"
+	keyPrefix  = "client_key"
+	keysPrefix = "client_keys"
+	// legacyIDPrefix is the prefix of the single client key entries stored
+	// by the previous versions, which are removed until they expire.
+	legacyIDPrefix = "client_id"
 )
 
 var _ clients.Cache = (*clientCache)(nil)
@@ -35,16 +38,38 @@ func NewCache(client *redis.Client, duration time.Duration) clients.Cache {
 }
 
 func (tc *clientCache) Save(ctx context.Context, clientKey, clientID string) error {
+	return tc.save(ctx, clientKey, clientID, tc.keyDuration)
+}
+
+func (tc *clientCache) SaveWithExpiry(ctx context.Context, clientKey, clientID string, expiresAt time.Time) error {
+	duration := time.Until(expiresAt)
+	if duration <= 0 {
+		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("client key has expired"))
+	}
+	if duration > tc.keyDuration {
+		duration = tc.keyDuration
+	}
+
+	return tc.save(ctx, clientKey, clientID, duration)
+}
+
+// save stores the client key and adds it to the set of the client keys, so
+// all of them are removed together while the secret is being rotated.
+func (tc *clientCache) save(ctx context.Context, clientKey, clientID string, duration time.Duration) error {
 	if clientKey == "" || clientID == "" {
 		return errors.Wrap(repoerr.ErrCreateEntity, errors.New("client key or client id is empty"))
 	}
 	tkey := fmt.Sprintf("%s:%s", keyPrefix, clientKey)
-	if err := tc.client.Set(ctx, tkey, clientID, tc.keyDuration).Err(); err != nil {
+	if err := tc.client.Set(ctx, tkey, clientID, duration).Err(); err != nil {
 		return errors.Wrap(repoerr.ErrCreateEntity, err)
 	}
 
-	tid := fmt.Sprintf("%s:%s", idPrefix, clientID)
-	if err := tc.client.Set(ctx, tid, clientKey, tc.keyDuration).Err(); err != nil {
+	tid := fmt.Sprintf("%s:%s", keysPrefix, clientID)
+	if _, err := tc.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, tid, clientKey)
+		pipe.Expire(ctx, tid, tc.keyDuration)
+		return nil
+	}); err != nil {
 		return errors.Wrap(repoerr.ErrCreateEntity, err)
 	}
 
@@ -66,18 +91,27 @@ func (tc *clientCache) ID(ctx context.Context, clientKey string) (string, error)
 }
 
 func (tc *clientCache) Remove(ctx context.Context, clientID string) error {
-	tid := fmt.Sprintf("%s:%s", idPrefix, clientID)
-	key, err := tc.client.Get(ctx, tid).Result()
-	// Redis returns Nil Reply when key does not exist.
-	if err == redis.Nil {
-		return nil
-	}
+	tid := fmt.Sprintf("%s:%s", keysPrefix, clientID)
+	keys, err := tc.client.SMembers(ctx, tid).Result()
 	if err != nil {
 		return errors.Wrap(repoerr.ErrRemoveEntity, err)
 	}
 
-	tkey := fmt.Sprintf("%s:%s", keyPrefix, key)
-	if err := tc.client.Del(ctx, tkey, tid).Err(); err != nil {
+	lid := fmt.Sprintf("%s:%s", legacyIDPrefix, clientID)
+	legacyKey, err := tc.client.Get(ctx, lid).Result()
+	// Redis returns Nil Reply when key does not exist.
+	if err != nil && err != redis.Nil {
+		return errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	if legacyKey != "" {
+		keys = append(keys, legacyKey)
+	}
+
+	tkeys := []string{tid, lid}
+	for _, key := range keys {
+		tkeys = append(tkeys, fmt.Sprintf("%s:%s", keyPrefix, key))
+	}
+	if err := tc.client.Del(ctx, tkeys...).Err(); err != nil {
 		return errors.Wrap(repoerr.ErrRemoveEntity, err)
 	}
 
@@ -94,6 +94,63 @@ func TestSave(t *testing.T) {
 	}
 }
 
+func TestSaveWithExpiry(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	tscache := cache.NewCache(redisClient, 1*time.Minute)
+	ctx := context.Background()
+
+	cases := []struct {
+		desc      string
+		key       string
+		id        string
+		expiresAt time.Time
+		ttl       time.Duration
+		err       error
+	}{
+		{
+			desc:      "Save client expiring before the key duration",
+			key:       testKey,
+			id:        testID,
+			expiresAt: time.Now().Add(10 * time.Second),
+			ttl:       10 * time.Second,
+			err:       nil,
+		},
+		{
+			desc:      "Save client expiring after the key duration",
+			key:       testKey2,
+			id:        testID,
+			expiresAt: time.Now().Add(time.Hour),
+			ttl:       1 * time.Minute,
+			err:       nil,
+		},
+		{
+			desc:      "Save expired client",
+			key:       "expiredKey",
+			id:        testID2,
+			expiresAt: time.Now().Add(-time.Second),
+			err:       repoerr.ErrCreateEntity,
+		},
+		{
+			desc:      "Save client with empty key",
+			key:       "",
+			id:        testID,
+			expiresAt: time.Now().Add(time.Hour),
+			err:       repoerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		err := tscache.SaveWithExpiry(ctx, tc.key, tc.id, tc.expiresAt)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+		if err == nil {
+			id, _ := tscache.ID(ctx, tc.key)
+			assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.id, id))
+			ttl := redisClient.TTL(ctx, fmt.Sprintf("client_key:%s", tc.key)).Val()
+			assert.True(t, ttl > 0 && ttl <= tc.ttl, fmt.Sprintf("%s: expected TTL up to %s got %s", tc.desc, tc.ttl, ttl))
+		}
+	}
+}
+
 func TestID(t *testing.T) {
 	redisClient.FlushAll(context.Background())
 	tscache := cache.NewCache(redisClient, 1*time.Minute)
@@ -177,3 +234,45 @@ func TestRemove(t *testing.T) {
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 	}
 }
+
+func TestRemoveRotatedSecrets(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	tscache := cache.NewCache(redisClient, 1*time.Minute)
+	ctx := context.Background()
+
+	err := tscache.Save(ctx, testKey, testID)
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
+	err = tscache.SaveWithExpiry(ctx, testKey2, testID, time.Now().Add(time.Minute))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
+
+	err = tscache.Remove(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to remove: %s", err))
+	for _, key := range []string{testKey, testKey2} {
+		_, err := tscache.ID(ctx, key)
+		assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s\n", repoerr.ErrNotFound, err))
+	}
+}
+
+func TestRemoveLegacyEntries(t *testing.T) {
+	redisClient.FlushAll(context.Background())
+	tscache := cache.NewCache(redisClient, 1*time.Minute)
+	ctx := context.Background()
+
+	// Entries stored by the previous versions map the client ID to its key.
+	err := redisClient.Set(ctx, fmt.Sprintf("client_key:%s", testKey), testID, time.Minute).Err()
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
+	err = redisClient.Set(ctx, fmt.Sprintf("client_id:%s", testID), testKey, time.Minute).Err()
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
+	err = tscache.Save(ctx, testKey2, testID)
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to save: %s", err))
+
+	err = tscache.Remove(ctx, testID)
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while trying to remove: %s", err))
+	for _, key := range []string{testKey, testKey2} {
+		_, err := tscache.ID(ctx, key)
+		assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s\n", repoerr.ErrNotFound, err))
+	}
+	n, err := redisClient.Exists(ctx, fmt.Sprintf("client_id:%s", testID)).Result()
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error while checking legacy entry: %s", err))
+	assert.Equal(t, int64(0), n, "expected legacy client ID entry to be removed")
+}
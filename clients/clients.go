@@ -13,6 +13,9 @@ import (
 	"github.com/absmach/supermq/pkg/roles"
 )
 
+// LockoutClientKind is the kind of the failed client secret attempts keys.
+const LockoutClientKind = "client"
+
 type Connection struct {
 	ClientID  string
 	ChannelID string
@@ -53,9 +56,18 @@ type Repository interface {
 	// UpdateIdentity updates identity for client with given id.
 	UpdateIdentity(ctx context.Context, client Client) (Client, error)
 
-	// UpdateSecret updates secret for client with given identity.
+	// UpdateSecret updates secret for client with given identity and
+	// discards the pending secret.
 	UpdateSecret(ctx context.Context, client Client) (Client, error)
 
+	// UpdatePendingSecret sets the pending secret of the client and the
+	// expiry of the current secret, unless a different secret is already
+	// pending.
+	UpdatePendingSecret(ctx context.Context, client Client) (Client, error)
+
+	// ConfirmSecret replaces the client secret with the pending one.
+	ConfirmSecret(ctx context.Context, client Client) (Client, error)
+
 	// ChangeStatus changes client status to enabled or disabled
 	ChangeStatus(ctx context.Context, client Client) (Client, error)
 
@@ -66,7 +78,8 @@ type Repository interface {
 	// operation failure.
 	Save(ctx context.Context, client ...Client) ([]Client, error)
 
-	// RetrieveBySecret retrieves a client based on the secret (key).
+	// RetrieveBySecret retrieves a client based on the secret (key) or the
+	// pending secret. It fails if the secret belongs to more than one client.
 	RetrieveBySecret(ctx context.Context, key string) (Client, error)
 
 	AddConnections(ctx context.Context, conns []Connection) error
@@ -91,6 +104,8 @@ type Repository interface {
 
 	UnsetParentGroupFromClient(ctx context.Context, parentGroupID string) error
 
+	PresenceRepository
+
 	roles.Repository
 }
 
@@ -121,12 +136,27 @@ type Service interface {
 	// UpdateSecret updates the client's secret
 	UpdateSecret(ctx context.Context, session authn.Session, id, key string) (Client, error)
 
+	// RotateSecret schedules the rotation of the client's secret. The key
+	// becomes the pending secret and both secrets are accepted until the
+	// current one expires after the given duration. If the key is empty,
+	// it is generated. A rotation with a different key is rejected until the
+	// pending secret is confirmed.
+	RotateSecret(ctx context.Context, session authn.Session, id, key string, duration time.Duration) (Client, error)
+
+	// ConfirmSecret confirms the adoption of the pending secret, which
+	// replaces the client's secret.
+	ConfirmSecret(ctx context.Context, session authn.Session, id string) (Client, error)
+
 	// Enable logically enableds the client identified with the provided ID
 	Enable(ctx context.Context, session authn.Session, id string) (Client, error)
 
 	// Disable logically disables the client identified with the provided ID
 	Disable(ctx context.Context, session authn.Session, id string) (Client, error)
 
+	// Unlock removes the failed authentication attempts of the client locked
+	// out due to too many failed secret attempts.
+	Unlock(ctx context.Context, session authn.Session, id string) error
+
 	// Delete deletes client with given ID.
 	Delete(ctx context.Context, session authn.Session, id string) error
 
@@ -144,10 +174,14 @@ type Cache interface {
 	// Save stores pair client secret, client id.
 	Save(ctx context.Context, clientSecret, clientID string) error
 
+	// SaveWithExpiry stores pair client secret, client id which is removed
+	// at the secret expiry at the latest.
+	SaveWithExpiry(ctx context.Context, clientSecret, clientID string, expiresAt time.Time) error
+
 	// ID returns client ID for given client secret.
 	ID(ctx context.Context, clientSecret string) (string, error)
 
-	// Removes client from cache.
+	// Removes client secrets from cache.
 	Remove(ctx context.Context, clientID string) error
 }
 
@@ -166,6 +200,7 @@ type Client struct {
 	UpdatedBy   string      `json:"updated_by,omitempty"`
 	Status      Status      `json:"status,omitempty"` // 1 for enabled, 0 for disabled
 	Identity    string      `json:"identity,omitempty"`
+	Presence    *Presence   `json:"presence,omitempty"`
 	// Extended
 	ParentGroupPath           string                 `json:"parent_group_path,omitempty"`
 	RoleID                    string                 `json:"role_id,omitempty"`
@@ -196,26 +231,27 @@ type MembersPage struct {
 // Page contains the page metadata that helps navigation.
 
 type Page struct {
-	Total          uint64   `json:"total"`
-	Offset         uint64   `json:"offset"`
-	Limit          uint64   `json:"limit"`
-	Order          string   `json:"order,omitempty"`
-	Dir            string   `json:"dir,omitempty"`
-	Id             string   `json:"id,omitempty"`
-	Name           string   `json:"name,omitempty"`
-	Metadata       Metadata `json:"metadata,omitempty"`
-	Domain         string   `json:"domain,omitempty"`
-	Tag            string   `json:"tag,omitempty"`
-	Status         Status   `json:"status,omitempty"`
-	Identity       string   `json:"identity,omitempty"`
-	Group          string   `json:"group,omitempty"`
-	Channel        string   `json:"channel,omitempty"`
-	ConnectionType string   `json:"connection_type,omitempty"`
-	RoleName       string   `json:"role_name,omitempty"`
-	RoleID         string   `json:"role_id,omitempty"`
-	Actions        []string `json:"actions,omitempty"`
-	AccessType     string   `json:"access_type,omitempty"`
-	IDs            []string `json:"-"`
+	Total          uint64         `json:"total"`
+	Offset         uint64         `json:"offset"`
+	Limit          uint64         `json:"limit"`
+	Order          string         `json:"order,omitempty"`
+	Dir            string         `json:"dir,omitempty"`
+	Id             string         `json:"id,omitempty"`
+	Name           string         `json:"name,omitempty"`
+	Metadata       Metadata       `json:"metadata,omitempty"`
+	Domain         string         `json:"domain,omitempty"`
+	Tag            string         `json:"tag,omitempty"`
+	Status         Status         `json:"status,omitempty"`
+	Presence       PresenceStatus `json:"presence,omitempty"`
+	Identity       string         `json:"identity,omitempty"`
+	Group          string         `json:"group,omitempty"`
+	Channel        string         `json:"channel,omitempty"`
+	ConnectionType string         `json:"connection_type,omitempty"`
+	RoleName       string         `json:"role_name,omitempty"`
+	RoleID         string         `json:"role_id,omitempty"`
+	Actions        []string       `json:"actions,omitempty"`
+	AccessType     string         `json:"access_type,omitempty"`
+	IDs            []string       `json:"-"`
 }
 
 // Metadata represents arbitrary JSON.
@@ -225,6 +261,8 @@ type Metadata map[string]interface{}
 // "identity" which can be a username, email, generated name;
 // and "secret" which can be a password or access token.
 type Credentials struct {
-	Identity string `json:"identity,omitempty"` // username or generated login ID
-	Secret   string `json:"secret,omitempty"`   // password or token
+	Identity        string     `json:"identity,omitempty"`          // username or generated login ID
+	Secret          string     `json:"secret,omitempty"`            // password or token
+	PendingSecret   string     `json:"pending_secret,omitempty"`    // secret waiting for the adoption confirmation
+	SecretExpiresAt *time.Time `json:"secret_expires_at,omitempty"` // end of the rotation window of the secret
 }
@@ -11,4 +11,21 @@ var (
 
 	// ErrDisableClient indicates error in disabling client.
 	ErrDisableClient = errors.New("failed to disable client")
+
+	// ErrNoPendingSecret indicates that the client has no secret rotation
+	// to confirm.
+	ErrNoPendingSecret = errors.New("client has no pending secret")
+
+	// ErrPendingSecret indicates that the client secret rotation is already
+	// in progress.
+	ErrPendingSecret = errors.New("client has a pending secret")
+
+	// ErrSecretExpired indicates that the client secret has been rotated and
+	// its rotation window has ended.
+	ErrSecretExpired = errors.New("client secret expired")
+
+	// ErrReservedSecret indicates that the client secret starts with the
+	// prefix of the personal access tokens, which the protocol adapters
+	// accept in place of the client secret.
+	ErrReservedSecret = errors.New("client secret must not start with the personal access token prefix")
 )
@@ -13,19 +13,24 @@ import (
 )
 
 const (
-	clientPrefix       = "client."
-	clientCreate       = clientPrefix + "create"
-	clientUpdate       = clientPrefix + "update"
-	clientChangeStatus = clientPrefix + "change_status"
-	clientRemove       = clientPrefix + "remove"
-	clientView         = clientPrefix + "view"
-	clientViewPerms    = clientPrefix + "view_perms"
-	clientList         = clientPrefix + "list"
-	clientListByGroup  = clientPrefix + "list_by_channel"
-	clientIdentify     = clientPrefix + "identify"
-	clientAuthorize    = clientPrefix + "authorize"
-	clientSetParent    = clientPrefix + "set_parent"
-	clientRemoveParent = clientPrefix + "remove_parent"
+	clientPrefix        = "client."
+	clientCreate        = clientPrefix + "create"
+	clientUpdate        = clientPrefix + "update"
+	clientChangeStatus  = clientPrefix + "change_status"
+	clientRemove        = clientPrefix + "remove"
+	clientView          = clientPrefix + "view"
+	clientViewPerms     = clientPrefix + "view_perms"
+	clientList          = clientPrefix + "list"
+	clientListByGroup   = clientPrefix + "list_by_channel"
+	clientIdentify      = clientPrefix + "identify"
+	clientAuthorize     = clientPrefix + "authorize"
+	clientSetParent     = clientPrefix + "set_parent"
+	clientRemoveParent  = clientPrefix + "remove_parent"
+	clientLockout       = clientPrefix + "lockout"
+	clientUnlock        = clientPrefix + "unlock"
+	clientRotateSecret  = clientPrefix + "rotate_secret"
+	clientConfirmSecret = clientPrefix + "confirm_secret"
+	clientPresence      = clientPrefix + "presence"
 )
 
 var (
@@ -40,6 +45,11 @@ var (
 	_ events.Event = (*authorizeClientEvent)(nil)
 	_ events.Event = (*shareClientEvent)(nil)
 	_ events.Event = (*removeClientEvent)(nil)
+	_ events.Event = (*lockoutClientEvent)(nil)
+	_ events.Event = (*unlockClientEvent)(nil)
+	_ events.Event = (*rotateSecretClientEvent)(nil)
+	_ events.Event = (*confirmSecretClientEvent)(nil)
+	_ events.Event = (*presenceClientEvent)(nil)
 )
 
 type createClientEvent struct {
@@ -419,6 +429,75 @@ func (dce removeClientEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type lockoutClientEvent struct {
+	id string
+}
+
+func (lce lockoutClientEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": clientLockout,
+		"id":        lce.id,
+	}, nil
+}
+
+type unlockClientEvent struct {
+	id string
+	authn.Session
+}
+
+func (uce unlockClientEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   clientUnlock,
+		"id":          uce.id,
+		"domain":      uce.DomainID,
+		"user_id":     uce.UserID,
+		"token_type":  uce.Type.String(),
+		"super_admin": uce.SuperAdmin,
+	}, nil
+}
+
+type rotateSecretClientEvent struct {
+	id        string
+	expiresAt *time.Time
+	updatedAt time.Time
+	updatedBy string
+	authn.Session
+}
+
+func (rce rotateSecretClientEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":         clientRotateSecret,
+		"id":                rce.id,
+		"secret_expires_at": rce.expiresAt,
+		"updated_at":        rce.updatedAt,
+		"updated_by":        rce.updatedBy,
+		"domain":            rce.DomainID,
+		"user_id":           rce.UserID,
+		"token_type":        rce.Type.String(),
+		"super_admin":       rce.SuperAdmin,
+	}, nil
+}
+
+type confirmSecretClientEvent struct {
+	id        string
+	updatedAt time.Time
+	updatedBy string
+	authn.Session
+}
+
+func (cce confirmSecretClientEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   clientConfirmSecret,
+		"id":          cce.id,
+		"updated_at":  cce.updatedAt,
+		"updated_by":  cce.updatedBy,
+		"domain":      cce.DomainID,
+		"user_id":     cce.UserID,
+		"token_type":  cce.Type.String(),
+		"super_admin": cce.SuperAdmin,
+	}, nil
+}
+
 type setParentGroupEvent struct {
 	id            string
 	parentGroupID string
@@ -452,3 +531,28 @@ func (rpge removeParentGroupEvent) Encode() (map[string]interface{}, error) {
 		"super_admin": rpge.SuperAdmin,
 	}, nil
 }
+
+type presenceClientEvent struct {
+	clients.Presence
+}
+
+func (pce presenceClientEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation":  clientPresence,
+		"id":         pce.ClientID,
+		"status":     pce.Status.String(),
+		"session_id": pce.SessionID,
+		"protocol":   pce.Protocol,
+	}
+	if pce.RemoteAddr != "" {
+		val["remote_addr"] = pce.RemoteAddr
+	}
+	if pce.SessionStart != nil {
+		val["session_start"] = *pce.SessionStart
+	}
+	if pce.LastSeen != nil {
+		val["last_seen"] = *pce.LastSeen
+	}
+
+	return val, nil
+}
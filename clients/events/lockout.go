@@ -0,0 +1,58 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/pkg/lockout"
+)
+
+var _ lockout.Tracker = (*lockoutTracker)(nil)
+
+type lockoutTracker struct {
+	events.Publisher
+	tracker lockout.Tracker
+}
+
+// NewLockoutTracker returns wrapper around failed attempts tracker that sends
+// client lockout events to event store. The clients are authenticated by the
+// private service, which has no event store middleware.
+func NewLockoutTracker(ctx context.Context, tracker lockout.Tracker, url string) (lockout.Tracker, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lockoutTracker{
+		tracker:   tracker,
+		Publisher: publisher,
+	}, nil
+}
+
+func (lt *lockoutTracker) Check(ctx context.Context, keys ...string) error {
+	return lt.tracker.Check(ctx, keys...)
+}
+
+func (lt *lockoutTracker) Fail(ctx context.Context, keys ...string) ([]string, error) {
+	locked, err := lt.tracker.Fail(ctx, keys...)
+	if err != nil {
+		return locked, err
+	}
+
+	for _, key := range locked {
+		_, id := lockout.ParseKey(key)
+		if err := lt.Publish(ctx, lockoutClientEvent{id: id}); err != nil {
+			return locked, err
+		}
+	}
+
+	return locked, nil
+}
+
+func (lt *lockoutTracker) Reset(ctx context.Context, keys ...string) error {
+	return lt.tracker.Reset(ctx, keys...)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/presence"
+)
+
+var (
+	errNoOperationKey  = errors.New("operation key is not found in event message")
+	errConnectEvent    = errors.New("failed to consume connect event")
+	errDisconnectEvent = errors.New("failed to consume disconnect event")
+	errHeartbeatEvent  = errors.New("failed to consume heartbeat event")
+	errPublishPresence = errors.New("failed to publish client presence event")
+)
+
+// presenceStreams are the session events streams of the protocol adapters.
+var presenceStreams = []string{
+	"events." + presence.MQTTStream,
+	"events." + presence.WSStream,
+	"events." + presence.CoAPStream,
+}
+
+type presenceHandler struct {
+	repo      clients.PresenceRepository
+	publisher events.Publisher
+}
+
+// PresenceEventsSubscribe subscribes to the session events of the MQTT,
+// WebSocket and CoAP adapters, and tracks the presence of the clients. The
+// changes of the presence are published to the clients events stream. The
+// sessions of the adapter instances which sent no heartbeat within the
+// session timeout are expired.
+func PresenceEventsSubscribe(ctx context.Context, repo clients.PresenceRepository, esURL, esConsumerName string, sessionTimeout time.Duration, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, esURL, logger)
+	if err != nil {
+		return err
+	}
+
+	publisher, err := store.NewPublisher(ctx, esURL, streamID)
+	if err != nil {
+		return err
+	}
+
+	handler := &presenceHandler{
+		repo:      repo,
+		publisher: publisher,
+	}
+	for _, stream := range presenceStreams {
+		subConfig := events.SubscriberConfig{
+			Stream:         stream,
+			Consumer:       esConsumerName,
+			Handler:        handler,
+			DeliveryPolicy: messaging.DeliverNewPolicy,
+			Ordered:        true,
+		}
+		if err := subscriber.Subscribe(ctx, subConfig); err != nil {
+			return err
+		}
+	}
+
+	go handler.expireSessions(ctx, sessionTimeout, logger)
+
+	return nil
+}
+
+// NewPresenceHandler returns the handler of the adapters session events
+// which stores the presence of the clients and publishes its changes.
+func NewPresenceHandler(repo clients.PresenceRepository, publisher events.Publisher) events.EventHandler {
+	return &presenceHandler{
+		repo:      repo,
+		publisher: publisher,
+	}
+}
+
+func (ph *presenceHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	op, ok := msg["operation"]
+	if !ok {
+		return errNoOperationKey
+	}
+
+	occurredAt := time.Now().UTC()
+	if ts := events.Read(msg, "occurred_at", float64(0)); ts != 0 {
+		occurredAt = time.Unix(0, int64(ts)).UTC()
+	}
+
+	if op == presence.HeartbeatOp {
+		if err := ph.repo.SaveHeartbeat(ctx, events.Read(msg, "instance", ""), occurredAt); err != nil {
+			return errors.Wrap(errHeartbeatEvent, err)
+		}
+		return nil
+	}
+
+	// Sessions of the users are not tracked.
+	s := decodeSession(msg)
+	if s.ClientID == "" {
+		return nil
+	}
+
+	switch op {
+	case presence.ConnectOp:
+		s.StartedAt = occurredAt
+		if err := ph.connect(ctx, s); err != nil {
+			return errors.Wrap(errConnectEvent, err)
+		}
+	case presence.DisconnectOp:
+		if err := ph.disconnect(ctx, s, occurredAt); err != nil {
+			return errors.Wrap(errDisconnectEvent, err)
+		}
+	}
+
+	return nil
+}
+
+// connect stores the new session and marks the client online.
+func (ph *presenceHandler) connect(ctx context.Context, s clients.Session) error {
+	p, err := ph.repo.SaveSession(ctx, s)
+	switch {
+	// The client has been removed in the meantime.
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	}
+
+	return ph.publish(ctx, p)
+}
+
+// disconnect removes the closed session. The client is marked offline once
+// all of its sessions are closed.
+func (ph *presenceHandler) disconnect(ctx context.Context, s clients.Session, occurredAt time.Time) error {
+	p, err := ph.repo.RemoveSession(ctx, s, occurredAt)
+	switch {
+	// The session has been expired or the client removed in the meantime.
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	}
+	if p.Status != clients.OfflinePresence {
+		return nil
+	}
+
+	return ph.publish(ctx, p)
+}
+
+// expireSessions periodically removes the sessions of the adapter instances
+// which sent no heartbeat within the timeout, such as the instances which
+// crashed without closing the sessions.
+func (ph *presenceHandler) expireSessions(ctx context.Context, timeout time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(presence.HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		ps, err := ph.repo.RemoveExpiredSessions(ctx, time.Now().UTC().Add(-timeout))
+		if err != nil {
+			logger.Warn(fmt.Sprintf("failed to expire client sessions: %s", err))
+			continue
+		}
+		for _, p := range ps {
+			if err := ph.publish(ctx, p); err != nil {
+				logger.Warn(err.Error())
+			}
+		}
+	}
+}
+
+func (ph *presenceHandler) publish(ctx context.Context, p clients.Presence) error {
+	if err := ph.publisher.Publish(ctx, presenceClientEvent{p}); err != nil {
+		return errors.Wrap(errPublishPresence, err)
+	}
+
+	return nil
+}
+
+func decodeSession(msg map[string]interface{}) clients.Session {
+	return clients.Session{
+		ID:         events.Read(msg, "session_id", ""),
+		ClientID:   events.Read(msg, "client_id", ""),
+		Protocol:   events.Read(msg, "protocol", ""),
+		Instance:   events.Read(msg, "instance", ""),
+		RemoteAddr: events.Read(msg, "remote_addr", ""),
+	}
+}
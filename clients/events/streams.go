@@ -5,6 +5,7 @@ package events
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/clients"
 	"github.com/absmach/supermq/pkg/authn"
@@ -87,6 +88,45 @@ func (es *eventStore) UpdateSecret(ctx context.Context, session authn.Session, i
 	return es.update(ctx, session, "secret", cli)
 }
 
+func (es *eventStore) RotateSecret(ctx context.Context, session authn.Session, id, key string, duration time.Duration) (clients.Client, error) {
+	cli, err := es.svc.RotateSecret(ctx, session, id, key, duration)
+	if err != nil {
+		return cli, err
+	}
+
+	event := rotateSecretClientEvent{
+		id:        cli.ID,
+		expiresAt: cli.Credentials.SecretExpiresAt,
+		updatedAt: cli.UpdatedAt,
+		updatedBy: cli.UpdatedBy,
+		Session:   session,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return cli, err
+	}
+
+	return cli, nil
+}
+
+func (es *eventStore) ConfirmSecret(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
+	cli, err := es.svc.ConfirmSecret(ctx, session, id)
+	if err != nil {
+		return cli, err
+	}
+
+	event := confirmSecretClientEvent{
+		id:        cli.ID,
+		updatedAt: cli.UpdatedAt,
+		updatedBy: cli.UpdatedBy,
+		Session:   session,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return cli, err
+	}
+
+	return cli, nil
+}
+
 func (es *eventStore) update(ctx context.Context, session authn.Session, operation string, client clients.Client) (clients.Client, error) {
 	event := updateClientEvent{
 		Client:    client,
@@ -169,6 +209,19 @@ func (es *eventStore) Disable(ctx context.Context, session authn.Session, id str
 	return es.changeStatus(ctx, session, cli)
 }
 
+func (es *eventStore) Unlock(ctx context.Context, session authn.Session, id string) error {
+	if err := es.svc.Unlock(ctx, session, id); err != nil {
+		return err
+	}
+
+	event := unlockClientEvent{
+		id:      id,
+		Session: session,
+	}
+
+	return es.Publish(ctx, event)
+}
+
 func (es *eventStore) changeStatus(ctx context.Context, session authn.Session, cli clients.Client) (clients.Client, error) {
 	event := changeStatusClientEvent{
 		id:        cli.ID,
@@ -5,6 +5,7 @@ package middleware
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/auth"
 	"github.com/absmach/supermq/clients"
@@ -23,8 +24,11 @@ var (
 	errUpdate                  = errors.New("not authorized to update thing")
 	errUpdateTags              = errors.New("not authorized to update thing tags")
 	errUpdateSecret            = errors.New("not authorized to update thing secret")
+	errRotateSecret            = errors.New("not authorized to rotate thing secret")
+	errConfirmSecret           = errors.New("not authorized to confirm thing secret")
 	errEnable                  = errors.New("not authorized to enable thing")
 	errDisable                 = errors.New("not authorized to disable thing")
+	errUnlock                  = errors.New("not authorized to unlock thing")
 	errDelete                  = errors.New("not authorized to delete thing")
 	errSetParentGroup          = errors.New("not authorized to set parent group to thing")
 	errRemoveParentGroup       = errors.New("not authorized to remove parent group from thing")
@@ -256,6 +260,60 @@ func (am *authorizationMiddleware) UpdateSecret(ctx context.Context, session aut
 	return am.svc.UpdateSecret(ctx, session, id, key)
 }
 
+func (am *authorizationMiddleware) RotateSecret(ctx context.Context, session authn.Session, id, key string, duration time.Duration) (clients.Client, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainClientsScope,
+			Operation:                auth.UpdateOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return clients.Client{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.authorize(ctx, clients.OpRotateClientSecret, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.ClientType,
+		Object:      id,
+	}); err != nil {
+		return clients.Client{}, errors.Wrap(err, errRotateSecret)
+	}
+	return am.svc.RotateSecret(ctx, session, id, key, duration)
+}
+
+func (am *authorizationMiddleware) ConfirmSecret(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainClientsScope,
+			Operation:                auth.UpdateOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return clients.Client{}, errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.authorize(ctx, clients.OpConfirmClientSecret, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.ClientType,
+		Object:      id,
+	}); err != nil {
+		return clients.Client{}, errors.Wrap(err, errConfirmSecret)
+	}
+	return am.svc.ConfirmSecret(ctx, session, id)
+}
+
 func (am *authorizationMiddleware) Enable(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
 	if session.Type == authn.PersonalAccessToken {
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
@@ -311,6 +369,33 @@ func (am *authorizationMiddleware) Disable(ctx context.Context, session authn.Se
 	return am.svc.Disable(ctx, session, id)
 }
 
+func (am *authorizationMiddleware) Unlock(ctx context.Context, session authn.Session, id string) error {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			PlatformEntityType:       auth.PlatformDomainsScope,
+			OptionalDomainID:         session.DomainID,
+			OptionalDomainEntityType: auth.DomainClientsScope,
+			Operation:                auth.UpdateOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.authorize(ctx, clients.OpUnlockClient, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		Subject:     session.DomainUserID,
+		ObjectType:  policies.ClientType,
+		Object:      id,
+	}); err != nil {
+		return errors.Wrap(err, errUnlock)
+	}
+	return am.svc.Unlock(ctx, session, id)
+}
+
 func (am *authorizationMiddleware) Delete(ctx context.Context, session authn.Session, id string) error {
 	if session.Type == authn.PersonalAccessToken {
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
@@ -165,6 +165,45 @@ func (lm *loggingMiddleware) UpdateSecret(ctx context.Context, session authn.Ses
 	return lm.svc.UpdateSecret(ctx, session, oldSecret, newSecret)
 }
 
+func (lm *loggingMiddleware) RotateSecret(ctx context.Context, session authn.Session, id, key string, duration time.Duration) (c clients.Client, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("client",
+				slog.String("id", id),
+				slog.String("name", c.Name),
+				slog.Any("secret_expires_at", c.Credentials.SecretExpiresAt),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Rotate client secret failed", args...)
+			return
+		}
+		lm.logger.Info("Rotate client secret completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RotateSecret(ctx, session, id, key, duration)
+}
+
+func (lm *loggingMiddleware) ConfirmSecret(ctx context.Context, session authn.Session, id string) (c clients.Client, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("client",
+				slog.String("id", id),
+				slog.String("name", c.Name),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Confirm client secret failed", args...)
+			return
+		}
+		lm.logger.Info("Confirm client secret completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ConfirmSecret(ctx, session, id)
+}
+
 func (lm *loggingMiddleware) Enable(ctx context.Context, session authn.Session, id string) (c clients.Client, err error) {
 	defer func(begin time.Time) {
 		args := []any{
@@ -203,6 +242,24 @@ func (lm *loggingMiddleware) Disable(ctx context.Context, session authn.Session,
 	return lm.svc.Disable(ctx, session, id)
 }
 
+func (lm *loggingMiddleware) Unlock(ctx context.Context, session authn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("client",
+				slog.String("id", id),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.String("error", err.Error()))
+			lm.logger.Warn("Unlock client failed", args...)
+			return
+		}
+		lm.logger.Info("Unlock client completed successfully", args...)
+	}(time.Now())
+	return lm.svc.Unlock(ctx, session, id)
+}
+
 func (lm *loggingMiddleware) Delete(ctx context.Context, session authn.Session, id string) (err error) {
 	defer func(begin time.Time) {
 		args := []any{
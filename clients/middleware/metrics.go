@@ -89,6 +89,22 @@ func (ms *metricsMiddleware) UpdateSecret(ctx context.Context, session authn.Ses
 	return ms.svc.UpdateSecret(ctx, session, oldSecret, newSecret)
 }
 
+func (ms *metricsMiddleware) RotateSecret(ctx context.Context, session authn.Session, id, key string, duration time.Duration) (clients.Client, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "rotate_client_secret").Add(1)
+		ms.latency.With("method", "rotate_client_secret").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RotateSecret(ctx, session, id, key, duration)
+}
+
+func (ms *metricsMiddleware) ConfirmSecret(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "confirm_client_secret").Add(1)
+		ms.latency.With("method", "confirm_client_secret").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ConfirmSecret(ctx, session, id)
+}
+
 func (ms *metricsMiddleware) Enable(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "enable_client").Add(1)
@@ -105,6 +121,14 @@ func (ms *metricsMiddleware) Disable(ctx context.Context, session authn.Session,
 	return ms.svc.Disable(ctx, session, id)
 }
 
+func (ms *metricsMiddleware) Unlock(ctx context.Context, session authn.Session, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unlock_client").Add(1)
+		ms.latency.With("method", "unlock_client").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Unlock(ctx, session, id)
+}
+
 func (ms *metricsMiddleware) Delete(ctx context.Context, session authn.Session, id string) error {
 	defer func(begin time.Time) {
 		ms.counter.With("method", "delete_client").Add(1)
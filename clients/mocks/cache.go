@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -79,6 +80,24 @@ func (_m *Cache) Save(ctx context.Context, clientSecret string, clientID string)
 	return r0
 }
 
+// SaveWithExpiry provides a mock function with given fields: ctx, clientSecret, clientID, expiresAt
+func (_m *Cache) SaveWithExpiry(ctx context.Context, clientSecret string, clientID string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, clientSecret, clientID, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveWithExpiry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, clientSecret, clientID, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewCache creates a new instance of Cache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCache(t interface {
@@ -179,6 +179,80 @@ func (_c *ClientsServiceClient_Authenticate_Call) RunAndReturn(run func(context.
 	return _c
 }
 
+// CreateClients provides a mock function with given fields: ctx, in, opts
+func (_m *ClientsServiceClient) CreateClients(ctx context.Context, in *clientsv1.CreateClientsReq, opts ...grpc.CallOption) (*clientsv1.CreateClientsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClients")
+	}
+
+	var r0 *clientsv1.CreateClientsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *clientsv1.CreateClientsReq, ...grpc.CallOption) (*clientsv1.CreateClientsRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *clientsv1.CreateClientsReq, ...grpc.CallOption) *clientsv1.CreateClientsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*clientsv1.CreateClientsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *clientsv1.CreateClientsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientsServiceClient_CreateClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateClients'
+type ClientsServiceClient_CreateClients_Call struct {
+	*mock.Call
+}
+
+// CreateClients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *clientsv1.CreateClientsReq
+//   - opts ...grpc.CallOption
+func (_e *ClientsServiceClient_Expecter) CreateClients(ctx interface{}, in interface{}, opts ...interface{}) *ClientsServiceClient_CreateClients_Call {
+	return &ClientsServiceClient_CreateClients_Call{Call: _e.mock.On("CreateClients",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *ClientsServiceClient_CreateClients_Call) Run(run func(ctx context.Context, in *clientsv1.CreateClientsReq, opts ...grpc.CallOption)) *ClientsServiceClient_CreateClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*clientsv1.CreateClientsReq), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ClientsServiceClient_CreateClients_Call) Return(_a0 *clientsv1.CreateClientsRes, _a1 error) *ClientsServiceClient_CreateClients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientsServiceClient_CreateClients_Call) RunAndReturn(run func(context.Context, *clientsv1.CreateClientsReq, ...grpc.CallOption) (*clientsv1.CreateClientsRes, error)) *ClientsServiceClient_CreateClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveChannelConnections provides a mock function with given fields: ctx, in, opts
 func (_m *ClientsServiceClient) RemoveChannelConnections(ctx context.Context, in *clientsv1.RemoveChannelConnectionsReq, opts ...grpc.CallOption) (*clientsv1.RemoveChannelConnectionsRes, error) {
 	_va := make([]interface{}, len(opts))
@@ -253,6 +327,80 @@ func (_c *ClientsServiceClient_RemoveChannelConnections_Call) RunAndReturn(run f
 	return _c
 }
 
+// RemoveClients provides a mock function with given fields: ctx, in, opts
+func (_m *ClientsServiceClient) RemoveClients(ctx context.Context, in *clientsv1.RemoveClientsReq, opts ...grpc.CallOption) (*clientsv1.RemoveClientsRes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveClients")
+	}
+
+	var r0 *clientsv1.RemoveClientsRes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *clientsv1.RemoveClientsReq, ...grpc.CallOption) (*clientsv1.RemoveClientsRes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *clientsv1.RemoveClientsReq, ...grpc.CallOption) *clientsv1.RemoveClientsRes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*clientsv1.RemoveClientsRes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *clientsv1.RemoveClientsReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClientsServiceClient_RemoveClients_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveClients'
+type ClientsServiceClient_RemoveClients_Call struct {
+	*mock.Call
+}
+
+// RemoveClients is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *clientsv1.RemoveClientsReq
+//   - opts ...grpc.CallOption
+func (_e *ClientsServiceClient_Expecter) RemoveClients(ctx interface{}, in interface{}, opts ...interface{}) *ClientsServiceClient_RemoveClients_Call {
+	return &ClientsServiceClient_RemoveClients_Call{Call: _e.mock.On("RemoveClients",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *ClientsServiceClient_RemoveClients_Call) Run(run func(ctx context.Context, in *clientsv1.RemoveClientsReq, opts ...grpc.CallOption)) *ClientsServiceClient_RemoveClients_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*clientsv1.RemoveClientsReq), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *ClientsServiceClient_RemoveClients_Call) Return(_a0 *clientsv1.RemoveClientsRes, _a1 error) *ClientsServiceClient_RemoveClients_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ClientsServiceClient_RemoveClients_Call) RunAndReturn(run func(context.Context, *clientsv1.RemoveClientsReq, ...grpc.CallOption) (*clientsv1.RemoveClientsRes, error)) *ClientsServiceClient_RemoveClients_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RemoveConnections provides a mock function with given fields: ctx, in, opts
 func (_m *ClientsServiceClient) RemoveConnections(ctx context.Context, in *v1.RemoveConnectionsReq, opts ...grpc.CallOption) (*v1.RemoveConnectionsRes, error) {
 	_va := make([]interface{}, len(opts))
@@ -12,6 +12,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	roles "github.com/absmach/supermq/pkg/roles"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -123,6 +125,34 @@ func (_m *Repository) ClientConnectionsCount(ctx context.Context, id string) (ui
 	return r0, r1
 }
 
+// ConfirmSecret provides a mock function with given fields: ctx, client
+func (_m *Repository) ConfirmSecret(ctx context.Context, client clients.Client) (clients.Client, error) {
+	ret := _m.Called(ctx, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmSecret")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Client) (clients.Client, error)); ok {
+		return rf(ctx, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Client) clients.Client); ok {
+		r0 = rf(ctx, client)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, clients.Client) error); ok {
+		r1 = rf(ctx, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, clientIDs
 func (_m *Repository) Delete(ctx context.Context, clientIDs ...string) error {
 	_va := make([]interface{}, len(clientIDs))
@@ -230,6 +260,36 @@ func (_m *Repository) RemoveConnections(ctx context.Context, conns []clients.Con
 	return r0
 }
 
+// RemoveExpiredSessions provides a mock function with given fields: ctx, seenBefore
+func (_m *Repository) RemoveExpiredSessions(ctx context.Context, seenBefore time.Time) ([]clients.Presence, error) {
+	ret := _m.Called(ctx, seenBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpiredSessions")
+	}
+
+	var r0 []clients.Presence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]clients.Presence, error)); ok {
+		return rf(ctx, seenBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []clients.Presence); ok {
+		r0 = rf(ctx, seenBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.Presence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, seenBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveMemberFromAllRoles provides a mock function with given fields: ctx, memberID
 func (_m *Repository) RemoveMemberFromAllRoles(ctx context.Context, memberID string) error {
 	ret := _m.Called(ctx, memberID)
@@ -284,6 +344,34 @@ func (_m *Repository) RemoveRoles(ctx context.Context, roleIDs []string) error {
 	return r0
 }
 
+// RemoveSession provides a mock function with given fields: ctx, s, closedAt
+func (_m *Repository) RemoveSession(ctx context.Context, s clients.Session, closedAt time.Time) (clients.Presence, error) {
+	ret := _m.Called(ctx, s, closedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveSession")
+	}
+
+	var r0 clients.Presence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Session, time.Time) (clients.Presence, error)); ok {
+		return rf(ctx, s, closedAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Session, time.Time) clients.Presence); ok {
+		r0 = rf(ctx, s, closedAt)
+	} else {
+		r0 = ret.Get(0).(clients.Presence)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, clients.Session, time.Time) error); ok {
+		r1 = rf(ctx, s, closedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveAll provides a mock function with given fields: ctx, pm
 func (_m *Repository) RetrieveAll(ctx context.Context, pm clients.Page) (clients.ClientsPage, error) {
 	ret := _m.Called(ctx, pm)
@@ -521,6 +609,34 @@ func (_m *Repository) RetrieveParentGroupClients(ctx context.Context, parentGrou
 	return r0, r1
 }
 
+// RetrievePresence provides a mock function with given fields: ctx, clientID
+func (_m *Repository) RetrievePresence(ctx context.Context, clientID string) (clients.Presence, error) {
+	ret := _m.Called(ctx, clientID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrievePresence")
+	}
+
+	var r0 clients.Presence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (clients.Presence, error)); ok {
+		return rf(ctx, clientID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) clients.Presence); ok {
+		r0 = rf(ctx, clientID)
+	} else {
+		r0 = ret.Get(0).(clients.Presence)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, clientID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveRole provides a mock function with given fields: ctx, roleID
 func (_m *Repository) RetrieveRole(ctx context.Context, roleID string) (roles.Role, error) {
 	ret := _m.Called(ctx, roleID)
@@ -860,6 +976,52 @@ func (_m *Repository) Save(ctx context.Context, client ...clients.Client) ([]cli
 	return r0, r1
 }
 
+// SaveHeartbeat provides a mock function with given fields: ctx, instance, seenAt
+func (_m *Repository) SaveHeartbeat(ctx context.Context, instance string, seenAt time.Time) error {
+	ret := _m.Called(ctx, instance, seenAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveHeartbeat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, instance, seenAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSession provides a mock function with given fields: ctx, s
+func (_m *Repository) SaveSession(ctx context.Context, s clients.Session) (clients.Presence, error) {
+	ret := _m.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSession")
+	}
+
+	var r0 clients.Presence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Session) (clients.Presence, error)); ok {
+		return rf(ctx, s)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Session) clients.Presence); ok {
+		r0 = rf(ctx, s)
+	} else {
+		r0 = ret.Get(0).(clients.Presence)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, clients.Session) error); ok {
+		r1 = rf(ctx, s)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SearchClients provides a mock function with given fields: ctx, pm
 func (_m *Repository) SearchClients(ctx context.Context, pm clients.Page) (clients.ClientsPage, error) {
 	ret := _m.Called(ctx, pm)
@@ -980,6 +1142,34 @@ func (_m *Repository) UpdateIdentity(ctx context.Context, client clients.Client)
 	return r0, r1
 }
 
+// UpdatePendingSecret provides a mock function with given fields: ctx, client
+func (_m *Repository) UpdatePendingSecret(ctx context.Context, client clients.Client) (clients.Client, error) {
+	ret := _m.Called(ctx, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePendingSecret")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Client) (clients.Client, error)); ok {
+		return rf(ctx, client)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, clients.Client) clients.Client); ok {
+		r0 = rf(ctx, client)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, clients.Client) error); ok {
+		r1 = rf(ctx, client)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateRole provides a mock function with given fields: ctx, ro
 func (_m *Repository) UpdateRole(ctx context.Context, ro roles.Role) (roles.Role, error) {
 	ret := _m.Called(ctx, ro)
@@ -13,6 +13,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	roles "github.com/absmach/supermq/pkg/roles"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -48,6 +50,34 @@ func (_m *Service) AddRole(ctx context.Context, session authn.Session, entityID
 	return r0, r1
 }
 
+// ConfirmSecret provides a mock function with given fields: ctx, session, id
+func (_m *Service) ConfirmSecret(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
+	ret := _m.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmSecret")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) (clients.Client, error)); ok {
+		return rf(ctx, session, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) clients.Client); ok {
+		r0 = rf(ctx, session, id)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string) error); ok {
+		r1 = rf(ctx, session, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateClients provides a mock function with given fields: ctx, session, client
 func (_m *Service) CreateClients(ctx context.Context, session authn.Session, client ...clients.Client) ([]clients.Client, []roles.RoleProvision, error) {
 	_va := make([]interface{}, len(client))
@@ -610,6 +640,34 @@ func (_m *Service) RoleRemoveMembers(ctx context.Context, session authn.Session,
 	return r0
 }
 
+// RotateSecret provides a mock function with given fields: ctx, session, id, key, duration
+func (_m *Service) RotateSecret(ctx context.Context, session authn.Session, id string, key string, duration time.Duration) (clients.Client, error) {
+	ret := _m.Called(ctx, session, id, key, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RotateSecret")
+	}
+
+	var r0 clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string, time.Duration) (clients.Client, error)); ok {
+		return rf(ctx, session, id, key, duration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string, string, time.Duration) clients.Client); ok {
+		r0 = rf(ctx, session, id, key, duration)
+	} else {
+		r0 = ret.Get(0).(clients.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, session, id, key, duration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetParentGroup provides a mock function with given fields: ctx, session, parentGroupID, id
 func (_m *Service) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) error {
 	ret := _m.Called(ctx, session, parentGroupID, id)
@@ -628,6 +686,24 @@ func (_m *Service) SetParentGroup(ctx context.Context, session authn.Session, pa
 	return r0
 }
 
+// Unlock provides a mock function with given fields: ctx, session, id
+func (_m *Service) Unlock(ctx context.Context, session authn.Session, id string) error {
+	ret := _m.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = rf(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, session, client
 func (_m *Service) Update(ctx context.Context, session authn.Session, client clients.Client) (clients.Client, error) {
 	ret := _m.Called(ctx, session, client)
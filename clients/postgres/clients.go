@@ -31,6 +31,8 @@ const (
 
 var _ clients.Repository = (*clientRepo)(nil)
 
+var errDuplicateSecret = errors.New("secret belongs to more than one client")
+
 type clientRepo struct {
 	DB postgres.Database
 	rolesPostgres.Repository
@@ -85,9 +87,9 @@ func (repo *clientRepo) Save(ctx context.Context, cls ...clients.Client) ([]clie
 }
 
 func (repo *clientRepo) RetrieveBySecret(ctx context.Context, key string) (clients.Client, error) {
-	q := fmt.Sprintf(`SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id,  COALESCE(parent_group_id, '') AS parent_group_id, identity, secret, metadata, created_at, updated_at, updated_by, status
+	q := fmt.Sprintf(`SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id,  COALESCE(parent_group_id, '') AS parent_group_id, identity, secret, pending_secret, secret_expires_at, metadata, created_at, updated_at, updated_by, status
         FROM clients
-        WHERE secret = :secret AND status = %d`, clients.EnabledStatus)
+        WHERE (secret = :secret OR pending_secret = :secret) AND status = %d`, clients.EnabledStatus)
 
 	dbc := DBClient{
 		Secret: key,
@@ -100,20 +102,25 @@ func (repo *clientRepo) RetrieveBySecret(ctx context.Context, key string) (clien
 	defer rows.Close()
 
 	dbc = DBClient{}
+	if !rows.Next() {
+		return clients.Client{}, repoerr.ErrNotFound
+	}
+	if err = rows.StructScan(&dbc); err != nil {
+		return clients.Client{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	// The secrets written before they were unique across the current and
+	// the pending secrets may be shared, in which case none of the clients
+	// is authenticated.
 	if rows.Next() {
-		if err = rows.StructScan(&dbc); err != nil {
-			return clients.Client{}, postgres.HandleError(repoerr.ErrViewEntity, err)
-		}
-
-		client, err := ToClient(dbc)
-		if err != nil {
-			return clients.Client{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
-		}
+		return clients.Client{}, errors.Wrap(repoerr.ErrConflict, errDuplicateSecret)
+	}
 
-		return client, nil
+	client, err := ToClient(dbc)
+	if err != nil {
+		return clients.Client{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
 	}
 
-	return clients.Client{}, repoerr.ErrNotFound
+	return client, nil
 }
 
 func (repo *clientRepo) Update(ctx context.Context, client clients.Client) (clients.Client, error) {
@@ -154,13 +161,29 @@ func (repo *clientRepo) UpdateIdentity(ctx context.Context, client clients.Clien
 }
 
 func (repo *clientRepo) UpdateSecret(ctx context.Context, client clients.Client) (clients.Client, error) {
-	q := `UPDATE clients SET secret = :secret, updated_at = :updated_at, updated_by = :updated_by
+	q := `UPDATE clients SET secret = :secret, pending_secret = NULL, secret_expires_at = NULL, updated_at = :updated_at, updated_by = :updated_by
         WHERE id = :id AND status = :status
         RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, status, created_at, updated_at, updated_by`
 	client.Status = clients.EnabledStatus
 	return repo.update(ctx, client, q)
 }
 
+func (repo *clientRepo) UpdatePendingSecret(ctx context.Context, client clients.Client) (clients.Client, error) {
+	q := `UPDATE clients SET pending_secret = :pending_secret, secret_expires_at = :secret_expires_at, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id AND status = :status AND (pending_secret IS NULL OR pending_secret = :pending_secret)
+        RETURNING id, name, tags, identity, pending_secret, secret_expires_at, metadata, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, status, created_at, updated_at, updated_by`
+	client.Status = clients.EnabledStatus
+	return repo.update(ctx, client, q)
+}
+
+func (repo *clientRepo) ConfirmSecret(ctx context.Context, client clients.Client) (clients.Client, error) {
+	q := `UPDATE clients SET secret = pending_secret, pending_secret = NULL, secret_expires_at = NULL, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id AND status = :status AND pending_secret IS NOT NULL
+        RETURNING id, name, tags, identity, metadata, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, status, created_at, updated_at, updated_by`
+	client.Status = clients.EnabledStatus
+	return repo.update(ctx, client, q)
+}
+
 func (repo *clientRepo) ChangeStatus(ctx context.Context, client clients.Client) (clients.Client, error) {
 	q := `UPDATE clients SET status = :status, updated_at = :updated_at, updated_by = :updated_by
 		WHERE id = :id
@@ -170,8 +193,9 @@ func (repo *clientRepo) ChangeStatus(ctx context.Context, client clients.Client)
 }
 
 func (repo *clientRepo) RetrieveByID(ctx context.Context, id string) (clients.Client, error) {
-	q := `SELECT id, name, tags, COALESCE(domain_id, '') AS domain_id, COALESCE(parent_group_id, '') AS parent_group_id, identity, secret, metadata, created_at, updated_at, updated_by, status
-        FROM clients WHERE id = :id`
+	q := fmt.Sprintf(`SELECT c.id, c.name, c.tags, COALESCE(c.domain_id, '') AS domain_id, COALESCE(c.parent_group_id, '') AS parent_group_id, c.identity, c.secret, c.pending_secret, c.secret_expires_at, c.metadata, c.created_at, c.updated_at, c.updated_by, c.status,
+        %s
+        FROM clients c %s WHERE c.id = :id`, presenceColumns, presenceJoinQuery)
 
 	dbc := DBClient{
 		ID: id,
@@ -223,6 +247,7 @@ func (repo *clientRepo) RetrieveAll(ctx context.Context, pm clients.Page) (clien
 			) conn ON c.id = conn.client_id
 		`
 	}
+	connJoinQuery += presenceJoinQuery
 
 	comQuery := fmt.Sprintf(`WITH clients AS (
 				SELECT
@@ -242,10 +267,11 @@ func (repo *clientRepo) RetrieveAll(ctx context.Context, pm clients.Page) (clien
 					clients c
 			)
 			SELECT
-				*
+				c.*,
+				%s
 			%s
 			%s
-		`, connJoinQuery, pageQuery)
+		`, presenceColumns, connJoinQuery, pageQuery)
 
 	q := applyOrdering(comQuery, pm)
 
@@ -332,6 +358,7 @@ func (repo *clientRepo) retrieveClients(ctx context.Context, domainID, userID st
 			) conn ON c.id = conn.client_id
 		`
 	}
+	connJoinQuery += presenceJoinQuery
 
 	q := fmt.Sprintf(`
 				%s
@@ -356,10 +383,11 @@ func (repo *clientRepo) retrieveClients(ctx context.Context, domainID, userID st
 					c.access_provider_id,
 					c.access_provider_role_id,
 					c.access_provider_role_name,
-					c.access_provider_role_actions
+					c.access_provider_role_actions,
+					%s
 				%s
 				%s
-	`, bq, connJoinQuery, pageQuery)
+	`, bq, presenceColumns, connJoinQuery, pageQuery)
 
 	q = applyOrdering(q, pm)
 
@@ -799,28 +827,35 @@ func (repo *clientRepo) Delete(ctx context.Context, clientIDs ...string) error {
 }
 
 type DBClient struct {
-	ID                        string           `db:"id"`
-	Name                      string           `db:"name,omitempty"`
-	Tags                      pgtype.TextArray `db:"tags,omitempty"`
-	Identity                  string           `db:"identity"`
-	Domain                    string           `db:"domain_id"`
-	ParentGroup               sql.NullString   `db:"parent_group_id,omitempty"`
-	Secret                    string           `db:"secret"`
-	Metadata                  []byte           `db:"metadata,omitempty"`
-	CreatedAt                 time.Time        `db:"created_at,omitempty"`
-	UpdatedAt                 sql.NullTime     `db:"updated_at,omitempty"`
-	UpdatedBy                 *string          `db:"updated_by,omitempty"`
-	Status                    clients.Status   `db:"status,omitempty"`
-	ParentGroupPath           string           `db:"parent_group_path,omitempty"`
-	RoleID                    string           `db:"role_id,omitempty"`
-	RoleName                  string           `db:"role_name,omitempty"`
-	Actions                   pq.StringArray   `db:"actions,omitempty"`
-	AccessType                string           `db:"access_type,omitempty"`
-	AccessProviderId          string           `db:"access_provider_id,omitempty"`
-	AccessProviderRoleId      string           `db:"access_provider_role_id,omitempty"`
-	AccessProviderRoleName    string           `db:"access_provider_role_name,omitempty"`
-	AccessProviderRoleActions pq.StringArray   `db:"access_provider_role_actions,omitempty"`
-	ConnectionTypes           pq.Int32Array    `db:"connection_types,omitempty"`
+	ID                        string                  `db:"id"`
+	Name                      string                  `db:"name,omitempty"`
+	Tags                      pgtype.TextArray        `db:"tags,omitempty"`
+	Identity                  string                  `db:"identity"`
+	Domain                    string                  `db:"domain_id"`
+	ParentGroup               sql.NullString          `db:"parent_group_id,omitempty"`
+	Secret                    string                  `db:"secret"`
+	PendingSecret             sql.NullString          `db:"pending_secret,omitempty"`
+	SecretExpiresAt           sql.NullTime            `db:"secret_expires_at,omitempty"`
+	Metadata                  []byte                  `db:"metadata,omitempty"`
+	CreatedAt                 time.Time               `db:"created_at,omitempty"`
+	UpdatedAt                 sql.NullTime            `db:"updated_at,omitempty"`
+	UpdatedBy                 *string                 `db:"updated_by,omitempty"`
+	Status                    clients.Status          `db:"status,omitempty"`
+	ParentGroupPath           string                  `db:"parent_group_path,omitempty"`
+	RoleID                    string                  `db:"role_id,omitempty"`
+	RoleName                  string                  `db:"role_name,omitempty"`
+	Actions                   pq.StringArray          `db:"actions,omitempty"`
+	AccessType                string                  `db:"access_type,omitempty"`
+	AccessProviderId          string                  `db:"access_provider_id,omitempty"`
+	AccessProviderRoleId      string                  `db:"access_provider_role_id,omitempty"`
+	AccessProviderRoleName    string                  `db:"access_provider_role_name,omitempty"`
+	AccessProviderRoleActions pq.StringArray          `db:"access_provider_role_actions,omitempty"`
+	ConnectionTypes           pq.Int32Array           `db:"connection_types,omitempty"`
+	PresenceStatus            *clients.PresenceStatus `db:"presence_status,omitempty"`
+	PresenceProtocol          string                  `db:"presence_protocol,omitempty"`
+	PresenceRemoteAddr        string                  `db:"presence_remote_addr,omitempty"`
+	PresenceSessionStart      sql.NullTime            `db:"presence_session_start,omitempty"`
+	PresenceLastSeen          sql.NullTime            `db:"presence_last_seen,omitempty"`
 }
 
 func ToDBClient(c clients.Client) (DBClient, error) {
@@ -844,20 +879,26 @@ func ToDBClient(c clients.Client) (DBClient, error) {
 	if c.UpdatedAt != (time.Time{}) {
 		updatedAt = sql.NullTime{Time: c.UpdatedAt, Valid: true}
 	}
+	var secretExpiresAt sql.NullTime
+	if c.Credentials.SecretExpiresAt != nil {
+		secretExpiresAt = sql.NullTime{Time: *c.Credentials.SecretExpiresAt, Valid: true}
+	}
 
 	return DBClient{
-		ID:          c.ID,
-		Name:        c.Name,
-		Tags:        tags,
-		Domain:      c.Domain,
-		ParentGroup: toNullString(c.ParentGroup),
-		Identity:    c.Credentials.Identity,
-		Secret:      c.Credentials.Secret,
-		Metadata:    data,
-		CreatedAt:   c.CreatedAt,
-		UpdatedAt:   updatedAt,
-		UpdatedBy:   updatedBy,
-		Status:      c.Status,
+		ID:              c.ID,
+		Name:            c.Name,
+		Tags:            tags,
+		Domain:          c.Domain,
+		ParentGroup:     toNullString(c.ParentGroup),
+		Identity:        c.Credentials.Identity,
+		Secret:          c.Credentials.Secret,
+		PendingSecret:   toNullString(c.Credentials.PendingSecret),
+		SecretExpiresAt: secretExpiresAt,
+		Metadata:        data,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       updatedAt,
+		UpdatedBy:       updatedBy,
+		Status:          c.Status,
 	}, nil
 }
 
@@ -880,6 +921,10 @@ func ToClient(t DBClient) (clients.Client, error) {
 	if t.UpdatedAt.Valid {
 		updatedAt = t.UpdatedAt.Time
 	}
+	var secretExpiresAt *time.Time
+	if t.SecretExpiresAt.Valid {
+		secretExpiresAt = &t.SecretExpiresAt.Time
+	}
 
 	connTypes := []connections.ConnType{}
 	for _, ct := range t.ConnectionTypes {
@@ -897,8 +942,10 @@ func ToClient(t DBClient) (clients.Client, error) {
 		Domain:      t.Domain,
 		ParentGroup: toString(t.ParentGroup),
 		Credentials: clients.Credentials{
-			Identity: t.Identity,
-			Secret:   t.Secret,
+			Identity:        t.Identity,
+			Secret:          t.Secret,
+			PendingSecret:   toString(t.PendingSecret),
+			SecretExpiresAt: secretExpiresAt,
 		},
 		Metadata:                  metadata,
 		CreatedAt:                 t.CreatedAt,
@@ -916,6 +963,16 @@ func ToClient(t DBClient) (clients.Client, error) {
 		AccessProviderRoleActions: t.AccessProviderRoleActions,
 		ConnectionTypes:           connTypes,
 	}
+	if t.PresenceStatus != nil {
+		cli.Presence = &clients.Presence{
+			ClientID:     t.ID,
+			Status:       *t.PresenceStatus,
+			Protocol:     t.PresenceProtocol,
+			RemoteAddr:   t.PresenceRemoteAddr,
+			SessionStart: toTime(t.PresenceSessionStart),
+			LastSeen:     toTime(t.PresenceLastSeen),
+		}
+	}
 	return cli, nil
 }
 
@@ -941,26 +998,28 @@ func ToDBClientsPage(pm clients.Page) (dbClientsPage, error) {
 		RoleID:     pm.RoleID,
 		Actions:    pm.Actions,
 		AccessType: pm.AccessType,
+		Presence:   pm.Presence,
 	}, nil
 }
 
 type dbClientsPage struct {
-	Limit      uint64         `db:"limit"`
-	Offset     uint64         `db:"offset"`
-	Name       string         `db:"name"`
-	Id         string         `db:"id"`
-	Domain     string         `db:"domain_id"`
-	Identity   string         `db:"identity"`
-	Metadata   []byte         `db:"metadata"`
-	Tag        string         `db:"tag"`
-	Status     clients.Status `db:"status"`
-	GroupID    string         `db:"group_id"`
-	ChannelID  string         `db:"channel_id"`
-	ConnType   string         `db:"type"`
-	RoleName   string         `db:"role_name"`
-	RoleID     string         `db:"role_id"`
-	Actions    pq.StringArray `db:"actions"`
-	AccessType string         `db:"access_type"`
+	Limit      uint64                 `db:"limit"`
+	Offset     uint64                 `db:"offset"`
+	Name       string                 `db:"name"`
+	Id         string                 `db:"id"`
+	Domain     string                 `db:"domain_id"`
+	Identity   string                 `db:"identity"`
+	Metadata   []byte                 `db:"metadata"`
+	Tag        string                 `db:"tag"`
+	Status     clients.Status         `db:"status"`
+	GroupID    string                 `db:"group_id"`
+	ChannelID  string                 `db:"channel_id"`
+	ConnType   string                 `db:"type"`
+	RoleName   string                 `db:"role_name"`
+	RoleID     string                 `db:"role_id"`
+	Actions    pq.StringArray         `db:"actions"`
+	AccessType string                 `db:"access_type"`
+	Presence   clients.PresenceStatus `db:"presence_status"`
 }
 
 func PageQuery(pm clients.Page) (string, error) {
@@ -994,6 +1053,9 @@ func PageQuery(pm clients.Page) (string, error) {
 	if pm.Status != clients.AllStatus {
 		query = append(query, "c.status = :status")
 	}
+	if pm.Presence != clients.AllPresence {
+		query = append(query, fmt.Sprintf("COALESCE(p.status, %d) = :presence_status", clients.OfflinePresence))
+	}
 	if pm.Domain != "" {
 		query = append(query, "c.domain_id = :domain_id")
 	}
@@ -1056,6 +1118,14 @@ func toNullString(s string) sql.NullString {
 	}
 }
 
+func toTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
 func toString(s sql.NullString) string {
 	if s.Valid {
 		return s.String
@@ -349,12 +349,45 @@ func TestClientsRetrieveBySecret(t *testing.T) {
 	_, err := repo.Save(context.Background(), client)
 	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
 
+	// The clients of different domains could share the secret before the
+	// secrets became unique, which is reproduced with the trigger disabled.
+	legacySecret := testsutil.GenerateUUID(t)
+	_, err = db.Exec("ALTER TABLE clients DISABLE TRIGGER clients_secrets_sync")
+	require.Nil(t, err, fmt.Sprintf("disable trigger unexpected error: %s", err))
+	for i := 0; i < 2; i++ {
+		legacy := clients.Client{
+			ID:          testsutil.GenerateUUID(t),
+			Name:        fmt.Sprintf("%s-legacy-%d", clientName, i),
+			Domain:      testsutil.GenerateUUID(t),
+			Credentials: clients.Credentials{Secret: legacySecret},
+			Metadata:    clients.Metadata{},
+			Status:      clients.EnabledStatus,
+		}
+		_, err = repo.Save(context.Background(), legacy)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+	_, err = db.Exec("ALTER TABLE clients ENABLE TRIGGER clients_secrets_sync")
+	require.Nil(t, err, fmt.Sprintf("enable trigger unexpected error: %s", err))
+
+	duplicate := client
+	duplicate.ID = testsutil.GenerateUUID(t)
+	duplicate.Name = clientName + "-duplicate"
+	duplicate.Domain = testsutil.GenerateUUID(t)
+	_, err = repo.Save(context.Background(), duplicate)
+	assert.True(t, errors.Contains(err, repoerr.ErrConflict), fmt.Sprintf("save client with existing secret: expected %s got %s\n", repoerr.ErrConflict, err))
+
 	cases := []struct {
 		desc     string
 		secret   string
 		response clients.Client
 		err      error
 	}{
+		{
+			desc:     "retrieve client by secret shared by legacy clients",
+			secret:   legacySecret,
+			response: clients.Client{},
+			err:      repoerr.ErrConflict,
+		},
 		{
 			desc:     "retrieve client by secret successfully",
 			secret:   client.Credentials.Secret,
@@ -680,6 +713,12 @@ func TestUpdateSecret(t *testing.T) {
 
 	client1 := generateClient(t, clients.EnabledStatus, repo)
 	client2 := generateClient(t, clients.DisabledStatus, repo)
+	client3 := generateClient(t, clients.EnabledStatus, repo)
+	expiresAt := time.Now().UTC().Add(time.Hour)
+	client3.Credentials.PendingSecret = "pendingsecret"
+	client3.Credentials.SecretExpiresAt = &expiresAt
+	_, err := repo.UpdatePendingSecret(context.Background(), client3)
+	require.Nil(t, err, fmt.Sprintf("update pending secret unexpected error: %s", err))
 
 	cases := []struct {
 		desc   string
@@ -721,6 +760,16 @@ func TestUpdateSecret(t *testing.T) {
 			client: clients.Client{},
 			err:    repoerr.ErrNotFound,
 		},
+		{
+			desc: "with pending secret of another client",
+			client: clients.Client{
+				ID: client1.ID,
+				Credentials: clients.Credentials{
+					Secret: client3.Credentials.PendingSecret,
+				},
+			},
+			err: repoerr.ErrConflict,
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.desc, func(t *testing.T) {
@@ -739,6 +788,190 @@ func TestUpdateSecret(t *testing.T) {
 	}
 }
 
+func TestUpdatePendingSecret(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	client1 := generateClient(t, clients.EnabledStatus, repo)
+	client2 := generateClient(t, clients.DisabledStatus, repo)
+	client3 := generateClient(t, clients.EnabledStatus, repo)
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Millisecond)
+
+	cases := []struct {
+		desc   string
+		client clients.Client
+		err    error
+	}{
+		{
+			desc: "for enabled client",
+			client: clients.Client{
+				ID: client1.ID,
+				Credentials: clients.Credentials{
+					PendingSecret:   "pendingsecret",
+					SecretExpiresAt: &expiresAt,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "for enabled client with the same pending secret",
+			client: clients.Client{
+				ID: client1.ID,
+				Credentials: clients.Credentials{
+					PendingSecret:   "pendingsecret",
+					SecretExpiresAt: &expiresAt,
+				},
+			},
+			err: nil,
+		},
+		{
+			desc: "for enabled client with another pending secret",
+			client: clients.Client{
+				ID: client1.ID,
+				Credentials: clients.Credentials{
+					PendingSecret:   "otherpendingsecret",
+					SecretExpiresAt: &expiresAt,
+				},
+			},
+			err: repoerr.ErrNotFound,
+		},
+		{
+			desc: "with pending secret of another client",
+			client: clients.Client{
+				ID: client3.ID,
+				Credentials: clients.Credentials{
+					PendingSecret:   "pendingsecret",
+					SecretExpiresAt: &expiresAt,
+				},
+			},
+			err: repoerr.ErrConflict,
+		},
+		{
+			desc: "with secret of another client",
+			client: clients.Client{
+				ID: client3.ID,
+				Credentials: clients.Credentials{
+					PendingSecret:   client1.Credentials.Secret,
+					SecretExpiresAt: &expiresAt,
+				},
+			},
+			err: repoerr.ErrConflict,
+		},
+		{
+			desc: "with secret of disabled client",
+			client: clients.Client{
+				ID: client3.ID,
+				Credentials: clients.Credentials{
+					PendingSecret:   client2.Credentials.Secret,
+					SecretExpiresAt: &expiresAt,
+				},
+			},
+			err: repoerr.ErrConflict,
+		},
+		{
+			desc: "for disabled client",
+			client: clients.Client{
+				ID: client2.ID,
+				Credentials: clients.Credentials{
+					PendingSecret:   "pendingsecret",
+					SecretExpiresAt: &expiresAt,
+				},
+			},
+			err: repoerr.ErrNotFound,
+		},
+		{
+			desc: "for invalid client",
+			client: clients.Client{
+				ID: testsutil.GenerateUUID(t),
+				Credentials: clients.Credentials{
+					PendingSecret:   "pendingsecret",
+					SecretExpiresAt: &expiresAt,
+				},
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			c.client.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+			c.client.UpdatedBy = testsutil.GenerateUUID(t)
+			_, err := repo.UpdatePendingSecret(context.Background(), c.client)
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected %s to contain %s\n", err, c.err))
+			if err == nil {
+				rc, err := repo.RetrieveByID(context.Background(), c.client.ID)
+				require.Nil(t, err, fmt.Sprintf("retrieve client by id during update of pending secret unexpected error: %s", err))
+				assert.Equal(t, client1.Credentials.Secret, rc.Credentials.Secret)
+				assert.Equal(t, c.client.Credentials.PendingSecret, rc.Credentials.PendingSecret)
+				assert.Equal(t, c.client.Credentials.SecretExpiresAt, rc.Credentials.SecretExpiresAt)
+
+				rc, err = repo.RetrieveBySecret(context.Background(), c.client.Credentials.PendingSecret)
+				require.Nil(t, err, fmt.Sprintf("retrieve client by pending secret unexpected error: %s", err))
+				assert.Equal(t, c.client.ID, rc.ID)
+			}
+		})
+	}
+}
+
+func TestConfirmSecret(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+
+	repo := postgres.NewRepository(database)
+
+	client1 := generateClient(t, clients.EnabledStatus, repo)
+	client2 := generateClient(t, clients.EnabledStatus, repo)
+	expiresAt := time.Now().UTC().Add(time.Hour)
+	client1.Credentials.PendingSecret = "pendingsecret"
+	client1.Credentials.SecretExpiresAt = &expiresAt
+	_, err := repo.UpdatePendingSecret(context.Background(), client1)
+	require.Nil(t, err, fmt.Sprintf("update pending secret unexpected error: %s", err))
+
+	cases := []struct {
+		desc   string
+		client clients.Client
+		secret string
+		err    error
+	}{
+		{
+			desc:   "for client with pending secret",
+			client: clients.Client{ID: client1.ID},
+			secret: client1.Credentials.PendingSecret,
+			err:    nil,
+		},
+		{
+			desc:   "for client without pending secret",
+			client: clients.Client{ID: client2.ID},
+			err:    repoerr.ErrNotFound,
+		},
+		{
+			desc:   "for invalid client",
+			client: clients.Client{ID: testsutil.GenerateUUID(t)},
+			err:    repoerr.ErrNotFound,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			c.client.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+			c.client.UpdatedBy = testsutil.GenerateUUID(t)
+			_, err := repo.ConfirmSecret(context.Background(), c.client)
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected %s to contain %s\n", err, c.err))
+			if err == nil {
+				rc, err := repo.RetrieveByID(context.Background(), c.client.ID)
+				require.Nil(t, err, fmt.Sprintf("retrieve client by id during confirmation of secret unexpected error: %s", err))
+				assert.Equal(t, c.secret, rc.Credentials.Secret)
+				assert.Empty(t, rc.Credentials.PendingSecret)
+				assert.Nil(t, rc.Credentials.SecretExpiresAt)
+			}
+		})
+	}
+}
+
 func TestChangeStatus(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM clients")
@@ -2419,6 +2652,7 @@ func stripClientDetails(clients []clients.Client) []clients.Client {
 	for i := range clients {
 		clients[i].CreatedAt = validTimestamp
 		clients[i].Credentials.Secret = ""
+		clients[i].Presence = nil
 	}
 
 	return clients
@@ -56,6 +56,106 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 					`DROP TABLE IF EXISTS connections`,
 				},
 			},
+			{
+				Id: "clients_02",
+				Up: []string{
+					`ALTER TABLE clients
+						ADD COLUMN pending_secret VARCHAR(4096),
+						ADD COLUMN secret_expires_at TIMESTAMP,
+						ADD UNIQUE (domain_id, pending_secret)`,
+				},
+				Down: []string{
+					`ALTER TABLE clients
+						DROP COLUMN pending_secret,
+						DROP COLUMN secret_expires_at`,
+				},
+			},
+			{
+				Id: "clients_03",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS clients_presence (
+						client_id       VARCHAR(36) PRIMARY KEY REFERENCES clients (id) ON DELETE CASCADE,
+						status          SMALLINT NOT NULL CHECK (status > 0),
+						session_id      VARCHAR(254),
+						protocol        VARCHAR(36),
+						remote_addr     VARCHAR(254),
+						session_start   TIMESTAMP,
+						last_seen       TIMESTAMP
+					)`,
+					`CREATE INDEX IF NOT EXISTS clients_presence_status_idx ON clients_presence (status)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS clients_presence`,
+				},
+			},
+			{
+				Id: "clients_04",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS clients_sessions (
+						instance        VARCHAR(254) NOT NULL,
+						protocol        VARCHAR(36) NOT NULL,
+						session_id      VARCHAR(254) NOT NULL,
+						client_id       VARCHAR(36) NOT NULL REFERENCES clients (id) ON DELETE CASCADE,
+						remote_addr     VARCHAR(254),
+						started_at      TIMESTAMP NOT NULL,
+						PRIMARY KEY (instance, protocol, session_id)
+					)`,
+					`CREATE INDEX IF NOT EXISTS clients_sessions_client_id_idx ON clients_sessions (client_id)`,
+					`CREATE TABLE IF NOT EXISTS clients_instances (
+						instance        VARCHAR(254) PRIMARY KEY,
+						last_seen       TIMESTAMP NOT NULL
+					)`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS clients_sessions`,
+					`DROP TABLE IF EXISTS clients_instances`,
+				},
+			},
+			{
+				// The current and the pending secrets share a single unique
+				// index, so a secret identifies at most one client.
+				Id: "clients_05",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS clients_secrets (
+						secret          VARCHAR(4096) PRIMARY KEY,
+						client_id       VARCHAR(36) NOT NULL REFERENCES clients (id) ON DELETE CASCADE
+					)`,
+					`CREATE INDEX IF NOT EXISTS clients_secrets_client_id_idx ON clients_secrets (client_id)`,
+					`INSERT INTO clients_secrets (secret, client_id)
+						SELECT secret, id FROM clients WHERE secret IS NOT NULL AND secret <> ''
+						ON CONFLICT DO NOTHING`,
+					`INSERT INTO clients_secrets (secret, client_id)
+						SELECT pending_secret, id FROM clients WHERE pending_secret IS NOT NULL AND pending_secret <> ''
+						ON CONFLICT DO NOTHING`,
+					`CREATE OR REPLACE FUNCTION clients_sync_secrets() RETURNS TRIGGER AS $$
+					BEGIN
+						DELETE FROM clients_secrets
+							WHERE client_id = NEW.id
+							AND secret IS DISTINCT FROM NEW.secret
+							AND secret IS DISTINCT FROM NEW.pending_secret;
+						IF COALESCE(NEW.secret, '') <> '' AND NOT EXISTS (
+							SELECT 1 FROM clients_secrets WHERE secret = NEW.secret AND client_id = NEW.id
+						) THEN
+							INSERT INTO clients_secrets (secret, client_id) VALUES (NEW.secret, NEW.id);
+						END IF;
+						IF COALESCE(NEW.pending_secret, '') <> '' AND NOT EXISTS (
+							SELECT 1 FROM clients_secrets WHERE secret = NEW.pending_secret AND client_id = NEW.id
+						) THEN
+							INSERT INTO clients_secrets (secret, client_id) VALUES (NEW.pending_secret, NEW.id);
+						END IF;
+						RETURN NEW;
+					END;
+					$$ LANGUAGE plpgsql`,
+					`CREATE TRIGGER clients_secrets_sync
+						AFTER INSERT OR UPDATE OF secret, pending_secret ON clients
+						FOR EACH ROW EXECUTE FUNCTION clients_sync_secrets()`,
+				},
+				Down: []string{
+					`DROP TRIGGER IF EXISTS clients_secrets_sync ON clients`,
+					`DROP FUNCTION IF EXISTS clients_sync_secrets`,
+					`DROP TABLE IF EXISTS clients_secrets`,
+				},
+			},
 		},
 	}
 
@@ -0,0 +1,298 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/jmoiron/sqlx"
+)
+
+// presenceJoinQuery joins the presence of the clients aliased as c. Clients
+// which never connected have no presence row.
+const presenceJoinQuery = `
+			LEFT JOIN clients_presence p ON p.client_id = c.id
+`
+
+var presenceColumns = fmt.Sprintf(`COALESCE(p.status, %d) AS presence_status,
+	COALESCE(p.protocol, '') AS presence_protocol,
+	COALESCE(p.remote_addr, '') AS presence_remote_addr,
+	p.session_start AS presence_session_start,
+	p.last_seen AS presence_last_seen`, clients.OfflinePresence)
+
+type dbPresence struct {
+	ClientID     string                 `db:"client_id"`
+	Status       clients.PresenceStatus `db:"status"`
+	SessionID    sql.NullString         `db:"session_id"`
+	Protocol     sql.NullString         `db:"protocol"`
+	RemoteAddr   sql.NullString         `db:"remote_addr"`
+	SessionStart sql.NullTime           `db:"session_start"`
+	LastSeen     sql.NullTime           `db:"last_seen"`
+}
+
+func (repo *clientRepo) RetrievePresence(ctx context.Context, clientID string) (clients.Presence, error) {
+	q := `SELECT client_id, status, session_id, protocol, remote_addr, session_start, last_seen
+		FROM clients_presence WHERE client_id = :client_id`
+
+	rows, err := repo.DB.NamedQueryContext(ctx, q, dbPresence{ClientID: clientID})
+	if err != nil {
+		return clients.Presence{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return clients.Presence{ClientID: clientID, Status: clients.OfflinePresence}, nil
+	}
+	dbp := dbPresence{}
+	if err := rows.StructScan(&dbp); err != nil {
+		return clients.Presence{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return toPresence(dbp), nil
+}
+
+func (repo *clientRepo) SaveSession(ctx context.Context, s clients.Session) (p clients.Presence, retErr error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return clients.Presence{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollBack := tx.Rollback(); errRollBack != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollBack))
+			}
+		}
+	}()
+
+	dbs := toDBSession(s)
+	q := `INSERT INTO clients_sessions (instance, protocol, session_id, client_id, remote_addr, started_at)
+		SELECT :instance, :protocol, :session_id, :client_id, :remote_addr, :started_at
+		WHERE EXISTS (SELECT 1 FROM clients WHERE id = :client_id)
+		ON CONFLICT (instance, protocol, session_id) DO UPDATE SET
+			client_id = EXCLUDED.client_id,
+			remote_addr = EXCLUDED.remote_addr,
+			started_at = EXCLUDED.started_at`
+	result, err := tx.NamedExecContext(ctx, q, dbs)
+	if err != nil {
+		return clients.Presence{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return clients.Presence{}, repoerr.ErrNotFound
+	}
+
+	q = fmt.Sprintf(`INSERT INTO clients_presence (client_id, status, session_id, protocol, remote_addr, session_start, last_seen)
+		VALUES (:client_id, %d, :session_id, :protocol, :remote_addr, :started_at, :started_at)
+		ON CONFLICT (client_id) DO UPDATE SET
+			status = EXCLUDED.status,
+			session_id = EXCLUDED.session_id,
+			protocol = EXCLUDED.protocol,
+			remote_addr = EXCLUDED.remote_addr,
+			session_start = EXCLUDED.session_start,
+			last_seen = GREATEST(clients_presence.last_seen, EXCLUDED.last_seen)
+		RETURNING client_id, status, session_id, protocol, remote_addr, session_start, last_seen`, clients.OnlinePresence)
+	p, err = scanPresence(tx.NamedQuery(q, dbs))
+	if err != nil {
+		return clients.Presence{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return clients.Presence{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return p, nil
+}
+
+func (repo *clientRepo) RemoveSession(ctx context.Context, s clients.Session, closedAt time.Time) (p clients.Presence, retErr error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return clients.Presence{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollBack := tx.Rollback(); errRollBack != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollBack))
+			}
+		}
+	}()
+
+	dbs := toDBSession(s)
+	q := `DELETE FROM clients_sessions
+		WHERE instance = :instance AND protocol = :protocol AND session_id = :session_id`
+	result, err := tx.NamedExecContext(ctx, q, dbs)
+	if err != nil {
+		return clients.Presence{}, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return clients.Presence{}, repoerr.ErrNotFound
+	}
+
+	// The client stays online while any of its sessions is open.
+	q = fmt.Sprintf(`UPDATE clients_presence SET status = %d, last_seen = :closed_at
+		WHERE client_id = :client_id AND NOT EXISTS (SELECT 1 FROM clients_sessions WHERE client_id = :client_id)`, clients.OfflinePresence)
+	params := map[string]interface{}{"client_id": s.ClientID, "closed_at": closedAt}
+	if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+		return clients.Presence{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	q = `SELECT client_id, status, session_id, protocol, remote_addr, session_start, last_seen
+		FROM clients_presence WHERE client_id = :client_id`
+	p, err = scanPresence(tx.NamedQuery(q, params))
+	if err != nil {
+		return clients.Presence{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return clients.Presence{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return p, nil
+}
+
+func (repo *clientRepo) SaveHeartbeat(ctx context.Context, instance string, seenAt time.Time) error {
+	q := `INSERT INTO clients_instances (instance, last_seen) VALUES (:instance, :last_seen)
+		ON CONFLICT (instance) DO UPDATE SET last_seen = GREATEST(clients_instances.last_seen, EXCLUDED.last_seen)`
+
+	params := map[string]interface{}{"instance": instance, "last_seen": seenAt}
+	if _, err := repo.DB.NamedExecContext(ctx, q, params); err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (repo *clientRepo) RemoveExpiredSessions(ctx context.Context, seenBefore time.Time) (ps []clients.Presence, retErr error) {
+	tx, err := repo.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrRemoveEntity, err)
+	}
+	defer func() {
+		if retErr != nil {
+			if errRollBack := tx.Rollback(); errRollBack != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(apiutil.ErrRollbackTx, errRollBack))
+			}
+		}
+	}()
+
+	params := map[string]interface{}{"seen_before": seenBefore}
+	// Sessions started after the given time are kept, since the heartbeat of
+	// their instance may not have been received yet.
+	q := `DELETE FROM clients_sessions s
+		WHERE s.started_at < :seen_before AND NOT EXISTS (
+			SELECT 1 FROM clients_instances i WHERE i.instance = s.instance AND i.last_seen >= :seen_before)`
+	if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+		return nil, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	q = `DELETE FROM clients_instances WHERE last_seen < :seen_before`
+	if _, err := tx.NamedExecContext(ctx, q, params); err != nil {
+		return nil, postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+
+	// The clients marked online before the sessions were tracked are marked
+	// offline as well once they were not seen since the given time.
+	q = fmt.Sprintf(`UPDATE clients_presence p SET status = %d
+		WHERE p.status = %d AND (p.last_seen IS NULL OR p.last_seen < :seen_before)
+			AND NOT EXISTS (SELECT 1 FROM clients_sessions s WHERE s.client_id = p.client_id)
+		RETURNING client_id, status, session_id, protocol, remote_addr, session_start, last_seen`, clients.OfflinePresence, clients.OnlinePresence)
+	rows, err := tx.NamedQuery(q, params)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		dbp := dbPresence{}
+		if err := rows.StructScan(&dbp); err != nil {
+			return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+		ps = append(ps, toPresence(dbp))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	rows.Close()
+	if err := tx.Commit(); err != nil {
+		return nil, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+
+	return ps, nil
+}
+
+type dbSession struct {
+	Instance   string         `db:"instance"`
+	Protocol   string         `db:"protocol"`
+	SessionID  string         `db:"session_id"`
+	ClientID   string         `db:"client_id"`
+	RemoteAddr sql.NullString `db:"remote_addr"`
+	StartedAt  time.Time      `db:"started_at"`
+}
+
+func toDBSession(s clients.Session) dbSession {
+	return dbSession{
+		Instance:   s.Instance,
+		Protocol:   s.Protocol,
+		SessionID:  s.ID,
+		ClientID:   s.ClientID,
+		RemoteAddr: toNullString(s.RemoteAddr),
+		StartedAt:  s.StartedAt,
+	}
+}
+
+// scanPresence scans the single presence row returned by the query.
+func scanPresence(rows *sqlx.Rows, err error) (clients.Presence, error) {
+	if err != nil {
+		return clients.Presence{}, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return clients.Presence{}, err
+		}
+		return clients.Presence{}, repoerr.ErrNotFound
+	}
+	dbp := dbPresence{}
+	if err := rows.StructScan(&dbp); err != nil {
+		return clients.Presence{}, err
+	}
+
+	return toPresence(dbp), nil
+}
+
+func toDBPresence(p clients.Presence) dbPresence {
+	return dbPresence{
+		ClientID:     p.ClientID,
+		Status:       p.Status,
+		SessionID:    toNullString(p.SessionID),
+		Protocol:     toNullString(p.Protocol),
+		RemoteAddr:   toNullString(p.RemoteAddr),
+		SessionStart: toNullTime(p.SessionStart),
+		LastSeen:     toNullTime(p.LastSeen),
+	}
+}
+
+func toPresence(p dbPresence) clients.Presence {
+	return clients.Presence{
+		ClientID:     p.ClientID,
+		Status:       p.Status,
+		SessionID:    toString(p.SessionID),
+		Protocol:     toString(p.Protocol),
+		RemoteAddr:   toString(p.RemoteAddr),
+		SessionStart: toTime(p.SessionStart),
+		LastSeen:     toTime(p.LastSeen),
+	}
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: *t, Valid: true}
+}
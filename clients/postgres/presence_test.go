@@ -0,0 +1,311 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/clients/postgres"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveSession(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	client := validClient
+	client.ID = testsutil.GenerateUUID(t)
+	client.Credentials.Secret = testsutil.GenerateUUID(t)
+	_, err := repo.Save(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	start := time.Now().UTC().Truncate(time.Microsecond)
+	instance := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc    string
+		session clients.Session
+		err     error
+	}{
+		{
+			desc: "save session successfully",
+			session: clients.Session{
+				ID:         testsutil.GenerateUUID(t),
+				ClientID:   client.ID,
+				Protocol:   "coap",
+				Instance:   instance,
+				RemoteAddr: "127.0.0.1:5683",
+				StartedAt:  start,
+			},
+			err: nil,
+		},
+		{
+			desc: "save other session of the client successfully",
+			session: clients.Session{
+				ID:        testsutil.GenerateUUID(t),
+				ClientID:  client.ID,
+				Protocol:  "mqtt",
+				Instance:  instance,
+				StartedAt: start.Add(time.Minute),
+			},
+			err: nil,
+		},
+		{
+			desc: "save session of non-existing client",
+			session: clients.Session{
+				ID:        testsutil.GenerateUUID(t),
+				ClientID:  testsutil.GenerateUUID(t),
+				Protocol:  "mqtt",
+				Instance:  instance,
+				StartedAt: start,
+			},
+			err: repoerr.ErrNotFound,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := repo.SaveSession(context.Background(), tc.session)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected %s got %s\n", tc.err, err))
+			if err == nil {
+				assert.Equal(t, clients.OnlinePresence, p.Status)
+				assert.Equal(t, tc.session.ID, p.SessionID)
+				assert.Equal(t, tc.session.Protocol, p.Protocol)
+				assert.Equal(t, tc.session.RemoteAddr, p.RemoteAddr)
+				require.NotNil(t, p.SessionStart)
+				assert.True(t, tc.session.StartedAt.Equal(*p.SessionStart), fmt.Sprintf("expected session start %s got %s", tc.session.StartedAt, p.SessionStart))
+
+				cli, err := repo.RetrieveByID(context.Background(), tc.session.ClientID)
+				require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+				require.NotNil(t, cli.Presence)
+				assert.Equal(t, clients.OnlinePresence, cli.Presence.Status)
+				assert.Equal(t, tc.session.Protocol, cli.Presence.Protocol)
+			}
+		})
+	}
+}
+
+func TestRemoveSession(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	client := validClient
+	client.ID = testsutil.GenerateUUID(t)
+	client.Credentials.Secret = testsutil.GenerateUUID(t)
+	_, err := repo.Save(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	start := time.Now().UTC().Truncate(time.Microsecond)
+	end := start.Add(time.Hour)
+	instance := testsutil.GenerateUUID(t)
+	first := clients.Session{
+		ID:        testsutil.GenerateUUID(t),
+		ClientID:  client.ID,
+		Protocol:  "ws",
+		Instance:  instance,
+		StartedAt: start,
+	}
+	second := first
+	second.ID = testsutil.GenerateUUID(t)
+	for _, s := range []clients.Session{first, second} {
+		_, err := repo.SaveSession(context.Background(), s)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	cases := []struct {
+		desc    string
+		session clients.Session
+		status  clients.PresenceStatus
+		err     error
+	}{
+		{
+			desc:    "remove session of client with other open session",
+			session: first,
+			status:  clients.OnlinePresence,
+		},
+		{
+			desc:    "remove removed session",
+			session: first,
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc:    "remove last open session of client",
+			session: second,
+			status:  clients.OfflinePresence,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			p, err := repo.RemoveSession(context.Background(), tc.session, end)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected %s got %s\n", tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.status, p.Status)
+				if tc.status == clients.OfflinePresence {
+					require.NotNil(t, p.LastSeen)
+					assert.True(t, end.Equal(*p.LastSeen), fmt.Sprintf("expected last seen %s got %s", end, p.LastSeen))
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveExpiredSessions(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+		_, err = db.Exec("DELETE FROM clients_instances")
+		require.Nil(t, err, fmt.Sprintf("clean instances unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	live := testsutil.GenerateUUID(t)
+	stopped := testsutil.GenerateUUID(t)
+	require.Nil(t, repo.SaveHeartbeat(context.Background(), live, now))
+	require.Nil(t, repo.SaveHeartbeat(context.Background(), stopped, now.Add(-time.Hour)))
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		client := validClient
+		client.ID = testsutil.GenerateUUID(t)
+		client.Name = namegen.Generate()
+		client.Credentials.Secret = testsutil.GenerateUUID(t)
+		_, err := repo.Save(context.Background(), client)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		ids = append(ids, client.ID)
+	}
+	sessions := []clients.Session{
+		// The client connected to the live instance stays online.
+		{ID: testsutil.GenerateUUID(t), ClientID: ids[0], Protocol: "mqtt", Instance: live, StartedAt: now.Add(-time.Hour)},
+		// The client connected to both instances stays online.
+		{ID: testsutil.GenerateUUID(t), ClientID: ids[1], Protocol: "mqtt", Instance: live, StartedAt: now.Add(-time.Hour)},
+		{ID: testsutil.GenerateUUID(t), ClientID: ids[1], Protocol: "ws", Instance: stopped, StartedAt: now.Add(-time.Hour)},
+		// The client connected only to the stopped instance is marked offline.
+		{ID: testsutil.GenerateUUID(t), ClientID: ids[2], Protocol: "ws", Instance: stopped, StartedAt: now.Add(-time.Hour)},
+	}
+	for _, s := range sessions {
+		_, err := repo.SaveSession(context.Background(), s)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	}
+
+	ps, err := repo.RemoveExpiredSessions(context.Background(), now.Add(-time.Minute))
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.Len(t, ps, 1)
+	assert.Equal(t, ids[2], ps[0].ClientID)
+	assert.Equal(t, clients.OfflinePresence, ps[0].Status)
+
+	for i, status := range []clients.PresenceStatus{clients.OnlinePresence, clients.OnlinePresence, clients.OfflinePresence} {
+		p, err := repo.RetrievePresence(context.Background(), ids[i])
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		assert.Equal(t, status, p.Status)
+	}
+
+	_, err = repo.RemoveSession(context.Background(), sessions[2], now)
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("expected %s got %s\n", repoerr.ErrNotFound, err))
+}
+
+func TestRetrievePresence(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	client := validClient
+	client.ID = testsutil.GenerateUUID(t)
+	client.Credentials.Secret = testsutil.GenerateUUID(t)
+	_, err := repo.Save(context.Background(), client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+
+	p, err := repo.RetrievePresence(context.Background(), client.ID)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	assert.Equal(t, clients.Presence{ClientID: client.ID, Status: clients.OfflinePresence}, p)
+
+	cli, err := repo.RetrieveByID(context.Background(), client.ID)
+	require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+	require.NotNil(t, cli.Presence)
+	assert.Equal(t, clients.OfflinePresence, cli.Presence.Status)
+}
+
+func TestRetrieveAllByPresence(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM clients")
+		require.Nil(t, err, fmt.Sprintf("clean clients unexpected error: %s", err))
+	})
+	repo := postgres.NewRepository(database)
+
+	var online, offline []string
+	for i := 0; i < 10; i++ {
+		client := validClient
+		client.ID = testsutil.GenerateUUID(t)
+		client.Name = namegen.Generate()
+		client.Credentials.Secret = testsutil.GenerateUUID(t)
+		_, err := repo.Save(context.Background(), client)
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		if i%2 == 0 {
+			offline = append(offline, client.ID)
+			continue
+		}
+		_, err = repo.SaveSession(context.Background(), clients.Session{
+			ID:        testsutil.GenerateUUID(t),
+			ClientID:  client.ID,
+			Protocol:  "ws",
+			Instance:  testsutil.GenerateUUID(t),
+			StartedAt: time.Now().UTC(),
+		})
+		require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+		online = append(online, client.ID)
+	}
+
+	cases := []struct {
+		desc     string
+		presence clients.PresenceStatus
+		response []string
+	}{
+		{
+			desc:     "retrieve online clients",
+			presence: clients.OnlinePresence,
+			response: online,
+		},
+		{
+			desc:     "retrieve offline clients",
+			presence: clients.OfflinePresence,
+			response: offline,
+		},
+		{
+			desc:     "retrieve all clients",
+			presence: clients.AllPresence,
+			response: append(append([]string{}, online...), offline...),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), clients.Page{Limit: 100, Presence: tc.presence})
+			require.Nil(t, err, fmt.Sprintf("unexpected error: %s", err))
+			var ids []string
+			for _, cli := range page.Clients {
+				ids = append(ids, cli.ID)
+				require.NotNil(t, cli.Presence)
+				if tc.presence != clients.AllPresence {
+					assert.Equal(t, tc.presence, cli.Presence.Status)
+				}
+			}
+			assert.ElementsMatch(t, tc.response, ids)
+			assert.Equal(t, uint64(len(tc.response)), page.Total)
+		})
+	}
+}
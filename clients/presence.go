@@ -0,0 +1,123 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+)
+
+// PresenceStatus represents the connection state of a client on the
+// messaging adapters.
+type PresenceStatus uint8
+
+// Possible client presence values.
+const (
+	// AllPresence is used for querying purposes to list clients irrespective
+	// of their presence. It is never stored in the database.
+	AllPresence PresenceStatus = iota
+	// OfflinePresence represents a client without an open session.
+	OfflinePresence
+	// OnlinePresence represents a client with an open session.
+	OnlinePresence
+)
+
+// String representation of the possible presence values.
+const (
+	Offline = "offline"
+	Online  = "online"
+)
+
+// String converts client presence to string literal.
+func (p PresenceStatus) String() string {
+	switch p {
+	case OfflinePresence:
+		return Offline
+	case OnlinePresence:
+		return Online
+	case AllPresence:
+		return All
+	default:
+		return Unknown
+	}
+}
+
+// ToPresenceStatus converts string value to a valid client presence.
+func ToPresenceStatus(presence string) (PresenceStatus, error) {
+	switch presence {
+	case "", All:
+		return AllPresence, nil
+	case Offline:
+		return OfflinePresence, nil
+	case Online:
+		return OnlinePresence, nil
+	}
+	return AllPresence, svcerr.ErrInvalidPresence
+}
+
+// MarshalJSON is a custom marshaller for client presence.
+func (p PresenceStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON is a custom unmarshaler for client presence.
+func (p *PresenceStatus) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(string(data), "\"")
+	val, err := ToPresenceStatus(str)
+	*p = val
+	return err
+}
+
+// Presence represents the state of the latest session a client opened on
+// one of the messaging adapters.
+type Presence struct {
+	ClientID     string         `json:"-"`
+	Status       PresenceStatus `json:"status"`
+	SessionID    string         `json:"-"`
+	Protocol     string         `json:"protocol,omitempty"`
+	RemoteAddr   string         `json:"remote_addr,omitempty"`
+	SessionStart *time.Time     `json:"session_start,omitempty"`
+	LastSeen     *time.Time     `json:"last_seen,omitempty"`
+}
+
+// Session represents a session a client opened on an instance of one of the
+// messaging adapters.
+type Session struct {
+	ID         string
+	ClientID   string
+	Protocol   string
+	Instance   string
+	RemoteAddr string
+	StartedAt  time.Time
+}
+
+// PresenceRepository specifies the persistence API of client presence.
+//
+//go:generate mockery --name PresenceRepository --output=./mocks --filename presence.go --quiet --note "Copyright (c) Abstract Machines"
+type PresenceRepository interface {
+	// RetrievePresence retrieves the presence of the client. Clients which
+	// never connected are reported as offline.
+	RetrievePresence(ctx context.Context, clientID string) (Presence, error)
+
+	// SaveSession stores the session opened by the client and marks the
+	// client online with the session as its latest session.
+	SaveSession(ctx context.Context, s Session) (Presence, error)
+
+	// RemoveSession removes the closed session and marks the client offline
+	// if it has no other open sessions. The repository ErrNotFound is
+	// returned if the session is not stored.
+	RemoveSession(ctx context.Context, s Session, closedAt time.Time) (Presence, error)
+
+	// SaveHeartbeat stores the time the adapter instance was last seen.
+	SaveHeartbeat(ctx context.Context, instance string, seenAt time.Time) error
+
+	// RemoveExpiredSessions removes the sessions of the adapter instances
+	// which were not seen since the given time and marks offline the clients
+	// left without open sessions, returning their presence.
+	RemoveExpiredSessions(ctx context.Context, seenBefore time.Time) ([]Presence, error)
+}
@@ -0,0 +1,96 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package clients_test
+
+import (
+	"testing"
+
+	"github.com/absmach/supermq/clients"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPresenceStatusString(t *testing.T) {
+	cases := []struct {
+		desc     string
+		presence clients.PresenceStatus
+		expected string
+	}{
+		{
+			desc:     "Online",
+			presence: clients.OnlinePresence,
+			expected: "online",
+		},
+		{
+			desc:     "Offline",
+			presence: clients.OfflinePresence,
+			expected: "offline",
+		},
+		{
+			desc:     "All",
+			presence: clients.AllPresence,
+			expected: "all",
+		},
+		{
+			desc:     "Unknown",
+			presence: clients.PresenceStatus(100),
+			expected: "unknown",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := tc.presence.String()
+			assert.Equal(t, tc.expected, got, "String() = %v, expected %v", got, tc.expected)
+		})
+	}
+}
+
+func TestToPresenceStatus(t *testing.T) {
+	cases := []struct {
+		desc     string
+		presence string
+		expected clients.PresenceStatus
+		err      error
+	}{
+		{
+			desc:     "Online",
+			presence: "online",
+			expected: clients.OnlinePresence,
+			err:      nil,
+		},
+		{
+			desc:     "Offline",
+			presence: "offline",
+			expected: clients.OfflinePresence,
+			err:      nil,
+		},
+		{
+			desc:     "All",
+			presence: "all",
+			expected: clients.AllPresence,
+			err:      nil,
+		},
+		{
+			desc:     "Empty",
+			presence: "",
+			expected: clients.AllPresence,
+			err:      nil,
+		},
+		{
+			desc:     "Unknown",
+			presence: "unknown",
+			expected: clients.AllPresence,
+			err:      svcerr.ErrInvalidPresence,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := clients.ToPresenceStatus(tc.presence)
+			assert.Equal(t, tc.err, err, "ToPresenceStatus() error = %v, expected %v", err, tc.err)
+			assert.Equal(t, tc.expected, got, "ToPresenceStatus() = %v, expected %v", got, tc.expected)
+		})
+	}
+}
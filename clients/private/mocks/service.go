@@ -5,9 +5,10 @@
 package mocks
 
 import (
-	context "context"
-
 	clients "github.com/absmach/supermq/clients"
+	authn "github.com/absmach/supermq/pkg/authn"
+
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -35,9 +36,9 @@ func (_m *Service) AddConnections(ctx context.Context, conns []clients.Connectio
 	return r0
 }
 
-// Authenticate provides a mock function with given fields: ctx, key
-func (_m *Service) Authenticate(ctx context.Context, key string) (string, error) {
-	ret := _m.Called(ctx, key)
+// Authenticate provides a mock function with given fields: ctx, id, key
+func (_m *Service) Authenticate(ctx context.Context, id string, key string) (string, error) {
+	ret := _m.Called(ctx, id, key)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Authenticate")
@@ -45,17 +46,54 @@ func (_m *Service) Authenticate(ctx context.Context, key string) (string, error)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
-		return rf(ctx, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, id, key)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
-		r0 = rf(ctx, key)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, id, key)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, key)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClients provides a mock function with given fields: ctx, session, cls
+func (_m *Service) CreateClients(ctx context.Context, session authn.Session, cls ...clients.Client) ([]clients.Client, error) {
+	_va := make([]interface{}, len(cls))
+	for _i := range cls {
+		_va[_i] = cls[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, session)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClients")
+	}
+
+	var r0 []clients.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, ...clients.Client) ([]clients.Client, error)); ok {
+		return rf(ctx, session, cls...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, ...clients.Client) []clients.Client); ok {
+		r0 = rf(ctx, session, cls...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]clients.Client)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session, ...clients.Client) error); ok {
+		r1 = rf(ctx, session, cls...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -81,6 +119,31 @@ func (_m *Service) RemoveChannelConnections(ctx context.Context, channelID strin
 	return r0
 }
 
+// RemoveClients provides a mock function with given fields: ctx, session, ids
+func (_m *Service) RemoveClients(ctx context.Context, session authn.Session, ids ...string) error {
+	_va := make([]interface{}, len(ids))
+	for _i := range ids {
+		_va[_i] = ids[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, session)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveClients")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, ...string) error); ok {
+		r0 = rf(ctx, session, ids...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveConnections provides a mock function with given fields: ctx, conns
 func (_m *Service) RemoveConnections(ctx context.Context, conns []clients.Connection) error {
 	ret := _m.Called(ctx, conns)
@@ -5,17 +5,24 @@ package private
 
 import (
 	"context"
+	"time"
 
+	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/roles"
 )
 
 //go:generate mockery --name Service  --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
 type Service interface {
-	// Authenticate returns client ID for given client key.
-	Authenticate(ctx context.Context, key string) (string, error)
+	// Authenticate returns client ID for given client key. The failed
+	// attempts are tracked per request source and, if the client ID is
+	// provided, per client, which are locked out after too many of them.
+	Authenticate(ctx context.Context, id, key string) (string, error)
 
 	RetrieveById(ctx context.Context, id string) (clients.Client, error)
 
@@ -28,27 +35,97 @@ type Service interface {
 	RemoveChannelConnections(ctx context.Context, channelID string) error
 
 	UnsetParentGroupFromClient(ctx context.Context, parentGroupID string) error
+
+	// CreateClients creates the clients in the session domain, provisions
+	// their built-in roles with the session user as the administrator and
+	// assigns the parent groups. It is either applied completely or not at all.
+	CreateClients(ctx context.Context, session authn.Session, cls ...clients.Client) ([]clients.Client, error)
+
+	// RemoveClients removes the clients together with their roles and
+	// policies. It is used to roll back the clients created by CreateClients.
+	RemoveClients(ctx context.Context, session authn.Session, ids ...string) error
 }
 
 var _ Service = (*service)(nil)
 
-func New(repo clients.Repository, cache clients.Cache, evaluator policies.Evaluator, policy policies.Service) Service {
+var errClientMismatch = errors.New("client secret does not belong to the client")
+
+func New(repo clients.Repository, cache clients.Cache, evaluator policies.Evaluator, policy policies.Service, tracker lockout.Tracker, idProvider supermq.IDProvider, provisioner roles.Provisioner) Service {
 	return service{
-		repo:      repo,
-		cache:     cache,
-		evaluator: evaluator,
-		policy:    policy,
+		repo:        repo,
+		cache:       cache,
+		evaluator:   evaluator,
+		policy:      policy,
+		lockout:     tracker,
+		idProvider:  idProvider,
+		provisioner: provisioner,
 	}
 }
 
 type service struct {
-	repo      clients.Repository
-	cache     clients.Cache
-	evaluator policies.Evaluator
-	policy    policies.Service
+	repo        clients.Repository
+	cache       clients.Cache
+	evaluator   policies.Evaluator
+	policy      policies.Service
+	lockout     lockout.Tracker
+	idProvider  supermq.IDProvider
+	provisioner roles.Provisioner
+}
+
+func (svc service) Authenticate(ctx context.Context, id, key string) (string, error) {
+	keys := lockoutKeys(ctx, id)
+	if len(keys) > 0 {
+		if err := svc.lockout.Check(ctx, keys...); err != nil {
+			return "", errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+	}
+	clientID, err := svc.authenticate(ctx, key)
+	if err == nil && id != "" && clientID != id {
+		err = errors.Wrap(svcerr.ErrAuthorization, errClientMismatch)
+	}
+	if err != nil {
+		if len(keys) == 0 {
+			return "", err
+		}
+		locked, lerr := svc.lockout.Fail(ctx, keys...)
+		switch {
+		case lerr != nil:
+			return "", errors.Wrap(err, lerr)
+		case len(locked) > 0:
+			return "", errors.Wrap(lockout.ErrLockout, err)
+		default:
+			return "", err
+		}
+	}
+	// The source key is not reset, so the successful attempts with a known
+	// secret can't be used to keep guessing the secrets of other clients.
+	if id != "" {
+		if err := svc.lockout.Reset(ctx, lockout.Key(clients.LockoutClientKind, id)); err != nil {
+			return "", errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+	}
+
+	return clientID, nil
+}
+
+// lockoutKeys returns the failed attempts keys of the request source and the
+// client. The secret-only requests without the source are not tracked, since
+// a shared key would let any caller lock out all the others.
+func lockoutKeys(ctx context.Context, id string) []string {
+	source := lockout.Source(ctx)
+	switch {
+	case id == "" && source == "":
+		return nil
+	case id == "":
+		return []string{lockout.Key(lockout.SourceKind, source)}
+	case source == "":
+		return []string{lockout.Key(clients.LockoutClientKind, id)}
+	default:
+		return []string{lockout.Key(lockout.SourceKind, source), lockout.Key(clients.LockoutClientKind, id)}
+	}
 }
 
-func (svc service) Authenticate(ctx context.Context, key string) (string, error) {
+func (svc service) authenticate(ctx context.Context, key string) (string, error) {
 	id, err := svc.cache.ID(ctx, key)
 	if err == nil {
 		return id, nil
@@ -58,6 +135,21 @@ func (svc service) Authenticate(ctx context.Context, key string) (string, error)
 	if err != nil {
 		return "", errors.Wrap(svcerr.ErrAuthorization, err)
 	}
+
+	// While the secret is being rotated, both the current and the pending
+	// secret are accepted until the current one expires.
+	if client.Credentials.PendingSecret != "" && key == client.Credentials.Secret {
+		expiresAt := client.Credentials.SecretExpiresAt
+		if expiresAt == nil || !time.Now().Before(*expiresAt) {
+			return "", errors.Wrap(svcerr.ErrAuthorization, clients.ErrSecretExpired)
+		}
+		if err := svc.cache.SaveWithExpiry(ctx, key, client.ID, *expiresAt); err != nil {
+			return "", errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+
+		return client.ID, nil
+	}
+
 	if err := svc.cache.Save(ctx, key, client.ID); err != nil {
 		return "", errors.Wrap(svcerr.ErrAuthorization, err)
 	}
@@ -120,3 +212,118 @@ func (svc service) UnsetParentGroupFromClient(ctx context.Context, parentGroupID
 	}
 	return nil
 }
+
+func (svc service) CreateClients(ctx context.Context, session authn.Session, cls ...clients.Client) (retClients []clients.Client, retErr error) {
+	var reClients []clients.Client
+	for _, c := range cls {
+		if c.ID == "" {
+			clientID, err := svc.idProvider.ID()
+			if err != nil {
+				return []clients.Client{}, err
+			}
+			c.ID = clientID
+		}
+		if c.Credentials.Secret == "" {
+			key, err := svc.idProvider.ID()
+			if err != nil {
+				return []clients.Client{}, err
+			}
+			c.Credentials.Secret = key
+		}
+		if c.Status != clients.DisabledStatus && c.Status != clients.EnabledStatus {
+			return []clients.Client{}, svcerr.ErrInvalidStatus
+		}
+		c.Domain = session.DomainID
+		c.CreatedAt = time.Now()
+		reClients = append(reClients, c)
+	}
+
+	newClients, err := svc.repo.Save(ctx, reClients...)
+	if err != nil {
+		return []clients.Client{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	ids := []string{}
+	for _, c := range newClients {
+		ids = append(ids, c.ID)
+	}
+
+	defer func() {
+		if retErr != nil {
+			if errRollBack := svc.repo.Delete(ctx, ids...); errRollBack != nil {
+				retErr = errors.Wrap(retErr, errors.Wrap(svcerr.ErrRollbackRepo, errRollBack))
+			}
+		}
+	}()
+
+	newBuiltInRoleMembers := map[roles.BuiltInRoleName][]roles.Member{
+		clients.ClientBuiltInRoleAdmin: {roles.Member(session.UserID)},
+	}
+
+	optionalPolicies := []policies.Policy{}
+	for _, c := range newClients {
+		optionalPolicies = append(optionalPolicies, policies.Policy{
+			Domain:      session.DomainID,
+			SubjectType: policies.DomainType,
+			Subject:     session.DomainID,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ClientType,
+			Object:      c.ID,
+		})
+		if c.ParentGroup != "" {
+			optionalPolicies = append(optionalPolicies, policies.Policy{
+				Domain:      session.DomainID,
+				SubjectType: policies.GroupType,
+				Subject:     c.ParentGroup,
+				Relation:    policies.ParentGroupRelation,
+				ObjectType:  policies.ClientType,
+				Object:      c.ID,
+			})
+		}
+	}
+
+	if _, err := svc.provisioner.AddNewEntitiesRoles(ctx, session.DomainID, session.UserID, ids, optionalPolicies, newBuiltInRoleMembers); err != nil {
+		return []clients.Client{}, errors.Wrap(svcerr.ErrAddPolicies, err)
+	}
+
+	return newClients, nil
+}
+
+func (svc service) RemoveClients(ctx context.Context, session authn.Session, ids ...string) error {
+	filterDeletePolicies := []policies.Policy{}
+	deletePolicies := []policies.Policy{}
+	for _, id := range ids {
+		filterDeletePolicies = append(filterDeletePolicies,
+			policies.Policy{
+				SubjectType: policies.ClientType,
+				Subject:     id,
+			},
+			policies.Policy{
+				ObjectType: policies.ClientType,
+				Object:     id,
+			},
+		)
+		deletePolicies = append(deletePolicies, policies.Policy{
+			SubjectType: policies.DomainType,
+			Subject:     session.DomainID,
+			Relation:    policies.DomainRelation,
+			ObjectType:  policies.ClientType,
+			Object:      id,
+		})
+	}
+
+	if err := svc.provisioner.RemoveEntitiesRoles(ctx, session.DomainID, session.UserID, ids, filterDeletePolicies, deletePolicies); err != nil {
+		return errors.Wrap(svcerr.ErrDeletePolicies, err)
+	}
+
+	for _, id := range ids {
+		if err := svc.cache.Remove(ctx, id); err != nil {
+			return errors.Wrap(svcerr.ErrRemoveEntity, err)
+		}
+	}
+
+	if err := svc.repo.Delete(ctx, ids...); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
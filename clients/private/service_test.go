@@ -0,0 +1,160 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package private_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/clients"
+	climocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/clients/private"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	validID      = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
+	validSecret  = "validSecret"
+	wrongSecret  = "wrongSecret"
+	sourceIP     = "10.0.0.7"
+	otherIP      = "10.0.0.8"
+	anotherIP    = "10.0.0.9"
+	maxAttempts  = 3
+	lockDuration = time.Hour
+)
+
+type authnTestCase struct {
+	desc   string
+	ctx    context.Context
+	id     string
+	secret string
+	err    error
+}
+
+func TestAuthenticateLockout(t *testing.T) {
+	cfg := lockout.Config{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+		Duration:    lockDuration,
+		Window:      time.Hour,
+	}
+	repo := new(climocks.Repository)
+	cache := new(climocks.Cache)
+	svc := private.New(repo, cache, nil, nil, lockout.NewMemoryTracker(cfg), nil, nil)
+
+	cacheCall := cache.On("ID", mock.Anything, mock.Anything).Return("", repoerr.ErrNotFound)
+	cacheCall1 := cache.On("Save", mock.Anything, validSecret, validID).Return(nil)
+	repoCall := repo.On("RetrieveBySecret", mock.Anything, validSecret).Return(clients.Client{ID: validID}, nil)
+	repoCall1 := repo.On("RetrieveBySecret", mock.Anything, wrongSecret).Return(clients.Client{}, repoerr.ErrNotFound)
+	defer func() {
+		cacheCall.Unset()
+		cacheCall1.Unset()
+		repoCall.Unset()
+		repoCall1.Unset()
+	}()
+
+	source := lockout.WithSource(context.Background(), sourceIP)
+	otherSource := lockout.WithSource(context.Background(), otherIP)
+	anotherSource := lockout.WithSource(context.Background(), anotherIP)
+
+	cases := []authnTestCase{
+		{
+			desc:   "authenticate with wrong secret",
+			ctx:    source,
+			secret: wrongSecret,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "authenticate with wrong secret again",
+			ctx:    source,
+			secret: wrongSecret,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "authenticate with wrong secret reaching max attempts",
+			ctx:    source,
+			secret: wrongSecret,
+			err:    lockout.ErrLockout,
+		},
+		{
+			desc:   "authenticate from locked out source",
+			ctx:    source,
+			secret: validSecret,
+			err:    lockout.ErrLocked,
+		},
+		{
+			desc:   "authenticate from other source",
+			ctx:    otherSource,
+			secret: validSecret,
+			err:    nil,
+		},
+		{
+			desc:   "authenticate client with wrong secret",
+			ctx:    otherSource,
+			id:     validID,
+			secret: wrongSecret,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "authenticate client with wrong secret again",
+			ctx:    anotherSource,
+			id:     validID,
+			secret: wrongSecret,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "authenticate client with wrong secret reaching max attempts",
+			ctx:    anotherSource,
+			id:     validID,
+			secret: wrongSecret,
+			err:    lockout.ErrLockout,
+		},
+		{
+			desc:   "authenticate locked out client",
+			ctx:    lockout.WithSource(context.Background(), "10.0.0.10"),
+			id:     validID,
+			secret: validSecret,
+			err:    lockout.ErrLocked,
+		},
+		{
+			desc:   "authenticate without source",
+			ctx:    context.Background(),
+			secret: validSecret,
+			err:    nil,
+		},
+	}
+	for i := 0; i < maxAttempts+1; i++ {
+		cases = append(cases, authnTestCase{
+			desc:   fmt.Sprintf("authenticate with wrong secret without source %d", i+1),
+			ctx:    context.Background(),
+			secret: wrongSecret,
+			err:    svcerr.ErrAuthorization,
+		})
+	}
+	cases = append(cases, authnTestCase{
+		desc:   "authenticate without source after other caller failures without source",
+		ctx:    context.Background(),
+		secret: validSecret,
+		err:    nil,
+	})
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			time.Sleep(2 * cfg.MaxDelay)
+			id, err := svc.Authenticate(tc.ctx, tc.id, tc.secret)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, validID, id)
+			}
+		})
+	}
+}
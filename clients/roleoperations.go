@@ -15,8 +15,11 @@ const (
 	OpUpdateClient
 	OpUpdateClientTags
 	OpUpdateClientSecret
+	OpRotateClientSecret
+	OpConfirmClientSecret
 	OpEnableClient
 	OpDisableClient
+	OpUnlockClient
 	OpDeleteClient
 	OpSetParentGroup
 	OpRemoveParentGroup
@@ -29,8 +32,11 @@ var expectedOperations = []svcutil.Operation{
 	OpUpdateClient,
 	OpUpdateClientTags,
 	OpUpdateClientSecret,
+	OpRotateClientSecret,
+	OpConfirmClientSecret,
 	OpEnableClient,
 	OpDisableClient,
+	OpUnlockClient,
 	OpDeleteClient,
 	OpSetParentGroup,
 	OpRemoveParentGroup,
@@ -43,8 +49,11 @@ var operationNames = []string{
 	"OpUpdateClient",
 	"OpUpdateClientTags",
 	"OpUpdateClientSecret",
+	"OpRotateClientSecret",
+	"OpConfirmClientSecret",
 	"OpEnableClient",
 	"OpDisableClient",
+	"OpUnlockClient",
 	"OpDeleteClient",
 	"OpSetParentGroup",
 	"OpRemoveParentGroup",
@@ -109,8 +118,11 @@ func NewOperationPermissionMap() map[svcutil.Operation]svcutil.Permission {
 		OpUpdateClient:          updatePermission,
 		OpUpdateClientTags:      updatePermission,
 		OpUpdateClientSecret:    updatePermission,
+		OpRotateClientSecret:    updatePermission,
+		OpConfirmClientSecret:   updatePermission,
 		OpEnableClient:          updatePermission,
 		OpDisableClient:         updatePermission,
+		OpUnlockClient:          updatePermission,
 		OpDeleteClient:          deletePermission,
 		OpSetParentGroup:        setParentGroupPermission,
 		OpRemoveParentGroup:     setParentGroupPermission,
@@ -5,6 +5,7 @@ package clients
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	smq "github.com/absmach/supermq"
@@ -14,7 +15,9 @@ import (
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/pkg/roles"
 )
@@ -23,6 +26,12 @@ var (
 	errRollbackRepo   = errors.New("failed to rollback repo")
 	errSetParentGroup = errors.New("client already have parent")
 )
+
+// patPrefix is the prefix of the personal access tokens. The protocol adapters
+// authenticate the passwords with the prefix as tokens, so client secrets
+// can't start with it.
+const patPrefix = "pat_"
+
 var _ Service = (*service)(nil)
 
 type service struct {
@@ -32,11 +41,14 @@ type service struct {
 	groups     grpcGroupsV1.GroupsServiceClient
 	cache      Cache
 	idProvider smq.IDProvider
+	lockout    lockout.Tracker
 	roles.ProvisionManageService
 }
 
-// NewService returns a new Clients service implementation.
-func NewService(repo Repository, policy policies.Service, cache Cache, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, idProvider smq.IDProvider, sIDProvider smq.IDProvider) (Service, error) {
+// NewService returns a new Clients service implementation. The tracker is
+// shared with the private service, which records the failed authentication
+// attempts, so the locked out clients can be unlocked.
+func NewService(repo Repository, policy policies.Service, cache Cache, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, idProvider smq.IDProvider, sIDProvider smq.IDProvider, tracker lockout.Tracker) (Service, error) {
 	rpms, err := roles.NewProvisionManageService(policies.ClientType, repo, policy, sIDProvider, AvailableActions(), BuiltInRoles())
 	if err != nil {
 		return service{}, err
@@ -48,6 +60,7 @@ func NewService(repo Repository, policy policies.Service, cache Cache, channels
 		groups:                 groups,
 		cache:                  cache,
 		idProvider:             idProvider,
+		lockout:                tracker,
 		ProvisionManageService: rpms,
 	}, nil
 }
@@ -69,6 +82,9 @@ func (svc service) CreateClients(ctx context.Context, session authn.Session, cls
 			}
 			c.Credentials.Secret = key
 		}
+		if err := validateSecret(c.Credentials.Secret); err != nil {
+			return []Client{}, []roles.RoleProvision{}, err
+		}
 		if c.Status != DisabledStatus && c.Status != EnabledStatus {
 			return []Client{}, []roles.RoleProvision{}, svcerr.ErrInvalidStatus
 		}
@@ -184,6 +200,9 @@ func (svc service) UpdateTags(ctx context.Context, session authn.Session, cli Cl
 }
 
 func (svc service) UpdateSecret(ctx context.Context, session authn.Session, id, key string) (Client, error) {
+	if err := validateSecret(key); err != nil {
+		return Client{}, err
+	}
 	client := Client{
 		ID: id,
 		Credentials: Credentials{
@@ -194,9 +213,86 @@ func (svc service) UpdateSecret(ctx context.Context, session authn.Session, id,
 		Status:    EnabledStatus,
 	}
 	client, err := svc.repo.UpdateSecret(ctx, client)
+	switch {
+	case errors.Contains(err, repoerr.ErrConflict):
+		return Client{}, errors.Wrap(svcerr.ErrConflict, err)
+	case err != nil:
+		return Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	return client, nil
+}
+
+func (svc service) RotateSecret(ctx context.Context, session authn.Session, id, key string, duration time.Duration) (Client, error) {
+	if key == "" {
+		k, err := svc.idProvider.ID()
+		if err != nil {
+			return Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		key = k
+	}
+	if err := validateSecret(key); err != nil {
+		return Client{}, err
+	}
+
+	c, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	// Overwriting the pending secret would revoke it while it may already
+	// be in use, so it has to be confirmed first.
+	if c.Credentials.PendingSecret != "" && c.Credentials.PendingSecret != key {
+		return Client{}, errors.Wrap(svcerr.ErrConflict, ErrPendingSecret)
+	}
+
+	expiresAt := time.Now().Add(duration)
+	client := Client{
+		ID: id,
+		Credentials: Credentials{
+			PendingSecret:   key,
+			SecretExpiresAt: &expiresAt,
+		},
+		UpdatedAt: time.Now(),
+		UpdatedBy: session.UserID,
+	}
+	client, err = svc.repo.UpdatePendingSecret(ctx, client)
+	switch {
+	case errors.Contains(err, repoerr.ErrConflict):
+		return Client{}, errors.Wrap(svcerr.ErrConflict, err)
+	case err != nil:
+		return Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	// The current secret may be cached beyond its new expiry.
+	if err := svc.cache.Remove(ctx, id); err != nil {
+		return client, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return client, nil
+}
+
+func (svc service) ConfirmSecret(ctx context.Context, session authn.Session, id string) (Client, error) {
+	client, err := svc.repo.RetrieveByID(ctx, id)
+	if err != nil {
+		return Client{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if client.Credentials.PendingSecret == "" {
+		return Client{}, errors.Wrap(svcerr.ErrConflict, ErrNoPendingSecret)
+	}
+
+	client = Client{
+		ID:        id,
+		UpdatedAt: time.Now(),
+		UpdatedBy: session.UserID,
+	}
+	client, err = svc.repo.ConfirmSecret(ctx, client)
 	if err != nil {
 		return Client{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
 	}
+
+	if err := svc.cache.Remove(ctx, id); err != nil {
+		return client, errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
 	return client, nil
 }
 
@@ -232,6 +328,17 @@ func (svc service) Disable(ctx context.Context, session authn.Session, id string
 	return client, nil
 }
 
+func (svc service) Unlock(ctx context.Context, session authn.Session, id string) error {
+	if _, err := svc.repo.RetrieveByID(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if err := svc.lockout.Reset(ctx, lockout.Key(LockoutClientKind, id)); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
 func (svc service) SetParentGroup(ctx context.Context, session authn.Session, parentGroupID string, id string) (retErr error) {
 	cli, err := svc.repo.RetrieveByID(ctx, id)
 	if err != nil {
@@ -390,3 +497,11 @@ func (svc service) changeClientStatus(ctx context.Context, session authn.Session
 	}
 	return client, nil
 }
+
+func validateSecret(secret string) error {
+	if strings.HasPrefix(secret, patPrefix) {
+		return errors.Wrap(svcerr.ErrMalformedEntity, ErrReservedSecret)
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
@@ -20,6 +21,8 @@ import (
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
+	lockoutmocks "github.com/absmach/supermq/pkg/lockout/mocks"
 	policysvc "github.com/absmach/supermq/pkg/policies"
 	policymocks "github.com/absmach/supermq/pkg/policies/mocks"
 	"github.com/absmach/supermq/pkg/roles"
@@ -51,6 +54,7 @@ var (
 	repo         *climocks.Repository
 	chgRPCClient *chmocks.ChannelsServiceClient
 	gpgRPCClient *gpmocks.GroupsServiceClient
+	tracker      *lockoutmocks.Tracker
 )
 
 func newService() clients.Service {
@@ -61,7 +65,8 @@ func newService() clients.Service {
 	repo = new(climocks.Repository)
 	chgRPCClient = new(chmocks.ChannelsServiceClient)
 	gpgRPCClient = new(gpmocks.GroupsServiceClient)
-	tsv, _ := clients.NewService(repo, pService, cache, chgRPCClient, gpgRPCClient, idProvider, sidProvider)
+	tracker = new(lockoutmocks.Tracker)
+	tsv, _ := clients.NewService(repo, pService, cache, chgRPCClient, gpgRPCClient, idProvider, sidProvider, tracker)
 	return tsv
 }
 
@@ -104,6 +109,18 @@ func TestCreateClients(t *testing.T) {
 			token: validToken,
 			err:   nil,
 		},
+		{
+			desc: "create a new client with personal access token prefix in secret",
+			client: clients.Client{
+				Name: "clientWithPATSecret",
+				Credentials: clients.Credentials{
+					Secret: "pat_secret",
+				},
+				Status: clients.EnabledStatus,
+			},
+			token: validToken,
+			err:   svcerr.ErrMalformedEntity,
+		},
 		{
 			desc: "create a new client without identity",
 			client: clients.Client{
@@ -658,6 +675,23 @@ func TestUpdateSecret(t *testing.T) {
 			updateErr:            repoerr.ErrMalformedEntity,
 			err:                  svcerr.ErrUpdateEntity,
 		},
+		{
+			desc:                 "update client secret with personal access token prefix",
+			client:               client,
+			newSecret:            "pat_secret",
+			session:              smqauthn.Session{UserID: validID},
+			updateSecretResponse: clients.Client{},
+			err:                  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:                 "update client secret with secret of another client",
+			client:               client,
+			newSecret:            "newSecret",
+			session:              smqauthn.Session{UserID: validID},
+			updateSecretResponse: clients.Client{},
+			updateErr:            repoerr.ErrConflict,
+			err:                  svcerr.ErrConflict,
+		},
 	}
 
 	for _, tc := range cases {
@@ -669,6 +703,211 @@ func TestUpdateSecret(t *testing.T) {
 	}
 }
 
+func TestRotateSecret(t *testing.T) {
+	svc := newService()
+
+	expiresAt := time.Now().Add(time.Hour)
+	rotatedClient := clients.Client{
+		ID: client.ID,
+		Credentials: clients.Credentials{
+			PendingSecret:   "newSecret",
+			SecretExpiresAt: &expiresAt,
+		},
+	}
+
+	cases := []struct {
+		desc                 string
+		id                   string
+		newSecret            string
+		duration             time.Duration
+		session              smqauthn.Session
+		retrieveByIDResponse clients.Client
+		retrieveByIDErr      error
+		updateResponse       clients.Client
+		updateErr            error
+		removeErr            error
+		err                  error
+	}{
+		{
+			desc:                 "rotate client secret successfully",
+			id:                   client.ID,
+			newSecret:            "newSecret",
+			duration:             time.Hour,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: client,
+			updateResponse:       rotatedClient,
+			err:                  nil,
+		},
+		{
+			desc:                 "rotate client secret with generated secret",
+			id:                   client.ID,
+			duration:             time.Hour,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: client,
+			updateResponse:       rotatedClient,
+			err:                  nil,
+		},
+		{
+			desc:                 "rotate client secret with the same pending secret",
+			id:                   client.ID,
+			newSecret:            "newSecret",
+			duration:             time.Hour,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: rotatedClient,
+			updateResponse:       rotatedClient,
+			err:                  nil,
+		},
+		{
+			desc:                 "rotate client secret with another secret pending",
+			id:                   client.ID,
+			newSecret:            "otherSecret",
+			duration:             time.Hour,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: rotatedClient,
+			err:                  clients.ErrPendingSecret,
+		},
+		{
+			desc:                 "rotate client secret with personal access token prefix",
+			id:                   client.ID,
+			newSecret:            "pat_secret",
+			duration:             time.Hour,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: client,
+			err:                  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:                 "rotate client secret with secret of another client",
+			id:                   client.ID,
+			newSecret:            "newSecret",
+			duration:             time.Hour,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: client,
+			updateErr:            repoerr.ErrConflict,
+			err:                  svcerr.ErrConflict,
+		},
+		{
+			desc:            "rotate client secret with failed to retrieve client",
+			id:              client.ID,
+			newSecret:       "newSecret",
+			duration:        time.Hour,
+			session:         smqauthn.Session{UserID: validID},
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+		{
+			desc:                 "rotate client secret with failed to update repo",
+			id:                   client.ID,
+			newSecret:            "newSecret",
+			duration:             time.Hour,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: client,
+			updateErr:            repoerr.ErrMalformedEntity,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                 "rotate client secret with failed to remove from cache",
+			id:                   client.ID,
+			newSecret:            "newSecret",
+			duration:             time.Hour,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: client,
+			updateResponse:       rotatedClient,
+			removeErr:            repoerr.ErrRemoveEntity,
+			err:                  svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := repo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+		repoCall1 := repo.On("UpdatePendingSecret", context.Background(), mock.Anything).Return(tc.updateResponse, tc.updateErr)
+		cacheCall := cache.On("Remove", context.Background(), tc.id).Return(tc.removeErr)
+		_, err := svc.RotateSecret(context.Background(), tc.session, tc.id, tc.newSecret, tc.duration)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		repoCall1.Unset()
+		cacheCall.Unset()
+	}
+}
+
+func TestConfirmSecret(t *testing.T) {
+	svc := newService()
+
+	rotatingClient := client
+	rotatingClient.Credentials.PendingSecret = "newSecret"
+	expiresAt := time.Now().Add(time.Hour)
+	rotatingClient.Credentials.SecretExpiresAt = &expiresAt
+	confirmedClient := clients.Client{
+		ID:          client.ID,
+		Credentials: clients.Credentials{Secret: "newSecret"},
+	}
+
+	cases := []struct {
+		desc                 string
+		id                   string
+		session              smqauthn.Session
+		retrieveByIDResponse clients.Client
+		retrieveByIDErr      error
+		confirmResponse      clients.Client
+		confirmErr           error
+		removeErr            error
+		err                  error
+	}{
+		{
+			desc:                 "confirm client secret successfully",
+			id:                   client.ID,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: rotatingClient,
+			confirmResponse:      confirmedClient,
+			err:                  nil,
+		},
+		{
+			desc:                 "confirm client secret without pending secret",
+			id:                   client.ID,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: client,
+			err:                  clients.ErrNoPendingSecret,
+		},
+		{
+			desc:            "confirm secret of non-existing client",
+			id:              wrongID,
+			session:         smqauthn.Session{UserID: validID},
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+		{
+			desc:                 "confirm client secret with failed to update repo",
+			id:                   client.ID,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: rotatingClient,
+			confirmErr:           repoerr.ErrNotFound,
+			err:                  svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:                 "confirm client secret with failed to remove from cache",
+			id:                   client.ID,
+			session:              smqauthn.Session{UserID: validID},
+			retrieveByIDResponse: rotatingClient,
+			confirmResponse:      confirmedClient,
+			removeErr:            repoerr.ErrRemoveEntity,
+			err:                  svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := repo.On("RetrieveByID", context.Background(), tc.id).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
+		repoCall1 := repo.On("ConfirmSecret", context.Background(), mock.Anything).Return(tc.confirmResponse, tc.confirmErr)
+		cacheCall := cache.On("Remove", context.Background(), tc.id).Return(tc.removeErr)
+		confirmedClient, err := svc.ConfirmSecret(context.Background(), tc.session, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		if err == nil {
+			assert.Equal(t, tc.confirmResponse, confirmedClient, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.confirmResponse, confirmedClient))
+		}
+		repoCall.Unset()
+		repoCall1.Unset()
+		cacheCall.Unset()
+	}
+}
+
 func TestEnable(t *testing.T) {
 	svc := newService()
 
@@ -822,6 +1061,49 @@ func TestDisable(t *testing.T) {
 	}
 }
 
+func TestUnlock(t *testing.T) {
+	svc := newService()
+
+	cases := []struct {
+		desc          string
+		id            string
+		session       smqauthn.Session
+		retrieveIDErr error
+		resetErr      error
+		err           error
+	}{
+		{
+			desc:    "unlock client",
+			id:      client.ID,
+			session: smqauthn.Session{UserID: validID},
+			err:     nil,
+		},
+		{
+			desc:          "unlock non-existing client",
+			id:            wrongID,
+			session:       smqauthn.Session{UserID: validID},
+			retrieveIDErr: repoerr.ErrNotFound,
+			err:           svcerr.ErrViewEntity,
+		},
+		{
+			desc:     "unlock client with failed to reset attempts",
+			id:       client.ID,
+			session:  smqauthn.Session{UserID: validID},
+			resetErr: errors.New("reset failed"),
+			err:      svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		repoCall := repo.On("RetrieveByID", context.Background(), tc.id).Return(client, tc.retrieveIDErr)
+		trackerCall := tracker.On("Reset", context.Background(), lockout.Key(clients.LockoutClientKind, tc.id)).Return(tc.resetErr)
+		err := svc.Unlock(context.Background(), tc.session, tc.id)
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		repoCall.Unset()
+		trackerCall.Unset()
+	}
+}
+
 func TestDelete(t *testing.T) {
 	svc := newService()
 
@@ -5,6 +5,7 @@ package tracing
 
 import (
 	"context"
+	"time"
 
 	"github.com/absmach/supermq/clients"
 	"github.com/absmach/supermq/pkg/authn"
@@ -86,6 +87,25 @@ func (tm *tracingMiddleware) UpdateSecret(ctx context.Context, session authn.Ses
 	return tm.svc.UpdateSecret(ctx, session, oldSecret, newSecret)
 }
 
+// RotateSecret traces the "RotateSecret" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) RotateSecret(ctx context.Context, session authn.Session, id, key string, duration time.Duration) (clients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_rotate_client_secret", trace.WithAttributes(
+		attribute.String("id", id),
+		attribute.String("duration", duration.String()),
+	))
+	defer span.End()
+
+	return tm.svc.RotateSecret(ctx, session, id, key, duration)
+}
+
+// ConfirmSecret traces the "ConfirmSecret" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) ConfirmSecret(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_confirm_client_secret", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.ConfirmSecret(ctx, session, id)
+}
+
 // Enable traces the "Enable" operation of the wrapped clients.Service.
 func (tm *tracingMiddleware) Enable(ctx context.Context, session authn.Session, id string) (clients.Client, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_enable_client", trace.WithAttributes(attribute.String("id", id)))
@@ -102,6 +122,14 @@ func (tm *tracingMiddleware) Disable(ctx context.Context, session authn.Session,
 	return tm.svc.Disable(ctx, session, id)
 }
 
+// Unlock traces the "Unlock" operation of the wrapped clients.Service.
+func (tm *tracingMiddleware) Unlock(ctx context.Context, session authn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_unlock_client", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.Unlock(ctx, session, id)
+}
+
 // Delete traces the "Delete" operation of the wrapped clients.Service.
 func (tm *tracingMiddleware) Delete(ctx context.Context, session authn.Session, id string) error {
 	ctx, span := tm.tracer.Start(ctx, "delete_client", trace.WithAttributes(attribute.String("id", id)))
@@ -22,6 +22,7 @@ import (
 	tokengrpcapi "github.com/absmach/supermq/auth/api/grpc/token"
 	httpapi "github.com/absmach/supermq/auth/api/http"
 	"github.com/absmach/supermq/auth/bolt"
+	"github.com/absmach/supermq/auth/events"
 	"github.com/absmach/supermq/auth/hasher"
 	"github.com/absmach/supermq/auth/jwt"
 	apostgres "github.com/absmach/supermq/auth/postgres"
@@ -58,11 +59,21 @@ const (
 	defDB          = "auth"
 	defSvcHTTPPort = "8189"
 	defSvcGRPCPort = "8181"
+
+	patRepoBolt     = "bolt"
+	patRepoPostgres = "postgres"
+	// migratePATsCmd is the command which copies the PATs from BoltDB
+	// to PostgreSQL and exits without starting the service.
+	migratePATsCmd = "migrate-pats"
 )
 
 type config struct {
 	LogLevel            string        `env:"SMQ_AUTH_LOG_LEVEL"               envDefault:"info"`
 	SecretKey           string        `env:"SMQ_AUTH_SECRET_KEY"              envDefault:"secret"`
+	JWTAlgorithm        string        `env:"SMQ_AUTH_JWT_ALGORITHM"           envDefault:"HS512"`
+	JWTKeysDir          string        `env:"SMQ_AUTH_JWT_KEYS_DIR"            envDefault:""`
+	JWTRotationInterval time.Duration `env:"SMQ_AUTH_JWT_ROTATION_INTERVAL"   envDefault:"720h"`
+	JWTGracePeriod      time.Duration `env:"SMQ_AUTH_JWT_GRACE_PERIOD"        envDefault:"48h"`
 	JaegerURL           url.URL       `env:"SMQ_JAEGER_URL"                   envDefault:"http://localhost:4318/v1/traces"`
 	SendTelemetry       bool          `env:"SMQ_SEND_TELEMETRY"               envDefault:"true"`
 	InstanceID          string        `env:"SMQ_AUTH_ADAPTER_INSTANCE_ID"     envDefault:""`
@@ -75,6 +86,10 @@ type config struct {
 	SpicedbPreSharedKey string        `env:"SMQ_SPICEDB_PRE_SHARED_KEY"       envDefault:"12345678"`
 	TraceRatio          float64       `env:"SMQ_JAEGER_TRACE_RATIO"           envDefault:"1.0"`
 	ESURL               string        `env:"SMQ_ES_URL"                       envDefault:"nats://localhost:4222"`
+	PATRepository       string        `env:"SMQ_AUTH_PAT_REPOSITORY"          envDefault:"bolt"`
+	PATCheckInterval    time.Duration `env:"SMQ_AUTH_PAT_CHECK_INTERVAL"      envDefault:"24h"`
+	PATNotifyBefore     time.Duration `env:"SMQ_AUTH_PAT_NOTIFY_BEFORE"       envDefault:"168h"`
+	PATRetention        time.Duration `env:"SMQ_AUTH_PAT_RETENTION"           envDefault:"720h"`
 }
 
 func main() {
@@ -129,6 +144,44 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
+	if cfg.PATRepository != patRepoBolt && cfg.PATRepository != patRepoPostgres {
+		logger.Error(fmt.Sprintf("invalid PAT repository %q, expected %s or %s", cfg.PATRepository, patRepoBolt, patRepoPostgres))
+		exitCode = 1
+		return
+	}
+
+	migrate := len(os.Args) > 1 && os.Args[1] == migratePATsCmd
+
+	var bClient *bbolt.DB
+	boltDBConfig := boltclient.Config{}
+	if cfg.PATRepository == patRepoBolt || migrate {
+		if err := env.ParseWithOptions(&boltDBConfig, env.Options{Prefix: envPrefixPATDB}); err != nil {
+			logger.Error(fmt.Sprintf("failed to parse bolt db config : %s\n", err.Error()))
+			exitCode = 1
+			return
+		}
+
+		bClient, err = boltclient.Connect(boltDBConfig, bolt.Init)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to connect to bolt db : %s\n", err.Error()))
+			exitCode = 1
+			return
+		}
+		defer bClient.Close()
+	}
+
+	if migrate {
+		patsRepo := apostgres.NewPATSRepository(pgclient.NewDatabase(db, dbConfig, tracer))
+		n, err := bolt.MigratePATs(ctx, bClient, boltDBConfig.Bucket, patsRepo)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to migrate PATs after %d copied PATs : %s", n, err))
+			exitCode = 1
+			return
+		}
+		logger.Info(fmt.Sprintf("migrated %d PATs from bolt db to postgres", n))
+		return
+	}
+
 	spicedbclient, err := initSpiceDB(ctx, cfg)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to init spicedb grpc client : %s\n", err.Error()))
@@ -136,22 +189,19 @@ func main() {
 		return
 	}
 
-	boltDBConfig := boltclient.Config{}
-	if err := env.ParseWithOptions(&boltDBConfig, env.Options{Prefix: envPrefixPATDB}); err != nil {
-		logger.Error(fmt.Sprintf("failed to parse bolt db config : %s\n", err.Error()))
+	tokenizer, err := newTokenizer(ctx, cfg, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create tokenizer : %s\n", err.Error()))
 		exitCode = 1
 		return
 	}
 
-	bClient, err := boltclient.Connect(boltDBConfig, bolt.Init)
+	svc, err := newService(ctx, db, tracer, cfg, dbConfig, logger, spicedbclient, bClient, boltDBConfig, tokenizer)
 	if err != nil {
-		logger.Error(fmt.Sprintf("failed to connect to bolt db : %s\n", err.Error()))
+		logger.Error(fmt.Sprintf("failed to create %s service : %s", svcName, err))
 		exitCode = 1
 		return
 	}
-	defer bClient.Close()
-
-	svc := newService(ctx, db, tracer, cfg, dbConfig, logger, spicedbclient, bClient, boltDBConfig)
 
 	grpcServerConfig := server.Config{Port: defSvcGRPCPort}
 	if err := env.ParseWithOptions(&grpcServerConfig, env.Options{Prefix: envPrefixGrpc}); err != nil {
@@ -231,23 +281,61 @@ func initSchema(ctx context.Context, client *authzed.ClientWithExperimental, sch
 	return nil
 }
 
-func newService(_ context.Context, db *sqlx.DB, tracer trace.Tracer, cfg config, dbConfig pgclient.Config, logger *slog.Logger, spicedbClient *authzed.ClientWithExperimental, bClient *bbolt.DB, bConfig boltclient.Config) auth.Service {
+func newService(ctx context.Context, db *sqlx.DB, tracer trace.Tracer, cfg config, dbConfig pgclient.Config, logger *slog.Logger, spicedbClient *authzed.ClientWithExperimental, bClient *bbolt.DB, bConfig boltclient.Config, t auth.Tokenizer) (auth.Service, error) {
 	database := pgclient.NewDatabase(db, dbConfig, tracer)
 	keysRepo := apostgres.New(database)
-	patsRepo := bolt.NewPATSRepository(bClient, bConfig.Bucket)
+	var patsRepo auth.PATSRepository
+	switch cfg.PATRepository {
+	case patRepoPostgres:
+		patsRepo = apostgres.NewPATSRepository(database)
+	default:
+		patsRepo = bolt.NewPATSRepository(bClient, bConfig.Bucket)
+	}
+	sessionsRepo := apostgres.NewSessionRepository(database)
 	hasher := hasher.New()
 	idProvider := uuid.New()
 
 	pEvaluator := spicedb.NewPolicyEvaluator(spicedbClient, logger)
 	pService := spicedb.NewPolicyService(spicedbClient, logger)
 
-	t := jwt.New([]byte(cfg.SecretKey))
-
-	svc := auth.New(keysRepo, patsRepo, hasher, idProvider, t, pEvaluator, pService, cfg.AccessDuration, cfg.RefreshDuration, cfg.InvitationDuration)
+	svc := auth.New(keysRepo, patsRepo, sessionsRepo, hasher, idProvider, t, pEvaluator, pService, cfg.AccessDuration, cfg.RefreshDuration, cfg.InvitationDuration)
 	svc = api.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics("auth", "api")
 	svc = api.MetricsMiddleware(svc, counter, latency)
 	svc = tracing.New(svc, tracer)
 
-	return svc
+	// The auth service doesn't keep the user email addresses, so the PAT
+	// expiry notifications are published as events, which the users service
+	// consumes to e-mail the PAT owners.
+	emailer, err := events.NewEmailer(ctx, nil, cfg.ESURL)
+	if err != nil {
+		return nil, err
+	}
+	auth.NewPATHandler(ctx, patsRepo, emailer, cfg.PATCheckInterval, cfg.PATNotifyBefore, cfg.PATRetention, logger)
+
+	return svc, nil
+}
+
+func newTokenizer(ctx context.Context, cfg config, logger *slog.Logger) (auth.Tokenizer, error) {
+	if cfg.JWTAlgorithm == jwt.HS512 {
+		return jwt.New([]byte(cfg.SecretKey)), nil
+	}
+	// Keys kept in memory are lost on restart and are not shared with the
+	// other instances, which would reject the tokens signed with them.
+	if cfg.JWTKeysDir == "" {
+		return nil, fmt.Errorf("SMQ_AUTH_JWT_KEYS_DIR is required for the %s token signing algorithm", cfg.JWTAlgorithm)
+	}
+
+	km, err := jwt.NewKeyManager(jwt.KeyManagerConfig{
+		Algorithm:        cfg.JWTAlgorithm,
+		KeysDir:          cfg.JWTKeysDir,
+		RotationInterval: cfg.JWTRotationInterval,
+		GracePeriod:      cfg.JWTGracePeriod,
+	})
+	if err != nil {
+		return nil, err
+	}
+	km.StartRotation(ctx, logger)
+
+	return jwt.NewAsymmetric(km), nil
 }
@@ -14,8 +14,10 @@ import (
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/supermq"
+	grpcCertsV1 "github.com/absmach/supermq/api/grpc/certs/v1"
 	"github.com/absmach/supermq/certs"
 	httpapi "github.com/absmach/supermq/certs/api"
+	grpcapi "github.com/absmach/supermq/certs/api/grpc"
 	pki "github.com/absmach/supermq/certs/pki/amcerts"
 	"github.com/absmach/supermq/certs/tracing"
 	smqlog "github.com/absmach/supermq/logger"
@@ -25,20 +27,25 @@ import (
 	"github.com/absmach/supermq/pkg/prometheus"
 	mgsdk "github.com/absmach/supermq/pkg/sdk"
 	"github.com/absmach/supermq/pkg/server"
+	grpcserver "github.com/absmach/supermq/pkg/server/grpc"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/caarlos0/env/v11"
 	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 const (
 	svcName        = "certs"
 	envPrefixDB    = "SMQ_CERTS_DB_"
 	envPrefixHTTP  = "SMQ_CERTS_HTTP_"
+	envPrefixGRPC  = "SMQ_CERTS_GRPC_"
 	envPrefixAuth  = "SMQ_AUTH_GRPC_"
 	defDB          = "certs"
 	defSvcHTTPPort = "9019"
+	defSvcGRPCPort = "7012"
 )
 
 type config struct {
@@ -135,6 +142,18 @@ func main() {
 	}
 	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, httpapi.MakeHandler(svc, authn, logger, cfg.InstanceID), logger)
 
+	grpcServerConfig := server.Config{Port: defSvcGRPCPort}
+	if err := env.ParseWithOptions(&grpcServerConfig, env.Options{Prefix: envPrefixGRPC}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s gRPC server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+	registerCertsServer := func(srv *grpc.Server) {
+		reflection.Register(srv)
+		grpcCertsV1.RegisterCertsServiceServer(srv, grpcapi.NewServer(svc))
+	}
+	gs := grpcserver.NewServer(ctx, cancel, svcName, grpcServerConfig, registerCertsServer, logger)
+
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
 		go chc.CallHome(ctx)
@@ -145,7 +164,11 @@ func main() {
 	})
 
 	g.Go(func() error {
-		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+		return gs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs, gs)
 	})
 
 	if err := g.Wait(); err != nil {
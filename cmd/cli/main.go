@@ -45,6 +45,9 @@ func main() {
 	configCmd := cli.NewConfigCmd()
 	invitationsCmd := cli.NewInvitationsCmd()
 	journalCmd := cli.NewJournalCmd()
+	subscriptionsCmd := cli.NewSubscriptionCmd()
+	commandsCmd := cli.NewCommandsCmd()
+	twinsCmd := cli.NewTwinsCmd()
 
 	// Root Commands
 	rootCmd.AddCommand(healthCmd)
@@ -58,6 +61,9 @@ func main() {
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(invitationsCmd)
 	rootCmd.AddCommand(journalCmd)
+	rootCmd.AddCommand(subscriptionsCmd)
+	rootCmd.AddCommand(commandsCmd)
+	rootCmd.AddCommand(twinsCmd)
 
 	// Root Flags
 	rootCmd.PersistentFlags().StringVarP(
@@ -116,6 +122,38 @@ func main() {
 		"Journal Log URL",
 	)
 
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.ReadersURL,
+		"readers-url",
+		"R",
+		sdkConf.ReadersURL,
+		"Readers URL",
+	)
+
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.NotifiersURL,
+		"notifiers-url",
+		"N",
+		sdkConf.NotifiersURL,
+		"Notifiers URL",
+	)
+
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.CommandsURL,
+		"commands-url",
+		"M",
+		sdkConf.CommandsURL,
+		"Commands service URL",
+	)
+
+	rootCmd.PersistentFlags().StringVarP(
+		&sdkConf.TwinsURL,
+		"twins-url",
+		"W",
+		sdkConf.TwinsURL,
+		"Twins service URL",
+	)
+
 	rootCmd.PersistentFlags().StringVarP(
 		&sdkConf.HostURL,
 		"host-url",
@@ -227,6 +265,13 @@ func main() {
 		"",
 		"Subscription contact query parameter",
 	)
+
+	rootCmd.PersistentFlags().BoolVar(
+		&cli.AllOrNothing,
+		"all-or-nothing",
+		false,
+		"Roll back provisioned entities if any manifest row fails",
+	)
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
 	}
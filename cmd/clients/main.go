@@ -39,11 +39,14 @@ import (
 	gconsumer "github.com/absmach/supermq/pkg/groups/events/consumer"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
+	"github.com/absmach/supermq/pkg/lockout"
+	lockoutredis "github.com/absmach/supermq/pkg/lockout/redis"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/pkg/policies/spicedb"
 	pg "github.com/absmach/supermq/pkg/postgres"
 	pgclient "github.com/absmach/supermq/pkg/postgres"
 	"github.com/absmach/supermq/pkg/prometheus"
+	"github.com/absmach/supermq/pkg/roles"
 	"github.com/absmach/supermq/pkg/server"
 	grpcserver "github.com/absmach/supermq/pkg/server/grpc"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
@@ -71,6 +74,7 @@ const (
 	envPrefixChannels  = "SMQ_CHANNELS_GRPC_"
 	envPrefixGroups    = "SMQ_GROUPS_GRPC_"
 	envPrefixDomains   = "SMQ_DOMAINS_GRPC_"
+	envPrefixLockout   = "SMQ_CLIENTS_LOCKOUT_"
 	defDB              = "clients"
 	defSvcHTTPPort     = "9000"
 	defSvcAuthGRPCPort = "7000"
@@ -87,6 +91,7 @@ type config struct {
 	SendTelemetry       bool          `env:"SMQ_SEND_TELEMETRY"             envDefault:"true"`
 	ESURL               string        `env:"SMQ_ES_URL"                     envDefault:"nats://localhost:4222"`
 	ESConsumerName      string        `env:"SMQ_CLIENTS_EVENT_CONSUMER"     envDefault:"clients"`
+	PresenceTimeout     time.Duration `env:"SMQ_CLIENTS_PRESENCE_TIMEOUT"   envDefault:"2m"`
 	TraceRatio          float64       `env:"SMQ_JAEGER_TRACE_RATIO"         envDefault:"1.0"`
 	SpicedbHost         string        `env:"SMQ_SPICEDB_HOST"               envDefault:"localhost"`
 	SpicedbPort         string        `env:"SMQ_SPICEDB_PORT"               envDefault:"50051"`
@@ -239,7 +244,14 @@ func main() {
 	defer groupsHandler.Close()
 	logger.Info("Groups gRPC client successfully connected to groups gRPC server " + groupsHandler.Secure())
 
-	svc, psvc, err := newService(ctx, db, dbConfig, authz, policyEvaluator, policyService, cacheclient, cfg.CacheKeyDuration, cfg.ESURL, channelsgRPC, groupsClient, tracer, logger)
+	lockoutConfig := lockout.Config{}
+	if err := env.ParseWithOptions(&lockoutConfig, env.Options{Prefix: envPrefixLockout}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s lockout configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	svc, psvc, err := newService(ctx, db, dbConfig, authz, policyEvaluator, policyService, cacheclient, cfg.CacheKeyDuration, lockoutConfig, cfg.ESURL, channelsgRPC, groupsClient, tracer, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to create services: %s", err))
 		exitCode = 1
@@ -255,6 +267,13 @@ func main() {
 		return
 	}
 
+	crepo := postgres.NewRepository(pg.NewDatabase(db, dbConfig, tracer))
+	if err := events.PresenceEventsSubscribe(ctx, crepo, cfg.ESURL, cfg.ESConsumerName, cfg.PresenceTimeout, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to create presence event store : %s", err))
+		exitCode = 1
+		return
+	}
+
 	gdatabase := pg.NewDatabase(db, dbConfig, tracer)
 	grepo := gpostgres.New(gdatabase)
 
@@ -309,7 +328,7 @@ func main() {
 	}
 }
 
-func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorization, pe policies.Evaluator, ps policies.Service, cacheClient *redis.Client, keyDuration time.Duration, esURL string, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, tracer trace.Tracer, logger *slog.Logger) (clients.Service, pClients.Service, error) {
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorization, pe policies.Evaluator, ps policies.Service, cacheClient *redis.Client, keyDuration time.Duration, lockoutConfig lockout.Config, esURL string, channels grpcChannelsV1.ChannelsServiceClient, groups grpcGroupsV1.GroupsServiceClient, tracer trace.Tracer, logger *slog.Logger) (clients.Service, pClients.Service, error) {
 	database := pg.NewDatabase(db, dbConfig, tracer)
 	repo := postgres.NewRepository(database)
 
@@ -322,7 +341,15 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 	// Clients service
 	cache := cache.NewCache(cacheClient, keyDuration)
 
-	csvc, err := clients.NewService(repo, ps, cache, channels, groups, idp, sidp)
+	// Failed client authentication attempts are shared between the instances
+	// and tracked locally while the cache is not available.
+	tracker := lockout.NewFallbackTracker(lockoutredis.NewTracker(cacheClient, lockoutConfig), lockout.NewMemoryTracker(lockoutConfig), logger)
+	tracker, err = events.NewLockoutTracker(ctx, tracker, esURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	csvc, err := clients.NewService(repo, ps, cache, channels, groups, idp, sidp, tracker)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -344,7 +371,11 @@ func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, auth
 	}
 	csvc = middleware.LoggingMiddleware(csvc, logger)
 
-	isvc := pClients.New(repo, cache, pe, ps)
+	rpms, err := roles.NewProvisionManageService(policies.ClientType, repo, ps, sidp, clients.AvailableActions(), clients.BuiltInRoles())
+	if err != nil {
+		return nil, nil, err
+	}
+	isvc := pClients.New(repo, cache, pe, ps, tracker, idp, rpms)
 
 	return csvc, isvc, err
 }
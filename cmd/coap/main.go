@@ -10,18 +10,26 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/coap"
 	httpapi "github.com/absmach/supermq/coap/api"
 	"github.com/absmach/supermq/coap/tracing"
+	redisclient "github.com/absmach/supermq/internal/clients/redis"
 	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/certauth"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
+	"github.com/absmach/supermq/pkg/lastvalue"
+	lvredis "github.com/absmach/supermq/pkg/lastvalue/redis"
+	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
+	"github.com/absmach/supermq/pkg/presence"
 	"github.com/absmach/supermq/pkg/prometheus"
+	"github.com/absmach/supermq/pkg/schema"
 	"github.com/absmach/supermq/pkg/server"
 	coapserver "github.com/absmach/supermq/pkg/server/coap"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
@@ -36,17 +44,24 @@ const (
 	envPrefixHTTP     = "SMQ_COAP_ADAPTER_HTTP_"
 	envPrefixClients  = "SMQ_CLIENTS_AUTH_GRPC_"
 	envPrefixChannels = "SMQ_CHANNELS_GRPC_"
+	envPrefixCerts    = "SMQ_CERTS_GRPC_"
+	envPrefixCertAuth = "SMQ_COAP_ADAPTER_CERT_AUTH_"
 	defSvcHTTPPort    = "5683"
 	defSvcCoAPPort    = "5683"
 )
 
 type config struct {
-	LogLevel      string  `env:"SMQ_COAP_ADAPTER_LOG_LEVEL"   envDefault:"info"`
-	BrokerURL     string  `env:"SMQ_MESSAGE_BROKER_URL"       envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"SMQ_JAEGER_URL"               envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"SMQ_SEND_TELEMETRY"           envDefault:"true"`
-	InstanceID    string  `env:"SMQ_COAP_ADAPTER_INSTANCE_ID" envDefault:""`
-	TraceRatio    float64 `env:"SMQ_JAEGER_TRACE_RATIO"       envDefault:"1.0"`
+	LogLevel            string        `env:"SMQ_COAP_ADAPTER_LOG_LEVEL"         envDefault:"info"`
+	BrokerURL           string        `env:"SMQ_MESSAGE_BROKER_URL"             envDefault:"nats://localhost:4222"`
+	ESURL               string        `env:"SMQ_ES_URL"                         envDefault:"nats://localhost:4222"`
+	JaegerURL           url.URL       `env:"SMQ_JAEGER_URL"                     envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry       bool          `env:"SMQ_SEND_TELEMETRY"                 envDefault:"true"`
+	InstanceID          string        `env:"SMQ_COAP_ADAPTER_INSTANCE_ID"       envDefault:""`
+	TraceRatio          float64       `env:"SMQ_JAEGER_TRACE_RATIO"             envDefault:"1.0"`
+	SchemaCacheSize     int           `env:"SMQ_COAP_ADAPTER_SCHEMA_CACHE_SIZE" envDefault:"10000"`
+	LastValueURL        string        `env:"SMQ_COAP_ADAPTER_LAST_VALUE_URL"    envDefault:""`
+	LastValueTTL        time.Duration `env:"SMQ_COAP_ADAPTER_LAST_VALUE_TTL"    envDefault:"0"`
+	LastValueMaxEntries int           `env:"SMQ_COAP_ADAPTER_LAST_VALUE_MAX_ENTRIES" envDefault:"100000"`
 }
 
 func main() {
@@ -121,6 +136,34 @@ func main() {
 	defer channelsHandler.Close()
 	logger.Info("Channels service gRPC client successfully connected to channels gRPC server " + channelsHandler.Secure())
 
+	certAuthCfg := certauth.Config{}
+	if err := env.ParseWithOptions(&certAuthCfg, env.Options{Prefix: envPrefixCertAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s client certificate authentication configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	var certs certauth.Authenticator
+	if certAuthCfg.Enabled {
+		certsClientCfg := grpcclient.Config{}
+		if err := env.ParseWithOptions(&certsClientCfg, env.Options{Prefix: envPrefixCerts}); err != nil {
+			logger.Error(fmt.Sprintf("failed to load certs gRPC client configuration : %s", err))
+			exitCode = 1
+			return
+		}
+
+		certsClient, certsHandler, err := grpcclient.SetupCertsClient(ctx, certsClientCfg)
+		if err != nil {
+			logger.Error(err.Error())
+			exitCode = 1
+			return
+		}
+		defer certsHandler.Close()
+		logger.Info("Certs service gRPC client successfully connected to certs gRPC server " + certsHandler.Secure())
+
+		certs = certauth.New(certsClient, clientsClient, certAuthCfg)
+	}
+
 	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
@@ -143,7 +186,40 @@ func main() {
 	defer nps.Close()
 	nps = brokerstracing.NewPubSub(coapServerConfig, tracer, nps)
 
-	svc := coap.New(clientsClient, channelsClient, nps)
+	schemas := schema.NewCache(cfg.SchemaCacheSize)
+
+	lastValues := lastvalue.NewMemoryStore(cfg.LastValueMaxEntries, cfg.LastValueTTL)
+	if cfg.LastValueURL != "" {
+		lvClient, err := redisclient.Connect(cfg.LastValueURL)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to connect to last value cache: %s", err))
+			exitCode = 1
+			return
+		}
+		defer lvClient.Close()
+		lastValues = lvredis.NewStore(lvClient, cfg.LastValueTTL)
+	}
+
+	lvCfg := messaging.SubscriberConfig{
+		ID:             fmt.Sprintf("%s-%s-%s", lastvalue.SubscriberID, svcName, cfg.InstanceID),
+		Topic:          brokers.SubjectAllChannels,
+		Handler:        lastvalue.NewHandler(ctx, lastValues),
+		DeliveryPolicy: messaging.DeliverNewPolicy,
+	}
+	if err := nps.Subscribe(ctx, lvCfg); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to last values: %s", err))
+		exitCode = 1
+		return
+	}
+
+	es, err := presence.NewEventStore(ctx, cfg.ESURL, presence.CoAPStream, cfg.InstanceID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s event store : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	svc := coap.New(clientsClient, channelsClient, schemas, lastValues, nps, certs, es, logger)
 
 	svc = tracing.New(tracer, svc)
 
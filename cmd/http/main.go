@@ -7,12 +7,14 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
+	"time"
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/mgate"
@@ -23,16 +25,26 @@ import (
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	adapter "github.com/absmach/supermq/http"
 	httpapi "github.com/absmach/supermq/http/api"
+	redisclient "github.com/absmach/supermq/internal/clients/redis"
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/authn/authsvc"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	authsvcAuthz "github.com/absmach/supermq/pkg/authz/authsvc"
+	"github.com/absmach/supermq/pkg/certauth"
+	domainsAuthz "github.com/absmach/supermq/pkg/domains/grpcclient"
+	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
+	"github.com/absmach/supermq/pkg/lastvalue"
+	lvredis "github.com/absmach/supermq/pkg/lastvalue/redis"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
 	"github.com/absmach/supermq/pkg/messaging/handler"
 	"github.com/absmach/supermq/pkg/prometheus"
+	"github.com/absmach/supermq/pkg/schema"
 	"github.com/absmach/supermq/pkg/server"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
 	"github.com/absmach/supermq/pkg/uuid"
@@ -47,18 +59,28 @@ const (
 	envPrefixClients  = "SMQ_CLIENTS_AUTH_GRPC_"
 	envPrefixChannels = "SMQ_CHANNELS_GRPC_"
 	envPrefixAuth     = "SMQ_AUTH_GRPC_"
+	envPrefixDomains  = "SMQ_DOMAINS_GRPC_"
+	envPrefixCerts    = "SMQ_CERTS_GRPC_"
+	envPrefixCertAuth = "SMQ_HTTP_ADAPTER_CERT_AUTH_"
 	defSvcHTTPPort    = "80"
 	targetHTTPPort    = "81"
 	targetHTTPHost    = "http://localhost"
 )
 
+var errAppendClientCA = errors.New("failed to append client CA to the client CAs pool")
+
 type config struct {
-	LogLevel      string  `env:"SMQ_HTTP_ADAPTER_LOG_LEVEL"   envDefault:"info"`
-	BrokerURL     string  `env:"SMQ_MESSAGE_BROKER_URL"       envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"SMQ_JAEGER_URL"               envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"SMQ_SEND_TELEMETRY"           envDefault:"true"`
-	InstanceID    string  `env:"SMQ_HTTP_ADAPTER_INSTANCE_ID" envDefault:""`
-	TraceRatio    float64 `env:"SMQ_JAEGER_TRACE_RATIO"       envDefault:"1.0"`
+	LogLevel            string        `env:"SMQ_HTTP_ADAPTER_LOG_LEVEL"         envDefault:"info"`
+	BrokerURL           string        `env:"SMQ_MESSAGE_BROKER_URL"             envDefault:"nats://localhost:4222"`
+	JaegerURL           url.URL       `env:"SMQ_JAEGER_URL"                     envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry       bool          `env:"SMQ_SEND_TELEMETRY"                 envDefault:"true"`
+	InstanceID          string        `env:"SMQ_HTTP_ADAPTER_INSTANCE_ID"       envDefault:""`
+	TraceRatio          float64       `env:"SMQ_JAEGER_TRACE_RATIO"             envDefault:"1.0"`
+	SchemaCacheSize     int           `env:"SMQ_HTTP_ADAPTER_SCHEMA_CACHE_SIZE" envDefault:"10000"`
+	LastValueURL        string        `env:"SMQ_HTTP_ADAPTER_LAST_VALUE_URL"    envDefault:""`
+	LastValueTTL        time.Duration `env:"SMQ_HTTP_ADAPTER_LAST_VALUE_TTL"    envDefault:"0"`
+	LastValueMaxEntries int           `env:"SMQ_HTTP_ADAPTER_LAST_VALUE_MAX_ENTRIES" envDefault:"100000"`
+	TrustedProxies      []string      `env:"SMQ_HTTP_ADAPTER_TRUSTED_PROXIES"   envDefault:""`
 }
 
 func main() {
@@ -93,6 +115,13 @@ func main() {
 		return
 	}
 
+	trustedProxies, err := lockout.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse trusted proxies: %s", err))
+		exitCode = 1
+		return
+	}
+
 	clientsClientCfg := grpcclient.Config{}
 	if err := env.ParseWithOptions(&clientsClientCfg, env.Options{Prefix: envPrefixClients}); err != nil {
 		logger.Error(fmt.Sprintf("failed to load clients gRPC client configuration : %s", err))
@@ -141,6 +170,57 @@ func main() {
 	defer authnHandler.Close()
 	logger.Info("authn successfully connected to auth gRPC server " + authnHandler.Secure())
 
+	domsGrpcCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&domsGrpcCfg, env.Options{Prefix: envPrefixDomains}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load domains gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+	domAuthz, _, domainsHandler, err := domainsAuthz.NewAuthorization(ctx, domsGrpcCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer domainsHandler.Close()
+
+	authz, authzHandler, err := authsvcAuthz.NewAuthorization(ctx, authnCfg, domAuthz)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authzHandler.Close()
+	logger.Info("authz successfully connected to auth gRPC server " + authzHandler.Secure())
+
+	certAuthCfg := certauth.Config{}
+	if err := env.ParseWithOptions(&certAuthCfg, env.Options{Prefix: envPrefixCertAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s client certificate authentication configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	var certs certauth.Authenticator
+	if certAuthCfg.Enabled {
+		certsClientCfg := grpcclient.Config{}
+		if err := env.ParseWithOptions(&certsClientCfg, env.Options{Prefix: envPrefixCerts}); err != nil {
+			logger.Error(fmt.Sprintf("failed to load certs gRPC client configuration : %s", err))
+			exitCode = 1
+			return
+		}
+
+		certsClient, certsHandler, err := grpcclient.SetupCertsClient(ctx, certsClientCfg)
+		if err != nil {
+			logger.Error(err.Error())
+			exitCode = 1
+			return
+		}
+		defer certsHandler.Close()
+		logger.Info("Certs service gRPC client successfully connected to certs gRPC server " + certsHandler.Secure())
+
+		certs = certauth.New(certsClient, clientsClient, certAuthCfg)
+	}
+
 	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Failed to init Jaeger: %s", err))
@@ -154,19 +234,47 @@ func main() {
 	}()
 	tracer := tp.Tracer(svcName)
 
-	pub, err := brokers.NewPublisher(ctx, cfg.BrokerURL)
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
 		exitCode = 1
 		return
 	}
-	defer pub.Close()
-	pub = brokerstracing.NewPublisher(httpServerConfig, tracer, pub)
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
 
-	svc := newService(pub, authn, clientsClient, channelsClient, logger, tracer)
+	lastValues := lastvalue.NewMemoryStore(cfg.LastValueMaxEntries, cfg.LastValueTTL)
+	if cfg.LastValueURL != "" {
+		lvClient, err := redisclient.Connect(cfg.LastValueURL)
+		if err != nil {
+			logger.Error(fmt.Sprintf("failed to connect to last value cache: %s", err))
+			exitCode = 1
+			return
+		}
+		defer lvClient.Close()
+		lastValues = lvredis.NewStore(lvClient, cfg.LastValueTTL)
+	}
+
+	lvCfg := messaging.SubscriberConfig{
+		ID:             fmt.Sprintf("%s-%s-%s", lastvalue.SubscriberID, svcName, cfg.InstanceID),
+		Topic:          brokers.SubjectAllChannels,
+		Handler:        lastvalue.NewHandler(ctx, lastValues),
+		DeliveryPolicy: messaging.DeliverNewPolicy,
+	}
+	if err := pubSub.Subscribe(ctx, lvCfg); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to last values: %s", err))
+		exitCode = 1
+		return
+	}
+
+	schemas := schema.NewCache(cfg.SchemaCacheSize)
+
+	svc := newService(pubSub, authn, authz, clientsClient, channelsClient, schemas, certs, logger, tracer)
+	lvSvc := adapter.NewLastValueService(lastValues, authn, authz, clientsClient, channelsClient, certs)
 	targetServerCfg := server.Config{Port: targetHTTPPort}
 
-	hs := httpserver.NewServer(ctx, cancel, svcName, targetServerCfg, httpapi.MakeHandler(logger, cfg.InstanceID), logger)
+	target := httpapi.MakeHandler(lvSvc, logger, cfg.InstanceID)
+	hs := httpserver.NewServer(ctx, cancel, svcName, targetServerCfg, target, logger)
 
 	if cfg.SendTelemetry {
 		chc := chclient.New(svcName, supermq.Version, logger, cancel)
@@ -178,7 +286,7 @@ func main() {
 	})
 
 	g.Go(func() error {
-		return proxyHTTP(ctx, httpServerConfig, logger, svc)
+		return proxyHTTP(ctx, httpServerConfig, certAuthCfg, trustedProxies, logger, svc, target)
 	})
 
 	g.Go(func() error {
@@ -190,8 +298,8 @@ func main() {
 	}
 }
 
-func newService(pub messaging.Publisher, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, logger *slog.Logger, tracer trace.Tracer) session.Handler {
-	svc := adapter.NewHandler(pub, authn, clients, channels, logger)
+func newService(pub messaging.Publisher, authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, schemas schema.Cache, certs certauth.Authenticator, logger *slog.Logger, tracer trace.Tracer) session.Handler {
+	svc := adapter.NewHandler(pub, authn, authz, clients, channels, schemas, certs, logger)
 	svc = handler.NewTracing(tracer, svc)
 	svc = handler.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics(svcName, "api")
@@ -199,7 +307,7 @@ func newService(pub messaging.Publisher, authn smqauthn.Authentication, clients
 	return svc
 }
 
-func proxyHTTP(ctx context.Context, cfg server.Config, logger *slog.Logger, sessionHandler session.Handler) error {
+func proxyHTTP(ctx context.Context, cfg server.Config, certAuthCfg certauth.Config, trustedProxies lockout.TrustedProxies, logger *slog.Logger, sessionHandler session.Handler, target http.Handler) error {
 	config := mgate.Config{
 		Address:    fmt.Sprintf("%s:%s", "", cfg.Port),
 		Target:     fmt.Sprintf("%s:%s", targetHTTPHost, targetHTTPPort),
@@ -213,23 +321,44 @@ func proxyHTTP(ctx context.Context, cfg server.Config, logger *slog.Logger, sess
 		config.TLSConfig = &tls.Config{
 			Certificates: []tls.Certificate{tlsCert},
 		}
+		// The client certificates are verified against the client CAs, and
+		// are required only if the secret authentication fallback is not
+		// allowed.
+		if cfg.ClientCAFile != "" {
+			clientCA, err := os.ReadFile(cfg.ClientCAFile)
+			if err != nil {
+				return err
+			}
+			config.TLSConfig.ClientCAs = x509.NewCertPool()
+			if !config.TLSConfig.ClientCAs.AppendCertsFromPEM(clientCA) {
+				return errAppendClientCA
+			}
+			config.TLSConfig.ClientAuth = certAuthCfg.ClientAuth()
+		}
 	}
 	mp, err := mgatehttp.NewProxy(config, sessionHandler, logger)
 	if err != nil {
 		return err
 	}
-	http.HandleFunc("/", mp.ServeHTTP)
+	// The proxy is served directly, instead of using the mgate proxy listener,
+	// so the request headers are propagated with the published messages and
+	// the last value reads bypass the proxy.
+	hs := &http.Server{
+		Addr:      config.Address,
+		Handler:   adapter.WithSource(trustedProxies, logger, adapter.WithClientCert(adapter.WithLastValue(adapter.WithHeaders(mp), target))),
+		TLSConfig: config.TLSConfig,
+	}
 
 	errCh := make(chan error)
 	switch {
 	case cfg.CertFile != "" || cfg.KeyFile != "":
 		go func() {
-			errCh <- mp.Listen(ctx)
+			errCh <- hs.ListenAndServeTLS("", "")
 		}()
 		logger.Info(fmt.Sprintf("%s service HTTPS server listening at %s:%s with TLS cert %s and key %s", svcName, cfg.Host, cfg.Port, cfg.CertFile, cfg.KeyFile))
 	default:
 		go func() {
-			errCh <- mp.Listen(ctx)
+			errCh <- hs.ListenAndServe()
 		}()
 		logger.Info(fmt.Sprintf("%s service HTTP server listening at %s:%s without TLS", svcName, cfg.Host, cfg.Port))
 	}
@@ -237,7 +366,7 @@ func proxyHTTP(ctx context.Context, cfg server.Config, logger *slog.Logger, sess
 	select {
 	case <-ctx.Done():
 		logger.Info(fmt.Sprintf("proxy HTTP shutdown at %s", config.Target))
-		return nil
+		return hs.Close()
 	case err := <-errCh:
 		return err
 	}
@@ -6,6 +6,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"log"
@@ -19,21 +20,26 @@ import (
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	mgate "github.com/absmach/mgate"
-	mgatemqtt "github.com/absmach/mgate/pkg/mqtt"
-	"github.com/absmach/mgate/pkg/mqtt/websocket"
 	"github.com/absmach/mgate/pkg/session"
+	mptls "github.com/absmach/mgate/pkg/tls"
 	"github.com/absmach/supermq"
 	smqlog "github.com/absmach/supermq/logger"
 	"github.com/absmach/supermq/mqtt"
 	"github.com/absmach/supermq/mqtt/events"
 	mqtttracing "github.com/absmach/supermq/mqtt/tracing"
+	"github.com/absmach/supermq/pkg/authn/authsvc"
+	authsvcAuthz "github.com/absmach/supermq/pkg/authz/authsvc"
+	"github.com/absmach/supermq/pkg/certauth"
+	domainsAuthz "github.com/absmach/supermq/pkg/domains/grpcclient"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
 	"github.com/absmach/supermq/pkg/messaging/handler"
 	mqttpub "github.com/absmach/supermq/pkg/messaging/mqtt"
+	"github.com/absmach/supermq/pkg/schema"
 	"github.com/absmach/supermq/pkg/server"
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/caarlos0/env/v11"
@@ -45,28 +51,35 @@ const (
 	svcName           = "mqtt"
 	envPrefixClients  = "SMQ_CLIENTS_AUTH_GRPC_"
 	envPrefixChannels = "SMQ_CHANNELS_GRPC_"
+	envPrefixAuth     = "SMQ_AUTH_GRPC_"
+	envPrefixDomains  = "SMQ_DOMAINS_GRPC_"
+	envPrefixCerts    = "SMQ_CERTS_GRPC_"
+	envPrefixCertAuth = "SMQ_MQTT_ADAPTER_CERT_AUTH_"
+	envPrefixTLS      = "SMQ_MQTT_ADAPTER_"
 	wsPathPrefix      = "/mqtt"
 )
 
 type config struct {
-	LogLevel              string        `env:"SMQ_MQTT_ADAPTER_LOG_LEVEL"                    envDefault:"info"`
-	MQTTPort              string        `env:"SMQ_MQTT_ADAPTER_MQTT_PORT"                    envDefault:"1883"`
-	MQTTTargetHost        string        `env:"SMQ_MQTT_ADAPTER_MQTT_TARGET_HOST"             envDefault:"localhost"`
-	MQTTTargetPort        string        `env:"SMQ_MQTT_ADAPTER_MQTT_TARGET_PORT"             envDefault:"1883"`
-	MQTTForwarderTimeout  time.Duration `env:"SMQ_MQTT_ADAPTER_FORWARDER_TIMEOUT"            envDefault:"30s"`
-	MQTTTargetHealthCheck string        `env:"SMQ_MQTT_ADAPTER_MQTT_TARGET_HEALTH_CHECK"     envDefault:""`
-	MQTTQoS               uint8         `env:"SMQ_MQTT_ADAPTER_MQTT_QOS"                     envDefault:"1"`
-	HTTPPort              string        `env:"SMQ_MQTT_ADAPTER_WS_PORT"                      envDefault:"8080"`
-	HTTPTargetHost        string        `env:"SMQ_MQTT_ADAPTER_WS_TARGET_HOST"               envDefault:"localhost"`
-	HTTPTargetPort        string        `env:"SMQ_MQTT_ADAPTER_WS_TARGET_PORT"               envDefault:"8080"`
-	HTTPTargetPath        string        `env:"SMQ_MQTT_ADAPTER_WS_TARGET_PATH"               envDefault:"/mqtt"`
-	Instance              string        `env:"SMQ_MQTT_ADAPTER_INSTANCE"                     envDefault:""`
-	JaegerURL             url.URL       `env:"SMQ_JAEGER_URL"                                envDefault:"http://localhost:4318/v1/traces"`
-	BrokerURL             string        `env:"SMQ_MESSAGE_BROKER_URL"                        envDefault:"nats://localhost:4222"`
-	SendTelemetry         bool          `env:"SMQ_SEND_TELEMETRY"                            envDefault:"true"`
-	InstanceID            string        `env:"SMQ_MQTT_ADAPTER_INSTANCE_ID"                  envDefault:""`
-	ESURL                 string        `env:"SMQ_ES_URL"                                    envDefault:"nats://localhost:4222"`
-	TraceRatio            float64       `env:"SMQ_JAEGER_TRACE_RATIO"                        envDefault:"1.0"`
+	LogLevel              string        `env:"SMQ_MQTT_ADAPTER_LOG_LEVEL"                envDefault:"info"`
+	MQTTPort              string        `env:"SMQ_MQTT_ADAPTER_MQTT_PORT"                envDefault:"1883"`
+	MQTTTargetHost        string        `env:"SMQ_MQTT_ADAPTER_MQTT_TARGET_HOST"         envDefault:"localhost"`
+	MQTTTargetPort        string        `env:"SMQ_MQTT_ADAPTER_MQTT_TARGET_PORT"         envDefault:"1883"`
+	MQTTForwarderTimeout  time.Duration `env:"SMQ_MQTT_ADAPTER_FORWARDER_TIMEOUT"        envDefault:"30s"`
+	MQTTTargetHealthCheck string        `env:"SMQ_MQTT_ADAPTER_MQTT_TARGET_HEALTH_CHECK" envDefault:""`
+	MQTTQoS               uint8         `env:"SMQ_MQTT_ADAPTER_MQTT_QOS"                 envDefault:"1"`
+	HTTPPort              string        `env:"SMQ_MQTT_ADAPTER_WS_PORT"                  envDefault:"8080"`
+	HTTPTargetHost        string        `env:"SMQ_MQTT_ADAPTER_WS_TARGET_HOST"           envDefault:"localhost"`
+	HTTPTargetPort        string        `env:"SMQ_MQTT_ADAPTER_WS_TARGET_PORT"           envDefault:"8080"`
+	HTTPTargetPath        string        `env:"SMQ_MQTT_ADAPTER_WS_TARGET_PATH"           envDefault:"/mqtt"`
+	Instance              string        `env:"SMQ_MQTT_ADAPTER_INSTANCE"                 envDefault:""`
+	JaegerURL             url.URL       `env:"SMQ_JAEGER_URL"                            envDefault:"http://localhost:4318/v1/traces"`
+	BrokerURL             string        `env:"SMQ_MESSAGE_BROKER_URL"                    envDefault:"nats://localhost:4222"`
+	SendTelemetry         bool          `env:"SMQ_SEND_TELEMETRY"                        envDefault:"true"`
+	InstanceID            string        `env:"SMQ_MQTT_ADAPTER_INSTANCE_ID"              envDefault:""`
+	ESURL                 string        `env:"SMQ_ES_URL"                                envDefault:"nats://localhost:4222"`
+	TraceRatio            float64       `env:"SMQ_JAEGER_TRACE_RATIO"                    envDefault:"1.0"`
+	SchemaCacheSize       int           `env:"SMQ_MQTT_ADAPTER_SCHEMA_CACHE_SIZE"        envDefault:"10000"`
+	TrustedProxies        []string      `env:"SMQ_MQTT_ADAPTER_TRUSTED_PROXIES"          envDefault:""`
 }
 
 func main() {
@@ -93,6 +106,10 @@ func main() {
 			return
 		}
 	}
+	// The sessions of the instances are told apart by the instance name.
+	if cfg.Instance == "" {
+		cfg.Instance = cfg.InstanceID
+	}
 
 	if cfg.MQTTTargetHealthCheck != "" {
 		notify := func(e error, next time.Duration) {
@@ -198,7 +215,90 @@ func main() {
 	defer channelsHandler.Close()
 	logger.Info("Channels service gRPC client successfully connected to channels gRPC server " + channelsHandler.Secure())
 
-	h := mqtt.NewHandler(np, es, logger, clientsClient, channelsClient)
+	authnCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&authnCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load auth gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+
+	authn, authnHandler, err := authsvc.NewAuthentication(ctx, authnCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authnHandler.Close()
+	logger.Info("authn successfully connected to auth gRPC server " + authnHandler.Secure())
+
+	domsGrpcCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&domsGrpcCfg, env.Options{Prefix: envPrefixDomains}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load domains gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+	domAuthz, _, domainsHandler, err := domainsAuthz.NewAuthorization(ctx, domsGrpcCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer domainsHandler.Close()
+
+	authz, authzHandler, err := authsvcAuthz.NewAuthorization(ctx, authnCfg, domAuthz)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authzHandler.Close()
+	logger.Info("authz successfully connected to auth gRPC server " + authzHandler.Secure())
+
+	schemas := schema.NewCache(cfg.SchemaCacheSize)
+
+	certAuthCfg := certauth.Config{}
+	if err := env.ParseWithOptions(&certAuthCfg, env.Options{Prefix: envPrefixCertAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s client certificate authentication configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	tlsConfig, err := loadTLSConfig(certAuthCfg)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s TLS configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	trustedProxies, err := lockout.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse trusted proxies: %s", err))
+		exitCode = 1
+		return
+	}
+
+	var certs certauth.Authenticator
+	if certAuthCfg.Enabled {
+		certsClientCfg := grpcclient.Config{}
+		if err := env.ParseWithOptions(&certsClientCfg, env.Options{Prefix: envPrefixCerts}); err != nil {
+			logger.Error(fmt.Sprintf("failed to load certs gRPC client configuration : %s", err))
+			exitCode = 1
+			return
+		}
+
+		certsClient, certsHandler, err := grpcclient.SetupCertsClient(ctx, certsClientCfg)
+		if err != nil {
+			logger.Error(err.Error())
+			exitCode = 1
+			return
+		}
+		defer certsHandler.Close()
+		logger.Info("Certs service gRPC client successfully connected to certs gRPC server " + certsHandler.Secure())
+
+		certs = certauth.New(certsClient, clientsClient, certAuthCfg)
+	}
+
+	h := mqtt.NewHandler(np, es, logger, authn, authz, clientsClient, channelsClient, schemas, certs)
 	h = handler.NewTracing(tracer, h)
 
 	if cfg.SendTelemetry {
@@ -209,12 +309,12 @@ func main() {
 	var interceptor session.Interceptor
 	logger.Info(fmt.Sprintf("Starting MQTT proxy on port %s", cfg.MQTTPort))
 	g.Go(func() error {
-		return proxyMQTT(ctx, cfg, logger, h, interceptor)
+		return proxyMQTT(ctx, cfg, tlsConfig, trustedProxies, logger, h, interceptor)
 	})
 
 	logger.Info(fmt.Sprintf("Starting MQTT over WS  proxy on port %s", cfg.HTTPPort))
 	g.Go(func() error {
-		return proxyWS(ctx, cfg, logger, h, interceptor)
+		return proxyWS(ctx, cfg, tlsConfig, trustedProxies, logger, h, interceptor)
 	})
 
 	g.Go(func() error {
@@ -226,12 +326,32 @@ func main() {
 	}
 }
 
-func proxyMQTT(ctx context.Context, cfg config, logger *slog.Logger, sessionHandler session.Handler, interceptor session.Interceptor) error {
+// loadTLSConfig loads the TLS configuration of the MQTT and WS proxies. The
+// client certificates are verified against the client CAs, and are required
+// only if the secret authentication fallback is not allowed.
+func loadTLSConfig(certAuthCfg certauth.Config) (*tls.Config, error) {
+	cfg, err := mptls.NewConfig(env.Options{Prefix: envPrefixTLS})
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := mptls.Load(&cfg)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil && tlsConfig.ClientCAs != nil {
+		tlsConfig.ClientAuth = certAuthCfg.ClientAuth()
+	}
+
+	return tlsConfig, nil
+}
+
+func proxyMQTT(ctx context.Context, cfg config, tlsConfig *tls.Config, trustedProxies lockout.TrustedProxies, logger *slog.Logger, sessionHandler session.Handler, interceptor session.Interceptor) error {
 	config := mgate.Config{
-		Address: fmt.Sprintf(":%s", cfg.MQTTPort),
-		Target:  fmt.Sprintf("%s:%s", cfg.MQTTTargetHost, cfg.MQTTTargetPort),
+		Address:   fmt.Sprintf(":%s", cfg.MQTTPort),
+		Target:    fmt.Sprintf("%s:%s", cfg.MQTTTargetHost, cfg.MQTTTargetPort),
+		TLSConfig: tlsConfig,
 	}
-	mproxy := mgatemqtt.New(config, sessionHandler, interceptor, logger)
+	mproxy := mqtt.NewProxy(config, sessionHandler, interceptor, trustedProxies, logger)
 
 	errCh := make(chan error)
 	go func() {
@@ -247,14 +367,15 @@ func proxyMQTT(ctx context.Context, cfg config, logger *slog.Logger, sessionHand
 	}
 }
 
-func proxyWS(ctx context.Context, cfg config, logger *slog.Logger, sessionHandler session.Handler, interceptor session.Interceptor) error {
+func proxyWS(ctx context.Context, cfg config, tlsConfig *tls.Config, trustedProxies lockout.TrustedProxies, logger *slog.Logger, sessionHandler session.Handler, interceptor session.Interceptor) error {
 	config := mgate.Config{
 		Address:    fmt.Sprintf("%s:%s", "", cfg.HTTPPort),
 		Target:     fmt.Sprintf("ws://%s:%s%s", cfg.HTTPTargetHost, cfg.HTTPTargetPort, wsPathPrefix),
 		PathPrefix: wsPathPrefix,
+		TLSConfig:  tlsConfig,
 	}
 
-	wp := websocket.New(config, sessionHandler, interceptor, logger)
+	wp := mqtt.NewWSProxy(config, sessionHandler, interceptor, trustedProxies, logger)
 	http.HandleFunc(wsPathPrefix, wp.ServeHTTP)
 
 	errCh := make(chan error)
@@ -0,0 +1,261 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package main contains rules main function to start the rules engine service.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+
+	chclient "github.com/absmach/callhome/pkg/client"
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/consumers/notifiers/smtp"
+	"github.com/absmach/supermq/internal/email"
+	smqlog "github.com/absmach/supermq/logger"
+	authsvcAuthn "github.com/absmach/supermq/pkg/authn/authsvc"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	authsvcAuthz "github.com/absmach/supermq/pkg/authz/authsvc"
+	domainsAuthz "github.com/absmach/supermq/pkg/domains/grpcclient"
+	"github.com/absmach/supermq/pkg/grpcclient"
+	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/messaging/brokers"
+	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
+	pgclient "github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/pkg/prometheus"
+	"github.com/absmach/supermq/pkg/server"
+	httpserver "github.com/absmach/supermq/pkg/server/http"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/absmach/supermq/rules"
+	"github.com/absmach/supermq/rules/api"
+	"github.com/absmach/supermq/rules/events"
+	"github.com/absmach/supermq/rules/middleware"
+	rulespg "github.com/absmach/supermq/rules/postgres"
+	"github.com/caarlos0/env/v11"
+	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	svcName          = "rules"
+	envPrefixDB      = "SMQ_RULES_DB_"
+	envPrefixHTTP    = "SMQ_RULES_HTTP_"
+	envPrefixAuth    = "SMQ_AUTH_GRPC_"
+	envPrefixDomains = "SMQ_DOMAINS_GRPC_"
+	defDB            = "rules"
+	defSvcHTTPPort   = "9008"
+)
+
+type config struct {
+	LogLevel      string        `env:"SMQ_RULES_LOG_LEVEL"   envDefault:"info"`
+	ConfigPath    string        `env:"SMQ_RULES_CONFIG_PATH" envDefault:"/config.toml"`
+	BrokerURL     string        `env:"SMQ_MESSAGE_BROKER_URL" envDefault:"nats://localhost:4222"`
+	ESURL         string        `env:"SMQ_ES_URL"            envDefault:"nats://localhost:4222"`
+	JaegerURL     url.URL       `env:"SMQ_JAEGER_URL"        envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry bool          `env:"SMQ_SEND_TELEMETRY"    envDefault:"true"`
+	InstanceID    string        `env:"SMQ_RULES_INSTANCE_ID" envDefault:""`
+	TraceRatio    float64       `env:"SMQ_JAEGER_TRACE_RATIO" envDefault:"1.0"`
+	CacheTTL      time.Duration `env:"SMQ_RULES_CACHE_TTL"   envDefault:"30s"`
+	CacheSize     int           `env:"SMQ_RULES_CACHE_SIZE"  envDefault:"10000"`
+}
+
+func main() {
+	ctx, cancel := context.WithCancel(context.Background())
+	g, ctx := errgroup.WithContext(ctx)
+
+	cfg := config{}
+	if err := env.Parse(&cfg); err != nil {
+		log.Fatalf("failed to load %s configuration : %s", svcName, err)
+	}
+
+	logger, err := smqlog.New(os.Stdout, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to init logger: %s", err)
+	}
+
+	var exitCode int
+	defer smqlog.ExitWithError(&exitCode)
+
+	if cfg.InstanceID == "" {
+		if cfg.InstanceID, err = uuid.New().ID(); err != nil {
+			logger.Error(fmt.Sprintf("failed to generate instanceID: %s", err))
+			exitCode = 1
+			return
+		}
+	}
+
+	httpServerConfig := server.Config{Port: defSvcHTTPPort}
+	if err := env.ParseWithOptions(&httpServerConfig, env.Options{Prefix: envPrefixHTTP}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load %s HTTP server configuration : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	ec := email.Config{}
+	if err := env.Parse(&ec); err != nil {
+		logger.Error(fmt.Sprintf("failed to load email configuration : %s", err))
+		exitCode = 1
+		return
+	}
+
+	dbConfig := pgclient.Config{Name: defDB}
+	if err := env.ParseWithOptions(&dbConfig, env.Options{Prefix: envPrefixDB}); err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	db, err := pgclient.Setup(dbConfig, *rulespg.Migration())
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer db.Close()
+
+	authClientCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&authClientCfg, env.Options{Prefix: envPrefixAuth}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load auth gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+
+	authn, authnHandler, err := authsvcAuthn.NewAuthentication(ctx, authClientCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authnHandler.Close()
+	logger.Info("AuthN successfully connected to auth gRPC server " + authnHandler.Secure())
+
+	domsGrpcCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&domsGrpcCfg, env.Options{Prefix: envPrefixDomains}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load domains gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+	domAuthz, _, domainsHandler, err := domainsAuthz.NewAuthorization(ctx, domsGrpcCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer domainsHandler.Close()
+
+	authz, authzHandler, err := authsvcAuthz.NewAuthorization(ctx, authClientCfg, domAuthz)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authzHandler.Close()
+	logger.Info("AuthZ successfully connected to auth gRPC server " + authzHandler.Secure())
+
+	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
+		exitCode = 1
+		return
+	}
+	defer func() {
+		if err := tp.Shutdown(ctx); err != nil {
+			logger.Error(fmt.Sprintf("error shutting down tracer provider: %s", err))
+		}
+	}()
+	tracer := tp.Tracer(svcName)
+
+	pubSub, err := brokers.NewPubSub(ctx, cfg.BrokerURL, logger)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer pubSub.Close()
+	pubSub = brokerstracing.NewPubSub(httpServerConfig, tracer, pubSub)
+
+	output, err := brokers.NewPublisher(ctx, cfg.BrokerURL, brokers.Prefix(brokers.RulesPrefix))
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to connect to message broker: %s", err))
+		exitCode = 1
+		return
+	}
+	defer output.Close()
+	output = brokerstracing.NewPublisher(httpServerConfig, tracer, output)
+
+	cache := rules.NewCache(cfg.CacheSize, cfg.CacheTTL)
+	svc, err := newService(ctx, db, dbConfig, authz, pubSub, output, cfg.ESURL, cache, tracer, ec, logger)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+
+	// Every instance invalidates its cache on the rule changes made by the
+	// other instances, so each of them uses its own events consumer.
+	if err := events.CacheEventsSubscribe(ctx, cache, cfg.ESURL, fmt.Sprintf("%s-%s", svcName, cfg.InstanceID), logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to subscribe to rules events: %s", err))
+		exitCode = 1
+		return
+	}
+
+	if err = consumers.Start(ctx, svcName, pubSub, svc, cfg.ConfigPath, logger); err != nil {
+		logger.Error(fmt.Sprintf("failed to start rules engine consumer: %s", err))
+		exitCode = 1
+		return
+	}
+
+	hs := httpserver.NewServer(ctx, cancel, svcName, httpServerConfig, api.MakeHandler(svc, authn, logger, svcName, cfg.InstanceID), logger)
+
+	if cfg.SendTelemetry {
+		chc := chclient.New(svcName, supermq.Version, logger, cancel)
+		go chc.CallHome(ctx)
+	}
+
+	g.Go(func() error {
+		return hs.Start()
+	})
+
+	g.Go(func() error {
+		return server.StopSignalHandler(ctx, cancel, logger, svcName, hs)
+	})
+
+	if err := g.Wait(); err != nil {
+		logger.Error(fmt.Sprintf("%s service terminated: %s", svcName, err))
+	}
+}
+
+func newService(ctx context.Context, db *sqlx.DB, dbConfig pgclient.Config, authz smqauthz.Authorization, publisher, output messaging.Publisher, esURL string, cache *rules.Cache, tracer trace.Tracer, ec email.Config, logger *slog.Logger) (rules.Service, error) {
+	database := pgclient.NewDatabase(db, dbConfig, tracer)
+	repo := rulespg.NewRepository(database)
+
+	// Notify actions are optional, so the service starts without a notifier
+	// if the e-mail agent can't be created.
+	var notifier consumers.Notifier
+	agent, err := email.New(&ec)
+	if err != nil {
+		logger.Warn(fmt.Sprintf("failed to create email agent, notify actions are disabled: %s", err))
+	} else {
+		notifier = smtp.New(agent)
+	}
+
+	svc := rules.NewService(repo, uuid.New(), publisher, output, notifier, ec.FromAddress, cache)
+	svc, err = events.NewEventStoreMiddleware(ctx, svc, esURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init rules event store middleware: %w", err)
+	}
+	svc = middleware.AuthorizationMiddleware(svc, authz)
+	svc = middleware.Tracing(svc, tracer)
+	svc = middleware.LoggingMiddleware(svc, logger)
+	counter, latency := prometheus.MakeMetrics("rules", "api")
+	svc = middleware.MetricsMiddleware(svc, counter, latency)
+
+	return svc, nil
+}
@@ -14,18 +14,24 @@ import (
 
 	chclient "github.com/absmach/callhome/pkg/client"
 	"github.com/absmach/mgate/pkg/session"
-	"github.com/absmach/mgate/pkg/websockets"
 	"github.com/absmach/supermq"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/authn/authsvc"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	authsvcAuthz "github.com/absmach/supermq/pkg/authz/authsvc"
+	domainsAuthz "github.com/absmach/supermq/pkg/domains/grpcclient"
 	"github.com/absmach/supermq/pkg/grpcclient"
 	jaegerclient "github.com/absmach/supermq/pkg/jaeger"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/messaging/brokers"
 	brokerstracing "github.com/absmach/supermq/pkg/messaging/brokers/tracing"
+	"github.com/absmach/supermq/pkg/presence"
 	"github.com/absmach/supermq/pkg/prometheus"
+	"github.com/absmach/supermq/pkg/schema"
 	"github.com/absmach/supermq/pkg/server"
 	httpserver "github.com/absmach/supermq/pkg/server/http"
 	"github.com/absmach/supermq/pkg/uuid"
@@ -43,18 +49,22 @@ const (
 	envPrefixClients  = "SMQ_CLIENTS_AUTH_GRPC_"
 	envPrefixChannels = "SMQ_CHANNELS_GRPC_"
 	envPrefixAuth     = "SMQ_AUTH_GRPC_"
+	envPrefixDomains  = "SMQ_DOMAINS_GRPC_"
 	defSvcHTTPPort    = "8190"
 	targetWSPort      = "8191"
 	targetWSHost      = "localhost"
 )
 
 type config struct {
-	LogLevel      string  `env:"SMQ_WS_ADAPTER_LOG_LEVEL"    envDefault:"info"`
-	BrokerURL     string  `env:"SMQ_MESSAGE_BROKER_URL"      envDefault:"nats://localhost:4222"`
-	JaegerURL     url.URL `env:"SMQ_JAEGER_URL"              envDefault:"http://localhost:4318/v1/traces"`
-	SendTelemetry bool    `env:"SMQ_SEND_TELEMETRY"          envDefault:"true"`
-	InstanceID    string  `env:"SMQ_WS_ADAPTER_INSTANCE_ID"  envDefault:""`
-	TraceRatio    float64 `env:"SMQ_JAEGER_TRACE_RATIO"      envDefault:"1.0"`
+	LogLevel        string   `env:"SMQ_WS_ADAPTER_LOG_LEVEL"         envDefault:"info"`
+	BrokerURL       string   `env:"SMQ_MESSAGE_BROKER_URL"           envDefault:"nats://localhost:4222"`
+	ESURL           string   `env:"SMQ_ES_URL"                       envDefault:"nats://localhost:4222"`
+	JaegerURL       url.URL  `env:"SMQ_JAEGER_URL"                   envDefault:"http://localhost:4318/v1/traces"`
+	SendTelemetry   bool     `env:"SMQ_SEND_TELEMETRY"               envDefault:"true"`
+	InstanceID      string   `env:"SMQ_WS_ADAPTER_INSTANCE_ID"       envDefault:""`
+	TraceRatio      float64  `env:"SMQ_JAEGER_TRACE_RATIO"           envDefault:"1.0"`
+	SchemaCacheSize int      `env:"SMQ_WS_ADAPTER_SCHEMA_CACHE_SIZE" envDefault:"10000"`
+	TrustedProxies  []string `env:"SMQ_WS_ADAPTER_TRUSTED_PROXIES"   envDefault:""`
 }
 
 func main() {
@@ -89,6 +99,13 @@ func main() {
 		return
 	}
 
+	trustedProxies, err := lockout.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to parse trusted proxies: %s", err))
+		exitCode = 1
+		return
+	}
+
 	targetServerConfig := server.Config{
 		Port: targetWSPort,
 		Host: targetWSHost,
@@ -143,6 +160,29 @@ func main() {
 	defer authnHandler.Close()
 	logger.Info("authn successfully connected to auth gRPC server " + authnHandler.Secure())
 
+	domsGrpcCfg := grpcclient.Config{}
+	if err := env.ParseWithOptions(&domsGrpcCfg, env.Options{Prefix: envPrefixDomains}); err != nil {
+		logger.Error(fmt.Sprintf("failed to load domains gRPC client configuration : %s", err))
+		exitCode = 1
+		return
+	}
+	domAuthz, _, domainsHandler, err := domainsAuthz.NewAuthorization(ctx, domsGrpcCfg)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer domainsHandler.Close()
+
+	authz, authzHandler, err := authsvcAuthz.NewAuthorization(ctx, authnCfg, domAuthz)
+	if err != nil {
+		logger.Error(err.Error())
+		exitCode = 1
+		return
+	}
+	defer authzHandler.Close()
+	logger.Info("authz successfully connected to auth gRPC server " + authzHandler.Secure())
+
 	tp, err := jaegerclient.NewProvider(ctx, svcName, cfg.JaegerURL, cfg.InstanceID, cfg.TraceRatio)
 	if err != nil {
 		logger.Error(fmt.Sprintf("failed to init Jaeger: %s", err))
@@ -165,7 +205,14 @@ func main() {
 	defer nps.Close()
 	nps = brokerstracing.NewPubSub(targetServerConfig, tracer, nps)
 
-	svc := newService(clientsClient, channelsClient, nps, logger, tracer)
+	es, err := presence.NewEventStore(ctx, cfg.ESURL, presence.WSStream, cfg.InstanceID)
+	if err != nil {
+		logger.Error(fmt.Sprintf("failed to create %s event store : %s", svcName, err))
+		exitCode = 1
+		return
+	}
+
+	svc := newService(authn, authz, clientsClient, channelsClient, nps, logger, tracer)
 
 	hs := httpserver.NewServer(ctx, cancel, svcName, targetServerConfig, httpapi.MakeHandler(ctx, svc, logger, cfg.InstanceID), logger)
 
@@ -178,8 +225,9 @@ func main() {
 		g.Go(func() error {
 			return hs.Start()
 		})
-		handler := ws.NewHandler(nps, logger, authn, clientsClient, channelsClient)
-		return proxyWS(ctx, httpServerConfig, targetServerConfig, logger, handler)
+		schemas := schema.NewCache(cfg.SchemaCacheSize)
+		handler := ws.NewHandler(nps, es, logger, authn, authz, clientsClient, channelsClient, schemas)
+		return proxyWS(ctx, httpServerConfig, targetServerConfig, trustedProxies, logger, handler)
 	})
 
 	g.Go(func() error {
@@ -191,8 +239,8 @@ func main() {
 	}
 }
 
-func newService(clientsClient grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, nps messaging.PubSub, logger *slog.Logger, tracer trace.Tracer) ws.Service {
-	svc := ws.New(clientsClient, channels, nps)
+func newService(authn smqauthn.Authentication, authz smqauthz.Authorization, clientsClient grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, nps messaging.PubSub, logger *slog.Logger, tracer trace.Tracer) ws.Service {
+	svc := ws.New(authn, authz, clientsClient, channels, nps)
 	svc = tracing.New(tracer, svc)
 	svc = httpapi.LoggingMiddleware(svc, logger)
 	counter, latency := prometheus.MakeMetrics("ws_adapter", "api")
@@ -200,13 +248,10 @@ func newService(clientsClient grpcClientsV1.ClientsServiceClient, channels grpcC
 	return svc
 }
 
-func proxyWS(ctx context.Context, hostConfig, targetConfig server.Config, logger *slog.Logger, handler session.Handler) error {
+func proxyWS(ctx context.Context, hostConfig, targetConfig server.Config, trustedProxies lockout.TrustedProxies, logger *slog.Logger, handler session.Handler) error {
 	target := fmt.Sprintf("ws://%s:%s", targetConfig.Host, targetConfig.Port)
 	address := fmt.Sprintf("%s:%s", hostConfig.Host, hostConfig.Port)
-	wp, err := websockets.NewProxy(address, target, logger, handler)
-	if err != nil {
-		return err
-	}
+	wp := ws.NewProxy(address, target, handler, trustedProxies, logger)
 
 	errCh := make(chan error)
 
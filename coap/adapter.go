@@ -9,24 +9,36 @@ package coap
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"sync"
 
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	"github.com/absmach/supermq/pkg/certauth"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lastvalue"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/presence"
+	"github.com/absmach/supermq/pkg/schema"
 )
 
-var errFailedToDisconnectClient = errors.New("failed to disconnect client")
+var (
+	errFailedToDisconnectClient = errors.New("failed to disconnect client")
+	errFailedPublishConnect     = errors.New("failed to publish connect event")
+	errFailedPublishDisconnect  = errors.New("failed to publish disconnect event")
+)
 
 const chansPrefix = "channels"
 
 // Service specifies CoAP service API.
 type Service interface {
 	// Publish publishes message to specified channel.
-	// Key is used to authorize publisher.
+	// Key is used to authorize publisher. The clients which present the
+	// certificate are identified by it, and the key is not used.
 	Publish(ctx context.Context, key string, msg *messaging.Message) error
 
 	// Subscribes to channel with specified id, subtopic and adds subscription to
@@ -38,41 +50,59 @@ type Service interface {
 
 	// DisconnectHandler method is used to disconnected the client
 	DisconnectHandler(ctx context.Context, chanID, subptopic, token string) error
+
+	// LastValue returns the last message published on the channel with
+	// specified id and subtopic. Key is used to authorize subscriber.
+	LastValue(ctx context.Context, key, chanID, subtopic string) (*messaging.Message, error)
 }
 
 var _ Service = (*adapterService)(nil)
 
 // Observers is a map of maps,.
 type adapterService struct {
-	clients  grpcClientsV1.ClientsServiceClient
-	channels grpcChannelsV1.ChannelsServiceClient
-	pubsub   messaging.PubSub
+	clients    grpcClientsV1.ClientsServiceClient
+	channels   grpcChannelsV1.ChannelsServiceClient
+	schemas    schema.Cache
+	lastValues lastvalue.Store
+	pubsub     messaging.PubSub
+	certs      certauth.Authenticator
+	es         presence.EventStore
+	logger     *slog.Logger
+	// sessions contains the observe sessions by their tokens, since the
+	// client is not authenticated when the observation is cancelled on
+	// disconnect.
+	sessions map[string]presence.Session
+	mu       sync.Mutex
 }
 
-// New instantiates the CoAP adapter implementation.
-func New(clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, pubsub messaging.PubSub) Service {
+// New instantiates the CoAP adapter implementation. The clients are
+// authenticated using the client certificates if the certs authenticator is
+// set, and using the client key otherwise. The observe sessions of the
+// clients are reported to the event store.
+func New(clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, schemas schema.Cache, lastValues lastvalue.Store, pubsub messaging.PubSub, certs certauth.Authenticator, es presence.EventStore, logger *slog.Logger) Service {
 	as := &adapterService{
-		clients:  clients,
-		channels: channels,
-		pubsub:   pubsub,
+		clients:    clients,
+		channels:   channels,
+		schemas:    schemas,
+		lastValues: lastValues,
+		pubsub:     pubsub,
+		certs:      certs,
+		es:         es,
+		logger:     logger,
+		sessions:   make(map[string]presence.Session),
 	}
 
 	return as
 }
 
 func (svc *adapterService) Publish(ctx context.Context, key string, msg *messaging.Message) error {
-	authnRes, err := svc.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{
-		ClientSecret: key,
-	})
+	clientID, err := svc.authenticate(ctx, key)
 	if err != nil {
-		return errors.Wrap(svcerr.ErrAuthentication, err)
-	}
-	if !authnRes.Authenticated {
-		return svcerr.ErrAuthentication
+		return err
 	}
 
 	authzRes, err := svc.channels.Authorize(ctx, &grpcChannelsV1.AuthzReq{
-		ClientId:   authnRes.GetId(),
+		ClientId:   clientID,
 		ClientType: policies.ClientType,
 		Type:       uint32(connections.Publish),
 		ChannelId:  msg.GetChannel(),
@@ -84,23 +114,21 @@ func (svc *adapterService) Publish(ctx context.Context, key string, msg *messagi
 		return svcerr.ErrAuthorization
 	}
 
-	msg.Publisher = authnRes.GetId()
+	if err := svc.schemas.Validate(msg.GetChannel(), authzRes.GetSchema(), msg.GetPayload()); err != nil {
+		return err
+	}
+
+	msg.Publisher = clientID
 
 	return svc.pubsub.Publish(ctx, msg.GetChannel(), msg)
 }
 
 func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic string, c Client) error {
-	authnRes, err := svc.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{
-		ClientSecret: key,
-	})
+	clientID, err := svc.authenticate(ctx, key)
 	if err != nil {
-		return errors.Wrap(svcerr.ErrAuthentication, err)
-	}
-	if !authnRes.Authenticated {
-		return svcerr.ErrAuthentication
+		return err
 	}
 
-	clientID := authnRes.GetId()
 	authzRes, err := svc.channels.Authorize(ctx, &grpcChannelsV1.AuthzReq{
 		ClientId:   clientID,
 		ClientType: policies.ClientType,
@@ -125,23 +153,35 @@ func (svc *adapterService) Subscribe(ctx context.Context, key, chanID, subtopic
 		Topic:   subject,
 		Handler: authzc,
 	}
-	return svc.pubsub.Subscribe(ctx, subCfg)
+	if err := svc.pubsub.Subscribe(ctx, subCfg); err != nil {
+		return err
+	}
+
+	s := presence.Session{
+		ID:         c.Token(),
+		ClientID:   clientID,
+		Protocol:   presence.CoAP,
+		RemoteAddr: c.RemoteAddr(),
+	}
+	svc.mu.Lock()
+	svc.sessions[s.ID] = s
+	svc.mu.Unlock()
+	if err := svc.es.Connect(ctx, s); err != nil {
+		svc.logger.Error(errors.Wrap(errFailedPublishConnect, err).Error())
+	}
+
+	return nil
 }
 
 func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopic, token string) error {
-	authnRes, err := svc.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{
-		ClientSecret: key,
-	})
+	clientID, err := svc.authenticate(ctx, key)
 	if err != nil {
-		return errors.Wrap(svcerr.ErrAuthentication, err)
-	}
-	if !authnRes.Authenticated {
-		return svcerr.ErrAuthentication
+		return err
 	}
 
 	authzRes, err := svc.channels.Authorize(ctx, &grpcChannelsV1.AuthzReq{
 		DomainId:   "",
-		ClientId:   authnRes.GetId(),
+		ClientId:   clientID,
 		ClientType: policies.ClientType,
 		Type:       uint32(connections.Subscribe),
 		ChannelId:  chanID,
@@ -158,7 +198,11 @@ func (svc *adapterService) Unsubscribe(ctx context.Context, key, chanID, subtopi
 		subject = fmt.Sprintf("%s.%s", subject, subtopic)
 	}
 
-	return svc.pubsub.Unsubscribe(ctx, token, subject)
+	if err := svc.pubsub.Unsubscribe(ctx, token, subject); err != nil {
+		return err
+	}
+
+	return svc.disconnect(ctx, token)
 }
 
 func (svc *adapterService) DisconnectHandler(ctx context.Context, chanID, subtopic, token string) error {
@@ -167,7 +211,87 @@ func (svc *adapterService) DisconnectHandler(ctx context.Context, chanID, subtop
 		subject = fmt.Sprintf("%s.%s", subject, subtopic)
 	}
 
-	return svc.pubsub.Unsubscribe(ctx, token, subject)
+	if err := svc.pubsub.Unsubscribe(ctx, token, subject); err != nil {
+		return err
+	}
+
+	return svc.disconnect(ctx, token)
+}
+
+// disconnect reports the end of the observe session with the given token.
+// The session is reported once, either on the cancellation of the
+// observation or on the connection close.
+func (svc *adapterService) disconnect(ctx context.Context, token string) error {
+	svc.mu.Lock()
+	s, ok := svc.sessions[token]
+	delete(svc.sessions, token)
+	svc.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if err := svc.es.Disconnect(ctx, s); err != nil {
+		return errors.Wrap(errFailedPublishDisconnect, err)
+	}
+
+	return nil
+}
+
+func (svc *adapterService) LastValue(ctx context.Context, key, chanID, subtopic string) (*messaging.Message, error) {
+	clientID, err := svc.authenticate(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	authzRes, err := svc.channels.Authorize(ctx, &grpcChannelsV1.AuthzReq{
+		ClientId:   clientID,
+		ClientType: policies.ClientType,
+		Type:       uint32(connections.Subscribe),
+		ChannelId:  chanID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !authzRes.Authorized {
+		return nil, svcerr.ErrAuthorization
+	}
+
+	msg, err := svc.lastValues.Retrieve(ctx, chanID, subtopic)
+	if err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return nil, errors.Wrap(svcerr.ErrNotFound, err)
+		}
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return msg, nil
+}
+
+// authenticate returns the ID of the client identified by the certificate,
+// or by the key if the client didn't present the certificate and the certs
+// authenticator allows the fallback.
+func (svc *adapterService) authenticate(ctx context.Context, key string) (string, error) {
+	if svc.certs != nil {
+		clientID, err := svc.certs.Authenticate(ctx, certauth.FromContext(ctx))
+		if err != nil {
+			return "", err
+		}
+		if clientID != "" {
+			return clientID, nil
+		}
+	}
+
+	authnRes, err := svc.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{
+		ClientSecret: key,
+	})
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if !authnRes.Authenticated {
+		return "", svcerr.ErrAuthentication
+	}
+
+	return authnRes.GetId(), nil
 }
 
 type authzClient interface {
@@ -114,3 +114,25 @@ func (lm *loggingMiddleware) DisconnectHandler(ctx context.Context, chanID, subt
 
 	return lm.svc.DisconnectHandler(ctx, chanID, subtopic, token)
 }
+
+// LastValue logs the last value request. It logs the channel ID, subtopic (if any) and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) LastValue(ctx context.Context, key, chanID, subtopic string) (msg *messaging.Message, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", chanID),
+		}
+		if subtopic != "" {
+			args = append(args, slog.String("subtopic", subtopic))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Retrieve last value failed", args...)
+			return
+		}
+		lm.logger.Info("Retrieve last value completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.LastValue(ctx, key, chanID, subtopic)
+}
@@ -70,3 +70,13 @@ func (mm *metricsMiddleware) DisconnectHandler(ctx context.Context, chanID, subt
 
 	return mm.svc.DisconnectHandler(ctx, chanID, subtopic, token)
 }
+
+// LastValue instruments LastValue method with metrics.
+func (mm *metricsMiddleware) LastValue(ctx context.Context, key, chanID, subtopic string) (*messaging.Message, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "last_value").Add(1)
+		mm.latency.With("method", "last_value").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.LastValue(ctx, key, chanID, subtopic)
+}
@@ -4,10 +4,13 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"crypto/x509"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"regexp"
@@ -16,10 +19,14 @@ import (
 
 	"github.com/absmach/supermq"
 	"github.com/absmach/supermq/coap"
+	"github.com/absmach/supermq/pkg/certauth"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/schema"
 	"github.com/go-chi/chi/v5"
+	piondtls "github.com/pion/dtls/v3"
 	"github.com/plgd-dev/go-coap/v3/message"
 	"github.com/plgd-dev/go-coap/v3/message/codes"
 	"github.com/plgd-dev/go-coap/v3/message/pool"
@@ -88,8 +95,10 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 		resp.SetCode(codes.BadRequest)
 		return
 	}
+	// The clients which present the certificate don't need the auth query.
+	cert := clientCert(w.Conn())
 	key, err := parseKey(m)
-	if err != nil {
+	if err != nil && cert.SerialNumber == nil {
 		logger.Warn(fmt.Sprintf("Error parsing auth: %s", err))
 		resp.SetCode(codes.Unauthorized)
 		return
@@ -98,10 +107,10 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 	switch m.Code() {
 	case codes.GET:
 		resp.SetCode(codes.Content)
-		err = handleGet(m, w, msg, key)
+		err = handleGet(m, w, resp, msg, key, cert)
 	case codes.POST:
 		resp.SetCode(codes.Created)
-		err = service.Publish(m.Context(), key, msg)
+		err = service.Publish(newContext(m.Context(), w.Conn(), cert), key, msg)
 	default:
 		err = errMethodNotAllowed
 	}
@@ -116,27 +125,53 @@ func handler(w mux.ResponseWriter, m *mux.Message) {
 			resp.SetCode(codes.Forbidden)
 		case errors.Contains(err, svcerr.ErrAuthentication):
 			resp.SetCode(codes.Unauthorized)
+		case errors.Contains(err, svcerr.ErrNotFound):
+			resp.SetCode(codes.NotFound)
+		case errors.Contains(err, schema.ErrInvalidPayload):
+			resp.SetCode(codes.BadRequest)
 		default:
 			resp.SetCode(codes.InternalServerError)
 		}
 	}
 }
 
-func handleGet(m *mux.Message, w mux.ResponseWriter, msg *messaging.Message, key string) error {
+func handleGet(m *mux.Message, w mux.ResponseWriter, resp *pool.Message, msg *messaging.Message, key string, cert x509.Certificate) error {
 	var obs uint32
 	obs, err := m.Options().Observe()
+	if err == message.ErrOptionNotFound {
+		return handleLastValue(newContext(m.Context(), w.Conn(), cert), resp, msg, key)
+	}
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Error reading observe option: %s", err))
 		return errBadOptions
 	}
+	ctx := newContext(w.Conn().Context(), w.Conn(), cert)
 	if obs == startObserve {
 		c := coap.NewClient(w.Conn(), m.Token(), logger)
 		w.Conn().AddOnClose(func() {
 			_ = service.DisconnectHandler(context.Background(), msg.GetChannel(), msg.GetSubtopic(), c.Token())
 		})
-		return service.Subscribe(w.Conn().Context(), key, msg.GetChannel(), msg.GetSubtopic(), c)
+		return service.Subscribe(ctx, key, msg.GetChannel(), msg.GetSubtopic(), c)
+	}
+	return service.Unsubscribe(ctx, key, msg.GetChannel(), msg.GetSubtopic(), m.Token().String())
+}
+
+// handleLastValue responds to the GET request without Observe option with the
+// last message published on the requested channel topic.
+func handleLastValue(ctx context.Context, resp *pool.Message, msg *messaging.Message, key string) error {
+	lv, err := service.LastValue(ctx, key, msg.GetChannel(), msg.GetSubtopic())
+	if err != nil {
+		return err
 	}
-	return service.Unsubscribe(w.Conn().Context(), key, msg.GetChannel(), msg.GetSubtopic(), m.Token().String())
+
+	cf := message.TextPlain
+	if mt, err := message.ToMediaType(lv.GetHeaders()[messaging.ContentTypeHeader]); err == nil {
+		cf = mt
+	}
+	resp.SetContentFormat(cf)
+	resp.SetBody(bytes.NewReader(lv.GetPayload()))
+
+	return nil
 }
 
 func decodeMessage(msg *mux.Message) (*messaging.Message, error) {
@@ -163,6 +198,9 @@ func decodeMessage(msg *mux.Message) (*messaging.Message, error) {
 		Payload:  []byte{},
 		Created:  time.Now().UnixNano(),
 	}
+	if cf, err := msg.Options().ContentFormat(); err == nil {
+		ret.Headers = map[string]string{messaging.ContentTypeHeader: cf.String()}
+	}
 
 	if msg.Body() != nil {
 		buff, err := io.ReadAll(msg.Body())
@@ -174,6 +212,42 @@ func decodeMessage(msg *mux.Message) (*messaging.Message, error) {
 	return ret, nil
 }
 
+// clientCert returns the verified certificate of the client connected over
+// DTLS, or the empty certificate if the client didn't present one.
+// newContext returns the request context carrying the client certificate and
+// the client address, which limits the failed authentication attempts.
+func newContext(ctx context.Context, conn mux.Conn, cert x509.Certificate) context.Context {
+	ctx = certauth.NewContext(ctx, cert)
+	addr := conn.RemoteAddr()
+	if addr == nil {
+		return ctx
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return ctx
+	}
+
+	return lockout.WithSource(ctx, host)
+}
+
+func clientCert(conn mux.Conn) x509.Certificate {
+	dtlsConn, ok := conn.NetConn().(*piondtls.Conn)
+	if !ok {
+		return x509.Certificate{}
+	}
+	state, ok := dtlsConn.ConnectionState()
+	if !ok || len(state.PeerCertificates) == 0 {
+		return x509.Certificate{}
+	}
+	cert, err := x509.ParseCertificate(state.PeerCertificates[0])
+	if err != nil {
+		logger.Warn(fmt.Sprintf("Error parsing client certificate: %s", err))
+		return x509.Certificate{}
+	}
+
+	return *cert
+}
+
 func parseKey(msg *mux.Message) (string, error) {
 	authKey, err := msg.Options().GetString(message.URIQuery)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log/slog"
 	"sync/atomic"
+	"time"
 
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/messaging"
@@ -29,6 +30,9 @@ type Client interface {
 
 	// Done returns a channel that's closed when the client is done.
 	Done() <-chan struct{}
+
+	// RemoteAddr returns the address of the client.
+	RemoteAddr() string
 }
 
 // ErrOption indicates an error when adding an option.
@@ -66,11 +70,18 @@ func (c *client) Cancel() error {
 	return c.conn.Close()
 }
 
+func (c *client) RemoteAddr() string {
+	return c.conn.RemoteAddr().String()
+}
+
 func (c *client) Token() string {
 	return c.token.String()
 }
 
 func (c *client) Handle(msg *messaging.Message) error {
+	if messaging.Expired(msg, time.Now()) {
+		return nil
+	}
 	pm := c.conn.AcquireMessage(c.conn.Context())
 	defer c.conn.ReleaseMessage(pm)
 	pm.SetCode(codes.Content)
@@ -78,12 +89,16 @@ func (c *client) Handle(msg *messaging.Message) error {
 	pm.SetBody(bytes.NewReader(msg.GetPayload()))
 
 	atomic.AddUint32(&c.observe, 1)
+	cf := message.TextPlain
+	if mt, err := message.ToMediaType(msg.GetHeaders()[messaging.ContentTypeHeader]); err == nil {
+		cf = mt
+	}
 	var opts message.Options
 	var buff []byte
-	opts, n, err := opts.SetContentFormat(buff, message.TextPlain)
+	opts, n, err := opts.SetContentFormat(buff, cf)
 	if err == message.ErrTooSmall {
 		buff = append(buff, make([]byte, n)...)
-		_, _, err = opts.SetContentFormat(buff, message.TextPlain)
+		_, _, err = opts.SetContentFormat(buff, cf)
 	}
 	if err != nil {
 		c.logger.Error(fmt.Sprintf("Can't set content format: %s.", err))
@@ -20,6 +20,7 @@ const (
 	subscribeOP         = "subscribe_op"
 	unsubscribeOP       = "unsubscribe_op"
 	disconnectHandlerOp = "disconnect_handler_op"
+	lastValueOp         = "last_value_op"
 )
 
 // tracingServiceMiddleware is a middleware implementation for tracing CoAP service operations using OpenTelemetry.
@@ -72,3 +73,13 @@ func (tm *tracingServiceMiddleware) DisconnectHandler(ctx context.Context, chanI
 	defer span.End()
 	return tm.svc.DisconnectHandler(ctx, chanID, subptopic, token)
 }
+
+// LastValue traces a CoAP last value retrieval operation.
+func (tm *tracingServiceMiddleware) LastValue(ctx context.Context, key, chanID, subtopic string) (*messaging.Message, error) {
+	ctx, span := tm.tracer.Start(ctx, lastValueOp, trace.WithAttributes(
+		attribute.String("channel_id", chanID),
+		attribute.String("subtopic", subtopic),
+	))
+	defer span.End()
+	return tm.svc.LastValue(ctx, key, chanID, subtopic)
+}
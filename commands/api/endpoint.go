@@ -0,0 +1,95 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/commands"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func sendCommandEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(sendCommandReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		cmd := commands.Command{
+			ChannelID:   req.ChannelID,
+			ClientID:    req.ClientID,
+			Payload:     req.Payload,
+			ContentType: req.ContentType,
+		}
+		cmd, err := svc.SendCommand(ctx, session, cmd, req.timeout(), req.wait)
+		if err != nil {
+			return nil, err
+		}
+
+		return commandRes{Command: cmd, created: true}, nil
+	}
+}
+
+func viewCommandEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(commandReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		cmd, err := svc.ViewCommand(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return commandRes{Command: cmd}, nil
+	}
+}
+
+func listCommandsEndpoint(svc commands.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listCommandsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		page, err := svc.ListCommands(ctx, session, req.PageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		res := commandsPageRes{
+			Offset:   page.Offset,
+			Limit:    page.Limit,
+			Total:    page.Total,
+			Commands: page.Commands,
+		}
+		if res.Commands == nil {
+			res.Commands = []commands.Command{}
+		}
+
+		return res, nil
+	}
+}
@@ -0,0 +1,417 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/commands"
+	"github.com/absmach/supermq/commands/api"
+	"github.com/absmach/supermq/commands/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	contentType  = "application/json"
+	validToken   = "valid-token"
+	invalidToken = "invalid-token"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+var (
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	userID    = testsutil.GenerateUUID(&testing.T{})
+	chanID    = testsutil.GenerateUUID(&testing.T{})
+	commandID = testsutil.GenerateUUID(&testing.T{})
+	session   = smqauthn.Session{UserID: userID}
+	command   = commands.Command{
+		ID:        commandID,
+		DomainID:  domainID,
+		ChannelID: chanID,
+		Payload:   `{"cmd":"reboot"}`,
+		Status:    commands.DeliveredStatus,
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+type respBody struct {
+	Err      string             `json:"error"`
+	Message  string             `json:"message"`
+	ID       string             `json:"id"`
+	Status   commands.Status    `json:"status"`
+	Total    uint64             `json:"total"`
+	Commands []commands.Command `json:"commands"`
+}
+
+func newServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	logger := smqlog.NewMock()
+	mux := api.MakeHandler(svc, authn, logger, "test", instanceID)
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func toJSON(data interface{}) string {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return string(jsonData)
+}
+
+func decodeBody(t *testing.T, res *http.Response) (respBody, error) {
+	var body respBody
+	err := json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error while decoding response body: %s", err))
+	if body.Err != "" || body.Message != "" {
+		return body, errors.Wrap(errors.New(body.Err), errors.New(body.Message))
+	}
+
+	return body, nil
+}
+
+func commandBody(chanID, payload string, timeout uint64) string {
+	return toJSON(map[string]interface{}{
+		"channel_id": chanID,
+		"payload":    payload,
+		"timeout":    timeout,
+	})
+}
+
+func TestSendCommand(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		contentType string
+		query       string
+		req         string
+		timeout     time.Duration
+		wait        bool
+		authnErr    error
+		svcRes      commands.Command
+		svcErr      error
+		status      int
+		location    string
+		err         error
+	}{
+		{
+			desc:        "send command successfully",
+			token:       validToken,
+			contentType: contentType,
+			req:         commandBody(chanID, command.Payload, 10),
+			timeout:     10 * time.Second,
+			svcRes:      command,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/commands/%s", commandID),
+		},
+		{
+			desc:        "send command with default timeout",
+			token:       validToken,
+			contentType: contentType,
+			req:         commandBody(chanID, command.Payload, 0),
+			timeout:     30 * time.Second,
+			svcRes:      command,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/commands/%s", commandID),
+		},
+		{
+			desc:        "send command and wait for reply",
+			token:       validToken,
+			contentType: contentType,
+			query:       "?wait=true",
+			req:         commandBody(chanID, command.Payload, 10),
+			timeout:     10 * time.Second,
+			wait:        true,
+			svcRes:      commands.Command{ID: commandID, Status: commands.RepliedStatus},
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/commands/%s", commandID),
+		},
+		{
+			desc:        "send command with invalid wait query",
+			token:       validToken,
+			contentType: contentType,
+			query:       "?wait=invalid",
+			req:         commandBody(chanID, command.Payload, 10),
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "send command with invalid token",
+			token:       invalidToken,
+			contentType: contentType,
+			req:         commandBody(chanID, command.Payload, 10),
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "send command with invalid content type",
+			token:       validToken,
+			contentType: "application/xml",
+			req:         commandBody(chanID, command.Payload, 10),
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "send command with invalid request body",
+			token:       validToken,
+			contentType: contentType,
+			req:         "{",
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "send command without channel",
+			token:       validToken,
+			contentType: contentType,
+			req:         commandBody("", command.Payload, 10),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "send command without payload",
+			token:       validToken,
+			contentType: contentType,
+			req:         commandBody(chanID, "", 10),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "send command with timeout exceeding max",
+			token:       validToken,
+			contentType: contentType,
+			req:         commandBody(chanID, command.Payload, 301),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "send command without channel permission",
+			token:       validToken,
+			contentType: contentType,
+			req:         commandBody(chanID, command.Payload, 10),
+			timeout:     10 * time.Second,
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/commands%s", ts.URL, domainID, tc.query),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("SendCommand", mock.Anything, mock.Anything, mock.Anything, tc.timeout, tc.wait).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
+			if tc.err == nil {
+				assert.Equal(t, commandID, body.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, commandID, body.ID))
+				assert.Equal(t, tc.svcRes.Status, body.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.svcRes.Status, body.Status))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewCommand(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnErr error
+		svcRes   commands.Command
+		svcErr   error
+		status   int
+		err      error
+	}{
+		{
+			desc:   "view command successfully",
+			token:  validToken,
+			id:     commandID,
+			svcRes: command,
+			status: http.StatusOK,
+		},
+		{
+			desc:     "view command with invalid token",
+			token:    invalidToken,
+			id:       commandID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "view non-existing command",
+			token:  validToken,
+			id:     commandID,
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/commands/%s", ts.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("ViewCommand", mock.Anything, mock.Anything, tc.id).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.err == nil {
+				assert.Equal(t, tc.svcRes.ID, body.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, tc.svcRes.ID, body.ID))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListCommands(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       commands.PageMeta
+		authnErr error
+		svcRes   commands.Page
+		svcErr   error
+		status   int
+		total    uint64
+		err      error
+	}{
+		{
+			desc:   "list commands successfully",
+			token:  validToken,
+			pm:     commands.PageMeta{Offset: 0, Limit: 10},
+			svcRes: commands.Page{Total: 1, Commands: []commands.Command{command}},
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:   "list commands filtered by channel and status",
+			token:  validToken,
+			query:  "?channel_id=" + chanID + "&status=delivered",
+			pm:     commands.PageMeta{Offset: 0, Limit: 10, ChannelID: chanID, Status: commands.DeliveredStatus},
+			svcRes: commands.Page{Total: 1, Commands: []commands.Command{command}},
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:     "list commands with invalid token",
+			token:    invalidToken,
+			pm:       commands.PageMeta{Offset: 0, Limit: 10},
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "list commands with invalid status",
+			token:  validToken,
+			query:  "?status=unknown",
+			status: http.StatusBadRequest,
+			err:    errors.ErrMalformedEntity,
+		},
+		{
+			desc:   "list commands with limit exceeding max",
+			token:  validToken,
+			query:  "?limit=101",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrLimitSize,
+		},
+		{
+			desc:   "list commands with invalid offset",
+			token:  validToken,
+			query:  "?offset=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/commands%s", ts.URL, domainID, tc.query),
+				token:  tc.token,
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("ListCommands", mock.Anything, mock.Anything, tc.pm).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/commands"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const (
+	maxLimitSize = 100
+
+	// defTimeout is used for the commands sent without timeout.
+	defTimeout = 30 * time.Second
+	maxTimeout = 5 * time.Minute
+)
+
+var errInvalidTimeout = errors.New("invalid command timeout")
+
+type sendCommandReq struct {
+	ChannelID   string `json:"channel_id"`
+	ClientID    string `json:"client_id,omitempty"`
+	Payload     string `json:"payload"`
+	ContentType string `json:"content_type,omitempty"`
+	// Timeout is the command timeout in seconds.
+	Timeout uint64 `json:"timeout,omitempty"`
+	wait    bool
+}
+
+func (req sendCommandReq) validate() error {
+	if req.ChannelID == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrMissingChannelID)
+	}
+	if req.Payload == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrEmptyMessage)
+	}
+	if req.timeout() > maxTimeout {
+		return errors.Wrap(errors.ErrMalformedEntity, errInvalidTimeout)
+	}
+
+	return nil
+}
+
+func (req sendCommandReq) timeout() time.Duration {
+	if req.Timeout == 0 {
+		return defTimeout
+	}
+
+	return time.Duration(req.Timeout) * time.Second
+}
+
+type commandReq struct {
+	id string
+}
+
+func (req commandReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listCommandsReq struct {
+	commands.PageMeta
+}
+
+func (req listCommandsReq) validate() error {
+	if req.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	if req.Status != "" {
+		if err := req.Status.Validate(); err != nil {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return nil
+}
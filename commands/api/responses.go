@@ -0,0 +1,63 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/commands"
+)
+
+var (
+	_ supermq.Response = (*commandRes)(nil)
+	_ supermq.Response = (*commandsPageRes)(nil)
+)
+
+type commandRes struct {
+	commands.Command
+	created bool
+}
+
+func (res commandRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res commandRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/commands/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res commandRes) Empty() bool {
+	return false
+}
+
+type commandsPageRes struct {
+	Offset   uint64             `json:"offset"`
+	Limit    uint64             `json:"limit"`
+	Total    uint64             `json:"total"`
+	Commands []commands.Command `json:"commands"`
+}
+
+func (res commandsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res commandsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res commandsPageRes) Empty() bool {
+	return false
+}
@@ -0,0 +1,126 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/supermq"
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/commands"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	commandIDKey = "commandID"
+	channelKey   = "channel_id"
+	waitKey      = "wait"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc commands.Service, authn smqauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/{domainID}/commands", func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			sendCommandEndpoint(svc),
+			decodeSendCommand,
+			api.EncodeResponse,
+			opts...,
+		), "send_command").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listCommandsEndpoint(svc),
+			decodeListCommands,
+			api.EncodeResponse,
+			opts...,
+		), "list_commands").ServeHTTP)
+
+		r.Get("/{commandID}", otelhttp.NewHandler(kithttp.NewServer(
+			viewCommandEndpoint(svc),
+			decodeCommand,
+			api.EncodeResponse,
+			opts...,
+		), "view_command").ServeHTTP)
+	})
+
+	mux.Get("/health", supermq.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeSendCommand(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	wait, err := apiutil.ReadBoolQuery(r, waitKey, false)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := sendCommandReq{
+		wait: wait,
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeCommand(_ context.Context, r *http.Request) (interface{}, error) {
+	req := commandReq{
+		id: chi.URLParam(r, commandIDKey),
+	}
+
+	return req, nil
+}
+
+func decodeListCommands(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	chanID, err := apiutil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	status, err := apiutil.ReadStringQuery(r, api.StatusKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listCommandsReq{
+		PageMeta: commands.PageMeta{
+			Offset:    offset,
+			Limit:     limit,
+			ChannelID: chanID,
+			Status:    commands.Status(status),
+		},
+	}
+
+	return req, nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+const (
+	// Subtopic is the reserved channel subtopic commands are published to.
+	// A command is published on the "commands.<command_id>" subtopic.
+	Subtopic = "commands"
+
+	// ReplySubtopic is appended to the command subtopic by the clients
+	// replying to the command, i.e. "commands.<command_id>.reply".
+	ReplySubtopic = "reply"
+
+	// ReplyTopic is the message broker topic of the command replies.
+	ReplyTopic = "channels.*." + Subtopic + ".*." + ReplySubtopic
+
+	// Protocol is set on the command messages published by the service.
+	Protocol = "commands"
+)
+
+var (
+	// ErrInvalidStatus indicates an unsupported command status.
+	ErrInvalidStatus = errors.New("invalid command status")
+
+	// ErrInvalidReply indicates a reply which doesn't match the command.
+	ErrInvalidReply = errors.New("invalid command reply")
+
+	// ErrCommandExpired indicates a reply received after the command timed out.
+	ErrCommandExpired = errors.New("command timed out")
+)
+
+// Status represents the command delivery status.
+type Status string
+
+// Command statuses.
+const (
+	// PendingStatus is the status of the command which is not published yet.
+	PendingStatus Status = "pending"
+	// DeliveredStatus is the status of the command published to the channel.
+	DeliveredStatus Status = "delivered"
+	// RepliedStatus is the status of the command the client replied to.
+	RepliedStatus Status = "replied"
+	// TimedOutStatus is the status of the command which wasn't replied to in time.
+	TimedOutStatus Status = "timed-out"
+)
+
+// Validate checks whether the status is supported.
+func (s Status) Validate() error {
+	switch s {
+	case PendingStatus, DeliveredStatus, RepliedStatus, TimedOutStatus:
+		return nil
+	default:
+		return ErrInvalidStatus
+	}
+}
+
+// Command represents a command sent to the clients connected to a channel.
+type Command struct {
+	ID        string `json:"id"`
+	DomainID  string `json:"domain_id"`
+	ChannelID string `json:"channel_id"`
+
+	// ClientID is the optional command target. If set, only the replies
+	// published by the client are accepted.
+	ClientID string `json:"client_id,omitempty"`
+
+	Payload     string    `json:"payload"`
+	ContentType string    `json:"content_type,omitempty"`
+	Response    string    `json:"response,omitempty"`
+	Status      Status    `json:"status"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   string    `json:"created_by"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+// Topic returns the channel subtopic the command is published to.
+func (cmd Command) Topic() string {
+	return Subtopic + "." + cmd.ID
+}
+
+// ResponseTopic returns the topic the clients publish the command reply to.
+func (cmd Command) ResponseTopic() string {
+	return "channels/" + cmd.ChannelID + "/messages/" + Subtopic + "/" + cmd.ID + "/" + ReplySubtopic
+}
+
+// PageMeta contains page metadata that helps navigation.
+type PageMeta struct {
+	Offset    uint64 `json:"offset" db:"offset"`
+	Limit     uint64 `json:"limit" db:"limit"`
+	DomainID  string `json:"domain_id,omitempty" db:"domain_id"`
+	ChannelID string `json:"channel_id,omitempty" db:"channel_id"`
+	Status    Status `json:"status,omitempty" db:"status"`
+}
+
+// Page represents a page of commands.
+type Page struct {
+	PageMeta
+	Total    uint64    `json:"total"`
+	Commands []Command `json:"commands"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// SendCommand publishes the command to the command channel. The command
+	// times out if it's not replied to within the timeout. If wait is set,
+	// SendCommand blocks until the command is replied to or times out.
+	SendCommand(ctx context.Context, session authn.Session, cmd Command, timeout time.Duration, wait bool) (Command, error)
+
+	// ViewCommand retrieves the command with the given ID.
+	ViewCommand(ctx context.Context, session authn.Session, id string) (Command, error)
+
+	// ListCommands retrieves the commands of the session domain.
+	ListCommands(ctx context.Context, session authn.Session, pm PageMeta) (Page, error)
+
+	// Reply handles the client reply to the command.
+	Reply(ctx context.Context, msg *messaging.Message) error
+}
+
+// Repository specifies a command persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the command.
+	Save(ctx context.Context, cmd Command) (Command, error)
+
+	// Retrieve retrieves the command with the given ID.
+	Retrieve(ctx context.Context, id string) (Command, error)
+
+	// Update updates the command status and response if the current command
+	// status is one of the given statuses. It returns the not found error
+	// if the command doesn't exist or its status doesn't match.
+	Update(ctx context.Context, cmd Command, statuses ...Status) (Command, error)
+
+	// RetrieveAll retrieves the commands matching the page metadata.
+	RetrieveAll(ctx context.Context, pm PageMeta) (Page, error)
+}
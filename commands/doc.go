@@ -0,0 +1,9 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package commands contains the device commands service. The service sends
+// commands to clients over channels and correlates the client replies with
+// the commands, which provides a request/response facility on top of the
+// fire-and-forget channel messaging. Commands are tracked by command records,
+// so the replies can be awaited synchronously or polled for.
+package commands
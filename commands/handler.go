@@ -0,0 +1,34 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+var _ messaging.MessageHandler = (*replyHandler)(nil)
+
+type replyHandler struct {
+	ctx context.Context
+	svc Service
+}
+
+// NewReplyHandler returns the message handler which passes the command
+// replies received from the message broker to the service.
+func NewReplyHandler(ctx context.Context, svc Service) messaging.MessageHandler {
+	return &replyHandler{
+		ctx: ctx,
+		svc: svc,
+	}
+}
+
+func (h *replyHandler) Handle(msg *messaging.Message) error {
+	return h.svc.Reply(h.ctx, msg)
+}
+
+func (h *replyHandler) Cancel() error {
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/commands"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/policies"
+)
+
+const (
+	readPermission    = "read_permission"
+	publishPermission = "publish_permission"
+)
+
+var (
+	errDomainViewCommands = errors.New("not authorized to view commands in domain")
+	errChannelPublish     = errors.New("not authorized to publish to command channel")
+)
+
+var _ commands.Service = (*authorizationMiddleware)(nil)
+
+type authorizationMiddleware struct {
+	svc   commands.Service
+	authz smqauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the commands service.
+func AuthorizationMiddleware(svc commands.Service, authz smqauthz.Authorization) commands.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) SendCommand(ctx context.Context, session smqauthn.Session, cmd commands.Command, timeout time.Duration, wait bool) (commands.Command, error) {
+	if err := am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  publishPermission,
+		ObjectType:  policies.ChannelType,
+		Object:      cmd.ChannelID,
+	}); err != nil {
+		return commands.Command{}, errors.Wrap(err, errChannelPublish)
+	}
+
+	return am.svc.SendCommand(ctx, session, cmd, timeout, wait)
+}
+
+func (am *authorizationMiddleware) ViewCommand(ctx context.Context, session smqauthn.Session, id string) (commands.Command, error) {
+	if err := am.authorizeDomain(ctx, session, readPermission); err != nil {
+		return commands.Command{}, errors.Wrap(err, errDomainViewCommands)
+	}
+
+	return am.svc.ViewCommand(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ListCommands(ctx context.Context, session smqauthn.Session, pm commands.PageMeta) (commands.Page, error) {
+	if err := am.authorizeDomain(ctx, session, readPermission); err != nil {
+		return commands.Page{}, errors.Wrap(err, errDomainViewCommands)
+	}
+
+	return am.svc.ListCommands(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) Reply(ctx context.Context, msg *messaging.Message) error {
+	return am.svc.Reply(ctx, msg)
+}
+
+func (am *authorizationMiddleware) authorizeDomain(ctx context.Context, session smqauthn.Session, permission string) error {
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  permission,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/commands"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+var _ commands.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    commands.Service
+}
+
+// LoggingMiddleware adds logging facilities to the commands service.
+func LoggingMiddleware(svc commands.Service, logger *slog.Logger) commands.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) SendCommand(ctx context.Context, session smqauthn.Session, cmd commands.Command, timeout time.Duration, wait bool) (c commands.Command, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("command",
+				slog.String("id", c.ID),
+				slog.String("channel_id", cmd.ChannelID),
+				slog.String("client_id", cmd.ClientID),
+				slog.String("timeout", timeout.String()),
+				slog.Bool("wait", wait),
+				slog.String("status", string(c.Status)),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Send command failed", args...)
+			return
+		}
+		lm.logger.Info("Send command completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.SendCommand(ctx, session, cmd, timeout, wait)
+}
+
+func (lm *loggingMiddleware) ViewCommand(ctx context.Context, session smqauthn.Session, id string) (c commands.Command, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("command_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View command failed", args...)
+			return
+		}
+		lm.logger.Info("View command completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewCommand(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListCommands(ctx context.Context, session smqauthn.Session, pm commands.PageMeta) (page commands.Page, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.String("channel_id", pm.ChannelID),
+				slog.String("status", string(pm.Status)),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List commands failed", args...)
+			return
+		}
+		lm.logger.Info("List commands completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListCommands(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) Reply(ctx context.Context, msg *messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", msg.GetChannel()),
+			slog.String("subtopic", msg.GetSubtopic()),
+			slog.String("publisher", msg.GetPublisher()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Command reply failed", args...)
+			return
+		}
+		lm.logger.Info("Command reply completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Reply(ctx, msg)
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/commands"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ commands.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     commands.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc commands.Service, counter metrics.Counter, latency metrics.Histogram) commands.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) SendCommand(ctx context.Context, session smqauthn.Session, cmd commands.Command, timeout time.Duration, wait bool) (commands.Command, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "send_command").Add(1)
+		mm.latency.With("method", "send_command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.SendCommand(ctx, session, cmd, timeout, wait)
+}
+
+func (mm *metricsMiddleware) ViewCommand(ctx context.Context, session smqauthn.Session, id string) (commands.Command, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_command").Add(1)
+		mm.latency.With("method", "view_command").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewCommand(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListCommands(ctx context.Context, session smqauthn.Session, pm commands.PageMeta) (commands.Page, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_commands").Add(1)
+		mm.latency.With("method", "list_commands").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListCommands(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) Reply(ctx context.Context, msg *messaging.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "reply").Add(1)
+		mm.latency.With("method", "reply").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Reply(ctx, msg)
+}
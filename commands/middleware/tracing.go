@@ -0,0 +1,70 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/commands"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ commands.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    commands.Service
+}
+
+// Tracing returns a new commands service with tracing capabilities.
+func Tracing(svc commands.Service, tracer trace.Tracer) commands.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) SendCommand(ctx context.Context, session smqauthn.Session, cmd commands.Command, timeout time.Duration, wait bool) (commands.Command, error) {
+	ctx, span := tm.tracer.Start(ctx, "send_command", trace.WithAttributes(
+		attribute.String("channel_id", cmd.ChannelID),
+		attribute.String("client_id", cmd.ClientID),
+		attribute.String("timeout", timeout.String()),
+		attribute.Bool("wait", wait),
+	))
+	defer span.End()
+
+	return tm.svc.SendCommand(ctx, session, cmd, timeout, wait)
+}
+
+func (tm *tracing) ViewCommand(ctx context.Context, session smqauthn.Session, id string) (commands.Command, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_command", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewCommand(ctx, session, id)
+}
+
+func (tm *tracing) ListCommands(ctx context.Context, session smqauthn.Session, pm commands.PageMeta) (commands.Page, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_commands", trace.WithAttributes(
+		attribute.String("channel_id", pm.ChannelID),
+		attribute.String("status", string(pm.Status)),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListCommands(ctx, session, pm)
+}
+
+func (tm *tracing) Reply(ctx context.Context, msg *messaging.Message) error {
+	ctx, span := tm.tracer.Start(ctx, "reply", trace.WithAttributes(
+		attribute.String("channel_id", msg.GetChannel()),
+		attribute.String("subtopic", msg.GetSubtopic()),
+	))
+	defer span.End()
+
+	return tm.svc.Reply(ctx, msg)
+}
@@ -0,0 +1,220 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/commands"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+)
+
+const commandColumns = `id, domain_id, channel_id, client_id, payload, content_type, response, status,
+	expires_at, created_at, created_by, updated_at`
+
+var _ commands.Repository = (*commandsRepo)(nil)
+
+type commandsRepo struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of commands repository.
+func NewRepository(db postgres.Database) commands.Repository {
+	return &commandsRepo{db: db}
+}
+
+func (repo *commandsRepo) Save(ctx context.Context, cmd commands.Command) (commands.Command, error) {
+	q := fmt.Sprintf(`INSERT INTO commands (%s)
+		VALUES (:id, :domain_id, :channel_id, :client_id, :payload, :content_type, :response, :status,
+		:expires_at, :created_at, :created_by, :updated_at)
+		RETURNING %s`, commandColumns, commandColumns)
+
+	row, err := repo.db.NamedQueryContext(ctx, q, toDBCommand(cmd))
+	if err != nil {
+		return commands.Command{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer row.Close()
+
+	return scanCommand(row, repoerr.ErrCreateEntity)
+}
+
+func (repo *commandsRepo) Retrieve(ctx context.Context, id string) (commands.Command, error) {
+	q := fmt.Sprintf(`SELECT %s FROM commands WHERE id = $1`, commandColumns)
+
+	var dbc dbCommand
+	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&dbc); err != nil {
+		if err == sql.ErrNoRows {
+			return commands.Command{}, repoerr.ErrNotFound
+		}
+		return commands.Command{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return fromDBCommand(dbc), nil
+}
+
+func (repo *commandsRepo) Update(ctx context.Context, cmd commands.Command, statuses ...commands.Status) (commands.Command, error) {
+	dbc := toDBCommand(cmd)
+	params := map[string]interface{}{
+		"id":         dbc.ID,
+		"status":     dbc.Status,
+		"response":   dbc.Response,
+		"updated_at": dbc.UpdatedAt,
+	}
+
+	var cond string
+	if len(statuses) > 0 {
+		names := make([]string, len(statuses))
+		for i, status := range statuses {
+			name := fmt.Sprintf("status_%d", i)
+			names[i] = ":" + name
+			params[name] = string(status)
+		}
+		cond = fmt.Sprintf(" AND status IN (%s)", strings.Join(names, ", "))
+	}
+
+	q := fmt.Sprintf(`UPDATE commands SET status = :status, response = :response, updated_at = :updated_at
+		WHERE id = :id%s
+		RETURNING %s`, cond, commandColumns)
+
+	row, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return commands.Command{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	return scanCommand(row, repoerr.ErrUpdateEntity)
+}
+
+func (repo *commandsRepo) RetrieveAll(ctx context.Context, pm commands.PageMeta) (commands.Page, error) {
+	query := pageQuery(pm)
+
+	q := fmt.Sprintf(`SELECT %s FROM commands %s ORDER BY created_at DESC LIMIT :limit OFFSET :offset`, commandColumns, query)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return commands.Page{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	cmds := []commands.Command{}
+	for rows.Next() {
+		var dbc dbCommand
+		if err := rows.StructScan(&dbc); err != nil {
+			return commands.Page{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		cmds = append(cmds, fromDBCommand(dbc))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM commands %s`, query)
+	total, err := postgres.Total(ctx, repo.db, cq, pm)
+	if err != nil {
+		return commands.Page{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return commands.Page{
+		PageMeta: pm,
+		Total:    total,
+		Commands: cmds,
+	}, nil
+}
+
+type rowScanner interface {
+	Next() bool
+	StructScan(dest interface{}) error
+}
+
+func scanCommand(row rowScanner, wrapErr error) (commands.Command, error) {
+	if !row.Next() {
+		return commands.Command{}, repoerr.ErrNotFound
+	}
+
+	var dbc dbCommand
+	if err := row.StructScan(&dbc); err != nil {
+		return commands.Command{}, errors.Wrap(wrapErr, err)
+	}
+
+	return fromDBCommand(dbc), nil
+}
+
+func pageQuery(pm commands.PageMeta) string {
+	var query []string
+	if pm.DomainID != "" {
+		query = append(query, "domain_id = :domain_id")
+	}
+	if pm.ChannelID != "" {
+		query = append(query, "channel_id = :channel_id")
+	}
+	if pm.Status != "" {
+		query = append(query, "status = :status")
+	}
+	if len(query) > 0 {
+		return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	return ""
+}
+
+type dbCommand struct {
+	ID          string       `db:"id"`
+	DomainID    string       `db:"domain_id"`
+	ChannelID   string       `db:"channel_id"`
+	ClientID    string       `db:"client_id"`
+	Payload     string       `db:"payload"`
+	ContentType string       `db:"content_type"`
+	Response    string       `db:"response"`
+	Status      string       `db:"status"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+	CreatedAt   time.Time    `db:"created_at"`
+	CreatedBy   string       `db:"created_by"`
+	UpdatedAt   sql.NullTime `db:"updated_at"`
+}
+
+func toDBCommand(cmd commands.Command) dbCommand {
+	var updatedAt sql.NullTime
+	if !cmd.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: cmd.UpdatedAt, Valid: true}
+	}
+
+	return dbCommand{
+		ID:          cmd.ID,
+		DomainID:    cmd.DomainID,
+		ChannelID:   cmd.ChannelID,
+		ClientID:    cmd.ClientID,
+		Payload:     cmd.Payload,
+		ContentType: cmd.ContentType,
+		Response:    cmd.Response,
+		Status:      string(cmd.Status),
+		ExpiresAt:   cmd.ExpiresAt,
+		CreatedAt:   cmd.CreatedAt,
+		CreatedBy:   cmd.CreatedBy,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+func fromDBCommand(dbc dbCommand) commands.Command {
+	cmd := commands.Command{
+		ID:          dbc.ID,
+		DomainID:    dbc.DomainID,
+		ChannelID:   dbc.ChannelID,
+		ClientID:    dbc.ClientID,
+		Payload:     dbc.Payload,
+		ContentType: dbc.ContentType,
+		Response:    dbc.Response,
+		Status:      commands.Status(dbc.Status),
+		ExpiresAt:   dbc.ExpiresAt,
+		CreatedAt:   dbc.CreatedAt,
+		CreatedBy:   dbc.CreatedBy,
+	}
+	if dbc.UpdatedAt.Valid {
+		cmd.UpdatedAt = dbc.UpdatedAt.Time
+	}
+
+	return cmd
+}
@@ -0,0 +1,256 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/commands"
+	commandspg "github.com/absmach/supermq/commands/postgres"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numCommands = 10
+
+func newCommand(t *testing.T, domainID, chanID string) commands.Command {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return commands.Command{
+		ID:          testsutil.GenerateUUID(t),
+		DomainID:    domainID,
+		ChannelID:   chanID,
+		ClientID:    testsutil.GenerateUUID(t),
+		Payload:     `{"cmd":"reboot"}`,
+		ContentType: "application/json",
+		Status:      commands.PendingStatus,
+		ExpiresAt:   now.Add(time.Minute),
+		CreatedAt:   now,
+		CreatedBy:   testsutil.GenerateUUID(t),
+	}
+}
+
+func cleanup(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM commands")
+		require.Nil(t, err, fmt.Sprintf("clean commands unexpected error: %s", err))
+	})
+}
+
+func TestSave(t *testing.T) {
+	cleanup(t)
+	repo := commandspg.NewRepository(database)
+
+	cmd := newCommand(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	noClient := newCommand(t, cmd.DomainID, cmd.ChannelID)
+	noClient.ClientID = ""
+
+	cases := []struct {
+		desc string
+		cmd  commands.Command
+		err  error
+	}{
+		{
+			desc: "save command successfully",
+			cmd:  cmd,
+		},
+		{
+			desc: "save command without client",
+			cmd:  noClient,
+		},
+		{
+			desc: "save duplicate command",
+			cmd:  cmd,
+			err:  repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.cmd)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.cmd, saved, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.cmd, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieve(t *testing.T) {
+	cleanup(t)
+	repo := commandspg.NewRepository(database)
+
+	cmd, err := repo.Save(context.Background(), newCommand(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save command unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		cmd  commands.Command
+		err  error
+	}{
+		{
+			desc: "retrieve existing command",
+			id:   cmd.ID,
+			cmd:  cmd,
+		},
+		{
+			desc: "retrieve non-existing command",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := repo.Retrieve(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.cmd, c, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.cmd, c))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cleanup(t)
+	repo := commandspg.NewRepository(database)
+
+	cmd, err := repo.Save(context.Background(), newCommand(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save command unexpected error: %s", err))
+
+	delivered := cmd
+	delivered.Status = commands.DeliveredStatus
+	delivered.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+
+	replied := delivered
+	replied.Status = commands.RepliedStatus
+	replied.Response = `{"status":"ok"}`
+	replied.UpdatedAt = delivered.UpdatedAt.Add(time.Second)
+
+	timedOut := replied
+	timedOut.Status = commands.TimedOutStatus
+	timedOut.Response = ""
+
+	missing := delivered
+	missing.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		cmd      commands.Command
+		statuses []commands.Status
+		err      error
+	}{
+		{
+			desc:     "update pending command",
+			cmd:      delivered,
+			statuses: []commands.Status{commands.PendingStatus},
+		},
+		{
+			desc:     "update command with multiple statuses",
+			cmd:      replied,
+			statuses: []commands.Status{commands.PendingStatus, commands.DeliveredStatus},
+		},
+		{
+			desc:     "update command with status mismatch",
+			cmd:      timedOut,
+			statuses: []commands.Status{commands.PendingStatus, commands.DeliveredStatus},
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc: "update command without status condition",
+			cmd:  timedOut,
+		},
+		{
+			desc: "update non-existing command",
+			cmd:  missing,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			c, err := repo.Update(context.Background(), tc.cmd, tc.statuses...)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.cmd, c, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.cmd, c))
+			}
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	cleanup(t)
+	repo := commandspg.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	chanID := testsutil.GenerateUUID(t)
+	// Commands are listed from the newest to the oldest.
+	saved := make([]commands.Command, numCommands)
+	for i := 0; i < numCommands; i++ {
+		cmd := newCommand(t, domainID, chanID)
+		cmd.CreatedAt = cmd.CreatedAt.Add(time.Duration(i) * time.Second)
+		if i%2 == 1 {
+			cmd.ChannelID = testsutil.GenerateUUID(t)
+		}
+		if i == 3 {
+			cmd.Status = commands.RepliedStatus
+		}
+		c, err := repo.Save(context.Background(), cmd)
+		require.Nil(t, err, fmt.Sprintf("save command unexpected error: %s", err))
+		saved[numCommands-1-i] = c
+	}
+	_, err := repo.Save(context.Background(), newCommand(t, testsutil.GenerateUUID(t), chanID))
+	require.Nil(t, err, fmt.Sprintf("save command unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		pm       commands.PageMeta
+		total    uint64
+		commands []commands.Command
+	}{
+		{
+			desc:     "retrieve all domain commands",
+			pm:       commands.PageMeta{Offset: 0, Limit: numCommands, DomainID: domainID},
+			total:    numCommands,
+			commands: saved,
+		},
+		{
+			desc:     "retrieve domain commands with offset and limit",
+			pm:       commands.PageMeta{Offset: 2, Limit: 3, DomainID: domainID},
+			total:    numCommands,
+			commands: saved[2:5],
+		},
+		{
+			desc:     "retrieve domain commands by channel",
+			pm:       commands.PageMeta{Offset: 0, Limit: numCommands, DomainID: domainID, ChannelID: chanID},
+			total:    numCommands / 2,
+			commands: []commands.Command{saved[1], saved[3], saved[5], saved[7], saved[9]},
+		},
+		{
+			desc:     "retrieve domain commands by status",
+			pm:       commands.PageMeta{Offset: 0, Limit: numCommands, DomainID: domainID, Status: commands.RepliedStatus},
+			total:    1,
+			commands: []commands.Command{saved[6]},
+		},
+		{
+			desc:     "retrieve commands of domain without commands",
+			pm:       commands.PageMeta{Offset: 0, Limit: numCommands, DomainID: testsutil.GenerateUUID(t)},
+			total:    0,
+			commands: []commands.Command{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+			assert.Equal(t, tc.commands, page.Commands, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.commands, page.Commands))
+		})
+	}
+}
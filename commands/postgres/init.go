@@ -0,0 +1,41 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of commands service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "commands_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS commands (
+						id           VARCHAR(36) PRIMARY KEY,
+						domain_id    VARCHAR(36) NOT NULL,
+						channel_id   VARCHAR(36) NOT NULL,
+						client_id    VARCHAR(36) NOT NULL DEFAULT '',
+						payload      TEXT NOT NULL DEFAULT '',
+						content_type VARCHAR(254) NOT NULL DEFAULT '',
+						response     TEXT NOT NULL DEFAULT '',
+						status       VARCHAR(16) NOT NULL,
+						expires_at   TIMESTAMP NOT NULL,
+						created_at   TIMESTAMP NOT NULL,
+						created_by   VARCHAR(254) NOT NULL,
+						updated_at   TIMESTAMP
+					)`,
+					`CREATE INDEX idx_commands_domain_id ON commands(domain_id);`,
+					`CREATE INDEX idx_commands_channel_id ON commands(channel_id);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS commands`,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+var errPublish = errors.New("failed to publish command")
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	repo      Repository
+	idp       supermq.IDProvider
+	publisher messaging.Publisher
+
+	mu      sync.Mutex
+	waiters map[string]chan Command
+}
+
+// NewService instantiates the commands service implementation.
+func NewService(repo Repository, idp supermq.IDProvider, publisher messaging.Publisher) Service {
+	return &service{
+		repo:      repo,
+		idp:       idp,
+		publisher: publisher,
+		waiters:   make(map[string]chan Command),
+	}
+}
+
+func (svc *service) SendCommand(ctx context.Context, session authn.Session, cmd Command, timeout time.Duration, wait bool) (Command, error) {
+	id, err := svc.idp.ID()
+	if err != nil {
+		return Command{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	now := time.Now().UTC()
+	cmd.ID = id
+	cmd.DomainID = session.DomainID
+	cmd.Response = ""
+	cmd.Status = PendingStatus
+	cmd.ExpiresAt = now.Add(timeout)
+	cmd.CreatedAt = now
+	cmd.CreatedBy = session.UserID
+	cmd.UpdatedAt = time.Time{}
+
+	cmd, err = svc.repo.Save(ctx, cmd)
+	if err != nil {
+		return Command{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	// The waiter is registered before the command is published,
+	// so a prompt reply can't be missed.
+	var replies chan Command
+	if wait {
+		replies = svc.register(cmd.ID)
+		defer svc.unregister(cmd.ID)
+	}
+
+	if err := svc.publish(ctx, cmd, timeout); err != nil {
+		return Command{}, err
+	}
+
+	delivered := cmd
+	delivered.Status = DeliveredStatus
+	delivered.UpdatedAt = time.Now().UTC()
+	delivered, err = svc.repo.Update(ctx, delivered, PendingStatus)
+	switch {
+	case err == nil:
+		cmd = delivered
+	case errors.Contains(err, repoerr.ErrNotFound):
+		// The command has already been replied to.
+	default:
+		return Command{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	if !wait {
+		return cmd, nil
+	}
+
+	timer := time.NewTimer(time.Until(cmd.ExpiresAt))
+	defer timer.Stop()
+
+	select {
+	case replied := <-replies:
+		return replied, nil
+	case <-timer.C:
+		return svc.expire(ctx, cmd)
+	case <-ctx.Done():
+		return cmd, ctx.Err()
+	}
+}
+
+func (svc *service) ViewCommand(ctx context.Context, session authn.Session, id string) (Command, error) {
+	cmd, err := svc.repo.Retrieve(ctx, id)
+	if err != nil {
+		return Command{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if cmd.DomainID != session.DomainID {
+		return Command{}, svcerr.ErrNotFound
+	}
+	if cmd.expired(time.Now()) {
+		return svc.expire(ctx, cmd)
+	}
+
+	return cmd, nil
+}
+
+func (svc *service) ListCommands(ctx context.Context, session authn.Session, pm PageMeta) (Page, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return Page{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	now := time.Now()
+	for i, cmd := range page.Commands {
+		if !cmd.expired(now) {
+			continue
+		}
+		if page.Commands[i], err = svc.expire(ctx, cmd); err != nil {
+			return Page{}, err
+		}
+	}
+
+	return page, nil
+}
+
+func (svc *service) Reply(ctx context.Context, msg *messaging.Message) error {
+	id, ok := commandID(msg.GetSubtopic())
+	if !ok {
+		return ErrInvalidReply
+	}
+	if corrID, ok := msg.GetHeaders()[messaging.CorrelationDataHeader]; ok && corrID != id {
+		return ErrInvalidReply
+	}
+
+	cmd, err := svc.repo.Retrieve(ctx, id)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if cmd.ChannelID != msg.GetChannel() || (cmd.ClientID != "" && cmd.ClientID != msg.GetPublisher()) {
+		return ErrInvalidReply
+	}
+
+	switch {
+	case cmd.Status == RepliedStatus:
+		// Only the first reply is accepted. The reply may have been handled
+		// by another service instance, so the local waiter is notified.
+		svc.notify(cmd)
+		return nil
+	case cmd.Status == TimedOutStatus:
+		return ErrCommandExpired
+	case cmd.expired(time.Now()):
+		if _, err := svc.expire(ctx, cmd); err != nil {
+			return err
+		}
+		return ErrCommandExpired
+	}
+
+	replied := cmd
+	replied.Status = RepliedStatus
+	replied.Response = string(msg.GetPayload())
+	replied.UpdatedAt = time.Now().UTC()
+	replied, err = svc.repo.Update(ctx, replied, PendingStatus, DeliveredStatus)
+	switch {
+	case err == nil:
+		svc.notify(replied)
+		return nil
+	case errors.Contains(err, repoerr.ErrNotFound):
+		// The command status has changed meanwhile, so the reply is
+		// handled according to the current command status.
+		return svc.Reply(ctx, msg)
+	default:
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+}
+
+func (svc *service) publish(ctx context.Context, cmd Command, timeout time.Duration) error {
+	headers := map[string]string{
+		messaging.ResponseTopicHeader:   cmd.ResponseTopic(),
+		messaging.CorrelationDataHeader: cmd.ID,
+		messaging.MessageExpiryHeader:   strconv.FormatInt(int64(math.Ceil(timeout.Seconds())), 10),
+	}
+	if cmd.ContentType != "" {
+		headers[messaging.ContentTypeHeader] = cmd.ContentType
+	}
+
+	msg := &messaging.Message{
+		Channel:  cmd.ChannelID,
+		Subtopic: cmd.Topic(),
+		Protocol: Protocol,
+		Payload:  []byte(cmd.Payload),
+		Created:  cmd.CreatedAt.UnixNano(),
+		Headers:  headers,
+	}
+	if err := svc.publisher.Publish(ctx, cmd.ChannelID, msg); err != nil {
+		return errors.Wrap(errPublish, err)
+	}
+
+	return nil
+}
+
+// expire marks the command as timed out. If the command has been replied
+// to meanwhile, the replied command is returned.
+func (svc *service) expire(ctx context.Context, cmd Command) (Command, error) {
+	expired := cmd
+	expired.Status = TimedOutStatus
+	expired.UpdatedAt = time.Now().UTC()
+	expired, err := svc.repo.Update(ctx, expired, PendingStatus, DeliveredStatus)
+	switch {
+	case err == nil:
+		return expired, nil
+	case errors.Contains(err, repoerr.ErrNotFound):
+		cmd, err := svc.repo.Retrieve(ctx, cmd.ID)
+		if err != nil {
+			return Command{}, errors.Wrap(svcerr.ErrViewEntity, err)
+		}
+		return cmd, nil
+	default:
+		return Command{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+}
+
+func (svc *service) register(id string) chan Command {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	ch := make(chan Command, 1)
+	svc.waiters[id] = ch
+
+	return ch
+}
+
+func (svc *service) unregister(id string) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	delete(svc.waiters, id)
+}
+
+func (svc *service) notify(cmd Command) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	ch, ok := svc.waiters[cmd.ID]
+	if !ok {
+		return
+	}
+	select {
+	case ch <- cmd:
+	default:
+	}
+}
+
+// expired checks whether the command timed out without being replied to.
+func (cmd Command) expired(now time.Time) bool {
+	return (cmd.Status == PendingStatus || cmd.Status == DeliveredStatus) && now.After(cmd.ExpiresAt)
+}
+
+// commandID extracts the command ID from the reply subtopic
+// "commands.<command_id>.reply".
+func commandID(subtopic string) (string, bool) {
+	parts := strings.Split(subtopic, ".")
+	if len(parts) != 3 || parts[0] != Subtopic || parts[1] == "" || parts[2] != ReplySubtopic {
+		return "", false
+	}
+
+	return parts[1], true
+}
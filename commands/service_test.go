@@ -0,0 +1,457 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package commands_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/commands"
+	"github.com/absmach/supermq/commands/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	pubsubmocks "github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	payload  = `{"cmd":"reboot"}`
+	response = `{"status":"ok"}`
+	jsonCT   = "application/json"
+)
+
+var (
+	domainID     = testsutil.GenerateUUID(&testing.T{})
+	userID       = testsutil.GenerateUUID(&testing.T{})
+	chanID       = testsutil.GenerateUUID(&testing.T{})
+	clientID     = testsutil.GenerateUUID(&testing.T{})
+	session      = smqauthn.Session{UserID: userID, DomainID: domainID, DomainUserID: domainID + "_" + userID}
+	validCommand = commands.Command{
+		ID:          testsutil.GenerateUUID(&testing.T{}),
+		DomainID:    domainID,
+		ChannelID:   chanID,
+		ClientID:    clientID,
+		Payload:     payload,
+		ContentType: jsonCT,
+		Status:      commands.DeliveredStatus,
+		ExpiresAt:   time.Now().Add(time.Hour),
+		CreatedBy:   userID,
+	}
+	errPublish = errors.New("failed to publish message")
+)
+
+func newService() (commands.Service, *mocks.Repository, *pubsubmocks.PubSub) {
+	repo := new(mocks.Repository)
+	pubsub := new(pubsubmocks.PubSub)
+
+	return commands.NewService(repo, uuid.NewMock(), pubsub), repo, pubsub
+}
+
+func returnCommand(_ context.Context, cmd commands.Command, _ ...commands.Status) commands.Command {
+	return cmd
+}
+
+func replyMessage(cmd commands.Command, publisher string) *messaging.Message {
+	return &messaging.Message{
+		Channel:   cmd.ChannelID,
+		Subtopic:  cmd.Topic() + "." + commands.ReplySubtopic,
+		Publisher: publisher,
+		Payload:   []byte(response),
+	}
+}
+
+func TestSendCommand(t *testing.T) {
+	svc, repo, pubsub := newService()
+
+	cmd := commands.Command{ChannelID: chanID, ClientID: clientID, Payload: payload, ContentType: jsonCT}
+
+	cases := []struct {
+		desc      string
+		cmd       commands.Command
+		saveErr   error
+		pubErr    error
+		updateErr error
+		status    commands.Status
+		err       error
+	}{
+		{
+			desc:   "send command successfully",
+			cmd:    cmd,
+			status: commands.DeliveredStatus,
+		},
+		{
+			desc:    "send command with failed repository save",
+			cmd:     cmd,
+			saveErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrCreateEntity,
+		},
+		{
+			desc:   "send command with failed publish",
+			cmd:    cmd,
+			pubErr: errPublish,
+			err:    errPublish,
+		},
+		{
+			desc:      "send command replied to before delivery update",
+			cmd:       cmd,
+			updateErr: repoerr.ErrNotFound,
+			status:    commands.PendingStatus,
+		},
+		{
+			desc:      "send command with failed repository update",
+			cmd:       cmd,
+			updateErr: repoerr.ErrUpdateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var msg *messaging.Message
+			saveCall := repo.On("Save", mock.Anything, mock.Anything).Return(func(_ context.Context, c commands.Command) commands.Command { return c }, tc.saveErr)
+			pubCall := pubsub.On("Publish", mock.Anything, chanID, mock.Anything).Return(tc.pubErr).Run(func(args mock.Arguments) {
+				msg = args.Get(2).(*messaging.Message)
+			})
+			updateCall := repo.On("Update", mock.Anything, mock.Anything, commands.PendingStatus).Return(returnCommand, tc.updateErr)
+			res, err := svc.SendCommand(context.Background(), session, tc.cmd, time.Minute, false)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.NotEmpty(t, res.ID, fmt.Sprintf("%s: expected non-empty id", tc.desc))
+				assert.Equal(t, tc.status, res.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.status, res.Status))
+				assert.Equal(t, domainID, res.DomainID, fmt.Sprintf("%s: expected domain %s got %s", tc.desc, domainID, res.DomainID))
+				assert.Equal(t, userID, res.CreatedBy, fmt.Sprintf("%s: expected creator %s got %s", tc.desc, userID, res.CreatedBy))
+				assert.WithinDuration(t, time.Now().Add(time.Minute), res.ExpiresAt, time.Second, fmt.Sprintf("%s: unexpected expiration time", tc.desc))
+				assert.Equal(t, commands.Subtopic+"."+res.ID, msg.GetSubtopic(), fmt.Sprintf("%s: unexpected command subtopic", tc.desc))
+				assert.Equal(t, []byte(payload), msg.GetPayload(), fmt.Sprintf("%s: unexpected command payload", tc.desc))
+				headers := map[string]string{
+					messaging.ResponseTopicHeader:   "channels/" + chanID + "/messages/commands/" + res.ID + "/reply",
+					messaging.CorrelationDataHeader: res.ID,
+					messaging.MessageExpiryHeader:   "60",
+					messaging.ContentTypeHeader:     jsonCT,
+				}
+				assert.Equal(t, headers, msg.GetHeaders(), fmt.Sprintf("%s: expected headers %v got %v", tc.desc, headers, msg.GetHeaders()))
+			}
+			saveCall.Unset()
+			pubCall.Unset()
+			updateCall.Unset()
+		})
+	}
+}
+
+func TestSendCommandWait(t *testing.T) {
+	cmd := commands.Command{ChannelID: chanID, ClientID: clientID, Payload: payload}
+
+	cases := []struct {
+		desc    string
+		reply   bool
+		timeout time.Duration
+		cancel  bool
+		status  commands.Status
+		err     error
+	}{
+		{
+			desc:    "send command and receive reply",
+			reply:   true,
+			timeout: time.Minute,
+			status:  commands.RepliedStatus,
+		},
+		{
+			desc:    "send command which times out",
+			timeout: 10 * time.Millisecond,
+			status:  commands.TimedOutStatus,
+		},
+		{
+			desc:    "send command with canceled context",
+			timeout: time.Minute,
+			cancel:  true,
+			err:     context.Canceled,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svc, repo, pubsub := newService()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			saved := make(chan commands.Command, 1)
+			repo.On("Save", mock.Anything, mock.Anything).Return(func(_ context.Context, c commands.Command) commands.Command {
+				saved <- c
+				return c
+			}, nil)
+			repo.On("Retrieve", mock.Anything, mock.Anything).Return(func(_ context.Context, _ string) commands.Command {
+				c := <-saved
+				c.Status = commands.DeliveredStatus
+				return c
+			}, nil)
+			repo.On("Update", mock.Anything, mock.Anything, commands.PendingStatus).Return(returnCommand, nil)
+			repo.On("Update", mock.Anything, mock.Anything, commands.PendingStatus, commands.DeliveredStatus).Return(returnCommand, nil)
+			pubsub.On("Publish", mock.Anything, chanID, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				switch {
+				case tc.reply:
+					msg := args.Get(2).(*messaging.Message)
+					reply := &messaging.Message{
+						Channel:   chanID,
+						Subtopic:  msg.GetSubtopic() + "." + commands.ReplySubtopic,
+						Publisher: clientID,
+						Payload:   []byte(response),
+					}
+					go func() {
+						err := svc.Reply(context.Background(), reply)
+						assert.Nil(t, err, fmt.Sprintf("%s: unexpected reply error %s", tc.desc, err))
+					}()
+				case tc.cancel:
+					cancel()
+				}
+			})
+
+			res, err := svc.SendCommand(ctx, session, cmd, tc.timeout, true)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.status, res.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.status, res.Status))
+			}
+			if tc.reply {
+				assert.Equal(t, response, res.Response, fmt.Sprintf("%s: expected response %s got %s", tc.desc, response, res.Response))
+			}
+		})
+	}
+}
+
+func TestViewCommand(t *testing.T) {
+	svc, repo, _ := newService()
+
+	otherDomain := validCommand
+	otherDomain.ID = testsutil.GenerateUUID(t)
+	otherDomain.DomainID = testsutil.GenerateUUID(t)
+
+	expired := validCommand
+	expired.ID = testsutil.GenerateUUID(t)
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+	cases := []struct {
+		desc    string
+		id      string
+		res     commands.Command
+		repoErr error
+		status  commands.Status
+		err     error
+	}{
+		{
+			desc:   "view command successfully",
+			id:     validCommand.ID,
+			res:    validCommand,
+			status: commands.DeliveredStatus,
+		},
+		{
+			desc:   "view expired command",
+			id:     expired.ID,
+			res:    expired,
+			status: commands.TimedOutStatus,
+		},
+		{
+			desc:    "view non-existing command",
+			id:      testsutil.GenerateUUID(t),
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc: "view command from another domain",
+			id:   otherDomain.ID,
+			res:  otherDomain,
+			err:  svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("Retrieve", mock.Anything, tc.id).Return(tc.res, tc.repoErr)
+			updateCall := repo.On("Update", mock.Anything, mock.Anything, commands.PendingStatus, commands.DeliveredStatus).Return(returnCommand, nil)
+			cmd, err := svc.ViewCommand(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.status, cmd.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.status, cmd.Status))
+			}
+			repoCall.Unset()
+			updateCall.Unset()
+		})
+	}
+}
+
+func TestListCommands(t *testing.T) {
+	svc, repo, _ := newService()
+
+	expired := validCommand
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	timedOut := expired
+	timedOut.Status = commands.TimedOutStatus
+
+	cases := []struct {
+		desc    string
+		pm      commands.PageMeta
+		page    commands.Page
+		repoErr error
+		res     []commands.Command
+		err     error
+	}{
+		{
+			desc: "list commands successfully",
+			pm:   commands.PageMeta{Offset: 0, Limit: 10},
+			page: commands.Page{Total: 1, Commands: []commands.Command{validCommand}},
+			res:  []commands.Command{validCommand},
+		},
+		{
+			desc: "list commands with expired command",
+			pm:   commands.PageMeta{Offset: 0, Limit: 10},
+			page: commands.Page{Total: 1, Commands: []commands.Command{expired}},
+			res:  []commands.Command{timedOut},
+		},
+		{
+			desc:    "list commands with failed repository retrieve",
+			pm:      commands.PageMeta{Offset: 0, Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pm := tc.pm
+			pm.DomainID = domainID
+			repoCall := repo.On("RetrieveAll", mock.Anything, pm).Return(tc.page, tc.repoErr)
+			updateCall := repo.On("Update", mock.Anything, mock.Anything, commands.PendingStatus, commands.DeliveredStatus).Return(func(_ context.Context, c commands.Command, _ ...commands.Status) commands.Command {
+				c.UpdatedAt = time.Time{}
+				return c
+			}, nil)
+			page, err := svc.ListCommands(context.Background(), session, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.res, page.Commands, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, page.Commands))
+			}
+			repoCall.Unset()
+			updateCall.Unset()
+		})
+	}
+}
+
+func TestReply(t *testing.T) {
+	svc, repo, _ := newService()
+
+	replied := validCommand
+	replied.Status = commands.RepliedStatus
+	timedOut := validCommand
+	timedOut.Status = commands.TimedOutStatus
+	expired := validCommand
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	anyClient := validCommand
+	anyClient.ClientID = ""
+
+	corrMismatch := replyMessage(validCommand, clientID)
+	corrMismatch.Headers = map[string]string{messaging.CorrelationDataHeader: testsutil.GenerateUUID(t)}
+	otherChannel := replyMessage(validCommand, clientID)
+	otherChannel.Channel = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc      string
+		msg       *messaging.Message
+		cmd       commands.Command
+		repoErr   error
+		updateErr error
+		status    commands.Status
+		err       error
+	}{
+		{
+			desc:   "reply to command successfully",
+			msg:    replyMessage(validCommand, clientID),
+			cmd:    validCommand,
+			status: commands.RepliedStatus,
+		},
+		{
+			desc:   "reply to command without target client",
+			msg:    replyMessage(anyClient, testsutil.GenerateUUID(t)),
+			cmd:    anyClient,
+			status: commands.RepliedStatus,
+		},
+		{
+			desc: "reply with invalid subtopic",
+			msg:  &messaging.Message{Channel: chanID, Subtopic: commands.Subtopic + "." + validCommand.ID},
+			err:  commands.ErrInvalidReply,
+		},
+		{
+			desc: "reply with correlation data mismatch",
+			msg:  corrMismatch,
+			err:  commands.ErrInvalidReply,
+		},
+		{
+			desc:    "reply to non-existing command",
+			msg:     replyMessage(validCommand, clientID),
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc: "reply from another channel",
+			msg:  otherChannel,
+			cmd:  validCommand,
+			err:  commands.ErrInvalidReply,
+		},
+		{
+			desc: "reply from another client",
+			msg:  replyMessage(validCommand, testsutil.GenerateUUID(t)),
+			cmd:  validCommand,
+			err:  commands.ErrInvalidReply,
+		},
+		{
+			desc: "reply to replied command",
+			msg:  replyMessage(validCommand, clientID),
+			cmd:  replied,
+		},
+		{
+			desc: "reply to timed out command",
+			msg:  replyMessage(validCommand, clientID),
+			cmd:  timedOut,
+			err:  commands.ErrCommandExpired,
+		},
+		{
+			desc:   "reply to expired command",
+			msg:    replyMessage(validCommand, clientID),
+			cmd:    expired,
+			status: commands.TimedOutStatus,
+			err:    commands.ErrCommandExpired,
+		},
+		{
+			desc:      "reply with failed repository update",
+			msg:       replyMessage(validCommand, clientID),
+			cmd:       validCommand,
+			updateErr: repoerr.ErrUpdateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var updated commands.Command
+			repoCall := repo.On("Retrieve", mock.Anything, validCommand.ID).Return(tc.cmd, tc.repoErr)
+			updateCall := repo.On("Update", mock.Anything, mock.Anything, commands.PendingStatus, commands.DeliveredStatus).Return(returnCommand, tc.updateErr).Run(func(args mock.Arguments) {
+				updated = args.Get(1).(commands.Command)
+			})
+			err := svc.Reply(context.Background(), tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.status != "" {
+				assert.Equal(t, tc.status, updated.Status, fmt.Sprintf("%s: expected status %s got %s", tc.desc, tc.status, updated.Status))
+			}
+			if tc.status == commands.RepliedStatus {
+				assert.Equal(t, response, updated.Response, fmt.Sprintf("%s: expected response %s got %s", tc.desc, response, updated.Response))
+			}
+			repoCall.Unset()
+			updateCall.Unset()
+		})
+	}
+}
@@ -15,7 +15,8 @@ type AsyncConsumer interface {
 	// Errors method returns a channel for reading errors which occur during async writes.
 	// Must be  called before performing any writes for errors to be collected.
 	// The channel is buffered(1) so it allows only 1 error without blocking if not drained.
-	// The channel may receive nil error to indicate success.
+	// The channel must receive exactly one error for each ConsumeAsync call, nil to
+	// indicate success, since the consumer pipeline acknowledges messages by it.
 	Errors() <-chan error
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/messaging/brokers"
 	"github.com/absmach/supermq/pkg/transformers"
+	"github.com/absmach/supermq/pkg/transformers/binary"
 	"github.com/absmach/supermq/pkg/transformers/json"
 	"github.com/absmach/supermq/pkg/transformers/senml"
 	"github.com/pelletier/go-toml"
@@ -32,21 +33,51 @@ var (
 
 // Start method starts consuming messages received from Message broker.
 // This method transforms messages to SenML format before
-// using MessageRepository to store them.
-func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer interface{}, configPath string, logger *slog.Logger) error {
+// using MessageRepository to store them. If the pipeline is enabled
+// in the configuration file, messages are batched, retried and
+// dead-lettered by the consumer pipeline.
+func Start(ctx context.Context, id string, sub messaging.Subscriber, consumer interface{}, configPath string, logger *slog.Logger, opts ...Option) error {
 	cfg, err := loadConfig(configPath)
 	if err != nil {
 		logger.Warn(fmt.Sprintf("Failed to load consumer config: %s", err))
 	}
 
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	transformer := makeTransformer(cfg.TransformerCfg, logger)
 
+	var p *pipeline
+	if cfg.PipelineCfg.Enabled {
+		p, err = newPipeline(id, cfg.PipelineCfg, transformer, consumer, o.deadLetter, logger)
+		if err != nil {
+			return err
+		}
+		go p.run(ctx)
+		logger.Info(fmt.Sprintf("Using consumer pipeline with batch size %d and batch timeout %s", p.cfg.BatchSize, p.cfg.BatchTimeout))
+	}
+
+	// Without the pipeline, the async consume results are only logged. They
+	// are drained, so the consumer is not blocked by unread results.
+	if ac, ok := consumer.(AsyncConsumer); ok && p == nil {
+		go logErrors(ctx, ac.Errors(), logger)
+	}
+
 	for _, subject := range cfg.SubscriberCfg.Subjects {
 		subCfg := messaging.SubscriberConfig{
 			ID:             id,
 			Topic:          subject,
 			DeliveryPolicy: messaging.DeliverAllPolicy,
 		}
+		if p != nil {
+			subCfg.Handler = p
+			if err := sub.Subscribe(ctx, subCfg); err != nil {
+				return err
+			}
+			continue
+		}
 		switch c := consumer.(type) {
 		case AsyncConsumer:
 			subCfg.Handler = handleAsync(ctx, transformer, c)
@@ -95,6 +126,19 @@ func handleAsync(ctx context.Context, t transformers.Transformer, ac AsyncConsum
 	}
 }
 
+func logErrors(ctx context.Context, errs <-chan error, logger *slog.Logger) {
+	for {
+		select {
+		case err := <-errs:
+			if err != nil {
+				logger.Warn(fmt.Sprintf("Failed to consume messages: %s", err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 type handleFunc func(msg *messaging.Message) error
 
 func (h handleFunc) Handle(msg *messaging.Message) error {
@@ -113,11 +157,13 @@ type transformerConfig struct {
 	Format      string           `toml:"format"`
 	ContentType string           `toml:"content_type"`
 	TimeFields  []json.TimeField `toml:"time_fields"`
+	Schemas     []binary.Schema  `toml:"schemas"`
 }
 
 type config struct {
 	SubscriberCfg  subscriberConfig  `toml:"subscriber"`
 	TransformerCfg transformerConfig `toml:"transformer"`
+	PipelineCfg    PipelineConfig    `toml:"pipeline"`
 }
 
 func loadConfig(configPath string) (config, error) {
@@ -144,16 +190,31 @@ func loadConfig(configPath string) (config, error) {
 }
 
 func makeTransformer(cfg transformerConfig, logger *slog.Logger) transformers.Transformer {
+	var t transformers.Transformer
 	switch strings.ToUpper(cfg.Format) {
 	case "SENML":
 		logger.Info("Using SenML transformer")
-		return senml.New(cfg.ContentType)
+		t = senml.New(cfg.ContentType)
 	case "JSON":
 		logger.Info("Using JSON transformer")
-		return json.New(cfg.TimeFields)
+		t = json.New(cfg.TimeFields)
+	case "NONE":
+		logger.Info("Using no transformer")
 	default:
 		logger.Error(fmt.Sprintf("Can't create transformer: unknown transformer type %s", cfg.Format))
 		os.Exit(1)
 		return nil
 	}
+	if len(cfg.Schemas) == 0 {
+		return t
+	}
+
+	logger.Info("Using binary transformer for Protobuf and CBOR messages")
+	bt, err := binary.NewDispatcher(cfg.Schemas, t)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Can't create binary transformer: %s", err))
+		os.Exit(1)
+	}
+
+	return bt
 }
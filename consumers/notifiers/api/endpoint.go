@@ -0,0 +1,122 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/consumers/notifiers"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createSubscriptionEndpoint(svc notifiers.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createSubReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		sub := notifiers.Subscription{
+			Contact: req.Contact,
+			Topic:   req.Topic,
+		}
+		id, err := svc.CreateSubscription(ctx, session, sub)
+		if err != nil {
+			return nil, err
+		}
+
+		return createSubRes{ID: id}, nil
+	}
+}
+
+func viewSubscriptionEndpoint(svc notifiers.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(subReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		sub, err := svc.ViewSubscription(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return toViewSubRes(sub), nil
+	}
+}
+
+func listSubscriptionsEndpoint(svc notifiers.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listSubsReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		page, err := svc.ListSubscriptions(ctx, session, req.PageMetadata)
+		if err != nil {
+			return nil, err
+		}
+
+		res := listSubsRes{
+			Offset:        page.Offset,
+			Limit:         page.Limit,
+			Total:         page.Total,
+			Subscriptions: []viewSubRes{},
+		}
+		for _, sub := range page.Subscriptions {
+			res.Subscriptions = append(res.Subscriptions, toViewSubRes(sub))
+		}
+
+		return res, nil
+	}
+}
+
+func removeSubscriptionEndpoint(svc notifiers.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(subReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		if err := svc.RemoveSubscription(ctx, session, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeSubRes{}, nil
+	}
+}
+
+func toViewSubRes(sub notifiers.Subscription) viewSubRes {
+	return viewSubRes{
+		ID:      sub.ID,
+		OwnerID: sub.OwnerID,
+		Contact: sub.Contact,
+		Topic:   sub.Topic,
+	}
+}
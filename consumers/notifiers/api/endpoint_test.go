@@ -0,0 +1,473 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/consumers/notifiers"
+	"github.com/absmach/supermq/consumers/notifiers/api"
+	"github.com/absmach/supermq/consumers/notifiers/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	contentType  = "application/json"
+	validToken   = "valid-token"
+	invalidToken = "invalid-token"
+	contact      = "user@example.com"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+var (
+	userID  = testsutil.GenerateUUID(&testing.T{})
+	chanID  = testsutil.GenerateUUID(&testing.T{})
+	subID   = testsutil.GenerateUUID(&testing.T{})
+	topic   = "channels." + chanID + ".temperature"
+	session = smqauthn.Session{UserID: userID}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+type respBody struct {
+	Err           string                   `json:"error"`
+	Message       string                   `json:"message"`
+	Total         uint64                   `json:"total"`
+	Subscriptions []notifiers.Subscription `json:"subscriptions"`
+}
+
+func newServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	logger := smqlog.NewMock()
+	mux := api.MakeHandler(svc, authn, logger, "test", instanceID)
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func toJSON(data interface{}) string {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return string(jsonData)
+}
+
+func decodeErr(t *testing.T, res *http.Response) (respBody, error) {
+	var body respBody
+	if res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusCreated {
+		return body, nil
+	}
+	err := json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error while decoding response body: %s", err))
+	if body.Err != "" || body.Message != "" {
+		return body, errors.Wrap(errors.New(body.Err), errors.New(body.Message))
+	}
+
+	return body, nil
+}
+
+func TestCreateSubscription(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	sub := notifiers.Subscription{Topic: topic, Contact: contact}
+
+	cases := []struct {
+		desc        string
+		token       string
+		contentType string
+		req         string
+		authnErr    error
+		svcRes      string
+		svcErr      error
+		status      int
+		location    string
+		err         error
+	}{
+		{
+			desc:        "create subscription successfully",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(sub),
+			svcRes:      subID,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/subscriptions/%s", subID),
+		},
+		{
+			desc:        "create subscription with invalid token",
+			token:       invalidToken,
+			contentType: contentType,
+			req:         toJSON(sub),
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "create subscription with empty token",
+			contentType: contentType,
+			req:         toJSON(sub),
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "create subscription with empty topic",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(notifiers.Subscription{Contact: contact}),
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrInvalidTopic,
+		},
+		{
+			desc:        "create subscription with empty contact",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(notifiers.Subscription{Topic: topic}),
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrInvalidContact,
+		},
+		{
+			desc:        "create subscription with too long contact",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(notifiers.Subscription{Topic: topic, Contact: strings.Repeat("a", 255)}),
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrInvalidContact,
+		},
+		{
+			desc:        "create subscription with invalid request body",
+			token:       validToken,
+			contentType: contentType,
+			req:         "{",
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "create subscription with invalid content type",
+			token:       validToken,
+			contentType: "application/xml",
+			req:         toJSON(sub),
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "create subscription with invalid topic",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(notifiers.Subscription{Topic: "channels.>.a", Contact: contact}),
+			svcErr:      errors.Wrap(svcerr.ErrMalformedEntity, notifiers.ErrInvalidTopic),
+			status:      http.StatusBadRequest,
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "create existing subscription",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(sub),
+			svcErr:      svcerr.ErrConflict,
+			status:      http.StatusConflict,
+			err:         svcerr.ErrConflict,
+		},
+		{
+			desc:        "create subscription without channel permission",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(sub),
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/subscriptions", ts.URL),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("CreateSubscription", mock.Anything, session, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			_, err = decodeErr(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewSubscription(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	sub := notifiers.Subscription{ID: subID, OwnerID: userID, Topic: topic, Contact: contact}
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnErr error
+		svcRes   notifiers.Subscription
+		svcErr   error
+		status   int
+		err      error
+	}{
+		{
+			desc:   "view subscription successfully",
+			token:  validToken,
+			id:     subID,
+			svcRes: sub,
+			status: http.StatusOK,
+		},
+		{
+			desc:     "view subscription with invalid token",
+			token:    invalidToken,
+			id:       subID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "view non-existing subscription",
+			token:  validToken,
+			id:     "non-existing",
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/subscriptions/%s", ts.URL, tc.id),
+				token:  tc.token,
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("ViewSubscription", mock.Anything, session, tc.id).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err == nil {
+				var got notifiers.Subscription
+				err = json.NewDecoder(res.Body).Decode(&got)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, tc.svcRes, got, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.svcRes, got))
+			} else {
+				_, err = decodeErr(t, res)
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListSubscriptions(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	var subs []notifiers.Subscription
+	for i := 0; i < 10; i++ {
+		subs = append(subs, notifiers.Subscription{
+			ID:      testsutil.GenerateUUID(t),
+			OwnerID: userID,
+			Topic:   topic,
+			Contact: fmt.Sprintf("user%d@example.com", i),
+		})
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       notifiers.PageMetadata
+		authnErr error
+		svcRes   notifiers.Page
+		svcErr   error
+		status   int
+		total    uint64
+		err      error
+	}{
+		{
+			desc:   "list subscriptions successfully",
+			token:  validToken,
+			pm:     notifiers.PageMetadata{Offset: 0, Limit: 10},
+			svcRes: notifiers.Page{Total: 10, Subscriptions: subs},
+			status: http.StatusOK,
+			total:  10,
+		},
+		{
+			desc:   "list subscriptions with topic and contact",
+			token:  validToken,
+			query:  fmt.Sprintf("?topic=%s&contact=%s", topic, contact),
+			pm:     notifiers.PageMetadata{Offset: 0, Limit: 10, Topic: topic, Contact: contact},
+			svcRes: notifiers.Page{Total: 1, Subscriptions: subs[:1]},
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:   "list subscriptions with offset and limit",
+			token:  validToken,
+			query:  "?offset=5&limit=5",
+			pm:     notifiers.PageMetadata{Offset: 5, Limit: 5},
+			svcRes: notifiers.Page{Total: 10, Subscriptions: subs[5:]},
+			status: http.StatusOK,
+			total:  10,
+		},
+		{
+			desc:     "list subscriptions with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "list subscriptions with limit above maximum",
+			token:  validToken,
+			query:  "?limit=1000",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrLimitSize,
+		},
+		{
+			desc:   "list subscriptions with invalid offset",
+			token:  validToken,
+			query:  "?offset=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+		{
+			desc:   "list subscriptions with duplicate topic",
+			token:  validToken,
+			query:  fmt.Sprintf("?topic=%s&topic=%s", topic, topic),
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrInvalidQueryParams,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/subscriptions%s", ts.URL, tc.query),
+				token:  tc.token,
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("ListSubscriptions", mock.Anything, session, tc.pm).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := decodeErr(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+			assert.Equal(t, len(tc.svcRes.Subscriptions), len(body.Subscriptions), fmt.Sprintf("%s: expected %d subscriptions got %d", tc.desc, len(tc.svcRes.Subscriptions), len(body.Subscriptions)))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRemoveSubscription(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnErr error
+		svcErr   error
+		status   int
+		err      error
+	}{
+		{
+			desc:   "remove subscription successfully",
+			token:  validToken,
+			id:     subID,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:     "remove subscription with invalid token",
+			token:    invalidToken,
+			id:       subID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "remove non-existing subscription",
+			token:  validToken,
+			id:     "non-existing",
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/subscriptions/%s", ts.URL, tc.id),
+				token:  tc.token,
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("RemoveSubscription", mock.Anything, session, tc.id).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			_, err = decodeErr(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/consumers/notifiers"
+)
+
+const (
+	maxLimitSize   = 100
+	maxContactSize = 254
+)
+
+type createSubReq struct {
+	Topic   string `json:"topic,omitempty"`
+	Contact string `json:"contact,omitempty"`
+}
+
+func (req createSubReq) validate() error {
+	if req.Topic == "" {
+		return apiutil.ErrInvalidTopic
+	}
+	if req.Contact == "" || len(req.Contact) > maxContactSize {
+		return apiutil.ErrInvalidContact
+	}
+
+	return nil
+}
+
+type subReq struct {
+	id string
+}
+
+func (req subReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listSubsReq struct {
+	notifiers.PageMetadata
+}
+
+func (req listSubsReq) validate() error {
+	if req.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/supermq"
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/consumers/notifiers"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	topicKey   = "topic"
+	contactKey = "contact"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc notifiers.Service, authn smqauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/subscriptions", func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, false))
+
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createSubscriptionEndpoint(svc),
+			decodeCreate,
+			api.EncodeResponse,
+			opts...,
+		), "create_subscription").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listSubscriptionsEndpoint(svc),
+			decodeList,
+			api.EncodeResponse,
+			opts...,
+		), "list_subscriptions").ServeHTTP)
+
+		r.Get("/{subID}", otelhttp.NewHandler(kithttp.NewServer(
+			viewSubscriptionEndpoint(svc),
+			decodeSubscription,
+			api.EncodeResponse,
+			opts...,
+		), "view_subscription").ServeHTTP)
+
+		r.Delete("/{subID}", otelhttp.NewHandler(kithttp.NewServer(
+			removeSubscriptionEndpoint(svc),
+			decodeSubscription,
+			api.EncodeResponse,
+			opts...,
+		), "remove_subscription").ServeHTTP)
+	})
+
+	mux.Get("/health", supermq.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeCreate(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req createSubReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeSubscription(_ context.Context, r *http.Request) (interface{}, error) {
+	req := subReq{
+		id: chi.URLParam(r, "subID"),
+	}
+
+	return req, nil
+}
+
+func decodeList(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	topic, err := apiutil.ReadStringQuery(r, topicKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	contact, err := apiutil.ReadStringQuery(r, contactKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listSubsReq{
+		PageMetadata: notifiers.PageMetadata{
+			Offset:  offset,
+			Limit:   limit,
+			Topic:   topic,
+			Contact: contact,
+		},
+	}
+
+	return req, nil
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package notifiers contains the domain concept definitions needed to
+// support SuperMQ notifier services. Users create subscriptions that
+// bind a topic pattern to a contact, and every message published to a
+// matching topic is forwarded to the subscribed contacts using a
+// consumers.Notifier implementation.
+package notifiers
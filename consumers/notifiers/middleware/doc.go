@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package middleware provides middleware for the notifiers service.
+// This is logging, metrics, and tracing middleware.
+package middleware
@@ -0,0 +1,120 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/consumers/notifiers"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+)
+
+var _ notifiers.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    notifiers.Service
+}
+
+// LoggingMiddleware adds logging facilities to the notifier service.
+func LoggingMiddleware(svc notifiers.Service, logger *slog.Logger) notifiers.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) CreateSubscription(ctx context.Context, session smqauthn.Session, sub notifiers.Subscription) (id string, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("subscription",
+				slog.String("topic", sub.Topic),
+				slog.String("id", id),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create subscription failed", args...)
+			return
+		}
+		lm.logger.Info("Create subscription completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateSubscription(ctx, session, sub)
+}
+
+func (lm *loggingMiddleware) ViewSubscription(ctx context.Context, session smqauthn.Session, id string) (sub notifiers.Subscription, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("subscription_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View subscription failed", args...)
+			return
+		}
+		lm.logger.Info("View subscription completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewSubscription(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ListSubscriptions(ctx context.Context, session smqauthn.Session, pm notifiers.PageMetadata) (page notifiers.Page, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("page",
+				slog.String("topic", pm.Topic),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List subscriptions failed", args...)
+			return
+		}
+		lm.logger.Info("List subscriptions completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListSubscriptions(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) RemoveSubscription(ctx context.Context, session smqauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("subscription_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove subscription failed", args...)
+			return
+		}
+		lm.logger.Info("Remove subscription completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveSubscription(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ConsumeBlocking(ctx context.Context, msg interface{}) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Blocking consumer failed to consume messages successfully", args...)
+			return
+		}
+		lm.logger.Info("Blocking consumer consumed messages successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ConsumeBlocking(ctx, msg)
+}
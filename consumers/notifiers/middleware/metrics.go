@@ -0,0 +1,75 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/consumers/notifiers"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ notifiers.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     notifiers.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc notifiers.Service, counter metrics.Counter, latency metrics.Histogram) notifiers.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) CreateSubscription(ctx context.Context, session smqauthn.Session, sub notifiers.Subscription) (string, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_subscription").Add(1)
+		mm.latency.With("method", "create_subscription").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateSubscription(ctx, session, sub)
+}
+
+func (mm *metricsMiddleware) ViewSubscription(ctx context.Context, session smqauthn.Session, id string) (notifiers.Subscription, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_subscription").Add(1)
+		mm.latency.With("method", "view_subscription").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewSubscription(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ListSubscriptions(ctx context.Context, session smqauthn.Session, pm notifiers.PageMetadata) (notifiers.Page, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "list_subscriptions").Add(1)
+		mm.latency.With("method", "list_subscriptions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ListSubscriptions(ctx, session, pm)
+}
+
+func (mm *metricsMiddleware) RemoveSubscription(ctx context.Context, session smqauthn.Session, id string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_subscription").Add(1)
+		mm.latency.With("method", "remove_subscription").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveSubscription(ctx, session, id)
+}
+
+func (mm *metricsMiddleware) ConsumeBlocking(ctx context.Context, msg interface{}) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "consume").Add(1)
+		mm.latency.With("method", "consume").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ConsumeBlocking(ctx, msg)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/consumers/notifiers"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ notifiers.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    notifiers.Service
+}
+
+// Tracing returns a new notifier service with tracing capabilities.
+func Tracing(svc notifiers.Service, tracer trace.Tracer) notifiers.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) CreateSubscription(ctx context.Context, session smqauthn.Session, sub notifiers.Subscription) (string, error) {
+	ctx, span := tm.tracer.Start(ctx, "create_subscription", trace.WithAttributes(
+		attribute.String("topic", sub.Topic),
+	))
+	defer span.End()
+
+	return tm.svc.CreateSubscription(ctx, session, sub)
+}
+
+func (tm *tracing) ViewSubscription(ctx context.Context, session smqauthn.Session, id string) (notifiers.Subscription, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_subscription", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewSubscription(ctx, session, id)
+}
+
+func (tm *tracing) ListSubscriptions(ctx context.Context, session smqauthn.Session, pm notifiers.PageMetadata) (notifiers.Page, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_subscriptions", trace.WithAttributes(
+		attribute.String("topic", pm.Topic),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListSubscriptions(ctx, session, pm)
+}
+
+func (tm *tracing) RemoveSubscription(ctx context.Context, session smqauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_subscription", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveSubscription(ctx, session, id)
+}
+
+func (tm *tracing) ConsumeBlocking(ctx context.Context, msg interface{}) error {
+	ctx, span := tm.tracer.Start(ctx, "consume_blocking")
+	defer span.End()
+
+	return tm.svc.ConsumeBlocking(ctx, msg)
+}
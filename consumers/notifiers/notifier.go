@@ -0,0 +1,31 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/consumers"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+)
+
+// Service represents a notification service.
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// CreateSubscription persists a subscription.
+	// Successful operation is indicated by non-nil error response.
+	CreateSubscription(ctx context.Context, session smqauthn.Session, sub Subscription) (string, error)
+
+	// ViewSubscription retrieves the subscription for the given user and id.
+	ViewSubscription(ctx context.Context, session smqauthn.Session, id string) (Subscription, error)
+
+	// ListSubscriptions lists subscriptions of the user having the provided page metadata.
+	ListSubscriptions(ctx context.Context, session smqauthn.Session, pm PageMetadata) (Page, error)
+
+	// RemoveSubscription removes the subscription having the provided identifier.
+	RemoveSubscription(ctx context.Context, session smqauthn.Session, id string) error
+
+	consumers.BlockingConsumer
+}
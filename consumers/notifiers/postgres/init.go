@@ -0,0 +1,34 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of SMTP notifier.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "subscriptions_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS subscriptions (
+						id          VARCHAR(36) PRIMARY KEY,
+						owner_id    VARCHAR(36) NOT NULL,
+						contact     VARCHAR(254) NOT NULL,
+						topic       TEXT NOT NULL,
+						channel_id  VARCHAR(36) NOT NULL,
+						UNIQUE(owner_id, topic, contact)
+					)`,
+					`CREATE INDEX idx_subscriptions_channel_id ON subscriptions(channel_id);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS subscriptions`,
+				},
+			},
+		},
+	}
+}
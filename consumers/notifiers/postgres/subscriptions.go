@@ -0,0 +1,185 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/absmach/supermq/consumers/notifiers"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+)
+
+var _ notifiers.SubscriptionsRepository = (*subscriptionsRepo)(nil)
+
+type subscriptionsRepo struct {
+	db postgres.Database
+}
+
+// New instantiates a PostgreSQL implementation of Subscriptions repository.
+func New(db postgres.Database) notifiers.SubscriptionsRepository {
+	return &subscriptionsRepo{db: db}
+}
+
+func (repo subscriptionsRepo) Save(ctx context.Context, sub notifiers.Subscription) (string, error) {
+	q := `INSERT INTO subscriptions (id, owner_id, contact, topic, channel_id)
+		VALUES (:id, :owner_id, :contact, :topic, :channel_id) RETURNING id`
+
+	dbSub, err := toDBSub(sub)
+	if err != nil {
+		return "", errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	row, err := repo.db.NamedQueryContext(ctx, q, dbSub)
+	if err != nil {
+		return "", postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer row.Close()
+
+	var id string
+	if row.Next() {
+		if err := row.Scan(&id); err != nil {
+			return "", errors.Wrap(repoerr.ErrCreateEntity, err)
+		}
+	}
+
+	return id, nil
+}
+
+func (repo subscriptionsRepo) Retrieve(ctx context.Context, id string) (notifiers.Subscription, error) {
+	q := `SELECT id, owner_id, contact, topic, channel_id FROM subscriptions WHERE id = $1`
+
+	var sub dbSubscription
+	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&sub); err != nil {
+		if err == sql.ErrNoRows {
+			return notifiers.Subscription{}, repoerr.ErrNotFound
+		}
+		return notifiers.Subscription{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return fromDBSub(sub), nil
+}
+
+func (repo subscriptionsRepo) RetrieveAll(ctx context.Context, pm notifiers.PageMetadata) (notifiers.Page, error) {
+	query := pageQuery(pm)
+
+	q := fmt.Sprintf(`SELECT id, owner_id, contact, topic, channel_id FROM subscriptions %s
+		ORDER BY id LIMIT :limit OFFSET :offset`, query)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return notifiers.Page{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	subs := []notifiers.Subscription{}
+	for rows.Next() {
+		var sub dbSubscription
+		if err := rows.StructScan(&sub); err != nil {
+			return notifiers.Page{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		subs = append(subs, fromDBSub(sub))
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM subscriptions %s`, query)
+	total, err := postgres.Total(ctx, repo.db, cq, pm)
+	if err != nil {
+		return notifiers.Page{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return notifiers.Page{
+		PageMetadata:  pm,
+		Total:         total,
+		Subscriptions: subs,
+	}, nil
+}
+
+func (repo subscriptionsRepo) RetrieveByChannel(ctx context.Context, chanID string) ([]notifiers.Subscription, error) {
+	q := `SELECT id, owner_id, contact, topic, channel_id FROM subscriptions WHERE channel_id = $1`
+
+	rows, err := repo.db.QueryxContext(ctx, q, chanID)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var subs []notifiers.Subscription
+	for rows.Next() {
+		var sub dbSubscription
+		if err := rows.StructScan(&sub); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		subs = append(subs, fromDBSub(sub))
+	}
+
+	return subs, nil
+}
+
+func (repo subscriptionsRepo) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM subscriptions WHERE id = $1`
+
+	result, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func pageQuery(pm notifiers.PageMetadata) string {
+	var query []string
+	if pm.OwnerID != "" {
+		query = append(query, "owner_id = :owner_id")
+	}
+	if pm.Topic != "" {
+		query = append(query, "topic = :topic")
+	}
+	if pm.Contact != "" {
+		query = append(query, "contact = :contact")
+	}
+	if len(query) > 0 {
+		return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	return ""
+}
+
+type dbSubscription struct {
+	ID        string `db:"id"`
+	OwnerID   string `db:"owner_id"`
+	Contact   string `db:"contact"`
+	Topic     string `db:"topic"`
+	ChannelID string `db:"channel_id"`
+}
+
+func toDBSub(sub notifiers.Subscription) (dbSubscription, error) {
+	chanID, err := notifiers.ParseTopic(sub.Topic)
+	if err != nil {
+		return dbSubscription{}, err
+	}
+
+	return dbSubscription{
+		ID:        sub.ID,
+		OwnerID:   sub.OwnerID,
+		Contact:   sub.Contact,
+		Topic:     sub.Topic,
+		ChannelID: chanID,
+	}, nil
+}
+
+func fromDBSub(sub dbSubscription) notifiers.Subscription {
+	return notifiers.Subscription{
+		ID:      sub.ID,
+		OwnerID: sub.OwnerID,
+		Contact: sub.Contact,
+		Topic:   sub.Topic,
+	}
+}
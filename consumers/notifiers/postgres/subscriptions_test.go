@@ -0,0 +1,301 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/consumers/notifiers"
+	notifierpg "github.com/absmach/supermq/consumers/notifiers/postgres"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	owner   = "owner@example.com"
+	numSubs = 100
+)
+
+var contact = "contact@example.com"
+
+func TestSave(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM subscriptions")
+		require.Nil(t, err, fmt.Sprintf("clean subscriptions unexpected error: %s", err))
+	})
+	repo := notifierpg.New(database)
+
+	id1 := testsutil.GenerateUUID(t)
+	id2 := testsutil.GenerateUUID(t)
+	ownerID := testsutil.GenerateUUID(t)
+	topic := testsutil.GenerateUUID(t) + ".temperature"
+
+	sub1 := notifiers.Subscription{
+		OwnerID: ownerID,
+		ID:      id1,
+		Contact: contact,
+		Topic:   topic,
+	}
+
+	sub2 := sub1
+	sub2.ID = id2
+
+	cases := []struct {
+		desc string
+		sub  notifiers.Subscription
+		id   string
+		err  error
+	}{
+		{
+			desc: "save successfully",
+			sub:  sub1,
+			id:   id1,
+			err:  nil,
+		},
+		{
+			desc: "save duplicate",
+			sub:  sub2,
+			id:   "",
+			err:  repoerr.ErrConflict,
+		},
+		{
+			desc: "save with invalid topic",
+			sub: notifiers.Subscription{
+				OwnerID: ownerID,
+				ID:      testsutil.GenerateUUID(t),
+				Contact: contact,
+				Topic:   "",
+			},
+			id:  "",
+			err: repoerr.ErrMalformedEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			id, err := repo.Save(context.Background(), tc.sub)
+			assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected id %s got %s\n", tc.desc, tc.id, id))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestView(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM subscriptions")
+		require.Nil(t, err, fmt.Sprintf("clean subscriptions unexpected error: %s", err))
+	})
+	repo := notifierpg.New(database)
+
+	id := testsutil.GenerateUUID(t)
+	sub := notifiers.Subscription{
+		OwnerID: testsutil.GenerateUUID(t),
+		ID:      id,
+		Contact: contact,
+		Topic:   testsutil.GenerateUUID(t),
+	}
+
+	_, err := repo.Save(context.Background(), sub)
+	require.Nil(t, err, fmt.Sprintf("save subscription unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		sub  notifiers.Subscription
+		id   string
+		err  error
+	}{
+		{
+			desc: "retrieve successfully",
+			sub:  sub,
+			id:   id,
+			err:  nil,
+		},
+		{
+			desc: "retrieve not existing",
+			sub:  notifiers.Subscription{},
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			sub, err := repo.Retrieve(context.Background(), tc.id)
+			assert.Equal(t, tc.sub, sub, fmt.Sprintf("%s: expected sub %v got %v\n", tc.desc, tc.sub, sub))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM subscriptions")
+		require.Nil(t, err, fmt.Sprintf("clean subscriptions unexpected error: %s", err))
+	})
+	repo := notifierpg.New(database)
+
+	ownerID := testsutil.GenerateUUID(t)
+	chanID := testsutil.GenerateUUID(t)
+
+	var subs []notifiers.Subscription
+	for i := 0; i < numSubs; i++ {
+		sub := notifiers.Subscription{
+			OwnerID: ownerID,
+			ID:      fmt.Sprintf("%03d", i),
+			Contact: fmt.Sprintf("%d@example.com", i),
+			Topic:   fmt.Sprintf("%s.topic%d", chanID, i),
+		}
+		_, err := repo.Save(context.Background(), sub)
+		require.Nil(t, err, fmt.Sprintf("save subscription unexpected error: %s", err))
+		subs = append(subs, sub)
+	}
+
+	cases := []struct {
+		desc     string
+		pageMeta notifiers.PageMetadata
+		page     notifiers.Page
+		err      error
+	}{
+		{
+			desc: "retrieve all",
+			pageMeta: notifiers.PageMetadata{
+				Offset: 10,
+				Limit:  2,
+			},
+			page: notifiers.Page{
+				Total:         numSubs,
+				Subscriptions: subs[10:12],
+			},
+		},
+		{
+			desc: "retrieve all by owner",
+			pageMeta: notifiers.PageMetadata{
+				Offset:  0,
+				Limit:   numSubs,
+				OwnerID: ownerID,
+			},
+			page: notifiers.Page{
+				Total:         numSubs,
+				Subscriptions: subs,
+			},
+		},
+		{
+			desc: "retrieve all by topic",
+			pageMeta: notifiers.PageMetadata{
+				Offset: 0,
+				Limit:  10,
+				Topic:  fmt.Sprintf("%s.topic1", chanID),
+			},
+			page: notifiers.Page{
+				Total:         1,
+				Subscriptions: subs[1:2],
+			},
+		},
+		{
+			desc: "retrieve all by contact",
+			pageMeta: notifiers.PageMetadata{
+				Offset:  0,
+				Limit:   10,
+				Contact: "2@example.com",
+			},
+			page: notifiers.Page{
+				Total:         1,
+				Subscriptions: subs[2:3],
+			},
+		},
+		{
+			desc: "retrieve all for unknown owner",
+			pageMeta: notifiers.PageMetadata{
+				Offset:  0,
+				Limit:   10,
+				OwnerID: testsutil.GenerateUUID(t),
+			},
+			page: notifiers.Page{
+				Subscriptions: []notifiers.Subscription{},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pageMeta)
+			tc.page.PageMetadata = tc.pageMeta
+			assert.Equal(t, tc.page, page, fmt.Sprintf("%s: expected page %v got %v\n", tc.desc, tc.page, page))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestRetrieveByChannel(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM subscriptions")
+		require.Nil(t, err, fmt.Sprintf("clean subscriptions unexpected error: %s", err))
+	})
+	repo := notifierpg.New(database)
+
+	chanID := testsutil.GenerateUUID(t)
+	topics := []string{chanID, chanID + ".temperature", chanID + ".>", testsutil.GenerateUUID(t)}
+	for _, topic := range topics {
+		sub := notifiers.Subscription{
+			OwnerID: testsutil.GenerateUUID(t),
+			ID:      testsutil.GenerateUUID(t),
+			Contact: contact,
+			Topic:   topic,
+		}
+		_, err := repo.Save(context.Background(), sub)
+		require.Nil(t, err, fmt.Sprintf("save subscription unexpected error: %s", err))
+	}
+
+	subs, err := repo.RetrieveByChannel(context.Background(), chanID)
+	assert.Nil(t, err, fmt.Sprintf("retrieve by channel unexpected error: %s", err))
+	assert.Len(t, subs, 3, fmt.Sprintf("expected 3 subscriptions got %d", len(subs)))
+}
+
+func TestRemove(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM subscriptions")
+		require.Nil(t, err, fmt.Sprintf("clean subscriptions unexpected error: %s", err))
+	})
+	repo := notifierpg.New(database)
+
+	id := testsutil.GenerateUUID(t)
+	sub := notifiers.Subscription{
+		OwnerID: owner,
+		ID:      id,
+		Contact: contact,
+		Topic:   testsutil.GenerateUUID(t),
+	}
+
+	_, err := repo.Save(context.Background(), sub)
+	require.Nil(t, err, fmt.Sprintf("save subscription unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove successfully",
+			id:   id,
+			err:  nil,
+		},
+		{
+			desc: "remove not existing",
+			id:   id,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
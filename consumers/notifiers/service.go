@@ -0,0 +1,206 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"context"
+	"slices"
+
+	"github.com/absmach/supermq"
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/consumers"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/policies"
+)
+
+// ErrMessage indicates an error converting a message to SuperMQ message.
+var ErrMessage = errors.New("failed to convert to SuperMQ message")
+
+var _ consumers.BlockingConsumer = (*notifierService)(nil)
+
+type notifierService struct {
+	subs     SubscriptionsRepository
+	idp      supermq.IDProvider
+	channels grpcChannelsV1.ChannelsServiceClient
+	notifier consumers.Notifier
+	from     string
+}
+
+// New instantiates the subscriptions service implementation.
+func New(subs SubscriptionsRepository, idp supermq.IDProvider, channels grpcChannelsV1.ChannelsServiceClient, notifier consumers.Notifier, from string) Service {
+	return &notifierService{
+		subs:     subs,
+		idp:      idp,
+		channels: channels,
+		notifier: notifier,
+		from:     from,
+	}
+}
+
+func (ns *notifierService) CreateSubscription(ctx context.Context, session smqauthn.Session, sub Subscription) (string, error) {
+	chanID, err := ParseTopic(sub.Topic)
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+	domainID, err := ns.channelDomain(ctx, chanID)
+	if err != nil {
+		return "", err
+	}
+	if err := ns.authorize(ctx, domainID, session.UserID, chanID); err != nil {
+		return "", err
+	}
+
+	id, err := ns.idp.ID()
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	sub.ID = id
+	sub.OwnerID = session.UserID
+
+	id, err = ns.subs.Save(ctx, sub)
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	return id, nil
+}
+
+func (ns *notifierService) ViewSubscription(ctx context.Context, session smqauthn.Session, id string) (Subscription, error) {
+	sub, err := ns.subs.Retrieve(ctx, id)
+	if err != nil {
+		return Subscription{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if sub.OwnerID != session.UserID {
+		return Subscription{}, svcerr.ErrNotFound
+	}
+
+	return sub, nil
+}
+
+func (ns *notifierService) ListSubscriptions(ctx context.Context, session smqauthn.Session, pm PageMetadata) (Page, error) {
+	pm.OwnerID = session.UserID
+	page, err := ns.subs.RetrieveAll(ctx, pm)
+	if err != nil {
+		return Page{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (ns *notifierService) RemoveSubscription(ctx context.Context, session smqauthn.Session, id string) error {
+	if _, err := ns.ViewSubscription(ctx, session, id); err != nil {
+		return err
+	}
+	if err := ns.subs.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (ns *notifierService) ConsumeBlocking(ctx context.Context, message interface{}) error {
+	msg, ok := message.(*messaging.Message)
+	if !ok {
+		return ErrMessage
+	}
+
+	topic := msg.GetChannel()
+	if msg.GetSubtopic() != "" {
+		topic = topic + topicSeparator + msg.GetSubtopic()
+	}
+
+	subs, err := ns.subs.RetrieveByChannel(ctx, msg.GetChannel())
+	if err != nil {
+		return err
+	}
+
+	var matched []Subscription
+	for _, sub := range subs {
+		if MatchTopic(sub.Topic, topic) {
+			matched = append(matched, sub)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+
+	// The owners may have lost access to the channel since they subscribed,
+	// so they're authorized again on each delivery.
+	domainID, err := ns.channelDomain(ctx, msg.GetChannel())
+	if err != nil {
+		return err
+	}
+	// The contacts of the owners who can't be authorized are skipped, and
+	// the authorization error is returned once the others are notified.
+	var ret error
+	owners := make(map[string]bool)
+	var to []string
+	for _, sub := range matched {
+		ok, checked := owners[sub.OwnerID]
+		if !checked {
+			var err error
+			ok, err = ns.allowed(ctx, domainID, sub.OwnerID, msg.GetChannel())
+			if err != nil && ret == nil {
+				ret = errors.Wrap(svcerr.ErrAuthorization, err)
+			}
+			owners[sub.OwnerID] = ok
+		}
+		if ok && !slices.Contains(to, sub.Contact) {
+			to = append(to, sub.Contact)
+		}
+	}
+
+	if len(to) > 0 {
+		if err := ns.notifier.Notify(ns.from, to, msg); err != nil {
+			return errors.Wrap(consumers.ErrNotify, err)
+		}
+	}
+
+	return ret
+}
+
+// channelDomain returns the ID of the domain of the channel.
+func (ns *notifierService) channelDomain(ctx context.Context, chanID string) (string, error) {
+	res, err := ns.channels.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: chanID})
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+
+	return res.GetEntity().GetDomainId(), nil
+}
+
+// authorize checks whether the user is allowed to subscribe to the channel.
+func (ns *notifierService) authorize(ctx context.Context, domainID, userID, chanID string) error {
+	ok, err := ns.allowed(ctx, domainID, userID, chanID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !ok {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
+
+// allowed reports whether the user is allowed to subscribe to the channel.
+func (ns *notifierService) allowed(ctx context.Context, domainID, userID, chanID string) (bool, error) {
+	res, err := ns.channels.Authorize(ctx, &grpcChannelsV1.AuthzReq{
+		DomainId:   domainID,
+		ClientId:   auth.EncodeDomainUserID(domainID, userID),
+		ClientType: policies.UserType,
+		ChannelId:  chanID,
+		Type:       uint32(connections.Subscribe),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return res.GetAuthorized(), nil
+}
@@ -0,0 +1,370 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	"github.com/absmach/supermq/auth"
+	chmocks "github.com/absmach/supermq/channels/mocks"
+	"github.com/absmach/supermq/consumers"
+	cmocks "github.com/absmach/supermq/consumers/mocks"
+	"github.com/absmach/supermq/consumers/notifiers"
+	"github.com/absmach/supermq/consumers/notifiers/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	contact1 = "user1@example.com"
+	contact2 = "user2@example.com"
+	contact3 = "user3@example.com"
+	from     = "notifier@example.com"
+)
+
+var (
+	chanID   = testsutil.GenerateUUID(&testing.T{})
+	userID   = testsutil.GenerateUUID(&testing.T{})
+	otherID  = testsutil.GenerateUUID(&testing.T{})
+	domainID = testsutil.GenerateUUID(&testing.T{})
+	session  = smqauthn.Session{UserID: userID}
+)
+
+func newService() (notifiers.Service, *mocks.SubscriptionsRepository, *chmocks.ChannelsServiceClient, *cmocks.Notifier) {
+	repo := new(mocks.SubscriptionsRepository)
+	channels := new(chmocks.ChannelsServiceClient)
+	notifier := new(cmocks.Notifier)
+
+	return notifiers.New(repo, uuid.NewMock(), channels, notifier, from), repo, channels, notifier
+}
+
+func TestCreateSubscription(t *testing.T) {
+	svc, repo, channels, _ := newService()
+
+	cases := []struct {
+		desc     string
+		sub      notifiers.Subscription
+		authzRes *grpcChannelsV1.AuthzRes
+		authzErr error
+		repoErr  error
+		err      error
+	}{
+		{
+			desc:     "create subscription successfully",
+			sub:      notifiers.Subscription{Contact: contact1, Topic: chanID + ".temperature"},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+		},
+		{
+			desc:     "create subscription with wildcard topic",
+			sub:      notifiers.Subscription{Contact: contact1, Topic: chanID + ".*.>"},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+		},
+		{
+			desc: "create subscription with invalid topic",
+			sub:  notifiers.Subscription{Contact: contact1, Topic: "*.temperature"},
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:     "create subscription for unauthorized channel",
+			sub:      notifiers.Subscription{Contact: contact1, Topic: chanID},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: false},
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "create subscription with failed authorization",
+			sub:      notifiers.Subscription{Contact: contact1, Topic: chanID},
+			authzRes: &grpcChannelsV1.AuthzRes{},
+			authzErr: svcerr.ErrAuthorization,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "create duplicate subscription",
+			sub:      notifiers.Subscription{Contact: contact1, Topic: chanID},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			repoErr:  repoerr.ErrConflict,
+			err:      repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			entityCall := channels.On("RetrieveEntity", mock.Anything, &grpcCommonV1.RetrieveEntityReq{Id: chanID}).Return(&grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: chanID}}, nil)
+			authzCall := channels.On("Authorize", mock.Anything, mock.Anything).Return(tc.authzRes, tc.authzErr)
+			repoCall := repo.On("Save", mock.Anything, mock.Anything).Return(mock.Anything, tc.repoErr)
+			id, err := svc.CreateSubscription(context.Background(), session, tc.sub)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.NotEmpty(t, id, fmt.Sprintf("%s: expected non-empty id", tc.desc))
+				ok := repoCall.Parent.AssertCalled(t, "Save", mock.Anything, mock.MatchedBy(func(sub notifiers.Subscription) bool {
+					return sub.OwnerID == userID && sub.Topic == tc.sub.Topic && sub.Contact == tc.sub.Contact
+				}))
+				assert.True(t, ok, fmt.Sprintf("%s: Save was not called with the expected subscription", tc.desc))
+			}
+			entityCall.Unset()
+			authzCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewSubscription(t *testing.T) {
+	svc, repo, _, _ := newService()
+
+	sub := notifiers.Subscription{
+		ID:      testsutil.GenerateUUID(t),
+		OwnerID: userID,
+		Contact: contact1,
+		Topic:   chanID,
+	}
+	otherSub := sub
+	otherSub.OwnerID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc    string
+		id      string
+		repoRes notifiers.Subscription
+		repoErr error
+		res     notifiers.Subscription
+		err     error
+	}{
+		{
+			desc:    "view subscription successfully",
+			id:      sub.ID,
+			repoRes: sub,
+			res:     sub,
+		},
+		{
+			desc:    "view subscription of another user",
+			id:      sub.ID,
+			repoRes: otherSub,
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:    "view non-existing subscription",
+			id:      testsutil.GenerateUUID(t),
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("Retrieve", mock.Anything, tc.id).Return(tc.repoRes, tc.repoErr)
+			res, err := svc.ViewSubscription(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestListSubscriptions(t *testing.T) {
+	svc, repo, _, _ := newService()
+
+	page := notifiers.Page{
+		Total: 1,
+		Subscriptions: []notifiers.Subscription{
+			{ID: testsutil.GenerateUUID(t), OwnerID: userID, Contact: contact1, Topic: chanID},
+		},
+	}
+
+	cases := []struct {
+		desc    string
+		pm      notifiers.PageMetadata
+		repoRes notifiers.Page
+		repoErr error
+		res     notifiers.Page
+		err     error
+	}{
+		{
+			desc:    "list subscriptions successfully",
+			pm:      notifiers.PageMetadata{Limit: 10},
+			repoRes: page,
+			res:     page,
+		},
+		{
+			desc:    "list subscriptions with repository error",
+			pm:      notifiers.PageMetadata{Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pm := tc.pm
+			pm.OwnerID = userID
+			repoCall := repo.On("RetrieveAll", mock.Anything, pm).Return(tc.repoRes, tc.repoErr)
+			res, err := svc.ListSubscriptions(context.Background(), session, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRemoveSubscription(t *testing.T) {
+	svc, repo, _, _ := newService()
+
+	sub := notifiers.Subscription{
+		ID:      testsutil.GenerateUUID(t),
+		OwnerID: userID,
+		Contact: contact1,
+		Topic:   chanID,
+	}
+	otherSub := sub
+	otherSub.OwnerID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc      string
+		repoRes   notifiers.Subscription
+		removeErr error
+		err       error
+	}{
+		{
+			desc:    "remove subscription successfully",
+			repoRes: sub,
+		},
+		{
+			desc:    "remove subscription of another user",
+			repoRes: otherSub,
+			err:     svcerr.ErrNotFound,
+		},
+		{
+			desc:      "remove subscription with repository error",
+			repoRes:   sub,
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			retrieveCall := repo.On("Retrieve", mock.Anything, sub.ID).Return(tc.repoRes, nil)
+			removeCall := repo.On("Remove", mock.Anything, sub.ID).Return(tc.removeErr)
+			err := svc.RemoveSubscription(context.Background(), session, sub.ID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			retrieveCall.Unset()
+			removeCall.Unset()
+		})
+	}
+}
+
+func TestConsumeBlocking(t *testing.T) {
+	svc, repo, channels, notifier := newService()
+
+	subs := []notifiers.Subscription{
+		{OwnerID: userID, Contact: contact1, Topic: chanID},
+		{OwnerID: userID, Contact: contact1, Topic: chanID + ".>"},
+		{OwnerID: userID, Contact: contact2, Topic: chanID + ".temperature"},
+		{OwnerID: userID, Contact: contact2, Topic: chanID + ".*.room1"},
+		{OwnerID: otherID, Contact: contact3, Topic: chanID},
+	}
+	owner := func(id string) *grpcChannelsV1.AuthzReq {
+		return &grpcChannelsV1.AuthzReq{
+			DomainId:   domainID,
+			ClientId:   auth.EncodeDomainUserID(domainID, id),
+			ClientType: policies.UserType,
+			ChannelId:  chanID,
+			Type:       uint32(connections.Subscribe),
+		}
+	}
+
+	cases := []struct {
+		desc       string
+		msg        interface{}
+		to         []string
+		entityErr  error
+		otherAllow bool
+		otherErr   error
+		notifyErr  error
+		err        error
+	}{
+		{
+			desc: "consume message without subtopic",
+			msg:  &messaging.Message{Channel: chanID},
+			to:   []string{contact1},
+		},
+		{
+			desc: "consume message with subtopic",
+			msg:  &messaging.Message{Channel: chanID, Subtopic: "temperature"},
+			to:   []string{contact1, contact2},
+		},
+		{
+			desc: "consume message with subtopic matching single wildcard",
+			msg:  &messaging.Message{Channel: chanID, Subtopic: "humidity.room1"},
+			to:   []string{contact1, contact2},
+		},
+		{
+			desc: "consume message with subtopic matching only multi wildcard",
+			msg:  &messaging.Message{Channel: chanID, Subtopic: "humidity.room2"},
+			to:   []string{contact1},
+		},
+		{
+			desc:       "consume message with all owners authorized",
+			msg:        &messaging.Message{Channel: chanID},
+			to:         []string{contact1, contact3},
+			otherAllow: true,
+		},
+		{
+			desc:     "consume message with failed owner authorization",
+			msg:      &messaging.Message{Channel: chanID},
+			to:       []string{contact1},
+			otherErr: svcerr.ErrAuthorization,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "consume message with failed to retrieve channel",
+			msg:       &messaging.Message{Channel: chanID},
+			entityErr: svcerr.ErrNotFound,
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "consume message with notifier error",
+			msg:       &messaging.Message{Channel: chanID},
+			to:        []string{contact1},
+			notifyErr: consumers.ErrNotify,
+			err:       consumers.ErrNotify,
+		},
+		{
+			desc: "consume invalid message",
+			msg:  "invalid",
+			err:  notifiers.ErrMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByChannel", mock.Anything, chanID).Return(subs, nil)
+			entityCall := channels.On("RetrieveEntity", mock.Anything, &grpcCommonV1.RetrieveEntityReq{Id: chanID}).Return(&grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: chanID, DomainId: domainID}}, tc.entityErr)
+			authzCall := channels.On("Authorize", mock.Anything, owner(userID)).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+			authzCall1 := channels.On("Authorize", mock.Anything, owner(otherID)).Return(&grpcChannelsV1.AuthzRes{Authorized: tc.otherAllow}, tc.otherErr)
+			notifyCall := notifier.On("Notify", from, tc.to, tc.msg).Return(tc.notifyErr)
+			err := svc.ConsumeBlocking(context.Background(), tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if len(tc.to) > 0 {
+				ok := notifyCall.Parent.AssertCalled(t, "Notify", from, tc.to, tc.msg)
+				assert.True(t, ok, fmt.Sprintf("%s: Notify was not called with expected contacts", tc.desc))
+			}
+			repoCall.Unset()
+			entityCall.Unset()
+			authzCall.Unset()
+			authzCall1.Unset()
+			notifyCall.Unset()
+		})
+	}
+}
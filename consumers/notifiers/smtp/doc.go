@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package smtp contains the SMTP implementation of the consumers.Notifier
+// interface, sending e-mail notifications for received messages.
+package smtp
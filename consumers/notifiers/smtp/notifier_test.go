@@ -0,0 +1,236 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package smtp_test
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/absmach/supermq/consumers/notifiers/smtp"
+	"github.com/absmach/supermq/internal/email"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tmpl = `To: {{range $index, $v := .To}}{{if $index}},{{end}}{{$v}}{{end}}
+From: {{.From}}
+Subject: {{.Subject}}
+{{.Header}}
+You have a new message:
+{{.Content}}
+{{.Footer}}
+`
+
+// mail is an e-mail received by the fake SMTP server.
+type mail struct {
+	from string
+	to   []string
+	data string
+}
+
+// fakeSMTP is a minimal SMTP server that records received e-mails.
+type fakeSMTP struct {
+	listener net.Listener
+	mu       sync.Mutex
+	mails    []mail
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err, fmt.Sprintf("failed to start fake SMTP server: %s", err))
+
+	s := &fakeSMTP{listener: l}
+	go s.serve()
+	t.Cleanup(func() { l.Close() })
+
+	return s
+}
+
+func (s *fakeSMTP) port() string {
+	return fmt.Sprint(s.listener.Addr().(*net.TCPAddr).Port)
+}
+
+func (s *fakeSMTP) received() []mail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]mail{}, s.mails...)
+}
+
+func (s *fakeSMTP) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTP) handle(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) {
+		fmt.Fprintf(conn, "%s\r\n", line)
+	}
+
+	var m mail
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "MAIL":
+			m = mail{from: extractAddress(line)}
+			reply("250 OK")
+		case "RCPT":
+			m.to = append(m.to, extractAddress(line))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			m.data = data.String()
+			s.mu.Lock()
+			s.mails = append(s.mails, m)
+			s.mu.Unlock()
+			reply("250 OK")
+		case "RSET", "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func extractAddress(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.Index(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+
+	return line[start+1 : end]
+}
+
+func newAgent(t *testing.T, port string) *email.Agent {
+	path := filepath.Join(t.TempDir(), "email.tmpl")
+	err := os.WriteFile(path, []byte(tmpl), 0o600)
+	require.Nil(t, err, fmt.Sprintf("failed to write template: %s", err))
+
+	agent, err := email.New(&email.Config{
+		Host:        "127.0.0.1",
+		Port:        port,
+		FromAddress: "notifier@example.com",
+		FromName:    "SuperMQ",
+		Template:    path,
+	})
+	require.Nil(t, err, fmt.Sprintf("failed to create e-mail agent: %s", err))
+
+	return agent
+}
+
+func TestNotify(t *testing.T) {
+	server := newFakeSMTP(t)
+	notifier := smtp.New(newAgent(t, server.port()))
+
+	cases := []struct {
+		desc     string
+		from     string
+		to       []string
+		msg      *messaging.Message
+		subject  string
+		contains string
+		err      bool
+	}{
+		{
+			desc: "notify with message without subtopic",
+			from: "sender@example.com",
+			to:   []string{"user1@example.com", "user2@example.com"},
+			msg: &messaging.Message{
+				Channel:   "chan",
+				Publisher: "pub",
+				Protocol:  "http",
+				Payload:   []byte(`[{"n":"temp","v":20}]`),
+			},
+			subject:  "Notification for Channel chan",
+			contains: `[{"n":"temp","v":20}]`,
+		},
+		{
+			desc: "notify with message with subtopic",
+			from: "sender@example.com",
+			to:   []string{"user1@example.com"},
+			msg: &messaging.Message{
+				Channel:   "chan",
+				Subtopic:  "temperature",
+				Publisher: "pub",
+				Protocol:  "mqtt",
+				Payload:   []byte("hello"),
+			},
+			subject:  "Notification for Channel chan and subtopic temperature",
+			contains: "A publisher with an id pub sent the message over mqtt",
+		},
+		{
+			desc: "notify with default sender",
+			to:   []string{"user1@example.com"},
+			msg: &messaging.Message{
+				Channel: "chan",
+				Payload: []byte("hello"),
+			},
+			subject:  "Notification for Channel chan",
+			contains: "notifier@example.com",
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := notifier.Notify(tc.from, tc.to, tc.msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+
+			mails := server.received()
+			require.Len(t, mails, i+1, fmt.Sprintf("%s: expected %d mails got %d", tc.desc, i+1, len(mails)))
+			m := mails[i]
+			assert.Equal(t, tc.to, m.to, fmt.Sprintf("%s: expected recipients %v got %v", tc.desc, tc.to, m.to))
+			assert.Contains(t, m.data, tc.subject, fmt.Sprintf("%s: expected subject %s", tc.desc, tc.subject))
+			assert.Contains(t, m.data, tc.contains, fmt.Sprintf("%s: expected mail to contain %s", tc.desc, tc.contains))
+		})
+	}
+}
+
+func TestNotifyFailure(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err, fmt.Sprintf("failed to reserve port: %s", err))
+	port := fmt.Sprint(l.Addr().(*net.TCPAddr).Port)
+	l.Close()
+
+	notifier := smtp.New(newAgent(t, port))
+	err = notifier.Notify("", []string{"user@example.com"}, &messaging.Message{Channel: "chan"})
+	assert.NotNil(t, err, "expected error sending to unavailable SMTP server")
+}
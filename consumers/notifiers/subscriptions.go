@@ -0,0 +1,54 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import "context"
+
+// Subscription represents a user subscription to the topic. Every message
+// published to a topic matching the subscription topic will be sent to the
+// subscription contact.
+type Subscription struct {
+	ID      string `json:"id"`
+	OwnerID string `json:"owner_id"`
+	Contact string `json:"contact"`
+	Topic   string `json:"topic"`
+}
+
+// Page represents page metadata with content.
+type Page struct {
+	PageMetadata
+	Total         uint64         `json:"total"`
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+// PageMetadata contains page metadata that helps navigation.
+type PageMetadata struct {
+	Offset  uint64 `json:"offset"`
+	Limit   uint64 `json:"limit"`
+	OwnerID string `json:"owner_id,omitempty"`
+	Topic   string `json:"topic,omitempty"`
+	Contact string `json:"contact,omitempty"`
+}
+
+// SubscriptionsRepository specifies a Subscription persistence API.
+//
+//go:generate mockery --name SubscriptionsRepository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type SubscriptionsRepository interface {
+	// Save persists a subscription. Successful operation is indicated by non-nil
+	// error response.
+	Save(ctx context.Context, sub Subscription) (string, error)
+
+	// Retrieve retrieves the subscription for the given id.
+	Retrieve(ctx context.Context, id string) (Subscription, error)
+
+	// RetrieveAll retrieves all the subscriptions for the given page metadata.
+	RetrieveAll(ctx context.Context, pm PageMetadata) (Page, error)
+
+	// RetrieveByChannel retrieves all the subscriptions whose topic belongs
+	// to the given channel.
+	RetrieveByChannel(ctx context.Context, chanID string) ([]Subscription, error)
+
+	// Remove removes the subscription for the given ID.
+	Remove(ctx context.Context, id string) error
+}
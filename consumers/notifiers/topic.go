@@ -0,0 +1,69 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers
+
+import (
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const (
+	topicSeparator = "."
+	// singleWildcard matches exactly one subtopic segment.
+	singleWildcard = "*"
+	// multiWildcard matches one or more trailing subtopic segments.
+	multiWildcard = ">"
+)
+
+// ErrInvalidTopic indicates a malformed subscription topic.
+var ErrInvalidTopic = errors.New("invalid subscription topic")
+
+// ParseTopic validates the subscription topic and returns the channel ID it
+// belongs to. The topic has the form <channel_id>[.<subtopic>], where the
+// subtopic segments may contain "*" to match a single segment, and a
+// trailing ">" to match any number of remaining segments.
+func ParseTopic(topic string) (string, error) {
+	if topic == "" || strings.ContainsAny(topic, " \t\r\n") {
+		return "", ErrInvalidTopic
+	}
+
+	segments := strings.Split(topic, topicSeparator)
+	chanID := segments[0]
+	if chanID == singleWildcard || chanID == multiWildcard {
+		return "", ErrInvalidTopic
+	}
+	for i, s := range segments {
+		switch {
+		case s == "":
+			return "", ErrInvalidTopic
+		case s == multiWildcard && i != len(segments)-1:
+			return "", ErrInvalidTopic
+		case s != singleWildcard && s != multiWildcard && strings.ContainsAny(s, singleWildcard+multiWildcard):
+			return "", ErrInvalidTopic
+		}
+	}
+
+	return chanID, nil
+}
+
+// MatchTopic reports whether the message topic matches the subscription topic.
+func MatchTopic(pattern, topic string) bool {
+	ps := strings.Split(pattern, topicSeparator)
+	ts := strings.Split(topic, topicSeparator)
+
+	for i, p := range ps {
+		if p == multiWildcard {
+			return len(ts) > i
+		}
+		if i >= len(ts) {
+			return false
+		}
+		if p != singleWildcard && p != ts[i] {
+			return false
+		}
+	}
+
+	return len(ps) == len(ts)
+}
@@ -0,0 +1,66 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package notifiers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/consumers/notifiers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTopic(t *testing.T) {
+	cases := []struct {
+		topic  string
+		chanID string
+		err    error
+	}{
+		{topic: "chan", chanID: "chan"},
+		{topic: "chan.temp", chanID: "chan"},
+		{topic: "chan.*.room", chanID: "chan"},
+		{topic: "chan.>", chanID: "chan"},
+		{topic: "", err: notifiers.ErrInvalidTopic},
+		{topic: "*", err: notifiers.ErrInvalidTopic},
+		{topic: ">", err: notifiers.ErrInvalidTopic},
+		{topic: "chan..temp", err: notifiers.ErrInvalidTopic},
+		{topic: "chan.>.temp", err: notifiers.ErrInvalidTopic},
+		{topic: "chan.te*mp", err: notifiers.ErrInvalidTopic},
+		{topic: "chan temp", err: notifiers.ErrInvalidTopic},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.topic, func(t *testing.T) {
+			chanID, err := notifiers.ParseTopic(tc.topic)
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected error %v got %v", tc.topic, tc.err, err))
+			assert.Equal(t, tc.chanID, chanID, fmt.Sprintf("%s: expected channel %s got %s", tc.topic, tc.chanID, chanID))
+		})
+	}
+}
+
+func TestMatchTopic(t *testing.T) {
+	cases := []struct {
+		pattern string
+		topic   string
+		match   bool
+	}{
+		{pattern: "chan", topic: "chan", match: true},
+		{pattern: "chan", topic: "chan.temp", match: false},
+		{pattern: "chan.temp", topic: "chan.temp", match: true},
+		{pattern: "chan.temp", topic: "chan.hum", match: false},
+		{pattern: "chan.*", topic: "chan.temp", match: true},
+		{pattern: "chan.*", topic: "chan", match: false},
+		{pattern: "chan.*", topic: "chan.temp.room", match: false},
+		{pattern: "chan.>", topic: "chan.temp.room", match: true},
+		{pattern: "chan.>", topic: "chan", match: false},
+		{pattern: "chan.*.room", topic: "chan.temp.room", match: true},
+		{pattern: "chan.*.room", topic: "chan.temp.hall", match: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s_%s", tc.pattern, tc.topic), func(t *testing.T) {
+			assert.Equal(t, tc.match, notifiers.MatchTopic(tc.pattern, tc.topic))
+		})
+	}
+}
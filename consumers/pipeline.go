@@ -0,0 +1,514 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-kit/kit/metrics"
+	kitprometheus "github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// DeadLetterProtocol is the protocol set on messages published to the
+// dead-letter topic. Pipelines skip messages carrying this protocol so
+// that dead letters are never consumed again by the consumer that
+// rejected them.
+const DeadLetterProtocol = "dead-letter"
+
+const (
+	defBatchSize        = 100
+	defBatchTimeout     = time.Second
+	defBufferSize       = 1000
+	defMaxRetries       = 5
+	defRetryInterval    = 100 * time.Millisecond
+	defMaxRetryInterval = 10 * time.Second
+	defConsumeTimeout   = 5 * time.Second
+	defAckWait          = 30 * time.Second
+)
+
+var (
+	// ErrDeadLetter indicates failure to publish a message to the dead-letter topic.
+	ErrDeadLetter = errors.New("failed to publish message to dead-letter topic")
+
+	errPipelineStopped     = errors.New("consumer pipeline is stopped")
+	errUnsupportedConsumer = errors.New("unsupported consumer type")
+	errMissingDeadLetter   = errors.New("consumer pipeline requires a dead-letter topic and publisher")
+	errConsumeTimeout      = errors.New("consumer did not report the consume result in time")
+	errInvalidAckWait      = errors.New("consumer pipeline ack wait must be longer than the consume timeout")
+	errAckWait             = errors.New("message is not consumed within the ack wait")
+	errNotConsumed         = errors.New("message is neither consumed nor dead-lettered")
+)
+
+// PipelineConfig contains the consumer pipeline configuration. It is read
+// from the [pipeline] section of the consumer configuration file.
+type PipelineConfig struct {
+	Enabled          bool          `toml:"enabled"`
+	BatchSize        int           `toml:"batch_size"`
+	BatchTimeout     time.Duration `toml:"batch_timeout"`
+	BufferSize       int           `toml:"buffer_size"`
+	MaxRetries       uint64        `toml:"max_retries"`
+	RetryInterval    time.Duration `toml:"retry_interval"`
+	MaxRetryInterval time.Duration `toml:"max_retry_interval"`
+	ConsumeTimeout   time.Duration `toml:"consume_timeout"`
+	AckWait          time.Duration `toml:"ack_wait"`
+	DeadLetterTopic  string        `toml:"dead_letter_topic"`
+}
+
+// Option configures the consumer runtime started by Start.
+type Option func(*options)
+
+type options struct {
+	deadLetter messaging.Publisher
+}
+
+// WithDeadLetterPublisher sets the publisher used to publish messages
+// which could not be consumed to the configured dead-letter topic.
+func WithDeadLetterPublisher(pub messaging.Publisher) Option {
+	return func(o *options) {
+		o.deadLetter = pub
+	}
+}
+
+type consumeFunc func(ctx context.Context, messages interface{}) error
+
+// delivery is a received message along with the callback which
+// acknowledges it to the broker.
+type delivery struct {
+	msg      *messaging.Message
+	ack      func(error)
+	received time.Time
+}
+
+var _ messaging.AckHandler = (*pipeline)(nil)
+
+// pipeline buffers messages received from the broker, groups them in
+// batches by size and time window and passes them to the wrapped consumer.
+// Failed batches are retried with exponential backoff and messages which
+// still can not be consumed are published to the dead-letter topic.
+// Messages are acknowledged to the broker only once they are consumed or
+// dead-lettered, so the buffered ones are redelivered after a restart.
+// Messages which are not consumed within the broker ack wait are released
+// for redelivery instead of being dead-lettered.
+type pipeline struct {
+	id          string
+	cfg         PipelineConfig
+	transformer transformers.Transformer
+	consume     consumeFunc
+	deadLetter  messaging.Publisher
+	metrics     *pipelineMetrics
+	logger      *slog.Logger
+	msgs        chan delivery
+	done        chan struct{}
+}
+
+func newPipeline(id string, cfg PipelineConfig, t transformers.Transformer, consumer interface{}, deadLetter messaging.Publisher, logger *slog.Logger) (*pipeline, error) {
+	if deadLetter == nil || cfg.DeadLetterTopic == "" {
+		return nil, errMissingDeadLetter
+	}
+	cfg = withDefaults(cfg)
+	if cfg.ConsumeTimeout >= cfg.AckWait {
+		return nil, errInvalidAckWait
+	}
+	consume, err := consumeFn(consumer, cfg.ConsumeTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pipeline{
+		id:          id,
+		cfg:         cfg,
+		transformer: t,
+		consume:     consume,
+		deadLetter:  deadLetter,
+		metrics:     newPipelineMetrics(),
+		logger:      logger,
+		msgs:        make(chan delivery, cfg.BufferSize),
+		done:        make(chan struct{}),
+	}, nil
+}
+
+func consumeFn(consumer interface{}, timeout time.Duration) (consumeFunc, error) {
+	switch c := consumer.(type) {
+	case BlockingConsumer:
+		return c.ConsumeBlocking, nil
+	case AsyncConsumer:
+		// Async consumers report exactly one error, nil on success, for
+		// each ConsumeAsync call. The timeout only guards against a stuck
+		// consumer, so the call fails if the result is not reported in
+		// time. Results reported late are discarded before the next call.
+		return func(ctx context.Context, messages interface{}) error {
+			drainErrors(c.Errors())
+			c.ConsumeAsync(ctx, messages)
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			select {
+			case err := <-c.Errors():
+				return err
+			case <-timer.C:
+				return errConsumeTimeout
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}, nil
+	default:
+		return nil, errUnsupportedConsumer
+	}
+}
+
+func drainErrors(errs <-chan error) {
+	for {
+		select {
+		case <-errs:
+		default:
+			return
+		}
+	}
+}
+
+func withDefaults(cfg PipelineConfig) PipelineConfig {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defBatchSize
+	}
+	if cfg.BatchTimeout <= 0 {
+		cfg.BatchTimeout = defBatchTimeout
+	}
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defBufferSize
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defMaxRetries
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = defRetryInterval
+	}
+	if cfg.MaxRetryInterval <= 0 {
+		cfg.MaxRetryInterval = defMaxRetryInterval
+	}
+	if cfg.ConsumeTimeout <= 0 {
+		cfg.ConsumeTimeout = defConsumeTimeout
+	}
+	if cfg.AckWait <= 0 {
+		cfg.AckWait = defAckWait
+	}
+
+	return cfg
+}
+
+// HandleAck enqueues the message for consumption. It blocks while the
+// buffer is full, which propagates backpressure to the message broker.
+// The message is acknowledged once it's consumed or dead-lettered.
+func (p *pipeline) HandleAck(msg *messaging.Message, ack func(error)) error {
+	if msg.GetProtocol() == DeadLetterProtocol {
+		ack(nil)
+		return nil
+	}
+
+	select {
+	case p.msgs <- delivery{msg: msg, ack: ack, received: time.Now()}:
+		p.metrics.messages.With("consumer", p.id, "status", "received").Add(1)
+		p.metrics.buffered.With("consumer", p.id).Set(float64(len(p.msgs)))
+		return nil
+	case <-p.done:
+		return errPipelineStopped
+	}
+}
+
+// Handle is used by the subscribers which don't acknowledge messages
+// asynchronously. It returns once the message is buffered, so the message is
+// acknowledged to the broker before it's consumed and batches can fill up.
+func (p *pipeline) Handle(msg *messaging.Message) error {
+	return p.HandleAck(msg, func(err error) {
+		if err != nil {
+			p.logger.Warn(fmt.Sprintf("Failed to consume message: %s", err))
+		}
+	})
+}
+
+func (p *pipeline) Cancel() error {
+	return nil
+}
+
+// run collects buffered messages into batches until the context is
+// canceled. Messages left in the buffer are flushed before returning.
+func (p *pipeline) run(ctx context.Context) {
+	defer close(p.done)
+
+	batch := make([]delivery, 0, p.cfg.BatchSize)
+	timer := time.NewTimer(p.cfg.BatchTimeout)
+	defer timer.Stop()
+
+	flushBatch := func(ctx context.Context) {
+		if len(batch) > 0 {
+			p.flush(ctx, batch)
+			batch = make([]delivery, 0, p.cfg.BatchSize)
+		}
+		p.metrics.buffered.With("consumer", p.id).Set(float64(len(p.msgs)))
+	}
+
+	for {
+		select {
+		case d := <-p.msgs:
+			batch = append(batch, d)
+			if len(batch) >= p.cfg.BatchSize {
+				flushBatch(ctx)
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(p.cfg.BatchTimeout)
+			}
+		case <-timer.C:
+			flushBatch(ctx)
+			timer.Reset(p.cfg.BatchTimeout)
+		case <-ctx.Done():
+			// Flush what is already buffered using a context which is not
+			// canceled, so the last batch is not dropped on shutdown.
+			for len(p.msgs) > 0 {
+				batch = append(batch, <-p.msgs)
+			}
+			flushBatch(context.WithoutCancel(ctx))
+			return
+		}
+	}
+}
+
+// entry pairs a received message with its transformed representation.
+type entry struct {
+	raw         delivery
+	transformed interface{}
+}
+
+// group is a set of entries which are passed to the consumer in a single call.
+type group struct {
+	entries  []entry
+	messages interface{}
+}
+
+func (p *pipeline) flush(ctx context.Context, batch []delivery) {
+	p.metrics.batchSize.With("consumer", p.id).Observe(float64(len(batch)))
+
+	entries := make([]entry, 0, len(batch))
+	for _, d := range batch {
+		if p.transformer == nil {
+			entries = append(entries, entry{raw: d, transformed: d.msg})
+			continue
+		}
+		m, err := p.transformer.Transform(d.msg)
+		if err != nil {
+			// Transformation is deterministic, so there is no point in retrying.
+			p.logger.Warn(fmt.Sprintf("Failed to transform message: %s", err))
+			d.ack(p.publishDeadLetter(ctx, d.msg))
+			continue
+		}
+		entries = append(entries, entry{raw: d, transformed: m})
+	}
+
+	for _, g := range groupEntries(entries) {
+		p.consumeGroup(ctx, g)
+	}
+}
+
+// consumeGroup consumes the group and acknowledges its messages. The group is
+// retried only until the ack deadline of its oldest message, since the broker
+// redelivers the messages which are not acknowledged within the ack wait.
+func (p *pipeline) consumeGroup(ctx context.Context, g group) {
+	cctx, cancel := context.WithDeadline(ctx, p.ackDeadline(g.entries))
+	defer cancel()
+
+	if err := p.consumeWithRetry(cctx, g.messages); err == nil {
+		p.metrics.messages.With("consumer", p.id, "status", "consumed").Add(float64(len(g.entries)))
+		for _, e := range g.entries {
+			e.raw.ack(nil)
+		}
+		return
+	}
+	// Consume messages of the failed group one by one to isolate
+	// the ones that can not be consumed at all.
+	for _, e := range g.entries {
+		if len(g.entries) > 1 && cctx.Err() == nil {
+			if err := p.consumeOnce(cctx, e.transformed); err == nil {
+				p.metrics.messages.With("consumer", p.id, "status", "consumed").Add(1)
+				e.raw.ack(nil)
+				continue
+			}
+		}
+		if cctx.Err() != nil {
+			p.metrics.messages.With("consumer", p.id, "status", "released").Add(1)
+			e.raw.ack(errAckWait)
+			continue
+		}
+		e.raw.ack(p.publishDeadLetter(ctx, e.raw.msg))
+	}
+}
+
+// ackDeadline returns the time until which the entries may be consumed. A
+// tenth of the ack wait is left to dead-letter and acknowledge them.
+func (p *pipeline) ackDeadline(entries []entry) time.Time {
+	received := entries[0].raw.received
+	for _, e := range entries[1:] {
+		if e.raw.received.Before(received) {
+			received = e.raw.received
+		}
+	}
+
+	return received.Add(p.cfg.AckWait - p.cfg.AckWait/10)
+}
+
+// groupEntries merges transformed messages of the same kind so that they
+// can be consumed at once. Messages of unknown types are consumed one by one.
+func groupEntries(entries []entry) []group {
+	var groups []group
+	var senmlGroup *group
+	jsonGroups := map[string]*group{}
+	var jsonFormats []string
+
+	for _, e := range entries {
+		switch m := e.transformed.(type) {
+		case []senml.Message:
+			if senmlGroup == nil {
+				senmlGroup = &group{messages: []senml.Message{}}
+			}
+			senmlGroup.entries = append(senmlGroup.entries, e)
+			senmlGroup.messages = append(senmlGroup.messages.([]senml.Message), m...)
+		case smqjson.Messages:
+			g, ok := jsonGroups[m.Format]
+			if !ok {
+				g = &group{messages: smqjson.Messages{Format: m.Format}}
+				jsonGroups[m.Format] = g
+				jsonFormats = append(jsonFormats, m.Format)
+			}
+			msgs := g.messages.(smqjson.Messages)
+			msgs.Data = append(msgs.Data, m.Data...)
+			g.messages = msgs
+			g.entries = append(g.entries, e)
+		default:
+			groups = append(groups, group{entries: []entry{e}, messages: e.transformed})
+		}
+	}
+
+	if senmlGroup != nil {
+		groups = append(groups, *senmlGroup)
+	}
+	for _, f := range jsonFormats {
+		groups = append(groups, *jsonGroups[f])
+	}
+
+	return groups
+}
+
+func (p *pipeline) consumeWithRetry(ctx context.Context, messages interface{}) error {
+	b := backoff.NewExponentialBackOff(
+		backoff.WithInitialInterval(p.cfg.RetryInterval),
+		backoff.WithMaxInterval(p.cfg.MaxRetryInterval),
+		backoff.WithMaxElapsedTime(0),
+	)
+	op := func() error {
+		return p.consumeOnce(ctx, messages)
+	}
+	notify := func(err error, next time.Duration) {
+		p.metrics.retries.With("consumer", p.id).Add(1)
+		p.logger.Warn(fmt.Sprintf("Failed to consume messages, retrying in %s: %s", next, err))
+	}
+
+	return backoff.RetryNotify(op, backoff.WithContext(backoff.WithMaxRetries(b, p.cfg.MaxRetries), ctx), notify)
+}
+
+func (p *pipeline) consumeOnce(ctx context.Context, messages interface{}) error {
+	defer func(begin time.Time) {
+		p.metrics.latency.With("consumer", p.id).Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return p.consume(ctx, messages)
+}
+
+// publishDeadLetter publishes the message to the dead-letter topic. If that
+// fails, the returned error leaves the message unacknowledged, so the
+// broker redelivers it instead of it being dropped.
+func (p *pipeline) publishDeadLetter(ctx context.Context, msg *messaging.Message) error {
+	p.metrics.messages.With("consumer", p.id, "status", "failed").Add(1)
+
+	dl := &messaging.Message{
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  DeadLetterProtocol,
+		Payload:   msg.GetPayload(),
+		Created:   msg.GetCreated(),
+		Headers:   msg.GetHeaders(),
+	}
+	if err := p.deadLetter.Publish(ctx, p.cfg.DeadLetterTopic, dl); err != nil {
+		err = errors.Wrap(ErrDeadLetter, err)
+		p.logger.Error(err.Error())
+		return errors.Wrap(errNotConsumed, err)
+	}
+	p.metrics.messages.With("consumer", p.id, "status", "dead_lettered").Add(1)
+
+	return nil
+}
+
+type pipelineMetrics struct {
+	messages  metrics.Counter
+	retries   metrics.Counter
+	buffered  metrics.Gauge
+	batchSize metrics.Histogram
+	latency   metrics.Histogram
+}
+
+var (
+	metricsOnce   sync.Once
+	sharedMetrics *pipelineMetrics
+)
+
+// newPipelineMetrics returns metrics labeled by consumer. Collectors are
+// registered only once since a single process may run several pipelines.
+func newPipelineMetrics() *pipelineMetrics {
+	metricsOnce.Do(func() {
+		sharedMetrics = &pipelineMetrics{
+			messages: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "consumer",
+				Subsystem: "pipeline",
+				Name:      "messages_total",
+				Help:      "Number of messages handled by the consumer pipeline by status.",
+			}, []string{"consumer", "status"}),
+			retries: kitprometheus.NewCounterFrom(stdprometheus.CounterOpts{
+				Namespace: "consumer",
+				Subsystem: "pipeline",
+				Name:      "retries_total",
+				Help:      "Number of retried consume attempts.",
+			}, []string{"consumer"}),
+			buffered: kitprometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+				Namespace: "consumer",
+				Subsystem: "pipeline",
+				Name:      "buffered_messages",
+				Help:      "Number of messages waiting in the pipeline buffer.",
+			}, []string{"consumer"}),
+			batchSize: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+				Namespace: "consumer",
+				Subsystem: "pipeline",
+				Name:      "batch_size",
+				Help:      "Number of messages in flushed batches.",
+				Buckets:   stdprometheus.ExponentialBuckets(1, 2, 12),
+			}, []string{"consumer"}),
+			latency: kitprometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+				Namespace: "consumer",
+				Subsystem: "pipeline",
+				Name:      "consume_latency_seconds",
+				Help:      "Duration of consume calls in seconds.",
+				Buckets:   stdprometheus.DefBuckets,
+			}, []string{"consumer"}),
+		}
+	})
+
+	return sharedMetrics
+}
@@ -0,0 +1,465 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package consumers_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/consumers"
+	smqlog "github.com/absmach/supermq/logger"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	pubsubmocks "github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	deadLetterTopic = "dead-letter.test"
+	validPayload    = `[{"bn":"dev:","n":"temp","v":21.5}]`
+	invalidPayload  = `invalid`
+)
+
+var (
+	errConsume    = errors.New("failed to consume")
+	errDeadLetter = errors.New("failed to publish")
+)
+
+// blockingConsumer records consumed messages and fails the first
+// failures calls or every call if failures is negative. If blocked,
+// every call blocks until the context is done.
+type blockingConsumer struct {
+	mu       sync.Mutex
+	failures int
+	blocked  bool
+	calls    int
+	consumed [][]senml.Message
+}
+
+func (bc *blockingConsumer) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	bc.calls++
+	if bc.blocked {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	if bc.failures < 0 || bc.calls <= bc.failures {
+		return errConsume
+	}
+	bc.consumed = append(bc.consumed, messages.([]senml.Message))
+
+	return nil
+}
+
+func (bc *blockingConsumer) state() (int, [][]senml.Message) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	return bc.calls, bc.consumed
+}
+
+// asyncConsumer reports the result of every consume call except the first
+// skipped ones.
+type asyncConsumer struct {
+	mu      sync.Mutex
+	skipped int
+	calls   int
+	errs    chan error
+}
+
+func (ac *asyncConsumer) ConsumeAsync(_ context.Context, _ interface{}) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+
+	ac.calls++
+	if ac.calls > ac.skipped {
+		ac.errs <- nil
+	}
+}
+
+func (ac *asyncConsumer) Errors() <-chan error {
+	return ac.errs
+}
+
+// acks records the results passed to the acknowledgement callbacks.
+type acks struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (a *acks) ack(err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.errs = append(a.errs, err)
+}
+
+func (a *acks) results() (acked, nacked int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, err := range a.errs {
+		if err != nil {
+			nacked++
+			continue
+		}
+		acked++
+	}
+
+	return acked, nacked
+}
+
+func writeConfig(t *testing.T, batchSize int, batchTimeout, ackWait, dlTopic string) string {
+	cfg := fmt.Sprintf(`
+[subscriber]
+subjects = ["channels.>"]
+
+[transformer]
+format = "senml"
+content_type = "application/senml+json"
+
+[pipeline]
+enabled = true
+batch_size = %d
+batch_timeout = "%s"
+max_retries = 2
+retry_interval = "1ms"
+max_retry_interval = "2ms"
+consume_timeout = "20ms"
+ack_wait = "%s"
+dead_letter_topic = "%s"
+`, batchSize, batchTimeout, ackWait, dlTopic)
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	err := os.WriteFile(path, []byte(cfg), 0o644)
+	require.Nil(t, err, fmt.Sprintf("unexpected error writing config: %s", err))
+
+	return path
+}
+
+// deadLetterRecorder records messages published to the dead-letter topic.
+type deadLetterRecorder struct {
+	mu   sync.Mutex
+	msgs []*messaging.Message
+}
+
+func (dr *deadLetterRecorder) record(args mock.Arguments) {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	dr.msgs = append(dr.msgs, args.Get(2).(*messaging.Message))
+}
+
+func (dr *deadLetterRecorder) messages() []*messaging.Message {
+	dr.mu.Lock()
+	defer dr.mu.Unlock()
+
+	return dr.msgs
+}
+
+func startPipeline(t *testing.T, ctx context.Context, consumer interface{}, batchSize int, batchTimeout, ackWait string, dlErr error) (messaging.AckHandler, *deadLetterRecorder) {
+	pubsub := new(pubsubmocks.PubSub)
+	dlr := &deadLetterRecorder{}
+	var handler messaging.MessageHandler
+	pubsub.On("Subscribe", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		handler = args.Get(1).(messaging.SubscriberConfig).Handler
+	}).Return(nil)
+	pubsub.On("Publish", mock.Anything, deadLetterTopic, mock.Anything).Run(dlr.record).Return(dlErr)
+
+	err := consumers.Start(ctx, "test", pubsub, consumer, writeConfig(t, batchSize, batchTimeout, ackWait, deadLetterTopic), smqlog.NewMock(), consumers.WithDeadLetterPublisher(pubsub))
+	require.Nil(t, err, fmt.Sprintf("unexpected error starting consumer: %s", err))
+	require.NotNil(t, handler)
+	ah, ok := handler.(messaging.AckHandler)
+	require.True(t, ok, "expected pipeline to acknowledge messages asynchronously")
+
+	return ah, dlr
+}
+
+func newMessage(payload string) *messaging.Message {
+	return &messaging.Message{
+		Channel:   "channel",
+		Publisher: "publisher",
+		Protocol:  "http",
+		Payload:   []byte(payload),
+		Created:   time.Now().UnixNano(),
+	}
+}
+
+func TestPipelineBatching(t *testing.T) {
+	cases := []struct {
+		desc         string
+		batchSize    int
+		batchTimeout string
+		messages     int
+		batches      int
+	}{
+		{
+			desc:         "flush batch when batch size is reached",
+			batchSize:    3,
+			batchTimeout: "1h",
+			messages:     6,
+			batches:      2,
+		},
+		{
+			desc:         "flush batch when batch timeout expires",
+			batchSize:    100,
+			batchTimeout: "20ms",
+			messages:     5,
+			batches:      1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			consumer := &blockingConsumer{}
+			handler, _ := startPipeline(t, ctx, consumer, tc.batchSize, tc.batchTimeout, "1s", nil)
+			a := &acks{}
+			for i := 0; i < tc.messages; i++ {
+				err := handler.HandleAck(newMessage(validPayload), a.ack)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			}
+
+			assert.Eventually(t, func() bool {
+				_, consumed := consumer.state()
+				return len(consumed) == tc.batches
+			}, time.Second, 5*time.Millisecond, fmt.Sprintf("%s: expected %d batches", tc.desc, tc.batches))
+
+			_, consumed := consumer.state()
+			total := 0
+			for _, batch := range consumed {
+				total += len(batch)
+			}
+			assert.Equal(t, tc.messages, total, fmt.Sprintf("%s: expected %d consumed messages got %d", tc.desc, tc.messages, total))
+			assert.Eventually(t, func() bool {
+				acked, _ := a.results()
+				return acked == tc.messages
+			}, time.Second, 5*time.Millisecond, fmt.Sprintf("%s: expected %d acknowledged messages", tc.desc, tc.messages))
+		})
+	}
+}
+
+func TestPipelineRetryAndDeadLetter(t *testing.T) {
+	cases := []struct {
+		desc        string
+		payloads    []string
+		failures    int
+		dlErr       error
+		calls       int
+		consumed    int
+		deadLetters int
+		nacked      int
+	}{
+		{
+			desc:     "consume batch after retries",
+			payloads: []string{validPayload, validPayload},
+			failures: 2,
+			calls:    3,
+			consumed: 2,
+		},
+		{
+			desc:        "dead-letter messages when retries are exhausted",
+			payloads:    []string{validPayload, validPayload},
+			failures:    -1,
+			calls:       5,
+			deadLetters: 2,
+		},
+		{
+			desc:        "leave messages unacknowledged when dead-lettering fails",
+			payloads:    []string{validPayload, validPayload},
+			failures:    -1,
+			dlErr:       errDeadLetter,
+			calls:       5,
+			deadLetters: 2,
+			nacked:      2,
+		},
+		{
+			desc:        "dead-letter message which can not be transformed",
+			payloads:    []string{invalidPayload, validPayload},
+			calls:       1,
+			consumed:    1,
+			deadLetters: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			consumer := &blockingConsumer{failures: tc.failures}
+			handler, dlr := startPipeline(t, ctx, consumer, len(tc.payloads), "1h", "1s", tc.dlErr)
+			a := &acks{}
+			for _, payload := range tc.payloads {
+				err := handler.HandleAck(newMessage(payload), a.ack)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			}
+
+			assert.Eventually(t, func() bool {
+				calls, _ := consumer.state()
+				return calls == tc.calls && len(dlr.messages()) == tc.deadLetters
+			}, time.Second, 5*time.Millisecond, fmt.Sprintf("%s: expected %d consume calls and %d dead letters", tc.desc, tc.calls, tc.deadLetters))
+
+			_, consumed := consumer.state()
+			total := 0
+			for _, batch := range consumed {
+				total += len(batch)
+			}
+			assert.Equal(t, tc.consumed, total, fmt.Sprintf("%s: expected %d consumed messages got %d", tc.desc, tc.consumed, total))
+			for _, msg := range dlr.messages() {
+				assert.Equal(t, consumers.DeadLetterProtocol, msg.GetProtocol(), fmt.Sprintf("%s: expected dead-letter protocol got %s", tc.desc, msg.GetProtocol()))
+			}
+			assert.Eventually(t, func() bool {
+				acked, nacked := a.results()
+				return acked == len(tc.payloads)-tc.nacked && nacked == tc.nacked
+			}, time.Second, 5*time.Millisecond, fmt.Sprintf("%s: expected %d not acknowledged messages", tc.desc, tc.nacked))
+		})
+	}
+}
+
+func TestPipelineSkipsDeadLetters(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	consumer := &blockingConsumer{}
+	handler, _ := startPipeline(t, ctx, consumer, 1, "1h", "1s", nil)
+
+	msg := newMessage(validPayload)
+	msg.Protocol = consumers.DeadLetterProtocol
+	err := handler.HandleAck(msg, func(error) {})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	err = handler.HandleAck(newMessage(validPayload), func(error) {})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	assert.Eventually(t, func() bool {
+		_, consumed := consumer.state()
+		return len(consumed) == 1
+	}, time.Second, 5*time.Millisecond, "expected a single consumed batch")
+	time.Sleep(20 * time.Millisecond)
+	calls, _ := consumer.state()
+	assert.Equal(t, 1, calls, fmt.Sprintf("expected 1 consume call got %d", calls))
+}
+
+func TestPipelineFlushOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	consumer := &blockingConsumer{}
+	handler, _ := startPipeline(t, ctx, consumer, 100, "1h", "1s", nil)
+	a := &acks{}
+	for i := 0; i < 3; i++ {
+		err := handler.HandleAck(newMessage(validPayload), a.ack)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	}
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		_, consumed := consumer.state()
+		return len(consumed) == 1 && len(consumed[0]) == 3
+	}, time.Second, 5*time.Millisecond, "expected buffered messages to be flushed on shutdown")
+	assert.Eventually(t, func() bool {
+		acked, _ := a.results()
+		return acked == 3
+	}, time.Second, 5*time.Millisecond, "expected flushed messages to be acknowledged")
+}
+
+func TestPipelineAcksAfterConsume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	consumer := &blockingConsumer{}
+	handler, _ := startPipeline(t, ctx, consumer, 100, "50ms", "1s", nil)
+	a := &acks{}
+	err := handler.HandleAck(newMessage(validPayload), a.ack)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	acked, _ := a.results()
+	assert.Equal(t, 0, acked, "expected buffered message not to be acknowledged")
+	assert.Eventually(t, func() bool {
+		acked, _ := a.results()
+		return acked == 1
+	}, time.Second, 5*time.Millisecond, "expected consumed message to be acknowledged")
+}
+
+func TestPipelineAsyncConsumerTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	consumer := &asyncConsumer{skipped: 1, errs: make(chan error, 10)}
+	handler, dlr := startPipeline(t, ctx, consumer, 1, "1h", "1s", nil)
+	a := &acks{}
+	err := handler.HandleAck(newMessage(validPayload), a.ack)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	assert.Eventually(t, func() bool {
+		acked, _ := a.results()
+		return acked == 1
+	}, time.Second, 5*time.Millisecond, "expected message to be consumed after the timed out call")
+	assert.Empty(t, dlr.messages(), "expected no dead letters")
+}
+
+func TestPipelineReleasesAfterAckWait(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	consumer := &blockingConsumer{blocked: true}
+	handler, dlr := startPipeline(t, ctx, consumer, 2, "1h", "100ms", nil)
+	a := &acks{}
+	for i := 0; i < 2; i++ {
+		err := handler.HandleAck(newMessage(validPayload), a.ack)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	}
+
+	assert.Eventually(t, func() bool {
+		_, nacked := a.results()
+		return nacked == 2
+	}, time.Second, 5*time.Millisecond, "expected messages to be released after the ack wait")
+	calls, _ := consumer.state()
+	assert.Equal(t, 1, calls, fmt.Sprintf("expected 1 consume call got %d", calls))
+	assert.Empty(t, dlr.messages(), "expected no dead letters")
+}
+
+func TestPipelineHandle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	consumer := &blockingConsumer{}
+	handler, _ := startPipeline(t, ctx, consumer, 3, "1h", "1s", nil)
+	for i := 0; i < 3; i++ {
+		err := handler.Handle(newMessage(validPayload))
+		assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+	}
+
+	assert.Eventually(t, func() bool {
+		_, consumed := consumer.state()
+		return len(consumed) == 1 && len(consumed[0]) == 3
+	}, time.Second, 5*time.Millisecond, "expected handled messages to be consumed in a single batch")
+}
+
+func TestPipelineRequiresDeadLetter(t *testing.T) {
+	pubsub := new(pubsubmocks.PubSub)
+	cfg := writeConfig(t, 1, "1h", "1s", "")
+
+	err := consumers.Start(context.Background(), "test", pubsub, &blockingConsumer{}, cfg, smqlog.NewMock(), consumers.WithDeadLetterPublisher(pubsub))
+	assert.NotNil(t, err, "expected error starting pipeline without dead-letter topic")
+}
+
+func TestPipelineRequiresAckWait(t *testing.T) {
+	pubsub := new(pubsubmocks.PubSub)
+	cfg := writeConfig(t, 1, "1h", "10ms", deadLetterTopic)
+
+	err := consumers.Start(context.Background(), "test", pubsub, &blockingConsumer{}, cfg, smqlog.NewMock(), consumers.WithDeadLetterPublisher(pubsub))
+	assert.NotNil(t, err, "expected error starting pipeline with ack wait shorter than consume timeout")
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/pkg/messaging/tracing"
+	"github.com/absmach/supermq/pkg/server"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	consumeBlockingOP = "retrieve_blocking"
+	consumeAsyncOP    = "retrieve_async"
+)
+
+var (
+	_ consumers.AsyncConsumer    = (*tracingMiddlewareAsync)(nil)
+	_ consumers.BlockingConsumer = (*tracingMiddlewareBlock)(nil)
+)
+
+type tracingMiddlewareAsync struct {
+	consumer consumers.AsyncConsumer
+	tracer   trace.Tracer
+	host     server.Config
+}
+
+type tracingMiddlewareBlock struct {
+	consumer consumers.BlockingConsumer
+	tracer   trace.Tracer
+	host     server.Config
+}
+
+// NewAsync creates a new traced consumers.AsyncConsumer service.
+func NewAsync(tracer trace.Tracer, consumerAsync consumers.AsyncConsumer, host server.Config) consumers.AsyncConsumer {
+	return &tracingMiddlewareAsync{
+		consumer: consumerAsync,
+		tracer:   tracer,
+		host:     host,
+	}
+}
+
+// NewBlocking creates a new traced consumers.BlockingConsumer service.
+func NewBlocking(tracer trace.Tracer, consumerBlock consumers.BlockingConsumer, host server.Config) consumers.BlockingConsumer {
+	return &tracingMiddlewareBlock{
+		consumer: consumerBlock,
+		tracer:   tracer,
+		host:     host,
+	}
+}
+
+// ConsumeBlocking traces consume operations for message/s consumed.
+func (tm *tracingMiddlewareBlock) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	ctx, span := createMessageSpan(ctx, consumeBlockingOP, messages, tm.host, tm.tracer)
+	if span != nil {
+		defer span.End()
+	}
+
+	return tm.consumer.ConsumeBlocking(ctx, messages)
+}
+
+// ConsumeAsync traces consume operations for message/s consumed.
+func (tm *tracingMiddlewareAsync) ConsumeAsync(ctx context.Context, messages interface{}) {
+	ctx, span := createMessageSpan(ctx, consumeAsyncOP, messages, tm.host, tm.tracer)
+	if span != nil {
+		defer span.End()
+	}
+
+	tm.consumer.ConsumeAsync(ctx, messages)
+}
+
+// Errors traces async consume errors.
+func (tm *tracingMiddlewareAsync) Errors() <-chan error {
+	return tm.consumer.Errors()
+}
+
+func createMessageSpan(ctx context.Context, operation string, messages interface{}, host server.Config, tracer trace.Tracer) (context.Context, trace.Span) {
+	switch m := messages.(type) {
+	case smqjson.Messages:
+		if len(m.Data) > 0 {
+			first := m.Data[0]
+			return tracing.CreateSpan(ctx, operation, first.Publisher, first.Channel, first.Subtopic, len(m.Data), host, trace.SpanKindConsumer, tracer)
+		}
+	case []senml.Message:
+		if len(m) > 0 {
+			first := m[0]
+			return tracing.CreateSpan(ctx, operation, first.Publisher, first.Channel, first.Subtopic, len(m), host, trace.SpanKindConsumer, tracer)
+		}
+	}
+
+	return ctx, nil
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package writers contain the domain concept definitions needed to
+// support SuperMQ writer services functionality. Writers are
+// consumers which persist received messages to a database.
+package writers
@@ -0,0 +1,243 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/postgres"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+// Postgres error codes:
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	errInvalid   = "22P02" // invalid_text_representation
+	errUndefined = "42P01" // undefined_table
+)
+
+// batchSize limits the number of rows inserted by a single statement so that
+// the statement stays well below the PostgreSQL bind parameters limit.
+const batchSize = 1000
+
+var (
+	errInvalidMessage = errors.New("invalid message representation")
+	errSaveMessage    = errors.New("failed to save message to postgres database")
+	errTransRollback  = errors.New("failed to rollback transaction")
+	errNoTable        = errors.New("relation does not exist")
+	errInvalidFormat  = errors.New("invalid message format")
+
+	formatRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+)
+
+var _ consumers.BlockingConsumer = (*postgresRepo)(nil)
+
+type postgresRepo struct {
+	db         postgres.Database
+	idProvider supermq.IDProvider
+}
+
+// New returns new PostgreSQL writer.
+func New(db postgres.Database, idp supermq.IDProvider) consumers.BlockingConsumer {
+	return &postgresRepo{
+		db:         db,
+		idProvider: idp,
+	}
+}
+
+func (pr postgresRepo) ConsumeBlocking(ctx context.Context, message interface{}) (err error) {
+	switch m := message.(type) {
+	case smqjson.Messages:
+		return pr.saveJSON(ctx, m)
+	default:
+		return pr.saveSenml(ctx, m)
+	}
+}
+
+func (pr postgresRepo) saveSenml(ctx context.Context, messages interface{}) (err error) {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errSaveMessage
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	q := `INSERT INTO messages (id, channel, subtopic, publisher, protocol,
+		name, unit, value, string_value, bool_value, data_value, sum,
+		time, update_time)
+		VALUES (:id, :channel, :subtopic, :publisher, :protocol, :name, :unit,
+		:value, :string_value, :bool_value, :data_value, :sum,
+		:time, :update_time);`
+
+	dbMsgs := make([]senmlMessage, len(msgs))
+	for i, msg := range msgs {
+		id, err := pr.idProvider.ID()
+		if err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		dbMsgs[i] = senmlMessage{Message: msg, ID: id}
+	}
+
+	return pr.inTx(ctx, func(tx *sqlx.Tx) error {
+		for start := 0; start < len(dbMsgs); start += batchSize {
+			end := min(start+batchSize, len(dbMsgs))
+			if _, err := tx.NamedExecContext(ctx, q, dbMsgs[start:end]); err != nil {
+				return handleError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (pr postgresRepo) saveJSON(ctx context.Context, msgs smqjson.Messages) error {
+	if len(msgs.Data) == 0 {
+		return nil
+	}
+	if !formatRegexp.MatchString(msgs.Format) {
+		return errors.Wrap(errSaveMessage, errInvalidFormat)
+	}
+
+	err := pr.insertJSON(ctx, msgs)
+	if errors.Contains(err, errNoTable) {
+		if err := pr.createTable(ctx, msgs.Format); err != nil {
+			return err
+		}
+		return pr.insertJSON(ctx, msgs)
+	}
+
+	return err
+}
+
+func (pr postgresRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) error {
+	q := `INSERT INTO %s (id, channel, created, subtopic, publisher, protocol, payload)
+		VALUES (:id, :channel, :created, :subtopic, :publisher, :protocol, :payload);`
+	q = fmt.Sprintf(q, msgs.Format)
+
+	dbMsgs := make([]jsonMessage, len(msgs.Data))
+	for i, m := range msgs.Data {
+		id, err := pr.idProvider.ID()
+		if err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		dbMsg, err := toJSONMessage(id, m)
+		if err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		dbMsgs[i] = dbMsg
+	}
+
+	return pr.inTx(ctx, func(tx *sqlx.Tx) error {
+		for start := 0; start < len(dbMsgs); start += batchSize {
+			end := min(start+batchSize, len(dbMsgs))
+			if _, err := tx.NamedExecContext(ctx, q, dbMsgs[start:end]); err != nil {
+				return handleError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (pr postgresRepo) createTable(ctx context.Context, name string) error {
+	q := `CREATE TABLE IF NOT EXISTS %s (
+		id            VARCHAR(36),
+		created       BIGINT,
+		channel       VARCHAR(36),
+		subtopic      VARCHAR(254),
+		publisher     VARCHAR(36),
+		protocol      TEXT,
+		payload       JSONB,
+		PRIMARY KEY (id)
+	)`
+	q = fmt.Sprintf(q, name)
+
+	if _, err := pr.db.ExecContext(ctx, q); err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+// inTx runs the given function inside a transaction which is committed
+// on success and rolled back otherwise.
+func (pr postgresRepo) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) (err error) {
+	tx, err := pr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errSaveMessage, err)
+		}
+	}()
+
+	return fn(tx)
+}
+
+func handleError(err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		switch pgErr.Code {
+		case errInvalid:
+			return errors.Wrap(errSaveMessage, errInvalidMessage)
+		case errUndefined:
+			return errNoTable
+		}
+	}
+
+	return errors.Wrap(errSaveMessage, err)
+}
+
+type senmlMessage struct {
+	senml.Message
+	ID string `db:"id"`
+}
+
+type jsonMessage struct {
+	ID        string `db:"id"`
+	Channel   string `db:"channel"`
+	Created   int64  `db:"created"`
+	Subtopic  string `db:"subtopic"`
+	Publisher string `db:"publisher"`
+	Protocol  string `db:"protocol"`
+	Payload   []byte `db:"payload"`
+}
+
+func toJSONMessage(id string, msg smqjson.Message) (jsonMessage, error) {
+	data := []byte("{}")
+	if msg.Payload != nil {
+		b, err := json.Marshal(msg.Payload)
+		if err != nil {
+			return jsonMessage{}, errors.Wrap(errSaveMessage, err)
+		}
+		data = b
+	}
+
+	m := jsonMessage{
+		ID:        id,
+		Channel:   msg.Channel,
+		Created:   msg.Created,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Payload:   data,
+	}
+
+	return m, nil
+}
@@ -0,0 +1,141 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/consumers/writers/postgres"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	msgsNum     = 42
+	valueFields = 5
+	subtopic    = "topic"
+)
+
+var (
+	v       float64 = 5
+	stringV         = "value"
+	boolV           = true
+	dataV           = "base64"
+	sum     float64 = 42
+)
+
+func TestSaveSenml(t *testing.T) {
+	repo := postgres.New(database, uuid.New())
+
+	chid := testsutil.GenerateUUID(t)
+	pubid := testsutil.GenerateUUID(t)
+
+	msg := senml.Message{
+		Channel:   chid,
+		Publisher: pubid,
+		Subtopic:  subtopic,
+		Protocol:  "mqtt",
+		Name:      "temperature",
+		Unit:      "C",
+	}
+
+	now := time.Now().UnixNano()
+	var msgs []senml.Message
+
+	for i := 0; i < msgsNum; i++ {
+		// Mix possible values as well as value sum.
+		count := i % valueFields
+		switch count {
+		case 0:
+			msg.Value = &v
+		case 1:
+			msg.BoolValue = &boolV
+		case 2:
+			msg.StringValue = &stringV
+		case 3:
+			msg.DataValue = &dataV
+		case 4:
+			msg.Sum = &sum
+		}
+
+		msg.Time = float64(now + int64(i))
+		msgs = append(msgs, msg)
+	}
+
+	err := repo.ConsumeBlocking(context.TODO(), msgs)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+
+	var total int
+	err = db.Get(&total, "SELECT COUNT(*) FROM messages WHERE channel = $1", chid)
+	assert.Nil(t, err, fmt.Sprintf("expected no error got %s\n", err))
+	assert.Equal(t, msgsNum, total)
+}
+
+func TestSaveJSON(t *testing.T) {
+	repo := postgres.New(database, uuid.New())
+
+	chid := testsutil.GenerateUUID(t)
+	pubid := testsutil.GenerateUUID(t)
+
+	msg := json.Message{
+		Channel:   chid,
+		Publisher: pubid,
+		Created:   time.Now().UnixNano(),
+		Subtopic:  "subtopic/format/some_json",
+		Protocol:  "mqtt",
+		Payload: map[string]interface{}{
+			"field_1": 123,
+			"field_2": "value",
+			"field_3": false,
+			"field_4": 12.344,
+			"field_5": map[string]interface{}{
+				"field_1": "value",
+				"field_2": 42,
+			},
+		},
+	}
+
+	now := time.Now().Unix()
+	msgs := json.Messages{
+		Format: "some_json",
+	}
+
+	for i := 0; i < msgsNum; i++ {
+		msg.Created = now + int64(i)
+		msgs.Data = append(msgs.Data, msg)
+	}
+
+	cases := []struct {
+		desc string
+		msgs json.Messages
+		err  bool
+	}{
+		{
+			desc: "save json messages to a new table",
+			msgs: msgs,
+		},
+		{
+			desc: "save json messages to an existing table",
+			msgs: msgs,
+		},
+		{
+			desc: "save json messages with invalid format",
+			msgs: json.Messages{Format: "invalid; DROP TABLE messages", Data: msgs.Data},
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.ConsumeBlocking(context.TODO(), tc.msgs)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of postgres-writer.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "messages_1",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS messages (
+						id            VARCHAR(36),
+						channel       VARCHAR(36),
+						subtopic      VARCHAR(254),
+						publisher     VARCHAR(36),
+						protocol      TEXT,
+						name          TEXT,
+						unit          TEXT,
+						value         FLOAT,
+						string_value  TEXT,
+						bool_value    BOOL,
+						data_value    TEXT,
+						sum           FLOAT,
+						time          FLOAT,
+						update_time   FLOAT,
+						PRIMARY KEY (id)
+					)`,
+					`CREATE INDEX IF NOT EXISTS idx_messages_channel_time ON messages (channel, time DESC)`,
+				},
+				Down: []string{
+					"DROP TABLE messages",
+				},
+			},
+		},
+	}
+}
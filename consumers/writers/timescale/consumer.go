@@ -0,0 +1,264 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/postgres"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+// Postgres error codes:
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	errInvalid   = "22P02" // invalid_text_representation
+	errUndefined = "42P01" // undefined_table
+)
+
+// batchSize limits the number of rows inserted by a single statement so that
+// the statement stays well below the PostgreSQL bind parameters limit.
+const batchSize = 1000
+
+var (
+	errInvalidMessage = errors.New("invalid message representation")
+	errSaveMessage    = errors.New("failed to save message to timescale database")
+	errTransRollback  = errors.New("failed to rollback transaction")
+	errNoTable        = errors.New("relation does not exist")
+	errInvalidFormat  = errors.New("invalid message format")
+
+	formatRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+)
+
+var _ consumers.BlockingConsumer = (*timescaleRepo)(nil)
+
+type timescaleRepo struct {
+	db postgres.Database
+}
+
+// New returns new TimescaleSQL writer.
+func New(db postgres.Database) consumers.BlockingConsumer {
+	return &timescaleRepo{db: db}
+}
+
+func (tr timescaleRepo) ConsumeBlocking(ctx context.Context, message interface{}) (err error) {
+	switch m := message.(type) {
+	case smqjson.Messages:
+		return tr.saveJSON(ctx, m)
+	default:
+		return tr.saveSenml(ctx, m)
+	}
+}
+
+func (tr timescaleRepo) saveSenml(ctx context.Context, messages interface{}) (err error) {
+	msgs, ok := messages.([]senml.Message)
+	if !ok {
+		return errSaveMessage
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	q := `INSERT INTO messages (channel, subtopic, publisher, protocol,
+		name, unit, value, string_value, bool_value, data_value, sum,
+		time, update_time)
+		VALUES (:channel, :subtopic, :publisher, :protocol, :name, :unit,
+		:value, :string_value, :bool_value, :data_value, :sum,
+		:time, :update_time)
+		ON CONFLICT DO NOTHING;`
+
+	dbMsgs := make([]senmlMessage, len(msgs))
+	for i, msg := range msgs {
+		dbMsgs[i] = toSenmlMessage(msg)
+	}
+
+	return tr.inTx(ctx, func(tx *sqlx.Tx) error {
+		for start := 0; start < len(dbMsgs); start += batchSize {
+			end := min(start+batchSize, len(dbMsgs))
+			if _, err := tx.NamedExecContext(ctx, q, dbMsgs[start:end]); err != nil {
+				return handleError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (tr timescaleRepo) saveJSON(ctx context.Context, msgs smqjson.Messages) error {
+	if len(msgs.Data) == 0 {
+		return nil
+	}
+	if !formatRegexp.MatchString(msgs.Format) {
+		return errors.Wrap(errSaveMessage, errInvalidFormat)
+	}
+
+	err := tr.insertJSON(ctx, msgs)
+	if errors.Contains(err, errNoTable) {
+		if err := tr.createTable(ctx, msgs.Format); err != nil {
+			return err
+		}
+		return tr.insertJSON(ctx, msgs)
+	}
+
+	return err
+}
+
+func (tr timescaleRepo) insertJSON(ctx context.Context, msgs smqjson.Messages) error {
+	q := `INSERT INTO %s (channel, created, subtopic, publisher, protocol, payload)
+		VALUES (:channel, :created, :subtopic, :publisher, :protocol, :payload)
+		ON CONFLICT DO NOTHING;`
+	q = fmt.Sprintf(q, msgs.Format)
+
+	dbMsgs := make([]jsonMessage, len(msgs.Data))
+	for i, m := range msgs.Data {
+		dbMsg, err := toJSONMessage(m)
+		if err != nil {
+			return errors.Wrap(errSaveMessage, err)
+		}
+		dbMsgs[i] = dbMsg
+	}
+
+	return tr.inTx(ctx, func(tx *sqlx.Tx) error {
+		for start := 0; start < len(dbMsgs); start += batchSize {
+			end := min(start+batchSize, len(dbMsgs))
+			if _, err := tx.NamedExecContext(ctx, q, dbMsgs[start:end]); err != nil {
+				return handleError(err)
+			}
+		}
+		return nil
+	})
+}
+
+func (tr timescaleRepo) createTable(ctx context.Context, name string) error {
+	q := `CREATE TABLE IF NOT EXISTS %s (
+		created       BIGINT NOT NULL,
+		channel       VARCHAR(36),
+		subtopic      VARCHAR(254),
+		publisher     VARCHAR(36),
+		protocol      TEXT,
+		payload       JSONB,
+		PRIMARY KEY (created, publisher, subtopic)
+	)`
+	q = fmt.Sprintf(q, name)
+	if _, err := tr.db.ExecContext(ctx, q); err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	hq := `SELECT create_hypertable('%s', 'created', create_default_indexes => FALSE, chunk_time_interval => 86400000000000, if_not_exists => TRUE)`
+	if _, err := tr.db.ExecContext(ctx, fmt.Sprintf(hq, name)); err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+
+	return nil
+}
+
+// inTx runs the given function inside a transaction which is committed
+// on success and rolled back otherwise.
+func (tr timescaleRepo) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) (err error) {
+	tx, err := tr.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(errSaveMessage, err)
+	}
+	defer func() {
+		if err != nil {
+			if txErr := tx.Rollback(); txErr != nil {
+				err = errors.Wrap(err, errors.Wrap(errTransRollback, txErr))
+			}
+			return
+		}
+
+		if err = tx.Commit(); err != nil {
+			err = errors.Wrap(errSaveMessage, err)
+		}
+	}()
+
+	return fn(tx)
+}
+
+func handleError(err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		switch pgErr.Code {
+		case errInvalid:
+			return errors.Wrap(errSaveMessage, errInvalidMessage)
+		case errUndefined:
+			return errNoTable
+		}
+	}
+
+	return errors.Wrap(errSaveMessage, err)
+}
+
+// senmlMessage is a SenML record with time converted to integer
+// nanoseconds, as TimescaleDB hypertables require an integer time column.
+type senmlMessage struct {
+	Channel     string   `db:"channel"`
+	Subtopic    string   `db:"subtopic"`
+	Publisher   string   `db:"publisher"`
+	Protocol    string   `db:"protocol"`
+	Name        string   `db:"name"`
+	Unit        string   `db:"unit"`
+	Time        int64    `db:"time"`
+	UpdateTime  float64  `db:"update_time"`
+	Value       *float64 `db:"value"`
+	StringValue *string  `db:"string_value"`
+	DataValue   *string  `db:"data_value"`
+	BoolValue   *bool    `db:"bool_value"`
+	Sum         *float64 `db:"sum"`
+}
+
+func toSenmlMessage(msg senml.Message) senmlMessage {
+	return senmlMessage{
+		Channel:     msg.Channel,
+		Subtopic:    msg.Subtopic,
+		Publisher:   msg.Publisher,
+		Protocol:    msg.Protocol,
+		Name:        msg.Name,
+		Unit:        msg.Unit,
+		Time:        int64(msg.Time),
+		UpdateTime:  msg.UpdateTime,
+		Value:       msg.Value,
+		StringValue: msg.StringValue,
+		DataValue:   msg.DataValue,
+		BoolValue:   msg.BoolValue,
+		Sum:         msg.Sum,
+	}
+}
+
+type jsonMessage struct {
+	Channel   string `db:"channel"`
+	Created   int64  `db:"created"`
+	Subtopic  string `db:"subtopic"`
+	Publisher string `db:"publisher"`
+	Protocol  string `db:"protocol"`
+	Payload   []byte `db:"payload"`
+}
+
+func toJSONMessage(msg smqjson.Message) (jsonMessage, error) {
+	data := []byte("{}")
+	if msg.Payload != nil {
+		b, err := json.Marshal(msg.Payload)
+		if err != nil {
+			return jsonMessage{}, errors.Wrap(errSaveMessage, err)
+		}
+		data = b
+	}
+
+	m := jsonMessage{
+		Channel:   msg.Channel,
+		Created:   msg.Created,
+		Subtopic:  msg.Subtopic,
+		Publisher: msg.Publisher,
+		Protocol:  msg.Protocol,
+		Payload:   data,
+	}
+
+	return m, nil
+}
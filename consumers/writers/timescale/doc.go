@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package timescale contains TimescaleDB-specific message writer implementation
+// (a consumer which persists SenML records and JSON documents in hypertables).
+package timescale
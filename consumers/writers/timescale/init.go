@@ -0,0 +1,44 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package timescale
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of timescale-writer.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "messages_1",
+				Up: []string{
+					`CREATE EXTENSION IF NOT EXISTS timescaledb`,
+					`CREATE TABLE IF NOT EXISTS messages (
+						time          BIGINT NOT NULL,
+						channel       VARCHAR(36),
+						subtopic      VARCHAR(254),
+						publisher     VARCHAR(36),
+						protocol      TEXT,
+						name          VARCHAR(254),
+						unit          TEXT,
+						value         FLOAT,
+						string_value  TEXT,
+						bool_value    BOOL,
+						data_value    TEXT,
+						sum           FLOAT,
+						update_time   FLOAT,
+						PRIMARY KEY (time, publisher, subtopic, name)
+					)`,
+					`SELECT create_hypertable('messages', 'time', create_default_indexes => FALSE, chunk_time_interval => 86400000000000, if_not_exists => TRUE)`,
+					`CREATE INDEX IF NOT EXISTS idx_messages_channel_time ON messages (channel, time DESC)`,
+				},
+				Down: []string{
+					"DROP TABLE messages",
+				},
+			},
+		},
+	}
+}
@@ -22,6 +22,7 @@ var _ grpcDomainsV1.DomainsServiceClient = (*domainsGrpcClient)(nil)
 type domainsGrpcClient struct {
 	deleteUserFromDomains endpoint.Endpoint
 	retrieveEntity        endpoint.Endpoint
+	requiresMFA           endpoint.Endpoint
 	timeout               time.Duration
 }
 
@@ -44,6 +45,14 @@ func NewDomainsClient(conn *grpc.ClientConn, timeout time.Duration) grpcDomainsV
 			decodeRetrieveEntityResponse,
 			grpcCommonV1.RetrieveEntityRes{},
 		).Endpoint(),
+		requiresMFA: kitgrpc.NewClient(
+			conn,
+			domainsSvcName,
+			"RequiresMFA",
+			encodeRequiresMFARequest,
+			decodeRequiresMFAResponse,
+			grpcDomainsV1.RequiresMFARes{},
+		).Endpoint(),
 		timeout: timeout,
 	}
 }
@@ -106,3 +115,30 @@ func encodeRetrieveEntityRequest(_ context.Context, grpcReq interface{}) (interf
 		Id: req.ID,
 	}, nil
 }
+
+func (client domainsGrpcClient) RequiresMFA(ctx context.Context, in *grpcDomainsV1.RequiresMFAReq, opts ...grpc.CallOption) (*grpcDomainsV1.RequiresMFARes, error) {
+	ctx, cancel := context.WithTimeout(ctx, client.timeout)
+	defer cancel()
+
+	res, err := client.requiresMFA(ctx, requiresMFAReq{
+		userID: in.GetUserId(),
+	})
+	if err != nil {
+		return &grpcDomainsV1.RequiresMFARes{}, grpcapi.DecodeError(err)
+	}
+
+	rmr := res.(requiresMFARes)
+	return &grpcDomainsV1.RequiresMFARes{Required: rmr.required}, nil
+}
+
+func decodeRequiresMFAResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(*grpcDomainsV1.RequiresMFARes)
+	return requiresMFARes{required: res.GetRequired()}, nil
+}
+
+func encodeRequiresMFARequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(requiresMFAReq)
+	return &grpcDomainsV1.RequiresMFAReq{
+		UserId: req.userID,
+	}, nil
+}
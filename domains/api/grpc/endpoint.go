@@ -43,3 +43,19 @@ func retrieveEntityEndpoint(svc domains.Service) endpoint.Endpoint {
 		}, nil
 	}
 }
+
+func requiresMFAEndpoint(svc domains.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(requiresMFAReq)
+		if err := req.validate(); err != nil {
+			return requiresMFARes{}, err
+		}
+
+		required, err := svc.RequiresMFA(ctx, req.userID)
+		if err != nil {
+			return requiresMFARes{}, err
+		}
+
+		return requiresMFARes{required: required}, nil
+	}
+}
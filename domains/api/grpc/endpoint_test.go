@@ -15,6 +15,7 @@ import (
 	grpcapi "github.com/absmach/supermq/domains/api/grpc"
 	domains "github.com/absmach/supermq/domains/private"
 	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
@@ -101,3 +102,46 @@ func TestDeleteUserFromDomains(t *testing.T) {
 		repoCall.Unset()
 	}
 }
+
+func TestRequiresMFA(t *testing.T) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.Nil(t, err, fmt.Sprintf("Unexpected error creating client connection %s", err))
+	grpcClient := grpcapi.NewDomainsClient(conn, time.Second)
+
+	cases := []struct {
+		desc     string
+		req      *grpcDomainsV1.RequiresMFAReq
+		required bool
+		svcErr   error
+		err      error
+	}{
+		{
+			desc:     "check user in domain requiring MFA",
+			req:      &grpcDomainsV1.RequiresMFAReq{UserId: id},
+			required: true,
+		},
+		{
+			desc:     "check user without domain requiring MFA",
+			req:      &grpcDomainsV1.RequiresMFAReq{UserId: id},
+			required: false,
+		},
+		{
+			desc: "check with empty user ID",
+			req:  &grpcDomainsV1.RequiresMFAReq{},
+			err:  apiutil.ErrMissingID,
+		},
+		{
+			desc:   "check with failed service",
+			req:    &grpcDomainsV1.RequiresMFAReq{UserId: id},
+			svcErr: svcerr.ErrViewEntity,
+			err:    svcerr.ErrViewEntity,
+		},
+	}
+	for _, tc := range cases {
+		svcCall := svc.On("RequiresMFA", mock.Anything, tc.req.GetUserId()).Return(tc.required, tc.svcErr)
+		res, err := grpcClient.RequiresMFA(context.Background(), tc.req)
+		assert.Equal(t, tc.required, res.GetRequired(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.required, res.GetRequired()))
+		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		svcCall.Unset()
+	}
+}
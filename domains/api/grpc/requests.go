@@ -30,3 +30,15 @@ func (req retrieveEntityReq) validate() error {
 
 	return nil
 }
+
+type requiresMFAReq struct {
+	userID string
+}
+
+func (req requiresMFAReq) validate() error {
+	if req.userID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
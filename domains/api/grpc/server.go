@@ -19,6 +19,7 @@ type domainsGrpcServer struct {
 	grpcDomainsV1.UnimplementedDomainsServiceServer
 	deleteUserFromDomains kitgrpc.Handler
 	retrieveEntity        kitgrpc.Handler
+	requiresMFA           kitgrpc.Handler
 }
 
 func NewDomainsServer(svc domains.Service) grpcDomainsV1.DomainsServiceServer {
@@ -33,6 +34,11 @@ func NewDomainsServer(svc domains.Service) grpcDomainsV1.DomainsServiceServer {
 			decodeRetrieveEntityRequest,
 			encodeRetrieveEntityResponse,
 		),
+		requiresMFA: kitgrpc.NewServer(
+			requiresMFAEndpoint(svc),
+			decodeRequiresMFARequest,
+			encodeRequiresMFAResponse,
+		),
 	}
 }
 
@@ -83,3 +89,26 @@ func (s *domainsGrpcServer) RetrieveEntity(ctx context.Context, req *grpcCommonV
 
 	return res.(*grpcCommonV1.RetrieveEntityRes), nil
 }
+
+func decodeRequiresMFARequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	req := grpcReq.(*grpcDomainsV1.RequiresMFAReq)
+
+	return requiresMFAReq{
+		userID: req.GetUserId(),
+	}, nil
+}
+
+func encodeRequiresMFAResponse(_ context.Context, grpcRes interface{}) (interface{}, error) {
+	res := grpcRes.(requiresMFARes)
+
+	return &grpcDomainsV1.RequiresMFARes{Required: res.required}, nil
+}
+
+func (s *domainsGrpcServer) RequiresMFA(ctx context.Context, req *grpcDomainsV1.RequiresMFAReq) (*grpcDomainsV1.RequiresMFARes, error) {
+	_, res, err := s.requiresMFA.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, grpcapi.EncodeError(err)
+	}
+
+	return res.(*grpcDomainsV1.RequiresMFARes), nil
+}
@@ -23,10 +23,11 @@ type page struct {
 }
 
 type createDomainReq struct {
-	Name     string                 `json:"name"`
-	Metadata map[string]interface{} `json:"metadata,omitempty"`
-	Tags     []string               `json:"tags,omitempty"`
-	Alias    string                 `json:"alias"`
+	Name        string                 `json:"name"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	Tags        []string               `json:"tags,omitempty"`
+	Alias       string                 `json:"alias"`
+	MFARequired bool                   `json:"mfa_required,omitempty"`
 }
 
 func (req createDomainReq) validate() error {
@@ -54,11 +55,12 @@ func (req retrieveDomainRequest) validate() error {
 }
 
 type updateDomainReq struct {
-	domainID string
-	Name     *string                 `json:"name,omitempty"`
-	Metadata *map[string]interface{} `json:"metadata,omitempty"`
-	Tags     *[]string               `json:"tags,omitempty"`
-	Alias    *string                 `json:"alias,omitempty"`
+	domainID    string
+	Name        *string                 `json:"name,omitempty"`
+	Metadata    *map[string]interface{} `json:"metadata,omitempty"`
+	Tags        *[]string               `json:"tags,omitempty"`
+	Alias       *string                 `json:"alias,omitempty"`
+	MFARequired *bool                   `json:"mfa_required,omitempty"`
 }
 
 func (req updateDomainReq) validate() error {
@@ -97,47 +97,50 @@ func (s *Status) UnmarshalJSON(data []byte) error {
 type Metadata map[string]interface{}
 
 type DomainReq struct {
-	Name      *string    `json:"name,omitempty"`
-	Metadata  *Metadata  `json:"metadata,omitempty"`
-	Tags      *[]string  `json:"tags,omitempty"`
-	Alias     *string    `json:"alias,omitempty"`
-	Status    *Status    `json:"status,omitempty"`
-	UpdatedBy *string    `json:"updated_by,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Name        *string    `json:"name,omitempty"`
+	Metadata    *Metadata  `json:"metadata,omitempty"`
+	Tags        *[]string  `json:"tags,omitempty"`
+	Alias       *string    `json:"alias,omitempty"`
+	Status      *Status    `json:"status,omitempty"`
+	MFARequired *bool      `json:"mfa_required,omitempty"`
+	UpdatedBy   *string    `json:"updated_by,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
 }
 type Domain struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Metadata  Metadata  `json:"metadata,omitempty"`
-	Tags      []string  `json:"tags,omitempty"`
-	Alias     string    `json:"alias,omitempty"`
-	Status    Status    `json:"status"`
-	RoleID    string    `json:"role_id,omitempty"`
-	RoleName  string    `json:"role_name,omitempty"`
-	Actions   []string  `json:"actions,omitempty"`
-	CreatedBy string    `json:"created_by,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedBy string    `json:"updated_by,omitempty"`
-	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Metadata    Metadata  `json:"metadata,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Alias       string    `json:"alias,omitempty"`
+	Status      Status    `json:"status"`
+	MFARequired bool      `json:"mfa_required,omitempty"`
+	RoleID      string    `json:"role_id,omitempty"`
+	RoleName    string    `json:"role_name,omitempty"`
+	Actions     []string  `json:"actions,omitempty"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedBy   string    `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
 }
 
 type Page struct {
-	Total    uint64   `json:"total"`
-	Offset   uint64   `json:"offset"`
-	Limit    uint64   `json:"limit"`
-	Name     string   `json:"name,omitempty"`
-	Order    string   `json:"-"`
-	Dir      string   `json:"-"`
-	Metadata Metadata `json:"metadata,omitempty"`
-	Tag      string   `json:"tag,omitempty"`
-	RoleName string   `json:"role_name,omitempty"`
-	RoleID   string   `json:"role_id,omitempty"`
-	Actions  []string `json:"actions,omitempty"`
-	Status   Status   `json:"status,omitempty"`
-	ID       string   `json:"id,omitempty"`
-	IDs      []string `json:"-"`
-	Identity string   `json:"identity,omitempty"`
-	UserID   string   `json:"-"`
+	Total       uint64   `json:"total"`
+	Offset      uint64   `json:"offset"`
+	Limit       uint64   `json:"limit"`
+	Name        string   `json:"name,omitempty"`
+	Order       string   `json:"-"`
+	Dir         string   `json:"-"`
+	Metadata    Metadata `json:"metadata,omitempty"`
+	Tag         string   `json:"tag,omitempty"`
+	RoleName    string   `json:"role_name,omitempty"`
+	RoleID      string   `json:"role_id,omitempty"`
+	Actions     []string `json:"actions,omitempty"`
+	Status      Status   `json:"status,omitempty"`
+	MFARequired bool     `json:"-"`
+	ID          string   `json:"id,omitempty"`
+	IDs         []string `json:"-"`
+	Identity    string   `json:"identity,omitempty"`
+	UserID      string   `json:"-"`
 }
 
 type DomainsPage struct {
@@ -48,6 +48,7 @@ func (cde createDomainEvent) Encode() (map[string]interface{}, error) {
 		"id":                cde.ID,
 		"alias":             cde.Alias,
 		"status":            cde.Status.String(),
+		"mfa_required":      cde.MFARequired,
 		"created_at":        cde.CreatedAt,
 		"created_by":        cde.CreatedBy,
 		"roles_provisioned": cde.rolesProvisioned,
@@ -131,17 +132,18 @@ type updateDomainEvent struct {
 
 func (ude updateDomainEvent) Encode() (map[string]interface{}, error) {
 	val := map[string]interface{}{
-		"operation":   domainUpdate,
-		"id":          ude.ID,
-		"alias":       ude.Alias,
-		"status":      ude.Status.String(),
-		"created_at":  ude.CreatedAt,
-		"created_by":  ude.CreatedBy,
-		"updated_at":  ude.UpdatedAt,
-		"updated_by":  ude.UpdatedBy,
-		"user_id":     ude.UserID,
-		"token_type":  ude.Type.String(),
-		"super_admin": ude.SuperAdmin,
+		"operation":    domainUpdate,
+		"id":           ude.ID,
+		"alias":        ude.Alias,
+		"status":       ude.Status.String(),
+		"mfa_required": ude.MFARequired,
+		"created_at":   ude.CreatedAt,
+		"created_by":   ude.CreatedBy,
+		"updated_at":   ude.UpdatedAt,
+		"updated_by":   ude.UpdatedBy,
+		"user_id":      ude.UserID,
+		"token_type":   ude.Type.String(),
+		"super_admin":  ude.SuperAdmin,
 	}
 
 	if ude.Name != "" {
@@ -105,6 +105,80 @@ func (_c *DomainsServiceClient_DeleteUserFromDomains_Call) RunAndReturn(run func
 	return _c
 }
 
+// RequiresMFA provides a mock function with given fields: ctx, in, opts
+func (_m *DomainsServiceClient) RequiresMFA(ctx context.Context, in *v1.RequiresMFAReq, opts ...grpc.CallOption) (*v1.RequiresMFARes, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequiresMFA")
+	}
+
+	var r0 *v1.RequiresMFARes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.RequiresMFAReq, ...grpc.CallOption) (*v1.RequiresMFARes, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.RequiresMFAReq, ...grpc.CallOption) *v1.RequiresMFARes); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.RequiresMFARes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.RequiresMFAReq, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DomainsServiceClient_RequiresMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequiresMFA'
+type DomainsServiceClient_RequiresMFA_Call struct {
+	*mock.Call
+}
+
+// RequiresMFA is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *v1.RequiresMFAReq
+//   - opts ...grpc.CallOption
+func (_e *DomainsServiceClient_Expecter) RequiresMFA(ctx interface{}, in interface{}, opts ...interface{}) *DomainsServiceClient_RequiresMFA_Call {
+	return &DomainsServiceClient_RequiresMFA_Call{Call: _e.mock.On("RequiresMFA",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *DomainsServiceClient_RequiresMFA_Call) Run(run func(ctx context.Context, in *v1.RequiresMFAReq, opts ...grpc.CallOption)) *DomainsServiceClient_RequiresMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		run(args[0].(context.Context), args[1].(*v1.RequiresMFAReq), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *DomainsServiceClient_RequiresMFA_Call) Return(_a0 *v1.RequiresMFARes, _a1 error) *DomainsServiceClient_RequiresMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DomainsServiceClient_RequiresMFA_Call) RunAndReturn(run func(context.Context, *v1.RequiresMFAReq, ...grpc.CallOption) (*v1.RequiresMFARes, error)) *DomainsServiceClient_RequiresMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveEntity provides a mock function with given fields: ctx, in, opts
 func (_m *DomainsServiceClient) RetrieveEntity(ctx context.Context, in *commonv1.RetrieveEntityReq, opts ...grpc.CallOption) (*commonv1.RetrieveEntityRes, error) {
 	_va := make([]interface{}, len(opts))
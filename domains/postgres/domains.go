@@ -46,9 +46,9 @@ func New(db postgres.Database) domains.Repository {
 }
 
 func (repo domainRepo) Save(ctx context.Context, d domains.Domain) (dd domains.Domain, err error) {
-	q := `INSERT INTO domains (id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status)
-	VALUES (:id, :name, :tags, :alias, :metadata, :created_at, :updated_at, :updated_by, :created_by, :status)
-	RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status;`
+	q := `INSERT INTO domains (id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, mfa_required)
+	VALUES (:id, :name, :tags, :alias, :metadata, :created_at, :updated_at, :updated_by, :created_by, :status, :mfa_required)
+	RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, mfa_required;`
 
 	dbd, err := toDBDomain(d)
 	if err != nil {
@@ -77,7 +77,7 @@ func (repo domainRepo) Save(ctx context.Context, d domains.Domain) (dd domains.D
 
 // RetrieveByID retrieves Domain by its unique ID.
 func (repo domainRepo) RetrieveByID(ctx context.Context, id string) (domains.Domain, error) {
-	q := `SELECT d.id as id, d.name as name, d.tags as tags,  d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status
+	q := `SELECT d.id as id, d.name as name, d.tags as tags,  d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status, d.mfa_required as mfa_required
         FROM domains d WHERE d.id = :id`
 
 	dbdp := dbDomainsPage{
@@ -115,6 +115,7 @@ func (repo domainRepo) RetrieveByUserAndID(ctx context.Context, userID, id strin
 			d.alias as alias,
 			d.metadata as metadata,
 			d.status as status,
+			d.mfa_required as mfa_required,
 			d.role_id AS role_id,
 			d.role_name AS role_name,
 			d.actions AS actions,
@@ -165,7 +166,7 @@ func (repo domainRepo) RetrieveAllByIDs(ctx context.Context, pm domains.Page) (d
 		return domains.DomainsPage{}, errors.Wrap(repoerr.ErrFailedOpDB, err)
 	}
 
-	q = `SELECT d.id as id, d.name as name, d.tags as tags, d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status
+	q = `SELECT d.id as id, d.name as name, d.tags as tags, d.alias as alias, d.metadata as metadata, d.created_at as created_at, d.updated_at as updated_at, d.updated_by as updated_by, d.created_by as created_by, d.status as status, d.mfa_required as mfa_required
 	FROM domains d`
 	q = fmt.Sprintf("%s %s  LIMIT %d OFFSET %d;", q, query, pm.Limit, pm.Offset)
 
@@ -221,7 +222,8 @@ func (repo domainRepo) ListDomains(ctx context.Context, pm domains.Page) (domain
 			d.updated_at as updated_at,
 			d.updated_by as updated_by,
 			d.created_by as created_by,
-			d.status as status
+			d.status as status,
+			d.mfa_required as mfa_required
 		FROM
 			domains as d
 		%s
@@ -237,6 +239,7 @@ func (repo domainRepo) ListDomains(ctx context.Context, pm domains.Page) (domain
 				d.alias as alias,
 				d.metadata as metadata,
 				d.status as status,
+				d.mfa_required as mfa_required,
 				d.role_id AS role_id,
 				d.role_name AS role_name,
 				d.actions AS actions,
@@ -318,6 +321,10 @@ func (repo domainRepo) Update(ctx context.Context, id string, dr domains.DomainR
 		query = append(query, "alias = :alias")
 		d.Alias = *dr.Alias
 	}
+	if dr.MFARequired != nil {
+		query = append(query, "mfa_required = :mfa_required")
+		d.MFARequired = *dr.MFARequired
+	}
 	d.UpdatedAt = time.Now()
 	if dr.UpdatedAt != nil {
 		query = append(query, "updated_at = :updated_at")
@@ -332,7 +339,7 @@ func (repo domainRepo) Update(ctx context.Context, id string, dr domains.DomainR
 	}
 	q := fmt.Sprintf(`UPDATE domains SET %s
         WHERE id = :id
-        RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status;`,
+        RETURNING id, name, tags, alias, metadata, created_at, updated_at, updated_by, created_by, status, mfa_required;`,
 		upq)
 
 	dbd, err := toDBDomain(d)
@@ -388,6 +395,7 @@ func (repo domainRepo) userDomainsBaseQuery() string {
 				d.updated_by as updated_by,
 				d.created_by as created_by,
 				d.status as status,
+				d.mfa_required as mfa_required,
 				dr.entity_id AS entity_id,
 				drm.member_id AS member_id,
 				dr.id AS role_id,
@@ -436,19 +444,20 @@ func applyOrdering(emq string, pm domains.Page) string {
 }
 
 type dbDomain struct {
-	ID        string           `db:"id"`
-	Name      string           `db:"name"`
-	Metadata  []byte           `db:"metadata,omitempty"`
-	Tags      pgtype.TextArray `db:"tags,omitempty"`
-	Alias     *string          `db:"alias,omitempty"`
-	Status    domains.Status   `db:"status"`
-	RoleID    string           `db:"role_id"`
-	RoleName  string           `db:"role_name"`
-	Actions   pq.StringArray   `db:"actions"`
-	CreatedBy string           `db:"created_by"`
-	CreatedAt time.Time        `db:"created_at"`
-	UpdatedBy *string          `db:"updated_by,omitempty"`
-	UpdatedAt sql.NullTime     `db:"updated_at,omitempty"`
+	ID          string           `db:"id"`
+	Name        string           `db:"name"`
+	Metadata    []byte           `db:"metadata,omitempty"`
+	Tags        pgtype.TextArray `db:"tags,omitempty"`
+	Alias       *string          `db:"alias,omitempty"`
+	Status      domains.Status   `db:"status"`
+	MFARequired bool             `db:"mfa_required"`
+	RoleID      string           `db:"role_id"`
+	RoleName    string           `db:"role_name"`
+	Actions     pq.StringArray   `db:"actions"`
+	CreatedBy   string           `db:"created_by"`
+	CreatedAt   time.Time        `db:"created_at"`
+	UpdatedBy   *string          `db:"updated_by,omitempty"`
+	UpdatedAt   sql.NullTime     `db:"updated_at,omitempty"`
 }
 
 func toDBDomain(d domains.Domain) (dbDomain, error) {
@@ -479,17 +488,18 @@ func toDBDomain(d domains.Domain) (dbDomain, error) {
 	}
 
 	return dbDomain{
-		ID:        d.ID,
-		Name:      d.Name,
-		Metadata:  data,
-		Tags:      tags,
-		Alias:     alias,
-		Status:    d.Status,
-		RoleID:    d.RoleID,
-		CreatedBy: d.CreatedBy,
-		CreatedAt: d.CreatedAt,
-		UpdatedBy: updatedBy,
-		UpdatedAt: updatedAt,
+		ID:          d.ID,
+		Name:        d.Name,
+		Metadata:    data,
+		Tags:        tags,
+		Alias:       alias,
+		Status:      d.Status,
+		MFARequired: d.MFARequired,
+		RoleID:      d.RoleID,
+		CreatedBy:   d.CreatedBy,
+		CreatedAt:   d.CreatedAt,
+		UpdatedBy:   updatedBy,
+		UpdatedAt:   updatedAt,
 	}, nil
 }
 
@@ -518,19 +528,20 @@ func toDomain(d dbDomain) (domains.Domain, error) {
 	}
 
 	return domains.Domain{
-		ID:        d.ID,
-		Name:      d.Name,
-		Metadata:  metadata,
-		Tags:      tags,
-		Alias:     alias,
-		RoleID:    d.RoleID,
-		RoleName:  d.RoleName,
-		Actions:   d.Actions,
-		Status:    d.Status,
-		CreatedBy: d.CreatedBy,
-		CreatedAt: d.CreatedAt,
-		UpdatedBy: updatedBy,
-		UpdatedAt: updatedAt,
+		ID:          d.ID,
+		Name:        d.Name,
+		Metadata:    metadata,
+		Tags:        tags,
+		Alias:       alias,
+		RoleID:      d.RoleID,
+		RoleName:    d.RoleName,
+		Actions:     d.Actions,
+		Status:      d.Status,
+		MFARequired: d.MFARequired,
+		CreatedBy:   d.CreatedBy,
+		CreatedAt:   d.CreatedAt,
+		UpdatedBy:   updatedBy,
+		UpdatedAt:   updatedAt,
 	}, nil
 }
 
@@ -598,6 +609,10 @@ func buildPageQuery(pm domains.Page) (string, error) {
 		query = append(query, "d.name = :name")
 	}
 
+	if pm.MFARequired {
+		query = append(query, "d.mfa_required = TRUE")
+	}
+
 	if pm.UserID != "" {
 		if pm.RoleName != "" {
 			query = append(query, "d.role_name = :role_name")
@@ -40,6 +40,15 @@ func Migration() (*migrate.MemoryMigrationSource, error) {
 					`DROP TABLE IF EXISTS domains`,
 				},
 			},
+			{
+				Id: "domain_2",
+				Up: []string{
+					`ALTER TABLE domains ADD COLUMN mfa_required BOOLEAN NOT NULL DEFAULT FALSE`,
+				},
+				Down: []string{
+					`ALTER TABLE domains DROP COLUMN mfa_required`,
+				},
+			},
 		},
 	}
 
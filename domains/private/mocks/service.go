@@ -34,6 +34,34 @@ func (_m *Service) DeleteUserFromDomains(ctx context.Context, id string) error {
 	return r0
 }
 
+// RequiresMFA provides a mock function with given fields: ctx, userID
+func (_m *Service) RequiresMFA(ctx context.Context, userID string) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequiresMFA")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveEntity provides a mock function with given fields: ctx, id
 func (_m *Service) RetrieveEntity(ctx context.Context, id string) (domains.Domain, error) {
 	ret := _m.Called(ctx, id)
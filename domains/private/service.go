@@ -17,6 +17,9 @@ const defLimit = 100
 type Service interface {
 	RetrieveEntity(ctx context.Context, id string) (domains.Domain, error)
 	DeleteUserFromDomains(ctx context.Context, id string) error
+	// RequiresMFA reports whether the user is a member of an enabled domain
+	// which requires multi-factor authentication.
+	RequiresMFA(ctx context.Context, userID string) (bool, error)
 }
 
 var _ Service = (*service)(nil)
@@ -69,3 +72,12 @@ func (svc service) DeleteUserFromDomains(ctx context.Context, id string) (err er
 
 	return nil
 }
+
+func (svc service) RequiresMFA(ctx context.Context, userID string) (bool, error) {
+	page, err := svc.repo.ListDomains(ctx, domains.Page{UserID: userID, MFARequired: true, Status: domains.EnabledStatus, Limit: 1})
+	if err != nil {
+		return false, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page.Total > 0, nil
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	apiutil "github.com/absmach/supermq/api/http/util"
+	adapter "github.com/absmach/supermq/http"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/go-kit/kit/endpoint"
 )
@@ -21,3 +22,19 @@ func sendMessageEndpoint() endpoint.Endpoint {
 		return publishMessageRes{}, nil
 	}
 }
+
+func lastValueEndpoint(svc adapter.LastValueService) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(lastValueReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		msg, err := svc.LastValue(ctx, req.token, req.chanID, req.subtopic)
+		if err != nil {
+			return nil, err
+		}
+
+		return lastValueRes{msg: msg}, nil
+	}
+}
@@ -4,6 +4,7 @@
 package api_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,7 +17,9 @@ import (
 	"github.com/absmach/mgate/pkg/session"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	smqauth "github.com/absmach/supermq/auth"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	climocks "github.com/absmach/supermq/clients/mocks"
 	server "github.com/absmach/supermq/http"
@@ -25,9 +28,15 @@ import (
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnMocks "github.com/absmach/supermq/pkg/authn/mocks"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	authzMocks "github.com/absmach/supermq/pkg/authz/mocks"
 	"github.com/absmach/supermq/pkg/connections"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lastvalue"
+	"github.com/absmach/supermq/pkg/messaging"
 	pubsub "github.com/absmach/supermq/pkg/messaging/mocks"
 	"github.com/absmach/supermq/pkg/policies"
+	schemamocks "github.com/absmach/supermq/pkg/schema/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -39,13 +48,15 @@ const (
 
 var clientID = testsutil.GenerateUUID(&testing.T{})
 
-func newService(authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) (session.Handler, *pubsub.PubSub) {
+func newService(authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) (session.Handler, *pubsub.PubSub) {
 	pub := new(pubsub.PubSub)
-	return server.NewHandler(pub, authn, clients, channels, smqlog.NewMock()), pub
+	schemas := new(schemamocks.Cache)
+	schemas.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return server.NewHandler(pub, authn, authz, clients, channels, schemas, nil, smqlog.NewMock()), pub
 }
 
-func newTargetHTTPServer() *httptest.Server {
-	mux := api.MakeHandler(smqlog.NewMock(), instanceID)
+func newTargetHTTPServer(svc server.LastValueService) *httptest.Server {
+	mux := api.MakeHandler(svc, smqlog.NewMock(), instanceID)
 	return httptest.NewServer(mux)
 }
 
@@ -58,7 +69,7 @@ func newProxyHTPPServer(svc session.Handler, targetServer *httptest.Server) (*ht
 	if err != nil {
 		return nil, err
 	}
-	return httptest.NewServer(http.HandlerFunc(mp.ServeHTTP)), nil
+	return httptest.NewServer(server.WithLastValue(server.WithHeaders(mp), targetServer.Config.Handler)), nil
 }
 
 type testRequest struct {
@@ -69,6 +80,7 @@ type testRequest struct {
 	token       string
 	body        io.Reader
 	basicAuth   bool
+	headers     map[string]string
 }
 
 func (tr testRequest) make() (*http.Response, error) {
@@ -86,12 +98,16 @@ func (tr testRequest) make() (*http.Response, error) {
 	if tr.contentType != "" {
 		req.Header.Set("Content-Type", tr.contentType)
 	}
+	for key, val := range tr.headers {
+		req.Header.Set(key, val)
+	}
 	return tr.client.Do(req)
 }
 
 func TestPublish(t *testing.T) {
 	clients := new(climocks.ClientsServiceClient)
 	authn := new(authnMocks.Authentication)
+	authz := new(authzMocks.Authorization)
 	channels := new(chmocks.ChannelsServiceClient)
 	chanID := "1"
 	ctSenmlJSON := "application/senml+json"
@@ -102,8 +118,8 @@ func TestPublish(t *testing.T) {
 	msg := `[{"n":"current","t":-1,"v":1.6}]`
 	msgJSON := `{"field1":"val1","field2":"val2"}`
 	msgCBOR := `81A3616E6763757272656E746174206176FB3FF999999999999A`
-	svc, pub := newService(authn, clients, channels)
-	target := newTargetHTTPServer()
+	svc, pub := newService(authn, authz, clients, channels)
+	target := newTargetHTTPServer(server.NewLastValueService(lastvalue.NewMemoryStore(100, 0), authn, authz, clients, channels, nil))
 	defer target.Close()
 	ts, err := newProxyHTPPServer(svc, target)
 	assert.Nil(t, err, fmt.Sprintf("failed to create proxy server with err: %v", err))
@@ -122,6 +138,8 @@ func TestPublish(t *testing.T) {
 		authnRes    *grpcClientsV1.AuthnRes
 		authzRes    *grpcChannelsV1.AuthzRes
 		authzErr    error
+		headers     map[string]string
+		msgHeaders  map[string]string
 		err         error
 	}{
 		{
@@ -154,6 +172,26 @@ func TestPublish(t *testing.T) {
 			authnRes:    &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
 			authzRes:    &grpcChannelsV1.AuthzRes{Authorized: true},
 		},
+		{
+			desc:        "publish message with headers",
+			chanID:      chanID,
+			msg:         msgJSON,
+			contentType: ctJSON,
+			key:         clientKey,
+			status:      http.StatusAccepted,
+			authnRes:    &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			authzRes:    &grpcChannelsV1.AuthzRes{Authorized: true},
+			headers: map[string]string{
+				"X-SMQ-Response-Topic":   "channels/" + chanID + "/messages/responses",
+				"X-SMQ-Correlation-Data": "request-1",
+				"X-Request-ID":           "ignored",
+			},
+			msgHeaders: map[string]string{
+				messaging.ContentTypeHeader:     ctJSON,
+				messaging.ResponseTopicHeader:   "channels/" + chanID + "/messages/responses",
+				messaging.CorrelationDataHeader: "request-1",
+			},
+		},
 		{
 			desc:        "publish message with empty key",
 			chanID:      chanID,
@@ -223,7 +261,10 @@ func TestPublish(t *testing.T) {
 				ClientType: policies.ClientType,
 				Type:       uint32(connections.Publish),
 			}).Return(tc.authzRes, tc.authzErr)
-			svcCall := pub.On("Publish", mock.Anything, tc.chanID, mock.Anything).Return(nil)
+			var headers map[string]string
+			svcCall := pub.On("Publish", mock.Anything, tc.chanID, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				headers = args.Get(2).(*messaging.Message).GetHeaders()
+			})
 			req := testRequest{
 				client:      ts.Client(),
 				method:      http.MethodPost,
@@ -232,13 +273,204 @@ func TestPublish(t *testing.T) {
 				token:       tc.key,
 				body:        strings.NewReader(tc.msg),
 				basicAuth:   tc.basicAuth,
+				headers:     tc.headers,
 			}
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.msgHeaders != nil {
+				assert.Equal(t, tc.msgHeaders, headers, fmt.Sprintf("%s: expected headers %v got %v", tc.desc, tc.msgHeaders, headers))
+			}
 			svcCall.Unset()
 			clientsCall.Unset()
 			channelsCall.Unset()
 		})
 	}
 }
+
+func TestLastValue(t *testing.T) {
+	clients := new(climocks.ClientsServiceClient)
+	authn := new(authnMocks.Authentication)
+	authz := new(authzMocks.Authorization)
+	channels := new(chmocks.ChannelsServiceClient)
+	chanID := testsutil.GenerateUUID(t)
+	clientKey := "client_key"
+	userToken := "user_token"
+	patToken := "pat_token"
+	domainUserID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	userID := testsutil.GenerateUUID(t)
+	patID := testsutil.GenerateUUID(t)
+	patUserID := policies.EncodeDomainUserID(domainID, userID)
+	store := lastvalue.NewMemoryStore(100, 0)
+	svc, pub := newService(authn, authz, clients, channels)
+	target := newTargetHTTPServer(server.NewLastValueService(store, authn, authz, clients, channels, nil))
+	defer target.Close()
+	ts, err := newProxyHTPPServer(svc, target)
+	assert.Nil(t, err, fmt.Sprintf("failed to create proxy server with err: %v", err))
+	defer ts.Close()
+
+	msg := &messaging.Message{
+		Channel:  chanID,
+		Subtopic: "desired.state",
+		Payload:  []byte(`{"state":"on"}`),
+		Headers: map[string]string{
+			messaging.ContentTypeHeader:     "application/json",
+			messaging.CorrelationDataHeader: "request-1",
+		},
+	}
+	err = store.Save(context.Background(), msg)
+	assert.Nil(t, err, fmt.Sprintf("failed to save last value with err: %v", err))
+
+	cases := []struct {
+		desc       string
+		url        string
+		token      string
+		clientType string
+		clientID   string
+		authnRes   *grpcClientsV1.AuthnRes
+		authnErr   error
+		patErr     error
+		authzRes   *grpcChannelsV1.AuthzRes
+		authzErr   error
+		status     int
+		body       string
+		headers    map[string]string
+	}{
+		{
+			desc:       "get last value with client key",
+			url:        fmt.Sprintf("%s/channels/%s/messages/desired/state", ts.URL, chanID),
+			token:      apiutil.ClientPrefix + clientKey,
+			clientType: policies.ClientType,
+			authnRes:   &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			authzRes:   &grpcChannelsV1.AuthzRes{Authorized: true},
+			status:     http.StatusOK,
+			body:       `{"state":"on"}`,
+			headers: map[string]string{
+				"Content-Type":           "application/json",
+				"X-Smq-Correlation-Data": "request-1",
+			},
+		},
+		{
+			desc:       "get last value with user token",
+			url:        fmt.Sprintf("%s/channels/%s/messages/desired.state", ts.URL, chanID),
+			token:      apiutil.BearerPrefix + userToken,
+			clientType: policies.UserType,
+			authzRes:   &grpcChannelsV1.AuthzRes{Authorized: true},
+			status:     http.StatusOK,
+			body:       `{"state":"on"}`,
+		},
+		{
+			desc:       "get last value with personal access token",
+			url:        fmt.Sprintf("%s/channels/%s/messages/desired/state", ts.URL, chanID),
+			token:      apiutil.BearerPrefix + patToken,
+			clientType: policies.UserType,
+			clientID:   patUserID,
+			authzRes:   &grpcChannelsV1.AuthzRes{Authorized: true},
+			status:     http.StatusOK,
+			body:       `{"state":"on"}`,
+		},
+		{
+			desc:       "get last value with personal access token without subscribe scope",
+			url:        fmt.Sprintf("%s/channels/%s/messages/desired/state", ts.URL, chanID),
+			token:      apiutil.BearerPrefix + patToken,
+			clientType: policies.UserType,
+			clientID:   patUserID,
+			patErr:     svcerr.ErrAuthorization,
+			status:     http.StatusForbidden,
+		},
+		{
+			desc:       "get last value of topic without messages",
+			url:        fmt.Sprintf("%s/channels/%s/messages", ts.URL, chanID),
+			token:      apiutil.ClientPrefix + clientKey,
+			clientType: policies.ClientType,
+			authnRes:   &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			authzRes:   &grpcChannelsV1.AuthzRes{Authorized: true},
+			status:     http.StatusNotFound,
+		},
+		{
+			desc:       "get last value with invalid client key",
+			url:        fmt.Sprintf("%s/channels/%s/messages/desired/state", ts.URL, chanID),
+			token:      apiutil.ClientPrefix + invalidValue,
+			clientType: policies.ClientType,
+			authnRes:   &grpcClientsV1.AuthnRes{Authenticated: false},
+			status:     http.StatusUnauthorized,
+		},
+		{
+			desc:   "get last value without key",
+			url:    fmt.Sprintf("%s/channels/%s/messages/desired/state", ts.URL, chanID),
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:       "get last value without subscribe permission",
+			url:        fmt.Sprintf("%s/channels/%s/messages/desired/state", ts.URL, chanID),
+			token:      apiutil.ClientPrefix + clientKey,
+			clientType: policies.ClientType,
+			authnRes:   &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			authzRes:   &grpcChannelsV1.AuthzRes{Authorized: false},
+			status:     http.StatusForbidden,
+		},
+		{
+			desc:       "get last value with wildcard subtopic",
+			url:        fmt.Sprintf("%s/channels/%s/messages/desired/*", ts.URL, chanID),
+			token:      apiutil.ClientPrefix + clientKey,
+			clientType: policies.ClientType,
+			status:     http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			id := clientID
+			switch {
+			case tc.clientID != "":
+				id = tc.clientID
+			case tc.clientType == policies.UserType:
+				id = domainUserID
+			}
+			clientsCall := clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{ClientSecret: clientKey}).Return(tc.authnRes, tc.authnErr)
+			invalidCall := clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{ClientSecret: invalidValue}).Return(tc.authnRes, tc.authnErr)
+			authnCall := authn.On("Authenticate", mock.Anything, userToken).Return(smqauthn.Session{DomainUserID: domainUserID}, nil)
+			patAuthnCall := authn.On("Authenticate", mock.Anything, patToken).Return(smqauthn.Session{Type: smqauthn.PersonalAccessToken, UserID: userID, PatID: patID}, nil)
+			patCall := authz.On("AuthorizePAT", mock.Anything, smqauthz.PatReq{
+				UserID:                   userID,
+				PatID:                    patID,
+				PlatformEntityType:       smqauth.PlatformMesagingScope,
+				OptionalDomainEntityType: smqauth.DomainNullScope,
+				Operation:                smqauth.SubscribeOp,
+				EntityIDs:                []string{chanID},
+			}).Return(tc.patErr)
+			entityCall := channels.On("RetrieveEntity", mock.Anything, &grpcCommonV1.RetrieveEntityReq{Id: chanID}).Return(&grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: chanID, DomainId: domainID}}, nil)
+			channelsCall := channels.On("Authorize", mock.Anything, &grpcChannelsV1.AuthzReq{
+				ChannelId:  chanID,
+				ClientId:   id,
+				ClientType: tc.clientType,
+				Type:       uint32(connections.Subscribe),
+			}).Return(tc.authzRes, tc.authzErr)
+			req, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.token != "" {
+				req.Header.Set("Authorization", tc.token)
+			}
+			res, err := ts.Client().Do(req)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.body != "" {
+				body, err := io.ReadAll(res.Body)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, tc.body, string(body), fmt.Sprintf("%s: expected body %s got %s", tc.desc, tc.body, string(body)))
+			}
+			for key, val := range tc.headers {
+				assert.Equal(t, val, res.Header.Get(key), fmt.Sprintf("%s: expected header %s to be %s got %s", tc.desc, key, val, res.Header.Get(key)))
+			}
+			pub.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything)
+			clientsCall.Unset()
+			invalidCall.Unset()
+			authnCall.Unset()
+			patAuthnCall.Unset()
+			patCall.Unset()
+			entityCall.Unset()
+			channelsCall.Unset()
+		})
+	}
+}
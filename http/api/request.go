@@ -23,3 +23,20 @@ func (req publishReq) validate() error {
 
 	return nil
 }
+
+type lastValueReq struct {
+	token    string
+	chanID   string
+	subtopic string
+}
+
+func (req lastValueReq) validate() error {
+	if req.token == "" {
+		return apiutil.ErrBearerKey
+	}
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/pkg/messaging"
 )
 
 var _ supermq.Response = (*publishMessageRes)(nil)
@@ -24,3 +25,7 @@ func (res publishMessageRes) Headers() map[string]string {
 func (res publishMessageRes) Empty() bool {
 	return true
 }
+
+type lastValueRes struct {
+	msg *messaging.Message
+}
@@ -8,10 +8,13 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/absmach/supermq"
 	api "github.com/absmach/supermq/api/http"
 	apiutil "github.com/absmach/supermq/api/http/util"
+	adapter "github.com/absmach/supermq/http"
 	"github.com/absmach/supermq/pkg/errors"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/go-chi/chi/v5"
@@ -21,13 +24,16 @@ import (
 )
 
 const (
-	ctSenmlJSON = "application/senml+json"
-	ctSenmlCBOR = "application/senml+cbor"
-	contentType = "application/json"
+	ctSenmlJSON   = "application/senml+json"
+	ctSenmlCBOR   = "application/senml+cbor"
+	contentType   = "application/json"
+	ctOctetStream = "application/octet-stream"
 )
 
+var errMalformedSubtopic = errors.New("malformed subtopic")
+
 // MakeHandler returns a HTTP handler for API endpoints.
-func MakeHandler(logger *slog.Logger, instanceID string) http.Handler {
+func MakeHandler(svc adapter.LastValueService, logger *slog.Logger, instanceID string) http.Handler {
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
 	}
@@ -46,6 +52,20 @@ func MakeHandler(logger *slog.Logger, instanceID string) http.Handler {
 		api.EncodeResponse,
 		opts...,
 	), "publish").ServeHTTP)
+
+	r.Get("/channels/{chanID}/messages", otelhttp.NewHandler(kithttp.NewServer(
+		lastValueEndpoint(svc),
+		decodeLastValueRequest,
+		encodeLastValueResponse,
+		opts...,
+	), "last_value").ServeHTTP)
+
+	r.Get("/channels/{chanID}/messages/*", otelhttp.NewHandler(kithttp.NewServer(
+		lastValueEndpoint(svc),
+		decodeLastValueRequest,
+		encodeLastValueResponse,
+		opts...,
+	), "last_value").ServeHTTP)
 	r.Get("/health", supermq.Health("http", instanceID))
 	r.Handle("/metrics", promhttp.Handler())
 
@@ -77,3 +97,72 @@ func decodeRequest(_ context.Context, r *http.Request) (interface{}, error) {
 
 	return req, nil
 }
+
+func decodeLastValueRequest(_ context.Context, r *http.Request) (interface{}, error) {
+	req := lastValueReq{
+		chanID: chi.URLParam(r, "chanID"),
+	}
+	_, pass, ok := r.BasicAuth()
+	switch {
+	case ok:
+		req.token = pass
+	case !ok:
+		req.token = r.Header.Get("Authorization")
+	}
+
+	subtopic, err := parseSubtopic(chi.URLParam(r, "*"))
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+	req.subtopic = subtopic
+
+	return req, nil
+}
+
+// encodeLastValueResponse writes the message payload as the response body and
+// the message headers as the X-SMQ- prefixed response headers.
+func encodeLastValueResponse(_ context.Context, w http.ResponseWriter, response interface{}) error {
+	res := response.(lastValueRes)
+
+	ct := ctOctetStream
+	for key, val := range res.msg.GetHeaders() {
+		if key == messaging.ContentTypeHeader {
+			ct = val
+			continue
+		}
+		w.Header().Set(adapter.HeaderPrefix+key, val)
+	}
+	w.Header().Set("Content-Type", ct)
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write(res.msg.GetPayload())
+
+	return err
+}
+
+func parseSubtopic(subtopic string) (string, error) {
+	if subtopic == "" {
+		return subtopic, nil
+	}
+
+	subtopic, err := url.QueryUnescape(subtopic)
+	if err != nil {
+		return "", errMalformedSubtopic
+	}
+	subtopic = strings.ReplaceAll(subtopic, "/", ".")
+
+	elems := strings.Split(subtopic, ".")
+	filteredElems := []string{}
+	for _, elem := range elems {
+		if elem == "" {
+			continue
+		}
+
+		if strings.Contains(elem, "*") || strings.Contains(elem, ">") {
+			return "", errMalformedSubtopic
+		}
+
+		filteredElems = append(filteredElems, elem)
+	}
+
+	return strings.Join(filteredElems, "."), nil
+}
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	mgate "github.com/absmach/mgate/pkg/http"
@@ -19,11 +20,15 @@ import (
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/certauth"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/schema"
 )
 
 var _ session.Handler = (*handler)(nil)
@@ -34,8 +39,13 @@ const (
 	protocol                = "http"
 	clientIDCtxKey   ctxKey = "client_id"
 	clientTypeCtxKey ctxKey = "client_type"
+	headersCtxKey    ctxKey = "headers"
 )
 
+// HeaderPrefix is the prefix of the HTTP request headers which are propagated
+// as message headers, e.g. X-SMQ-Response-Topic is propagated as response-topic.
+const HeaderPrefix = "X-Smq-"
+
 // Log message formats.
 const (
 	logInfoConnected         = "connected with client_key %s"
@@ -62,17 +72,25 @@ type handler struct {
 	publisher messaging.Publisher
 	clients   grpcClientsV1.ClientsServiceClient
 	channels  grpcChannelsV1.ChannelsServiceClient
+	schemas   schema.Cache
 	authn     smqauthn.Authentication
+	authz     smqauthz.Authorization
+	certs     certauth.Authenticator
 	logger    *slog.Logger
 }
 
-// NewHandler creates new Handler entity.
-func NewHandler(publisher messaging.Publisher, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, logger *slog.Logger) session.Handler {
+// NewHandler creates new Handler entity. The clients are authenticated using
+// the client certificates if the certs authenticator is set, and using the
+// client secret otherwise.
+func NewHandler(publisher messaging.Publisher, authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, schemas schema.Cache, certs certauth.Authenticator, logger *slog.Logger) session.Handler {
 	return &handler{
 		publisher: publisher,
 		authn:     authn,
+		authz:     authz,
 		clients:   clients,
 		channels:  channels,
+		schemas:   schemas,
+		certs:     certs,
 		logger:    logger,
 	}
 }
@@ -125,45 +143,34 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		return errors.Wrap(errFailedPublish, errClientNotInitialized)
 	}
 
-	var clientID, clientType string
-	switch {
-	case strings.HasPrefix(string(s.Password), "Client"):
-		secret := strings.TrimPrefix(string(s.Password), apiutil.ClientPrefix)
-		authnRes, err := h.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{ClientSecret: secret})
-		if err != nil {
-			h.logger.Info(fmt.Sprintf(logInfoFailedAuthNClient, secret, *topic, err))
-			return mgate.NewHTTPProxyError(http.StatusUnauthorized, svcerr.ErrAuthentication)
-		}
-		if !authnRes.Authenticated {
-			h.logger.Info(fmt.Sprintf(logInfoFailedAuthNClient, secret, *topic, svcerr.ErrAuthentication))
-			return mgate.NewHTTPProxyError(http.StatusUnauthorized, svcerr.ErrAuthentication)
-		}
-		clientType = policies.ClientType
-		clientID = authnRes.GetId()
-	case strings.HasPrefix(string(s.Password), apiutil.BearerPrefix):
-		token := strings.TrimPrefix(string(s.Password), apiutil.BearerPrefix)
-		authnSession, err := h.authn.Authenticate(ctx, token)
-		if err != nil {
-			h.logger.Info(fmt.Sprintf(logInfoFailedAuthNToken, *topic, err))
-			return mgate.NewHTTPProxyError(http.StatusUnauthorized, svcerr.ErrAuthentication)
-		}
-		clientType = policies.UserType
-		clientID = authnSession.DomainUserID
-	default:
-		return mgate.NewHTTPProxyError(http.StatusUnauthorized, svcerr.ErrAuthentication)
-	}
-
 	chanID, subtopic, err := parseTopic(*topic)
 	if err != nil {
 		return mgate.NewHTTPProxyError(http.StatusBadRequest, err)
 	}
 
+	clientID, clientType, err := authenticate(ctx, h.authn, h.authz, h.clients, h.channels, h.certs, s.Username, string(s.Password), chanID, connections.Publish)
+	if err != nil {
+		switch {
+		case errors.Contains(err, svcerr.ErrUnauthorizedPAT):
+			return mgate.NewHTTPProxyError(http.StatusForbidden, svcerr.ErrUnauthorizedPAT)
+		case errors.Contains(err, svcerr.ErrAuthorization):
+			return mgate.NewHTTPProxyError(http.StatusUnauthorized, svcerr.ErrAuthorization)
+		case strings.HasPrefix(string(s.Password), apiutil.BearerPrefix):
+			h.logger.Info(fmt.Sprintf(logInfoFailedAuthNToken, *topic, err))
+		case strings.HasPrefix(string(s.Password), apiutil.ClientPrefix):
+			secret := strings.TrimPrefix(string(s.Password), apiutil.ClientPrefix)
+			h.logger.Info(fmt.Sprintf(logInfoFailedAuthNClient, secret, *topic, err))
+		}
+		return mgate.NewHTTPProxyError(http.StatusUnauthorized, svcerr.ErrAuthentication)
+	}
+
 	msg := messaging.Message{
 		Protocol: protocol,
 		Channel:  chanID,
 		Subtopic: subtopic,
 		Payload:  *payload,
 		Created:  time.Now().UnixNano(),
+		Headers:  messageHeaders(ctx),
 	}
 
 	ar := &grpcChannelsV1.AuthzReq{
@@ -180,6 +187,13 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		return mgate.NewHTTPProxyError(http.StatusUnauthorized, svcerr.ErrAuthorization)
 	}
 
+	if err := h.schemas.Validate(msg.Channel, res.GetSchema(), msg.Payload); err != nil {
+		if errors.Contains(err, schema.ErrInvalidPayload) {
+			return mgate.NewHTTPProxyError(http.StatusBadRequest, err)
+		}
+		return mgate.NewHTTPProxyError(http.StatusInternalServerError, err)
+	}
+
 	if clientType == policies.ClientType {
 		msg.Publisher = clientID
 	}
@@ -208,6 +222,144 @@ func (h *handler) Disconnect(ctx context.Context) error {
 	return nil
 }
 
+// WithHeaders wraps the HTTP proxy handler so the request headers are available
+// to the session handler and propagated with the published message.
+func WithHeaders(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), headersCtxKey, r.Header)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WithClientCert wraps the HTTP proxy handler so the verified client
+// certificate is available to the session handler. The requests with the
+// certificate don't need the Authorization header, so the empty client key is
+// set for the proxy to accept them.
+func WithClientCert(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := certauth.NewContext(r.Context(), *r.TLS.PeerCertificates[0])
+		r = r.WithContext(ctx)
+		if _, _, ok := r.BasicAuth(); !ok && r.Header.Get("Authorization") == "" {
+			r.Header.Set("Authorization", apiutil.ClientPrefix)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WithSource wraps the HTTP proxy handler so the address of the client is
+// available to the clients service, which limits the failed authentication
+// attempts per source. The X-Real-IP header is honoured only if the request
+// comes from one of the trusted proxies. The requests forwarded by a proxy
+// while no trusted proxies are configured are passed without the source, so
+// the clients behind the proxy don't share the proxy address and lock each
+// other out.
+func WithSource(proxies lockout.TrustedProxies, logger *slog.Logger, next http.Handler) http.Handler {
+	var warn sync.Once
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if proxies.Untrusted(r) {
+			warn.Do(func() {
+				logger.Warn("Received request forwarded by a reverse proxy without trusted proxies configured, failed authentication attempts are not limited per source")
+			})
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := lockout.WithSource(r.Context(), proxies.RequestSource(r))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WithLastValue wraps the HTTP proxy handler so the GET requests are served by
+// the target handler directly. The proxy publishes every request it handles,
+// while GET requests only read the last values of the channel topics.
+func WithLastValue(proxy, target http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			target.ServeHTTP(w, r)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	})
+}
+
+func messageHeaders(ctx context.Context) map[string]string {
+	hdr, ok := ctx.Value(headersCtxKey).(http.Header)
+	if !ok {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	if ct := hdr.Get("Content-Type"); ct != "" {
+		headers[messaging.ContentTypeHeader] = ct
+	}
+	for key, vals := range hdr {
+		name, ok := strings.CutPrefix(http.CanonicalHeaderKey(key), HeaderPrefix)
+		if !ok || name == "" || len(vals) == 0 {
+			continue
+		}
+		headers[strings.ToLower(name)] = vals[0]
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+
+	return headers
+}
+
+// authenticate authenticates the client using the client certificate or the
+// client secret, or the user using the bearer token, and returns the
+// authenticated ID and its type. The username, if set, is the client ID used
+// to limit the failed attempts. The personal access token is accepted as the
+// bearer token if its scope allows the operation on the channel. The clients
+// which present the certificate are identified by it, and the client secret is
+// used only if the certs authenticator allows the fallback.
+func authenticate(ctx context.Context, authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, certs certauth.Authenticator, username, password, chanID string, msgType connections.ConnType) (string, string, error) {
+	if certs != nil && !strings.HasPrefix(password, apiutil.BearerPrefix) {
+		clientID, err := certs.Authenticate(ctx, certauth.FromContext(ctx))
+		if err != nil {
+			return "", "", err
+		}
+		if clientID != "" {
+			if username != "" && username != clientID {
+				return "", "", svcerr.ErrAuthentication
+			}
+			return clientID, policies.ClientType, nil
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(password, "Client"):
+		secret := strings.TrimPrefix(password, apiutil.ClientPrefix)
+		authnRes, err := clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{ClientId: username, ClientSecret: secret})
+		if err != nil {
+			return "", "", errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+		if !authnRes.Authenticated {
+			return "", "", svcerr.ErrAuthentication
+		}
+		return authnRes.GetId(), policies.ClientType, nil
+	case strings.HasPrefix(password, apiutil.BearerPrefix):
+		token := strings.TrimPrefix(password, apiutil.BearerPrefix)
+		authnSession, err := authn.Authenticate(ctx, token)
+		if err != nil {
+			return "", "", errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+		if authnSession.Type == smqauthn.PersonalAccessToken {
+			userID, err := smqauthz.AuthorizeMessagingPAT(ctx, authz, channels, authnSession, chanID, msgType)
+			if err != nil {
+				return "", "", err
+			}
+			return userID, policies.UserType, nil
+		}
+		return authnSession.DomainUserID, policies.UserType, nil
+	default:
+		return "", "", svcerr.ErrAuthentication
+	}
+}
+
 func parseTopic(topic string) (string, string, error) {
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
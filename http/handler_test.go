@@ -5,14 +5,19 @@ package http_test
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	mghttp "github.com/absmach/mgate/pkg/http"
 	"github.com/absmach/mgate/pkg/session"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	clmocks "github.com/absmach/supermq/clients/mocks"
@@ -21,9 +26,14 @@ import (
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	authzmocks "github.com/absmach/supermq/pkg/authz/mocks"
+	"github.com/absmach/supermq/pkg/certauth"
+	certauthmocks "github.com/absmach/supermq/pkg/certauth/mocks"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/absmach/supermq/pkg/schema"
+	schemamocks "github.com/absmach/supermq/pkg/schema/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -36,6 +46,7 @@ const (
 	invalidID             = "invalidID"
 	invalidValue          = "invalidValue"
 	invalidChannelIDTopic = "channels/**/messages"
+	chanSchema            = `{"json":{"type":"object"}}`
 )
 
 var (
@@ -63,18 +74,36 @@ var (
 var (
 	clients   = new(clmocks.ClientsServiceClient)
 	channels  = new(chmocks.ChannelsServiceClient)
+	schemas   = new(schemamocks.Cache)
 	authn     = new(authnmocks.Authentication)
+	authz     = new(authzmocks.Authorization)
 	publisher = new(mocks.PubSub)
+	certs     = new(certauthmocks.Authenticator)
 )
 
 func newHandler() session.Handler {
 	logger := smqlog.NewMock()
 	authn = new(authnmocks.Authentication)
+	authz = new(authzmocks.Authorization)
 	clients = new(clmocks.ClientsServiceClient)
 	channels = new(chmocks.ChannelsServiceClient)
+	schemas = new(schemamocks.Cache)
 	publisher = new(mocks.PubSub)
 
-	return mhttp.NewHandler(publisher, authn, clients, channels, logger)
+	return mhttp.NewHandler(publisher, authn, authz, clients, channels, schemas, nil, logger)
+}
+
+func newCertHandler() session.Handler {
+	logger := smqlog.NewMock()
+	authn = new(authnmocks.Authentication)
+	authz = new(authzmocks.Authorization)
+	clients = new(clmocks.ClientsServiceClient)
+	channels = new(chmocks.ChannelsServiceClient)
+	schemas = new(schemamocks.Cache)
+	publisher = new(mocks.PubSub)
+	certs = new(certauthmocks.Authenticator)
+
+	return mhttp.NewHandler(publisher, authn, authz, clients, channels, schemas, certs, logger)
 }
 
 func TestAuthConnect(t *testing.T) {
@@ -143,6 +172,7 @@ func TestPublish(t *testing.T) {
 	tokenSession := session.Session{
 		Password: []byte(apiutil.BearerPrefix + validToken),
 	}
+	patSession := smqauthn.Session{Type: smqauthn.PersonalAccessToken, PatID: validID, UserID: validID}
 	cases := []struct {
 		desc       string
 		topic      *string
@@ -154,8 +184,12 @@ func TestPublish(t *testing.T) {
 		authNRes   *grpcClientsV1.AuthnRes
 		authNRes1  smqauthn.Session
 		authNErr   error
+		patErr     error
+		entityRes  *grpcCommonV1.RetrieveEntityRes
+		entityErr  error
 		authZRes   *grpcChannelsV1.AuthzRes
 		authZErr   error
+		schemaErr  error
 		publishErr error
 		err        error
 	}{
@@ -185,6 +219,42 @@ func TestPublish(t *testing.T) {
 			authZErr:  nil,
 			err:       nil,
 		},
+		{
+			desc:      "publish with personal access token successfully",
+			topic:     &topic,
+			payload:   &payload,
+			password:  validToken,
+			session:   &tokenSession,
+			channelID: chanID,
+			authNRes1: patSession,
+			entityRes: &grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: chanID, DomainId: validID}},
+			authZRes:  &grpcChannelsV1.AuthzRes{Authorized: true},
+			err:       nil,
+		},
+		{
+			desc:      "publish with personal access token without messaging scope",
+			topic:     &topic,
+			payload:   &payload,
+			password:  validToken,
+			session:   &tokenSession,
+			channelID: chanID,
+			status:    http.StatusForbidden,
+			authNRes1: patSession,
+			patErr:    svcerr.ErrAuthorization,
+			err:       svcerr.ErrUnauthorizedPAT,
+		},
+		{
+			desc:      "publish with personal access token and failed to retrieve channel",
+			topic:     &topic,
+			payload:   &payload,
+			password:  validToken,
+			session:   &tokenSession,
+			channelID: chanID,
+			status:    http.StatusUnauthorized,
+			authNRes1: patSession,
+			entityErr: svcerr.ErrNotFound,
+			err:       svcerr.ErrAuthorization,
+		},
 		{
 			desc:      "publish  with key and subtopic successfully",
 			topic:     &subtopic,
@@ -325,6 +395,32 @@ func TestPublish(t *testing.T) {
 			publishErr: errors.New("failed to publish"),
 			err:        errFailedPublishToMsgBroker,
 		},
+		{
+			desc:      "publish with payload not conforming to channel schema",
+			topic:     &topic,
+			payload:   &payload,
+			password:  clientKey,
+			session:   &clientKeySession,
+			channelID: chanID,
+			authNRes:  &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			status:    http.StatusBadRequest,
+			authZRes:  &grpcChannelsV1.AuthzRes{Authorized: true, Schema: []byte(chanSchema)},
+			schemaErr: schema.ErrInvalidPayload,
+			err:       schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "publish to channel with invalid schema",
+			topic:     &topic,
+			payload:   &payload,
+			password:  clientKey,
+			session:   &clientKeySession,
+			channelID: chanID,
+			authNRes:  &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			status:    http.StatusInternalServerError,
+			authZRes:  &grpcChannelsV1.AuthzRes{Authorized: true, Schema: []byte(`{}`)},
+			schemaErr: schema.ErrInvalidSchema,
+			err:       schema.ErrInvalidSchema,
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -334,7 +430,10 @@ func TestPublish(t *testing.T) {
 			}
 			clientsCall := clients.On("Authenticate", ctx, &grpcClientsV1.AuthnReq{ClientSecret: tc.password}).Return(tc.authNRes, tc.authNErr)
 			authCall := authn.On("Authenticate", ctx, mock.Anything).Return(tc.authNRes1, tc.authNErr)
+			patCall := authz.On("AuthorizePAT", ctx, mock.Anything).Return(tc.patErr)
+			entityCall := channels.On("RetrieveEntity", ctx, &grpcCommonV1.RetrieveEntityReq{Id: tc.channelID}).Return(tc.entityRes, tc.entityErr)
 			channelsCall := channels.On("Authorize", ctx, mock.Anything).Return(tc.authZRes, tc.authZErr)
+			schemaCall := schemas.On("Validate", tc.channelID, tc.authZRes.GetSchema(), mock.Anything).Return(tc.schemaErr)
 			repoCall := publisher.On("Publish", ctx, tc.channelID, mock.Anything).Return(tc.publishErr)
 			err := handler.Publish(ctx, tc.topic, tc.payload)
 			hpe, ok := err.(mghttp.HTTPProxyError)
@@ -343,9 +442,144 @@ func TestPublish(t *testing.T) {
 			}
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected: %v, got: %v", tc.err, err))
 			authCall.Unset()
+			patCall.Unset()
+			entityCall.Unset()
+			schemaCall.Unset()
 			repoCall.Unset()
 			clientsCall.Unset()
 			channelsCall.Unset()
 		})
 	}
 }
+
+func TestPublishWithCert(t *testing.T) {
+	handler := newCertHandler()
+
+	cert := x509.Certificate{SerialNumber: big.NewInt(1)}
+
+	cases := []struct {
+		desc     string
+		cert     x509.Certificate
+		session  session.Session
+		certRes  string
+		certErr  error
+		authNRes *grpcClientsV1.AuthnRes
+		status   int
+		err      error
+	}{
+		{
+			desc:    "publish with valid certificate",
+			cert:    cert,
+			session: session.Session{Password: []byte(apiutil.ClientPrefix)},
+			certRes: clientID,
+			err:     nil,
+		},
+		{
+			desc:    "publish with valid certificate and client ID of another client",
+			cert:    cert,
+			session: session.Session{Username: clientID1, Password: []byte(apiutil.ClientPrefix)},
+			certRes: clientID,
+			status:  http.StatusUnauthorized,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "publish with revoked certificate",
+			cert:    cert,
+			session: session.Session{Password: []byte(apiutil.ClientPrefix)},
+			certErr: errors.Wrap(svcerr.ErrAuthentication, certauth.ErrRevokedCert),
+			status:  http.StatusUnauthorized,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:     "publish without certificate with valid key",
+			session:  session.Session{Password: []byte(apiutil.ClientPrefix + clientKey)},
+			authNRes: &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			err:      nil,
+		},
+		{
+			desc:    "publish without certificate when certificate is required",
+			session: session.Session{Password: []byte(apiutil.ClientPrefix + clientKey)},
+			certErr: errors.Wrap(svcerr.ErrAuthentication, certauth.ErrMissingCert),
+			status:  http.StatusUnauthorized,
+			err:     svcerr.ErrAuthentication,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := certauth.NewContext(context.TODO(), tc.cert)
+			ctx = session.NewContext(ctx, &tc.session)
+			certsCall := certs.On("Authenticate", ctx, tc.cert).Return(tc.certRes, tc.certErr)
+			clientsCall := clients.On("Authenticate", ctx, mock.Anything).Return(tc.authNRes, nil)
+			channelsCall := channels.On("Authorize", ctx, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+			schemaCall := schemas.On("Validate", chanID, mock.Anything, mock.Anything).Return(nil)
+			repoCall := publisher.On("Publish", ctx, chanID, mock.Anything).Return(nil)
+			err := handler.Publish(ctx, &topic, &payload)
+			hpe, ok := err.(mghttp.HTTPProxyError)
+			if ok {
+				assert.Equal(t, tc.status, hpe.StatusCode())
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("expected: %v, got: %v", tc.err, err))
+			if tc.certRes != "" {
+				clients.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+			}
+			certsCall.Unset()
+			clientsCall.Unset()
+			channelsCall.Unset()
+			schemaCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestWithClientCert(t *testing.T) {
+	cert := &x509.Certificate{SerialNumber: big.NewInt(1)}
+
+	cases := []struct {
+		desc  string
+		tls   *tls.ConnectionState
+		auth  string
+		cert  x509.Certificate
+		token string
+	}{
+		{
+			desc: "request without TLS",
+		},
+		{
+			desc: "request without client certificate",
+			tls:  &tls.ConnectionState{},
+		},
+		{
+			desc:  "request with client certificate",
+			tls:   &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+			cert:  *cert,
+			token: apiutil.ClientPrefix,
+		},
+		{
+			desc:  "request with client certificate and client key",
+			tls:   &tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}},
+			auth:  apiutil.ClientPrefix + clientKey,
+			cert:  *cert,
+			token: apiutil.ClientPrefix + clientKey,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var (
+				cert  x509.Certificate
+				token string
+			)
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				cert = certauth.FromContext(r.Context())
+				token = r.Header.Get("Authorization")
+			})
+			req := httptest.NewRequest(http.MethodPost, "/"+topic, nil)
+			req.TLS = tc.tls
+			if tc.auth != "" {
+				req.Header.Set("Authorization", tc.auth)
+			}
+			mhttp.WithClientCert(next).ServeHTTP(httptest.NewRecorder(), req)
+			assert.Equal(t, tc.cert, cert)
+			assert.Equal(t, tc.token, token)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/certauth"
+	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lastvalue"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+// LastValueService retrieves the last messages published on the channel topics.
+//
+//go:generate mockery --name LastValueService --output=./mocks --filename lastvalue.go --quiet --note "Copyright (c) Abstract Machines"
+type LastValueService interface {
+	// LastValue returns the last message published on the channel with
+	// specified id and subtopic. Token is the value of the Authorization
+	// header, i.e. the client secret, the user bearer token or the personal
+	// access token, and it's used to authorize subscriber.
+	LastValue(ctx context.Context, token, chanID, subtopic string) (*messaging.Message, error)
+}
+
+var _ LastValueService = (*lastValueService)(nil)
+
+type lastValueService struct {
+	store    lastvalue.Store
+	authn    smqauthn.Authentication
+	authz    smqauthz.Authorization
+	clients  grpcClientsV1.ClientsServiceClient
+	channels grpcChannelsV1.ChannelsServiceClient
+	certs    certauth.Authenticator
+}
+
+// NewLastValueService creates new last value service.
+func NewLastValueService(store lastvalue.Store, authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, certs certauth.Authenticator) LastValueService {
+	return &lastValueService{
+		store:    store,
+		authn:    authn,
+		authz:    authz,
+		clients:  clients,
+		channels: channels,
+		certs:    certs,
+	}
+}
+
+func (svc *lastValueService) LastValue(ctx context.Context, token, chanID, subtopic string) (*messaging.Message, error) {
+	clientID, clientType, err := authenticate(ctx, svc.authn, svc.authz, svc.clients, svc.channels, svc.certs, "", token, chanID, connections.Subscribe)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := svc.channels.Authorize(ctx, &grpcChannelsV1.AuthzReq{
+		ClientId:   clientID,
+		ClientType: clientType,
+		ChannelId:  chanID,
+		Type:       uint32(connections.Subscribe),
+	})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() {
+		return nil, svcerr.ErrAuthorization
+	}
+
+	msg, err := svc.store.Retrieve(ctx, chanID, subtopic)
+	if err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return nil, errors.Wrap(svcerr.ErrNotFound, err)
+		}
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return msg, nil
+}
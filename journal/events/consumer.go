@@ -11,6 +11,7 @@ import (
 	"github.com/absmach/supermq/journal"
 	"github.com/absmach/supermq/pkg/events"
 	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/pkg/presence"
 )
 
 var ErrMissingOccurredAt = errors.New("missing occurred_at")
@@ -42,6 +43,10 @@ func Handle(service journal.Service) handleFunc {
 		if operation == "" {
 			return errors.New("missing operation")
 		}
+		// Heartbeats of the adapter instances aren't journaled.
+		if operation == presence.HeartbeatOp {
+			return nil
+		}
 
 		occurredAt, ok := data["occurred_at"].(float64)
 		if !ok {
@@ -17,6 +17,7 @@ import (
 	aevents "github.com/absmach/supermq/journal/events"
 	"github.com/absmach/supermq/journal/mocks"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/presence"
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -238,6 +239,16 @@ func TestHandle(t *testing.T) {
 			},
 			err: nil,
 		},
+		{
+			desc: "with heartbeat operation",
+			event: map[string]interface{}{
+				"operation":   presence.HeartbeatOp,
+				"occurred_at": float64(time.Now().UnixNano()),
+				"instance":    testsutil.GenerateUUID(t),
+			},
+			repoErr: repoerr.ErrCreateEntity,
+			err:     nil,
+		},
 		{
 			desc: "success",
 			event: map[string]interface{}{
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/absmach/supermq/pkg/messaging"
 )
@@ -47,6 +48,11 @@ func handle(ctx context.Context, pub messaging.Publisher, logger *slog.Logger) h
 		if msg.GetProtocol() == protocol {
 			return nil
 		}
+		// The messages which expired before they are forwarded are dropped,
+		// and the broker drops the rest once the expiry interval elapses.
+		if messaging.Expired(msg, time.Now()) {
+			return nil
+		}
 		// Use concatenation instead of fmt.Sprintf for the
 		// sake of simplicity and performance.
 		topic := "channels/" + msg.GetChannel() + "/messages"
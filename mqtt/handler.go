@@ -16,16 +16,28 @@ import (
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	"github.com/absmach/supermq/mqtt/events"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/certauth"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/presence"
+	"github.com/absmach/supermq/pkg/schema"
 )
 
 var _ session.Handler = (*handler)(nil)
 
-const protocol = "mqtt"
+const (
+	protocol = "mqtt"
+
+	// patPrefix is the prefix of the personal access token, which may be used
+	// as the password instead of the client secret. Client secrets can't
+	// start with it, so the password is never ambiguous.
+	patPrefix = "pat_"
+)
 
 // Log message formats.
 const (
@@ -63,20 +75,30 @@ var (
 // Event implements events.Event interface.
 type handler struct {
 	publisher messaging.Publisher
+	authn     smqauthn.Authentication
+	authz     smqauthz.Authorization
 	clients   grpcClientsV1.ClientsServiceClient
 	channels  grpcChannelsV1.ChannelsServiceClient
+	schemas   schema.Cache
+	certs     certauth.Authenticator
 	logger    *slog.Logger
 	es        events.EventStore
 }
 
-// NewHandler creates new Handler entity.
-func NewHandler(publisher messaging.Publisher, es events.EventStore, logger *slog.Logger, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) session.Handler {
+// NewHandler creates new Handler entity. The clients are authenticated using
+// the client certificates if the certs authenticator is set, and using the
+// client secrets otherwise.
+func NewHandler(publisher messaging.Publisher, es events.EventStore, logger *slog.Logger, authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, schemas schema.Cache, certs certauth.Authenticator) session.Handler {
 	return &handler{
 		es:        es,
 		logger:    logger,
 		publisher: publisher,
+		authn:     authn,
+		authz:     authz,
 		clients:   clients,
 		channels:  channels,
+		schemas:   schemas,
+		certs:     certs,
 	}
 }
 
@@ -94,7 +116,38 @@ func (h *handler) AuthConnect(ctx context.Context) error {
 
 	pwd := string(s.Password)
 
-	res, err := h.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{ClientSecret: pwd})
+	// Users authenticate with the personal access token, and the username,
+	// if set, must be the ID of the token owner.
+	if strings.HasPrefix(pwd, patPrefix) {
+		authnSession, err := h.authn.Authenticate(ctx, pwd)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+		if s.Username != "" && authnSession.UserID != s.Username {
+			return errInvalidUserId
+		}
+		return nil
+	}
+
+	// Clients which present the certificate are identified by it, and the
+	// username, if set, must be the ID of the client. The session username
+	// is set to the client ID, since it identifies the client on access and
+	// in the session events.
+	if h.certs != nil {
+		clientID, err := h.certs.Authenticate(ctx, s.Cert)
+		if err != nil {
+			return err
+		}
+		if clientID != "" {
+			if s.Username != "" && clientID != s.Username {
+				return errInvalidUserId
+			}
+			h.connected(ctx, s, clientID)
+			return nil
+		}
+	}
+
+	res, err := h.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{ClientId: s.Username, ClientSecret: pwd})
 	if err != nil {
 		return errors.Wrap(svcerr.ErrAuthentication, err)
 	}
@@ -106,15 +159,22 @@ func (h *handler) AuthConnect(ctx context.Context) error {
 		return errInvalidUserId
 	}
 
-	if err := h.es.Connect(ctx, pwd); err != nil {
-		h.logger.Error(errors.Wrap(ErrFailedPublishConnectEvent, err).Error())
-	}
+	h.connected(ctx, s, res.GetId())
 
 	return nil
 }
 
+func (h *handler) connected(ctx context.Context, s *session.Session, clientID string) {
+	s.Username = clientID
+	if err := h.es.Connect(ctx, presenceSession(s)); err != nil {
+		h.logger.Error(errors.Wrap(ErrFailedPublishConnectEvent, err).Error())
+	}
+}
+
 // AuthPublish is called on device publish,
-// prior forwarding to the MQTT broker.
+// prior forwarding to the MQTT broker. Payloads which don't
+// conform to the channel message schema are rejected, which
+// results in the MQTT 3.1.1 client being disconnected.
 func (h *handler) AuthPublish(ctx context.Context, topic *string, payload *[]byte) error {
 	if topic == nil {
 		return ErrMissingTopicPub
@@ -124,7 +184,18 @@ func (h *handler) AuthPublish(ctx context.Context, topic *string, payload *[]byt
 		return ErrClientNotInitialized
 	}
 
-	return h.authAccess(ctx, string(s.Username), *topic, connections.Publish)
+	res, err := h.authAccess(ctx, s, *topic, connections.Publish)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if payload != nil {
+		data = *payload
+	}
+	chanID := channelRegExp.FindStringSubmatch(*topic)[1]
+
+	return h.schemas.Validate(chanID, res.GetSchema(), data)
 }
 
 // AuthSubscribe is called on device subscribe,
@@ -139,7 +210,7 @@ func (h *handler) AuthSubscribe(ctx context.Context, topics *[]string) error {
 	}
 
 	for _, topic := range *topics {
-		if err := h.authAccess(ctx, string(s.Username), topic, connections.Subscribe); err != nil {
+		if _, err := h.authAccess(ctx, s, topic, connections.Subscribe); err != nil {
 			return err
 		}
 	}
@@ -181,12 +252,16 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 	}
 
 	msg := messaging.Message{
-		Protocol:  protocol,
-		Channel:   chanID,
-		Subtopic:  subtopic,
-		Publisher: s.Username,
-		Payload:   *payload,
-		Created:   time.Now().UnixNano(),
+		Protocol: protocol,
+		Channel:  chanID,
+		Subtopic: subtopic,
+		Payload:  *payload,
+		Created:  time.Now().UnixNano(),
+		Headers:  messageHeaders(ctx),
+	}
+
+	if !strings.HasPrefix(string(s.Password), patPrefix) {
+		msg.Publisher = s.Username
 	}
 
 	if err := h.publisher.Publish(ctx, msg.GetChannel(), &msg); err != nil {
@@ -222,42 +297,70 @@ func (h *handler) Disconnect(ctx context.Context) error {
 	if !ok {
 		return errors.Wrap(ErrFailedDisconnect, ErrClientNotInitialized)
 	}
-	h.logger.Error(fmt.Sprintf(LogInfoDisconnected, s.ID, s.Password))
-	if err := h.es.Disconnect(ctx, string(s.Password)); err != nil {
+	h.logger.Info(fmt.Sprintf(LogInfoDisconnected, s.ID, s.Username))
+	if strings.HasPrefix(string(s.Password), patPrefix) {
+		return nil
+	}
+	if err := h.es.Disconnect(ctx, presenceSession(s)); err != nil {
 		return errors.Wrap(ErrFailedPublishDisconnectEvent, err)
 	}
 	return nil
 }
 
-func (h *handler) authAccess(ctx context.Context, clientID, topic string, msgType connections.ConnType) error {
+// presenceSession returns the presence session of the authenticated client.
+// The MQTT client ID identifies the session, since the broker keeps at most
+// one session per MQTT client ID.
+func presenceSession(s *session.Session) presence.Session {
+	return presence.Session{
+		ID:       s.ID,
+		ClientID: s.Username,
+		Protocol: presence.MQTT,
+	}
+}
+
+func (h *handler) authAccess(ctx context.Context, s *session.Session, topic string, msgType connections.ConnType) (*grpcChannelsV1.AuthzRes, error) {
 	// Topics are in the format:
 	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
 	if !channelRegExp.MatchString(topic) {
-		return ErrMalformedTopic
+		return nil, ErrMalformedTopic
 	}
 
 	channelParts := channelRegExp.FindStringSubmatch(topic)
 	if len(channelParts) < 1 {
-		return ErrMalformedTopic
+		return nil, ErrMalformedTopic
 	}
 
 	chanID := channelParts[1]
 
+	clientID, clientType := s.Username, policies.ClientType
+	if pwd := string(s.Password); strings.HasPrefix(pwd, patPrefix) {
+		// The token is checked on each access, so revoked or expired token
+		// is rejected during the connection.
+		authnSession, err := h.authn.Authenticate(ctx, pwd)
+		if err != nil {
+			return nil, errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+		if clientID, err = smqauthz.AuthorizeMessagingPAT(ctx, h.authz, h.channels, authnSession, chanID, msgType); err != nil {
+			return nil, err
+		}
+		clientType = policies.UserType
+	}
+
 	ar := &grpcChannelsV1.AuthzReq{
 		Type:       uint32(msgType),
 		ClientId:   clientID,
-		ClientType: policies.ClientType,
+		ClientType: clientType,
 		ChannelId:  chanID,
 	}
 	res, err := h.channels.Authorize(ctx, ar)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !res.GetAuthorized() {
-		return svcerr.ErrAuthorization
+		return nil, svcerr.ErrAuthorization
 	}
 
-	return nil
+	return res, nil
 }
 
 func parseSubtopic(subtopic string) (string, error) {
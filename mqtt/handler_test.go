@@ -6,23 +6,36 @@ package mqtt_test
 import (
 	"bytes"
 	"context"
+	"crypto/x509"
 	"fmt"
 	"log"
+	"math/big"
 	"testing"
 
 	"github.com/absmach/mgate/pkg/session"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	smqauth "github.com/absmach/supermq/auth"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	climocks "github.com/absmach/supermq/clients/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
 	smqlog "github.com/absmach/supermq/logger"
 	"github.com/absmach/supermq/mqtt"
 	"github.com/absmach/supermq/mqtt/mocks"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	authzmocks "github.com/absmach/supermq/pkg/authz/mocks"
+	"github.com/absmach/supermq/pkg/certauth"
+	certauthmocks "github.com/absmach/supermq/pkg/certauth/mocks"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/presence"
+	"github.com/absmach/supermq/pkg/schema"
+	schemamocks "github.com/absmach/supermq/pkg/schema/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -37,6 +50,8 @@ const (
 	clientID1             = "clientID1"
 	subtopic              = "testSubtopic"
 	invalidChannelIDTopic = "channels/**/messages"
+	patToken              = "pat_token"
+	chanSchema            = `{"json":{"type":"object"}}`
 )
 
 var (
@@ -68,9 +83,13 @@ var (
 )
 
 var (
+	authn      = new(authnmocks.Authentication)
+	authz      = new(authzmocks.Authorization)
 	clients    = new(climocks.ClientsServiceClient)
 	channels   = new(chmocks.ChannelsServiceClient)
+	schemas    = new(schemamocks.Cache)
 	eventStore = new(mocks.EventStore)
+	certs      = new(certauthmocks.Authenticator)
 )
 
 func TestAuthConnect(t *testing.T) {
@@ -141,13 +160,17 @@ func TestAuthConnect(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			ctx := context.TODO()
-			password := ""
+			username, password := "", ""
+			ps := presence.Session{Protocol: presence.MQTT}
 			if tc.session != nil {
 				ctx = session.NewContext(ctx, tc.session)
+				username = tc.session.Username
 				password = string(tc.session.Password)
+				ps.ID = tc.session.ID
+				ps.ClientID = tc.authNRes.GetId()
 			}
-			clientsCall := clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{ClientSecret: password}).Return(tc.authNRes, tc.authNErr)
-			svcCall := eventStore.On("Connect", mock.Anything, password).Return(tc.err)
+			clientsCall := clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{ClientId: username, ClientSecret: password}).Return(tc.authNRes, tc.authNErr)
+			svcCall := eventStore.On("Connect", mock.Anything, ps).Return(tc.err)
 			err := handler.AuthConnect(ctx)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			svcCall.Unset()
@@ -156,17 +179,152 @@ func TestAuthConnect(t *testing.T) {
 	}
 }
 
-func TestAuthPublish(t *testing.T) {
+func TestAuthConnectWithPAT(t *testing.T) {
 	handler := newHandler()
 
+	userID := testsutil.GenerateUUID(t)
+
 	cases := []struct {
 		desc     string
-		session  *session.Session
+		username string
+		authNRes smqauthn.Session
+		authNErr error
 		err      error
-		topic    *string
-		payload  []byte
-		authZRes *grpcChannelsV1.AuthzRes
-		authZErr error
+	}{
+		{
+			desc:     "connect with personal access token",
+			username: userID,
+			authNRes: smqauthn.Session{Type: smqauthn.PersonalAccessToken, UserID: userID, PatID: testsutil.GenerateUUID(t)},
+			err:      nil,
+		},
+		{
+			desc:     "connect with personal access token without username",
+			authNRes: smqauthn.Session{Type: smqauthn.PersonalAccessToken, UserID: userID, PatID: testsutil.GenerateUUID(t)},
+			err:      nil,
+		},
+		{
+			desc:     "connect with personal access token and username of another user",
+			username: testsutil.GenerateUUID(t),
+			authNRes: smqauthn.Session{Type: smqauthn.PersonalAccessToken, UserID: userID, PatID: testsutil.GenerateUUID(t)},
+			err:      errInvalidUserId,
+		},
+		{
+			desc:     "connect with invalid personal access token",
+			username: userID,
+			authNErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := session.NewContext(context.TODO(), &session.Session{
+				ID:       clientID,
+				Username: tc.username,
+				Password: []byte(patToken),
+			})
+			authnCall := authn.On("Authenticate", mock.Anything, patToken).Return(tc.authNRes, tc.authNErr)
+			err := handler.AuthConnect(ctx)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			clients.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+			eventStore.AssertNotCalled(t, "Connect", mock.Anything, mock.Anything)
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestAuthConnectWithCert(t *testing.T) {
+	handler := newCertHandler()
+
+	cert := x509.Certificate{SerialNumber: big.NewInt(1)}
+
+	cases := []struct {
+		desc        string
+		session     *session.Session
+		certRes     string
+		certErr     error
+		authNRes    *grpcClientsV1.AuthnRes
+		username    string
+		err         error
+		secretCheck bool
+	}{
+		{
+			desc:     "connect with valid certificate",
+			session:  &session.Session{ID: clientID, Username: clientID, Cert: cert},
+			certRes:  clientID,
+			username: clientID,
+			err:      nil,
+		},
+		{
+			desc:     "connect with valid certificate without username",
+			session:  &session.Session{ID: clientID, Cert: cert},
+			certRes:  clientID,
+			username: clientID,
+			err:      nil,
+		},
+		{
+			desc:     "connect with valid certificate and username of another client",
+			session:  &session.Session{ID: clientID, Username: clientID1, Cert: cert},
+			certRes:  clientID,
+			username: clientID1,
+			err:      errInvalidUserId,
+		},
+		{
+			desc:     "connect with revoked certificate",
+			session:  &session.Session{ID: clientID, Username: clientID, Cert: cert},
+			certErr:  errors.Wrap(svcerr.ErrAuthentication, certauth.ErrRevokedCert),
+			username: clientID,
+			err:      certauth.ErrRevokedCert,
+		},
+		{
+			desc:     "connect without certificate with valid password",
+			session:  &session.Session{ID: clientID, Username: clientID, Password: []byte(password)},
+			authNRes: &grpcClientsV1.AuthnRes{Authenticated: true, Id: clientID},
+			username: clientID,
+			err:      nil,
+
+			secretCheck: true,
+		},
+		{
+			desc:     "connect without certificate with invalid password",
+			session:  &session.Session{ID: clientID, Username: clientID, Password: []byte("invalid")},
+			authNRes: &grpcClientsV1.AuthnRes{Authenticated: false},
+			username: clientID,
+			err:      svcerr.ErrAuthentication,
+
+			secretCheck: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := session.NewContext(context.TODO(), tc.session)
+			certsCall := certs.On("Authenticate", mock.Anything, tc.session.Cert).Return(tc.certRes, tc.certErr)
+			clientsCall := clients.On("Authenticate", mock.Anything, mock.Anything).Return(tc.authNRes, nil)
+			svcCall := eventStore.On("Connect", mock.Anything, mock.Anything).Return(nil)
+			err := handler.AuthConnect(ctx)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.username, tc.session.Username, fmt.Sprintf("%s: expected username %s got %s\n", tc.desc, tc.username, tc.session.Username))
+			if !tc.secretCheck {
+				clients.AssertNotCalled(t, "Authenticate", mock.Anything, mock.Anything)
+			}
+			certsCall.Unset()
+			clientsCall.Unset()
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestAuthPublish(t *testing.T) {
+	handler := newHandler()
+
+	cases := []struct {
+		desc      string
+		session   *session.Session
+		err       error
+		topic     *string
+		payload   []byte
+		authZRes  *grpcChannelsV1.AuthzRes
+		authZErr  error
+		schemaErr error
 	}{
 		{
 			desc:     "publish successfully",
@@ -206,6 +364,24 @@ func TestAuthPublish(t *testing.T) {
 			authZRes: &grpcChannelsV1.AuthzRes{Authorized: false},
 			authZErr: svcerr.ErrAuthorization,
 		},
+		{
+			desc:      "publish with payload not conforming to channel schema",
+			session:   &sessionClient,
+			err:       schema.ErrInvalidPayload,
+			topic:     &topic,
+			payload:   payload,
+			authZRes:  &grpcChannelsV1.AuthzRes{Authorized: true, Schema: []byte(chanSchema)},
+			schemaErr: schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "publish to channel with invalid schema",
+			session:   &sessionClient,
+			err:       schema.ErrInvalidSchema,
+			topic:     &topic,
+			payload:   payload,
+			authZRes:  &grpcChannelsV1.AuthzRes{Authorized: true, Schema: []byte(`{}`)},
+			schemaErr: schema.ErrInvalidSchema,
+		},
 	}
 
 	for _, tc := range cases {
@@ -220,9 +396,11 @@ func TestAuthPublish(t *testing.T) {
 				ClientType: policies.ClientType,
 				Type:       uint32(connections.Publish),
 			}).Return(tc.authZRes, tc.authZErr)
+			schemaCall := schemas.On("Validate", chanID, tc.authZRes.GetSchema(), tc.payload).Return(tc.schemaErr)
 			err := handler.AuthPublish(ctx, tc.topic, &tc.payload)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 			channelsCall.Unset()
+			schemaCall.Unset()
 		})
 	}
 }
@@ -302,6 +480,112 @@ func TestAuthSubscribe(t *testing.T) {
 	}
 }
 
+func TestAuthAccessWithPAT(t *testing.T) {
+	handler := newHandler()
+
+	userID := testsutil.GenerateUUID(t)
+	patID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	patSession := session.Session{
+		ID:       clientID,
+		Username: userID,
+		Password: []byte(patToken),
+	}
+
+	cases := []struct {
+		desc      string
+		msgType   connections.ConnType
+		authNErr  error
+		patErr    error
+		entityErr error
+		authZRes  *grpcChannelsV1.AuthzRes
+		err       error
+	}{
+		{
+			desc:     "publish with personal access token",
+			msgType:  connections.Publish,
+			authZRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			err:      nil,
+		},
+		{
+			desc:     "subscribe with personal access token",
+			msgType:  connections.Subscribe,
+			authZRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			err:      nil,
+		},
+		{
+			desc:     "publish with revoked personal access token",
+			msgType:  connections.Publish,
+			authNErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "publish with personal access token without publish scope",
+			msgType: connections.Publish,
+			patErr:  svcerr.ErrAuthorization,
+			err:     svcerr.ErrUnauthorizedPAT,
+		},
+		{
+			desc:    "subscribe with personal access token without subscribe scope",
+			msgType: connections.Subscribe,
+			patErr:  svcerr.ErrAuthorization,
+			err:     svcerr.ErrUnauthorizedPAT,
+		},
+		{
+			desc:      "publish to non-existing channel with personal access token",
+			msgType:   connections.Publish,
+			entityErr: svcerr.ErrNotFound,
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "publish with personal access token of user without access to the channel",
+			msgType:  connections.Publish,
+			authZRes: &grpcChannelsV1.AuthzRes{Authorized: false},
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := session.NewContext(context.TODO(), &patSession)
+			op := smqauth.PublishOp
+			if tc.msgType == connections.Subscribe {
+				op = smqauth.SubscribeOp
+			}
+			authnCall := authn.On("Authenticate", mock.Anything, patToken).Return(smqauthn.Session{Type: smqauthn.PersonalAccessToken, UserID: userID, PatID: patID}, tc.authNErr)
+			patCall := authz.On("AuthorizePAT", mock.Anything, smqauthz.PatReq{
+				UserID:                   userID,
+				PatID:                    patID,
+				PlatformEntityType:       smqauth.PlatformMesagingScope,
+				OptionalDomainEntityType: smqauth.DomainNullScope,
+				Operation:                op,
+				EntityIDs:                []string{chanID},
+			}).Return(tc.patErr)
+			entityCall := channels.On("RetrieveEntity", mock.Anything, &grpcCommonV1.RetrieveEntityReq{Id: chanID}).Return(&grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: chanID, DomainId: domainID}}, tc.entityErr)
+			channelsCall := channels.On("Authorize", mock.Anything, &grpcChannelsV1.AuthzReq{
+				ChannelId:  chanID,
+				ClientId:   policies.EncodeDomainUserID(domainID, userID),
+				ClientType: policies.UserType,
+				Type:       uint32(tc.msgType),
+			}).Return(tc.authZRes, nil)
+			schemaCall := schemas.On("Validate", chanID, mock.Anything, payload).Return(nil)
+			var err error
+			switch tc.msgType {
+			case connections.Publish:
+				err = handler.AuthPublish(ctx, &topic, &payload)
+			default:
+				err = handler.AuthSubscribe(ctx, &topics)
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			authnCall.Unset()
+			patCall.Unset()
+			entityCall.Unset()
+			channelsCall.Unset()
+			schemaCall.Unset()
+		})
+	}
+}
+
 func TestConnect(t *testing.T) {
 	handler := newHandler()
 	logBuffer.Reset()
@@ -514,12 +798,13 @@ func TestDisconnect(t *testing.T) {
 
 	for _, tc := range cases {
 		ctx := context.TODO()
-		password := ""
+		ps := presence.Session{Protocol: presence.MQTT}
 		if tc.session != nil {
 			ctx = session.NewContext(ctx, tc.session)
-			password = string(tc.session.Password)
+			ps.ID = tc.session.ID
+			ps.ClientID = tc.session.Username
 		}
-		svcCall := eventStore.On("Disconnect", mock.Anything, password).Return(tc.err)
+		svcCall := eventStore.On("Disconnect", mock.Anything, ps).Return(tc.err)
 		err := handler.Disconnect(ctx)
 		assert.Contains(t, logBuffer.String(), tc.logMsg)
 		assert.Equal(t, tc.err, err)
@@ -532,8 +817,26 @@ func newHandler() session.Handler {
 	if err != nil {
 		log.Fatalf("failed to create logger: %s", err)
 	}
+	authn = new(authnmocks.Authentication)
+	authz = new(authzmocks.Authorization)
+	clients = new(climocks.ClientsServiceClient)
+	channels = new(chmocks.ChannelsServiceClient)
+	schemas = new(schemamocks.Cache)
+	eventStore = new(mocks.EventStore)
+	return mqtt.NewHandler(mocks.NewPublisher(), eventStore, logger, authn, authz, clients, channels, schemas, nil)
+}
+
+func newCertHandler() session.Handler {
+	logger, err := smqlog.New(&logBuffer, "debug")
+	if err != nil {
+		log.Fatalf("failed to create logger: %s", err)
+	}
+	authn = new(authnmocks.Authentication)
+	authz = new(authzmocks.Authorization)
 	clients = new(climocks.ClientsServiceClient)
 	channels = new(chmocks.ChannelsServiceClient)
+	schemas = new(schemamocks.Cache)
 	eventStore = new(mocks.EventStore)
-	return mqtt.NewHandler(mocks.NewPublisher(), eventStore, logger, clients, channels)
+	certs = new(certauthmocks.Authenticator)
+	return mqtt.NewHandler(mocks.NewPublisher(), eventStore, logger, authn, authz, clients, channels, schemas, certs)
 }
@@ -8,6 +8,8 @@ import (
 	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	presence "github.com/absmach/supermq/pkg/presence"
 )
 
 // EventStore is an autogenerated mock type for the EventStore type
@@ -15,17 +17,17 @@ type EventStore struct {
 	mock.Mock
 }
 
-// Connect provides a mock function with given fields: ctx, clientID
-func (_m *EventStore) Connect(ctx context.Context, clientID string) error {
-	ret := _m.Called(ctx, clientID)
+// Connect provides a mock function with given fields: ctx, session
+func (_m *EventStore) Connect(ctx context.Context, session presence.Session) error {
+	ret := _m.Called(ctx, session)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Connect")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, clientID)
+	if rf, ok := ret.Get(0).(func(context.Context, presence.Session) error); ok {
+		r0 = rf(ctx, session)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -33,17 +35,17 @@ func (_m *EventStore) Connect(ctx context.Context, clientID string) error {
 	return r0
 }
 
-// Disconnect provides a mock function with given fields: ctx, clientID
-func (_m *EventStore) Disconnect(ctx context.Context, clientID string) error {
-	ret := _m.Called(ctx, clientID)
+// Disconnect provides a mock function with given fields: ctx, session
+func (_m *EventStore) Disconnect(ctx context.Context, session presence.Session) error {
+	ret := _m.Called(ctx, session)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Disconnect")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, clientID)
+	if rf, ok := ret.Get(0).(func(context.Context, presence.Session) error); ok {
+		r0 = rf(ctx, session)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,118 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+
+	"github.com/absmach/mgate"
+	"github.com/absmach/mgate/pkg/session"
+	mptls "github.com/absmach/mgate/pkg/tls"
+	"github.com/absmach/supermq/pkg/lockout"
+	"golang.org/x/sync/errgroup"
+)
+
+// Proxy is the MQTT proxy. Unlike the mGate MQTT proxy, it passes the remote
+// address of the client connection to the session handler, so the failed
+// authentication attempts are limited per source, and it supports MQTT 5.
+type Proxy struct {
+	config      mgate.Config
+	handler     session.Handler
+	interceptor session.Interceptor
+	proxies     lockout.TrustedProxies
+	logger      *slog.Logger
+	dialer      net.Dialer
+}
+
+// NewProxy returns a new MQTT proxy. The connections of the trusted proxies
+// are passed without the source, since they don't carry the client address.
+func NewProxy(config mgate.Config, handler session.Handler, interceptor session.Interceptor, proxies lockout.TrustedProxies, logger *slog.Logger) *Proxy {
+	return &Proxy{
+		config:      config,
+		handler:     handler,
+		interceptor: interceptor,
+		proxies:     proxies,
+		logger:      logger,
+	}
+}
+
+// Listen accepts the client connections until the context is canceled.
+func (p *Proxy) Listen(ctx context.Context) error {
+	l, err := net.Listen("tcp", p.config.Address)
+	if err != nil {
+		return err
+	}
+
+	if p.config.TLSConfig != nil {
+		l = tls.NewListener(l, p.config.TLSConfig)
+	}
+	status := mptls.SecurityStatus(p.config.TLSConfig)
+	p.logger.Info(fmt.Sprintf("MQTT proxy server started at %s with %s", p.config.Address, status))
+	g, ctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		p.accept(ctx, l)
+		return nil
+	})
+
+	g.Go(func() error {
+		<-ctx.Done()
+		return l.Close()
+	})
+	if err := g.Wait(); err != nil {
+		p.logger.Info(fmt.Sprintf("MQTT proxy server at %s with %s exiting with errors", p.config.Address, status), slog.String("error", err.Error()))
+	} else {
+		p.logger.Info(fmt.Sprintf("MQTT proxy server at %s with %s exiting...", p.config.Address, status))
+	}
+
+	return nil
+}
+
+func (p *Proxy) accept(ctx context.Context, l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			p.logger.Warn("Accept error " + err.Error())
+			continue
+		}
+		go p.handle(ctx, conn)
+	}
+}
+
+func (p *Proxy) handle(ctx context.Context, inbound net.Conn) {
+	defer p.close(inbound)
+	outbound, err := p.dialer.DialContext(ctx, "tcp", p.config.Target)
+	if err != nil {
+		p.logger.Error("Cannot connect to remote broker " + p.config.Target + " due to: " + err.Error())
+		return
+	}
+	defer p.close(outbound)
+
+	clientCert, err := mptls.ClientCert(inbound)
+	if err != nil {
+		p.logger.Error("Failed to get client certificate: " + err.Error())
+		return
+	}
+
+	if source := p.proxies.ConnSource(inbound.RemoteAddr()); source != "" {
+		ctx = lockout.WithSource(ctx, source)
+	}
+	if err = stream(ctx, inbound, outbound, p.handler, p.interceptor, clientCert); err != io.EOF {
+		p.logger.Warn(err.Error())
+	}
+}
+
+func (p *Proxy) close(conn net.Conn) {
+	if err := conn.Close(); err != nil {
+		p.logger.Warn(fmt.Sprintf("Error closing connection %s", err.Error()))
+	}
+}
@@ -0,0 +1,332 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"bufio"
+	"context"
+	"crypto/x509"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/absmach/mgate/pkg/session"
+	smqerrors "github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mqttpubsub "github.com/absmach/supermq/pkg/messaging/mqtt"
+	"github.com/absmach/supermq/pkg/schema"
+	"github.com/eclipse/paho.golang/packets"
+)
+
+// protocolV5 is the protocol version of MQTT 5 in the CONNECT packet.
+const protocolV5 = 5
+
+var (
+	errMalformedConnect = errors.New("malformed connect packet")
+	errTopicAlias       = errors.New("invalid topic alias")
+)
+
+// headersKey is the context key of the headers of the published message.
+type headersKey struct{}
+
+// stream proxies the session between the client and the broker. MQTT 5
+// sessions are proxied by the adapter, so the message properties are passed to
+// the handler with the context and the rejected packets are acknowledged with
+// the reason code. The other sessions are proxied by mGate, and the
+// interceptor applies to them only.
+func stream(ctx context.Context, in, out net.Conn, h session.Handler, ic session.Interceptor, cert x509.Certificate) error {
+	r := bufio.NewReader(in)
+	version, err := protocolVersion(r)
+	if err != nil {
+		return err
+	}
+	// The peeked CONNECT packet is read again by the session stream.
+	in = &peekedConn{Conn: in, r: r}
+	if version != protocolV5 {
+		return session.Stream(ctx, in, out, h, ic, cert)
+	}
+
+	s := session.Session{
+		Cert: cert,
+	}
+	ctx = session.NewContext(ctx, &s)
+	// The rejected packets are acknowledged to the client while the broker
+	// delivers the messages to it, so the client writes are synchronized.
+	in = packets.NewThreadSafeConn(in)
+	errs := make(chan error, 2)
+
+	go upstream(ctx, in, out, h, errs)
+	go downstream(ctx, out, in, h, errs)
+
+	err = <-errs
+	disconnectErr := h.Disconnect(ctx)
+
+	return errors.Join(err, disconnectErr)
+}
+
+// protocolVersion returns the protocol version of the CONNECT packet without
+// consuming it. If the first packet is not CONNECT, the version is 0.
+func protocolVersion(r *bufio.Reader) (byte, error) {
+	b, err := r.Peek(1)
+	if err != nil {
+		return 0, err
+	}
+	if b[0]>>4 != packets.CONNECT {
+		return 0, nil
+	}
+
+	// The remaining length is encoded using up to 4 bytes.
+	n := 1
+	for ; ; n++ {
+		if n > 4 {
+			return 0, errMalformedConnect
+		}
+		if b, err = r.Peek(n + 1); err != nil {
+			return 0, err
+		}
+		if b[n]&0x80 == 0 {
+			break
+		}
+	}
+
+	// The fixed header is followed by the protocol name and version.
+	if b, err = r.Peek(n + 3); err != nil {
+		return 0, err
+	}
+	nameLen := int(binary.BigEndian.Uint16(b[n+1:]))
+	if b, err = r.Peek(n + 3 + nameLen + 1); err != nil {
+		return 0, err
+	}
+
+	return b[len(b)-1], nil
+}
+
+func upstream(ctx context.Context, client, broker net.Conn, h session.Handler, errs chan error) {
+	// The topic aliases are resolved, since the handler authorizes the topic.
+	aliases := make(map[uint16]string)
+	for {
+		cp, err := packets.ReadPacket(client)
+		if err != nil {
+			errs <- wrap(ctx, err, session.Up)
+			return
+		}
+
+		pctx, forward, err := authorize(ctx, cp, client, h, aliases)
+		if err != nil {
+			errs <- wrap(ctx, err, session.Up)
+			return
+		}
+		if !forward {
+			continue
+		}
+
+		if _, err := cp.WriteTo(broker); err != nil {
+			errs <- wrap(ctx, err, session.Up)
+			return
+		}
+
+		if err := notify(pctx, cp, h); err != nil {
+			errs <- wrap(ctx, err, session.Up)
+			return
+		}
+	}
+}
+
+func downstream(ctx context.Context, broker, client net.Conn, h session.Handler, errs chan error) {
+	for {
+		cp, err := packets.ReadPacket(broker)
+		if err != nil {
+			errs <- wrap(ctx, err, session.Down)
+			return
+		}
+
+		// The broker sends the subscribed messages to the client as PUBLISH
+		// packets, so the client must be allowed to subscribe to the topic.
+		if p, ok := cp.Content.(*packets.Publish); ok {
+			if err := h.AuthSubscribe(ctx, &[]string{p.Topic}); err != nil {
+				d := &packets.Disconnect{ReasonCode: packets.DisconnectNotAuthorized, Properties: &packets.Properties{}}
+				if _, wErr := d.WriteTo(client); wErr != nil {
+					err = errors.Join(err, wErr)
+				}
+				errs <- wrap(ctx, err, session.Down)
+				return
+			}
+		}
+
+		if _, err := cp.WriteTo(client); err != nil {
+			errs <- wrap(ctx, err, session.Down)
+			return
+		}
+	}
+}
+
+// authorize authorizes the packet sent by the client. It returns the context
+// of the packet and whether the packet is forwarded to the broker. The packets
+// rejected by the handler are acknowledged with the reason code, and the error
+// is returned only if the client is disconnected.
+func authorize(ctx context.Context, cp *packets.ControlPacket, client net.Conn, h session.Handler, aliases map[uint16]string) (context.Context, bool, error) {
+	switch p := cp.Content.(type) {
+	case *packets.Connect:
+		s, _ := session.FromContext(ctx)
+		s.ID = p.ClientID
+		s.Username = p.Username
+		s.Password = p.Password
+		if err := h.AuthConnect(ctx); err != nil {
+			ack := &packets.Connack{ReasonCode: reasonCode(err, packets.CONNECT), Properties: &packets.Properties{}}
+			return ctx, false, write(client, ack, err)
+		}
+		// Copy back to the packet in case the values are changed by the handler.
+		p.ClientID = s.ID
+		p.Username = s.Username
+		p.Password = s.Password
+		// The broker must send the topic of each message, since the topic is
+		// authorized before the message is delivered.
+		p.Properties.TopicAliasMaximum = nil
+
+		return ctx, true, nil
+	case *packets.Publish:
+		if alias := p.Properties.TopicAlias; alias != nil {
+			switch p.Topic {
+			case "":
+				topic, ok := aliases[*alias]
+				if !ok {
+					d := &packets.Disconnect{ReasonCode: packets.DisconnectTopicAliasInvalid, Properties: &packets.Properties{}}
+					return ctx, false, write(client, d, errTopicAlias)
+				}
+				p.Topic = topic
+			default:
+				aliases[*alias] = p.Topic
+			}
+			p.Properties.TopicAlias = nil
+		}
+
+		headers, err := mqttpubsub.Headers(p.Properties)
+		if err == nil {
+			ctx = context.WithValue(ctx, headersKey{}, headers)
+			err = h.AuthPublish(ctx, &p.Topic, &p.Payload)
+		}
+		if err == nil {
+			return ctx, true, nil
+		}
+
+		code := reasonCode(err, packets.PUBLISH)
+		switch p.QoS {
+		case 1:
+			return ctx, false, write(client, &packets.Puback{PacketID: p.PacketID, ReasonCode: code, Properties: &packets.Properties{}}, nil)
+		case 2:
+			return ctx, false, write(client, &packets.Pubrec{PacketID: p.PacketID, ReasonCode: code, Properties: &packets.Properties{}}, nil)
+		default:
+			// QoS 0 messages are not acknowledged, so the client is
+			// disconnected with the reason code.
+			return ctx, false, write(client, &packets.Disconnect{ReasonCode: code, Properties: &packets.Properties{}}, err)
+		}
+	case *packets.Subscribe:
+		topics := make([]string, len(p.Subscriptions))
+		for i, sub := range p.Subscriptions {
+			topics[i] = sub.Topic
+		}
+		if err := h.AuthSubscribe(ctx, &topics); err != nil {
+			reasons := make([]byte, len(p.Subscriptions))
+			for i := range reasons {
+				reasons[i] = reasonCode(err, packets.SUBSCRIBE)
+			}
+			return ctx, false, write(client, &packets.Suback{PacketID: p.PacketID, Reasons: reasons, Properties: &packets.Properties{}}, nil)
+		}
+		for i := range p.Subscriptions {
+			p.Subscriptions[i].Topic = topics[i]
+		}
+
+		return ctx, true, nil
+	default:
+		return ctx, true, nil
+	}
+}
+
+func notify(ctx context.Context, cp *packets.ControlPacket, h session.Handler) error {
+	switch p := cp.Content.(type) {
+	case *packets.Connect:
+		return h.Connect(ctx)
+	case *packets.Publish:
+		return h.Publish(ctx, &p.Topic, &p.Payload)
+	case *packets.Subscribe:
+		topics := make([]string, len(p.Subscriptions))
+		for i, sub := range p.Subscriptions {
+			topics[i] = sub.Topic
+		}
+		return h.Subscribe(ctx, &topics)
+	case *packets.Unsubscribe:
+		return h.Unsubscribe(ctx, &p.Topics)
+	default:
+		return nil
+	}
+}
+
+// write writes the packet to the client and returns the error the packet
+// responds to, joined with the write error.
+func write(client net.Conn, pkt packets.Packet, err error) error {
+	if _, wErr := pkt.WriteTo(client); wErr != nil {
+		return errors.Join(err, wErr)
+	}
+
+	return err
+}
+
+// reasonCode returns the reason code of the error returned by the handler
+// for the packet of the given type.
+func reasonCode(err error, pktType byte) byte {
+	switch {
+	case smqerrors.Contains(err, schema.ErrInvalidPayload):
+		return packets.PubackPayloadFormatInvalid
+	case smqerrors.Contains(err, mqttpubsub.ErrCorrelationData):
+		return packets.PubackImplementationSpecificError
+	case smqerrors.Contains(err, ErrMissingClientID):
+		return packets.ConnackInvalidClientID
+	case smqerrors.Contains(err, ErrMalformedTopic), smqerrors.Contains(err, ErrMissingTopicPub), smqerrors.Contains(err, ErrMissingTopicSub):
+		if pktType == packets.SUBSCRIBE {
+			return packets.SubackTopicFilterinvalid
+		}
+		return packets.PubackTopicNameInvalid
+	case pktType == packets.CONNECT && (smqerrors.Contains(err, svcerr.ErrAuthentication) || smqerrors.Contains(err, errInvalidUserId)):
+		return packets.ConnackBadUsernameOrPassword
+	case smqerrors.Contains(err, svcerr.ErrAuthentication), smqerrors.Contains(err, svcerr.ErrAuthorization), smqerrors.Contains(err, errInvalidUserId):
+		return packets.PubackNotAuthorized
+	default:
+		return packets.PubackUnspecifiedError
+	}
+}
+
+// messageHeaders returns the headers of the message published by the client.
+func messageHeaders(ctx context.Context) map[string]string {
+	headers, _ := ctx.Value(headersKey{}).(map[string]string)
+	return headers
+}
+
+func wrap(ctx context.Context, err error, dir session.Direction) error {
+	if err == io.EOF {
+		return err
+	}
+	cid := "unknown"
+	if s, ok := session.FromContext(ctx); ok {
+		cid = s.ID
+	}
+	switch dir {
+	case session.Up:
+		return fmt.Errorf("failed to proxy from MQTT client with id %s to MQTT broker with error: %s", cid, err)
+	default:
+		return fmt.Errorf("failed to proxy from MQTT broker to client with id %s with error: %s", cid, err)
+	}
+}
+
+// peekedConn reads the connection using the buffered reader, which holds the
+// peeked bytes.
+type peekedConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (c *peekedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
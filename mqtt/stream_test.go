@@ -0,0 +1,408 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/absmach/mgate/pkg/session"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/schema"
+	"github.com/eclipse/paho.golang/packets"
+	mqtt3 "github.com/eclipse/paho.mqtt.golang/packets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	streamSecret  = "secret"
+	streamTopic   = "channels/1/messages"
+	invalidTopic  = "channels/1/messages/invalid"
+	rejectedTopic = "channels/2/messages"
+)
+
+// streamHandler authenticates the clients with the secret, rejects the
+// invalid topic payloads and the access to the rejected topic.
+type streamHandler struct {
+	published chan map[string]string
+}
+
+var _ session.Handler = (*streamHandler)(nil)
+
+func (h *streamHandler) AuthConnect(ctx context.Context) error {
+	s, _ := session.FromContext(ctx)
+	if string(s.Password) != streamSecret {
+		return svcerr.ErrAuthentication
+	}
+	return nil
+}
+
+func (h *streamHandler) AuthPublish(ctx context.Context, topic *string, payload *[]byte) error {
+	switch *topic {
+	case invalidTopic:
+		return errors.Wrap(svcerr.ErrMalformedEntity, schema.ErrInvalidPayload)
+	case rejectedTopic:
+		return svcerr.ErrAuthorization
+	default:
+		return nil
+	}
+}
+
+func (h *streamHandler) AuthSubscribe(ctx context.Context, topics *[]string) error {
+	for _, topic := range *topics {
+		if topic == rejectedTopic {
+			return svcerr.ErrAuthorization
+		}
+	}
+	return nil
+}
+
+func (h *streamHandler) Connect(ctx context.Context) error {
+	return nil
+}
+
+func (h *streamHandler) Publish(ctx context.Context, topic *string, payload *[]byte) error {
+	h.published <- messageHeaders(ctx)
+	return nil
+}
+
+func (h *streamHandler) Subscribe(ctx context.Context, topics *[]string) error {
+	return nil
+}
+
+func (h *streamHandler) Unsubscribe(ctx context.Context, topics *[]string) error {
+	return nil
+}
+
+func (h *streamHandler) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// newStream starts the stream and returns the client and the broker side of
+// the proxied connections.
+func newStream(t *testing.T, h session.Handler) (net.Conn, net.Conn, chan error) {
+	client, in := net.Pipe()
+	out, broker := net.Pipe()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- stream(context.Background(), in, out, h, nil, x509.Certificate{})
+		in.Close()
+		out.Close()
+	}()
+	t.Cleanup(func() {
+		client.Close()
+		broker.Close()
+	})
+
+	return client, broker, errs
+}
+
+func connectV5(t *testing.T, client, broker net.Conn, password string) {
+	connect := &packets.Connect{
+		ProtocolName:    "MQTT",
+		ProtocolVersion: protocolV5,
+		ClientID:        "client",
+		PasswordFlag:    true,
+		Password:        []byte(password),
+		CleanStart:      true,
+		Properties:      &packets.Properties{TopicAliasMaximum: uint16Ptr(10)},
+	}
+	_, err := connect.WriteTo(client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error writing connect: %s", err))
+	if password != streamSecret {
+		return
+	}
+
+	cp := readPacket(t, broker)
+	c, ok := cp.Content.(*packets.Connect)
+	require.True(t, ok, fmt.Sprintf("expected connect got %s", cp.PacketType()))
+	assert.Nil(t, c.Properties.TopicAliasMaximum, "expected topic aliases to be disabled for the broker")
+}
+
+func readPacket(t *testing.T, conn net.Conn) *packets.ControlPacket {
+	require.Nil(t, conn.SetReadDeadline(time.Now().Add(time.Second)))
+	cp, err := packets.ReadPacket(conn)
+	require.Nil(t, err, fmt.Sprintf("unexpected error reading packet: %s", err))
+
+	return cp
+}
+
+func uint16Ptr(v uint16) *uint16 {
+	return &v
+}
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func TestStreamConnectV5(t *testing.T) {
+	cases := []struct {
+		desc     string
+		password string
+		code     byte
+	}{
+		{
+			desc:     "connect with valid secret",
+			password: streamSecret,
+		},
+		{
+			desc:     "connect with invalid secret",
+			password: "invalid",
+			code:     packets.ConnackBadUsernameOrPassword,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client, broker, errs := newStream(t, &streamHandler{})
+			connectV5(t, client, broker, tc.password)
+			if tc.code == 0 {
+				return
+			}
+			cp := readPacket(t, client)
+			ack, ok := cp.Content.(*packets.Connack)
+			require.True(t, ok, fmt.Sprintf("%s: expected connack got %s", tc.desc, cp.PacketType()))
+			assert.Equal(t, tc.code, ack.ReasonCode, fmt.Sprintf("%s: expected reason code %d got %d", tc.desc, tc.code, ack.ReasonCode))
+			assert.NotNil(t, <-errs, fmt.Sprintf("%s: expected stream error", tc.desc))
+		})
+	}
+}
+
+func TestStreamPublishV5(t *testing.T) {
+	h := &streamHandler{published: make(chan map[string]string, 1)}
+	client, broker, _ := newStream(t, h)
+	connectV5(t, client, broker, streamSecret)
+
+	cases := []struct {
+		desc     string
+		publish  *packets.Publish
+		topic    string
+		headers  map[string]string
+		ack      byte
+		code     byte
+		rejected bool
+	}{
+		{
+			desc: "publish with properties",
+			publish: &packets.Publish{
+				Topic:    streamTopic,
+				QoS:      1,
+				PacketID: 1,
+				Payload:  []byte("payload"),
+				Properties: &packets.Properties{
+					ContentType:     "application/json",
+					ResponseTopic:   "channels/1/messages/response",
+					CorrelationData: []byte("request-1"),
+					MessageExpiry:   uint32Ptr(60),
+					User:            []packets.User{{Key: "key", Value: "value"}},
+				},
+			},
+			topic: streamTopic,
+			headers: map[string]string{
+				messaging.ContentTypeHeader:     "application/json",
+				messaging.ResponseTopicHeader:   "channels/1/messages/response",
+				messaging.CorrelationDataHeader: "request-1",
+				messaging.MessageExpiryHeader:   "60",
+				"key":                           "value",
+			},
+		},
+		{
+			desc: "publish with topic alias",
+			publish: &packets.Publish{
+				Topic:      streamTopic,
+				QoS:        1,
+				PacketID:   2,
+				Payload:    []byte("payload"),
+				Properties: &packets.Properties{TopicAlias: uint16Ptr(1)},
+			},
+			topic: streamTopic,
+		},
+		{
+			desc: "publish with resolved topic alias",
+			publish: &packets.Publish{
+				QoS:        1,
+				PacketID:   3,
+				Payload:    []byte("payload"),
+				Properties: &packets.Properties{TopicAlias: uint16Ptr(1)},
+			},
+			topic: streamTopic,
+		},
+		{
+			desc: "publish payload which doesn't conform to schema",
+			publish: &packets.Publish{
+				Topic:      invalidTopic,
+				QoS:        1,
+				PacketID:   4,
+				Payload:    []byte("payload"),
+				Properties: &packets.Properties{},
+			},
+			ack:      packets.PUBACK,
+			code:     packets.PubackPayloadFormatInvalid,
+			rejected: true,
+		},
+		{
+			desc: "publish to unauthorized topic",
+			publish: &packets.Publish{
+				Topic:      rejectedTopic,
+				QoS:        2,
+				PacketID:   5,
+				Payload:    []byte("payload"),
+				Properties: &packets.Properties{},
+			},
+			ack:      packets.PUBREC,
+			code:     packets.PubrecNotAuthorized,
+			rejected: true,
+		},
+		{
+			desc: "publish with binary correlation data",
+			publish: &packets.Publish{
+				Topic:      streamTopic,
+				QoS:        1,
+				PacketID:   6,
+				Payload:    []byte("payload"),
+				Properties: &packets.Properties{CorrelationData: []byte{0xff, 0xfe}},
+			},
+			ack:      packets.PUBACK,
+			code:     packets.PubackImplementationSpecificError,
+			rejected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.publish.WriteTo(client)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error writing publish: %s", tc.desc, err))
+
+			if tc.rejected {
+				cp := readPacket(t, client)
+				require.Equal(t, tc.ack, cp.Type, fmt.Sprintf("%s: expected packet type %d got %s", tc.desc, tc.ack, cp.PacketType()))
+				var code byte
+				switch ack := cp.Content.(type) {
+				case *packets.Puback:
+					code = ack.ReasonCode
+				case *packets.Pubrec:
+					code = ack.ReasonCode
+				}
+				assert.Equal(t, tc.code, code, fmt.Sprintf("%s: expected reason code %d got %d", tc.desc, tc.code, code))
+				return
+			}
+
+			cp := readPacket(t, broker)
+			p, ok := cp.Content.(*packets.Publish)
+			require.True(t, ok, fmt.Sprintf("%s: expected publish got %s", tc.desc, cp.PacketType()))
+			assert.Equal(t, tc.topic, p.Topic, fmt.Sprintf("%s: expected topic %s got %s", tc.desc, tc.topic, p.Topic))
+			assert.Nil(t, p.Properties.TopicAlias, fmt.Sprintf("%s: expected topic alias to be resolved", tc.desc))
+			assert.Equal(t, tc.headers, <-h.published, fmt.Sprintf("%s: unexpected message headers", tc.desc))
+		})
+	}
+}
+
+func TestStreamRejectV5(t *testing.T) {
+	cases := []struct {
+		desc   string
+		packet packets.Packet
+		code   byte
+	}{
+		{
+			desc: "publish with QoS 0 to unauthorized topic",
+			packet: &packets.Publish{
+				Topic:      rejectedTopic,
+				Payload:    []byte("payload"),
+				Properties: &packets.Properties{},
+			},
+			code: packets.DisconnectNotAuthorized,
+		},
+		{
+			desc: "publish with QoS 0 and unknown topic alias",
+			packet: &packets.Publish{
+				Payload:    []byte("payload"),
+				Properties: &packets.Properties{TopicAlias: uint16Ptr(2)},
+			},
+			code: packets.DisconnectTopicAliasInvalid,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			client, broker, errs := newStream(t, &streamHandler{})
+			connectV5(t, client, broker, streamSecret)
+
+			_, err := tc.packet.WriteTo(client)
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error writing packet: %s", tc.desc, err))
+			cp := readPacket(t, client)
+			d, ok := cp.Content.(*packets.Disconnect)
+			require.True(t, ok, fmt.Sprintf("%s: expected disconnect got %s", tc.desc, cp.PacketType()))
+			assert.Equal(t, tc.code, d.ReasonCode, fmt.Sprintf("%s: expected reason code %d got %d", tc.desc, tc.code, d.ReasonCode))
+			assert.NotNil(t, <-errs, fmt.Sprintf("%s: expected stream error", tc.desc))
+		})
+	}
+}
+
+func TestStreamSubscribeV5(t *testing.T) {
+	client, broker, _ := newStream(t, &streamHandler{})
+	connectV5(t, client, broker, streamSecret)
+
+	sub := &packets.Subscribe{
+		PacketID:      1,
+		Subscriptions: []packets.SubOptions{{Topic: rejectedTopic, QoS: 1}},
+		Properties:    &packets.Properties{},
+	}
+	_, err := sub.WriteTo(client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error writing subscribe: %s", err))
+	cp := readPacket(t, client)
+	ack, ok := cp.Content.(*packets.Suback)
+	require.True(t, ok, fmt.Sprintf("expected suback got %s", cp.PacketType()))
+	assert.Equal(t, []byte{packets.SubackNotauthorized}, ack.Reasons)
+
+	sub = &packets.Subscribe{
+		PacketID:      2,
+		Subscriptions: []packets.SubOptions{{Topic: streamTopic, QoS: 1}},
+		Properties:    &packets.Properties{},
+	}
+	_, err = sub.WriteTo(client)
+	require.Nil(t, err, fmt.Sprintf("unexpected error writing subscribe: %s", err))
+	cp = readPacket(t, broker)
+	_, ok = cp.Content.(*packets.Subscribe)
+	assert.True(t, ok, fmt.Sprintf("expected subscribe got %s", cp.PacketType()))
+
+	// The delivered messages keep the properties set by the publisher.
+	pub := &packets.Publish{
+		Topic:      streamTopic,
+		Payload:    []byte("payload"),
+		Properties: &packets.Properties{ContentType: "application/json"},
+	}
+	_, err = pub.WriteTo(broker)
+	require.Nil(t, err, fmt.Sprintf("unexpected error writing publish: %s", err))
+	cp = readPacket(t, client)
+	p, ok := cp.Content.(*packets.Publish)
+	require.True(t, ok, fmt.Sprintf("expected publish got %s", cp.PacketType()))
+	assert.Equal(t, "application/json", p.Properties.ContentType)
+}
+
+func TestStreamV311(t *testing.T) {
+	client, broker, _ := newStream(t, &streamHandler{})
+
+	connect := mqtt3.NewControlPacket(mqtt3.Connect).(*mqtt3.ConnectPacket)
+	connect.ProtocolName = "MQTT"
+	connect.ProtocolVersion = 4
+	connect.ClientIdentifier = "client"
+	connect.PasswordFlag = true
+	connect.Password = []byte(streamSecret)
+	require.Nil(t, connect.Write(client))
+
+	require.Nil(t, broker.SetReadDeadline(time.Now().Add(time.Second)))
+	cp, err := mqtt3.ReadPacket(broker)
+	require.Nil(t, err, fmt.Sprintf("unexpected error reading packet: %s", err))
+	c, ok := cp.(*mqtt3.ConnectPacket)
+	require.True(t, ok, fmt.Sprintf("expected connect got %s", cp.String()))
+	assert.Equal(t, byte(4), c.ProtocolVersion)
+	assert.Equal(t, "client", c.ClientIdentifier)
+}
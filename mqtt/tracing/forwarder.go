@@ -48,7 +48,7 @@ func (fm *forwarderMiddleware) Forward(ctx context.Context, id string, sub messa
 			attribute.String("messaging.destination.template", "channels/{channelID}/messages/*"),
 			attribute.Bool("messaging.destination.temporary", true),
 			attribute.String("network.protocol.name", "mqtt"),
-			attribute.String("network.protocol.version", "3.1.1"),
+			attribute.String("network.protocol.version", "5"),
 			attribute.String("network.transport", "tcp"),
 			attribute.String("network.type", "ipv4"),
 			attribute.String("messaging.operation", forwardOP),
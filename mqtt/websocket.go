@@ -0,0 +1,216 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/mgate"
+	"github.com/absmach/mgate/pkg/session"
+	mptls "github.com/absmach/mgate/pkg/tls"
+	"github.com/absmach/supermq/pkg/lockout"
+	"github.com/gorilla/websocket"
+	"golang.org/x/sync/errgroup"
+)
+
+var upgrader = websocket.Upgrader{
+	HandshakeTimeout: 10 * time.Second,
+	// Paho JS client expects the mqtt subprotocol in the upgrade response.
+	Subprotocols: []string{"mqttv3.1", "mqtt"},
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// WSProxy is the MQTT over WebSocket proxy. Unlike the mGate WebSocket proxy,
+// it passes the address of the client to the session handler, so the failed
+// authentication attempts are limited per source, and it supports MQTT 5.
+type WSProxy struct {
+	config      mgate.Config
+	handler     session.Handler
+	interceptor session.Interceptor
+	proxies     lockout.TrustedProxies
+	logger      *slog.Logger
+	warn        sync.Once
+}
+
+// NewWSProxy returns a new MQTT over WebSocket proxy. The X-Real-IP header is
+// honoured only if the request comes from one of the trusted proxies.
+func NewWSProxy(config mgate.Config, handler session.Handler, interceptor session.Interceptor, proxies lockout.TrustedProxies, logger *slog.Logger) *WSProxy {
+	return &WSProxy{
+		config:      config,
+		handler:     handler,
+		interceptor: interceptor,
+		proxies:     proxies,
+		logger:      logger,
+	}
+}
+
+func (p *WSProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, p.config.PathPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+	source := p.source(r)
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		p.logger.Error("Error upgrading connection", slog.Any("error", err))
+		return
+	}
+
+	go p.pass(conn, source)
+}
+
+// Listen serves the WebSocket connections until the context is canceled.
+func (p *WSProxy) Listen(ctx context.Context) error {
+	l, err := net.Listen("tcp", p.config.Address)
+	if err != nil {
+		return err
+	}
+
+	if p.config.TLSConfig != nil {
+		l = tls.NewListener(l, p.config.TLSConfig)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(p.config.PathPrefix, p)
+	server := http.Server{Handler: mux}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return server.Serve(l)
+	})
+	status := mptls.SecurityStatus(p.config.TLSConfig)
+	p.logger.Info(fmt.Sprintf("MQTT websocket proxy server started at %s%s with %s", p.config.Address, p.config.PathPrefix, status))
+
+	g.Go(func() error {
+		<-ctx.Done()
+		return server.Close()
+	})
+	if err := g.Wait(); err != nil {
+		p.logger.Info(fmt.Sprintf("MQTT websocket proxy server at %s%s with %s exiting with errors", p.config.Address, p.config.PathPrefix, status), slog.String("error", err.Error()))
+	} else {
+		p.logger.Info(fmt.Sprintf("MQTT websocket proxy server at %s%s with %s exiting...", p.config.Address, p.config.PathPrefix, status))
+	}
+
+	return nil
+}
+
+// source returns the address of the client. The requests forwarded by a proxy
+// while no trusted proxies are configured have no source, so the clients
+// behind the proxy don't share the proxy address and lock each other out.
+func (p *WSProxy) source(r *http.Request) string {
+	if p.proxies.Untrusted(r) {
+		p.warn.Do(func() {
+			p.logger.Warn("Received request forwarded by a reverse proxy without trusted proxies configured, failed authentication attempts are not limited per source")
+		})
+		return ""
+	}
+
+	return p.proxies.RequestSource(r)
+}
+
+func (p *WSProxy) pass(in *websocket.Conn, source string) {
+	defer in.Close()
+	// A new context is used so the connection outlives the upgrade request.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if source != "" {
+		ctx = lockout.WithSource(ctx, source)
+	}
+
+	dialer := &websocket.Dialer{
+		Subprotocols: []string{"mqtt"},
+	}
+	srv, _, err := dialer.DialContext(ctx, p.config.Target, nil)
+	if err != nil {
+		p.logger.Error("Unable to connect to broker", slog.Any("error", err))
+		return
+	}
+
+	inbound := newWSConn(in)
+	outbound := newWSConn(srv)
+	defer inbound.Close()
+	defer outbound.Close()
+
+	clientCert, err := mptls.ClientCert(in.UnderlyingConn())
+	if err != nil {
+		p.logger.Error("Failed to get client certificate", slog.Any("error", err))
+		return
+	}
+
+	err = stream(ctx, inbound, outbound, p.handler, p.interceptor, clientCert)
+	p.logger.Warn("Broken connection for client", slog.Any("error", err))
+}
+
+// wsConn wraps the WebSocket connection so it satisfies the net.Conn
+// interface, which is used by the session stream.
+type wsConn struct {
+	*websocket.Conn
+	r   io.Reader
+	rio sync.Mutex
+	wio sync.Mutex
+}
+
+func newWSConn(ws *websocket.Conn) net.Conn {
+	return &wsConn{
+		Conn: ws,
+	}
+}
+
+func (c *wsConn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+
+	return c.SetWriteDeadline(t)
+}
+
+func (c *wsConn) Write(p []byte) (int, error) {
+	c.wio.Lock()
+	defer c.wio.Unlock()
+
+	if err := c.WriteMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+func (c *wsConn) Read(p []byte) (int, error) {
+	c.rio.Lock()
+	defer c.rio.Unlock()
+	for {
+		if c.r == nil {
+			var err error
+			if _, c.r, err = c.NextReader(); err != nil {
+				return 0, err
+			}
+		}
+		n, err := c.r.Read(p)
+		if err == io.EOF {
+			// The current message is read, advance to the next one.
+			c.r = nil
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+
+		return n, err
+	}
+}
+
+func (c *wsConn) Close() error {
+	return c.Conn.Close()
+}
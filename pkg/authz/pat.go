@@ -0,0 +1,45 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package authz
+
+import (
+	"context"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	"github.com/absmach/supermq/auth"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+)
+
+// AuthorizeMessagingPAT checks the messaging scope of the personal access token
+// for the operation on the channel and returns the domain user ID of the token
+// owner. The token is not bound to a domain, so the domain user ID is composed
+// using the domain of the channel.
+func AuthorizeMessagingPAT(ctx context.Context, authz Authorization, channels grpcChannelsV1.ChannelsServiceClient, session authn.Session, chanID string, msgType connections.ConnType) (string, error) {
+	op := auth.PublishOp
+	if msgType == connections.Subscribe {
+		op = auth.SubscribeOp
+	}
+	if err := authz.AuthorizePAT(ctx, PatReq{
+		UserID:                   session.UserID,
+		PatID:                    session.PatID,
+		PlatformEntityType:       auth.PlatformMesagingScope,
+		OptionalDomainEntityType: auth.DomainNullScope,
+		Operation:                op,
+		EntityIDs:                []string{chanID},
+	}); err != nil {
+		return "", errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+	}
+
+	res, err := channels.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: chanID})
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+
+	return policies.EncodeDomainUserID(res.GetEntity().GetDomainId(), session.UserID), nil
+}
@@ -0,0 +1,196 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package certauth
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+
+	grpcCertsV1 "github.com/absmach/supermq/api/grpc/certs/v1"
+	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	"github.com/absmach/supermq/clients"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+)
+
+// Identity is the client certificate attribute which identifies the client.
+type Identity string
+
+const (
+	// SerialNumber identifies the client by the certs service record of the
+	// certificate serial number, so only the certificates issued by the certs
+	// service are accepted. The presented certificate must match the record.
+	SerialNumber Identity = "serial"
+
+	// CommonName identifies the client by the certificate common name. The
+	// certificates known to the certs service must be issued to the same
+	// client, and the certificates issued by the other trusted CAs are
+	// accepted only if enabled by the configuration.
+	CommonName Identity = "cn"
+)
+
+var (
+	// ErrMissingCert indicates that the client didn't present the certificate
+	// and the fallback to the secret authentication is not allowed.
+	ErrMissingCert = errors.New("missing client certificate")
+
+	// ErrRevokedCert indicates that the client certificate is revoked.
+	ErrRevokedCert = errors.New("client certificate is revoked")
+
+	// ErrExpiredCert indicates that the client certificate is expired.
+	ErrExpiredCert = errors.New("client certificate is expired")
+
+	// ErrUnknownCert indicates that the client certificate has the serial
+	// number of a certificate issued by the certs service, but is not that
+	// certificate, e.g. it is issued by another trusted CA.
+	ErrUnknownCert = errors.New("client certificate is not issued by the certs service")
+
+	// ErrCertClient indicates that the client certificate common name doesn't
+	// match the client the certificate is issued to.
+	ErrCertClient = errors.New("client certificate is not issued to the client")
+
+	// ErrDisabledClient indicates that the client identified by the
+	// certificate is disabled.
+	ErrDisabledClient = errors.New("client is disabled")
+
+	// ErrInvalidIdentity indicates an unknown client certificate identity.
+	ErrInvalidIdentity = errors.New("invalid client certificate identity")
+)
+
+// Config contains the client certificate authentication configuration.
+type Config struct {
+	// Enabled enables the client certificate authentication.
+	Enabled bool `env:"ENABLED"  envDefault:"false"`
+	// Identity is the certificate attribute which identifies the client.
+	Identity Identity `env:"IDENTITY" envDefault:"serial"`
+	// Fallback allows the clients without the certificate to authenticate
+	// using the client secret.
+	Fallback bool `env:"FALLBACK" envDefault:"true"`
+	// External allows the certificates which are not issued by the certs
+	// service to identify the client by their common name. It applies only
+	// to the common name identity.
+	External bool `env:"EXTERNAL" envDefault:"false"`
+}
+
+// ClientAuth returns the TLS client authentication policy of the adapter
+// servers which trust the client CAs. The certificates are required only if
+// the certificate authentication is enabled without the fallback to the
+// secret authentication.
+func (cfg Config) ClientAuth() tls.ClientAuthType {
+	if cfg.Enabled && !cfg.Fallback {
+		return tls.RequireAndVerifyClientCert
+	}
+
+	return tls.VerifyClientCertIfGiven
+}
+
+// UnmarshalText parses the client certificate identity.
+func (id *Identity) UnmarshalText(text []byte) error {
+	switch i := Identity(text); i {
+	case SerialNumber, CommonName:
+		*id = i
+		return nil
+	default:
+		return errors.Wrap(ErrInvalidIdentity, errors.New(string(text)))
+	}
+}
+
+// Authenticator authenticates the clients using the X.509 client certificates.
+//
+//go:generate mockery --name Authenticator --output=./mocks --filename authenticator.go --quiet --note "Copyright (c) Abstract Machines"
+type Authenticator interface {
+	// Authenticate returns the ID of the enabled client identified by the
+	// certificate. The certificate is verified against the trusted CAs by the
+	// TLS connection, while the authenticator checks its revocation status
+	// using the certs service. If the certificate is not presented, the empty
+	// ID is returned when the fallback to the secret authentication is allowed.
+	Authenticate(ctx context.Context, cert x509.Certificate) (string, error)
+}
+
+type authenticator struct {
+	certs    grpcCertsV1.CertsServiceClient
+	clients  grpcClientsV1.ClientsServiceClient
+	identity Identity
+	fallback bool
+	external bool
+}
+
+var _ Authenticator = (*authenticator)(nil)
+
+// New returns the client certificate authenticator, which retrieves the
+// certificates from the certs service and the clients from the clients service.
+func New(certs grpcCertsV1.CertsServiceClient, clients grpcClientsV1.ClientsServiceClient, cfg Config) Authenticator {
+	return &authenticator{
+		certs:    certs,
+		clients:  clients,
+		identity: cfg.Identity,
+		fallback: cfg.Fallback,
+		external: cfg.External,
+	}
+}
+
+func (a *authenticator) Authenticate(ctx context.Context, cert x509.Certificate) (string, error) {
+	if cert.SerialNumber == nil {
+		if a.fallback {
+			return "", nil
+		}
+		return "", errors.Wrap(svcerr.ErrAuthentication, ErrMissingCert)
+	}
+
+	clientID, err := a.clientID(ctx, cert)
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	res, err := a.clients.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: clientID})
+	if err != nil {
+		return "", errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if res.GetEntity().GetStatus() != uint32(clients.EnabledStatus) {
+		return "", errors.Wrap(svcerr.ErrAuthentication, ErrDisabledClient)
+	}
+
+	return clientID, nil
+}
+
+func (a *authenticator) clientID(ctx context.Context, cert x509.Certificate) (string, error) {
+	res, err := a.certs.RetrieveCert(ctx, &grpcCertsV1.RetrieveCertReq{SerialNumber: cert.SerialNumber.String()})
+	switch {
+	// The serial numbers are unique per CA only, so the record must describe
+	// the presented certificate rather than one with the same serial number.
+	case err == nil && len(res.GetCertificate()) > 0 && bytes.Equal(res.GetCertificate(), cert.Raw):
+	case err == nil && !a.externalCN():
+		return "", ErrUnknownCert
+	case err == nil, a.externalCN() && errors.Contains(err, svcerr.ErrNotFound):
+		// The certificate is issued by the other trusted CA.
+		if cert.Subject.CommonName == "" {
+			return "", ErrCertClient
+		}
+		return cert.Subject.CommonName, nil
+	default:
+		return "", err
+	}
+
+	if res.GetRevoked() {
+		return "", ErrRevokedCert
+	}
+	if !time.Now().Before(res.GetExpiryTime().AsTime()) {
+		return "", ErrExpiredCert
+	}
+	if a.identity == CommonName && res.GetClientId() != cert.Subject.CommonName {
+		return "", ErrCertClient
+	}
+
+	return res.GetClientId(), nil
+}
+
+// externalCN reports whether the certificates issued by the other trusted CAs
+// identify the client by their common name.
+func (a *authenticator) externalCN() bool {
+	return a.identity == CommonName && a.external
+}
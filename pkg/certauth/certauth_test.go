@@ -0,0 +1,319 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package certauth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	grpcCertsV1 "github.com/absmach/supermq/api/grpc/certs/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	certsmocks "github.com/absmach/supermq/certs/mocks"
+	"github.com/absmach/supermq/clients"
+	clmocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/certauth"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/caarlos0/env/v11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	clientID = testsutil.GenerateUUID(&testing.T{})
+	serial   = big.NewInt(123456789)
+	cert     = issueCert("Certs CA", clientID)
+	// otherCACert has the serial number and the common name of the cert, but
+	// it is issued by another CA.
+	otherCACert = issueCert("Other CA", clientID)
+	validCert   = &grpcCertsV1.RetrieveCertRes{
+		SerialNumber: serial.String(),
+		Certificate:  cert.Raw,
+		ClientId:     clientID,
+		ExpiryTime:   timestamppb.New(time.Now().Add(time.Hour)),
+	}
+	enabledClient = &grpcCommonV1.RetrieveEntityRes{
+		Entity: &grpcCommonV1.EntityBasic{Id: clientID, Status: uint32(clients.EnabledStatus)},
+	}
+)
+
+func TestConfig(t *testing.T) {
+	cases := []struct {
+		desc       string
+		env        map[string]string
+		cfg        certauth.Config
+		clientAuth tls.ClientAuthType
+		err        error
+	}{
+		{
+			desc:       "default config",
+			env:        map[string]string{},
+			cfg:        certauth.Config{Identity: certauth.SerialNumber, Fallback: true},
+			clientAuth: tls.VerifyClientCertIfGiven,
+		},
+		{
+			desc:       "enabled with fallback",
+			env:        map[string]string{"ENABLED": "true", "IDENTITY": "cn"},
+			cfg:        certauth.Config{Enabled: true, Identity: certauth.CommonName, Fallback: true},
+			clientAuth: tls.VerifyClientCertIfGiven,
+		},
+		{
+			desc:       "enabled with external certificates",
+			env:        map[string]string{"ENABLED": "true", "IDENTITY": "cn", "EXTERNAL": "true"},
+			cfg:        certauth.Config{Enabled: true, Identity: certauth.CommonName, Fallback: true, External: true},
+			clientAuth: tls.VerifyClientCertIfGiven,
+		},
+		{
+			desc:       "disabled without fallback",
+			env:        map[string]string{"FALLBACK": "false"},
+			cfg:        certauth.Config{Identity: certauth.SerialNumber},
+			clientAuth: tls.VerifyClientCertIfGiven,
+		},
+		{
+			desc:       "enabled without fallback",
+			env:        map[string]string{"ENABLED": "true", "FALLBACK": "false"},
+			cfg:        certauth.Config{Enabled: true, Identity: certauth.SerialNumber},
+			clientAuth: tls.RequireAndVerifyClientCert,
+		},
+		{
+			desc: "invalid identity",
+			env:  map[string]string{"IDENTITY": "email"},
+			err:  certauth.ErrInvalidIdentity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var cfg certauth.Config
+			err := env.ParseWithOptions(&cfg, env.Options{Environment: tc.env})
+			if tc.err != nil {
+				assert.ErrorContains(t, err, tc.err.Error(), fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.err, err))
+				return
+			}
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %v", tc.desc, err))
+			assert.Equal(t, tc.cfg, cfg)
+			assert.Equal(t, tc.clientAuth, cfg.ClientAuth())
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	certsClient := new(certsmocks.CertsServiceClient)
+	clientsClient := new(clmocks.ClientsServiceClient)
+
+	otherCert := issueCert("Certs CA", testsutil.GenerateUUID(t))
+
+	cases := []struct {
+		desc      string
+		cfg       certauth.Config
+		cert      x509.Certificate
+		certRes   *grpcCertsV1.RetrieveCertRes
+		certErr   error
+		entityRes *grpcCommonV1.RetrieveEntityRes
+		entityErr error
+		id        string
+		err       error
+	}{
+		{
+			desc:      "authenticate with serial number",
+			cfg:       certauth.Config{Identity: certauth.SerialNumber},
+			cert:      cert,
+			certRes:   validCert,
+			entityRes: enabledClient,
+			id:        clientID,
+		},
+		{
+			desc:    "authenticate with serial number of certificate issued by other CA with the same serial number",
+			cfg:     certauth.Config{Identity: certauth.SerialNumber},
+			cert:    otherCACert,
+			certRes: validCert,
+			err:     certauth.ErrUnknownCert,
+		},
+		{
+			desc:    "authenticate with common name of certificate issued by other CA with the same serial number",
+			cfg:     certauth.Config{Identity: certauth.CommonName},
+			cert:    otherCACert,
+			certRes: validCert,
+			err:     certauth.ErrUnknownCert,
+		},
+		{
+			desc:      "authenticate with common name of external certificate with the same serial number",
+			cfg:       certauth.Config{Identity: certauth.CommonName, External: true},
+			cert:      otherCACert,
+			certRes:   validCert,
+			entityRes: enabledClient,
+			id:        clientID,
+		},
+		{
+			desc:      "authenticate with common name",
+			cfg:       certauth.Config{Identity: certauth.CommonName},
+			cert:      cert,
+			certRes:   validCert,
+			entityRes: enabledClient,
+			id:        clientID,
+		},
+		{
+			desc:      "authenticate with common name of certificate issued by other CA",
+			cfg:       certauth.Config{Identity: certauth.CommonName, External: true},
+			cert:      cert,
+			certErr:   svcerr.ErrNotFound,
+			entityRes: enabledClient,
+			id:        clientID,
+		},
+		{
+			desc:    "authenticate with common name of certificate issued by other CA without external certificates",
+			cfg:     certauth.Config{Identity: certauth.CommonName},
+			cert:    cert,
+			certErr: svcerr.ErrNotFound,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc: "authenticate without certificate with fallback",
+			cfg:  certauth.Config{Identity: certauth.SerialNumber, Fallback: true},
+			cert: x509.Certificate{},
+			id:   "",
+		},
+		{
+			desc: "authenticate without certificate without fallback",
+			cfg:  certauth.Config{Identity: certauth.SerialNumber},
+			cert: x509.Certificate{},
+			err:  certauth.ErrMissingCert,
+		},
+		{
+			desc:    "authenticate with serial number of certificate issued by other CA",
+			cfg:     certauth.Config{Identity: certauth.SerialNumber},
+			cert:    cert,
+			certErr: svcerr.ErrNotFound,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc: "authenticate with revoked certificate",
+			cfg:  certauth.Config{Identity: certauth.SerialNumber},
+			cert: cert,
+			certRes: &grpcCertsV1.RetrieveCertRes{
+				SerialNumber: serial.String(),
+				Certificate:  cert.Raw,
+				ClientId:     clientID,
+				Revoked:      true,
+				ExpiryTime:   timestamppb.New(time.Now().Add(time.Hour)),
+			},
+			err: certauth.ErrRevokedCert,
+		},
+		{
+			desc: "authenticate with expired certificate",
+			cfg:  certauth.Config{Identity: certauth.SerialNumber},
+			cert: cert,
+			certRes: &grpcCertsV1.RetrieveCertRes{
+				SerialNumber: serial.String(),
+				Certificate:  cert.Raw,
+				ClientId:     clientID,
+				ExpiryTime:   timestamppb.New(time.Now().Add(-time.Hour)),
+			},
+			err: certauth.ErrExpiredCert,
+		},
+		{
+			desc: "authenticate with common name of other client",
+			cfg:  certauth.Config{Identity: certauth.CommonName},
+			cert: otherCert,
+			certRes: &grpcCertsV1.RetrieveCertRes{
+				SerialNumber: serial.String(),
+				Certificate:  otherCert.Raw,
+				ClientId:     clientID,
+				ExpiryTime:   timestamppb.New(time.Now().Add(time.Hour)),
+			},
+			err: certauth.ErrCertClient,
+		},
+		{
+			desc:    "authenticate with failed certs service",
+			cfg:     certauth.Config{Identity: certauth.CommonName},
+			cert:    cert,
+			certErr: svcerr.ErrViewEntity,
+			err:     svcerr.ErrAuthentication,
+		},
+		{
+			desc:    "authenticate disabled client",
+			cfg:     certauth.Config{Identity: certauth.SerialNumber},
+			cert:    cert,
+			certRes: validCert,
+			entityRes: &grpcCommonV1.RetrieveEntityRes{
+				Entity: &grpcCommonV1.EntityBasic{Id: clientID, Status: uint32(clients.DisabledStatus)},
+			},
+			err: certauth.ErrDisabledClient,
+		},
+		{
+			desc:      "authenticate non-existing client",
+			cfg:       certauth.Config{Identity: certauth.SerialNumber},
+			cert:      cert,
+			certRes:   validCert,
+			entityErr: svcerr.ErrNotFound,
+			err:       svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			certsCall := certsClient.On("RetrieveCert", mock.Anything, &grpcCertsV1.RetrieveCertReq{SerialNumber: serial.String()}).Return(tc.certRes, tc.certErr)
+			clientsCall := clientsClient.On("RetrieveEntity", mock.Anything, &grpcCommonV1.RetrieveEntityReq{Id: clientID}).Return(tc.entityRes, tc.entityErr)
+			authn := certauth.New(certsClient, clientsClient, tc.cfg)
+			id, err := authn.Authenticate(context.Background(), tc.cert)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.id, id, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.id, id))
+			certsCall.Unset()
+			clientsCall.Unset()
+		})
+	}
+}
+
+func TestContext(t *testing.T) {
+	assert.Nil(t, certauth.FromContext(context.Background()).SerialNumber)
+
+	ctx := certauth.NewContext(context.Background(), cert)
+	assert.Equal(t, cert.SerialNumber, certauth.FromContext(ctx).SerialNumber)
+}
+
+// issueCert returns the client certificate with the test serial number issued
+// by the self-signed CA with the given name.
+func issueCert(ca, cn string) x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: ca},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caTmpl, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		panic(err)
+	}
+
+	return *cert
+}
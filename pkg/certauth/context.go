@@ -0,0 +1,24 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package certauth
+
+import (
+	"context"
+	"crypto/x509"
+)
+
+type certKey struct{}
+
+// NewContext stores the client certificate of the connection in the context,
+// for the adapters which don't keep it in the session.
+func NewContext(ctx context.Context, cert x509.Certificate) context.Context {
+	return context.WithValue(ctx, certKey{}, cert)
+}
+
+// FromContext returns the client certificate stored in the context, or the
+// empty certificate if the client didn't present one.
+func FromContext(ctx context.Context) x509.Certificate {
+	cert, _ := ctx.Value(certKey{}).(x509.Certificate)
+	return cert
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package certauth contains the authentication of the clients using the X.509
+// client certificates issued by the certs service, which is used by the
+// protocol adapters.
+package certauth
@@ -43,6 +43,16 @@ var (
 	// ErrInvalidStatus indicates an invalid status.
 	ErrInvalidStatus = errors.New("invalid status")
 
+	// ErrInvalidPresence indicates an invalid presence.
+	ErrInvalidPresence = errors.New("invalid presence")
+
+	// ErrTooManyRequests indicates that the request is rate limited.
+	ErrTooManyRequests = errors.New("too many requests, try again later")
+
+	// ErrRevisionConflict indicates that the entity has been modified since
+	// the revision the update was based on.
+	ErrRevisionConflict = errors.New("entity revision has changed")
+
 	// ErrInvalidRole indicates that an invalid role.
 	ErrInvalidRole = errors.New("invalid client role")
 
@@ -6,12 +6,14 @@ package grpcclient
 import (
 	"context"
 
+	grpcCertsV1 "github.com/absmach/supermq/api/grpc/certs/v1"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	grpcGroupsV1 "github.com/absmach/supermq/api/grpc/groups/v1"
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	tokengrpc "github.com/absmach/supermq/auth/api/grpc/token"
+	certsgrpc "github.com/absmach/supermq/certs/api/grpc"
 	channelsgrpc "github.com/absmach/supermq/channels/api/grpc"
 	clientsauth "github.com/absmach/supermq/clients/api/grpc"
 	domainsgrpc "github.com/absmach/supermq/domains/api/grpc"
@@ -97,3 +99,17 @@ func SetupGroupsClient(ctx context.Context, cfg Config) (grpcGroupsV1.GroupsServ
 
 	return groupsgrpc.NewClient(client.Connection(), cfg.Timeout), client, nil
 }
+
+// SetupCertsClient loads certs gRPC configuration and creates new certs gRPC client.
+//
+// For example:
+//
+// certsClient, certsHandler, err := grpcclient.SetupCertsClient(ctx, grpcclient.Config{}).
+func SetupCertsClient(ctx context.Context, cfg Config) (grpcCertsV1.CertsServiceClient, Handler, error) {
+	client, err := NewHandler(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return certsgrpc.NewClient(client.Connection(), cfg.Timeout), client, nil
+}
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lastvalue contains the retained last-value store, which keeps the
+// latest message published on each channel topic so that the clients can fetch
+// the current state of the topic without waiting for the next message.
+package lastvalue
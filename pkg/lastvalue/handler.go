@@ -0,0 +1,37 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package lastvalue
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+// SubscriberID is the prefix of the ID the last-value subscribers use.
+const SubscriberID = "lastvalue"
+
+var _ messaging.MessageHandler = (*handler)(nil)
+
+type handler struct {
+	ctx   context.Context
+	store Store
+}
+
+// NewHandler returns the message handler which saves the consumed messages
+// to the last-value store.
+func NewHandler(ctx context.Context, store Store) messaging.MessageHandler {
+	return &handler{
+		ctx:   ctx,
+		store: store,
+	}
+}
+
+func (h *handler) Handle(msg *messaging.Message) error {
+	return h.store.Save(h.ctx, msg)
+}
+
+func (h *handler) Cancel() error {
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package lastvalue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+// Store keeps the latest message published on each channel and subtopic pair.
+//
+//go:generate mockery --name Store --output=./mocks --filename store.go --quiet --note "Copyright (c) Abstract Machines"
+type Store interface {
+	// Save stores the message as the last value of its channel and subtopic.
+	Save(ctx context.Context, msg *messaging.Message) error
+
+	// Retrieve returns the last message published on the channel and subtopic.
+	// The repository ErrNotFound is returned if there is no such message.
+	Retrieve(ctx context.Context, chanID, subtopic string) (*messaging.Message, error)
+}
+
+// Key returns the key the last value of the channel and subtopic is stored under.
+func Key(chanID, subtopic string) string {
+	if subtopic == "" {
+		return chanID
+	}
+
+	return fmt.Sprintf("%s.%s", chanID, subtopic)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package lastvalue
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/messaging"
+	"google.golang.org/protobuf/proto"
+)
+
+var _ Store = (*memoryStore)(nil)
+
+type memoryEntry struct {
+	key       string
+	msg       *messaging.Message
+	expiresAt time.Time
+}
+
+type memoryStore struct {
+	maxEntries int
+	ttl        time.Duration
+	mu         sync.Mutex
+	order      *list.List
+	values     map[string]*list.Element
+}
+
+// NewMemoryStore returns an in-memory last-value store. The values are local
+// to the service instance and lost on restart. The store keeps the values of
+// at most maxEntries channel and subtopic pairs, evicting the least recently
+// updated ones, and values expire after the ttl duration, or never if the ttl
+// is zero.
+func NewMemoryStore(maxEntries int, ttl time.Duration) Store {
+	return &memoryStore{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		order:      list.New(),
+		values:     make(map[string]*list.Element),
+	}
+}
+
+func (ms *memoryStore) Save(_ context.Context, msg *messaging.Message) error {
+	e := &memoryEntry{
+		key: Key(msg.GetChannel(), msg.GetSubtopic()),
+		msg: proto.Clone(msg).(*messaging.Message),
+	}
+	if ms.ttl > 0 {
+		e.expiresAt = time.Now().Add(ms.ttl)
+	}
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if el, ok := ms.values[e.key]; ok {
+		el.Value = e
+		ms.order.MoveToFront(el)
+		return nil
+	}
+	ms.values[e.key] = ms.order.PushFront(e)
+	for ms.order.Len() > ms.maxEntries {
+		ms.remove(ms.order.Back())
+	}
+
+	return nil
+}
+
+func (ms *memoryStore) Retrieve(_ context.Context, chanID, subtopic string) (*messaging.Message, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	el, ok := ms.values[Key(chanID, subtopic)]
+	if !ok {
+		return nil, repoerr.ErrNotFound
+	}
+	e := el.Value.(*memoryEntry)
+	if !e.expiresAt.IsZero() && time.Now().After(e.expiresAt) {
+		ms.remove(el)
+		return nil, repoerr.ErrNotFound
+	}
+
+	return proto.Clone(e.msg).(*messaging.Message), nil
+}
+
+func (ms *memoryStore) remove(el *list.Element) {
+	ms.order.Remove(el)
+	delete(ms.values, el.Value.(*memoryEntry).key)
+}
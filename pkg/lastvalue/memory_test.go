@@ -0,0 +1,155 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package lastvalue_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/lastvalue"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	store := lastvalue.NewMemoryStore(10, 0)
+	handler := lastvalue.NewHandler(context.Background(), store)
+
+	chanID := testsutil.GenerateUUID(t)
+	first := &messaging.Message{
+		Channel:  chanID,
+		Subtopic: "desired",
+		Payload:  []byte(`{"state":"on"}`),
+		Created:  time.Now().UnixNano(),
+	}
+	last := &messaging.Message{
+		Channel:  chanID,
+		Subtopic: "desired",
+		Payload:  []byte(`{"state":"off"}`),
+		Headers:  map[string]string{messaging.ContentTypeHeader: "application/json"},
+		Created:  time.Now().UnixNano(),
+	}
+	root := &messaging.Message{
+		Channel: chanID,
+		Payload: []byte(`{"temp":21}`),
+		Created: time.Now().UnixNano(),
+	}
+	for _, msg := range []*messaging.Message{first, last, root} {
+		require.Nil(t, handler.Handle(msg), fmt.Sprintf("handling message expected to succeed: %s", msg))
+	}
+
+	cases := []struct {
+		desc     string
+		chanID   string
+		subtopic string
+		msg      *messaging.Message
+		err      error
+	}{
+		{
+			desc:     "retrieve last value of subtopic",
+			chanID:   chanID,
+			subtopic: "desired",
+			msg:      last,
+		},
+		{
+			desc:   "retrieve last value of channel without subtopic",
+			chanID: chanID,
+			msg:    root,
+		},
+		{
+			desc:     "retrieve last value of subtopic without messages",
+			chanID:   chanID,
+			subtopic: "reported",
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:   "retrieve last value of channel without messages",
+			chanID: testsutil.GenerateUUID(t),
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg, err := store.Retrieve(context.Background(), tc.chanID, tc.subtopic)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.msg.String(), msg.String(), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.msg, msg))
+			}
+		})
+	}
+}
+
+func TestMemoryStoreBounds(t *testing.T) {
+	ttl := 50 * time.Millisecond
+	chanID := testsutil.GenerateUUID(t)
+	msg := func(subtopic string) *messaging.Message {
+		return &messaging.Message{
+			Channel:  chanID,
+			Subtopic: subtopic,
+			Payload:  []byte(`{"temp":21}`),
+		}
+	}
+
+	cases := []struct {
+		desc     string
+		store    lastvalue.Store
+		saved    []string
+		wait     time.Duration
+		subtopic string
+		err      error
+	}{
+		{
+			desc:     "retrieve last value within max entries",
+			store:    lastvalue.NewMemoryStore(2, 0),
+			saved:    []string{"a", "b"},
+			subtopic: "a",
+		},
+		{
+			desc:     "retrieve evicted last value",
+			store:    lastvalue.NewMemoryStore(2, 0),
+			saved:    []string{"a", "b", "c"},
+			subtopic: "a",
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "retrieve updated last value over max entries",
+			store:    lastvalue.NewMemoryStore(2, 0),
+			saved:    []string{"a", "b", "a", "c"},
+			subtopic: "a",
+		},
+		{
+			desc:     "retrieve last value before expiration",
+			store:    lastvalue.NewMemoryStore(2, time.Hour),
+			saved:    []string{"a"},
+			wait:     ttl,
+			subtopic: "a",
+		},
+		{
+			desc:     "retrieve expired last value",
+			store:    lastvalue.NewMemoryStore(2, ttl),
+			saved:    []string{"a"},
+			wait:     2 * ttl,
+			subtopic: "a",
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			for _, subtopic := range tc.saved {
+				require.Nil(t, tc.store.Save(context.Background(), msg(subtopic)), fmt.Sprintf("saving message expected to succeed on subtopic %s", subtopic))
+			}
+			time.Sleep(tc.wait)
+			_, err := tc.store.Retrieve(context.Background(), chanID, tc.subtopic)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
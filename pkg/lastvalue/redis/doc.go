@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redis contains the Redis implementation of the retained last-value
+// store.
+package redis
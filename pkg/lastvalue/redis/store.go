@@ -0,0 +1,68 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/lastvalue"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+)
+
+const keyPrefix = "last_value"
+
+var _ lastvalue.Store = (*store)(nil)
+
+type store struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewStore returns Redis last-value store implementation. The values expire
+// after the ttl duration, or never if the ttl is zero.
+func NewStore(client *redis.Client, ttl time.Duration) lastvalue.Store {
+	return &store{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (s *store) Save(ctx context.Context, msg *messaging.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	key := fmt.Sprintf("%s:%s", keyPrefix, lastvalue.Key(msg.GetChannel(), msg.GetSubtopic()))
+	if err := s.client.Set(ctx, key, data, s.ttl).Err(); err != nil {
+		return errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	return nil
+}
+
+func (s *store) Retrieve(ctx context.Context, chanID, subtopic string) (*messaging.Message, error) {
+	key := fmt.Sprintf("%s:%s", keyPrefix, lastvalue.Key(chanID, subtopic))
+	data, err := s.client.Get(ctx, key).Bytes()
+	// Redis returns Nil Reply when key does not exist.
+	if err == redis.Nil {
+		return nil, repoerr.ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	var msg messaging.Message
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return &msg, nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/lastvalue/redis"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSave(t *testing.T) {
+	store := redis.NewStore(redisClient, time.Minute)
+
+	chanID := testsutil.GenerateUUID(t)
+	cases := []struct {
+		desc string
+		msg  *messaging.Message
+		err  error
+	}{
+		{
+			desc: "save message with subtopic",
+			msg: &messaging.Message{
+				Channel:  chanID,
+				Subtopic: "desired",
+				Payload:  []byte(`{"state":"on"}`),
+				Created:  time.Now().UnixNano(),
+			},
+		},
+		{
+			desc: "save message without subtopic",
+			msg: &messaging.Message{
+				Channel: chanID,
+				Payload: []byte(`{"temp":21}`),
+				Created: time.Now().UnixNano(),
+			},
+		},
+		{
+			desc: "save newer message with subtopic",
+			msg: &messaging.Message{
+				Channel:  chanID,
+				Subtopic: "desired",
+				Payload:  []byte(`{"state":"off"}`),
+				Headers:  map[string]string{messaging.ContentTypeHeader: "application/json"},
+				Created:  time.Now().UnixNano(),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := store.Save(context.Background(), tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			msg, err := store.Retrieve(context.Background(), tc.msg.GetChannel(), tc.msg.GetSubtopic())
+			require.Nil(t, err, fmt.Sprintf("%s: unexpected error %s\n", tc.desc, err))
+			assert.Equal(t, tc.msg.String(), msg.String(), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.msg, msg))
+		})
+	}
+}
+
+func TestRetrieve(t *testing.T) {
+	store := redis.NewStore(redisClient, time.Minute)
+
+	msg := &messaging.Message{
+		Channel:  testsutil.GenerateUUID(t),
+		Subtopic: "desired",
+		Payload:  []byte(`{"state":"on"}`),
+		Created:  time.Now().UnixNano(),
+	}
+	err := store.Save(context.Background(), msg)
+	require.Nil(t, err, fmt.Sprintf("saving message expected to succeed: %s", err))
+
+	cases := []struct {
+		desc     string
+		chanID   string
+		subtopic string
+		msg      *messaging.Message
+		err      error
+	}{
+		{
+			desc:     "retrieve existing last value",
+			chanID:   msg.Channel,
+			subtopic: msg.Subtopic,
+			msg:      msg,
+		},
+		{
+			desc:     "retrieve last value of another subtopic",
+			chanID:   msg.Channel,
+			subtopic: "reported",
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:   "retrieve last value of non-existing channel",
+			chanID: testsutil.GenerateUUID(t),
+			err:    repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg, err := store.Retrieve(context.Background(), tc.chanID, tc.subtopic)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.msg.String(), msg.String(), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.msg, msg))
+			}
+		})
+	}
+}
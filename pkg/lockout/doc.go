@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lockout contains the failed authentication attempts tracker, which
+// slows down and temporarily locks out the identities and sources that
+// repeatedly fail to authenticate.
+package lockout
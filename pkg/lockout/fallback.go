@@ -0,0 +1,61 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var _ Tracker = (*fallbackTracker)(nil)
+
+type fallbackTracker struct {
+	primary  Tracker
+	fallback Tracker
+	logger   *slog.Logger
+}
+
+// NewFallbackTracker returns a tracker that uses the primary tracker, and the
+// fallback tracker while the primary one is not available, so the failed
+// attempts are still limited if the shared store is down.
+func NewFallbackTracker(primary, fallback Tracker, logger *slog.Logger) Tracker {
+	return &fallbackTracker{
+		primary:  primary,
+		fallback: fallback,
+		logger:   logger,
+	}
+}
+
+func (ft *fallbackTracker) Check(ctx context.Context, keys ...string) error {
+	err := ft.primary.Check(ctx, keys...)
+	if err == nil || errors.Contains(err, ErrLocked) {
+		return err
+	}
+	ft.logger.Warn("failed to check failed attempts, using fallback tracker", slog.Any("error", err))
+
+	return ft.fallback.Check(ctx, keys...)
+}
+
+func (ft *fallbackTracker) Fail(ctx context.Context, keys ...string) ([]string, error) {
+	locked, err := ft.primary.Fail(ctx, keys...)
+	if err == nil {
+		return locked, nil
+	}
+	ft.logger.Warn("failed to record failed attempt, using fallback tracker", slog.Any("error", err))
+
+	return ft.fallback.Fail(ctx, keys...)
+}
+
+func (ft *fallbackTracker) Reset(ctx context.Context, keys ...string) error {
+	if err := ft.fallback.Reset(ctx, keys...); err != nil {
+		return err
+	}
+	if err := ft.primary.Reset(ctx, keys...); err != nil {
+		ft.logger.Warn("failed to reset failed attempts", slog.Any("error", err))
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const (
+	realIPHeader    = "X-Real-IP"
+	forwardedHeader = "X-Forwarded-For"
+	keySeparator    = ":"
+
+	// SourceKind is the kind of the request source keys.
+	SourceKind = "source"
+)
+
+var (
+	// ErrLocked indicates that the identity or the source is blocked due to
+	// too many failed attempts.
+	ErrLocked = errors.New("too many failed attempts, try again later")
+
+	// ErrLockout indicates that the failed attempt locked out the identity or
+	// the source. It is returned by the services, while the tracker returns
+	// the locked out keys.
+	ErrLockout = errors.New("locked out after too many failed attempts")
+
+	errInvalidProxy = errors.New("invalid trusted proxy address")
+)
+
+// Config contains the failed attempts tracker configuration.
+type Config struct {
+	// MaxAttempts is the number of consecutive failed attempts after which
+	// the key is locked out. Zero disables the tracking.
+	MaxAttempts uint64 `env:"MAX_ATTEMPTS"  envDefault:"5"`
+	// BaseDelay is the time the key is blocked for after the first failed
+	// attempt. The delay doubles with each next failed attempt.
+	BaseDelay time.Duration `env:"BASE_DELAY"    envDefault:"1s"`
+	// MaxDelay caps the delay between the failed attempts.
+	MaxDelay time.Duration `env:"MAX_DELAY"     envDefault:"30s"`
+	// Duration is the time the key is locked out for.
+	Duration time.Duration `env:"DURATION"      envDefault:"15m"`
+	// Window is the time after the last failed attempt in which the failed
+	// attempts are counted.
+	Window time.Duration `env:"WINDOW"        envDefault:"1h"`
+}
+
+// Block returns the time the key is blocked for after the given number of
+// consecutive failed attempts, and whether the key is locked out.
+func (cfg Config) Block(failures uint64) (time.Duration, bool) {
+	if cfg.MaxAttempts == 0 || failures == 0 {
+		return 0, false
+	}
+	if failures >= cfg.MaxAttempts {
+		return cfg.Duration, true
+	}
+
+	delay := cfg.BaseDelay
+	for i := uint64(1); i < failures && delay < cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, cfg.MaxDelay), false
+}
+
+// TTL returns the time the failed attempts are kept for after the key is
+// blocked for the given duration.
+func (cfg Config) TTL(block time.Duration) time.Duration {
+	return max(cfg.Window, block)
+}
+
+// Tracker tracks the failed authentication attempts per key. The key is an
+// identity, such as the user or client ID, or a source, such as an IP address.
+//
+//go:generate mockery --name Tracker --output=./mocks --filename tracker.go --quiet --note "Copyright (c) Abstract Machines"
+type Tracker interface {
+	// Check returns ErrLocked if any of the keys is blocked.
+	Check(ctx context.Context, keys ...string) error
+
+	// Fail records a failed attempt for each of the keys, blocks them for an
+	// exponentially growing delay and returns the keys locked out by the
+	// attempt.
+	Fail(ctx context.Context, keys ...string) ([]string, error)
+
+	// Reset removes the failed attempts of the keys and unblocks them.
+	Reset(ctx context.Context, keys ...string) error
+}
+
+// Key returns the tracker key of the identity or the source of the given kind,
+// e.g. the user ID or the IP address.
+func Key(kind, id string) string {
+	return kind + keySeparator + id
+}
+
+// ParseKey returns the kind and the ID of the tracker key.
+func ParseKey(key string) (kind, id string) {
+	kind, id, _ = strings.Cut(key, keySeparator)
+	return kind, id
+}
+
+type sourceKey struct{}
+
+// WithSource returns a context that carries the source of the request.
+func WithSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// Source returns the source of the request carried by the context.
+func Source(ctx context.Context) string {
+	source, _ := ctx.Value(sourceKey{}).(string)
+	return source
+}
+
+// TrustedProxies contains the networks of the reverse proxies which are
+// trusted to set the X-Real-IP header.
+type TrustedProxies []netip.Prefix
+
+// ParseTrustedProxies parses the IP addresses and the CIDR ranges of the
+// trusted reverse proxies.
+func ParseTrustedProxies(addrs []string) (TrustedProxies, error) {
+	var proxies TrustedProxies
+	for _, addr := range addrs {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		if strings.Contains(addr, "/") {
+			prefix, err := netip.ParsePrefix(addr)
+			if err != nil {
+				return nil, errors.Wrap(errInvalidProxy, err)
+			}
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+		ip, err := netip.ParseAddr(addr)
+		if err != nil {
+			return nil, errors.Wrap(errInvalidProxy, err)
+		}
+		proxies = append(proxies, netip.PrefixFrom(ip, ip.BitLen()))
+	}
+
+	return proxies, nil
+}
+
+// RequestSource returns the client IP address of the HTTP request. The
+// X-Real-IP header is honoured only if the request comes from one of the
+// trusted proxies, so the clients can't spoof their address.
+func (tp TrustedProxies) RequestSource(r *http.Request) string {
+	remote := RequestSource(r)
+	if len(tp) == 0 {
+		return remote
+	}
+	ip, err := netip.ParseAddr(remote)
+	if err != nil {
+		return remote
+	}
+	if !tp.contains(ip) {
+		return remote
+	}
+	if realIP := strings.TrimSpace(r.Header.Get(realIPHeader)); realIP != "" {
+		return realIP
+	}
+
+	return remote
+}
+
+// ConnSource returns the remote IP address of the connection. The connections
+// of the trusted proxies have no source, since they don't carry the address of
+// the clients behind the proxy.
+func (tp TrustedProxies) ConnSource(addr net.Addr) string {
+	remote := addr.String()
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		remote = host
+	}
+	if ip, err := netip.ParseAddr(remote); err == nil && tp.contains(ip) {
+		return ""
+	}
+
+	return remote
+}
+
+func (tp TrustedProxies) contains(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	for _, prefix := range tp {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Untrusted reports whether the request was forwarded by a reverse proxy while
+// no trusted proxies are configured. The remote address of such requests is
+// the address of the proxy, which is shared by all the clients behind it.
+func (tp TrustedProxies) Untrusted(r *http.Request) bool {
+	if len(tp) > 0 {
+		return false
+	}
+
+	return r.Header.Get(realIPHeader) != "" || r.Header.Get(forwardedHeader) != ""
+}
+
+// RequestSource returns the remote IP address of the HTTP request. Use
+// TrustedProxies to honour the X-Real-IP header set by the reverse proxy.
+func RequestSource(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
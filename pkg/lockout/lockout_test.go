@@ -0,0 +1,314 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	smqlog "github.com/absmach/supermq/logger"
+	smqerrors "github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/lockout"
+	"github.com/absmach/supermq/pkg/lockout/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var cfg = lockout.Config{
+	MaxAttempts: 4,
+	BaseDelay:   10 * time.Millisecond,
+	MaxDelay:    25 * time.Millisecond,
+	Duration:    time.Hour,
+	Window:      time.Hour,
+}
+
+func TestBlock(t *testing.T) {
+	cases := []struct {
+		desc     string
+		cfg      lockout.Config
+		failures uint64
+		block    time.Duration
+		locked   bool
+	}{
+		{
+			desc:     "block without failures",
+			cfg:      cfg,
+			failures: 0,
+			block:    0,
+		},
+		{
+			desc:     "block after first failure",
+			cfg:      cfg,
+			failures: 1,
+			block:    10 * time.Millisecond,
+		},
+		{
+			desc:     "block after second failure",
+			cfg:      cfg,
+			failures: 2,
+			block:    20 * time.Millisecond,
+		},
+		{
+			desc:     "block after third failure is capped",
+			cfg:      cfg,
+			failures: 3,
+			block:    25 * time.Millisecond,
+		},
+		{
+			desc:     "block after max attempts",
+			cfg:      cfg,
+			failures: 4,
+			block:    time.Hour,
+			locked:   true,
+		},
+		{
+			desc:     "block with disabled tracking",
+			cfg:      lockout.Config{},
+			failures: 10,
+			block:    0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			block, locked := tc.cfg.Block(tc.failures)
+			assert.Equal(t, tc.block, block)
+			assert.Equal(t, tc.locked, locked)
+		})
+	}
+}
+
+func TestMemoryTracker(t *testing.T) {
+	tracker := lockout.NewMemoryTracker(cfg)
+	ctx := context.Background()
+	key := "user:1"
+	other := "user:2"
+
+	err := tracker.Check(ctx, key)
+	assert.Nil(t, err, fmt.Sprintf("check without failures: expected nil got %s", err))
+
+	locked, err := tracker.Fail(ctx, key)
+	assert.Nil(t, err, fmt.Sprintf("first failure: expected nil got %s", err))
+	assert.Empty(t, locked, "first failure: expected no locked out keys")
+	err = tracker.Check(ctx, key, other)
+	assert.True(t, smqerrors.Contains(err, lockout.ErrLocked), fmt.Sprintf("check during delay: expected %s got %s", lockout.ErrLocked, err))
+	err = tracker.Check(ctx, other)
+	assert.Nil(t, err, fmt.Sprintf("check other key: expected nil got %s", err))
+
+	time.Sleep(cfg.BaseDelay)
+	err = tracker.Check(ctx, key)
+	assert.Nil(t, err, fmt.Sprintf("check after delay: expected nil got %s", err))
+
+	for i := 2; i < int(cfg.MaxAttempts); i++ {
+		locked, err = tracker.Fail(ctx, key)
+		assert.Nil(t, err, fmt.Sprintf("failure %d: expected nil got %s", i, err))
+		assert.Empty(t, locked, fmt.Sprintf("failure %d: expected no locked out keys", i))
+	}
+	locked, err = tracker.Fail(ctx, key, other)
+	assert.Nil(t, err, fmt.Sprintf("last failure: expected nil got %s", err))
+	assert.Equal(t, []string{key}, locked)
+
+	time.Sleep(cfg.MaxDelay)
+	err = tracker.Check(ctx, key)
+	assert.True(t, smqerrors.Contains(err, lockout.ErrLocked), fmt.Sprintf("check after lockout: expected %s got %s", lockout.ErrLocked, err))
+
+	err = tracker.Reset(ctx, key)
+	assert.Nil(t, err, fmt.Sprintf("reset: expected nil got %s", err))
+	err = tracker.Check(ctx, key)
+	assert.Nil(t, err, fmt.Sprintf("check after reset: expected nil got %s", err))
+}
+
+func TestFallbackTracker(t *testing.T) {
+	errStore := errors.New("store unavailable")
+	ctx := context.Background()
+
+	cases := []struct {
+		desc        string
+		primaryErr  error
+		fallbackErr error
+		err         error
+	}{
+		{
+			desc: "primary tracker succeeds",
+		},
+		{
+			desc:       "primary tracker blocks",
+			primaryErr: lockout.ErrLocked,
+			err:        lockout.ErrLocked,
+		},
+		{
+			desc:        "primary tracker fails",
+			primaryErr:  errStore,
+			fallbackErr: lockout.ErrLocked,
+			err:         lockout.ErrLocked,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			primary := new(mocks.Tracker)
+			fallback := new(mocks.Tracker)
+			primary.On("Check", ctx, "key").Return(tc.primaryErr)
+			fallback.On("Check", ctx, "key").Return(tc.fallbackErr)
+			tracker := lockout.NewFallbackTracker(primary, fallback, smqlog.NewMock())
+
+			err := tracker.Check(ctx, "key")
+			assert.True(t, smqerrors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			if tc.primaryErr != errStore {
+				fallback.AssertNotCalled(t, "Check", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	key := lockout.Key(lockout.SourceKind, "10.0.0.7")
+	assert.Equal(t, "source:10.0.0.7", key)
+
+	kind, id := lockout.ParseKey(key)
+	assert.Equal(t, lockout.SourceKind, kind)
+	assert.Equal(t, "10.0.0.7", id)
+}
+
+func TestRequestSource(t *testing.T) {
+	proxies, err := lockout.ParseTrustedProxies([]string{"172.18.0.0/16", "10.1.1.1"})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cases := []struct {
+		desc       string
+		proxies    lockout.TrustedProxies
+		remoteAddr string
+		realIP     string
+		source     string
+		untrusted  bool
+	}{
+		{
+			desc:       "source from remote address",
+			proxies:    proxies,
+			remoteAddr: "192.168.0.10:52344",
+			source:     "192.168.0.10",
+		},
+		{
+			desc:       "source from real IP header set by trusted proxy",
+			proxies:    proxies,
+			remoteAddr: "172.18.0.2:52344",
+			realIP:     "10.0.0.7",
+			source:     "10.0.0.7",
+		},
+		{
+			desc:       "source from real IP header set by trusted proxy address",
+			proxies:    proxies,
+			remoteAddr: "10.1.1.1:52344",
+			realIP:     "10.0.0.7",
+			source:     "10.0.0.7",
+		},
+		{
+			desc:       "source from remote address of trusted proxy without real IP header",
+			proxies:    proxies,
+			remoteAddr: "172.18.0.2:52344",
+			source:     "172.18.0.2",
+		},
+		{
+			desc:       "source from remote address with real IP header set by untrusted source",
+			proxies:    proxies,
+			remoteAddr: "192.168.0.10:52344",
+			realIP:     "10.0.0.7",
+			source:     "192.168.0.10",
+		},
+		{
+			desc:       "source from remote address with real IP header without trusted proxies",
+			remoteAddr: "172.18.0.2:52344",
+			realIP:     "10.0.0.7",
+			source:     "172.18.0.2",
+			untrusted:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodPost, "http://localhost/users/tokens/issue", nil)
+			assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+			r.RemoteAddr = tc.remoteAddr
+			if tc.realIP != "" {
+				r.Header.Set("X-Real-IP", tc.realIP)
+			}
+			assert.Equal(t, tc.source, tc.proxies.RequestSource(r))
+			assert.Equal(t, tc.untrusted, tc.proxies.Untrusted(r))
+		})
+	}
+}
+
+func TestConnSource(t *testing.T) {
+	proxies, err := lockout.ParseTrustedProxies([]string{"172.18.0.0/16"})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error %s", err))
+
+	cases := []struct {
+		desc    string
+		proxies lockout.TrustedProxies
+		addr    net.Addr
+		source  string
+	}{
+		{
+			desc:    "source from remote address",
+			proxies: proxies,
+			addr:    &net.TCPAddr{IP: net.ParseIP("192.168.0.10"), Port: 52344},
+			source:  "192.168.0.10",
+		},
+		{
+			desc:   "source from remote address without trusted proxies",
+			addr:   &net.TCPAddr{IP: net.ParseIP("172.18.0.2"), Port: 52344},
+			source: "172.18.0.2",
+		},
+		{
+			desc:    "source from remote address of trusted proxy",
+			proxies: proxies,
+			addr:    &net.TCPAddr{IP: net.ParseIP("172.18.0.2"), Port: 52344},
+			source:  "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.source, tc.proxies.ConnSource(tc.addr))
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	cases := []struct {
+		desc    string
+		addrs   []string
+		proxies int
+		err     bool
+	}{
+		{
+			desc:    "parse addresses and ranges",
+			addrs:   []string{"10.0.0.1", " 172.18.0.0/16", "", "fd00::/8"},
+			proxies: 3,
+		},
+		{
+			desc:  "parse invalid address",
+			addrs: []string{"proxy"},
+			err:   true,
+		},
+		{
+			desc:  "parse invalid range",
+			addrs: []string{"10.0.0.0/40"},
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			proxies, err := lockout.ParseTrustedProxies(tc.addrs)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("unexpected error %s", err))
+			assert.Len(t, proxies, tc.proxies)
+		})
+	}
+}
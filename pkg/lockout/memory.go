@@ -0,0 +1,100 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package lockout
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var _ Tracker = (*memoryTracker)(nil)
+
+type attempts struct {
+	failures     uint64
+	blockedUntil time.Time
+	expiresAt    time.Time
+}
+
+type memoryTracker struct {
+	cfg       Config
+	mu        sync.Mutex
+	attempts  map[string]attempts
+	lastSweep time.Time
+}
+
+// NewMemoryTracker returns an in-memory failed attempts tracker. The failed
+// attempts are local to the service instance and lost on restart.
+func NewMemoryTracker(cfg Config) Tracker {
+	return &memoryTracker{
+		cfg:       cfg,
+		attempts:  make(map[string]attempts),
+		lastSweep: time.Now(),
+	}
+}
+
+func (mt *memoryTracker) Check(_ context.Context, keys ...string) error {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	now := time.Now()
+	for _, key := range keys {
+		if a, ok := mt.attempts[key]; ok && now.Before(a.blockedUntil) {
+			return ErrLocked
+		}
+	}
+
+	return nil
+}
+
+func (mt *memoryTracker) Fail(_ context.Context, keys ...string) ([]string, error) {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	now := time.Now()
+	mt.sweep(now)
+
+	var lockedOut []string
+	for _, key := range keys {
+		a := mt.attempts[key]
+		if now.After(a.expiresAt) {
+			a = attempts{}
+		}
+		a.failures++
+		block, locked := mt.cfg.Block(a.failures)
+		a.blockedUntil = now.Add(block)
+		a.expiresAt = now.Add(mt.cfg.TTL(block))
+		mt.attempts[key] = a
+		if locked {
+			lockedOut = append(lockedOut, key)
+		}
+	}
+
+	return lockedOut, nil
+}
+
+func (mt *memoryTracker) Reset(_ context.Context, keys ...string) error {
+	mt.mu.Lock()
+	defer mt.mu.Unlock()
+
+	for _, key := range keys {
+		delete(mt.attempts, key)
+	}
+
+	return nil
+}
+
+// sweep removes the expired attempts at most once per window, so the
+// attempts of the keys that are never retried do not pile up.
+func (mt *memoryTracker) sweep(now time.Time) {
+	if now.Sub(mt.lastSweep) < mt.cfg.Window {
+		return
+	}
+	for key, a := range mt.attempts {
+		if now.After(a.expiresAt) {
+			delete(mt.attempts, key)
+		}
+	}
+	mt.lastSweep = now
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/absmach/supermq/pkg/lockout"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix         = "lockout"
+	failuresField     = "failures"
+	blockedUntilField = "blocked_until"
+)
+
+var _ lockout.Tracker = (*tracker)(nil)
+
+type tracker struct {
+	client *redis.Client
+	cfg    lockout.Config
+}
+
+// NewTracker returns Redis failed attempts tracker implementation, which
+// shares the failed attempts between the service instances.
+func NewTracker(client *redis.Client, cfg lockout.Config) lockout.Tracker {
+	return &tracker{
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+func (t *tracker) Check(ctx context.Context, keys ...string) error {
+	cmds := make([]*redis.StringCmd, len(keys))
+	if _, err := t.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.HGet(ctx, redisKey(key), blockedUntilField)
+		}
+		return nil
+	}); err != nil && err != redis.Nil {
+		return err
+	}
+
+	now := time.Now().UnixMilli()
+	for _, cmd := range cmds {
+		blockedUntil, err := cmd.Int64()
+		// Redis returns Nil Reply when key does not exist.
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if now < blockedUntil {
+			return lockout.ErrLocked
+		}
+	}
+
+	return nil
+}
+
+func (t *tracker) Fail(ctx context.Context, keys ...string) ([]string, error) {
+	var lockedOut []string
+	for _, key := range keys {
+		rkey := redisKey(key)
+		failures, err := t.client.HIncrBy(ctx, rkey, failuresField, 1).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		block, locked := t.cfg.Block(uint64(failures))
+		blockedUntil := time.Now().Add(block).UnixMilli()
+		if _, err := t.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, rkey, blockedUntilField, strconv.FormatInt(blockedUntil, 10))
+			pipe.PExpire(ctx, rkey, t.cfg.TTL(block))
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		if locked {
+			lockedOut = append(lockedOut, key)
+		}
+	}
+
+	return lockedOut, nil
+}
+
+func (t *tracker) Reset(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	redisKeys := make([]string, len(keys))
+	for i, key := range keys {
+		redisKeys[i] = redisKey(key)
+	}
+
+	return t.client.Del(ctx, redisKeys...).Err()
+}
+
+func redisKey(key string) string {
+	return fmt.Sprintf("%s:%s", keyPrefix, key)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package redis_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/lockout"
+	"github.com/absmach/supermq/pkg/lockout/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker(t *testing.T) {
+	cfg := lockout.Config{
+		MaxAttempts: 3,
+		BaseDelay:   10 * time.Millisecond,
+		MaxDelay:    20 * time.Millisecond,
+		Duration:    time.Hour,
+		Window:      time.Hour,
+	}
+	tracker := redis.NewTracker(redisClient, cfg)
+	ctx := context.Background()
+	key := "user:" + testsutil.GenerateUUID(t)
+	source := "source:" + testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		keys     []string
+		fail     bool
+		wait     time.Duration
+		locked   []string
+		checkErr error
+	}{
+		{
+			desc: "check without failures",
+			keys: []string{key, source},
+		},
+		{
+			desc:     "check after first failure",
+			keys:     []string{key, source},
+			fail:     true,
+			checkErr: lockout.ErrLocked,
+		},
+		{
+			desc: "check after delay",
+			keys: []string{key, source},
+			wait: cfg.BaseDelay,
+		},
+		{
+			desc:     "check after second failure",
+			keys:     []string{key},
+			fail:     true,
+			checkErr: lockout.ErrLocked,
+		},
+		{
+			desc:     "check after lockout",
+			keys:     []string{key},
+			fail:     true,
+			wait:     cfg.MaxDelay,
+			locked:   []string{key},
+			checkErr: lockout.ErrLocked,
+		},
+		{
+			desc: "check source not locked out",
+			keys: []string{source},
+			wait: cfg.MaxDelay,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.fail {
+				locked, err := tracker.Fail(ctx, tc.keys...)
+				assert.Nil(t, err, fmt.Sprintf("%s: expected nil got %s", tc.desc, err))
+				assert.Equal(t, tc.locked, locked, fmt.Sprintf("%s: expected locked out keys %v got %v", tc.desc, tc.locked, locked))
+			}
+			time.Sleep(tc.wait)
+			err := tracker.Check(ctx, tc.keys...)
+			assert.True(t, errors.Contains(err, tc.checkErr), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.checkErr, err))
+		})
+	}
+
+	err := tracker.Reset(ctx, key)
+	assert.Nil(t, err, fmt.Sprintf("reset: expected nil got %s", err))
+	err = tracker.Check(ctx, key)
+	assert.Nil(t, err, fmt.Sprintf("check after reset: expected nil got %s", err))
+}
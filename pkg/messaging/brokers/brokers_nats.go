@@ -18,6 +18,13 @@ import (
 // SubjectAllChannels represents subject to subscribe for all the channels.
 const SubjectAllChannels = "channels.>"
 
+// SubjectAllRules represents subject to subscribe for all the messages passed
+// on by the rules engine.
+const SubjectAllRules = "rules.>"
+
+// RulesPrefix is the prefix of the messages passed on by the rules engine.
+const RulesPrefix = "rules"
+
 func init() {
 	log.Println("The binary was build using Nats as the message broker")
 }
@@ -39,3 +46,8 @@ func NewPubSub(ctx context.Context, url string, logger *slog.Logger, opts ...mes
 
 	return pb, nil
 }
+
+// Prefix sets the prefix of the publisher subjects.
+func Prefix(prefix string) messaging.Option {
+	return nats.Prefix(prefix)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging
+
+import (
+	"strconv"
+	"time"
+)
+
+// Well-known message header keys. The keys are named after the corresponding
+// MQTT 5 properties, so the headers are consistent across the protocols.
+const (
+	// ContentTypeHeader is the MIME type of the message payload.
+	ContentTypeHeader = "content-type"
+
+	// ResponseTopicHeader is the topic the response to the message should be published to.
+	ResponseTopicHeader = "response-topic"
+
+	// CorrelationDataHeader is used to correlate the response with the request.
+	CorrelationDataHeader = "correlation-data"
+
+	// MessageExpiryHeader is the message lifetime in seconds.
+	MessageExpiryHeader = "message-expiry-interval"
+)
+
+// Expiry returns the time the message expires at, which is the time the
+// message is created at increased by its lifetime. The second value is false
+// if the message has no valid message expiry header, so it doesn't expire.
+func Expiry(msg *Message) (time.Time, bool) {
+	val, ok := msg.GetHeaders()[MessageExpiryHeader]
+	if !ok {
+		return time.Time{}, false
+	}
+	secs, err := strconv.ParseUint(val, 10, 32)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, msg.GetCreated()).Add(time.Duration(secs) * time.Second), true
+}
+
+// Expired reports whether the message lifetime elapsed at the given time.
+// Expired messages must not be delivered to the subscribers.
+func Expired(msg *Message, now time.Time) bool {
+	exp, ok := Expiry(msg)
+	return ok && !now.Before(exp)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package messaging_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpired(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		desc    string
+		headers map[string]string
+		created time.Time
+		expired bool
+	}{
+		{
+			desc:    "message without expiry",
+			created: now.Add(-time.Hour),
+			expired: false,
+		},
+		{
+			desc:    "message within lifetime",
+			headers: map[string]string{messaging.MessageExpiryHeader: "60"},
+			created: now.Add(-30 * time.Second),
+			expired: false,
+		},
+		{
+			desc:    "message after lifetime",
+			headers: map[string]string{messaging.MessageExpiryHeader: "60"},
+			created: now.Add(-time.Minute),
+			expired: true,
+		},
+		{
+			desc:    "message with invalid expiry",
+			headers: map[string]string{messaging.MessageExpiryHeader: "-1"},
+			created: now.Add(-time.Hour),
+			expired: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg := &messaging.Message{Headers: tc.headers, Created: tc.created.UnixNano()}
+			expired := messaging.Expired(msg, now)
+			assert.Equal(t, tc.expired, expired, fmt.Sprintf("%s: expected %t got %t", tc.desc, tc.expired, expired))
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/eclipse/paho.golang/packets"
+)
+
+// ErrCorrelationData indicates that the correlation data can't be carried by
+// the message headers, which are UTF-8 strings.
+var ErrCorrelationData = errors.New("correlation data is not a valid UTF-8 string")
+
+// Headers returns the message headers of the MQTT 5 publish properties. The
+// content type, response topic, correlation data and message expiry interval
+// are mapped to the well-known headers, and the user properties are mapped to
+// the headers of the same name. If the user property is repeated, the last
+// value is used. The well-known headers take precedence over the user
+// properties of the same name.
+func Headers(props *packets.Properties) (map[string]string, error) {
+	if props == nil {
+		return nil, nil
+	}
+
+	headers := make(map[string]string)
+	for _, u := range props.User {
+		headers[u.Key] = u.Value
+	}
+	if props.ContentType != "" {
+		headers[messaging.ContentTypeHeader] = props.ContentType
+	}
+	if props.ResponseTopic != "" {
+		headers[messaging.ResponseTopicHeader] = props.ResponseTopic
+	}
+	if len(props.CorrelationData) > 0 {
+		if !utf8.Valid(props.CorrelationData) {
+			return nil, ErrCorrelationData
+		}
+		headers[messaging.CorrelationDataHeader] = string(props.CorrelationData)
+	}
+	if props.MessageExpiry != nil {
+		headers[messaging.MessageExpiryHeader] = strconv.FormatUint(uint64(*props.MessageExpiry), 10)
+	}
+	if len(headers) == 0 {
+		return nil, nil
+	}
+
+	return headers, nil
+}
+
+// Properties returns the MQTT 5 publish properties of the message headers.
+// The well-known headers are mapped to the corresponding properties, and the
+// other headers are mapped to the user properties. The message expiry interval
+// is the remaining lifetime of the message at the given time, rounded up to
+// the second.
+func Properties(msg *messaging.Message, now time.Time) *packets.Properties {
+	props := &packets.Properties{}
+	headers := msg.GetHeaders()
+	keys := make([]string, 0, len(headers))
+	for key := range headers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val := headers[key]
+		switch key {
+		case messaging.ContentTypeHeader:
+			props.ContentType = val
+		case messaging.ResponseTopicHeader:
+			props.ResponseTopic = val
+		case messaging.CorrelationDataHeader:
+			props.CorrelationData = []byte(val)
+		case messaging.MessageExpiryHeader:
+			if exp, ok := messaging.Expiry(msg); ok {
+				secs := uint32(min(max(math.Ceil(exp.Sub(now).Seconds()), 1), math.MaxUint32))
+				props.MessageExpiry = &secs
+			}
+		default:
+			props.User = append(props.User, packets.User{Key: key, Value: val})
+		}
+	}
+
+	return props
+}
@@ -0,0 +1,120 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package mqtt_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	mqttpubsub "github.com/absmach/supermq/pkg/messaging/mqtt"
+	"github.com/eclipse/paho.golang/packets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaders(t *testing.T) {
+	expiry := uint32(60)
+
+	cases := []struct {
+		desc    string
+		props   *packets.Properties
+		headers map[string]string
+		err     error
+	}{
+		{
+			desc:  "headers of empty properties",
+			props: &packets.Properties{},
+		},
+		{
+			desc: "headers of properties",
+			props: &packets.Properties{
+				ContentType:     "application/json",
+				ResponseTopic:   "channels/1/messages/response",
+				CorrelationData: []byte("request-1"),
+				MessageExpiry:   &expiry,
+				User: []packets.User{
+					{Key: "key", Value: "first"},
+					{Key: "key", Value: "value"},
+					{Key: messaging.ContentTypeHeader, Value: "text/plain"},
+				},
+			},
+			headers: map[string]string{
+				messaging.ContentTypeHeader:     "application/json",
+				messaging.ResponseTopicHeader:   "channels/1/messages/response",
+				messaging.CorrelationDataHeader: "request-1",
+				messaging.MessageExpiryHeader:   "60",
+				"key":                           "value",
+			},
+		},
+		{
+			desc:  "headers of properties with binary correlation data",
+			props: &packets.Properties{CorrelationData: []byte{0xff, 0xfe}},
+			err:   mqttpubsub.ErrCorrelationData,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			headers, err := mqttpubsub.Headers(tc.props)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.headers, headers, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.headers, headers))
+		})
+	}
+}
+
+func TestProperties(t *testing.T) {
+	now := time.Now()
+	remaining := uint32(30)
+	minimum := uint32(1)
+
+	cases := []struct {
+		desc  string
+		msg   *messaging.Message
+		props *packets.Properties
+	}{
+		{
+			desc:  "properties of message without headers",
+			msg:   &messaging.Message{Created: now.UnixNano()},
+			props: &packets.Properties{},
+		},
+		{
+			desc: "properties of message with headers",
+			msg: &messaging.Message{
+				Created: now.Add(-30 * time.Second).UnixNano(),
+				Headers: map[string]string{
+					messaging.ContentTypeHeader:     "application/json",
+					messaging.ResponseTopicHeader:   "channels/1/messages/response",
+					messaging.CorrelationDataHeader: "request-1",
+					messaging.MessageExpiryHeader:   "60",
+					"b":                             "2",
+					"a":                             "1",
+				},
+			},
+			props: &packets.Properties{
+				ContentType:     "application/json",
+				ResponseTopic:   "channels/1/messages/response",
+				CorrelationData: []byte("request-1"),
+				MessageExpiry:   &remaining,
+				User:            []packets.User{{Key: "a", Value: "1"}, {Key: "b", Value: "2"}},
+			},
+		},
+		{
+			desc: "properties of expired message",
+			msg: &messaging.Message{
+				Created: now.Add(-time.Minute).UnixNano(),
+				Headers: map[string]string{messaging.MessageExpiryHeader: "30"},
+			},
+			props: &packets.Properties{MessageExpiry: &minimum},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			props := mqttpubsub.Properties(tc.msg, now)
+			assert.Equal(t, tc.props, props, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.props, props))
+		})
+	}
+}
@@ -6,35 +6,70 @@ package mqtt
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/absmach/supermq/pkg/messaging"
-	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
 )
 
+// keepAlive is the keep alive interval of the publisher connection in seconds.
+const keepAlive = 30
+
 var errPublishTimeout = errors.New("failed to publish due to timeout reached")
 
 var _ messaging.Publisher = (*publisher)(nil)
 
+// publisher publishes the messages using MQTT 5, so the message headers are
+// delivered to the subscribers as the message properties.
 type publisher struct {
-	client  mqtt.Client
+	client  *autopaho.ConnectionManager
 	timeout time.Duration
 	qos     uint8
 }
 
 // NewPublisher returns a new MQTT message publisher.
 func NewPublisher(address string, qos uint8, timeout time.Duration) (messaging.Publisher, error) {
-	client, err := newClient(address, "mqtt-publisher", timeout)
+	return newPublisher(address, "mqtt-publisher", qos, timeout)
+}
+
+func newPublisher(address, id string, qos uint8, timeout time.Duration) (publisher, error) {
+	if !strings.Contains(address, "://") {
+		address = "mqtt://" + address
+	}
+	u, err := url.Parse(address)
 	if err != nil {
-		return nil, err
+		return publisher{}, err
 	}
 
-	ret := publisher{
+	cfg := autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{u},
+		KeepAlive:                     keepAlive,
+		CleanStartOnInitialConnection: true,
+		ConnectTimeout:                timeout,
+		ConnectUsername:               username,
+		ClientConfig: paho.ClientConfig{
+			ClientID: id,
+		},
+	}
+	client, err := autopaho.NewConnection(context.Background(), cfg)
+	if err != nil {
+		return publisher{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := client.AwaitConnection(ctx); err != nil {
+		return publisher{}, errors.Join(ErrConnect, client.Disconnect(context.Background()))
+	}
+
+	return publisher{
 		client:  client,
 		timeout: timeout,
 		qos:     qos,
-	}
-	return ret, nil
+	}, nil
 }
 
 func (pub publisher) Publish(ctx context.Context, topic string, msg *messaging.Message) error {
@@ -42,20 +77,30 @@ func (pub publisher) Publish(ctx context.Context, topic string, msg *messaging.M
 		return ErrEmptyTopic
 	}
 
-	// Publish only the payload and not the whole message.
-	token := pub.client.Publish(topic, byte(pub.qos), false, msg.GetPayload())
-	if token.Error() != nil {
-		return token.Error()
+	// Publish only the payload and not the whole message. The message
+	// headers are published as the message properties.
+	p := &paho.Publish{
+		Topic:   topic,
+		QoS:     pub.qos,
+		Payload: msg.GetPayload(),
 	}
+	p.InitProperties(Properties(msg, time.Now()))
 
-	if ok := token.WaitTimeout(pub.timeout); !ok {
-		return errPublishTimeout
+	ctx, cancel := context.WithTimeout(ctx, pub.timeout)
+	defer cancel()
+	if _, err := pub.client.Publish(ctx, p); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return errPublishTimeout
+		}
+		return err
 	}
 
 	return nil
 }
 
 func (pub publisher) Close() error {
-	pub.client.Disconnect(uint(pub.timeout))
-	return nil
+	ctx, cancel := context.WithTimeout(context.Background(), pub.timeout)
+	defer cancel()
+
+	return pub.client.Disconnect(ctx)
 }
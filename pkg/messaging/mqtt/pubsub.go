@@ -60,16 +60,12 @@ type pubsub struct {
 
 // NewPubSub returns MQTT message publisher/subscriber.
 func NewPubSub(url string, qos uint8, timeout time.Duration, logger *slog.Logger) (messaging.PubSub, error) {
-	client, err := newClient(url, "mqtt-publisher", timeout)
+	pub, err := newPublisher(url, "mqtt-publisher", qos, timeout)
 	if err != nil {
 		return nil, err
 	}
 	ret := &pubsub{
-		publisher: publisher{
-			client:  client,
-			timeout: timeout,
-			qos:     qos,
-		},
+		publisher:     pub,
 		address:       url,
 		timeout:       timeout,
 		logger:        logger,
@@ -43,7 +43,7 @@ func NewPublisher(ctx context.Context, url string, opts ...messaging.Option) (me
 	if err != nil {
 		return nil, err
 	}
-	if _, err := js.CreateStream(ctx, jsStreamConfig); err != nil {
+	if _, err := js.CreateOrUpdateStream(ctx, jsStreamConfig); err != nil {
 		return nil, err
 	}
 
@@ -17,7 +17,11 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
-const chansPrefix = "channels"
+const (
+	chansPrefix = "channels"
+	// rulesPrefix is the prefix of the messages passed on by the rules engine.
+	rulesPrefix = "rules"
+)
 
 // Publisher and Subscriber errors.
 var (
@@ -28,7 +32,7 @@ var (
 	jsStreamConfig = jetstream.StreamConfig{
 		Name:              "channels",
 		Description:       "SuperMQ stream for sending and receiving messages in between SuperMQ channels",
-		Subjects:          []string{"channels.>"},
+		Subjects:          []string{chansPrefix + ".>", rulesPrefix + ".>"},
 		Retention:         jetstream.LimitsPolicy,
 		MaxMsgsPerSubject: 1e6,
 		MaxAge:            time.Hour * 24,
@@ -62,7 +66,7 @@ func NewPubSub(ctx context.Context, url string, logger *slog.Logger, opts ...mes
 	if err != nil {
 		return nil, err
 	}
-	stream, err := js.CreateStream(ctx, jsStreamConfig)
+	stream, err := js.CreateOrUpdateStream(ctx, jsStreamConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -152,6 +156,13 @@ func (ps *pubsub) natsHandler(h messaging.MessageHandler, ackErr bool) func(m je
 			return
 		}
 
+		if ah, ok := h.(messaging.AckHandler); ok {
+			if err := ah.HandleAck(&msg, ps.ack(m)); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to handle SuperMQ message: %s", err))
+			}
+			return
+		}
+
 		if err := h.Handle(&msg); err != nil {
 			ps.logger.Warn(fmt.Sprintf("Failed to handle SuperMQ message: %s", err))
 			if ackErr {
@@ -167,6 +178,22 @@ func (ps *pubsub) natsHandler(h messaging.MessageHandler, ackErr bool) func(m je
 	}
 }
 
+// ack returns the callback which acknowledges the message once it's handled,
+// or requests its redelivery if the handling failed.
+func (ps *pubsub) ack(m jetstream.Msg) func(error) {
+	return func(err error) {
+		if err != nil {
+			if err := m.Nak(); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to nak message: %s", err))
+			}
+			return
+		}
+		if err := m.Ack(); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to ack message: %s", err))
+		}
+	}
+}
+
 func formatConsumerName(topic, id string) string {
 	// A durable name cannot contain whitespace, ., *, >, path separators (forward or backwards slash), and non-printable characters.
 	chars := []string{
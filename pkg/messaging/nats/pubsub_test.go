@@ -31,6 +31,7 @@ var (
 		Protocol:  "mqtt",
 		Payload:   []byte("payload"),
 		Created:   time.Now().UnixNano(),
+		Headers:   map[string]string{messaging.ContentTypeHeader: "application/json"},
 	}
 )
 
@@ -99,6 +100,7 @@ func TestPublisher(t *testing.T) {
 			assert.Equal(t, tc.message.Protocol, receivedMsg.Protocol, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, &tc.message, receivedMsg))
 			assert.Equal(t, tc.message.Publisher, receivedMsg.Publisher, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, &tc.message, receivedMsg))
 			assert.Equal(t, tc.message.Subtopic, receivedMsg.Subtopic, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, &tc.message, receivedMsg))
+			assert.Equal(t, tc.message.Headers, receivedMsg.Headers, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, &tc.message, receivedMsg))
 			assert.Equal(t, tc.message.Payload, receivedMsg.Payload, fmt.Sprintf("%s: expected %+v got %+v\n", tc.desc, &tc.message, receivedMsg))
 		}
 	}
@@ -48,7 +48,7 @@ func (pm *pubsubMiddleware) Subscribe(ctx context.Context, cfg messaging.Subscri
 
 	span.SetAttributes(defaultAttributes...)
 
-	cfg.Handler = &traceHandler{
+	th := &traceHandler{
 		ctx:      ctx,
 		handler:  cfg.Handler,
 		tracer:   pm.tracer,
@@ -56,6 +56,10 @@ func (pm *pubsubMiddleware) Subscribe(ctx context.Context, cfg messaging.Subscri
 		topic:    cfg.Topic,
 		clientID: cfg.ID,
 	}
+	cfg.Handler = th
+	if ah, ok := th.handler.(messaging.AckHandler); ok {
+		cfg.Handler = &traceAckHandler{traceHandler: th, ackHandler: ah}
+	}
 
 	return pm.pubsub.Subscribe(ctx, cfg)
 }
@@ -94,3 +98,20 @@ func (h *traceHandler) Handle(msg *messaging.Message) error {
 func (h *traceHandler) Cancel() error {
 	return h.handler.Cancel()
 }
+
+// traceAckHandler is used to trace the handling operation of the
+// handlers which acknowledge messages asynchronously.
+type traceAckHandler struct {
+	*traceHandler
+	ackHandler messaging.AckHandler
+}
+
+// HandleAck instruments the message handling operation.
+func (h *traceAckHandler) HandleAck(msg *messaging.Message, ack func(error)) error {
+	_, span := tracing.CreateSpan(h.ctx, processOp, h.clientID, h.topic, msg.GetSubtopic(), len(msg.GetPayload()), h.host, trace.SpanKindConsumer, h.tracer)
+	defer span.End()
+
+	span.SetAttributes(defaultAttributes...)
+
+	return h.ackHandler.HandleAck(msg, ack)
+}
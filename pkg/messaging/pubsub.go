@@ -34,6 +34,19 @@ type MessageHandler interface {
 	Cancel() error
 }
 
+// AckHandler represents a MessageHandler which acknowledges messages
+// asynchronously. Subscribers which support it call HandleAck instead of
+// Handle and acknowledge the message to the broker only once ack is called
+// with a nil error. A non-nil error passed to ack requests redelivery.
+type AckHandler interface {
+	MessageHandler
+
+	// HandleAck accepts the message for handling. The returned error
+	// indicates that the message was not accepted, in which case ack
+	// is not called.
+	HandleAck(msg *Message, ack func(error)) error
+}
+
 type SubscriberConfig struct {
 	ID             string
 	Topic          string
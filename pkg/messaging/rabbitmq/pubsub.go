@@ -122,7 +122,10 @@ func (ps *pubsub) Subscribe(ctx context.Context, cfg messaging.SubscriberConfig)
 		return err
 	}
 
-	msgs, err := ps.channel.Consume(queue.Name, clientID, true, false, false, false, nil)
+	// Messages passed to an AckHandler are acknowledged once handled,
+	// all the others are acknowledged on delivery.
+	_, manualAck := cfg.Handler.(messaging.AckHandler)
+	msgs, err := ps.channel.Consume(queue.Name, clientID, !manualAck, false, false, false, nil)
 	if err != nil {
 		return err
 	}
@@ -183,9 +186,34 @@ func (ps *pubsub) handle(deliveries <-chan amqp.Delivery, h messaging.MessageHan
 			ps.logger.Warn(fmt.Sprintf("Failed to unmarshal received message: %s", err))
 			return
 		}
+		if ah, ok := h.(messaging.AckHandler); ok {
+			if err := ah.HandleAck(&msg, ps.ack(d)); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to handle SuperMQ message: %s", err))
+				if err := d.Nack(false, true); err != nil {
+					ps.logger.Warn(fmt.Sprintf("Failed to nack message: %s", err))
+				}
+			}
+			continue
+		}
 		if err := h.Handle(&msg); err != nil {
 			ps.logger.Warn(fmt.Sprintf("Failed to handle SuperMQ message: %s", err))
 			return
 		}
 	}
 }
+
+// ack returns the callback which acknowledges the delivery once it's
+// handled, or requeues it if the handling failed.
+func (ps *pubsub) ack(d amqp.Delivery) func(error) {
+	return func(err error) {
+		if err != nil {
+			if err := d.Nack(false, true); err != nil {
+				ps.logger.Warn(fmt.Sprintf("Failed to nack message: %s", err))
+			}
+			return
+		}
+		if err := d.Ack(false); err != nil {
+			ps.logger.Warn(fmt.Sprintf("Failed to ack message: %s", err))
+		}
+	}
+}
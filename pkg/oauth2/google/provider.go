@@ -75,8 +75,16 @@ func (cfg *config) IsEnabled() bool {
 	return cfg.config.ClientID != "" && cfg.config.ClientSecret != ""
 }
 
-func (cfg *config) Exchange(ctx context.Context, code string) (oauth2.Token, error) {
-	token, err := cfg.config.Exchange(ctx, code)
+func (cfg *config) AuthCodeURL(_ context.Context, state mgoauth2.AuthState) (string, error) {
+	return cfg.config.AuthCodeURL(state.State, oauth2.S256ChallengeOption(state.Verifier)), nil
+}
+
+func (cfg *config) Exchange(ctx context.Context, code string, state mgoauth2.AuthState) (oauth2.Token, error) {
+	var opts []oauth2.AuthCodeOption
+	if state.Verifier != "" {
+		opts = append(opts, oauth2.VerifierOption(state.Verifier))
+	}
+	token, err := cfg.config.Exchange(ctx, code, opts...)
 	if err != nil {
 		return oauth2.Token{}, err
 	}
@@ -84,8 +92,8 @@ func (cfg *config) Exchange(ctx context.Context, code string) (oauth2.Token, err
 	return *token, nil
 }
 
-func (cfg *config) UserInfo(accessToken string) (uclient.User, error) {
-	resp, err := http.Get(userInfoURL + url.QueryEscape(accessToken))
+func (cfg *config) UserInfo(token oauth2.Token) (uclient.User, error) {
+	resp, err := http.Get(userInfoURL + url.QueryEscape(token.AccessToken))
 	if err != nil {
 		return uclient.User{}, err
 	}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 
+	oauth2 "github.com/absmach/supermq/pkg/oauth2"
 	mock "github.com/stretchr/testify/mock"
 
 	users "github.com/absmach/supermq/users"
@@ -19,7 +20,35 @@ type Provider struct {
 	mock.Mock
 }
 
-// ErrorURL provides a mock function with given fields:
+// AuthCodeURL provides a mock function with given fields: ctx, state
+func (_m *Provider) AuthCodeURL(ctx context.Context, state oauth2.AuthState) (string, error) {
+	ret := _m.Called(ctx, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthCodeURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, oauth2.AuthState) (string, error)); ok {
+		return rf(ctx, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, oauth2.AuthState) string); ok {
+		r0 = rf(ctx, state)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, oauth2.AuthState) error); ok {
+		r1 = rf(ctx, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ErrorURL provides a mock function with no fields
 func (_m *Provider) ErrorURL() string {
 	ret := _m.Called()
 
@@ -37,9 +66,9 @@ func (_m *Provider) ErrorURL() string {
 	return r0
 }
 
-// Exchange provides a mock function with given fields: ctx, code
-func (_m *Provider) Exchange(ctx context.Context, code string) (xoauth2.Token, error) {
-	ret := _m.Called(ctx, code)
+// Exchange provides a mock function with given fields: ctx, code, state
+func (_m *Provider) Exchange(ctx context.Context, code string, state oauth2.AuthState) (xoauth2.Token, error) {
+	ret := _m.Called(ctx, code, state)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Exchange")
@@ -47,17 +76,17 @@ func (_m *Provider) Exchange(ctx context.Context, code string) (xoauth2.Token, e
 
 	var r0 xoauth2.Token
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (xoauth2.Token, error)); ok {
-		return rf(ctx, code)
+	if rf, ok := ret.Get(0).(func(context.Context, string, oauth2.AuthState) (xoauth2.Token, error)); ok {
+		return rf(ctx, code, state)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) xoauth2.Token); ok {
-		r0 = rf(ctx, code)
+	if rf, ok := ret.Get(0).(func(context.Context, string, oauth2.AuthState) xoauth2.Token); ok {
+		r0 = rf(ctx, code, state)
 	} else {
 		r0 = ret.Get(0).(xoauth2.Token)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, code)
+	if rf, ok := ret.Get(1).(func(context.Context, string, oauth2.AuthState) error); ok {
+		r1 = rf(ctx, code, state)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -65,7 +94,7 @@ func (_m *Provider) Exchange(ctx context.Context, code string) (xoauth2.Token, e
 	return r0, r1
 }
 
-// IsEnabled provides a mock function with given fields:
+// IsEnabled provides a mock function with no fields
 func (_m *Provider) IsEnabled() bool {
 	ret := _m.Called()
 
@@ -83,7 +112,7 @@ func (_m *Provider) IsEnabled() bool {
 	return r0
 }
 
-// Name provides a mock function with given fields:
+// Name provides a mock function with no fields
 func (_m *Provider) Name() string {
 	ret := _m.Called()
 
@@ -101,7 +130,7 @@ func (_m *Provider) Name() string {
 	return r0
 }
 
-// RedirectURL provides a mock function with given fields:
+// RedirectURL provides a mock function with no fields
 func (_m *Provider) RedirectURL() string {
 	ret := _m.Called()
 
@@ -119,7 +148,7 @@ func (_m *Provider) RedirectURL() string {
 	return r0
 }
 
-// State provides a mock function with given fields:
+// State provides a mock function with no fields
 func (_m *Provider) State() string {
 	ret := _m.Called()
 
@@ -137,9 +166,9 @@ func (_m *Provider) State() string {
 	return r0
 }
 
-// UserInfo provides a mock function with given fields: accessToken
-func (_m *Provider) UserInfo(accessToken string) (users.User, error) {
-	ret := _m.Called(accessToken)
+// UserInfo provides a mock function with given fields: token
+func (_m *Provider) UserInfo(token xoauth2.Token) (users.User, error) {
+	ret := _m.Called(token)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserInfo")
@@ -147,17 +176,17 @@ func (_m *Provider) UserInfo(accessToken string) (users.User, error) {
 
 	var r0 users.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (users.User, error)); ok {
-		return rf(accessToken)
+	if rf, ok := ret.Get(0).(func(xoauth2.Token) (users.User, error)); ok {
+		return rf(token)
 	}
-	if rf, ok := ret.Get(0).(func(string) users.User); ok {
-		r0 = rf(accessToken)
+	if rf, ok := ret.Get(0).(func(xoauth2.Token) users.User); ok {
+		r0 = rf(token)
 	} else {
 		r0 = ret.Get(0).(users.User)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(accessToken)
+	if rf, ok := ret.Get(1).(func(xoauth2.Token) error); ok {
+		r1 = rf(token)
 	} else {
 		r1 = ret.Error(1)
 	}
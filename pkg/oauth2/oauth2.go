@@ -5,11 +5,15 @@ package oauth2
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
 
 	"github.com/absmach/supermq/users"
 	"golang.org/x/oauth2"
 )
 
+const randomBytes = 32
+
 // Config is the configuration for the OAuth2 provider.
 type Config struct {
 	ClientID     string `env:"CLIENT_ID"       envDefault:""`
@@ -18,6 +22,36 @@ type Config struct {
 	RedirectURL  string `env:"REDIRECT_URL"    envDefault:""`
 }
 
+// AuthState contains the values generated for a single authorization request.
+// They are kept by the user agent until the provider redirects back, and are
+// used to bind the authorization code to the request that started the flow.
+type AuthState struct {
+	// State is compared with the state returned in the callback.
+	State string `json:"state"`
+	// Verifier is the PKCE code verifier.
+	Verifier string `json:"verifier"`
+	// Nonce is compared with the nonce claim of the OpenID Connect ID token.
+	Nonce string `json:"nonce"`
+}
+
+// NewAuthState returns a new AuthState with random values.
+func NewAuthState() (AuthState, error) {
+	var values [3]string
+	for i := range values {
+		b := make([]byte, randomBytes)
+		if _, err := rand.Read(b); err != nil {
+			return AuthState{}, err
+		}
+		values[i] = base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	return AuthState{
+		State:    values[0],
+		Verifier: values[1],
+		Nonce:    values[2],
+	}, nil
+}
+
 // Provider is an interface that provides the OAuth2 flow for a specific provider
 // (e.g. Google, GitHub, etc.)
 //
@@ -38,9 +72,15 @@ type Provider interface {
 	// IsEnabled checks if the OAuth2 provider is enabled.
 	IsEnabled() bool
 
-	// Exchange converts an authorization code into a token.
-	Exchange(ctx context.Context, code string) (oauth2.Token, error)
+	// AuthCodeURL returns the URL of the provider consent page which starts
+	// the OAuth2 flow bound to the given state.
+	AuthCodeURL(ctx context.Context, state AuthState) (string, error)
+
+	// Exchange converts an authorization code into a token. The state is empty
+	// if the flow was not started with AuthCodeURL.
+	Exchange(ctx context.Context, code string, state AuthState) (oauth2.Token, error)
 
-	// UserInfo retrieves the user's information using the access token.
-	UserInfo(accessToken string) (users.User, error)
+	// UserInfo retrieves the user's information using the token returned
+	// by Exchange.
+	UserInfo(token oauth2.Token) (users.User, error)
 }
@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package oidc contains the generic OpenID Connect provider which supports
+// any issuer that publishes a discovery document, such as Keycloak,
+// Azure AD or Okta.
+package oidc
@@ -0,0 +1,411 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgoauth2 "github.com/absmach/supermq/pkg/oauth2"
+	uclient "github.com/absmach/supermq/users"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"golang.org/x/oauth2"
+)
+
+const (
+	defTimeout        = 1 * time.Minute
+	clockSkew         = 1 * time.Minute
+	discoveryPath     = "/.well-known/openid-configuration"
+	idTokenField      = "id_token"
+	nonceClaim        = "nonce"
+	subjectClaim      = "sub"
+	emailVerifiedKey  = "email_verified"
+	providerMetaKey   = "oauth_provider"
+	groupsMetaKey     = "oauth_groups"
+	claimPathSplitter = "."
+)
+
+var (
+	// ErrDiscovery indicates that the issuer discovery document could not be retrieved.
+	ErrDiscovery = errors.New("failed to retrieve OpenID Connect discovery document")
+	// ErrMissingAuthState indicates that the flow was not started with PKCE and nonce.
+	ErrMissingAuthState = errors.New("missing PKCE verifier or nonce")
+	// ErrMissingIDToken indicates that the token response does not contain an ID token.
+	ErrMissingIDToken = errors.New("missing ID token")
+	// ErrInvalidIDToken indicates that the ID token is not valid.
+	ErrInvalidIDToken = errors.New("invalid ID token")
+	// ErrInvalidNonce indicates that the ID token nonce does not match the request nonce.
+	ErrInvalidNonce = errors.New("invalid ID token nonce")
+	// ErrEmailNotVerified indicates that the issuer did not verify the user email.
+	ErrEmailNotVerified = errors.New("email is not verified")
+	// ErrSubjectMismatch indicates that the userinfo subject differs from the ID token subject.
+	ErrSubjectMismatch = errors.New("userinfo subject does not match ID token subject")
+)
+
+// Config is the configuration for the OpenID Connect provider.
+//
+// Users are matched by email, so emails without the email_verified claim are
+// rejected unless TrustEmail is set. It should be set only for the issuers
+// which return verified emails exclusively. If AdminGroups are set, users who
+// are members of any of them are admins, and the role is updated on every
+// login, so the users removed from the groups are demoted.
+type Config struct {
+	IssuerURL    string   `env:"ISSUER_URL"      envDefault:""`
+	ClientID     string   `env:"CLIENT_ID"       envDefault:""`
+	ClientSecret string   `env:"CLIENT_SECRET"   envDefault:""`
+	RedirectURL  string   `env:"REDIRECT_URL"    envDefault:""`
+	Scopes       []string `env:"SCOPES"          envDefault:"openid,email,profile"`
+	TrustEmail   bool     `env:"TRUST_EMAIL"     envDefault:"false"`
+	AdminGroups  []string `env:"ADMIN_GROUPS"    envDefault:""`
+	Claims       ClaimMapping
+}
+
+// ClaimMapping contains the names of the claims that are mapped to the user
+// fields. Nested claims are referenced with a dot separated path, e.g.
+// "realm_access.roles".
+type ClaimMapping struct {
+	Email     string `env:"EMAIL_CLAIM"      envDefault:"email"`
+	FirstName string `env:"FIRST_NAME_CLAIM" envDefault:"given_name"`
+	LastName  string `env:"LAST_NAME_CLAIM"  envDefault:"family_name"`
+	Username  string `env:"USERNAME_CLAIM"   envDefault:"preferred_username"`
+	Picture   string `env:"PICTURE_CLAIM"    envDefault:"picture"`
+	Groups    string `env:"GROUPS_CLAIM"     envDefault:"groups"`
+}
+
+// metadata is the subset of the OpenID Provider Metadata used by the provider.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+var _ mgoauth2.Provider = (*provider)(nil)
+
+type provider struct {
+	name          string
+	cfg           Config
+	uiRedirectURL string
+	errorURL      string
+	client        *http.Client
+
+	mu     sync.Mutex
+	meta   *metadata
+	config *oauth2.Config
+	keys   jwk.Set
+}
+
+// NewProvider returns a new OpenID Connect provider with the given name.
+// The issuer discovery document is retrieved on the first use, so the
+// issuer does not have to be available when the service starts.
+func NewProvider(name string, cfg Config, uiRedirectURL, errorURL string) mgoauth2.Provider {
+	return &provider{
+		name:          name,
+		cfg:           cfg,
+		uiRedirectURL: uiRedirectURL,
+		errorURL:      errorURL,
+		client:        &http.Client{Timeout: defTimeout},
+	}
+}
+
+func (p *provider) Name() string {
+	return p.name
+}
+
+// State returns an empty state since the state is generated for each
+// authorization request.
+func (p *provider) State() string {
+	return ""
+}
+
+func (p *provider) RedirectURL() string {
+	return p.uiRedirectURL
+}
+
+func (p *provider) ErrorURL() string {
+	return p.errorURL
+}
+
+func (p *provider) IsEnabled() bool {
+	return p.cfg.IssuerURL != "" && p.cfg.ClientID != "" && p.cfg.ClientSecret != ""
+}
+
+func (p *provider) AuthCodeURL(ctx context.Context, state mgoauth2.AuthState) (string, error) {
+	config, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return config.AuthCodeURL(state.State, oauth2.S256ChallengeOption(state.Verifier), oauth2.SetAuthURLParam(nonceClaim, state.Nonce)), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, code string, state mgoauth2.AuthState) (oauth2.Token, error) {
+	if state.Verifier == "" || state.Nonce == "" {
+		return oauth2.Token{}, ErrMissingAuthState
+	}
+	config, meta, err := p.discover(ctx)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.client)
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	idToken, ok := token.Extra(idTokenField).(string)
+	if !ok || idToken == "" {
+		return oauth2.Token{}, ErrMissingIDToken
+	}
+	subject, err := p.verifyIDToken(ctx, meta, idToken, state.Nonce)
+	if err != nil {
+		return oauth2.Token{}, err
+	}
+
+	// The verified subject is kept with the token, so the userinfo
+	// response can be bound to the ID token.
+	return *token.WithExtra(map[string]interface{}{
+		idTokenField: idToken,
+		subjectClaim: subject,
+	}), nil
+}
+
+func (p *provider) UserInfo(token oauth2.Token) (uclient.User, error) {
+	subject, ok := token.Extra(subjectClaim).(string)
+	if !ok || subject == "" {
+		return uclient.User{}, ErrMissingIDToken
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defTimeout)
+	defer cancel()
+
+	_, meta, err := p.discover(ctx)
+	if err != nil {
+		return uclient.User{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.UserinfoEndpoint, nil)
+	if err != nil {
+		return uclient.User{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return uclient.User{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return uclient.User{}, svcerr.ErrAuthentication
+	}
+
+	var claims map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&claims); err != nil {
+		return uclient.User{}, err
+	}
+	if claimString(claims, subjectClaim) != subject {
+		return uclient.User{}, ErrSubjectMismatch
+	}
+
+	return p.toUser(claims)
+}
+
+func (p *provider) discover(ctx context.Context) (*oauth2.Config, metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.meta != nil {
+		return p.config, *p.meta, nil
+	}
+
+	issuer := strings.TrimSuffix(p.cfg.IssuerURL, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+discoveryPath, nil)
+	if err != nil {
+		return nil, metadata{}, errors.Wrap(ErrDiscovery, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, metadata{}, errors.Wrap(ErrDiscovery, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, metadata{}, errors.Wrap(ErrDiscovery, fmt.Errorf("unexpected status code %d", resp.StatusCode))
+	}
+
+	var meta metadata
+	if err := json.NewDecoder(resp.Body).Decode(&meta); err != nil {
+		return nil, metadata{}, errors.Wrap(ErrDiscovery, err)
+	}
+	// The issuer must match the configured one to prevent a compromised
+	// discovery document from redirecting the flow to another issuer.
+	if strings.TrimSuffix(meta.Issuer, "/") != issuer {
+		return nil, metadata{}, errors.Wrap(ErrDiscovery, fmt.Errorf("issuer %q does not match %q", meta.Issuer, p.cfg.IssuerURL))
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.UserinfoEndpoint == "" || meta.JWKSURI == "" {
+		return nil, metadata{}, errors.Wrap(ErrDiscovery, errors.New("missing provider endpoints"))
+	}
+
+	p.meta = &meta
+	p.config = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  meta.AuthorizationEndpoint,
+			TokenURL: meta.TokenEndpoint,
+		},
+		RedirectURL: p.cfg.RedirectURL,
+		Scopes:      p.cfg.Scopes,
+	}
+
+	return p.config, meta, nil
+}
+
+func (p *provider) keySet(ctx context.Context, meta metadata, refresh bool) (jwk.Set, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.keys != nil && !refresh {
+		return p.keys, nil
+	}
+	keys, err := jwk.Fetch(ctx, meta.JWKSURI, jwk.WithHTTPClient(p.client))
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+
+	return keys, nil
+}
+
+// verifyIDToken verifies the ID token and returns its subject.
+func (p *provider) verifyIDToken(ctx context.Context, meta metadata, idToken, nonce string) (string, error) {
+	parse := func(keys jwk.Set) (jwt.Token, error) {
+		return jwt.Parse(
+			[]byte(idToken),
+			jwt.WithKeySet(keys, jws.WithInferAlgorithmFromKey(true)),
+			jwt.WithValidate(true),
+			jwt.WithIssuer(meta.Issuer),
+			jwt.WithAudience(p.cfg.ClientID),
+			jwt.WithAcceptableSkew(clockSkew),
+		)
+	}
+
+	keys, err := p.keySet(ctx, meta, false)
+	if err != nil {
+		return "", errors.Wrap(ErrInvalidIDToken, err)
+	}
+	token, err := parse(keys)
+	if err != nil {
+		// The issuer may have rotated its signing keys since they were fetched.
+		if keys, err = p.keySet(ctx, meta, true); err != nil {
+			return "", errors.Wrap(ErrInvalidIDToken, err)
+		}
+		if token, err = parse(keys); err != nil {
+			return "", errors.Wrap(ErrInvalidIDToken, err)
+		}
+	}
+
+	tokenNonce, _ := token.PrivateClaims()[nonceClaim].(string)
+	if subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(nonce)) != 1 {
+		return "", ErrInvalidNonce
+	}
+	if token.Subject() == "" {
+		return "", ErrInvalidIDToken
+	}
+
+	return token.Subject(), nil
+}
+
+func (p *provider) toUser(claims map[string]interface{}) (uclient.User, error) {
+	email := claimString(claims, p.cfg.Claims.Email)
+	if email == "" || claimString(claims, subjectClaim) == "" {
+		return uclient.User{}, svcerr.ErrAuthentication
+	}
+	// Users are matched by email, so an unverified email could be used
+	// to take over an existing account.
+	verified, ok := claims[emailVerifiedKey].(bool)
+	if !verified && (ok || !p.cfg.TrustEmail) {
+		return uclient.User{}, ErrEmailNotVerified
+	}
+
+	user := uclient.User{
+		ID:             claimString(claims, subjectClaim),
+		FirstName:      claimString(claims, p.cfg.Claims.FirstName),
+		LastName:       claimString(claims, p.cfg.Claims.LastName),
+		Email:          email,
+		ProfilePicture: claimString(claims, p.cfg.Claims.Picture),
+		Credentials: uclient.Credentials{
+			Username: claimString(claims, p.cfg.Claims.Username),
+		},
+		Metadata: uclient.Metadata{
+			providerMetaKey: p.name,
+		},
+		Status: uclient.EnabledStatus,
+	}
+	groups := claimStrings(claims, p.cfg.Claims.Groups)
+	if len(groups) > 0 {
+		user.Metadata[groupsMetaKey] = groups
+	}
+	if len(p.cfg.AdminGroups) > 0 {
+		user.OAuthRole = true
+		if slices.ContainsFunc(groups, func(group string) bool { return slices.Contains(p.cfg.AdminGroups, group) }) {
+			user.Role = uclient.AdminRole
+		}
+	}
+
+	return user, nil
+}
+
+// claim returns the value of the claim referenced by a dot separated path.
+func claim(claims map[string]interface{}, path string) interface{} {
+	if path == "" {
+		return nil
+	}
+	var value interface{} = claims
+	for _, field := range strings.Split(path, claimPathSplitter) {
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = obj[field]
+	}
+
+	return value
+}
+
+func claimString(claims map[string]interface{}, path string) string {
+	value, _ := claim(claims, path).(string)
+	return value
+}
+
+func claimStrings(claims map[string]interface{}, path string) []string {
+	switch value := claim(claims, path).(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
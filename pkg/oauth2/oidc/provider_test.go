@@ -0,0 +1,471 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package oidc_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	mgoauth2 "github.com/absmach/supermq/pkg/oauth2"
+	"github.com/absmach/supermq/pkg/oauth2/oidc"
+	"github.com/absmach/supermq/users"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+const (
+	providerName = "keycloak"
+	clientID     = "supermq"
+	clientSecret = "secret"
+	code         = "code"
+	accessToken  = "access-token"
+	subject      = "f0c5d3c4-3b31-4e6a-9a7d-7f2f8c1b2a10"
+	redirectURL  = "http://localhost/oauth/callback/keycloak"
+	uiURL        = "http://localhost:9095/domains"
+	errorURL     = "http://localhost:9095/error"
+)
+
+// server is a minimal OpenID Connect issuer.
+type server struct {
+	*httptest.Server
+	issuer   string
+	key      jwk.Key
+	signKey  jwk.Key
+	verifier string
+	nonce    string
+	audience string
+	userinfo map[string]interface{}
+}
+
+func newServer(t *testing.T) *server {
+	s := &server{
+		audience: clientID,
+		userinfo: map[string]interface{}{
+			"sub":                subject,
+			"email":              "john.doe@example.com",
+			"email_verified":     true,
+			"given_name":         "John",
+			"family_name":        "Doe",
+			"preferred_username": "johndoe",
+			"picture":            "https://example.com/john.png",
+			"realm_access": map[string]interface{}{
+				"roles": []interface{}{"admin", "operator"},
+			},
+		},
+	}
+	s.key = newKey(t, "key-1")
+	s.signKey = s.key
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.discovery)
+	mux.HandleFunc("/jwks", s.jwks)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/userinfo", s.userInfo)
+	s.Server = httptest.NewServer(mux)
+	s.issuer = s.URL
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *server) discovery(w http.ResponseWriter, _ *http.Request) {
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"issuer":                 s.issuer,
+		"authorization_endpoint": s.URL + "/auth",
+		"token_endpoint":         s.URL + "/token",
+		"userinfo_endpoint":      s.URL + "/userinfo",
+		"jwks_uri":               s.URL + "/jwks",
+	})
+}
+
+func (s *server) jwks(w http.ResponseWriter, _ *http.Request) {
+	public, _ := s.key.PublicKey()
+	set := jwk.NewSet()
+	_ = set.AddKey(public)
+	_ = json.NewEncoder(w).Encode(set)
+}
+
+func (s *server) token(w http.ResponseWriter, r *http.Request) {
+	if r.FormValue("code") != code || r.FormValue("code_verifier") != s.verifier {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	tkn, _ := jwt.NewBuilder().
+		Issuer(s.URL).
+		Subject(s.userinfo["sub"].(string)).
+		Audience([]string{s.audience}).
+		IssuedAt(now).
+		Expiration(now.Add(time.Minute)).
+		Claim("nonce", s.nonce).
+		Build()
+	idToken, _ := jwt.Sign(tkn, jwt.WithKey(jwa.EdDSA, s.signKey))
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     string(idToken),
+	})
+}
+
+func (s *server) userInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+accessToken {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(s.userinfo)
+}
+
+func newKey(t *testing.T, kid string) jwk.Key {
+	_, raw, err := ed25519.GenerateKey(rand.Reader)
+	require.Nil(t, err, fmt.Sprintf("generating key expected to succeed: %s", err))
+	key, err := jwk.FromRaw(raw)
+	require.Nil(t, err, fmt.Sprintf("creating JWK expected to succeed: %s", err))
+	require.Nil(t, key.Set(jwk.KeyIDKey, kid))
+	require.Nil(t, key.Set(jwk.AlgorithmKey, jwa.EdDSA))
+
+	return key
+}
+
+func newProvider(issuer string) mgoauth2.Provider {
+	return oidc.NewProvider(providerName, newConfig(issuer), uiURL, errorURL)
+}
+
+func newConfig(issuer string) oidc.Config {
+	return oidc.Config{
+		IssuerURL:    issuer,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  redirectURL,
+		Scopes:       []string{"openid", "email", "profile"},
+		Claims: oidc.ClaimMapping{
+			Email:     "email",
+			FirstName: "given_name",
+			LastName:  "family_name",
+			Username:  "preferred_username",
+			Picture:   "picture",
+			Groups:    "realm_access.roles",
+		},
+	}
+}
+
+func TestIsEnabled(t *testing.T) {
+	assert.True(t, newProvider("http://localhost").IsEnabled())
+	assert.False(t, oidc.NewProvider(providerName, oidc.Config{ClientID: clientID}, uiURL, errorURL).IsEnabled())
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	srv := newServer(t)
+	state, err := mgoauth2.NewAuthState()
+	require.Nil(t, err, fmt.Sprintf("generating state expected to succeed: %s", err))
+
+	cases := []struct {
+		desc   string
+		issuer string
+		err    error
+	}{
+		{
+			desc:   "get auth code URL",
+			issuer: srv.URL,
+			err:    nil,
+		},
+		{
+			desc:   "get auth code URL with unavailable issuer",
+			issuer: srv.URL + "/unknown",
+			err:    oidc.ErrDiscovery,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authURL, err := newProvider(tc.issuer).AuthCodeURL(context.Background(), state)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			if err != nil {
+				return
+			}
+			u, err := url.Parse(authURL)
+			require.Nil(t, err, fmt.Sprintf("parsing URL expected to succeed: %s", err))
+			assert.Equal(t, srv.URL+"/auth", u.Scheme+"://"+u.Host+u.Path)
+			query := u.Query()
+			assert.Equal(t, clientID, query.Get("client_id"))
+			assert.Equal(t, state.State, query.Get("state"))
+			assert.Equal(t, state.Nonce, query.Get("nonce"))
+			assert.Equal(t, "S256", query.Get("code_challenge_method"))
+			assert.NotEmpty(t, query.Get("code_challenge"))
+		})
+	}
+}
+
+func TestExchange(t *testing.T) {
+	state, err := mgoauth2.NewAuthState()
+	require.Nil(t, err, fmt.Sprintf("generating state expected to succeed: %s", err))
+
+	cases := []struct {
+		desc    string
+		state   mgoauth2.AuthState
+		code    string
+		prepare func(s *server)
+		err     error
+		errMsg  string
+	}{
+		{
+			desc:  "exchange code",
+			state: state,
+			code:  code,
+			err:   nil,
+		},
+		{
+			desc:  "exchange code without PKCE verifier and nonce",
+			state: mgoauth2.AuthState{},
+			code:  code,
+			err:   oidc.ErrMissingAuthState,
+		},
+		{
+			desc:   "exchange invalid code",
+			state:  state,
+			code:   "invalid",
+			errMsg: "invalid_grant",
+		},
+		{
+			desc:  "exchange code with invalid PKCE verifier",
+			state: state,
+			code:  code,
+			prepare: func(s *server) {
+				s.verifier = "invalid"
+			},
+			errMsg: "invalid_grant",
+		},
+		{
+			desc:  "exchange code with invalid nonce",
+			state: state,
+			code:  code,
+			prepare: func(s *server) {
+				s.nonce = "invalid"
+			},
+			err: oidc.ErrInvalidNonce,
+		},
+		{
+			desc:  "exchange code with ID token for another audience",
+			state: state,
+			code:  code,
+			prepare: func(s *server) {
+				s.audience = "another"
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:  "exchange code with ID token signed with unknown key",
+			state: state,
+			code:  code,
+			prepare: func(s *server) {
+				s.signKey = newKey(t, "key-1")
+			},
+			err: oidc.ErrInvalidIDToken,
+		},
+		{
+			desc:  "exchange code with issuer mismatch",
+			state: state,
+			code:  code,
+			prepare: func(s *server) {
+				s.issuer = "https://another.example.com"
+			},
+			err: oidc.ErrDiscovery,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			srv := newServer(t)
+			srv.verifier = state.Verifier
+			srv.nonce = state.Nonce
+			if tc.prepare != nil {
+				tc.prepare(srv)
+			}
+			token, err := newProvider(srv.URL).Exchange(context.Background(), tc.code, tc.state)
+			if tc.errMsg != "" {
+				assert.ErrorContains(t, err, tc.errMsg, fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.errMsg, err))
+				return
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, accessToken, token.AccessToken)
+				assert.Equal(t, subject, token.Extra("sub"))
+			}
+		})
+	}
+}
+
+func TestExchangeKeyRotation(t *testing.T) {
+	srv := newServer(t)
+	state, err := mgoauth2.NewAuthState()
+	require.Nil(t, err, fmt.Sprintf("generating state expected to succeed: %s", err))
+	srv.verifier = state.Verifier
+	srv.nonce = state.Nonce
+	provider := newProvider(srv.URL)
+
+	_, err = provider.Exchange(context.Background(), code, state)
+	require.Nil(t, err, fmt.Sprintf("exchanging code expected to succeed: %s", err))
+
+	srv.key = newKey(t, "key-2")
+	srv.signKey = srv.key
+	_, err = provider.Exchange(context.Background(), code, state)
+	assert.Nil(t, err, fmt.Sprintf("exchanging code after issuer key rotation expected to succeed: %s", err))
+}
+
+func TestUserInfo(t *testing.T) {
+	token := (&oauth2.Token{AccessToken: accessToken}).WithExtra(map[string]interface{}{"sub": subject})
+	user := users.User{
+		ID:             subject,
+		FirstName:      "John",
+		LastName:       "Doe",
+		Email:          "john.doe@example.com",
+		ProfilePicture: "https://example.com/john.png",
+		Credentials:    users.Credentials{Username: "johndoe"},
+		Metadata: users.Metadata{
+			"oauth_provider": providerName,
+			"oauth_groups":   []string{"admin", "operator"},
+		},
+		Status: users.EnabledStatus,
+	}
+	admin := user
+	admin.Role = users.AdminRole
+	admin.OAuthRole = true
+	nonAdmin := user
+	nonAdmin.OAuthRole = true
+
+	cases := []struct {
+		desc    string
+		token   oauth2.Token
+		config  func(cfg *oidc.Config)
+		prepare func(s *server)
+		user    users.User
+		err     error
+	}{
+		{
+			desc:  "retrieve user info",
+			token: *token,
+			user:  user,
+			err:   nil,
+		},
+		{
+			desc:  "retrieve user info of admin group member",
+			token: *token,
+			config: func(cfg *oidc.Config) {
+				cfg.AdminGroups = []string{"operator"}
+			},
+			user: admin,
+			err:  nil,
+		},
+		{
+			desc:  "retrieve user info of user outside admin groups",
+			token: *token,
+			config: func(cfg *oidc.Config) {
+				cfg.AdminGroups = []string{"superuser"}
+			},
+			user: nonAdmin,
+			err:  nil,
+		},
+		{
+			desc:  "retrieve user info with invalid access token",
+			token: *(&oauth2.Token{AccessToken: "invalid"}).WithExtra(map[string]interface{}{"sub": subject}),
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "retrieve user info with token without ID token subject",
+			token: oauth2.Token{AccessToken: accessToken},
+			err:   oidc.ErrMissingIDToken,
+		},
+		{
+			desc:  "retrieve user info of another subject",
+			token: *token,
+			prepare: func(s *server) {
+				s.userinfo["sub"] = "another"
+			},
+			err: oidc.ErrSubjectMismatch,
+		},
+		{
+			desc:  "retrieve user info without email",
+			token: *token,
+			prepare: func(s *server) {
+				delete(s.userinfo, "email")
+			},
+			err: svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "retrieve user info with unverified email",
+			token: *token,
+			prepare: func(s *server) {
+				s.userinfo["email_verified"] = false
+			},
+			err: oidc.ErrEmailNotVerified,
+		},
+		{
+			desc:  "retrieve user info without email verification claim",
+			token: *token,
+			prepare: func(s *server) {
+				delete(s.userinfo, "email_verified")
+			},
+			err: oidc.ErrEmailNotVerified,
+		},
+		{
+			desc:  "retrieve user info without email verification claim from trusted issuer",
+			token: *token,
+			config: func(cfg *oidc.Config) {
+				cfg.TrustEmail = true
+			},
+			prepare: func(s *server) {
+				delete(s.userinfo, "email_verified")
+			},
+			user: user,
+			err:  nil,
+		},
+		{
+			desc:  "retrieve user info with unverified email from trusted issuer",
+			token: *token,
+			config: func(cfg *oidc.Config) {
+				cfg.TrustEmail = true
+			},
+			prepare: func(s *server) {
+				s.userinfo["email_verified"] = false
+			},
+			err: oidc.ErrEmailNotVerified,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			srv := newServer(t)
+			if tc.prepare != nil {
+				tc.prepare(srv)
+			}
+			cfg := newConfig(srv.URL)
+			if tc.config != nil {
+				tc.config(&cfg)
+			}
+			user, err := oidc.NewProvider(providerName, cfg, uiURL, errorURL).UserInfo(tc.token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.user, user)
+			}
+		})
+	}
+}
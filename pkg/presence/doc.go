@@ -0,0 +1,7 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package presence contains the session events the protocol adapters publish
+// when clients connect and disconnect, which are used by the clients service
+// to track the presence of the clients.
+package presence
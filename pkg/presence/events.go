@@ -0,0 +1,104 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package presence
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+)
+
+var (
+	_ events.Event = (*sessionEvent)(nil)
+	_ events.Event = (*heartbeatEvent)(nil)
+)
+
+type sessionEvent struct {
+	Session
+	operation string
+	instance  string
+}
+
+func (se sessionEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation":  se.operation,
+		"session_id": se.ID,
+		"client_id":  se.ClientID,
+		"protocol":   se.Protocol,
+		"instance":   se.instance,
+	}
+	if se.RemoteAddr != "" {
+		val["remote_addr"] = se.RemoteAddr
+	}
+
+	return val, nil
+}
+
+type heartbeatEvent struct {
+	instance string
+}
+
+func (he heartbeatEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": HeartbeatOp,
+		"instance":  he.instance,
+	}, nil
+}
+
+type eventStore struct {
+	events.Publisher
+	instance string
+}
+
+// NewEventStore returns the session event store which publishes the events
+// to the given stream. Until the context is canceled, the event store also
+// publishes the heartbeat events of the instance, so that the sessions of an
+// instance which stopped without closing them can be expired.
+func NewEventStore(ctx context.Context, url, streamID, instance string) (EventStore, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	es := &eventStore{
+		instance:  instance,
+		Publisher: publisher,
+	}
+	go es.heartbeat(ctx)
+
+	return es, nil
+}
+
+func (es *eventStore) heartbeat(ctx context.Context) {
+	ticker := time.NewTicker(HeartbeatInterval)
+	defer ticker.Stop()
+	for {
+		// A missed heartbeat is followed by the next one, so the errors
+		// are not handled.
+		_ = es.Publish(ctx, heartbeatEvent{instance: es.instance})
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (es *eventStore) Connect(ctx context.Context, session Session) error {
+	return es.Publish(ctx, sessionEvent{
+		Session:   session,
+		operation: ConnectOp,
+		instance:  es.instance,
+	})
+}
+
+func (es *eventStore) Disconnect(ctx context.Context, session Session) error {
+	return es.Publish(ctx, sessionEvent{
+		Session:   session,
+		operation: DisconnectOp,
+		instance:  es.instance,
+	})
+}
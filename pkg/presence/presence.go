@@ -0,0 +1,53 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package presence
+
+import (
+	"context"
+	"time"
+)
+
+// Streams of the session events of the protocol adapters.
+const (
+	MQTTStream = "supermq.mqtt"
+	WSStream   = "supermq.ws"
+	CoAPStream = "supermq.coap"
+)
+
+// Protocols of the client sessions.
+const (
+	MQTT = "mqtt"
+	WS   = "ws"
+	CoAP = "coap"
+)
+
+// Session event operations.
+const (
+	ConnectOp    = "connect"
+	DisconnectOp = "disconnect"
+	HeartbeatOp  = "heartbeat"
+)
+
+// HeartbeatInterval is the interval of the heartbeat events the adapter
+// instances publish while they are running.
+const HeartbeatInterval = 30 * time.Second
+
+// Session represents a session a client opened on a protocol adapter.
+type Session struct {
+	ID         string
+	ClientID   string
+	Protocol   string
+	RemoteAddr string
+}
+
+// EventStore publishes the session events of a protocol adapter.
+//
+//go:generate mockery --name EventStore --output=./mocks --filename events.go --quiet --note "Copyright (c) Abstract Machines"
+type EventStore interface {
+	// Connect issues the event of a session being opened.
+	Connect(ctx context.Context, session Session) error
+
+	// Disconnect issues the event of a session being closed.
+	Disconnect(ctx context.Context, session Session) error
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"bytes"
+	"container/list"
+	"sync"
+)
+
+// Cache validates the payloads published on channels using the channel
+// message schemas returned by the channel authorization lookup.
+//
+//go:generate mockery --name Cache --output=./mocks --filename cache.go --quiet --note "Copyright (c) Abstract Machines"
+type Cache interface {
+	// Validate returns ErrInvalidPayload if the payload doesn't conform to
+	// the JSON encoded channel message schema. Payloads published on
+	// channels without schema are always valid.
+	Validate(chanID string, schema, payload []byte) error
+}
+
+type entry struct {
+	chanID    string
+	schema    []byte
+	validator Validator
+}
+
+type cache struct {
+	size    int
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+var _ Cache = (*cache)(nil)
+
+// NewCache returns a schema cache which keeps the parsed schemas of at most
+// size channels, evicting the least recently used ones. The schema is
+// retrieved along with the channel authorization, so the cached schema is
+// replaced as soon as the channel schema changes.
+func NewCache(size int) Cache {
+	return &cache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (c *cache) Validate(chanID string, schema, payload []byte) error {
+	if len(schema) == 0 {
+		c.remove(chanID)
+		return nil
+	}
+
+	v, err := c.validator(chanID, schema)
+	if err != nil {
+		return err
+	}
+
+	return v.Validate(payload)
+}
+
+func (c *cache) validator(chanID string, schema []byte) (Validator, error) {
+	c.mu.Lock()
+	if el, ok := c.entries[chanID]; ok {
+		e := el.Value.(*entry)
+		if bytes.Equal(e.schema, schema) {
+			c.order.MoveToFront(el)
+			c.mu.Unlock()
+			return e.validator, nil
+		}
+	}
+	c.mu.Unlock()
+
+	v, err := Parse(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[chanID]; ok {
+		el.Value = &entry{chanID: chanID, schema: schema, validator: v}
+		c.order.MoveToFront(el)
+		return v, nil
+	}
+	c.entries[chanID] = c.order.PushFront(&entry{chanID: chanID, schema: schema, validator: v})
+	for c.order.Len() > c.size {
+		el := c.order.Back()
+		c.order.Remove(el)
+		delete(c.entries, el.Value.(*entry).chanID)
+	}
+
+	return v, nil
+}
+
+func (c *cache) remove(chanID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[chanID]; ok {
+		c.order.Remove(el)
+		delete(c.entries, chanID)
+	}
+}
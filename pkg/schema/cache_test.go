@@ -0,0 +1,84 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schema_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheValidate(t *testing.T) {
+	cache := schema.NewCache(10)
+	chanID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc    string
+		chanID  string
+		schema  string
+		payload string
+		err     error
+	}{
+		{
+			desc:    "validate valid payload",
+			chanID:  chanID,
+			schema:  jsonSchema,
+			payload: `{"temp":21.5}`,
+		},
+		{
+			desc:    "validate invalid payload using cached schema",
+			chanID:  chanID,
+			schema:  jsonSchema,
+			payload: `{"hum":40}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload using changed schema",
+			chanID:  chanID,
+			schema:  senmlSchema,
+			payload: `{"temp":21.5}`,
+			err:     schema.ErrInvalidPayload,
+		},
+		{
+			desc:    "validate payload on channel without schema",
+			chanID:  chanID,
+			payload: `not JSON`,
+		},
+		{
+			desc:    "validate payload on channel with invalid schema",
+			chanID:  testsutil.GenerateUUID(t),
+			schema:  `{}`,
+			payload: `{"temp":21.5}`,
+			err:     schema.ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := cache.Validate(tc.chanID, []byte(tc.schema), []byte(tc.payload))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	cache := schema.NewCache(2)
+
+	chanIDs := []string{testsutil.GenerateUUID(t), testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)}
+	for _, id := range chanIDs {
+		err := cache.Validate(id, []byte(jsonSchema), []byte(`{"temp":21.5}`))
+		assert.Nil(t, err, fmt.Sprintf("validate payload unexpected error: %s", err))
+	}
+
+	// The evicted schemas are parsed again, so validation doesn't depend
+	// on the cache size.
+	for _, id := range chanIDs {
+		err := cache.Validate(id, []byte(jsonSchema), []byte(`{"hum":40}`))
+		assert.True(t, errors.Contains(err, schema.ErrInvalidPayload), fmt.Sprintf("expected %s got %s\n", schema.ErrInvalidPayload, err))
+	}
+}
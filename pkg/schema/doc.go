@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package schema contains the channel message schema definition and the
+// validation of published payloads against it.
+package schema
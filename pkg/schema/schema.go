@@ -0,0 +1,338 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/absmach/senml"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// MetadataKey is the channel metadata key holding the channel message schema.
+const MetadataKey = "schema"
+
+// SenML record value types.
+const (
+	NumberType = "number"
+	StringType = "string"
+	BoolType   = "bool"
+	DataType   = "data"
+)
+
+var (
+	// ErrInvalidSchema indicates a malformed channel message schema.
+	ErrInvalidSchema = errors.New("invalid message schema")
+
+	// ErrInvalidPayload indicates a payload which doesn't conform to the channel message schema.
+	ErrInvalidPayload = errors.New("payload does not conform to the channel message schema")
+
+	errEmptySchema  = errors.New("schema must contain JSON schema or SenML fields")
+	errExternalRef  = errors.New("JSON schema references must be local fragments")
+	errInvalidType  = errors.New("invalid SenML field type")
+	errInvalidRange = errors.New("SenML field min is greater than max")
+)
+
+// Field represents constraints of the SenML records with the given name.
+type Field struct {
+	// Required indicates that the message must contain a record with the name.
+	Required bool `json:"required,omitempty"`
+	// Unit is the required record unit.
+	Unit string `json:"unit,omitempty"`
+	// Type is the required type of the record value: number, string, bool or data.
+	Type string `json:"type,omitempty"`
+	// Min is the minimum numeric record value.
+	Min *float64 `json:"min,omitempty"`
+	// Max is the maximum numeric record value.
+	Max *float64 `json:"max,omitempty"`
+}
+
+// Schema represents the channel message schema. Payloads are validated
+// against the JSON Schema, the SenML fields constraints, or both.
+type Schema struct {
+	JSON  json.RawMessage  `json:"json,omitempty"`
+	SenML map[string]Field `json:"senml,omitempty"`
+}
+
+// Validator validates message payloads.
+type Validator interface {
+	// Validate returns ErrInvalidPayload if the payload doesn't conform to the schema.
+	Validate(payload []byte) error
+}
+
+// FromMetadata returns the JSON encoded channel message schema from the channel
+// metadata, or nil if the channel has no schema.
+func FromMetadata(metadata map[string]interface{}) ([]byte, error) {
+	s, ok := metadata[MetadataKey]
+	if !ok || s == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, errors.Wrap(ErrInvalidSchema, err)
+	}
+
+	return data, nil
+}
+
+// ValidateMetadata checks that the message schema in the channel metadata, if
+// any, is valid.
+func ValidateMetadata(metadata map[string]interface{}) error {
+	data, err := FromMetadata(metadata)
+	if err != nil || data == nil {
+		return err
+	}
+	_, err = Parse(data)
+
+	return err
+}
+
+// Parse parses the JSON encoded channel message schema and returns its validator.
+func Parse(data []byte) (Validator, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, errors.Wrap(ErrInvalidSchema, err)
+	}
+	if len(s.JSON) == 0 && len(s.SenML) == 0 {
+		return nil, errors.Wrap(ErrInvalidSchema, errEmptySchema)
+	}
+
+	v := validator{fields: s.SenML}
+	if len(s.JSON) > 0 {
+		// The JSON schema loader resolves the references by fetching the
+		// URLs and reading the files, so only the references within the
+		// schema itself are allowed.
+		var doc interface{}
+		if err := json.Unmarshal(s.JSON, &doc); err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, err)
+		}
+		if err := checkRefs(doc); err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, err)
+		}
+		js, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(doc))
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, err)
+		}
+		v.json = js
+	}
+	for name, f := range s.SenML {
+		if err := f.validate(); err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, fmt.Errorf("field %s: %w", name, err))
+		}
+	}
+
+	return v, nil
+}
+
+// checkRefs rejects the references which aren't local JSON pointer fragments,
+// and the schema IDs which would change the base URI the references are
+// resolved against. Only the subschemas are checked, so the instance values
+// of keywords such as default, const and enum may contain any keys, and the
+// local references must point to the subschemas too, since the referenced
+// value is loaded as a schema.
+func checkRefs(doc interface{}) error {
+	rc := refChecker{schemas: make(map[string]struct{})}
+	if err := rc.walk(doc, ""); err != nil {
+		return err
+	}
+	for _, ref := range rc.refs {
+		ptr := strings.TrimPrefix(ref, "#")
+		if p, err := url.PathUnescape(ptr); err == nil {
+			ptr = p
+		}
+		if _, ok := rc.schemas[ptr]; !ok {
+			return fmt.Errorf("%w: %s", errExternalRef, ref)
+		}
+	}
+
+	return nil
+}
+
+var (
+	// schemaKeywords hold a subschema, or a list of subschemas for items.
+	schemaKeywords = []string{"additionalItems", "additionalProperties", "contains", "else", "if", "items", "not", "propertyNames", "then"}
+	// schemaListKeywords hold a list of subschemas.
+	schemaListKeywords = []string{"allOf", "anyOf", "oneOf"}
+	// schemaMapKeywords hold subschemas by name. Dependencies which are lists
+	// of property names are skipped.
+	schemaMapKeywords = []string{"$defs", "definitions", "dependencies", "patternProperties", "properties"}
+
+	pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+)
+
+type refChecker struct {
+	schemas map[string]struct{}
+	refs    []string
+}
+
+func (rc *refChecker) walk(doc interface{}, ptr string) error {
+	s, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	rc.schemas[ptr] = struct{}{}
+
+	for k, v := range s {
+		kp := ptr + "/" + pointerEscaper.Replace(k)
+		switch {
+		case k == "$ref":
+			if str, ok := v.(string); ok {
+				if !strings.HasPrefix(str, "#") {
+					return fmt.Errorf("%w: %s", errExternalRef, str)
+				}
+				rc.refs = append(rc.refs, str)
+			}
+		case k == "$id" || k == "id":
+			if str, ok := v.(string); ok {
+				return fmt.Errorf("%w: %s %s", errExternalRef, k, str)
+			}
+		case slices.Contains(schemaKeywords, k):
+			if l, ok := v.([]interface{}); ok {
+				if err := rc.walkList(l, kp); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := rc.walk(v, kp); err != nil {
+				return err
+			}
+		case slices.Contains(schemaListKeywords, k):
+			if l, ok := v.([]interface{}); ok {
+				if err := rc.walkList(l, kp); err != nil {
+					return err
+				}
+			}
+		case slices.Contains(schemaMapKeywords, k):
+			if m, ok := v.(map[string]interface{}); ok {
+				for name, sub := range m {
+					if err := rc.walk(sub, kp+"/"+pointerEscaper.Replace(name)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (rc *refChecker) walkList(l []interface{}, ptr string) error {
+	for i, v := range l {
+		if err := rc.walk(v, fmt.Sprintf("%s/%d", ptr, i)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f Field) validate() error {
+	switch f.Type {
+	case "", NumberType, StringType, BoolType, DataType:
+	default:
+		return errInvalidType
+	}
+	if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+		return errInvalidRange
+	}
+
+	return nil
+}
+
+type validator struct {
+	json   *gojsonschema.Schema
+	fields map[string]Field
+}
+
+func (v validator) Validate(payload []byte) error {
+	if v.json != nil {
+		res, err := v.json.Validate(gojsonschema.NewBytesLoader(payload))
+		if err != nil {
+			return errors.Wrap(ErrInvalidPayload, err)
+		}
+		if !res.Valid() {
+			msgs := make([]string, len(res.Errors()))
+			for i, e := range res.Errors() {
+				msgs[i] = e.String()
+			}
+			return errors.Wrap(ErrInvalidPayload, errors.New(strings.Join(msgs, "; ")))
+		}
+	}
+	if len(v.fields) > 0 {
+		return v.validateSenML(payload)
+	}
+
+	return nil
+}
+
+func (v validator) validateSenML(payload []byte) error {
+	raw, err := senml.Decode(payload, senml.JSON)
+	if err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+	pack, err := senml.Normalize(raw)
+	if err != nil {
+		return errors.Wrap(ErrInvalidPayload, err)
+	}
+
+	found := make(map[string]bool)
+	for _, r := range pack.Records {
+		f, ok := v.fields[r.Name]
+		if !ok {
+			continue
+		}
+		found[r.Name] = true
+		if err := f.check(r); err != nil {
+			return errors.Wrap(ErrInvalidPayload, fmt.Errorf("record %s: %w", r.Name, err))
+		}
+	}
+	for name, f := range v.fields {
+		if f.Required && !found[name] {
+			return errors.Wrap(ErrInvalidPayload, fmt.Errorf("missing required record %s", name))
+		}
+	}
+
+	return nil
+}
+
+func (f Field) check(r senml.Record) error {
+	if f.Unit != "" && r.Unit != f.Unit {
+		return fmt.Errorf("unit %q, expected %q", r.Unit, f.Unit)
+	}
+	if typ := recordType(r); f.Type != "" && typ != f.Type {
+		return fmt.Errorf("value type %s, expected %s", typ, f.Type)
+	}
+	if f.Min == nil && f.Max == nil {
+		return nil
+	}
+	if r.Value == nil {
+		return fmt.Errorf("missing numeric value")
+	}
+	if f.Min != nil && *r.Value < *f.Min {
+		return fmt.Errorf("value %v less than %v", *r.Value, *f.Min)
+	}
+	if f.Max != nil && *r.Value > *f.Max {
+		return fmt.Errorf("value %v greater than %v", *r.Value, *f.Max)
+	}
+
+	return nil
+}
+
+func recordType(r senml.Record) string {
+	switch {
+	case r.StringValue != nil:
+		return StringType
+	case r.BoolValue != nil:
+		return BoolType
+	case r.DataValue != nil:
+		return DataType
+	default:
+		return NumberType
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package schema_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	jsonSchema  = `{"json":{"type":"object","properties":{"temp":{"type":"number"}},"required":["temp"]}}`
+	senmlSchema = `{"senml":{"temp":{"required":true,"unit":"Cel","min":-40,"max":85},"status":{"type":"string"}}}`
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		desc   string
+		schema string
+		err    error
+	}{
+		{
+			desc:   "parse JSON schema",
+			schema: jsonSchema,
+		},
+		{
+			desc:   "parse SenML schema",
+			schema: senmlSchema,
+		},
+		{
+			desc:   "parse malformed schema",
+			schema: `{"json":`,
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "parse empty schema",
+			schema: `{}`,
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "parse invalid JSON schema",
+			schema: `{"json":{"type":"unknown"}}`,
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "parse JSON schema with local reference",
+			schema: `{"json":{"definitions":{"temp":{"type":"number"}},"properties":{"temp":{"$ref":"#/definitions/temp"}}}}`,
+		},
+		{
+			desc:   "parse JSON schema with remote reference",
+			schema: `{"json":{"properties":{"temp":{"$ref":"http://169.254.169.254/latest/meta-data"}}}}`,
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "parse JSON schema with file reference",
+			schema: `{"json":{"allOf":[{"$ref":"file:///etc/passwd"}]}}`,
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "parse JSON schema with relative reference",
+			schema: `{"json":{"$ref":"other.json#/definitions/temp"}}`,
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "parse JSON schema with remote base URI",
+			schema: `{"json":{"properties":{"temp":{"$id":"http://example.com/","$ref":"#/definitions/temp"}}}}`,
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "parse JSON schema with id property",
+			schema: `{"json":{"properties":{"id":{"type":"string"}}}}`,
+		},
+		{
+			desc:   "parse JSON schema with id in default value",
+			schema: `{"json":{"type":"object","default":{"id":"x"}}}`,
+		},
+		{
+			desc:   "parse JSON schema with ids in instance values",
+			schema: `{"json":{"properties":{"device":{"const":{"$id":"x"},"enum":[{"id":"y"}],"examples":[{"id":"z"}]}}}}`,
+		},
+		{
+			desc:   "parse JSON schema with id in nested subschema",
+			schema: `{"json":{"items":[{"anyOf":[{"id":"http://example.com/"}]}]}}`,
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "parse JSON schema with reference to default value",
+			schema: `{"json":{"default":{"$ref":"http://169.254.169.254/latest/meta-data"},"properties":{"temp":{"$ref":"#/default"}}}}`,
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "parse JSON schema with reference to root",
+			schema: `{"json":{"properties":{"child":{"$ref":"#"}}}}`,
+		},
+		{
+			desc:   "parse JSON schema with reference to escaped property",
+			schema: `{"json":{"properties":{"a/b":{"type":"number"},"c":{"$ref":"#/properties/a~1b"}}}}`,
+		},
+		{
+			desc:   "parse SenML schema with invalid type",
+			schema: `{"senml":{"temp":{"type":"float"}}}`,
+			err:    schema.ErrInvalidSchema,
+		},
+		{
+			desc:   "parse SenML schema with invalid range",
+			schema: `{"senml":{"temp":{"min":10,"max":1}}}`,
+			err:    schema.ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := schema.Parse([]byte(tc.schema))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	jsonValidator, err := schema.Parse([]byte(jsonSchema))
+	require.Nil(t, err, fmt.Sprintf("parse schema unexpected error: %s", err))
+	senmlValidator, err := schema.Parse([]byte(senmlSchema))
+	require.Nil(t, err, fmt.Sprintf("parse schema unexpected error: %s", err))
+
+	cases := []struct {
+		desc      string
+		validator schema.Validator
+		payload   string
+		err       error
+	}{
+		{
+			desc:      "validate valid JSON payload",
+			validator: jsonValidator,
+			payload:   `{"temp":21.5}`,
+		},
+		{
+			desc:      "validate JSON payload without required field",
+			validator: jsonValidator,
+			payload:   `{"hum":40}`,
+			err:       schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "validate JSON payload with invalid field type",
+			validator: jsonValidator,
+			payload:   `{"temp":"hot"}`,
+			err:       schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "validate malformed JSON payload",
+			validator: jsonValidator,
+			payload:   `{"temp":`,
+			err:       schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "validate valid SenML payload",
+			validator: senmlValidator,
+			payload:   `[{"n":"temp","u":"Cel","v":21.5},{"n":"status","vs":"ok"},{"n":"hum","v":40}]`,
+		},
+		{
+			desc:      "validate SenML payload without required record",
+			validator: senmlValidator,
+			payload:   `[{"n":"hum","v":40}]`,
+			err:       schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "validate SenML payload with invalid unit",
+			validator: senmlValidator,
+			payload:   `[{"n":"temp","u":"K","v":294}]`,
+			err:       schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "validate SenML payload with value out of range",
+			validator: senmlValidator,
+			payload:   `[{"n":"temp","u":"Cel","v":120}]`,
+			err:       schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "validate SenML payload with value below range",
+			validator: senmlValidator,
+			payload:   `[{"n":"temp","u":"Cel","v":-41}]`,
+			err:       schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "validate SenML payload with invalid value type",
+			validator: senmlValidator,
+			payload:   `[{"n":"temp","u":"Cel","v":20},{"n":"status","v":1}]`,
+			err:       schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "validate SenML payload with missing numeric value",
+			validator: senmlValidator,
+			payload:   `[{"n":"temp","u":"Cel","vs":"hot"}]`,
+			err:       schema.ErrInvalidPayload,
+		},
+		{
+			desc:      "validate non-SenML payload",
+			validator: senmlValidator,
+			payload:   `{"temp":21.5}`,
+			err:       schema.ErrInvalidPayload,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.validator.Validate([]byte(tc.payload))
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestValidateMetadata(t *testing.T) {
+	cases := []struct {
+		desc     string
+		metadata map[string]interface{}
+		err      error
+	}{
+		{
+			desc:     "validate metadata without schema",
+			metadata: map[string]interface{}{"location": "lab"},
+		},
+		{
+			desc: "validate metadata with valid schema",
+			metadata: map[string]interface{}{
+				schema.MetadataKey: map[string]interface{}{
+					"json": map[string]interface{}{"type": "object"},
+				},
+			},
+		},
+		{
+			desc:     "validate metadata with invalid schema",
+			metadata: map[string]interface{}{schema.MetadataKey: "schema"},
+			err:      schema.ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := schema.ValidateMetadata(tc.metadata)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
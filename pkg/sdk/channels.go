@@ -14,8 +14,9 @@ import (
 )
 
 const (
-	channelsEndpoint = "channels"
-	parentEndpoint   = "parent"
+	channelsEndpoint  = "channels"
+	parentEndpoint    = "parent"
+	provisionEndpoint = "provision"
 )
 
 // Channel represents supermq channel.
@@ -174,6 +175,27 @@ func (sdk mgSDK) Connect(conn Connection, domainID, token string) errors.SDKErro
 	return sdkerr
 }
 
+func (sdk mgSDK) Provision(manifest Manifest, domainID, token string) (ProvisionReport, errors.SDKError) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return ProvisionReport{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.channelsURL, domainID, channelsEndpoint, provisionEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated, http.StatusMultiStatus)
+	if sdkerr != nil {
+		return ProvisionReport{}, sdkerr
+	}
+
+	var report ProvisionReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		return ProvisionReport{}, errors.NewSDKError(err)
+	}
+
+	return report, nil
+}
+
 func (sdk mgSDK) Disconnect(conn Connection, domainID, token string) errors.SDKError {
 	data, err := json.Marshal(conn)
 	if err != nil {
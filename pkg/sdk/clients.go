@@ -19,6 +19,9 @@ const (
 	connectEndpoint     = "connect"
 	disconnectEndpoint  = "disconnect"
 	identifyEndpoint    = "identify"
+	secretEndpoint      = "secret"
+	rotateEndpoint      = "rotate"
+	confirmEndpoint     = "confirm"
 	rolesEndpoint       = "roles"
 	actionsEndpoint     = "actions"
 )
@@ -36,12 +39,25 @@ type Client struct {
 	UpdatedAt   time.Time              `json:"updated_at,omitempty"`
 	UpdatedBy   string                 `json:"updated_by,omitempty"`
 	Status      string                 `json:"status,omitempty"`
+	Presence    *ClientPresence        `json:"presence,omitempty"`
 	Permissions []string               `json:"permissions,omitempty"`
 }
 
+// ClientPresence represents the state of the latest session a client opened
+// on one of the messaging adapters.
+type ClientPresence struct {
+	Status       string     `json:"status"`
+	Protocol     string     `json:"protocol,omitempty"`
+	RemoteAddr   string     `json:"remote_addr,omitempty"`
+	SessionStart *time.Time `json:"session_start,omitempty"`
+	LastSeen     *time.Time `json:"last_seen,omitempty"`
+}
+
 type ClientCredentials struct {
-	Identity string `json:"identity,omitempty"`
-	Secret   string `json:"secret,omitempty"`
+	Identity        string     `json:"identity,omitempty"`
+	Secret          string     `json:"secret,omitempty"`
+	PendingSecret   string     `json:"pending_secret,omitempty"`
+	SecretExpiresAt *time.Time `json:"secret_expires_at,omitempty"`
 }
 
 func (sdk mgSDK) CreateClient(client Client, domainID, token string) (Client, errors.SDKError) {
@@ -193,6 +209,51 @@ func (sdk mgSDK) UpdateClientSecret(id, secret, domainID, token string) (Client,
 	return t, nil
 }
 
+func (sdk mgSDK) RotateClientSecret(id, secret string, duration time.Duration, domainID, token string) (Client, errors.SDKError) {
+	if id == "" {
+		return Client{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	rcsr := rotateClientSecretReq{Secret: secret, Duration: duration.String()}
+
+	data, err := json.Marshal(rcsr)
+	if err != nil {
+		return Client{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/%s/%s", sdk.clientsURL, domainID, clientsEndpoint, id, secretEndpoint, rotateEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Client{}, sdkerr
+	}
+
+	var t Client
+	if err = json.Unmarshal(body, &t); err != nil {
+		return Client{}, errors.NewSDKError(err)
+	}
+
+	return t, nil
+}
+
+func (sdk mgSDK) ConfirmClientSecret(id, domainID, token string) (Client, errors.SDKError) {
+	if id == "" {
+		return Client{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s/%s/%s", sdk.clientsURL, domainID, clientsEndpoint, id, secretEndpoint, confirmEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Client{}, sdkerr
+	}
+
+	var t Client
+	if err := json.Unmarshal(body, &t); err != nil {
+		return Client{}, errors.NewSDKError(err)
+	}
+
+	return t, nil
+}
+
 func (sdk mgSDK) EnableClient(id, domainID, token string) (Client, errors.SDKError) {
 	return sdk.changeClientStatus(id, enableEndpoint, domainID, token)
 }
@@ -217,6 +278,15 @@ func (sdk mgSDK) changeClientStatus(id, status, domainID, token string) (Client,
 	return t, nil
 }
 
+func (sdk mgSDK) UnlockClient(id, domainID, token string) errors.SDKError {
+	if id == "" {
+		return errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.clientsURL, domainID, clientsEndpoint, id, unlockEndpoint)
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusNoContent)
+	return sdkerr
+}
+
 func (sdk mgSDK) SetClientParent(id, domainID, groupID, token string) errors.SDKError {
 	scpg := parentGroupReq{ParentGroupID: groupID}
 	data, err := json.Marshal(scpg)
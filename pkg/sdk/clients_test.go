@@ -1341,6 +1341,262 @@ func TestDisableClient(t *testing.T) {
 	}
 }
 
+func TestUnlockClient(t *testing.T) {
+	ts, tsvc, auth := setupClients()
+	defer ts.Close()
+
+	client := generateTestClient(t)
+
+	conf := sdk.Config{
+		ClientsURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		domainID        string
+		token           string
+		session         smqauthn.Session
+		clientID        string
+		svcErr          error
+		authenticateErr error
+		err             errors.SDKError
+	}{
+		{
+			desc:     "unlock client successfully",
+			domainID: domainID,
+			token:    validToken,
+			clientID: client.ID,
+			svcErr:   nil,
+			err:      nil,
+		},
+		{
+			desc:            "unlock client with an invalid token",
+			domainID:        domainID,
+			token:           invalidToken,
+			clientID:        client.ID,
+			authenticateErr: svcerr.ErrAuthorization,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:     "unlock client with an invalid client id",
+			domainID: domainID,
+			token:    validToken,
+			clientID: wrongID,
+			svcErr:   svcerr.ErrViewEntity,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrViewEntity, http.StatusBadRequest),
+		},
+		{
+			desc:     "unlock client with empty client id",
+			domainID: domainID,
+			token:    validToken,
+			clientID: "",
+			svcErr:   nil,
+			err:      errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, mock.Anything).Return(tc.session, tc.authenticateErr)
+			svcCall := tsvc.On("Unlock", mock.Anything, tc.session, tc.clientID).Return(tc.svcErr)
+			err := mgsdk.UnlockClient(tc.clientID, tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "Unlock", mock.Anything, tc.session, tc.clientID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRotateClientSecret(t *testing.T) {
+	ts, tsvc, auth := setupClients()
+	defer ts.Close()
+
+	sdkClient := generateTestClient(t)
+	newSecret := generateUUID(t)
+	expiresAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	rotatedClient := sdkClient
+	rotatedClient.Credentials.PendingSecret = newSecret
+	rotatedClient.Credentials.SecretExpiresAt = &expiresAt
+
+	conf := sdk.Config{
+		ClientsURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		domainID        string
+		token           string
+		session         smqauthn.Session
+		clientID        string
+		newSecret       string
+		duration        time.Duration
+		svcRes          clients.Client
+		svcErr          error
+		authenticateErr error
+		response        sdk.Client
+		err             errors.SDKError
+	}{
+		{
+			desc:      "rotate client secret successfully",
+			domainID:  domainID,
+			token:     validToken,
+			clientID:  sdkClient.ID,
+			newSecret: newSecret,
+			duration:  time.Hour,
+			svcRes:    convertClient(rotatedClient),
+			response:  rotatedClient,
+			err:       nil,
+		},
+		{
+			desc:            "rotate client secret with an invalid token",
+			domainID:        domainID,
+			token:           invalidToken,
+			clientID:        sdkClient.ID,
+			newSecret:       newSecret,
+			duration:        time.Hour,
+			authenticateErr: svcerr.ErrAuthorization,
+			response:        sdk.Client{},
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:      "rotate client secret with conflicting secret",
+			domainID:  domainID,
+			token:     validToken,
+			clientID:  sdkClient.ID,
+			newSecret: newSecret,
+			duration:  time.Hour,
+			svcErr:    svcerr.ErrConflict,
+			response:  sdk.Client{},
+			err:       errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+		},
+		{
+			desc:      "rotate client secret with invalid duration",
+			domainID:  domainID,
+			token:     validToken,
+			clientID:  sdkClient.ID,
+			newSecret: newSecret,
+			duration:  0,
+			response:  sdk.Client{},
+			err:       errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidDuration), http.StatusBadRequest),
+		},
+		{
+			desc:      "rotate client secret with empty client id",
+			domainID:  domainID,
+			token:     validToken,
+			clientID:  "",
+			newSecret: newSecret,
+			duration:  time.Hour,
+			response:  sdk.Client{},
+			err:       errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, mock.Anything).Return(tc.session, tc.authenticateErr)
+			svcCall := tsvc.On("RotateSecret", mock.Anything, tc.session, tc.clientID, tc.newSecret, tc.duration).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.RotateClientSecret(tc.clientID, tc.newSecret, tc.duration, tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "RotateSecret", mock.Anything, tc.session, tc.clientID, tc.newSecret, tc.duration)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestConfirmClientSecret(t *testing.T) {
+	ts, tsvc, auth := setupClients()
+	defer ts.Close()
+
+	sdkClient := generateTestClient(t)
+
+	conf := sdk.Config{
+		ClientsURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		domainID        string
+		token           string
+		session         smqauthn.Session
+		clientID        string
+		svcRes          clients.Client
+		svcErr          error
+		authenticateErr error
+		response        sdk.Client
+		err             errors.SDKError
+	}{
+		{
+			desc:     "confirm client secret successfully",
+			domainID: domainID,
+			token:    validToken,
+			clientID: sdkClient.ID,
+			svcRes:   convertClient(sdkClient),
+			response: sdkClient,
+			err:      nil,
+		},
+		{
+			desc:            "confirm client secret with an invalid token",
+			domainID:        domainID,
+			token:           invalidToken,
+			clientID:        sdkClient.ID,
+			authenticateErr: svcerr.ErrAuthorization,
+			response:        sdk.Client{},
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:     "confirm client secret without pending secret",
+			domainID: domainID,
+			token:    validToken,
+			clientID: sdkClient.ID,
+			svcErr:   errors.Wrap(svcerr.ErrConflict, clients.ErrNoPendingSecret),
+			response: sdk.Client{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+		},
+		{
+			desc:     "confirm client secret with empty client id",
+			domainID: domainID,
+			token:    validToken,
+			clientID: "",
+			response: sdk.Client{},
+			err:      errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, mock.Anything).Return(tc.session, tc.authenticateErr)
+			svcCall := tsvc.On("ConfirmSecret", mock.Anything, tc.session, tc.clientID).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.ConfirmClientSecret(tc.clientID, tc.domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ConfirmSecret", mock.Anything, tc.session, tc.clientID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func TestDeleteClient(t *testing.T) {
 	ts, tsvc, auth := setupClients()
 	defer ts.Close()
@@ -0,0 +1,101 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const commandsEndpoint = "commands"
+
+// Command represents a command sent to the clients connected to a channel.
+type Command struct {
+	ID          string `json:"id,omitempty"`
+	DomainID    string `json:"domain_id,omitempty"`
+	ChannelID   string `json:"channel_id,omitempty"`
+	ClientID    string `json:"client_id,omitempty"`
+	Payload     string `json:"payload,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	// Timeout is the command timeout in seconds. It is only used when
+	// the command is sent.
+	Timeout   uint64    `json:"timeout,omitempty"`
+	Response  string    `json:"response,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// CommandsPage contains a page of commands.
+type CommandsPage struct {
+	Commands []Command `json:"commands"`
+	PageRes
+}
+
+func (sdk mgSDK) SendCommand(cmd Command, wait bool, domainID, token string) (Command, errors.SDKError) {
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return Command{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s?wait=%t", sdk.commandsURL, domainID, commandsEndpoint, wait)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Command{}, sdkerr
+	}
+
+	var c Command
+	if err := json.Unmarshal(body, &c); err != nil {
+		return Command{}, errors.NewSDKError(err)
+	}
+
+	return c, nil
+}
+
+func (sdk mgSDK) ViewCommand(id, domainID, token string) (Command, errors.SDKError) {
+	if id == "" {
+		return Command{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.commandsURL, domainID, commandsEndpoint, id)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Command{}, sdkerr
+	}
+
+	var c Command
+	if err := json.Unmarshal(body, &c); err != nil {
+		return Command{}, errors.NewSDKError(err)
+	}
+
+	return c, nil
+}
+
+func (sdk mgSDK) ListCommands(pm PageMetadata, domainID, token string) (CommandsPage, errors.SDKError) {
+	endpoint := fmt.Sprintf("%s/%s", domainID, commandsEndpoint)
+	url, err := sdk.withQueryParams(sdk.commandsURL, endpoint, pm)
+	if err != nil {
+		return CommandsPage{}, errors.NewSDKError(err)
+	}
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return CommandsPage{}, sdkerr
+	}
+
+	var cp CommandsPage
+	if err := json.Unmarshal(body, &cp); err != nil {
+		return CommandsPage{}, errors.NewSDKError(err)
+	}
+
+	return cp, nil
+}
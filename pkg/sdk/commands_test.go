@@ -0,0 +1,288 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/commands"
+	"github.com/absmach/supermq/commands/api"
+	"github.com/absmach/supermq/commands/mocks"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	sdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var command = commands.Command{
+	ID:        generateUUID(&testing.T{}),
+	DomainID:  domainID,
+	ChannelID: generateUUID(&testing.T{}),
+	Payload:   `{"cmd":"reboot"}`,
+	Response:  `{"status":"ok"}`,
+	Status:    commands.RepliedStatus,
+	ExpiresAt: time.Now().UTC().Add(time.Minute).Truncate(time.Second),
+	CreatedAt: time.Now().UTC().Truncate(time.Second),
+	CreatedBy: validID,
+}
+
+func setupCommands() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	logger := smqlog.NewMock()
+	mux := api.MakeHandler(svc, authn, logger, "commands", "test")
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestSendCommand(t *testing.T) {
+	ts, svc, authn := setupCommands()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{CommandsURL: ts.URL})
+
+	cmd := commands.Command{ChannelID: command.ChannelID, Payload: command.Payload}
+
+	cases := []struct {
+		desc     string
+		token    string
+		cmd      sdk.Command
+		wait     bool
+		timeout  time.Duration
+		session  smqauthn.Session
+		authnErr error
+		svcRes   commands.Command
+		svcErr   error
+		response sdk.Command
+		err      errors.SDKError
+	}{
+		{
+			desc:     "send command successfully",
+			token:    validToken,
+			cmd:      sdk.Command{ChannelID: command.ChannelID, Payload: command.Payload, Timeout: 10},
+			timeout:  10 * time.Second,
+			svcRes:   command,
+			response: convertCommand(command),
+		},
+		{
+			desc:     "send command and wait for reply",
+			token:    validToken,
+			cmd:      sdk.Command{ChannelID: command.ChannelID, Payload: command.Payload},
+			wait:     true,
+			timeout:  30 * time.Second,
+			svcRes:   command,
+			response: convertCommand(command),
+		},
+		{
+			desc:     "send command with invalid token",
+			token:    invalidToken,
+			cmd:      sdk.Command{ChannelID: command.ChannelID, Payload: command.Payload, Timeout: 10},
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "send command without channel",
+			token: validToken,
+			cmd:   sdk.Command{Payload: command.Payload},
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, errors.ErrMalformedEntity), http.StatusBadRequest),
+		},
+		{
+			desc:    "send command without channel permission",
+			token:   validToken,
+			cmd:     sdk.Command{ChannelID: command.ChannelID, Payload: command.Payload, Timeout: 10},
+			timeout: 10 * time.Second,
+			svcErr:  svcerr.ErrAuthorization,
+			err:     errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("SendCommand", mock.Anything, tc.session, cmd, tc.timeout, tc.wait).Return(tc.svcRes, tc.svcErr)
+			res, err := mgsdk.SendCommand(tc.cmd, tc.wait, domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, res)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "SendCommand", mock.Anything, tc.session, cmd, tc.timeout, tc.wait)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewCommand(t *testing.T) {
+	ts, svc, authn := setupCommands()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{CommandsURL: ts.URL})
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		session  smqauthn.Session
+		authnErr error
+		svcRes   commands.Command
+		svcErr   error
+		response sdk.Command
+		err      errors.SDKError
+	}{
+		{
+			desc:     "view command successfully",
+			token:    validToken,
+			id:       command.ID,
+			svcRes:   command,
+			response: convertCommand(command),
+		},
+		{
+			desc:     "view command with invalid token",
+			token:    invalidToken,
+			id:       command.ID,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "view command with empty id",
+			token: validToken,
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+		{
+			desc:   "view non-existing command",
+			token:  validToken,
+			id:     command.ID,
+			svcErr: svcerr.ErrNotFound,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ViewCommand", mock.Anything, tc.session, tc.id).Return(tc.svcRes, tc.svcErr)
+			res, err := mgsdk.ViewCommand(tc.id, domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, res)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ViewCommand", mock.Anything, tc.session, tc.id)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListCommands(t *testing.T) {
+	ts, svc, authn := setupCommands()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{CommandsURL: ts.URL})
+
+	cases := []struct {
+		desc     string
+		token    string
+		pageMeta sdk.PageMetadata
+		session  smqauthn.Session
+		authnErr error
+		svcReq   commands.PageMeta
+		svcRes   commands.Page
+		svcErr   error
+		response sdk.CommandsPage
+		err      errors.SDKError
+	}{
+		{
+			desc:     "list commands successfully",
+			token:    validToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 10},
+			svcReq:   commands.PageMeta{Offset: 0, Limit: 10},
+			svcRes: commands.Page{
+				PageMeta: commands.PageMeta{Offset: 0, Limit: 10},
+				Total:    1,
+				Commands: []commands.Command{command},
+			},
+			response: sdk.CommandsPage{
+				PageRes:  sdk.PageRes{Total: 1, Offset: 0, Limit: 10},
+				Commands: []sdk.Command{convertCommand(command)},
+			},
+		},
+		{
+			desc:     "list commands by channel and status",
+			token:    validToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 10, ChannelID: command.ChannelID, Status: string(commands.RepliedStatus)},
+			svcReq:   commands.PageMeta{Offset: 0, Limit: 10, ChannelID: command.ChannelID, Status: commands.RepliedStatus},
+			svcRes: commands.Page{
+				PageMeta: commands.PageMeta{Offset: 0, Limit: 10},
+				Total:    1,
+				Commands: []commands.Command{command},
+			},
+			response: sdk.CommandsPage{
+				PageRes:  sdk.PageRes{Total: 1, Offset: 0, Limit: 10},
+				Commands: []sdk.Command{convertCommand(command)},
+			},
+		},
+		{
+			desc:     "list commands with invalid token",
+			token:    invalidToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 10},
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "list commands with limit greater than max",
+			token:    validToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 1000},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrLimitSize), http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ListCommands", mock.Anything, tc.session, tc.svcReq).Return(tc.svcRes, tc.svcErr)
+			page, err := mgsdk.ListCommands(tc.pageMeta, domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, page)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ListCommands", mock.Anything, tc.session, tc.svcReq)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func convertCommand(c commands.Command) sdk.Command {
+	return sdk.Command{
+		ID:          c.ID,
+		DomainID:    c.DomainID,
+		ChannelID:   c.ChannelID,
+		ClientID:    c.ClientID,
+		Payload:     c.Payload,
+		ContentType: c.ContentType,
+		Response:    c.Response,
+		Status:      string(c.Status),
+		ExpiresAt:   c.ExpiresAt,
+		CreatedAt:   c.CreatedAt,
+		CreatedBy:   c.CreatedBy,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
@@ -0,0 +1,346 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/consumers/notifiers"
+	"github.com/absmach/supermq/consumers/notifiers/api"
+	"github.com/absmach/supermq/consumers/notifiers/mocks"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	sdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupSubscriptions() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	logger := smqlog.NewMock()
+	mux := api.MakeHandler(svc, authn, logger, "smtp-notifier", "test")
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestCreateSubscription(t *testing.T) {
+	ts, svc, authn := setupSubscriptions()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{NotifiersURL: ts.URL})
+
+	topic := fmt.Sprintf("channels.%s.temperature", validID)
+	contact := "user@example.com"
+
+	cases := []struct {
+		desc     string
+		token    string
+		topic    string
+		contact  string
+		session  smqauthn.Session
+		authnErr error
+		svcRes   string
+		svcErr   error
+		response string
+		err      errors.SDKError
+	}{
+		{
+			desc:     "create subscription successfully",
+			token:    validToken,
+			topic:    topic,
+			contact:  contact,
+			svcRes:   validID,
+			response: validID,
+		},
+		{
+			desc:     "create subscription with invalid token",
+			token:    invalidToken,
+			topic:    topic,
+			contact:  contact,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:    "create subscription with empty topic",
+			token:   validToken,
+			contact: contact,
+			err:     errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidTopic), http.StatusBadRequest),
+		},
+		{
+			desc:  "create subscription with empty contact",
+			token: validToken,
+			topic: topic,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidContact), http.StatusBadRequest),
+		},
+		{
+			desc:    "create existing subscription",
+			token:   validToken,
+			topic:   topic,
+			contact: contact,
+			svcErr:  svcerr.ErrConflict,
+			err:     errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{UserID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			sub := notifiers.Subscription{Topic: tc.topic, Contact: tc.contact}
+			svcCall := svc.On("CreateSubscription", mock.Anything, tc.session, sub).Return(tc.svcRes, tc.svcErr)
+			id, err := mgsdk.CreateSubscription(tc.topic, tc.contact, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, id)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "CreateSubscription", mock.Anything, tc.session, sub)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListSubscriptions(t *testing.T) {
+	ts, svc, authn := setupSubscriptions()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{NotifiersURL: ts.URL})
+
+	var subs []notifiers.Subscription
+	var sdkSubs []sdk.Subscription
+	for i := 0; i < 5; i++ {
+		sub := notifiers.Subscription{
+			ID:      generateUUID(t),
+			OwnerID: validID,
+			Topic:   fmt.Sprintf("channels.%s", validID),
+			Contact: fmt.Sprintf("user%d@example.com", i),
+		}
+		subs = append(subs, sub)
+		sdkSubs = append(sdkSubs, convertSubscription(sub))
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		pageMeta sdk.PageMetadata
+		session  smqauthn.Session
+		authnErr error
+		svcReq   notifiers.PageMetadata
+		svcRes   notifiers.Page
+		svcErr   error
+		response sdk.SubscriptionPage
+		err      errors.SDKError
+	}{
+		{
+			desc:     "list subscriptions successfully",
+			token:    validToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 10},
+			svcReq:   notifiers.PageMetadata{Offset: 0, Limit: 10},
+			svcRes: notifiers.Page{
+				PageMetadata:  notifiers.PageMetadata{Offset: 0, Limit: 10},
+				Total:         5,
+				Subscriptions: subs,
+			},
+			response: sdk.SubscriptionPage{
+				PageRes:       sdk.PageRes{Total: 5, Offset: 0, Limit: 10},
+				Subscriptions: sdkSubs,
+			},
+		},
+		{
+			desc:     "list subscriptions by topic",
+			token:    validToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 10, Topic: fmt.Sprintf("channels.%s", validID)},
+			svcReq:   notifiers.PageMetadata{Offset: 0, Limit: 10, Topic: fmt.Sprintf("channels.%s", validID)},
+			svcRes: notifiers.Page{
+				PageMetadata:  notifiers.PageMetadata{Offset: 0, Limit: 10},
+				Total:         1,
+				Subscriptions: subs[:1],
+			},
+			response: sdk.SubscriptionPage{
+				PageRes:       sdk.PageRes{Total: 1, Offset: 0, Limit: 10},
+				Subscriptions: sdkSubs[:1],
+			},
+		},
+		{
+			desc:     "list subscriptions with invalid token",
+			token:    invalidToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 10},
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "list subscriptions with limit greater than max",
+			token:    validToken,
+			pageMeta: sdk.PageMetadata{Offset: 0, Limit: 1000},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrLimitSize), http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{UserID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ListSubscriptions", mock.Anything, tc.session, tc.svcReq).Return(tc.svcRes, tc.svcErr)
+			page, err := mgsdk.ListSubscriptions(tc.pageMeta, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, page)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ListSubscriptions", mock.Anything, tc.session, tc.svcReq)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewSubscription(t *testing.T) {
+	ts, svc, authn := setupSubscriptions()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{NotifiersURL: ts.URL})
+
+	sub := notifiers.Subscription{
+		ID:      validID,
+		OwnerID: validID,
+		Topic:   fmt.Sprintf("channels.%s", validID),
+		Contact: "user@example.com",
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		session  smqauthn.Session
+		authnErr error
+		svcRes   notifiers.Subscription
+		svcErr   error
+		response sdk.Subscription
+		err      errors.SDKError
+	}{
+		{
+			desc:     "view subscription successfully",
+			token:    validToken,
+			id:       validID,
+			svcRes:   sub,
+			response: convertSubscription(sub),
+		},
+		{
+			desc:     "view subscription with invalid token",
+			token:    invalidToken,
+			id:       validID,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "view non-existing subscription",
+			token:  validToken,
+			id:     wrongID,
+			svcErr: svcerr.ErrNotFound,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:  "view subscription with empty id",
+			token: validToken,
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{UserID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ViewSubscription", mock.Anything, tc.session, tc.id).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.ViewSubscription(tc.id, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ViewSubscription", mock.Anything, tc.session, tc.id)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestDeleteSubscription(t *testing.T) {
+	ts, svc, authn := setupSubscriptions()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{NotifiersURL: ts.URL})
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		session  smqauthn.Session
+		authnErr error
+		svcErr   error
+		err      errors.SDKError
+	}{
+		{
+			desc:  "delete subscription successfully",
+			token: validToken,
+			id:    validID,
+		},
+		{
+			desc:     "delete subscription with invalid token",
+			token:    invalidToken,
+			id:       validID,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "delete non-existing subscription",
+			token:  validToken,
+			id:     wrongID,
+			svcErr: svcerr.ErrNotFound,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:  "delete subscription with empty id",
+			token: validToken,
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{UserID: validID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("RemoveSubscription", mock.Anything, tc.session, tc.id).Return(tc.svcErr)
+			err := mgsdk.DeleteSubscription(tc.id, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "RemoveSubscription", mock.Anything, tc.session, tc.id)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func convertSubscription(s notifiers.Subscription) sdk.Subscription {
+	return sdk.Subscription{
+		ID:      s.ID,
+		OwnerID: s.OwnerID,
+		Topic:   s.Topic,
+		Contact: s.Contact,
+	}
+}
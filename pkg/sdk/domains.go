@@ -32,6 +32,7 @@ type Domain struct {
 	UpdatedBy   string    `json:"updated_by,omitempty"`
 	UpdatedAt   time.Time `json:"updated_at,omitempty"`
 	Permissions []string  `json:"permissions,omitempty"`
+	MFARequired *bool     `json:"mfa_required,omitempty"`
 }
 
 func (sdk mgSDK) CreateDomain(domain Domain, token string) (Domain, errors.SDKError) {
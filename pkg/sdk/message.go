@@ -4,15 +4,29 @@
 package sdk
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	apiutil "github.com/absmach/supermq/api/http/util"
 	"github.com/absmach/supermq/pkg/errors"
 )
 
-const channelParts = 2
+const (
+	channelParts = 2
+	// headerPrefix is the prefix of the HTTP headers carrying message headers.
+	headerPrefix = "X-Smq-"
+)
+
+// LastValue is the last message published on the channel topic.
+type LastValue struct {
+	Payload     []byte            `json:"payload"`
+	ContentType string            `json:"content_type,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+}
 
 func (sdk mgSDK) SendMessage(chanName, msg, key string) errors.SDKError {
 	chanNameParts := strings.SplitN(chanName, ".", channelParts)
@@ -29,6 +43,65 @@ func (sdk mgSDK) SendMessage(chanName, msg, key string) errors.SDKError {
 	return err
 }
 
+func (sdk mgSDK) ReadLastValue(chanName, key string) (LastValue, errors.SDKError) {
+	chanNameParts := strings.SplitN(chanName, ".", channelParts)
+	chanID := chanNameParts[0]
+	subtopicPart := ""
+	if len(chanNameParts) == channelParts {
+		subtopicPart = fmt.Sprintf("/%s", strings.ReplaceAll(chanNameParts[1], ".", "/"))
+	}
+
+	reqURL := fmt.Sprintf("%s/channels/%s/messages%s", sdk.httpAdapterURL, chanID, subtopicPart)
+
+	header, body, sdkerr := sdk.processRequest(http.MethodGet, reqURL, ClientPrefix+key, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return LastValue{}, sdkerr
+	}
+
+	lv := LastValue{
+		Payload:     body,
+		ContentType: header.Get("Content-Type"),
+	}
+	for name, vals := range header {
+		name, ok := strings.CutPrefix(name, headerPrefix)
+		if !ok || len(vals) == 0 {
+			continue
+		}
+		if lv.Headers == nil {
+			lv.Headers = make(map[string]string)
+		}
+		lv.Headers[strings.ToLower(name)] = vals[0]
+	}
+
+	return lv, nil
+}
+
+func (sdk mgSDK) ReadMessages(pm MessagePageMetadata, chanName, token string) (MessagesPage, errors.SDKError) {
+	chanNameParts := strings.SplitN(chanName, ".", channelParts)
+	chanID := chanNameParts[0]
+	if len(chanNameParts) == channelParts {
+		pm.Subtopic = chanNameParts[1]
+	}
+
+	q, err := pm.query()
+	if err != nil {
+		return MessagesPage{}, errors.NewSDKError(err)
+	}
+	reqURL := fmt.Sprintf("%s/channels/%s/messages?%s", sdk.readersURL, chanID, q)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, reqURL, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return MessagesPage{}, sdkerr
+	}
+
+	var mp MessagesPage
+	if err := json.Unmarshal(body, &mp); err != nil {
+		return MessagesPage{}, errors.NewSDKError(err)
+	}
+
+	return mp, nil
+}
+
 func (sdk *mgSDK) SetContentType(ct ContentType) errors.SDKError {
 	if ct != CTJSON && ct != CTJSONSenML && ct != CTBinary {
 		return errors.NewSDKError(apiutil.ErrUnsupportedContentType)
@@ -38,3 +111,52 @@ func (sdk *mgSDK) SetContentType(ct ContentType) errors.SDKError {
 
 	return nil
 }
+
+func (pm MessagePageMetadata) query() (string, error) {
+	q, err := pm.PageMetadata.query()
+	if err != nil {
+		return "", err
+	}
+	vals, err := url.ParseQuery(q)
+	if err != nil {
+		return "", err
+	}
+	if pm.Subtopic != "" {
+		vals.Add("subtopic", pm.Subtopic)
+	}
+	if pm.Publisher != "" {
+		vals.Add("publisher", pm.Publisher)
+	}
+	if pm.Protocol != "" {
+		vals.Add("protocol", pm.Protocol)
+	}
+	if pm.Comparator != "" {
+		vals.Add("comparator", pm.Comparator)
+	}
+	if pm.Value != 0 {
+		vals.Add("v", strconv.FormatFloat(pm.Value, 'f', -1, 64))
+	}
+	if pm.BoolValue != nil {
+		vals.Add("vb", strconv.FormatBool(*pm.BoolValue))
+	}
+	if pm.StringValue != "" {
+		vals.Add("vs", pm.StringValue)
+	}
+	if pm.DataValue != "" {
+		vals.Add("vd", pm.DataValue)
+	}
+	if pm.From != 0 {
+		vals.Add("from", strconv.FormatFloat(pm.From, 'f', -1, 64))
+	}
+	if pm.To != 0 {
+		vals.Add("to", strconv.FormatFloat(pm.To, 'f', -1, 64))
+	}
+	if pm.Aggregation != "" {
+		vals.Add("aggregation", pm.Aggregation)
+	}
+	if pm.Interval != "" {
+		vals.Add("interval", pm.Interval)
+	}
+
+	return vals.Encode(), nil
+}
@@ -4,6 +4,7 @@
 package sdk_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -13,17 +14,27 @@ import (
 	proxy "github.com/absmach/mgate/pkg/http"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	climocks "github.com/absmach/supermq/clients/mocks"
 	adapter "github.com/absmach/supermq/http"
 	"github.com/absmach/supermq/http/api"
 	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	authzmocks "github.com/absmach/supermq/pkg/authz/mocks"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lastvalue"
+	"github.com/absmach/supermq/pkg/messaging"
 	pubsub "github.com/absmach/supermq/pkg/messaging/mocks"
+	schemamocks "github.com/absmach/supermq/pkg/schema/mocks"
 	sdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/absmach/supermq/readers"
+	readersapi "github.com/absmach/supermq/readers/api"
+	readersmocks "github.com/absmach/supermq/readers/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -31,6 +42,7 @@ import (
 var (
 	channelsGRPCClient *chmocks.ChannelsServiceClient
 	clientsGRPCClient  *climocks.ClientsServiceClient
+	lastValues         lastvalue.Store
 )
 
 func setupMessages() (*httptest.Server, *pubsub.PubSub) {
@@ -38,9 +50,14 @@ func setupMessages() (*httptest.Server, *pubsub.PubSub) {
 	channelsGRPCClient = new(chmocks.ChannelsServiceClient)
 	pub := new(pubsub.PubSub)
 	authn := new(authnmocks.Authentication)
-	handler := adapter.NewHandler(pub, authn, clientsGRPCClient, channelsGRPCClient, smqlog.NewMock())
+	authz := new(authzmocks.Authorization)
+	schemas := new(schemamocks.Cache)
+	schemas.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	handler := adapter.NewHandler(pub, authn, authz, clientsGRPCClient, channelsGRPCClient, schemas, nil, smqlog.NewMock())
+	lastValues = lastvalue.NewMemoryStore(100, 0)
+	lvSvc := adapter.NewLastValueService(lastValues, authn, authz, clientsGRPCClient, channelsGRPCClient, nil)
 
-	mux := api.MakeHandler(smqlog.NewMock(), "")
+	mux := api.MakeHandler(lvSvc, smqlog.NewMock(), "")
 	target := httptest.NewServer(mux)
 
 	config := mgate.Config{
@@ -52,7 +69,7 @@ func setupMessages() (*httptest.Server, *pubsub.PubSub) {
 		return nil, nil
 	}
 
-	return httptest.NewServer(http.HandlerFunc(mp.ServeHTTP)), pub
+	return httptest.NewServer(adapter.WithLastValue(mp, mux)), pub
 }
 
 func TestSendMessage(t *testing.T) {
@@ -160,6 +177,222 @@ func TestSendMessage(t *testing.T) {
 	}
 }
 
+func TestReadLastValue(t *testing.T) {
+	ts, _ := setupMessages()
+	defer ts.Close()
+
+	clientKey := "clientKey"
+	channelID := "channelID"
+	payload := `{"state":"on"}`
+
+	err := lastValues.Save(context.Background(), &messaging.Message{
+		Channel:  channelID,
+		Subtopic: "desired",
+		Payload:  []byte(payload),
+		Headers: map[string]string{
+			messaging.ContentTypeHeader:     "application/json",
+			messaging.CorrelationDataHeader: "request-1",
+		},
+	})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error saving last value: %s", err))
+
+	sdkConf := sdk.Config{
+		HTTPAdapterURL:  ts.URL,
+		TLSVerification: false,
+	}
+
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	cases := []struct {
+		desc      string
+		chanName  string
+		clientKey string
+		authRes   *grpcClientsV1.AuthnRes
+		authzRes  *grpcChannelsV1.AuthzRes
+		response  sdk.LastValue
+		err       errors.SDKError
+	}{
+		{
+			desc:      "read last value successfully",
+			chanName:  channelID + ".desired",
+			clientKey: clientKey,
+			authRes:   &grpcClientsV1.AuthnRes{Authenticated: true, Id: validID},
+			authzRes:  &grpcChannelsV1.AuthzRes{Authorized: true},
+			response: sdk.LastValue{
+				Payload:     []byte(payload),
+				ContentType: "application/json",
+				Headers:     map[string]string{messaging.CorrelationDataHeader: "request-1"},
+			},
+		},
+		{
+			desc:      "read last value with invalid client key",
+			chanName:  channelID + ".desired",
+			clientKey: "invalid",
+			authRes:   &grpcClientsV1.AuthnRes{Authenticated: false},
+			err:       errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:      "read last value without subscribe permission",
+			chanName:  channelID + ".desired",
+			clientKey: clientKey,
+			authRes:   &grpcClientsV1.AuthnRes{Authenticated: true, Id: validID},
+			authzRes:  &grpcChannelsV1.AuthzRes{Authorized: false},
+			err:       errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:      "read last value of topic without messages",
+			chanName:  channelID,
+			clientKey: clientKey,
+			authRes:   &grpcClientsV1.AuthnRes{Authenticated: true, Id: validID},
+			authzRes:  &grpcChannelsV1.AuthzRes{Authorized: true},
+			err:       errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := clientsGRPCClient.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{ClientSecret: tc.clientKey}).Return(tc.authRes, nil)
+			authzCall := channelsGRPCClient.On("Authorize", mock.Anything, mock.Anything).Return(tc.authzRes, nil)
+			lv, err := mgsdk.ReadLastValue(tc.chanName, tc.clientKey)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, lv)
+			authnCall.Unset()
+			authzCall.Unset()
+		})
+	}
+}
+
+func setupReaders() (*httptest.Server, *readersmocks.MessageRepository, *authnmocks.Authentication) {
+	clientsGRPCClient = new(climocks.ClientsServiceClient)
+	channelsGRPCClient = new(chmocks.ChannelsServiceClient)
+	repo := new(readersmocks.MessageRepository)
+	authn := new(authnmocks.Authentication)
+
+	mux := readersapi.MakeHandler(repo, authn, clientsGRPCClient, channelsGRPCClient, "test", "")
+
+	return httptest.NewServer(mux), repo, authn
+}
+
+func TestReadMessages(t *testing.T) {
+	ts, repo, authn := setupReaders()
+	defer ts.Close()
+
+	channelID := "channelID"
+	v := 1.6
+	msgs := []senml.Message{
+		{Channel: channelID, Name: "current", Value: &v, Time: 1},
+		{Channel: channelID, Name: "current", Value: &v, Time: 2},
+	}
+	rmsgs := []readers.Message{}
+	for _, m := range msgs {
+		rmsgs = append(rmsgs, m)
+	}
+
+	sdkConf := sdk.Config{
+		ReadersURL:      ts.URL,
+		TLSVerification: false,
+	}
+	mgsdk := sdk.NewSDK(sdkConf)
+
+	cases := []struct {
+		desc     string
+		chanName string
+		token    string
+		pm       sdk.MessagePageMetadata
+		rpm      readers.PageMetadata
+		authnErr error
+		authzRes *grpcChannelsV1.AuthzRes
+		page     readers.MessagesPage
+		response sdk.MessagesPage
+		err      errors.SDKError
+	}{
+		{
+			desc:     "read messages successfully",
+			chanName: channelID,
+			token:    validToken,
+			pm: sdk.MessagePageMetadata{
+				PageMetadata: sdk.PageMetadata{Limit: 10},
+			},
+			rpm:      readers.PageMetadata{Limit: 10, Format: "messages"},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			page:     readers.MessagesPage{Total: 2, Messages: rmsgs},
+			response: sdk.MessagesPage{
+				PageRes:  sdk.PageRes{Total: 2, Limit: 10},
+				Messages: msgs,
+			},
+		},
+		{
+			desc:     "read messages with subtopic and value filter",
+			chanName: channelID + ".subtopic",
+			token:    validToken,
+			pm: sdk.MessagePageMetadata{
+				PageMetadata: sdk.PageMetadata{Limit: 10},
+				Value:        v,
+				Comparator:   "eq",
+			},
+			rpm:      readers.PageMetadata{Limit: 10, Format: "messages", Subtopic: "subtopic", Value: v, Comparator: "eq"},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			page:     readers.MessagesPage{Total: 2, Messages: rmsgs},
+			response: sdk.MessagesPage{
+				PageRes:  sdk.PageRes{Total: 2, Limit: 10},
+				Messages: msgs,
+			},
+		},
+		{
+			desc:     "read messages with invalid token",
+			chanName: channelID,
+			token:    invalidToken,
+			pm: sdk.MessagePageMetadata{
+				PageMetadata: sdk.PageMetadata{Limit: 10},
+			},
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "read messages from unauthorized channel",
+			chanName: channelID,
+			token:    validToken,
+			pm: sdk.MessagePageMetadata{
+				PageMetadata: sdk.PageMetadata{Limit: 10},
+			},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: false},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:     "read messages with invalid aggregation",
+			chanName: channelID,
+			token:    validToken,
+			pm: sdk.MessagePageMetadata{
+				PageMetadata: sdk.PageMetadata{Limit: 10},
+				Aggregation:  "invalid",
+				Interval:     "1h",
+				From:         1,
+				To:           2,
+			},
+			err: errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrInvalidAggregation), http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(smqauthn.Session{UserID: validID}, tc.authnErr)
+			entityCall := channelsGRPCClient.On("RetrieveEntity", mock.Anything, mock.Anything).Return(&grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: channelID}}, nil)
+			authzCall := channelsGRPCClient.On("Authorize", mock.Anything, mock.Anything).Return(tc.authzRes, nil)
+			tc.page.PageMetadata = tc.rpm
+			repoCall := repo.On("ReadAll", channelID, tc.rpm).Return(tc.page, nil)
+			resp, err := mgsdk.ReadMessages(tc.pm, tc.chanName, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := repoCall.Parent.AssertCalled(t, "ReadAll", channelID, tc.rpm)
+				assert.True(t, ok)
+			}
+			authnCall.Unset()
+			entityCall.Unset()
+			authzCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
 func TestSetContentType(t *testing.T) {
 	ts, _ := setupMessages()
 	defer ts.Close()
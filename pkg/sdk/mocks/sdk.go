@@ -1238,6 +1238,66 @@ func (_c *SDK_Clients_Call) RunAndReturn(run func(sdk.PageMetadata, string, stri
 	return _c
 }
 
+// ConfirmClientSecret provides a mock function with given fields: id, domainID, token
+func (_m *SDK) ConfirmClientSecret(id string, domainID string, token string) (sdk.Client, errors.SDKError) {
+	ret := _m.Called(id, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmClientSecret")
+	}
+
+	var r0 sdk.Client
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Client, errors.SDKError)); ok {
+		return rf(id, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Client); ok {
+		r0 = rf(id, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Client)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(id, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_ConfirmClientSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmClientSecret'
+type SDK_ConfirmClientSecret_Call struct {
+	*mock.Call
+}
+
+// ConfirmClientSecret is a helper method to define mock.On call
+//   - id string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ConfirmClientSecret(id interface{}, domainID interface{}, token interface{}) *SDK_ConfirmClientSecret_Call {
+	return &SDK_ConfirmClientSecret_Call{Call: _e.mock.On("ConfirmClientSecret", id, domainID, token)}
+}
+
+func (_c *SDK_ConfirmClientSecret_Call) Run(run func(id string, domainID string, token string)) *SDK_ConfirmClientSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ConfirmClientSecret_Call) Return(_a0 sdk.Client, _a1 errors.SDKError) *SDK_ConfirmClientSecret_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_ConfirmClientSecret_Call) RunAndReturn(run func(string, string, string) (sdk.Client, errors.SDKError)) *SDK_ConfirmClientSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Connect provides a mock function with given fields: conn, domainID, token
 func (_m *SDK) Connect(conn sdk.Connection, domainID string, token string) errors.SDKError {
 	ret := _m.Called(conn, domainID, token)
@@ -1885,6 +1945,66 @@ func (_c *SDK_CreateGroupRole_Call) RunAndReturn(run func(string, string, sdk.Ro
 	return _c
 }
 
+// CreateSubscription provides a mock function with given fields: topic, contact, token
+func (_m *SDK) CreateSubscription(topic string, contact string, token string) (string, errors.SDKError) {
+	ret := _m.Called(topic, contact, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubscription")
+	}
+
+	var r0 string
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (string, errors.SDKError)); ok {
+		return rf(topic, contact, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(topic, contact, token)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(topic, contact, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_CreateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSubscription'
+type SDK_CreateSubscription_Call struct {
+	*mock.Call
+}
+
+// CreateSubscription is a helper method to define mock.On call
+//   - topic string
+//   - contact string
+//   - token string
+func (_e *SDK_Expecter) CreateSubscription(topic interface{}, contact interface{}, token interface{}) *SDK_CreateSubscription_Call {
+	return &SDK_CreateSubscription_Call{Call: _e.mock.On("CreateSubscription", topic, contact, token)}
+}
+
+func (_c *SDK_CreateSubscription_Call) Run(run func(topic string, contact string, token string)) *SDK_CreateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_CreateSubscription_Call) Return(_a0 string, _a1 errors.SDKError) *SDK_CreateSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_CreateSubscription_Call) RunAndReturn(run func(string, string, string) (string, errors.SDKError)) *SDK_CreateSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateToken provides a mock function with given fields: lt
 func (_m *SDK) CreateToken(lt sdk.Login) (sdk.Token, errors.SDKError) {
 	ret := _m.Called(lt)
@@ -1943,6 +2063,68 @@ func (_c *SDK_CreateToken_Call) RunAndReturn(run func(sdk.Login) (sdk.Token, err
 	return _c
 }
 
+// CreateTwin provides a mock function with given fields: clientID, channelID, desired, domainID, token
+func (_m *SDK) CreateTwin(clientID string, channelID string, desired map[string]interface{}, domainID string, token string) (sdk.Twin, errors.SDKError) {
+	ret := _m.Called(clientID, channelID, desired, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTwin")
+	}
+
+	var r0 sdk.Twin
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, map[string]interface{}, string, string) (sdk.Twin, errors.SDKError)); ok {
+		return rf(clientID, channelID, desired, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, map[string]interface{}, string, string) sdk.Twin); ok {
+		r0 = rf(clientID, channelID, desired, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Twin)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, map[string]interface{}, string, string) errors.SDKError); ok {
+		r1 = rf(clientID, channelID, desired, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_CreateTwin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTwin'
+type SDK_CreateTwin_Call struct {
+	*mock.Call
+}
+
+// CreateTwin is a helper method to define mock.On call
+//   - clientID string
+//   - channelID string
+//   - desired map[string]interface{}
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) CreateTwin(clientID interface{}, channelID interface{}, desired interface{}, domainID interface{}, token interface{}) *SDK_CreateTwin_Call {
+	return &SDK_CreateTwin_Call{Call: _e.mock.On("CreateTwin", clientID, channelID, desired, domainID, token)}
+}
+
+func (_c *SDK_CreateTwin_Call) Run(run func(clientID string, channelID string, desired map[string]interface{}, domainID string, token string)) *SDK_CreateTwin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(map[string]interface{}), args[3].(string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_CreateTwin_Call) Return(_a0 sdk.Twin, _a1 errors.SDKError) *SDK_CreateTwin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_CreateTwin_Call) RunAndReturn(run func(string, string, map[string]interface{}, string, string) (sdk.Twin, errors.SDKError)) *SDK_CreateTwin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function with given fields: user, token
 func (_m *SDK) CreateUser(user sdk.User, token string) (sdk.User, errors.SDKError) {
 	ret := _m.Called(user, token)
@@ -2352,6 +2534,105 @@ func (_c *SDK_DeleteInvitation_Call) RunAndReturn(run func(string, string, strin
 	return _c
 }
 
+// DeleteSubscription provides a mock function with given fields: id, token
+func (_m *SDK) DeleteSubscription(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_DeleteSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubscription'
+type SDK_DeleteSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteSubscription is a helper method to define mock.On call
+//   - id string
+//   - token string
+func (_e *SDK_Expecter) DeleteSubscription(id interface{}, token interface{}) *SDK_DeleteSubscription_Call {
+	return &SDK_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", id, token)}
+}
+
+func (_c *SDK_DeleteSubscription_Call) Run(run func(id string, token string)) *SDK_DeleteSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_DeleteSubscription_Call) Return(_a0 errors.SDKError) *SDK_DeleteSubscription_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_DeleteSubscription_Call) RunAndReturn(run func(string, string) errors.SDKError) *SDK_DeleteSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTwin provides a mock function with given fields: clientID, domainID, token
+func (_m *SDK) DeleteTwin(clientID string, domainID string, token string) errors.SDKError {
+	ret := _m.Called(clientID, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTwin")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(clientID, domainID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_DeleteTwin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTwin'
+type SDK_DeleteTwin_Call struct {
+	*mock.Call
+}
+
+// DeleteTwin is a helper method to define mock.On call
+//   - clientID string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) DeleteTwin(clientID interface{}, domainID interface{}, token interface{}) *SDK_DeleteTwin_Call {
+	return &SDK_DeleteTwin_Call{Call: _e.mock.On("DeleteTwin", clientID, domainID, token)}
+}
+
+func (_c *SDK_DeleteTwin_Call) Run(run func(clientID string, domainID string, token string)) *SDK_DeleteTwin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_DeleteTwin_Call) Return(_a0 errors.SDKError) *SDK_DeleteTwin_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_DeleteTwin_Call) RunAndReturn(run func(string, string, string) errors.SDKError) *SDK_DeleteTwin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteUser provides a mock function with given fields: id, token
 func (_m *SDK) DeleteUser(id string, token string) errors.SDKError {
 	ret := _m.Called(id, token)
@@ -2630,6 +2911,55 @@ func (_c *SDK_DisableGroup_Call) RunAndReturn(run func(string, string, string) (
 	return _c
 }
 
+// DisableMFA provides a mock function with given fields: code, token
+func (_m *SDK) DisableMFA(code string, token string) errors.SDKError {
+	ret := _m.Called(code, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableMFA")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(code, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_DisableMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableMFA'
+type SDK_DisableMFA_Call struct {
+	*mock.Call
+}
+
+// DisableMFA is a helper method to define mock.On call
+//   - code string
+//   - token string
+func (_e *SDK_Expecter) DisableMFA(code interface{}, token interface{}) *SDK_DisableMFA_Call {
+	return &SDK_DisableMFA_Call{Call: _e.mock.On("DisableMFA", code, token)}
+}
+
+func (_c *SDK_DisableMFA_Call) Run(run func(code string, token string)) *SDK_DisableMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_DisableMFA_Call) Return(_a0 errors.SDKError) *SDK_DisableMFA_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_DisableMFA_Call) RunAndReturn(run func(string, string) errors.SDKError) *SDK_DisableMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DisableUser provides a mock function with given fields: id, token
 func (_m *SDK) DisableUser(id string, token string) (sdk.User, errors.SDKError) {
 	ret := _m.Called(id, token)
@@ -3381,25 +3711,74 @@ func (_c *SDK_EnableGroup_Call) RunAndReturn(run func(string, string, string) (s
 	return _c
 }
 
-// EnableUser provides a mock function with given fields: id, token
-func (_m *SDK) EnableUser(id string, token string) (sdk.User, errors.SDKError) {
-	ret := _m.Called(id, token)
+// EnableMFA provides a mock function with given fields: code, token
+func (_m *SDK) EnableMFA(code string, token string) errors.SDKError {
+	ret := _m.Called(code, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for EnableUser")
+		panic("no return value specified for EnableMFA")
 	}
 
-	var r0 sdk.User
-	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string) (sdk.User, errors.SDKError)); ok {
-		return rf(id, token)
-	}
-	if rf, ok := ret.Get(0).(func(string, string) sdk.User); ok {
-		r0 = rf(id, token)
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(code, token)
 	} else {
-		r0 = ret.Get(0).(sdk.User)
-	}
-
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_EnableMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableMFA'
+type SDK_EnableMFA_Call struct {
+	*mock.Call
+}
+
+// EnableMFA is a helper method to define mock.On call
+//   - code string
+//   - token string
+func (_e *SDK_Expecter) EnableMFA(code interface{}, token interface{}) *SDK_EnableMFA_Call {
+	return &SDK_EnableMFA_Call{Call: _e.mock.On("EnableMFA", code, token)}
+}
+
+func (_c *SDK_EnableMFA_Call) Run(run func(code string, token string)) *SDK_EnableMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_EnableMFA_Call) Return(_a0 errors.SDKError) *SDK_EnableMFA_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_EnableMFA_Call) RunAndReturn(run func(string, string) errors.SDKError) *SDK_EnableMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnableUser provides a mock function with given fields: id, token
+func (_m *SDK) EnableUser(id string, token string) (sdk.User, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableUser")
+	}
+
+	var r0 sdk.User
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.User, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.User); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.User)
+	}
+
 	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
 		r1 = rf(id, token)
 	} else {
@@ -3440,6 +3819,64 @@ func (_c *SDK_EnableUser_Call) RunAndReturn(run func(string, string) (sdk.User,
 	return _c
 }
 
+// EnrollMFA provides a mock function with given fields: token
+func (_m *SDK) EnrollMFA(token string) (sdk.MFAEnrolment, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollMFA")
+	}
+
+	var r0 sdk.MFAEnrolment
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.MFAEnrolment, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.MFAEnrolment); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.MFAEnrolment)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_EnrollMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnrollMFA'
+type SDK_EnrollMFA_Call struct {
+	*mock.Call
+}
+
+// EnrollMFA is a helper method to define mock.On call
+//   - token string
+func (_e *SDK_Expecter) EnrollMFA(token interface{}) *SDK_EnrollMFA_Call {
+	return &SDK_EnrollMFA_Call{Call: _e.mock.On("EnrollMFA", token)}
+}
+
+func (_c *SDK_EnrollMFA_Call) Run(run func(token string)) *SDK_EnrollMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_EnrollMFA_Call) Return(_a0 sdk.MFAEnrolment, _a1 errors.SDKError) *SDK_EnrollMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_EnrollMFA_Call) RunAndReturn(run func(string) (sdk.MFAEnrolment, errors.SDKError)) *SDK_EnrollMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FreezeDomain provides a mock function with given fields: domainID, token
 func (_m *SDK) FreezeDomain(domainID string, token string) errors.SDKError {
 	ret := _m.Called(domainID, token)
@@ -4333,27 +4770,27 @@ func (_c *SDK_ListClientUsers_Call) RunAndReturn(run func(string, string, sdk.Pa
 	return _c
 }
 
-// ListDomainUsers provides a mock function with given fields: domainID, pm, token
-func (_m *SDK) ListDomainUsers(domainID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
-	ret := _m.Called(domainID, pm, token)
+// ListCommands provides a mock function with given fields: pm, domainID, token
+func (_m *SDK) ListCommands(pm sdk.PageMetadata, domainID string, token string) (sdk.CommandsPage, errors.SDKError) {
+	ret := _m.Called(pm, domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListDomainUsers")
+		panic("no return value specified for ListCommands")
 	}
 
-	var r0 sdk.UsersPage
+	var r0 sdk.CommandsPage
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
-		return rf(domainID, pm, token)
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string, string) (sdk.CommandsPage, errors.SDKError)); ok {
+		return rf(pm, domainID, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.UsersPage); ok {
-		r0 = rf(domainID, pm, token)
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string, string) sdk.CommandsPage); ok {
+		r0 = rf(pm, domainID, token)
 	} else {
-		r0 = ret.Get(0).(sdk.UsersPage)
+		r0 = ret.Get(0).(sdk.CommandsPage)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(domainID, pm, token)
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string, string) errors.SDKError); ok {
+		r1 = rf(pm, domainID, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -4363,57 +4800,57 @@ func (_m *SDK) ListDomainUsers(domainID string, pm sdk.PageMetadata, token strin
 	return r0, r1
 }
 
-// SDK_ListDomainUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDomainUsers'
-type SDK_ListDomainUsers_Call struct {
+// SDK_ListCommands_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCommands'
+type SDK_ListCommands_Call struct {
 	*mock.Call
 }
 
-// ListDomainUsers is a helper method to define mock.On call
-//   - domainID string
+// ListCommands is a helper method to define mock.On call
 //   - pm sdk.PageMetadata
+//   - domainID string
 //   - token string
-func (_e *SDK_Expecter) ListDomainUsers(domainID interface{}, pm interface{}, token interface{}) *SDK_ListDomainUsers_Call {
-	return &SDK_ListDomainUsers_Call{Call: _e.mock.On("ListDomainUsers", domainID, pm, token)}
+func (_e *SDK_Expecter) ListCommands(pm interface{}, domainID interface{}, token interface{}) *SDK_ListCommands_Call {
+	return &SDK_ListCommands_Call{Call: _e.mock.On("ListCommands", pm, domainID, token)}
 }
 
-func (_c *SDK_ListDomainUsers_Call) Run(run func(domainID string, pm sdk.PageMetadata, token string)) *SDK_ListDomainUsers_Call {
+func (_c *SDK_ListCommands_Call) Run(run func(pm sdk.PageMetadata, domainID string, token string)) *SDK_ListCommands_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(sdk.PageMetadata), args[2].(string))
+		run(args[0].(sdk.PageMetadata), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *SDK_ListDomainUsers_Call) Return(_a0 sdk.UsersPage, _a1 errors.SDKError) *SDK_ListDomainUsers_Call {
+func (_c *SDK_ListCommands_Call) Return(_a0 sdk.CommandsPage, _a1 errors.SDKError) *SDK_ListCommands_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SDK_ListDomainUsers_Call) RunAndReturn(run func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)) *SDK_ListDomainUsers_Call {
+func (_c *SDK_ListCommands_Call) RunAndReturn(run func(sdk.PageMetadata, string, string) (sdk.CommandsPage, errors.SDKError)) *SDK_ListCommands_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Members provides a mock function with given fields: groupID, domainID, pm, token
-func (_m *SDK) Members(groupID string, domainID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
-	ret := _m.Called(groupID, domainID, pm, token)
+// ListDomainUsers provides a mock function with given fields: domainID, pm, token
+func (_m *SDK) ListDomainUsers(domainID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
+	ret := _m.Called(domainID, pm, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Members")
+		panic("no return value specified for ListDomainUsers")
 	}
 
 	var r0 sdk.UsersPage
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
-		return rf(groupID, domainID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
+		return rf(domainID, pm, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, sdk.PageMetadata, string) sdk.UsersPage); ok {
-		r0 = rf(groupID, domainID, pm, token)
+	if rf, ok := ret.Get(0).(func(string, sdk.PageMetadata, string) sdk.UsersPage); ok {
+		r0 = rf(domainID, pm, token)
 	} else {
 		r0 = ret.Get(0).(sdk.UsersPage)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, sdk.PageMetadata, string) errors.SDKError); ok {
-		r1 = rf(groupID, domainID, pm, token)
+	if rf, ok := ret.Get(1).(func(string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(domainID, pm, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -4423,54 +4860,53 @@ func (_m *SDK) Members(groupID string, domainID string, pm sdk.PageMetadata, tok
 	return r0, r1
 }
 
-// SDK_Members_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Members'
-type SDK_Members_Call struct {
+// SDK_ListDomainUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDomainUsers'
+type SDK_ListDomainUsers_Call struct {
 	*mock.Call
 }
 
-// Members is a helper method to define mock.On call
-//   - groupID string
+// ListDomainUsers is a helper method to define mock.On call
 //   - domainID string
 //   - pm sdk.PageMetadata
 //   - token string
-func (_e *SDK_Expecter) Members(groupID interface{}, domainID interface{}, pm interface{}, token interface{}) *SDK_Members_Call {
-	return &SDK_Members_Call{Call: _e.mock.On("Members", groupID, domainID, pm, token)}
+func (_e *SDK_Expecter) ListDomainUsers(domainID interface{}, pm interface{}, token interface{}) *SDK_ListDomainUsers_Call {
+	return &SDK_ListDomainUsers_Call{Call: _e.mock.On("ListDomainUsers", domainID, pm, token)}
 }
 
-func (_c *SDK_Members_Call) Run(run func(groupID string, domainID string, pm sdk.PageMetadata, token string)) *SDK_Members_Call {
+func (_c *SDK_ListDomainUsers_Call) Run(run func(domainID string, pm sdk.PageMetadata, token string)) *SDK_ListDomainUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(sdk.PageMetadata), args[3].(string))
+		run(args[0].(string), args[1].(sdk.PageMetadata), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *SDK_Members_Call) Return(_a0 sdk.UsersPage, _a1 errors.SDKError) *SDK_Members_Call {
+func (_c *SDK_ListDomainUsers_Call) Return(_a0 sdk.UsersPage, _a1 errors.SDKError) *SDK_ListDomainUsers_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SDK_Members_Call) RunAndReturn(run func(string, string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)) *SDK_Members_Call {
+func (_c *SDK_ListDomainUsers_Call) RunAndReturn(run func(string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)) *SDK_ListDomainUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RefreshToken provides a mock function with given fields: token
-func (_m *SDK) RefreshToken(token string) (sdk.Token, errors.SDKError) {
+// ListSessions provides a mock function with given fields: token
+func (_m *SDK) ListSessions(token string) (sdk.SessionsPage, errors.SDKError) {
 	ret := _m.Called(token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RefreshToken")
+		panic("no return value specified for ListSessions")
 	}
 
-	var r0 sdk.Token
+	var r0 sdk.SessionsPage
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string) (sdk.Token, errors.SDKError)); ok {
+	if rf, ok := ret.Get(0).(func(string) (sdk.SessionsPage, errors.SDKError)); ok {
 		return rf(token)
 	}
-	if rf, ok := ret.Get(0).(func(string) sdk.Token); ok {
+	if rf, ok := ret.Get(0).(func(string) sdk.SessionsPage); ok {
 		r0 = rf(token)
 	} else {
-		r0 = ret.Get(0).(sdk.Token)
+		r0 = ret.Get(0).(sdk.SessionsPage)
 	}
 
 	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
@@ -4484,117 +4920,474 @@ func (_m *SDK) RefreshToken(token string) (sdk.Token, errors.SDKError) {
 	return r0, r1
 }
 
-// SDK_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
-type SDK_RefreshToken_Call struct {
+// SDK_ListSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSessions'
+type SDK_ListSessions_Call struct {
 	*mock.Call
 }
 
-// RefreshToken is a helper method to define mock.On call
+// ListSessions is a helper method to define mock.On call
 //   - token string
-func (_e *SDK_Expecter) RefreshToken(token interface{}) *SDK_RefreshToken_Call {
-	return &SDK_RefreshToken_Call{Call: _e.mock.On("RefreshToken", token)}
+func (_e *SDK_Expecter) ListSessions(token interface{}) *SDK_ListSessions_Call {
+	return &SDK_ListSessions_Call{Call: _e.mock.On("ListSessions", token)}
 }
 
-func (_c *SDK_RefreshToken_Call) Run(run func(token string)) *SDK_RefreshToken_Call {
+func (_c *SDK_ListSessions_Call) Run(run func(token string)) *SDK_ListSessions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *SDK_RefreshToken_Call) Return(_a0 sdk.Token, _a1 errors.SDKError) *SDK_RefreshToken_Call {
+func (_c *SDK_ListSessions_Call) Return(_a0 sdk.SessionsPage, _a1 errors.SDKError) *SDK_ListSessions_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SDK_RefreshToken_Call) RunAndReturn(run func(string) (sdk.Token, errors.SDKError)) *SDK_RefreshToken_Call {
+func (_c *SDK_ListSessions_Call) RunAndReturn(run func(string) (sdk.SessionsPage, errors.SDKError)) *SDK_ListSessions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RejectInvitation provides a mock function with given fields: domainID, token
-func (_m *SDK) RejectInvitation(domainID string, token string) error {
-	ret := _m.Called(domainID, token)
+// ListSubscriptions provides a mock function with given fields: pm, token
+func (_m *SDK) ListSubscriptions(pm sdk.PageMetadata, token string) (sdk.SubscriptionPage, errors.SDKError) {
+	ret := _m.Called(pm, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RejectInvitation")
+		panic("no return value specified for ListSubscriptions")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(domainID, token)
+	var r0 sdk.SubscriptionPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) (sdk.SubscriptionPage, errors.SDKError)); ok {
+		return rf(pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.PageMetadata, string) sdk.SubscriptionPage); ok {
+		r0 = rf(pm, token)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(sdk.SubscriptionPage)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
 }
 
-// SDK_RejectInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectInvitation'
-type SDK_RejectInvitation_Call struct {
+// SDK_ListSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubscriptions'
+type SDK_ListSubscriptions_Call struct {
 	*mock.Call
 }
 
-// RejectInvitation is a helper method to define mock.On call
-//   - domainID string
+// ListSubscriptions is a helper method to define mock.On call
+//   - pm sdk.PageMetadata
 //   - token string
-func (_e *SDK_Expecter) RejectInvitation(domainID interface{}, token interface{}) *SDK_RejectInvitation_Call {
-	return &SDK_RejectInvitation_Call{Call: _e.mock.On("RejectInvitation", domainID, token)}
+func (_e *SDK_Expecter) ListSubscriptions(pm interface{}, token interface{}) *SDK_ListSubscriptions_Call {
+	return &SDK_ListSubscriptions_Call{Call: _e.mock.On("ListSubscriptions", pm, token)}
 }
 
-func (_c *SDK_RejectInvitation_Call) Run(run func(domainID string, token string)) *SDK_RejectInvitation_Call {
+func (_c *SDK_ListSubscriptions_Call) Run(run func(pm sdk.PageMetadata, token string)) *SDK_ListSubscriptions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(sdk.PageMetadata), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *SDK_RejectInvitation_Call) Return(err error) *SDK_RejectInvitation_Call {
-	_c.Call.Return(err)
+func (_c *SDK_ListSubscriptions_Call) Return(_a0 sdk.SubscriptionPage, _a1 errors.SDKError) *SDK_ListSubscriptions_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SDK_RejectInvitation_Call) RunAndReturn(run func(string, string) error) *SDK_RejectInvitation_Call {
+func (_c *SDK_ListSubscriptions_Call) RunAndReturn(run func(sdk.PageMetadata, string) (sdk.SubscriptionPage, errors.SDKError)) *SDK_ListSubscriptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveAllChildren provides a mock function with given fields: id, domainID, token
-func (_m *SDK) RemoveAllChildren(id string, domainID string, token string) errors.SDKError {
-	ret := _m.Called(id, domainID, token)
+// Members provides a mock function with given fields: groupID, domainID, pm, token
+func (_m *SDK) Members(groupID string, domainID string, pm sdk.PageMetadata, token string) (sdk.UsersPage, errors.SDKError) {
+	ret := _m.Called(groupID, domainID, pm, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveAllChildren")
+		panic("no return value specified for Members")
 	}
 
-	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
-		r0 = rf(id, domainID, token)
+	var r0 sdk.UsersPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)); ok {
+		return rf(groupID, domainID, pm, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, sdk.PageMetadata, string) sdk.UsersPage); ok {
+		r0 = rf(groupID, domainID, pm, token)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(errors.SDKError)
+		r0 = ret.Get(0).(sdk.UsersPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, sdk.PageMetadata, string) errors.SDKError); ok {
+		r1 = rf(groupID, domainID, pm, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
 		}
 	}
 
-	return r0
+	return r0, r1
 }
 
-// SDK_RemoveAllChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveAllChildren'
-type SDK_RemoveAllChildren_Call struct {
+// SDK_Members_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Members'
+type SDK_Members_Call struct {
 	*mock.Call
 }
 
-// RemoveAllChildren is a helper method to define mock.On call
-//   - id string
+// Members is a helper method to define mock.On call
+//   - groupID string
 //   - domainID string
+//   - pm sdk.PageMetadata
 //   - token string
-func (_e *SDK_Expecter) RemoveAllChildren(id interface{}, domainID interface{}, token interface{}) *SDK_RemoveAllChildren_Call {
-	return &SDK_RemoveAllChildren_Call{Call: _e.mock.On("RemoveAllChildren", id, domainID, token)}
+func (_e *SDK_Expecter) Members(groupID interface{}, domainID interface{}, pm interface{}, token interface{}) *SDK_Members_Call {
+	return &SDK_Members_Call{Call: _e.mock.On("Members", groupID, domainID, pm, token)}
 }
 
-func (_c *SDK_RemoveAllChildren_Call) Run(run func(id string, domainID string, token string)) *SDK_RemoveAllChildren_Call {
+func (_c *SDK_Members_Call) Run(run func(groupID string, domainID string, pm sdk.PageMetadata, token string)) *SDK_Members_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(string), args[1].(string), args[2].(sdk.PageMetadata), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_Members_Call) Return(_a0 sdk.UsersPage, _a1 errors.SDKError) *SDK_Members_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_Members_Call) RunAndReturn(run func(string, string, sdk.PageMetadata, string) (sdk.UsersPage, errors.SDKError)) *SDK_Members_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Provision provides a mock function with given fields: manifest, domainID, token
+func (_m *SDK) Provision(manifest sdk.Manifest, domainID string, token string) (sdk.ProvisionReport, errors.SDKError) {
+	ret := _m.Called(manifest, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Provision")
+	}
+
+	var r0 sdk.ProvisionReport
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Manifest, string, string) (sdk.ProvisionReport, errors.SDKError)); ok {
+		return rf(manifest, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Manifest, string, string) sdk.ProvisionReport); ok {
+		r0 = rf(manifest, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.ProvisionReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Manifest, string, string) errors.SDKError); ok {
+		r1 = rf(manifest, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_Provision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Provision'
+type SDK_Provision_Call struct {
+	*mock.Call
+}
+
+// Provision is a helper method to define mock.On call
+//   - manifest sdk.Manifest
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) Provision(manifest interface{}, domainID interface{}, token interface{}) *SDK_Provision_Call {
+	return &SDK_Provision_Call{Call: _e.mock.On("Provision", manifest, domainID, token)}
+}
+
+func (_c *SDK_Provision_Call) Run(run func(manifest sdk.Manifest, domainID string, token string)) *SDK_Provision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(sdk.Manifest), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_Provision_Call) Return(_a0 sdk.ProvisionReport, _a1 errors.SDKError) *SDK_Provision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_Provision_Call) RunAndReturn(run func(sdk.Manifest, string, string) (sdk.ProvisionReport, errors.SDKError)) *SDK_Provision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadLastValue provides a mock function with given fields: chanName, key
+func (_m *SDK) ReadLastValue(chanName string, key string) (sdk.LastValue, errors.SDKError) {
+	ret := _m.Called(chanName, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadLastValue")
+	}
+
+	var r0 sdk.LastValue
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.LastValue, errors.SDKError)); ok {
+		return rf(chanName, key)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.LastValue); ok {
+		r0 = rf(chanName, key)
+	} else {
+		r0 = ret.Get(0).(sdk.LastValue)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(chanName, key)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_ReadLastValue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadLastValue'
+type SDK_ReadLastValue_Call struct {
+	*mock.Call
+}
+
+// ReadLastValue is a helper method to define mock.On call
+//   - chanName string
+//   - key string
+func (_e *SDK_Expecter) ReadLastValue(chanName interface{}, key interface{}) *SDK_ReadLastValue_Call {
+	return &SDK_ReadLastValue_Call{Call: _e.mock.On("ReadLastValue", chanName, key)}
+}
+
+func (_c *SDK_ReadLastValue_Call) Run(run func(chanName string, key string)) *SDK_ReadLastValue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ReadLastValue_Call) Return(_a0 sdk.LastValue, _a1 errors.SDKError) *SDK_ReadLastValue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_ReadLastValue_Call) RunAndReturn(run func(string, string) (sdk.LastValue, errors.SDKError)) *SDK_ReadLastValue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReadMessages provides a mock function with given fields: pm, chanID, token
+func (_m *SDK) ReadMessages(pm sdk.MessagePageMetadata, chanID string, token string) (sdk.MessagesPage, errors.SDKError) {
+	ret := _m.Called(pm, chanID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadMessages")
+	}
+
+	var r0 sdk.MessagesPage
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.MessagePageMetadata, string, string) (sdk.MessagesPage, errors.SDKError)); ok {
+		return rf(pm, chanID, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.MessagePageMetadata, string, string) sdk.MessagesPage); ok {
+		r0 = rf(pm, chanID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.MessagesPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.MessagePageMetadata, string, string) errors.SDKError); ok {
+		r1 = rf(pm, chanID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_ReadMessages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadMessages'
+type SDK_ReadMessages_Call struct {
+	*mock.Call
+}
+
+// ReadMessages is a helper method to define mock.On call
+//   - pm sdk.MessagePageMetadata
+//   - chanID string
+//   - token string
+func (_e *SDK_Expecter) ReadMessages(pm interface{}, chanID interface{}, token interface{}) *SDK_ReadMessages_Call {
+	return &SDK_ReadMessages_Call{Call: _e.mock.On("ReadMessages", pm, chanID, token)}
+}
+
+func (_c *SDK_ReadMessages_Call) Run(run func(pm sdk.MessagePageMetadata, chanID string, token string)) *SDK_ReadMessages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(sdk.MessagePageMetadata), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ReadMessages_Call) Return(_a0 sdk.MessagesPage, _a1 errors.SDKError) *SDK_ReadMessages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_ReadMessages_Call) RunAndReturn(run func(sdk.MessagePageMetadata, string, string) (sdk.MessagesPage, errors.SDKError)) *SDK_ReadMessages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshToken provides a mock function with given fields: token
+func (_m *SDK) RefreshToken(token string) (sdk.Token, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshToken")
+	}
+
+	var r0 sdk.Token
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.Token, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.Token); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.Token)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_RefreshToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshToken'
+type SDK_RefreshToken_Call struct {
+	*mock.Call
+}
+
+// RefreshToken is a helper method to define mock.On call
+//   - token string
+func (_e *SDK_Expecter) RefreshToken(token interface{}) *SDK_RefreshToken_Call {
+	return &SDK_RefreshToken_Call{Call: _e.mock.On("RefreshToken", token)}
+}
+
+func (_c *SDK_RefreshToken_Call) Run(run func(token string)) *SDK_RefreshToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_RefreshToken_Call) Return(_a0 sdk.Token, _a1 errors.SDKError) *SDK_RefreshToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_RefreshToken_Call) RunAndReturn(run func(string) (sdk.Token, errors.SDKError)) *SDK_RefreshToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RejectInvitation provides a mock function with given fields: domainID, token
+func (_m *SDK) RejectInvitation(domainID string, token string) error {
+	ret := _m.Called(domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(domainID, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SDK_RejectInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectInvitation'
+type SDK_RejectInvitation_Call struct {
+	*mock.Call
+}
+
+// RejectInvitation is a helper method to define mock.On call
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) RejectInvitation(domainID interface{}, token interface{}) *SDK_RejectInvitation_Call {
+	return &SDK_RejectInvitation_Call{Call: _e.mock.On("RejectInvitation", domainID, token)}
+}
+
+func (_c *SDK_RejectInvitation_Call) Run(run func(domainID string, token string)) *SDK_RejectInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_RejectInvitation_Call) Return(err error) *SDK_RejectInvitation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *SDK_RejectInvitation_Call) RunAndReturn(run func(string, string) error) *SDK_RejectInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveAllChildren provides a mock function with given fields: id, domainID, token
+func (_m *SDK) RemoveAllChildren(id string, domainID string, token string) errors.SDKError {
+	ret := _m.Called(id, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveAllChildren")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(id, domainID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_RemoveAllChildren_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveAllChildren'
+type SDK_RemoveAllChildren_Call struct {
+	*mock.Call
+}
+
+// RemoveAllChildren is a helper method to define mock.On call
+//   - id string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) RemoveAllChildren(id interface{}, domainID interface{}, token interface{}) *SDK_RemoveAllChildren_Call {
+	return &SDK_RemoveAllChildren_Call{Call: _e.mock.On("RemoveAllChildren", id, domainID, token)}
+}
+
+func (_c *SDK_RemoveAllChildren_Call) Run(run func(id string, domainID string, token string)) *SDK_RemoveAllChildren_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -5400,44 +6193,250 @@ type SDK_RemoveGroupRoleMembers_Call struct {
 	*mock.Call
 }
 
-// RemoveGroupRoleMembers is a helper method to define mock.On call
-//   - id string
-//   - roleID string
+// RemoveGroupRoleMembers is a helper method to define mock.On call
+//   - id string
+//   - roleID string
+//   - domainID string
+//   - members []string
+//   - token string
+func (_e *SDK_Expecter) RemoveGroupRoleMembers(id interface{}, roleID interface{}, domainID interface{}, members interface{}, token interface{}) *SDK_RemoveGroupRoleMembers_Call {
+	return &SDK_RemoveGroupRoleMembers_Call{Call: _e.mock.On("RemoveGroupRoleMembers", id, roleID, domainID, members, token)}
+}
+
+func (_c *SDK_RemoveGroupRoleMembers_Call) Run(run func(id string, roleID string, domainID string, members []string, token string)) *SDK_RemoveGroupRoleMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].([]string), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_RemoveGroupRoleMembers_Call) Return(_a0 errors.SDKError) *SDK_RemoveGroupRoleMembers_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_RemoveGroupRoleMembers_Call) RunAndReturn(run func(string, string, string, []string, string) errors.SDKError) *SDK_RemoveGroupRoleMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResendVerification provides a mock function with given fields: email
+func (_m *SDK) ResendVerification(email string) errors.SDKError {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerification")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) errors.SDKError); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_ResendVerification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResendVerification'
+type SDK_ResendVerification_Call struct {
+	*mock.Call
+}
+
+// ResendVerification is a helper method to define mock.On call
+//   - email string
+func (_e *SDK_Expecter) ResendVerification(email interface{}) *SDK_ResendVerification_Call {
+	return &SDK_ResendVerification_Call{Call: _e.mock.On("ResendVerification", email)}
+}
+
+func (_c *SDK_ResendVerification_Call) Run(run func(email string)) *SDK_ResendVerification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ResendVerification_Call) Return(_a0 errors.SDKError) *SDK_ResendVerification_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_ResendVerification_Call) RunAndReturn(run func(string) errors.SDKError) *SDK_ResendVerification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPassword provides a mock function with given fields: password, confPass, token
+func (_m *SDK) ResetPassword(password string, confPass string, token string) errors.SDKError {
+	ret := _m.Called(password, confPass, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPassword")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(password, confPass, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
+type SDK_ResetPassword_Call struct {
+	*mock.Call
+}
+
+// ResetPassword is a helper method to define mock.On call
+//   - password string
+//   - confPass string
+//   - token string
+func (_e *SDK_Expecter) ResetPassword(password interface{}, confPass interface{}, token interface{}) *SDK_ResetPassword_Call {
+	return &SDK_ResetPassword_Call{Call: _e.mock.On("ResetPassword", password, confPass, token)}
+}
+
+func (_c *SDK_ResetPassword_Call) Run(run func(password string, confPass string, token string)) *SDK_ResetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ResetPassword_Call) Return(_a0 errors.SDKError) *SDK_ResetPassword_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_ResetPassword_Call) RunAndReturn(run func(string, string, string) errors.SDKError) *SDK_ResetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetPasswordRequest provides a mock function with given fields: email
+func (_m *SDK) ResetPasswordRequest(email string) errors.SDKError {
+	ret := _m.Called(email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetPasswordRequest")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) errors.SDKError); ok {
+		r0 = rf(email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_ResetPasswordRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPasswordRequest'
+type SDK_ResetPasswordRequest_Call struct {
+	*mock.Call
+}
+
+// ResetPasswordRequest is a helper method to define mock.On call
+//   - email string
+func (_e *SDK_Expecter) ResetPasswordRequest(email interface{}) *SDK_ResetPasswordRequest_Call {
+	return &SDK_ResetPasswordRequest_Call{Call: _e.mock.On("ResetPasswordRequest", email)}
+}
+
+func (_c *SDK_ResetPasswordRequest_Call) Run(run func(email string)) *SDK_ResetPasswordRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ResetPasswordRequest_Call) Return(_a0 errors.SDKError) *SDK_ResetPasswordRequest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_ResetPasswordRequest_Call) RunAndReturn(run func(string) errors.SDKError) *SDK_ResetPasswordRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeCert provides a mock function with given fields: clientID, domainID, token
+func (_m *SDK) RevokeCert(clientID string, domainID string, token string) (time.Time, errors.SDKError) {
+	ret := _m.Called(clientID, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeCert")
+	}
+
+	var r0 time.Time
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (time.Time, errors.SDKError)); ok {
+		return rf(clientID, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) time.Time); ok {
+		r0 = rf(clientID, domainID, token)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(clientID, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_RevokeCert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeCert'
+type SDK_RevokeCert_Call struct {
+	*mock.Call
+}
+
+// RevokeCert is a helper method to define mock.On call
+//   - clientID string
 //   - domainID string
-//   - members []string
 //   - token string
-func (_e *SDK_Expecter) RemoveGroupRoleMembers(id interface{}, roleID interface{}, domainID interface{}, members interface{}, token interface{}) *SDK_RemoveGroupRoleMembers_Call {
-	return &SDK_RemoveGroupRoleMembers_Call{Call: _e.mock.On("RemoveGroupRoleMembers", id, roleID, domainID, members, token)}
+func (_e *SDK_Expecter) RevokeCert(clientID interface{}, domainID interface{}, token interface{}) *SDK_RevokeCert_Call {
+	return &SDK_RevokeCert_Call{Call: _e.mock.On("RevokeCert", clientID, domainID, token)}
 }
 
-func (_c *SDK_RemoveGroupRoleMembers_Call) Run(run func(id string, roleID string, domainID string, members []string, token string)) *SDK_RemoveGroupRoleMembers_Call {
+func (_c *SDK_RevokeCert_Call) Run(run func(clientID string, domainID string, token string)) *SDK_RevokeCert_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].([]string), args[4].(string))
+		run(args[0].(string), args[1].(string), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *SDK_RemoveGroupRoleMembers_Call) Return(_a0 errors.SDKError) *SDK_RemoveGroupRoleMembers_Call {
-	_c.Call.Return(_a0)
+func (_c *SDK_RevokeCert_Call) Return(_a0 time.Time, _a1 errors.SDKError) *SDK_RevokeCert_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SDK_RemoveGroupRoleMembers_Call) RunAndReturn(run func(string, string, string, []string, string) errors.SDKError) *SDK_RemoveGroupRoleMembers_Call {
+func (_c *SDK_RevokeCert_Call) RunAndReturn(run func(string, string, string) (time.Time, errors.SDKError)) *SDK_RevokeCert_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ResetPassword provides a mock function with given fields: password, confPass, token
-func (_m *SDK) ResetPassword(password string, confPass string, token string) errors.SDKError {
-	ret := _m.Called(password, confPass, token)
+// RevokeSession provides a mock function with given fields: id, token
+func (_m *SDK) RevokeSession(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResetPassword")
+		panic("no return value specified for RevokeSession")
 	}
 
 	var r0 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
-		r0 = rf(password, confPass, token)
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errors.SDKError)
@@ -5447,47 +6446,46 @@ func (_m *SDK) ResetPassword(password string, confPass string, token string) err
 	return r0
 }
 
-// SDK_ResetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPassword'
-type SDK_ResetPassword_Call struct {
+// SDK_RevokeSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSession'
+type SDK_RevokeSession_Call struct {
 	*mock.Call
 }
 
-// ResetPassword is a helper method to define mock.On call
-//   - password string
-//   - confPass string
+// RevokeSession is a helper method to define mock.On call
+//   - id string
 //   - token string
-func (_e *SDK_Expecter) ResetPassword(password interface{}, confPass interface{}, token interface{}) *SDK_ResetPassword_Call {
-	return &SDK_ResetPassword_Call{Call: _e.mock.On("ResetPassword", password, confPass, token)}
+func (_e *SDK_Expecter) RevokeSession(id interface{}, token interface{}) *SDK_RevokeSession_Call {
+	return &SDK_RevokeSession_Call{Call: _e.mock.On("RevokeSession", id, token)}
 }
 
-func (_c *SDK_ResetPassword_Call) Run(run func(password string, confPass string, token string)) *SDK_ResetPassword_Call {
+func (_c *SDK_RevokeSession_Call) Run(run func(id string, token string)) *SDK_RevokeSession_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *SDK_ResetPassword_Call) Return(_a0 errors.SDKError) *SDK_ResetPassword_Call {
+func (_c *SDK_RevokeSession_Call) Return(_a0 errors.SDKError) *SDK_RevokeSession_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *SDK_ResetPassword_Call) RunAndReturn(run func(string, string, string) errors.SDKError) *SDK_ResetPassword_Call {
+func (_c *SDK_RevokeSession_Call) RunAndReturn(run func(string, string) errors.SDKError) *SDK_RevokeSession_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ResetPasswordRequest provides a mock function with given fields: email
-func (_m *SDK) ResetPasswordRequest(email string) errors.SDKError {
-	ret := _m.Called(email)
+// RevokeSessions provides a mock function with given fields: token
+func (_m *SDK) RevokeSessions(token string) errors.SDKError {
+	ret := _m.Called(token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ResetPasswordRequest")
+		panic("no return value specified for RevokeSessions")
 	}
 
 	var r0 errors.SDKError
 	if rf, ok := ret.Get(0).(func(string) errors.SDKError); ok {
-		r0 = rf(email)
+		r0 = rf(token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(errors.SDKError)
@@ -5497,55 +6495,55 @@ func (_m *SDK) ResetPasswordRequest(email string) errors.SDKError {
 	return r0
 }
 
-// SDK_ResetPasswordRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetPasswordRequest'
-type SDK_ResetPasswordRequest_Call struct {
+// SDK_RevokeSessions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeSessions'
+type SDK_RevokeSessions_Call struct {
 	*mock.Call
 }
 
-// ResetPasswordRequest is a helper method to define mock.On call
-//   - email string
-func (_e *SDK_Expecter) ResetPasswordRequest(email interface{}) *SDK_ResetPasswordRequest_Call {
-	return &SDK_ResetPasswordRequest_Call{Call: _e.mock.On("ResetPasswordRequest", email)}
+// RevokeSessions is a helper method to define mock.On call
+//   - token string
+func (_e *SDK_Expecter) RevokeSessions(token interface{}) *SDK_RevokeSessions_Call {
+	return &SDK_RevokeSessions_Call{Call: _e.mock.On("RevokeSessions", token)}
 }
 
-func (_c *SDK_ResetPasswordRequest_Call) Run(run func(email string)) *SDK_ResetPasswordRequest_Call {
+func (_c *SDK_RevokeSessions_Call) Run(run func(token string)) *SDK_RevokeSessions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(string))
 	})
 	return _c
 }
 
-func (_c *SDK_ResetPasswordRequest_Call) Return(_a0 errors.SDKError) *SDK_ResetPasswordRequest_Call {
+func (_c *SDK_RevokeSessions_Call) Return(_a0 errors.SDKError) *SDK_RevokeSessions_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *SDK_ResetPasswordRequest_Call) RunAndReturn(run func(string) errors.SDKError) *SDK_ResetPasswordRequest_Call {
+func (_c *SDK_RevokeSessions_Call) RunAndReturn(run func(string) errors.SDKError) *SDK_RevokeSessions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RevokeCert provides a mock function with given fields: clientID, domainID, token
-func (_m *SDK) RevokeCert(clientID string, domainID string, token string) (time.Time, errors.SDKError) {
-	ret := _m.Called(clientID, domainID, token)
+// RotateClientSecret provides a mock function with given fields: id, secret, duration, domainID, token
+func (_m *SDK) RotateClientSecret(id string, secret string, duration time.Duration, domainID string, token string) (sdk.Client, errors.SDKError) {
+	ret := _m.Called(id, secret, duration, domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RevokeCert")
+		panic("no return value specified for RotateClientSecret")
 	}
 
-	var r0 time.Time
+	var r0 sdk.Client
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(string, string, string) (time.Time, errors.SDKError)); ok {
-		return rf(clientID, domainID, token)
+	if rf, ok := ret.Get(0).(func(string, string, time.Duration, string, string) (sdk.Client, errors.SDKError)); ok {
+		return rf(id, secret, duration, domainID, token)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) time.Time); ok {
-		r0 = rf(clientID, domainID, token)
+	if rf, ok := ret.Get(0).(func(string, string, time.Duration, string, string) sdk.Client); ok {
+		r0 = rf(id, secret, duration, domainID, token)
 	} else {
-		r0 = ret.Get(0).(time.Time)
+		r0 = ret.Get(0).(sdk.Client)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
-		r1 = rf(clientID, domainID, token)
+	if rf, ok := ret.Get(1).(func(string, string, time.Duration, string, string) errors.SDKError); ok {
+		r1 = rf(id, secret, duration, domainID, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -5555,32 +6553,34 @@ func (_m *SDK) RevokeCert(clientID string, domainID string, token string) (time.
 	return r0, r1
 }
 
-// SDK_RevokeCert_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeCert'
-type SDK_RevokeCert_Call struct {
+// SDK_RotateClientSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateClientSecret'
+type SDK_RotateClientSecret_Call struct {
 	*mock.Call
 }
 
-// RevokeCert is a helper method to define mock.On call
-//   - clientID string
+// RotateClientSecret is a helper method to define mock.On call
+//   - id string
+//   - secret string
+//   - duration time.Duration
 //   - domainID string
 //   - token string
-func (_e *SDK_Expecter) RevokeCert(clientID interface{}, domainID interface{}, token interface{}) *SDK_RevokeCert_Call {
-	return &SDK_RevokeCert_Call{Call: _e.mock.On("RevokeCert", clientID, domainID, token)}
+func (_e *SDK_Expecter) RotateClientSecret(id interface{}, secret interface{}, duration interface{}, domainID interface{}, token interface{}) *SDK_RotateClientSecret_Call {
+	return &SDK_RotateClientSecret_Call{Call: _e.mock.On("RotateClientSecret", id, secret, duration, domainID, token)}
 }
 
-func (_c *SDK_RevokeCert_Call) Run(run func(clientID string, domainID string, token string)) *SDK_RevokeCert_Call {
+func (_c *SDK_RotateClientSecret_Call) Run(run func(id string, secret string, duration time.Duration, domainID string, token string)) *SDK_RotateClientSecret_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(string), args[1].(string), args[2].(time.Duration), args[3].(string), args[4].(string))
 	})
 	return _c
 }
 
-func (_c *SDK_RevokeCert_Call) Return(_a0 time.Time, _a1 errors.SDKError) *SDK_RevokeCert_Call {
+func (_c *SDK_RotateClientSecret_Call) Return(_a0 sdk.Client, _a1 errors.SDKError) *SDK_RotateClientSecret_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SDK_RevokeCert_Call) RunAndReturn(run func(string, string, string) (time.Time, errors.SDKError)) *SDK_RevokeCert_Call {
+func (_c *SDK_RotateClientSecret_Call) RunAndReturn(run func(string, string, time.Duration, string, string) (sdk.Client, errors.SDKError)) *SDK_RotateClientSecret_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -5644,6 +6644,67 @@ func (_c *SDK_SearchUsers_Call) RunAndReturn(run func(sdk.PageMetadata, string)
 	return _c
 }
 
+// SendCommand provides a mock function with given fields: cmd, wait, domainID, token
+func (_m *SDK) SendCommand(cmd sdk.Command, wait bool, domainID string, token string) (sdk.Command, errors.SDKError) {
+	ret := _m.Called(cmd, wait, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendCommand")
+	}
+
+	var r0 sdk.Command
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.Command, bool, string, string) (sdk.Command, errors.SDKError)); ok {
+		return rf(cmd, wait, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.Command, bool, string, string) sdk.Command); ok {
+		r0 = rf(cmd, wait, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Command)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.Command, bool, string, string) errors.SDKError); ok {
+		r1 = rf(cmd, wait, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_SendCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendCommand'
+type SDK_SendCommand_Call struct {
+	*mock.Call
+}
+
+// SendCommand is a helper method to define mock.On call
+//   - cmd sdk.Command
+//   - wait bool
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) SendCommand(cmd interface{}, wait interface{}, domainID interface{}, token interface{}) *SDK_SendCommand_Call {
+	return &SDK_SendCommand_Call{Call: _e.mock.On("SendCommand", cmd, wait, domainID, token)}
+}
+
+func (_c *SDK_SendCommand_Call) Run(run func(cmd sdk.Command, wait bool, domainID string, token string)) *SDK_SendCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(sdk.Command), args[1].(bool), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_SendCommand_Call) Return(_a0 sdk.Command, _a1 errors.SDKError) *SDK_SendCommand_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_SendCommand_Call) RunAndReturn(run func(sdk.Command, bool, string, string) (sdk.Command, errors.SDKError)) *SDK_SendCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendInvitation provides a mock function with given fields: invitation, token
 func (_m *SDK) SendInvitation(invitation sdk.Invitation, token string) error {
 	ret := _m.Called(invitation, token)
@@ -5942,6 +7003,105 @@ func (_c *SDK_SetGroupParent_Call) RunAndReturn(run func(string, string, string,
 	return _c
 }
 
+// UnlockClient provides a mock function with given fields: id, domainID, token
+func (_m *SDK) UnlockClient(id string, domainID string, token string) errors.SDKError {
+	ret := _m.Called(id, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockClient")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) errors.SDKError); ok {
+		r0 = rf(id, domainID, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_UnlockClient_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockClient'
+type SDK_UnlockClient_Call struct {
+	*mock.Call
+}
+
+// UnlockClient is a helper method to define mock.On call
+//   - id string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) UnlockClient(id interface{}, domainID interface{}, token interface{}) *SDK_UnlockClient_Call {
+	return &SDK_UnlockClient_Call{Call: _e.mock.On("UnlockClient", id, domainID, token)}
+}
+
+func (_c *SDK_UnlockClient_Call) Run(run func(id string, domainID string, token string)) *SDK_UnlockClient_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_UnlockClient_Call) Return(_a0 errors.SDKError) *SDK_UnlockClient_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_UnlockClient_Call) RunAndReturn(run func(string, string, string) errors.SDKError) *SDK_UnlockClient_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnlockUser provides a mock function with given fields: id, token
+func (_m *SDK) UnlockUser(id string, token string) errors.SDKError {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnlockUser")
+	}
+
+	var r0 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) errors.SDKError); ok {
+		r0 = rf(id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(errors.SDKError)
+		}
+	}
+
+	return r0
+}
+
+// SDK_UnlockUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnlockUser'
+type SDK_UnlockUser_Call struct {
+	*mock.Call
+}
+
+// UnlockUser is a helper method to define mock.On call
+//   - id string
+//   - token string
+func (_e *SDK_Expecter) UnlockUser(id interface{}, token interface{}) *SDK_UnlockUser_Call {
+	return &SDK_UnlockUser_Call{Call: _e.mock.On("UnlockUser", id, token)}
+}
+
+func (_c *SDK_UnlockUser_Call) Run(run func(id string, token string)) *SDK_UnlockUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_UnlockUser_Call) Return(_a0 errors.SDKError) *SDK_UnlockUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SDK_UnlockUser_Call) RunAndReturn(run func(string, string) errors.SDKError) *SDK_UnlockUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateChannel provides a mock function with given fields: channel, domainID, token
 func (_m *SDK) UpdateChannel(channel sdk.Channel, domainID string, token string) (sdk.Channel, errors.SDKError) {
 	ret := _m.Called(channel, domainID, token)
@@ -6590,44 +7750,103 @@ func (_e *SDK_Expecter) UpdatePassword(oldPass interface{}, newPass interface{},
 	return &SDK_UpdatePassword_Call{Call: _e.mock.On("UpdatePassword", oldPass, newPass, token)}
 }
 
-func (_c *SDK_UpdatePassword_Call) Run(run func(oldPass string, newPass string, token string)) *SDK_UpdatePassword_Call {
+func (_c *SDK_UpdatePassword_Call) Run(run func(oldPass string, newPass string, token string)) *SDK_UpdatePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_UpdatePassword_Call) Return(_a0 sdk.User, _a1 errors.SDKError) *SDK_UpdatePassword_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_UpdatePassword_Call) RunAndReturn(run func(string, string, string) (sdk.User, errors.SDKError)) *SDK_UpdatePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfilePicture provides a mock function with given fields: user, token
+func (_m *SDK) UpdateProfilePicture(user sdk.User, token string) (sdk.User, errors.SDKError) {
+	ret := _m.Called(user, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfilePicture")
+	}
+
+	var r0 sdk.User
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(sdk.User, string) (sdk.User, errors.SDKError)); ok {
+		return rf(user, token)
+	}
+	if rf, ok := ret.Get(0).(func(sdk.User, string) sdk.User); ok {
+		r0 = rf(user, token)
+	} else {
+		r0 = ret.Get(0).(sdk.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(sdk.User, string) errors.SDKError); ok {
+		r1 = rf(user, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_UpdateProfilePicture_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfilePicture'
+type SDK_UpdateProfilePicture_Call struct {
+	*mock.Call
+}
+
+// UpdateProfilePicture is a helper method to define mock.On call
+//   - user sdk.User
+//   - token string
+func (_e *SDK_Expecter) UpdateProfilePicture(user interface{}, token interface{}) *SDK_UpdateProfilePicture_Call {
+	return &SDK_UpdateProfilePicture_Call{Call: _e.mock.On("UpdateProfilePicture", user, token)}
+}
+
+func (_c *SDK_UpdateProfilePicture_Call) Run(run func(user sdk.User, token string)) *SDK_UpdateProfilePicture_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(sdk.User), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *SDK_UpdatePassword_Call) Return(_a0 sdk.User, _a1 errors.SDKError) *SDK_UpdatePassword_Call {
+func (_c *SDK_UpdateProfilePicture_Call) Return(_a0 sdk.User, _a1 errors.SDKError) *SDK_UpdateProfilePicture_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SDK_UpdatePassword_Call) RunAndReturn(run func(string, string, string) (sdk.User, errors.SDKError)) *SDK_UpdatePassword_Call {
+func (_c *SDK_UpdateProfilePicture_Call) RunAndReturn(run func(sdk.User, string) (sdk.User, errors.SDKError)) *SDK_UpdateProfilePicture_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateProfilePicture provides a mock function with given fields: user, token
-func (_m *SDK) UpdateProfilePicture(user sdk.User, token string) (sdk.User, errors.SDKError) {
-	ret := _m.Called(user, token)
+// UpdateTwinDesired provides a mock function with given fields: clientID, desired, revision, domainID, token
+func (_m *SDK) UpdateTwinDesired(clientID string, desired map[string]interface{}, revision uint64, domainID string, token string) (sdk.Twin, errors.SDKError) {
+	ret := _m.Called(clientID, desired, revision, domainID, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateProfilePicture")
+		panic("no return value specified for UpdateTwinDesired")
 	}
 
-	var r0 sdk.User
+	var r0 sdk.Twin
 	var r1 errors.SDKError
-	if rf, ok := ret.Get(0).(func(sdk.User, string) (sdk.User, errors.SDKError)); ok {
-		return rf(user, token)
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}, uint64, string, string) (sdk.Twin, errors.SDKError)); ok {
+		return rf(clientID, desired, revision, domainID, token)
 	}
-	if rf, ok := ret.Get(0).(func(sdk.User, string) sdk.User); ok {
-		r0 = rf(user, token)
+	if rf, ok := ret.Get(0).(func(string, map[string]interface{}, uint64, string, string) sdk.Twin); ok {
+		r0 = rf(clientID, desired, revision, domainID, token)
 	} else {
-		r0 = ret.Get(0).(sdk.User)
+		r0 = ret.Get(0).(sdk.Twin)
 	}
 
-	if rf, ok := ret.Get(1).(func(sdk.User, string) errors.SDKError); ok {
-		r1 = rf(user, token)
+	if rf, ok := ret.Get(1).(func(string, map[string]interface{}, uint64, string, string) errors.SDKError); ok {
+		r1 = rf(clientID, desired, revision, domainID, token)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(errors.SDKError)
@@ -6637,31 +7856,34 @@ func (_m *SDK) UpdateProfilePicture(user sdk.User, token string) (sdk.User, erro
 	return r0, r1
 }
 
-// SDK_UpdateProfilePicture_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfilePicture'
-type SDK_UpdateProfilePicture_Call struct {
+// SDK_UpdateTwinDesired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTwinDesired'
+type SDK_UpdateTwinDesired_Call struct {
 	*mock.Call
 }
 
-// UpdateProfilePicture is a helper method to define mock.On call
-//   - user sdk.User
+// UpdateTwinDesired is a helper method to define mock.On call
+//   - clientID string
+//   - desired map[string]interface{}
+//   - revision uint64
+//   - domainID string
 //   - token string
-func (_e *SDK_Expecter) UpdateProfilePicture(user interface{}, token interface{}) *SDK_UpdateProfilePicture_Call {
-	return &SDK_UpdateProfilePicture_Call{Call: _e.mock.On("UpdateProfilePicture", user, token)}
+func (_e *SDK_Expecter) UpdateTwinDesired(clientID interface{}, desired interface{}, revision interface{}, domainID interface{}, token interface{}) *SDK_UpdateTwinDesired_Call {
+	return &SDK_UpdateTwinDesired_Call{Call: _e.mock.On("UpdateTwinDesired", clientID, desired, revision, domainID, token)}
 }
 
-func (_c *SDK_UpdateProfilePicture_Call) Run(run func(user sdk.User, token string)) *SDK_UpdateProfilePicture_Call {
+func (_c *SDK_UpdateTwinDesired_Call) Run(run func(clientID string, desired map[string]interface{}, revision uint64, domainID string, token string)) *SDK_UpdateTwinDesired_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(sdk.User), args[1].(string))
+		run(args[0].(string), args[1].(map[string]interface{}), args[2].(uint64), args[3].(string), args[4].(string))
 	})
 	return _c
 }
 
-func (_c *SDK_UpdateProfilePicture_Call) Return(_a0 sdk.User, _a1 errors.SDKError) *SDK_UpdateProfilePicture_Call {
+func (_c *SDK_UpdateTwinDesired_Call) Return(_a0 sdk.Twin, _a1 errors.SDKError) *SDK_UpdateTwinDesired_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SDK_UpdateProfilePicture_Call) RunAndReturn(run func(sdk.User, string) (sdk.User, errors.SDKError)) *SDK_UpdateProfilePicture_Call {
+func (_c *SDK_UpdateTwinDesired_Call) RunAndReturn(run func(string, map[string]interface{}, uint64, string, string) (sdk.Twin, errors.SDKError)) *SDK_UpdateTwinDesired_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -7137,6 +8359,123 @@ func (_c *SDK_Users_Call) RunAndReturn(run func(sdk.PageMetadata, string) (sdk.U
 	return _c
 }
 
+// VerifyEmail provides a mock function with given fields: token
+func (_m *SDK) VerifyEmail(token string) (sdk.User, errors.SDKError) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 sdk.User
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string) (sdk.User, errors.SDKError)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) sdk.User); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Get(0).(sdk.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) errors.SDKError); ok {
+		r1 = rf(token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_VerifyEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyEmail'
+type SDK_VerifyEmail_Call struct {
+	*mock.Call
+}
+
+// VerifyEmail is a helper method to define mock.On call
+//   - token string
+func (_e *SDK_Expecter) VerifyEmail(token interface{}) *SDK_VerifyEmail_Call {
+	return &SDK_VerifyEmail_Call{Call: _e.mock.On("VerifyEmail", token)}
+}
+
+func (_c *SDK_VerifyEmail_Call) Run(run func(token string)) *SDK_VerifyEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_VerifyEmail_Call) Return(_a0 sdk.User, _a1 errors.SDKError) *SDK_VerifyEmail_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_VerifyEmail_Call) RunAndReturn(run func(string) (sdk.User, errors.SDKError)) *SDK_VerifyEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// VerifyMFA provides a mock function with given fields: mfaToken, code
+func (_m *SDK) VerifyMFA(mfaToken string, code string) (sdk.Token, errors.SDKError) {
+	ret := _m.Called(mfaToken, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMFA")
+	}
+
+	var r0 sdk.Token
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Token, errors.SDKError)); ok {
+		return rf(mfaToken, code)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Token); ok {
+		r0 = rf(mfaToken, code)
+	} else {
+		r0 = ret.Get(0).(sdk.Token)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(mfaToken, code)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_VerifyMFA_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VerifyMFA'
+type SDK_VerifyMFA_Call struct {
+	*mock.Call
+}
+
+// VerifyMFA is a helper method to define mock.On call
+//   - mfaToken string
+//   - code string
+func (_e *SDK_Expecter) VerifyMFA(mfaToken interface{}, code interface{}) *SDK_VerifyMFA_Call {
+	return &SDK_VerifyMFA_Call{Call: _e.mock.On("VerifyMFA", mfaToken, code)}
+}
+
+func (_c *SDK_VerifyMFA_Call) Run(run func(mfaToken string, code string)) *SDK_VerifyMFA_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_VerifyMFA_Call) Return(_a0 sdk.Token, _a1 errors.SDKError) *SDK_VerifyMFA_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_VerifyMFA_Call) RunAndReturn(run func(string, string) (sdk.Token, errors.SDKError)) *SDK_VerifyMFA_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ViewCert provides a mock function with given fields: certID, domainID, token
 func (_m *SDK) ViewCert(certID string, domainID string, token string) (sdk.Cert, errors.SDKError) {
 	ret := _m.Called(certID, domainID, token)
@@ -7257,6 +8596,185 @@ func (_c *SDK_ViewCertByClient_Call) RunAndReturn(run func(string, string, strin
 	return _c
 }
 
+// ViewCommand provides a mock function with given fields: id, domainID, token
+func (_m *SDK) ViewCommand(id string, domainID string, token string) (sdk.Command, errors.SDKError) {
+	ret := _m.Called(id, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewCommand")
+	}
+
+	var r0 sdk.Command
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Command, errors.SDKError)); ok {
+		return rf(id, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Command); ok {
+		r0 = rf(id, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Command)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(id, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_ViewCommand_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewCommand'
+type SDK_ViewCommand_Call struct {
+	*mock.Call
+}
+
+// ViewCommand is a helper method to define mock.On call
+//   - id string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ViewCommand(id interface{}, domainID interface{}, token interface{}) *SDK_ViewCommand_Call {
+	return &SDK_ViewCommand_Call{Call: _e.mock.On("ViewCommand", id, domainID, token)}
+}
+
+func (_c *SDK_ViewCommand_Call) Run(run func(id string, domainID string, token string)) *SDK_ViewCommand_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ViewCommand_Call) Return(_a0 sdk.Command, _a1 errors.SDKError) *SDK_ViewCommand_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_ViewCommand_Call) RunAndReturn(run func(string, string, string) (sdk.Command, errors.SDKError)) *SDK_ViewCommand_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ViewSubscription provides a mock function with given fields: id, token
+func (_m *SDK) ViewSubscription(id string, token string) (sdk.Subscription, errors.SDKError) {
+	ret := _m.Called(id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewSubscription")
+	}
+
+	var r0 sdk.Subscription
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string) (sdk.Subscription, errors.SDKError)); ok {
+		return rf(id, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) sdk.Subscription); ok {
+		r0 = rf(id, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Subscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) errors.SDKError); ok {
+		r1 = rf(id, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_ViewSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewSubscription'
+type SDK_ViewSubscription_Call struct {
+	*mock.Call
+}
+
+// ViewSubscription is a helper method to define mock.On call
+//   - id string
+//   - token string
+func (_e *SDK_Expecter) ViewSubscription(id interface{}, token interface{}) *SDK_ViewSubscription_Call {
+	return &SDK_ViewSubscription_Call{Call: _e.mock.On("ViewSubscription", id, token)}
+}
+
+func (_c *SDK_ViewSubscription_Call) Run(run func(id string, token string)) *SDK_ViewSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ViewSubscription_Call) Return(_a0 sdk.Subscription, _a1 errors.SDKError) *SDK_ViewSubscription_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_ViewSubscription_Call) RunAndReturn(run func(string, string) (sdk.Subscription, errors.SDKError)) *SDK_ViewSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ViewTwin provides a mock function with given fields: clientID, domainID, token
+func (_m *SDK) ViewTwin(clientID string, domainID string, token string) (sdk.Twin, errors.SDKError) {
+	ret := _m.Called(clientID, domainID, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ViewTwin")
+	}
+
+	var r0 sdk.Twin
+	var r1 errors.SDKError
+	if rf, ok := ret.Get(0).(func(string, string, string) (sdk.Twin, errors.SDKError)); ok {
+		return rf(clientID, domainID, token)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) sdk.Twin); ok {
+		r0 = rf(clientID, domainID, token)
+	} else {
+		r0 = ret.Get(0).(sdk.Twin)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) errors.SDKError); ok {
+		r1 = rf(clientID, domainID, token)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(errors.SDKError)
+		}
+	}
+
+	return r0, r1
+}
+
+// SDK_ViewTwin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ViewTwin'
+type SDK_ViewTwin_Call struct {
+	*mock.Call
+}
+
+// ViewTwin is a helper method to define mock.On call
+//   - clientID string
+//   - domainID string
+//   - token string
+func (_e *SDK_Expecter) ViewTwin(clientID interface{}, domainID interface{}, token interface{}) *SDK_ViewTwin_Call {
+	return &SDK_ViewTwin_Call{Call: _e.mock.On("ViewTwin", clientID, domainID, token)}
+}
+
+func (_c *SDK_ViewTwin_Call) Run(run func(clientID string, domainID string, token string)) *SDK_ViewTwin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SDK_ViewTwin_Call) Return(_a0 sdk.Twin, _a1 errors.SDKError) *SDK_ViewTwin_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SDK_ViewTwin_Call) RunAndReturn(run func(string, string, string) (sdk.Twin, errors.SDKError)) *SDK_ViewTwin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewSDK creates a new instance of SDK. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewSDK(t interface {
@@ -9,11 +9,28 @@ type updateUserSecretReq struct {
 	NewSecret string `json:"new_secret,omitempty"`
 }
 
+type verifyMFAReq struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+type mfaCodeReq struct {
+	Code string `json:"code"`
+}
+
 type resetPasswordRequestreq struct {
 	Email string `json:"email"`
 	Host  string `json:"host"`
 }
 
+type verifyEmailReq struct {
+	Token string `json:"token"`
+}
+
+type resendVerificationReq struct {
+	Email string `json:"email"`
+}
+
 type resetPasswordReq struct {
 	Token    string `json:"token"`
 	Password string `json:"password"`
@@ -24,6 +41,11 @@ type updateClientSecretReq struct {
 	Secret string `json:"secret,omitempty"`
 }
 
+type rotateClientSecretReq struct {
+	Secret   string `json:"secret,omitempty"`
+	Duration string `json:"duration"`
+}
+
 // updateUserEmailReq is used to update the user email.
 type updateUserEmailReq struct {
 	token string
@@ -44,6 +66,42 @@ type Connection struct {
 	Types      []string `json:"types,omitempty"`
 }
 
+// Manifest describes the clients and channels to be provisioned and the
+// connections between them. Clients and channels are referenced by keys
+// unique within the manifest.
+type Manifest struct {
+	Clients      []ManifestClient     `json:"clients,omitempty"`
+	Channels     []ManifestChannel    `json:"channels,omitempty"`
+	Connections  []ManifestConnection `json:"connections,omitempty"`
+	AllOrNothing bool                 `json:"all_or_nothing,omitempty"`
+}
+
+// ManifestClient is a client to be provisioned.
+type ManifestClient struct {
+	Key         string   `json:"key"`
+	Name        string   `json:"name,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Metadata    Metadata `json:"metadata,omitempty"`
+	ParentGroup string   `json:"parent_group_id,omitempty"`
+	Secret      string   `json:"secret,omitempty"`
+}
+
+// ManifestChannel is a channel to be provisioned.
+type ManifestChannel struct {
+	Key         string   `json:"key"`
+	Name        string   `json:"name,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Metadata    Metadata `json:"metadata,omitempty"`
+	ParentGroup string   `json:"parent_group_id,omitempty"`
+}
+
+// ManifestConnection connects the manifest client to the manifest channel.
+type ManifestConnection struct {
+	ClientKey  string   `json:"client_key"`
+	ChannelKey string   `json:"channel_key"`
+	Types      []string `json:"types"`
+}
+
 type UsersRelationRequest struct {
 	Relation string   `json:"relation"`
 	UserIDs  []string `json:"user_ids"`
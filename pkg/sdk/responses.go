@@ -17,6 +17,22 @@ type createChannelsRes struct {
 	Channels []Channel `json:"channels"`
 }
 
+// ProvisionRow is the outcome of a single manifest row.
+type ProvisionRow struct {
+	Kind   string `json:"kind"`
+	Key    string `json:"key"`
+	ID     string `json:"id,omitempty"`
+	Secret string `json:"secret,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ProvisionReport contains the outcome of every manifest row.
+type ProvisionReport struct {
+	Rows       []ProvisionRow `json:"rows"`
+	Failed     uint64         `json:"failed"`
+	RolledBack bool           `json:"rolled_back"`
+}
+
 type PageRes struct {
 	Total  uint64 `json:"total"`
 	Offset uint64 `json:"offset"`
@@ -51,6 +67,11 @@ type UsersPage struct {
 	PageRes
 }
 
+type SessionsPage struct {
+	Total    uint64    `json:"total"`
+	Sessions []Session `json:"sessions"`
+}
+
 type MembersPage struct {
 	Members []User `json:"members"`
 	PageRes
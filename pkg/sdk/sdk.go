@@ -103,6 +103,7 @@ type PageMetadata struct {
 	Type            string   `json:"type,omitempty"`
 	Metadata        Metadata `json:"metadata,omitempty"`
 	Status          string   `json:"status,omitempty"`
+	Presence        string   `json:"presence,omitempty"`
 	Action          string   `json:"action,omitempty"`
 	Subject         string   `json:"subject,omitempty"`
 	Object          string   `json:"object,omitempty"`
@@ -120,6 +121,7 @@ type PageMetadata struct {
 	UserID          string   `json:"user_id,omitempty"`
 	DomainID        string   `json:"domain_id,omitempty"`
 	Relation        string   `json:"relation,omitempty"`
+	ChannelID       string   `json:"channel_id,omitempty"`
 	Operation       string   `json:"operation,omitempty"`
 	From            int64    `json:"from,omitempty"`
 	To              int64    `json:"to,omitempty"`
@@ -301,6 +303,21 @@ type SDK interface {
 	//  fmt.Println(err)
 	ResetPassword(password, confPass, token string) errors.SDKError
 
+	// VerifyEmail confirms the user's e-mail address using the token
+	// received in the verification e-mail.
+	//
+	// example:
+	//  user, _ := sdk.VerifyEmail("token")
+	//  fmt.Println(user)
+	VerifyEmail(token string) (User, errors.SDKError)
+
+	// ResendVerification sends a new verification e-mail to the given address.
+	//
+	// example:
+	//  err := sdk.ResendVerification("example@email.com")
+	//  fmt.Println(err)
+	ResendVerification(email string) errors.SDKError
+
 	// UpdatePassword updates user password.
 	//
 	// example:
@@ -322,6 +339,14 @@ type SDK interface {
 	//  fmt.Println(user)
 	DisableUser(id, token string) (User, errors.SDKError)
 
+	// UnlockUser unlocks the user locked out due to too many failed login
+	// attempts. Only super admins can unlock users.
+	//
+	// example:
+	//  err := sdk.UnlockUser("userID", "token")
+	//  fmt.Println(err)
+	UnlockUser(id, token string) errors.SDKError
+
 	// DeleteUser deletes a user with the given id.
 	//
 	// example:
@@ -347,6 +372,59 @@ type SDK interface {
 	//  fmt.Println(token)
 	RefreshToken(token string) (Token, errors.SDKError)
 
+	// VerifyMFA exchanges the MFA challenge token returned by CreateToken
+	// and a TOTP or recovery code for the user access and refresh tokens.
+	//
+	// example:
+	//  token, _ := sdk.VerifyMFA("mfa_token", "123456")
+	//  fmt.Println(token)
+	VerifyMFA(mfaToken, code string) (Token, errors.SDKError)
+
+	// EnrollMFA starts TOTP enrolment for the user and returns the secret,
+	// the provisioning URI and the recovery codes.
+	//
+	// example:
+	//  enrolment, _ := sdk.EnrollMFA("token")
+	//  fmt.Println(enrolment)
+	EnrollMFA(token string) (MFAEnrolment, errors.SDKError)
+
+	// EnableMFA enables MFA for the user after confirming the TOTP code.
+	//
+	// example:
+	//  err := sdk.EnableMFA("123456", "token")
+	//  fmt.Println(err)
+	EnableMFA(code, token string) errors.SDKError
+
+	// DisableMFA disables MFA for the user using a TOTP or recovery code.
+	//
+	// example:
+	//  err := sdk.DisableMFA("123456", "token")
+	//  fmt.Println(err)
+	DisableMFA(code, token string) errors.SDKError
+
+	// ListSessions lists the active login sessions of the user, i.e. the
+	// devices and browsers holding the user's refresh tokens.
+	//
+	// example:
+	//  sessions, _ := sdk.ListSessions("token")
+	//  fmt.Println(sessions)
+	ListSessions(token string) (SessionsPage, errors.SDKError)
+
+	// RevokeSession revokes the user's login session, so its refresh token
+	// can no longer be used.
+	//
+	// example:
+	//  err := sdk.RevokeSession("sessionID", "token")
+	//  fmt.Println(err)
+	RevokeSession(id, token string) errors.SDKError
+
+	// RevokeSessions revokes all the login sessions of the user.
+	//
+	// example:
+	//  err := sdk.RevokeSessions("token")
+	//  fmt.Println(err)
+	RevokeSessions(token string) errors.SDKError
+
 	// SeachUsers filters users and returns a page result.
 	//
 	// example:
@@ -480,6 +558,23 @@ type SDK interface {
 	//  fmt.Println(client)
 	UpdateClientSecret(id, secret, domainID, token string) (Client, errors.SDKError)
 
+	// RotateClientSecret schedules the rotation of the client's secret. Both
+	// the current and the new secret are accepted for the given duration,
+	// until the new one is confirmed. If the secret is empty, it's generated.
+	//
+	// example:
+	//  client, _ := sdk.RotateClientSecret("clientID", "newSecret", 24*time.Hour, "domainID", "token")
+	//  fmt.Println(client.Credentials.PendingSecret)
+	RotateClientSecret(id, secret string, duration time.Duration, domainID, token string) (Client, errors.SDKError)
+
+	// ConfirmClientSecret confirms the adoption of the client's pending
+	// secret, which replaces the current one.
+	//
+	// example:
+	//  client, _ := sdk.ConfirmClientSecret("clientID", "domainID", "token")
+	//  fmt.Println(client)
+	ConfirmClientSecret(id, domainID, token string) (Client, errors.SDKError)
+
 	// EnableClient changes client status to enabled.
 	//
 	// example:
@@ -494,6 +589,14 @@ type SDK interface {
 	//  fmt.Println(client)
 	DisableClient(id, domainID, token string) (Client, errors.SDKError)
 
+	// UnlockClient unlocks the client locked out due to too many failed
+	// authentication attempts.
+	//
+	// example:
+	//  err := sdk.UnlockClient("clientID", "domainID", "token")
+	//  fmt.Println(err)
+	UnlockClient(id, domainID, token string) errors.SDKError
+
 	// DeleteClient deletes a client with the given id.
 	//
 	// example:
@@ -997,6 +1100,20 @@ type SDK interface {
 	//  fmt.Println(err)
 	Connect(conn Connection, domainID, token string) errors.SDKError
 
+	// Provision creates the clients and channels of the manifest and connects
+	// them. The report contains the outcome of every manifest row.
+	//
+	// example:
+	//  manifest := sdk.Manifest{
+	//    Clients:     []sdk.ManifestClient{{Key: "sensor-1", Name: "sensor-1"}},
+	//    Channels:    []sdk.ManifestChannel{{Key: "telemetry", Name: "telemetry"}},
+	//    Connections: []sdk.ManifestConnection{{ClientKey: "sensor-1", ChannelKey: "telemetry", Types: []string{"publish"}}},
+	//    AllOrNothing: true,
+	//  }
+	//  report, err := sdk.Provision(manifest, "domainID", "token")
+	//  fmt.Println(report, err)
+	Provision(manifest Manifest, domainID, token string) (ProvisionReport, errors.SDKError)
+
 	// Disconnect
 	//
 	// example:
@@ -1033,6 +1150,60 @@ type SDK interface {
 	//  fmt.Println(err)
 	SendMessage(chanID, msg, key string) errors.SDKError
 
+	// ReadLastValue returns the last message published on the specified channel
+	// topic. The channel may contain the subtopic separated by ".".
+	//
+	// example:
+	//  lv, _ := sdk.ReadLastValue("channelID.desired", "clientSecret")
+	//  fmt.Println(string(lv.Payload))
+	ReadLastValue(chanName, key string) (LastValue, errors.SDKError)
+
+	// ReadMessages read messages of specified channel. The token may be
+	// either a user token or a client secret prefixed with "Client ".
+	//
+	// example:
+	//  pm := sdk.MessagePageMetadata{
+	//    PageMetadata: sdk.PageMetadata{
+	//      Offset: 0,
+	//      Limit:  10,
+	//    },
+	//  }
+	//  msgs, _ := sdk.ReadMessages(pm, "channelID", "token")
+	//  fmt.Println(msgs)
+	ReadMessages(pm MessagePageMetadata, chanID, token string) (MessagesPage, errors.SDKError)
+
+	// CreateSubscription creates a new subscription.
+	//
+	// example:
+	//  subscription, _ := sdk.CreateSubscription("channels.<channel_id>.temperature", "user@example.com", "token")
+	//  fmt.Println(subscription)
+	CreateSubscription(topic, contact, token string) (string, errors.SDKError)
+
+	// ListSubscriptions list subscriptions given list parameters.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset: 0,
+	//    Limit:  10,
+	//  }
+	//  subscriptions, _ := sdk.ListSubscriptions(pm, "token")
+	//  fmt.Println(subscriptions)
+	ListSubscriptions(pm PageMetadata, token string) (SubscriptionPage, errors.SDKError)
+
+	// ViewSubscription retrieves a subscription with the provided id.
+	//
+	// example:
+	//  subscription, _ := sdk.ViewSubscription("id", "token")
+	//  fmt.Println(subscription)
+	ViewSubscription(id, token string) (Subscription, errors.SDKError)
+
+	// DeleteSubscription removes a subscription with the provided id.
+	//
+	// example:
+	//  err := sdk.DeleteSubscription("id", "token")
+	//  fmt.Println(err)
+	DeleteSubscription(id, token string) errors.SDKError
+
 	// SetContentType sets message content type.
 	//
 	// example:
@@ -1311,6 +1482,73 @@ type SDK interface {
 	//  journals, _ := sdk.Journal("client", "clientID","domainID", PageMetadata{Offset: 0, Limit: 10, Operation: "client.create"}, "token")
 	//  fmt.Println(journals)
 	Journal(entityType, entityID, domainID string, pm PageMetadata, token string) (journal JournalsPage, err error)
+
+	// SendCommand sends the command to the clients connected to the command
+	// channel. If wait is set, the request blocks until the command is replied
+	// to or times out.
+	//
+	// example:
+	//  cmd := sdk.Command{
+	//    ChannelID: "channelID",
+	//    Payload:   `{"cmd":"reboot"}`,
+	//    Timeout:   10,
+	//  }
+	//  cmd, _ := sdk.SendCommand(cmd, true, "domainID", "token")
+	//  fmt.Println(cmd.Status, cmd.Response)
+	SendCommand(cmd Command, wait bool, domainID, token string) (Command, errors.SDKError)
+
+	// ViewCommand retrieves the command with the provided id.
+	//
+	// example:
+	//  cmd, _ := sdk.ViewCommand("commandID", "domainID", "token")
+	//  fmt.Println(cmd)
+	ViewCommand(id, domainID, token string) (Command, errors.SDKError)
+
+	// ListCommands lists the domain commands given list parameters.
+	//
+	// example:
+	//  pm := sdk.PageMetadata{
+	//    Offset:    0,
+	//    Limit:     10,
+	//    ChannelID: "channelID",
+	//    Status:    "replied",
+	//  }
+	//  cmds, _ := sdk.ListCommands(pm, "domainID", "token")
+	//  fmt.Println(cmds)
+	ListCommands(pm PageMetadata, domainID, token string) (CommandsPage, errors.SDKError)
+
+	// CreateTwin creates the device twin of the client. The deltas between
+	// the desired and the reported state are published to the given channel.
+	//
+	// example:
+	//  desired := map[string]any{"mode": "eco", "interval": 10}
+	//  twin, _ := sdk.CreateTwin("clientID", "channelID", desired, "domainID", "token")
+	//  fmt.Println(twin)
+	CreateTwin(clientID, channelID string, desired map[string]any, domainID, token string) (Twin, errors.SDKError)
+
+	// ViewTwin retrieves the device twin of the client.
+	//
+	// example:
+	//  twin, _ := sdk.ViewTwin("clientID", "domainID", "token")
+	//  fmt.Println(twin.Desired, twin.Reported, twin.Delta)
+	ViewTwin(clientID, domainID, token string) (Twin, errors.SDKError)
+
+	// UpdateTwinDesired merges the patch into the desired state of the
+	// client twin. Fields set to nil are removed. If revision is not zero,
+	// the update fails unless it matches the current desired state revision.
+	//
+	// example:
+	//  patch := map[string]any{"mode": "boost", "interval": nil}
+	//  twin, _ := sdk.UpdateTwinDesired("clientID", patch, 3, "domainID", "token")
+	//  fmt.Println(twin)
+	UpdateTwinDesired(clientID string, desired map[string]any, revision uint64, domainID, token string) (Twin, errors.SDKError)
+
+	// DeleteTwin removes the device twin of the client.
+	//
+	// example:
+	//  err := sdk.DeleteTwin("clientID", "domainID", "token")
+	//  fmt.Println(err)
+	DeleteTwin(clientID, domainID, token string) errors.SDKError
 }
 
 type mgSDK struct {
@@ -1323,6 +1561,10 @@ type mgSDK struct {
 	domainsURL     string
 	invitationsURL string
 	journalURL     string
+	readersURL     string
+	notifiersURL   string
+	commandsURL    string
+	twinsURL       string
 	HostURL        string
 
 	msgContentType ContentType
@@ -1341,6 +1583,10 @@ type Config struct {
 	DomainsURL     string
 	InvitationsURL string
 	JournalURL     string
+	ReadersURL     string
+	NotifiersURL   string
+	CommandsURL    string
+	TwinsURL       string
 	HostURL        string
 
 	MsgContentType  ContentType
@@ -1360,6 +1606,10 @@ func NewSDK(conf Config) SDK {
 		domainsURL:     conf.DomainsURL,
 		invitationsURL: conf.InvitationsURL,
 		journalURL:     conf.JournalURL,
+		readersURL:     conf.ReadersURL,
+		notifiersURL:   conf.NotifiersURL,
+		commandsURL:    conf.CommandsURL,
+		twinsURL:       conf.TwinsURL,
 		HostURL:        conf.HostURL,
 
 		msgContentType: conf.MsgContentType,
@@ -1483,6 +1733,9 @@ func (pm PageMetadata) query() (string, error) {
 	if pm.Status != "" {
 		q.Add("status", pm.Status)
 	}
+	if pm.Presence != "" {
+		q.Add("presence", pm.Presence)
+	}
 	if pm.Metadata != nil {
 		md, err := json.Marshal(pm.Metadata)
 		if err != nil {
@@ -1535,6 +1788,9 @@ func (pm PageMetadata) query() (string, error) {
 	if pm.Relation != "" {
 		q.Add("relation", pm.Relation)
 	}
+	if pm.ChannelID != "" {
+		q.Add("channel_id", pm.ChannelID)
+	}
 	if pm.Operation != "" {
 		q.Add("operation", pm.Operation)
 	}
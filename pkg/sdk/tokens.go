@@ -44,6 +44,27 @@ func (sdk mgSDK) CreateToken(lt Login) (Token, errors.SDKError) {
 	return token, nil
 }
 
+func (sdk mgSDK) VerifyMFA(mfaToken, code string) (Token, errors.SDKError) {
+	data, err := json.Marshal(verifyMFAReq{MFAToken: mfaToken, Code: code})
+	if err != nil {
+		return Token{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, usersEndpoint, mfaTokenEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, "", data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Token{}, sdkerr
+	}
+
+	var token Token
+	if err := json.Unmarshal(body, &token); err != nil {
+		return Token{}, errors.NewSDKError(err)
+	}
+
+	return token, nil
+}
+
 func (sdk mgSDK) RefreshToken(token string) (Token, errors.SDKError) {
 	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, usersEndpoint, refreshTokenEndpoint)
 
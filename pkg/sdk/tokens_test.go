@@ -176,6 +176,79 @@ func TestRefreshToken(t *testing.T) {
 	}
 }
 
+func TestVerifyMFA(t *testing.T) {
+	ts, svc, _ := setupUsers()
+	defer ts.Close()
+
+	token := generateTestToken()
+	mfaToken := "mfa_token"
+	code := "123456"
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc     string
+		mfaToken string
+		code     string
+		svcRes   *grpcTokenV1.Token
+		svcErr   error
+		response sdk.Token
+		err      errors.SDKError
+	}{
+		{
+			desc:     "verify MFA successfully",
+			mfaToken: mfaToken,
+			code:     code,
+			svcRes: &grpcTokenV1.Token{
+				AccessToken:  token.AccessToken,
+				RefreshToken: &token.RefreshToken,
+				AccessType:   token.AccessType,
+			},
+			response: token,
+			err:      nil,
+		},
+		{
+			desc:     "verify MFA with invalid code",
+			mfaToken: mfaToken,
+			code:     "000000",
+			svcRes:   &grpcTokenV1.Token{},
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.Token{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "verify MFA with empty token",
+			mfaToken: "",
+			code:     code,
+			response: sdk.Token{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrBearerToken), http.StatusUnauthorized),
+		},
+		{
+			desc:     "verify MFA with empty code",
+			mfaToken: mfaToken,
+			code:     "",
+			response: sdk.Token{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingMFACode), http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("VerifyMFA", mock.Anything, tc.mfaToken, tc.code).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.VerifyMFA(tc.mfaToken, tc.code)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "VerifyMFA", mock.Anything, tc.mfaToken, tc.code)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func generateTestToken() sdk.Token {
 	return sdk.Token{
 		AccessToken:  "access_token",
@@ -0,0 +1,127 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+const (
+	twinsEndpoint   = "twins"
+	desiredEndpoint = "desired"
+)
+
+// TwinState represents the desired or the reported state of a device twin.
+type TwinState struct {
+	Document  map[string]any    `json:"document,omitempty"`
+	Revisions map[string]uint64 `json:"revisions,omitempty"`
+	Revision  uint64            `json:"revision,omitempty"`
+	UpdatedAt time.Time         `json:"updated_at,omitempty"`
+}
+
+// Twin represents the device twin of a client.
+type Twin struct {
+	ClientID  string    `json:"client_id,omitempty"`
+	DomainID  string    `json:"domain_id,omitempty"`
+	ChannelID string    `json:"channel_id,omitempty"`
+	Desired   TwinState `json:"desired,omitempty"`
+	Reported  TwinState `json:"reported,omitempty"`
+	// Delta contains the desired fields which differ from the reported ones.
+	Delta     map[string]any `json:"delta,omitempty"`
+	CreatedAt time.Time      `json:"created_at,omitempty"`
+	CreatedBy string         `json:"created_by,omitempty"`
+	UpdatedAt time.Time      `json:"updated_at,omitempty"`
+}
+
+type createTwinReq struct {
+	ClientID  string         `json:"client_id"`
+	ChannelID string         `json:"channel_id"`
+	Desired   map[string]any `json:"desired,omitempty"`
+}
+
+type updateDesiredReq struct {
+	Desired  map[string]any `json:"desired"`
+	Revision uint64         `json:"revision,omitempty"`
+}
+
+func (sdk mgSDK) CreateTwin(clientID, channelID string, desired map[string]any, domainID, token string) (Twin, errors.SDKError) {
+	data, err := json.Marshal(createTwinReq{ClientID: clientID, ChannelID: channelID, Desired: desired})
+	if err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", sdk.twinsURL, domainID, twinsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return Twin{}, sdkerr
+	}
+
+	var t Twin
+	if err := json.Unmarshal(body, &t); err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	return t, nil
+}
+
+func (sdk mgSDK) ViewTwin(clientID, domainID, token string) (Twin, errors.SDKError) {
+	if clientID == "" {
+		return Twin{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.twinsURL, domainID, twinsEndpoint, clientID)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Twin{}, sdkerr
+	}
+
+	var t Twin
+	if err := json.Unmarshal(body, &t); err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	return t, nil
+}
+
+func (sdk mgSDK) UpdateTwinDesired(clientID string, desired map[string]any, revision uint64, domainID, token string) (Twin, errors.SDKError) {
+	if clientID == "" {
+		return Twin{}, errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	data, err := json.Marshal(updateDesiredReq{Desired: desired, Revision: revision})
+	if err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s/%s", sdk.twinsURL, domainID, twinsEndpoint, clientID, desiredEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPatch, url, token, data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return Twin{}, sdkerr
+	}
+
+	var t Twin
+	if err := json.Unmarshal(body, &t); err != nil {
+		return Twin{}, errors.NewSDKError(err)
+	}
+
+	return t, nil
+}
+
+func (sdk mgSDK) DeleteTwin(clientID, domainID, token string) errors.SDKError {
+	if clientID == "" {
+		return errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.twinsURL, domainID, twinsEndpoint, clientID)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+
+	return sdkerr
+}
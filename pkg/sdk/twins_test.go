@@ -0,0 +1,351 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	sdk "github.com/absmach/supermq/pkg/sdk"
+	"github.com/absmach/supermq/twins"
+	"github.com/absmach/supermq/twins/api"
+	"github.com/absmach/supermq/twins/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var twin = twins.Twin{
+	ClientID:  generateUUID(&testing.T{}),
+	DomainID:  domainID,
+	ChannelID: generateUUID(&testing.T{}),
+	Desired: twins.State{
+		Document:  map[string]any{"mode": "eco"},
+		Revisions: map[string]uint64{"mode": 1},
+		Revision:  1,
+		UpdatedAt: time.Now().UTC().Truncate(time.Second),
+	},
+	Reported: twins.State{
+		Document:  map[string]any{"mode": "boost"},
+		Revisions: map[string]uint64{"mode": 1},
+		Revision:  1,
+		UpdatedAt: time.Now().UTC().Truncate(time.Second),
+	},
+	CreatedAt: time.Now().UTC().Truncate(time.Second),
+	CreatedBy: validID,
+}
+
+func setupTwins() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	logger := smqlog.NewMock()
+	mux := api.MakeHandler(svc, authn, logger, "twins", "test")
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func TestCreateTwin(t *testing.T) {
+	ts, svc, authn := setupTwins()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{TwinsURL: ts.URL})
+
+	desired := map[string]any{"mode": "eco"}
+	req := twins.Twin{ClientID: twin.ClientID, ChannelID: twin.ChannelID, Desired: twins.State{Document: desired}}
+
+	cases := []struct {
+		desc      string
+		token     string
+		clientID  string
+		channelID string
+		session   smqauthn.Session
+		authnErr  error
+		svcRes    twins.Twin
+		svcErr    error
+		response  sdk.Twin
+		err       errors.SDKError
+	}{
+		{
+			desc:      "create twin successfully",
+			token:     validToken,
+			clientID:  twin.ClientID,
+			channelID: twin.ChannelID,
+			svcRes:    twin,
+			response:  convertTwin(twin),
+		},
+		{
+			desc:      "create twin with invalid token",
+			token:     invalidToken,
+			clientID:  twin.ClientID,
+			channelID: twin.ChannelID,
+			authnErr:  svcerr.ErrAuthentication,
+			err:       errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "create twin without channel",
+			token:    validToken,
+			clientID: twin.ClientID,
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, errors.ErrMalformedEntity), http.StatusBadRequest),
+		},
+		{
+			desc:      "create existing twin",
+			token:     validToken,
+			clientID:  twin.ClientID,
+			channelID: twin.ChannelID,
+			svcErr:    svcerr.ErrConflict,
+			err:       errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("CreateTwin", mock.Anything, tc.session, req).Return(tc.svcRes, tc.svcErr)
+			res, err := mgsdk.CreateTwin(tc.clientID, tc.channelID, desired, domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, res)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "CreateTwin", mock.Anything, tc.session, req)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewTwin(t *testing.T) {
+	ts, svc, authn := setupTwins()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{TwinsURL: ts.URL})
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		session  smqauthn.Session
+		authnErr error
+		svcRes   twins.Twin
+		svcErr   error
+		response sdk.Twin
+		err      errors.SDKError
+	}{
+		{
+			desc:     "view twin successfully",
+			token:    validToken,
+			id:       twin.ClientID,
+			svcRes:   twin,
+			response: convertTwin(twin),
+		},
+		{
+			desc:     "view twin with invalid token",
+			token:    invalidToken,
+			id:       twin.ClientID,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "view twin with empty id",
+			token: validToken,
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+		{
+			desc:   "view non-existing twin",
+			token:  validToken,
+			id:     twin.ClientID,
+			svcErr: svcerr.ErrNotFound,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("ViewTwin", mock.Anything, tc.session, tc.id).Return(tc.svcRes, tc.svcErr)
+			res, err := mgsdk.ViewTwin(tc.id, domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, res)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ViewTwin", mock.Anything, tc.session, tc.id)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestUpdateTwinDesired(t *testing.T) {
+	ts, svc, authn := setupTwins()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{TwinsURL: ts.URL})
+
+	patch := map[string]any{"mode": "boost"}
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		patch    map[string]any
+		revision uint64
+		session  smqauthn.Session
+		authnErr error
+		svcRes   twins.Twin
+		svcErr   error
+		response sdk.Twin
+		err      errors.SDKError
+	}{
+		{
+			desc:     "update twin desired state successfully",
+			token:    validToken,
+			id:       twin.ClientID,
+			patch:    patch,
+			revision: 1,
+			svcRes:   twin,
+			response: convertTwin(twin),
+		},
+		{
+			desc:     "update twin desired state with invalid token",
+			token:    invalidToken,
+			id:       twin.ClientID,
+			patch:    patch,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "update twin desired state with empty id",
+			token: validToken,
+			patch: patch,
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+		{
+			desc:  "update twin desired state with empty patch",
+			token: validToken,
+			id:    twin.ClientID,
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, errors.ErrMalformedEntity), http.StatusBadRequest),
+		},
+		{
+			desc:     "update twin desired state with stale revision",
+			token:    validToken,
+			id:       twin.ClientID,
+			patch:    patch,
+			revision: 1,
+			svcErr:   svcerr.ErrRevisionConflict,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrRevisionConflict, http.StatusConflict),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("UpdateDesired", mock.Anything, tc.session, tc.id, tc.patch, tc.revision).Return(tc.svcRes, tc.svcErr)
+			res, err := mgsdk.UpdateTwinDesired(tc.id, tc.patch, tc.revision, domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, res)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "UpdateDesired", mock.Anything, tc.session, tc.id, tc.patch, tc.revision)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestDeleteTwin(t *testing.T) {
+	ts, svc, authn := setupTwins()
+	defer ts.Close()
+
+	mgsdk := sdk.NewSDK(sdk.Config{TwinsURL: ts.URL})
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		session  smqauthn.Session
+		authnErr error
+		svcErr   error
+		err      errors.SDKError
+	}{
+		{
+			desc:  "delete twin successfully",
+			token: validToken,
+			id:    twin.ClientID,
+		},
+		{
+			desc:     "delete twin with invalid token",
+			token:    invalidToken,
+			id:       twin.ClientID,
+			authnErr: svcerr.ErrAuthentication,
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "delete twin with empty id",
+			token: validToken,
+			err:   errors.NewSDKError(apiutil.ErrMissingID),
+		},
+		{
+			desc:   "delete non-existing twin",
+			token:  validToken,
+			id:     twin.ClientID,
+			svcErr: svcerr.ErrNotFound,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: domainID + "_" + validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authnErr)
+			svcCall := svc.On("RemoveTwin", mock.Anything, tc.session, tc.id).Return(tc.svcErr)
+			err := mgsdk.DeleteTwin(tc.id, domainID, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "RemoveTwin", mock.Anything, tc.session, tc.id)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func convertTwin(t twins.Twin) sdk.Twin {
+	return sdk.Twin{
+		ClientID:  t.ClientID,
+		DomainID:  t.DomainID,
+		ChannelID: t.ChannelID,
+		Desired:   convertTwinState(t.Desired),
+		Reported:  convertTwinState(t.Reported),
+		Delta:     t.Delta(),
+		CreatedAt: t.CreatedAt,
+		CreatedBy: t.CreatedBy,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func convertTwinState(s twins.State) sdk.TwinState {
+	return sdk.TwinState{
+		Document:  s.Document,
+		Revisions: s.Revisions,
+		Revision:  s.Revision,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
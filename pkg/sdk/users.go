@@ -19,8 +19,12 @@ const (
 	unassignEndpoint      = "unassign"
 	enableEndpoint        = "enable"
 	disableEndpoint       = "disable"
+	unlockEndpoint        = "unlock"
 	issueTokenEndpoint    = "tokens/issue"
 	refreshTokenEndpoint  = "tokens/refresh"
+	mfaTokenEndpoint      = "tokens/mfa"
+	mfaEndpoint           = "mfa"
+	sessionsEndpoint      = "sessions"
 	membersEndpoint       = "members"
 	PasswordResetEndpoint = "password"
 )
@@ -39,6 +43,27 @@ type User struct {
 	Status         string      `json:"status,omitempty"`
 	Role           string      `json:"role,omitempty"`
 	ProfilePicture string      `json:"profile_picture,omitempty"`
+	MFAEnabled     bool        `json:"mfa_enabled,omitempty"`
+	PendingEmail   string      `json:"pending_email,omitempty"`
+}
+
+// MFAEnrolment contains the TOTP secret, the provisioning URI and the
+// one-time recovery codes returned when the user starts MFA enrolment.
+type MFAEnrolment struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Session represents the user's login session, i.e. the device or browser
+// holding a refresh token issued to the user.
+type Session struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	IssuedAt   time.Time `json:"issued_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
 }
 
 func (sdk mgSDK) CreateUser(user User, token string) (User, errors.SDKError) {
@@ -234,6 +259,38 @@ func (sdk mgSDK) ResetPassword(password, confPass, token string) errors.SDKError
 	return sdkerr
 }
 
+func (sdk mgSDK) VerifyEmail(token string) (User, errors.SDKError) {
+	data, err := json.Marshal(verifyEmailReq{Token: token})
+	if err != nil {
+		return User{}, errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s/verify-email", sdk.usersURL, usersEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, "", data, nil, http.StatusOK)
+	if sdkerr != nil {
+		return User{}, sdkerr
+	}
+
+	var user User
+	if err := json.Unmarshal(body, &user); err != nil {
+		return User{}, errors.NewSDKError(err)
+	}
+
+	return user, nil
+}
+
+func (sdk mgSDK) ResendVerification(email string) errors.SDKError {
+	data, err := json.Marshal(resendVerificationReq{Email: email})
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+	url := fmt.Sprintf("%s/%s/verify-email/resend", sdk.usersURL, usersEndpoint)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, "", data, nil, http.StatusCreated)
+
+	return sdkerr
+}
+
 func (sdk mgSDK) UpdatePassword(oldPass, newPass, token string) (User, errors.SDKError) {
 	ucsr := updateUserSecretReq{OldSecret: oldPass, NewSecret: newPass}
 
@@ -257,6 +314,74 @@ func (sdk mgSDK) UpdatePassword(oldPass, newPass, token string) (User, errors.SD
 	return user, nil
 }
 
+func (sdk mgSDK) EnrollMFA(token string) (MFAEnrolment, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s/enroll", sdk.usersURL, usersEndpoint, mfaEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusCreated)
+	if sdkerr != nil {
+		return MFAEnrolment{}, sdkerr
+	}
+
+	var enrolment MFAEnrolment
+	if err := json.Unmarshal(body, &enrolment); err != nil {
+		return MFAEnrolment{}, errors.NewSDKError(err)
+	}
+
+	return enrolment, nil
+}
+
+func (sdk mgSDK) EnableMFA(code, token string) errors.SDKError {
+	return sdk.changeMFA(enableEndpoint, code, token)
+}
+
+func (sdk mgSDK) DisableMFA(code, token string) errors.SDKError {
+	return sdk.changeMFA(disableEndpoint, code, token)
+}
+
+func (sdk mgSDK) changeMFA(action, code, token string) errors.SDKError {
+	data, err := json.Marshal(mfaCodeReq{Code: code})
+	if err != nil {
+		return errors.NewSDKError(err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, usersEndpoint, mfaEndpoint, action)
+
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, data, nil, http.StatusNoContent)
+
+	return sdkerr
+}
+
+func (sdk mgSDK) ListSessions(token string) (SessionsPage, errors.SDKError) {
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, usersEndpoint, sessionsEndpoint)
+
+	_, body, sdkerr := sdk.processRequest(http.MethodGet, url, token, nil, nil, http.StatusOK)
+	if sdkerr != nil {
+		return SessionsPage{}, sdkerr
+	}
+
+	var sp SessionsPage
+	if err := json.Unmarshal(body, &sp); err != nil {
+		return SessionsPage{}, errors.NewSDKError(err)
+	}
+
+	return sp, nil
+}
+
+func (sdk mgSDK) RevokeSession(id, token string) errors.SDKError {
+	if id == "" {
+		return errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, usersEndpoint, sessionsEndpoint, id)
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+	return sdkerr
+}
+
+func (sdk mgSDK) RevokeSessions(token string) errors.SDKError {
+	url := fmt.Sprintf("%s/%s/%s", sdk.usersURL, usersEndpoint, sessionsEndpoint)
+	_, _, sdkerr := sdk.processRequest(http.MethodDelete, url, token, nil, nil, http.StatusNoContent)
+	return sdkerr
+}
+
 func (sdk mgSDK) UpdateUserRole(user User, token string) (User, errors.SDKError) {
 	data, err := json.Marshal(user)
 	if err != nil {
@@ -364,6 +489,15 @@ func (sdk mgSDK) changeUserStatus(token, id, status string) (User, errors.SDKErr
 	return user, nil
 }
 
+func (sdk mgSDK) UnlockUser(id, token string) errors.SDKError {
+	if id == "" {
+		return errors.NewSDKError(apiutil.ErrMissingID)
+	}
+	url := fmt.Sprintf("%s/%s/%s/%s", sdk.usersURL, usersEndpoint, id, unlockEndpoint)
+	_, _, sdkerr := sdk.processRequest(http.MethodPost, url, token, nil, nil, http.StatusNoContent)
+	return sdkerr
+}
+
 func (sdk mgSDK) DeleteUser(id, token string) errors.SDKError {
 	if id == "" {
 		return errors.NewSDKError(apiutil.ErrMissingID)
@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	api "github.com/absmach/supermq/api/http"
@@ -44,7 +45,7 @@ func setupUsers() (*httptest.Server, *umocks.Service, *authnmocks.Authentication
 	provider.On("Name").Return("test")
 	authn := new(authnmocks.Authentication)
 	token := new(authmocks.TokenServiceClient)
-	httpapi.MakeHandler(usvc, authn, token, true, mux, logger, "", passRegex, provider)
+	httpapi.MakeHandler(usvc, authn, token, true, mux, logger, "", passRegex, nil, provider)
 
 	return httptest.NewServer(mux), usvc, authn
 }
@@ -1418,6 +1419,117 @@ func TestResetPasswordRequest(t *testing.T) {
 	}
 }
 
+func TestVerifyEmail(t *testing.T) {
+	ts, svc, _ := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc     string
+		token    string
+		svcRes   users.User
+		svcErr   error
+		response sdk.User
+		err      errors.SDKError
+	}{
+		{
+			desc:     "verify email with valid token",
+			token:    validToken,
+			svcRes:   convertUser(user),
+			response: user,
+			err:      nil,
+		},
+		{
+			desc:     "verify email with invalid token",
+			token:    invalidToken,
+			svcRes:   users.User{},
+			svcErr:   svcerr.ErrAuthentication,
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "verify email with empty token",
+			token:    "",
+			svcRes:   users.User{},
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrBearerToken), http.StatusUnauthorized),
+		},
+		{
+			desc:     "verify email of verified user",
+			token:    validToken,
+			svcRes:   users.User{},
+			svcErr:   svcerr.ErrConflict,
+			response: sdk.User{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("VerifyEmail", mock.Anything, tc.token).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.VerifyEmail(tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "VerifyEmail", mock.Anything, tc.token)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	ts, svc, _ := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	validEmail := "test@email.com"
+
+	cases := []struct {
+		desc   string
+		email  string
+		svcErr error
+		err    errors.SDKError
+	}{
+		{
+			desc:  "resend verification with valid email",
+			email: validEmail,
+			err:   nil,
+		},
+		{
+			desc:   "resend verification with verified email",
+			email:  validEmail,
+			svcErr: svcerr.ErrViewEntity,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrViewEntity, http.StatusBadRequest),
+		},
+		{
+			desc:  "resend verification with empty email",
+			email: "",
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingEmail), http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			svcCall := svc.On("ResendVerification", mock.Anything, tc.email).Return(tc.svcErr)
+			err := mgsdk.ResendVerification(tc.email)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ResendVerification", mock.Anything, tc.email)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestResetPassword(t *testing.T) {
 	ts, svc, auth := setupUsers()
 	defer ts.Close()
@@ -1652,6 +1764,207 @@ func TestUpdatePassword(t *testing.T) {
 	}
 }
 
+func TestEnrollMFA(t *testing.T) {
+	ts, svc, auth := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	enrolment := users.MFAEnrolment{
+		Secret:        "JBSWY3DPEHPK3PXP",
+		URI:           "otpauth://totp/SuperMQ:user?secret=JBSWY3DPEHPK3PXP",
+		RecoveryCodes: []string{"abcde-fghij"},
+	}
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         smqauthn.Session
+		svcRes          users.MFAEnrolment
+		svcErr          error
+		authenticateErr error
+		response        sdk.MFAEnrolment
+		err             errors.SDKError
+	}{
+		{
+			desc:     "enroll MFA successfully",
+			token:    validToken,
+			svcRes:   enrolment,
+			response: sdk.MFAEnrolment(enrolment),
+			err:      nil,
+		},
+		{
+			desc:            "enroll MFA with invalid token",
+			token:           invalidToken,
+			authenticateErr: svcerr.ErrAuthentication,
+			response:        sdk.MFAEnrolment{},
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "enroll MFA with empty token",
+			token:    "",
+			response: sdk.MFAEnrolment{},
+			err:      errors.NewSDKErrorWithStatus(apiutil.ErrBearerToken, http.StatusUnauthorized),
+		},
+		{
+			desc:     "enroll MFA when already enabled",
+			token:    validToken,
+			svcErr:   svcerr.ErrConflict,
+			response: sdk.MFAEnrolment{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrConflict, http.StatusConflict),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("EnrollMFA", mock.Anything, tc.session).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.EnrollMFA(tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "EnrollMFA", mock.Anything, tc.session)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestEnableMFA(t *testing.T) {
+	ts, svc, auth := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         smqauthn.Session
+		code            string
+		svcErr          error
+		authenticateErr error
+		err             errors.SDKError
+	}{
+		{
+			desc:  "enable MFA successfully",
+			token: validToken,
+			code:  "123456",
+			err:   nil,
+		},
+		{
+			desc:            "enable MFA with invalid token",
+			token:           invalidToken,
+			code:            "123456",
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "enable MFA with empty code",
+			token: validToken,
+			code:  "",
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingMFACode), http.StatusBadRequest),
+		},
+		{
+			desc:   "enable MFA with invalid code",
+			token:  validToken,
+			code:   "000000",
+			svcErr: svcerr.ErrMalformedEntity,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrMalformedEntity, http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("EnableMFA", mock.Anything, tc.session, tc.code).Return(tc.svcErr)
+			err := mgsdk.EnableMFA(tc.code, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "EnableMFA", mock.Anything, tc.session, tc.code)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestDisableMFA(t *testing.T) {
+	ts, svc, auth := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         smqauthn.Session
+		code            string
+		svcErr          error
+		authenticateErr error
+		err             errors.SDKError
+	}{
+		{
+			desc:  "disable MFA successfully",
+			token: validToken,
+			code:  "123456",
+			err:   nil,
+		},
+		{
+			desc:            "disable MFA with invalid token",
+			token:           invalidToken,
+			code:            "123456",
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:  "disable MFA with empty code",
+			token: validToken,
+			code:  "",
+			err:   errors.NewSDKErrorWithStatus(errors.Wrap(apiutil.ErrValidation, apiutil.ErrMissingMFACode), http.StatusBadRequest),
+		},
+		{
+			desc:   "disable MFA when required by domain",
+			token:  validToken,
+			code:   "123456",
+			svcErr: svcerr.ErrAuthorization,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("DisableMFA", mock.Anything, tc.session, tc.code).Return(tc.svcErr)
+			err := mgsdk.DisableMFA(tc.code, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "DisableMFA", mock.Anything, tc.session, tc.code)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func TestUpdateUserRole(t *testing.T) {
 	ts, svc, auth := setupUsers()
 	defer ts.Close()
@@ -2571,6 +2884,272 @@ func TestDeleteUser(t *testing.T) {
 	}
 }
 
+func TestListSessions(t *testing.T) {
+	ts, svc, auth := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	session := users.Session{
+		ID:         generateUUID(t),
+		UserAgent:  "Mozilla/5.0",
+		IPAddress:  "192.168.0.1",
+		IssuedAt:   now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         smqauthn.Session
+		svcRes          []users.Session
+		svcErr          error
+		authenticateErr error
+		response        sdk.SessionsPage
+		err             errors.SDKError
+	}{
+		{
+			desc:   "list sessions successfully",
+			token:  validToken,
+			svcRes: []users.Session{session},
+			response: sdk.SessionsPage{
+				Total:    1,
+				Sessions: []sdk.Session{sdk.Session(session)},
+			},
+			err: nil,
+		},
+		{
+			desc:            "list sessions with invalid token",
+			token:           invalidToken,
+			authenticateErr: svcerr.ErrAuthentication,
+			response:        sdk.SessionsPage{},
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:     "list sessions with empty token",
+			token:    "",
+			response: sdk.SessionsPage{},
+			err:      errors.NewSDKErrorWithStatus(apiutil.ErrBearerToken, http.StatusUnauthorized),
+		},
+		{
+			desc:     "list sessions with failed service",
+			token:    validToken,
+			svcErr:   svcerr.ErrViewEntity,
+			response: sdk.SessionsPage{},
+			err:      errors.NewSDKErrorWithStatus(svcerr.ErrViewEntity, http.StatusBadRequest),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("ListSessions", mock.Anything, tc.session).Return(tc.svcRes, tc.svcErr)
+			resp, err := mgsdk.ListSessions(tc.token)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.response, resp)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "ListSessions", mock.Anything, tc.session)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	ts, svc, auth := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	sessionID := generateUUID(t)
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         smqauthn.Session
+		sessionID       string
+		svcErr          error
+		authenticateErr error
+		err             errors.SDKError
+	}{
+		{
+			desc:      "revoke session successfully",
+			token:     validToken,
+			sessionID: sessionID,
+			err:       nil,
+		},
+		{
+			desc:            "revoke session with invalid token",
+			token:           invalidToken,
+			sessionID:       sessionID,
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:      "revoke non-existing session",
+			token:     validToken,
+			sessionID: sessionID,
+			svcErr:    svcerr.ErrNotFound,
+			err:       errors.NewSDKErrorWithStatus(svcerr.ErrNotFound, http.StatusNotFound),
+		},
+		{
+			desc:      "revoke session with empty id",
+			token:     validToken,
+			sessionID: "",
+			err:       errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("RevokeSession", mock.Anything, tc.session, tc.sessionID).Return(tc.svcErr)
+			err := mgsdk.RevokeSession(tc.sessionID, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "RevokeSession", mock.Anything, tc.session, tc.sessionID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	ts, svc, auth := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         smqauthn.Session
+		svcErr          error
+		authenticateErr error
+		err             errors.SDKError
+	}{
+		{
+			desc:  "revoke sessions successfully",
+			token: validToken,
+			err:   nil,
+		},
+		{
+			desc:            "revoke sessions with invalid token",
+			token:           invalidToken,
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "revoke sessions with failed service",
+			token:  validToken,
+			svcErr: svcerr.ErrRemoveEntity,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrRemoveEntity, http.StatusUnprocessableEntity),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("RevokeSessions", mock.Anything, tc.session).Return(tc.svcErr)
+			err := mgsdk.RevokeSessions(tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "RevokeSessions", mock.Anything, tc.session)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestUnlockUser(t *testing.T) {
+	ts, svc, auth := setupUsers()
+	defer ts.Close()
+
+	conf := sdk.Config{
+		UsersURL: ts.URL,
+	}
+	mgsdk := sdk.NewSDK(conf)
+
+	cases := []struct {
+		desc            string
+		token           string
+		session         smqauthn.Session
+		userID          string
+		svcErr          error
+		authenticateErr error
+		err             errors.SDKError
+	}{
+		{
+			desc:   "unlock user successfully",
+			token:  validToken,
+			userID: validID,
+			svcErr: nil,
+			err:    nil,
+		},
+		{
+			desc:            "unlock user with invalid token",
+			token:           invalidToken,
+			userID:          validID,
+			authenticateErr: svcerr.ErrAuthentication,
+			err:             errors.NewSDKErrorWithStatus(svcerr.ErrAuthentication, http.StatusUnauthorized),
+		},
+		{
+			desc:   "unlock user as non super admin",
+			token:  validToken,
+			userID: validID,
+			svcErr: svcerr.ErrAuthorization,
+			err:    errors.NewSDKErrorWithStatus(svcerr.ErrAuthorization, http.StatusForbidden),
+		},
+		{
+			desc:   "unlock user with empty id",
+			token:  validToken,
+			userID: "",
+			svcErr: nil,
+			err:    errors.NewSDKError(apiutil.ErrMissingID),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.token == validToken {
+				tc.session = smqauthn.Session{DomainUserID: validID, UserID: validID, DomainID: domainID}
+			}
+			authCall := auth.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.authenticateErr)
+			svcCall := svc.On("Unlock", mock.Anything, tc.session, tc.userID).Return(tc.svcErr)
+			err := mgsdk.UnlockUser(tc.userID, tc.token)
+			assert.Equal(t, tc.err, err)
+			if tc.err == nil {
+				ok := svcCall.Parent.AssertCalled(t, "Unlock", mock.Anything, tc.session, tc.userID)
+				assert.True(t, ok)
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
 func TestListClientUsers(t *testing.T) {
 	ts, svc, auth := setupUsers()
 	defer ts.Close()
@@ -5,15 +5,27 @@ package coap
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 	"time"
 
 	"github.com/absmach/supermq/pkg/server"
+	piondtls "github.com/pion/dtls/v3"
 	gocoap "github.com/plgd-dev/go-coap/v3"
 	"github.com/plgd-dev/go-coap/v3/mux"
 )
 
+const (
+	coapProtocol  = "coap"
+	coapsProtocol = "coaps"
+)
+
+var errAppendClientCA = errors.New("failed to append client CA to the client CAs pool")
+
 type coapServer struct {
 	server.BaseServer
 	handler mux.HandlerFunc
@@ -32,12 +44,24 @@ func NewServer(ctx context.Context, cancel context.CancelFunc, name string, conf
 
 func (s *coapServer) Start() error {
 	errCh := make(chan error)
-	s.Logger.Info(fmt.Sprintf("%s service started using HTTP, exposed port %s", s.Name, s.Address))
-	s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s without TLS", s.Name, s.Protocol, s.Address))
-
-	go func() {
-		errCh <- gocoap.ListenAndServe("udp", s.Address, s.handler)
-	}()
+	s.Protocol = coapProtocol
+	switch {
+	case s.Config.CertFile != "" || s.Config.KeyFile != "":
+		s.Protocol = coapsProtocol
+		dtlsConfig, err := s.loadDTLSConfig()
+		if err != nil {
+			return err
+		}
+		s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s with DTLS cert %s and key %s", s.Name, s.Protocol, s.Address, s.Config.CertFile, s.Config.KeyFile))
+		go func() {
+			errCh <- gocoap.ListenAndServeDTLS("udp", s.Address, dtlsConfig, s.handler)
+		}()
+	default:
+		s.Logger.Info(fmt.Sprintf("%s service %s server listening at %s without TLS", s.Name, s.Protocol, s.Address))
+		go func() {
+			errCh <- gocoap.ListenAndServe("udp", s.Address, s.handler)
+		}()
+	}
 
 	select {
 	case <-s.Ctx.Done():
@@ -47,6 +71,33 @@ func (s *coapServer) Start() error {
 	}
 }
 
+// loadDTLSConfig loads the server certificate and the client CAs. The client
+// certificates are verified against the client CAs if given, and it's up to
+// the handler to reject the clients which don't present the certificate.
+func (s *coapServer) loadDTLSConfig() (*piondtls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(s.Config.CertFile, s.Config.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &piondtls.Config{
+		Certificates:         []tls.Certificate{cert},
+		ExtendedMasterSecret: piondtls.RequireExtendedMasterSecret,
+	}
+	if s.Config.ClientCAFile != "" {
+		clientCA, err := os.ReadFile(s.Config.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = x509.NewCertPool()
+		if !config.ClientCAs.AppendCertsFromPEM(clientCA) {
+			return nil, errAppendClientCA
+		}
+		config.ClientAuth = piondtls.VerifyClientCertIfGiven
+	}
+
+	return config, nil
+}
+
 func (s *coapServer) Stop() error {
 	defer s.Cancel()
 	c := make(chan bool)
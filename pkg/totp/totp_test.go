@@ -0,0 +1,148 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package totp_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 secret from the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerate(t *testing.T) {
+	cases := []struct {
+		desc   string
+		secret string
+		time   time.Time
+		code   string
+		err    error
+	}{
+		{
+			desc:   "generate code at 59",
+			secret: rfcSecret,
+			time:   time.Unix(59, 0),
+			code:   "287082",
+		},
+		{
+			desc:   "generate code at 1111111109",
+			secret: rfcSecret,
+			time:   time.Unix(1111111109, 0),
+			code:   "081804",
+		},
+		{
+			desc:   "generate code at 2000000000",
+			secret: rfcSecret,
+			time:   time.Unix(2000000000, 0),
+			code:   "279037",
+		},
+		{
+			desc:   "generate code with invalid secret",
+			secret: "invalid secret!",
+			time:   time.Unix(59, 0),
+			err:    totp.ErrInvalidSecret,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			code, err := totp.Generate(tc.secret, tc.time)
+			assert.True(t, errors.Contains(err, tc.err), "expected error %s got %s", tc.err, err)
+			assert.Equal(t, tc.code, code)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.Nil(t, err)
+	now := time.Now()
+	code, err := totp.Generate(secret, now)
+	require.Nil(t, err)
+
+	cases := []struct {
+		desc   string
+		secret string
+		code   string
+		time   time.Time
+		valid  bool
+	}{
+		{
+			desc:   "validate current code",
+			secret: secret,
+			code:   code,
+			time:   now,
+			valid:  true,
+		},
+		{
+			desc:   "validate code from the previous period",
+			secret: secret,
+			code:   code,
+			time:   now.Add(totp.Period),
+			valid:  true,
+		},
+		{
+			desc:   "validate expired code",
+			secret: secret,
+			code:   code,
+			time:   now.Add(3 * totp.Period),
+			valid:  false,
+		},
+		{
+			desc:   "validate code with invalid length",
+			secret: secret,
+			code:   code[1:],
+			time:   now,
+			valid:  false,
+		},
+		{
+			desc:   "validate code with invalid secret",
+			secret: "invalid secret!",
+			code:   code,
+			time:   now,
+			valid:  false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.valid, totp.Validate(tc.secret, tc.code, tc.time))
+		})
+	}
+}
+
+func TestValidateStep(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := now.Unix() / int64(totp.Period.Seconds())
+
+	s, ok := totp.ValidateStep(rfcSecret, "081804", now)
+	assert.True(t, ok)
+	assert.Equal(t, step, s)
+
+	s, ok = totp.ValidateStep(rfcSecret, "081804", now.Add(totp.Period))
+	assert.True(t, ok)
+	assert.Equal(t, step, s)
+
+	s, ok = totp.ValidateStep(rfcSecret, "000000", now)
+	assert.False(t, ok)
+	assert.Equal(t, int64(0), s)
+}
+
+func TestURI(t *testing.T) {
+	uri := totp.URI("SuperMQ", "user@example.com", rfcSecret)
+	u, err := url.Parse(uri)
+	require.Nil(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/SuperMQ:user@example.com", u.Path)
+	assert.Equal(t, rfcSecret, u.Query().Get("secret"))
+	assert.Equal(t, "SuperMQ", u.Query().Get("issuer"))
+}
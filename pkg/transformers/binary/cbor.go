@@ -0,0 +1,84 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package binary
+
+import (
+	"fmt"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/fxamacker/cbor/v2"
+)
+
+var (
+	errInvalidFormat  = errors.New("invalid CBOR map")
+	errInvalidNesting = errors.New("invalid nested CBOR map")
+)
+
+type cborDecoder struct {
+	fields map[string]string
+}
+
+func newCBORDecoder(fields map[string]string) (decoder, error) {
+	return cborDecoder{fields: fields}, nil
+}
+
+// decode decodes a CBOR map, or an array of CBOR maps, to payloads. Top level
+// keys are renamed using the schema fields.
+func (cd cborDecoder) decode(payload []byte) ([]json.Payload, error) {
+	var val interface{}
+	if err := cbor.Unmarshal(payload, &val); err != nil {
+		return nil, err
+	}
+
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		return []json.Payload{cd.payload(v)}, nil
+	case []interface{}:
+		ret := make([]json.Payload, len(v))
+		for i, item := range v {
+			m, ok := item.(map[interface{}]interface{})
+			if !ok {
+				return nil, errInvalidNesting
+			}
+			ret[i] = cd.payload(m)
+		}
+		return ret, nil
+	default:
+		return nil, errInvalidFormat
+	}
+}
+
+func (cd cborDecoder) payload(m map[interface{}]interface{}) json.Payload {
+	ret := make(json.Payload, len(m))
+	for k, v := range m {
+		key := fmt.Sprint(k)
+		if name, ok := cd.fields[key]; ok {
+			key = name
+		}
+		ret[key] = normalize(v)
+	}
+
+	return ret
+}
+
+// normalize converts nested CBOR maps to maps with string keys, so they
+// can be encoded to JSON.
+func normalize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[interface{}]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, mv := range v {
+			ret[fmt.Sprint(k)] = normalize(mv)
+		}
+		return ret
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package binary
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers"
+)
+
+type dispatcher struct {
+	binary   transformers.Transformer
+	channels map[string]struct{}
+	fallback transformers.Transformer
+}
+
+// NewDispatcher returns a transformer which selects the transformer per
+// message. Messages with Protobuf or CBOR content type header, as well as
+// messages without content type published on a channel with a registered
+// schema, are decoded using the binary transformer. Other messages are
+// transformed using the fallback transformer, or returned as is if the
+// fallback is nil. Schemas without content type describe Protobuf messages
+// if they specify a descriptor and CBOR messages otherwise.
+func NewDispatcher(schemas []Schema, fallback transformers.Transformer) (transformers.Transformer, error) {
+	d := dispatcher{
+		channels: make(map[string]struct{}, len(schemas)),
+		fallback: fallback,
+	}
+	ss := make([]Schema, len(schemas))
+	for i, s := range schemas {
+		if s.ContentType == "" {
+			s.ContentType = CBOR
+			if s.Descriptor != "" {
+				s.ContentType = Protobuf
+			}
+		}
+		if s.Channel != "" {
+			d.channels[s.Channel] = struct{}{}
+		}
+		ss[i] = s
+	}
+	t, err := New("", ss)
+	if err != nil {
+		return nil, err
+	}
+	d.binary = t
+
+	return d, nil
+}
+
+func (d dispatcher) Transform(msg *messaging.Message) (interface{}, error) {
+	switch contentType(msg) {
+	case Protobuf, CBOR:
+		return d.binary.Transform(msg)
+	case "":
+		if _, ok := d.channels[msg.GetChannel()]; ok {
+			return d.binary.Transform(msg)
+		}
+	}
+	if d.fallback == nil {
+		return msg, nil
+	}
+
+	return d.fallback.Transform(msg)
+}
+
+func contentType(msg *messaging.Message) string {
+	ct := msg.GetHeaders()[messaging.ContentTypeHeader]
+	if ct == "" {
+		return ""
+	}
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(ct))
+	}
+
+	return mt
+}
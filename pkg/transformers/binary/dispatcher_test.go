@@ -0,0 +1,151 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package binary_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers"
+	"github.com/absmach/supermq/pkg/transformers/binary"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDispatcher(t *testing.T) {
+	cases := []struct {
+		desc    string
+		schemas []binary.Schema
+		err     error
+	}{
+		{
+			desc: "create dispatcher with protobuf and CBOR schemas",
+			schemas: []binary.Schema{
+				{Channel: protoChan, Descriptor: writeDescriptor(t), Message: readingName},
+				{Channel: cborChan},
+			},
+		},
+		{
+			desc:    "create dispatcher with unknown content type",
+			schemas: []binary.Schema{{Channel: cborChan, ContentType: "application/senml+json"}},
+			err:     binary.ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := binary.NewDispatcher(tc.schemas, nil)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestDispatcherTransform(t *testing.T) {
+	schemas := []binary.Schema{
+		{Channel: protoChan, Descriptor: writeDescriptor(t), Message: readingName, Format: "readings"},
+		{Channel: cborChan},
+	}
+	tr, err := binary.NewDispatcher(schemas, json.New(nil))
+	require.Nil(t, err, fmt.Sprintf("create dispatcher unexpected error: %s", err))
+	noneTr, err := binary.NewDispatcher(schemas, nil)
+	require.Nil(t, err, fmt.Sprintf("create dispatcher unexpected error: %s", err))
+
+	newMsg := func(channel, contentType string, payload []byte) *messaging.Message {
+		msg := &messaging.Message{
+			Channel:   channel,
+			Subtopic:  "home.climate",
+			Publisher: "publisher-1",
+			Protocol:  "coap",
+			Payload:   payload,
+		}
+		if contentType != "" {
+			msg.Headers = map[string]string{messaging.ContentTypeHeader: contentType}
+		}
+		return msg
+	}
+	cborData := cborPayload(t, map[interface{}]interface{}{"temperature": 21.5})
+	jsonData := []byte(`{"temperature":21.5}`)
+	binaryMsg := func(msg *messaging.Message) interface{} {
+		return json.Messages{
+			Data: []json.Message{
+				{
+					Channel:   msg.Channel,
+					Subtopic:  msg.Subtopic,
+					Publisher: msg.Publisher,
+					Protocol:  msg.Protocol,
+					Payload:   json.Payload{"temperature": 21.5},
+				},
+			},
+			Format: "climate",
+		}
+	}
+	jsonMsg := newMsg(cborChan, "application/json", jsonData)
+	jsonRes, err := json.New(nil).Transform(jsonMsg)
+	require.Nil(t, err, fmt.Sprintf("transform JSON message unexpected error: %s", err))
+	noSchemaMsg := newMsg("unknown", "", jsonData)
+	noSchemaRes, err := json.New(nil).Transform(noSchemaMsg)
+	require.Nil(t, err, fmt.Sprintf("transform JSON message unexpected error: %s", err))
+
+	cases := []struct {
+		desc        string
+		transformer transformers.Transformer
+		msg         *messaging.Message
+		res         interface{}
+		err         error
+	}{
+		{
+			desc:        "transform message with CBOR content type",
+			transformer: tr,
+			msg:         newMsg(cborChan, binary.CBOR, cborData),
+			res:         binaryMsg(newMsg(cborChan, "", cborData)),
+		},
+		{
+			desc:        "transform message with CBOR content type and parameters",
+			transformer: tr,
+			msg:         newMsg(cborChan, "Application/CBOR; charset=binary", cborData),
+			res:         binaryMsg(newMsg(cborChan, "", cborData)),
+		},
+		{
+			desc:        "transform message without content type on channel with schema",
+			transformer: tr,
+			msg:         newMsg(cborChan, "", cborData),
+			res:         binaryMsg(newMsg(cborChan, "", cborData)),
+		},
+		{
+			desc:        "transform message with protobuf content type on channel without schema",
+			transformer: tr,
+			msg:         newMsg("unknown", binary.Protobuf, protoPayload(t, 1)),
+			err:         binary.ErrUnknownChannel,
+		},
+		{
+			desc:        "transform message with other content type using fallback",
+			transformer: tr,
+			msg:         jsonMsg,
+			res:         jsonRes,
+		},
+		{
+			desc:        "transform message without content type on channel without schema using fallback",
+			transformer: tr,
+			msg:         noSchemaMsg,
+			res:         noSchemaRes,
+		},
+		{
+			desc:        "transform message without fallback",
+			transformer: noneTr,
+			msg:         jsonMsg,
+			res:         jsonMsg,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, err := tc.transformer.Transform(tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.res, res))
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package binary contains the schema based transformer for Protobuf and CBOR
+// messages.
+package binary
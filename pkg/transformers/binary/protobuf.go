@@ -0,0 +1,115 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package binary
+
+import (
+	"os"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+var (
+	errReadDescriptor  = errors.New("failed to read descriptor file")
+	errParseDescriptor = errors.New("failed to parse descriptor file")
+	errUnknownMessage  = errors.New("message not found in descriptor")
+)
+
+type protobufDecoder struct {
+	desc protoreflect.MessageDescriptor
+}
+
+func newProtobufDecoder(path, message string) (decoder, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(errReadDescriptor, err)
+	}
+	var fds descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &fds); err != nil {
+		return nil, errors.Wrap(errParseDescriptor, err)
+	}
+	files, err := protodesc.NewFiles(&fds)
+	if err != nil {
+		return nil, errors.Wrap(errParseDescriptor, err)
+	}
+	d, err := files.FindDescriptorByName(protoreflect.FullName(message))
+	if err != nil {
+		return nil, errors.Wrap(errUnknownMessage, err)
+	}
+	desc, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, errUnknownMessage
+	}
+
+	return protobufDecoder{desc: desc}, nil
+}
+
+func (pd protobufDecoder) decode(payload []byte) ([]json.Payload, error) {
+	msg := dynamicpb.NewMessage(pd.desc)
+	if err := proto.Unmarshal(payload, msg); err != nil {
+		return nil, err
+	}
+
+	return []json.Payload{messageToMap(msg)}, nil
+}
+
+// messageToMap converts populated message fields to a map keyed by the field
+// names. Integers are widened to 64 bits and enums are represented by names.
+func messageToMap(msg protoreflect.Message) map[string]interface{} {
+	ret := make(map[string]interface{})
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		ret[string(fd.Name())] = fieldValue(fd, v)
+		return true
+	})
+
+	return ret
+}
+
+func fieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		vals := make([]interface{}, list.Len())
+		for i := range vals {
+			vals[i] = singularValue(fd, list.Get(i))
+		}
+		return vals
+	case fd.IsMap():
+		vals := make(map[string]interface{})
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			vals[k.String()] = singularValue(fd.MapValue(), mv)
+			return true
+		})
+		return vals
+	default:
+		return singularValue(fd, v)
+	}
+}
+
+func singularValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) interface{} {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageToMap(v.Message())
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return int64(v.Enum())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return v.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return v.Float()
+	default:
+		return v.Interface()
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package binary
+
+import (
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers"
+	"github.com/absmach/supermq/pkg/transformers/json"
+)
+
+const (
+	// Protobuf represents Protocol Buffers content type.
+	Protobuf = "application/x-protobuf"
+	// CBOR represents CBOR content type.
+	CBOR = "application/cbor"
+)
+
+var (
+	// ErrTransform represents an error during decoding message.
+	ErrTransform = errors.New("unable to decode binary message")
+	// ErrInvalidSchema represents an invalid schema configuration.
+	ErrInvalidSchema = errors.New("invalid schema")
+	// ErrUnknownChannel represents a message published on a channel without schema.
+	ErrUnknownChannel = errors.New("no schema registered for channel")
+
+	errUnknownContentType = errors.New("unknown content type")
+	errUnknownFormat      = errors.New("unknown format of binary message")
+	errInvalidTimeField   = errors.New("invalid time field")
+)
+
+// Schema describes how the payloads published on a channel are decoded.
+type Schema struct {
+	// Channel is the ID of the channel the schema is registered for. Schema
+	// with empty channel is used for channels without a registered schema.
+	Channel string `toml:"channel"`
+	// ContentType is the payload content type. It defaults to the content type
+	// of the transformer.
+	ContentType string `toml:"content_type"`
+	// Descriptor is the path of the serialized FileDescriptorSet containing the
+	// Protobuf message definition, as produced by
+	// protoc --include_imports --descriptor_set_out.
+	Descriptor string `toml:"descriptor"`
+	// Message is the fully qualified name of the Protobuf message.
+	Message string `toml:"message"`
+	// Fields maps CBOR map keys to field names. Integer keys are given in
+	// their decimal form. Keys without a mapping are kept as is.
+	Fields map[string]string `toml:"fields"`
+	// TimeField is the name of the numeric field holding the Unix time of
+	// the measurement. Seconds, milliseconds, microseconds and nanoseconds
+	// are detected from the value.
+	TimeField string `toml:"time_field"`
+	// Format is the message format used by consumers. It defaults to the last
+	// part of the message subtopic.
+	Format string `toml:"format"`
+}
+
+type decoder interface {
+	decode(payload []byte) ([]json.Payload, error)
+}
+
+type channelSchema struct {
+	Schema
+	decoder decoder
+}
+
+type transformer struct {
+	schemas map[string]channelSchema
+}
+
+// New returns a transformer which decodes Protobuf and CBOR messages using
+// the schemas registered per channel. The content type is used for schemas
+// which don't specify their own.
+func New(contentType string, schemas []Schema) (transformers.Transformer, error) {
+	t := transformer{
+		schemas: make(map[string]channelSchema, len(schemas)),
+	}
+	for _, s := range schemas {
+		if s.ContentType == "" {
+			s.ContentType = contentType
+		}
+		d, err := newDecoder(s)
+		if err != nil {
+			return nil, errors.Wrap(ErrInvalidSchema, err)
+		}
+		t.schemas[s.Channel] = channelSchema{Schema: s, decoder: d}
+	}
+
+	return t, nil
+}
+
+func newDecoder(s Schema) (decoder, error) {
+	switch s.ContentType {
+	case Protobuf:
+		return newProtobufDecoder(s.Descriptor, s.Message)
+	case CBOR:
+		return newCBORDecoder(s.Fields)
+	default:
+		return nil, errUnknownContentType
+	}
+}
+
+// Transform decodes SuperMQ message payload to a list of JSON messages.
+func (t transformer) Transform(msg *messaging.Message) (interface{}, error) {
+	s, ok := t.schemas[msg.GetChannel()]
+	if !ok {
+		if s, ok = t.schemas[""]; !ok {
+			return nil, ErrUnknownChannel
+		}
+	}
+
+	format := s.Format
+	if format == "" {
+		subs := strings.Split(msg.GetSubtopic(), ".")
+		format = subs[len(subs)-1]
+	}
+	if format == "" {
+		return nil, errors.Wrap(ErrTransform, errUnknownFormat)
+	}
+
+	payloads, err := s.decoder.decode(msg.GetPayload())
+	if err != nil {
+		return nil, errors.Wrap(ErrTransform, err)
+	}
+
+	ret := make([]json.Message, len(payloads))
+	for i, p := range payloads {
+		created := msg.GetCreated()
+		if s.TimeField != "" {
+			ts, err := timestamp(p[s.TimeField])
+			if err != nil {
+				return nil, errors.Wrap(ErrTransform, err)
+			}
+			if ts != 0 {
+				created = ts
+			}
+		}
+		ret[i] = json.Message{
+			Channel:   msg.GetChannel(),
+			Created:   created,
+			Subtopic:  msg.GetSubtopic(),
+			Publisher: msg.GetPublisher(),
+			Protocol:  msg.GetProtocol(),
+			Payload:   p,
+		}
+	}
+
+	return json.Messages{Data: ret, Format: format}, nil
+}
+
+func timestamp(val interface{}) (int64, error) {
+	switch v := val.(type) {
+	case nil:
+		return 0, nil
+	case int64:
+		return transformers.ToUnixNano(v), nil
+	case uint64:
+		return transformers.ToUnixNano(int64(v)), nil
+	case float64:
+		return int64(transformers.ToUnixNano(v)), nil
+	default:
+		return 0, errInvalidTimeField
+	}
+}
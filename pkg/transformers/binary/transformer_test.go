@@ -0,0 +1,366 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package binary_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers"
+	"github.com/absmach/supermq/pkg/transformers/binary"
+	"github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	protoChan   = "proto-channel"
+	cborChan    = "cbor-channel"
+	readingName = "sensors.Reading"
+)
+
+var fileDesc = &descriptorpb.FileDescriptorProto{
+	Name:    proto.String("reading.proto"),
+	Package: proto.String("sensors"),
+	Syntax:  proto.String("proto3"),
+	EnumType: []*descriptorpb.EnumDescriptorProto{
+		{
+			Name: proto.String("Unit"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("CELSIUS"), Number: proto.Int32(0)},
+				{Name: proto.String("FAHRENHEIT"), Number: proto.Int32(1)},
+			},
+		},
+	},
+	MessageType: []*descriptorpb.DescriptorProto{
+		{
+			Name: proto.String("Location"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("lat", 1, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE),
+				field("lon", 2, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE),
+			},
+		},
+		{
+			Name: proto.String("Reading"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("sensor", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+				field("temperature", 2, descriptorpb.FieldDescriptorProto_TYPE_FLOAT),
+				field("ts", 3, descriptorpb.FieldDescriptorProto_TYPE_UINT64),
+				field("count", 4, descriptorpb.FieldDescriptorProto_TYPE_INT32),
+				{
+					Name:     proto.String("unit"),
+					Number:   proto.Int32(5),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_ENUM.Enum(),
+					TypeName: proto.String(".sensors.Unit"),
+				},
+				{
+					Name:     proto.String("location"),
+					Number:   proto.Int32(6),
+					Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+					Type:     descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+					TypeName: proto.String(".sensors.Location"),
+				},
+				{
+					Name:   proto.String("tags"),
+					Number: proto.Int32(7),
+					Label:  descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+					Type:   descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+				},
+			},
+		},
+	},
+}
+
+func field(name string, num int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+	return &descriptorpb.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(num),
+		Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		Type:   typ.Enum(),
+	}
+}
+
+func writeDescriptor(t *testing.T) string {
+	data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{fileDesc}})
+	require.Nil(t, err, fmt.Sprintf("marshal descriptor unexpected error: %s", err))
+	path := filepath.Join(t.TempDir(), "reading.pb")
+	err = os.WriteFile(path, data, 0o600)
+	require.Nil(t, err, fmt.Sprintf("write descriptor unexpected error: %s", err))
+
+	return path
+}
+
+func protoPayload(t *testing.T, ts uint64) []byte {
+	fd, err := protodesc.NewFile(fileDesc, nil)
+	require.Nil(t, err, fmt.Sprintf("create file descriptor unexpected error: %s", err))
+	md := fd.Messages().ByName("Reading")
+	loc := dynamicpb.NewMessage(fd.Messages().ByName("Location"))
+	loc.Set(loc.Descriptor().Fields().ByName("lat"), protoreflect.ValueOfFloat64(44.8))
+	loc.Set(loc.Descriptor().Fields().ByName("lon"), protoreflect.ValueOfFloat64(20.4))
+
+	msg := dynamicpb.NewMessage(md)
+	fields := md.Fields()
+	msg.Set(fields.ByName("sensor"), protoreflect.ValueOfString("s-1"))
+	msg.Set(fields.ByName("temperature"), protoreflect.ValueOfFloat32(21.5))
+	msg.Set(fields.ByName("ts"), protoreflect.ValueOfUint64(ts))
+	msg.Set(fields.ByName("count"), protoreflect.ValueOfInt32(-3))
+	msg.Set(fields.ByName("unit"), protoreflect.ValueOfEnum(1))
+	msg.Set(fields.ByName("location"), protoreflect.ValueOfMessage(loc))
+	tags := msg.Mutable(fields.ByName("tags")).List()
+	tags.Append(protoreflect.ValueOfString("indoor"))
+	tags.Append(protoreflect.ValueOfString("lab"))
+
+	data, err := proto.Marshal(msg)
+	require.Nil(t, err, fmt.Sprintf("marshal message unexpected error: %s", err))
+
+	return data
+}
+
+func cborPayload(t *testing.T, v interface{}) []byte {
+	data, err := cbor.Marshal(v)
+	require.Nil(t, err, fmt.Sprintf("marshal CBOR unexpected error: %s", err))
+
+	return data
+}
+
+func TestNew(t *testing.T) {
+	descriptor := writeDescriptor(t)
+
+	cases := []struct {
+		desc        string
+		contentType string
+		schemas     []binary.Schema
+		err         error
+	}{
+		{
+			desc:        "create transformer with protobuf and CBOR schemas",
+			contentType: binary.CBOR,
+			schemas: []binary.Schema{
+				{Channel: protoChan, ContentType: binary.Protobuf, Descriptor: descriptor, Message: readingName},
+				{Channel: cborChan},
+			},
+		},
+		{
+			desc:        "create transformer without schemas",
+			contentType: binary.Protobuf,
+		},
+		{
+			desc:        "create transformer with unknown content type",
+			contentType: "application/senml+json",
+			schemas:     []binary.Schema{{Channel: cborChan}},
+			err:         binary.ErrInvalidSchema,
+		},
+		{
+			desc:        "create transformer with missing descriptor file",
+			contentType: binary.Protobuf,
+			schemas:     []binary.Schema{{Channel: protoChan, Descriptor: filepath.Join(t.TempDir(), "missing.pb"), Message: readingName}},
+			err:         binary.ErrInvalidSchema,
+		},
+		{
+			desc:        "create transformer with unknown message",
+			contentType: binary.Protobuf,
+			schemas:     []binary.Schema{{Channel: protoChan, Descriptor: descriptor, Message: "sensors.Unknown"}},
+			err:         binary.ErrInvalidSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := binary.New(tc.contentType, tc.schemas)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestTransform(t *testing.T) {
+	now := time.Now().Unix()
+	ts := uint64(1638310819)
+
+	tr, err := binary.New(binary.CBOR, []binary.Schema{
+		{Channel: protoChan, ContentType: binary.Protobuf, Descriptor: writeDescriptor(t), Message: readingName, TimeField: "ts", Format: "readings"},
+		{Channel: cborChan, Fields: map[string]string{"1": "temperature", "2": "humidity"}},
+	})
+	require.Nil(t, err, fmt.Sprintf("create transformer unexpected error: %s", err))
+
+	defTr, err := binary.New(binary.CBOR, []binary.Schema{{}})
+	require.Nil(t, err, fmt.Sprintf("create transformer unexpected error: %s", err))
+
+	newMsg := func(channel, subtopic, protocol string, payload []byte) *messaging.Message {
+		return &messaging.Message{
+			Channel:   channel,
+			Subtopic:  subtopic,
+			Publisher: "publisher-1",
+			Protocol:  protocol,
+			Payload:   payload,
+			Created:   now,
+		}
+	}
+	climatePayload := cborPayload(t, map[interface{}]interface{}{1: 21.5, 2: 40, "loc": map[interface{}]interface{}{1: "lab"}})
+	protoMsg := newMsg(protoChan, "home.temperature", "mqtt", protoPayload(t, ts))
+	cborMsg := newMsg(cborChan, "home.climate", "coap", climatePayload)
+	cborListMsg := newMsg(cborChan, "home.climate", "coap", cborPayload(t, []interface{}{
+		map[interface{}]interface{}{1: 21.5},
+		map[interface{}]interface{}{1: -2},
+	}))
+	noFormatMsg := newMsg(cborChan, "", "coap", climatePayload)
+	unknownChanMsg := newMsg("unknown", "home.climate", "coap", climatePayload)
+	invalidCBORMsg := newMsg(cborChan, "home.climate", "coap", cborPayload(t, "not a map"))
+	invalidNestedMsg := newMsg(cborChan, "home.climate", "coap", cborPayload(t, []interface{}{1, 2}))
+	invalidProtoMsg := newMsg(protoChan, "home.temperature", "mqtt", []byte{0xff, 0xff})
+
+	cases := []struct {
+		desc        string
+		transformer transformers.Transformer
+		msg         *messaging.Message
+		json        interface{}
+		err         error
+	}{
+		{
+			desc:        "transform protobuf message",
+			transformer: tr,
+			msg:         protoMsg,
+			json: json.Messages{
+				Data: []json.Message{
+					{
+						Channel:   protoChan,
+						Subtopic:  protoMsg.Subtopic,
+						Publisher: protoMsg.Publisher,
+						Protocol:  protoMsg.Protocol,
+						Created:   int64(ts) * 1e9,
+						Payload: json.Payload{
+							"sensor":      "s-1",
+							"temperature": float64(21.5),
+							"ts":          ts,
+							"count":       int64(-3),
+							"unit":        "FAHRENHEIT",
+							"location":    map[string]interface{}{"lat": 44.8, "lon": 20.4},
+							"tags":        []interface{}{"indoor", "lab"},
+						},
+					},
+				},
+				Format: "readings",
+			},
+		},
+		{
+			desc:        "transform CBOR map message",
+			transformer: tr,
+			msg:         cborMsg,
+			json: json.Messages{
+				Data: []json.Message{
+					{
+						Channel:   cborChan,
+						Subtopic:  cborMsg.Subtopic,
+						Publisher: cborMsg.Publisher,
+						Protocol:  cborMsg.Protocol,
+						Created:   now,
+						Payload: json.Payload{
+							"temperature": 21.5,
+							"humidity":    uint64(40),
+							"loc":         map[string]interface{}{"1": "lab"},
+						},
+					},
+				},
+				Format: "climate",
+			},
+		},
+		{
+			desc:        "transform CBOR array message",
+			transformer: tr,
+			msg:         cborListMsg,
+			json: json.Messages{
+				Data: []json.Message{
+					{
+						Channel:   cborChan,
+						Subtopic:  cborMsg.Subtopic,
+						Publisher: cborMsg.Publisher,
+						Protocol:  cborMsg.Protocol,
+						Created:   now,
+						Payload:   json.Payload{"temperature": 21.5},
+					},
+					{
+						Channel:   cborChan,
+						Subtopic:  cborMsg.Subtopic,
+						Publisher: cborMsg.Publisher,
+						Protocol:  cborMsg.Protocol,
+						Created:   now,
+						Payload:   json.Payload{"temperature": int64(-2)},
+					},
+				},
+				Format: "climate",
+			},
+		},
+		{
+			desc:        "transform message using default schema",
+			transformer: defTr,
+			msg:         unknownChanMsg,
+			json: json.Messages{
+				Data: []json.Message{
+					{
+						Channel:   "unknown",
+						Subtopic:  cborMsg.Subtopic,
+						Publisher: cborMsg.Publisher,
+						Protocol:  cborMsg.Protocol,
+						Created:   now,
+						Payload: json.Payload{
+							"1":   21.5,
+							"2":   uint64(40),
+							"loc": map[string]interface{}{"1": "lab"},
+						},
+					},
+				},
+				Format: "climate",
+			},
+		},
+		{
+			desc:        "transform message without format",
+			transformer: tr,
+			msg:         noFormatMsg,
+			err:         binary.ErrTransform,
+		},
+		{
+			desc:        "transform message from channel without schema",
+			transformer: tr,
+			msg:         unknownChanMsg,
+			err:         binary.ErrUnknownChannel,
+		},
+		{
+			desc:        "transform invalid CBOR message",
+			transformer: tr,
+			msg:         invalidCBORMsg,
+			err:         binary.ErrTransform,
+		},
+		{
+			desc:        "transform CBOR array of non-map items",
+			transformer: tr,
+			msg:         invalidNestedMsg,
+			err:         binary.ErrTransform,
+		},
+		{
+			desc:        "transform invalid protobuf message",
+			transformer: tr,
+			msg:         invalidProtoMsg,
+			err:         binary.ErrTransform,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			m, err := tc.transformer.Transform(tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.json, m, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.json, m))
+		})
+	}
+}
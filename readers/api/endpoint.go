@@ -0,0 +1,91 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/auth"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/connections"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/readers"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func listMessagesEndpoint(svc readers.MessageRepository, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listMessagesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		if err := authorize(ctx, req, authn, clients, channels); err != nil {
+			return nil, err
+		}
+
+		page, err := svc.ReadAll(req.chanID, req.pageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		return pageRes{
+			PageMetadata: page.PageMetadata,
+			Total:        page.Total,
+			Messages:     page.Messages,
+		}, nil
+	}
+}
+
+// authorize checks whether the client identified by the secret, or the user
+// identified by the token, is allowed to subscribe to the channel.
+func authorize(ctx context.Context, req listMessagesReq, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) error {
+	var clientID, clientType, domainID string
+	switch {
+	case req.key != "":
+		res, err := clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{ClientSecret: req.key})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+		if !res.GetAuthenticated() {
+			return svcerr.ErrAuthentication
+		}
+		clientID = res.GetId()
+		clientType = policies.ClientType
+	default:
+		session, err := authn.Authenticate(ctx, req.token)
+		if err != nil {
+			return errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+		ch, err := channels.RetrieveEntity(ctx, &grpcCommonV1.RetrieveEntityReq{Id: req.chanID})
+		if err != nil {
+			return errors.Wrap(svcerr.ErrAuthorization, err)
+		}
+		domainID = ch.GetEntity().GetDomainId()
+		clientID = auth.EncodeDomainUserID(domainID, session.UserID)
+		clientType = policies.UserType
+	}
+
+	res, err := channels.Authorize(ctx, &grpcChannelsV1.AuthzReq{
+		DomainId:   domainID,
+		ClientId:   clientID,
+		ClientType: clientType,
+		ChannelId:  req.chanID,
+		Type:       uint32(connections.Subscribe),
+	})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrAuthorization, err)
+	}
+	if !res.GetAuthorized() {
+		return svcerr.ErrAuthorization
+	}
+
+	return nil
+}
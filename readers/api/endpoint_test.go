@@ -0,0 +1,277 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
+	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	chmocks "github.com/absmach/supermq/channels/mocks"
+	climocks "github.com/absmach/supermq/clients/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/connections"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/absmach/supermq/readers"
+	"github.com/absmach/supermq/readers/api"
+	"github.com/absmach/supermq/readers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	svcName      = "test-service"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+	clientSecret = "client-secret"
+	userToken    = "user-token"
+	invalid      = "invalid"
+)
+
+var (
+	chanID   = testsutil.GenerateUUID(&testing.T{})
+	clientID = testsutil.GenerateUUID(&testing.T{})
+	userID   = testsutil.GenerateUUID(&testing.T{})
+	domainID = testsutil.GenerateUUID(&testing.T{})
+	v        = 5.0
+)
+
+func newServer(repo readers.MessageRepository, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) *httptest.Server {
+	mux := api.MakeHandler(repo, authn, clients, channels, svcName, instanceID)
+	return httptest.NewServer(mux)
+}
+
+type testRequest struct {
+	client *http.Client
+	method string
+	url    string
+	token  string
+	key    string
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.key != "" {
+		req.Header.Set("Authorization", apiutil.ClientPrefix+tr.key)
+	}
+
+	return tr.client.Do(req)
+}
+
+type pageRes struct {
+	readers.PageMetadata
+	Total    uint64            `json:"total"`
+	Messages []json.RawMessage `json:"messages,omitempty"`
+}
+
+func TestListMessages(t *testing.T) {
+	repo := new(mocks.MessageRepository)
+	authn := new(authnmocks.Authentication)
+	clients := new(climocks.ClientsServiceClient)
+	channels := new(chmocks.ChannelsServiceClient)
+	ts := newServer(repo, authn, clients, channels)
+	defer ts.Close()
+
+	msgs := []readers.Message{}
+	for i := 0; i < 10; i++ {
+		msgs = append(msgs, senml.Message{Channel: chanID, Publisher: clientID, Protocol: "mqtt", Name: "temperature", Value: &v, Time: float64(i)})
+	}
+
+	cases := []struct {
+		desc       string
+		url        string
+		token      string
+		key        string
+		pageMeta   readers.PageMetadata
+		authnRes   *grpcClientsV1.AuthnRes
+		authnErr   error
+		session    smqauthn.Session
+		sessionErr error
+		authzReq   *grpcChannelsV1.AuthzReq
+		authzRes   *grpcChannelsV1.AuthzRes
+		authzErr   error
+		page       readers.MessagesPage
+		repoErr    error
+		status     int
+		total      uint64
+	}{
+		{
+			desc:     "read messages with client secret",
+			url:      fmt.Sprintf("/channels/%s/messages?offset=0&limit=10", chanID),
+			key:      clientSecret,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "messages"},
+			authnRes: &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			authzReq: &grpcChannelsV1.AuthzReq{ClientId: clientID, ClientType: policies.ClientType, ChannelId: chanID, Type: uint32(connections.Subscribe)},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			page:     readers.MessagesPage{Total: 10, Messages: msgs},
+			status:   http.StatusOK,
+			total:    10,
+		},
+		{
+			desc:     "read messages with user token",
+			url:      fmt.Sprintf("/channels/%s/messages?subtopic=temp&publisher=%s", chanID, clientID),
+			token:    userToken,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "messages", Subtopic: "temp", Publisher: clientID},
+			session:  smqauthn.Session{UserID: userID},
+			authzReq: &grpcChannelsV1.AuthzReq{DomainId: domainID, ClientId: domainID + "_" + userID, ClientType: policies.UserType, ChannelId: chanID, Type: uint32(connections.Subscribe)},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			page:     readers.MessagesPage{Total: 10, Messages: msgs},
+			status:   http.StatusOK,
+			total:    10,
+		},
+		{
+			desc:     "read messages with value and comparator",
+			url:      fmt.Sprintf("/channels/%s/messages?v=5&comparator=ge", chanID),
+			key:      clientSecret,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "messages", Value: v, Comparator: readers.GreaterThanEqualKey},
+			authnRes: &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			authzReq: &grpcChannelsV1.AuthzReq{ClientId: clientID, ClientType: policies.ClientType, ChannelId: chanID, Type: uint32(connections.Subscribe)},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			page:     readers.MessagesPage{Total: 10, Messages: msgs},
+			status:   http.StatusOK,
+			total:    10,
+		},
+		{
+			desc:     "read messages with aggregation",
+			url:      fmt.Sprintf("/channels/%s/messages?aggregation=max&interval=1h&from=1&to=2", chanID),
+			key:      clientSecret,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "messages", Aggregation: "max", Interval: "1h", From: 1e9, To: 2e9},
+			authnRes: &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			authzReq: &grpcChannelsV1.AuthzReq{ClientId: clientID, ClientType: policies.ClientType, ChannelId: chanID, Type: uint32(connections.Subscribe)},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			page:     readers.MessagesPage{Total: 1, Messages: msgs[:1]},
+			status:   http.StatusOK,
+			total:    1,
+		},
+		{
+			desc:   "read messages without credentials",
+			url:    fmt.Sprintf("/channels/%s/messages", chanID),
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:   "read messages with invalid limit",
+			url:    fmt.Sprintf("/channels/%s/messages?limit=%s", chanID, invalid),
+			key:    clientSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages with limit exceeding maximum",
+			url:    fmt.Sprintf("/channels/%s/messages?limit=1001", chanID),
+			key:    clientSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages with invalid comparator",
+			url:    fmt.Sprintf("/channels/%s/messages?v=5&comparator=%s", chanID, invalid),
+			key:    clientSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages with invalid aggregation",
+			url:    fmt.Sprintf("/channels/%s/messages?aggregation=%s&interval=1h&from=1&to=2", chanID, invalid),
+			key:    clientSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages with aggregation and invalid interval",
+			url:    fmt.Sprintf("/channels/%s/messages?aggregation=max&interval=%s&from=1&to=2", chanID, invalid),
+			key:    clientSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages with aggregation and without from",
+			url:    fmt.Sprintf("/channels/%s/messages?aggregation=max&interval=1h&to=2", chanID),
+			key:    clientSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:   "read messages with aggregation and without to",
+			url:    fmt.Sprintf("/channels/%s/messages?aggregation=max&interval=1h&from=1", chanID),
+			key:    clientSecret,
+			status: http.StatusBadRequest,
+		},
+		{
+			desc:     "read messages with invalid client secret",
+			url:      fmt.Sprintf("/channels/%s/messages", chanID),
+			key:      invalid,
+			authnRes: &grpcClientsV1.AuthnRes{Authenticated: false},
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:       "read messages with invalid user token",
+			url:        fmt.Sprintf("/channels/%s/messages", chanID),
+			token:      invalid,
+			sessionErr: svcerr.ErrAuthentication,
+			status:     http.StatusUnauthorized,
+		},
+		{
+			desc:     "read messages from unauthorized channel",
+			url:      fmt.Sprintf("/channels/%s/messages", chanID),
+			key:      clientSecret,
+			authnRes: &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			authzReq: &grpcChannelsV1.AuthzReq{ClientId: clientID, ClientType: policies.ClientType, ChannelId: chanID, Type: uint32(connections.Subscribe)},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: false},
+			status:   http.StatusForbidden,
+		},
+		{
+			desc:     "read messages with repository error",
+			url:      fmt.Sprintf("/channels/%s/messages", chanID),
+			key:      clientSecret,
+			pageMeta: readers.PageMetadata{Limit: 10, Format: "messages"},
+			authnRes: &grpcClientsV1.AuthnRes{Id: clientID, Authenticated: true},
+			authzReq: &grpcChannelsV1.AuthzReq{ClientId: clientID, ClientType: policies.ClientType, ChannelId: chanID, Type: uint32(connections.Subscribe)},
+			authzRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			repoErr:  readers.ErrReadMessages,
+			status:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			clientsCall := clients.On("Authenticate", mock.Anything, &grpcClientsV1.AuthnReq{ClientSecret: tc.key}).Return(tc.authnRes, tc.authnErr)
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.session, tc.sessionErr)
+			entityCall := channels.On("RetrieveEntity", mock.Anything, &grpcCommonV1.RetrieveEntityReq{Id: chanID}).Return(&grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: chanID, DomainId: domainID}}, nil)
+			authzCall := channels.On("Authorize", mock.Anything, tc.authzReq).Return(tc.authzRes, tc.authzErr)
+			repoCall := repo.On("ReadAll", chanID, tc.pageMeta).Return(tc.page, tc.repoErr)
+
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    ts.URL + tc.url,
+				token:  tc.token,
+				key:    tc.key,
+			}
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.status == http.StatusOK {
+				var page pageRes
+				err := json.NewDecoder(res.Body).Decode(&page)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+				assert.Len(t, page.Messages, len(tc.page.Messages), fmt.Sprintf("%s: unexpected number of messages", tc.desc))
+			}
+			clientsCall.Unset()
+			authnCall.Unset()
+			entityCall.Unset()
+			authzCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/readers"
+)
+
+const maxLimitSize = 1000
+
+var (
+	validAggregations = []string{"MAX", "MIN", "AVG", "SUM", "COUNT"}
+	validComparators  = []string{
+		readers.EqualKey,
+		readers.LowerThanKey,
+		readers.LowerThanEqualKey,
+		readers.GreaterThanKey,
+		readers.GreaterThanEqualKey,
+	}
+)
+
+type listMessagesReq struct {
+	chanID   string
+	token    string
+	key      string
+	pageMeta readers.PageMetadata
+}
+
+func (req listMessagesReq) validate() error {
+	if req.token == "" && req.key == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.chanID == "" {
+		return apiutil.ErrMissingID
+	}
+	if req.pageMeta.Limit < 1 || req.pageMeta.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	if req.pageMeta.Comparator != "" && !slices.Contains(validComparators, req.pageMeta.Comparator) {
+		return apiutil.ErrInvalidComparator
+	}
+
+	if req.pageMeta.Aggregation != "" {
+		if req.pageMeta.From == 0 {
+			return apiutil.ErrMissingFrom
+		}
+		if req.pageMeta.To == 0 {
+			return apiutil.ErrMissingTo
+		}
+		if !slices.Contains(validAggregations, strings.ToUpper(req.pageMeta.Aggregation)) {
+			return apiutil.ErrInvalidAggregation
+		}
+		if d, err := time.ParseDuration(req.pageMeta.Interval); err != nil || d <= 0 {
+			return apiutil.ErrInvalidInterval
+		}
+	}
+
+	return nil
+}
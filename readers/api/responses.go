@@ -0,0 +1,31 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/readers"
+)
+
+var _ supermq.Response = (*pageRes)(nil)
+
+type pageRes struct {
+	readers.PageMetadata
+	Total    uint64            `json:"total"`
+	Messages []readers.Message `json:"messages,omitempty"`
+}
+
+func (res pageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res pageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res pageRes) Empty() bool {
+	return false
+}
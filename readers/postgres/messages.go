@@ -0,0 +1,247 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/postgres"
+	smqjson "github.com/absmach/supermq/pkg/transformers/json"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+	"github.com/absmach/supermq/readers"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	defTable = "messages"
+
+	// errUndefined is PostgreSQL undefined_table error code, see:
+	// https://www.postgresql.org/docs/current/errcodes-appendix.html
+	errUndefined = "42P01"
+)
+
+var (
+	errInvalidFormat = errors.New("invalid message format")
+
+	formatRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+
+	aggregations = map[string]string{
+		"MIN":   "MIN(value)",
+		"MAX":   "MAX(value)",
+		"AVG":   "AVG(value)",
+		"SUM":   "SUM(value)",
+		"COUNT": "COUNT(*)::FLOAT",
+	}
+)
+
+var _ readers.MessageRepository = (*postgresRepository)(nil)
+
+type postgresRepository struct {
+	db postgres.Database
+}
+
+// New returns new PostgreSQL messages repository.
+func New(db postgres.Database) readers.MessageRepository {
+	return &postgresRepository{db: db}
+}
+
+func (pr postgresRepository) ReadAll(chanID string, rpm readers.PageMetadata) (readers.MessagesPage, error) {
+	ctx := context.Background()
+
+	format := defTable
+	order := "time"
+	if rpm.Format != "" && rpm.Format != defTable {
+		if !formatRegexp.MatchString(rpm.Format) {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, errInvalidFormat)
+		}
+		format = rpm.Format
+		order = "created"
+	}
+
+	params := map[string]interface{}{
+		"channel":      chanID,
+		"limit":        rpm.Limit,
+		"offset":       rpm.Offset,
+		"subtopic":     rpm.Subtopic,
+		"publisher":    rpm.Publisher,
+		"name":         rpm.Name,
+		"protocol":     rpm.Protocol,
+		"value":        rpm.Value,
+		"bool_value":   rpm.BoolValue,
+		"string_value": rpm.StringValue,
+		"data_value":   rpm.DataValue,
+		"from":         rpm.From,
+		"to":           rpm.To,
+	}
+
+	condition := fmtCondition(rpm, order, format == defTable)
+
+	var q, tq string
+	switch {
+	case rpm.Aggregation != "" && format == defTable:
+		interval, err := time.ParseDuration(rpm.Interval)
+		if err != nil || interval <= 0 {
+			return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+		}
+		agg, ok := aggregations[strings.ToUpper(rpm.Aggregation)]
+		if !ok {
+			return readers.MessagesPage{}, readers.ErrReadMessages
+		}
+		params["interval"] = interval.Nanoseconds()
+		aq := fmt.Sprintf(`SELECT FLOOR(time / :interval) * :interval AS time, channel, subtopic, publisher, protocol, name, unit, %s AS value
+			FROM messages WHERE %s
+			GROUP BY 1, channel, subtopic, publisher, protocol, name, unit`, agg, condition)
+		q = fmt.Sprintf(`%s ORDER BY time DESC LIMIT :limit OFFSET :offset;`, aq)
+		tq = fmt.Sprintf(`SELECT COUNT(*) FROM (%s) AS buckets;`, aq)
+	case format == defTable:
+		q = fmt.Sprintf(`SELECT channel, subtopic, publisher, protocol, name, unit, value, string_value, bool_value, data_value, sum, time, update_time
+			FROM messages WHERE %s ORDER BY time DESC LIMIT :limit OFFSET :offset;`, condition)
+		tq = fmt.Sprintf(`SELECT COUNT(*) FROM messages WHERE %s;`, condition)
+	default:
+		q = fmt.Sprintf(`SELECT channel, created, subtopic, publisher, protocol, payload
+			FROM %s WHERE %s ORDER BY created DESC LIMIT :limit OFFSET :offset;`, format, condition)
+		tq = fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s;`, format, condition)
+	}
+
+	rows, err := pr.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == errUndefined {
+				return readers.MessagesPage{}, nil
+			}
+		}
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	defer rows.Close()
+
+	page := readers.MessagesPage{
+		PageMetadata: rpm,
+		Messages:     []readers.Message{},
+	}
+
+	switch format {
+	case defTable:
+		for rows.Next() {
+			msg := senml.Message{}
+			if err := rows.StructScan(&msg); err != nil {
+				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+			}
+			page.Messages = append(page.Messages, msg)
+		}
+	default:
+		for rows.Next() {
+			msg := jsonMessage{}
+			if err := rows.StructScan(&msg); err != nil {
+				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+			}
+			m, err := msg.toMap()
+			if err != nil {
+				return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+			}
+			page.Messages = append(page.Messages, m)
+		}
+	}
+
+	total, err := postgres.Total(ctx, pr.db, tq, params)
+	if err != nil {
+		return readers.MessagesPage{}, errors.Wrap(readers.ErrReadMessages, err)
+	}
+	page.Total = total
+
+	return page, nil
+}
+
+func fmtCondition(rpm readers.PageMetadata, timeColumn string, senmlTable bool) string {
+	condition := `channel = :channel`
+
+	var query map[string]interface{}
+	meta, err := json.Marshal(rpm)
+	if err != nil {
+		return condition
+	}
+	if err := json.Unmarshal(meta, &query); err != nil {
+		return condition
+	}
+
+	for name := range query {
+		switch name {
+		case "subtopic", "publisher", "protocol":
+			condition = fmt.Sprintf(`%s AND %s = :%s`, condition, name, name)
+		case "from":
+			condition = fmt.Sprintf(`%s AND %s >= :from`, condition, timeColumn)
+		case "to":
+			condition = fmt.Sprintf(`%s AND %s < :to`, condition, timeColumn)
+		}
+
+		// Value filters only apply to SenML records.
+		if !senmlTable {
+			continue
+		}
+		switch name {
+		case "name":
+			condition = fmt.Sprintf(`%s AND name = :name`, condition)
+		case "v":
+			comparator := readers.ParseValueComparator(query)
+			condition = fmt.Sprintf(`%s AND value %s :value`, condition, comparator)
+		case "vb":
+			condition = fmt.Sprintf(`%s AND bool_value = :bool_value`, condition)
+		case "vs":
+			comparator := readers.ParseValueComparator(query)
+			switch comparator {
+			case "=":
+				condition = fmt.Sprintf(`%s AND string_value = :string_value`, condition)
+			case ">":
+				condition = fmt.Sprintf(`%s AND string_value LIKE '%%' || :string_value || '%%' AND string_value <> :string_value`, condition)
+			case ">=":
+				condition = fmt.Sprintf(`%s AND string_value LIKE '%%' || :string_value || '%%'`, condition)
+			case "<=":
+				condition = fmt.Sprintf(`%s AND :string_value LIKE '%%' || string_value || '%%'`, condition)
+			case "<":
+				condition = fmt.Sprintf(`%s AND :string_value LIKE '%%' || string_value || '%%' AND string_value <> :string_value`, condition)
+			}
+		case "vd":
+			comparator := readers.ParseValueComparator(query)
+			condition = fmt.Sprintf(`%s AND data_value %s :data_value`, condition, comparator)
+		case "aggregation":
+			if strings.ToUpper(rpm.Aggregation) != "COUNT" {
+				condition = fmt.Sprintf(`%s AND value IS NOT NULL`, condition)
+			}
+		}
+	}
+
+	return condition
+}
+
+type jsonMessage struct {
+	Channel   string `db:"channel"`
+	Created   int64  `db:"created"`
+	Subtopic  string `db:"subtopic"`
+	Publisher string `db:"publisher"`
+	Protocol  string `db:"protocol"`
+	Payload   []byte `db:"payload"`
+}
+
+func (msg jsonMessage) toMap() (map[string]interface{}, error) {
+	ret := map[string]interface{}{
+		"channel":   msg.Channel,
+		"created":   msg.Created,
+		"subtopic":  msg.Subtopic,
+		"publisher": msg.Publisher,
+		"protocol":  msg.Protocol,
+		"payload":   map[string]interface{}{},
+	}
+	pld := smqjson.Payload{}
+	if err := json.Unmarshal(msg.Payload, &pld); err != nil {
+		return nil, err
+	}
+	ret["payload"] = pld
+
+	return ret, nil
+}
@@ -0,0 +1,147 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/rules"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func addRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(addRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		rule := rules.Rule{
+			Name:      req.Name,
+			ChannelID: req.ChannelID,
+			Subtopic:  req.Subtopic,
+			Publisher: req.Publisher,
+			Condition: req.Condition,
+			Actions:   req.Actions,
+		}
+		rule, err := svc.AddRule(ctx, session, rule)
+		if err != nil {
+			return nil, err
+		}
+
+		return ruleRes{Rule: rule, created: true}, nil
+	}
+}
+
+func viewRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		rule, err := svc.ViewRule(ctx, session, req.id)
+		if err != nil {
+			return nil, err
+		}
+
+		return ruleRes{Rule: rule}, nil
+	}
+}
+
+func updateRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateRuleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		rule := rules.Rule{
+			ID:        req.id,
+			Name:      req.Name,
+			ChannelID: req.ChannelID,
+			Subtopic:  req.Subtopic,
+			Publisher: req.Publisher,
+			Condition: req.Condition,
+			Actions:   req.Actions,
+		}
+		rule, err := svc.UpdateRule(ctx, session, rule)
+		if err != nil {
+			return nil, err
+		}
+
+		return ruleRes{Rule: rule}, nil
+	}
+}
+
+func listRulesEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(listRulesReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		page, err := svc.ListRules(ctx, session, req.PageMeta)
+		if err != nil {
+			return nil, err
+		}
+
+		res := rulesPageRes{
+			Offset: page.Offset,
+			Limit:  page.Limit,
+			Total:  page.Total,
+			Rules:  page.Rules,
+		}
+		if res.Rules == nil {
+			res.Rules = []rules.Rule{}
+		}
+
+		return res, nil
+	}
+}
+
+func removeRuleEndpoint(svc rules.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(ruleReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		if err := svc.RemoveRule(ctx, session, req.id); err != nil {
+			return nil, err
+		}
+
+		return removeRuleRes{}, nil
+	}
+}
@@ -0,0 +1,553 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/rules"
+	"github.com/absmach/supermq/rules/api"
+	"github.com/absmach/supermq/rules/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	contentType  = "application/json"
+	validToken   = "valid-token"
+	invalidToken = "invalid-token"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+var (
+	domainID = testsutil.GenerateUUID(&testing.T{})
+	userID   = testsutil.GenerateUUID(&testing.T{})
+	chanID   = testsutil.GenerateUUID(&testing.T{})
+	ruleID   = testsutil.GenerateUUID(&testing.T{})
+	session  = smqauthn.Session{UserID: userID}
+	rule     = rules.Rule{
+		ID:        ruleID,
+		Name:      "rule",
+		DomainID:  domainID,
+		ChannelID: chanID,
+		Condition: &rules.Condition{Name: "temp", Operator: rules.GreaterThanOp, Value: 30},
+		Actions: []rules.Action{
+			{Type: rules.RepublishAction, Channel: testsutil.GenerateUUID(&testing.T{})},
+		},
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+type respBody struct {
+	Err     string       `json:"error"`
+	Message string       `json:"message"`
+	ID      string       `json:"id"`
+	Total   uint64       `json:"total"`
+	Rules   []rules.Rule `json:"rules"`
+}
+
+func newServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	logger := smqlog.NewMock()
+	mux := api.MakeHandler(svc, authn, logger, "test", instanceID)
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func toJSON(data interface{}) string {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+	return string(jsonData)
+}
+
+func decodeBody(t *testing.T, res *http.Response) (respBody, error) {
+	var body respBody
+	if res.StatusCode == http.StatusNoContent {
+		return body, nil
+	}
+	err := json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error while decoding response body: %s", err))
+	if body.Err != "" || body.Message != "" {
+		return body, errors.Wrap(errors.New(body.Err), errors.New(body.Message))
+	}
+
+	return body, nil
+}
+
+func ruleBody(modify func(r *rules.Rule)) string {
+	r := rule
+	r.Actions = append([]rules.Action{}, rule.Actions...)
+	modify(&r)
+
+	return toJSON(map[string]interface{}{
+		"name":       r.Name,
+		"channel_id": r.ChannelID,
+		"condition":  r.Condition,
+		"actions":    r.Actions,
+	})
+}
+
+func TestAddRule(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		contentType string
+		req         string
+		authnErr    error
+		svcErr      error
+		status      int
+		location    string
+		err         error
+	}{
+		{
+			desc:        "add rule successfully",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(*rules.Rule) {}),
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/rules/%s", ruleID),
+		},
+		{
+			desc:        "add rule with invalid token",
+			token:       invalidToken,
+			contentType: contentType,
+			req:         ruleBody(func(*rules.Rule) {}),
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "add rule with empty token",
+			contentType: contentType,
+			req:         ruleBody(func(*rules.Rule) {}),
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "add rule with invalid content type",
+			token:       validToken,
+			contentType: "application/xml",
+			req:         ruleBody(func(*rules.Rule) {}),
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "add rule with invalid request body",
+			token:       validToken,
+			contentType: contentType,
+			req:         "{",
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "add rule with too long name",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(r *rules.Rule) { r.Name = strings.Repeat("a", 1025) }),
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrNameSize,
+		},
+		{
+			desc:        "add rule without channel",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(r *rules.Rule) { r.ChannelID = "" }),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "add rule without actions",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(r *rules.Rule) { r.Actions = nil }),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "add rule with invalid operator",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(r *rules.Rule) { r.Condition = &rules.Condition{Operator: "approx"} }),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "add rule with invalid action type",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(r *rules.Rule) { r.Actions = []rules.Action{{Type: "forward"}} }),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "add rule with republish action without channel",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(r *rules.Rule) { r.Actions = []rules.Action{{Type: rules.RepublishAction}} }),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "add rule with notify action without contacts",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(r *rules.Rule) { r.Actions = []rules.Action{{Type: rules.NotifyAction}} }),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "add rule without channel permission",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(*rules.Rule) {}),
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/rules", ts.URL, domainID),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("AddRule", mock.Anything, mock.Anything, mock.Anything).Return(rule, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
+			if tc.err == nil {
+				assert.Equal(t, ruleID, body.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, ruleID, body.ID))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewRule(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnErr error
+		svcRes   rules.Rule
+		svcErr   error
+		status   int
+		err      error
+	}{
+		{
+			desc:   "view rule successfully",
+			token:  validToken,
+			id:     ruleID,
+			svcRes: rule,
+			status: http.StatusOK,
+		},
+		{
+			desc:     "view rule with invalid token",
+			token:    invalidToken,
+			id:       ruleID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "view non-existing rule",
+			token:  validToken,
+			id:     ruleID,
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/rules/%s", ts.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("ViewRule", mock.Anything, mock.Anything, tc.id).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.err == nil {
+				assert.Equal(t, tc.svcRes.ID, body.ID, fmt.Sprintf("%s: expected id %s got %s", tc.desc, tc.svcRes.ID, body.ID))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		contentType string
+		req         string
+		authnErr    error
+		svcErr      error
+		status      int
+		err         error
+	}{
+		{
+			desc:        "update rule successfully",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(r *rules.Rule) { r.Name = "updated" }),
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update rule with invalid token",
+			token:       invalidToken,
+			contentType: contentType,
+			req:         ruleBody(func(*rules.Rule) {}),
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "update rule with invalid content type",
+			token:       validToken,
+			contentType: "application/xml",
+			req:         ruleBody(func(*rules.Rule) {}),
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "update rule with enrich action without metadata",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(r *rules.Rule) { r.Actions = []rules.Action{{Type: rules.EnrichAction}} }),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "update non-existing rule",
+			token:       validToken,
+			contentType: contentType,
+			req:         ruleBody(func(*rules.Rule) {}),
+			svcErr:      svcerr.ErrNotFound,
+			status:      http.StatusNotFound,
+			err:         svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPut,
+				url:         fmt.Sprintf("%s/%s/rules/%s", ts.URL, domainID, ruleID),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("UpdateRule", mock.Anything, mock.Anything, mock.Anything).Return(rule, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			_, err = decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestListRules(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		query    string
+		pm       rules.PageMeta
+		authnErr error
+		svcRes   rules.Page
+		svcErr   error
+		status   int
+		total    uint64
+		err      error
+	}{
+		{
+			desc:   "list rules successfully",
+			token:  validToken,
+			pm:     rules.PageMeta{Offset: 0, Limit: 10},
+			svcRes: rules.Page{Total: 1, Rules: []rules.Rule{rule}},
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:   "list rules filtered by channel",
+			token:  validToken,
+			query:  "?channel_id=" + chanID,
+			pm:     rules.PageMeta{Offset: 0, Limit: 10, ChannelID: chanID},
+			svcRes: rules.Page{Total: 1, Rules: []rules.Rule{rule}},
+			status: http.StatusOK,
+			total:  1,
+		},
+		{
+			desc:     "list rules with invalid token",
+			token:    invalidToken,
+			pm:       rules.PageMeta{Offset: 0, Limit: 10},
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "list rules with limit exceeding max",
+			token:  validToken,
+			query:  "?limit=101",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrLimitSize,
+		},
+		{
+			desc:   "list rules with invalid offset",
+			token:  validToken,
+			query:  "?offset=invalid",
+			status: http.StatusBadRequest,
+			err:    apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/rules%s", ts.URL, domainID, tc.query),
+				token:  tc.token,
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("ListRules", mock.Anything, mock.Anything, tc.pm).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.total, body.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, body.Total))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnErr error
+		svcErr   error
+		status   int
+		err      error
+	}{
+		{
+			desc:   "remove rule successfully",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:     "remove rule with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "remove non-existing rule",
+			token:  validToken,
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/rules/%s", ts.URL, domainID, ruleID),
+				token:  tc.token,
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("RemoveRule", mock.Anything, mock.Anything, ruleID).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			_, err = decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/rules"
+)
+
+const maxLimitSize = 100
+
+var errMissingActions = errors.New("missing rule actions")
+
+type addRuleReq struct {
+	Name      string           `json:"name,omitempty"`
+	ChannelID string           `json:"channel_id"`
+	Subtopic  string           `json:"subtopic,omitempty"`
+	Publisher string           `json:"publisher,omitempty"`
+	Condition *rules.Condition `json:"condition,omitempty"`
+	Actions   []rules.Action   `json:"actions"`
+}
+
+func (req addRuleReq) validate() error {
+	return validateRule(req.Name, req.ChannelID, req.Condition, req.Actions)
+}
+
+type updateRuleReq struct {
+	id        string
+	Name      string           `json:"name,omitempty"`
+	ChannelID string           `json:"channel_id"`
+	Subtopic  string           `json:"subtopic,omitempty"`
+	Publisher string           `json:"publisher,omitempty"`
+	Condition *rules.Condition `json:"condition,omitempty"`
+	Actions   []rules.Action   `json:"actions"`
+}
+
+func (req updateRuleReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return validateRule(req.Name, req.ChannelID, req.Condition, req.Actions)
+}
+
+type ruleReq struct {
+	id string
+}
+
+func (req ruleReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type listRulesReq struct {
+	rules.PageMeta
+}
+
+func (req listRulesReq) validate() error {
+	if req.Limit > maxLimitSize {
+		return apiutil.ErrLimitSize
+	}
+	if len(req.Name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+
+	return nil
+}
+
+func validateRule(name, chanID string, condition *rules.Condition, actions []rules.Action) error {
+	if len(name) > api.MaxNameSize {
+		return apiutil.ErrNameSize
+	}
+	if chanID == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrMissingChannelID)
+	}
+	if condition != nil {
+		if err := condition.Operator.Validate(); err != nil {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+	if len(actions) == 0 {
+		return errors.Wrap(errors.ErrMalformedEntity, errMissingActions)
+	}
+	for _, action := range actions {
+		if err := action.Validate(); err != nil {
+			return errors.Wrap(errors.ErrMalformedEntity, err)
+		}
+	}
+
+	return nil
+}
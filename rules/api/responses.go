@@ -0,0 +1,78 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/rules"
+)
+
+var (
+	_ supermq.Response = (*ruleRes)(nil)
+	_ supermq.Response = (*rulesPageRes)(nil)
+	_ supermq.Response = (*removeRuleRes)(nil)
+)
+
+type ruleRes struct {
+	rules.Rule
+	created bool
+}
+
+func (res ruleRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res ruleRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/rules/%s", res.ID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res ruleRes) Empty() bool {
+	return false
+}
+
+type rulesPageRes struct {
+	Offset uint64       `json:"offset"`
+	Limit  uint64       `json:"limit"`
+	Total  uint64       `json:"total"`
+	Rules  []rules.Rule `json:"rules"`
+}
+
+func (res rulesPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res rulesPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res rulesPageRes) Empty() bool {
+	return false
+}
+
+type removeRuleRes struct{}
+
+func (res removeRuleRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeRuleRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeRuleRes) Empty() bool {
+	return true
+}
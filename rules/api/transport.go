@@ -0,0 +1,147 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/supermq"
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/rules"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	ruleIDKey  = "ruleID"
+	channelKey = "channel_id"
+)
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc rules.Service, authn smqauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/{domainID}/rules", func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			addRuleEndpoint(svc),
+			decodeAddRule,
+			api.EncodeResponse,
+			opts...,
+		), "add_rule").ServeHTTP)
+
+		r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+			listRulesEndpoint(svc),
+			decodeListRules,
+			api.EncodeResponse,
+			opts...,
+		), "list_rules").ServeHTTP)
+
+		r.Get("/{ruleID}", otelhttp.NewHandler(kithttp.NewServer(
+			viewRuleEndpoint(svc),
+			decodeRule,
+			api.EncodeResponse,
+			opts...,
+		), "view_rule").ServeHTTP)
+
+		r.Put("/{ruleID}", otelhttp.NewHandler(kithttp.NewServer(
+			updateRuleEndpoint(svc),
+			decodeUpdateRule,
+			api.EncodeResponse,
+			opts...,
+		), "update_rule").ServeHTTP)
+
+		r.Delete("/{ruleID}", otelhttp.NewHandler(kithttp.NewServer(
+			removeRuleEndpoint(svc),
+			decodeRule,
+			api.EncodeResponse,
+			opts...,
+		), "remove_rule").ServeHTTP)
+	})
+
+	mux.Get("/health", supermq.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeAddRule(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req addRuleReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeUpdateRule(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateRuleReq{
+		id: chi.URLParam(r, ruleIDKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeRule(_ context.Context, r *http.Request) (interface{}, error) {
+	req := ruleReq{
+		id: chi.URLParam(r, ruleIDKey),
+	}
+
+	return req, nil
+}
+
+func decodeListRules(_ context.Context, r *http.Request) (interface{}, error) {
+	offset, err := apiutil.ReadNumQuery[uint64](r, api.OffsetKey, api.DefOffset)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	limit, err := apiutil.ReadNumQuery[uint64](r, api.LimitKey, api.DefLimit)
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	name, err := apiutil.ReadStringQuery(r, api.NameKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+	chanID, err := apiutil.ReadStringQuery(r, channelKey, "")
+	if err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	req := listRulesReq{
+		PageMeta: rules.PageMeta{
+			Offset:    offset,
+			Limit:     limit,
+			Name:      name,
+			ChannelID: chanID,
+		},
+	}
+
+	return req, nil
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	chanID    string
+	rules     []Rule
+	expiresAt time.Time
+}
+
+// Cache holds the rules of the channels evaluated by the service instance.
+// It keeps the rules of at most maxEntries channels, evicting the least
+// recently used ones, and the rules expire after the ttl duration.
+type Cache struct {
+	maxEntries int
+	ttl        time.Duration
+	mu         sync.Mutex
+	version    uint64
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+// NewCache returns the cache of the channel rules. Rules are not cached if
+// maxEntries or ttl is not positive.
+func NewCache(maxEntries int, ttl time.Duration) *Cache {
+	return &Cache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Invalidate removes the rules of the channels and of the channels which
+// have the rule with the given ID from the cache. It's called whenever the
+// rule is changed, which covers the channel the rule is moved from.
+func (c *Cache) Invalidate(ruleID string, chanIDs ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	for _, id := range chanIDs {
+		if el, ok := c.entries[id]; ok {
+			c.remove(el)
+		}
+	}
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		for _, rule := range el.Value.(*cacheEntry).rules {
+			if rule.ID == ruleID {
+				c.remove(el)
+				break
+			}
+		}
+		el = next
+	}
+}
+
+// get returns the cached rules of the channel and the cache version, which
+// is passed to set once the missing rules are retrieved.
+func (c *Cache) get(chanID string) ([]Rule, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[chanID]
+	if !ok {
+		return nil, c.version, false
+	}
+	e := el.Value.(*cacheEntry)
+	if time.Now().After(e.expiresAt) {
+		c.remove(el)
+		return nil, c.version, false
+	}
+	c.order.MoveToFront(el)
+
+	return e.rules, c.version, true
+}
+
+// set caches the rules of the channel, unless the cache has been invalidated
+// since the version was returned by get, in which case the rules may be stale.
+func (c *Cache) set(chanID string, rules []Rule, version uint64) {
+	if c.maxEntries <= 0 || c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.version != version {
+		return
+	}
+	e := &cacheEntry{
+		chanID:    chanID,
+		rules:     rules,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	if el, ok := c.entries[chanID]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[chanID] = c.order.PushFront(e)
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*cacheEntry).chanID)
+}
@@ -0,0 +1,10 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rules contains the rules engine service. The service subscribes
+// to channel messages and evaluates the rules defined in the message's
+// domain. Rules match messages by channel, subtopic and publisher, and
+// optionally by a condition on the SenML record name and value. Matched
+// messages trigger the rule actions: republishing to another channel,
+// dropping the message, enriching it with metadata or sending notifications.
+package rules
@@ -0,0 +1,70 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/rules"
+)
+
+var errNoOperationKey = errors.New("operation key is not found in event message")
+
+type cacheHandler struct {
+	cache *rules.Cache
+}
+
+// CacheEventsSubscribe subscribes to the rules events and invalidates the
+// cached rules changed by any service instance. Every instance has to
+// receive all the events, so the consumer name must be unique per instance.
+func CacheEventsSubscribe(ctx context.Context, cache *rules.Cache, esURL, esConsumerName string, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, esURL, logger)
+	if err != nil {
+		return err
+	}
+
+	subConfig := events.SubscriberConfig{
+		Stream:         "events." + streamID,
+		Consumer:       esConsumerName,
+		Handler:        NewCacheHandler(cache),
+		DeliveryPolicy: messaging.DeliverNewPolicy,
+	}
+
+	return subscriber.Subscribe(ctx, subConfig)
+}
+
+// NewCacheHandler returns the handler of the rules events which invalidates
+// the cached rules of the changed rule channels.
+func NewCacheHandler(cache *rules.Cache) events.EventHandler {
+	return &cacheHandler{
+		cache: cache,
+	}
+}
+
+func (ch *cacheHandler) Handle(_ context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	op, ok := msg["operation"].(string)
+	if !ok {
+		return errNoOperationKey
+	}
+	if !strings.HasPrefix(op, rulePrefix) {
+		return nil
+	}
+
+	// The removal event carries only the rule ID, so the channels of the
+	// rule are found in the cache.
+	ch.cache.Invalidate(events.Read(msg, "id", ""), events.Read(msg, "channel_id", ""))
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/rules"
+)
+
+const (
+	rulePrefix = "rules."
+	ruleCreate = rulePrefix + "create"
+	ruleUpdate = rulePrefix + "update"
+	ruleRemove = rulePrefix + "remove"
+)
+
+var (
+	_ events.Event = (*createRuleEvent)(nil)
+	_ events.Event = (*updateRuleEvent)(nil)
+	_ events.Event = (*removeRuleEvent)(nil)
+)
+
+type createRuleEvent struct {
+	rules.Rule
+	authn.Session
+}
+
+func (cre createRuleEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation":   ruleCreate,
+		"id":          cre.ID,
+		"channel_id":  cre.ChannelID,
+		"actions":     cre.Actions,
+		"created_at":  cre.CreatedAt,
+		"created_by":  cre.CreatedBy,
+		"domain":      cre.Rule.DomainID,
+		"user_id":     cre.UserID,
+		"token_type":  cre.Type.String(),
+		"super_admin": cre.SuperAdmin,
+	}
+	encodeRuleFields(val, cre.Rule)
+
+	return val, nil
+}
+
+type updateRuleEvent struct {
+	rules.Rule
+	authn.Session
+}
+
+func (ure updateRuleEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation":   ruleUpdate,
+		"id":          ure.ID,
+		"channel_id":  ure.ChannelID,
+		"actions":     ure.Actions,
+		"updated_at":  ure.UpdatedAt,
+		"updated_by":  ure.UpdatedBy,
+		"domain":      ure.Rule.DomainID,
+		"user_id":     ure.UserID,
+		"token_type":  ure.Type.String(),
+		"super_admin": ure.SuperAdmin,
+	}
+	encodeRuleFields(val, ure.Rule)
+
+	return val, nil
+}
+
+type removeRuleEvent struct {
+	id string
+	authn.Session
+}
+
+func (rre removeRuleEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   ruleRemove,
+		"id":          rre.id,
+		"domain":      rre.DomainID,
+		"user_id":     rre.UserID,
+		"token_type":  rre.Type.String(),
+		"super_admin": rre.SuperAdmin,
+	}, nil
+}
+
+func encodeRuleFields(val map[string]interface{}, rule rules.Rule) {
+	if rule.Name != "" {
+		val["name"] = rule.Name
+	}
+	if rule.Subtopic != "" {
+		val["subtopic"] = rule.Subtopic
+	}
+	if rule.Publisher != "" {
+		val["publisher"] = rule.Publisher
+	}
+	if rule.Condition != nil {
+		val["condition"] = rule.Condition
+	}
+}
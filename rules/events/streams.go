@@ -0,0 +1,95 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/rules"
+)
+
+const streamID = "supermq.rules"
+
+var _ rules.Service = (*eventStore)(nil)
+
+type eventStore struct {
+	events.Publisher
+	svc rules.Service
+}
+
+// NewEventStoreMiddleware returns wrapper around rules service that sends
+// events to event store.
+func NewEventStoreMiddleware(ctx context.Context, svc rules.Service, url string) (rules.Service, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &eventStore{
+		svc:       svc,
+		Publisher: publisher,
+	}, nil
+}
+
+func (es *eventStore) AddRule(ctx context.Context, session authn.Session, rule rules.Rule) (rules.Rule, error) {
+	rule, err := es.svc.AddRule(ctx, session, rule)
+	if err != nil {
+		return rule, err
+	}
+
+	event := createRuleEvent{
+		Rule:    rule,
+		Session: session,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return rule, err
+	}
+
+	return rule, nil
+}
+
+func (es *eventStore) ViewRule(ctx context.Context, session authn.Session, id string) (rules.Rule, error) {
+	return es.svc.ViewRule(ctx, session, id)
+}
+
+func (es *eventStore) UpdateRule(ctx context.Context, session authn.Session, rule rules.Rule) (rules.Rule, error) {
+	rule, err := es.svc.UpdateRule(ctx, session, rule)
+	if err != nil {
+		return rule, err
+	}
+
+	event := updateRuleEvent{
+		Rule:    rule,
+		Session: session,
+	}
+	if err := es.Publish(ctx, event); err != nil {
+		return rule, err
+	}
+
+	return rule, nil
+}
+
+func (es *eventStore) ListRules(ctx context.Context, session authn.Session, pm rules.PageMeta) (rules.Page, error) {
+	return es.svc.ListRules(ctx, session, pm)
+}
+
+func (es *eventStore) RemoveRule(ctx context.Context, session authn.Session, id string) error {
+	if err := es.svc.RemoveRule(ctx, session, id); err != nil {
+		return err
+	}
+
+	event := removeRuleEvent{
+		id:      id,
+		Session: session,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	return es.svc.ConsumeBlocking(ctx, messages)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/rules"
+)
+
+const (
+	updatePermission    = "update_permission"
+	readPermission      = "read_permission"
+	publishPermission   = "publish_permission"
+	subscribePermission = "subscribe_permission"
+)
+
+var (
+	errDomainManageRules = errors.New("not authorized to manage rules in domain")
+	errDomainViewRules   = errors.New("not authorized to view rules in domain")
+	errChannelSubscribe  = errors.New("not authorized to subscribe to rule channel")
+	errChannelPublish    = errors.New("not authorized to publish to republish channel")
+)
+
+var _ rules.Service = (*authorizationMiddleware)(nil)
+
+type authorizationMiddleware struct {
+	svc   rules.Service
+	authz smqauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the rules service.
+func AuthorizationMiddleware(svc rules.Service, authz smqauthz.Authorization) rules.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) AddRule(ctx context.Context, session smqauthn.Session, rule rules.Rule) (rules.Rule, error) {
+	if err := am.authorizeRule(ctx, session, rule); err != nil {
+		return rules.Rule{}, err
+	}
+
+	return am.svc.AddRule(ctx, session, rule)
+}
+
+func (am *authorizationMiddleware) ViewRule(ctx context.Context, session smqauthn.Session, id string) (rules.Rule, error) {
+	if err := am.authorizeDomain(ctx, session, readPermission); err != nil {
+		return rules.Rule{}, errors.Wrap(err, errDomainViewRules)
+	}
+
+	return am.svc.ViewRule(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) UpdateRule(ctx context.Context, session smqauthn.Session, rule rules.Rule) (rules.Rule, error) {
+	if err := am.authorizeRule(ctx, session, rule); err != nil {
+		return rules.Rule{}, err
+	}
+
+	return am.svc.UpdateRule(ctx, session, rule)
+}
+
+func (am *authorizationMiddleware) ListRules(ctx context.Context, session smqauthn.Session, pm rules.PageMeta) (rules.Page, error) {
+	if err := am.authorizeDomain(ctx, session, readPermission); err != nil {
+		return rules.Page{}, errors.Wrap(err, errDomainViewRules)
+	}
+
+	return am.svc.ListRules(ctx, session, pm)
+}
+
+func (am *authorizationMiddleware) RemoveRule(ctx context.Context, session smqauthn.Session, id string) error {
+	if err := am.authorizeDomain(ctx, session, updatePermission); err != nil {
+		return errors.Wrap(err, errDomainManageRules)
+	}
+
+	return am.svc.RemoveRule(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) ConsumeBlocking(ctx context.Context, messages interface{}) error {
+	return am.svc.ConsumeBlocking(ctx, messages)
+}
+
+// authorizeRule checks whether the user can manage rules in the domain,
+// read the messages of the rule channel and publish to the channels the
+// rule republishes messages to.
+func (am *authorizationMiddleware) authorizeRule(ctx context.Context, session smqauthn.Session, rule rules.Rule) error {
+	if err := am.authorizeDomain(ctx, session, updatePermission); err != nil {
+		return errors.Wrap(err, errDomainManageRules)
+	}
+	if err := am.authorizeChannel(ctx, session, rule.ChannelID, subscribePermission); err != nil {
+		return errors.Wrap(err, errChannelSubscribe)
+	}
+	for _, action := range rule.Actions {
+		if action.Type != rules.RepublishAction {
+			continue
+		}
+		if err := am.authorizeChannel(ctx, session, action.Channel, publishPermission); err != nil {
+			return errors.Wrap(err, errChannelPublish)
+		}
+	}
+
+	return nil
+}
+
+func (am *authorizationMiddleware) authorizeDomain(ctx context.Context, session smqauthn.Session, permission string) error {
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  permission,
+		ObjectType:  policies.DomainType,
+		Object:      session.DomainID,
+	})
+}
+
+func (am *authorizationMiddleware) authorizeChannel(ctx context.Context, session smqauthn.Session, chanID, permission string) error {
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  permission,
+		ObjectType:  policies.ChannelType,
+		Object:      chanID,
+	})
+}
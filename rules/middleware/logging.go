@@ -0,0 +1,147 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/rules"
+)
+
+var _ rules.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    rules.Service
+}
+
+// LoggingMiddleware adds logging facilities to the rules service.
+func LoggingMiddleware(svc rules.Service, logger *slog.Logger) rules.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) AddRule(ctx context.Context, session smqauthn.Session, rule rules.Rule) (r rules.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("rule",
+				slog.String("id", r.ID),
+				slog.String("name", rule.Name),
+				slog.String("channel_id", rule.ChannelID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Add rule failed", args...)
+			return
+		}
+		lm.logger.Info("Add rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.AddRule(ctx, session, rule)
+}
+
+func (lm *loggingMiddleware) ViewRule(ctx context.Context, session smqauthn.Session, id string) (r rules.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View rule failed", args...)
+			return
+		}
+		lm.logger.Info("View rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewRule(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) UpdateRule(ctx context.Context, session smqauthn.Session, rule rules.Rule) (r rules.Rule, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("rule",
+				slog.String("id", rule.ID),
+				slog.String("name", rule.Name),
+				slog.String("channel_id", rule.ChannelID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update rule failed", args...)
+			return
+		}
+		lm.logger.Info("Update rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateRule(ctx, session, rule)
+}
+
+func (lm *loggingMiddleware) ListRules(ctx context.Context, session smqauthn.Session, pm rules.PageMeta) (page rules.Page, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("page",
+				slog.String("channel_id", pm.ChannelID),
+				slog.Uint64("offset", pm.Offset),
+				slog.Uint64("limit", pm.Limit),
+				slog.Uint64("total", page.Total),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List rules failed", args...)
+			return
+		}
+		lm.logger.Info("List rules completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ListRules(ctx, session, pm)
+}
+
+func (lm *loggingMiddleware) RemoveRule(ctx context.Context, session smqauthn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("rule_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove rule failed", args...)
+			return
+		}
+		lm.logger.Info("Remove rule completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveRule(ctx, session, id)
+}
+
+func (lm *loggingMiddleware) ConsumeBlocking(ctx context.Context, msg interface{}) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Blocking consumer failed to consume messages successfully", args...)
+			return
+		}
+		lm.logger.Info("Blocking consumer consumed messages successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ConsumeBlocking(ctx, msg)
+}
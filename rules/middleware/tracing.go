@@ -0,0 +1,81 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/rules"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ rules.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    rules.Service
+}
+
+// Tracing returns a new rules service with tracing capabilities.
+func Tracing(svc rules.Service, tracer trace.Tracer) rules.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) AddRule(ctx context.Context, session smqauthn.Session, rule rules.Rule) (rules.Rule, error) {
+	ctx, span := tm.tracer.Start(ctx, "add_rule", trace.WithAttributes(
+		attribute.String("name", rule.Name),
+		attribute.String("channel_id", rule.ChannelID),
+	))
+	defer span.End()
+
+	return tm.svc.AddRule(ctx, session, rule)
+}
+
+func (tm *tracing) ViewRule(ctx context.Context, session smqauthn.Session, id string) (rules.Rule, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_rule", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.ViewRule(ctx, session, id)
+}
+
+func (tm *tracing) UpdateRule(ctx context.Context, session smqauthn.Session, rule rules.Rule) (rules.Rule, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_rule", trace.WithAttributes(
+		attribute.String("id", rule.ID),
+		attribute.String("channel_id", rule.ChannelID),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateRule(ctx, session, rule)
+}
+
+func (tm *tracing) ListRules(ctx context.Context, session smqauthn.Session, pm rules.PageMeta) (rules.Page, error) {
+	ctx, span := tm.tracer.Start(ctx, "list_rules", trace.WithAttributes(
+		attribute.String("channel_id", pm.ChannelID),
+		attribute.Int64("offset", int64(pm.Offset)),
+		attribute.Int64("limit", int64(pm.Limit)),
+	))
+	defer span.End()
+
+	return tm.svc.ListRules(ctx, session, pm)
+}
+
+func (tm *tracing) RemoveRule(ctx context.Context, session smqauthn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_rule", trace.WithAttributes(
+		attribute.String("id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveRule(ctx, session, id)
+}
+
+func (tm *tracing) ConsumeBlocking(ctx context.Context, msg interface{}) error {
+	ctx, span := tm.tracer.Start(ctx, "consume_blocking")
+	defer span.End()
+
+	return tm.svc.ConsumeBlocking(ctx, msg)
+}
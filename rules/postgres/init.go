@@ -0,0 +1,41 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of rules engine.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "rules_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS rules (
+						id             VARCHAR(36) PRIMARY KEY,
+						name           VARCHAR(1024) NOT NULL DEFAULT '',
+						domain_id      VARCHAR(36) NOT NULL,
+						channel_id     VARCHAR(36) NOT NULL,
+						subtopic       TEXT NOT NULL DEFAULT '',
+						publisher      VARCHAR(36) NOT NULL DEFAULT '',
+						rule_condition JSONB,
+						actions        JSONB NOT NULL,
+						created_at     TIMESTAMP NOT NULL,
+						created_by     VARCHAR(254) NOT NULL,
+						updated_at     TIMESTAMP,
+						updated_by     VARCHAR(254)
+					)`,
+					`CREATE INDEX idx_rules_domain_id ON rules(domain_id);`,
+					`CREATE INDEX idx_rules_channel_id ON rules(channel_id);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS rules`,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/rules"
+)
+
+const ruleColumns = `id, name, domain_id, channel_id, subtopic, publisher, rule_condition, actions,
+	created_at, created_by, updated_at, updated_by`
+
+var _ rules.Repository = (*rulesRepo)(nil)
+
+type rulesRepo struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of rules repository.
+func NewRepository(db postgres.Database) rules.Repository {
+	return &rulesRepo{db: db}
+}
+
+func (repo *rulesRepo) Save(ctx context.Context, rule rules.Rule) (rules.Rule, error) {
+	q := fmt.Sprintf(`INSERT INTO rules (%s)
+		VALUES (:id, :name, :domain_id, :channel_id, :subtopic, :publisher, :rule_condition, :actions,
+		:created_at, :created_by, :updated_at, :updated_by)
+		RETURNING %s`, ruleColumns, ruleColumns)
+
+	dbr, err := toDBRule(rule)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	row, err := repo.db.NamedQueryContext(ctx, q, dbr)
+	if err != nil {
+		return rules.Rule{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer row.Close()
+
+	return scanRule(row, repoerr.ErrCreateEntity)
+}
+
+func (repo *rulesRepo) Retrieve(ctx context.Context, id string) (rules.Rule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM rules WHERE id = $1`, ruleColumns)
+
+	var dbr dbRule
+	if err := repo.db.QueryRowxContext(ctx, q, id).StructScan(&dbr); err != nil {
+		if err == sql.ErrNoRows {
+			return rules.Rule{}, repoerr.ErrNotFound
+		}
+		return rules.Rule{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	rule, err := fromDBRule(dbr)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	return rule, nil
+}
+
+func (repo *rulesRepo) Update(ctx context.Context, rule rules.Rule) (rules.Rule, error) {
+	q := fmt.Sprintf(`UPDATE rules SET name = :name, channel_id = :channel_id, subtopic = :subtopic,
+		publisher = :publisher, rule_condition = :rule_condition, actions = :actions,
+		updated_at = :updated_at, updated_by = :updated_by
+		WHERE id = :id
+		RETURNING %s`, ruleColumns)
+
+	dbr, err := toDBRule(rule)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+
+	row, err := repo.db.NamedQueryContext(ctx, q, dbr)
+	if err != nil {
+		return rules.Rule{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	return scanRule(row, repoerr.ErrUpdateEntity)
+}
+
+func (repo *rulesRepo) RetrieveAll(ctx context.Context, pm rules.PageMeta) (rules.Page, error) {
+	query := pageQuery(pm)
+
+	q := fmt.Sprintf(`SELECT %s FROM rules %s ORDER BY created_at LIMIT :limit OFFSET :offset`, ruleColumns, query)
+
+	rows, err := repo.db.NamedQueryContext(ctx, q, pm)
+	if err != nil {
+		return rules.Page{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	rs := []rules.Rule{}
+	for rows.Next() {
+		var dbr dbRule
+		if err := rows.StructScan(&dbr); err != nil {
+			return rules.Page{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		rule, err := fromDBRule(dbr)
+		if err != nil {
+			return rules.Page{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		rs = append(rs, rule)
+	}
+
+	cq := fmt.Sprintf(`SELECT COUNT(*) FROM rules %s`, query)
+	total, err := postgres.Total(ctx, repo.db, cq, pm)
+	if err != nil {
+		return rules.Page{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return rules.Page{
+		PageMeta: pm,
+		Total:    total,
+		Rules:    rs,
+	}, nil
+}
+
+func (repo *rulesRepo) RetrieveByChannel(ctx context.Context, chanID string) ([]rules.Rule, error) {
+	q := fmt.Sprintf(`SELECT %s FROM rules WHERE channel_id = $1 ORDER BY created_at`, ruleColumns)
+
+	rows, err := repo.db.QueryxContext(ctx, q, chanID)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var rs []rules.Rule
+	for rows.Next() {
+		var dbr dbRule
+		if err := rows.StructScan(&dbr); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		rule, err := fromDBRule(dbr)
+		if err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		rs = append(rs, rule)
+	}
+
+	return rs, nil
+}
+
+func (repo *rulesRepo) Remove(ctx context.Context, id string) error {
+	q := `DELETE FROM rules WHERE id = $1`
+
+	result, err := repo.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Next() bool
+	StructScan(dest interface{}) error
+}
+
+func scanRule(row rowScanner, wrapErr error) (rules.Rule, error) {
+	if !row.Next() {
+		return rules.Rule{}, repoerr.ErrNotFound
+	}
+
+	var dbr dbRule
+	if err := row.StructScan(&dbr); err != nil {
+		return rules.Rule{}, errors.Wrap(wrapErr, err)
+	}
+
+	rule, err := fromDBRule(dbr)
+	if err != nil {
+		return rules.Rule{}, errors.Wrap(wrapErr, err)
+	}
+
+	return rule, nil
+}
+
+func pageQuery(pm rules.PageMeta) string {
+	var query []string
+	if pm.DomainID != "" {
+		query = append(query, "domain_id = :domain_id")
+	}
+	if pm.ChannelID != "" {
+		query = append(query, "channel_id = :channel_id")
+	}
+	if pm.Name != "" {
+		query = append(query, "name ILIKE '%' || :name || '%'")
+	}
+	if len(query) > 0 {
+		return fmt.Sprintf("WHERE %s", strings.Join(query, " AND "))
+	}
+
+	return ""
+}
+
+type dbRule struct {
+	ID        string         `db:"id"`
+	Name      string         `db:"name"`
+	DomainID  string         `db:"domain_id"`
+	ChannelID string         `db:"channel_id"`
+	Subtopic  string         `db:"subtopic"`
+	Publisher string         `db:"publisher"`
+	Condition []byte         `db:"rule_condition"`
+	Actions   []byte         `db:"actions"`
+	CreatedAt time.Time      `db:"created_at"`
+	CreatedBy string         `db:"created_by"`
+	UpdatedAt sql.NullTime   `db:"updated_at"`
+	UpdatedBy sql.NullString `db:"updated_by"`
+}
+
+func toDBRule(rule rules.Rule) (dbRule, error) {
+	var condition []byte
+	if rule.Condition != nil {
+		c, err := json.Marshal(rule.Condition)
+		if err != nil {
+			return dbRule{}, err
+		}
+		condition = c
+	}
+
+	actions, err := json.Marshal(rule.Actions)
+	if err != nil {
+		return dbRule{}, err
+	}
+
+	var updatedAt sql.NullTime
+	if !rule.UpdatedAt.IsZero() {
+		updatedAt = sql.NullTime{Time: rule.UpdatedAt, Valid: true}
+	}
+	var updatedBy sql.NullString
+	if rule.UpdatedBy != "" {
+		updatedBy = sql.NullString{String: rule.UpdatedBy, Valid: true}
+	}
+
+	return dbRule{
+		ID:        rule.ID,
+		Name:      rule.Name,
+		DomainID:  rule.DomainID,
+		ChannelID: rule.ChannelID,
+		Subtopic:  rule.Subtopic,
+		Publisher: rule.Publisher,
+		Condition: condition,
+		Actions:   actions,
+		CreatedAt: rule.CreatedAt,
+		CreatedBy: rule.CreatedBy,
+		UpdatedAt: updatedAt,
+		UpdatedBy: updatedBy,
+	}, nil
+}
+
+func fromDBRule(dbr dbRule) (rules.Rule, error) {
+	var condition *rules.Condition
+	if len(dbr.Condition) > 0 {
+		condition = &rules.Condition{}
+		if err := json.Unmarshal(dbr.Condition, condition); err != nil {
+			return rules.Rule{}, err
+		}
+	}
+
+	var actions []rules.Action
+	if err := json.Unmarshal(dbr.Actions, &actions); err != nil {
+		return rules.Rule{}, err
+	}
+
+	rule := rules.Rule{
+		ID:        dbr.ID,
+		Name:      dbr.Name,
+		DomainID:  dbr.DomainID,
+		ChannelID: dbr.ChannelID,
+		Subtopic:  dbr.Subtopic,
+		Publisher: dbr.Publisher,
+		Condition: condition,
+		Actions:   actions,
+		CreatedAt: dbr.CreatedAt,
+		CreatedBy: dbr.CreatedBy,
+	}
+	if dbr.UpdatedAt.Valid {
+		rule.UpdatedAt = dbr.UpdatedAt.Time
+	}
+	if dbr.UpdatedBy.Valid {
+		rule.UpdatedBy = dbr.UpdatedBy.String
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,301 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/rules"
+	rulespg "github.com/absmach/supermq/rules/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const numRules = 10
+
+func newRule(t *testing.T, domainID, chanID string) rules.Rule {
+	return rules.Rule{
+		ID:        testsutil.GenerateUUID(t),
+		Name:      "rule",
+		DomainID:  domainID,
+		ChannelID: chanID,
+		Subtopic:  "sensors",
+		Condition: &rules.Condition{Name: "temp", Operator: rules.GreaterThanOp, Value: 30},
+		Actions: []rules.Action{
+			{Type: rules.RepublishAction, Channel: testsutil.GenerateUUID(t)},
+			{Type: rules.NotifyAction, Contacts: []string{"user@example.com"}},
+		},
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		CreatedBy: testsutil.GenerateUUID(t),
+	}
+}
+
+func cleanup(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM rules")
+		require.Nil(t, err, fmt.Sprintf("clean rules unexpected error: %s", err))
+	})
+}
+
+func TestSave(t *testing.T) {
+	cleanup(t)
+	repo := rulespg.NewRepository(database)
+
+	rule := newRule(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t))
+	noCondition := newRule(t, rule.DomainID, rule.ChannelID)
+	noCondition.Condition = nil
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "save rule successfully",
+			rule: rule,
+		},
+		{
+			desc: "save rule without condition",
+			rule: noCondition,
+		},
+		{
+			desc: "save duplicate rule",
+			rule: rule,
+			err:  repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.rule)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.rule, saved, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.rule, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieve(t *testing.T) {
+	cleanup(t)
+	repo := rulespg.NewRepository(database)
+
+	rule, err := repo.Save(context.Background(), newRule(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "retrieve existing rule",
+			id:   rule.ID,
+			rule: rule,
+		},
+		{
+			desc: "retrieve non-existing rule",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := repo.Retrieve(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.rule, r, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.rule, r))
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cleanup(t)
+	repo := rulespg.NewRepository(database)
+
+	rule, err := repo.Save(context.Background(), newRule(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+
+	updated := rule
+	updated.Name = "updated"
+	updated.Condition = nil
+	updated.Actions = []rules.Action{{Type: rules.DropAction}}
+	updated.UpdatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	updated.UpdatedBy = testsutil.GenerateUUID(t)
+
+	missing := updated
+	missing.ID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc string
+		rule rules.Rule
+		err  error
+	}{
+		{
+			desc: "update existing rule",
+			rule: updated,
+		},
+		{
+			desc: "update non-existing rule",
+			rule: missing,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r, err := repo.Update(context.Background(), tc.rule)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.rule, r, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.rule, r))
+			}
+		})
+	}
+}
+
+func TestRetrieveAll(t *testing.T) {
+	cleanup(t)
+	repo := rulespg.NewRepository(database)
+
+	domainID := testsutil.GenerateUUID(t)
+	chanID := testsutil.GenerateUUID(t)
+	var saved []rules.Rule
+	for i := 0; i < numRules; i++ {
+		rule := newRule(t, domainID, chanID)
+		rule.Name = fmt.Sprintf("rule-%d", i)
+		rule.CreatedAt = rule.CreatedAt.Add(time.Duration(i) * time.Second)
+		if i%2 == 1 {
+			rule.ChannelID = testsutil.GenerateUUID(t)
+		}
+		r, err := repo.Save(context.Background(), rule)
+		require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+		saved = append(saved, r)
+	}
+	_, err := repo.Save(context.Background(), newRule(t, testsutil.GenerateUUID(t), chanID))
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+
+	cases := []struct {
+		desc  string
+		pm    rules.PageMeta
+		total uint64
+		rules []rules.Rule
+	}{
+		{
+			desc:  "retrieve all domain rules",
+			pm:    rules.PageMeta{Offset: 0, Limit: numRules, DomainID: domainID},
+			total: numRules,
+			rules: saved,
+		},
+		{
+			desc:  "retrieve domain rules with offset and limit",
+			pm:    rules.PageMeta{Offset: 2, Limit: 3, DomainID: domainID},
+			total: numRules,
+			rules: saved[2:5],
+		},
+		{
+			desc:  "retrieve domain rules by channel",
+			pm:    rules.PageMeta{Offset: 0, Limit: numRules, DomainID: domainID, ChannelID: chanID},
+			total: numRules / 2,
+			rules: []rules.Rule{saved[0], saved[2], saved[4], saved[6], saved[8]},
+		},
+		{
+			desc:  "retrieve domain rules by name",
+			pm:    rules.PageMeta{Offset: 0, Limit: numRules, DomainID: domainID, Name: "rule-3"},
+			total: 1,
+			rules: []rules.Rule{saved[3]},
+		},
+		{
+			desc:  "retrieve rules of domain without rules",
+			pm:    rules.PageMeta{Offset: 0, Limit: numRules, DomainID: testsutil.GenerateUUID(t)},
+			total: 0,
+			rules: []rules.Rule{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			page, err := repo.RetrieveAll(context.Background(), tc.pm)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.total, page.Total, fmt.Sprintf("%s: expected total %d got %d", tc.desc, tc.total, page.Total))
+			assert.Equal(t, tc.rules, page.Rules, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.rules, page.Rules))
+		})
+	}
+}
+
+func TestRetrieveByChannel(t *testing.T) {
+	cleanup(t)
+	repo := rulespg.NewRepository(database)
+
+	chanID := testsutil.GenerateUUID(t)
+	var saved []rules.Rule
+	for i := 0; i < numRules; i++ {
+		rule := newRule(t, testsutil.GenerateUUID(t), chanID)
+		rule.CreatedAt = rule.CreatedAt.Add(time.Duration(i) * time.Second)
+		r, err := repo.Save(context.Background(), rule)
+		require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+		saved = append(saved, r)
+	}
+
+	cases := []struct {
+		desc   string
+		chanID string
+		rules  []rules.Rule
+	}{
+		{
+			desc:   "retrieve rules by channel",
+			chanID: chanID,
+			rules:  saved,
+		},
+		{
+			desc:   "retrieve rules by channel without rules",
+			chanID: testsutil.GenerateUUID(t),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			rs, err := repo.RetrieveByChannel(context.Background(), tc.chanID)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.rules, rs, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.rules, rs))
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	cleanup(t)
+	repo := rulespg.NewRepository(database)
+
+	rule, err := repo.Save(context.Background(), newRule(t, testsutil.GenerateUUID(t), testsutil.GenerateUUID(t)))
+	require.Nil(t, err, fmt.Sprintf("save rule unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove existing rule",
+			id:   rule.ID,
+		},
+		{
+			desc: "remove non-existing rule",
+			id:   rule.ID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
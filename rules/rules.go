@@ -0,0 +1,207 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"time"
+
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var (
+	// ErrInvalidOperator indicates an unsupported condition operator.
+	ErrInvalidOperator = errors.New("invalid condition operator")
+
+	// ErrInvalidAction indicates an unsupported or malformed rule action.
+	ErrInvalidAction = errors.New("invalid rule action")
+)
+
+// Operator represents a condition comparison operator.
+type Operator string
+
+// Supported condition operators.
+const (
+	EqualOp          Operator = "eq"
+	NotEqualOp       Operator = "ne"
+	LessThanOp       Operator = "lt"
+	LessOrEqualOp    Operator = "le"
+	GreaterThanOp    Operator = "gt"
+	GreaterOrEqualOp Operator = "ge"
+)
+
+// Validate checks whether the operator is supported.
+func (op Operator) Validate() error {
+	switch op {
+	case EqualOp, NotEqualOp, LessThanOp, LessOrEqualOp, GreaterThanOp, GreaterOrEqualOp:
+		return nil
+	default:
+		return ErrInvalidOperator
+	}
+}
+
+// Compare applies the operator to the given operands.
+func (op Operator) Compare(left, right float64) bool {
+	switch op {
+	case EqualOp:
+		return left == right
+	case NotEqualOp:
+		return left != right
+	case LessThanOp:
+		return left < right
+	case LessOrEqualOp:
+		return left <= right
+	case GreaterThanOp:
+		return left > right
+	case GreaterOrEqualOp:
+		return left >= right
+	default:
+		return false
+	}
+}
+
+// ActionType represents the type of the action a rule performs.
+type ActionType string
+
+// Supported action types.
+const (
+	// RepublishAction publishes the message to another channel.
+	RepublishAction ActionType = "republish"
+	// DropAction stops processing of the message. Actions and rules
+	// following the drop action are not applied to the message.
+	DropAction ActionType = "drop"
+	// EnrichAction adds metadata fields to every SenML record of the message.
+	EnrichAction ActionType = "enrich"
+	// NotifyAction sends the message to the action contacts.
+	NotifyAction ActionType = "notify"
+)
+
+// Condition filters messages by the value of their SenML records.
+// A message satisfies the condition if any of its records with the
+// condition name (or any record if the name is empty) has a numeric
+// value for which the comparison holds.
+type Condition struct {
+	Name     string   `json:"name,omitempty"`
+	Operator Operator `json:"operator"`
+	Value    float64  `json:"value"`
+}
+
+// Action represents an action performed on the matched message.
+type Action struct {
+	Type ActionType `json:"type"`
+
+	// Channel and Subtopic are the republish destination. If the subtopic
+	// is empty, the subtopic of the matched message is kept.
+	Channel  string `json:"channel,omitempty"`
+	Subtopic string `json:"subtopic,omitempty"`
+
+	// Metadata contains the fields added to the message by the enrich action.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+
+	// Contacts contains the receivers of the notify action.
+	Contacts []string `json:"contacts,omitempty"`
+}
+
+// Validate checks whether the action is well formed.
+func (a Action) Validate() error {
+	switch a.Type {
+	case RepublishAction:
+		if a.Channel == "" {
+			return errors.Wrap(ErrInvalidAction, errors.New("missing republish channel"))
+		}
+	case DropAction:
+	case EnrichAction:
+		if len(a.Metadata) == 0 {
+			return errors.Wrap(ErrInvalidAction, errors.New("missing enrich metadata"))
+		}
+	case NotifyAction:
+		if len(a.Contacts) == 0 {
+			return errors.Wrap(ErrInvalidAction, errors.New("missing notify contacts"))
+		}
+	default:
+		return ErrInvalidAction
+	}
+
+	return nil
+}
+
+// Rule represents a message processing rule defined in a domain.
+type Rule struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name,omitempty"`
+	DomainID  string     `json:"domain_id"`
+	ChannelID string     `json:"channel_id"`
+	Subtopic  string     `json:"subtopic,omitempty"`
+	Publisher string     `json:"publisher,omitempty"`
+	Condition *Condition `json:"condition,omitempty"`
+	Actions   []Action   `json:"actions"`
+	CreatedAt time.Time  `json:"created_at"`
+	CreatedBy string     `json:"created_by"`
+	UpdatedAt time.Time  `json:"updated_at,omitempty"`
+	UpdatedBy string     `json:"updated_by,omitempty"`
+}
+
+// PageMeta contains page metadata that helps navigation.
+type PageMeta struct {
+	Offset    uint64 `json:"offset" db:"offset"`
+	Limit     uint64 `json:"limit" db:"limit"`
+	DomainID  string `json:"domain_id,omitempty" db:"domain_id"`
+	ChannelID string `json:"channel_id,omitempty" db:"channel_id"`
+	Name      string `json:"name,omitempty" db:"name"`
+}
+
+// Page represents a page of rules.
+type Page struct {
+	PageMeta
+	Total uint64 `json:"total"`
+	Rules []Rule `json:"rules"`
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// AddRule adds a rule to the session domain.
+	AddRule(ctx context.Context, session authn.Session, rule Rule) (Rule, error)
+
+	// ViewRule retrieves the rule with the given ID.
+	ViewRule(ctx context.Context, session authn.Session, id string) (Rule, error)
+
+	// UpdateRule updates the rule matching criteria, condition and actions.
+	UpdateRule(ctx context.Context, session authn.Session, rule Rule) (Rule, error)
+
+	// ListRules retrieves the rules of the session domain.
+	ListRules(ctx context.Context, session authn.Session, pm PageMeta) (Page, error)
+
+	// RemoveRule removes the rule with the given ID.
+	RemoveRule(ctx context.Context, session authn.Session, id string) error
+
+	consumers.BlockingConsumer
+}
+
+// Repository specifies a rule persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the rule.
+	Save(ctx context.Context, rule Rule) (Rule, error)
+
+	// Retrieve retrieves the rule with the given ID.
+	Retrieve(ctx context.Context, id string) (Rule, error)
+
+	// Update updates the rule matching criteria, condition and actions.
+	Update(ctx context.Context, rule Rule) (Rule, error)
+
+	// RetrieveAll retrieves the rules matching the page metadata.
+	RetrieveAll(ctx context.Context, pm PageMeta) (Page, error)
+
+	// RetrieveByChannel retrieves all the rules defined for the channel.
+	RetrieveByChannel(ctx context.Context, chanID string) ([]Rule, error)
+
+	// Remove removes the rule with the given ID.
+	Remove(ctx context.Context, id string) error
+}
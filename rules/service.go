@@ -0,0 +1,335 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package rules
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/consumers"
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/transformers"
+	"github.com/absmach/supermq/pkg/transformers/senml"
+)
+
+// Protocol is set on messages republished by the rules engine. Messages
+// carrying it are not evaluated again, which prevents republish loops.
+const Protocol = "rules"
+
+var (
+	// ErrMessage indicates an error converting a message to SuperMQ message.
+	ErrMessage = errors.New("failed to convert to SuperMQ message")
+
+	errRepublish = errors.New("failed to republish message")
+	errPass      = errors.New("failed to pass on message")
+	errEnrich    = errors.New("failed to enrich message")
+	errNotifier  = errors.New("notifier is not configured")
+)
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	repo        Repository
+	idp         supermq.IDProvider
+	publisher   messaging.Publisher
+	output      messaging.Publisher
+	notifier    consumers.Notifier
+	from        string
+	transformer transformers.Transformer
+	cache       *Cache
+}
+
+// NewService instantiates the rules engine service implementation.
+// Messages which are not dropped by the rules are passed on using the
+// output publisher. The notifier is used by the notify action and may
+// be nil, in which case notify actions fail. The rules of the channels are
+// cached in the cache, which is invalidated when the rules are changed.
+func NewService(repo Repository, idp supermq.IDProvider, publisher, output messaging.Publisher, notifier consumers.Notifier, from string, cache *Cache) Service {
+	return &service{
+		repo:        repo,
+		idp:         idp,
+		publisher:   publisher,
+		output:      output,
+		notifier:    notifier,
+		from:        from,
+		transformer: senml.New(senml.JSON),
+		cache:       cache,
+	}
+}
+
+func (svc *service) AddRule(ctx context.Context, session authn.Session, rule Rule) (Rule, error) {
+	id, err := svc.idp.ID()
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	rule.ID = id
+	rule.DomainID = session.DomainID
+	rule.CreatedAt = time.Now().UTC()
+	rule.CreatedBy = session.UserID
+	rule.UpdatedAt = time.Time{}
+	rule.UpdatedBy = ""
+
+	rule, err = svc.repo.Save(ctx, rule)
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	svc.cache.Invalidate(rule.ID, rule.ChannelID)
+
+	return rule, nil
+}
+
+func (svc *service) ViewRule(ctx context.Context, session authn.Session, id string) (Rule, error) {
+	rule, err := svc.repo.Retrieve(ctx, id)
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if rule.DomainID != session.DomainID {
+		return Rule{}, svcerr.ErrNotFound
+	}
+
+	return rule, nil
+}
+
+func (svc *service) UpdateRule(ctx context.Context, session authn.Session, rule Rule) (Rule, error) {
+	old, err := svc.ViewRule(ctx, session, rule.ID)
+	if err != nil {
+		return Rule{}, err
+	}
+	rule.DomainID = session.DomainID
+	rule.UpdatedAt = time.Now().UTC()
+	rule.UpdatedBy = session.UserID
+
+	rule, err = svc.repo.Update(ctx, rule)
+	if err != nil {
+		return Rule{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	svc.cache.Invalidate(rule.ID, old.ChannelID, rule.ChannelID)
+
+	return rule, nil
+}
+
+func (svc *service) ListRules(ctx context.Context, session authn.Session, pm PageMeta) (Page, error) {
+	pm.DomainID = session.DomainID
+	page, err := svc.repo.RetrieveAll(ctx, pm)
+	if err != nil {
+		return Page{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	return page, nil
+}
+
+func (svc *service) RemoveRule(ctx context.Context, session authn.Session, id string) error {
+	rule, err := svc.ViewRule(ctx, session, id)
+	if err != nil {
+		return err
+	}
+	if err := svc.repo.Remove(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+	svc.cache.Invalidate(rule.ID, rule.ChannelID)
+
+	return nil
+}
+
+func (svc *service) ConsumeBlocking(ctx context.Context, message interface{}) error {
+	msg, ok := message.(*messaging.Message)
+	if !ok {
+		return ErrMessage
+	}
+	// Messages republished by the rules engine are passed on without
+	// evaluation, which prevents republish loops.
+	if msg.GetProtocol() == Protocol {
+		return svc.pass(ctx, msg)
+	}
+
+	rules, err := svc.channelRules(ctx, msg.GetChannel())
+	if err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return svc.pass(ctx, msg)
+	}
+
+	// Payloads which are not valid SenML never satisfy rule conditions.
+	var records []senml.Message
+	if res, err := svc.transformer.Transform(msg); err == nil {
+		records, _ = res.([]senml.Message)
+	}
+
+	var ret error
+	for _, rule := range rules {
+		if !rule.matches(msg, records) {
+			continue
+		}
+		drop, err := svc.apply(ctx, rule, msg)
+		if err != nil && ret == nil {
+			ret = err
+		}
+		if drop {
+			return ret
+		}
+	}
+	if err := svc.pass(ctx, msg); err != nil && ret == nil {
+		ret = err
+	}
+
+	return ret
+}
+
+// pass publishes the message to the consumers of the rules engine output.
+func (svc *service) pass(ctx context.Context, msg *messaging.Message) error {
+	if err := svc.output.Publish(ctx, msg.GetChannel(), msg); err != nil {
+		return errors.Wrap(errPass, err)
+	}
+
+	return nil
+}
+
+// channelRules returns the rules of the channel from the cache, retrieving
+// and caching them if they are missing or expired.
+func (svc *service) channelRules(ctx context.Context, chanID string) ([]Rule, error) {
+	rules, version, ok := svc.cache.get(chanID)
+	if ok {
+		return rules, nil
+	}
+
+	rules, err := svc.repo.RetrieveByChannel(ctx, chanID)
+	if err != nil {
+		return nil, err
+	}
+	svc.cache.set(chanID, rules, version)
+
+	return rules, nil
+}
+
+// apply performs the rule actions in order on a copy of the message. It
+// reports whether the message has been dropped. An action failure doesn't
+// prevent the following actions from being performed.
+func (svc *service) apply(ctx context.Context, rule Rule, msg *messaging.Message) (bool, error) {
+	payload := msg.GetPayload()
+
+	var ret error
+	for _, action := range rule.Actions {
+		var err error
+		switch action.Type {
+		case DropAction:
+			return true, ret
+		case RepublishAction:
+			err = svc.republish(ctx, action, msg, payload)
+		case EnrichAction:
+			var enriched []byte
+			if enriched, err = enrich(payload, action.Metadata); err == nil {
+				payload = enriched
+			}
+		case NotifyAction:
+			err = svc.notify(action, msg, payload)
+		}
+		if err != nil && ret == nil {
+			ret = err
+		}
+	}
+
+	return false, ret
+}
+
+func (svc *service) republish(ctx context.Context, action Action, msg *messaging.Message, payload []byte) error {
+	subtopic := action.Subtopic
+	if subtopic == "" {
+		subtopic = msg.GetSubtopic()
+	}
+	m := &messaging.Message{
+		Channel:   action.Channel,
+		Subtopic:  subtopic,
+		Publisher: msg.GetPublisher(),
+		Protocol:  Protocol,
+		Payload:   payload,
+		Created:   msg.GetCreated(),
+		Headers:   msg.GetHeaders(),
+	}
+	if err := svc.publisher.Publish(ctx, action.Channel, m); err != nil {
+		return errors.Wrap(errRepublish, err)
+	}
+
+	return nil
+}
+
+func (svc *service) notify(action Action, msg *messaging.Message, payload []byte) error {
+	if svc.notifier == nil {
+		return errors.Wrap(consumers.ErrNotify, errNotifier)
+	}
+	m := &messaging.Message{
+		Channel:   msg.GetChannel(),
+		Subtopic:  msg.GetSubtopic(),
+		Publisher: msg.GetPublisher(),
+		Protocol:  msg.GetProtocol(),
+		Payload:   payload,
+		Created:   msg.GetCreated(),
+		Headers:   msg.GetHeaders(),
+	}
+	if err := svc.notifier.Notify(svc.from, action.Contacts, m); err != nil {
+		return errors.Wrap(consumers.ErrNotify, err)
+	}
+
+	return nil
+}
+
+// matches checks whether the message satisfies the rule criteria and condition.
+func (rule Rule) matches(msg *messaging.Message, records []senml.Message) bool {
+	if rule.ChannelID != msg.GetChannel() {
+		return false
+	}
+	if rule.Subtopic != "" && rule.Subtopic != msg.GetSubtopic() {
+		return false
+	}
+	if rule.Publisher != "" && rule.Publisher != msg.GetPublisher() {
+		return false
+	}
+	if rule.Condition == nil {
+		return true
+	}
+	for _, rec := range records {
+		if rule.Condition.Name != "" && rule.Condition.Name != rec.Name {
+			continue
+		}
+		if rec.Value != nil && rule.Condition.Operator.Compare(*rec.Value, rule.Condition.Value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enrich adds the metadata fields to the JSON object payload or to every
+// object of the JSON array payload, such as SenML records. Fields already
+// present in the payload are not overwritten.
+func enrich(payload []byte, metadata map[string]interface{}) ([]byte, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal(payload, &obj); err == nil {
+		addFields(obj, metadata)
+		return json.Marshal(obj)
+	}
+
+	var objs []map[string]interface{}
+	if err := json.Unmarshal(payload, &objs); err != nil {
+		return nil, errors.Wrap(errEnrich, err)
+	}
+	for _, obj := range objs {
+		addFields(obj, metadata)
+	}
+
+	return json.Marshal(objs)
+}
+
+func addFields(obj, fields map[string]interface{}) {
+	for k, v := range fields {
+		if _, ok := obj[k]; !ok {
+			obj[k] = v
+		}
+	}
+}
@@ -0,0 +1,643 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package rules_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	cmocks "github.com/absmach/supermq/consumers/mocks"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	pubsubmocks "github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/absmach/supermq/pkg/uuid"
+	"github.com/absmach/supermq/rules"
+	"github.com/absmach/supermq/rules/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	from    = "rules@example.com"
+	contact = "user@example.com"
+	payload = `[{"bn":"dev:","n":"temp","v":30},{"n":"hum","v":40}]`
+
+	cacheSize = 10
+)
+
+var (
+	domainID    = testsutil.GenerateUUID(&testing.T{})
+	userID      = testsutil.GenerateUUID(&testing.T{})
+	chanID      = testsutil.GenerateUUID(&testing.T{})
+	targetID    = testsutil.GenerateUUID(&testing.T{})
+	session     = smqauthn.Session{UserID: userID, DomainID: domainID, DomainUserID: domainID + "_" + userID}
+	republishTo = rules.Action{Type: rules.RepublishAction, Channel: targetID}
+	validRule   = rules.Rule{
+		ID:        testsutil.GenerateUUID(&testing.T{}),
+		Name:      "rule",
+		DomainID:  domainID,
+		ChannelID: chanID,
+		Actions:   []rules.Action{republishTo},
+	}
+)
+
+func newService(cacheTTL time.Duration) (rules.Service, *mocks.Repository, *pubsubmocks.PubSub, *pubsubmocks.PubSub, *cmocks.Notifier) {
+	repo := new(mocks.Repository)
+	pubsub := new(pubsubmocks.PubSub)
+	output := new(pubsubmocks.PubSub)
+	notifier := new(cmocks.Notifier)
+
+	return rules.NewService(repo, uuid.NewMock(), pubsub, output, notifier, from, rules.NewCache(cacheSize, cacheTTL)), repo, pubsub, output, notifier
+}
+
+func TestAddRule(t *testing.T) {
+	svc, repo, _, _, _ := newService(0)
+
+	cases := []struct {
+		desc    string
+		rule    rules.Rule
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "add rule successfully",
+			rule: rules.Rule{Name: "rule", ChannelID: chanID, Actions: []rules.Action{republishTo}},
+		},
+		{
+			desc:    "add rule with failed repository save",
+			rule:    rules.Rule{Name: "rule", ChannelID: chanID, Actions: []rules.Action{republishTo}},
+			repoErr: repoerr.ErrCreateEntity,
+			err:     svcerr.ErrCreateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("Save", mock.Anything, mock.Anything).Return(func(_ context.Context, r rules.Rule) rules.Rule { return r }, tc.repoErr)
+			rule, err := svc.AddRule(context.Background(), session, tc.rule)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.NotEmpty(t, rule.ID, fmt.Sprintf("%s: expected non-empty id", tc.desc))
+				assert.Equal(t, domainID, rule.DomainID, fmt.Sprintf("%s: expected domain %s got %s", tc.desc, domainID, rule.DomainID))
+				assert.Equal(t, userID, rule.CreatedBy, fmt.Sprintf("%s: expected creator %s got %s", tc.desc, userID, rule.CreatedBy))
+				assert.False(t, rule.CreatedAt.IsZero(), fmt.Sprintf("%s: expected creation time to be set", tc.desc))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestViewRule(t *testing.T) {
+	svc, repo, _, _, _ := newService(0)
+
+	otherDomainRule := validRule
+	otherDomainRule.DomainID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc    string
+		id      string
+		res     rules.Rule
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "view rule successfully",
+			id:   validRule.ID,
+			res:  validRule,
+		},
+		{
+			desc:    "view non-existing rule",
+			id:      testsutil.GenerateUUID(t),
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc: "view rule from another domain",
+			id:   otherDomainRule.ID,
+			res:  otherDomainRule,
+			err:  svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("Retrieve", mock.Anything, tc.id).Return(tc.res, tc.repoErr)
+			rule, err := svc.ViewRule(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.res, rule, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, rule))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUpdateRule(t *testing.T) {
+	svc, repo, _, _, _ := newService(0)
+
+	update := validRule
+	update.Name = "updated"
+
+	cases := []struct {
+		desc      string
+		rule      rules.Rule
+		retrieved rules.Rule
+		retErr    error
+		updateErr error
+		err       error
+	}{
+		{
+			desc:      "update rule successfully",
+			rule:      update,
+			retrieved: validRule,
+		},
+		{
+			desc:   "update non-existing rule",
+			rule:   update,
+			retErr: repoerr.ErrNotFound,
+			err:    svcerr.ErrViewEntity,
+		},
+		{
+			desc:      "update rule with failed repository update",
+			rule:      update,
+			retrieved: validRule,
+			updateErr: repoerr.ErrUpdateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			retCall := repo.On("Retrieve", mock.Anything, tc.rule.ID).Return(tc.retrieved, tc.retErr)
+			updateCall := repo.On("Update", mock.Anything, mock.Anything).Return(func(_ context.Context, r rules.Rule) rules.Rule { return r }, tc.updateErr)
+			rule, err := svc.UpdateRule(context.Background(), session, tc.rule)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.rule.Name, rule.Name, fmt.Sprintf("%s: expected name %s got %s", tc.desc, tc.rule.Name, rule.Name))
+				assert.Equal(t, userID, rule.UpdatedBy, fmt.Sprintf("%s: expected updater %s got %s", tc.desc, userID, rule.UpdatedBy))
+			}
+			retCall.Unset()
+			updateCall.Unset()
+		})
+	}
+}
+
+func TestListRules(t *testing.T) {
+	svc, repo, _, _, _ := newService(0)
+
+	cases := []struct {
+		desc    string
+		pm      rules.PageMeta
+		res     rules.Page
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "list rules successfully",
+			pm:   rules.PageMeta{Offset: 0, Limit: 10},
+			res:  rules.Page{Total: 1, Rules: []rules.Rule{validRule}},
+		},
+		{
+			desc:    "list rules with failed repository retrieve",
+			pm:      rules.PageMeta{Offset: 0, Limit: 10},
+			repoErr: repoerr.ErrViewEntity,
+			err:     svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			pm := tc.pm
+			pm.DomainID = domainID
+			repoCall := repo.On("RetrieveAll", mock.Anything, pm).Return(tc.res, tc.repoErr)
+			page, err := svc.ListRules(context.Background(), session, tc.pm)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.res, page, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, page))
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRemoveRule(t *testing.T) {
+	svc, repo, _, _, _ := newService(0)
+
+	cases := []struct {
+		desc      string
+		id        string
+		retrieved rules.Rule
+		retErr    error
+		removeErr error
+		err       error
+	}{
+		{
+			desc:      "remove rule successfully",
+			id:        validRule.ID,
+			retrieved: validRule,
+		},
+		{
+			desc:   "remove non-existing rule",
+			id:     validRule.ID,
+			retErr: repoerr.ErrNotFound,
+			err:    svcerr.ErrViewEntity,
+		},
+		{
+			desc:      "remove rule with failed repository remove",
+			id:        validRule.ID,
+			retrieved: validRule,
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			retCall := repo.On("Retrieve", mock.Anything, tc.id).Return(tc.retrieved, tc.retErr)
+			removeCall := repo.On("Remove", mock.Anything, tc.id).Return(tc.removeErr)
+			err := svc.RemoveRule(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			retCall.Unset()
+			removeCall.Unset()
+		})
+	}
+}
+
+func TestConsumeBlocking(t *testing.T) {
+	svc, repo, pubsub, output, notifier := newService(0)
+
+	rule := func(condition *rules.Condition, actions ...rules.Action) rules.Rule {
+		r := validRule
+		r.Condition = condition
+		r.Actions = actions
+		return r
+	}
+	enrichAction := rules.Action{Type: rules.EnrichAction, Metadata: map[string]interface{}{"building": "A"}}
+	notifyAction := rules.Action{Type: rules.NotifyAction, Contacts: []string{contact}}
+	dropAction := rules.Action{Type: rules.DropAction}
+
+	msg := &messaging.Message{
+		Channel:   chanID,
+		Subtopic:  "sensors",
+		Publisher: testsutil.GenerateUUID(t),
+		Protocol:  "http",
+		Payload:   []byte(payload),
+	}
+
+	cases := []struct {
+		desc       string
+		msg        interface{}
+		rules      []rules.Rule
+		repoErr    error
+		publishes  int
+		passes     int
+		notifies   int
+		enriched   bool
+		publishErr error
+		passErr    error
+		err        error
+	}{
+		{
+			desc:      "republish message without condition",
+			msg:       msg,
+			rules:     []rules.Rule{rule(nil, republishTo)},
+			publishes: 1,
+			passes:    1,
+		},
+		{
+			desc:      "republish message with satisfied condition",
+			msg:       msg,
+			rules:     []rules.Rule{rule(&rules.Condition{Name: "dev:temp", Operator: rules.GreaterThanOp, Value: 25}, republishTo)},
+			publishes: 1,
+			passes:    1,
+		},
+		{
+			desc:   "skip message with unsatisfied condition",
+			msg:    msg,
+			rules:  []rules.Rule{rule(&rules.Condition{Name: "dev:temp", Operator: rules.LessThanOp, Value: 25}, republishTo)},
+			passes: 1,
+		},
+		{
+			desc:   "skip message with condition on missing record",
+			msg:    msg,
+			rules:  []rules.Rule{rule(&rules.Condition{Name: "pressure", Operator: rules.GreaterThanOp, Value: 0}, republishTo)},
+			passes: 1,
+		},
+		{
+			desc: "skip message from other publisher",
+			msg:  msg,
+			rules: []rules.Rule{func() rules.Rule {
+				r := rule(nil, republishTo)
+				r.Publisher = testsutil.GenerateUUID(t)
+				return r
+			}()},
+			passes: 1,
+		},
+		{
+			desc: "skip message on other subtopic",
+			msg:  msg,
+			rules: []rules.Rule{func() rules.Rule {
+				r := rule(nil, republishTo)
+				r.Subtopic = "other"
+				return r
+			}()},
+			passes: 1,
+		},
+		{
+			desc:      "enrich and republish message",
+			msg:       msg,
+			rules:     []rules.Rule{rule(nil, enrichAction, republishTo)},
+			publishes: 1,
+			enriched:  true,
+			passes:    1,
+		},
+		{
+			desc:     "notify contacts",
+			msg:      msg,
+			rules:    []rules.Rule{rule(nil, notifyAction)},
+			notifies: 1,
+			passes:   1,
+		},
+		{
+			desc:  "drop message before further actions and rules",
+			msg:   msg,
+			rules: []rules.Rule{rule(nil, dropAction, republishTo), rule(nil, notifyAction)},
+		},
+		{
+			desc:      "apply every matching rule",
+			msg:       msg,
+			rules:     []rules.Rule{rule(nil, republishTo), rule(nil, notifyAction)},
+			publishes: 1,
+			notifies:  1,
+			passes:    1,
+		},
+		{
+			desc: "pass message republished by rules engine without evaluation",
+			msg: &messaging.Message{
+				Channel:  chanID,
+				Protocol: rules.Protocol,
+				Payload:  []byte(payload),
+			},
+			rules:  []rules.Rule{rule(nil, republishTo)},
+			passes: 1,
+		},
+		{
+			desc:       "return error on failed republish",
+			msg:        msg,
+			rules:      []rules.Rule{rule(nil, republishTo, notifyAction)},
+			publishes:  1,
+			notifies:   1,
+			passes:     1,
+			publishErr: errors.New("failed to publish"),
+			err:        errors.New("failed to republish message"),
+		},
+		{
+			desc:   "pass message without rules",
+			msg:    msg,
+			passes: 1,
+		},
+		{
+			desc:    "return error on failed pass",
+			msg:     msg,
+			passes:  1,
+			passErr: errors.New("failed to publish"),
+			err:     errors.New("failed to pass on message"),
+		},
+		{
+			desc:    "return error on failed rules retrieval",
+			msg:     msg,
+			repoErr: repoerr.ErrViewEntity,
+			err:     repoerr.ErrViewEntity,
+		},
+		{
+			desc: "return error on invalid message",
+			msg:  "invalid",
+			err:  rules.ErrMessage,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByChannel", mock.Anything, chanID).Return(tc.rules, tc.repoErr)
+			pubCall := pubsub.On("Publish", mock.Anything, targetID, mock.Anything).Return(tc.publishErr)
+			passCall := output.On("Publish", mock.Anything, chanID, mock.Anything).Return(tc.passErr)
+			notifyCall := notifier.On("Notify", from, []string{contact}, mock.Anything).Return(nil)
+			err := svc.ConsumeBlocking(context.Background(), tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			pubsub.AssertNumberOfCalls(t, "Publish", tc.publishes)
+			output.AssertNumberOfCalls(t, "Publish", tc.passes)
+			notifier.AssertNumberOfCalls(t, "Notify", tc.notifies)
+			for _, call := range pubsub.Calls {
+				m := call.Arguments.Get(2).(*messaging.Message)
+				assert.Equal(t, targetID, m.GetChannel(), fmt.Sprintf("%s: expected channel %s got %s", tc.desc, targetID, m.GetChannel()))
+				assert.Equal(t, rules.Protocol, m.GetProtocol(), fmt.Sprintf("%s: expected protocol %s got %s", tc.desc, rules.Protocol, m.GetProtocol()))
+				var records []map[string]interface{}
+				err := json.Unmarshal(m.GetPayload(), &records)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error decoding payload: %s", tc.desc, err))
+				for _, rec := range records {
+					_, ok := rec["building"]
+					assert.Equal(t, tc.enriched, ok, fmt.Sprintf("%s: expected enriched %t got %t", tc.desc, tc.enriched, ok))
+				}
+			}
+			repoCall.Unset()
+			pubCall.Unset()
+			passCall.Unset()
+			notifyCall.Unset()
+			pubsub.Calls = nil
+			output.Calls = nil
+			notifier.Calls = nil
+		})
+	}
+}
+
+func TestConsumeBlockingCache(t *testing.T) {
+	svc, repo, pubsub, output, _ := newService(time.Hour)
+
+	msg := &messaging.Message{
+		Channel:  chanID,
+		Protocol: "http",
+		Payload:  []byte(payload),
+	}
+	update := validRule
+	update.ChannelID = testsutil.GenerateUUID(t)
+
+	repoCall := repo.On("RetrieveByChannel", mock.Anything, chanID).Return([]rules.Rule{validRule}, nil)
+	repoCall1 := repo.On("Retrieve", mock.Anything, validRule.ID).Return(validRule, nil)
+	repoCall2 := repo.On("Update", mock.Anything, mock.Anything).Return(update, nil)
+	repoCall3 := repo.On("Remove", mock.Anything, validRule.ID).Return(nil)
+	repoCall4 := repo.On("Save", mock.Anything, mock.Anything).Return(validRule, nil)
+	pubCall := pubsub.On("Publish", mock.Anything, targetID, mock.Anything).Return(nil)
+	passCall := output.On("Publish", mock.Anything, chanID, mock.Anything).Return(nil)
+	defer func() {
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		repoCall3.Unset()
+		repoCall4.Unset()
+		pubCall.Unset()
+		passCall.Unset()
+	}()
+
+	cases := []struct {
+		desc       string
+		change     func() error
+		retrievals int
+	}{
+		{
+			desc:       "consume message with uncached rules",
+			retrievals: 1,
+		},
+		{
+			desc:       "consume message with cached rules",
+			retrievals: 1,
+		},
+		{
+			desc: "consume message after rule update",
+			change: func() error {
+				_, err := svc.UpdateRule(context.Background(), session, validRule)
+				return err
+			},
+			retrievals: 2,
+		},
+		{
+			desc: "consume message after rule removal",
+			change: func() error {
+				return svc.RemoveRule(context.Background(), session, validRule.ID)
+			},
+			retrievals: 3,
+		},
+		{
+			desc: "consume message after rule creation",
+			change: func() error {
+				_, err := svc.AddRule(context.Background(), session, validRule)
+				return err
+			},
+			retrievals: 4,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.change != nil {
+				err := tc.change()
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			}
+			err := svc.ConsumeBlocking(context.Background(), msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			repo.AssertNumberOfCalls(t, "RetrieveByChannel", tc.retrievals)
+		})
+	}
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	repo := new(mocks.Repository)
+	output := new(pubsubmocks.PubSub)
+	cache := rules.NewCache(cacheSize, time.Hour)
+	svc := rules.NewService(repo, uuid.NewMock(), new(pubsubmocks.PubSub), output, new(cmocks.Notifier), from, cache)
+
+	rule := rules.Rule{ID: testsutil.GenerateUUID(t), DomainID: domainID, ChannelID: chanID}
+	msg := &messaging.Message{Channel: chanID, Protocol: "http", Payload: []byte(payload)}
+
+	repoCall := repo.On("RetrieveByChannel", mock.Anything, chanID).Return([]rules.Rule{rule}, nil)
+	passCall := output.On("Publish", mock.Anything, chanID, mock.Anything).Return(nil)
+	defer func() {
+		repoCall.Unset()
+		passCall.Unset()
+	}()
+
+	cases := []struct {
+		desc       string
+		ruleID     string
+		chanIDs    []string
+		retrievals int
+	}{
+		{
+			desc:       "consume message with uncached rules",
+			retrievals: 1,
+		},
+		{
+			desc:       "consume message after invalidating another rule",
+			ruleID:     testsutil.GenerateUUID(t),
+			chanIDs:    []string{targetID},
+			retrievals: 1,
+		},
+		{
+			desc:       "consume message after invalidating the rule channel",
+			ruleID:     testsutil.GenerateUUID(t),
+			chanIDs:    []string{chanID},
+			retrievals: 2,
+		},
+		{
+			desc:       "consume message after invalidating the rule moved to another channel",
+			ruleID:     rule.ID,
+			chanIDs:    []string{targetID},
+			retrievals: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.ruleID != "" {
+				cache.Invalidate(tc.ruleID, tc.chanIDs...)
+			}
+			err := svc.ConsumeBlocking(context.Background(), msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			repo.AssertNumberOfCalls(t, "RetrieveByChannel", tc.retrievals)
+		})
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	repo := new(mocks.Repository)
+	output := new(pubsubmocks.PubSub)
+	svc := rules.NewService(repo, uuid.NewMock(), new(pubsubmocks.PubSub), output, new(cmocks.Notifier), from, rules.NewCache(1, time.Hour))
+
+	repoCall := repo.On("RetrieveByChannel", mock.Anything, mock.Anything).Return([]rules.Rule{}, nil)
+	passCall := output.On("Publish", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	defer func() {
+		repoCall.Unset()
+		passCall.Unset()
+	}()
+
+	cases := []struct {
+		desc       string
+		chanID     string
+		retrievals int
+	}{
+		{
+			desc:       "consume message with uncached rules",
+			chanID:     chanID,
+			retrievals: 1,
+		},
+		{
+			desc:       "consume message with cached rules",
+			chanID:     chanID,
+			retrievals: 1,
+		},
+		{
+			desc:       "consume message of another channel",
+			chanID:     targetID,
+			retrievals: 2,
+		},
+		{
+			desc:       "consume message with evicted rules",
+			chanID:     chanID,
+			retrievals: 3,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			msg := &messaging.Message{Channel: tc.chanID, Protocol: "http", Payload: []byte(payload)}
+			err := svc.ConsumeBlocking(context.Background(), msg)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error: %s", tc.desc, err))
+			repo.AssertNumberOfCalls(t, "RetrieveByChannel", tc.retrievals)
+		})
+	}
+}
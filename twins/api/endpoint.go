@@ -0,0 +1,104 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/twins"
+	"github.com/go-kit/kit/endpoint"
+)
+
+func createTwinEndpoint(svc twins.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(createTwinReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		twin := twins.Twin{
+			ClientID:  req.ClientID,
+			ChannelID: req.ChannelID,
+			Desired:   twins.State{Document: req.Desired},
+		}
+		twin, err := svc.CreateTwin(ctx, session, twin)
+		if err != nil {
+			return nil, err
+		}
+
+		return twinRes{Twin: twin, Delta: twin.Delta(), created: true}, nil
+	}
+}
+
+func viewTwinEndpoint(svc twins.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(twinReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		twin, err := svc.ViewTwin(ctx, session, req.clientID)
+		if err != nil {
+			return nil, err
+		}
+
+		return twinRes{Twin: twin, Delta: twin.Delta()}, nil
+	}
+}
+
+func updateDesiredEndpoint(svc twins.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(updateDesiredReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		twin, err := svc.UpdateDesired(ctx, session, req.clientID, req.Desired, req.Revision)
+		if err != nil {
+			return nil, err
+		}
+
+		return twinRes{Twin: twin, Delta: twin.Delta()}, nil
+	}
+}
+
+func removeTwinEndpoint(svc twins.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		req := request.(twinReq)
+		if err := req.validate(); err != nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, err)
+		}
+
+		session, ok := ctx.Value(api.SessionKey).(smqauthn.Session)
+		if !ok {
+			return nil, svcerr.ErrAuthentication
+		}
+
+		if err := svc.RemoveTwin(ctx, session, req.clientID); err != nil {
+			return nil, err
+		}
+
+		return removeTwinRes{}, nil
+	}
+}
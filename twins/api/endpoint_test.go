@@ -0,0 +1,469 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/internal/testsutil"
+	smqlog "github.com/absmach/supermq/logger"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	"github.com/absmach/supermq/pkg/errors"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/twins"
+	"github.com/absmach/supermq/twins/api"
+	"github.com/absmach/supermq/twins/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	contentType  = "application/json"
+	validToken   = "valid-token"
+	invalidToken = "invalid-token"
+	instanceID   = "5de9b29a-feb9-11ed-be56-0242ac120002"
+)
+
+var (
+	domainID = testsutil.GenerateUUID(&testing.T{})
+	userID   = testsutil.GenerateUUID(&testing.T{})
+	chanID   = testsutil.GenerateUUID(&testing.T{})
+	clientID = testsutil.GenerateUUID(&testing.T{})
+	session  = smqauthn.Session{UserID: userID}
+	twin     = twins.Twin{
+		ClientID:  clientID,
+		DomainID:  domainID,
+		ChannelID: chanID,
+		Desired: twins.State{
+			Document:  map[string]any{"mode": "eco"},
+			Revisions: map[string]uint64{"mode": 1},
+			Revision:  1,
+		},
+	}
+)
+
+type testRequest struct {
+	client      *http.Client
+	method      string
+	url         string
+	contentType string
+	token       string
+	body        io.Reader
+}
+
+func (tr testRequest) make() (*http.Response, error) {
+	req, err := http.NewRequest(tr.method, tr.url, tr.body)
+	if err != nil {
+		return nil, err
+	}
+	if tr.token != "" {
+		req.Header.Set("Authorization", apiutil.BearerPrefix+tr.token)
+	}
+	if tr.contentType != "" {
+		req.Header.Set("Content-Type", tr.contentType)
+	}
+
+	return tr.client.Do(req)
+}
+
+type respBody struct {
+	Err      string         `json:"error"`
+	Message  string         `json:"message"`
+	ClientID string         `json:"client_id"`
+	Desired  twins.State    `json:"desired"`
+	Delta    map[string]any `json:"delta"`
+}
+
+func newServer() (*httptest.Server, *mocks.Service, *authnmocks.Authentication) {
+	svc := new(mocks.Service)
+	authn := new(authnmocks.Authentication)
+	logger := smqlog.NewMock()
+	mux := api.MakeHandler(svc, authn, logger, "test", instanceID)
+
+	return httptest.NewServer(mux), svc, authn
+}
+
+func toJSON(data interface{}) string {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return ""
+	}
+
+	return string(jsonData)
+}
+
+func decodeBody(t *testing.T, res *http.Response) (respBody, error) {
+	var body respBody
+	if res.StatusCode == http.StatusNoContent {
+		return body, nil
+	}
+	err := json.NewDecoder(res.Body).Decode(&body)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error while decoding response body: %s", err))
+	if body.Err != "" || body.Message != "" {
+		return body, errors.Wrap(errors.New(body.Err), errors.New(body.Message))
+	}
+
+	return body, nil
+}
+
+func TestCreateTwin(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc        string
+		token       string
+		contentType string
+		req         string
+		authnErr    error
+		svcRes      twins.Twin
+		svcErr      error
+		status      int
+		location    string
+		err         error
+	}{
+		{
+			desc:        "create twin successfully",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"client_id": clientID, "channel_id": chanID, "desired": map[string]any{"mode": "eco"}}),
+			svcRes:      twin,
+			status:      http.StatusCreated,
+			location:    fmt.Sprintf("/twins/%s", clientID),
+		},
+		{
+			desc:        "create twin with invalid token",
+			token:       invalidToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"client_id": clientID, "channel_id": chanID}),
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "create twin with invalid content type",
+			token:       validToken,
+			contentType: "application/xml",
+			req:         toJSON(map[string]any{"client_id": clientID, "channel_id": chanID}),
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "create twin with invalid request body",
+			token:       validToken,
+			contentType: contentType,
+			req:         "{",
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "create twin with desired state which is not an object",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"client_id": clientID, "channel_id": chanID, "desired": "eco"}),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "create twin without client",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"channel_id": chanID}),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "create twin without channel",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"client_id": clientID}),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "create twin without client permission",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"client_id": clientID, "channel_id": chanID}),
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/%s/twins", ts.URL, domainID),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("CreateTwin", mock.Anything, mock.Anything, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
+			if tc.err == nil {
+				assert.Equal(t, clientID, body.ClientID, fmt.Sprintf("%s: expected client id %s got %s", tc.desc, clientID, body.ClientID))
+				assert.Equal(t, twin.Delta(), body.Delta, fmt.Sprintf("%s: expected delta %v got %v", tc.desc, twin.Delta(), body.Delta))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestViewTwin(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		id       string
+		authnErr error
+		svcRes   twins.Twin
+		svcErr   error
+		status   int
+		err      error
+	}{
+		{
+			desc:   "view twin successfully",
+			token:  validToken,
+			id:     clientID,
+			svcRes: twin,
+			status: http.StatusOK,
+		},
+		{
+			desc:     "view twin with invalid token",
+			token:    invalidToken,
+			id:       clientID,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "view twin without client permission",
+			token:  validToken,
+			id:     clientID,
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "view non-existing twin",
+			token:  validToken,
+			id:     clientID,
+			svcErr: svcerr.ErrNotFound,
+			status: http.StatusNotFound,
+			err:    svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/%s/twins/%s", ts.URL, domainID, tc.id),
+				token:  tc.token,
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("ViewTwin", mock.Anything, mock.Anything, tc.id).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.err == nil {
+				assert.Equal(t, tc.svcRes.Desired, body.Desired, fmt.Sprintf("%s: expected desired %v got %v", tc.desc, tc.svcRes.Desired, body.Desired))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestUpdateDesired(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	patch := map[string]any{"mode": "boost"}
+
+	cases := []struct {
+		desc        string
+		token       string
+		contentType string
+		req         string
+		revision    uint64
+		authnErr    error
+		svcRes      twins.Twin
+		svcErr      error
+		status      int
+		err         error
+	}{
+		{
+			desc:        "update desired state successfully",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"desired": patch}),
+			svcRes:      twin,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update desired state with revision",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"desired": patch, "revision": 1}),
+			revision:    1,
+			svcRes:      twin,
+			status:      http.StatusOK,
+		},
+		{
+			desc:        "update desired state with stale revision",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"desired": patch, "revision": 3}),
+			revision:    3,
+			svcErr:      svcerr.ErrRevisionConflict,
+			status:      http.StatusConflict,
+			err:         svcerr.ErrRevisionConflict,
+		},
+		{
+			desc:        "update desired state with invalid token",
+			token:       invalidToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"desired": patch}),
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "update desired state with invalid content type",
+			token:       validToken,
+			contentType: "application/xml",
+			req:         toJSON(map[string]any{"desired": patch}),
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+		{
+			desc:        "update desired state with invalid request body",
+			token:       validToken,
+			contentType: contentType,
+			req:         "{",
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "update desired state without desired state",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"revision": 1}),
+			status:      http.StatusBadRequest,
+			err:         errors.ErrMalformedEntity,
+		},
+		{
+			desc:        "update desired state without client permission",
+			token:       validToken,
+			contentType: contentType,
+			req:         toJSON(map[string]any{"desired": patch}),
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client:      ts.Client(),
+				method:      http.MethodPatch,
+				url:         fmt.Sprintf("%s/%s/twins/%s/desired", ts.URL, domainID, clientID),
+				contentType: tc.contentType,
+				token:       tc.token,
+				body:        strings.NewReader(tc.req),
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("UpdateDesired", mock.Anything, mock.Anything, clientID, patch, tc.revision).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			body, err := decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			if tc.err == nil {
+				assert.Equal(t, clientID, body.ClientID, fmt.Sprintf("%s: expected client id %s got %s", tc.desc, clientID, body.ClientID))
+			}
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRemoveTwin(t *testing.T) {
+	ts, svc, authn := newServer()
+	defer ts.Close()
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnErr error
+		svcErr   error
+		status   int
+		err      error
+	}{
+		{
+			desc:   "remove twin successfully",
+			token:  validToken,
+			status: http.StatusNoContent,
+		},
+		{
+			desc:     "remove twin with invalid token",
+			token:    invalidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "remove twin without client permission",
+			token:  validToken,
+			svcErr: svcerr.ErrAuthorization,
+			status: http.StatusForbidden,
+			err:    svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				client: ts.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/%s/twins/%s", ts.URL, domainID, clientID),
+				token:  tc.token,
+			}
+			authCall := authn.On("Authenticate", mock.Anything, tc.token).Return(session, tc.authnErr)
+			svcCall := svc.On("RemoveTwin", mock.Anything, mock.Anything, clientID).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			_, err = decodeBody(t, res)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected error %s got %s", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
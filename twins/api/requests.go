@@ -0,0 +1,59 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+var errMissingDesired = errors.New("missing desired state")
+
+type createTwinReq struct {
+	ClientID  string         `json:"client_id"`
+	ChannelID string         `json:"channel_id"`
+	Desired   map[string]any `json:"desired,omitempty"`
+}
+
+func (req createTwinReq) validate() error {
+	if req.ClientID == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrMissingID)
+	}
+	if req.ChannelID == "" {
+		return errors.Wrap(errors.ErrMalformedEntity, apiutil.ErrMissingChannelID)
+	}
+
+	return nil
+}
+
+type twinReq struct {
+	clientID string
+}
+
+func (req twinReq) validate() error {
+	if req.clientID == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
+type updateDesiredReq struct {
+	clientID string
+	Desired  map[string]any `json:"desired"`
+	// Revision is the desired state revision the update is based on.
+	// The update is applied unconditionally if it's not set.
+	Revision uint64 `json:"revision,omitempty"`
+}
+
+func (req updateDesiredReq) validate() error {
+	if req.clientID == "" {
+		return apiutil.ErrMissingID
+	}
+	if len(req.Desired) == 0 {
+		return errors.Wrap(errors.ErrMalformedEntity, errMissingDesired)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/absmach/supermq"
+	"github.com/absmach/supermq/twins"
+)
+
+var (
+	_ supermq.Response = (*twinRes)(nil)
+	_ supermq.Response = (*removeTwinRes)(nil)
+)
+
+type twinRes struct {
+	twins.Twin
+	Delta   map[string]any `json:"delta"`
+	created bool
+}
+
+func (res twinRes) Code() int {
+	if res.created {
+		return http.StatusCreated
+	}
+
+	return http.StatusOK
+}
+
+func (res twinRes) Headers() map[string]string {
+	if res.created {
+		return map[string]string{
+			"Location": fmt.Sprintf("/twins/%s", res.ClientID),
+		}
+	}
+
+	return map[string]string{}
+}
+
+func (res twinRes) Empty() bool {
+	return false
+}
+
+type removeTwinRes struct{}
+
+func (res removeTwinRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res removeTwinRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res removeTwinRes) Empty() bool {
+	return true
+}
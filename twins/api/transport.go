@@ -0,0 +1,107 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/absmach/supermq"
+	api "github.com/absmach/supermq/api/http"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/twins"
+	"github.com/go-chi/chi/v5"
+	kithttp "github.com/go-kit/kit/transport/http"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const clientIDKey = "clientID"
+
+// MakeHandler returns a HTTP handler for API endpoints.
+func MakeHandler(svc twins.Service, authn smqauthn.Authentication, logger *slog.Logger, svcName, instanceID string) http.Handler {
+	opts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+	}
+
+	mux := chi.NewRouter()
+
+	mux.Route("/{domainID}/twins", func(r chi.Router) {
+		r.Use(api.AuthenticateMiddleware(authn, true))
+
+		r.Post("/", otelhttp.NewHandler(kithttp.NewServer(
+			createTwinEndpoint(svc),
+			decodeCreateTwin,
+			api.EncodeResponse,
+			opts...,
+		), "create_twin").ServeHTTP)
+
+		r.Get("/{clientID}", otelhttp.NewHandler(kithttp.NewServer(
+			viewTwinEndpoint(svc),
+			decodeTwin,
+			api.EncodeResponse,
+			opts...,
+		), "view_twin").ServeHTTP)
+
+		r.Patch("/{clientID}/desired", otelhttp.NewHandler(kithttp.NewServer(
+			updateDesiredEndpoint(svc),
+			decodeUpdateDesired,
+			api.EncodeResponse,
+			opts...,
+		), "update_desired").ServeHTTP)
+
+		r.Delete("/{clientID}", otelhttp.NewHandler(kithttp.NewServer(
+			removeTwinEndpoint(svc),
+			decodeTwin,
+			api.EncodeResponse,
+			opts...,
+		), "remove_twin").ServeHTTP)
+	})
+
+	mux.Get("/health", supermq.Health(svcName, instanceID))
+	mux.Handle("/metrics", promhttp.Handler())
+
+	return mux
+}
+
+func decodeCreateTwin(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	var req createTwinReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
+
+func decodeTwin(_ context.Context, r *http.Request) (interface{}, error) {
+	req := twinReq{
+		clientID: chi.URLParam(r, clientIDKey),
+	}
+
+	return req, nil
+}
+
+func decodeUpdateDesired(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := updateDesiredReq{
+		clientID: chi.URLParam(r, clientIDKey),
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(errors.ErrMalformedEntity, err))
+	}
+
+	return req, nil
+}
@@ -0,0 +1,9 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package twins contains the device twin service. A twin keeps the desired
+// state of a client, set by the users, and the reported state, published by
+// the client itself. Whenever the desired state is updated, the difference
+// between the desired and the reported state is published to the client, so
+// the client can converge to the desired state and report it back.
+package twins
@@ -0,0 +1,34 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+var _ messaging.MessageHandler = (*reportHandler)(nil)
+
+type reportHandler struct {
+	ctx context.Context
+	svc Service
+}
+
+// NewReportHandler returns the message handler which passes the states
+// reported by the clients received from the message broker to the service.
+func NewReportHandler(ctx context.Context, svc Service) messaging.MessageHandler {
+	return &reportHandler{
+		ctx: ctx,
+		svc: svc,
+	}
+}
+
+func (h *reportHandler) Handle(msg *messaging.Message) error {
+	return h.svc.Report(h.ctx, msg)
+}
+
+func (h *reportHandler) Cancel() error {
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/twins"
+)
+
+const (
+	readPermission    = "read_permission"
+	updatePermission  = "update_permission"
+	publishPermission = "publish_permission"
+)
+
+var (
+	errClientView     = errors.New("not authorized to view client twin")
+	errClientUpdate   = errors.New("not authorized to update client twin")
+	errChannelPublish = errors.New("not authorized to publish to twin channel")
+)
+
+var _ twins.Service = (*authorizationMiddleware)(nil)
+
+type authorizationMiddleware struct {
+	svc   twins.Service
+	authz smqauthz.Authorization
+}
+
+// AuthorizationMiddleware adds authorization to the twins service. The twin
+// is authorized through the client roles: viewing the twin requires the
+// client read action, and changing it requires the client update action.
+func AuthorizationMiddleware(svc twins.Service, authz smqauthz.Authorization) twins.Service {
+	return &authorizationMiddleware{
+		svc:   svc,
+		authz: authz,
+	}
+}
+
+func (am *authorizationMiddleware) CreateTwin(ctx context.Context, session smqauthn.Session, twin twins.Twin) (twins.Twin, error) {
+	if err := am.authorizeClient(ctx, session, twin.ClientID, updatePermission); err != nil {
+		return twins.Twin{}, errors.Wrap(err, errClientUpdate)
+	}
+	if err := am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  publishPermission,
+		ObjectType:  policies.ChannelType,
+		Object:      twin.ChannelID,
+	}); err != nil {
+		return twins.Twin{}, errors.Wrap(err, errChannelPublish)
+	}
+
+	return am.svc.CreateTwin(ctx, session, twin)
+}
+
+func (am *authorizationMiddleware) ViewTwin(ctx context.Context, session smqauthn.Session, clientID string) (twins.Twin, error) {
+	if err := am.authorizeClient(ctx, session, clientID, readPermission); err != nil {
+		return twins.Twin{}, errors.Wrap(err, errClientView)
+	}
+
+	return am.svc.ViewTwin(ctx, session, clientID)
+}
+
+func (am *authorizationMiddleware) UpdateDesired(ctx context.Context, session smqauthn.Session, clientID string, patch map[string]any, revision uint64) (twins.Twin, error) {
+	if err := am.authorizeClient(ctx, session, clientID, updatePermission); err != nil {
+		return twins.Twin{}, errors.Wrap(err, errClientUpdate)
+	}
+
+	return am.svc.UpdateDesired(ctx, session, clientID, patch, revision)
+}
+
+func (am *authorizationMiddleware) RemoveTwin(ctx context.Context, session smqauthn.Session, clientID string) error {
+	if err := am.authorizeClient(ctx, session, clientID, updatePermission); err != nil {
+		return errors.Wrap(err, errClientUpdate)
+	}
+
+	return am.svc.RemoveTwin(ctx, session, clientID)
+}
+
+func (am *authorizationMiddleware) Report(ctx context.Context, msg *messaging.Message) error {
+	return am.svc.Report(ctx, msg)
+}
+
+func (am *authorizationMiddleware) authorizeClient(ctx context.Context, session smqauthn.Session, clientID, permission string) error {
+	return am.authz.Authorize(ctx, smqauthz.PolicyReq{
+		Domain:      session.DomainID,
+		SubjectType: policies.UserType,
+		SubjectKind: policies.UsersKind,
+		Subject:     session.DomainUserID,
+		Permission:  permission,
+		ObjectType:  policies.ClientType,
+		Object:      clientID,
+	})
+}
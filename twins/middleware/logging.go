@@ -0,0 +1,129 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/twins"
+)
+
+var _ twins.Service = (*loggingMiddleware)(nil)
+
+type loggingMiddleware struct {
+	logger *slog.Logger
+	svc    twins.Service
+}
+
+// LoggingMiddleware adds logging facilities to the twins service.
+func LoggingMiddleware(svc twins.Service, logger *slog.Logger) twins.Service {
+	return &loggingMiddleware{
+		logger: logger,
+		svc:    svc,
+	}
+}
+
+func (lm *loggingMiddleware) CreateTwin(ctx context.Context, session smqauthn.Session, twin twins.Twin) (t twins.Twin, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("twin",
+				slog.String("client_id", twin.ClientID),
+				slog.String("channel_id", twin.ChannelID),
+				slog.Uint64("desired_revision", t.Desired.Revision),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Create twin failed", args...)
+			return
+		}
+		lm.logger.Info("Create twin completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.CreateTwin(ctx, session, twin)
+}
+
+func (lm *loggingMiddleware) ViewTwin(ctx context.Context, session smqauthn.Session, clientID string) (t twins.Twin, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("client_id", clientID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("View twin failed", args...)
+			return
+		}
+		lm.logger.Info("View twin completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.ViewTwin(ctx, session, clientID)
+}
+
+func (lm *loggingMiddleware) UpdateDesired(ctx context.Context, session smqauthn.Session, clientID string, patch map[string]any, revision uint64) (t twins.Twin, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.Group("twin",
+				slog.String("client_id", clientID),
+				slog.Int("fields", len(patch)),
+				slog.Uint64("revision", revision),
+				slog.Uint64("desired_revision", t.Desired.Revision),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Update twin desired state failed", args...)
+			return
+		}
+		lm.logger.Info("Update twin desired state completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.UpdateDesired(ctx, session, clientID, patch, revision)
+}
+
+func (lm *loggingMiddleware) RemoveTwin(ctx context.Context, session smqauthn.Session, clientID string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("domain_id", session.DomainID),
+			slog.String("client_id", clientID),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Remove twin failed", args...)
+			return
+		}
+		lm.logger.Info("Remove twin completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.RemoveTwin(ctx, session, clientID)
+}
+
+func (lm *loggingMiddleware) Report(ctx context.Context, msg *messaging.Message) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.String("channel_id", msg.GetChannel()),
+			slog.String("subtopic", msg.GetSubtopic()),
+			slog.String("publisher", msg.GetPublisher()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Twin report failed", args...)
+			return
+		}
+		lm.logger.Info("Twin report completed successfully", args...)
+	}(time.Now())
+
+	return lm.svc.Report(ctx, msg)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+	"time"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/twins"
+	"github.com/go-kit/kit/metrics"
+)
+
+var _ twins.Service = (*metricsMiddleware)(nil)
+
+type metricsMiddleware struct {
+	counter metrics.Counter
+	latency metrics.Histogram
+	svc     twins.Service
+}
+
+// MetricsMiddleware instruments core service by tracking request count and latency.
+func MetricsMiddleware(svc twins.Service, counter metrics.Counter, latency metrics.Histogram) twins.Service {
+	return &metricsMiddleware{
+		counter: counter,
+		latency: latency,
+		svc:     svc,
+	}
+}
+
+func (mm *metricsMiddleware) CreateTwin(ctx context.Context, session smqauthn.Session, twin twins.Twin) (twins.Twin, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "create_twin").Add(1)
+		mm.latency.With("method", "create_twin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.CreateTwin(ctx, session, twin)
+}
+
+func (mm *metricsMiddleware) ViewTwin(ctx context.Context, session smqauthn.Session, clientID string) (twins.Twin, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "view_twin").Add(1)
+		mm.latency.With("method", "view_twin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.ViewTwin(ctx, session, clientID)
+}
+
+func (mm *metricsMiddleware) UpdateDesired(ctx context.Context, session smqauthn.Session, clientID string, patch map[string]any, revision uint64) (twins.Twin, error) {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "update_desired").Add(1)
+		mm.latency.With("method", "update_desired").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.UpdateDesired(ctx, session, clientID, patch, revision)
+}
+
+func (mm *metricsMiddleware) RemoveTwin(ctx context.Context, session smqauthn.Session, clientID string) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "remove_twin").Add(1)
+		mm.latency.With("method", "remove_twin").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.RemoveTwin(ctx, session, clientID)
+}
+
+func (mm *metricsMiddleware) Report(ctx context.Context, msg *messaging.Message) error {
+	defer func(begin time.Time) {
+		mm.counter.With("method", "report").Add(1)
+		mm.latency.With("method", "report").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+
+	return mm.svc.Report(ctx, msg)
+}
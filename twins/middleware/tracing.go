@@ -0,0 +1,75 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package middleware
+
+import (
+	"context"
+
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/twins"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var _ twins.Service = (*tracing)(nil)
+
+type tracing struct {
+	tracer trace.Tracer
+	svc    twins.Service
+}
+
+// Tracing returns a new twins service with tracing capabilities.
+func Tracing(svc twins.Service, tracer trace.Tracer) twins.Service {
+	return &tracing{tracer, svc}
+}
+
+func (tm *tracing) CreateTwin(ctx context.Context, session smqauthn.Session, twin twins.Twin) (twins.Twin, error) {
+	ctx, span := tm.tracer.Start(ctx, "create_twin", trace.WithAttributes(
+		attribute.String("client_id", twin.ClientID),
+		attribute.String("channel_id", twin.ChannelID),
+	))
+	defer span.End()
+
+	return tm.svc.CreateTwin(ctx, session, twin)
+}
+
+func (tm *tracing) ViewTwin(ctx context.Context, session smqauthn.Session, clientID string) (twins.Twin, error) {
+	ctx, span := tm.tracer.Start(ctx, "view_twin", trace.WithAttributes(
+		attribute.String("client_id", clientID),
+	))
+	defer span.End()
+
+	return tm.svc.ViewTwin(ctx, session, clientID)
+}
+
+func (tm *tracing) UpdateDesired(ctx context.Context, session smqauthn.Session, clientID string, patch map[string]any, revision uint64) (twins.Twin, error) {
+	ctx, span := tm.tracer.Start(ctx, "update_desired", trace.WithAttributes(
+		attribute.String("client_id", clientID),
+		attribute.Int("fields", len(patch)),
+		attribute.Int64("revision", int64(revision)),
+	))
+	defer span.End()
+
+	return tm.svc.UpdateDesired(ctx, session, clientID, patch, revision)
+}
+
+func (tm *tracing) RemoveTwin(ctx context.Context, session smqauthn.Session, clientID string) error {
+	ctx, span := tm.tracer.Start(ctx, "remove_twin", trace.WithAttributes(
+		attribute.String("client_id", clientID),
+	))
+	defer span.End()
+
+	return tm.svc.RemoveTwin(ctx, session, clientID)
+}
+
+func (tm *tracing) Report(ctx context.Context, msg *messaging.Message) error {
+	ctx, span := tm.tracer.Start(ctx, "report", trace.WithAttributes(
+		attribute.String("channel_id", msg.GetChannel()),
+		attribute.String("subtopic", msg.GetSubtopic()),
+	))
+	defer span.End()
+
+	return tm.svc.Report(ctx, msg)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	_ "github.com/jackc/pgx/v5/stdlib" // required for SQL access
+	migrate "github.com/rubenv/sql-migrate"
+)
+
+// Migration of twins service.
+func Migration() *migrate.MemoryMigrationSource {
+	return &migrate.MemoryMigrationSource{
+		Migrations: []*migrate.Migration{
+			{
+				Id: "twins_01",
+				Up: []string{
+					`CREATE TABLE IF NOT EXISTS twins (
+						client_id           VARCHAR(36) PRIMARY KEY,
+						domain_id           VARCHAR(36) NOT NULL,
+						channel_id          VARCHAR(36) NOT NULL,
+						desired             JSONB NOT NULL DEFAULT '{}',
+						desired_revisions   JSONB NOT NULL DEFAULT '{}',
+						desired_revision    BIGINT NOT NULL DEFAULT 0,
+						desired_updated_at  TIMESTAMP,
+						reported            JSONB NOT NULL DEFAULT '{}',
+						reported_revisions  JSONB NOT NULL DEFAULT '{}',
+						reported_revision   BIGINT NOT NULL DEFAULT 0,
+						reported_updated_at TIMESTAMP,
+						created_at          TIMESTAMP NOT NULL,
+						created_by          VARCHAR(254) NOT NULL,
+						updated_at          TIMESTAMP
+					)`,
+					`CREATE INDEX idx_twins_domain_id ON twins(domain_id);`,
+				},
+				Down: []string{
+					`DROP TABLE IF EXISTS twins`,
+				},
+			},
+		},
+	}
+}
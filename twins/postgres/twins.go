@@ -0,0 +1,259 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/postgres"
+	"github.com/absmach/supermq/twins"
+)
+
+const twinColumns = `client_id, domain_id, channel_id,
+	desired, desired_revisions, desired_revision, desired_updated_at,
+	reported, reported_revisions, reported_revision, reported_updated_at,
+	created_at, created_by, updated_at`
+
+var _ twins.Repository = (*twinsRepo)(nil)
+
+type twinsRepo struct {
+	db postgres.Database
+}
+
+// NewRepository instantiates a PostgreSQL implementation of twins repository.
+func NewRepository(db postgres.Database) twins.Repository {
+	return &twinsRepo{db: db}
+}
+
+func (repo *twinsRepo) Save(ctx context.Context, twin twins.Twin) (twins.Twin, error) {
+	dbt, err := toDBTwin(twin)
+	if err != nil {
+		return twins.Twin{}, errors.Wrap(repoerr.ErrCreateEntity, err)
+	}
+
+	q := fmt.Sprintf(`INSERT INTO twins (%s)
+		VALUES (:client_id, :domain_id, :channel_id,
+		:desired, :desired_revisions, :desired_revision, :desired_updated_at,
+		:reported, :reported_revisions, :reported_revision, :reported_updated_at,
+		:created_at, :created_by, :updated_at)
+		RETURNING %s`, twinColumns, twinColumns)
+
+	row, err := repo.db.NamedQueryContext(ctx, q, dbt)
+	if err != nil {
+		return twins.Twin{}, postgres.HandleError(repoerr.ErrCreateEntity, err)
+	}
+	defer row.Close()
+
+	return scanTwin(row, repoerr.ErrCreateEntity)
+}
+
+func (repo *twinsRepo) Retrieve(ctx context.Context, clientID string) (twins.Twin, error) {
+	q := fmt.Sprintf(`SELECT %s FROM twins WHERE client_id = $1`, twinColumns)
+
+	var dbt dbTwin
+	if err := repo.db.QueryRowxContext(ctx, q, clientID).StructScan(&dbt); err != nil {
+		if err == sql.ErrNoRows {
+			return twins.Twin{}, repoerr.ErrNotFound
+		}
+		return twins.Twin{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+
+	return fromDBTwin(dbt)
+}
+
+func (repo *twinsRepo) UpdateDesired(ctx context.Context, twin twins.Twin, revision uint64) (twins.Twin, error) {
+	q := fmt.Sprintf(`UPDATE twins SET desired = :desired, desired_revisions = :desired_revisions,
+		desired_revision = :desired_revision, desired_updated_at = :desired_updated_at, updated_at = :updated_at
+		WHERE client_id = :client_id AND desired_revision = :revision
+		RETURNING %s`, twinColumns)
+
+	return repo.update(ctx, q, twin, revision)
+}
+
+func (repo *twinsRepo) UpdateReported(ctx context.Context, twin twins.Twin, revision uint64) (twins.Twin, error) {
+	q := fmt.Sprintf(`UPDATE twins SET reported = :reported, reported_revisions = :reported_revisions,
+		reported_revision = :reported_revision, reported_updated_at = :reported_updated_at, updated_at = :updated_at
+		WHERE client_id = :client_id AND reported_revision = :revision
+		RETURNING %s`, twinColumns)
+
+	return repo.update(ctx, q, twin, revision)
+}
+
+func (repo *twinsRepo) Remove(ctx context.Context, clientID string) error {
+	q := `DELETE FROM twins WHERE client_id = $1`
+
+	result, err := repo.db.ExecContext(ctx, q, clientID)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrRemoveEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *twinsRepo) update(ctx context.Context, q string, twin twins.Twin, revision uint64) (twins.Twin, error) {
+	dbt, err := toDBTwin(twin)
+	if err != nil {
+		return twins.Twin{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	params := dbTwinUpdate{
+		dbTwin:   dbt,
+		Revision: revision,
+	}
+
+	row, err := repo.db.NamedQueryContext(ctx, q, params)
+	if err != nil {
+		return twins.Twin{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	return scanTwin(row, repoerr.ErrUpdateEntity)
+}
+
+type rowScanner interface {
+	Next() bool
+	StructScan(dest interface{}) error
+}
+
+func scanTwin(row rowScanner, wrapErr error) (twins.Twin, error) {
+	if !row.Next() {
+		return twins.Twin{}, repoerr.ErrNotFound
+	}
+
+	var dbt dbTwin
+	if err := row.StructScan(&dbt); err != nil {
+		return twins.Twin{}, errors.Wrap(wrapErr, err)
+	}
+
+	return fromDBTwin(dbt)
+}
+
+type dbTwin struct {
+	ClientID          string       `db:"client_id"`
+	DomainID          string       `db:"domain_id"`
+	ChannelID         string       `db:"channel_id"`
+	Desired           []byte       `db:"desired"`
+	DesiredRevisions  []byte       `db:"desired_revisions"`
+	DesiredRevision   uint64       `db:"desired_revision"`
+	DesiredUpdatedAt  sql.NullTime `db:"desired_updated_at"`
+	Reported          []byte       `db:"reported"`
+	ReportedRevisions []byte       `db:"reported_revisions"`
+	ReportedRevision  uint64       `db:"reported_revision"`
+	ReportedUpdatedAt sql.NullTime `db:"reported_updated_at"`
+	CreatedAt         time.Time    `db:"created_at"`
+	CreatedBy         string       `db:"created_by"`
+	UpdatedAt         sql.NullTime `db:"updated_at"`
+}
+
+type dbTwinUpdate struct {
+	dbTwin
+	Revision uint64 `db:"revision"`
+}
+
+func toDBTwin(twin twins.Twin) (dbTwin, error) {
+	desired, desiredRevs, err := toDBState(twin.Desired)
+	if err != nil {
+		return dbTwin{}, err
+	}
+	reported, reportedRevs, err := toDBState(twin.Reported)
+	if err != nil {
+		return dbTwin{}, err
+	}
+
+	return dbTwin{
+		ClientID:          twin.ClientID,
+		DomainID:          twin.DomainID,
+		ChannelID:         twin.ChannelID,
+		Desired:           desired,
+		DesiredRevisions:  desiredRevs,
+		DesiredRevision:   twin.Desired.Revision,
+		DesiredUpdatedAt:  toNullTime(twin.Desired.UpdatedAt),
+		Reported:          reported,
+		ReportedRevisions: reportedRevs,
+		ReportedRevision:  twin.Reported.Revision,
+		ReportedUpdatedAt: toNullTime(twin.Reported.UpdatedAt),
+		CreatedAt:         twin.CreatedAt,
+		CreatedBy:         twin.CreatedBy,
+		UpdatedAt:         toNullTime(twin.UpdatedAt),
+	}, nil
+}
+
+func fromDBTwin(dbt dbTwin) (twins.Twin, error) {
+	desired, err := fromDBState(dbt.Desired, dbt.DesiredRevisions, dbt.DesiredRevision, dbt.DesiredUpdatedAt)
+	if err != nil {
+		return twins.Twin{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+	reported, err := fromDBState(dbt.Reported, dbt.ReportedRevisions, dbt.ReportedRevision, dbt.ReportedUpdatedAt)
+	if err != nil {
+		return twins.Twin{}, errors.Wrap(repoerr.ErrViewEntity, err)
+	}
+
+	twin := twins.Twin{
+		ClientID:  dbt.ClientID,
+		DomainID:  dbt.DomainID,
+		ChannelID: dbt.ChannelID,
+		Desired:   desired,
+		Reported:  reported,
+		CreatedAt: dbt.CreatedAt,
+		CreatedBy: dbt.CreatedBy,
+	}
+	if dbt.UpdatedAt.Valid {
+		twin.UpdatedAt = dbt.UpdatedAt.Time
+	}
+
+	return twin, nil
+}
+
+func toDBState(s twins.State) ([]byte, []byte, error) {
+	doc := s.Document
+	if doc == nil {
+		doc = map[string]any{}
+	}
+	revs := s.Revisions
+	if revs == nil {
+		revs = map[string]uint64{}
+	}
+
+	docData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	revsData, err := json.Marshal(revs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return docData, revsData, nil
+}
+
+func fromDBState(doc, revs []byte, revision uint64, updatedAt sql.NullTime) (twins.State, error) {
+	s := twins.State{Revision: revision}
+	if err := json.Unmarshal(doc, &s.Document); err != nil {
+		return twins.State{}, err
+	}
+	if err := json.Unmarshal(revs, &s.Revisions); err != nil {
+		return twins.State{}, err
+	}
+	if updatedAt.Valid {
+		s.UpdatedAt = updatedAt.Time
+	}
+
+	return s, nil
+}
+
+func toNullTime(t time.Time) sql.NullTime {
+	if t.IsZero() {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: t, Valid: true}
+}
@@ -0,0 +1,250 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/twins"
+	twinspg "github.com/absmach/supermq/twins/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTwin(t *testing.T) twins.Twin {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	return twins.Twin{
+		ClientID:  testsutil.GenerateUUID(t),
+		DomainID:  testsutil.GenerateUUID(t),
+		ChannelID: testsutil.GenerateUUID(t),
+		Desired: twins.State{
+			Document:  map[string]any{"mode": "eco", "interval": float64(10)},
+			Revisions: map[string]uint64{"mode": 1, "interval": 1},
+			Revision:  1,
+			UpdatedAt: now,
+		},
+		Reported: twins.State{
+			Document:  map[string]any{},
+			Revisions: map[string]uint64{},
+		},
+		CreatedAt: now,
+		CreatedBy: testsutil.GenerateUUID(t),
+	}
+}
+
+func cleanup(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM twins")
+		require.Nil(t, err, fmt.Sprintf("clean twins unexpected error: %s", err))
+	})
+}
+
+func TestSave(t *testing.T) {
+	cleanup(t)
+	repo := twinspg.NewRepository(database)
+
+	twin := newTwin(t)
+	noDesired := newTwin(t)
+	noDesired.Desired = twins.State{Document: map[string]any{}, Revisions: map[string]uint64{}}
+
+	cases := []struct {
+		desc string
+		twin twins.Twin
+		err  error
+	}{
+		{
+			desc: "save twin successfully",
+			twin: twin,
+		},
+		{
+			desc: "save twin without desired state",
+			twin: noDesired,
+		},
+		{
+			desc: "save duplicate twin",
+			twin: twin,
+			err:  repoerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			saved, err := repo.Save(context.Background(), tc.twin)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.twin, saved, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.twin, saved))
+			}
+		})
+	}
+}
+
+func TestRetrieve(t *testing.T) {
+	cleanup(t)
+	repo := twinspg.NewRepository(database)
+
+	twin, err := repo.Save(context.Background(), newTwin(t))
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		twin twins.Twin
+		err  error
+	}{
+		{
+			desc: "retrieve existing twin",
+			id:   twin.ClientID,
+			twin: twin,
+		},
+		{
+			desc: "retrieve non-existing twin",
+			id:   testsutil.GenerateUUID(t),
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tw, err := repo.Retrieve(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.twin, tw, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.twin, tw))
+		})
+	}
+}
+
+func TestUpdateDesired(t *testing.T) {
+	cleanup(t)
+	repo := twinspg.NewRepository(database)
+
+	twin, err := repo.Save(context.Background(), newTwin(t))
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	updated := twin
+	updated.Desired, _ = twin.Desired.Apply(map[string]any{"mode": "boost", "interval": nil}, now)
+	updated.UpdatedAt = now
+
+	missing := updated
+	missing.ClientID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc     string
+		twin     twins.Twin
+		revision uint64
+		err      error
+	}{
+		{
+			desc:     "update desired state successfully",
+			twin:     updated,
+			revision: 1,
+		},
+		{
+			desc:     "update desired state with revision mismatch",
+			twin:     updated,
+			revision: 1,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "update desired state of non-existing twin",
+			twin:     missing,
+			revision: 1,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tw, err := repo.UpdateDesired(context.Background(), tc.twin, tc.revision)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.twin, tw, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.twin, tw))
+			}
+		})
+	}
+}
+
+func TestUpdateReported(t *testing.T) {
+	cleanup(t)
+	repo := twinspg.NewRepository(database)
+
+	twin, err := repo.Save(context.Background(), newTwin(t))
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	updated := twin
+	updated.Reported, _ = twin.Reported.Apply(map[string]any{"mode": "eco", "temperature": float64(21)}, now)
+	updated.UpdatedAt = now
+
+	// The desired state isn't changed by the reported state update.
+	stale := updated
+	stale.Desired = twins.State{Document: map[string]any{}, Revisions: map[string]uint64{}}
+
+	cases := []struct {
+		desc     string
+		twin     twins.Twin
+		revision uint64
+		res      twins.Twin
+		err      error
+	}{
+		{
+			desc:     "update reported state successfully",
+			twin:     stale,
+			revision: 0,
+			res:      updated,
+		},
+		{
+			desc:     "update reported state with revision mismatch",
+			twin:     updated,
+			revision: 0,
+			err:      repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tw, err := repo.UpdateReported(context.Background(), tc.twin, tc.revision)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.res, tw, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, tw))
+			}
+		})
+	}
+}
+
+func TestRemove(t *testing.T) {
+	cleanup(t)
+	repo := twinspg.NewRepository(database)
+
+	twin, err := repo.Save(context.Background(), newTwin(t))
+	require.Nil(t, err, fmt.Sprintf("save twin unexpected error: %s", err))
+
+	cases := []struct {
+		desc string
+		id   string
+		err  error
+	}{
+		{
+			desc: "remove existing twin",
+			id:   twin.ClientID,
+		},
+		{
+			desc: "remove non-existing twin",
+			id:   twin.ClientID,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.Remove(context.Background(), tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+const contentType = "application/json"
+
+var errPublish = errors.New("failed to publish twin delta")
+
+var _ Service = (*service)(nil)
+
+type service struct {
+	repo      Repository
+	publisher messaging.Publisher
+}
+
+// NewService instantiates the twins service implementation.
+func NewService(repo Repository, publisher messaging.Publisher) Service {
+	return &service{
+		repo:      repo,
+		publisher: publisher,
+	}
+}
+
+// delta is the payload of the message published to the client.
+type delta struct {
+	ClientID string         `json:"client_id"`
+	Revision uint64         `json:"revision"`
+	State    map[string]any `json:"state"`
+}
+
+func (svc *service) CreateTwin(ctx context.Context, session authn.Session, twin Twin) (Twin, error) {
+	now := time.Now().UTC()
+	desired, _ := State{}.Apply(twin.Desired.Document, now)
+
+	twin.DomainID = session.DomainID
+	twin.Desired = desired
+	twin.Reported = State{}
+	twin.CreatedAt = now
+	twin.CreatedBy = session.UserID
+	twin.UpdatedAt = time.Time{}
+
+	twin, err := svc.repo.Save(ctx, twin)
+	if err != nil {
+		return Twin{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+
+	if err := svc.publish(ctx, twin); err != nil {
+		return Twin{}, err
+	}
+
+	return twin, nil
+}
+
+func (svc *service) ViewTwin(ctx context.Context, session authn.Session, clientID string) (Twin, error) {
+	return svc.retrieve(ctx, session, clientID)
+}
+
+func (svc *service) UpdateDesired(ctx context.Context, session authn.Session, clientID string, patch map[string]any, revision uint64) (Twin, error) {
+	twin, err := svc.retrieve(ctx, session, clientID)
+	if err != nil {
+		return Twin{}, err
+	}
+	if revision != 0 && twin.Desired.Revision != revision {
+		return Twin{}, svcerr.ErrRevisionConflict
+	}
+
+	now := time.Now().UTC()
+	desired, changed := twin.Desired.Apply(patch, now)
+	if changed {
+		prev := twin.Desired.Revision
+		twin.Desired = desired
+		twin.UpdatedAt = now
+
+		twin, err = svc.repo.UpdateDesired(ctx, twin, prev)
+		switch {
+		case err == nil:
+		case errors.Contains(err, repoerr.ErrNotFound) && revision == 0:
+			// The desired state has changed meanwhile, so the patch is
+			// applied to the current desired state.
+			return svc.UpdateDesired(ctx, session, clientID, patch, revision)
+		case errors.Contains(err, repoerr.ErrNotFound):
+			return Twin{}, svcerr.ErrRevisionConflict
+		default:
+			return Twin{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+	}
+
+	// The delta is published even if the desired state is unchanged, so
+	// the update can be used to resend the delta to the client.
+	if err := svc.publish(ctx, twin); err != nil {
+		return Twin{}, err
+	}
+
+	return twin, nil
+}
+
+func (svc *service) RemoveTwin(ctx context.Context, session authn.Session, clientID string) error {
+	if _, err := svc.retrieve(ctx, session, clientID); err != nil {
+		return err
+	}
+	if err := svc.repo.Remove(ctx, clientID); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc *service) Report(ctx context.Context, msg *messaging.Message) error {
+	clientID, ok := reportedClientID(msg.GetSubtopic())
+	if !ok || clientID != msg.GetPublisher() {
+		return ErrInvalidReport
+	}
+
+	var patch map[string]any
+	if err := json.Unmarshal(msg.GetPayload(), &patch); err != nil || patch == nil {
+		return ErrInvalidState
+	}
+
+	twin, err := svc.repo.Retrieve(ctx, clientID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if twin.ChannelID != msg.GetChannel() {
+		return ErrInvalidReport
+	}
+
+	reported, changed := twin.Reported.Apply(patch, time.Now().UTC())
+	if !changed {
+		return nil
+	}
+	prev := twin.Reported.Revision
+	twin.Reported = reported
+	twin.UpdatedAt = reported.UpdatedAt
+
+	_, err = svc.repo.UpdateReported(ctx, twin, prev)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Contains(err, repoerr.ErrNotFound):
+		// The reported state has changed meanwhile, so the report is
+		// applied to the current reported state.
+		return svc.Report(ctx, msg)
+	default:
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+}
+
+// retrieve retrieves the twin of the client from the session domain.
+func (svc *service) retrieve(ctx context.Context, session authn.Session, clientID string) (Twin, error) {
+	twin, err := svc.repo.Retrieve(ctx, clientID)
+	if err != nil {
+		return Twin{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if twin.DomainID != session.DomainID {
+		return Twin{}, svcerr.ErrNotFound
+	}
+
+	return twin, nil
+}
+
+// publish publishes the delta of the twin to the twin channel. Nothing is
+// published if the reported state matches the desired state.
+func (svc *service) publish(ctx context.Context, twin Twin) error {
+	state := twin.Delta()
+	if len(state) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(delta{
+		ClientID: twin.ClientID,
+		Revision: twin.Desired.Revision,
+		State:    state,
+	})
+	if err != nil {
+		return errors.Wrap(errPublish, err)
+	}
+
+	msg := &messaging.Message{
+		Channel:  twin.ChannelID,
+		Subtopic: twin.DeltaTopic(),
+		Protocol: Protocol,
+		Payload:  payload,
+		Created:  time.Now().UnixNano(),
+		Headers: map[string]string{
+			messaging.ContentTypeHeader:   contentType,
+			messaging.ResponseTopicHeader: twin.ReportTopic(),
+		},
+	}
+	if err := svc.publisher.Publish(ctx, twin.ChannelID, msg); err != nil {
+		return errors.Wrap(errPublish, err)
+	}
+
+	return nil
+}
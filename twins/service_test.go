@@ -0,0 +1,446 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/messaging"
+	pubsubmocks "github.com/absmach/supermq/pkg/messaging/mocks"
+	"github.com/absmach/supermq/twins"
+	"github.com/absmach/supermq/twins/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	domainID  = testsutil.GenerateUUID(&testing.T{})
+	userID    = testsutil.GenerateUUID(&testing.T{})
+	chanID    = testsutil.GenerateUUID(&testing.T{})
+	clientID  = testsutil.GenerateUUID(&testing.T{})
+	session   = smqauthn.Session{UserID: userID, DomainID: domainID, DomainUserID: domainID + "_" + userID}
+	validTwin = twins.Twin{
+		ClientID:  clientID,
+		DomainID:  domainID,
+		ChannelID: chanID,
+		Desired: twins.State{
+			Document:  map[string]any{"mode": "eco", "interval": float64(10)},
+			Revisions: map[string]uint64{"mode": 1, "interval": 1},
+			Revision:  1,
+		},
+		Reported: twins.State{
+			Document:  map[string]any{"mode": "boost", "interval": float64(10)},
+			Revisions: map[string]uint64{"mode": 1, "interval": 1},
+			Revision:  1,
+		},
+		CreatedBy: userID,
+	}
+	errPublish = errors.New("failed to publish message")
+)
+
+type deltaPayload struct {
+	ClientID string         `json:"client_id"`
+	Revision uint64         `json:"revision"`
+	State    map[string]any `json:"state"`
+}
+
+func newService() (twins.Service, *mocks.Repository, *pubsubmocks.PubSub) {
+	repo := new(mocks.Repository)
+	pubsub := new(pubsubmocks.PubSub)
+
+	return twins.NewService(repo, pubsub), repo, pubsub
+}
+
+func returnTwin(_ context.Context, twin twins.Twin, _ uint64) twins.Twin {
+	return twin
+}
+
+func decodeDelta(t *testing.T, msg *messaging.Message) deltaPayload {
+	var d deltaPayload
+	err := json.Unmarshal(msg.GetPayload(), &d)
+	assert.Nil(t, err, fmt.Sprintf("unexpected error decoding delta: %s", err))
+
+	return d
+}
+
+func reportMessage(twin twins.Twin, publisher, payload string) *messaging.Message {
+	return &messaging.Message{
+		Channel:   twin.ChannelID,
+		Subtopic:  twins.Subtopic + "." + twin.ClientID + "." + twins.ReportedSubtopic,
+		Publisher: publisher,
+		Payload:   []byte(payload),
+	}
+}
+
+func TestCreateTwin(t *testing.T) {
+	svc, repo, pubsub := newService()
+
+	cases := []struct {
+		desc    string
+		twin    twins.Twin
+		saveErr error
+		pubErr  error
+		publish bool
+		err     error
+	}{
+		{
+			desc: "create twin successfully",
+			twin: twins.Twin{
+				ClientID:  clientID,
+				ChannelID: chanID,
+				Desired:   twins.State{Document: map[string]any{"mode": "eco"}},
+			},
+			publish: true,
+		},
+		{
+			desc: "create twin without desired state",
+			twin: twins.Twin{ClientID: clientID, ChannelID: chanID},
+		},
+		{
+			desc: "create twin with failed repository save",
+			twin: twins.Twin{
+				ClientID:  clientID,
+				ChannelID: chanID,
+				Desired:   twins.State{Document: map[string]any{"mode": "eco"}},
+			},
+			saveErr: repoerr.ErrConflict,
+			err:     svcerr.ErrCreateEntity,
+		},
+		{
+			desc: "create twin with failed publish",
+			twin: twins.Twin{
+				ClientID:  clientID,
+				ChannelID: chanID,
+				Desired:   twins.State{Document: map[string]any{"mode": "eco"}},
+			},
+			pubErr:  errPublish,
+			publish: true,
+			err:     errPublish,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var msg *messaging.Message
+			saveCall := repo.On("Save", mock.Anything, mock.Anything).Return(func(_ context.Context, twin twins.Twin) twins.Twin { return twin }, tc.saveErr)
+			pubCall := pubsub.On("Publish", mock.Anything, chanID, mock.Anything).Return(tc.pubErr).Run(func(args mock.Arguments) {
+				msg = args.Get(2).(*messaging.Message)
+			})
+			res, err := svc.CreateTwin(context.Background(), session, tc.twin)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.publish, msg != nil, fmt.Sprintf("%s: expected delta published %t", tc.desc, tc.publish))
+			if tc.err == nil {
+				assert.Equal(t, domainID, res.DomainID, fmt.Sprintf("%s: expected domain %s got %s", tc.desc, domainID, res.DomainID))
+				assert.Equal(t, userID, res.CreatedBy, fmt.Sprintf("%s: expected creator %s got %s", tc.desc, userID, res.CreatedBy))
+				assert.Equal(t, uint64(0), res.Reported.Revision, fmt.Sprintf("%s: expected empty reported state", tc.desc))
+			}
+			if tc.err == nil && tc.publish {
+				assert.Equal(t, uint64(1), res.Desired.Revision, fmt.Sprintf("%s: expected desired revision 1 got %d", tc.desc, res.Desired.Revision))
+				assert.Equal(t, twins.Subtopic+"."+clientID+"."+twins.DeltaSubtopic, msg.GetSubtopic(), fmt.Sprintf("%s: unexpected delta subtopic", tc.desc))
+				headers := map[string]string{
+					messaging.ContentTypeHeader:   "application/json",
+					messaging.ResponseTopicHeader: "channels/" + chanID + "/messages/twin/" + clientID + "/reported",
+				}
+				assert.Equal(t, headers, msg.GetHeaders(), fmt.Sprintf("%s: expected headers %v got %v", tc.desc, headers, msg.GetHeaders()))
+				d := decodeDelta(t, msg)
+				assert.Equal(t, deltaPayload{ClientID: clientID, Revision: 1, State: map[string]any{"mode": "eco"}}, d, fmt.Sprintf("%s: unexpected delta %v", tc.desc, d))
+			}
+			saveCall.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestViewTwin(t *testing.T) {
+	svc, repo, _ := newService()
+
+	otherDomain := validTwin
+	otherDomain.ClientID = testsutil.GenerateUUID(t)
+	otherDomain.DomainID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc    string
+		id      string
+		res     twins.Twin
+		repoErr error
+		err     error
+	}{
+		{
+			desc: "view twin successfully",
+			id:   validTwin.ClientID,
+			res:  validTwin,
+		},
+		{
+			desc:    "view non-existing twin",
+			id:      testsutil.GenerateUUID(t),
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc: "view twin from another domain",
+			id:   otherDomain.ClientID,
+			res:  otherDomain,
+			err:  svcerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("Retrieve", mock.Anything, tc.id).Return(tc.res, tc.repoErr)
+			twin, err := svc.ViewTwin(context.Background(), session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.res, twin, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, twin))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUpdateDesired(t *testing.T) {
+	svc, repo, pubsub := newService()
+
+	cases := []struct {
+		desc      string
+		patch     map[string]any
+		revision  uint64
+		repoErr   error
+		updateErr error
+		pubErr    error
+		update    bool
+		delta     map[string]any
+		res       uint64
+		err       error
+	}{
+		{
+			desc:   "update desired state successfully",
+			patch:  map[string]any{"interval": float64(30)},
+			update: true,
+			delta:  map[string]any{"mode": "eco", "interval": float64(30)},
+			res:    2,
+		},
+		{
+			desc:     "update desired state with matching revision",
+			patch:    map[string]any{"mode": nil},
+			revision: 1,
+			update:   true,
+			res:      2,
+		},
+		{
+			desc:  "update desired state without changes",
+			patch: map[string]any{"mode": "eco"},
+			delta: map[string]any{"mode": "eco"},
+			res:   1,
+		},
+		{
+			desc:     "update desired state with stale revision",
+			patch:    map[string]any{"interval": float64(30)},
+			revision: 2,
+			err:      svcerr.ErrRevisionConflict,
+		},
+		{
+			desc:      "update desired state concurrently modified since revision",
+			patch:     map[string]any{"interval": float64(30)},
+			revision:  1,
+			updateErr: repoerr.ErrNotFound,
+			err:       svcerr.ErrRevisionConflict,
+		},
+		{
+			desc:    "update desired state of non-existing twin",
+			patch:   map[string]any{"interval": float64(30)},
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc:      "update desired state with failed repository update",
+			patch:     map[string]any{"interval": float64(30)},
+			updateErr: repoerr.ErrUpdateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:   "update desired state with failed publish",
+			patch:  map[string]any{"interval": float64(30)},
+			update: true,
+			pubErr: errPublish,
+			err:    errPublish,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var msg *messaging.Message
+			var updated bool
+			repoCall := repo.On("Retrieve", mock.Anything, clientID).Return(validTwin, tc.repoErr)
+			updateCall := repo.On("UpdateDesired", mock.Anything, mock.Anything, validTwin.Desired.Revision).Return(returnTwin, tc.updateErr).Run(func(_ mock.Arguments) {
+				updated = true
+			})
+			pubCall := pubsub.On("Publish", mock.Anything, chanID, mock.Anything).Return(tc.pubErr).Run(func(args mock.Arguments) {
+				msg = args.Get(2).(*messaging.Message)
+			})
+			twin, err := svc.UpdateDesired(context.Background(), session, clientID, tc.patch, tc.revision)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.update, updated, fmt.Sprintf("%s: expected update %t got %t", tc.desc, tc.update, updated))
+				assert.Equal(t, tc.res, twin.Desired.Revision, fmt.Sprintf("%s: expected revision %d got %d", tc.desc, tc.res, twin.Desired.Revision))
+				assert.Equal(t, tc.delta != nil, msg != nil, fmt.Sprintf("%s: unexpected delta publish", tc.desc))
+			}
+			if tc.err == nil && tc.delta != nil {
+				d := decodeDelta(t, msg)
+				assert.Equal(t, tc.delta, d.State, fmt.Sprintf("%s: expected delta %v got %v", tc.desc, tc.delta, d.State))
+				assert.Equal(t, tc.res, d.Revision, fmt.Sprintf("%s: expected delta revision %d got %d", tc.desc, tc.res, d.Revision))
+			}
+			repoCall.Unset()
+			updateCall.Unset()
+			pubCall.Unset()
+		})
+	}
+}
+
+func TestRemoveTwin(t *testing.T) {
+	svc, repo, _ := newService()
+
+	otherDomain := validTwin
+	otherDomain.DomainID = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc      string
+		twin      twins.Twin
+		repoErr   error
+		removeErr error
+		err       error
+	}{
+		{
+			desc: "remove twin successfully",
+			twin: validTwin,
+		},
+		{
+			desc:    "remove non-existing twin",
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc: "remove twin from another domain",
+			twin: otherDomain,
+			err:  svcerr.ErrNotFound,
+		},
+		{
+			desc:      "remove twin with failed repository remove",
+			twin:      validTwin,
+			removeErr: repoerr.ErrRemoveEntity,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("Retrieve", mock.Anything, clientID).Return(tc.twin, tc.repoErr)
+			removeCall := repo.On("Remove", mock.Anything, clientID).Return(tc.removeErr)
+			err := svc.RemoveTwin(context.Background(), session, clientID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+			removeCall.Unset()
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	svc, repo, _ := newService()
+
+	otherChannel := reportMessage(validTwin, clientID, `{"mode":"eco"}`)
+	otherChannel.Channel = testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc      string
+		msg       *messaging.Message
+		repoErr   error
+		updateErr error
+		update    bool
+		reported  twins.State
+		err       error
+	}{
+		{
+			desc:   "report state successfully",
+			msg:    reportMessage(validTwin, clientID, `{"mode":"eco","temperature":21}`),
+			update: true,
+			reported: twins.State{
+				Document:  map[string]any{"mode": "eco", "interval": float64(10), "temperature": float64(21)},
+				Revisions: map[string]uint64{"mode": 2, "interval": 1, "temperature": 2},
+				Revision:  2,
+			},
+		},
+		{
+			desc: "report unchanged state",
+			msg:  reportMessage(validTwin, clientID, `{"mode":"boost"}`),
+		},
+		{
+			desc: "report state with invalid subtopic",
+			msg:  &messaging.Message{Channel: chanID, Subtopic: twins.Subtopic + "." + clientID, Publisher: clientID, Payload: []byte(`{}`)},
+			err:  twins.ErrInvalidReport,
+		},
+		{
+			desc: "report state of another client",
+			msg:  reportMessage(validTwin, testsutil.GenerateUUID(t), `{"mode":"eco"}`),
+			err:  twins.ErrInvalidReport,
+		},
+		{
+			desc: "report state which is not an object",
+			msg:  reportMessage(validTwin, clientID, `["eco"]`),
+			err:  twins.ErrInvalidState,
+		},
+		{
+			desc: "report null state",
+			msg:  reportMessage(validTwin, clientID, `null`),
+			err:  twins.ErrInvalidState,
+		},
+		{
+			desc:    "report state of non-existing twin",
+			msg:     reportMessage(validTwin, clientID, `{"mode":"eco"}`),
+			repoErr: repoerr.ErrNotFound,
+			err:     svcerr.ErrViewEntity,
+		},
+		{
+			desc: "report state from another channel",
+			msg:  otherChannel,
+			err:  twins.ErrInvalidReport,
+		},
+		{
+			desc:      "report state with failed repository update",
+			msg:       reportMessage(validTwin, clientID, `{"mode":"eco"}`),
+			updateErr: repoerr.ErrUpdateEntity,
+			err:       svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var updated *twins.Twin
+			repoCall := repo.On("Retrieve", mock.Anything, clientID).Return(validTwin, tc.repoErr)
+			updateCall := repo.On("UpdateReported", mock.Anything, mock.Anything, validTwin.Reported.Revision).Return(returnTwin, tc.updateErr).Run(func(args mock.Arguments) {
+				twin := args.Get(1).(twins.Twin)
+				updated = &twin
+			})
+			err := svc.Report(context.Background(), tc.msg)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.update, updated != nil, fmt.Sprintf("%s: expected update %t", tc.desc, tc.update))
+			}
+			if tc.update {
+				reported := updated.Reported
+				reported.UpdatedAt = tc.reported.UpdatedAt
+				assert.Equal(t, tc.reported, reported, fmt.Sprintf("%s: expected reported state %v got %v", tc.desc, tc.reported, reported))
+				assert.Equal(t, validTwin.Desired, updated.Desired, fmt.Sprintf("%s: desired state must not change", tc.desc))
+			}
+			repoCall.Unset()
+			updateCall.Unset()
+		})
+	}
+}
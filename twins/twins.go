@@ -0,0 +1,192 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/absmach/supermq/pkg/authn"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/messaging"
+)
+
+const (
+	// Subtopic is the reserved channel subtopic of the twin messages.
+	// Messages of the twin are exchanged on the "twin.<client_id>" subtopic.
+	Subtopic = "twin"
+
+	// ReportedSubtopic is appended to the twin subtopic by the clients
+	// reporting their state, i.e. "twin.<client_id>.reported".
+	ReportedSubtopic = "reported"
+
+	// DeltaSubtopic is appended to the twin subtopic by the service
+	// publishing the state delta, i.e. "twin.<client_id>.delta".
+	DeltaSubtopic = "delta"
+
+	// ReportedTopic is the message broker topic of the reported states.
+	ReportedTopic = "channels.*." + Subtopic + ".*." + ReportedSubtopic
+
+	// Protocol is set on the delta messages published by the service.
+	Protocol = "twins"
+)
+
+var (
+	// ErrInvalidState indicates a state which is not a JSON object.
+	ErrInvalidState = errors.New("invalid twin state")
+
+	// ErrInvalidReport indicates a reported state which doesn't match the twin.
+	ErrInvalidReport = errors.New("invalid twin report")
+)
+
+// State represents a JSON document of the twin. Each top-level field of the
+// document is versioned separately: its revision is the document revision
+// at which the field was last changed. Nested objects are versioned and
+// replaced as a whole.
+type State struct {
+	Document  map[string]any    `json:"document"`
+	Revisions map[string]uint64 `json:"revisions"`
+	Revision  uint64            `json:"revision"`
+	UpdatedAt time.Time         `json:"updated_at,omitempty"`
+}
+
+// Apply merges the patch into the state. Fields set to null are removed from
+// the document. The state revision is incremented only if the patch changes
+// the document, which is reported by the second return value.
+func (s State) Apply(patch map[string]any, now time.Time) (State, bool) {
+	var changed []string
+	for field, val := range patch {
+		cur, ok := s.Document[field]
+		switch {
+		case val == nil && !ok:
+		case val != nil && ok && reflect.DeepEqual(cur, val):
+		default:
+			changed = append(changed, field)
+		}
+	}
+	if len(changed) == 0 {
+		return s, false
+	}
+
+	ret := State{
+		Document:  make(map[string]any, len(s.Document)+len(changed)),
+		Revisions: make(map[string]uint64, len(s.Revisions)+len(changed)),
+		Revision:  s.Revision + 1,
+		UpdatedAt: now,
+	}
+	for field, val := range s.Document {
+		ret.Document[field] = val
+		ret.Revisions[field] = s.Revisions[field]
+	}
+	for _, field := range changed {
+		if val := patch[field]; val != nil {
+			ret.Document[field] = val
+			ret.Revisions[field] = ret.Revision
+			continue
+		}
+		delete(ret.Document, field)
+		delete(ret.Revisions, field)
+	}
+
+	return ret, true
+}
+
+// Twin represents the desired and the reported state of a client.
+type Twin struct {
+	ClientID string `json:"client_id"`
+	DomainID string `json:"domain_id"`
+
+	// ChannelID is the channel the twin messages are exchanged on.
+	ChannelID string `json:"channel_id"`
+
+	Desired   State     `json:"desired"`
+	Reported  State     `json:"reported"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy string    `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// Delta returns the desired fields which differ from the reported ones.
+func (twin Twin) Delta() map[string]any {
+	delta := make(map[string]any)
+	for field, val := range twin.Desired.Document {
+		if rep, ok := twin.Reported.Document[field]; ok && reflect.DeepEqual(rep, val) {
+			continue
+		}
+		delta[field] = val
+	}
+
+	return delta
+}
+
+// DeltaTopic returns the channel subtopic the delta is published to.
+func (twin Twin) DeltaTopic() string {
+	return Subtopic + "." + twin.ClientID + "." + DeltaSubtopic
+}
+
+// ReportTopic returns the topic the client publishes its reported state to.
+func (twin Twin) ReportTopic() string {
+	return "channels/" + twin.ChannelID + "/messages/" + Subtopic + "/" + twin.ClientID + "/" + ReportedSubtopic
+}
+
+// Service specifies an API that must be fulfilled by the domain service
+// implementation, and all of its decorators (e.g. logging & metrics).
+//
+//go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
+type Service interface {
+	// CreateTwin creates the twin of the client with the initial desired
+	// state. The delta is published to the twin channel.
+	CreateTwin(ctx context.Context, session authn.Session, twin Twin) (Twin, error)
+
+	// ViewTwin retrieves the twin of the client.
+	ViewTwin(ctx context.Context, session authn.Session, clientID string) (Twin, error)
+
+	// UpdateDesired merges the patch into the desired state of the twin and
+	// publishes the delta to the twin channel. If the revision is set, the
+	// update is rejected unless it matches the current desired revision.
+	UpdateDesired(ctx context.Context, session authn.Session, clientID string, patch map[string]any, revision uint64) (Twin, error)
+
+	// RemoveTwin removes the twin of the client.
+	RemoveTwin(ctx context.Context, session authn.Session, clientID string) error
+
+	// Report handles the state reported by the client.
+	Report(ctx context.Context, msg *messaging.Message) error
+}
+
+// Repository specifies a twin persistence API.
+//
+//go:generate mockery --name Repository --output=./mocks --filename repository.go --quiet --note "Copyright (c) Abstract Machines"
+type Repository interface {
+	// Save persists the twin.
+	Save(ctx context.Context, twin Twin) (Twin, error)
+
+	// Retrieve retrieves the twin of the client.
+	Retrieve(ctx context.Context, clientID string) (Twin, error)
+
+	// UpdateDesired updates the desired state of the twin if its current
+	// desired revision is equal to the given revision. It returns the not
+	// found error if the twin doesn't exist or its revision doesn't match.
+	UpdateDesired(ctx context.Context, twin Twin, revision uint64) (Twin, error)
+
+	// UpdateReported updates the reported state of the twin if its current
+	// reported revision is equal to the given revision. It returns the not
+	// found error if the twin doesn't exist or its revision doesn't match.
+	UpdateReported(ctx context.Context, twin Twin, revision uint64) (Twin, error)
+
+	// Remove removes the twin of the client.
+	Remove(ctx context.Context, clientID string) error
+}
+
+// reportedClientID extracts the client ID from the reported state subtopic
+// "twin.<client_id>.reported".
+func reportedClientID(subtopic string) (string, bool) {
+	parts := strings.Split(subtopic, ".")
+	if len(parts) != 3 || parts[0] != Subtopic || parts[1] == "" || parts[2] != ReportedSubtopic {
+		return "", false
+	}
+
+	return parts[1], true
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package twins_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/twins"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateApply(t *testing.T) {
+	now := time.Now().UTC()
+	state := twins.State{
+		Document:  map[string]any{"mode": "eco", "interval": float64(10)},
+		Revisions: map[string]uint64{"mode": 1, "interval": 2},
+		Revision:  2,
+	}
+
+	cases := []struct {
+		desc    string
+		patch   map[string]any
+		res     twins.State
+		changed bool
+	}{
+		{
+			desc:  "apply patch with new field",
+			patch: map[string]any{"led": true},
+			res: twins.State{
+				Document:  map[string]any{"mode": "eco", "interval": float64(10), "led": true},
+				Revisions: map[string]uint64{"mode": 1, "interval": 2, "led": 3},
+				Revision:  3,
+				UpdatedAt: now,
+			},
+			changed: true,
+		},
+		{
+			desc:  "apply patch with changed field",
+			patch: map[string]any{"mode": "boost", "interval": float64(10)},
+			res: twins.State{
+				Document:  map[string]any{"mode": "boost", "interval": float64(10)},
+				Revisions: map[string]uint64{"mode": 3, "interval": 2},
+				Revision:  3,
+				UpdatedAt: now,
+			},
+			changed: true,
+		},
+		{
+			desc:  "apply patch with removed field",
+			patch: map[string]any{"interval": nil},
+			res: twins.State{
+				Document:  map[string]any{"mode": "eco"},
+				Revisions: map[string]uint64{"mode": 1},
+				Revision:  3,
+				UpdatedAt: now,
+			},
+			changed: true,
+		},
+		{
+			desc:  "apply patch with nested object",
+			patch: map[string]any{"mode": map[string]any{"name": "eco"}},
+			res: twins.State{
+				Document:  map[string]any{"mode": map[string]any{"name": "eco"}, "interval": float64(10)},
+				Revisions: map[string]uint64{"mode": 3, "interval": 2},
+				Revision:  3,
+				UpdatedAt: now,
+			},
+			changed: true,
+		},
+		{
+			desc:  "apply patch with unchanged fields",
+			patch: map[string]any{"mode": "eco", "led": nil},
+			res:   state,
+		},
+		{
+			desc:  "apply empty patch",
+			patch: map[string]any{},
+			res:   state,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			res, changed := state.Apply(tc.patch, now)
+			assert.Equal(t, tc.changed, changed, fmt.Sprintf("%s: expected changed %t got %t", tc.desc, tc.changed, changed))
+			assert.Equal(t, tc.res, res, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.res, res))
+		})
+	}
+	assert.Equal(t, map[string]any{"mode": "eco", "interval": float64(10)}, state.Document, "applying patches changed the original state")
+}
+
+func TestTwinDelta(t *testing.T) {
+	cases := []struct {
+		desc     string
+		desired  map[string]any
+		reported map[string]any
+		delta    map[string]any
+	}{
+		{
+			desc:     "delta of twin without reported state",
+			desired:  map[string]any{"mode": "eco"},
+			reported: nil,
+			delta:    map[string]any{"mode": "eco"},
+		},
+		{
+			desc:     "delta of twin with different reported field",
+			desired:  map[string]any{"mode": "eco", "interval": float64(10)},
+			reported: map[string]any{"mode": "boost", "interval": float64(10)},
+			delta:    map[string]any{"mode": "eco"},
+		},
+		{
+			desc:     "delta of twin with extra reported field",
+			desired:  map[string]any{"mode": "eco"},
+			reported: map[string]any{"mode": "eco", "temperature": float64(21)},
+			delta:    map[string]any{},
+		},
+		{
+			desc:     "delta of twin with different nested object",
+			desired:  map[string]any{"led": map[string]any{"color": "red", "on": true}},
+			reported: map[string]any{"led": map[string]any{"color": "red", "on": false}},
+			delta:    map[string]any{"led": map[string]any{"color": "red", "on": true}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			twin := twins.Twin{
+				Desired:  twins.State{Document: tc.desired},
+				Reported: twins.State{Document: tc.reported},
+			}
+			delta := twin.Delta()
+			assert.Equal(t, tc.delta, delta, fmt.Sprintf("%s: expected %v got %v", tc.desc, tc.delta, delta))
+		})
+	}
+}
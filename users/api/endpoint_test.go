@@ -23,6 +23,8 @@ import (
 	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
+	oauth2pkg "github.com/absmach/supermq/pkg/oauth2"
 	oauth2mocks "github.com/absmach/supermq/pkg/oauth2/mocks"
 	"github.com/absmach/supermq/users"
 	usersapi "github.com/absmach/supermq/users/api"
@@ -30,6 +32,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/oauth2"
 )
 
 var (
@@ -93,7 +96,7 @@ func newUsersServer() (*httptest.Server, *mocks.Service, *authnmocks.Authenticat
 	provider.On("Name").Return("test")
 	authn := new(authnmocks.Authentication)
 	token := new(authmocks.TokenServiceClient)
-	usersapi.MakeHandler(svc, authn, token, true, mux, logger, "", passRegex, provider)
+	usersapi.MakeHandler(svc, authn, token, true, mux, logger, "", passRegex, nil, provider)
 
 	return httptest.NewServer(mux), svc, authn
 }
@@ -1741,6 +1744,154 @@ func TestPasswordResetRequest(t *testing.T) {
 	}
 }
 
+func TestVerifyEmail(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		status      int
+		svcRes      users.User
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "verify email with valid token",
+			data:        fmt.Sprintf(`{"token": "%s"}`, validToken),
+			contentType: contentType,
+			status:      http.StatusOK,
+			svcRes:      user,
+			err:         nil,
+		},
+		{
+			desc:        "verify email with empty token",
+			data:        `{"token": ""}`,
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "verify email with invalid token",
+			data:        fmt.Sprintf(`{"token": "%s"}`, inValidToken),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			svcErr:      svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "verify email of already verified user",
+			data:        fmt.Sprintf(`{"token": "%s"}`, validToken),
+			contentType: contentType,
+			status:      http.StatusConflict,
+			svcErr:      svcerr.ErrConflict,
+			err:         svcerr.ErrConflict,
+		},
+		{
+			desc:        "verify email with malformed data",
+			data:        fmt.Sprintf(`{"token": %s}`, validToken),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "verify email with invalid content type",
+			data:        fmt.Sprintf(`{"token": "%s"}`, validToken),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:        us.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/users/verify-email", us.URL),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			svcCall := svc.On("VerifyEmail", mock.Anything, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	testemail := "test@example.com"
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		status      int
+		svcErr      error
+		err         error
+	}{
+		{
+			desc:        "resend verification with valid email",
+			data:        fmt.Sprintf(`{"email": "%s"}`, testemail),
+			contentType: contentType,
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "resend verification with empty email",
+			data:        `{"email": ""}`,
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "resend verification for unknown email",
+			data:        fmt.Sprintf(`{"email": "%s"}`, testemail),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			svcErr:      svcerr.ErrViewEntity,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:        "resend verification with malformed data",
+			data:        fmt.Sprintf(`{"email": %s}`, testemail),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "resend verification with invalid content type",
+			data:        fmt.Sprintf(`{"email": "%s"}`, testemail),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrUnsupportedContentType,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:        us.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/users/verify-email/resend", us.URL),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+			svcCall := svc.On("ResendVerification", mock.Anything, mock.Anything).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
 func TestPasswordReset(t *testing.T) {
 	us, svc, authn := newUsersServer()
 	defer us.Close()
@@ -2053,6 +2204,24 @@ func TestUpdateSecret(t *testing.T) {
 			status:      http.StatusBadRequest,
 			err:         apiutil.ErrMissingPass,
 		},
+		{
+			desc:        "update user secret with recently used secret",
+			data:        `{"old_secret": "strongersecret", "new_secret": "strongersecret"}`,
+			user:        users.User{},
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrPasswordReused,
+		},
+		{
+			desc:        "update user secret with breached secret",
+			data:        `{"old_secret": "strongersecret", "new_secret": "password1234"}`,
+			user:        users.User{},
+			contentType: contentType,
+			token:       validToken,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrPasswordBreached,
+		},
 		{
 			desc: "update user secret with invalid contentype",
 			data: `{"old_secret": "strongersecret", "new_secret": "strongersecret"}`,
@@ -2143,46 +2312,626 @@ func TestIssueToken(t *testing.T) {
 			err:         apiutil.ErrValidation,
 		},
 		{
-			desc:        "issue token with empty secret",
-			data:        fmt.Sprintf(dataFormat, validUsername, ""),
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			desc:        "issue token with empty secret",
+			data:        fmt.Sprintf(dataFormat, validUsername, ""),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "issue token with invalid email",
+			data:        fmt.Sprintf(dataFormat, "invalid", secret),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "issue token for locked out user",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret),
+			contentType: contentType,
+			status:      http.StatusTooManyRequests,
+			err:         lockout.ErrLocked,
+		},
+		{
+			desc:        "issue token with expired password",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrPasswordExpired,
+		},
+		{
+			desc:        "issue token with failed attempt locking out user",
+			data:        fmt.Sprintf(dataFormat, validUsername, "wrongsecret"),
+			contentType: contentType,
+			status:      http.StatusTooManyRequests,
+			err:         lockout.ErrLockout,
+		},
+		{
+			desc:        "issues token with malformed data",
+			data:        fmt.Sprintf(dataFormat, validUsername, secret),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "issue token with invalid contentype",
+			data:        fmt.Sprintf(dataFormat, "invalid", secret),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:        us.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/users/tokens/issue", us.URL),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+
+			svcCall := svc.On("IssueToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&grpcTokenV1.Token{AccessToken: validToken}, tc.err)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != nil {
+				var resBody respBody
+				err = json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if resBody.Err != "" || resBody.Message != "" {
+					err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestRefreshToken(t *testing.T) {
+	us, svc, authn := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		token       string
+		authnRes    smqauthn.Session
+		authnErr    error
+		status      int
+		refreshErr  error
+		err         error
+	}{
+		{
+			desc:        "refresh token with valid token",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, validID),
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID, DomainID: domainID},
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "refresh token with invalid token",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, inValidToken, validID),
+			contentType: contentType,
+			token:       inValidToken,
+			status:      http.StatusUnauthorized,
+			authnErr:    svcerr.ErrAuthentication,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "refresh token with empty token",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, "", validID),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			authnErr:    svcerr.ErrAuthentication,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "refresh token with invalid domain",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, "invalid"),
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID, DomainID: domainID},
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "refresh token with malformed data",
+			data:        fmt.Sprintf(`{"refresh_token": %s, "domain_id": %s}`, validToken, validID),
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID, DomainID: domainID},
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "refresh token with invalid contentype",
+			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, validID),
+			contentType: "application/xml",
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID, DomainID: domainID},
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		req := testRequest{
+			user:        us.Client(),
+			method:      http.MethodPost,
+			url:         fmt.Sprintf("%s/users/tokens/refresh", us.URL),
+			contentType: tc.contentType,
+			body:        strings.NewReader(tc.data),
+			token:       tc.token,
+		}
+		authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+		svcCall := svc.On("RefreshToken", mock.Anything, tc.authnRes, tc.token, mock.Anything).Return(&grpcTokenV1.Token{AccessToken: validToken}, tc.err)
+		res, err := req.make()
+		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+		if tc.err != nil {
+			var resBody respBody
+			err = json.NewDecoder(res.Body).Decode(&resBody)
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+			if resBody.Err != "" || resBody.Message != "" {
+				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+			}
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		}
+		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+		svcCall.Unset()
+		authnCall.Unset()
+	}
+}
+
+func TestVerifyMFA(t *testing.T) {
+	us, svc, _ := newUsersServer()
+	defer us.Close()
+
+	dataFormat := `{"mfa_token": "%s", "code": "%s"}`
+
+	cases := []struct {
+		desc        string
+		data        string
+		contentType string
+		svcRes      *grpcTokenV1.Token
+		svcErr      error
+		status      int
+		err         error
+	}{
+		{
+			desc:        "verify MFA with valid token and code",
+			data:        fmt.Sprintf(dataFormat, validToken, "123456"),
+			contentType: contentType,
+			svcRes:      &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken},
+			status:      http.StatusCreated,
+			err:         nil,
+		},
+		{
+			desc:        "verify MFA with empty token",
+			data:        fmt.Sprintf(dataFormat, "", "123456"),
+			contentType: contentType,
+			status:      http.StatusUnauthorized,
+			err:         apiutil.ErrBearerToken,
+		},
+		{
+			desc:        "verify MFA with empty code",
+			data:        fmt.Sprintf(dataFormat, validToken, ""),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "verify MFA with invalid code",
+			data:        fmt.Sprintf(dataFormat, validToken, "000000"),
+			contentType: contentType,
+			svcRes:      &grpcTokenV1.Token{},
+			svcErr:      svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "verify MFA with malformed data",
+			data:        fmt.Sprintf(`{"mfa_token": %s}`, validToken),
+			contentType: contentType,
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "verify MFA with invalid contentype",
+			data:        fmt.Sprintf(dataFormat, validToken, "123456"),
+			contentType: "application/xml",
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:        us.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/users/tokens/mfa", us.URL),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+			}
+
+			svcCall := svc.On("VerifyMFA", mock.Anything, mock.Anything, mock.Anything).Return(tc.svcRes, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != nil {
+				var resBody respBody
+				err = json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if resBody.Err != "" || resBody.Message != "" {
+					err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+		})
+	}
+}
+
+func TestEnrollMFA(t *testing.T) {
+	us, svc, authn := newUsersServer()
+	defer us.Close()
+
+	enrolment := users.MFAEnrolment{
+		Secret:        "JBSWY3DPEHPK3PXP",
+		URI:           "otpauth://totp/SuperMQ:user?secret=JBSWY3DPEHPK3PXP",
+		RecoveryCodes: []string{"abcde-fghij"},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes smqauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+		err      error
+	}{
+		{
+			desc:     "enroll MFA with valid token",
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID},
+			status:   http.StatusCreated,
+			err:      nil,
+		},
+		{
+			desc:     "enroll MFA with invalid token",
+			token:    inValidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "enroll MFA with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "enroll MFA when already enabled",
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID},
+			svcErr:   svcerr.ErrConflict,
+			status:   http.StatusConflict,
+			err:      svcerr.ErrConflict,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:   us.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/users/mfa/enroll", us.URL),
+				token:  tc.token,
+			}
+
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("EnrollMFA", mock.Anything, tc.authnRes).Return(enrolment, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != nil {
+				var resBody respBody
+				err = json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if resBody.Err != "" || resBody.Message != "" {
+					err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestEnableDisableMFA(t *testing.T) {
+	us, svc, authn := newUsersServer()
+	defer us.Close()
+
+	dataFormat := `{"code": "%s"}`
+
+	cases := []struct {
+		desc        string
+		action      string
+		data        string
+		contentType string
+		token       string
+		authnRes    smqauthn.Session
+		authnErr    error
+		svcErr      error
+		status      int
+		err         error
+	}{
+		{
+			desc:        "enable MFA with valid code",
+			action:      "enable",
+			data:        fmt.Sprintf(dataFormat, "123456"),
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID},
+			status:      http.StatusNoContent,
+			err:         nil,
+		},
+		{
+			desc:        "enable MFA with invalid code",
+			action:      "enable",
+			data:        fmt.Sprintf(dataFormat, "000000"),
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID},
+			svcErr:      svcerr.ErrMalformedEntity,
+			status:      http.StatusBadRequest,
+			err:         svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:        "enable MFA with empty code",
+			action:      "enable",
+			data:        fmt.Sprintf(dataFormat, ""),
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID},
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "enable MFA with invalid token",
+			action:      "enable",
+			data:        fmt.Sprintf(dataFormat, "123456"),
+			contentType: contentType,
+			token:       inValidToken,
+			authnErr:    svcerr.ErrAuthentication,
+			status:      http.StatusUnauthorized,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "enable MFA with invalid contentype",
+			action:      "enable",
+			data:        fmt.Sprintf(dataFormat, "123456"),
+			contentType: "application/xml",
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID},
+			status:      http.StatusUnsupportedMediaType,
+			err:         apiutil.ErrValidation,
+		},
+		{
+			desc:        "disable MFA with valid code",
+			action:      "disable",
+			data:        fmt.Sprintf(dataFormat, "123456"),
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID},
+			status:      http.StatusNoContent,
+			err:         nil,
+		},
+		{
+			desc:        "disable MFA when required by domain",
+			action:      "disable",
+			data:        fmt.Sprintf(dataFormat, "123456"),
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID},
+			svcErr:      svcerr.ErrAuthorization,
+			status:      http.StatusForbidden,
+			err:         svcerr.ErrAuthorization,
+		},
+		{
+			desc:        "disable MFA with malformed data",
+			action:      "disable",
+			data:        `{"code": 123456`,
+			contentType: contentType,
+			token:       validToken,
+			authnRes:    smqauthn.Session{UserID: validID},
+			status:      http.StatusBadRequest,
+			err:         apiutil.ErrValidation,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:        us.Client(),
+				method:      http.MethodPost,
+				url:         fmt.Sprintf("%s/users/mfa/%s", us.URL, tc.action),
+				contentType: tc.contentType,
+				body:        strings.NewReader(tc.data),
+				token:       tc.token,
+			}
+
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("EnableMFA", mock.Anything, tc.authnRes, mock.Anything).Return(tc.svcErr)
+			svcCall1 := svc.On("DisableMFA", mock.Anything, tc.authnRes, mock.Anything).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != nil {
+				var resBody respBody
+				err = json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if resBody.Err != "" || resBody.Message != "" {
+					err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall1.Unset()
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	us, svc, authn := newUsersServer()
+	defer us.Close()
+
+	sessions := []users.Session{
+		{
+			ID:        testsutil.GenerateUUID(t),
+			UserAgent: "Mozilla/5.0",
+			IPAddress: "192.168.0.1",
+		},
+	}
+
+	cases := []struct {
+		desc     string
+		token    string
+		authnRes smqauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+		err      error
+	}{
+		{
+			desc:     "list sessions with valid token",
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID},
+			status:   http.StatusOK,
+			err:      nil,
+		},
+		{
+			desc:     "list sessions with invalid token",
+			token:    inValidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
+		},
+		{
+			desc:   "list sessions with empty token",
+			token:  "",
+			status: http.StatusUnauthorized,
+			err:    apiutil.ErrBearerToken,
+		},
+		{
+			desc:     "list sessions with personal access token",
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, Type: smqauthn.PersonalAccessToken},
+			svcErr:   svcerr.ErrUnauthorizedPAT,
+			status:   http.StatusForbidden,
+			err:      svcerr.ErrUnauthorizedPAT,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:   us.Client(),
+				method: http.MethodGet,
+				url:    fmt.Sprintf("%s/users/sessions", us.URL),
+				token:  tc.token,
+			}
+
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("ListSessions", mock.Anything, tc.authnRes).Return(sessions, tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != nil {
+				var resBody respBody
+				err = json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if resBody.Err != "" || resBody.Message != "" {
+					err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	us, svc, authn := newUsersServer()
+	defer us.Close()
+
+	sessionID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc      string
+		token     string
+		sessionID string
+		authnRes  smqauthn.Session
+		authnErr  error
+		svcErr    error
+		status    int
+		err       error
+	}{
+		{
+			desc:      "revoke session with valid token",
+			token:     validToken,
+			sessionID: sessionID,
+			authnRes:  smqauthn.Session{UserID: validID},
+			status:    http.StatusNoContent,
+			err:       nil,
 		},
 		{
-			desc:        "issue token with invalid email",
-			data:        fmt.Sprintf(dataFormat, "invalid", secret),
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
+			desc:      "revoke session with invalid token",
+			token:     inValidToken,
+			sessionID: sessionID,
+			authnErr:  svcerr.ErrAuthentication,
+			status:    http.StatusUnauthorized,
+			err:       svcerr.ErrAuthentication,
 		},
 		{
-			desc:        "issues token with malformed data",
-			data:        fmt.Sprintf(dataFormat, validUsername, secret),
-			contentType: contentType,
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			desc:      "revoke session with empty token",
+			token:     "",
+			sessionID: sessionID,
+			status:    http.StatusUnauthorized,
+			err:       apiutil.ErrBearerToken,
 		},
 		{
-			desc:        "issue token with invalid contentype",
-			data:        fmt.Sprintf(dataFormat, "invalid", secret),
-			contentType: "application/xml",
-			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
+			desc:      "revoke non-existing session",
+			token:     validToken,
+			sessionID: sessionID,
+			authnRes:  smqauthn.Session{UserID: validID},
+			svcErr:    svcerr.ErrNotFound,
+			status:    http.StatusNotFound,
+			err:       svcerr.ErrNotFound,
 		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.desc, func(t *testing.T) {
 			req := testRequest{
-				user:        us.Client(),
-				method:      http.MethodPost,
-				url:         fmt.Sprintf("%s/users/tokens/issue", us.URL),
-				contentType: tc.contentType,
-				body:        strings.NewReader(tc.data),
+				user:   us.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/users/sessions/%s", us.URL, tc.sessionID),
+				token:  tc.token,
 			}
 
-			svcCall := svc.On("IssueToken", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&grpcTokenV1.Token{AccessToken: validToken}, tc.err)
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("RevokeSession", mock.Anything, tc.authnRes, tc.sessionID).Return(tc.svcErr)
 			res, err := req.make()
 			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
 			if tc.err != nil {
@@ -2196,105 +2945,74 @@ func TestIssueToken(t *testing.T) {
 			}
 			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
 			svcCall.Unset()
+			authnCall.Unset()
 		})
 	}
 }
 
-func TestRefreshToken(t *testing.T) {
+func TestRevokeSessions(t *testing.T) {
 	us, svc, authn := newUsersServer()
 	defer us.Close()
 
 	cases := []struct {
-		desc        string
-		data        string
-		contentType string
-		token       string
-		authnRes    smqauthn.Session
-		authnErr    error
-		status      int
-		refreshErr  error
-		err         error
+		desc     string
+		token    string
+		authnRes smqauthn.Session
+		authnErr error
+		svcErr   error
+		status   int
+		err      error
 	}{
 		{
-			desc:        "refresh token with valid token",
-			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, validID),
-			contentType: contentType,
-			token:       validToken,
-			authnRes:    smqauthn.Session{UserID: validID, DomainID: domainID},
-			status:      http.StatusCreated,
-			err:         nil,
-		},
-		{
-			desc:        "refresh token with invalid token",
-			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, inValidToken, validID),
-			contentType: contentType,
-			token:       inValidToken,
-			status:      http.StatusUnauthorized,
-			authnErr:    svcerr.ErrAuthentication,
-			err:         svcerr.ErrAuthentication,
-		},
-		{
-			desc:        "refresh token with empty token",
-			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, "", validID),
-			contentType: contentType,
-			status:      http.StatusUnauthorized,
-			authnErr:    svcerr.ErrAuthentication,
-			err:         apiutil.ErrBearerToken,
-		},
-		{
-			desc:        "refresh token with invalid domain",
-			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, "invalid"),
-			contentType: contentType,
-			token:       validToken,
-			authnRes:    smqauthn.Session{UserID: validID, DomainID: domainID},
-			status:      http.StatusUnauthorized,
-			err:         svcerr.ErrAuthentication,
+			desc:     "revoke sessions with valid token",
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID},
+			status:   http.StatusNoContent,
+			err:      nil,
 		},
 		{
-			desc:        "refresh token with malformed data",
-			data:        fmt.Sprintf(`{"refresh_token": %s, "domain_id": %s}`, validToken, validID),
-			contentType: contentType,
-			token:       validToken,
-			authnRes:    smqauthn.Session{UserID: validID, DomainID: domainID},
-			status:      http.StatusBadRequest,
-			err:         apiutil.ErrValidation,
+			desc:     "revoke sessions with invalid token",
+			token:    inValidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+			err:      svcerr.ErrAuthentication,
 		},
 		{
-			desc:        "refresh token with invalid contentype",
-			data:        fmt.Sprintf(`{"refresh_token": "%s", "domain_id": "%s"}`, validToken, validID),
-			contentType: "application/xml",
-			token:       validToken,
-			authnRes:    smqauthn.Session{UserID: validID, DomainID: domainID},
-			status:      http.StatusUnsupportedMediaType,
-			err:         apiutil.ErrValidation,
+			desc:     "revoke sessions with failed service",
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID},
+			svcErr:   svcerr.ErrRemoveEntity,
+			status:   http.StatusUnprocessableEntity,
+			err:      svcerr.ErrRemoveEntity,
 		},
 	}
 
 	for _, tc := range cases {
-		req := testRequest{
-			user:        us.Client(),
-			method:      http.MethodPost,
-			url:         fmt.Sprintf("%s/users/tokens/refresh", us.URL),
-			contentType: tc.contentType,
-			body:        strings.NewReader(tc.data),
-			token:       tc.token,
-		}
-		authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
-		svcCall := svc.On("RefreshToken", mock.Anything, tc.authnRes, tc.token, mock.Anything).Return(&grpcTokenV1.Token{AccessToken: validToken}, tc.err)
-		res, err := req.make()
-		assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
-		if tc.err != nil {
-			var resBody respBody
-			err = json.NewDecoder(res.Body).Decode(&resBody)
-			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
-			if resBody.Err != "" || resBody.Message != "" {
-				err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:   us.Client(),
+				method: http.MethodDelete,
+				url:    fmt.Sprintf("%s/users/sessions", us.URL),
+				token:  tc.token,
 			}
-			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
-		}
-		assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
-		svcCall.Unset()
-		authnCall.Unset()
+
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("RevokeSessions", mock.Anything, tc.authnRes).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			if tc.err != nil {
+				var resBody respBody
+				err = json.NewDecoder(res.Body).Decode(&resBody)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error while decoding response body: %s", tc.desc, err))
+				if resBody.Err != "" || resBody.Message != "" {
+					err = errors.Wrap(errors.New(resBody.Err), errors.New(resBody.Message))
+				}
+				assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			}
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
 	}
 }
 
@@ -2488,6 +3206,70 @@ func TestDisable(t *testing.T) {
 	}
 }
 
+func TestUnlock(t *testing.T) {
+	us, svc, authn := newUsersServer()
+	defer us.Close()
+
+	cases := []struct {
+		desc     string
+		id       string
+		token    string
+		authnRes smqauthn.Session
+		authnErr error
+		status   int
+		svcErr   error
+	}{
+		{
+			desc:     "unlock user as admin with valid token",
+			id:       user.ID,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID, SuperAdmin: true},
+			status:   http.StatusNoContent,
+		},
+		{
+			desc:     "unlock user with invalid token",
+			id:       user.ID,
+			token:    inValidToken,
+			authnErr: svcerr.ErrAuthentication,
+			status:   http.StatusUnauthorized,
+		},
+		{
+			desc:     "unlock user with empty id",
+			id:       "",
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID},
+			status:   http.StatusBadRequest,
+		},
+		{
+			desc:     "unlock user as normal user",
+			id:       user.ID,
+			token:    validToken,
+			authnRes: smqauthn.Session{UserID: validID, DomainID: domainID},
+			svcErr:   svcerr.ErrAuthorization,
+			status:   http.StatusForbidden,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			req := testRequest{
+				user:   us.Client(),
+				method: http.MethodPost,
+				url:    fmt.Sprintf("%s/users/%s/unlock", us.URL, tc.id),
+				token:  tc.token,
+			}
+
+			authnCall := authn.On("Authenticate", mock.Anything, tc.token).Return(tc.authnRes, tc.authnErr)
+			svcCall := svc.On("Unlock", mock.Anything, mock.Anything, tc.id).Return(tc.svcErr)
+			res, err := req.make()
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			svcCall.Unset()
+			authnCall.Unset()
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	us, svc, authn := newUsersServer()
 	defer us.Close()
@@ -3897,3 +4679,99 @@ type respBody struct {
 	Role    users.Role   `json:"role"`
 	Status  users.Status `json:"status"`
 }
+
+func TestOAuthAuthorizeAndCallback(t *testing.T) {
+	const (
+		authURL     = "https://issuer.example.com/auth"
+		errorURL    = "http://localhost:9095/error"
+		stateCookie = "oauth_state_test"
+	)
+
+	cases := []struct {
+		desc        string
+		enabled     bool
+		authURLErr  error
+		status      int
+		location    string
+		cookie      bool
+		callbackErr string
+	}{
+		{
+			desc:     "authorize with disabled provider",
+			enabled:  false,
+			status:   http.StatusSeeOther,
+			location: errorURL + "?error=oauth%20provider%20is%20disabled",
+		},
+		{
+			desc:       "authorize with unavailable provider",
+			enabled:    true,
+			authURLErr: svcerr.ErrAuthentication,
+			status:     http.StatusSeeOther,
+			location:   errorURL + "?error=" + svcerr.ErrAuthentication.Error(),
+		},
+		{
+			desc:     "authorize with enabled provider",
+			enabled:  true,
+			status:   http.StatusFound,
+			location: authURL,
+			cookie:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			provider := new(oauth2mocks.Provider)
+			provider.On("Name").Return("test")
+			provider.On("IsEnabled").Return(tc.enabled)
+			provider.On("ErrorURL").Return(errorURL)
+			provider.On("State").Return("")
+			var state oauth2pkg.AuthState
+			provider.On("AuthCodeURL", mock.Anything, mock.Anything).Return(authURL, tc.authURLErr).Run(func(args mock.Arguments) {
+				state = args.Get(1).(oauth2pkg.AuthState)
+			})
+			mux := chi.NewRouter()
+			usersapi.MakeHandler(new(mocks.Service), new(authnmocks.Authentication), new(authmocks.TokenServiceClient), true, mux, smqlog.NewMock(), "", passRegex, nil, provider)
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+			client := ts.Client()
+			client.CheckRedirect = func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			}
+
+			res, err := client.Get(ts.URL + "/oauth/authorize/test")
+			assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+			assert.Equal(t, tc.status, res.StatusCode, fmt.Sprintf("%s: expected status code %d got %d", tc.desc, tc.status, res.StatusCode))
+			assert.Equal(t, tc.location, res.Header.Get("Location"), fmt.Sprintf("%s: expected location %s got %s", tc.desc, tc.location, res.Header.Get("Location")))
+			var cookie *http.Cookie
+			for _, c := range res.Cookies() {
+				if c.Name == stateCookie {
+					cookie = c
+				}
+			}
+			if !tc.cookie {
+				assert.Nil(t, cookie, fmt.Sprintf("%s: unexpected state cookie", tc.desc))
+				return
+			}
+			assert.NotNil(t, cookie, fmt.Sprintf("%s: expected state cookie", tc.desc))
+			assert.True(t, cookie.HttpOnly, fmt.Sprintf("%s: expected HttpOnly state cookie", tc.desc))
+
+			provider.On("Exchange", mock.Anything, "code", state).Return(oauth2.Token{}, svcerr.ErrAuthentication)
+
+			callback := func(stateParam string) *http.Response {
+				req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/oauth/callback/test?state=%s&code=code", ts.URL, stateParam), nil)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				req.AddCookie(cookie)
+				res, err := client.Do(req)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected error %s", tc.desc, err))
+				return res
+			}
+
+			res = callback("invalid")
+			assert.Equal(t, errorURL+"?error=invalid%20state", res.Header.Get("Location"), fmt.Sprintf("%s: expected invalid state redirect", tc.desc))
+
+			res = callback(state.State)
+			assert.Equal(t, errorURL+"?error="+svcerr.ErrAuthentication.Error(), res.Header.Get("Location"), fmt.Sprintf("%s: expected exchange error redirect", tc.desc))
+			provider.AssertCalled(t, "Exchange", mock.Anything, "code", state)
+		})
+	}
+}
@@ -258,6 +258,18 @@ func (req changeUserStatusReq) validate() error {
 	return nil
 }
 
+type revokeSessionReq struct {
+	id string
+}
+
+func (req revokeSessionReq) validate() error {
+	if req.id == "" {
+		return apiutil.ErrMissingID
+	}
+
+	return nil
+}
+
 type loginUserReq struct {
 	Username string `json:"username,omitempty"`
 	Password string `json:"password,omitempty"`
@@ -286,6 +298,34 @@ func (req tokenReq) validate() error {
 	return nil
 }
 
+type verifyMFAReq struct {
+	MFAToken string `json:"mfa_token,omitempty"`
+	Code     string `json:"code,omitempty"`
+}
+
+func (req verifyMFAReq) validate() error {
+	if req.MFAToken == "" {
+		return apiutil.ErrBearerToken
+	}
+	if req.Code == "" {
+		return apiutil.ErrMissingMFACode
+	}
+
+	return nil
+}
+
+type mfaCodeReq struct {
+	Code string `json:"code,omitempty"`
+}
+
+func (req mfaCodeReq) validate() error {
+	if req.Code == "" {
+		return apiutil.ErrMissingMFACode
+	}
+
+	return nil
+}
+
 type passwResetReq struct {
 	Email string `json:"email"`
 	Host  string `json:"host"`
@@ -302,6 +342,30 @@ func (req passwResetReq) validate() error {
 	return nil
 }
 
+type verifyEmailReq struct {
+	Token string `json:"token"`
+}
+
+func (req verifyEmailReq) validate() error {
+	if req.Token == "" {
+		return apiutil.ErrBearerToken
+	}
+
+	return nil
+}
+
+type resendVerificationReq struct {
+	Email string `json:"email"`
+}
+
+func (req resendVerificationReq) validate() error {
+	if req.Email == "" {
+		return apiutil.ErrMissingEmail
+	}
+
+	return nil
+}
+
 type resetTokenReq struct {
 	Token    string `json:"token"`
 	Password string `json:"password"`
@@ -566,6 +566,52 @@ func TestTokenReqValidate(t *testing.T) {
 	}
 }
 
+func TestVerifyEmailReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  verifyEmailReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req:  verifyEmailReq{Token: valid},
+			err:  nil,
+		},
+		{
+			desc: "empty token",
+			req:  verifyEmailReq{Token: ""},
+			err:  apiutil.ErrBearerToken,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
+func TestResendVerificationReqValidate(t *testing.T) {
+	cases := []struct {
+		desc string
+		req  resendVerificationReq
+		err  error
+	}{
+		{
+			desc: "valid request",
+			req:  resendVerificationReq{Email: "example@example.com"},
+			err:  nil,
+		},
+		{
+			desc: "empty email",
+			req:  resendVerificationReq{Email: ""},
+			err:  apiutil.ErrMissingEmail,
+		},
+	}
+	for _, c := range cases {
+		err := c.req.validate()
+		assert.Equal(t, c.err, err, "%s: expected %s got %s\n", c.desc, c.err, err)
+	}
+}
+
 func TestPasswResetReqValidate(t *testing.T) {
 	cases := []struct {
 		desc string
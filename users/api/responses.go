@@ -11,8 +11,12 @@ import (
 	"github.com/absmach/supermq/users"
 )
 
-// MailSent message response when link is sent.
-const MailSent = "Email with reset link is sent"
+const (
+	// MailSent message response when link is sent.
+	MailSent = "Email with reset link is sent"
+	// VerificationSent message response when verification link is sent.
+	VerificationSent = "Email with verification link is sent"
+)
 
 var (
 	_ supermq.Response = (*tokenRes)(nil)
@@ -22,12 +26,16 @@ var (
 	_ supermq.Response = (*usersPageRes)(nil)
 	_ supermq.Response = (*viewMembersRes)(nil)
 	_ supermq.Response = (*passwResetReqRes)(nil)
+	_ supermq.Response = (*resendVerificationRes)(nil)
 	_ supermq.Response = (*passwChangeRes)(nil)
 	_ supermq.Response = (*assignUsersRes)(nil)
 	_ supermq.Response = (*unassignUsersRes)(nil)
 	_ supermq.Response = (*updateUserRes)(nil)
 	_ supermq.Response = (*tokenRes)(nil)
 	_ supermq.Response = (*deleteUserRes)(nil)
+	_ supermq.Response = (*unlockUserRes)(nil)
+	_ supermq.Response = (*enrollMFARes)(nil)
+	_ supermq.Response = (*mfaRes)(nil)
 )
 
 type pageRes struct {
@@ -78,9 +86,74 @@ func (res tokenRes) Headers() map[string]string {
 }
 
 func (res tokenRes) Empty() bool {
+	if res.AccessType == users.MFAAccessType {
+		return res.AccessToken == ""
+	}
+
 	return res.AccessToken == "" || res.RefreshToken == ""
 }
 
+type enrollMFARes struct {
+	users.MFAEnrolment `json:",inline"`
+}
+
+func (res enrollMFARes) Code() int {
+	return http.StatusCreated
+}
+
+func (res enrollMFARes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res enrollMFARes) Empty() bool {
+	return false
+}
+
+type mfaRes struct{}
+
+func (res mfaRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res mfaRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res mfaRes) Empty() bool {
+	return true
+}
+
+type sessionsPageRes struct {
+	Total    uint64          `json:"total"`
+	Sessions []users.Session `json:"sessions"`
+}
+
+func (res sessionsPageRes) Code() int {
+	return http.StatusOK
+}
+
+func (res sessionsPageRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res sessionsPageRes) Empty() bool {
+	return false
+}
+
+type revokeSessionRes struct{}
+
+func (res revokeSessionRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res revokeSessionRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res revokeSessionRes) Empty() bool {
+	return true
+}
+
 type updateUserRes struct {
 	users.User `json:",inline"`
 }
@@ -178,6 +251,22 @@ func (res passwResetReqRes) Empty() bool {
 	return false
 }
 
+type resendVerificationRes struct {
+	Msg string `json:"msg"`
+}
+
+func (res resendVerificationRes) Code() int {
+	return http.StatusCreated
+}
+
+func (res resendVerificationRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res resendVerificationRes) Empty() bool {
+	return false
+}
+
 type passwChangeRes struct{}
 
 func (res passwChangeRes) Code() int {
@@ -220,6 +309,20 @@ func (res unassignUsersRes) Empty() bool {
 	return true
 }
 
+type unlockUserRes struct{}
+
+func (res unlockUserRes) Code() int {
+	return http.StatusNoContent
+}
+
+func (res unlockUserRes) Headers() map[string]string {
+	return map[string]string{}
+}
+
+func (res unlockUserRes) Empty() bool {
+	return true
+}
+
 type deleteUserRes struct {
 	deleted bool
 }
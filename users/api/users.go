@@ -5,11 +5,14 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
+	"encoding/base64"
 	"encoding/json"
 	"log/slog"
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	api "github.com/absmach/supermq/api/http"
@@ -17,6 +20,7 @@ import (
 	smqauth "github.com/absmach/supermq/auth"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/pkg/oauth2"
 	"github.com/absmach/supermq/pkg/policies"
 	"github.com/absmach/supermq/users"
@@ -25,15 +29,28 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+const (
+	oauthStateCookie   = "oauth_state_"
+	oauthStateDuration = 10 * time.Minute
+)
+
 var passRegex = regexp.MustCompile("^.{8,}$")
 
 // usersHandler returns a HTTP handler for API endpoints.
-func usersHandler(svc users.Service, authn smqauthn.Authentication, tokenClient grpcTokenV1.TokenServiceClient, selfRegister bool, r *chi.Mux, logger *slog.Logger, pr *regexp.Regexp, providers ...oauth2.Provider) *chi.Mux {
+func usersHandler(svc users.Service, authn smqauthn.Authentication, tokenClient grpcTokenV1.TokenServiceClient, selfRegister bool, r *chi.Mux, logger *slog.Logger, pr *regexp.Regexp, proxies lockout.TrustedProxies, providers ...oauth2.Provider) *chi.Mux {
 	passRegex = pr
 
 	opts := []kithttp.ServerOption{
 		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
 	}
+	loginOpts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(lockoutSource(proxies), sessionInfo(proxies)),
+	}
+	refreshOpts := []kithttp.ServerOption{
+		kithttp.ServerErrorEncoder(apiutil.LoggingErrorEncoder(logger, api.EncodeError)),
+		kithttp.ServerBefore(sessionInfo(proxies)),
+	}
 
 	r.Route("/users", func(r chi.Router) {
 		switch selfRegister {
@@ -147,6 +164,13 @@ func usersHandler(svc users.Service, authn smqauthn.Authentication, tokenClient
 				opts...,
 			), "disable_user").ServeHTTP)
 
+			r.Post("/{id}/unlock", otelhttp.NewHandler(kithttp.NewServer(
+				unlockEndpoint(svc),
+				decodeChangeUserStatus,
+				api.EncodeResponse,
+				opts...,
+			), "unlock_user").ServeHTTP)
+
 			r.Delete("/{id}", otelhttp.NewHandler(kithttp.NewServer(
 				deleteEndpoint(svc),
 				decodeChangeUserStatus,
@@ -158,8 +182,54 @@ func usersHandler(svc users.Service, authn smqauthn.Authentication, tokenClient
 				refreshTokenEndpoint(svc),
 				decodeRefreshToken,
 				api.EncodeResponse,
-				opts...,
+				refreshOpts...,
 			), "refresh_token").ServeHTTP)
+
+			r.Route("/sessions", func(r chi.Router) {
+				r.Get("/", otelhttp.NewHandler(kithttp.NewServer(
+					listSessionsEndpoint(svc),
+					decodeSessions,
+					api.EncodeResponse,
+					opts...,
+				), "list_sessions").ServeHTTP)
+
+				r.Delete("/", otelhttp.NewHandler(kithttp.NewServer(
+					revokeSessionsEndpoint(svc),
+					decodeSessions,
+					api.EncodeResponse,
+					opts...,
+				), "revoke_sessions").ServeHTTP)
+
+				r.Delete("/{sessionID}", otelhttp.NewHandler(kithttp.NewServer(
+					revokeSessionEndpoint(svc),
+					decodeRevokeSession,
+					api.EncodeResponse,
+					opts...,
+				), "revoke_session").ServeHTTP)
+			})
+
+			r.Route("/mfa", func(r chi.Router) {
+				r.Post("/enroll", otelhttp.NewHandler(kithttp.NewServer(
+					enrollMFAEndpoint(svc),
+					decodeEnrollMFA,
+					api.EncodeResponse,
+					opts...,
+				), "enroll_mfa").ServeHTTP)
+
+				r.Post("/enable", otelhttp.NewHandler(kithttp.NewServer(
+					enableMFAEndpoint(svc),
+					decodeMFACode,
+					api.EncodeResponse,
+					opts...,
+				), "enable_mfa").ServeHTTP)
+
+				r.Post("/disable", otelhttp.NewHandler(kithttp.NewServer(
+					disableMFAEndpoint(svc),
+					decodeMFACode,
+					api.EncodeResponse,
+					opts...,
+				), "disable_mfa").ServeHTTP)
+			})
 		})
 	})
 
@@ -219,9 +289,16 @@ func usersHandler(svc users.Service, authn smqauthn.Authentication, tokenClient
 		issueTokenEndpoint(svc),
 		decodeCredentials,
 		api.EncodeResponse,
-		opts...,
+		loginOpts...,
 	), "issue_token").ServeHTTP)
 
+	r.Post("/users/tokens/mfa", otelhttp.NewHandler(kithttp.NewServer(
+		verifyMFAEndpoint(svc),
+		decodeVerifyMFA,
+		api.EncodeResponse,
+		loginOpts...,
+	), "verify_mfa").ServeHTTP)
+
 	r.Post("/password/reset-request", otelhttp.NewHandler(kithttp.NewServer(
 		passwordResetRequestEndpoint(svc),
 		decodePasswordResetRequest,
@@ -229,8 +306,23 @@ func usersHandler(svc users.Service, authn smqauthn.Authentication, tokenClient
 		opts...,
 	), "password_reset_req").ServeHTTP)
 
+	r.Post("/users/verify-email", otelhttp.NewHandler(kithttp.NewServer(
+		verifyEmailEndpoint(svc),
+		decodeVerifyEmail,
+		api.EncodeResponse,
+		opts...,
+	), "verify_email").ServeHTTP)
+
+	r.Post("/users/verify-email/resend", otelhttp.NewHandler(kithttp.NewServer(
+		resendVerificationEndpoint(svc),
+		decodeResendVerification,
+		api.EncodeResponse,
+		opts...,
+	), "resend_verification").ServeHTTP)
+
 	for _, provider := range providers {
-		r.HandleFunc("/oauth/callback/"+provider.Name(), oauth2CallbackHandler(provider, svc, tokenClient))
+		r.Get("/oauth/authorize/"+provider.Name(), oauth2AuthorizeHandler(provider))
+		r.HandleFunc("/oauth/callback/"+provider.Name(), oauth2CallbackHandler(provider, svc, tokenClient, proxies))
 	}
 
 	return r
@@ -478,6 +570,32 @@ func decodePasswordResetRequest(_ context.Context, r *http.Request) (interface{}
 	return req, nil
 }
 
+func decodeVerifyEmail(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	var req verifyEmailReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeResendVerification(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, apiutil.ErrUnsupportedContentType
+	}
+
+	var req resendVerificationReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
 func decodePasswordReset(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -520,6 +638,71 @@ func decodeCredentials(_ context.Context, r *http.Request) (interface{}, error)
 	return req, nil
 }
 
+// lockoutSource puts the request source to the context, so the failed login
+// attempts are also limited per source. The requests forwarded by an untrusted
+// proxy are limited per account only, since they share the proxy address.
+func lockoutSource(proxies lockout.TrustedProxies) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		if proxies.Untrusted(r) {
+			return ctx
+		}
+		return lockout.WithSource(ctx, proxies.RequestSource(r))
+	}
+}
+
+// sessionInfo puts the user agent and the IP address of the request to the
+// context, so they are recorded in the session the issued token belongs to.
+func sessionInfo(proxies lockout.TrustedProxies) kithttp.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		return smqauth.WithSessionInfo(ctx, smqauth.SessionInfo{
+			UserAgent: r.UserAgent(),
+			IPAddress: proxies.RequestSource(r),
+		})
+	}
+}
+
+func decodeVerifyMFA(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := verifyMFAReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeEnrollMFA(_ context.Context, r *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeMFACode(_ context.Context, r *http.Request) (interface{}, error) {
+	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
+		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
+	}
+
+	req := mfaCodeReq{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, errors.Wrap(err, errors.ErrMalformedEntity))
+	}
+
+	return req, nil
+}
+
+func decodeSessions(_ context.Context, r *http.Request) (interface{}, error) {
+	return nil, nil
+}
+
+func decodeRevokeSession(_ context.Context, r *http.Request) (interface{}, error) {
+	req := revokeSessionReq{
+		id: chi.URLParam(r, "sessionID"),
+	}
+
+	return req, nil
+}
+
 func decodeRefreshToken(_ context.Context, r *http.Request) (interface{}, error) {
 	if !strings.Contains(r.Header.Get("Content-Type"), api.ContentType) {
 		return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrUnsupportedContentType)
@@ -667,27 +850,98 @@ func queryPageParams(r *http.Request, defPermission string) (users.Page, error)
 	}, nil
 }
 
+// oauth2AuthorizeHandler is a http.HandlerFunc that starts the OAuth2 flow.
+// The generated state is stored in a cookie and checked in the callback.
+func oauth2AuthorizeHandler(oauth oauth2.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !oauth.IsEnabled() {
+			http.Redirect(w, r, oauth.ErrorURL()+"?error=oauth%20provider%20is%20disabled", http.StatusSeeOther)
+			return
+		}
+
+		state, err := oauth2.NewAuthState()
+		if err != nil {
+			http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
+			return
+		}
+		authURL, err := oauth.AuthCodeURL(r.Context(), state)
+		if err != nil {
+			http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
+			return
+		}
+		data, err := json.Marshal(state)
+		if err != nil {
+			http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     oauthStateCookie + oauth.Name(),
+			Value:    base64.RawURLEncoding.EncodeToString(data),
+			Path:     "/",
+			MaxAge:   int(oauthStateDuration.Seconds()),
+			HttpOnly: true,
+			Secure:   true,
+			// Lax mode is required since the provider redirects back with a
+			// cross-site top-level navigation.
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
+// oauth2CallbackState returns the state stored by oauth2AuthorizeHandler and
+// removes the cookie. If the flow was not started by the service, the state
+// is compared with the static provider state.
+func oauth2CallbackState(w http.ResponseWriter, r *http.Request, oauth oauth2.Provider) (oauth2.AuthState, bool) {
+	cookie, err := r.Cookie(oauthStateCookie + oauth.Name())
+	if err != nil {
+		return oauth2.AuthState{}, r.FormValue("state") == oauth.State()
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookie.Name,
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+	})
+
+	data, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return oauth2.AuthState{}, false
+	}
+	var state oauth2.AuthState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return oauth2.AuthState{}, false
+	}
+	if state.State == "" || subtle.ConstantTimeCompare([]byte(state.State), []byte(r.FormValue("state"))) != 1 {
+		return oauth2.AuthState{}, false
+	}
+
+	return state, true
+}
+
 // oauth2CallbackHandler is a http.HandlerFunc that handles OAuth2 callbacks.
-func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service, tokenClient grpcTokenV1.TokenServiceClient) http.HandlerFunc {
+func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service, tokenClient grpcTokenV1.TokenServiceClient, proxies lockout.TrustedProxies) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if !oauth.IsEnabled() {
 			http.Redirect(w, r, oauth.ErrorURL()+"?error=oauth%20provider%20is%20disabled", http.StatusSeeOther)
 			return
 		}
-		state := r.FormValue("state")
-		if state != oauth.State() {
+		state, ok := oauth2CallbackState(w, r, oauth)
+		if !ok {
 			http.Redirect(w, r, oauth.ErrorURL()+"?error=invalid%20state", http.StatusSeeOther)
 			return
 		}
 
 		if code := r.FormValue("code"); code != "" {
-			token, err := oauth.Exchange(r.Context(), code)
+			token, err := oauth.Exchange(r.Context(), code, state)
 			if err != nil {
 				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
 				return
 			}
 
-			user, err := oauth.UserInfo(token.AccessToken)
+			user, err := oauth.UserInfo(token)
 			if err != nil {
 				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
 				return
@@ -704,8 +958,10 @@ func oauth2CallbackHandler(oauth oauth2.Provider, svc users.Service, tokenClient
 			}
 
 			jwt, err := tokenClient.Issue(r.Context(), &grpcTokenV1.IssueReq{
-				UserId: user.ID,
-				Type:   uint32(smqauth.AccessKey),
+				UserId:    user.ID,
+				Type:      uint32(smqauth.AccessKey),
+				UserAgent: r.UserAgent(),
+				IpAddress: proxies.RequestSource(r),
 			})
 			if err != nil {
 				http.Redirect(w, r, oauth.ErrorURL()+"?error="+err.Error(), http.StatusSeeOther)
@@ -3,10 +3,19 @@
 
 package users
 
+import "time"
+
 // Emailer wrapper around the email.
 //
 //go:generate mockery --name Emailer --output=./mocks --filename emailer.go --quiet --note "Copyright (c) Abstract Machines"
 type Emailer interface {
 	// SendPasswordReset sends an email to the user with a link to reset the password.
 	SendPasswordReset(To []string, host, user, token string) error
+
+	// SendVerification sends an email to the user with a link to verify the email address.
+	SendVerification(To []string, user, token string) error
+
+	// SendPATExpiry sends an email to the user about the personal access
+	// token which expires soon.
+	SendPATExpiry(To []string, user, name string, expiresAt time.Time) error
 }
@@ -5,6 +5,7 @@ package emailer
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/absmach/supermq/internal/email"
 	"github.com/absmach/supermq/users"
@@ -13,17 +14,45 @@ import (
 var _ users.Emailer = (*emailer)(nil)
 
 type emailer struct {
-	resetURL string
-	agent    *email.Agent
+	resetURL          string
+	verificationURL   string
+	agent             *email.Agent
+	verificationAgent *email.Agent
+	patExpiryAgent    *email.Agent
 }
 
-// New creates new emailer utility.
-func New(url string, c *email.Config) (users.Emailer, error) {
+// New creates new emailer utility. The verification and the PAT expiry
+// e-mails use the same configuration as the password reset ones, with their
+// own templates.
+func New(resetURL, verificationURL, verificationTemplate, patExpiryTemplate string, c *email.Config) (users.Emailer, error) {
 	e, err := email.New(c)
-	return &emailer{resetURL: url, agent: e}, err
+	vc := *c
+	vc.Template = verificationTemplate
+	ve, verr := email.New(&vc)
+	if err == nil {
+		err = verr
+	}
+	pc := *c
+	pc.Template = patExpiryTemplate
+	pe, perr := email.New(&pc)
+	if err == nil {
+		err = perr
+	}
+
+	return &emailer{resetURL: resetURL, verificationURL: verificationURL, agent: e, verificationAgent: ve, patExpiryAgent: pe}, err
 }
 
 func (e *emailer) SendPasswordReset(to []string, host, user, token string) error {
 	url := fmt.Sprintf("%s%s?token=%s", host, e.resetURL, token)
 	return e.agent.Send(to, "", "Password Reset Request", "", user, url, "")
 }
+
+func (e *emailer) SendVerification(to []string, user, token string) error {
+	url := fmt.Sprintf("%s?token=%s", e.verificationURL, token)
+	return e.verificationAgent.Send(to, "", "E-mail Address Verification", "", user, url, "")
+}
+
+func (e *emailer) SendPATExpiry(to []string, user, name string, expiresAt time.Time) error {
+	content := fmt.Sprintf("Your personal access token %q expires at %s.", name, expiresAt.UTC().Format(time.RFC1123))
+	return e.patExpiryAgent.Send(to, "", "Personal Access Token Expiry", "", user, content, "")
+}
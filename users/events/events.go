@@ -8,6 +8,7 @@ import (
 
 	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/users"
 )
 
@@ -32,6 +33,17 @@ const (
 	deleteUser               = userPrefix + "delete"
 	userUpdateUsername       = userPrefix + "update_username"
 	userUpdateProfilePicture = userPrefix + "update_profile_picture"
+	verifyMFA                = userPrefix + "verify_mfa"
+	enrollMFA                = userPrefix + "enroll_mfa"
+	enableMFA                = userPrefix + "enable_mfa"
+	disableMFA               = userPrefix + "disable_mfa"
+	userLockout              = userPrefix + "lockout"
+	userUnlock               = userPrefix + "unlock"
+	listSessions             = userPrefix + "list_sessions"
+	revokeSession            = userPrefix + "revoke_session"
+	revokeSessions           = userPrefix + "revoke_sessions"
+	verifyEmail              = userPrefix + "verify_email"
+	resendVerification       = userPrefix + "resend_verification"
 )
 
 var (
@@ -54,6 +66,13 @@ var (
 	_ events.Event = (*oauthCallbackEvent)(nil)
 	_ events.Event = (*deleteUserEvent)(nil)
 	_ events.Event = (*addUserPolicyEvent)(nil)
+	_ events.Event = (*verifyMFAEvent)(nil)
+	_ events.Event = (*mfaEvent)(nil)
+	_ events.Event = (*lockoutEvent)(nil)
+	_ events.Event = (*unlockUserEvent)(nil)
+	_ events.Event = (*sessionEvent)(nil)
+	_ events.Event = (*verifyEmailEvent)(nil)
+	_ events.Event = (*resendVerificationEvent)(nil)
 )
 
 type createUserEvent struct {
@@ -129,6 +148,9 @@ func (uce updateUserEvent) Encode() (map[string]interface{}, error) {
 	if uce.Email != "" {
 		val["email"] = uce.Email
 	}
+	if uce.PendingEmail != "" {
+		val["pending_email"] = uce.PendingEmail
+	}
 	if uce.Metadata != nil {
 		val["metadata"] = uce.Metadata
 	}
@@ -497,6 +519,77 @@ func (rse resetSecretEvent) Encode() (map[string]interface{}, error) {
 	}, nil
 }
 
+type verifyMFAEvent struct{}
+
+func (vme verifyMFAEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": verifyMFA,
+	}, nil
+}
+
+type mfaEvent struct {
+	operation string
+	id        string
+}
+
+func (me mfaEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": me.operation,
+		"id":        me.id,
+	}, nil
+}
+
+type sessionEvent struct {
+	operation string
+	id        string
+	sessionID string
+}
+
+func (se sessionEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": se.operation,
+		"id":        se.id,
+	}
+	if se.sessionID != "" {
+		val["session_id"] = se.sessionID
+	}
+
+	return val, nil
+}
+
+type lockoutEvent struct {
+	kind string
+	id   string
+}
+
+func (le lockoutEvent) Encode() (map[string]interface{}, error) {
+	val := map[string]interface{}{
+		"operation": userLockout,
+		"kind":      le.kind,
+	}
+	if le.kind == lockout.SourceKind {
+		val["source"] = le.id
+		return val, nil
+	}
+	val["id"] = le.id
+
+	return val, nil
+}
+
+type unlockUserEvent struct {
+	id string
+	authn.Session
+}
+
+func (uue unlockUserEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":   userUnlock,
+		"id":          uue.id,
+		"token_type":  uue.Type.String(),
+		"super_admin": uue.SuperAdmin,
+	}, nil
+}
+
 type sendPasswordResetEvent struct {
 	host  string
 	email string
@@ -552,3 +645,28 @@ func (acpe addUserPolicyEvent) Encode() (map[string]interface{}, error) {
 		"super_admin": acpe.SuperAdmin,
 	}, nil
 }
+
+type verifyEmailEvent struct {
+	users.User
+}
+
+func (vee verifyEmailEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation":  verifyEmail,
+		"id":         vee.ID,
+		"email":      vee.Email,
+		"status":     vee.Status.String(),
+		"updated_at": vee.UpdatedAt,
+	}, nil
+}
+
+type resendVerificationEvent struct {
+	email string
+}
+
+func (rve resendVerificationEvent) Encode() (map[string]interface{}, error) {
+	return map[string]interface{}{
+		"operation": resendVerification,
+		"email":     rve.email,
+	}, nil
+}
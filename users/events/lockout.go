@@ -0,0 +1,62 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/pkg/lockout"
+)
+
+var _ lockout.Tracker = (*lockoutTracker)(nil)
+
+type lockoutTracker struct {
+	events.Publisher
+	tracker lockout.Tracker
+}
+
+// NewLockoutTracker returns wrapper around failed attempts tracker that sends
+// user lockout events to event store. Only the tracker knows which user or
+// source got locked out, so the events are not sent by the service middleware.
+func NewLockoutTracker(ctx context.Context, tracker lockout.Tracker, url string) (lockout.Tracker, error) {
+	publisher, err := store.NewPublisher(ctx, url, streamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lockoutTracker{
+		tracker:   tracker,
+		Publisher: publisher,
+	}, nil
+}
+
+func (lt *lockoutTracker) Check(ctx context.Context, keys ...string) error {
+	return lt.tracker.Check(ctx, keys...)
+}
+
+func (lt *lockoutTracker) Fail(ctx context.Context, keys ...string) ([]string, error) {
+	locked, err := lt.tracker.Fail(ctx, keys...)
+	if err != nil {
+		return locked, err
+	}
+
+	for _, key := range locked {
+		kind, id := lockout.ParseKey(key)
+		event := lockoutEvent{
+			kind: kind,
+			id:   id,
+		}
+		if err := lt.Publish(ctx, event); err != nil {
+			return locked, err
+		}
+	}
+
+	return locked, nil
+}
+
+func (lt *lockoutTracker) Reset(ctx context.Context, keys ...string) error {
+	return lt.tracker.Reset(ctx, keys...)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/pkg/events"
+	"github.com/absmach/supermq/pkg/events/store"
+	"github.com/absmach/supermq/pkg/messaging"
+	"github.com/absmach/supermq/users"
+)
+
+const (
+	// authStream is the events stream of the auth service.
+	authStream  = "events.supermq.auth"
+	patExpiring = "pat.expiring"
+)
+
+var (
+	errNoOperationKey   = errors.New("operation key is not found in event message")
+	errPATExpiringEvent = errors.New("failed to consume PAT expiring event")
+)
+
+type patHandler struct {
+	repo    users.Repository
+	emailer users.Emailer
+}
+
+// PATEventsSubscribe subscribes to the auth service events and sends the
+// PAT expiry notifications to the e-mail addresses of the PAT owners. The
+// auth service doesn't keep the e-mail addresses, so it only publishes the
+// notifications as events.
+func PATEventsSubscribe(ctx context.Context, repo users.Repository, emailer users.Emailer, esURL, esConsumerName string, logger *slog.Logger) error {
+	subscriber, err := store.NewSubscriber(ctx, esURL, logger)
+	if err != nil {
+		return err
+	}
+
+	subConfig := events.SubscriberConfig{
+		Stream:         authStream,
+		Consumer:       esConsumerName,
+		Handler:        NewPATHandler(repo, emailer),
+		DeliveryPolicy: messaging.DeliverNewPolicy,
+	}
+
+	return subscriber.Subscribe(ctx, subConfig)
+}
+
+// NewPATHandler returns the handler of the auth service events which sends
+// the PAT expiry notifications.
+func NewPATHandler(repo users.Repository, emailer users.Emailer) events.EventHandler {
+	return &patHandler{
+		repo:    repo,
+		emailer: emailer,
+	}
+}
+
+func (ph *patHandler) Handle(ctx context.Context, event events.Event) error {
+	msg, err := event.Encode()
+	if err != nil {
+		return err
+	}
+
+	op, ok := msg["operation"]
+	if !ok {
+		return errNoOperationKey
+	}
+	if op != patExpiring {
+		return nil
+	}
+
+	if err := ph.patExpiring(ctx, msg); err != nil {
+		return errors.Wrap(errPATExpiringEvent, err)
+	}
+
+	return nil
+}
+
+func (ph *patHandler) patExpiring(ctx context.Context, msg map[string]interface{}) error {
+	expiresAt, err := time.Parse(time.RFC3339, events.Read(msg, "expires_at", ""))
+	if err != nil {
+		return err
+	}
+
+	user, err := ph.repo.RetrieveByID(ctx, events.Read(msg, "user_id", ""))
+	switch {
+	// The PATs of the removed users are not notified.
+	case errors.Contains(err, repoerr.ErrNotFound):
+		return nil
+	case err != nil:
+		return err
+	}
+	if user.Status != users.EnabledStatus || user.Email == "" {
+		return nil
+	}
+
+	return ph.emailer.SendPATExpiry([]string{user.Email}, user.Credentials.Username, events.Read(msg, "name", ""), expiresAt)
+}
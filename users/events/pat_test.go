@@ -0,0 +1,110 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/absmach/supermq/internal/testsutil"
+	"github.com/absmach/supermq/pkg/errors"
+	repoerr "github.com/absmach/supermq/pkg/errors/repository"
+	"github.com/absmach/supermq/users"
+	"github.com/absmach/supermq/users/events"
+	"github.com/absmach/supermq/users/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type testEvent struct {
+	data map[string]interface{}
+}
+
+func (e testEvent) Encode() (map[string]interface{}, error) {
+	return e.data, nil
+}
+
+func TestPATHandler(t *testing.T) {
+	repo := new(mocks.Repository)
+	emailer := new(mocks.Emailer)
+	handler := events.NewPATHandler(repo, emailer)
+
+	userID := testsutil.GenerateUUID(t)
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	user := users.User{
+		ID:          userID,
+		Email:       "user@example.com",
+		Status:      users.EnabledStatus,
+		Credentials: users.Credentials{Username: "user"},
+	}
+	disabled := user
+	disabled.Status = users.DisabledStatus
+	event := map[string]interface{}{
+		"operation":  "pat.expiring",
+		"id":         testsutil.GenerateUUID(t),
+		"user_id":    userID,
+		"name":       "pat",
+		"expires_at": expiresAt.Format(time.RFC3339),
+	}
+
+	cases := []struct {
+		desc     string
+		event    map[string]interface{}
+		user     users.User
+		repoErr  error
+		sendErr  error
+		sendCall bool
+		err      error
+	}{
+		{
+			desc:     "handle PAT expiring event",
+			event:    event,
+			user:     user,
+			sendCall: true,
+		},
+		{
+			desc:     "handle PAT expiring event with failed e-mail",
+			event:    event,
+			user:     user,
+			sendCall: true,
+			sendErr:  repoerr.ErrMalformedEntity,
+			err:      repoerr.ErrMalformedEntity,
+		},
+		{
+			desc:  "handle PAT expiring event of disabled user",
+			event: event,
+			user:  disabled,
+		},
+		{
+			desc:    "handle PAT expiring event of removed user",
+			event:   event,
+			repoErr: repoerr.ErrNotFound,
+		},
+		{
+			desc: "handle other auth event",
+			event: map[string]interface{}{
+				"operation": "pat.other",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := repo.On("RetrieveByID", mock.Anything, userID).Return(tc.user, tc.repoErr)
+			sendCall := emailer.On("SendPATExpiry", []string{user.Email}, user.Credentials.Username, "pat", expiresAt).Return(tc.sendErr)
+			err := handler.Handle(context.Background(), testEvent{data: tc.event})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.sendCall {
+				emailer.AssertCalled(t, "SendPATExpiry", []string{user.Email}, user.Credentials.Username, "pat", expiresAt)
+			} else {
+				emailer.AssertNotCalled(t, "SendPATExpiry", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			repoCall.Unset()
+			sendCall.Unset()
+			emailer.Calls = nil
+		})
+	}
+}
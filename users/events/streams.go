@@ -135,6 +135,31 @@ func (es *eventStore) UpdateEmail(ctx context.Context, session authn.Session, id
 	return es.update(ctx, session, "email", user)
 }
 
+func (es *eventStore) VerifyEmail(ctx context.Context, token string) (users.User, error) {
+	user, err := es.svc.VerifyEmail(ctx, token)
+	if err != nil {
+		return user, err
+	}
+
+	event := verifyEmailEvent{user}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return user, err
+	}
+
+	return user, nil
+}
+
+func (es *eventStore) ResendVerification(ctx context.Context, email string) error {
+	if err := es.svc.ResendVerification(ctx, email); err != nil {
+		return err
+	}
+
+	event := resendVerificationEvent{email: email}
+
+	return es.Publish(ctx, event)
+}
+
 func (es *eventStore) update(ctx context.Context, session authn.Session, operation string, user users.User) (users.User, error) {
 	event := updateUserEvent{
 		user, operation, session,
@@ -253,6 +278,19 @@ func (es *eventStore) Disable(ctx context.Context, session authn.Session, id str
 	return es.delete(ctx, session, user)
 }
 
+func (es *eventStore) Unlock(ctx context.Context, session authn.Session, id string) error {
+	if err := es.svc.Unlock(ctx, session, id); err != nil {
+		return err
+	}
+
+	event := unlockUserEvent{
+		id:      id,
+		Session: session,
+	}
+
+	return es.Publish(ctx, event)
+}
+
 func (es *eventStore) delete(ctx context.Context, session authn.Session, user users.User) (users.User, error) {
 	event := removeUserEvent{
 		id:        user.ID,
@@ -332,6 +370,108 @@ func (es *eventStore) RefreshToken(ctx context.Context, session authn.Session, r
 	return token, nil
 }
 
+func (es *eventStore) VerifyMFA(ctx context.Context, mfaToken, code string) (*grpcTokenV1.Token, error) {
+	token, err := es.svc.VerifyMFA(ctx, mfaToken, code)
+	if err != nil {
+		return token, err
+	}
+
+	if err := es.Publish(ctx, verifyMFAEvent{}); err != nil {
+		return token, err
+	}
+
+	return token, nil
+}
+
+func (es *eventStore) EnrollMFA(ctx context.Context, session authn.Session) (users.MFAEnrolment, error) {
+	enrolment, err := es.svc.EnrollMFA(ctx, session)
+	if err != nil {
+		return enrolment, err
+	}
+
+	event := mfaEvent{
+		operation: enrollMFA,
+		id:        session.UserID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return enrolment, err
+	}
+
+	return enrolment, nil
+}
+
+func (es *eventStore) EnableMFA(ctx context.Context, session authn.Session, code string) error {
+	if err := es.svc.EnableMFA(ctx, session, code); err != nil {
+		return err
+	}
+
+	event := mfaEvent{
+		operation: enableMFA,
+		id:        session.UserID,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) DisableMFA(ctx context.Context, session authn.Session, code string) error {
+	if err := es.svc.DisableMFA(ctx, session, code); err != nil {
+		return err
+	}
+
+	event := mfaEvent{
+		operation: disableMFA,
+		id:        session.UserID,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) ListSessions(ctx context.Context, session authn.Session) ([]users.Session, error) {
+	sessions, err := es.svc.ListSessions(ctx, session)
+	if err != nil {
+		return sessions, err
+	}
+
+	event := sessionEvent{
+		operation: listSessions,
+		id:        session.UserID,
+	}
+
+	if err := es.Publish(ctx, event); err != nil {
+		return sessions, err
+	}
+
+	return sessions, nil
+}
+
+func (es *eventStore) RevokeSession(ctx context.Context, session authn.Session, id string) error {
+	if err := es.svc.RevokeSession(ctx, session, id); err != nil {
+		return err
+	}
+
+	event := sessionEvent{
+		operation: revokeSession,
+		id:        session.UserID,
+		sessionID: id,
+	}
+
+	return es.Publish(ctx, event)
+}
+
+func (es *eventStore) RevokeSessions(ctx context.Context, session authn.Session) error {
+	if err := es.svc.RevokeSessions(ctx, session); err != nil {
+		return err
+	}
+
+	event := sessionEvent{
+		operation: revokeSessions,
+		id:        session.UserID,
+	}
+
+	return es.Publish(ctx, event)
+}
+
 func (es *eventStore) ResetSecret(ctx context.Context, session authn.Session, secret string) error {
 	if err := es.svc.ResetSecret(ctx, session, secret); err != nil {
 		return err
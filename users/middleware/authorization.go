@@ -275,6 +275,14 @@ func (am *authorizationMiddleware) UpdateProfilePicture(ctx context.Context, ses
 	return am.svc.UpdateProfilePicture(ctx, session, user)
 }
 
+func (am *authorizationMiddleware) VerifyEmail(ctx context.Context, token string) (users.User, error) {
+	return am.svc.VerifyEmail(ctx, token)
+}
+
+func (am *authorizationMiddleware) ResendVerification(ctx context.Context, email string) error {
+	return am.svc.ResendVerification(ctx, email)
+}
+
 func (am *authorizationMiddleware) GenerateResetToken(ctx context.Context, email, host string) error {
 	return am.svc.GenerateResetToken(ctx, email, host)
 }
@@ -371,6 +379,27 @@ func (am *authorizationMiddleware) Disable(ctx context.Context, session authn.Se
 	return am.svc.Disable(ctx, session, id)
 }
 
+func (am *authorizationMiddleware) Unlock(ctx context.Context, session authn.Session, id string) error {
+	if session.Type == authn.PersonalAccessToken {
+		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
+			UserID:                   session.UserID,
+			PatID:                    session.PatID,
+			PlatformEntityType:       smqauth.PlatformUsersScope,
+			OptionalDomainEntityType: smqauth.DomainNullScope,
+			Operation:                smqauth.UpdateOp,
+			EntityIDs:                []string{id},
+		}); err != nil {
+			return errors.Wrap(svcerr.ErrUnauthorizedPAT, err)
+		}
+	}
+
+	if err := am.checkSuperAdmin(ctx, session.UserID); err == nil {
+		session.SuperAdmin = true
+	}
+
+	return am.svc.Unlock(ctx, session, id)
+}
+
 func (am *authorizationMiddleware) Delete(ctx context.Context, session authn.Session, id string) error {
 	if session.Type == authn.PersonalAccessToken {
 		if err := am.authz.AuthorizePAT(ctx, smqauthz.PatReq{
@@ -404,6 +433,58 @@ func (am *authorizationMiddleware) RefreshToken(ctx context.Context, session aut
 	return am.svc.RefreshToken(ctx, session, refreshToken)
 }
 
+func (am *authorizationMiddleware) VerifyMFA(ctx context.Context, mfaToken, code string) (*grpcTokenV1.Token, error) {
+	return am.svc.VerifyMFA(ctx, mfaToken, code)
+}
+
+func (am *authorizationMiddleware) EnrollMFA(ctx context.Context, session authn.Session) (users.MFAEnrolment, error) {
+	if session.Type == authn.PersonalAccessToken {
+		return users.MFAEnrolment{}, svcerr.ErrUnauthorizedPAT
+	}
+
+	return am.svc.EnrollMFA(ctx, session)
+}
+
+func (am *authorizationMiddleware) EnableMFA(ctx context.Context, session authn.Session, code string) error {
+	if session.Type == authn.PersonalAccessToken {
+		return svcerr.ErrUnauthorizedPAT
+	}
+
+	return am.svc.EnableMFA(ctx, session, code)
+}
+
+func (am *authorizationMiddleware) DisableMFA(ctx context.Context, session authn.Session, code string) error {
+	if session.Type == authn.PersonalAccessToken {
+		return svcerr.ErrUnauthorizedPAT
+	}
+
+	return am.svc.DisableMFA(ctx, session, code)
+}
+
+func (am *authorizationMiddleware) ListSessions(ctx context.Context, session authn.Session) ([]users.Session, error) {
+	if session.Type == authn.PersonalAccessToken {
+		return nil, svcerr.ErrUnauthorizedPAT
+	}
+
+	return am.svc.ListSessions(ctx, session)
+}
+
+func (am *authorizationMiddleware) RevokeSession(ctx context.Context, session authn.Session, id string) error {
+	if session.Type == authn.PersonalAccessToken {
+		return svcerr.ErrUnauthorizedPAT
+	}
+
+	return am.svc.RevokeSession(ctx, session, id)
+}
+
+func (am *authorizationMiddleware) RevokeSessions(ctx context.Context, session authn.Session) error {
+	if session.Type == authn.PersonalAccessToken {
+		return svcerr.ErrUnauthorizedPAT
+	}
+
+	return am.svc.RevokeSessions(ctx, session)
+}
+
 func (am *authorizationMiddleware) OAuthCallback(ctx context.Context, user users.User) (users.User, error) {
 	return am.svc.OAuthCallback(ctx, user)
 }
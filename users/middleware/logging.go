@@ -88,6 +88,148 @@ func (lm *loggingMiddleware) RefreshToken(ctx context.Context, session authn.Ses
 	return lm.svc.RefreshToken(ctx, session, refreshToken)
 }
 
+// VerifyMFA logs the verify_mfa request. It logs the token type and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) VerifyMFA(ctx context.Context, mfaToken, code string) (t *grpcTokenV1.Token, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if t.AccessType != "" {
+			args = append(args, slog.String("access_type", t.AccessType))
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Verify MFA failed", args...)
+			return
+		}
+		lm.logger.Info("Verify MFA completed successfully", args...)
+	}(time.Now())
+	return lm.svc.VerifyMFA(ctx, mfaToken, code)
+}
+
+// EnrollMFA logs the enroll_mfa request. It logs the user id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) EnrollMFA(ctx context.Context, session authn.Session) (e users.MFAEnrolment, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", session.UserID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Enroll MFA failed", args...)
+			return
+		}
+		lm.logger.Info("Enroll MFA completed successfully", args...)
+	}(time.Now())
+	return lm.svc.EnrollMFA(ctx, session)
+}
+
+// EnableMFA logs the enable_mfa request. It logs the user id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) EnableMFA(ctx context.Context, session authn.Session, code string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", session.UserID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Enable MFA failed", args...)
+			return
+		}
+		lm.logger.Info("Enable MFA completed successfully", args...)
+	}(time.Now())
+	return lm.svc.EnableMFA(ctx, session, code)
+}
+
+// DisableMFA logs the disable_mfa request. It logs the user id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) DisableMFA(ctx context.Context, session authn.Session, code string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", session.UserID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Disable MFA failed", args...)
+			return
+		}
+		lm.logger.Info("Disable MFA completed successfully", args...)
+	}(time.Now())
+	return lm.svc.DisableMFA(ctx, session, code)
+}
+
+// ListSessions logs the list_sessions request. It logs the user id, the number of sessions and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ListSessions(ctx context.Context, session authn.Session) (s []users.Session, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", session.UserID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("List sessions failed", args...)
+			return
+		}
+		args = append(args, slog.Int("sessions", len(s)))
+		lm.logger.Info("List sessions completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ListSessions(ctx, session)
+}
+
+// RevokeSession logs the revoke_session request. It logs the user id, the session id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RevokeSession(ctx context.Context, session authn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", session.UserID),
+			),
+			slog.String("session_id", id),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke session failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke session completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RevokeSession(ctx, session, id)
+}
+
+// RevokeSessions logs the revoke_sessions request. It logs the user id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) RevokeSessions(ctx context.Context, session authn.Session) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", session.UserID),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Revoke sessions failed", args...)
+			return
+		}
+		lm.logger.Info("Revoke sessions completed successfully", args...)
+	}(time.Now())
+	return lm.svc.RevokeSessions(ctx, session)
+}
+
 // View logs the view_user request. It logs the user id and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) View(ctx context.Context, session authn.Session, id string) (c users.User, err error) {
@@ -300,6 +442,44 @@ func (lm *loggingMiddleware) UpdateProfilePicture(ctx context.Context, session a
 	return lm.svc.UpdateProfilePicture(ctx, session, user)
 }
 
+// VerifyEmail logs the verify_email request. It logs the user id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) VerifyEmail(ctx context.Context, token string) (c users.User, err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", c.ID),
+				slog.String("email", c.Email),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Verify email failed", args...)
+			return
+		}
+		lm.logger.Info("Verify email completed successfully", args...)
+	}(time.Now())
+	return lm.svc.VerifyEmail(ctx, token)
+}
+
+// ResendVerification logs the resend_verification request. It logs the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) ResendVerification(ctx context.Context, email string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Resend verification failed", args...)
+			return
+		}
+		lm.logger.Info("Resend verification completed successfully", args...)
+	}(time.Now())
+	return lm.svc.ResendVerification(ctx, email)
+}
+
 // GenerateResetToken logs the generate_reset_token request. It logs the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) GenerateResetToken(ctx context.Context, email, host string) (err error) {
@@ -414,6 +594,26 @@ func (lm *loggingMiddleware) Disable(ctx context.Context, session authn.Session,
 	return lm.svc.Disable(ctx, session, id)
 }
 
+// Unlock logs the unlock_user request. It logs the user id and the time it took to complete the request.
+// If the request fails, it logs the error.
+func (lm *loggingMiddleware) Unlock(ctx context.Context, session authn.Session, id string) (err error) {
+	defer func(begin time.Time) {
+		args := []any{
+			slog.String("duration", time.Since(begin).String()),
+			slog.Group("user",
+				slog.String("id", id),
+			),
+		}
+		if err != nil {
+			args = append(args, slog.Any("error", err))
+			lm.logger.Warn("Unlock user failed", args...)
+			return
+		}
+		lm.logger.Info("Unlock user completed successfully", args...)
+	}(time.Now())
+	return lm.svc.Unlock(ctx, session, id)
+}
+
 // ListMembers logs the list_members request. It logs the group id, and the time it took to complete the request.
 // If the request fails, it logs the error.
 func (lm *loggingMiddleware) ListMembers(ctx context.Context, session authn.Session, objectKind, objectID string, cp users.Page) (mp users.MembersPage, err error) {
@@ -57,6 +57,69 @@ func (ms *metricsMiddleware) RefreshToken(ctx context.Context, session authn.Ses
 	return ms.svc.RefreshToken(ctx, session, refreshToken)
 }
 
+// VerifyMFA instruments VerifyMFA method with metrics.
+func (ms *metricsMiddleware) VerifyMFA(ctx context.Context, mfaToken, code string) (*grpcTokenV1.Token, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_mfa").Add(1)
+		ms.latency.With("method", "verify_mfa").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.VerifyMFA(ctx, mfaToken, code)
+}
+
+// EnrollMFA instruments EnrollMFA method with metrics.
+func (ms *metricsMiddleware) EnrollMFA(ctx context.Context, session authn.Session) (users.MFAEnrolment, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enroll_mfa").Add(1)
+		ms.latency.With("method", "enroll_mfa").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.EnrollMFA(ctx, session)
+}
+
+// EnableMFA instruments EnableMFA method with metrics.
+func (ms *metricsMiddleware) EnableMFA(ctx context.Context, session authn.Session, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "enable_mfa").Add(1)
+		ms.latency.With("method", "enable_mfa").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.EnableMFA(ctx, session, code)
+}
+
+// DisableMFA instruments DisableMFA method with metrics.
+func (ms *metricsMiddleware) DisableMFA(ctx context.Context, session authn.Session, code string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "disable_mfa").Add(1)
+		ms.latency.With("method", "disable_mfa").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.DisableMFA(ctx, session, code)
+}
+
+// ListSessions instruments ListSessions method with metrics.
+func (ms *metricsMiddleware) ListSessions(ctx context.Context, session authn.Session) ([]users.Session, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "list_sessions").Add(1)
+		ms.latency.With("method", "list_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ListSessions(ctx, session)
+}
+
+// RevokeSession instruments RevokeSession method with metrics.
+func (ms *metricsMiddleware) RevokeSession(ctx context.Context, session authn.Session, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_session").Add(1)
+		ms.latency.With("method", "revoke_session").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RevokeSession(ctx, session, id)
+}
+
+// RevokeSessions instruments RevokeSessions method with metrics.
+func (ms *metricsMiddleware) RevokeSessions(ctx context.Context, session authn.Session) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "revoke_sessions").Add(1)
+		ms.latency.With("method", "revoke_sessions").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.RevokeSessions(ctx, session)
+}
+
 // View instruments View method with metrics.
 func (ms *metricsMiddleware) View(ctx context.Context, session authn.Session, id string) (users.User, error) {
 	defer func(begin time.Time) {
@@ -147,6 +210,24 @@ func (ms *metricsMiddleware) UpdateProfilePicture(ctx context.Context, session a
 	return ms.svc.UpdateProfilePicture(ctx, session, user)
 }
 
+// VerifyEmail instruments VerifyEmail method with metrics.
+func (ms *metricsMiddleware) VerifyEmail(ctx context.Context, token string) (users.User, error) {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "verify_email").Add(1)
+		ms.latency.With("method", "verify_email").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.VerifyEmail(ctx, token)
+}
+
+// ResendVerification instruments ResendVerification method with metrics.
+func (ms *metricsMiddleware) ResendVerification(ctx context.Context, email string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "resend_verification").Add(1)
+		ms.latency.With("method", "resend_verification").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.ResendVerification(ctx, email)
+}
+
 // GenerateResetToken instruments GenerateResetToken method with metrics.
 func (ms *metricsMiddleware) GenerateResetToken(ctx context.Context, email, host string) error {
 	defer func(begin time.Time) {
@@ -201,6 +282,15 @@ func (ms *metricsMiddleware) Disable(ctx context.Context, session authn.Session,
 	return ms.svc.Disable(ctx, session, id)
 }
 
+// Unlock instruments Unlock method with metrics.
+func (ms *metricsMiddleware) Unlock(ctx context.Context, session authn.Session, id string) error {
+	defer func(begin time.Time) {
+		ms.counter.With("method", "unlock_user").Add(1)
+		ms.latency.With("method", "unlock_user").Observe(time.Since(begin).Seconds())
+	}(time.Now())
+	return ms.svc.Unlock(ctx, session, id)
+}
+
 // ListMembers instruments ListMembers method with metrics.
 func (ms *metricsMiddleware) ListMembers(ctx context.Context, session authn.Session, objectKind, objectID string, pm users.Page) (mp users.MembersPage, err error) {
 	defer func(begin time.Time) {
@@ -4,13 +4,35 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Emailer is an autogenerated mock type for the Emailer type
 type Emailer struct {
 	mock.Mock
 }
 
+// SendPATExpiry provides a mock function with given fields: To, user, name, expiresAt
+func (_m *Emailer) SendPATExpiry(To []string, user string, name string, expiresAt time.Time) error {
+	ret := _m.Called(To, user, name, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendPATExpiry")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string, string, string, time.Time) error); ok {
+		r0 = rf(To, user, name, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendPasswordReset provides a mock function with given fields: To, host, user, token
 func (_m *Emailer) SendPasswordReset(To []string, host string, user string, token string) error {
 	ret := _m.Called(To, host, user, token)
@@ -29,6 +51,24 @@ func (_m *Emailer) SendPasswordReset(To []string, host string, user string, toke
 	return r0
 }
 
+// SendVerification provides a mock function with given fields: To, user, token
+func (_m *Emailer) SendVerification(To []string, user string, token string) error {
+	ret := _m.Called(To, user, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string, string, string) error); ok {
+		r0 = rf(To, user, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewEmailer creates a new instance of Emailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewEmailer(t interface {
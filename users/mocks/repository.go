@@ -7,8 +7,11 @@ package mocks
 import (
 	context "context"
 
-	users "github.com/absmach/supermq/users"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	users "github.com/absmach/supermq/users"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -62,6 +65,62 @@ func (_m *Repository) CheckSuperAdmin(ctx context.Context, adminID string) error
 	return r0
 }
 
+// ConfirmEmail provides a mock function with given fields: ctx, user, nonce
+func (_m *Repository) ConfirmEmail(ctx context.Context, user users.User, nonce string) (users.User, error) {
+	ret := _m.Called(ctx, user, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEmail")
+	}
+
+	var r0 users.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, users.User, string) (users.User, error)); ok {
+		return rf(ctx, user, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, users.User, string) users.User); ok {
+		r0 = rf(ctx, user, nonce)
+	} else {
+		r0 = ret.Get(0).(users.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, users.User, string) error); ok {
+		r1 = rf(ctx, user, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmRegistration provides a mock function with given fields: ctx, user, nonce
+func (_m *Repository) ConfirmRegistration(ctx context.Context, user users.User, nonce string) (users.User, error) {
+	ret := _m.Called(ctx, user, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmRegistration")
+	}
+
+	var r0 users.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, users.User, string) (users.User, error)); ok {
+		return rf(ctx, user, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, users.User, string) users.User); ok {
+		r0 = rf(ctx, user, nonce)
+	} else {
+		r0 = ret.Get(0).(users.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, users.User, string) error); ok {
+		r1 = rf(ctx, user, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *Repository) Delete(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -136,6 +195,36 @@ func (_m *Repository) RetrieveAllByIDs(ctx context.Context, pm users.Page) (user
 	return r0, r1
 }
 
+// RetrieveAllByUnverifiedEmail provides a mock function with given fields: ctx, email
+func (_m *Repository) RetrieveAllByUnverifiedEmail(ctx context.Context, email string) ([]users.User, error) {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveAllByUnverifiedEmail")
+	}
+
+	var r0 []users.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]users.User, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []users.User); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]users.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveByEmail provides a mock function with given fields: ctx, email
 func (_m *Repository) RetrieveByEmail(ctx context.Context, email string) (users.User, error) {
 	ret := _m.Called(ctx, email)
@@ -220,6 +309,34 @@ func (_m *Repository) RetrieveByUsername(ctx context.Context, username string) (
 	return r0, r1
 }
 
+// RetrieveMFA provides a mock function with given fields: ctx, id
+func (_m *Repository) RetrieveMFA(ctx context.Context, id string) (users.MFA, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetrieveMFA")
+	}
+
+	var r0 users.MFA
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (users.MFA, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.MFA); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(users.MFA)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, user
 func (_m *Repository) Save(ctx context.Context, user users.User) (users.User, error) {
 	ret := _m.Called(ctx, user)
@@ -304,6 +421,70 @@ func (_m *Repository) Update(ctx context.Context, user users.User) (users.User,
 	return r0, r1
 }
 
+// UpdateMFA provides a mock function with given fields: ctx, id, mfa
+func (_m *Repository) UpdateMFA(ctx context.Context, id string, mfa users.MFA) error {
+	ret := _m.Called(ctx, id, mfa)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, users.MFA) error); ok {
+		r0 = rf(ctx, id, mfa)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMFAStep provides a mock function with given fields: ctx, id, step
+func (_m *Repository) UpdateMFAStep(ctx context.Context, id string, step int64) error {
+	ret := _m.Called(ctx, id, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMFAStep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, id, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePendingEmail provides a mock function with given fields: ctx, user
+func (_m *Repository) UpdatePendingEmail(ctx context.Context, user users.User) (users.User, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePendingEmail")
+	}
+
+	var r0 users.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, users.User) (users.User, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, users.User) users.User); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Get(0).(users.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, users.User) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateSecret provides a mock function with given fields: ctx, user
 func (_m *Repository) UpdateSecret(ctx context.Context, user users.User) (users.User, error) {
 	ret := _m.Called(ctx, user)
@@ -360,6 +541,24 @@ func (_m *Repository) UpdateUsername(ctx context.Context, user users.User) (user
 	return r0, r1
 }
 
+// UpdateVerification provides a mock function with given fields: ctx, id, nonce, sentBefore
+func (_m *Repository) UpdateVerification(ctx context.Context, id string, nonce string, sentBefore time.Time) error {
+	ret := _m.Called(ctx, id, nonce, sentBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, id, nonce, sentBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRepository(t interface {
@@ -67,6 +67,24 @@ func (_m *Service) Disable(ctx context.Context, session authn.Session, id string
 	return r0, r1
 }
 
+// DisableMFA provides a mock function with given fields: ctx, session, code
+func (_m *Service) DisableMFA(ctx context.Context, session authn.Session, code string) error {
+	ret := _m.Called(ctx, session, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = rf(ctx, session, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Enable provides a mock function with given fields: ctx, session, id
 func (_m *Service) Enable(ctx context.Context, session authn.Session, id string) (users.User, error) {
 	ret := _m.Called(ctx, session, id)
@@ -95,6 +113,52 @@ func (_m *Service) Enable(ctx context.Context, session authn.Session, id string)
 	return r0, r1
 }
 
+// EnableMFA provides a mock function with given fields: ctx, session, code
+func (_m *Service) EnableMFA(ctx context.Context, session authn.Session, code string) error {
+	ret := _m.Called(ctx, session, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnableMFA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = rf(ctx, session, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollMFA provides a mock function with given fields: ctx, session
+func (_m *Service) EnrollMFA(ctx context.Context, session authn.Session) (users.MFAEnrolment, error) {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnrollMFA")
+	}
+
+	var r0 users.MFAEnrolment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session) (users.MFAEnrolment, error)); ok {
+		return rf(ctx, session)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session) users.MFAEnrolment); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Get(0).(users.MFAEnrolment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session) error); ok {
+		r1 = rf(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateResetToken provides a mock function with given fields: ctx, email, host
 func (_m *Service) GenerateResetToken(ctx context.Context, email string, host string) error {
 	ret := _m.Called(ctx, email, host)
@@ -199,6 +263,36 @@ func (_m *Service) ListMembers(ctx context.Context, session authn.Session, objec
 	return r0, r1
 }
 
+// ListSessions provides a mock function with given fields: ctx, session
+func (_m *Service) ListSessions(ctx context.Context, session authn.Session) ([]users.Session, error) {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSessions")
+	}
+
+	var r0 []users.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session) ([]users.Session, error)); ok {
+		return rf(ctx, session)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session) []users.Session); ok {
+		r0 = rf(ctx, session)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]users.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, authn.Session) error); ok {
+		r1 = rf(ctx, session)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListUsers provides a mock function with given fields: ctx, session, pm
 func (_m *Service) ListUsers(ctx context.Context, session authn.Session, pm users.Page) (users.UsersPage, error) {
 	ret := _m.Called(ctx, session, pm)
@@ -331,6 +425,24 @@ func (_m *Service) Register(ctx context.Context, session authn.Session, user use
 	return r0, r1
 }
 
+// ResendVerification provides a mock function with given fields: ctx, email
+func (_m *Service) ResendVerification(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResendVerification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetSecret provides a mock function with given fields: ctx, session, secret
 func (_m *Service) ResetSecret(ctx context.Context, session authn.Session, secret string) error {
 	ret := _m.Called(ctx, session, secret)
@@ -349,6 +461,42 @@ func (_m *Service) ResetSecret(ctx context.Context, session authn.Session, secre
 	return r0
 }
 
+// RevokeSession provides a mock function with given fields: ctx, session, id
+func (_m *Service) RevokeSession(ctx context.Context, session authn.Session, id string) error {
+	ret := _m.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = rf(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeSessions provides a mock function with given fields: ctx, session
+func (_m *Service) RevokeSessions(ctx context.Context, session authn.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeSessions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SearchUsers provides a mock function with given fields: ctx, pm
 func (_m *Service) SearchUsers(ctx context.Context, pm users.Page) (users.UsersPage, error) {
 	ret := _m.Called(ctx, pm)
@@ -395,6 +543,24 @@ func (_m *Service) SendPasswordReset(ctx context.Context, host string, email str
 	return r0
 }
 
+// Unlock provides a mock function with given fields: ctx, session, id
+func (_m *Service) Unlock(ctx context.Context, session authn.Session, id string) error {
+	ret := _m.Called(ctx, session, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unlock")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, authn.Session, string) error); ok {
+		r0 = rf(ctx, session, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, session, user
 func (_m *Service) Update(ctx context.Context, session authn.Session, user users.User) (users.User, error) {
 	ret := _m.Called(ctx, session, user)
@@ -591,6 +757,64 @@ func (_m *Service) UpdateUsername(ctx context.Context, session authn.Session, id
 	return r0, r1
 }
 
+// VerifyEmail provides a mock function with given fields: ctx, token
+func (_m *Service) VerifyEmail(ctx context.Context, token string) (users.User, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyEmail")
+	}
+
+	var r0 users.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (users.User, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) users.User); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(users.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMFA provides a mock function with given fields: ctx, mfaToken, code
+func (_m *Service) VerifyMFA(ctx context.Context, mfaToken string, code string) (*v1.Token, error) {
+	ret := _m.Called(ctx, mfaToken, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for VerifyMFA")
+	}
+
+	var r0 *v1.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*v1.Token, error)); ok {
+		return rf(ctx, mfaToken, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *v1.Token); ok {
+		r0 = rf(ctx, mfaToken, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, mfaToken, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // View provides a mock function with given fields: ctx, session, id
 func (_m *Service) View(ctx context.Context, session authn.Session, id string) (users.User, error) {
 	ret := _m.Called(ctx, session, id)
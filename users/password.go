@@ -0,0 +1,23 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package users
+
+import "time"
+
+// PasswordPolicy specifies the rules the user passwords have to satisfy.
+//
+//go:generate mockery --name PasswordPolicy --output=./mocks --filename password.go --quiet --note "Copyright (c) Abstract Machines"
+type PasswordPolicy interface {
+	// Validate checks the plain-text password against the policy rules.
+	// The returned error describes the first rule that is not satisfied.
+	Validate(password string) error
+
+	// History returns the number of the most recent passwords, including
+	// the current one, that can't be reused. Zero allows any password.
+	History() int
+
+	// MaxAge returns the time after which the password expires and has
+	// to be reset. Zero disables the expiration.
+	MaxAge() time.Duration
+}
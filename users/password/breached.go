@@ -0,0 +1,82 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package password
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/absmach/supermq/pkg/errors"
+)
+
+// PrefixLen is the length of the SHA-1 hash prefix used to look up
+// the breached passwords.
+const PrefixLen = 5
+
+const hashLen = 40
+
+var errInvalidHash = errors.New("invalid breached password hash")
+
+// Breached is the source of the breached password hashes. The passwords are
+// looked up in the k-anonymity fashion: only the prefix of the upper-case
+// hex encoded SHA-1 hash of the password is passed to the source, which
+// returns the suffixes of all the breached hashes starting with that prefix.
+// That way, a remote source never learns the checked password or its hash.
+type Breached interface {
+	// Range returns the hash suffixes of the breached passwords whose
+	// SHA-1 hash starts with the given prefix.
+	Range(prefix string) ([]string, error)
+}
+
+type breachedList map[string][]string
+
+// NewBreachedList loads the breached password hashes from the file. The file
+// uses the Pwned Passwords format: one upper-case hex encoded SHA-1 hash per
+// line, optionally followed by a colon and the number of occurrences. Empty
+// lines and lines starting with "#" are ignored.
+func NewBreachedList(path string) (Breached, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	list := breachedList{}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		hash, _, _ := strings.Cut(entry, ":")
+		hash = strings.ToUpper(hash)
+		if !isHash(hash) {
+			return nil, errors.Wrap(errInvalidHash, fmt.Errorf("line %d", line))
+		}
+		list[hash[:PrefixLen]] = append(list[hash[:PrefixLen]], hash[PrefixLen:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (l breachedList) Range(prefix string) ([]string, error) {
+	return l[strings.ToUpper(prefix)], nil
+}
+
+func isHash(s string) bool {
+	if len(s) != hashLen {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'F') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,6 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+// Package password contains the password policy implementation used
+// by the SuperMQ users service.
+package password
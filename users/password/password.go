@@ -0,0 +1,119 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package password
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/users"
+)
+
+var errBreachedCheck = errors.New("failed to check password against breached passwords")
+
+var _ users.PasswordPolicy = (*policy)(nil)
+
+// Config contains the password policy configuration.
+type Config struct {
+	// MinLength is the minimum number of characters. Zero disables the check.
+	MinLength int `env:"MIN_LENGTH"      envDefault:"8"`
+	// MaxLength is the maximum number of characters. Zero disables the check.
+	MaxLength int `env:"MAX_LENGTH"      envDefault:"72"`
+	// RequireUpper requires at least one uppercase letter.
+	RequireUpper bool `env:"REQUIRE_UPPER"   envDefault:"false"`
+	// RequireLower requires at least one lowercase letter.
+	RequireLower bool `env:"REQUIRE_LOWER"   envDefault:"false"`
+	// RequireDigit requires at least one digit.
+	RequireDigit bool `env:"REQUIRE_DIGIT"   envDefault:"false"`
+	// RequireSpecial requires at least one character which is neither
+	// a letter nor a digit.
+	RequireSpecial bool `env:"REQUIRE_SPECIAL" envDefault:"false"`
+	// History is the number of the most recent passwords, including the
+	// current one, that can't be reused.
+	History int `env:"HISTORY"         envDefault:"0"`
+	// MaxAge is the time after which the password has to be reset.
+	MaxAge time.Duration `env:"MAX_AGE"         envDefault:"0"`
+	// BreachedList is the path to the file with the SHA-1 hashes of the
+	// breached passwords. Empty value disables the check.
+	BreachedList string `env:"BREACHED_LIST"   envDefault:""`
+}
+
+type policy struct {
+	cfg      Config
+	breached Breached
+}
+
+// New instantiates the password policy. The breached passwords source is
+// optional; if nil, the passwords are not checked against known breaches.
+func New(cfg Config, breached Breached) users.PasswordPolicy {
+	return &policy{
+		cfg:      cfg,
+		breached: breached,
+	}
+}
+
+func (p *policy) Validate(password string) error {
+	length := utf8.RuneCountInString(password)
+	if p.cfg.MinLength > 0 && length < p.cfg.MinLength {
+		return apiutil.ErrPasswordTooShort
+	}
+	if p.cfg.MaxLength > 0 && length > p.cfg.MaxLength {
+		return apiutil.ErrPasswordTooLong
+	}
+
+	var upper, lower, digit, special bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case !unicode.IsLetter(r):
+			special = true
+		}
+	}
+	switch {
+	case p.cfg.RequireUpper && !upper:
+		return apiutil.ErrPasswordUpper
+	case p.cfg.RequireLower && !lower:
+		return apiutil.ErrPasswordLower
+	case p.cfg.RequireDigit && !digit:
+		return apiutil.ErrPasswordDigit
+	case p.cfg.RequireSpecial && !special:
+		return apiutil.ErrPasswordSpecial
+	}
+
+	if p.breached == nil {
+		return nil
+	}
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	suffixes, err := p.breached.Range(hash[:PrefixLen])
+	if err != nil {
+		return errors.Wrap(errBreachedCheck, err)
+	}
+	for _, suffix := range suffixes {
+		if suffix == hash[PrefixLen:] {
+			return apiutil.ErrPasswordBreached
+		}
+	}
+
+	return nil
+}
+
+func (p *policy) History() int {
+	return p.cfg.History
+}
+
+func (p *policy) MaxAge() time.Duration {
+	return p.cfg.MaxAge
+}
@@ -0,0 +1,175 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package password_test
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	apiutil "github.com/absmach/supermq/api/http/util"
+	"github.com/absmach/supermq/users/password"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const breachedPassword = "P@ssw0rd1234"
+
+func sha1Hex(s string) string {
+	sum := sha1.Sum([]byte(s))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func writeBreachedList(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	err := os.WriteFile(path, []byte(content), 0o600)
+	require.Nil(t, err, fmt.Sprintf("unexpected error writing breached list: %s", err))
+	return path
+}
+
+func TestValidate(t *testing.T) {
+	path := writeBreachedList(t, fmt.Sprintf("# breached passwords\n\n%s:42\n%s\n", sha1Hex(breachedPassword), strings.ToLower(sha1Hex("123456"))))
+	breached, err := password.NewBreachedList(path)
+	require.Nil(t, err, fmt.Sprintf("unexpected error loading breached list: %s", err))
+
+	cfg := password.Config{
+		MinLength:      8,
+		MaxLength:      16,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+	}
+
+	cases := []struct {
+		desc     string
+		cfg      password.Config
+		breached password.Breached
+		password string
+		err      error
+	}{
+		{
+			desc:     "validate password with default policy",
+			cfg:      password.Config{},
+			password: "a",
+			err:      nil,
+		},
+		{
+			desc:     "validate valid password",
+			cfg:      cfg,
+			password: "Str0ng!Secret",
+			err:      nil,
+		},
+		{
+			desc:     "validate too short password",
+			cfg:      cfg,
+			password: "S0!s",
+			err:      apiutil.ErrPasswordTooShort,
+		},
+		{
+			desc:     "validate too long password",
+			cfg:      cfg,
+			password: "Str0ng!SecretStr0ng!Secret",
+			err:      apiutil.ErrPasswordTooLong,
+		},
+		{
+			desc:     "validate password length in characters",
+			cfg:      password.Config{MaxLength: 8},
+			password: "ääääääää",
+			err:      nil,
+		},
+		{
+			desc:     "validate password without uppercase letter",
+			cfg:      cfg,
+			password: "str0ng!secret",
+			err:      apiutil.ErrPasswordUpper,
+		},
+		{
+			desc:     "validate password without lowercase letter",
+			cfg:      cfg,
+			password: "STR0NG!SECRET",
+			err:      apiutil.ErrPasswordLower,
+		},
+		{
+			desc:     "validate password without digit",
+			cfg:      cfg,
+			password: "Strong!Secret",
+			err:      apiutil.ErrPasswordDigit,
+		},
+		{
+			desc:     "validate password without special character",
+			cfg:      cfg,
+			password: "Str0ngSecret",
+			err:      apiutil.ErrPasswordSpecial,
+		},
+		{
+			desc:     "validate breached password",
+			cfg:      cfg,
+			breached: breached,
+			password: breachedPassword,
+			err:      apiutil.ErrPasswordBreached,
+		},
+		{
+			desc:     "validate breached password with lowercase hash",
+			cfg:      password.Config{},
+			breached: breached,
+			password: "123456",
+			err:      apiutil.ErrPasswordBreached,
+		},
+		{
+			desc:     "validate password which is not breached",
+			cfg:      cfg,
+			breached: breached,
+			password: "Str0ng!Secret",
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := password.New(tc.cfg, tc.breached).Validate(tc.password)
+			assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestNewBreachedList(t *testing.T) {
+	cases := []struct {
+		desc    string
+		content string
+		path    string
+		err     bool
+	}{
+		{
+			desc:    "load valid breached list",
+			content: sha1Hex(breachedPassword) + ":3\n",
+			err:     false,
+		},
+		{
+			desc:    "load breached list with invalid hash",
+			content: "not a hash\n",
+			err:     true,
+		},
+		{
+			desc: "load non-existing breached list",
+			path: "non-existing.txt",
+			err:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			path := tc.path
+			if path == "" {
+				path = writeBreachedList(t, tc.content)
+			}
+			_, err := password.NewBreachedList(path)
+			assert.Equal(t, tc.err, err != nil, fmt.Sprintf("%s: expected error %t got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
@@ -86,6 +86,66 @@ func Migration() *migrate.MemoryMigrationSource {
 					`ALTER TABLE users ALTER COLUMN last_name SET DEFAULT ''`,
 				},
 			},
+			{
+				Id: "clients_06",
+				Up: []string{
+					`ALTER TABLE users
+                        ADD COLUMN mfa_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+                        ADD COLUMN mfa_secret TEXT,
+                        ADD COLUMN mfa_recovery_codes TEXT[]`,
+				},
+				Down: []string{
+					`ALTER TABLE users
+                        DROP COLUMN mfa_enabled,
+                        DROP COLUMN mfa_secret,
+                        DROP COLUMN mfa_recovery_codes`,
+				},
+			},
+			{
+				Id: "clients_07",
+				Up: []string{
+					`ALTER TABLE users ADD COLUMN pending_email VARCHAR(254) UNIQUE`,
+				},
+				Down: []string{
+					`ALTER TABLE users DROP COLUMN pending_email`,
+				},
+			},
+			{
+				Id: "clients_08",
+				Up: []string{
+					`ALTER TABLE users ADD COLUMN secret_history TEXT[]`,
+					`ALTER TABLE users ADD COLUMN secret_updated_at TIMESTAMP`,
+					// The password age is counted from the migration, so the existing
+					// passwords don't expire at once when the maximum age is enabled.
+					`UPDATE users SET secret_updated_at = NOW() AT TIME ZONE 'UTC' WHERE secret IS NOT NULL AND secret <> ''`,
+				},
+				Down: []string{
+					`ALTER TABLE users DROP COLUMN secret_history`,
+					`ALTER TABLE users DROP COLUMN secret_updated_at`,
+				},
+			},
+			{
+				Id: "clients_09",
+				Up: []string{
+					`ALTER TABLE users
+                        ADD COLUMN verification_nonce VARCHAR(36),
+                        ADD COLUMN verification_sent_at TIMESTAMP`,
+				},
+				Down: []string{
+					`ALTER TABLE users
+                        DROP COLUMN verification_nonce,
+                        DROP COLUMN verification_sent_at`,
+				},
+			},
+			{
+				Id: "clients_10",
+				Up: []string{
+					`ALTER TABLE users ADD COLUMN mfa_last_step BIGINT NOT NULL DEFAULT 0`,
+				},
+				Down: []string{
+					`ALTER TABLE users DROP COLUMN mfa_last_step`,
+				},
+			},
 		},
 	}
 }
@@ -31,8 +31,8 @@ func NewRepository(db postgres.Database) users.Repository {
 }
 
 func (repo *userRepo) Save(ctx context.Context, c users.User) (users.User, error) {
-	q := `INSERT INTO users (id, tags, email, secret, metadata, created_at, status, role, first_name, last_name, username, profile_picture)
-        VALUES (:id, :tags, :email, :secret, :metadata, :created_at, :status, :role, :first_name, :last_name, :username, :profile_picture)
+	q := `INSERT INTO users (id, tags, email, secret, secret_updated_at, metadata, created_at, status, role, first_name, last_name, username, profile_picture)
+        VALUES (:id, :tags, :email, :secret, :secret_updated_at, :metadata, :created_at, :status, :role, :first_name, :last_name, :username, :profile_picture)
         RETURNING id, tags, email, metadata, created_at, status, role, first_name, last_name, username, profile_picture`
 
 	dbu, err := toDBUser(c)
@@ -81,7 +81,7 @@ func (repo *userRepo) CheckSuperAdmin(ctx context.Context, adminID string) error
 }
 
 func (repo *userRepo) RetrieveByID(ctx context.Context, id string) (users.User, error) {
-	q := `SELECT id, tags, email, secret, metadata, created_at, updated_at, updated_by, status, role, first_name, last_name, username, profile_picture
+	q := `SELECT id, tags, email, pending_email, secret, secret_history, secret_updated_at, metadata, created_at, updated_at, updated_by, status, role, first_name, last_name, username, profile_picture, mfa_enabled
         FROM users WHERE id = :id`
 
 	dbu := DBUser{
@@ -263,13 +263,93 @@ func (repo *userRepo) update(ctx context.Context, user users.User, query string)
 }
 
 func (repo *userRepo) UpdateSecret(ctx context.Context, user users.User) (users.User, error) {
-	q := `UPDATE users SET secret = :secret, updated_at = :updated_at, updated_by = :updated_by
+	q := `UPDATE users SET secret = :secret, secret_history = :secret_history, secret_updated_at = :updated_at, updated_at = :updated_at, updated_by = :updated_by
         WHERE id = :id AND status = :status
         RETURNING id, tags, email, metadata, status, created_at, updated_at, updated_by, first_name, last_name, username`
 	user.Status = users.EnabledStatus
 	return repo.update(ctx, user, q)
 }
 
+func (repo *userRepo) UpdatePendingEmail(ctx context.Context, user users.User) (users.User, error) {
+	q := `UPDATE users SET pending_email = :pending_email, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id AND status = :status
+        RETURNING id, tags, email, pending_email, metadata, status, created_at, updated_at, updated_by, first_name, last_name, username`
+	user.Status = users.EnabledStatus
+	return repo.update(ctx, user, q)
+}
+
+func (repo *userRepo) UpdateVerification(ctx context.Context, id, nonce string, sentBefore time.Time) error {
+	q := `UPDATE users SET verification_nonce = :verification_nonce, verification_sent_at = :verification_sent_at
+        WHERE id = :id AND (verification_sent_at IS NULL OR verification_sent_at <= :sent_before)`
+
+	dbv := dbVerification{
+		ID:         id,
+		Nonce:      stringToNullString(nonce),
+		SentAt:     time.Now().UTC(),
+		SentBefore: sentBefore.UTC(),
+	}
+	result, err := repo.Repository.DB.NamedExecContext(ctx, q, dbv)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *userRepo) ConfirmRegistration(ctx context.Context, user users.User, nonce string) (users.User, error) {
+	q := `UPDATE users SET status = :status, verification_nonce = NULL, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id AND status = :pending_status AND email = :email AND verification_nonce = :verification_nonce
+        RETURNING id, tags, email, metadata, status, created_at, updated_at, updated_by, first_name, last_name, username`
+	user.Status = users.EnabledStatus
+	return repo.confirm(ctx, user, nonce, q)
+}
+
+func (repo *userRepo) ConfirmEmail(ctx context.Context, user users.User, nonce string) (users.User, error) {
+	q := `UPDATE users SET email = pending_email, pending_email = NULL, verification_nonce = NULL, updated_at = :updated_at, updated_by = :updated_by
+        WHERE id = :id AND status = :status AND pending_email = :pending_email AND verification_nonce = :verification_nonce
+        RETURNING id, tags, email, metadata, status, created_at, updated_at, updated_by, first_name, last_name, username`
+	user.Status = users.EnabledStatus
+	return repo.confirm(ctx, user, nonce, q)
+}
+
+// confirm runs the e-mail confirmation query, which clears the nonce of the
+// verification token so the token can't be used again.
+func (repo *userRepo) confirm(ctx context.Context, user users.User, nonce, query string) (users.User, error) {
+	dbu, err := toDBUser(user)
+	if err != nil {
+		return users.User{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+	}
+	params := struct {
+		DBUser
+		PendingStatus users.Status   `db:"pending_status"`
+		Nonce         sql.NullString `db:"verification_nonce"`
+	}{
+		DBUser:        dbu,
+		PendingStatus: users.PendingStatus,
+		Nonce:         stringToNullString(nonce),
+	}
+
+	row, err := repo.Repository.DB.NamedQueryContext(ctx, query, params)
+	if err != nil {
+		return users.User{}, postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	defer row.Close()
+
+	dbu = DBUser{}
+	if row.Next() {
+		if err := row.StructScan(&dbu); err != nil {
+			return users.User{}, errors.Wrap(repoerr.ErrUpdateEntity, err)
+		}
+
+		return ToUser(dbu)
+	}
+
+	return users.User{}, repoerr.ErrNotFound
+}
+
 func (repo *userRepo) ChangeStatus(ctx context.Context, user users.User) (users.User, error) {
 	q := `UPDATE users SET status = :status, updated_at = :updated_at, updated_by = :updated_by
 		WHERE id = :id
@@ -407,7 +487,7 @@ func (repo *userRepo) RetrieveAllByIDs(ctx context.Context, pm users.Page) (user
 }
 
 func (repo *userRepo) RetrieveByEmail(ctx context.Context, email string) (users.User, error) {
-	q := `SELECT id, tags, email, secret, metadata, created_at, updated_at, updated_by, status, role, first_name, last_name, username
+	q := `SELECT id, tags, email, secret, secret_history, secret_updated_at, metadata, created_at, updated_at, updated_by, status, role, first_name, last_name, username, mfa_enabled
         FROM users WHERE email = :email AND status = :status`
 
 	dbu := DBUser{
@@ -433,8 +513,42 @@ func (repo *userRepo) RetrieveByEmail(ctx context.Context, email string) (users.
 	return users.User{}, repoerr.ErrNotFound
 }
 
+func (repo *userRepo) RetrieveAllByUnverifiedEmail(ctx context.Context, email string) ([]users.User, error) {
+	q := `SELECT id, tags, email, pending_email, metadata, created_at, updated_at, updated_by, status, role, first_name, last_name, username, mfa_enabled
+        FROM users WHERE (email = :email AND status = :status) OR pending_email = :email`
+
+	dbu := DBUser{
+		Email:  email,
+		Status: users.PendingStatus,
+	}
+
+	rows, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbu)
+	if err != nil {
+		return nil, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer rows.Close()
+
+	var items []users.User
+	for rows.Next() {
+		dbu = DBUser{}
+		if err := rows.StructScan(&dbu); err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		user, err := ToUser(dbu)
+		if err != nil {
+			return nil, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+		items = append(items, user)
+	}
+	if len(items) == 0 {
+		return nil, repoerr.ErrNotFound
+	}
+
+	return items, nil
+}
+
 func (repo *userRepo) RetrieveByUsername(ctx context.Context, username string) (users.User, error) {
-	q := `SELECT id, tags, email, secret, metadata, created_at, updated_at, updated_by, status, role, first_name, last_name, username
+	q := `SELECT id, tags, email, secret, secret_history, secret_updated_at, metadata, created_at, updated_at, updated_by, status, role, first_name, last_name, username, mfa_enabled
 		FROM users WHERE username = :username AND status = :status`
 
 	dbu := DBUser{
@@ -460,6 +574,102 @@ func (repo *userRepo) RetrieveByUsername(ctx context.Context, username string) (
 	return users.User{}, repoerr.ErrNotFound
 }
 
+func (repo *userRepo) RetrieveMFA(ctx context.Context, id string) (users.MFA, error) {
+	q := `SELECT id, mfa_enabled, mfa_secret, mfa_recovery_codes, mfa_last_step FROM users WHERE id = :id`
+
+	dbm := dbMFA{
+		ID: id,
+	}
+
+	row, err := repo.Repository.DB.NamedQueryContext(ctx, q, dbm)
+	if err != nil {
+		return users.MFA{}, postgres.HandleError(repoerr.ErrViewEntity, err)
+	}
+	defer row.Close()
+
+	dbm = dbMFA{}
+	if row.Next() {
+		if err := row.StructScan(&dbm); err != nil {
+			return users.MFA{}, errors.Wrap(repoerr.ErrViewEntity, err)
+		}
+
+		var codes []string
+		for _, e := range dbm.RecoveryCodes.Elements {
+			codes = append(codes, e.String)
+		}
+
+		return users.MFA{
+			Enabled:       dbm.Enabled,
+			Secret:        nullStringString(dbm.Secret),
+			RecoveryCodes: codes,
+			LastStep:      dbm.LastStep,
+		}, nil
+	}
+
+	return users.MFA{}, repoerr.ErrNotFound
+}
+
+func (repo *userRepo) UpdateMFA(ctx context.Context, id string, mfa users.MFA) error {
+	q := `UPDATE users SET mfa_enabled = :mfa_enabled, mfa_secret = :mfa_secret, mfa_recovery_codes = :mfa_recovery_codes,
+        mfa_last_step = :mfa_last_step WHERE id = :id`
+
+	var codes pgtype.TextArray
+	if err := codes.Set(mfa.RecoveryCodes); err != nil {
+		return errors.Wrap(repoerr.ErrMalformedEntity, err)
+	}
+	dbm := dbMFA{
+		ID:            id,
+		Enabled:       mfa.Enabled,
+		Secret:        stringToNullString(mfa.Secret),
+		RecoveryCodes: codes,
+		LastStep:      mfa.LastStep,
+	}
+
+	result, err := repo.Repository.DB.NamedExecContext(ctx, q, dbm)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+func (repo *userRepo) UpdateMFAStep(ctx context.Context, id string, step int64) error {
+	q := `UPDATE users SET mfa_last_step = :mfa_last_step WHERE id = :id AND mfa_last_step < :mfa_last_step`
+
+	dbm := dbMFA{
+		ID:       id,
+		LastStep: step,
+	}
+
+	result, err := repo.Repository.DB.NamedExecContext(ctx, q, dbm)
+	if err != nil {
+		return postgres.HandleError(repoerr.ErrUpdateEntity, err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return repoerr.ErrNotFound
+	}
+
+	return nil
+}
+
+type dbMFA struct {
+	ID            string           `db:"id"`
+	Enabled       bool             `db:"mfa_enabled"`
+	Secret        sql.NullString   `db:"mfa_secret"`
+	RecoveryCodes pgtype.TextArray `db:"mfa_recovery_codes"`
+	LastStep      int64            `db:"mfa_last_step"`
+}
+
+type dbVerification struct {
+	ID         string         `db:"id"`
+	Nonce      sql.NullString `db:"verification_nonce"`
+	SentAt     time.Time      `db:"verification_sent_at"`
+	SentBefore time.Time      `db:"sent_before"`
+}
+
 type DBUser struct {
 	ID             string           `db:"id"`
 	Domain         string           `db:"domain_id"`
@@ -477,6 +687,12 @@ type DBUser struct {
 	LastName       sql.NullString   `db:"last_name, omitempty"`
 	ProfilePicture sql.NullString   `db:"profile_picture, omitempty"`
 	Email          string           `db:"email,omitempty"`
+	PendingEmail   sql.NullString   `db:"pending_email,omitempty"`
+	MFAEnabled     bool             `db:"mfa_enabled,omitempty"`
+	// SecretHistory and SecretUpdatedAt are used to enforce the password
+	// policy and are never returned to the service clients.
+	SecretHistory   pgtype.TextArray `db:"secret_history,omitempty"`
+	SecretUpdatedAt sql.NullTime     `db:"secret_updated_at,omitempty"`
 }
 
 func toDBUser(u users.User) (DBUser, error) {
@@ -500,22 +716,33 @@ func toDBUser(u users.User) (DBUser, error) {
 	if u.UpdatedAt != (time.Time{}) {
 		updatedAt = sql.NullTime{Time: u.UpdatedAt, Valid: true}
 	}
+	var secretHistory pgtype.TextArray
+	if err := secretHistory.Set(u.SecretHistory); err != nil {
+		return DBUser{}, err
+	}
+	var secretUpdatedAt sql.NullTime
+	if u.SecretUpdatedAt != (time.Time{}) {
+		secretUpdatedAt = sql.NullTime{Time: u.SecretUpdatedAt, Valid: true}
+	}
 
 	return DBUser{
-		ID:             u.ID,
-		Tags:           tags,
-		Secret:         u.Credentials.Secret,
-		Metadata:       data,
-		CreatedAt:      u.CreatedAt,
-		UpdatedAt:      updatedAt,
-		UpdatedBy:      updatedBy,
-		Status:         u.Status,
-		Role:           &u.Role,
-		LastName:       stringToNullString(u.LastName),
-		FirstName:      stringToNullString(u.FirstName),
-		Username:       stringToNullString(u.Credentials.Username),
-		ProfilePicture: stringToNullString(u.ProfilePicture),
-		Email:          u.Email,
+		ID:              u.ID,
+		Tags:            tags,
+		Secret:          u.Credentials.Secret,
+		Metadata:        data,
+		CreatedAt:       u.CreatedAt,
+		UpdatedAt:       updatedAt,
+		UpdatedBy:       updatedBy,
+		Status:          u.Status,
+		Role:            &u.Role,
+		LastName:        stringToNullString(u.LastName),
+		FirstName:       stringToNullString(u.FirstName),
+		Username:        stringToNullString(u.Credentials.Username),
+		ProfilePicture:  stringToNullString(u.ProfilePicture),
+		Email:           u.Email,
+		PendingEmail:    stringToNullString(u.PendingEmail),
+		SecretHistory:   secretHistory,
+		SecretUpdatedAt: secretUpdatedAt,
 	}, nil
 }
 
@@ -538,6 +765,14 @@ func ToUser(dbu DBUser) (users.User, error) {
 	if dbu.UpdatedAt.Valid {
 		updatedAt = dbu.UpdatedAt.Time
 	}
+	var secretHistory []string
+	for _, e := range dbu.SecretHistory.Elements {
+		secretHistory = append(secretHistory, e.String)
+	}
+	var secretUpdatedAt time.Time
+	if dbu.SecretUpdatedAt.Valid {
+		secretUpdatedAt = dbu.SecretUpdatedAt.Time
+	}
 
 	user := users.User{
 		ID:        dbu.ID,
@@ -547,14 +782,18 @@ func ToUser(dbu DBUser) (users.User, error) {
 			Username: nullStringString(dbu.Username),
 			Secret:   dbu.Secret,
 		},
-		Email:          dbu.Email,
-		Metadata:       metadata,
-		CreatedAt:      dbu.CreatedAt,
-		UpdatedAt:      updatedAt,
-		UpdatedBy:      updatedBy,
-		Status:         dbu.Status,
-		Tags:           tags,
-		ProfilePicture: nullStringString(dbu.ProfilePicture),
+		Email:           dbu.Email,
+		PendingEmail:    nullStringString(dbu.PendingEmail),
+		Metadata:        metadata,
+		CreatedAt:       dbu.CreatedAt,
+		UpdatedAt:       updatedAt,
+		UpdatedBy:       updatedBy,
+		Status:          dbu.Status,
+		Tags:            tags,
+		ProfilePicture:  nullStringString(dbu.ProfilePicture),
+		MFAEnabled:      dbu.MFAEnabled,
+		SecretHistory:   secretHistory,
+		SecretUpdatedAt: secretUpdatedAt,
 	}
 	if dbu.Role != nil {
 		user.Role = *dbu.Role
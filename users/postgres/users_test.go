@@ -1531,6 +1531,197 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestUpdateMFA(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM users")
+		require.Nil(t, err, fmt.Sprintf("clean users unexpected error: %s", err))
+	})
+	repo := cpostgres.NewRepository(database)
+
+	user := generateUser(t, users.EnabledStatus, repo)
+
+	cases := []struct {
+		desc string
+		id   string
+		mfa  users.MFA
+		err  error
+	}{
+		{
+			desc: "update MFA successfully",
+			id:   user.ID,
+			mfa:  users.MFA{Enabled: true, Secret: "JBSWY3DPEHPK3PXP", RecoveryCodes: []string{"hash1", "hash2"}, LastStep: 57000000},
+			err:  nil,
+		},
+		{
+			desc: "reset MFA successfully",
+			id:   user.ID,
+			mfa:  users.MFA{},
+			err:  nil,
+		},
+		{
+			desc: "update MFA with invalid id",
+			id:   testsutil.GenerateUUID(t),
+			mfa:  users.MFA{Enabled: true, Secret: "JBSWY3DPEHPK3PXP"},
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.UpdateMFA(context.Background(), tc.id, tc.mfa)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				mfa, err := repo.RetrieveMFA(context.Background(), tc.id)
+				require.Nil(t, err, fmt.Sprintf("retrieve MFA unexpected error: %s", err))
+				assert.Equal(t, tc.mfa, mfa)
+				u, err := repo.RetrieveByID(context.Background(), tc.id)
+				require.Nil(t, err, fmt.Sprintf("retrieve user unexpected error: %s", err))
+				assert.Equal(t, tc.mfa.Enabled, u.MFAEnabled)
+			}
+		})
+	}
+}
+
+func TestUpdateMFAStep(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM users")
+		require.Nil(t, err, fmt.Sprintf("clean users unexpected error: %s", err))
+	})
+	repo := cpostgres.NewRepository(database)
+
+	user := generateUser(t, users.EnabledStatus, repo)
+	err := repo.UpdateMFA(context.Background(), user.ID, users.MFA{Enabled: true, Secret: "JBSWY3DPEHPK3PXP", LastStep: 100})
+	require.Nil(t, err, fmt.Sprintf("update MFA unexpected error: %s", err))
+
+	cases := []struct {
+		desc     string
+		id       string
+		step     int64
+		lastStep int64
+		err      error
+	}{
+		{
+			desc:     "update MFA step with later step",
+			id:       user.ID,
+			step:     101,
+			lastStep: 101,
+			err:      nil,
+		},
+		{
+			desc:     "update MFA step with the same step",
+			id:       user.ID,
+			step:     101,
+			lastStep: 101,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc:     "update MFA step with earlier step",
+			id:       user.ID,
+			step:     99,
+			lastStep: 101,
+			err:      repoerr.ErrNotFound,
+		},
+		{
+			desc: "update MFA step with invalid id",
+			id:   testsutil.GenerateUUID(t),
+			step: 102,
+			err:  repoerr.ErrNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := repo.UpdateMFAStep(context.Background(), tc.id, tc.step)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.id == user.ID {
+				mfa, err := repo.RetrieveMFA(context.Background(), tc.id)
+				require.Nil(t, err, fmt.Sprintf("retrieve MFA unexpected error: %s", err))
+				assert.Equal(t, tc.lastStep, mfa.LastStep)
+			}
+		})
+	}
+}
+
+func TestEmailVerification(t *testing.T) {
+	t.Cleanup(func() {
+		_, err := db.Exec("DELETE FROM users")
+		require.Nil(t, err, fmt.Sprintf("clean users unexpected error: %s", err))
+	})
+	repo := cpostgres.NewRepository(database)
+
+	pending := generateUser(t, users.PendingStatus, repo)
+	changing := generateUser(t, users.EnabledStatus, repo)
+	changing.PendingEmail = pending.Email
+	changing.UpdatedAt = time.Now().UTC()
+	_, err := repo.UpdatePendingEmail(context.Background(), changing)
+	require.Nil(t, err, fmt.Sprintf("update pending email unexpected error: %s", err))
+
+	unverified, err := repo.RetrieveAllByUnverifiedEmail(context.Background(), pending.Email)
+	assert.Nil(t, err, fmt.Sprintf("retrieve users by unverified email unexpected error: %s", err))
+	assert.ElementsMatch(t, []string{pending.ID, changing.ID}, getIDs(unverified))
+
+	pendingNonce := testsutil.GenerateUUID(t)
+	err = repo.UpdateVerification(context.Background(), pending.ID, pendingNonce, time.Now())
+	assert.Nil(t, err, fmt.Sprintf("update verification unexpected error: %s", err))
+	err = repo.UpdateVerification(context.Background(), pending.ID, testsutil.GenerateUUID(t), time.Now().Add(-time.Minute))
+	assert.True(t, errors.Contains(err, repoerr.ErrNotFound), fmt.Sprintf("update verification sent too early: expected %s got %s\n", repoerr.ErrNotFound, err))
+	changingNonce := testsutil.GenerateUUID(t)
+	err = repo.UpdateVerification(context.Background(), changing.ID, changingNonce, time.Now())
+	assert.Nil(t, err, fmt.Sprintf("update verification unexpected error: %s", err))
+
+	cases := []struct {
+		desc    string
+		confirm func(ctx context.Context, user users.User, nonce string) (users.User, error)
+		user    users.User
+		nonce   string
+		err     error
+	}{
+		{
+			desc:    "confirm registration with invalid nonce",
+			confirm: repo.ConfirmRegistration,
+			user:    users.User{ID: pending.ID, Email: pending.Email},
+			nonce:   testsutil.GenerateUUID(t),
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc:    "confirm registration with another email",
+			confirm: repo.ConfirmRegistration,
+			user:    users.User{ID: pending.ID, Email: changing.Email},
+			nonce:   pendingNonce,
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc:    "confirm registration",
+			confirm: repo.ConfirmRegistration,
+			user:    users.User{ID: pending.ID, Email: pending.Email},
+			nonce:   pendingNonce,
+			err:     nil,
+		},
+		{
+			desc:    "confirm registration with used nonce",
+			confirm: repo.ConfirmRegistration,
+			user:    users.User{ID: pending.ID, Email: pending.Email},
+			nonce:   pendingNonce,
+			err:     repoerr.ErrNotFound,
+		},
+		{
+			desc:    "confirm email with another pending email",
+			confirm: repo.ConfirmEmail,
+			user:    users.User{ID: changing.ID, PendingEmail: changing.Email},
+			nonce:   changingNonce,
+			err:     repoerr.ErrNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			c.user.UpdatedAt = time.Now().UTC()
+			_, err := c.confirm(context.Background(), c.user, c.nonce)
+			assert.True(t, errors.Contains(err, c.err), fmt.Sprintf("expected %s to contain %s\n", err, c.err))
+		})
+	}
+}
+
 func TestRetrieveByIDs(t *testing.T) {
 	t.Cleanup(func() {
 		_, err := db.Exec("DELETE FROM users")
@@ -5,10 +5,13 @@ package users
 
 import (
 	"context"
+	"crypto/rand"
 	"net/mail"
+	"slices"
 	"time"
 
 	"github.com/absmach/supermq"
+	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	smqauth "github.com/absmach/supermq/auth"
@@ -16,51 +19,119 @@ import (
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/totp"
 	"golang.org/x/sync/errgroup"
 )
 
+const (
+	// MFAAccessType is the access type of the MFA challenge token.
+	MFAAccessType = "mfa"
+
+	// LockoutUserKind is the kind of the failed password attempts keys.
+	LockoutUserKind = "user"
+	// LockoutMFAKind is the kind of the failed MFA code attempts keys.
+	LockoutMFAKind = "mfa"
+
+	mfaIssuer          = "SuperMQ"
+	mfaTokenDuration   = 5 * time.Minute
+	recoveryCodesCount = 10
+	recoveryCodeLength = 10
+	recoveryCodeChars  = "abcdefghijkmnpqrstuvwxyz23456789"
+
+	// verificationResendInterval is the minimum time between the
+	// verification e-mails resent to the user.
+	verificationResendInterval = time.Minute
+)
+
 var (
+	// ErrMFARequired indicates that the user must use multi-factor
+	// authentication because a domain of the user requires it.
+	ErrMFARequired = errors.New("multi-factor authentication is required by the domain")
+
 	errIssueToken            = errors.New("failed to issue token")
 	errFailedPermissionsList = errors.New("failed to list permissions")
 	errRecoveryToken         = errors.New("failed to generate password recovery token")
 	errLoginDisableUser      = errors.New("failed to login in disabled user")
+	errInvalidMFACode        = errors.New("invalid multi-factor authentication code")
+	errMFAEnabled            = errors.New("multi-factor authentication is already enabled")
+	errMFANotEnabled         = errors.New("multi-factor authentication is not enabled")
+	errMFANotEnrolled        = errors.New("multi-factor authentication enrolment not started")
+	errVerificationToken     = errors.New("failed to generate e-mail verification token")
+	errEmailVerified         = errors.New("e-mail address is already verified")
+	errInvalidVerification   = errors.New("e-mail verification token is used or superseded")
 )
 
 type service struct {
-	token      grpcTokenV1.TokenServiceClient
-	users      Repository
-	idProvider supermq.IDProvider
-	policies   policies.Service
-	hasher     Hasher
-	email      Emailer
+	token       grpcTokenV1.TokenServiceClient
+	users       Repository
+	idProvider  supermq.IDProvider
+	policies    policies.Service
+	domains     grpcDomainsV1.DomainsServiceClient
+	hasher      Hasher
+	passwords   PasswordPolicy
+	email       Emailer
+	tokenizer   smqauth.Tokenizer
+	lockout     lockout.Tracker
+	verifyEmail bool
 }
 
-// NewService returns a new Users service implementation.
-func NewService(token grpcTokenV1.TokenServiceClient, urepo Repository, policyService policies.Service, emailer Emailer, hasher Hasher, idp supermq.IDProvider) Service {
+// NewService returns a new Users service implementation. The tokenizer
+// issues and parses the MFA challenge tokens, and the tracker limits the
+// failed login attempts per user and per request source. The passwords
+// policy is enforced whenever the user sets a password. If verifyEmail
+// is set, self-registered users and the users changing their e-mail
+// address have to verify it.
+func NewService(token grpcTokenV1.TokenServiceClient, urepo Repository, policyService policies.Service, domains grpcDomainsV1.DomainsServiceClient, emailer Emailer, hasher Hasher, passwords PasswordPolicy, idp supermq.IDProvider, tokenizer smqauth.Tokenizer, tracker lockout.Tracker, verifyEmail bool) Service {
 	return service{
-		token:      token,
-		users:      urepo,
-		policies:   policyService,
-		hasher:     hasher,
-		email:      emailer,
-		idProvider: idp,
+		token:       token,
+		users:       urepo,
+		policies:    policyService,
+		domains:     domains,
+		hasher:      hasher,
+		passwords:   passwords,
+		email:       emailer,
+		idProvider:  idp,
+		tokenizer:   tokenizer,
+		lockout:     tracker,
+		verifyEmail: verifyEmail,
 	}
 }
 
-func (svc service) Register(ctx context.Context, session authn.Session, u User, selfRegister bool) (uc User, err error) {
+func (svc service) Register(ctx context.Context, session authn.Session, u User, selfRegister bool) (User, error) {
 	if !selfRegister {
 		if err := svc.checkSuperAdmin(ctx, session); err != nil {
 			return User{}, err
 		}
 	}
 
+	pending := selfRegister && svc.verifyEmail
+	user, err := svc.register(ctx, u, pending)
+	if err != nil {
+		return User{}, err
+	}
+	if pending {
+		if err := svc.sendVerification(ctx, user, user.Email, time.Now()); err != nil {
+			return User{}, err
+		}
+	}
+
+	return user, nil
+}
+
+// register saves the user and adds the user's policies. The pending user
+// can't log in until the e-mail address is verified.
+func (svc service) register(ctx context.Context, u User, pending bool) (uc User, err error) {
 	userID, err := svc.idProvider.ID()
 	if err != nil {
 		return User{}, err
 	}
 
 	if u.Credentials.Secret != "" {
+		if err := svc.passwords.Validate(u.Credentials.Secret); err != nil {
+			return User{}, errors.Wrap(apiutil.ErrValidation, err)
+		}
 		hash, err := svc.hasher.Hash(u.Credentials.Secret)
 		if err != nil {
 			return User{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
@@ -74,8 +145,14 @@ func (svc service) Register(ctx context.Context, session authn.Session, u User,
 	if u.Role != UserRole && u.Role != AdminRole {
 		return User{}, errors.Wrap(svcerr.ErrMalformedEntity, svcerr.ErrInvalidRole)
 	}
+	if pending {
+		u.Status = PendingStatus
+	}
 	u.ID = userID
 	u.CreatedAt = time.Now()
+	if u.Credentials.Secret != "" {
+		u.SecretUpdatedAt = u.CreatedAt
+	}
 
 	if err := svc.addUserPolicy(ctx, u.ID, u.Role); err != nil {
 		return User{}, err
@@ -98,6 +175,11 @@ func (svc service) IssueToken(ctx context.Context, identity, secret string) (*gr
 	var dbUser User
 	var err error
 
+	sourceKeys := lockoutSourceKeys(ctx)
+	if err := svc.lockout.Check(ctx, sourceKeys...); err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
 	if _, parseErr := mail.ParseAddress(identity); parseErr != nil {
 		dbUser, err = svc.users.RetrieveByUsername(ctx, identity)
 	} else {
@@ -105,14 +187,75 @@ func (svc service) IssueToken(ctx context.Context, identity, secret string) (*gr
 	}
 
 	if err != nil {
-		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+		return &grpcTokenV1.Token{}, svc.failLogin(ctx, errors.Wrap(svcerr.ErrAuthentication, err), sourceKeys...)
 	}
 
+	userKey := lockout.Key(LockoutUserKind, dbUser.ID)
+	if err := svc.lockout.Check(ctx, userKey); err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
 	if err := svc.hasher.Compare(secret, dbUser.Credentials.Secret); err != nil {
-		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrLogin, err)
+		return &grpcTokenV1.Token{}, svc.failLogin(ctx, errors.Wrap(svcerr.ErrLogin, err), append(sourceKeys, userKey)...)
+	}
+	if err := svc.lockout.Reset(ctx, userKey); err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if svc.secretExpired(dbUser) {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, apiutil.ErrPasswordExpired)
+	}
+
+	if dbUser.MFAEnabled {
+		return svc.mfaChallenge(dbUser.ID)
+	}
+	if err := svc.checkMFARequired(ctx, dbUser.ID); err != nil {
+		return &grpcTokenV1.Token{}, err
+	}
+
+	token, err := svc.token.Issue(ctx, accessTokenReq(ctx, dbUser.ID))
+	if err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(errIssueToken, err)
+	}
+
+	return token, nil
+}
+
+func (svc service) VerifyMFA(ctx context.Context, mfaToken, code string) (*grpcTokenV1.Token, error) {
+	key, err := svc.tokenizer.Parse(mfaToken)
+	if err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if key.Type != smqauth.MFAKey {
+		return &grpcTokenV1.Token{}, svcerr.ErrAuthentication
+	}
+
+	dbUser, err := svc.users.RetrieveByID(ctx, key.User)
+	if err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if dbUser.Status == DisabledStatus {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, errLoginDisableUser)
 	}
 
-	token, err := svc.token.Issue(ctx, &grpcTokenV1.IssueReq{UserId: dbUser.ID, Type: uint32(smqauth.AccessKey)})
+	mfa, err := svc.users.RetrieveMFA(ctx, dbUser.ID)
+	if err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if !mfa.Enabled {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, errMFANotEnabled)
+	}
+	mfaKey := lockout.Key(LockoutMFAKind, dbUser.ID)
+	keys := append(lockoutSourceKeys(ctx), mfaKey)
+	if err := svc.lockout.Check(ctx, keys...); err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if err := svc.verifyMFACode(ctx, dbUser.ID, mfa, code); err != nil {
+		return &grpcTokenV1.Token{}, svc.failLogin(ctx, errors.Wrap(svcerr.ErrAuthentication, err), keys...)
+	}
+	if err := svc.lockout.Reset(ctx, mfaKey); err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	token, err := svc.token.Issue(ctx, accessTokenReq(ctx, dbUser.ID))
 	if err != nil {
 		return &grpcTokenV1.Token{}, errors.Wrap(errIssueToken, err)
 	}
@@ -120,6 +263,88 @@ func (svc service) IssueToken(ctx context.Context, identity, secret string) (*gr
 	return token, nil
 }
 
+func (svc service) EnrollMFA(ctx context.Context, session authn.Session) (MFAEnrolment, error) {
+	user, err := svc.users.RetrieveByID(ctx, session.UserID)
+	if err != nil {
+		return MFAEnrolment{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if user.MFAEnabled {
+		return MFAEnrolment{}, errors.Wrap(svcerr.ErrConflict, errMFAEnabled)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return MFAEnrolment{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+	}
+	codes := make([]string, recoveryCodesCount)
+	hashes := make([]string, recoveryCodesCount)
+	for i := range codes {
+		if codes[i], err = generateRecoveryCode(); err != nil {
+			return MFAEnrolment{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+		if hashes[i], err = svc.hasher.Hash(codes[i]); err != nil {
+			return MFAEnrolment{}, errors.Wrap(svcerr.ErrCreateEntity, err)
+		}
+	}
+
+	if err := svc.users.UpdateMFA(ctx, user.ID, MFA{Secret: secret, RecoveryCodes: hashes}); err != nil {
+		return MFAEnrolment{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return MFAEnrolment{
+		Secret:        secret,
+		URI:           totp.URI(mfaIssuer, user.Email, secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (svc service) EnableMFA(ctx context.Context, session authn.Session, code string) error {
+	mfa, err := svc.users.RetrieveMFA(ctx, session.UserID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if mfa.Enabled {
+		return errors.Wrap(svcerr.ErrConflict, errMFAEnabled)
+	}
+	if mfa.Secret == "" {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errMFANotEnrolled)
+	}
+	step, ok := totp.ValidateStep(mfa.Secret, code, time.Now())
+	if !ok {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errInvalidMFACode)
+	}
+
+	mfa.Enabled = true
+	mfa.LastStep = step
+	if err := svc.users.UpdateMFA(ctx, session.UserID, mfa); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) DisableMFA(ctx context.Context, session authn.Session, code string) error {
+	mfa, err := svc.users.RetrieveMFA(ctx, session.UserID)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if !mfa.Enabled {
+		return errors.Wrap(svcerr.ErrMalformedEntity, errMFANotEnabled)
+	}
+	if err := svc.checkMFARequired(ctx, session.UserID); err != nil {
+		return err
+	}
+	if err := svc.verifyMFACode(ctx, session.UserID, mfa, code); err != nil {
+		return errors.Wrap(svcerr.ErrMalformedEntity, err)
+	}
+
+	if err := svc.users.UpdateMFA(ctx, session.UserID, MFA{}); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
 func (svc service) RefreshToken(ctx context.Context, session authn.Session, refreshToken string) (*grpcTokenV1.Token, error) {
 	dbUser, err := svc.users.RetrieveByID(ctx, session.UserID)
 	if err != nil {
@@ -129,7 +354,52 @@ func (svc service) RefreshToken(ctx context.Context, session authn.Session, refr
 		return &grpcTokenV1.Token{}, errors.Wrap(svcerr.ErrAuthentication, errLoginDisableUser)
 	}
 
-	return svc.token.Refresh(ctx, &grpcTokenV1.RefreshReq{RefreshToken: refreshToken})
+	info := smqauth.SessionInfoFromContext(ctx)
+	return svc.token.Refresh(ctx, &grpcTokenV1.RefreshReq{
+		RefreshToken: refreshToken,
+		UserAgent:    info.UserAgent,
+		IpAddress:    info.IPAddress,
+	})
+}
+
+func (svc service) ListSessions(ctx context.Context, session authn.Session) ([]Session, error) {
+	res, err := svc.token.ListSessions(ctx, &grpcTokenV1.ListSessionsReq{UserId: session.UserID})
+	if err != nil {
+		return nil, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	sessions := make([]Session, 0, len(res.GetSessions()))
+	for _, s := range res.GetSessions() {
+		sessions = append(sessions, Session{
+			ID:         s.GetId(),
+			UserAgent:  s.GetUserAgent(),
+			IPAddress:  s.GetIpAddress(),
+			IssuedAt:   s.GetIssuedAt().AsTime(),
+			LastUsedAt: s.GetLastUsedAt().AsTime(),
+			ExpiresAt:  s.GetExpiresAt().AsTime(),
+		})
+	}
+
+	return sessions, nil
+}
+
+func (svc service) RevokeSession(ctx context.Context, session authn.Session, id string) error {
+	if _, err := svc.token.RevokeSession(ctx, &grpcTokenV1.RevokeSessionReq{UserId: session.UserID, SessionId: id}); err != nil {
+		if errors.Contains(err, svcerr.ErrNotFound) {
+			return err
+		}
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
+}
+
+func (svc service) RevokeSessions(ctx context.Context, session authn.Session) error {
+	if _, err := svc.token.RevokeSession(ctx, &grpcTokenV1.RevokeSessionReq{UserId: session.UserID}); err != nil {
+		return errors.Wrap(svcerr.ErrRemoveEntity, err)
+	}
+
+	return nil
 }
 
 func (svc service) View(ctx context.Context, session authn.Session, id string) (User, error) {
@@ -272,6 +542,10 @@ func (svc service) UpdateEmail(ctx context.Context, session authn.Session, userI
 		}
 	}
 
+	if svc.verifyEmail && session.UserID == userID {
+		return svc.updatePendingEmail(ctx, session, email)
+	}
+
 	user := User{
 		ID:        userID,
 		Email:     email,
@@ -286,6 +560,114 @@ func (svc service) UpdateEmail(ctx context.Context, session authn.Session, userI
 	return user, nil
 }
 
+func (svc service) VerifyEmail(ctx context.Context, token string) (User, error) {
+	res, err := svc.token.Verify(ctx, &grpcTokenV1.VerifyReq{Token: token, Type: uint32(smqauth.VerificationKey)})
+	if err != nil {
+		return User{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	user, err := svc.users.RetrieveByID(ctx, res.GetUserId())
+	if err != nil {
+		return User{}, errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	u := User{
+		ID:        user.ID,
+		UpdatedAt: time.Now(),
+		UpdatedBy: user.ID,
+	}
+	// The token is accepted only for the e-mail address it was sent to, and
+	// only if it's the latest token sent to the user. The confirmation
+	// clears the nonce, so the token can't be used again.
+	switch {
+	case user.Status == PendingStatus:
+		u.Email = res.GetEmail()
+		user, err = svc.users.ConfirmRegistration(ctx, u, res.GetNonce())
+	case user.PendingEmail != "":
+		u.PendingEmail = res.GetEmail()
+		user, err = svc.users.ConfirmEmail(ctx, u, res.GetNonce())
+	default:
+		return User{}, errors.Wrap(svcerr.ErrConflict, errEmailVerified)
+	}
+	if err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return User{}, errors.Wrap(svcerr.ErrAuthentication, errInvalidVerification)
+		}
+		return User{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return user, nil
+}
+
+func (svc service) ResendVerification(ctx context.Context, email string) error {
+	users, err := svc.users.RetrieveAllByUnverifiedEmail(ctx, email)
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+
+	// The e-mail address may be used by a pending user and by the user
+	// changing the e-mail address to it, so each of them gets a token.
+	sentBefore := time.Now().Add(-verificationResendInterval)
+	for _, user := range users {
+		if err := svc.sendVerification(ctx, user, email, sentBefore); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updatePendingEmail keeps the user's new e-mail address until it's verified
+// and sends the verification token to it.
+func (svc service) updatePendingEmail(ctx context.Context, session authn.Session, email string) (User, error) {
+	if _, err := svc.users.RetrieveByEmail(ctx, email); err == nil {
+		return User{}, errors.Wrap(svcerr.ErrUpdateEntity, repoerr.ErrConflict)
+	}
+
+	user := User{
+		ID:           session.UserID,
+		PendingEmail: email,
+		UpdatedAt:    time.Now(),
+		UpdatedBy:    session.UserID,
+	}
+	user, err := svc.users.UpdatePendingEmail(ctx, user)
+	if err != nil {
+		return User{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	if err := svc.sendVerification(ctx, user, email, time.Now()); err != nil {
+		return User{}, err
+	}
+
+	return user, nil
+}
+
+// sendVerification issues the verification token of the user and sends it
+// to the e-mail address. The token carries a new nonce, which supersedes the
+// previously sent tokens. It fails if the previous token was sent after
+// sentBefore.
+func (svc service) sendVerification(ctx context.Context, user User, email string, sentBefore time.Time) error {
+	nonce, err := svc.idProvider.ID()
+	if err != nil {
+		return errors.Wrap(errVerificationToken, err)
+	}
+	if err := svc.users.UpdateVerification(ctx, user.ID, nonce, sentBefore); err != nil {
+		if errors.Contains(err, repoerr.ErrNotFound) {
+			return svcerr.ErrTooManyRequests
+		}
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+	token, err := svc.token.Issue(ctx, &grpcTokenV1.IssueReq{
+		UserId: user.ID,
+		Type:   uint32(smqauth.VerificationKey),
+		Email:  email,
+		Nonce:  nonce,
+	})
+	if err != nil {
+		return errors.Wrap(errVerificationToken, err)
+	}
+
+	return svc.email.SendVerification([]string{email}, user.Credentials.Username, token.AccessToken)
+}
+
 func (svc service) GenerateResetToken(ctx context.Context, email, host string) error {
 	user, err := svc.users.RetrieveByEmail(ctx, email)
 	if err != nil {
@@ -309,6 +691,10 @@ func (svc service) ResetSecret(ctx context.Context, session authn.Session, secre
 		return errors.Wrap(svcerr.ErrViewEntity, err)
 	}
 
+	history, err := svc.checkSecret(u, secret)
+	if err != nil {
+		return err
+	}
 	secret, err = svc.hasher.Hash(secret)
 	if err != nil {
 		return errors.Wrap(svcerr.ErrMalformedEntity, err)
@@ -319,8 +705,9 @@ func (svc service) ResetSecret(ctx context.Context, session authn.Session, secre
 		Credentials: Credentials{
 			Secret: secret,
 		},
-		UpdatedAt: time.Now(),
-		UpdatedBy: session.UserID,
+		SecretHistory: history,
+		UpdatedAt:     time.Now(),
+		UpdatedBy:     session.UserID,
 	}
 	if _, err := svc.users.UpdateSecret(ctx, u); err != nil {
 		return errors.Wrap(svcerr.ErrAuthorization, err)
@@ -333,7 +720,20 @@ func (svc service) UpdateSecret(ctx context.Context, session authn.Session, oldS
 	if err != nil {
 		return User{}, errors.Wrap(svcerr.ErrViewEntity, err)
 	}
-	if _, err := svc.IssueToken(ctx, dbUser.Credentials.Username, oldSecret); err != nil {
+	// The old secret is compared directly, instead of logging in, so the
+	// users with the expired password or MFA enabled can change it.
+	userKey := lockout.Key(LockoutUserKind, dbUser.ID)
+	if err := svc.lockout.Check(ctx, userKey); err != nil {
+		return User{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if err := svc.hasher.Compare(oldSecret, dbUser.Credentials.Secret); err != nil {
+		return User{}, svc.failLogin(ctx, errors.Wrap(svcerr.ErrLogin, err), userKey)
+	}
+	if err := svc.lockout.Reset(ctx, userKey); err != nil {
+		return User{}, errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	history, err := svc.checkSecret(dbUser, newSecret)
+	if err != nil {
 		return User{}, err
 	}
 	newSecret, err = svc.hasher.Hash(newSecret)
@@ -341,6 +741,7 @@ func (svc service) UpdateSecret(ctx context.Context, session authn.Session, oldS
 		return User{}, errors.Wrap(svcerr.ErrMalformedEntity, err)
 	}
 	dbUser.Credentials.Secret = newSecret
+	dbUser.SecretHistory = history
 	dbUser.UpdatedAt = time.Now()
 	dbUser.UpdatedBy = session.UserID
 
@@ -352,6 +753,45 @@ func (svc service) UpdateSecret(ctx context.Context, session authn.Session, oldS
 	return dbUser, nil
 }
 
+// checkSecret validates the new secret against the password policy and the
+// user's recent passwords. It returns the secret history to be saved along
+// with the new secret.
+func (svc service) checkSecret(user User, secret string) ([]string, error) {
+	if err := svc.passwords.Validate(secret); err != nil {
+		return nil, errors.Wrap(apiutil.ErrValidation, err)
+	}
+
+	n := svc.passwords.History()
+	if n <= 0 {
+		return nil, nil
+	}
+	recent := user.SecretHistory
+	if user.Credentials.Secret != "" {
+		recent = append([]string{user.Credentials.Secret}, recent...)
+	}
+	recent = recent[:min(len(recent), n)]
+	for _, hash := range recent {
+		if err := svc.hasher.Compare(secret, hash); err == nil {
+			return nil, errors.Wrap(apiutil.ErrValidation, apiutil.ErrPasswordReused)
+		}
+	}
+
+	// The new secret counts towards the history, so only the n-1 most
+	// recent previous secrets have to be kept.
+	return recent[:min(len(recent), n-1)], nil
+}
+
+// secretExpired reports whether the user's password is older than the
+// password policy allows.
+func (svc service) secretExpired(user User) bool {
+	maxAge := svc.passwords.MaxAge()
+	if maxAge <= 0 || user.SecretUpdatedAt.IsZero() {
+		return false
+	}
+
+	return time.Since(user.SecretUpdatedAt) > maxAge
+}
+
 func (svc service) UpdateUsername(ctx context.Context, session authn.Session, id, username string) (User, error) {
 	if session.UserID != id {
 		if err := svc.checkSuperAdmin(ctx, session); err != nil {
@@ -433,6 +873,45 @@ func (svc service) Disable(ctx context.Context, session authn.Session, id string
 	return user, nil
 }
 
+func (svc service) Unlock(ctx context.Context, session authn.Session, id string) error {
+	if err := svc.checkSuperAdmin(ctx, session); err != nil {
+		return err
+	}
+	if _, err := svc.users.RetrieveByID(ctx, id); err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if err := svc.lockout.Reset(ctx, lockout.Key(LockoutUserKind, id), lockout.Key(LockoutMFAKind, id)); err != nil {
+		return errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return nil
+}
+
+// failLogin records the failed login attempt for the keys and marks the
+// login error with lockout.ErrLockout if the attempt locked any of them out.
+func (svc service) failLogin(ctx context.Context, loginErr error, keys ...string) error {
+	locked, err := svc.lockout.Fail(ctx, keys...)
+	if err != nil {
+		return errors.Wrap(loginErr, err)
+	}
+	if len(locked) > 0 {
+		return errors.Wrap(lockout.ErrLockout, loginErr)
+	}
+
+	return loginErr
+}
+
+// lockoutSourceKeys returns the failed attempts key of the request source,
+// if the transport set it.
+func lockoutSourceKeys(ctx context.Context) []string {
+	source := lockout.Source(ctx)
+	if source == "" {
+		return nil
+	}
+
+	return []string{lockout.Key(lockout.SourceKind, source)}
+}
+
 func (svc service) changeUserStatus(ctx context.Context, session authn.Session, user User) (User, error) {
 	if session.UserID != user.ID {
 		if err := svc.checkSuperAdmin(ctx, session); err != nil {
@@ -584,7 +1063,8 @@ func (svc service) OAuthCallback(ctx context.Context, user User) (User, error) {
 	if err != nil {
 		switch errors.Contains(err, repoerr.ErrNotFound) {
 		case true:
-			ruser, err = svc.Register(ctx, authn.Session{}, user, true)
+			// The OAuth provider has already verified the e-mail address.
+			ruser, err = svc.register(ctx, user, false)
 			if err != nil {
 				return User{}, err
 			}
@@ -592,6 +1072,14 @@ func (svc service) OAuthCallback(ctx context.Context, user User) (User, error) {
 			return User{}, err
 		}
 	}
+	if user.OAuthRole && ruser.Role != user.Role {
+		// The role follows the provider group membership, so the users
+		// removed from the admin groups are demoted on the next login.
+		ruser, err = svc.updateOAuthRole(ctx, ruser, user.Role)
+		if err != nil {
+			return User{}, err
+		}
+	}
 
 	return User{
 		ID:   ruser.ID,
@@ -599,6 +1087,27 @@ func (svc service) OAuthCallback(ctx context.Context, user User) (User, error) {
 	}, nil
 }
 
+func (svc service) updateOAuthRole(ctx context.Context, user User, role Role) (User, error) {
+	if err := svc.updateUserPolicy(ctx, user.ID, role); err != nil {
+		return User{}, err
+	}
+
+	u, err := svc.users.Update(ctx, User{
+		ID:        user.ID,
+		Role:      role,
+		UpdatedAt: time.Now(),
+		UpdatedBy: user.ID,
+	})
+	if err != nil {
+		if errRollback := svc.updateUserPolicy(ctx, user.ID, user.Role); errRollback != nil {
+			return User{}, errors.Wrap(errRollback, err)
+		}
+		return User{}, errors.Wrap(svcerr.ErrUpdateEntity, err)
+	}
+
+	return u, nil
+}
+
 func (svc service) OAuthAddUserPolicy(ctx context.Context, user User) error {
 	return svc.addUserPolicy(ctx, user.ID, user.Role)
 }
@@ -695,3 +1204,85 @@ func (svc service) updateUserPolicy(ctx context.Context, userID string, role Rol
 		return nil
 	}
 }
+
+func (svc service) mfaChallenge(userID string) (*grpcTokenV1.Token, error) {
+	now := time.Now().UTC()
+	token, err := svc.tokenizer.Issue(smqauth.Key{
+		Type:      smqauth.MFAKey,
+		User:      userID,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(mfaTokenDuration),
+	})
+	if err != nil {
+		return &grpcTokenV1.Token{}, errors.Wrap(errIssueToken, err)
+	}
+
+	return &grpcTokenV1.Token{AccessToken: token, AccessType: MFAAccessType}, nil
+}
+
+// accessTokenReq returns the request for the user's access and refresh token,
+// carrying the session info of the login request.
+func accessTokenReq(ctx context.Context, userID string) *grpcTokenV1.IssueReq {
+	info := smqauth.SessionInfoFromContext(ctx)
+	return &grpcTokenV1.IssueReq{
+		UserId:    userID,
+		Type:      uint32(smqauth.AccessKey),
+		UserAgent: info.UserAgent,
+		IpAddress: info.IPAddress,
+	}
+}
+
+// checkMFARequired returns an error if any domain of the user requires MFA.
+func (svc service) checkMFARequired(ctx context.Context, userID string) error {
+	res, err := svc.domains.RequiresMFA(ctx, &grpcDomainsV1.RequiresMFAReq{UserId: userID})
+	if err != nil {
+		return errors.Wrap(svcerr.ErrViewEntity, err)
+	}
+	if res.GetRequired() {
+		return errors.Wrap(svcerr.ErrAuthorization, ErrMFARequired)
+	}
+
+	return nil
+}
+
+// verifyMFACode checks the code against the TOTP secret and the recovery
+// codes. The recovery code is removed once it's used.
+func (svc service) verifyMFACode(ctx context.Context, userID string, mfa MFA, code string) error {
+	if step, ok := totp.ValidateStep(mfa.Secret, code, time.Now()); ok {
+		// Codes are accepted only once, so a code observed by an attacker
+		// can't be replayed while it is still valid.
+		if step <= mfa.LastStep {
+			return errInvalidMFACode
+		}
+		if err := svc.users.UpdateMFAStep(ctx, userID, step); err != nil {
+			if errors.Contains(err, repoerr.ErrNotFound) {
+				return errInvalidMFACode
+			}
+			return errors.Wrap(svcerr.ErrUpdateEntity, err)
+		}
+		return nil
+	}
+	for i, hash := range mfa.RecoveryCodes {
+		if err := svc.hasher.Compare(code, hash); err == nil {
+			mfa.RecoveryCodes = slices.Delete(slices.Clone(mfa.RecoveryCodes), i, i+1)
+			if err := svc.users.UpdateMFA(ctx, userID, mfa); err != nil {
+				return errors.Wrap(svcerr.ErrUpdateEntity, err)
+			}
+			return nil
+		}
+	}
+
+	return errInvalidMFACode
+}
+
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = recoveryCodeChars[int(b[i])%len(recoveryCodeChars)]
+	}
+
+	return string(b[:recoveryCodeLength/2]) + "-" + string(b[recoveryCodeLength/2:]), nil
+}
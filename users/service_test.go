@@ -8,28 +8,39 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	grpcDomainsV1 "github.com/absmach/supermq/api/grpc/domains/v1"
 	grpcTokenV1 "github.com/absmach/supermq/api/grpc/token/v1"
+	apiutil "github.com/absmach/supermq/api/http/util"
 	smqauth "github.com/absmach/supermq/auth"
+	authjwt "github.com/absmach/supermq/auth/jwt"
 	authmocks "github.com/absmach/supermq/auth/mocks"
+	domainsmocks "github.com/absmach/supermq/domains/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
 	"github.com/absmach/supermq/pkg/authn"
 	"github.com/absmach/supermq/pkg/errors"
 	repoerr "github.com/absmach/supermq/pkg/errors/repository"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
+	"github.com/absmach/supermq/pkg/lockout"
 	policysvc "github.com/absmach/supermq/pkg/policies"
 	policymocks "github.com/absmach/supermq/pkg/policies/mocks"
+	"github.com/absmach/supermq/pkg/totp"
 	"github.com/absmach/supermq/pkg/uuid"
 	"github.com/absmach/supermq/users"
 	"github.com/absmach/supermq/users/hasher"
 	"github.com/absmach/supermq/users/mocks"
+	"github.com/absmach/supermq/users/password"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
 	idProvider     = uuid.New()
 	phasher        = hasher.New()
+	passPolicy     = password.New(password.Config{}, nil)
 	secret         = "strongsecret"
 	validCMetadata = users.Metadata{"role": "user"}
 	userID         = "d8dd12ef-aa2a-43fe-8ef2-2e4fe514360f"
@@ -55,6 +66,7 @@ var (
 	validID         = "d4ebb847-5d0e-4e46-bdd9-b6aceaaa3a22"
 	wrongID         = testsutil.GenerateUUID(&testing.T{})
 	errHashPassword = errors.New("generate hash from password failed")
+	tokenizer       = authjwt.New([]byte("secret"))
 )
 
 func newService() (users.Service, *authmocks.TokenServiceClient, *mocks.Repository, *policymocks.Service, *mocks.Emailer) {
@@ -62,7 +74,29 @@ func newService() (users.Service, *authmocks.TokenServiceClient, *mocks.Reposito
 	policies := new(policymocks.Service)
 	e := new(mocks.Emailer)
 	tokenClient := new(authmocks.TokenServiceClient)
-	return users.NewService(tokenClient, cRepo, policies, e, phasher, idProvider), tokenClient, cRepo, policies, e
+	domainsClient := new(domainsmocks.DomainsServiceClient)
+	domainsClient.On("RequiresMFA", mock.Anything, mock.Anything).Return(&grpcDomainsV1.RequiresMFARes{}, nil).Maybe()
+	return users.NewService(tokenClient, cRepo, policies, domainsClient, e, phasher, passPolicy, idProvider, tokenizer, lockout.NewMemoryTracker(lockout.Config{}), false), tokenClient, cRepo, policies, e
+}
+
+func newVerificationService() (users.Service, *authmocks.TokenServiceClient, *mocks.Repository, *policymocks.Service, *mocks.Emailer) {
+	cRepo := new(mocks.Repository)
+	policies := new(policymocks.Service)
+	e := new(mocks.Emailer)
+	tokenClient := new(authmocks.TokenServiceClient)
+	domainsClient := new(domainsmocks.DomainsServiceClient)
+	return users.NewService(tokenClient, cRepo, policies, domainsClient, e, phasher, passPolicy, idProvider, tokenizer, lockout.NewMemoryTracker(lockout.Config{}), true), tokenClient, cRepo, policies, e
+}
+
+func newPasswordPolicyService(cfg password.Config) (users.Service, *authmocks.TokenServiceClient, *mocks.Repository) {
+	cRepo := new(mocks.Repository)
+	policies := new(policymocks.Service)
+	policies.On("AddPolicies", mock.Anything, mock.Anything).Return(nil).Maybe()
+	e := new(mocks.Emailer)
+	tokenClient := new(authmocks.TokenServiceClient)
+	domainsClient := new(domainsmocks.DomainsServiceClient)
+	domainsClient.On("RequiresMFA", mock.Anything, mock.Anything).Return(&grpcDomainsV1.RequiresMFARes{}, nil).Maybe()
+	return users.NewService(tokenClient, cRepo, policies, domainsClient, e, phasher, password.New(cfg, nil), idProvider, tokenizer, lockout.NewMemoryTracker(lockout.Config{}), false), tokenClient, cRepo
 }
 
 func newServiceMinimal() (users.Service, *mocks.Repository) {
@@ -70,7 +104,27 @@ func newServiceMinimal() (users.Service, *mocks.Repository) {
 	policies := new(policymocks.Service)
 	e := new(mocks.Emailer)
 	tokenUser := new(authmocks.TokenServiceClient)
-	return users.NewService(tokenUser, cRepo, policies, e, phasher, idProvider), cRepo
+	domainsClient := new(domainsmocks.DomainsServiceClient)
+	return users.NewService(tokenUser, cRepo, policies, domainsClient, e, phasher, passPolicy, idProvider, tokenizer, lockout.NewMemoryTracker(lockout.Config{}), false), cRepo
+}
+
+func newMFAService() (users.Service, *authmocks.TokenServiceClient, *mocks.Repository, *domainsmocks.DomainsServiceClient) {
+	cRepo := new(mocks.Repository)
+	policies := new(policymocks.Service)
+	e := new(mocks.Emailer)
+	tokenClient := new(authmocks.TokenServiceClient)
+	domainsClient := new(domainsmocks.DomainsServiceClient)
+	return users.NewService(tokenClient, cRepo, policies, domainsClient, e, phasher, passPolicy, idProvider, tokenizer, lockout.NewMemoryTracker(lockout.Config{}), false), tokenClient, cRepo, domainsClient
+}
+
+func newLockoutService(tracker lockout.Tracker) (users.Service, *authmocks.TokenServiceClient, *mocks.Repository) {
+	cRepo := new(mocks.Repository)
+	policies := new(policymocks.Service)
+	e := new(mocks.Emailer)
+	tokenClient := new(authmocks.TokenServiceClient)
+	domainsClient := new(domainsmocks.DomainsServiceClient)
+	domainsClient.On("RequiresMFA", mock.Anything, mock.Anything).Return(&grpcDomainsV1.RequiresMFARes{}, nil).Maybe()
+	return users.NewService(tokenClient, cRepo, policies, domainsClient, e, phasher, passPolicy, idProvider, tokenizer, tracker, false), tokenClient, cRepo
 }
 
 func TestRegister(t *testing.T) {
@@ -763,7 +817,7 @@ func TestUpdateRole(t *testing.T) {
 }
 
 func TestUpdateSecret(t *testing.T) {
-	svc, authUser, cRepo, _, _ := newService()
+	svc, _, cRepo, _, _ := newService()
 
 	newSecret := "newstrongSecret"
 	rUser := user
@@ -772,32 +826,26 @@ func TestUpdateSecret(t *testing.T) {
 	responseUser.Credentials.Secret = newSecret
 
 	cases := []struct {
-		desc                    string
-		oldSecret               string
-		newSecret               string
-		session                 authn.Session
-		retrieveByIDResponse    users.User
-		retrieveByEmailResponse users.User
-		updateSecretResponse    users.User
-		issueResponse           *grpcTokenV1.Token
-		response                users.User
-		retrieveByIDErr         error
-		retrieveByEmailErr      error
-		updateSecretErr         error
-		issueErr                error
-		err                     error
+		desc                 string
+		oldSecret            string
+		newSecret            string
+		session              authn.Session
+		retrieveByIDResponse users.User
+		updateSecretResponse users.User
+		response             users.User
+		retrieveByIDErr      error
+		updateSecretErr      error
+		err                  error
 	}{
 		{
-			desc:                    "update user secret with valid token",
-			oldSecret:               user.Credentials.Secret,
-			newSecret:               newSecret,
-			session:                 authn.Session{UserID: user.ID},
-			retrieveByEmailResponse: rUser,
-			retrieveByIDResponse:    user,
-			updateSecretResponse:    responseUser,
-			issueResponse:           &grpcTokenV1.Token{AccessToken: validToken},
-			response:                responseUser,
-			err:                     nil,
+			desc:                 "update user secret with valid token",
+			oldSecret:            user.Credentials.Secret,
+			newSecret:            newSecret,
+			session:              authn.Session{UserID: user.ID},
+			retrieveByIDResponse: rUser,
+			updateSecretResponse: responseUser,
+			response:             responseUser,
+			err:                  nil,
 		},
 		{
 			desc:                 "update user secret with failed to retrieve user by ID",
@@ -809,66 +857,47 @@ func TestUpdateSecret(t *testing.T) {
 			err:                  repoerr.ErrNotFound,
 		},
 		{
-			desc:                    "update user secret with failed to retrieve user by email",
-			oldSecret:               user.Credentials.Secret,
-			newSecret:               newSecret,
-			session:                 authn.Session{UserID: user.ID},
-			retrieveByIDResponse:    user,
-			retrieveByEmailResponse: users.User{},
-			retrieveByEmailErr:      repoerr.ErrNotFound,
-			err:                     repoerr.ErrNotFound,
-		},
-		{
-			desc:                    "update user secret with invalod old secret",
-			oldSecret:               "invalid",
-			newSecret:               newSecret,
-			session:                 authn.Session{UserID: user.ID},
-			retrieveByIDResponse:    user,
-			retrieveByEmailResponse: rUser,
-			err:                     svcerr.ErrLogin,
+			desc:                 "update user secret with invalod old secret",
+			oldSecret:            "invalid",
+			newSecret:            newSecret,
+			session:              authn.Session{UserID: user.ID},
+			retrieveByIDResponse: rUser,
+			err:                  svcerr.ErrLogin,
 		},
 		{
-			desc:                    "update user secret with too long new secret",
-			oldSecret:               user.Credentials.Secret,
-			newSecret:               strings.Repeat("a", 73),
-			session:                 authn.Session{UserID: user.ID},
-			retrieveByIDResponse:    user,
-			retrieveByEmailResponse: rUser,
-			err:                     repoerr.ErrMalformedEntity,
+			desc:                 "update user secret with too long new secret",
+			oldSecret:            user.Credentials.Secret,
+			newSecret:            strings.Repeat("a", 73),
+			session:              authn.Session{UserID: user.ID},
+			retrieveByIDResponse: rUser,
+			err:                  repoerr.ErrMalformedEntity,
 		},
 		{
-			desc:                    "update user secret with failed to update secret",
-			oldSecret:               user.Credentials.Secret,
-			newSecret:               newSecret,
-			session:                 authn.Session{UserID: user.ID},
-			retrieveByIDResponse:    user,
-			retrieveByEmailResponse: rUser,
-			updateSecretResponse:    users.User{},
-			updateSecretErr:         repoerr.ErrMalformedEntity,
-			err:                     svcerr.ErrUpdateEntity,
+			desc:                 "update user secret with failed to update secret",
+			oldSecret:            user.Credentials.Secret,
+			newSecret:            newSecret,
+			session:              authn.Session{UserID: user.ID},
+			retrieveByIDResponse: rUser,
+			updateSecretResponse: users.User{},
+			updateSecretErr:      repoerr.ErrMalformedEntity,
+			err:                  svcerr.ErrUpdateEntity,
 		},
 	}
 
 	for _, tc := range cases {
 		repoCall := cRepo.On("RetrieveByID", context.Background(), user.ID).Return(tc.retrieveByIDResponse, tc.retrieveByIDErr)
-		repoCall1 := cRepo.On("RetrieveByUsername", context.Background(), user.Credentials.Username).Return(tc.retrieveByEmailResponse, tc.retrieveByEmailErr)
-		repoCall2 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateSecretErr)
-		authCall := authUser.On("Issue", context.Background(), mock.Anything).Return(tc.issueResponse, tc.issueErr)
+		repoCall1 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(tc.updateSecretResponse, tc.updateSecretErr)
 		updatedUser, err := svc.UpdateSecret(context.Background(), tc.session, tc.oldSecret, tc.newSecret)
 		assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
 		assert.Equal(t, tc.response, updatedUser, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, updatedUser))
 		if tc.err == nil {
 			ok := repoCall.Parent.AssertCalled(t, "RetrieveByID", context.Background(), tc.response.ID)
 			assert.True(t, ok, fmt.Sprintf("RetrieveByID was not called on %s", tc.desc))
-			ok = repoCall1.Parent.AssertCalled(t, "RetrieveByUsername", context.Background(), tc.response.Credentials.Username)
-			assert.True(t, ok, fmt.Sprintf("RetrieveByUsername was not called on %s", tc.desc))
-			ok = repoCall2.Parent.AssertCalled(t, "UpdateSecret", context.Background(), mock.Anything)
+			ok = repoCall1.Parent.AssertCalled(t, "UpdateSecret", context.Background(), mock.Anything)
 			assert.True(t, ok, fmt.Sprintf("UpdateSecret was not called on %s", tc.desc))
 		}
 		repoCall.Unset()
 		repoCall1.Unset()
-		repoCall2.Unset()
-		authCall.Unset()
 	}
 }
 
@@ -1703,6 +1732,135 @@ func TestIssueToken(t *testing.T) {
 	}
 }
 
+func TestIssueTokenLockout(t *testing.T) {
+	cfg := lockout.Config{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+		Duration:    time.Hour,
+		Window:      time.Hour,
+	}
+	svc, auth, cRepo := newLockoutService(lockout.NewMemoryTracker(cfg))
+
+	rUser := user
+	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+	source := lockout.WithSource(context.Background(), "10.0.0.7")
+	otherSource := lockout.WithSource(context.Background(), "10.0.0.8")
+	repoCall := cRepo.On("RetrieveByUsername", mock.Anything, user.Credentials.Username).Return(rUser, nil)
+	repoCall1 := cRepo.On("RetrieveByID", mock.Anything, user.ID).Return(rUser, nil)
+	repoCall2 := cRepo.On("CheckSuperAdmin", mock.Anything, mock.Anything).Return(nil)
+	authCall := auth.On("Issue", mock.Anything, &grpcTokenV1.IssueReq{UserId: user.ID, Type: uint32(smqauth.AccessKey)}).Return(&grpcTokenV1.Token{AccessToken: validToken}, nil)
+	defer func() {
+		repoCall.Unset()
+		repoCall1.Unset()
+		repoCall2.Unset()
+		authCall.Unset()
+	}()
+
+	cases := []struct {
+		desc   string
+		ctx    context.Context
+		secret string
+		unlock bool
+		err    error
+	}{
+		{
+			desc:   "issue token with wrong secret",
+			ctx:    source,
+			secret: "wrongsecret",
+			err:    svcerr.ErrLogin,
+		},
+		{
+			desc:   "issue token with wrong secret again",
+			ctx:    source,
+			secret: "wrongsecret",
+			err:    svcerr.ErrLogin,
+		},
+		{
+			desc:   "issue token with wrong secret reaching max attempts",
+			ctx:    source,
+			secret: "wrongsecret",
+			err:    lockout.ErrLockout,
+		},
+		{
+			desc:   "issue token for locked out user",
+			ctx:    otherSource,
+			secret: user.Credentials.Secret,
+			err:    lockout.ErrLocked,
+		},
+		{
+			desc:   "issue token for unlocked user from locked out source",
+			ctx:    source,
+			secret: user.Credentials.Secret,
+			unlock: true,
+			err:    lockout.ErrLocked,
+		},
+		{
+			desc:   "issue token for unlocked user",
+			ctx:    otherSource,
+			secret: user.Credentials.Secret,
+			err:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.unlock {
+				err := svc.Unlock(context.Background(), authn.Session{SuperAdmin: true}, user.ID)
+				assert.Nil(t, err, fmt.Sprintf("%s: unexpected unlock error %s", tc.desc, err))
+			}
+			time.Sleep(2 * cfg.MaxDelay)
+			_, err := svc.IssueToken(tc.ctx, user.Credentials.Username, tc.secret)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		})
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	svc, _, cRepo := newLockoutService(lockout.NewMemoryTracker(lockout.Config{}))
+
+	cases := []struct {
+		desc               string
+		session            authn.Session
+		id                 string
+		checkSuperAdminErr error
+		retrieveByIDErr    error
+		err                error
+	}{
+		{
+			desc:    "unlock user as super admin",
+			session: authn.Session{UserID: validID, SuperAdmin: true},
+			id:      user.ID,
+			err:     nil,
+		},
+		{
+			desc:               "unlock user as non super admin",
+			session:            authn.Session{UserID: validID},
+			id:                 user.ID,
+			checkSuperAdminErr: repoerr.ErrNotFound,
+			err:                svcerr.ErrAuthorization,
+		},
+		{
+			desc:            "unlock non-existing user",
+			session:         authn.Session{UserID: validID, SuperAdmin: true},
+			id:              wrongID,
+			retrieveByIDErr: repoerr.ErrNotFound,
+			err:             svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("CheckSuperAdmin", context.Background(), tc.session.UserID).Return(tc.checkSuperAdminErr)
+			repoCall1 := cRepo.On("RetrieveByID", context.Background(), tc.id).Return(users.User{ID: tc.id}, tc.retrieveByIDErr)
+			err := svc.Unlock(context.Background(), tc.session, tc.id)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
 func TestRefreshToken(t *testing.T) {
 	svc, authsvc, crepo, _, _ := newService()
 
@@ -1981,6 +2139,10 @@ func TestOAuthCallback(t *testing.T) {
 		retrieveByEmailErr      error
 		saveResponse            users.User
 		addPoliciesErr          error
+		deletePolicyErr         error
+		updateResponse          users.User
+		updateErr               error
+		role                    users.Role
 		err                     error
 	}{
 		{
@@ -2023,6 +2185,83 @@ func TestOAuthCallback(t *testing.T) {
 			retrieveByEmailErr: repoerr.ErrNotFound,
 			err:                svcerr.ErrAuthorization,
 		},
+		{
+			desc: "oauth signin callback demotes admin removed from admin groups",
+			user: users.User{
+				Email:     "test@example.com",
+				Role:      users.UserRole,
+				OAuthRole: true,
+			},
+			retrieveByEmailResponse: users.User{
+				ID:   user.ID,
+				Role: users.AdminRole,
+			},
+			updateResponse: users.User{
+				ID:   user.ID,
+				Role: users.UserRole,
+			},
+			role: users.UserRole,
+			err:  nil,
+		},
+		{
+			desc: "oauth signin callback promotes user added to admin groups",
+			user: users.User{
+				Email:     "test@example.com",
+				Role:      users.AdminRole,
+				OAuthRole: true,
+			},
+			retrieveByEmailResponse: users.User{
+				ID:   user.ID,
+				Role: users.UserRole,
+			},
+			updateResponse: users.User{
+				ID:   user.ID,
+				Role: users.AdminRole,
+			},
+			role: users.AdminRole,
+			err:  nil,
+		},
+		{
+			desc: "oauth signin callback keeps admin role not mapped by provider",
+			user: users.User{
+				Email: "test@example.com",
+				Role:  users.UserRole,
+			},
+			retrieveByEmailResponse: users.User{
+				ID:   user.ID,
+				Role: users.AdminRole,
+			},
+			role: users.AdminRole,
+			err:  nil,
+		},
+		{
+			desc: "oauth signin callback with failed admin policy removal",
+			user: users.User{
+				Email:     "test@example.com",
+				Role:      users.UserRole,
+				OAuthRole: true,
+			},
+			retrieveByEmailResponse: users.User{
+				ID:   user.ID,
+				Role: users.AdminRole,
+			},
+			deletePolicyErr: svcerr.ErrAuthorization,
+			err:             svcerr.ErrDeletePolicies,
+		},
+		{
+			desc: "oauth signin callback with failed role update",
+			user: users.User{
+				Email:     "test@example.com",
+				Role:      users.UserRole,
+				OAuthRole: true,
+			},
+			retrieveByEmailResponse: users.User{
+				ID:   user.ID,
+				Role: users.AdminRole,
+			},
+			updateErr: repoerr.ErrNotFound,
+			err:       svcerr.ErrUpdateEntity,
+		},
 		{
 			desc: "oauth signin callback with user not in the platform",
 			user: users.User{
@@ -2039,13 +2278,1153 @@ func TestOAuthCallback(t *testing.T) {
 		t.Run(tc.desc, func(t *testing.T) {
 			repoCall := cRepo.On("RetrieveByEmail", context.Background(), tc.user.Email).Return(tc.retrieveByEmailResponse, tc.retrieveByEmailErr)
 			repoCall1 := cRepo.On("Save", context.Background(), mock.Anything).Return(tc.saveResponse, nil)
+			repoCall2 := cRepo.On("Update", context.Background(), mock.Anything).Return(tc.updateResponse, tc.updateErr)
 			policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(tc.addPoliciesErr)
-			_, err := svc.OAuthCallback(context.Background(), tc.user)
+			policyCall1 := policies.On("AddPolicy", context.Background(), mock.Anything).Return(nil)
+			policyCall2 := policies.On("DeletePolicyFilter", context.Background(), mock.Anything).Return(tc.deletePolicyErr)
+			u, err := svc.OAuthCallback(context.Background(), tc.user)
 			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil && tc.retrieveByEmailErr == nil {
+				assert.Equal(t, tc.role, u.Role, fmt.Sprintf("%s: expected role %s got %s\n", tc.desc, tc.role, u.Role))
+			}
 			repoCall.Parent.AssertCalled(t, "RetrieveByEmail", context.Background(), tc.user.Email)
 			repoCall.Unset()
 			repoCall1.Unset()
+			repoCall2.Unset()
 			policyCall.Unset()
+			policyCall1.Unset()
+			policyCall2.Unset()
+		})
+	}
+}
+
+func TestIssueTokenMFA(t *testing.T) {
+	svc, auth, cRepo, domainsClient := newMFAService()
+
+	rUser := user
+	rUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+	mfaUser := rUser
+	mfaUser.MFAEnabled = true
+
+	cases := []struct {
+		desc                string
+		retrieveResponse    users.User
+		requiresMFAResponse *grpcDomainsV1.RequiresMFARes
+		requiresMFAErr      error
+		accessType          string
+		err                 error
+	}{
+		{
+			desc:             "issue challenge token for a user with MFA enabled",
+			retrieveResponse: mfaUser,
+			accessType:       users.MFAAccessType,
+			err:              nil,
+		},
+		{
+			desc:                "issue token for a user without MFA when not required",
+			retrieveResponse:    rUser,
+			requiresMFAResponse: &grpcDomainsV1.RequiresMFARes{Required: false},
+			accessType:          "3",
+			err:                 nil,
+		},
+		{
+			desc:                "issue token for a user without MFA when required by domain",
+			retrieveResponse:    rUser,
+			requiresMFAResponse: &grpcDomainsV1.RequiresMFARes{Required: true},
+			err:                 users.ErrMFARequired,
+		},
+		{
+			desc:                "issue token with failed MFA policy check",
+			retrieveResponse:    rUser,
+			requiresMFAResponse: &grpcDomainsV1.RequiresMFARes{},
+			requiresMFAErr:      svcerr.ErrViewEntity,
+			err:                 svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RetrieveByUsername", context.Background(), user.Credentials.Username).Return(tc.retrieveResponse, nil)
+			domainsCall := domainsClient.On("RequiresMFA", context.Background(), &grpcDomainsV1.RequiresMFAReq{UserId: user.ID}).Return(tc.requiresMFAResponse, tc.requiresMFAErr)
+			authCall := auth.On("Issue", context.Background(), &grpcTokenV1.IssueReq{UserId: user.ID, Type: uint32(smqauth.AccessKey)}).Return(&grpcTokenV1.Token{AccessToken: validToken, AccessType: "3"}, nil)
+			token, err := svc.IssueToken(context.Background(), user.Credentials.Username, user.Credentials.Secret)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.accessType, token.GetAccessType(), fmt.Sprintf("%s: expected access type %s got %s\n", tc.desc, tc.accessType, token.GetAccessType()))
+				assert.NotEmpty(t, token.GetAccessToken(), fmt.Sprintf("%s: expected access token not to be empty\n", tc.desc))
+			}
+			authCall.Unset()
+			domainsCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestVerifyMFA(t *testing.T) {
+	svc, auth, cRepo, _ := newMFAService()
+
+	mfaSecret, err := totp.GenerateSecret()
+	assert.Nil(t, err, fmt.Sprintf("generating TOTP secret expected to succeed: %s", err))
+	codeTime := time.Now()
+	code, err := totp.Generate(mfaSecret, codeTime)
+	assert.Nil(t, err, fmt.Sprintf("generating TOTP code expected to succeed: %s", err))
+	step := codeTime.Unix() / int64(totp.Period.Seconds())
+	recoveryCode := "abcde-fghij"
+	recoveryHash, err := phasher.Hash(recoveryCode)
+	assert.Nil(t, err, fmt.Sprintf("hashing recovery code expected to succeed: %s", err))
+
+	now := time.Now()
+	challenge, err := tokenizer.Issue(smqauth.Key{Type: smqauth.MFAKey, User: user.ID, IssuedAt: now, ExpiresAt: now.Add(time.Minute)})
+	assert.Nil(t, err, fmt.Sprintf("issuing challenge token expected to succeed: %s", err))
+	accessToken, err := tokenizer.Issue(smqauth.Key{Type: smqauth.AccessKey, User: user.ID, IssuedAt: now, ExpiresAt: now.Add(time.Minute)})
+	assert.Nil(t, err, fmt.Sprintf("issuing access token expected to succeed: %s", err))
+	expired, err := tokenizer.Issue(smqauth.Key{Type: smqauth.MFAKey, User: user.ID, IssuedAt: now.Add(-time.Hour), ExpiresAt: now.Add(-time.Minute)})
+	assert.Nil(t, err, fmt.Sprintf("issuing expired token expected to succeed: %s", err))
+
+	enabledMFA := users.MFA{Enabled: true, Secret: mfaSecret, RecoveryCodes: []string{recoveryHash}}
+
+	cases := []struct {
+		desc             string
+		token            string
+		code             string
+		retrieveResponse users.User
+		retrieveErr      error
+		mfa              users.MFA
+		retrieveMFAErr   error
+		updateMFAErr     error
+		updateStepErr    error
+		issueResponse    *grpcTokenV1.Token
+		issueErr         error
+		err              error
+	}{
+		{
+			desc:             "verify MFA with valid TOTP code",
+			token:            challenge,
+			code:             code,
+			retrieveResponse: user,
+			mfa:              enabledMFA,
+			issueResponse:    &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+			err:              nil,
+		},
+		{
+			desc:             "verify MFA with already used TOTP code",
+			token:            challenge,
+			code:             code,
+			retrieveResponse: user,
+			mfa:              users.MFA{Enabled: true, Secret: mfaSecret, RecoveryCodes: []string{recoveryHash}, LastStep: step},
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "verify MFA with TOTP code used concurrently",
+			token:            challenge,
+			code:             code,
+			retrieveResponse: user,
+			mfa:              enabledMFA,
+			updateStepErr:    repoerr.ErrNotFound,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "verify MFA with valid recovery code",
+			token:            challenge,
+			code:             recoveryCode,
+			retrieveResponse: user,
+			mfa:              enabledMFA,
+			issueResponse:    &grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken, AccessType: "3"},
+			err:              nil,
+		},
+		{
+			desc:             "verify MFA with recovery code and failed update",
+			token:            challenge,
+			code:             recoveryCode,
+			retrieveResponse: user,
+			mfa:              enabledMFA,
+			updateMFAErr:     repoerr.ErrNotFound,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "verify MFA with invalid code",
+			token:            challenge,
+			code:             "000000",
+			retrieveResponse: user,
+			mfa:              enabledMFA,
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "verify MFA with access token",
+			token: accessToken,
+			code:  code,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "verify MFA with expired token",
+			token: expired,
+			code:  code,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:  "verify MFA with invalid token",
+			token: "invalid",
+			code:  code,
+			err:   svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "verify MFA for non-existing user",
+			token:       challenge,
+			code:        code,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "verify MFA for user without MFA enabled",
+			token:            challenge,
+			code:             code,
+			retrieveResponse: user,
+			mfa:              users.MFA{Secret: mfaSecret},
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "verify MFA with failed token issue",
+			token:            challenge,
+			code:             code,
+			retrieveResponse: user,
+			mfa:              enabledMFA,
+			issueResponse:    &grpcTokenV1.Token{},
+			issueErr:         svcerr.ErrAuthentication,
+			err:              svcerr.ErrAuthentication,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RetrieveByID", context.Background(), user.ID).Return(tc.retrieveResponse, tc.retrieveErr)
+			repoCall1 := cRepo.On("RetrieveMFA", context.Background(), user.ID).Return(tc.mfa, tc.retrieveMFAErr)
+			repoCall2 := cRepo.On("UpdateMFA", context.Background(), user.ID, mock.Anything).Return(tc.updateMFAErr)
+			repoCall3 := cRepo.On("UpdateMFAStep", context.Background(), user.ID, step).Return(tc.updateStepErr)
+			authCall := auth.On("Issue", context.Background(), &grpcTokenV1.IssueReq{UserId: user.ID, Type: uint32(smqauth.AccessKey)}).Return(tc.issueResponse, tc.issueErr)
+			token, err := svc.VerifyMFA(context.Background(), tc.token, tc.code)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, validToken, token.GetAccessToken(), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, validToken, token.GetAccessToken()))
+			}
+			authCall.Unset()
+			repoCall3.Unset()
+			repoCall2.Unset()
+			repoCall1.Unset()
+			repoCall.Unset()
 		})
 	}
 }
+
+func TestEnrollMFA(t *testing.T) {
+	svc, _, cRepo, _ := newMFAService()
+
+	mfaUser := user
+	mfaUser.MFAEnabled = true
+
+	cases := []struct {
+		desc             string
+		retrieveResponse users.User
+		retrieveErr      error
+		updateMFAErr     error
+		err              error
+	}{
+		{
+			desc:             "enroll MFA successfully",
+			retrieveResponse: user,
+			err:              nil,
+		},
+		{
+			desc:             "enroll MFA for user with MFA enabled",
+			retrieveResponse: mfaUser,
+			err:              svcerr.ErrConflict,
+		},
+		{
+			desc:        "enroll MFA for non-existing user",
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:             "enroll MFA with failed update",
+			retrieveResponse: user,
+			updateMFAErr:     repoerr.ErrNotFound,
+			err:              svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RetrieveByID", context.Background(), user.ID).Return(tc.retrieveResponse, tc.retrieveErr)
+			repoCall1 := cRepo.On("UpdateMFA", context.Background(), user.ID, mock.Anything).Return(tc.updateMFAErr)
+			enrolment, err := svc.EnrollMFA(context.Background(), authn.Session{UserID: user.ID})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.NotEmpty(t, enrolment.Secret, fmt.Sprintf("%s: expected secret not to be empty\n", tc.desc))
+				assert.True(t, strings.HasPrefix(enrolment.URI, "otpauth://totp/"), fmt.Sprintf("%s: expected otpauth URI got %s\n", tc.desc, enrolment.URI))
+				assert.Len(t, enrolment.RecoveryCodes, 10, fmt.Sprintf("%s: expected 10 recovery codes got %d\n", tc.desc, len(enrolment.RecoveryCodes)))
+			}
+			repoCall1.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestEnableMFA(t *testing.T) {
+	svc, _, cRepo, _ := newMFAService()
+
+	mfaSecret, err := totp.GenerateSecret()
+	assert.Nil(t, err, fmt.Sprintf("generating TOTP secret expected to succeed: %s", err))
+	codeTime := time.Now()
+	code, err := totp.Generate(mfaSecret, codeTime)
+	assert.Nil(t, err, fmt.Sprintf("generating TOTP code expected to succeed: %s", err))
+	step := codeTime.Unix() / int64(totp.Period.Seconds())
+
+	cases := []struct {
+		desc           string
+		code           string
+		mfa            users.MFA
+		retrieveMFAErr error
+		updateMFAErr   error
+		err            error
+	}{
+		{
+			desc: "enable MFA successfully",
+			code: code,
+			mfa:  users.MFA{Secret: mfaSecret},
+			err:  nil,
+		},
+		{
+			desc: "enable MFA with invalid code",
+			code: "000000",
+			mfa:  users.MFA{Secret: mfaSecret},
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "enable MFA without enrolment",
+			code: code,
+			mfa:  users.MFA{},
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc: "enable MFA when already enabled",
+			code: code,
+			mfa:  users.MFA{Enabled: true, Secret: mfaSecret},
+			err:  svcerr.ErrConflict,
+		},
+		{
+			desc:           "enable MFA for non-existing user",
+			code:           code,
+			retrieveMFAErr: repoerr.ErrNotFound,
+			err:            svcerr.ErrViewEntity,
+		},
+		{
+			desc:         "enable MFA with failed update",
+			code:         code,
+			mfa:          users.MFA{Secret: mfaSecret},
+			updateMFAErr: repoerr.ErrNotFound,
+			err:          svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RetrieveMFA", context.Background(), user.ID).Return(tc.mfa, tc.retrieveMFAErr)
+			repoCall1 := cRepo.On("UpdateMFA", context.Background(), user.ID, users.MFA{Enabled: true, Secret: mfaSecret, LastStep: step}).Return(tc.updateMFAErr)
+			err := svc.EnableMFA(context.Background(), authn.Session{UserID: user.ID}, tc.code)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall1.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestDisableMFA(t *testing.T) {
+	svc, _, cRepo, domainsClient := newMFAService()
+
+	mfaSecret, err := totp.GenerateSecret()
+	assert.Nil(t, err, fmt.Sprintf("generating TOTP secret expected to succeed: %s", err))
+	codeTime := time.Now()
+	code, err := totp.Generate(mfaSecret, codeTime)
+	assert.Nil(t, err, fmt.Sprintf("generating TOTP code expected to succeed: %s", err))
+	step := codeTime.Unix() / int64(totp.Period.Seconds())
+	enabledMFA := users.MFA{Enabled: true, Secret: mfaSecret}
+
+	cases := []struct {
+		desc                string
+		code                string
+		mfa                 users.MFA
+		retrieveMFAErr      error
+		requiresMFAResponse *grpcDomainsV1.RequiresMFARes
+		updateMFAErr        error
+		err                 error
+	}{
+		{
+			desc:                "disable MFA successfully",
+			code:                code,
+			mfa:                 enabledMFA,
+			requiresMFAResponse: &grpcDomainsV1.RequiresMFARes{Required: false},
+			err:                 nil,
+		},
+		{
+			desc:                "disable MFA with invalid code",
+			code:                "000000",
+			mfa:                 enabledMFA,
+			requiresMFAResponse: &grpcDomainsV1.RequiresMFARes{Required: false},
+			err:                 svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:                "disable MFA with already used code",
+			code:                code,
+			mfa:                 users.MFA{Enabled: true, Secret: mfaSecret, LastStep: step},
+			requiresMFAResponse: &grpcDomainsV1.RequiresMFARes{Required: false},
+			err:                 svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:                "disable MFA when required by domain",
+			code:                code,
+			mfa:                 enabledMFA,
+			requiresMFAResponse: &grpcDomainsV1.RequiresMFARes{Required: true},
+			err:                 users.ErrMFARequired,
+		},
+		{
+			desc: "disable MFA when not enabled",
+			code: code,
+			mfa:  users.MFA{},
+			err:  svcerr.ErrMalformedEntity,
+		},
+		{
+			desc:           "disable MFA for non-existing user",
+			code:           code,
+			retrieveMFAErr: repoerr.ErrNotFound,
+			err:            svcerr.ErrViewEntity,
+		},
+		{
+			desc:                "disable MFA with failed update",
+			code:                code,
+			mfa:                 enabledMFA,
+			requiresMFAResponse: &grpcDomainsV1.RequiresMFARes{Required: false},
+			updateMFAErr:        repoerr.ErrNotFound,
+			err:                 svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RetrieveMFA", context.Background(), user.ID).Return(tc.mfa, tc.retrieveMFAErr)
+			domainsCall := domainsClient.On("RequiresMFA", context.Background(), &grpcDomainsV1.RequiresMFAReq{UserId: user.ID}).Return(tc.requiresMFAResponse, nil)
+			repoCall1 := cRepo.On("UpdateMFA", context.Background(), user.ID, users.MFA{}).Return(tc.updateMFAErr)
+			repoCall2 := cRepo.On("UpdateMFAStep", context.Background(), user.ID, step).Return(nil)
+			err := svc.DisableMFA(context.Background(), authn.Session{UserID: user.ID}, tc.code)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall2.Unset()
+			repoCall1.Unset()
+			domainsCall.Unset()
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestRefreshTokenSessionInfo(t *testing.T) {
+	svc, authsvc, crepo, _, _ := newService()
+
+	info := smqauth.SessionInfo{UserAgent: "Mozilla/5.0", IPAddress: "192.168.0.1"}
+	ctx := smqauth.WithSessionInfo(context.Background(), info)
+	req := &grpcTokenV1.RefreshReq{RefreshToken: validToken, UserAgent: info.UserAgent, IpAddress: info.IPAddress}
+
+	repoCall := crepo.On("RetrieveByID", ctx, user.ID).Return(user, nil)
+	authCall := authsvc.On("Refresh", ctx, req).Return(&grpcTokenV1.Token{AccessToken: validToken, RefreshToken: &validToken}, nil)
+	_, err := svc.RefreshToken(ctx, authn.Session{UserID: user.ID}, validToken)
+	assert.Nil(t, err, fmt.Sprintf("refreshing token expected to succeed: %s", err))
+	ok := authCall.Parent.AssertCalled(t, "Refresh", ctx, req)
+	assert.True(t, ok, "Refresh was not called with the session info")
+	authCall.Unset()
+	repoCall.Unset()
+}
+
+func TestListSessions(t *testing.T) {
+	svc, authsvc, _, _, _ := newService()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	session := users.Session{
+		ID:         testsutil.GenerateUUID(t),
+		UserAgent:  "Mozilla/5.0",
+		IPAddress:  "192.168.0.1",
+		IssuedAt:   now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+
+	cases := []struct {
+		desc     string
+		listResp *grpcTokenV1.ListSessionsRes
+		listErr  error
+		sessions []users.Session
+		err      error
+	}{
+		{
+			desc: "list sessions successfully",
+			listResp: &grpcTokenV1.ListSessionsRes{
+				Sessions: []*grpcTokenV1.Session{
+					{
+						Id:         session.ID,
+						UserId:     user.ID,
+						UserAgent:  session.UserAgent,
+						IpAddress:  session.IPAddress,
+						IssuedAt:   timestamppb.New(session.IssuedAt),
+						LastUsedAt: timestamppb.New(session.LastUsedAt),
+						ExpiresAt:  timestamppb.New(session.ExpiresAt),
+					},
+				},
+			},
+			sessions: []users.Session{session},
+			err:      nil,
+		},
+		{
+			desc:     "list sessions of the user without sessions",
+			listResp: &grpcTokenV1.ListSessionsRes{},
+			sessions: []users.Session{},
+			err:      nil,
+		},
+		{
+			desc:     "list sessions with failed token service",
+			listResp: &grpcTokenV1.ListSessionsRes{},
+			listErr:  svcerr.ErrAuthentication,
+			err:      svcerr.ErrViewEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authsvc.On("ListSessions", context.Background(), &grpcTokenV1.ListSessionsReq{UserId: user.ID}).Return(tc.listResp, tc.listErr)
+			sessions, err := svc.ListSessions(context.Background(), authn.Session{UserID: user.ID})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if err == nil {
+				assert.Equal(t, tc.sessions, sessions, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.sessions, sessions))
+			}
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRevokeSession(t *testing.T) {
+	svc, authsvc, _, _, _ := newService()
+
+	sessionID := testsutil.GenerateUUID(t)
+
+	cases := []struct {
+		desc      string
+		revokeErr error
+		err       error
+	}{
+		{
+			desc: "revoke session successfully",
+			err:  nil,
+		},
+		{
+			desc:      "revoke non-existing session",
+			revokeErr: svcerr.ErrNotFound,
+			err:       svcerr.ErrNotFound,
+		},
+		{
+			desc:      "revoke session with failed token service",
+			revokeErr: svcerr.ErrAuthentication,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authsvc.On("RevokeSession", context.Background(), &grpcTokenV1.RevokeSessionReq{UserId: user.ID, SessionId: sessionID}).Return(&grpcTokenV1.RevokeSessionRes{}, tc.revokeErr)
+			err := svc.RevokeSession(context.Background(), authn.Session{UserID: user.ID}, sessionID)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	svc, authsvc, _, _, _ := newService()
+
+	cases := []struct {
+		desc      string
+		revokeErr error
+		err       error
+	}{
+		{
+			desc: "revoke sessions successfully",
+			err:  nil,
+		},
+		{
+			desc:      "revoke sessions with failed token service",
+			revokeErr: svcerr.ErrAuthentication,
+			err:       svcerr.ErrRemoveEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			authCall := authsvc.On("RevokeSession", context.Background(), &grpcTokenV1.RevokeSessionReq{UserId: user.ID}).Return(&grpcTokenV1.RevokeSessionRes{}, tc.revokeErr)
+			err := svc.RevokeSessions(context.Background(), authn.Session{UserID: user.ID})
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			authCall.Unset()
+		})
+	}
+}
+
+func TestRegisterWithVerification(t *testing.T) {
+	svc, tokenClient, cRepo, policies, e := newVerificationService()
+
+	cases := []struct {
+		desc         string
+		selfRegister bool
+		status       users.Status
+		issueErr     error
+		sendErr      error
+		err          error
+	}{
+		{
+			desc:         "self register user pending verification",
+			selfRegister: true,
+			status:       users.PendingStatus,
+			err:          nil,
+		},
+		{
+			desc:         "self register user with failed to issue verification token",
+			selfRegister: true,
+			status:       users.PendingStatus,
+			issueErr:     svcerr.ErrAuthentication,
+			err:          svcerr.ErrAuthentication,
+		},
+		{
+			desc:         "self register user with failed to send verification email",
+			selfRegister: true,
+			status:       users.PendingStatus,
+			sendErr:      errors.New("failed to send email"),
+			err:          errors.New("failed to send email"),
+		},
+		{
+			desc:         "register user as admin without verification",
+			selfRegister: false,
+			status:       users.EnabledStatus,
+			err:          nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var saved users.User
+			repoCall := cRepo.On("CheckSuperAdmin", context.Background(), mock.Anything).Return(nil)
+			policyCall := policies.On("AddPolicies", context.Background(), mock.Anything).Return(nil)
+			repoCall1 := cRepo.On("Save", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(users.User)
+			}).Return(user, nil)
+			var nonce string
+			repoCall2 := cRepo.On("UpdateVerification", context.Background(), user.ID, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				nonce = args.String(2)
+			}).Return(nil)
+			var issueReq *grpcTokenV1.IssueReq
+			authCall := tokenClient.On("Issue", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				issueReq = args.Get(1).(*grpcTokenV1.IssueReq)
+			}).Return(&grpcTokenV1.Token{AccessToken: validToken}, tc.issueErr)
+			emailCall := e.On("SendVerification", []string{user.Email}, user.Credentials.Username, validToken).Return(tc.sendErr)
+			_, err := svc.Register(context.Background(), authn.Session{UserID: validID}, user, tc.selfRegister)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.status, saved.Status, fmt.Sprintf("%s: expected status %s got %s\n", tc.desc, tc.status, saved.Status))
+			if tc.selfRegister {
+				assert.Equal(t, user.Email, issueReq.GetEmail(), fmt.Sprintf("%s: expected verification token for %s got %s\n", tc.desc, user.Email, issueReq.GetEmail()))
+				assert.Equal(t, nonce, issueReq.GetNonce(), fmt.Sprintf("%s: expected verification token nonce %s got %s\n", tc.desc, nonce, issueReq.GetNonce()))
+			}
+			repoCall.Unset()
+			policyCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			authCall.Unset()
+			emailCall.Unset()
+		})
+	}
+}
+
+func TestUpdateEmailWithVerification(t *testing.T) {
+	svc, tokenClient, cRepo, _, e := newVerificationService()
+
+	newEmail := "updated@example.com"
+	pendingUser := user
+	pendingUser.PendingEmail = newEmail
+
+	cases := []struct {
+		desc               string
+		session            authn.Session
+		id                 string
+		retrieveByEmailErr error
+		updatePendingErr   error
+		response           users.User
+		err                error
+	}{
+		{
+			desc:               "update own email pending verification",
+			session:            authn.Session{UserID: user.ID},
+			id:                 user.ID,
+			retrieveByEmailErr: repoerr.ErrNotFound,
+			response:           pendingUser,
+			err:                nil,
+		},
+		{
+			desc:     "update own email to email of other user",
+			session:  authn.Session{UserID: user.ID},
+			id:       user.ID,
+			response: users.User{},
+			err:      repoerr.ErrConflict,
+		},
+		{
+			desc:               "update own email with failed to update pending email",
+			session:            authn.Session{UserID: user.ID},
+			id:                 user.ID,
+			retrieveByEmailErr: repoerr.ErrNotFound,
+			updatePendingErr:   repoerr.ErrNotFound,
+			response:           users.User{},
+			err:                svcerr.ErrUpdateEntity,
+		},
+		{
+			desc:     "update email of other user as admin without verification",
+			session:  authn.Session{UserID: validID, SuperAdmin: true},
+			id:       user.ID,
+			response: user,
+			err:      nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RetrieveByEmail", context.Background(), newEmail).Return(user, tc.retrieveByEmailErr)
+			repoCall1 := cRepo.On("UpdatePendingEmail", context.Background(), mock.Anything).Return(pendingUser, tc.updatePendingErr)
+			repoCall2 := cRepo.On("Update", context.Background(), mock.Anything).Return(user, nil)
+			repoCall3 := cRepo.On("UpdateVerification", context.Background(), user.ID, mock.Anything, mock.Anything).Return(nil)
+			var issueReq *grpcTokenV1.IssueReq
+			authCall := tokenClient.On("Issue", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				issueReq = args.Get(1).(*grpcTokenV1.IssueReq)
+			}).Return(&grpcTokenV1.Token{AccessToken: validToken}, nil)
+			emailCall := e.On("SendVerification", []string{newEmail}, user.Credentials.Username, validToken).Return(nil)
+			updated, err := svc.UpdateEmail(context.Background(), tc.session, tc.id, newEmail)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, updated, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, updated))
+			if tc.err == nil && !tc.session.SuperAdmin {
+				assert.Equal(t, newEmail, issueReq.GetEmail(), fmt.Sprintf("%s: expected verification token for %s got %s\n", tc.desc, newEmail, issueReq.GetEmail()))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			repoCall3.Unset()
+			authCall.Unset()
+			emailCall.Unset()
+		})
+	}
+}
+
+func TestVerifyEmail(t *testing.T) {
+	svc, tokenClient, cRepo, _, _ := newVerificationService()
+
+	nonce := testsutil.GenerateUUID(t)
+	pendingUser := user
+	pendingUser.Status = users.PendingStatus
+	changingUser := user
+	changingUser.PendingEmail = "updated@example.com"
+	verifiedUser := user
+	verifiedUser.Email = changingUser.PendingEmail
+
+	cases := []struct {
+		desc             string
+		token            string
+		email            string
+		verifyErr        error
+		retrieveResponse users.User
+		retrieveErr      error
+		confirmed        users.User
+		updateErr        error
+		response         users.User
+		err              error
+	}{
+		{
+			desc:             "verify email of pending user",
+			token:            validToken,
+			email:            pendingUser.Email,
+			retrieveResponse: pendingUser,
+			confirmed:        users.User{ID: user.ID, Email: pendingUser.Email},
+			response:         user,
+			err:              nil,
+		},
+		{
+			desc:             "verify pending email of user",
+			token:            validToken,
+			email:            changingUser.PendingEmail,
+			retrieveResponse: changingUser,
+			confirmed:        users.User{ID: user.ID, PendingEmail: changingUser.PendingEmail},
+			response:         verifiedUser,
+			err:              nil,
+		},
+		{
+			desc:             "verify pending email with token for previous pending email",
+			token:            validToken,
+			email:            "previous@example.com",
+			retrieveResponse: changingUser,
+			confirmed:        users.User{ID: user.ID, PendingEmail: "previous@example.com"},
+			updateErr:        repoerr.ErrNotFound,
+			response:         users.User{},
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "verify email of pending user with used or superseded token",
+			token:            validToken,
+			email:            pendingUser.Email,
+			retrieveResponse: pendingUser,
+			confirmed:        users.User{ID: user.ID, Email: pendingUser.Email},
+			updateErr:        repoerr.ErrNotFound,
+			response:         users.User{},
+			err:              svcerr.ErrAuthentication,
+		},
+		{
+			desc:             "verify email of verified user",
+			token:            validToken,
+			email:            user.Email,
+			retrieveResponse: user,
+			response:         users.User{},
+			err:              svcerr.ErrConflict,
+		},
+		{
+			desc:      "verify email with invalid token",
+			token:     "invalid",
+			verifyErr: svcerr.ErrAuthentication,
+			response:  users.User{},
+			err:       svcerr.ErrAuthentication,
+		},
+		{
+			desc:        "verify email of non-existing user",
+			token:       validToken,
+			email:       user.Email,
+			retrieveErr: repoerr.ErrNotFound,
+			response:    users.User{},
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:             "verify pending email with failed to confirm email",
+			token:            validToken,
+			email:            changingUser.PendingEmail,
+			retrieveResponse: changingUser,
+			confirmed:        users.User{ID: user.ID, PendingEmail: changingUser.PendingEmail},
+			updateErr:        repoerr.ErrConflict,
+			response:         users.User{},
+			err:              svcerr.ErrUpdateEntity,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var confirmed users.User
+			capture := func(args mock.Arguments) {
+				confirmed = args.Get(1).(users.User)
+			}
+			authCall := tokenClient.On("Verify", context.Background(), &grpcTokenV1.VerifyReq{Token: tc.token, Type: uint32(smqauth.VerificationKey)}).Return(&grpcTokenV1.VerifyRes{UserId: user.ID, Email: tc.email, Nonce: nonce}, tc.verifyErr)
+			repoCall := cRepo.On("RetrieveByID", context.Background(), user.ID).Return(tc.retrieveResponse, tc.retrieveErr)
+			repoCall1 := cRepo.On("ConfirmRegistration", context.Background(), mock.Anything, nonce).Run(capture).Return(user, tc.updateErr)
+			repoCall2 := cRepo.On("ConfirmEmail", context.Background(), mock.Anything, nonce).Run(capture).Return(verifiedUser, tc.updateErr)
+			verified, err := svc.VerifyEmail(context.Background(), tc.token)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			assert.Equal(t, tc.response, verified, fmt.Sprintf("%s: expected %v got %v\n", tc.desc, tc.response, verified))
+			assert.Equal(t, tc.confirmed.ID, confirmed.ID, fmt.Sprintf("%s: expected confirmed user %s got %s\n", tc.desc, tc.confirmed.ID, confirmed.ID))
+			assert.Equal(t, tc.confirmed.Email, confirmed.Email, fmt.Sprintf("%s: expected confirmed email %s got %s\n", tc.desc, tc.confirmed.Email, confirmed.Email))
+			assert.Equal(t, tc.confirmed.PendingEmail, confirmed.PendingEmail, fmt.Sprintf("%s: expected confirmed pending email %s got %s\n", tc.desc, tc.confirmed.PendingEmail, confirmed.PendingEmail))
+			authCall.Unset()
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+		})
+	}
+}
+
+func TestResendVerification(t *testing.T) {
+	svc, tokenClient, cRepo, _, e := newVerificationService()
+
+	pendingUser := user
+	pendingUser.ID = testsutil.GenerateUUID(t)
+	pendingUser.Status = users.PendingStatus
+
+	cases := []struct {
+		desc             string
+		email            string
+		retrieveResponse []users.User
+		retrieveErr      error
+		updateErr        error
+		sendErr          error
+		err              error
+	}{
+		{
+			desc:             "resend verification successfully",
+			email:            user.Email,
+			retrieveResponse: []users.User{user},
+			err:              nil,
+		},
+		{
+			desc:             "resend verification to email of pending user and user changing email",
+			email:            user.Email,
+			retrieveResponse: []users.User{pendingUser, user},
+			err:              nil,
+		},
+		{
+			desc:        "resend verification for verified email",
+			email:       user.Email,
+			retrieveErr: repoerr.ErrNotFound,
+			err:         svcerr.ErrViewEntity,
+		},
+		{
+			desc:             "resend verification too early",
+			email:            user.Email,
+			retrieveResponse: []users.User{user},
+			updateErr:        repoerr.ErrNotFound,
+			err:              svcerr.ErrTooManyRequests,
+		},
+		{
+			desc:             "resend verification with failed to send email",
+			email:            user.Email,
+			retrieveResponse: []users.User{user},
+			sendErr:          errors.New("failed to send email"),
+			err:              errors.New("failed to send email"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RetrieveAllByUnverifiedEmail", context.Background(), tc.email).Return(tc.retrieveResponse, tc.retrieveErr)
+			var verified []string
+			var sentBefore time.Time
+			repoCall1 := cRepo.On("UpdateVerification", context.Background(), mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				sentBefore = args.Get(3).(time.Time)
+			}).Return(tc.updateErr)
+			authCall := tokenClient.On("Issue", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				verified = append(verified, args.Get(1).(*grpcTokenV1.IssueReq).GetUserId())
+			}).Return(&grpcTokenV1.Token{AccessToken: validToken}, nil)
+			emailCall := e.On("SendVerification", []string{tc.email}, user.Credentials.Username, validToken).Return(tc.sendErr)
+			err := svc.ResendVerification(context.Background(), tc.email)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				var ids []string
+				for _, u := range tc.retrieveResponse {
+					ids = append(ids, u.ID)
+				}
+				assert.Equal(t, ids, verified, fmt.Sprintf("%s: expected verification tokens for %v got %v\n", tc.desc, ids, verified))
+				assert.True(t, sentBefore.Before(time.Now().Add(-time.Second)), fmt.Sprintf("%s: expected resend interval to be enforced\n", tc.desc))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			authCall.Unset()
+			emailCall.Unset()
+		})
+	}
+}
+
+func TestRegisterWithPasswordPolicy(t *testing.T) {
+	svc, _, cRepo := newPasswordPolicyService(password.Config{MinLength: 12, RequireDigit: true})
+
+	cases := []struct {
+		desc   string
+		secret string
+		err    error
+	}{
+		{
+			desc:   "register user with valid password",
+			secret: "longpassword1",
+			err:    nil,
+		},
+		{
+			desc:   "register user with too short password",
+			secret: "short1",
+			err:    apiutil.ErrPasswordTooShort,
+		},
+		{
+			desc:   "register user with password without digit",
+			secret: "longpassword",
+			err:    apiutil.ErrPasswordDigit,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var saved users.User
+			u := user
+			u.Credentials.Secret = tc.secret
+			repoCall := cRepo.On("Save", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				saved = args.Get(1).(users.User)
+			}).Return(user, nil)
+			_, err := svc.Register(context.Background(), authn.Session{}, u, true)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.False(t, saved.SecretUpdatedAt.IsZero(), fmt.Sprintf("%s: expected password change time to be set", tc.desc))
+			}
+			repoCall.Unset()
+		})
+	}
+}
+
+func TestUpdateSecretWithPasswordHistory(t *testing.T) {
+	svc, tokenClient, cRepo := newPasswordPolicyService(password.Config{History: 3})
+
+	current := "currentpassword"
+	previous := "previouspassword"
+	oldest := "oldestpassword"
+	hash := func(secret string) string {
+		h, err := phasher.Hash(secret)
+		require.Nil(t, err, fmt.Sprintf("unexpected error hashing password: %s", err))
+		return h
+	}
+	dbUser := user
+	dbUser.Credentials.Secret = hash(current)
+	dbUser.SecretHistory = []string{hash(previous), hash(oldest)}
+
+	cases := []struct {
+		desc      string
+		newSecret string
+		history   []string
+		err       error
+	}{
+		{
+			desc:      "update secret to current password",
+			newSecret: current,
+			err:       apiutil.ErrPasswordReused,
+		},
+		{
+			desc:      "update secret to previous password",
+			newSecret: previous,
+			err:       apiutil.ErrPasswordReused,
+		},
+		{
+			desc:      "update secret to oldest password",
+			newSecret: oldest,
+			err:       apiutil.ErrPasswordReused,
+		},
+		{
+			desc:      "update secret to new password",
+			newSecret: "newpassword",
+			history:   []string{dbUser.Credentials.Secret, dbUser.SecretHistory[0]},
+			err:       nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			var updated users.User
+			repoCall := cRepo.On("RetrieveByID", context.Background(), user.ID).Return(dbUser, nil)
+			repoCall1 := cRepo.On("RetrieveByUsername", context.Background(), user.Credentials.Username).Return(dbUser, nil)
+			repoCall2 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Run(func(args mock.Arguments) {
+				updated = args.Get(1).(users.User)
+			}).Return(user, nil)
+			authCall := tokenClient.On("Issue", context.Background(), mock.Anything).Return(&grpcTokenV1.Token{AccessToken: validToken}, nil)
+			_, err := svc.UpdateSecret(context.Background(), authn.Session{UserID: user.ID}, current, tc.newSecret)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			if tc.err == nil {
+				assert.Equal(t, tc.history, updated.SecretHistory, fmt.Sprintf("%s: expected history %v got %v\n", tc.desc, tc.history, updated.SecretHistory))
+			}
+			repoCall.Unset()
+			repoCall1.Unset()
+			repoCall2.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestResetSecretWithPasswordPolicy(t *testing.T) {
+	svc, _, cRepo := newPasswordPolicyService(password.Config{History: 1, RequireUpper: true})
+
+	current := "Currentpassword"
+	dbUser := user
+	dbUser.Credentials.Secret, _ = phasher.Hash(current)
+
+	cases := []struct {
+		desc   string
+		secret string
+		err    error
+	}{
+		{
+			desc:   "reset secret with valid password",
+			secret: "Newpassword",
+			err:    nil,
+		},
+		{
+			desc:   "reset secret with current password",
+			secret: current,
+			err:    apiutil.ErrPasswordReused,
+		},
+		{
+			desc:   "reset secret with password without uppercase letter",
+			secret: "newpassword",
+			err:    apiutil.ErrPasswordUpper,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			repoCall := cRepo.On("RetrieveByID", context.Background(), user.ID).Return(dbUser, nil)
+			repoCall1 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(user, nil)
+			err := svc.ResetSecret(context.Background(), authn.Session{UserID: user.ID}, tc.secret)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+			repoCall1.Unset()
+		})
+	}
+}
+
+func TestIssueTokenWithExpiredPassword(t *testing.T) {
+	svc, tokenClient, cRepo := newPasswordPolicyService(password.Config{MaxAge: time.Hour})
+
+	dbUser := user
+	dbUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+
+	cases := []struct {
+		desc            string
+		secretUpdatedAt time.Time
+		err             error
+	}{
+		{
+			desc:            "issue token with valid password",
+			secretUpdatedAt: time.Now().Add(-time.Minute),
+			err:             nil,
+		},
+		{
+			desc:            "issue token with expired password",
+			secretUpdatedAt: time.Now().Add(-2 * time.Hour),
+			err:             apiutil.ErrPasswordExpired,
+		},
+		{
+			desc: "issue token with unknown password change time",
+			err:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			u := dbUser
+			u.SecretUpdatedAt = tc.secretUpdatedAt
+			repoCall := cRepo.On("RetrieveByUsername", context.Background(), user.Credentials.Username).Return(u, nil)
+			authCall := tokenClient.On("Issue", context.Background(), mock.Anything).Return(&grpcTokenV1.Token{AccessToken: validToken}, nil)
+			_, err := svc.IssueToken(context.Background(), user.Credentials.Username, user.Credentials.Secret)
+			assert.True(t, errors.Contains(err, tc.err), fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+			repoCall.Unset()
+			authCall.Unset()
+		})
+	}
+}
+
+func TestUpdateSecretWithExpiredPassword(t *testing.T) {
+	svc, _, cRepo := newPasswordPolicyService(password.Config{MaxAge: time.Hour})
+
+	dbUser := user
+	dbUser.Credentials.Secret, _ = phasher.Hash(user.Credentials.Secret)
+	dbUser.SecretUpdatedAt = time.Now().Add(-2 * time.Hour)
+	newSecret := "newstrongSecret"
+
+	repoCall := cRepo.On("RetrieveByID", context.Background(), user.ID).Return(dbUser, nil)
+	repoCall1 := cRepo.On("UpdateSecret", context.Background(), mock.Anything).Return(dbUser, nil)
+	defer func() {
+		repoCall.Unset()
+		repoCall1.Unset()
+	}()
+
+	_, err := svc.UpdateSecret(context.Background(), authn.Session{UserID: user.ID}, user.Credentials.Secret, newSecret)
+	assert.Nil(t, err, fmt.Sprintf("updating expired password expected to succeed: %s", err))
+}
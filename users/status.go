@@ -21,6 +21,9 @@ const (
 	DisabledStatus
 	// DeletedStatus represents a user that will be deleted.
 	DeletedStatus
+	// PendingStatus represents a self-registered user who hasn't verified
+	// the e-mail address yet.
+	PendingStatus
 
 	// AllStatus is used for querying purposes to list users irrespective
 	// of their status - both enabled and disabled. It is never stored in the
@@ -34,6 +37,7 @@ const (
 	Disabled = "disabled"
 	Enabled  = "enabled"
 	Deleted  = "deleted"
+	Pending  = "pending"
 	All      = "all"
 	Unknown  = "unknown"
 )
@@ -47,6 +51,8 @@ func (s Status) String() string {
 		return Enabled
 	case DeletedStatus:
 		return Deleted
+	case PendingStatus:
+		return Pending
 	case AllStatus:
 		return All
 	default:
@@ -63,6 +69,8 @@ func ToStatus(status string) (Status, error) {
 		return DisabledStatus, nil
 	case Deleted:
 		return DeletedStatus, nil
+	case Pending:
+		return PendingStatus, nil
 	case All:
 		return AllStatus, nil
 	}
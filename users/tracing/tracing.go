@@ -49,6 +49,65 @@ func (tm *tracingMiddleware) RefreshToken(ctx context.Context, session authn.Ses
 	return tm.svc.RefreshToken(ctx, session, refreshToken)
 }
 
+// VerifyMFA traces the "VerifyMFA" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) VerifyMFA(ctx context.Context, mfaToken, code string) (*grpcTokenV1.Token, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_verify_mfa")
+	defer span.End()
+
+	return tm.svc.VerifyMFA(ctx, mfaToken, code)
+}
+
+// EnrollMFA traces the "EnrollMFA" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) EnrollMFA(ctx context.Context, session authn.Session) (users.MFAEnrolment, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_enroll_mfa", trace.WithAttributes(attribute.String("id", session.UserID)))
+	defer span.End()
+
+	return tm.svc.EnrollMFA(ctx, session)
+}
+
+// EnableMFA traces the "EnableMFA" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) EnableMFA(ctx context.Context, session authn.Session, code string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_enable_mfa", trace.WithAttributes(attribute.String("id", session.UserID)))
+	defer span.End()
+
+	return tm.svc.EnableMFA(ctx, session, code)
+}
+
+// DisableMFA traces the "DisableMFA" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) DisableMFA(ctx context.Context, session authn.Session, code string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_disable_mfa", trace.WithAttributes(attribute.String("id", session.UserID)))
+	defer span.End()
+
+	return tm.svc.DisableMFA(ctx, session, code)
+}
+
+// ListSessions traces the "ListSessions" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) ListSessions(ctx context.Context, session authn.Session) ([]users.Session, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_list_sessions", trace.WithAttributes(attribute.String("id", session.UserID)))
+	defer span.End()
+
+	return tm.svc.ListSessions(ctx, session)
+}
+
+// RevokeSession traces the "RevokeSession" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) RevokeSession(ctx context.Context, session authn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_revoke_session", trace.WithAttributes(
+		attribute.String("id", session.UserID),
+		attribute.String("session_id", id),
+	))
+	defer span.End()
+
+	return tm.svc.RevokeSession(ctx, session, id)
+}
+
+// RevokeSessions traces the "RevokeSessions" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) RevokeSessions(ctx context.Context, session authn.Session) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_revoke_sessions", trace.WithAttributes(attribute.String("id", session.UserID)))
+	defer span.End()
+
+	return tm.svc.RevokeSessions(ctx, session)
+}
+
 // View traces the "View" operation of the wrapped users.Service.
 func (tm *tracingMiddleware) View(ctx context.Context, session authn.Session, id string) (users.User, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_view_user", trace.WithAttributes(attribute.String("id", id)))
@@ -145,6 +204,24 @@ func (tm *tracingMiddleware) UpdateProfilePicture(ctx context.Context, session a
 	return tm.svc.UpdateProfilePicture(ctx, session, usr)
 }
 
+// VerifyEmail traces the "VerifyEmail" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) VerifyEmail(ctx context.Context, token string) (users.User, error) {
+	ctx, span := tm.tracer.Start(ctx, "svc_verify_email")
+	defer span.End()
+
+	return tm.svc.VerifyEmail(ctx, token)
+}
+
+// ResendVerification traces the "ResendVerification" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) ResendVerification(ctx context.Context, email string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_resend_verification", trace.WithAttributes(
+		attribute.String("email", email),
+	))
+	defer span.End()
+
+	return tm.svc.ResendVerification(ctx, email)
+}
+
 // GenerateResetToken traces the "GenerateResetToken" operation of the wrapped users.Service.
 func (tm *tracingMiddleware) GenerateResetToken(ctx context.Context, email, host string) error {
 	ctx, span := tm.tracer.Start(ctx, "svc_generate_reset_token", trace.WithAttributes(
@@ -210,6 +287,14 @@ func (tm *tracingMiddleware) Disable(ctx context.Context, session authn.Session,
 	return tm.svc.Disable(ctx, session, id)
 }
 
+// Unlock traces the "Unlock" operation of the wrapped users.Service.
+func (tm *tracingMiddleware) Unlock(ctx context.Context, session authn.Session, id string) error {
+	ctx, span := tm.tracer.Start(ctx, "svc_unlock_user", trace.WithAttributes(attribute.String("id", id)))
+	defer span.End()
+
+	return tm.svc.Unlock(ctx, session, id)
+}
+
 // ListMembers traces the "ListMembers" operation of the wrapped users.Service.
 func (tm *tracingMiddleware) ListMembers(ctx context.Context, session authn.Session, objectKind, objectID string, pm users.Page) (users.MembersPage, error) {
 	ctx, span := tm.tracer.Start(ctx, "svc_list_members", trace.WithAttributes(attribute.String("object_kind", objectKind)), trace.WithAttributes(attribute.String("object_id", objectID)))
@@ -24,11 +24,20 @@ type User struct {
 	Role           Role        `json:"role"`                      // 0 for normal user, 1 for admin
 	ProfilePicture string      `json:"profile_picture,omitempty"` // profile picture URL
 	Credentials    Credentials `json:"credentials,omitempty"`
+	MFAEnabled     bool        `json:"mfa_enabled,omitempty"`
 	Permissions    []string    `json:"permissions,omitempty"`
 	Email          string      `json:"email,omitempty"`
+	PendingEmail   string      `json:"pending_email,omitempty"` // new e-mail address waiting for verification
 	CreatedAt      time.Time   `json:"created_at,omitempty"`
 	UpdatedAt      time.Time   `json:"updated_at,omitempty"`
 	UpdatedBy      string      `json:"updated_by,omitempty"`
+	// SecretHistory contains the hashes of the previous passwords, the most
+	// recent first.
+	SecretHistory   []string  `json:"-"`
+	SecretUpdatedAt time.Time `json:"-"`
+	// OAuthRole indicates that the role is mapped from the OAuth provider
+	// claims, so it is applied on every OAuth login.
+	OAuthRole bool `json:"-"`
 }
 
 type Credentials struct {
@@ -36,6 +45,35 @@ type Credentials struct {
 	Secret   string `json:"secret,omitempty"`   // password or token
 }
 
+// MFA represents the user's time-based one-time password (TOTP)
+// multi-factor authentication settings.
+type MFA struct {
+	Enabled       bool
+	Secret        string
+	RecoveryCodes []string // hashed recovery codes
+	LastStep      int64    // time step of the last accepted TOTP code
+}
+
+// MFAEnrolment contains the TOTP secret, its provisioning URI and the
+// recovery codes generated on the MFA enrolment. They are shown to the
+// user only once.
+type MFAEnrolment struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Session represents the user's login session, i.e. the device or browser
+// holding a refresh token issued to the user.
+type Session struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	IssuedAt   time.Time `json:"issued_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
 type UsersPage struct {
 	Page
 	Users []User
@@ -79,6 +117,28 @@ type Repository interface {
 	// UpdateSecret updates secret for user with given email.
 	UpdateSecret(ctx context.Context, user User) (User, error)
 
+	// RetrieveAllByUnverifiedEmail retrieves the pending users registered
+	// with the e-mail address and the user changing the e-mail address to it.
+	RetrieveAllByUnverifiedEmail(ctx context.Context, email string) ([]User, error)
+
+	// UpdatePendingEmail sets the new e-mail address of the user, which is
+	// applied once it's verified.
+	UpdatePendingEmail(ctx context.Context, user User) (User, error)
+
+	// UpdateVerification sets the nonce of the latest verification token of
+	// the user. It fails with ErrNotFound if the previous token was sent
+	// after sentBefore.
+	UpdateVerification(ctx context.Context, id, nonce string, sentBefore time.Time) error
+
+	// ConfirmRegistration enables the pending user if the e-mail address and
+	// the verification nonce match.
+	ConfirmRegistration(ctx context.Context, user User, nonce string) (User, error)
+
+	// ConfirmEmail replaces the user's e-mail address with the verified
+	// pending one if the pending e-mail address and the verification nonce
+	// match.
+	ConfirmEmail(ctx context.Context, user User, nonce string) (User, error)
+
 	// ChangeStatus changes user status to enabled or disabled
 	ChangeStatus(ctx context.Context, user User) (User, error)
 
@@ -93,6 +153,16 @@ type Repository interface {
 
 	CheckSuperAdmin(ctx context.Context, adminID string) error
 
+	// RetrieveMFA retrieves the user's MFA settings.
+	RetrieveMFA(ctx context.Context, id string) (MFA, error)
+
+	// UpdateMFA updates the user's MFA settings.
+	UpdateMFA(ctx context.Context, id string, mfa MFA) error
+
+	// UpdateMFAStep stores the time step of the accepted TOTP code if it is
+	// later than the stored one, and returns not found error otherwise.
+	UpdateMFAStep(ctx context.Context, id string, step int64) error
+
 	// Save persists the user account. A non-nil error is returned to indicate
 	// operation failure.
 	Save(ctx context.Context, user User) (User, error)
@@ -139,7 +209,8 @@ type Page struct {
 //go:generate mockery --name Service --output=./mocks --filename service.go --quiet --note "Copyright (c) Abstract Machines"
 type Service interface {
 	// Register creates new user. In case of the failed registration, a
-	// non-nil error value is returned.
+	// non-nil error value is returned. If the e-mail verification is enabled,
+	// self-registered users are pending until they verify the e-mail address.
 	Register(ctx context.Context, session authn.Session, user User, selfRegister bool) (User, error)
 
 	// View retrieves user info for a given user ID and an authorized token.
@@ -163,9 +234,19 @@ type Service interface {
 	// UpdateTags updates the user's tags.
 	UpdateTags(ctx context.Context, session authn.Session, user User) (User, error)
 
-	// UpdateEmail updates the user's email.
+	// UpdateEmail updates the user's email. If the e-mail verification is
+	// enabled, the user's own new address is kept as pending until it's
+	// verified using the token sent to it.
 	UpdateEmail(ctx context.Context, session authn.Session, id, email string) (User, error)
 
+	// VerifyEmail confirms the e-mail address using the verification token.
+	// It enables the pending user or applies the pending e-mail address.
+	VerifyEmail(ctx context.Context, token string) (User, error)
+
+	// ResendVerification sends a new verification token to the e-mail address
+	// of the pending user or to the pending e-mail address of the user.
+	ResendVerification(ctx context.Context, email string) error
+
 	// UpdateUsername updates the user's username.
 	UpdateUsername(ctx context.Context, session authn.Session, id, username string) (User, error)
 
@@ -195,6 +276,11 @@ type Service interface {
 	// Disable logically disables the user identified with the provided ID.
 	Disable(ctx context.Context, session authn.Session, id string) (User, error)
 
+	// Unlock removes the failed login attempts of the user locked out due to
+	// too many failed password or MFA code attempts. Only super admins can
+	// unlock users.
+	Unlock(ctx context.Context, session authn.Session, id string) error
+
 	// Delete deletes user with given ID.
 	Delete(ctx context.Context, session authn.Session, id string) error
 
@@ -202,13 +288,39 @@ type Service interface {
 	Identify(ctx context.Context, session authn.Session) (string, error)
 
 	// IssueToken issues a new access and refresh token when provided with either a username or email.
+	// If the user has MFA enabled, a short-lived MFA challenge token of MFAAccessType is issued instead,
+	// which is exchanged for the access and refresh token using VerifyMFA.
 	IssueToken(ctx context.Context, identity, secret string) (*grpcTokenV1.Token, error)
 
+	// VerifyMFA exchanges the MFA challenge token and the TOTP or recovery code
+	// for a new access and refresh token.
+	VerifyMFA(ctx context.Context, mfaToken, code string) (*grpcTokenV1.Token, error)
+
+	// EnrollMFA generates a new TOTP secret and recovery codes for the user.
+	// MFA is enabled once the secret is confirmed using EnableMFA.
+	EnrollMFA(ctx context.Context, session authn.Session) (MFAEnrolment, error)
+
+	// EnableMFA enables MFA if the code is valid for the enrolled TOTP secret.
+	EnableMFA(ctx context.Context, session authn.Session, code string) error
+
+	// DisableMFA disables MFA if the TOTP or recovery code is valid.
+	DisableMFA(ctx context.Context, session authn.Session, code string) error
+
 	// RefreshToken refreshes expired access tokens.
 	// After an access token expires, the refresh token is used to get
 	// a new pair of access and refresh tokens.
 	RefreshToken(ctx context.Context, session authn.Session, refreshToken string) (*grpcTokenV1.Token, error)
 
+	// ListSessions retrieves the active login sessions of the user.
+	ListSessions(ctx context.Context, session authn.Session) ([]Session, error)
+
+	// RevokeSession revokes the user's login session, so its refresh token
+	// can no longer be used.
+	RevokeSession(ctx context.Context, session authn.Session, id string) error
+
+	// RevokeSessions revokes all the login sessions of the user.
+	RevokeSessions(ctx context.Context, session authn.Session) error
+
 	// OAuthCallback handles the callback from any supported OAuth provider.
 	// It processes the OAuth tokens and either signs in or signs up the user based on the provided state.
 	OAuthCallback(ctx context.Context, user User) (User, error)
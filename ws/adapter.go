@@ -6,9 +6,13 @@ package ws
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
@@ -35,7 +39,8 @@ var (
 // Service specifies web socket service API.
 type Service interface {
 	// Subscribe subscribes message from the broker using the clientKey for authorization,
-	// and the channelID for subscription. Subtopic is optional.
+	// and the channelID for subscription. The clientKey is either the client secret or
+	// the user bearer token, which may be a personal access token. Subtopic is optional.
 	// If the subscription is successful, nil is returned otherwise error is returned.
 	Subscribe(ctx context.Context, clientKey, chanID, subtopic string, client *Client) error
 }
@@ -43,14 +48,18 @@ type Service interface {
 var _ Service = (*adapterService)(nil)
 
 type adapterService struct {
+	authn    smqauthn.Authentication
+	authz    smqauthz.Authorization
 	clients  grpcClientsV1.ClientsServiceClient
 	channels grpcChannelsV1.ChannelsServiceClient
 	pubsub   messaging.PubSub
 }
 
 // New instantiates the WS adapter implementation.
-func New(clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, pubsub messaging.PubSub) Service {
+func New(authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, pubsub messaging.PubSub) Service {
 	return &adapterService{
+		authn:    authn,
+		authz:    authz,
 		clients:  clients,
 		channels: channels,
 		pubsub:   pubsub,
@@ -89,20 +98,14 @@ func (svc *adapterService) Subscribe(ctx context.Context, clientKey, chanID, sub
 // authorize checks if the clientKey is authorized to access the channel
 // and returns the clientID if it is.
 func (svc *adapterService) authorize(ctx context.Context, clientKey, chanID string, msgType connections.ConnType) (string, error) {
-	authnReq := &grpcClientsV1.AuthnReq{
-		ClientSecret: clientKey,
-	}
-	authnRes, err := svc.clients.Authenticate(ctx, authnReq)
+	clientID, clientType, err := svc.authenticate(ctx, clientKey, chanID, msgType)
 	if err != nil {
-		return "", errors.Wrap(svcerr.ErrAuthentication, err)
-	}
-	if !authnRes.GetAuthenticated() {
-		return "", errors.Wrap(svcerr.ErrAuthentication, err)
+		return "", err
 	}
 
 	authzReq := &grpcChannelsV1.AuthzReq{
-		ClientType: policies.ClientType,
-		ClientId:   authnRes.GetId(),
+		ClientType: clientType,
+		ClientId:   clientID,
 		Type:       uint32(msgType),
 		ChannelId:  chanID,
 	}
@@ -114,5 +117,37 @@ func (svc *adapterService) authorize(ctx context.Context, clientKey, chanID stri
 		return "", errors.Wrap(svcerr.ErrAuthorization, err)
 	}
 
-	return authnRes.GetId(), nil
+	return clientID, nil
+}
+
+// authenticate authenticates the client using the client secret or the user
+// using the bearer token, and returns the authenticated ID and its type.
+func (svc *adapterService) authenticate(ctx context.Context, clientKey, chanID string, msgType connections.ConnType) (string, string, error) {
+	if token, ok := strings.CutPrefix(clientKey, apiutil.BearerPrefix); ok {
+		authnSession, err := svc.authn.Authenticate(ctx, token)
+		if err != nil {
+			return "", "", errors.Wrap(svcerr.ErrAuthentication, err)
+		}
+		if authnSession.Type != smqauthn.PersonalAccessToken {
+			return authnSession.DomainUserID, policies.UserType, nil
+		}
+		userID, err := smqauthz.AuthorizeMessagingPAT(ctx, svc.authz, svc.channels, authnSession, chanID, msgType)
+		if err != nil {
+			return "", "", err
+		}
+		return userID, policies.UserType, nil
+	}
+
+	authnReq := &grpcClientsV1.AuthnReq{
+		ClientSecret: clientKey,
+	}
+	authnRes, err := svc.clients.Authenticate(ctx, authnReq)
+	if err != nil {
+		return "", "", errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+	if !authnRes.GetAuthenticated() {
+		return "", "", errors.Wrap(svcerr.ErrAuthentication, err)
+	}
+
+	return authnRes.GetId(), policies.ClientType, nil
 }
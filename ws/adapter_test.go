@@ -10,9 +10,16 @@ import (
 
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
+	grpcCommonV1 "github.com/absmach/supermq/api/grpc/common/v1"
+	apiutil "github.com/absmach/supermq/api/http/util"
+	smqauth "github.com/absmach/supermq/auth"
 	chmocks "github.com/absmach/supermq/channels/mocks"
 	climocks "github.com/absmach/supermq/clients/mocks"
 	"github.com/absmach/supermq/internal/testsutil"
+	smqauthn "github.com/absmach/supermq/pkg/authn"
+	authnmocks "github.com/absmach/supermq/pkg/authn/mocks"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	authzmocks "github.com/absmach/supermq/pkg/authz/mocks"
 	"github.com/absmach/supermq/pkg/connections"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging"
@@ -44,18 +51,25 @@ var (
 	clientID = testsutil.GenerateUUID(&testing.T{})
 )
 
+var (
+	authn = new(authnmocks.Authentication)
+	authz = new(authzmocks.Authorization)
+)
+
 func newService() (ws.Service, *mocks.PubSub, *climocks.ClientsServiceClient, *chmocks.ChannelsServiceClient) {
 	pubsub := new(mocks.PubSub)
 	clients := new(climocks.ClientsServiceClient)
 	channels := new(chmocks.ChannelsServiceClient)
+	authn = new(authnmocks.Authentication)
+	authz = new(authzmocks.Authorization)
 
-	return ws.New(clients, channels, pubsub), pubsub, clients, channels
+	return ws.New(authn, authz, clients, channels, pubsub), pubsub, clients, channels
 }
 
 func TestSubscribe(t *testing.T) {
 	svc, pubsub, clients, channels := newService()
 
-	c := ws.NewClient(nil)
+	c := ws.NewClient(nil, false)
 
 	cases := []struct {
 		desc      string
@@ -177,3 +191,81 @@ func TestSubscribe(t *testing.T) {
 		channelsCall.Unset()
 	}
 }
+
+func TestSubscribeWithPAT(t *testing.T) {
+	svc, pubsub, _, channels := newService()
+
+	c := ws.NewClient(nil, false)
+	patToken := "pat_token"
+	userID := testsutil.GenerateUUID(t)
+	patID := testsutil.GenerateUUID(t)
+	domainID := testsutil.GenerateUUID(t)
+	domainUserID := policies.EncodeDomainUserID(domainID, userID)
+
+	cases := []struct {
+		desc      string
+		authNErr  error
+		patErr    error
+		entityErr error
+		authZRes  *grpcChannelsV1.AuthzRes
+		err       error
+	}{
+		{
+			desc:     "subscribe to channel with personal access token",
+			authZRes: &grpcChannelsV1.AuthzRes{Authorized: true},
+			err:      nil,
+		},
+		{
+			desc:     "subscribe to channel with invalid personal access token",
+			authNErr: svcerr.ErrAuthentication,
+			err:      svcerr.ErrAuthorization,
+		},
+		{
+			desc:   "subscribe to channel with personal access token without subscribe scope",
+			patErr: svcerr.ErrAuthorization,
+			err:    svcerr.ErrAuthorization,
+		},
+		{
+			desc:      "subscribe to non-existing channel with personal access token",
+			entityErr: svcerr.ErrNotFound,
+			err:       svcerr.ErrAuthorization,
+		},
+		{
+			desc:     "subscribe to channel with personal access token of user without access to the channel",
+			authZRes: &grpcChannelsV1.AuthzRes{Authorized: false},
+			err:      svcerr.ErrAuthorization,
+		},
+	}
+
+	for _, tc := range cases {
+		subConfig := messaging.SubscriberConfig{
+			ID:      domainUserID,
+			Topic:   "channels." + chanID + "." + subTopic,
+			Handler: c,
+		}
+		authnCall := authn.On("Authenticate", mock.Anything, patToken).Return(smqauthn.Session{Type: smqauthn.PersonalAccessToken, UserID: userID, PatID: patID}, tc.authNErr)
+		patCall := authz.On("AuthorizePAT", mock.Anything, smqauthz.PatReq{
+			UserID:                   userID,
+			PatID:                    patID,
+			PlatformEntityType:       smqauth.PlatformMesagingScope,
+			OptionalDomainEntityType: smqauth.DomainNullScope,
+			Operation:                smqauth.SubscribeOp,
+			EntityIDs:                []string{chanID},
+		}).Return(tc.patErr)
+		entityCall := channels.On("RetrieveEntity", mock.Anything, &grpcCommonV1.RetrieveEntityReq{Id: chanID}).Return(&grpcCommonV1.RetrieveEntityRes{Entity: &grpcCommonV1.EntityBasic{Id: chanID, DomainId: domainID}}, tc.entityErr)
+		channelsCall := channels.On("Authorize", mock.Anything, &grpcChannelsV1.AuthzReq{
+			ClientType: policies.UserType,
+			ClientId:   domainUserID,
+			Type:       uint32(connections.Subscribe),
+			ChannelId:  chanID,
+		}).Return(tc.authZRes, nil)
+		repoCall := pubsub.On("Subscribe", mock.Anything, subConfig).Return(nil)
+		err := svc.Subscribe(context.Background(), apiutil.BearerPrefix+patToken, chanID, subTopic, c)
+		assert.Equal(t, tc.err, err, fmt.Sprintf("%s: expected %s got %s\n", tc.desc, tc.err, err))
+		authnCall.Unset()
+		patCall.Unset()
+		entityCall.Unset()
+		channelsCall.Unset()
+		repoCall.Unset()
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"testing"
 
 	"github.com/absmach/mgate/pkg/session"
-	"github.com/absmach/mgate/pkg/websockets"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	chmocks "github.com/absmach/supermq/channels/mocks"
@@ -21,7 +20,11 @@ import (
 	smqlog "github.com/absmach/supermq/logger"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
 	authnMocks "github.com/absmach/supermq/pkg/authn/mocks"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
+	authzMocks "github.com/absmach/supermq/pkg/authz/mocks"
 	"github.com/absmach/supermq/pkg/messaging/mocks"
+	presencemocks "github.com/absmach/supermq/pkg/presence/mocks"
+	schemamocks "github.com/absmach/supermq/pkg/schema/mocks"
 	"github.com/absmach/supermq/ws"
 	"github.com/absmach/supermq/ws/api"
 	"github.com/gorilla/websocket"
@@ -40,9 +43,9 @@ const (
 
 var msg = []byte(`[{"n":"current","t":-1,"v":1.6}]`)
 
-func newService(clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) (ws.Service, *mocks.PubSub) {
+func newService(authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) (ws.Service, *mocks.PubSub) {
 	pubsub := new(mocks.PubSub)
-	return ws.New(clients, channels, pubsub), pubsub
+	return ws.New(authn, authz, clients, channels, pubsub), pubsub
 }
 
 func newHTTPServer(svc ws.Service) *httptest.Server {
@@ -52,10 +55,7 @@ func newHTTPServer(svc ws.Service) *httptest.Server {
 
 func newProxyHTPPServer(svc session.Handler, targetServer *httptest.Server) (*httptest.Server, error) {
 	turl := strings.ReplaceAll(targetServer.URL, "http", "ws")
-	mp, err := websockets.NewProxy("", turl, smqlog.NewMock(), svc)
-	if err != nil {
-		return nil, err
-	}
+	mp := ws.NewProxy("", turl, svc, nil, smqlog.NewMock())
 	return httptest.NewServer(http.HandlerFunc(mp.Handler)), nil
 }
 
@@ -97,10 +97,13 @@ func TestHandshake(t *testing.T) {
 	clients := new(climocks.ClientsServiceClient)
 	channels := new(chmocks.ChannelsServiceClient)
 	authn := new(authnMocks.Authentication)
-	svc, pubsub := newService(clients, channels)
+	authz := new(authzMocks.Authorization)
+	svc, pubsub := newService(authn, authz, clients, channels)
 	target := newHTTPServer(svc)
 	defer target.Close()
-	handler := ws.NewHandler(pubsub, smqlog.NewMock(), authn, clients, channels)
+	schemas := new(schemamocks.Cache)
+	es := new(presencemocks.EventStore)
+	handler := ws.NewHandler(pubsub, es, smqlog.NewMock(), authn, authz, clients, channels, schemas)
 	ts, err := newProxyHTPPServer(handler, target)
 	require.Nil(t, err)
 	defer ts.Close()
@@ -109,6 +112,9 @@ func TestHandshake(t *testing.T) {
 	clients.On("Authenticate", mock.Anything, mock.Anything).Return(&grpcClientsV1.AuthnRes{Authenticated: true}, nil)
 	authn.On("Authenticate", mock.Anything, mock.Anything).Return(smqauthn.Session{}, nil)
 	channels.On("Authorize", mock.Anything, mock.Anything, mock.Anything).Return(&grpcChannelsV1.AuthzRes{Authorized: true}, nil)
+	schemas.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	es.On("Connect", mock.Anything, mock.Anything).Return(nil)
+	es.On("Disconnect", mock.Anything, mock.Anything).Return(nil)
 
 	cases := []struct {
 		desc      string
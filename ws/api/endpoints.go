@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/absmach/supermq/pkg/errors"
@@ -31,7 +32,7 @@ func handshake(ctx context.Context, svc ws.Service) http.HandlerFunc {
 			return
 		}
 		req.conn = conn
-		client := ws.NewClient(conn)
+		client := ws.NewClient(conn, req.headers)
 
 		if err := svc.Subscribe(ctx, req.clientKey, req.chanID, req.subtopic, client); err != nil {
 			req.conn.Close()
@@ -60,6 +61,14 @@ func decodeRequest(r *http.Request) (connReq, error) {
 		chanID:    chanID,
 	}
 
+	if hdrs := r.URL.Query().Get(headersKey); hdrs != "" {
+		headers, err := strconv.ParseBool(hdrs)
+		if err != nil {
+			return connReq{}, errors.ErrMalformedEntity
+		}
+		req.headers = headers
+	}
+
 	channelParts := channelPartRegExp.FindStringSubmatch(r.RequestURI)
 	if len(channelParts) < 2 {
 		logger.Warn("Empty channel id or malformed url")
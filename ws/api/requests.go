@@ -9,5 +9,6 @@ type connReq struct {
 	clientKey string
 	chanID    string
 	subtopic  string
+	headers   bool
 	conn      *websocket.Conn
 }
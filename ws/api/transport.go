@@ -19,6 +19,7 @@ import (
 const (
 	service             = "ws"
 	readwriteBufferSize = 1024
+	headersKey          = "headers"
 )
 
 var (
@@ -4,21 +4,35 @@
 package ws
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/gorilla/websocket"
 )
 
 // Client handles messaging and websocket connection.
 type Client struct {
-	conn *websocket.Conn
-	id   string
+	conn    *websocket.Conn
+	id      string
+	headers bool
+}
+
+// Envelope is the message delivered to the clients which request the message
+// headers. The payload is base64 encoded.
+type Envelope struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	Payload []byte            `json:"payload"`
 }
 
-// NewClient returns a new websocket client.
-func NewClient(c *websocket.Conn) *Client {
+// NewClient returns a new websocket client. If headers is set, the messages
+// are delivered as JSON envelopes with the message headers, since WebSocket
+// frames don't carry the message properties.
+func NewClient(c *websocket.Conn, headers bool) *Client {
 	return &Client{
-		conn: c,
-		id:   "",
+		conn:    c,
+		id:      "",
+		headers: headers,
 	}
 }
 
@@ -36,6 +50,17 @@ func (c *Client) Handle(msg *messaging.Message) error {
 	if msg.GetPublisher() == c.id {
 		return nil
 	}
+	if messaging.Expired(msg, time.Now()) {
+		return nil
+	}
+
+	if !c.headers {
+		return c.conn.WriteMessage(websocket.TextMessage, msg.GetPayload())
+	}
+	data, err := json.Marshal(Envelope{Headers: msg.GetHeaders(), Payload: msg.GetPayload()})
+	if err != nil {
+		return err
+	}
 
-	return c.conn.WriteMessage(websocket.TextMessage, msg.GetPayload())
+	return c.conn.WriteMessage(websocket.TextMessage, data)
 }
@@ -4,6 +4,7 @@
 package ws_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -12,12 +13,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/ws"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 )
 
-const expectedCount = uint64(1)
+const expectedCount = uint64(2)
 
 var (
 	msgChan = make(chan []byte)
@@ -61,31 +63,71 @@ func TestHandle(t *testing.T) {
 	}
 	defer wsConn.Close()
 
-	c = ws.NewClient(wsConn)
+	headersMsg := messaging.Message{
+		Channel:   chanID,
+		Publisher: id,
+		Protocol:  protocol,
+		Payload:   msg.Payload,
+		Created:   time.Now().UnixNano(),
+		Headers: map[string]string{
+			messaging.ContentTypeHeader:   "application/senml+json",
+			messaging.MessageExpiryHeader: "60",
+		},
+	}
+	envelope, err := json.Marshal(ws.Envelope{Headers: headersMsg.Headers, Payload: headersMsg.Payload})
+	assert.Nil(t, err, fmt.Sprintf("unexpected error marshaling envelope: %s", err))
+	expiredMsg := messaging.Message{
+		Channel:   chanID,
+		Publisher: id,
+		Protocol:  protocol,
+		Payload:   msg.Payload,
+		Created:   time.Now().Add(-time.Minute).UnixNano(),
+		Headers:   map[string]string{messaging.MessageExpiryHeader: "30"},
+	}
 
 	cases := []struct {
 		desc            string
+		headers         bool
+		msg             *messaging.Message
 		publisher       string
 		expectedPayload []byte
 		expectMsg       bool
 	}{
 		{
 			desc:            "handling with different id from ws.Client",
+			msg:             &msg,
 			publisher:       msg.Publisher,
 			expectedPayload: msg.Payload,
 			expectMsg:       true,
 		},
 		{
 			desc:            "handling with same id as ws.Client (empty by default) drops message",
+			msg:             &msg,
 			publisher:       "",
 			expectedPayload: []byte{},
 			expectMsg:       false,
 		},
+		{
+			desc:            "handling with headers",
+			headers:         true,
+			msg:             &headersMsg,
+			publisher:       headersMsg.Publisher,
+			expectedPayload: envelope,
+			expectMsg:       true,
+		},
+		{
+			desc:            "handling expired message drops message",
+			msg:             &expiredMsg,
+			publisher:       expiredMsg.Publisher,
+			expectedPayload: []byte{},
+			expectMsg:       false,
+		},
 	}
 
 	for _, tc := range cases {
-		msg.Publisher = tc.publisher
-		err = c.Handle(&msg)
+		c = ws.NewClient(wsConn, tc.headers)
+		tc.msg.Publisher = tc.publisher
+		err = c.Handle(tc.msg)
 		assert.Nil(t, err, fmt.Sprintf("expected nil error from handle, got: %s", err))
 		receivedMsg := []byte{}
 		switch tc.expectMsg {
@@ -95,7 +137,7 @@ func TestHandle(t *testing.T) {
 		case false:
 			time.Sleep(100 * time.Millisecond) // Give time to server to process c.Handle call.
 		}
-		assert.Equal(t, tc.expectedPayload, receivedMsg, fmt.Sprintf("%s: expected %+v, got %+v", tc.desc, &msg, receivedMsg))
+		assert.Equal(t, tc.expectedPayload, receivedMsg, fmt.Sprintf("%s: expected %+v, got %+v", tc.desc, tc.msg, receivedMsg))
 	}
 	c := atomic.LoadUint64(&count)
 	assert.Equal(t, expectedCount, c, fmt.Sprintf("expected message count %d, got %d", expectedCount, c))
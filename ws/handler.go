@@ -13,15 +13,20 @@ import (
 	"time"
 
 	"github.com/absmach/mgate/pkg/session"
+	"github.com/absmach/supermq"
 	grpcChannelsV1 "github.com/absmach/supermq/api/grpc/channels/v1"
 	grpcClientsV1 "github.com/absmach/supermq/api/grpc/clients/v1"
 	apiutil "github.com/absmach/supermq/api/http/util"
 	smqauthn "github.com/absmach/supermq/pkg/authn"
+	smqauthz "github.com/absmach/supermq/pkg/authz"
 	"github.com/absmach/supermq/pkg/connections"
 	"github.com/absmach/supermq/pkg/errors"
 	svcerr "github.com/absmach/supermq/pkg/errors/service"
 	"github.com/absmach/supermq/pkg/messaging"
 	"github.com/absmach/supermq/pkg/policies"
+	"github.com/absmach/supermq/pkg/presence"
+	"github.com/absmach/supermq/pkg/schema"
+	"github.com/absmach/supermq/pkg/uuid"
 )
 
 var _ session.Handler = (*handler)(nil)
@@ -48,6 +53,8 @@ var (
 	errFailedPublish            = errors.New("failed to publish")
 	errFailedParseSubtopic      = errors.New("failed to parse subtopic")
 	errFailedPublishToMsgBroker = errors.New("failed to publish to supermq message broker")
+	errFailedPublishConnect     = errors.New("failed to publish connect event")
+	errFailedPublishDisconnect  = errors.New("failed to publish disconnect event")
 )
 
 var channelRegExp = regexp.MustCompile(`^\/?channels\/([\w\-]+)\/messages(\/[^?]*)?(\?.*)?$`)
@@ -55,20 +62,28 @@ var channelRegExp = regexp.MustCompile(`^\/?channels\/([\w\-]+)\/messages(\/[^?]
 // Event implements events.Event interface.
 type handler struct {
 	pubsub   messaging.PubSub
+	es       presence.EventStore
+	idp      supermq.IDProvider
 	clients  grpcClientsV1.ClientsServiceClient
 	channels grpcChannelsV1.ChannelsServiceClient
+	schemas  schema.Cache
 	authn    smqauthn.Authentication
+	authz    smqauthz.Authorization
 	logger   *slog.Logger
 }
 
 // NewHandler creates new Handler entity.
-func NewHandler(pubsub messaging.PubSub, logger *slog.Logger, authn smqauthn.Authentication, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient) session.Handler {
+func NewHandler(pubsub messaging.PubSub, es presence.EventStore, logger *slog.Logger, authn smqauthn.Authentication, authz smqauthz.Authorization, clients grpcClientsV1.ClientsServiceClient, channels grpcChannelsV1.ChannelsServiceClient, schemas schema.Cache) session.Handler {
 	return &handler{
 		logger:   logger,
 		pubsub:   pubsub,
+		es:       es,
+		idp:      uuid.New(),
 		authn:    authn,
+		authz:    authz,
 		clients:  clients,
 		channels: channels,
+		schemas:  schemas,
 	}
 }
 
@@ -97,7 +112,8 @@ func (h *handler) AuthPublish(ctx context.Context, topic *string, payload *[]byt
 		token = string(s.Password)
 	}
 
-	return h.authAccess(ctx, token, *topic, connections.Publish)
+	_, err := h.authAccess(ctx, token, *topic, connections.Publish)
+	return err
 }
 
 // AuthSubscribe is called on device publish,
@@ -119,10 +135,24 @@ func (h *handler) AuthSubscribe(ctx context.Context, topics *[]string) error {
 		token = string(s.Password)
 	}
 
+	var clientID string
 	for _, topic := range *topics {
-		if err := h.authAccess(ctx, token, topic, connections.Subscribe); err != nil {
+		id, err := h.authAccess(ctx, token, topic, connections.Subscribe)
+		if err != nil {
+			return err
+		}
+		clientID = id
+	}
+
+	// The sessions of the clients are identified, since they are reported
+	// to the clients service on subscribe and unsubscribe.
+	if strings.HasPrefix(string(s.Password), "Client") {
+		sessionID, err := h.idp.ID()
+		if err != nil {
 			return err
 		}
+		s.ID = sessionID
+		s.Username = clientID
 	}
 
 	return nil
@@ -181,6 +211,11 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		}
 		clientType = policies.UserType
 		clientID = authnSession.DomainUserID
+		if authnSession.Type == smqauthn.PersonalAccessToken {
+			if clientID, err = smqauthz.AuthorizeMessagingPAT(ctx, h.authz, h.channels, authnSession, chanID, connections.Publish); err != nil {
+				return err
+			}
+		}
 	}
 
 	ar := &grpcChannelsV1.AuthzReq{
@@ -197,6 +232,10 @@ func (h *handler) Publish(ctx context.Context, topic *string, payload *[]byte) e
 		return svcerr.ErrAuthorization
 	}
 
+	if err := h.schemas.Validate(chanID, res.GetSchema(), *payload); err != nil {
+		return err
+	}
+
 	msg := messaging.Message{
 		Protocol: protocol,
 		Channel:  chanID,
@@ -223,6 +262,11 @@ func (h *handler) Subscribe(ctx context.Context, topics *[]string) error {
 		return errors.Wrap(errFailedSubscribe, errClientNotInitialized)
 	}
 	h.logger.Info(fmt.Sprintf(LogInfoSubscribed, s.ID, strings.Join(*topics, ",")))
+	if s.Username != "" {
+		if err := h.es.Connect(ctx, presenceSession(s)); err != nil {
+			h.logger.Error(errors.Wrap(errFailedPublishConnect, err).Error())
+		}
+	}
 	return nil
 }
 
@@ -234,6 +278,11 @@ func (h *handler) Unsubscribe(ctx context.Context, topics *[]string) error {
 	}
 
 	h.logger.Info(fmt.Sprintf(LogInfoUnsubscribed, s.ID, strings.Join(*topics, ",")))
+	if s.Username != "" {
+		if err := h.es.Disconnect(ctx, presenceSession(s)); err != nil {
+			return errors.Wrap(errFailedPublishDisconnect, err)
+		}
+	}
 	return nil
 }
 
@@ -242,42 +291,49 @@ func (h *handler) Disconnect(ctx context.Context) error {
 	return nil
 }
 
-func (h *handler) authAccess(ctx context.Context, token, topic string, msgType connections.ConnType) error {
+// authAccess authorizes the access to the topic and returns the ID of the
+// client, or the domain user ID of the user, with the access.
+func (h *handler) authAccess(ctx context.Context, token, topic string, msgType connections.ConnType) (string, error) {
+	// Topics are in the format:
+	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
+	if !channelRegExp.MatchString(topic) {
+		return "", errMalformedTopic
+	}
+
+	channelParts := channelRegExp.FindStringSubmatch(topic)
+	if len(channelParts) < 1 {
+		return "", errMalformedTopic
+	}
+
+	chanID := channelParts[1]
+
 	var clientID, clientType string
 	switch {
 	case strings.HasPrefix(token, "Client"):
 		clientKey := extractClientSecret(token)
 		authnRes, err := h.clients.Authenticate(ctx, &grpcClientsV1.AuthnReq{ClientSecret: clientKey})
 		if err != nil {
-			return errors.Wrap(svcerr.ErrAuthentication, err)
+			return "", errors.Wrap(svcerr.ErrAuthentication, err)
 		}
 		if !authnRes.Authenticated {
-			return svcerr.ErrAuthentication
+			return "", svcerr.ErrAuthentication
 		}
 		clientType = policies.ClientType
 		clientID = authnRes.GetId()
 	default:
 		authnSession, err := h.authn.Authenticate(ctx, extractBearerToken(token))
 		if err != nil {
-			return err
+			return "", err
 		}
 		clientType = policies.UserType
 		clientID = authnSession.DomainUserID
+		if authnSession.Type == smqauthn.PersonalAccessToken {
+			if clientID, err = smqauthz.AuthorizeMessagingPAT(ctx, h.authz, h.channels, authnSession, chanID, msgType); err != nil {
+				return "", err
+			}
+		}
 	}
 
-	// Topics are in the format:
-	// channels/<channel_id>/messages/<subtopic>/.../ct/<content_type>
-	if !channelRegExp.MatchString(topic) {
-		return errMalformedTopic
-	}
-
-	channelParts := channelRegExp.FindStringSubmatch(topic)
-	if len(channelParts) < 1 {
-		return errMalformedTopic
-	}
-
-	chanID := channelParts[1]
-
 	ar := &grpcChannelsV1.AuthzReq{
 		Type:       uint32(msgType),
 		ClientId:   clientID,
@@ -286,13 +342,22 @@ func (h *handler) authAccess(ctx context.Context, token, topic string, msgType c
 	}
 	res, err := h.channels.Authorize(ctx, ar)
 	if err != nil {
-		return errors.Wrap(svcerr.ErrAuthorization, err)
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
 	}
 	if !res.GetAuthorized() {
-		return errors.Wrap(svcerr.ErrAuthorization, err)
+		return "", errors.Wrap(svcerr.ErrAuthorization, err)
 	}
 
-	return nil
+	return clientID, nil
+}
+
+// presenceSession returns the presence session of the subscribed client.
+func presenceSession(s *session.Session) presence.Session {
+	return presence.Session{
+		ID:       s.ID,
+		ClientID: s.Username,
+		Protocol: presence.WS,
+	}
 }
 
 func parseSubtopic(subtopic string) (string, error) {
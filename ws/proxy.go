@@ -0,0 +1,159 @@
+// Copyright (c) Abstract Machines
+// SPDX-License-Identifier: Apache-2.0
+
+package ws
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/absmach/mgate/pkg/session"
+	"github.com/absmach/supermq/pkg/errors"
+	"github.com/absmach/supermq/pkg/lockout"
+	"github.com/gorilla/websocket"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	upgrader = websocket.Upgrader{}
+
+	errAuthorizationNotSet = errors.New("authorization not set")
+)
+
+// Proxy is the WebSocket proxy. Unlike the mGate WebSocket proxy, it passes
+// the address of the client to the session handler, so the failed
+// authentication attempts are limited per source.
+type Proxy struct {
+	target  string
+	address string
+	handler session.Handler
+	proxies lockout.TrustedProxies
+	logger  *slog.Logger
+	warn    sync.Once
+}
+
+// NewProxy returns a new WebSocket proxy. The X-Real-IP header is honoured
+// only if the request comes from one of the trusted proxies.
+func NewProxy(address, target string, handler session.Handler, proxies lockout.TrustedProxies, logger *slog.Logger) *Proxy {
+	return &Proxy{
+		target:  target,
+		address: address,
+		handler: handler,
+		proxies: proxies,
+		logger:  logger,
+	}
+}
+
+// Handler authenticates and authorizes the client and passes the messages
+// between the client and the target.
+func (p *Proxy) Handler(w http.ResponseWriter, r *http.Request) {
+	var token string
+	headers := http.Header{}
+	switch {
+	case len(r.URL.Query()["authorization"]) != 0:
+		token = r.URL.Query()["authorization"][0]
+	case r.Header.Get("Authorization") != "":
+		token = r.Header.Get("Authorization")
+		headers.Add("Authorization", token)
+	default:
+		http.Error(w, errAuthorizationNotSet.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	target := fmt.Sprintf("%s%s", p.target, r.RequestURI)
+	targetConn, _, err := websocket.DefaultDialer.Dial(target, headers)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer targetConn.Close()
+
+	topic := r.URL.Path
+	s := session.Session{Password: []byte(token)}
+	ctx := context.Background()
+	if source := p.source(r); source != "" {
+		ctx = lockout.WithSource(ctx, source)
+	}
+	ctx = session.NewContext(ctx, &s)
+	if err := p.handler.AuthConnect(ctx); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := p.handler.AuthSubscribe(ctx, &[]string{topic}); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if err := p.handler.Subscribe(ctx, &[]string{topic}); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	inConn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		p.logger.Warn("WS Proxy failed to upgrade connection", slog.Any("error", err))
+		return
+	}
+	defer inConn.Close()
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		return p.stream(ctx, topic, inConn, targetConn, true)
+	})
+	g.Go(func() error {
+		return p.stream(ctx, topic, targetConn, inConn, false)
+	})
+
+	if err := g.Wait(); err != nil {
+		if err := p.handler.Unsubscribe(ctx, &[]string{topic}); err != nil {
+			p.logger.Warn("WS Proxy failed to unsubscribe", slog.Any("error", err))
+		}
+		p.logger.Warn("WS Proxy terminated", slog.Any("error", err))
+	}
+}
+
+// Listen serves the WebSocket connections without TLS.
+func (p *Proxy) Listen() error {
+	return http.ListenAndServe(p.address, http.HandlerFunc(p.Handler))
+}
+
+// ListenTLS serves the WebSocket connections with TLS.
+func (p *Proxy) ListenTLS(crt, key string) error {
+	return http.ListenAndServeTLS(p.address, crt, key, http.HandlerFunc(p.Handler))
+}
+
+// source returns the address of the client. The requests forwarded by a proxy
+// while no trusted proxies are configured have no source, so the clients
+// behind the proxy don't share the proxy address and lock each other out.
+func (p *Proxy) source(r *http.Request) string {
+	if p.proxies.Untrusted(r) {
+		p.warn.Do(func() {
+			p.logger.Warn("Received request forwarded by a reverse proxy without trusted proxies configured, failed authentication attempts are not limited per source")
+		})
+		return ""
+	}
+
+	return p.proxies.RequestSource(r)
+}
+
+func (p *Proxy) stream(ctx context.Context, topic string, src, dest *websocket.Conn, upstream bool) error {
+	for {
+		messageType, payload, err := src.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if upstream {
+			if err := p.handler.AuthPublish(ctx, &topic, &payload); err != nil {
+				return err
+			}
+			if err := p.handler.Publish(ctx, &topic, &payload); err != nil {
+				return err
+			}
+		}
+		if err := dest.WriteMessage(messageType, payload); err != nil {
+			return err
+		}
+	}
+}